@@ -18,6 +18,7 @@ package config
 
 import (
 	"os"
+	"strings"
 
 	"github.com/spf13/viper"
 )
@@ -30,6 +31,15 @@ type Var struct {
 	HasDefault bool
 }
 
+// scopeVars collects every Var whose viper key ends in /realm, /space, or
+// /stack and whose built-in default is "default" — the per-command scope
+// fallbacks ApplyScopeDefaults repoints at a client context. Scope vars with
+// no default (realm-scoped secrets, `kuke init --realm`) are deliberately
+// left out.
+//
+//nolint:gochecknoglobals // populated once by the DefineKV calls below
+var scopeVars = map[string][]Var{}
+
 func DefineKV(envName, viperKey string, defaultVal ...string) Var {
 	v := Var{Key: envName, ViperKey: viperKey}
 	if len(defaultVal) > 0 {
@@ -39,9 +49,34 @@ func DefineKV(envName, viperKey string, defaultVal ...string) Var {
 			viper.SetDefault(viperKey, defaultVal[0])
 		}
 	}
+	if v.Default == defaultScope {
+		for _, level := range []string{"realm", "space", "stack"} {
+			if strings.HasSuffix(viperKey, "/"+level) {
+				scopeVars[level] = append(scopeVars[level], v)
+			}
+		}
+	}
 	return v
 }
 
+// defaultScope is the built-in realm/space/stack name commands fall back to.
+const defaultScope = "default"
+
+// ApplyScopeDefaults replaces the built-in "default" realm, space, and stack
+// with the given names for every command that falls back to them. An empty
+// name leaves that level alone. Only the viper default moves, so an explicit
+// flag or a bound per-command env var still wins.
+func ApplyScopeDefaults(realm, space, stack string) {
+	for level, name := range map[string]string{"realm": realm, "space": space, "stack": stack} {
+		if name == "" {
+			continue
+		}
+		for _, v := range scopeVars[level] {
+			viper.SetDefault(v.ViperKey, name)
+		}
+	}
+}
+
 // DefineKVNoViperDefault is like DefineKV but does not register the default
 // with viper.SetDefault. Use for keys whose downstream logic relies on
 // viper.IsSet to distinguish "operator pinned this explicitly" from
@@ -113,8 +148,14 @@ var (
 	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
 	KUKEON_ROOT_LOG_LEVEL = DefineKV("KUKEON_LOG_LEVEL", "kukeon/logLevel", "info")
 	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKEON_ROOT_LOG_FORMAT = DefineKV("KUKEON_LOG_FORMAT", "kukeon/logFormat", "text")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
 	KUKEON_ROOT_CONTAINERD_SOCKET = DefineKV("KUKEON_CONTAINERD_SOCKET", "kukeon/containerd.socket")
 	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKEON_ROOT_CONTAINERD_TIMEOUT = DefineKV("KUKEON_CONTAINERD_TIMEOUT", "kukeon/containerd.timeout", "10s")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKEON_ROOT_TIMEOUT = DefineKV("KUKEON_TIMEOUT", "kukeon/timeout", "0s")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
 	KUKEON_ROOT_NAMESPACE_SUFFIX = DefineKV(
 		"KUKEON_NAMESPACE_SUFFIX", "kukeon/namespaceSuffix", "kukeon.io",
 	)
@@ -124,6 +165,10 @@ var (
 	KUKEON_ROOT_POD_SUBNET_CIDR = DefineKV("KUKEON_POD_SUBNET_CIDR", "kukeon/podSubnetCIDR", "10.88.0.0/16")
 	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
 	KUKEON_ROOT_HOST = DefineKV("KUKEON_HOST", "kukeon/host", "unix:///run/kukeon/kukeond.sock")
+	// KUKEON_ROOT_CONTEXT selects a named context from the ClientConfiguration
+	// (`--context`); empty falls back to spec.currentContext.
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKEON_ROOT_CONTEXT = DefineKV("KUKEON_CONTEXT", "kukeon/context")
 	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
 	KUKEON_ROOT_NO_DAEMON = DefineKV("KUKEON_NO_DAEMON", "kukeon/noDaemon", "false")
 	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
@@ -146,6 +191,18 @@ var (
 		"KUKEOND_RECONCILE_INTERVAL", "kukeond/reconcileInterval", "30s",
 	)
 	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	// KUKEOND_RECONCILE_CONCURRENCY bounds how many cells one reconcile pass
+	// works on in parallel. 1 (or less) reconciles cells one at a time.
+	KUKEOND_RECONCILE_CONCURRENCY = DefineKV(
+		"KUKEOND_RECONCILE_CONCURRENCY", "kukeond/reconcileConcurrency", "4",
+	)
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	// KUKEOND_DRAIN_TIMEOUT bounds how long a SIGTERM/SIGINT shutdown waits
+	// for an in-flight reconcile pass to return. 0 waits indefinitely.
+	KUKEOND_DRAIN_TIMEOUT = DefineKV(
+		"KUKEOND_DRAIN_TIMEOUT", "kukeond/drainTimeout", "30s",
+	)
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
 	KUKEOND_DEFAULT_MEMORY_LIMIT_BYTES = DefineKV(
 		"KUKEOND_DEFAULT_MEMORY_LIMIT_BYTES", "kukeond/defaultMemoryLimitBytes", "0",
 	)
@@ -173,6 +230,18 @@ var (
 	KUKEOND_DISK_PRESSURE_BLOCK_PCT = DefineKV(
 		"KUKEOND_DISK_PRESSURE_BLOCK_PCT", "kukeond/diskPressureBlockPct", "95",
 	)
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	// KUKEOND_BACKUP_RETENTION is how many metadata backups kukeond keeps
+	// under <run-path>/backups; the oldest are removed after each new one.
+	KUKEOND_BACKUP_RETENTION = DefineKV(
+		"KUKEOND_BACKUP_RETENTION", "kukeond/backupRetention", "10",
+	)
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	// KUKEOND_REQUIRE_BACKUP makes a failed metadata backup abort the realm
+	// purge or cascade delete it precedes instead of only logging a warning.
+	KUKEOND_REQUIRE_BACKUP = DefineKV(
+		"KUKEOND_REQUIRE_BACKUP", "kukeond/requireBackup", "false",
+	)
 
 	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
 	KUKE_INIT_REALM = DefineKV("KUKE_INIT_REALM", "kuke/init/realm")
@@ -240,6 +309,25 @@ var (
 	// --image (default cell.ImageDefaultCommand).
 	KUKE_CREATE_CELL_COMMAND = DefineKV("KUKE_CREATE_CELL_COMMAND", "kuke/create/cell/command")
 	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	// KUKE_CREATE_CELL_WAIT is the env-var twin of `kuke create cell --wait`:
+	// start the persisted cell and wait for its containers to come up.
+	KUKE_CREATE_CELL_WAIT = DefineKV("KUKE_CREATE_CELL_WAIT", "kuke/create/cell/wait", "false")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_CREATE_CELL_WAIT_TIMEOUT = DefineKV(
+		"KUKE_CREATE_CELL_WAIT_TIMEOUT", "kuke/create/cell/wait-timeout", "60s",
+	)
+	// KUKE_CREATE_CELL_WAIT_FOR_PARENT is the env-var twin of `kuke create
+	// cell --wait-for-parent`: wait for the realm, space and stack to become
+	// Ready before provisioning the cell.
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_CREATE_CELL_WAIT_FOR_PARENT = DefineKV(
+		"KUKE_CREATE_CELL_WAIT_FOR_PARENT", "kuke/create/cell/wait-for-parent", "false",
+	)
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_CREATE_CELL_WAIT_FOR_PARENT_TIMEOUT = DefineKV(
+		"KUKE_CREATE_CELL_WAIT_FOR_PARENT_TIMEOUT", "kuke/create/cell/wait-for-parent-timeout", "60s",
+	)
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
 	KUKE_CREATE_CONFIG_NAME = DefineKV("KUKE_CREATE_CONFIG_NAME", "kuke/create/config/name")
 	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
 	KUKE_CREATE_CONFIG_REALM = DefineKV("KUKE_CREATE_CONFIG_REALM", "kuke/create/config/realm", "default")
@@ -342,6 +430,26 @@ var (
 	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
 	KUKE_GET_CONFIG_STACK = DefineKV("KUKE_GET_CONFIG_STACK", "kuke/get/config/stack", "default")
 	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_GET_ORPHANS_REALM = DefineKV("KUKE_GET_ORPHANS_REALM", "kuke/get/orphans/realm", "default")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_GET_ORPHANS_PURGE = DefineKV("KUKE_GET_ORPHANS_PURGE", "kuke/get/orphans/purge")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_GET_EVENTS_REALM = DefineKV("KUKE_GET_EVENTS_REALM", "kuke/get/events/realm")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_GET_EVENTS_SPACE = DefineKV("KUKE_GET_EVENTS_SPACE", "kuke/get/events/space")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_GET_EVENTS_STACK = DefineKV("KUKE_GET_EVENTS_STACK", "kuke/get/events/stack")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_GET_EVENTS_CELL = DefineKV("KUKE_GET_EVENTS_CELL", "kuke/get/events/cell")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_GET_EVENTS_KIND = DefineKV("KUKE_GET_EVENTS_KIND", "kuke/get/events/kind")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_GET_EVENTS_SINCE = DefineKV("KUKE_GET_EVENTS_SINCE", "kuke/get/events/since")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_GET_EVENTS_LIMIT = DefineKV("KUKE_GET_EVENTS_LIMIT", "kuke/get/events/limit", "0")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_GET_QUOTA_SCOPE = DefineKV("KUKE_GET_QUOTA_SCOPE", "kuke/get/quota/scope")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
 	KUKE_GET_OUTPUT = DefineKV("KUKE_GET_OUTPUT", "kuke/get/output")
 
 	// Delete command variables
@@ -489,6 +597,10 @@ var (
 	// the entrypoint of the container synthesized by --image (default
 	// cell.ImageDefaultCommand).
 	KUKE_RUN_COMMAND = DefineKV("KUKE_RUN_COMMAND", "kuke/run/command")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	// KUKE_RUN_INTERACTIVE is the env-var twin of `kuke run -i`: keep the
+	// stdin of the container synthesized by --image -d open.
+	KUKE_RUN_INTERACTIVE = DefineKV("KUKE_RUN_INTERACTIVE", "kuke/run/interactive")
 
 	// Attach command variables
 	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
@@ -499,6 +611,8 @@ var (
 	KUKE_ATTACH_STACK = DefineKV("KUKE_ATTACH_STACK", "kuke/attach/stack", "default")
 	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
 	KUKE_ATTACH_CONTAINER = DefineKV("KUKE_ATTACH_CONTAINER", "kuke/attach/container")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_ATTACH_DETACH_KEYS = DefineKV("KUKE_ATTACH_DETACH_KEYS", "kuke/attach/detachKeys", "ctrl-p,ctrl-q")
 
 	// Log command variables.
 
@@ -512,6 +626,76 @@ var (
 	KUKE_LOG_CONTAINER = DefineKV("KUKE_LOG_CONTAINER", "kuke/log/container")
 	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
 	KUKE_LOG_FOLLOW = DefineKV("KUKE_LOG_FOLLOW", "kuke/log/follow", "false")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_LOG_STREAM = DefineKV("KUKE_LOG_STREAM", "kuke/log/stream", "combined")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_LOG_PREVIOUS = DefineKV("KUKE_LOG_PREVIOUS", "kuke/log/previous", "false")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_LOG_ALL_CONTAINERS = DefineKV("KUKE_LOG_ALL_CONTAINERS", "kuke/log/allContainers", "false")
+
+	// Cp command variables.
+
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_CP_REALM = DefineKV("KUKE_CP_REALM", "kuke/cp/realm", "default")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_CP_SPACE = DefineKV("KUKE_CP_SPACE", "kuke/cp/space", "default")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_CP_STACK = DefineKV("KUKE_CP_STACK", "kuke/cp/stack", "default")
+
+	// Inspect command variables.
+
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_INSPECT_REALM = DefineKV("KUKE_INSPECT_REALM", "kuke/inspect/realm", "default")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_INSPECT_SPACE = DefineKV("KUKE_INSPECT_SPACE", "kuke/inspect/space", "default")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_INSPECT_STACK = DefineKV("KUKE_INSPECT_STACK", "kuke/inspect/stack", "default")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_INSPECT_OCI = DefineKV("KUKE_INSPECT_OCI", "kuke/inspect/oci", "false")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_INSPECT_CONTAINERD = DefineKV("KUKE_INSPECT_CONTAINERD", "kuke/inspect/containerd", "false")
+
+	// Rename command variables
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_RENAME_SPACE_REALM = DefineKV("KUKE_RENAME_SPACE_REALM", "kuke/rename/space/realm", "default")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_RENAME_STACK_REALM = DefineKV("KUKE_RENAME_STACK_REALM", "kuke/rename/stack/realm", "default")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_RENAME_STACK_SPACE = DefineKV("KUKE_RENAME_STACK_SPACE", "kuke/rename/stack/space", "default")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_RENAME_CELL_REALM = DefineKV("KUKE_RENAME_CELL_REALM", "kuke/rename/cell/realm", "default")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_RENAME_CELL_SPACE = DefineKV("KUKE_RENAME_CELL_SPACE", "kuke/rename/cell/space", "default")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_RENAME_CELL_STACK = DefineKV("KUKE_RENAME_CELL_STACK", "kuke/rename/cell/stack", "default")
+
+	// Patch command variables
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_PATCH_TYPE = DefineKV("KUKE_PATCH_TYPE", "kuke/patch/type", "merge")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_PATCH_REALM = DefineKV("KUKE_PATCH_REALM", "kuke/patch/realm", "default")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_PATCH_SPACE = DefineKV("KUKE_PATCH_SPACE", "kuke/patch/space")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_PATCH_STACK = DefineKV("KUKE_PATCH_STACK", "kuke/patch/stack")
+
+	// Export command variables
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_EXPORT_REALM = DefineKV("KUKE_EXPORT_REALM", "kuke/export/realm", "default")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_EXPORT_INCLUDE_SECRETS = DefineKV("KUKE_EXPORT_INCLUDE_SECRETS", "kuke/export/includeSecrets", "false")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_EXPORT_OUTPUT = DefineKV("KUKE_EXPORT_OUTPUT", "kuke/export/output", "")
+
+	// Stack command variables
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_STACK_SCALE_REALM = DefineKV("KUKE_STACK_SCALE_REALM", "kuke/stack/scale/realm", "default")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_STACK_SCALE_SPACE = DefineKV("KUKE_STACK_SCALE_SPACE", "kuke/stack/scale/space", "default")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_STACK_RESTART_REALM = DefineKV("KUKE_STACK_RESTART_REALM", "kuke/stack/restart/realm", "default")
+	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
+	KUKE_STACK_RESTART_SPACE = DefineKV("KUKE_STACK_RESTART_SPACE", "kuke/stack/restart/space", "default")
 
 	// Restart command variables
 	//nolint:revive,gochecknoglobals,staticcheck // ignore linter warning about this variable
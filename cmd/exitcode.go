@@ -0,0 +1,124 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import "github.com/eminwux/kukeon/internal/errdefs"
+
+// Process exit codes. Scripts branch on these, so a value never changes
+// meaning once released; 3 is left unused on purpose.
+const (
+	exitOK           = 0
+	exitFailure      = 1
+	exitValidation   = 2
+	exitNotFound     = 4
+	exitConflict     = 5
+	exitConnectivity = 6
+)
+
+// exitCodes maps an error code to its exit-code category. Codes absent
+// here (operation failures, state checks, UNKNOWN) have no category of their
+// own: exitCodeFor looks past them to the cause they wrap, and exits with
+// exitFailure when nothing in the chain has a category.
+//
+//nolint:gochecknoglobals // read-only lookup table
+var exitCodes = map[errdefs.Code]int{
+	errdefs.CodeRealmNotFound:     exitNotFound,
+	errdefs.CodeSpaceNotFound:     exitNotFound,
+	errdefs.CodeStackNotFound:     exitNotFound,
+	errdefs.CodeCellNotFound:      exitNotFound,
+	errdefs.CodeContainerNotFound: exitNotFound,
+	errdefs.CodeNetworkNotFound:   exitNotFound,
+	errdefs.CodeImageNotFound:     exitNotFound,
+	errdefs.CodeSecretNotFound:    exitNotFound,
+	errdefs.CodeBlueprintNotFound: exitNotFound,
+	errdefs.CodeConfigNotFound:    exitNotFound,
+	errdefs.CodeVolumeNotFound:    exitNotFound,
+	errdefs.CodeTaskNotFound:      exitNotFound,
+	errdefs.CodeResourceNotFound:  exitNotFound,
+
+	errdefs.CodeRealmNameRequired:      exitValidation,
+	errdefs.CodeSpaceNameRequired:      exitValidation,
+	errdefs.CodeStackNameRequired:      exitValidation,
+	errdefs.CodeCellNameRequired:       exitValidation,
+	errdefs.CodeContainerNameRequired:  exitValidation,
+	errdefs.CodeInvalidName:            exitValidation,
+	errdefs.CodeInvalidRealmName:       exitValidation,
+	errdefs.CodeInvalidImage:           exitValidation,
+	errdefs.CodeInvalidPlatform:        exitValidation,
+	errdefs.CodeInvalidDevice:          exitValidation,
+	errdefs.CodeInvalidSeccompProfile:  exitValidation,
+	errdefs.CodeInvalidAppArmorProfile: exitValidation,
+	errdefs.CodeInvalidStdin:           exitValidation,
+	errdefs.CodeInvalidHostname:        exitValidation,
+	errdefs.CodeInvalidExtraHost:       exitValidation,
+	errdefs.CodeCellValidation:         exitValidation,
+	errdefs.CodeManifestInvalid:        exitValidation,
+	errdefs.CodeBlueprintInvalid:       exitValidation,
+	errdefs.CodeUnknownKind:            exitValidation,
+	errdefs.CodeUnsupportedAPIVersion:  exitValidation,
+	errdefs.CodeConversionFailed:       exitValidation,
+	errdefs.CodeInvalidPatch:           exitValidation,
+	errdefs.CodeImmutableField:         exitValidation,
+	errdefs.CodePatchUnsupportedKind:   exitValidation,
+	errdefs.CodeInvalidContinueToken:   exitValidation,
+	errdefs.CodeInvalidTimeout:         exitValidation,
+	errdefs.CodeInvalidSortBy:          exitValidation,
+	errdefs.CodeInvalidFieldSelector:   exitValidation,
+	errdefs.CodeInvalidBackup:          exitValidation,
+	errdefs.CodeInvalidLabelColumns:    exitValidation,
+	errdefs.CodeInvalidCustomColumns:   exitValidation,
+	errdefs.CodeInvalidChunkFlags:      exitValidation,
+	errdefs.CodeSelectorWithName:       exitValidation,
+	errdefs.CodeFieldSelectorWithName:  exitValidation,
+	errdefs.CodeAllWithScope:           exitValidation,
+	errdefs.CodeQuietWithOutput:        exitValidation,
+
+	errdefs.CodeResourceHasDependencies: exitConflict,
+	errdefs.CodeContainerExists:         exitConflict,
+	errdefs.CodeConfigExists:            exitConflict,
+	errdefs.CodeNamespaceAlreadyExists:  exitConflict,
+	errdefs.CodeNetworkAlreadyExists:    exitConflict,
+	errdefs.CodeRealmNamespaceInUse:     exitConflict,
+	errdefs.CodeVolumeInUse:             exitConflict,
+	errdefs.CodeSecretInUse:             exitConflict,
+	errdefs.CodeRenameTargetExists:      exitConflict,
+	errdefs.CodeRenameCellRunning:       exitConflict,
+	errdefs.CodeScaffoldFileExists:      exitConflict,
+	errdefs.CodeStaleResource:           exitConflict,
+
+	errdefs.CodeConnectContainerd: exitConnectivity,
+	errdefs.CodeDaemonUnreachable: exitConnectivity,
+	errdefs.CodeCommandTimeout:    exitConnectivity,
+}
+
+// exitCodeFor returns the process exit code for the error a root command
+// returned. The category is that of the innermost sentinel in the chain
+// that has one, so `ErrCreateCell: ErrStackNotFound` exits as not found and
+// a wrapped ErrConnectContainerd as a connectivity failure, while the
+// operation wrapper alone still exits with exitFailure.
+func exitCodeFor(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	codes := errdefs.CodesOf(err)
+	for i := len(codes) - 1; i >= 0; i-- {
+		if code, ok := exitCodes[codes[i]]; ok {
+			return code
+		}
+	}
+	return exitFailure
+}
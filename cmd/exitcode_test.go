@@ -0,0 +1,212 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/eminwux/kukeon/cmd/kuke/get/cell"
+	"github.com/eminwux/kukeon/cmd/kuke/get/space"
+	"github.com/eminwux/kukeon/cmd/kuke/get/stack"
+	"github.com/eminwux/kukeon/cmd/types"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestExitCodeFor(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil", err: nil, want: exitOK},
+		{name: "unregistered", err: errors.New("boom"), want: exitFailure},
+		{
+			name: "validation",
+			err:  fmt.Errorf("%w: cell has no containers", errdefs.ErrCellValidation),
+			want: exitValidation,
+		},
+		{
+			name: "not found",
+			err:  fmt.Errorf("%w: realm %q", errdefs.ErrRealmNotFound, "ghost"),
+			want: exitNotFound,
+		},
+		{
+			name: "dependency conflict",
+			err:  fmt.Errorf("%w: space %q has 2 stacks", errdefs.ErrResourceHasDependencies, "web"),
+			want: exitConflict,
+		},
+		{
+			name: "containerd unreachable",
+			err:  fmt.Errorf("%w: context deadline exceeded", errdefs.ErrConnectContainerd),
+			want: exitConnectivity,
+		},
+		{
+			name: "daemon unreachable",
+			err:  fmt.Errorf("list realms: %w", errdefs.ErrDaemonUnreachable),
+			want: exitConnectivity,
+		},
+		{
+			// The innermost categorized sentinel decides, so a create that
+			// failed on a missing parent exits as not found.
+			name: "wrapped not found",
+			err:  fmt.Errorf("%w: %w", errdefs.ErrCreateCell, errdefs.ErrStackNotFound),
+			want: exitNotFound,
+		},
+		{
+			name: "wrapped containerd connect",
+			err: fmt.Errorf("%w: %w", errdefs.ErrCreateCell,
+				fmt.Errorf("new client: %w", errdefs.ErrConnectContainerd)),
+			want: exitConnectivity,
+		},
+		{
+			name: "not found under a validation wrapper",
+			err:  fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, errdefs.ErrRealmNotFound),
+			want: exitNotFound,
+		},
+		{
+			name: "operation wrapper alone",
+			err:  fmt.Errorf("%w: boom", errdefs.ErrCreateCell),
+			want: exitFailure,
+		},
+		{
+			name: "across the daemon socket",
+			err: kukeonv1.FromAPIError(kukeonv1.ToAPIError(
+				fmt.Errorf("%w: cell %q", errdefs.ErrCellNotFound, "api"),
+			)),
+			want: exitNotFound,
+		},
+		{
+			name: "wrapped not found across the daemon socket",
+			err: kukeonv1.FromAPIError(kukeonv1.ToAPIError(
+				fmt.Errorf("%w: %w", errdefs.ErrCreateCell, errdefs.ErrStackNotFound),
+			)),
+			want: exitNotFound,
+		},
+		{
+			name: "wrapped connect across the daemon socket",
+			err: kukeonv1.FromAPIError(kukeonv1.ToAPIError(
+				fmt.Errorf("%w: %w", errdefs.ErrDeleteCell, errdefs.ErrConnectContainerd),
+			)),
+			want: exitConnectivity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCodeFor(tt.err); got != tt.want {
+				t.Errorf("exitCodeFor(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecRoot_ExitCodeFromError(t *testing.T) {
+	cmd := &cobra.Command{
+		Use:           "test",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return fmt.Errorf("get cell: %w", errdefs.ErrCellNotFound)
+		},
+	}
+	cmd.SetArgs([]string{})
+	if got := execRoot(cmd); got != exitNotFound {
+		t.Errorf("execRoot() = %d, want %d", got, exitNotFound)
+	}
+}
+
+// missingClient answers every Get with the outcome a daemon gives for a
+// resource it does not know: the not-found sentinel carried across the
+// socket when notFoundErr is set, MetadataExists=false otherwise.
+type missingClient struct {
+	kukeonv1.FakeClient
+
+	notFoundErr bool
+}
+
+func (c missingClient) err(sentinel error) error {
+	if !c.notFoundErr {
+		return nil
+	}
+	return kukeonv1.FromAPIError(kukeonv1.ToAPIError(sentinel))
+}
+
+func (c missingClient) GetSpace(context.Context, v1beta1.SpaceDoc) (kukeonv1.GetSpaceResult, error) {
+	return kukeonv1.GetSpaceResult{}, c.err(errdefs.ErrSpaceNotFound)
+}
+
+func (c missingClient) GetStack(context.Context, v1beta1.StackDoc) (kukeonv1.GetStackResult, error) {
+	return kukeonv1.GetStackResult{}, c.err(errdefs.ErrStackNotFound)
+}
+
+func (c missingClient) GetCell(context.Context, v1beta1.CellDoc) (kukeonv1.GetCellResult, error) {
+	return kukeonv1.GetCellResult{}, c.err(errdefs.ErrCellNotFound)
+}
+
+// TestExecRoot_GetMissingExitsNotFound runs the real `kuke get` commands
+// against a daemon that does not know the resource and checks the process
+// exit code is the documented not-found code, whichever way the daemon
+// reports the miss.
+func TestExecRoot_GetMissingExitsNotFound(t *testing.T) {
+	commands := []struct {
+		name string
+		new  func() *cobra.Command
+		key  any
+		args []string
+	}{
+		{name: "space", new: space.NewSpaceCmd, key: space.MockControllerKey{}, args: []string{"--realm", "r1"}},
+		{
+			name: "stack", new: stack.NewStackCmd, key: stack.MockControllerKey{},
+			args: []string{"--realm", "r1", "--space", "s1"},
+		},
+		{
+			name: "cell", new: cell.NewCellCmd, key: cell.MockControllerKey{},
+			args: []string{"--realm", "r1", "--space", "s1", "--stack", "st1"},
+		},
+	}
+	for _, c := range commands {
+		for _, notFoundErr := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s/notFoundErr=%v", c.name, notFoundErr), func(t *testing.T) {
+				t.Cleanup(viper.Reset)
+
+				cmd := c.new()
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+				cmd.SetOut(io.Discard)
+				cmd.SetErr(io.Discard)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+				ctx = context.WithValue(ctx, c.key, kukeonv1.Client(missingClient{notFoundErr: notFoundErr}))
+				cmd.SetContext(ctx)
+				cmd.SetArgs(append([]string{"missing"}, c.args...))
+
+				if got := execRoot(cmd); got != exitNotFound {
+					t.Errorf("execRoot(get %s missing) = %d, want %d", c.name, got, exitNotFound)
+				}
+			})
+		}
+	}
+}
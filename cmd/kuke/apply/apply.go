@@ -17,11 +17,17 @@
 package apply
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
-	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
 
 	kukshared "github.com/eminwux/kukeon/cmd/kuke/shared"
+	"github.com/eminwux/kukeon/internal/apply/envexpand"
+	"github.com/eminwux/kukeon/internal/controller"
 	"github.com/eminwux/kukeon/internal/errdefs"
 	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
 	"github.com/spf13/cobra"
@@ -33,45 +39,94 @@ type MockControllerKey struct{}
 const (
 	outputFormatJSON = "json"
 	outputFormatYAML = "yaml"
+
+	// actionSkipped marks a document --fail-fast never sent because an
+	// earlier one failed.
+	actionSkipped = "skipped"
+	// actionRolledBack marks a resource the daemon created and deleted
+	// again because a document under it failed in the same apply.
+	actionRolledBack = "rolled back"
 )
 
 // NewApplyCmd builds the `kuke apply` cobra command. `-f` reads a multi-document
-// YAML stream from disk or stdin — the sole shape `apply` supports. The
+// YAML stream from files, directories, globs, or stdin — the sole shape
+// `apply` supports. The
 // daemon-side reconcile-by-ref forms (`-b`/`-c`) were retired under #819; the
 // equivalent operator workflow is `kuke restart <name>` (which sees
 // OutOfSync on Config-lineage cells and reconciles implicitly).
 func NewApplyCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:           "apply -f <file>",
-		Short:         "Apply resource definitions from a YAML file or stdin",
-		Long:          "Apply resource definitions from a YAML file or stdin (-f).",
+		Use:   "apply -f <file|dir|glob>...",
+		Short: "Apply resource definitions from YAML files or stdin",
+		Long: "Apply resource definitions from YAML files or stdin (-f). -f is repeatable and " +
+			"takes files, directories (their .yaml/.yml/.json files), and glob patterns; every " +
+			"document is applied in hierarchy order (realms, spaces, stacks, cells, then the " +
+			"resources scoped to them) regardless of the file it came from.",
 		Args:          cobra.NoArgs,
 		SilenceUsage:  true,
 		SilenceErrors: false,
 		RunE:          runApply,
 	}
 
-	cmd.Flags().StringP("file", "f", "", "File to read YAML from (use - for stdin)")
+	cmd.Flags().StringArrayP("file", "f", nil,
+		"File, directory, or glob to read YAML from (use - for stdin); repeatable")
 	cmd.Flags().StringP("output", "o", "", "Output format: json, yaml (default: human-readable)")
+	cmd.Flags().String("field-manager", "",
+		"Name recorded as the writer of each resource's last-applied configuration (default: kuke)")
+	cmd.Flags().Bool("fail-fast", false,
+		"Stop at the first resource that fails to apply and skip the rest (default: apply every document)")
+	cmd.Flags().Bool("expand-env", false,
+		"Substitute ${VAR} and ${VAR:-default} from the environment before applying ($$ is a literal $)")
+	cmd.Flags().Bool("wait-for-parent", false,
+		"Let each cell wait for its realm, space and stack to become Ready instead of failing at once")
+	cmd.Flags().Duration("wait-for-parent-timeout", time.Minute,
+		"How long --wait-for-parent waits for a cell's parents (rounded up to whole seconds)")
+	cmd.Flags().Bool("prune", false,
+		"Stop and delete each applied cell's containers that its spec no longer declares (never the root container)")
 
 	return cmd
 }
 
 // applyFlags is the validated bundle of flag values runApply consumes.
 type applyFlags struct {
-	file   string
-	output string
+	files        []string
+	output       string
+	fieldManager string
+	expandEnv    bool
+	failFast     bool
+	// waitForParentSeconds is the --wait-for-parent timeout in whole
+	// seconds; 0 keeps the fail-fast parent check.
+	waitForParentSeconds int
+	// prune removes containers left in containerd that an applied cell's
+	// spec does not declare.
+	prune bool
 }
 
 func parseApplyFlags(cmd *cobra.Command) (applyFlags, error) {
 	flags := applyFlags{}
 	var err error
-	if flags.file, err = cmd.Flags().GetString("file"); err != nil {
+	if flags.files, err = cmd.Flags().GetStringArray("file"); err != nil {
 		return flags, err
 	}
 	if flags.output, err = cmd.Flags().GetString("output"); err != nil {
 		return flags, err
 	}
+	if flags.fieldManager, err = cmd.Flags().GetString("field-manager"); err != nil {
+		return flags, err
+	}
+	flags.fieldManager = strings.TrimSpace(flags.fieldManager)
+	if flags.expandEnv, err = cmd.Flags().GetBool("expand-env"); err != nil {
+		return flags, err
+	}
+	if flags.failFast, err = cmd.Flags().GetBool("fail-fast"); err != nil {
+		return flags, err
+	}
+	if flags.waitForParentSeconds, err = parseWaitForParent(cmd); err != nil {
+		return flags, err
+	}
+	if flags.prune, err = cmd.Flags().GetBool("prune"); err != nil {
+		return flags, err
+	}
 
 	if flags.output != "" && flags.output != outputFormatJSON && flags.output != outputFormatYAML {
 		return flags, fmt.Errorf("invalid --output %q: want json or yaml", flags.output)
@@ -79,6 +134,30 @@ func parseApplyFlags(cmd *cobra.Command) (applyFlags, error) {
 	return flags, nil
 }
 
+// parseWaitForParent validates --wait-for-parent/--wait-for-parent-timeout
+// and returns the timeout in whole seconds (rounded up), or 0 when the flag
+// is off.
+func parseWaitForParent(cmd *cobra.Command) (int, error) {
+	wait, err := cmd.Flags().GetBool("wait-for-parent")
+	if err != nil {
+		return 0, err
+	}
+	if !wait {
+		if cmd.Flags().Changed("wait-for-parent-timeout") {
+			return 0, errors.New("--wait-for-parent-timeout is only valid with --wait-for-parent")
+		}
+		return 0, nil
+	}
+	timeout, err := cmd.Flags().GetDuration("wait-for-parent-timeout")
+	if err != nil {
+		return 0, err
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("--wait-for-parent-timeout must be positive, got %s", timeout)
+	}
+	return int(math.Ceil(timeout.Seconds())), nil
+}
+
 func runApply(cmd *cobra.Command, _ []string) error {
 	flags, err := parseApplyFlags(cmd)
 	if err != nil {
@@ -97,22 +176,28 @@ func runApply(cmd *cobra.Command, _ []string) error {
 // runApplyFile is the `kuke apply -f` path: read YAML, send to the daemon's
 // ApplyDocuments, print result.
 func runApplyFile(cmd *cobra.Command, client kukeonv1.Client, flags applyFlags) error {
-	if flags.file == "" {
+	if len(flags.files) == 0 {
 		return errors.New("file flag is required (use -f <file> or -f - for stdin)")
 	}
 
-	reader, cleanup, err := kukshared.ReadFileOrStdin(flags.file)
+	rawYAML, err := kukshared.ReadManifests(flags.files)
 	if err != nil {
 		return err
 	}
-	defer func() { _ = cleanup() }()
-
-	rawYAML, err := io.ReadAll(reader)
-	if err != nil {
-		return fmt.Errorf("failed to read input: %w", err)
+	// Expansion runs client-side: the variables are the caller's, not the
+	// daemon's.
+	if flags.expandEnv {
+		if rawYAML, err = envexpand.Expand(rawYAML, os.LookupEnv); err != nil {
+			return err
+		}
 	}
 
-	result, err := client.ApplyDocuments(cmd.Context(), rawYAML)
+	var result kukeonv1.ApplyDocumentsResult
+	if flags.failFast {
+		result, err = applyFailFast(cmd, client, rawYAML, flags)
+	} else {
+		result, err = applyStream(cmd, client, rawYAML, flags)
+	}
 	if err != nil {
 		return err
 	}
@@ -123,6 +208,68 @@ func runApplyFile(cmd *cobra.Command, client kukeonv1.Client, flags applyFlags)
 	return printApplyResult(cmd, result)
 }
 
+func applyStream(
+	cmd *cobra.Command, client kukeonv1.Client, rawYAML []byte, flags applyFlags,
+) (kukeonv1.ApplyDocumentsResult, error) {
+	opts := kukeonv1.ApplyOptions{
+		FieldManager:         flags.fieldManager,
+		WaitForParentSeconds: flags.waitForParentSeconds,
+		PruneContainers:      flags.prune,
+	}
+	if opts == (kukeonv1.ApplyOptions{}) {
+		return client.ApplyDocuments(cmd.Context(), rawYAML)
+	}
+	return client.ApplyDocumentsWithOptions(cmd.Context(), rawYAML, opts)
+}
+
+// applyFailFast validates the whole stream up front, then sends the documents
+// one at a time in the order the daemon would apply them (the ordering
+// `kuke import` uses), so nothing after the first failure is touched. Unlike
+// import, what was already applied stays in place.
+func applyFailFast(
+	cmd *cobra.Command, client kukeonv1.Client, rawYAML []byte, flags applyFlags,
+) (kukeonv1.ApplyDocumentsResult, error) {
+	docs, validationErrors, err := kukshared.ParseAndValidateDocuments(bytes.NewReader(rawYAML))
+	if err != nil {
+		return kukeonv1.ApplyDocumentsResult{}, err
+	}
+	if len(validationErrors) > 0 {
+		msgs := make([]string, 0, len(validationErrors))
+		for _, ve := range validationErrors {
+			msgs = append(msgs, ve.Error())
+		}
+		return kukeonv1.ApplyDocumentsResult{}, fmt.Errorf("validation failed:\n  %s", strings.Join(msgs, "\n  "))
+	}
+
+	sorted := controller.SortDocumentsByKind(docs, false)
+	result := kukeonv1.ApplyDocumentsResult{Resources: make([]kukeonv1.ApplyResourceResult, 0, len(sorted))}
+	for i, doc := range sorted {
+		applied, applyErr := applyStream(cmd, client, doc.Raw, flags)
+		if applyErr != nil {
+			return result, applyErr
+		}
+		failed := false
+		for _, resource := range applied.Resources {
+			resource.Index = doc.Index
+			failed = failed || resource.Action == "failed"
+			result.Resources = append(result.Resources, resource)
+		}
+		if !failed {
+			continue
+		}
+		for _, rest := range sorted[i+1:] {
+			result.Resources = append(result.Resources, kukeonv1.ApplyResourceResult{
+				Index:  rest.Index,
+				Kind:   string(rest.Kind),
+				Name:   rest.Name(),
+				Action: actionSkipped,
+			})
+		}
+		break
+	}
+	return result, nil
+}
+
 func resolveClient(cmd *cobra.Command) (kukeonv1.Client, error) {
 	if mockClient, ok := cmd.Context().Value(MockControllerKey{}).(kukeonv1.Client); ok {
 		return mockClient, nil
@@ -143,6 +290,10 @@ func printApplyResult(cmd *cobra.Command, result kukeonv1.ApplyDocumentsResult)
 			}
 		case "unchanged":
 			cmd.Printf("%s %q: unchanged\n", resource.Kind, resource.Name)
+		case actionSkipped:
+			cmd.Printf("%s %q: skipped\n", resource.Kind, resource.Name)
+		case actionRolledBack:
+			cmd.Printf("%s %q: rolled back\n", resource.Kind, resource.Name)
 		case "failed":
 			hasFailures = true
 			cmd.Printf("%s %q: failed\n", resource.Kind, resource.Name)
@@ -152,6 +303,8 @@ func printApplyResult(cmd *cobra.Command, result kukeonv1.ApplyDocumentsResult)
 		}
 	}
 
+	printApplySummary(cmd, result)
+
 	if hasFailures {
 		return fmt.Errorf("%w: some resources failed to apply", errdefs.ErrConfig)
 	}
@@ -159,6 +312,27 @@ func printApplyResult(cmd *cobra.Command, result kukeonv1.ApplyDocumentsResult)
 	return nil
 }
 
+// printApplySummary prints one line counting the resources per outcome, in
+// a fixed order and leaving out outcomes that did not occur.
+func printApplySummary(cmd *cobra.Command, result kukeonv1.ApplyDocumentsResult) {
+	if len(result.Resources) < 2 {
+		return
+	}
+	counts := make(map[string]int)
+	for _, resource := range result.Resources {
+		counts[resource.Action]++
+	}
+	var parts []string
+	for _, action := range []string{"created", "updated", "unchanged", "failed", actionSkipped, actionRolledBack} {
+		if counts[action] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[action], action))
+		}
+	}
+	if len(parts) > 0 {
+		cmd.Printf("%d resources: %s\n", len(result.Resources), strings.Join(parts, ", "))
+	}
+}
+
 func printApplyResultJSON(cmd *cobra.Command, result kukeonv1.ApplyDocumentsResult, format string) error {
 	output := struct {
 		Resources []kukeonv1.ApplyResourceResult `json:"resources" yaml:"resources"`
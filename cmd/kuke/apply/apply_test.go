@@ -20,15 +20,18 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
 	apply "github.com/eminwux/kukeon/cmd/kuke/apply"
 	"github.com/eminwux/kukeon/cmd/types"
+	"github.com/eminwux/kukeon/internal/errdefs"
 	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
 )
 
@@ -180,6 +183,397 @@ spec:
 	}
 }
 
+func TestApply_FieldManagerFlag(t *testing.T) {
+	const validYAML = `apiVersion: v1beta1
+kind: Realm
+metadata:
+  name: r1
+`
+
+	cmd := apply.NewApplyCmd()
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+	fc := &fakeClient{
+		applyOptsFn: func(_ []byte, opts kukeonv1.ApplyOptions) (kukeonv1.ApplyDocumentsResult, error) {
+			if opts != (kukeonv1.ApplyOptions{FieldManager: "ci"}) {
+				return kukeonv1.ApplyDocumentsResult{}, fmt.Errorf("ApplyOptions = %+v, want FieldManager ci", opts)
+			}
+			return kukeonv1.ApplyDocumentsResult{
+				Resources: []kukeonv1.ApplyResourceResult{{Kind: "Realm", Name: "r1", Action: "unchanged"}},
+			}, nil
+		},
+	}
+	ctx = context.WithValue(ctx, apply.MockControllerKey{}, kukeonv1.Client(fc))
+	cmd.SetContext(ctx)
+
+	cmd.SetArgs([]string{"-f", writeTempYAML(t, validYAML), "--field-manager", "ci"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fc.applyCalls != 0 {
+		t.Errorf("ApplyDocuments called %d times, want ApplyDocumentsWithOptions only", fc.applyCalls)
+	}
+	if !strings.Contains(buf.String(), `Realm "r1": unchanged`) {
+		t.Errorf("output = %q, want the apply result", buf.String())
+	}
+}
+
+// TestApply_WaitForParentFlag pins that --wait-for-parent routes through
+// ApplyDocumentsWithOptions with the timeout rounded up to whole seconds,
+// and that the timeout flag alone is rejected.
+func TestApply_WaitForParentFlag(t *testing.T) {
+	const validYAML = `apiVersion: v1beta1
+kind: Realm
+metadata:
+  name: r1
+`
+	run := func(t *testing.T, fc *fakeClient, args ...string) error {
+		t.Helper()
+		cmd := apply.NewApplyCmd()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+		ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+		cmd.SetContext(context.WithValue(ctx, apply.MockControllerKey{}, kukeonv1.Client(fc)))
+		cmd.SetArgs(append([]string{"-f", writeTempYAML(t, validYAML)}, args...))
+		return cmd.Execute()
+	}
+
+	t.Run("routes the wait to the daemon", func(t *testing.T) {
+		var got kukeonv1.ApplyOptions
+		fc := &fakeClient{
+			applyOptsFn: func(_ []byte, opts kukeonv1.ApplyOptions) (kukeonv1.ApplyDocumentsResult, error) {
+				got = opts
+				return kukeonv1.ApplyDocumentsResult{
+					Resources: []kukeonv1.ApplyResourceResult{{Kind: "Realm", Name: "r1", Action: "unchanged"}},
+				}, nil
+			},
+		}
+		err := run(t, fc, "--wait-for-parent", "--wait-for-parent-timeout", "1500ms", "--field-manager", "ci")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := kukeonv1.ApplyOptions{FieldManager: "ci", WaitForParentSeconds: 2}
+		if got != want {
+			t.Errorf("ApplyOptions = %+v, want %+v", got, want)
+		}
+		if fc.applyCalls != 0 {
+			t.Errorf("ApplyDocuments called %d times, want ApplyDocumentsWithOptions only", fc.applyCalls)
+		}
+	})
+
+	t.Run("timeout without the flag is rejected", func(t *testing.T) {
+		err := run(t, &fakeClient{}, "--wait-for-parent-timeout", "5s")
+		if err == nil || !strings.Contains(err.Error(), "only valid with --wait-for-parent") {
+			t.Fatalf("err = %v, want the --wait-for-parent-timeout rejection", err)
+		}
+	})
+}
+
+// TestApply_PruneFlag pins that --prune routes through
+// ApplyDocumentsWithOptions, carrying the other per-invocation options along,
+// and that the pruned containers print as changes of the cell.
+func TestApply_PruneFlag(t *testing.T) {
+	const validYAML = `apiVersion: v1beta1
+kind: Realm
+metadata:
+  name: r1
+`
+	var got kukeonv1.ApplyOptions
+	fc := &fakeClient{
+		applyOptsFn: func(_ []byte, opts kukeonv1.ApplyOptions) (kukeonv1.ApplyDocumentsResult, error) {
+			got = opts
+			return kukeonv1.ApplyDocumentsResult{
+				Resources: []kukeonv1.ApplyResourceResult{{
+					Kind:    "Cell",
+					Name:    "web",
+					Action:  "updated",
+					Changes: []string{`container "default_default_web_sidecar" pruned`},
+				}},
+			}, nil
+		},
+	}
+	cmd := apply.NewApplyCmd()
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+	cmd.SetContext(context.WithValue(ctx, apply.MockControllerKey{}, kukeonv1.Client(fc)))
+	cmd.SetArgs([]string{"-f", writeTempYAML(t, validYAML), "--prune", "--field-manager", "ci", "--wait-for-parent"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := kukeonv1.ApplyOptions{FieldManager: "ci", WaitForParentSeconds: 60, PruneContainers: true}
+	if got != want {
+		t.Errorf("ApplyOptions = %+v, want %+v", got, want)
+	}
+	if !strings.Contains(buf.String(), `- container "default_default_web_sidecar" pruned`) {
+		t.Errorf("output = %q, want the pruned container listed", buf.String())
+	}
+}
+
+// TestApply_ExpandEnvFlag pins that ${VAR} references reach the daemon
+// verbatim unless --expand-env is given.
+func TestApply_ExpandEnvFlag(t *testing.T) {
+	const manifest = `apiVersion: v1beta1
+kind: Realm
+metadata:
+  name: ${KUKE_TEST_REALM}
+spec:
+  namespace: ${KUKE_TEST_NS:-r1}
+`
+	t.Setenv("KUKE_TEST_REALM", "team-a")
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr error
+	}{
+		{name: "off by default", want: manifest},
+		{
+			name: "expanded",
+			args: []string{"--expand-env"},
+			want: strings.NewReplacer("${KUKE_TEST_REALM}", "team-a", "${KUKE_TEST_NS:-r1}", "r1").Replace(manifest),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			cmd := apply.NewApplyCmd()
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+			ctx = context.WithValue(ctx, apply.MockControllerKey{}, kukeonv1.Client(&fakeClient{
+				applyFn: func(raw []byte) (kukeonv1.ApplyDocumentsResult, error) {
+					got = string(raw)
+					return kukeonv1.ApplyDocumentsResult{}, nil
+				},
+			}))
+			cmd.SetContext(ctx)
+			cmd.SetArgs(append([]string{"-f", writeTempYAML(t, manifest)}, tt.args...))
+
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("daemon received:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("undefined variable is not sent", func(t *testing.T) {
+		cmd := apply.NewApplyCmd()
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		fc := &fakeClient{}
+		cmd.SetContext(context.WithValue(context.Background(), apply.MockControllerKey{}, kukeonv1.Client(fc)))
+		cmd.SetArgs([]string{"-f", writeTempYAML(t, "name: ${KUKE_TEST_UNSET}\n"), "--expand-env"})
+
+		if err := cmd.Execute(); !errors.Is(err, errdefs.ErrManifestEnvUndefined) {
+			t.Fatalf("err = %v, want ErrManifestEnvUndefined", err)
+		}
+		if fc.applyCalls != 0 {
+			t.Errorf("ApplyDocuments called %d times, want 0", fc.applyCalls)
+		}
+	})
+}
+
+const (
+	hierarchyRealm = `apiVersion: v1beta1
+kind: Realm
+metadata:
+  name: shop
+spec:
+  namespace: shop
+`
+	hierarchySpace = `apiVersion: v1beta1
+kind: Space
+metadata:
+  name: apps
+spec:
+  realmId: shop
+`
+	hierarchyStack = `apiVersion: v1beta1
+kind: Stack
+metadata:
+  name: web
+spec:
+  id: web
+  realmId: shop
+  spaceId: apps
+`
+	hierarchyCell = `apiVersion: v1beta1
+kind: Cell
+metadata:
+  name: frontend
+spec:
+  id: frontend
+  realmId: shop
+  spaceId: apps
+  stackId: web
+  containers:
+    - id: root
+      root: true
+      image: busybox:latest
+`
+)
+
+// recordingClient answers every apply with one result per document in the
+// stream, failing documents whose name is in failNames, and records the
+// streams it was sent.
+func recordingClient(failNames ...string) *fakeClient {
+	fc := &fakeClient{}
+	fc.applyFn = func(raw []byte) (kukeonv1.ApplyDocumentsResult, error) {
+		fc.streams = append(fc.streams, string(raw))
+		var result kukeonv1.ApplyDocumentsResult
+		for _, doc := range strings.Split(string(raw), "\n---\n") {
+			var kind, name string
+			for _, line := range strings.Split(doc, "\n") {
+				if v, ok := strings.CutPrefix(line, "kind: "); ok {
+					kind = v
+				}
+				if v, ok := strings.CutPrefix(line, "  name: "); ok && name == "" {
+					name = v
+				}
+			}
+			action := "created"
+			if slices.Contains(failNames, name) {
+				action = "failed"
+			}
+			result.Resources = append(result.Resources, kukeonv1.ApplyResourceResult{Kind: kind, Name: name, Action: action})
+		}
+		return result, nil
+	}
+	return fc
+}
+
+func runApplyCmd(t *testing.T, fc *fakeClient, args ...string) (string, error) {
+	t.Helper()
+	cmd := apply.NewApplyCmd()
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+	cmd.SetContext(context.WithValue(ctx, apply.MockControllerKey{}, kukeonv1.Client(fc)))
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+// TestApply_Directory pins that a directory of mixed-kind files reaches the
+// daemon as one stream — so its kind ordering spans files — and that
+// non-manifest files are left out.
+func TestApply_Directory(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"10-cell.yaml":  hierarchyCell,
+		"20-realm.yml":  hierarchyRealm,
+		"30-scope.yaml": hierarchySpace + "---\n" + hierarchyStack,
+		"README.md":     "kind: Realm\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fc := recordingClient()
+	out, err := runApplyCmd(t, fc, "-f", dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fc.streams) != 1 {
+		t.Fatalf("ApplyDocuments called %d times, want one stream", len(fc.streams))
+	}
+	want := hierarchyCell + "\n---\n" + hierarchyRealm + "\n---\n" + hierarchySpace + "---\n" + hierarchyStack
+	if fc.streams[0] != want {
+		t.Errorf("stream:\n%s\nwant:\n%s", fc.streams[0], want)
+	}
+	if !strings.Contains(out, "4 resources: 4 created") {
+		t.Errorf("output missing the summary line:\n%s", out)
+	}
+
+	t.Run("glob", func(t *testing.T) {
+		fc := recordingClient()
+		if _, err := runApplyCmd(t, fc, "-f", filepath.Join(dir, "*.yaml")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := fc.streams[0]; strings.Contains(got, "kind: Realm") {
+			t.Errorf("*.yaml picked up the .yml file:\n%s", got)
+		}
+	})
+
+	t.Run("glob matching nothing", func(t *testing.T) {
+		if _, err := runApplyCmd(t, recordingClient(), "-f", filepath.Join(dir, "*.json")); err == nil ||
+			!strings.Contains(err.Error(), "matches no files") {
+			t.Fatalf("err = %v, want matches no files", err)
+		}
+	})
+}
+
+// TestApply_MultiDocFailFast pins the per-document order --fail-fast sends a
+// single out-of-order multi-doc file in, and that documents after the first
+// failure are reported as skipped rather than sent.
+func TestApply_MultiDocFailFast(t *testing.T) {
+	path := writeTempYAML(t, strings.Join([]string{hierarchyCell, hierarchyStack, hierarchySpace, hierarchyRealm}, "---\n"))
+
+	t.Run("default applies everything in one stream", func(t *testing.T) {
+		fc := recordingClient("apps")
+		out, err := runApplyCmd(t, fc, "-f", path)
+		if err == nil || !strings.Contains(err.Error(), "some resources failed to apply") {
+			t.Fatalf("err = %v, want some resources failed", err)
+		}
+		if len(fc.streams) != 1 {
+			t.Errorf("ApplyDocuments called %d times, want 1", len(fc.streams))
+		}
+		if !strings.Contains(out, "4 resources: 3 created, 1 failed") {
+			t.Errorf("output missing the summary line:\n%s", out)
+		}
+	})
+
+	t.Run("fail-fast stops at the first failure", func(t *testing.T) {
+		fc := recordingClient("apps")
+		out, err := runApplyCmd(t, fc, "-f", path, "--fail-fast")
+		if err == nil || !strings.Contains(err.Error(), "some resources failed to apply") {
+			t.Fatalf("err = %v, want some resources failed", err)
+		}
+		var sent []string
+		for _, stream := range fc.streams {
+			sent = append(sent, strings.SplitN(stream, "\n", 3)[1])
+		}
+		if want := []string{"kind: Realm", "kind: Space"}; !slices.Equal(sent, want) {
+			t.Errorf("sent %v, want %v", sent, want)
+		}
+		for _, line := range []string{`Space "apps": failed`, `Stack "web": skipped`, `Cell "frontend": skipped`,
+			"4 resources: 1 created, 1 failed, 2 skipped"} {
+			if !strings.Contains(out, line) {
+				t.Errorf("output missing %q:\n%s", line, out)
+			}
+		}
+	})
+
+	t.Run("fail-fast validates before sending anything", func(t *testing.T) {
+		fc := recordingClient()
+		bad := writeTempYAML(t, hierarchyRealm+"---\napiVersion: v1beta1\nkind: Space\nmetadata:\n  name: apps\n")
+		if _, err := runApplyCmd(t, fc, "-f", bad, "--fail-fast"); err == nil ||
+			!strings.Contains(err.Error(), "validation failed") {
+			t.Fatalf("err = %v, want validation failed", err)
+		}
+		if len(fc.streams) != 0 {
+			t.Errorf("ApplyDocuments called %d times, want 0", len(fc.streams))
+		}
+	})
+}
+
 // TestApply_BlueprintFlag_Removed pins #823's removal of `-b/--blueprint` from
 // `kuke apply`. The cobra-side response is the standard "unknown flag" error;
 // no fall-through to a no-op success.
@@ -244,8 +638,12 @@ type fakeClient struct {
 	kukeonv1.FakeClient
 
 	applyFn func(raw []byte) (kukeonv1.ApplyDocumentsResult, error)
+	// applyOptsFn backs ApplyDocumentsWithOptions (--field-manager,
+	// --wait-for-parent, --prune).
+	applyOptsFn func(raw []byte, opts kukeonv1.ApplyOptions) (kukeonv1.ApplyDocumentsResult, error)
 
 	applyCalls int
+	streams    []string
 }
 
 func (f *fakeClient) ApplyDocuments(_ context.Context, raw []byte) (kukeonv1.ApplyDocumentsResult, error) {
@@ -255,3 +653,12 @@ func (f *fakeClient) ApplyDocuments(_ context.Context, raw []byte) (kukeonv1.App
 	}
 	return f.applyFn(raw)
 }
+
+func (f *fakeClient) ApplyDocumentsWithOptions(
+	_ context.Context, raw []byte, opts kukeonv1.ApplyOptions,
+) (kukeonv1.ApplyDocumentsResult, error) {
+	if f.applyOptsFn == nil {
+		return kukeonv1.ApplyDocumentsResult{}, errors.New("unexpected ApplyDocumentsWithOptions call")
+	}
+	return f.applyOptsFn(raw, opts)
+}
@@ -74,6 +74,9 @@ func NewAttachCmd() *cobra.Command {
 	cmd.Flags().String("container", "",
 		"Container within the cell to attach to (omit to auto-pick the only non-root attachable)")
 	_ = viper.BindPFlag(config.KUKE_ATTACH_CONTAINER.ViperKey, cmd.Flags().Lookup("container"))
+	cmd.Flags().String("detach-keys", config.KUKE_ATTACH_DETACH_KEYS.Default,
+		"Key sequence that detaches without stopping the container (e.g. ctrl-p,ctrl-q or ctrl-a,d)")
+	_ = viper.BindPFlag(config.KUKE_ATTACH_DETACH_KEYS.ViperKey, cmd.Flags().Lookup("detach-keys"))
 
 	cmd.ValidArgsFunction = config.CompleteCellNames
 	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
@@ -104,6 +107,17 @@ func runAttach(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("%w (--stack)", errdefs.ErrStackNameRequired)
 	}
 
+	// An empty --detach-keys falls back to the default rather than
+	// disabling detach: a session with no way out is never what was meant.
+	detachSpec := viper.GetString(config.KUKE_ATTACH_DETACH_KEYS.ViperKey)
+	if strings.TrimSpace(detachSpec) == "" {
+		detachSpec = config.KUKE_ATTACH_DETACH_KEYS.Default
+	}
+	detachKeys, err := parseDetachKeys(detachSpec)
+	if err != nil {
+		return err
+	}
+
 	client, err := resolveClient(cmd)
 	if err != nil {
 		return err
@@ -150,12 +164,12 @@ func runAttach(cmd *cobra.Command, args []string) error {
 	}
 
 	run := resolveRun(cmd)
-	runErr := run(cmd.Context(), attach.Options{
+	runErr := runWithDetachKeys(cmd.Context(), run, attach.Options{
 		SocketPath: result.HostSocketPath,
 		Stdin:      os.Stdin,
 		Stdout:     os.Stdout,
 		Stderr:     os.Stderr,
-	})
+	}, detachKeys)
 	if runErr != nil && !kukeshared.IsCleanAttachExit(runErr) {
 		return runErr
 	}
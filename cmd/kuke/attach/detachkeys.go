@@ -0,0 +1,146 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package attach
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+// errDetachKeys is returned by detachReader once the operator has typed the
+// full detach sequence. It never escapes the attach command: the relay
+// translates it into sbsh's attach.ErrDetached.
+var errDetachKeys = errors.New("detach keys pressed")
+
+// parseDetachKeys turns a --detach-keys value into the byte sequence it
+// names, using the docker notation: a comma-separated list where each entry
+// is either a single printable character or ctrl-<key> with <key> one of
+// a-z, @, [, \, ], ^ or _.
+func parseDetachKeys(spec string) ([]byte, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, fmt.Errorf("%w: empty sequence", errdefs.ErrInvalidDetachKeys)
+	}
+	keys := strings.Split(spec, ",")
+	seq := make([]byte, 0, len(keys))
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if name, ok := strings.CutPrefix(key, "ctrl-"); ok && len(name) == 1 {
+			b, valid := ctrlKey(name[0])
+			if !valid {
+				return nil, fmt.Errorf("%w: unknown key %q", errdefs.ErrInvalidDetachKeys, key)
+			}
+			seq = append(seq, b)
+			continue
+		}
+		if len(key) != 1 || key[0] < ' ' || key[0] > '~' {
+			return nil, fmt.Errorf("%w: unknown key %q", errdefs.ErrInvalidDetachKeys, key)
+		}
+		seq = append(seq, key[0])
+	}
+	return seq, nil
+}
+
+// ctrlKey maps the <key> of ctrl-<key> to the control byte a terminal sends.
+func ctrlKey(c byte) (byte, bool) {
+	switch {
+	case c >= 'a' && c <= 'z':
+		return c - 'a' + 1, true
+	case c == '@':
+		return 0, true
+	case c >= '[' && c <= '_':
+		return c - '[' + 0x1b, true
+	default:
+		return 0, false
+	}
+}
+
+// detachMatcher scans a byte stream for the detach sequence. Bytes that may
+// still be the start of the sequence are held back; once they can no longer
+// match they are released in order, so a partial match costs the workload
+// nothing but latency.
+type detachMatcher struct {
+	seq  []byte
+	held []byte
+}
+
+// feed consumes p and returns the bytes that are safe to forward, plus
+// whether the sequence completed. Bytes of p after a completed sequence are
+// dropped: the session is ending.
+func (m *detachMatcher) feed(p []byte) ([]byte, bool) {
+	var out []byte
+	for _, c := range p {
+		m.held = append(m.held, c)
+		// On a mismatch, release the oldest held byte and retry with the
+		// rest, which may itself be a shorter prefix of the sequence.
+		for len(m.held) > 0 && !bytes.HasPrefix(m.seq, m.held) {
+			out = append(out, m.held[0])
+			m.held = m.held[1:]
+		}
+		if len(m.held) == len(m.seq) {
+			m.held = nil
+			return out, true
+		}
+	}
+	return out, false
+}
+
+// flush releases whatever partial match is still held.
+func (m *detachMatcher) flush() []byte {
+	out := m.held
+	m.held = nil
+	return out
+}
+
+// detachReader filters r through a detachMatcher. It returns errDetachKeys
+// after the last byte preceding the sequence has been read.
+type detachReader struct {
+	r       io.Reader
+	m       detachMatcher
+	buf     []byte
+	pending []byte
+	err     error
+}
+
+func newDetachReader(r io.Reader, seq []byte) *detachReader {
+	return &detachReader{r: r, m: detachMatcher{seq: seq}, buf: make([]byte, 4096)}
+}
+
+func (d *detachReader) Read(p []byte) (int, error) {
+	for len(d.pending) == 0 && d.err == nil {
+		n, err := d.r.Read(d.buf)
+		out, matched := d.m.feed(d.buf[:n])
+		d.pending = append(d.pending, out...)
+		switch {
+		case matched:
+			d.err = errDetachKeys
+		case err != nil:
+			d.pending = append(d.pending, d.m.flush()...)
+			d.err = err
+		}
+	}
+	n := copy(p, d.pending)
+	d.pending = d.pending[n:]
+	if len(d.pending) == 0 && d.err != nil {
+		return n, d.err
+	}
+	return n, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package attach_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	attachcmd "github.com/eminwux/kukeon/cmd/kuke/attach"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/spf13/viper"
+)
+
+func TestParseDetachKeys(t *testing.T) {
+	cases := []struct {
+		spec string
+		want []byte
+	}{
+		{spec: "ctrl-p,ctrl-q", want: []byte{0x10, 0x11}},
+		{spec: "ctrl-a, d", want: []byte{0x01, 'd'}},
+		{spec: "ctrl-@,ctrl-[,ctrl-\\,ctrl-],ctrl-^,ctrl-_", want: []byte{0x00, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f}},
+		{spec: "q", want: []byte{'q'}},
+	}
+	for _, tc := range cases {
+		got, err := attachcmd.ParseDetachKeys(tc.spec)
+		if err != nil {
+			t.Errorf("ParseDetachKeys(%q): %v", tc.spec, err)
+			continue
+		}
+		if !bytes.Equal(got, tc.want) {
+			t.Errorf("ParseDetachKeys(%q) = %v, want %v", tc.spec, got, tc.want)
+		}
+	}
+
+	for _, spec := range []string{"", "ctrl-", "ctrl-1", "ctrl-pq", "alt-x", "ab", "p,,q"} {
+		if _, err := attachcmd.ParseDetachKeys(spec); !errors.Is(err, errdefs.ErrInvalidDetachKeys) {
+			t.Errorf("ParseDetachKeys(%q) error = %v, want ErrInvalidDetachKeys", spec, err)
+		}
+	}
+}
+
+// TestDetachReader runs each stream through the reader whole and one byte
+// at a time: a partial match must behave the same whether or not it spans
+// reads.
+func TestDetachReader(t *testing.T) {
+	seq := []byte{0x10, 0x11} // ctrl-p,ctrl-q
+	cases := []struct {
+		name     string
+		in       []byte
+		want     []byte
+		detached bool
+	}{
+		{name: "no sequence passes through", in: []byte("ls -l\r"), want: []byte("ls -l\r")},
+		{name: "sequence detaches", in: []byte("ab\x10\x11"), want: []byte("ab"), detached: true},
+		{name: "bytes after the sequence are dropped", in: []byte("a\x10\x11bc"), want: []byte("a"), detached: true},
+		{name: "broken prefix is released", in: []byte("\x10x\x11"), want: []byte("\x10x\x11")},
+		{name: "repeated first key resets onto itself", in: []byte("\x10\x10\x11"), want: []byte("\x10"), detached: true},
+		{name: "trailing partial match is flushed at EOF", in: []byte("a\x10"), want: []byte("a\x10")},
+	}
+	for _, tc := range cases {
+		for _, chunked := range []bool{false, true} {
+			var src io.Reader = bytes.NewReader(tc.in)
+			if chunked {
+				src = iotest.OneByteReader(src)
+			}
+			got, err := io.ReadAll(attachcmd.NewDetachReader(src, seq))
+			if tc.detached != errors.Is(err, attachcmd.ErrDetachKeys) {
+				t.Errorf("%s (chunked=%v): err = %v, detached want %v", tc.name, chunked, err, tc.detached)
+			}
+			if !tc.detached && err != nil {
+				t.Errorf("%s (chunked=%v): unexpected error %v", tc.name, chunked, err)
+			}
+			if !bytes.Equal(got, tc.want) {
+				t.Errorf("%s (chunked=%v): forwarded %q, want %q", tc.name, chunked, got, tc.want)
+			}
+		}
+	}
+}
+
+func TestDetachReader_OverlappingSequence(t *testing.T) {
+	// "aab": after "aa" fails to be followed by 'b' at position 2, the
+	// second 'a' must still be considered as the start of a new match.
+	seq := []byte("aab")
+	got, err := io.ReadAll(attachcmd.NewDetachReader(bytes.NewReader([]byte("xaaab")), seq))
+	if !errors.Is(err, attachcmd.ErrDetachKeys) {
+		t.Fatalf("err = %v, want ErrDetachKeys", err)
+	}
+	if string(got) != "xa" {
+		t.Errorf("forwarded %q, want %q", got, "xa")
+	}
+}
+
+func TestAttach_InvalidDetachKeys_FailsBeforeAttach(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	fc := &fakeClient{}
+	run := &runCapture{}
+	cmd := newCmdWithCtx(t, fc, run)
+	cmd.SetArgs([]string{"--detach-keys", "ctrl-1", "c1"})
+
+	if err := cmd.Execute(); !errors.Is(err, errdefs.ErrInvalidDetachKeys) {
+		t.Fatalf("error %v does not unwrap to ErrInvalidDetachKeys", err)
+	}
+	if run.calls != 0 {
+		t.Errorf("attach loop ran %d times, want 0", run.calls)
+	}
+}
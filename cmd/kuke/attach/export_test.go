@@ -16,8 +16,23 @@
 
 package attach
 
+import "io"
+
 // RunFn is the test-visible alias of the unexported runFn type. Tests
 // build values of this type and store them under MockRunKey to bypass
 // the real pkg/attach.Run, which would open the user's TTY and connect
 // to a real control socket.
 type RunFn = runFn
+
+// ParseDetachKeys exposes parseDetachKeys to the external test package.
+var ParseDetachKeys = parseDetachKeys
+
+// ErrDetachKeys is the sentinel a detach reader returns once the full
+// sequence has been read.
+var ErrDetachKeys = errDetachKeys
+
+// NewDetachReader exposes the detach-sequence filtering reader the attach
+// relay copies the operator's terminal through.
+func NewDetachReader(r io.Reader, seq []byte) io.Reader {
+	return newDetachReader(r, seq)
+}
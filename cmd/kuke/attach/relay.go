@@ -0,0 +1,121 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package attach
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"syscall"
+
+	"github.com/creack/pty"
+	kukeshared "github.com/eminwux/kukeon/cmd/kuke/shared"
+	"github.com/eminwux/sbsh/pkg/attach"
+	"golang.org/x/term"
+)
+
+// runWithDetachKeys drives run behind a pty so kuke, not sbsh, owns the
+// detach sequence. sbsh only knows its built-in ^]^] and insists on a
+// TTY-backed *os.File for stdin, so the operator's terminal is put in raw
+// mode here and its bytes are relayed into a fresh pty through a
+// detachReader; sbsh attaches to the pty's slave end. When the sequence
+// fires, the attach context is cancelled: sbsh drops its client connection
+// and the remote terminal — and the task behind it — keeps running. The
+// operator's terminal mode is restored on every exit path. Window-size
+// changes are copied onto the pty, where sbsh picks them up and forwards
+// them to the container's terminal.
+//
+// When stdin is not a terminal there is nothing to relay and run is called
+// unchanged, keeping sbsh's own detach keystroke.
+func runWithDetachKeys(ctx context.Context, run runFn, opts attach.Options, keys []byte) error {
+	stdin := opts.Stdin
+	if stdin == nil {
+		stdin = os.Stdin
+	}
+	fd := int(stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return run(ctx, opts)
+	}
+
+	ptmx, tty, err := pty.Open()
+	if err != nil {
+		return fmt.Errorf("open attach pty: %w", err)
+	}
+	defer func() {
+		_ = tty.Close()
+		_ = ptmx.Close()
+	}()
+	// Raw slave before any byte is relayed, so the line discipline neither
+	// echoes into the unread master nor rewrites control characters.
+	if _, err = term.MakeRaw(int(tty.Fd())); err != nil {
+		return fmt.Errorf("set attach pty raw: %w", err)
+	}
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("set terminal raw: %w", err)
+	}
+	defer func() { _ = term.Restore(fd, state) }()
+
+	// The initial resize sizes the pty before sbsh first reads it.
+	stopResize := kukeshared.WatchResize(kukeshared.TerminalSizeOf(stdin), resizePty(ptmx))
+	defer stopResize()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	detached := make(chan struct{})
+	go func() {
+		// The copy outlives a session that ends for another reason: it
+		// stays blocked reading the terminal until the process exits.
+		_, copyErr := io.Copy(ptmx, newDetachReader(stdin, keys))
+		if errors.Is(copyErr, errDetachKeys) {
+			close(detached)
+			cancel()
+		}
+	}()
+
+	opts.Stdin = tty
+	opts.DisableDetachKeystroke = true
+	runErr := run(ctx, opts)
+
+	select {
+	case <-detached:
+		return fmt.Errorf("%w: %w", attach.ErrDetached, errDetachKeys)
+	default:
+		return runErr
+	}
+}
+
+// resizePty returns a ResizeFunc that sizes the relay pty. sbsh reads the
+// pty's size on its own SIGWINCH and may win the race with the Setsize, so
+// after a change the signal is raised once more; the shared watcher sees the
+// size unchanged on the repeat and does not forward it again.
+func resizePty(ptmx *os.File) kukeshared.ResizeFunc {
+	return func(width, height uint32) error {
+		err := pty.Setsize(ptmx, &pty.Winsize{
+			Cols: uint16(min(width, math.MaxUint16)),
+			Rows: uint16(min(height, math.MaxUint16)),
+		})
+		if err != nil {
+			return err
+		}
+		return syscall.Kill(os.Getpid(), syscall.SIGWINCH)
+	}
+}
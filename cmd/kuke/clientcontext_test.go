@@ -0,0 +1,152 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kuke
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eminwux/kukeon/cmd/config"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func contextTestDoc() *v1beta1.ClientConfigurationDoc {
+	return &v1beta1.ClientConfigurationDoc{Spec: v1beta1.ClientConfigurationSpec{
+		CurrentContext: "web",
+		Contexts: []v1beta1.ClientContext{
+			{Name: "web", Realm: "team-a", Stack: "frontend"},
+			{Name: "ops", Realm: "ops", Space: "infra"},
+		},
+	}}
+}
+
+// newContextTestCmd builds the real command tree and returns the `kuke
+// create cell` leaf, whose --realm/--space/--stack fall back to "default".
+func newContextTestCmd(t *testing.T) (*cobra.Command, *cobra.Command) {
+	t.Helper()
+	t.Cleanup(viper.Reset)
+	viper.Reset()
+	root, err := NewKukeCmd()
+	if err != nil {
+		t.Fatalf("NewKukeCmd() error = %v", err)
+	}
+	leaf, _, err := root.Find([]string{"create", "cell"})
+	if err != nil {
+		t.Fatalf("find create cell: %v", err)
+	}
+	return root, leaf
+}
+
+func scope() (string, string, string) {
+	return viper.GetString(config.KUKE_CREATE_CELL_REALM.ViperKey),
+		viper.GetString(config.KUKE_CREATE_CELL_SPACE.ViperKey),
+		viper.GetString(config.KUKE_CREATE_CELL_STACK.ViperKey)
+}
+
+func TestApplyClientContextCurrentContextSetsScopeDefaults(t *testing.T) {
+	_, leaf := newContextTestCmd(t)
+
+	if err := applyClientContext(leaf, contextTestDoc()); err != nil {
+		t.Fatalf("applyClientContext() error = %v", err)
+	}
+	realm, space, stack := scope()
+	if realm != "team-a" || stack != "frontend" {
+		t.Errorf("realm/stack = %q/%q, want team-a/frontend from the current context", realm, stack)
+	}
+	if space == "infra" {
+		t.Errorf("space = %q, leaked from a context that is not selected", space)
+	}
+}
+
+func TestApplyClientContextPrecedence(t *testing.T) {
+	t.Run("--context beats currentContext", func(t *testing.T) {
+		root, leaf := newContextTestCmd(t)
+		if err := root.PersistentFlags().Set("context", "ops"); err != nil {
+			t.Fatal(err)
+		}
+		if err := applyClientContext(leaf, contextTestDoc()); err != nil {
+			t.Fatalf("applyClientContext() error = %v", err)
+		}
+		if realm, space, _ := scope(); realm != "ops" || space != "infra" {
+			t.Errorf("realm/space = %q/%q, want ops/infra from --context", realm, space)
+		}
+	})
+
+	t.Run("KUKEON_CONTEXT beats currentContext", func(t *testing.T) {
+		_, leaf := newContextTestCmd(t)
+		t.Setenv(config.KUKEON_ROOT_CONTEXT.EnvVar(), "ops")
+		if err := applyClientContext(leaf, contextTestDoc()); err != nil {
+			t.Fatalf("applyClientContext() error = %v", err)
+		}
+		if realm, _, _ := scope(); realm != "ops" {
+			t.Errorf("realm = %q, want ops from KUKEON_CONTEXT", realm)
+		}
+	})
+
+	t.Run("env beats context", func(t *testing.T) {
+		_, leaf := newContextTestCmd(t)
+		t.Setenv(config.KUKE_CREATE_CELL_REALM.EnvVar(), "from-env")
+		if err := config.KUKE_CREATE_CELL_REALM.BindEnv(); err != nil {
+			t.Fatal(err)
+		}
+		if err := applyClientContext(leaf, contextTestDoc()); err != nil {
+			t.Fatalf("applyClientContext() error = %v", err)
+		}
+		if realm, _, _ := scope(); realm != "from-env" {
+			t.Errorf("realm = %q, want from-env", realm)
+		}
+	})
+
+	t.Run("flag beats env and context", func(t *testing.T) {
+		_, leaf := newContextTestCmd(t)
+		t.Setenv(config.KUKE_CREATE_CELL_REALM.EnvVar(), "from-env")
+		if err := config.KUKE_CREATE_CELL_REALM.BindEnv(); err != nil {
+			t.Fatal(err)
+		}
+		if err := leaf.Flags().Set("realm", "from-flag"); err != nil {
+			t.Fatal(err)
+		}
+		if err := applyClientContext(leaf, contextTestDoc()); err != nil {
+			t.Fatalf("applyClientContext() error = %v", err)
+		}
+		if realm, _, _ := scope(); realm != "from-flag" {
+			t.Errorf("realm = %q, want from-flag", realm)
+		}
+	})
+}
+
+func TestApplyClientContextUnknownContext(t *testing.T) {
+	root, leaf := newContextTestCmd(t)
+	if err := root.PersistentFlags().Set("context", "missing"); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyClientContext(leaf, contextTestDoc()); !errors.Is(err, errdefs.ErrClientContextNotFound) {
+		t.Fatalf("applyClientContext() error = %v, want ErrClientContextNotFound", err)
+	}
+
+	// `kuke config` itself must stay usable so the selection can be fixed.
+	configLeaf, _, err := root.Find([]string{"config", "unset"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = applyClientContext(configLeaf, contextTestDoc()); err != nil {
+		t.Errorf("applyClientContext(kuke config unset) error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package config implements `kuke config view|set|unset`, which read and edit
+// the kuke ClientConfiguration (`--configuration`, default ~/.kuke/kuke.yaml)
+// so persistent defaults — the daemon endpoint, the run path, and named
+// contexts selecting the default realm/space/stack — need not be passed as
+// flags on every invocation.
+package config
+
+import (
+	"strings"
+
+	"github.com/eminwux/kukeon/cmd/config"
+	"github.com/eminwux/kukeon/internal/clientconfig"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// NewConfigCmd builds the `kuke config` command group.
+func NewConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "View and edit the kuke client configuration",
+		Long: "View and edit the kuke ClientConfiguration (--configuration, default\n" +
+			"~/.kuke/kuke.yaml). Values set here are defaults: an explicit --flag or\n" +
+			"KUKEON_* env var still wins.\n\n" +
+			"A context names a default scope. With a context selected (set\n" +
+			"currentContext, or pass --context / KUKEON_CONTEXT), commands that would\n" +
+			"fall back to the \"default\" realm, space, or stack use the context's\n" +
+			"instead.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newViewCmd(), newSetCmd(), newUnsetCmd())
+	return cmd
+}
+
+func newViewCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:          "view",
+		Short:        "Print the client configuration",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			path := configurationPath()
+			doc, err := clientconfig.Load(path)
+			if err != nil {
+				return err
+			}
+			out, err := clientconfig.Marshal(doc)
+			if err != nil {
+				return err
+			}
+			cmd.Printf("# %s\n%s", path, out)
+			return nil
+		},
+	}
+}
+
+func newSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set KEY VALUE",
+		Short: "Set a client configuration value",
+		Long: "Set a client configuration value. Keys:\n  " +
+			strings.Join(clientconfig.Keys(), "\n  ") + "\n\n" +
+			"contexts.<name>.<level> creates the context if needed; currentContext\n" +
+			"must name a context that exists.",
+		Example: "  kuke config set host unix:///run/kukeon/kukeond.sock\n" +
+			"  kuke config set contexts.web.realm team-a\n" +
+			"  kuke config set contexts.web.stack frontend\n" +
+			"  kuke config set currentContext web",
+		Args:              cobra.ExactArgs(2),
+		SilenceUsage:      true,
+		ValidArgsFunction: completeKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return edit(cmd, args[0], func(path string) error {
+				doc, err := clientconfig.Load(path)
+				if err != nil {
+					return err
+				}
+				if err = clientconfig.Set(doc, args[0], args[1]); err != nil {
+					return err
+				}
+				return clientconfig.Save(path, doc)
+			})
+		},
+	}
+}
+
+func newUnsetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unset KEY",
+		Short: "Clear a client configuration value",
+		Long: "Clear a client configuration value so its built-in default applies again.\n" +
+			"contexts.<name> removes the whole context (and clears currentContext when\n" +
+			"it selected that context).",
+		Args:              cobra.ExactArgs(1),
+		SilenceUsage:      true,
+		ValidArgsFunction: completeKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return edit(cmd, args[0], func(path string) error {
+				doc, err := clientconfig.Load(path)
+				if err != nil {
+					return err
+				}
+				if err = clientconfig.Unset(doc, args[0]); err != nil {
+					return err
+				}
+				return clientconfig.Save(path, doc)
+			})
+		},
+	}
+}
+
+// edit runs one load-modify-save cycle against the configuration file and
+// reports which key changed where.
+func edit(cmd *cobra.Command, key string, apply func(path string) error) error {
+	path := configurationPath()
+	if err := apply(path); err != nil {
+		return err
+	}
+	cmd.Printf("%s updated in %s\n", key, path)
+	return nil
+}
+
+// configurationPath resolves the file the root command loaded: `--configuration`
+// > KUKE_CONFIGURATION > ~/.kuke/kuke.yaml.
+func configurationPath() string {
+	if path := viper.GetString(config.KUKE_CONFIGURATION.ViperKey); path != "" {
+		return path
+	}
+	return config.DefaultClientConfigurationFile()
+}
+
+func completeKeys(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return clientconfig.Keys(), cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cordon implements `kuke cordon` and `kuke uncordon`, which close
+// the node to new cells and containers for maintenance and reopen it.
+package cordon
+
+import (
+	"time"
+
+	kukeshared "github.com/eminwux/kukeon/cmd/kuke/shared"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	"github.com/spf13/cobra"
+)
+
+// MockControllerKey is used to inject a mock kukeonv1.Client via context in tests.
+type MockControllerKey struct{}
+
+// NewCordonCmd builds the `kuke cordon` command.
+func NewCordonCmd() *cobra.Command {
+	var reason string
+
+	cmd := &cobra.Command{
+		Use:   "cordon",
+		Short: "Stop new cells and containers from being created on this node",
+		Long: "Cordon the node for maintenance. While cordoned, creating a cell or adding\n" +
+			"a container to a cell fails with a \"node is cordoned\" error; everything\n" +
+			"already provisioned keeps running and can still be listed, started,\n" +
+			"stopped, and deleted, and the reconcile loop keeps ensuring it.\n\n" +
+			"The state persists in the run path across daemon restarts until\n" +
+			"`kuke uncordon`.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			client, err := resolveClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = client.Close() }()
+
+			result, err := client.CordonNode(cmd.Context(), reason)
+			if err != nil {
+				return err
+			}
+			printResult(cmd, result)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&reason, "reason", "", "note recorded with the cordon and shown by `kuke status`")
+
+	return cmd
+}
+
+// NewUncordonCmd builds the `kuke uncordon` command.
+func NewUncordonCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:          "uncordon",
+		Short:        "Allow new cells and containers on a cordoned node again",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			client, err := resolveClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = client.Close() }()
+
+			result, err := client.UncordonNode(cmd.Context())
+			if err != nil {
+				return err
+			}
+			printResult(cmd, result)
+			return nil
+		},
+	}
+}
+
+func resolveClient(cmd *cobra.Command) (kukeonv1.Client, error) {
+	if mockClient, ok := cmd.Context().Value(MockControllerKey{}).(kukeonv1.Client); ok {
+		return mockClient, nil
+	}
+	return kukeshared.ClientFromCmd(cmd)
+}
+
+func printResult(cmd *cobra.Command, r kukeonv1.NodeCordonResult) {
+	switch {
+	case r.Cordoned && r.Changed:
+		cmd.Println("node cordoned")
+	case r.Cordoned:
+		since := "an unknown time"
+		if r.Since != nil {
+			since = r.Since.Local().Format(time.RFC3339)
+		}
+		cmd.Printf("node already cordoned since %s\n", since)
+	case r.Changed:
+		cmd.Println("node uncordoned")
+	default:
+		cmd.Println("node was not cordoned")
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cordon_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	cordonpkg "github.com/eminwux/kukeon/cmd/kuke/cordon"
+	"github.com/eminwux/kukeon/cmd/types"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestCordonCmds(t *testing.T) {
+	since := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		cmd        func() *cobra.Command
+		args       []string
+		fake       *fakeClient
+		wantReason string
+		wantErr    string
+		wantOutput string
+	}{
+		{
+			name: "cordon",
+			cmd:  cordonpkg.NewCordonCmd,
+			args: []string{"--reason", "kernel upgrade"},
+			fake: &fakeClient{cordonResult: kukeonv1.NodeCordonResult{
+				Cordoned: true, Changed: true, Since: &since, Reason: "kernel upgrade",
+			}},
+			wantReason: "kernel upgrade",
+			wantOutput: "node cordoned",
+		},
+		{
+			name: "cordon already cordoned",
+			cmd:  cordonpkg.NewCordonCmd,
+			fake: &fakeClient{cordonResult: kukeonv1.NodeCordonResult{Cordoned: true, Since: &since}},
+			wantOutput: "node already cordoned since " +
+				since.Local().Format(time.RFC3339),
+		},
+		{
+			name:       "uncordon",
+			cmd:        cordonpkg.NewUncordonCmd,
+			fake:       &fakeClient{cordonResult: kukeonv1.NodeCordonResult{Changed: true}},
+			wantOutput: "node uncordoned",
+		},
+		{
+			name:       "uncordon not cordoned",
+			cmd:        cordonpkg.NewUncordonCmd,
+			fake:       &fakeClient{},
+			wantOutput: "node was not cordoned",
+		},
+		{
+			name:    "error",
+			cmd:     cordonpkg.NewCordonCmd,
+			fake:    &fakeClient{err: errors.New("boom")},
+			wantErr: "boom",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			viper.Reset()
+
+			cmd := tt.cmd()
+			buf := &bytes.Buffer{}
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+			ctx = context.WithValue(ctx, cordonpkg.MockControllerKey{}, kukeonv1.Client(tt.fake))
+			cmd.SetContext(ctx)
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("want err %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.wantOutput) {
+				t.Errorf("output missing %q\nGot:\n%s", tt.wantOutput, buf.String())
+			}
+			if tt.fake.gotReason != tt.wantReason {
+				t.Errorf("reason = %q, want %q", tt.fake.gotReason, tt.wantReason)
+			}
+		})
+	}
+}
+
+type fakeClient struct {
+	kukeonv1.FakeClient
+
+	cordonResult kukeonv1.NodeCordonResult
+	err          error
+	gotReason    string
+}
+
+func (f *fakeClient) CordonNode(_ context.Context, reason string) (kukeonv1.NodeCordonResult, error) {
+	f.gotReason = reason
+	return f.cordonResult, f.err
+}
+
+func (f *fakeClient) UncordonNode(context.Context) (kukeonv1.NodeCordonResult, error) {
+	return f.cordonResult, f.err
+}
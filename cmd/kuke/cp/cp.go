@@ -0,0 +1,191 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cp implements `kuke cp`, which copies files and directories
+// between the host and a running container:
+//
+//	kuke cp <cell>:<container>:<path> <local-path>   # copy out
+//	kuke cp <local-path> <cell>:<container>:<path>   # copy in
+//
+// The container side is reached through the task's /proc/<pid>/root view,
+// which only exists in the host PID namespace, so `kuke cp` is in-process
+// by design — the same daemon-independent category as `kuke image *`. It
+// always constructs a local in-process Client and never dials kukeond.
+package cp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/eminwux/kukeon/cmd/config"
+	kukeshared "github.com/eminwux/kukeon/cmd/kuke/shared"
+	"github.com/eminwux/kukeon/internal/client/local"
+	"github.com/eminwux/kukeon/internal/consts"
+	"github.com/eminwux/kukeon/internal/containerfs"
+	"github.com/eminwux/kukeon/internal/controller"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// MockControllerKey injects a mock Client via context for tests.
+type MockControllerKey struct{}
+
+// Client is the narrow surface `kuke cp` uses. It is satisfied by
+// `*local.Client` and by per-test fakes injected via MockControllerKey.
+type Client interface {
+	io.Closer
+
+	ResolveContainerRootFS(ctx context.Context, doc v1beta1.ContainerDoc) (kukeonv1.ContainerRootFSResult, error)
+}
+
+// remoteOperand is a parsed `<cell>:<container>:<path>` argument.
+type remoteOperand struct {
+	cell      string
+	container string
+	path      string
+}
+
+// NewCpCmd builds the `kuke cp` cobra command.
+func NewCpCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cp <cell>:<container>:<path> <local-path> | <local-path> <cell>:<container>:<path>",
+		Short: "Copy files and directories between the host and a running container",
+		Long: "Copy files and directories between the host and a running container. " +
+			"Exactly one operand names the container side as <cell>:<container>:<path>; " +
+			"the other is a host path. Directories copy recursively and file modes are " +
+			"preserved. When the destination is an existing directory the source is placed " +
+			"inside it under its own name; otherwise the destination names the copy. " +
+			"The container must have a running task.",
+		Args:          cobra.ExactArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE:          runCp,
+	}
+
+	cmd.Flags().String("realm", consts.KukeonDefaultRealmName, "Realm that owns the cell")
+	_ = viper.BindPFlag(config.KUKE_CP_REALM.ViperKey, cmd.Flags().Lookup("realm"))
+	cmd.Flags().String("space", consts.KukeonDefaultSpaceName, "Space that owns the cell")
+	_ = viper.BindPFlag(config.KUKE_CP_SPACE.ViperKey, cmd.Flags().Lookup("space"))
+	cmd.Flags().String("stack", consts.KukeonDefaultStackName, "Stack that owns the cell")
+	_ = viper.BindPFlag(config.KUKE_CP_STACK.ViperKey, cmd.Flags().Lookup("stack"))
+
+	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
+	_ = cmd.RegisterFlagCompletionFunc("space", config.CompleteSpaceNames)
+	_ = cmd.RegisterFlagCompletionFunc("stack", config.CompleteStackNames)
+
+	return cmd
+}
+
+func runCp(cmd *cobra.Command, args []string) error {
+	src, dst := args[0], args[1]
+	srcRemote, srcIsRemote := parseRemote(src)
+	dstRemote, dstIsRemote := parseRemote(dst)
+	if srcIsRemote == dstIsRemote {
+		return errdefs.ErrCopyPathSpec
+	}
+
+	remote := srcRemote
+	if dstIsRemote {
+		remote = dstRemote
+	}
+
+	realm := strings.TrimSpace(viper.GetString(config.KUKE_CP_REALM.ViperKey))
+	space := strings.TrimSpace(viper.GetString(config.KUKE_CP_SPACE.ViperKey))
+	stack := strings.TrimSpace(viper.GetString(config.KUKE_CP_STACK.ViperKey))
+
+	client := resolveClient(cmd)
+	defer func() { _ = client.Close() }()
+
+	res, err := client.ResolveContainerRootFS(cmd.Context(), buildContainerDoc(remote, realm, space, stack))
+	if err != nil {
+		return fmt.Errorf("resolve container %q in cell %q: %w", remote.container, remote.cell, err)
+	}
+
+	if srcIsRemote {
+		return containerfs.CopyFrom(res.HostRootPath, remote.path, dst)
+	}
+	return containerfs.CopyTo(res.HostRootPath, src, remote.path)
+}
+
+// parseRemote reports whether arg is a `<cell>:<container>:<path>` operand.
+// Anything that starts like a filesystem path (absolute, ./ or ../) is a
+// host path even if it contains colons, so a local file named `a:b:c` can
+// still be copied as `./a:b:c`. Container paths are always interpreted
+// from the container root.
+func parseRemote(arg string) (remoteOperand, bool) {
+	if filepath.IsAbs(arg) || strings.HasPrefix(arg, "."+string(filepath.Separator)) ||
+		strings.HasPrefix(arg, ".."+string(filepath.Separator)) {
+		return remoteOperand{}, false
+	}
+	parts := strings.SplitN(arg, ":", 3)
+	if len(parts) != 3 {
+		return remoteOperand{}, false
+	}
+	op := remoteOperand{
+		cell:      strings.TrimSpace(parts[0]),
+		container: strings.TrimSpace(parts[1]),
+		path:      parts[2],
+	}
+	if op.cell == "" || op.container == "" || op.path == "" {
+		return remoteOperand{}, false
+	}
+	if !filepath.IsAbs(op.path) {
+		op.path = string(filepath.Separator) + op.path
+	}
+	return op, true
+}
+
+// resolveClient returns the Client `kuke cp` uses: a test fake injected via
+// MockControllerKey, or a fresh in-process local.Client wired to the root
+// persistent --run-path and --containerd-socket flags.
+func resolveClient(cmd *cobra.Command) Client {
+	if mockClient, ok := cmd.Context().Value(MockControllerKey{}).(Client); ok {
+		return mockClient
+	}
+	logger, err := kukeshared.LoggerFromCmd(cmd)
+	if err != nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return local.New(cmd.Context(), logger, controller.Options{
+		RunPath:          viper.GetString(config.KUKEON_ROOT_RUN_PATH.ViperKey),
+		ContainerdSocket: viper.GetString(config.KUKEON_ROOT_CONTAINERD_SOCKET.ViperKey),
+	})
+}
+
+func buildContainerDoc(op remoteOperand, realm, space, stack string) v1beta1.ContainerDoc {
+	return v1beta1.ContainerDoc{
+		APIVersion: v1beta1.APIVersionV1Beta1,
+		Kind:       v1beta1.KindContainer,
+		Metadata: v1beta1.ContainerMetadata{
+			Name:   op.container,
+			Labels: make(map[string]string),
+		},
+		Spec: v1beta1.ContainerSpec{
+			ID:      op.container,
+			RealmID: realm,
+			SpaceID: space,
+			StackID: stack,
+			CellID:  op.cell,
+		},
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cp_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cp "github.com/eminwux/kukeon/cmd/kuke/cp"
+	"github.com/eminwux/kukeon/cmd/types"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+func TestCpCmd_CopyOutOfContainer(t *testing.T) {
+	rootfs := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(rootfs, "var/log"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(rootfs, "var/log/app.log"), []byte("line\n"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	var gotDoc v1beta1.ContainerDoc
+	fake := &fakeCpClient{resolveFn: func(doc v1beta1.ContainerDoc) (kukeonv1.ContainerRootFSResult, error) {
+		gotDoc = doc
+		return kukeonv1.ContainerRootFSResult{PID: 7, HostRootPath: rootfs}, nil
+	}}
+	dst := filepath.Join(t.TempDir(), "app.log")
+
+	if err := runCp(t, fake, []string{"web:app:/var/log/app.log", dst, "--realm", "r1"}); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	got, err := os.ReadFile(dst)
+	if err != nil || string(got) != "line\n" {
+		t.Fatalf("copied content = %q (err %v)", got, err)
+	}
+	if gotDoc.Spec.CellID != "web" || gotDoc.Metadata.Name != "app" || gotDoc.Spec.RealmID != "r1" {
+		t.Errorf("unexpected container doc: %+v", gotDoc)
+	}
+	if gotDoc.Spec.SpaceID != "default" || gotDoc.Spec.StackID != "default" {
+		t.Errorf("space/stack = %q/%q, want default/default", gotDoc.Spec.SpaceID, gotDoc.Spec.StackID)
+	}
+}
+
+func TestCpCmd_CopyIntoContainer(t *testing.T) {
+	rootfs := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(rootfs, "etc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	src := filepath.Join(t.TempDir(), "app.conf")
+	if err := os.WriteFile(src, []byte("k=v"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	fake := &fakeCpClient{resolveFn: func(v1beta1.ContainerDoc) (kukeonv1.ContainerRootFSResult, error) {
+		return kukeonv1.ContainerRootFSResult{PID: 7, HostRootPath: rootfs}, nil
+	}}
+
+	if err := runCp(t, fake, []string{src, "web:app:etc"}); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(rootfs, "etc/app.conf"))
+	if err != nil || string(got) != "k=v" {
+		t.Fatalf("copied content = %q (err %v)", got, err)
+	}
+}
+
+func TestCpCmd_RejectsTwoLocalOrTwoRemoteOperands(t *testing.T) {
+	fake := &fakeCpClient{resolveFn: func(v1beta1.ContainerDoc) (kukeonv1.ContainerRootFSResult, error) {
+		t.Fatal("client must not be called for an invalid operand pair")
+		return kukeonv1.ContainerRootFSResult{}, nil
+	}}
+	for _, args := range [][]string{
+		{"/tmp/a", "./b"},
+		{"web:app:/a", "web:app:/b"},
+		{"./web:app:/a", "/tmp/b"},
+	} {
+		if err := runCp(t, fake, args); !errors.Is(err, errdefs.ErrCopyPathSpec) {
+			t.Errorf("args %v: err = %v, want ErrCopyPathSpec", args, err)
+		}
+	}
+}
+
+func TestCpCmd_TaskNotRunningPropagates(t *testing.T) {
+	fake := &fakeCpClient{resolveFn: func(v1beta1.ContainerDoc) (kukeonv1.ContainerRootFSResult, error) {
+		return kukeonv1.ContainerRootFSResult{}, errdefs.ErrTaskNotRunning
+	}}
+	err := runCp(t, fake, []string{"web:app:/etc/hosts", t.TempDir()})
+	if !errors.Is(err, errdefs.ErrTaskNotRunning) {
+		t.Fatalf("err = %v, want ErrTaskNotRunning", err)
+	}
+}
+
+// --- helpers ---
+
+func runCp(t *testing.T, fake *fakeCpClient, args []string) error {
+	t.Helper()
+	cmd := cp.NewCpCmd()
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+	ctx = context.WithValue(ctx, cp.MockControllerKey{}, cp.Client(fake))
+	cmd.SetContext(ctx)
+	cmd.SetArgs(args)
+	return cmd.Execute()
+}
+
+type fakeCpClient struct {
+	resolveFn func(doc v1beta1.ContainerDoc) (kukeonv1.ContainerRootFSResult, error)
+}
+
+func (f *fakeCpClient) ResolveContainerRootFS(
+	_ context.Context,
+	doc v1beta1.ContainerDoc,
+) (kukeonv1.ContainerRootFSResult, error) {
+	return f.resolveFn(doc)
+}
+
+func (f *fakeCpClient) Close() error { return nil }
@@ -24,6 +24,7 @@
 package blueprint
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"strconv"
@@ -56,7 +57,10 @@ Operator workflow:
   $EDITOR web.yaml          # fill image, add parameters/repos/secrets/...
   kuke apply -f web.yaml
 
-No daemon call — pure stdout emission.`,
+Pass --output-dir to write the scaffold to <dir>/cellblueprint-<name>.yaml
+instead of stdout (--force overwrites an existing file).
+
+No daemon call — pure scaffold emission.`,
 		Args:          cobra.MaximumNArgs(1),
 		SilenceUsage:  true,
 		SilenceErrors: false,
@@ -72,6 +76,8 @@ No daemon call — pure stdout emission.`,
 	cmd.Flags().String("stack", "", "Stack that owns the blueprint")
 	_ = viper.BindPFlag(config.KUKE_CREATE_BLUEPRINT_STACK.ViperKey, cmd.Flags().Lookup("stack"))
 
+	shared.RegisterScaffoldOutputFlags(cmd)
+
 	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
 	_ = cmd.RegisterFlagCompletionFunc("space", config.CompleteSpaceNames)
 	_ = cmd.RegisterFlagCompletionFunc("stack", config.CompleteStackNames)
@@ -103,7 +109,15 @@ func runCreateBlueprint(cmd *cobra.Command, args []string) error {
 		stack = strings.TrimSpace(config.KUKE_CREATE_BLUEPRINT_STACK.ValueOrDefault())
 	}
 
-	return emitBlueprintYAML(cmd.OutOrStdout(), name, realm, space, stack)
+	var buf bytes.Buffer
+	if err = emitBlueprintYAML(&buf, name, realm, space, stack); err != nil {
+		return err
+	}
+	return shared.WriteScaffolds(cmd, shared.ScaffoldDoc{
+		Kind: string(v1beta1.KindCellBlueprint),
+		Name: name,
+		YAML: buf.Bytes(),
+	})
 }
 
 // emitBlueprintYAML renders the CellBlueprint scaffold. The output is
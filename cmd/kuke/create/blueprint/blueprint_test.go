@@ -18,6 +18,8 @@ package blueprint_test
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -213,3 +215,24 @@ func TestNewBlueprintCmd_QuotesUnsafeName(t *testing.T) {
 		t.Errorf("metadata.name = %q, want %q", doc.CellBlueprintDoc.Metadata.Name, "name with spaces")
 	}
 }
+
+func TestNewBlueprintCmd_OutputDirWritesFile(t *testing.T) {
+	dir := t.TempDir()
+	out := runScaffold(t, []string{"web"}, map[string]string{"output-dir": dir})
+
+	path := filepath.Join(dir, "cellblueprint-web.yaml")
+	if !strings.Contains(out, "Wrote "+path) {
+		t.Fatalf("output = %q, want a Wrote line for %s", out, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read scaffold: %v", err)
+	}
+	doc, err := parser.ParseDocument(0, data)
+	if err != nil {
+		t.Fatalf("ParseDocument failed on written scaffold: %v\nYAML:\n%s", err, data)
+	}
+	if doc.CellBlueprintDoc == nil || doc.CellBlueprintDoc.Metadata.Name != "web" {
+		t.Fatalf("written scaffold is not CellBlueprint %q: %+v", "web", doc)
+	}
+}
@@ -19,8 +19,10 @@ package cell
 import (
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/eminwux/kukeon/cmd/config"
 	"github.com/eminwux/kukeon/cmd/kuke/create/shared"
@@ -132,6 +134,31 @@ func NewCellCmd() *cobra.Command {
 			"Only valid with --image.")
 	_ = viper.BindPFlag(config.KUKE_CREATE_CELL_COMMAND.ViperKey, cmd.Flags().Lookup("command"))
 
+	// --set/--set-string edit the materialised CellDoc just before it is
+	// persisted, whichever source produced it.
+	shared.RegisterSetFlags(cmd)
+
+	// --wait turns the stopped-by-default create into create + start, holding
+	// the reply until every container is running (fire-and-forget otherwise).
+	cmd.Flags().Bool("wait", false,
+		"Start the cell after persisting it and wait until every container is running; "+
+			"fails with a per-container diagnostic if any is not up within --wait-timeout")
+	_ = viper.BindPFlag(config.KUKE_CREATE_CELL_WAIT.ViperKey, cmd.Flags().Lookup("wait"))
+	cmd.Flags().Duration("wait-timeout", time.Minute,
+		"How long --wait waits for the containers (rounded up to whole seconds)")
+	_ = viper.BindPFlag(config.KUKE_CREATE_CELL_WAIT_TIMEOUT.ViperKey, cmd.Flags().Lookup("wait-timeout"))
+
+	// --wait-for-parent holds provisioning until the realm, space and stack
+	// are Ready, for a cell created right behind its stack.
+	cmd.Flags().Bool("wait-for-parent", false,
+		"Wait for the cell's realm, space and stack to become Ready instead of failing at once")
+	_ = viper.BindPFlag(config.KUKE_CREATE_CELL_WAIT_FOR_PARENT.ViperKey, cmd.Flags().Lookup("wait-for-parent"))
+	cmd.Flags().Duration("wait-for-parent-timeout", time.Minute,
+		"How long --wait-for-parent waits for the parents (rounded up to whole seconds)")
+	_ = viper.BindPFlag(
+		config.KUKE_CREATE_CELL_WAIT_FOR_PARENT_TIMEOUT.ViperKey, cmd.Flags().Lookup("wait-for-parent-timeout"),
+	)
+
 	// --image is a source: mutually exclusive with every from-* source (the
 	// trio's own mutex is registered in RegisterSourceFlags).
 	cmd.MarkFlagsMutuallyExclusive("image", "from-blueprint")
@@ -392,6 +419,11 @@ func runCreateCell(cmd *cobra.Command, args []string) error {
 		return errors.New("--command is only valid with --image")
 	}
 
+	waits, err := resolveCreateWaits(cmd)
+	if err != nil {
+		return err
+	}
+
 	client, err := resolveClient(cmd)
 	if err != nil {
 		return err
@@ -399,7 +431,7 @@ func runCreateCell(cmd *cobra.Command, args []string) error {
 	defer func() { _ = client.Close() }()
 
 	if image != "" {
-		return createFromImage(cmd, client, args, image, command)
+		return createFromImage(cmd, client, args, image, command, waits)
 	}
 
 	flags, err := parseCreateCellFlags(cmd, args)
@@ -417,7 +449,10 @@ func runCreateCell(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	return materialiseAndPersist(cmd, client, cellDoc)
+	if err = shared.ApplySetFlags(cmd, &cellDoc); err != nil {
+		return err
+	}
+	return materialiseAndPersist(cmd, client, cellDoc, waits)
 }
 
 // createFromImage implements the imperative `--image <ref>` source for
@@ -435,7 +470,7 @@ func runCreateCell(cmd *cobra.Command, args []string) error {
 // synthesized single-image cell carries no binding to parameterise or layer
 // env onto — edit a Blueprint/Config for that).
 func createFromImage(
-	cmd *cobra.Command, client kukeonv1.Client, args []string, image, command string,
+	cmd *cobra.Command, client kukeonv1.Client, args []string, image, command string, waits createWaits,
 ) error {
 	if err := rejectBindingKnobsWithImage(cmd); err != nil {
 		return err
@@ -459,7 +494,10 @@ func createFromImage(
 	if err = finalizeCellName(cmd, client, &cellDoc, flags.Name, ImageNamePrefix(image)); err != nil {
 		return err
 	}
-	return materialiseAndPersist(cmd, client, cellDoc)
+	if err = shared.ApplySetFlags(cmd, &cellDoc); err != nil {
+		return err
+	}
+	return materialiseAndPersist(cmd, client, cellDoc, waits)
 }
 
 // rejectBindingKnobsWithImage rejects the binding render-time/override knobs
@@ -664,12 +702,60 @@ func finalizeCellName(
 	return nil
 }
 
+// createWaits carries the two opt-in waits of `kuke create cell`, each in
+// whole seconds (0 = off): ready is --wait (start the cell and wait for its
+// containers), parent is --wait-for-parent (wait for the parent chain to
+// turn Ready before provisioning).
+type createWaits struct {
+	ready  int
+	parent int
+}
+
+// resolveCreateWaits validates --wait/--wait-timeout and
+// --wait-for-parent/--wait-for-parent-timeout.
+func resolveCreateWaits(cmd *cobra.Command) (createWaits, error) {
+	var waits createWaits
+	var err error
+	if waits.ready, err = resolveWait(cmd,
+		"wait", config.KUKE_CREATE_CELL_WAIT, config.KUKE_CREATE_CELL_WAIT_TIMEOUT,
+	); err != nil {
+		return createWaits{}, err
+	}
+	if waits.parent, err = resolveWait(cmd,
+		"wait-for-parent", config.KUKE_CREATE_CELL_WAIT_FOR_PARENT, config.KUKE_CREATE_CELL_WAIT_FOR_PARENT_TIMEOUT,
+	); err != nil {
+		return createWaits{}, err
+	}
+	return waits, nil
+}
+
+// resolveWait validates a --<flag>/--<flag>-timeout pair and returns the
+// timeout in whole seconds (rounded up), or 0 when the wait is off.
+func resolveWait(cmd *cobra.Command, flag string, enabled, timeoutVar config.Var) (int, error) {
+	if !viper.GetBool(enabled.ViperKey) {
+		if cmd.Flags().Changed(flag + "-timeout") {
+			return 0, fmt.Errorf("--%s-timeout is only valid with --%s", flag, flag)
+		}
+		return 0, nil
+	}
+	timeout := viper.GetDuration(timeoutVar.ViperKey)
+	if timeout <= 0 {
+		return 0, fmt.Errorf("--%s-timeout must be positive, got %s", flag, timeout)
+	}
+	return int(math.Ceil(timeout.Seconds())), nil
+}
+
 // materialiseAndPersist runs the existence pre-check and persists the
 // materialised cell via MaterializeCell. Refuses if a cell with the same name
 // already lives at the target scope — silent attach-to-existing would mask the
 // spec divergence between the operator's chosen Blueprint/Config and whatever
-// the existing cell was materialised from.
-func materialiseAndPersist(cmd *cobra.Command, client kukeonv1.Client, cellDoc v1beta1.CellDoc) error {
+// the existing cell was materialised from. A positive waits.parent
+// (--wait-for-parent) lets the daemon wait for the parent chain first; a
+// positive waits.ready (--wait) then starts the cell and waits for its
+// containers, while 0 leaves it stopped.
+func materialiseAndPersist(
+	cmd *cobra.Command, client kukeonv1.Client, cellDoc v1beta1.CellDoc, waits createWaits,
+) error {
 	pre, err := client.GetCell(cmd.Context(), cellDoc)
 	switch {
 	case err == nil && pre.MetadataExists:
@@ -685,11 +771,34 @@ func materialiseAndPersist(cmd *cobra.Command, client kukeonv1.Client, cellDoc v
 		return err
 	}
 
+	cellDoc.Spec.WaitForParentSeconds = waits.parent
 	result, err := client.MaterializeCell(cmd.Context(), cellDoc)
 	if err != nil {
 		return err
 	}
+	if waits.ready <= 0 {
+		printCellResult(cmd, result)
+		return nil
+	}
+
+	startErr := startAndWaitReady(cmd, client, result.Cell, waits.ready)
+	result.Started = startErr == nil
 	printCellResult(cmd, result)
+	if startErr != nil {
+		return startErr
+	}
+	cmd.Println("  - containers: ready")
+	return nil
+}
+
+// startAndWaitReady starts the just-persisted cell with
+// Spec.WaitReadySeconds set, so the daemon's StartCell only replies once
+// every container task is running (or fails naming the ones that are not).
+func startAndWaitReady(cmd *cobra.Command, client kukeonv1.Client, cellDoc v1beta1.CellDoc, waitSeconds int) error {
+	cellDoc.Spec.WaitReadySeconds = waitSeconds
+	if _, err := client.StartCell(cmd.Context(), cellDoc); err != nil {
+		return fmt.Errorf("cell %q was created but did not become ready: %w", cellDoc.Metadata.Name, err)
+	}
 	return nil
 }
 
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"strings"
@@ -458,6 +459,14 @@ type fakeClient struct {
 	getCellFn         func(doc v1beta1.CellDoc) (kukeonv1.GetCellResult, error)
 	getBlueprintFn    func(doc v1beta1.CellBlueprintDoc) (kukeonv1.GetBlueprintResult, error)
 	getConfigFn       func(doc v1beta1.CellConfigDoc) (kukeonv1.GetConfigResult, error)
+	startCellFn       func(doc v1beta1.CellDoc) (kukeonv1.StartCellResult, error)
+}
+
+func (f *fakeClient) StartCell(_ context.Context, doc v1beta1.CellDoc) (kukeonv1.StartCellResult, error) {
+	if f.startCellFn == nil {
+		return kukeonv1.StartCellResult{}, errors.New("unexpected StartCell call")
+	}
+	return f.startCellFn(doc)
 }
 
 func (f *fakeClient) CreateCell(_ context.Context, doc v1beta1.CellDoc) (kukeonv1.CreateCellResult, error) {
@@ -1318,3 +1327,168 @@ func countEnvKey(env []string, key string) int {
 	}
 	return n
 }
+
+// TestCreateCell_Wait_StartsWithReadinessTimeout covers the --wait path: the
+// persisted cell is started with the rounded-up timeout on the transport-only
+// Spec.WaitReadySeconds, and the output reports it ready.
+func TestCreateCell_Wait_StartsWithReadinessTimeout(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	var startDoc *v1beta1.CellDoc
+	fc := &fakeClient{
+		materializeCellFn: func(doc v1beta1.CellDoc) (kukeonv1.CreateCellResult, error) {
+			return successResultFromDoc(doc), nil
+		},
+		startCellFn: func(doc v1beta1.CellDoc) (kukeonv1.StartCellResult, error) {
+			startDoc = &doc
+			return kukeonv1.StartCellResult{Cell: doc, Started: true}, nil
+		},
+	}
+
+	cmd, out := newTestExecCmd(t, fc)
+	setFlag(t, cmd, "image", "docker.io/library/alpine:3")
+	setFlag(t, cmd, "wait", "true")
+	setFlag(t, cmd, "wait-timeout", "1500ms")
+	cmd.SetArgs([]string{"ready-1"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if startDoc == nil {
+		t.Fatal("StartCell was not called with --wait")
+	}
+	if startDoc.Metadata.Name != "ready-1" {
+		t.Errorf("started cell=%q want ready-1", startDoc.Metadata.Name)
+	}
+	if startDoc.Spec.WaitReadySeconds != 2 {
+		t.Errorf("WaitReadySeconds=%d want 2 (1500ms rounded up)", startDoc.Spec.WaitReadySeconds)
+	}
+	for _, want := range []string{"containers: started", "containers: ready"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q; got:\n%s", want, out.String())
+		}
+	}
+}
+
+// TestCreateCell_Wait_SurfacesNotReady checks a readiness failure from the
+// daemon fails the command, naming the cell, after printing what was created.
+func TestCreateCell_Wait_SurfacesNotReady(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	fc := &fakeClient{
+		materializeCellFn: func(doc v1beta1.CellDoc) (kukeonv1.CreateCellResult, error) {
+			return successResultFromDoc(doc), nil
+		},
+		startCellFn: func(v1beta1.CellDoc) (kukeonv1.StartCellResult, error) {
+			return kukeonv1.StartCellResult{}, fmt.Errorf(
+				"%w: cell %q: not ready after 1s: container %q (task status=created)",
+				errdefs.ErrCellNotReady, "slow", "main")
+		},
+	}
+
+	cmd, out := newTestExecCmd(t, fc)
+	setFlag(t, cmd, "image", "docker.io/library/alpine:3")
+	setFlag(t, cmd, "wait", "true")
+	cmd.SetArgs([]string{"slow"})
+
+	err := cmd.Execute()
+	if !errors.Is(err, errdefs.ErrCellNotReady) {
+		t.Fatalf("err=%v want ErrCellNotReady", err)
+	}
+	if !strings.Contains(err.Error(), `cell "slow" was created but did not become ready`) {
+		t.Errorf("err=%q does not name the created cell", err)
+	}
+	if !strings.Contains(out.String(), "containers: not started") {
+		t.Errorf("expected the creation outcome before the failure; got:\n%s", out.String())
+	}
+}
+
+// TestCreateCell_NoWait_LeavesCellStopped pins the fire-and-forget default:
+// without --wait, create never calls StartCell.
+func TestCreateCell_NoWait_LeavesCellStopped(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	fc := &fakeClient{
+		materializeCellFn: func(doc v1beta1.CellDoc) (kukeonv1.CreateCellResult, error) {
+			if doc.Spec.WaitReadySeconds != 0 {
+				t.Errorf("WaitReadySeconds=%d on the persisted doc, want 0", doc.Spec.WaitReadySeconds)
+			}
+			return successResultFromDoc(doc), nil
+		},
+		startCellFn: func(v1beta1.CellDoc) (kukeonv1.StartCellResult, error) {
+			t.Fatal("StartCell must not be called without --wait")
+			return kukeonv1.StartCellResult{}, nil
+		},
+	}
+
+	cmd, _ := newTestExecCmd(t, fc)
+	setFlag(t, cmd, "image", "docker.io/library/alpine:3")
+	cmd.SetArgs([]string{"idle"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+}
+
+func TestCreateCell_WaitTimeoutRequiresWait(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	cmd, _ := newTestExecCmd(t, &fakeClient{})
+	setFlag(t, cmd, "image", "docker.io/library/alpine:3")
+	setFlag(t, cmd, "wait-timeout", "5s")
+	cmd.SetArgs([]string{"idle"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--wait-timeout is only valid with --wait") {
+		t.Fatalf("err=%v want --wait-timeout rejection", err)
+	}
+}
+
+// TestCreateCell_WaitForParent_StampsTimeout covers --wait-for-parent: the
+// rounded-up timeout rides the transport-only Spec.WaitForParentSeconds on
+// the MaterializeCell request, and the cell is still left stopped.
+func TestCreateCell_WaitForParent_StampsTimeout(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	var persisted *v1beta1.CellDoc
+	fc := &fakeClient{
+		materializeCellFn: func(doc v1beta1.CellDoc) (kukeonv1.CreateCellResult, error) {
+			persisted = &doc
+			return successResultFromDoc(doc), nil
+		},
+		startCellFn: func(v1beta1.CellDoc) (kukeonv1.StartCellResult, error) {
+			t.Fatal("StartCell must not be called without --wait")
+			return kukeonv1.StartCellResult{}, nil
+		},
+	}
+
+	cmd, _ := newTestExecCmd(t, fc)
+	setFlag(t, cmd, "image", "docker.io/library/alpine:3")
+	setFlag(t, cmd, "wait-for-parent", "true")
+	setFlag(t, cmd, "wait-for-parent-timeout", "2500ms")
+	cmd.SetArgs([]string{"late"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if persisted == nil {
+		t.Fatal("MaterializeCell was not called")
+	}
+	if persisted.Spec.WaitForParentSeconds != 3 {
+		t.Errorf("WaitForParentSeconds=%d want 3 (2500ms rounded up)", persisted.Spec.WaitForParentSeconds)
+	}
+}
+
+func TestCreateCell_WaitForParentTimeoutRequiresFlag(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	cmd, _ := newTestExecCmd(t, &fakeClient{})
+	setFlag(t, cmd, "image", "docker.io/library/alpine:3")
+	setFlag(t, cmd, "wait-for-parent-timeout", "5s")
+	cmd.SetArgs([]string{"idle"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--wait-for-parent-timeout is only valid with --wait-for-parent") {
+		t.Fatalf("err=%v want --wait-for-parent-timeout rejection", err)
+	}
+}
@@ -24,6 +24,7 @@
 package config
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -56,7 +57,10 @@ Reads the referenced Blueprint from the daemon, introspects its declared scalar
 parameters and structural repo/secret slots, and emits a starter Config YAML to
 stdout with defaults pre-filled and TODO markers where the operator must fill
 required-no-default parameters and slot sources. The output is not written to
-the daemon — pipe it to ` + "`kuke apply -f -`" + ` after editing.`,
+the daemon — pipe it to ` + "`kuke apply -f -`" + ` after editing.
+
+Pass --output-dir to write the scaffold to <dir>/cellconfig-<name>.yaml
+instead of stdout (--force overwrites an existing file).`,
 		Args:          cobra.MaximumNArgs(1),
 		SilenceUsage:  true,
 		SilenceErrors: false,
@@ -75,6 +79,8 @@ the daemon — pipe it to ` + "`kuke apply -f -`" + ` after editing.`,
 	cmd.Flags().String("from-blueprint", "", "Source CellBlueprint name (required)")
 	_ = viper.BindPFlag(config.KUKE_CREATE_CONFIG_BLUEPRINT.ViperKey, cmd.Flags().Lookup("from-blueprint"))
 
+	shared.RegisterScaffoldOutputFlags(cmd)
+
 	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
 	_ = cmd.RegisterFlagCompletionFunc("space", config.CompleteSpaceNames)
 	_ = cmd.RegisterFlagCompletionFunc("stack", config.CompleteStackNames)
@@ -140,7 +146,15 @@ func runCreateConfig(cmd *cobra.Command, args []string) error {
 		return notFoundError(blueprintName, realm, space, stack)
 	}
 
-	return emitConfigYAML(cmd.OutOrStdout(), name, realm, space, stack, &res.Blueprint)
+	var buf bytes.Buffer
+	if err = emitConfigYAML(&buf, name, realm, space, stack, &res.Blueprint); err != nil {
+		return err
+	}
+	return shared.WriteScaffolds(cmd, shared.ScaffoldDoc{
+		Kind: string(v1beta1.KindCellConfig),
+		Name: name,
+		YAML: buf.Bytes(),
+	})
 }
 
 func notFoundError(name, realm, space, stack string) error {
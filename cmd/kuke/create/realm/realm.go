@@ -57,6 +57,9 @@ func NewRealmCmd() *cobra.Command {
 				Metadata: v1beta1.RealmMetadata{Name: name},
 				Spec:     v1beta1.RealmSpec{Namespace: namespace},
 			}
+			if err = shared.ApplySetFlags(cmd, &doc); err != nil {
+				return err
+			}
 
 			client, err := resolveClient(cmd)
 			if err != nil {
@@ -75,6 +78,7 @@ func NewRealmCmd() *cobra.Command {
 	}
 
 	cmd.Flags().String("namespace", "", "Containerd namespace for the realm (defaults to the realm name)")
+	shared.RegisterSetFlags(cmd)
 
 	return cmd
 }
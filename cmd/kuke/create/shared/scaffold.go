@@ -0,0 +1,143 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/spf13/cobra"
+)
+
+// ScaffoldBundleFile is the file --bundle writes every document into.
+const ScaffoldBundleFile = "bundle.yaml"
+
+const (
+	scaffoldDirMode  = 0o755
+	scaffoldFileMode = 0o644
+)
+
+// ScaffoldDoc is one YAML document a scaffolding create command generated.
+// Kind and Name name the file it is written to under --output-dir.
+type ScaffoldDoc struct {
+	Kind string
+	Name string
+	YAML []byte
+}
+
+// FileName returns the per-document file name, <kind>-<name>.yaml, with the
+// kind lowercased (e.g. cellblueprint-web.yaml).
+func (d ScaffoldDoc) FileName() string {
+	return strings.ToLower(d.Kind) + "-" + d.Name + ".yaml"
+}
+
+// RegisterScaffoldOutputFlags adds --output-dir, --force, and --bundle to a
+// create command that emits YAML instead of calling the daemon.
+func RegisterScaffoldOutputFlags(cmd *cobra.Command) {
+	cmd.Flags().String("output-dir", "",
+		"Write each generated document to <dir>/<kind>-<name>.yaml instead of stdout (the directory is created)")
+	cmd.Flags().Bool("force", false, "With --output-dir, overwrite files that already exist")
+	cmd.Flags().Bool("bundle", false,
+		"With --output-dir, write all generated documents to <dir>/"+ScaffoldBundleFile+" instead of one file each")
+}
+
+// WriteScaffolds emits docs the way the scaffold output flags on cmd ask.
+// Without --output-dir the documents go to stdout, separated by `---`.
+// With it, each document is written to its own file, or all of them to one
+// bundle file with --bundle. Every target is checked before anything is
+// written, so an existing file without --force leaves the directory
+// untouched.
+func WriteScaffolds(cmd *cobra.Command, docs ...ScaffoldDoc) error {
+	dir, err := cmd.Flags().GetString("output-dir")
+	if err != nil {
+		return err
+	}
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		return err
+	}
+	bundle, err := cmd.Flags().GetBool("bundle")
+	if err != nil {
+		return err
+	}
+
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		if bundle || force {
+			return errors.New("--bundle and --force require --output-dir")
+		}
+		return writeYAMLStream(cmd.OutOrStdout(), docs)
+	}
+
+	type target struct {
+		path string
+		docs []ScaffoldDoc
+	}
+	var targets []target
+	if bundle {
+		targets = []target{{path: filepath.Join(dir, ScaffoldBundleFile), docs: docs}}
+	} else {
+		for _, doc := range docs {
+			targets = append(targets, target{path: filepath.Join(dir, doc.FileName()), docs: []ScaffoldDoc{doc}})
+		}
+	}
+
+	if !force {
+		for _, t := range targets {
+			if _, statErr := os.Stat(t.path); statErr == nil {
+				return fmt.Errorf("%w: %s (use --force to overwrite)", errdefs.ErrScaffoldFileExists, t.path)
+			} else if !errors.Is(statErr, os.ErrNotExist) {
+				return statErr
+			}
+		}
+	}
+	if err = os.MkdirAll(dir, scaffoldDirMode); err != nil {
+		return fmt.Errorf("create output directory %s: %w", dir, err)
+	}
+	for _, t := range targets {
+		var buf bytes.Buffer
+		if err = writeYAMLStream(&buf, t.docs); err != nil {
+			return err
+		}
+		if err = os.WriteFile(t.path, buf.Bytes(), scaffoldFileMode); err != nil {
+			return fmt.Errorf("write %s: %w", t.path, err)
+		}
+		cmd.Printf("Wrote %s\n", t.path)
+	}
+	return nil
+}
+
+// writeYAMLStream writes docs as one multi-document YAML stream.
+func writeYAMLStream(out io.Writer, docs []ScaffoldDoc) error {
+	for i, doc := range docs {
+		if i > 0 {
+			if _, err := io.WriteString(out, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := out.Write(doc.YAML); err != nil {
+			return err
+		}
+	}
+	return nil
+}
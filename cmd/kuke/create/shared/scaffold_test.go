@@ -0,0 +1,171 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sharedpkg "github.com/eminwux/kukeon/cmd/kuke/create/shared"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/spf13/cobra"
+)
+
+var scaffoldTestDocs = []sharedpkg.ScaffoldDoc{
+	{Kind: "CellBlueprint", Name: "web", YAML: []byte("kind: CellBlueprint\nmetadata:\n  name: web\n")},
+	{Kind: "CellConfig", Name: "web-prod", YAML: []byte("kind: CellConfig\nmetadata:\n  name: web-prod\n")},
+}
+
+// runWriteScaffolds builds a throwaway command carrying the scaffold output
+// flags, applies flags, and runs WriteScaffolds against docs.
+func runWriteScaffolds(
+	t *testing.T,
+	flags map[string]string,
+	docs ...sharedpkg.ScaffoldDoc,
+) (string, error) {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test"}
+	sharedpkg.RegisterScaffoldOutputFlags(cmd)
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	for k, v := range flags {
+		if err := cmd.Flags().Set(k, v); err != nil {
+			t.Fatalf("set flag %s=%s: %v", k, v, err)
+		}
+	}
+	err := sharedpkg.WriteScaffolds(cmd, docs...)
+	return buf.String(), err
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	return string(data)
+}
+
+func TestWriteScaffolds_StdoutWithoutOutputDir(t *testing.T) {
+	out, err := runWriteScaffolds(t, nil, scaffoldTestDocs...)
+	if err != nil {
+		t.Fatalf("WriteScaffolds: %v", err)
+	}
+	want := string(scaffoldTestDocs[0].YAML) + "---\n" + string(scaffoldTestDocs[1].YAML)
+	if out != want {
+		t.Fatalf("stdout = %q, want %q", out, want)
+	}
+}
+
+func TestWriteScaffolds_OneFilePerDoc(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "nested", "out")
+	out, err := runWriteScaffolds(t, map[string]string{"output-dir": dir}, scaffoldTestDocs...)
+	if err != nil {
+		t.Fatalf("WriteScaffolds: %v", err)
+	}
+
+	for _, tc := range []struct {
+		file string
+		want []byte
+	}{
+		{file: "cellblueprint-web.yaml", want: scaffoldTestDocs[0].YAML},
+		{file: "cellconfig-web-prod.yaml", want: scaffoldTestDocs[1].YAML},
+	} {
+		path := filepath.Join(dir, tc.file)
+		if got := readFile(t, path); got != string(tc.want) {
+			t.Errorf("%s = %q, want %q", tc.file, got, tc.want)
+		}
+		if !strings.Contains(out, "Wrote "+path) {
+			t.Errorf("output missing %q:\n%s", "Wrote "+path, out)
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	if len(entries) != len(scaffoldTestDocs) {
+		t.Errorf("dir has %d entries, want %d", len(entries), len(scaffoldTestDocs))
+	}
+}
+
+func TestWriteScaffolds_Bundle(t *testing.T) {
+	dir := t.TempDir()
+	_, err := runWriteScaffolds(t, map[string]string{"output-dir": dir, "bundle": "true"}, scaffoldTestDocs...)
+	if err != nil {
+		t.Fatalf("WriteScaffolds: %v", err)
+	}
+
+	want := string(scaffoldTestDocs[0].YAML) + "---\n" + string(scaffoldTestDocs[1].YAML)
+	if got := readFile(t, filepath.Join(dir, sharedpkg.ScaffoldBundleFile)); got != want {
+		t.Errorf("bundle = %q, want %q", got, want)
+	}
+	if _, statErr := os.Stat(filepath.Join(dir, "cellblueprint-web.yaml")); !errors.Is(statErr, os.ErrNotExist) {
+		t.Errorf("per-doc file written alongside bundle (stat err = %v)", statErr)
+	}
+}
+
+func TestWriteScaffolds_RefusesOverwriteWithoutForce(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "cellconfig-web-prod.yaml")
+	if err := os.WriteFile(existing, []byte("keep me\n"), 0o600); err != nil {
+		t.Fatalf("seed file: %v", err)
+	}
+
+	_, err := runWriteScaffolds(t, map[string]string{"output-dir": dir}, scaffoldTestDocs...)
+	if !errors.Is(err, errdefs.ErrScaffoldFileExists) {
+		t.Fatalf("err = %v, want ErrScaffoldFileExists", err)
+	}
+	if got := readFile(t, existing); got != "keep me\n" {
+		t.Errorf("existing file clobbered: %q", got)
+	}
+	// The check runs before any write, so the non-colliding doc is not
+	// written either.
+	if _, statErr := os.Stat(filepath.Join(dir, "cellblueprint-web.yaml")); !errors.Is(statErr, os.ErrNotExist) {
+		t.Errorf("partial write on collision (stat err = %v)", statErr)
+	}
+}
+
+func TestWriteScaffolds_ForceOverwrites(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "cellblueprint-web.yaml")
+	if err := os.WriteFile(existing, []byte("stale\n"), 0o600); err != nil {
+		t.Fatalf("seed file: %v", err)
+	}
+
+	_, err := runWriteScaffolds(t, map[string]string{"output-dir": dir, "force": "true"}, scaffoldTestDocs[0])
+	if err != nil {
+		t.Fatalf("WriteScaffolds: %v", err)
+	}
+	if got := readFile(t, existing); got != string(scaffoldTestDocs[0].YAML) {
+		t.Errorf("file = %q, want overwritten scaffold", got)
+	}
+}
+
+func TestWriteScaffolds_BundleRequiresOutputDir(t *testing.T) {
+	out, err := runWriteScaffolds(t, map[string]string{"bundle": "true"}, scaffoldTestDocs...)
+	if err == nil {
+		t.Fatalf("expected error for --bundle without --output-dir, got nil (output %q)", out)
+	}
+	if out != "" {
+		t.Errorf("stdout = %q, want nothing emitted on error", out)
+	}
+}
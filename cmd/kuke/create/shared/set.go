@@ -0,0 +1,277 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// RegisterSetFlags adds the repeatable --set and --set-string flags that
+// edit the document a create command builds before it is sent to the daemon.
+func RegisterSetFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArray("set", nil,
+		"Set a field of the created document: path=value, e.g. metadata.labels.tier=web or "+
+			"spec.containers[0].image=nginx (repeatable). true/false and integers are typed; "+
+			"everything else is a string")
+	cmd.Flags().StringArray("set-string", nil,
+		"Like --set, but the value is always a string (repeatable)")
+}
+
+// ApplySetFlags applies every --set and then every --set-string on cmd to
+// doc. It is a no-op when neither flag was given.
+func ApplySetFlags[T any](cmd *cobra.Command, doc *T) error {
+	sets, err := cmd.Flags().GetStringArray("set")
+	if err != nil {
+		return err
+	}
+	setStrings, err := cmd.Flags().GetStringArray("set-string")
+	if err != nil {
+		return err
+	}
+	return ApplySets(doc, sets, setStrings)
+}
+
+// ApplySets edits doc through its JSON form: doc is encoded to a generic
+// map, each path=value assignment is written into it, and the map is decoded
+// back into a fresh T. Decoding rejects paths that name no field of T and
+// values whose type does not fit the field, so a typo fails instead of being
+// dropped. Values in sets are type-inferred (see inferSetValue); values in
+// setStrings are always strings.
+func ApplySets[T any](doc *T, sets, setStrings []string) error {
+	if len(sets) == 0 && len(setStrings) == 0 {
+		return nil
+	}
+
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to encode document: %w", err)
+	}
+	var tree map[string]any
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err = dec.Decode(&tree); err != nil {
+		return fmt.Errorf("failed to decode document: %w", err)
+	}
+
+	for _, arg := range sets {
+		if err = applySetArg(tree, "--set", arg, false); err != nil {
+			return err
+		}
+	}
+	for _, arg := range setStrings {
+		if err = applySetArg(tree, "--set-string", arg, true); err != nil {
+			return err
+		}
+	}
+
+	if raw, err = json.Marshal(tree); err != nil {
+		return fmt.Errorf("failed to encode document: %w", err)
+	}
+	var out T
+	dec = json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&out); err != nil {
+		return fmt.Errorf("invalid --set: %w", err)
+	}
+	*doc = out
+	return nil
+}
+
+func applySetArg(tree map[string]any, flag, arg string, forceString bool) error {
+	path, raw, ok := strings.Cut(arg, "=")
+	if !ok {
+		return fmt.Errorf("invalid %s %q: expected path=value", flag, arg)
+	}
+	segments, err := parseSetPath(strings.TrimSpace(path))
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", flag, arg, err)
+	}
+	var value any = raw
+	if !forceString {
+		value = inferSetValue(raw)
+	}
+	if _, err = setPathValue(tree, segments, value, ""); err != nil {
+		return fmt.Errorf("invalid %s %q: %w", flag, arg, err)
+	}
+	return nil
+}
+
+// inferSetValue types a --set value: true and false become booleans, base-10
+// integers become numbers, and anything else stays a string.
+func inferSetValue(raw string) any {
+	switch raw {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return n
+	}
+	return raw
+}
+
+// setPathSegment is one step of a --set path: a map key, or a list index
+// when index is not negative.
+type setPathSegment struct {
+	key   string
+	index int
+}
+
+// parseSetPath splits a dotted --set path such as spec.containers[0].image
+// into segments. A backslash escapes the next character, so a key holding a
+// dot (a label like kukeon.io/team) is written metadata.labels.kukeon\.io/team.
+func parseSetPath(path string) ([]setPathSegment, error) {
+	if path == "" {
+		return nil, errors.New("empty path")
+	}
+	var (
+		segments   []setPathSegment
+		key        strings.Builder
+		hasKey     bool
+		afterIndex bool
+	)
+	flushKey := func() error {
+		if !hasKey {
+			return fmt.Errorf("empty key in path %q", path)
+		}
+		segments = append(segments, setPathSegment{key: key.String(), index: -1})
+		key.Reset()
+		hasKey = false
+		return nil
+	}
+
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; c {
+		case '\\':
+			if i+1 == len(path) {
+				return nil, fmt.Errorf("trailing escape in path %q", path)
+			}
+			i++
+			key.WriteByte(path[i])
+			hasKey = true
+		case '.':
+			// The dot in "[0].image" only separates the index from the key.
+			if afterIndex {
+				afterIndex = false
+				continue
+			}
+			if err := flushKey(); err != nil {
+				return nil, err
+			}
+		case '[':
+			if hasKey {
+				if err := flushKey(); err != nil {
+					return nil, err
+				}
+			} else if !afterIndex {
+				return nil, fmt.Errorf("list index without a key in path %q", path)
+			}
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated list index in path %q", path)
+			}
+			index, err := strconv.Atoi(path[i+1 : i+end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid list index %q in path %q", path[i+1:i+end], path)
+			}
+			segments = append(segments, setPathSegment{index: index})
+			i += end
+			afterIndex = true
+			if next := i + 1; next < len(path) && path[next] != '.' && path[next] != '[' {
+				return nil, fmt.Errorf("expected '.' or '[' after list index in path %q", path)
+			}
+		default:
+			key.WriteByte(c)
+			hasKey = true
+		}
+	}
+	if hasKey || !afterIndex {
+		if err := flushKey(); err != nil {
+			return nil, err
+		}
+	}
+	return segments, nil
+}
+
+// setPathValue writes value at segments below node, creating the maps and
+// lists on the way, and returns the updated node. A list index may address
+// an existing item or append one at the end of the list; anything further
+// out is an error rather than padding the list with empty items.
+func setPathValue(node any, segments []setPathSegment, value any, at string) (any, error) {
+	if len(segments) == 0 {
+		return value, nil
+	}
+	seg := segments[0]
+
+	if seg.index < 0 {
+		m, ok := node.(map[string]any)
+		if node == nil {
+			m, ok = map[string]any{}, true
+		}
+		if !ok {
+			return nil, fmt.Errorf("%s is not an object", describeSetPath(at))
+		}
+		child, err := setPathValue(m[seg.key], segments[1:], value, joinSetPath(at, seg.key))
+		if err != nil {
+			return nil, err
+		}
+		m[seg.key] = child
+		return m, nil
+	}
+
+	list, ok := node.([]any)
+	if node == nil {
+		ok = true
+	}
+	if !ok {
+		return nil, fmt.Errorf("%s is not a list", describeSetPath(at))
+	}
+	if seg.index > len(list) {
+		return nil, fmt.Errorf("index %d is out of range for %s (%d items)", seg.index, describeSetPath(at), len(list))
+	}
+	if seg.index == len(list) {
+		list = append(list, nil)
+	}
+	child, err := setPathValue(list[seg.index], segments[1:], value, fmt.Sprintf("%s[%d]", at, seg.index))
+	if err != nil {
+		return nil, err
+	}
+	list[seg.index] = child
+	return list, nil
+}
+
+func joinSetPath(at, key string) string {
+	if at == "" {
+		return key
+	}
+	return at + "." + key
+}
+
+func describeSetPath(at string) string {
+	if at == "" {
+		return "the document"
+	}
+	return at
+}
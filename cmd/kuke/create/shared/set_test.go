@@ -0,0 +1,168 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared_test
+
+import (
+	"strings"
+	"testing"
+
+	sharedpkg "github.com/eminwux/kukeon/cmd/kuke/create/shared"
+	"github.com/spf13/cobra"
+)
+
+type setTestContainer struct {
+	Image      string   `json:"image,omitempty"`
+	Privileged bool     `json:"privileged,omitempty"`
+	Args       []string `json:"args,omitempty"`
+}
+
+type setTestDoc struct {
+	Metadata struct {
+		Name   string            `json:"name"`
+		Labels map[string]string `json:"labels,omitempty"`
+	} `json:"metadata"`
+	Spec struct {
+		Replicas   int                `json:"replicas,omitempty"`
+		Note       string             `json:"note,omitempty"`
+		Containers []setTestContainer `json:"containers,omitempty"`
+	} `json:"spec"`
+}
+
+func TestApplySets_NestedSet(t *testing.T) {
+	var doc setTestDoc
+	doc.Metadata.Name = "web"
+
+	err := sharedpkg.ApplySets(&doc, []string{
+		"metadata.labels.tier=frontend",
+		`metadata.labels.kukeon\.io/team=core`,
+	}, nil)
+	if err != nil {
+		t.Fatalf("ApplySets: %v", err)
+	}
+	if doc.Metadata.Name != "web" {
+		t.Errorf("name = %q, want untouched %q", doc.Metadata.Name, "web")
+	}
+	if got := doc.Metadata.Labels["tier"]; got != "frontend" {
+		t.Errorf("labels[tier] = %q, want %q", got, "frontend")
+	}
+	if got := doc.Metadata.Labels["kukeon.io/team"]; got != "core" {
+		t.Errorf("labels[kukeon.io/team] = %q, want %q", got, "core")
+	}
+}
+
+func TestApplySets_ListIndex(t *testing.T) {
+	var doc setTestDoc
+	doc.Spec.Containers = []setTestContainer{{Image: "busybox"}}
+
+	err := sharedpkg.ApplySets(&doc, []string{
+		"spec.containers[0].image=nginx:1.27",
+		"spec.containers[1].image=redis",
+		"spec.containers[1].args[0]=--appendonly",
+	}, nil)
+	if err != nil {
+		t.Fatalf("ApplySets: %v", err)
+	}
+	if len(doc.Spec.Containers) != 2 {
+		t.Fatalf("containers = %d, want 2", len(doc.Spec.Containers))
+	}
+	if got := doc.Spec.Containers[0].Image; got != "nginx:1.27" {
+		t.Errorf("containers[0].image = %q, want %q", got, "nginx:1.27")
+	}
+	if got := doc.Spec.Containers[1].Image; got != "redis" {
+		t.Errorf("containers[1].image = %q, want %q", got, "redis")
+	}
+	if got := doc.Spec.Containers[1].Args; len(got) != 1 || got[0] != "--appendonly" {
+		t.Errorf("containers[1].args = %v, want [--appendonly]", got)
+	}
+}
+
+func TestApplySets_TypeInference(t *testing.T) {
+	var doc setTestDoc
+	doc.Spec.Containers = []setTestContainer{{}}
+
+	err := sharedpkg.ApplySets(&doc, []string{
+		"spec.replicas=3",
+		"spec.containers[0].privileged=true",
+		"spec.note=hello",
+	}, []string{"metadata.labels.version=2"})
+	if err != nil {
+		t.Fatalf("ApplySets: %v", err)
+	}
+	if doc.Spec.Replicas != 3 {
+		t.Errorf("replicas = %d, want 3", doc.Spec.Replicas)
+	}
+	if !doc.Spec.Containers[0].Privileged {
+		t.Errorf("containers[0].privileged = false, want true")
+	}
+	if doc.Spec.Note != "hello" {
+		t.Errorf("note = %q, want %q", doc.Spec.Note, "hello")
+	}
+	if got := doc.Metadata.Labels["version"]; got != "2" {
+		t.Errorf("labels[version] = %q, want %q", got, "2")
+	}
+
+	// Without --set-string an integer does not fit a string field.
+	if err = sharedpkg.ApplySets(&doc, []string{"metadata.labels.version=2"}, nil); err == nil {
+		t.Fatal("expected a type error for an inferred integer in a string map")
+	}
+}
+
+func TestApplySets_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		sets    []string
+		wantErr string
+	}{
+		{name: "missing value", sets: []string{"spec.note"}, wantErr: "expected path=value"},
+		{name: "unknown field", sets: []string{"spec.nope=1"}, wantErr: "unknown field"},
+		{name: "index out of range", sets: []string{"spec.containers[2].image=x"}, wantErr: "out of range"},
+		{name: "bad index", sets: []string{"spec.containers[x].image=x"}, wantErr: "invalid list index"},
+		{name: "empty key", sets: []string{"spec..note=x"}, wantErr: "empty key"},
+		{name: "scalar as object", sets: []string{"spec.note=x", "spec.note.inner=y"}, wantErr: "not an object"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc setTestDoc
+			err := sharedpkg.ApplySets(&doc, tt.sets, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want substring %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplySetFlags(t *testing.T) {
+	cmd := &cobra.Command{Use: "test"}
+	sharedpkg.RegisterSetFlags(cmd)
+	if err := cmd.ParseFlags([]string{
+		"--set", "spec.replicas=2",
+		"--set-string", "spec.note=true",
+	}); err != nil {
+		t.Fatalf("ParseFlags: %v", err)
+	}
+
+	var doc setTestDoc
+	if err := sharedpkg.ApplySetFlags(cmd, &doc); err != nil {
+		t.Fatalf("ApplySetFlags: %v", err)
+	}
+	if doc.Spec.Replicas != 2 {
+		t.Errorf("replicas = %d, want 2", doc.Spec.Replicas)
+	}
+	if doc.Spec.Note != "true" {
+		t.Errorf("note = %q, want %q", doc.Spec.Note, "true")
+	}
+}
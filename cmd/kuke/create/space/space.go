@@ -60,6 +60,9 @@ func NewSpaceCmd() *cobra.Command {
 				Metadata: v1beta1.SpaceMetadata{Name: name},
 				Spec:     v1beta1.SpaceSpec{RealmID: realm},
 			}
+			if err = shared.ApplySetFlags(cmd, &doc); err != nil {
+				return err
+			}
 
 			client, err := resolveClient(cmd)
 			if err != nil {
@@ -80,6 +83,8 @@ func NewSpaceCmd() *cobra.Command {
 	cmd.Flags().String("realm", "", "Realm that will own the space")
 	_ = viper.BindPFlag(config.KUKE_CREATE_SPACE_REALM.ViperKey, cmd.Flags().Lookup("realm"))
 
+	shared.RegisterSetFlags(cmd)
+
 	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
 
 	return cmd
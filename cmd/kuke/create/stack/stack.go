@@ -48,6 +48,8 @@ func NewStackCmd() *cobra.Command {
 	cmd.Flags().String("space", "", "Space that owns the stack")
 	_ = viper.BindPFlag(config.KUKE_CREATE_STACK_SPACE.ViperKey, cmd.Flags().Lookup("space"))
 
+	shared.RegisterSetFlags(cmd)
+
 	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
 	_ = cmd.RegisterFlagCompletionFunc("space", config.CompleteSpaceNames)
 
@@ -83,6 +85,9 @@ func runCreateStack(cmd *cobra.Command, args []string) error {
 			SpaceID: space,
 		},
 	}
+	if err = shared.ApplySetFlags(cmd, &doc); err != nil {
+		return err
+	}
 
 	client, err := resolveClient(cmd)
 	if err != nil {
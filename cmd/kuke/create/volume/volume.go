@@ -64,6 +64,8 @@ func NewVolumeCmd() *cobra.Command {
 	cmd.Flags().String("stack", "", "Stack that owns the volume")
 	_ = viper.BindPFlag(config.KUKE_CREATE_VOLUME_STACK.ViperKey, cmd.Flags().Lookup("stack"))
 
+	shared.RegisterSetFlags(cmd)
+
 	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
 	_ = cmd.RegisterFlagCompletionFunc("space", config.CompleteSpaceNames)
 	_ = cmd.RegisterFlagCompletionFunc("stack", config.CompleteStackNames)
@@ -111,6 +113,9 @@ func runCreateVolume(cmd *cobra.Command, args []string) error {
 			Stack: stack,
 		},
 	}
+	if err = shared.ApplySetFlags(cmd, &doc); err != nil {
+		return err
+	}
 
 	result, err := client.CreateVolume(cmd.Context(), doc)
 	if err != nil {
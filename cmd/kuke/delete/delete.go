@@ -18,7 +18,6 @@ package deletecmd
 
 import (
 	"fmt"
-	"io"
 	"strings"
 
 	"github.com/eminwux/kukeon/cmd/config"
@@ -53,14 +52,14 @@ func NewDeleteCmd() *cobra.Command {
 		Short:   "Delete Kukeon resources (realm, space, stack, cell, secret, blueprint, volume, config)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			// Check if -f flag is provided
-			file, err := cmd.Flags().GetString("file")
+			files, err := cmd.Flags().GetStringArray("file")
 			if err != nil {
 				return err
 			}
 
-			if file != "" {
+			if len(files) > 0 {
 				// Handle file-based deletion
-				return handleFileDeletion(cmd, file)
+				return handleFileDeletion(cmd, files)
 			}
 
 			// Default behavior: show help
@@ -72,7 +71,10 @@ func NewDeleteCmd() *cobra.Command {
 	cmd.ValidArgsFunction = completeDeleteSubcommands
 
 	// Add -f, --file flag for file-based deletion
-	cmd.Flags().StringP("file", "f", "", "File to read YAML from (use - for stdin)")
+	cmd.Flags().StringArrayP("file", "f", nil,
+		"File, directory, or glob to read YAML from (use - for stdin); repeatable")
+	cmd.Flags().Bool("ignore-not-found", true,
+		"Treat resources that do not exist as already deleted; false makes them an error")
 
 	// Add --output flag for output format
 	cmd.Flags().StringP("output", "o", "", "Output format: json, yaml (default: human-readable)")
@@ -123,22 +125,23 @@ func completeDeleteSubcommands(_ *cobra.Command, _ []string, toComplete string)
 // in-process `controller.Exec` shortcut was a #574-class bug: it bypassed
 // `--host`/`KUKEON_HOST` and read /opt/kukeon directly even when a daemon
 // was managing a different run path.
-func handleFileDeletion(cmd *cobra.Command, file string) error {
+//
+// The daemon deletes the documents in reverse hierarchy order, so a manifest
+// or directory that `kuke apply -f` accepted deletes children first.
+func handleFileDeletion(cmd *cobra.Command, files []string) error {
 	output, err := cmd.Flags().GetString("output")
 	if err != nil {
 		return err
 	}
-
-	// Read raw YAML; the client (local or RPC) owns parse/validate.
-	reader, cleanup, err := kukshared.ReadFileOrStdin(file)
+	ignoreNotFound, err := cmd.Flags().GetBool("ignore-not-found")
 	if err != nil {
 		return err
 	}
-	defer func() { _ = cleanup() }()
 
-	rawYAML, err := io.ReadAll(reader)
+	// Read raw YAML; the client (local or RPC) owns parse/validate.
+	rawYAML, err := kukshared.ReadManifests(files)
 	if err != nil {
-		return fmt.Errorf("failed to read input: %w", err)
+		return err
 	}
 
 	client, err := resolveClient(cmd)
@@ -156,9 +159,31 @@ func handleFileDeletion(cmd *cobra.Command, file string) error {
 	}
 
 	if output == "json" || output == "yaml" {
-		return printDeleteResultJSON(cmd, result, output)
+		if err = printDeleteResultJSON(cmd, result, output); err != nil {
+			return err
+		}
+	} else if err = printDeleteResult(cmd, result); err != nil {
+		return err
+	}
+	if !ignoreNotFound {
+		return notFoundError(result)
+	}
+	return nil
+}
+
+// notFoundError reports the resources the daemon found nothing to delete
+// for, for --ignore-not-found=false.
+func notFoundError(result kukeonv1.DeleteDocumentsResult) error {
+	var missing []string
+	for _, resource := range result.Resources {
+		if resource.Action == actionNotFound {
+			missing = append(missing, fmt.Sprintf("%s %q", resource.Kind, resource.Name))
+		}
+	}
+	if len(missing) == 0 {
+		return nil
 	}
-	return printDeleteResult(cmd, result)
+	return fmt.Errorf("%w: %s", errdefs.ErrDeleteResourceNotFound, strings.Join(missing, ", "))
 }
 
 // resolveClient picks the test-injected mock from context if present, else
@@ -23,12 +23,14 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/eminwux/kukeon/cmd/config"
 	deletecmd "github.com/eminwux/kukeon/cmd/kuke/delete"
 	"github.com/eminwux/kukeon/cmd/types"
+	"github.com/eminwux/kukeon/internal/errdefs"
 	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -283,7 +285,7 @@ func TestNewDeleteCmd_FileFlag(t *testing.T) {
 	if fileFlag == nil {
 		t.Fatal("expected 'file' flag to exist")
 	}
-	if fileFlag.Usage != "File to read YAML from (use - for stdin)" {
+	if fileFlag.Usage != "File, directory, or glob to read YAML from (use - for stdin); repeatable" {
 		t.Errorf("unexpected file flag usage: %q", fileFlag.Usage)
 	}
 }
@@ -471,6 +473,94 @@ spec:
 	}
 }
 
+// TestNewDeleteCmd_RunE_IgnoreNotFound pins that a missing resource is
+// tolerated by default and becomes an error, naming it, only with
+// --ignore-not-found=false — after every document has still been processed.
+func TestNewDeleteCmd_RunE_IgnoreNotFound(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "realm.yaml")
+	if err := os.WriteFile(path, []byte("apiVersion: v1beta1\nkind: Realm\nmetadata:\n  name: gone\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	result := kukeonv1.DeleteDocumentsResult{
+		Resources: []kukeonv1.DeleteResourceResult{
+			{Index: 1, Kind: "Space", Name: "apps", Action: "deleted"},
+			{Index: 0, Kind: "Realm", Name: "gone", Action: "not found"},
+		},
+	}
+
+	for _, tt := range []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{name: "default", args: nil},
+		{name: "explicit true", args: []string{"--ignore-not-found"}},
+		{name: "false", args: []string{"--ignore-not-found=false"}, wantErr: errdefs.ErrDeleteResourceNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := deletecmd.NewDeleteCmd()
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(io.Discard)
+			fakeCtrl := &fakeClient{
+				deleteFn: func(_ []byte, _, _ bool) (kukeonv1.DeleteDocumentsResult, error) {
+					return result, nil
+				},
+			}
+			cmd.SetContext(context.WithValue(context.Background(), deletecmd.MockControllerKey{},
+				kukeonv1.Client(fakeCtrl)))
+			cmd.SetArgs(append([]string{"-f", path}, tt.args...))
+
+			err := cmd.Execute()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil && !strings.Contains(err.Error(), `Realm "gone"`) {
+				t.Errorf("err = %v, want it to name the missing realm", err)
+			}
+			if !strings.Contains(out.String(), `Space "apps": deleted`) {
+				t.Errorf("output = %q, want the other documents reported", out.String())
+			}
+		})
+	}
+}
+
+// TestNewDeleteCmd_RunE_Directory pins that -f takes a directory, like
+// `kuke apply -f`, and sends its manifests as one stream so the daemon's
+// reverse ordering spans files.
+func TestNewDeleteCmd_RunE_Directory(t *testing.T) {
+	dir := t.TempDir()
+	realm := "apiVersion: v1beta1\nkind: Realm\nmetadata:\n  name: shop\n"
+	space := "apiVersion: v1beta1\nkind: Space\nmetadata:\n  name: apps\nspec:\n  realmId: shop\n"
+	for name, content := range map[string]string{"realm.yaml": realm, "space.yaml": space} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	calls := 0
+	cmd := deletecmd.NewDeleteCmd()
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	fakeCtrl := &fakeClient{
+		deleteFn: func(raw []byte, _, _ bool) (kukeonv1.DeleteDocumentsResult, error) {
+			calls++
+			if want := realm + "\n---\n" + space; string(raw) != want {
+				t.Errorf("raw = %q, want %q", raw, want)
+			}
+			return kukeonv1.DeleteDocumentsResult{}, nil
+		},
+	}
+	cmd.SetContext(context.WithValue(context.Background(), deletecmd.MockControllerKey{}, kukeonv1.Client(fakeCtrl)))
+	cmd.SetArgs([]string{"-f", dir})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("DeleteDocuments called %d times, want 1", calls)
+	}
+}
+
 func TestNewDeleteCmd_RunE_CascadeFlag(t *testing.T) {
 	tmpFile, err := os.CreateTemp(t.TempDir(), "test-*.yaml")
 	if err != nil {
@@ -64,6 +64,10 @@ func NewRealmCmd() *cobra.Command {
 				realmName = result.Realm.Metadata.Name
 			}
 			cmd.Printf("Deleted realm %q\n", realmName)
+			shared.PrintCascaded(cmd, result.Deleted)
+			if result.BackupPath != "" {
+				cmd.Printf("Metadata backup: %s\n", result.BackupPath)
+			}
 			return nil
 		},
 	}
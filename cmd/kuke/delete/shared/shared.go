@@ -18,6 +18,7 @@ package shared
 
 import (
 	"log/slog"
+	"strings"
 
 	"github.com/eminwux/kukeon/cmd/config"
 	kukshared "github.com/eminwux/kukeon/cmd/kuke/shared"
@@ -57,3 +58,16 @@ func GetCascadeFromViper() bool {
 func GetForceFromViper() bool {
 	return viper.GetBool(config.KUKE_DELETE_FORCE.ViperKey)
 }
+
+// PrintCascaded lists the child resources a cascading delete removed. The
+// controller reports them in Deleted as "<kind>:<name>" entries next to the
+// parent's own "metadata"/"cgroup"/... entries, which are skipped.
+func PrintCascaded(cmd *cobra.Command, deleted []string) {
+	for _, entry := range deleted {
+		kind, name, ok := strings.Cut(entry, ":")
+		if !ok {
+			continue
+		}
+		cmd.Printf("  - %s %q deleted (cascade)\n", kind, name)
+	}
+}
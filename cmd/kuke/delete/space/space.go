@@ -81,6 +81,7 @@ func NewSpaceCmd() *cobra.Command {
 			}
 
 			cmd.Printf("Deleted space %q from realm %q\n", spaceName, realmName)
+			shared.PrintCascaded(cmd, result.Deleted)
 			return nil
 		},
 	}
@@ -26,6 +26,7 @@ import (
 	"testing"
 
 	"github.com/eminwux/kukeon/cmd/config"
+	deletecmd "github.com/eminwux/kukeon/cmd/kuke/delete"
 	space "github.com/eminwux/kukeon/cmd/kuke/delete/space"
 	"github.com/eminwux/kukeon/cmd/types"
 	"github.com/eminwux/kukeon/internal/errdefs"
@@ -107,6 +108,90 @@ func TestDeleteSpace(t *testing.T) {
 	}
 }
 
+// TestDeleteSpace_CascadeAndForce runs through the parent delete command,
+// which owns the persistent --cascade / --force flags, and pins that both
+// reach the client and that cascaded children are listed.
+func TestDeleteSpace_CascadeAndForce(t *testing.T) {
+	tests := []struct {
+		name        string
+		flags       []string
+		err         error
+		wantForce   bool
+		wantCascade bool
+		wantErr     error
+		wantOutput  []string
+	}{
+		{
+			name:        "cascade",
+			flags:       []string{"--cascade"},
+			wantCascade: true,
+			wantOutput:  []string{`stack "st1" deleted (cascade)`, `stack "st2" deleted (cascade)`},
+		},
+		{
+			name:      "force",
+			flags:     []string{"--force"},
+			wantForce: true,
+		},
+		{
+			name:    "children block delete without cascade",
+			err:     errdefs.ErrResourceHasDependencies,
+			wantErr: errdefs.ErrResourceHasDependencies,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			viper.Reset()
+
+			var gotForce, gotCascade bool
+			fake := &fakeClient{
+				deleteSpaceFn: func(doc v1beta1.SpaceDoc, force, cascade bool) (kukeonv1.DeleteSpaceResult, error) {
+					gotForce, gotCascade = force, cascade
+					if tt.err != nil {
+						return kukeonv1.DeleteSpaceResult{}, tt.err
+					}
+					return kukeonv1.DeleteSpaceResult{
+						SpaceName: doc.Metadata.Name,
+						RealmName: doc.Spec.RealmID,
+						Deleted:   []string{"stack:st1", "stack:st2", "metadata", "cgroup", "network"},
+					}, nil
+				},
+			}
+
+			cmd := deletecmd.NewDeleteCmd()
+			buf := &bytes.Buffer{}
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+			ctx = context.WithValue(ctx, space.MockControllerKey{}, kukeonv1.Client(fake))
+			cmd.SetContext(ctx)
+			cmd.SetArgs(append([]string{"space", "s1", "--realm", "r1"}, tt.flags...))
+
+			err := cmd.Execute()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if gotForce != tt.wantForce || gotCascade != tt.wantCascade {
+				t.Errorf("client got force=%v cascade=%v, want force=%v cascade=%v",
+					gotForce, gotCascade, tt.wantForce, tt.wantCascade)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q\nGot:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
 type fakeClient struct {
 	kukeonv1.FakeClient
 
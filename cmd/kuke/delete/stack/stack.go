@@ -81,6 +81,7 @@ func NewStackCmd() *cobra.Command {
 				spaceName = space
 			}
 			cmd.Printf("Deleted stack %q from space %q\n", stackName, spaceName)
+			shared.PrintCascaded(cmd, result.Deleted)
 			return nil
 		},
 	}
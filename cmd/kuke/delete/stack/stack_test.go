@@ -26,6 +26,7 @@ import (
 	"testing"
 
 	"github.com/eminwux/kukeon/cmd/config"
+	deletecmd "github.com/eminwux/kukeon/cmd/kuke/delete"
 	stack "github.com/eminwux/kukeon/cmd/kuke/delete/stack"
 	"github.com/eminwux/kukeon/cmd/types"
 	"github.com/eminwux/kukeon/internal/errdefs"
@@ -113,6 +114,90 @@ func TestDeleteStack(t *testing.T) {
 	}
 }
 
+// TestDeleteStack_CascadeAndForce runs through the parent delete command,
+// which owns the persistent --cascade / --force flags, and pins that both
+// reach the client and that cascaded children are listed.
+func TestDeleteStack_CascadeAndForce(t *testing.T) {
+	tests := []struct {
+		name        string
+		flags       []string
+		err         error
+		wantForce   bool
+		wantCascade bool
+		wantErr     error
+		wantOutput  []string
+	}{
+		{
+			name:        "cascade",
+			flags:       []string{"--cascade"},
+			wantCascade: true,
+			wantOutput:  []string{`cell "c1" deleted (cascade)`},
+		},
+		{
+			name:      "force",
+			flags:     []string{"--force"},
+			wantForce: true,
+		},
+		{
+			name:    "children block delete without cascade",
+			err:     errdefs.ErrResourceHasDependencies,
+			wantErr: errdefs.ErrResourceHasDependencies,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			viper.Reset()
+
+			var gotForce, gotCascade bool
+			fake := &fakeClient{
+				deleteStackFn: func(doc v1beta1.StackDoc, force, cascade bool) (kukeonv1.DeleteStackResult, error) {
+					gotForce, gotCascade = force, cascade
+					if tt.err != nil {
+						return kukeonv1.DeleteStackResult{}, tt.err
+					}
+					return kukeonv1.DeleteStackResult{
+						StackName: doc.Metadata.Name,
+						SpaceName: doc.Spec.SpaceID,
+						Deleted:   []string{"cell:c1", "metadata", "cgroup"},
+					}, nil
+				},
+			}
+
+			cmd := deletecmd.NewDeleteCmd()
+			buf := &bytes.Buffer{}
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+			ctx = context.WithValue(ctx, stack.MockControllerKey{}, kukeonv1.Client(fake))
+			cmd.SetContext(ctx)
+			cmd.SetArgs(append([]string{"stack", "st1", "--realm", "r1", "--space", "s1"}, tt.flags...))
+
+			err := cmd.Execute()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if gotForce != tt.wantForce || gotCascade != tt.wantCascade {
+				t.Errorf("client got force=%v cascade=%v, want force=%v cascade=%v",
+					gotForce, gotCascade, tt.wantForce, tt.wantCascade)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q\nGot:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
 type fakeClient struct {
 	kukeonv1.FakeClient
 
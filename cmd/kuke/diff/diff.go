@@ -0,0 +1,243 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package diff hosts the `kuke diff` command, which compares every document
+// in a manifest against the version the store holds and prints a field-level
+// diff, exiting non-zero when anything differs.
+package diff
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	kukshared "github.com/eminwux/kukeon/cmd/kuke/shared"
+	"github.com/eminwux/kukeon/internal/apply/docdiff"
+	"github.com/eminwux/kukeon/internal/apply/parser"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+	"github.com/spf13/cobra"
+)
+
+// MockControllerKey is used to inject a mock kukeonv1.Client via context in tests.
+type MockControllerKey struct{}
+
+// NewDiffCmd builds the `kuke diff` cobra command.
+func NewDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff -f <file>",
+		Short: "Show how resource definitions in a YAML file differ from the store",
+		Long: "Compare every realm, space, stack, cell and container document in a YAML file or " +
+			"stdin (-f) with the version the store holds and print a field-level diff. Status and " +
+			"fields the daemon fills in are ignored; a resource the store does not hold is shown " +
+			"as entirely new. Exits non-zero when any document differs.",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE:          runDiff,
+	}
+
+	cmd.Flags().StringP("file", "f", "", "File to read YAML from (use - for stdin)")
+
+	return cmd
+}
+
+func runDiff(cmd *cobra.Command, _ []string) error {
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return err
+	}
+	if file == "" {
+		return errors.New("file flag is required (use -f <file> or -f - for stdin)")
+	}
+
+	reader, cleanup, err := kukshared.ReadFileOrStdin(file)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cleanup() }()
+
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("failed to read input: %w", err)
+	}
+	docs, err := parseDocuments(raw)
+	if err != nil {
+		return err
+	}
+
+	client, err := resolveClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	compared, differing := 0, 0
+	for _, doc := range docs {
+		if !docdiff.Supported(doc.Kind) {
+			cmd.PrintErrf("Skipping document %d: %s: %v\n", doc.Index, doc.Kind, errdefs.ErrDiffUnsupportedKind)
+			continue
+		}
+		res, diffErr := diffDocument(cmd.Context(), client, doc)
+		if diffErr != nil {
+			return fmt.Errorf("document %d (%s): %w", doc.Index, doc.Kind, diffErr)
+		}
+		if err = docdiff.Render(cmd.OutOrStdout(), res); err != nil {
+			return err
+		}
+		compared++
+		if res.Differs() {
+			differing++
+		}
+	}
+
+	if differing > 0 {
+		return fmt.Errorf("%w: %d of %d document(s)", errdefs.ErrManifestDiffers, differing, compared)
+	}
+	cmd.Printf("%d document(s) match the store\n", compared)
+	return nil
+}
+
+// parseDocuments parses and validates every document up front so a broken
+// manifest fails before any store lookups.
+func parseDocuments(raw []byte) ([]*parser.Document, error) {
+	rawDocs, err := parser.ParseDocuments(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errdefs.ErrManifestInvalid, err)
+	}
+	docs := make([]*parser.Document, 0, len(rawDocs))
+	for i, rawDoc := range rawDocs {
+		doc, parseErr := parser.ParseDocument(i, rawDoc)
+		if parseErr != nil {
+			return nil, fmt.Errorf("%w: document %d: %w", errdefs.ErrManifestInvalid, i, parseErr)
+		}
+		if validationErr := parser.ValidateDocument(doc); validationErr != nil {
+			return nil, fmt.Errorf("%w: %w", errdefs.ErrManifestInvalid, validationErr)
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// diffDocument fetches the stored counterpart of doc and compares the two.
+func diffDocument(ctx context.Context, client kukeonv1.Client, doc *parser.Document) (docdiff.Result, error) {
+	res := docdiff.Result{Index: doc.Index, Kind: doc.Kind}
+	desiredDoc, liveDoc, err := fetch(ctx, client, doc)
+	if err != nil {
+		return res, err
+	}
+	res.Name = documentName(desiredDoc)
+
+	desired, err := docdiff.Normalize(desiredDoc)
+	if err != nil {
+		return res, err
+	}
+	var live map[string]any
+	if liveDoc != nil {
+		res.Found = true
+		if live, err = docdiff.Normalize(liveDoc); err != nil {
+			return res, err
+		}
+	}
+	res.Changes = docdiff.Compare(live, desired)
+	return res, nil
+}
+
+// fetch returns the manifest document and the stored one, or a nil stored
+// document when the store does not hold the resource.
+func fetch(ctx context.Context, client kukeonv1.Client, doc *parser.Document) (any, any, error) {
+	switch doc.Kind {
+	case v1beta1.KindRealm:
+		got, err := client.GetRealm(ctx, *doc.RealmDoc)
+		if err != nil || !got.MetadataExists {
+			return *doc.RealmDoc, nil, err
+		}
+		return *doc.RealmDoc, got.Realm, nil
+	case v1beta1.KindSpace:
+		got, err := client.GetSpace(ctx, *doc.SpaceDoc)
+		if err != nil || !got.MetadataExists {
+			return *doc.SpaceDoc, nil, err
+		}
+		return *doc.SpaceDoc, got.Space, nil
+	case v1beta1.KindStack:
+		got, err := client.GetStack(ctx, *doc.StackDoc)
+		if err != nil || !got.MetadataExists {
+			return *doc.StackDoc, nil, err
+		}
+		return *doc.StackDoc, got.Stack, nil
+	case v1beta1.KindCell:
+		got, err := client.GetCell(ctx, *doc.CellDoc)
+		if err != nil || !got.MetadataExists {
+			return *doc.CellDoc, nil, err
+		}
+		return *doc.CellDoc, got.Cell, nil
+	case v1beta1.KindContainer:
+		return fetchContainer(ctx, client, *doc.ContainerDoc)
+	default:
+		return nil, nil, fmt.Errorf("%w: %s", errdefs.ErrDiffUnsupportedKind, doc.Kind)
+	}
+}
+
+// fetchContainer looks the parent cell up first: GetContainer reports a
+// missing cell as an error, while for a diff it only means the container
+// is new.
+func fetchContainer(ctx context.Context, client kukeonv1.Client, doc v1beta1.ContainerDoc) (any, any, error) {
+	cell, err := client.GetCell(ctx, v1beta1.CellDoc{
+		APIVersion: doc.APIVersion,
+		Kind:       v1beta1.KindCell,
+		Metadata:   v1beta1.CellMetadata{Name: doc.Spec.CellID},
+		Spec: v1beta1.CellSpec{
+			RealmID: doc.Spec.RealmID,
+			SpaceID: doc.Spec.SpaceID,
+			StackID: doc.Spec.StackID,
+		},
+	})
+	if err != nil || !cell.MetadataExists {
+		return doc, nil, err
+	}
+	got, err := client.GetContainer(ctx, doc)
+	if err != nil || !got.ContainerExists {
+		return doc, nil, err
+	}
+	return doc, got.Container, nil
+}
+
+func documentName(doc any) string {
+	switch d := doc.(type) {
+	case v1beta1.RealmDoc:
+		return d.Metadata.Name
+	case v1beta1.SpaceDoc:
+		return d.Metadata.Name
+	case v1beta1.StackDoc:
+		return d.Metadata.Name
+	case v1beta1.CellDoc:
+		return d.Metadata.Name
+	case v1beta1.ContainerDoc:
+		return d.Metadata.Name
+	default:
+		return ""
+	}
+}
+
+func resolveClient(cmd *cobra.Command) (kukeonv1.Client, error) {
+	if mockClient, ok := cmd.Context().Value(MockControllerKey{}).(kukeonv1.Client); ok {
+		return mockClient, nil
+	}
+	return kukshared.DaemonClientFromCmd(cmd)
+}
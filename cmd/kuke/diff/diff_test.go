@@ -0,0 +1,165 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package diff_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	diff "github.com/eminwux/kukeon/cmd/kuke/diff"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+const stackManifest = `apiVersion: v1beta1
+kind: Stack
+metadata:
+  name: web
+  labels:
+    tier: frontend
+spec:
+  realmId: shop
+  spaceId: apps
+`
+
+func writeTempYAML(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write temp yaml: %v", err)
+	}
+	return path
+}
+
+func storedStack(tier string) *v1beta1.StackDoc {
+	return &v1beta1.StackDoc{
+		APIVersion: v1beta1.APIVersionV1Beta1,
+		Kind:       v1beta1.KindStack,
+		Metadata: v1beta1.StackMetadata{
+			Name:   "web",
+			Labels: map[string]string{"tier": tier},
+		},
+		Spec: v1beta1.StackSpec{ID: "web", RealmID: "shop", SpaceID: "apps"},
+	}
+}
+
+func TestDiffRunE(t *testing.T) {
+	tests := []struct {
+		name       string
+		file       string
+		stored     *v1beta1.StackDoc
+		wantIs     error
+		wantOutput []string
+		notOutput  []string
+	}{
+		{
+			name:       "identical",
+			file:       stackManifest,
+			stored:     storedStack("frontend"),
+			wantOutput: []string{"1 document(s) match the store"},
+			notOutput:  []string{"---"},
+		},
+		{
+			name:   "changed",
+			file:   stackManifest,
+			stored: storedStack("backend"),
+			wantIs: errdefs.ErrManifestDiffers,
+			wantOutput: []string{
+				"--- live stack/web\n+++ manifest stack/web (document 0)",
+				`-metadata.labels.tier: "backend"`,
+				`+metadata.labels.tier: "frontend"`,
+			},
+		},
+		{
+			name:   "new resource",
+			file:   stackManifest,
+			wantIs: errdefs.ErrManifestDiffers,
+			wantOutput: []string{
+				"--- live stack/web (not found)",
+				`+spec.spaceId: "apps"`,
+				`+metadata.labels.tier: "frontend"`,
+			},
+		},
+		{
+			name: "unsupported kind is skipped",
+			file: "apiVersion: v1beta1\nkind: Volume\nmetadata:\n  name: data\n  realm: shop\n---\n" +
+				stackManifest,
+			stored:     storedStack("frontend"),
+			wantOutput: []string{"Skipping document 0: Volume", "1 document(s) match the store"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeClient{stack: tt.stored}
+			cmd := diff.NewDiffCmd()
+			buf := &bytes.Buffer{}
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			cmd.SetContext(context.WithValue(context.Background(), diff.MockControllerKey{}, kukeonv1.Client(fake)))
+			cmd.SetArgs([]string{"-f", writeTempYAML(t, tt.file)})
+
+			err := cmd.Execute()
+			if tt.wantIs != nil {
+				if !errors.Is(err, tt.wantIs) {
+					t.Fatalf("err = %v, want %v", err, tt.wantIs)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q\nGot:\n%s", want, buf.String())
+				}
+			}
+			for _, unwanted := range tt.notOutput {
+				if strings.Contains(buf.String(), unwanted) {
+					t.Errorf("output contains %q\nGot:\n%s", unwanted, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestDiffRunE_RequiresFile(t *testing.T) {
+	cmd := diff.NewDiffCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(nil)
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "file flag is required") {
+		t.Fatalf("err = %v, want file flag is required", err)
+	}
+}
+
+type fakeClient struct {
+	kukeonv1.FakeClient
+
+	stack *v1beta1.StackDoc
+}
+
+func (f *fakeClient) GetStack(_ context.Context, doc v1beta1.StackDoc) (kukeonv1.GetStackResult, error) {
+	if f.stack == nil || f.stack.Metadata.Name != doc.Metadata.Name {
+		return kukeonv1.GetStackResult{}, nil
+	}
+	return kukeonv1.GetStackResult{Stack: *f.stack, MetadataExists: true}, nil
+}
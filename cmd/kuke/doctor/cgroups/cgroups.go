@@ -30,6 +30,7 @@ import (
 
 	"github.com/eminwux/kukeon/cmd/kuke/shared"
 	"github.com/eminwux/kukeon/internal/cgroupcheck"
+	"github.com/eminwux/kukeon/internal/errdefs"
 	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
 	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
 	"github.com/spf13/cobra"
@@ -369,7 +370,7 @@ func (t scopedTarget) resolveCgroupPath(ctx context.Context, c kukeonv1.Client)
 			return "", err
 		}
 		if !res.MetadataExists {
-			return "", fmt.Errorf("realm %q not found", t.name)
+			return "", fmt.Errorf("realm %q not found: %w", t.name, errdefs.ErrRealmNotFound)
 		}
 		if res.Realm.Status.CgroupPath == "" {
 			return "", fmt.Errorf("realm %q has no Status.CgroupPath yet", t.name)
@@ -384,7 +385,7 @@ func (t scopedTarget) resolveCgroupPath(ctx context.Context, c kukeonv1.Client)
 			return "", err
 		}
 		if !res.MetadataExists {
-			return "", fmt.Errorf("space %q not found in realm %q", t.name, t.realm)
+			return "", fmt.Errorf("space %q not found in realm %q: %w", t.name, t.realm, errdefs.ErrSpaceNotFound)
 		}
 		if res.Space.Status.CgroupPath == "" {
 			return "", fmt.Errorf("space %q has no Status.CgroupPath yet", t.name)
@@ -399,7 +400,9 @@ func (t scopedTarget) resolveCgroupPath(ctx context.Context, c kukeonv1.Client)
 			return "", err
 		}
 		if !res.MetadataExists {
-			return "", fmt.Errorf("stack %q not found in realm %q, space %q", t.name, t.realm, t.space)
+			return "", fmt.Errorf(
+				"stack %q not found in realm %q, space %q: %w", t.name, t.realm, t.space, errdefs.ErrStackNotFound,
+			)
 		}
 		if res.Stack.Status.CgroupPath == "" {
 			return "", fmt.Errorf("stack %q has no Status.CgroupPath yet", t.name)
@@ -418,7 +421,9 @@ func (t scopedTarget) resolveCgroupPath(ctx context.Context, c kukeonv1.Client)
 			return "", err
 		}
 		if !res.MetadataExists {
-			return "", fmt.Errorf("cell %q not found in stack %q/%q/%q", t.name, t.realm, t.space, t.stack)
+			return "", fmt.Errorf(
+				"cell %q not found in stack %q/%q/%q: %w", t.name, t.realm, t.space, t.stack, errdefs.ErrCellNotFound,
+			)
 		}
 		if res.Cell.Status.CgroupPath == "" {
 			return "", fmt.Errorf("cell %q has no Status.CgroupPath yet", t.name)
@@ -541,6 +541,33 @@ func TestCgroupsCmdScopeResolvesAndPasses(t *testing.T) {
 	}
 }
 
+// TestCgroupsCmdScopeMissingIsNotFound: a scope the daemon does not know
+// fails with the kind's not-found sentinel, so `kuke doctor cgroups
+// --scope` exits with the not-found code like `kuke get` does.
+func TestCgroupsCmdScopeMissingIsNotFound(t *testing.T) {
+	root := t.TempDir()
+	cases := []struct {
+		name string
+		args []string
+		want error
+	}{
+		{"realm", []string{"--scope", "realm", "default"}, errdefs.ErrRealmNotFound},
+		{"space", []string{"--scope", "space", "sp", "--realm", "default"}, errdefs.ErrSpaceNotFound},
+		{"stack", []string{"--scope", "stack", "st", "--realm", "default", "--space", "sp"}, errdefs.ErrStackNotFound},
+		{"cell", []string{
+			"--scope", "cell", "ce", "--realm", "default", "--space", "sp", "--stack", "st",
+		}, errdefs.ErrCellNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := runCmdWithClient(t, &fakeScopedClient{}, append(tc.args, "--root", root)...)
+			if !errors.Is(err, tc.want) {
+				t.Errorf("scope=%s Execute() error = %v, want %v", tc.name, err, tc.want)
+			}
+		})
+	}
+}
+
 // TestCgroupsCmdScopeFailsOnGap: with a synthetic gap (memory missing
 // from the realm's cgroup.subtree_control), --scope realm must exit
 // non-zero and the stderr must identify the realm via the new "scope:"
@@ -0,0 +1,176 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package drain implements `kuke drain` and `kuke undrain`, which stop every
+// cell under a realm, space, or stack for maintenance and bring them back.
+package drain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eminwux/kukeon/cmd/config"
+	kukeshared "github.com/eminwux/kukeon/cmd/kuke/shared"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	"github.com/spf13/cobra"
+)
+
+// MockControllerKey is used to inject a mock kukeonv1.Client via context in tests.
+type MockControllerKey struct{}
+
+type scopeFlags struct {
+	realm string
+	space string
+	stack string
+}
+
+func (f *scopeFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.realm, "realm", "", "Realm to act on (required)")
+	cmd.Flags().StringVar(&f.space, "space", "", "Narrow the scope to one space of the realm")
+	cmd.Flags().StringVar(&f.stack, "stack", "", "Narrow the scope to one stack of the space (requires --space)")
+	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
+	_ = cmd.RegisterFlagCompletionFunc("space", config.CompleteSpaceNames)
+	_ = cmd.RegisterFlagCompletionFunc("stack", config.CompleteStackNames)
+}
+
+func (f *scopeFlags) validate() error {
+	f.realm = strings.TrimSpace(f.realm)
+	f.space = strings.TrimSpace(f.space)
+	f.stack = strings.TrimSpace(f.stack)
+	if f.realm == "" {
+		return fmt.Errorf("%w (--realm)", errdefs.ErrRealmNameRequired)
+	}
+	if f.stack != "" && f.space == "" {
+		return fmt.Errorf("%w (--space, required with --stack)", errdefs.ErrSpaceNameRequired)
+	}
+	return nil
+}
+
+// NewDrainCmd builds the `kuke drain` command.
+func NewDrainCmd() *cobra.Command {
+	var scope scopeFlags
+
+	cmd := &cobra.Command{
+		Use:   "drain",
+		Short: "Stop every cell under a realm, space, or stack for maintenance",
+		Long: "Drain a scope before host maintenance. Every running cell under it is\n" +
+			"stopped gracefully and detached from its network; metadata is kept.\n" +
+			"The scope is marked drained, so the reconcile loop leaves its cells\n" +
+			"alone and `kuke start` refuses them until `kuke undrain`, which starts\n" +
+			"the cells the drain stopped. Cells that were already down are left\n" +
+			"as they are.",
+		Example: "  kuke drain --realm default\n" +
+			"  kuke drain --realm default --space web --stack frontend",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := scope.validate(); err != nil {
+				return err
+			}
+			client, err := resolveClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = client.Close() }()
+
+			result, err := client.DrainScope(cmd.Context(), scope.realm, scope.space, scope.stack)
+			for _, c := range result.Cells {
+				cmd.Printf("Stopped cell %q\n", c)
+			}
+			if result.Realm == "" {
+				return err
+			}
+			label := scopeLabel(result.Realm, result.Space, result.Stack)
+			if result.Changed {
+				cmd.Printf("Drained %s: %d cell(s) stopped, %d already down\n",
+					label, len(result.Cells), result.Skipped)
+			} else {
+				cmd.Printf("%s already drained since %s: %d cell(s) stopped, %d already down\n",
+					label, formatSince(result.Since), len(result.Cells), result.Skipped)
+			}
+			return err
+		},
+	}
+	scope.register(cmd)
+	return cmd
+}
+
+// NewUndrainCmd builds the `kuke undrain` command.
+func NewUndrainCmd() *cobra.Command {
+	var scope scopeFlags
+
+	cmd := &cobra.Command{
+		Use:          "undrain",
+		Short:        "Lift a drain and start the cells it stopped",
+		Example:      "  kuke undrain --realm default",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := scope.validate(); err != nil {
+				return err
+			}
+			client, err := resolveClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = client.Close() }()
+
+			result, err := client.UndrainScope(cmd.Context(), scope.realm, scope.space, scope.stack)
+			for _, c := range result.Cells {
+				cmd.Printf("Started cell %q\n", c)
+			}
+			if result.Realm == "" {
+				return err
+			}
+			label := scopeLabel(result.Realm, result.Space, result.Stack)
+			if result.Changed {
+				cmd.Printf("Undrained %s: %d cell(s) started\n", label, len(result.Cells))
+			} else {
+				cmd.Printf("%s was not drained\n", label)
+			}
+			return err
+		},
+	}
+	scope.register(cmd)
+	return cmd
+}
+
+func resolveClient(cmd *cobra.Command) (kukeonv1.Client, error) {
+	if mockClient, ok := cmd.Context().Value(MockControllerKey{}).(kukeonv1.Client); ok {
+		return mockClient, nil
+	}
+	return kukeshared.ClientFromCmd(cmd)
+}
+
+func scopeLabel(realm, space, stack string) string {
+	out := fmt.Sprintf("realm %q", realm)
+	if space != "" {
+		out += fmt.Sprintf(" space %q", space)
+	}
+	if stack != "" {
+		out += fmt.Sprintf(" stack %q", stack)
+	}
+	return out
+}
+
+func formatSince(since *time.Time) string {
+	if since == nil {
+		return "an unknown time"
+	}
+	return since.Local().Format(time.RFC3339)
+}
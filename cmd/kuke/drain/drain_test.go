@@ -0,0 +1,169 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package drain_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	drainpkg "github.com/eminwux/kukeon/cmd/kuke/drain"
+	"github.com/eminwux/kukeon/cmd/types"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestDrainCmds(t *testing.T) {
+	since := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		cmd        func() *cobra.Command
+		args       []string
+		fake       *fakeClient
+		wantScope  string
+		wantErr    string
+		wantOutput []string
+	}{
+		{
+			name: "drain stack",
+			cmd:  drainpkg.NewDrainCmd,
+			args: []string{"--realm", "r1", "--space", "s1", "--stack", "st1"},
+			fake: &fakeClient{result: kukeonv1.DrainScopeResult{
+				Realm: "r1", Space: "s1", Stack: "st1", Changed: true, Since: &since,
+				Cells: []string{"s1/st1/a"}, Skipped: 1,
+			}},
+			wantScope: "r1/s1/st1",
+			wantOutput: []string{
+				`Stopped cell "s1/st1/a"`,
+				`Drained realm "r1" space "s1" stack "st1": 1 cell(s) stopped, 1 already down`,
+			},
+		},
+		{
+			name:      "drain already drained",
+			cmd:       drainpkg.NewDrainCmd,
+			args:      []string{"--realm", "r1"},
+			fake:      &fakeClient{result: kukeonv1.DrainScopeResult{Realm: "r1", Since: &since}},
+			wantScope: "r1//",
+			wantOutput: []string{
+				`realm "r1" already drained since ` + since.Local().Format(time.RFC3339),
+			},
+		},
+		{
+			name: "drain partial failure still reports",
+			cmd:  drainpkg.NewDrainCmd,
+			args: []string{"--realm", "r1"},
+			fake: &fakeClient{
+				result: kukeonv1.DrainScopeResult{Realm: "r1", Changed: true, Cells: []string{"s1/st1/a"}},
+				err:    errors.New("stop cell b: boom"),
+			},
+			wantScope:  "r1//",
+			wantErr:    "boom",
+			wantOutput: []string{`Drained realm "r1": 1 cell(s) stopped, 0 already down`},
+		},
+		{
+			name: "undrain",
+			cmd:  drainpkg.NewUndrainCmd,
+			args: []string{"--realm", "r1", "--space", "s1"},
+			fake: &fakeClient{result: kukeonv1.DrainScopeResult{
+				Realm: "r1", Space: "s1", Changed: true, Cells: []string{"s1/st1/a"},
+			}},
+			wantScope: "r1/s1/",
+			wantOutput: []string{
+				`Started cell "s1/st1/a"`,
+				`Undrained realm "r1" space "s1": 1 cell(s) started`,
+			},
+		},
+		{
+			name:       "undrain not drained",
+			cmd:        drainpkg.NewUndrainCmd,
+			args:       []string{"--realm", "r1"},
+			fake:       &fakeClient{result: kukeonv1.DrainScopeResult{Realm: "r1"}},
+			wantScope:  "r1//",
+			wantOutput: []string{`realm "r1" was not drained`},
+		},
+		{
+			name:    "realm required",
+			cmd:     drainpkg.NewDrainCmd,
+			fake:    &fakeClient{},
+			wantErr: "--realm",
+		},
+		{
+			name:    "stack requires space",
+			cmd:     drainpkg.NewUndrainCmd,
+			args:    []string{"--realm", "r1", "--stack", "st1"},
+			fake:    &fakeClient{},
+			wantErr: "--space",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			viper.Reset()
+
+			cmd := tt.cmd()
+			buf := &bytes.Buffer{}
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+			ctx = context.WithValue(ctx, drainpkg.MockControllerKey{}, kukeonv1.Client(tt.fake))
+			cmd.SetContext(ctx)
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("want err %q, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q\nGot:\n%s", want, buf.String())
+				}
+			}
+			if tt.fake.gotScope != tt.wantScope {
+				t.Errorf("scope = %q, want %q", tt.fake.gotScope, tt.wantScope)
+			}
+		})
+	}
+}
+
+type fakeClient struct {
+	kukeonv1.FakeClient
+
+	result   kukeonv1.DrainScopeResult
+	err      error
+	gotScope string
+}
+
+func (f *fakeClient) DrainScope(_ context.Context, realm, space, stack string) (kukeonv1.DrainScopeResult, error) {
+	f.gotScope = realm + "/" + space + "/" + stack
+	return f.result, f.err
+}
+
+func (f *fakeClient) UndrainScope(_ context.Context, realm, space, stack string) (kukeonv1.DrainScopeResult, error) {
+	f.gotScope = realm + "/" + space + "/" + stack
+	return f.result, f.err
+}
@@ -0,0 +1,190 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/cmd/config"
+	exportpkg "github.com/eminwux/kukeon/cmd/kuke/export"
+	"github.com/eminwux/kukeon/cmd/types"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	"github.com/spf13/viper"
+)
+
+const manifest = "---\napiVersion: v1beta1\nkind: Realm\nmetadata:\n    name: r1\n"
+
+func TestExportCmd(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func()
+		fake       *fakeClient
+		wantErr    string
+		wantOutput []string
+	}{
+		{
+			name:  "prints manifest and redaction notes",
+			setup: func() { viper.Set(config.KUKE_EXPORT_REALM.ViperKey, "r1") },
+			fake: &fakeClient{
+				exportRealmFn: func(realm string, includeSecrets bool) (kukeonv1.ExportRealmResult, error) {
+					if realm != "r1" || includeSecrets {
+						return kukeonv1.ExportRealmResult{}, errors.New("unexpected export arguments")
+					}
+					return kukeonv1.ExportRealmResult{
+						Manifest: []byte(manifest),
+						Redacted: []string{"secret r1/api-key"},
+					}, nil
+				},
+			},
+			wantOutput: []string{"kind: Realm", "Redacted secret r1/api-key"},
+		},
+		{
+			name: "include secrets",
+			setup: func() {
+				viper.Set(config.KUKE_EXPORT_REALM.ViperKey, "r1")
+				viper.Set(config.KUKE_EXPORT_INCLUDE_SECRETS.ViperKey, true)
+			},
+			fake: &fakeClient{
+				exportRealmFn: func(_ string, includeSecrets bool) (kukeonv1.ExportRealmResult, error) {
+					if !includeSecrets {
+						return kukeonv1.ExportRealmResult{}, errors.New("includeSecrets not forwarded")
+					}
+					return kukeonv1.ExportRealmResult{Manifest: []byte(manifest)}, nil
+				},
+			},
+			wantOutput: []string{"name: r1"},
+		},
+		{
+			name:  "realm not found",
+			setup: func() { viper.Set(config.KUKE_EXPORT_REALM.ViperKey, "missing") },
+			fake: &fakeClient{
+				exportRealmFn: func(string, bool) (kukeonv1.ExportRealmResult, error) {
+					return kukeonv1.ExportRealmResult{}, errdefs.ErrRealmNotFound
+				},
+			},
+			wantErr: "realm not found",
+		},
+		{
+			name:    "empty realm",
+			setup:   func() { viper.Set(config.KUKE_EXPORT_REALM.ViperKey, " ") },
+			wantErr: "realm name is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			viper.Reset()
+			if tt.setup != nil {
+				tt.setup()
+			}
+
+			buf := &bytes.Buffer{}
+			cmd := newTestCmd(t, tt.fake, buf)
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("want err %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q\nGot:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestExportCmd_OutputFile(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Reset()
+	out := filepath.Join(t.TempDir(), "realm.yaml")
+	viper.Set(config.KUKE_EXPORT_REALM.ViperKey, "r1")
+	viper.Set(config.KUKE_EXPORT_OUTPUT.ViperKey, out)
+
+	buf := &bytes.Buffer{}
+	cmd := newTestCmd(t, &fakeClient{
+		exportRealmFn: func(string, bool) (kukeonv1.ExportRealmResult, error) {
+			return kukeonv1.ExportRealmResult{Manifest: []byte(manifest)}, nil
+		},
+	}, buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if string(got) != manifest {
+		t.Errorf("file content = %q, want %q", got, manifest)
+	}
+	info, err := os.Stat(out)
+	if err != nil {
+		t.Fatalf("stat output: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("file mode = %o, want 600", perm)
+	}
+	if strings.Contains(buf.String(), "kind: Realm") {
+		t.Errorf("manifest leaked to stdout with --output set:\n%s", buf.String())
+	}
+}
+
+func newTestCmd(t *testing.T, fake *fakeClient, buf *bytes.Buffer) interface{ Execute() error } {
+	t.Helper()
+	cmd := exportpkg.NewExportCmd()
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+	if fake != nil {
+		ctx = context.WithValue(ctx, exportpkg.MockControllerKey{}, kukeonv1.Client(fake))
+	}
+	cmd.SetContext(ctx)
+	cmd.SetArgs(nil)
+	return cmd
+}
+
+type fakeClient struct {
+	kukeonv1.FakeClient
+
+	exportRealmFn func(realm string, includeSecrets bool) (kukeonv1.ExportRealmResult, error)
+}
+
+func (f *fakeClient) ExportRealm(
+	_ context.Context, realm string, includeSecrets bool,
+) (kukeonv1.ExportRealmResult, error) {
+	if f.exportRealmFn == nil {
+		return kukeonv1.ExportRealmResult{}, errors.New("unexpected ExportRealm call")
+	}
+	return f.exportRealmFn(realm, includeSecrets)
+}
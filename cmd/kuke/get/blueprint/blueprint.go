@@ -66,6 +66,11 @@ func NewBlueprintCmd() *cobra.Command {
 			space := shared.ExplicitFlag(cmd, "space", config.KUKE_GET_BLUEPRINT_SPACE.ViperKey)
 			stack := shared.ExplicitFlag(cmd, "stack", config.KUKE_GET_BLUEPRINT_STACK.ViperKey)
 
+			sortBy, err := shared.ParseSortByFlag(cmd)
+			if err != nil {
+				return err
+			}
+
 			var name string
 			if len(args) > 0 {
 				name = strings.TrimSpace(args[0])
@@ -121,6 +126,9 @@ func NewBlueprintCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if err = shared.SortItems(blueprints, sortBy, nil); err != nil {
+				return err
+			}
 			return printBlueprints(cmd, blueprints, outputFormat)
 		},
 	}
@@ -132,9 +140,13 @@ func NewBlueprintCmd() *cobra.Command {
 	cmd.Flags().String("stack", "", "Filter blueprints by stack name")
 	_ = viper.BindPFlag(config.KUKE_GET_BLUEPRINT_STACK.ViperKey, cmd.Flags().Lookup("stack"))
 	cmd.Flags().
-		StringP("output", "o", "", "Output format (yaml, json, table, wide). Default: table for list, table for single resource")
+		StringP("output", "o", "", "Output format (yaml, json, table, wide, custom-columns=<spec>). "+
+			"Default: table for list, table for single resource")
 	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("output"))
 	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("o"))
+	shared.RegisterSortByFlag(cmd)
+	shared.RegisterQuietFlag(cmd)
+	shared.RegisterNoHeadersFlag(cmd)
 
 	cmd.ValidArgsFunction = config.CompleteBlueprintNames
 	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
@@ -167,18 +179,18 @@ func blueprintNotFoundErr(
 	)
 	blueprints, err := client.ListBlueprints(cmd.Context(), realm, "", "")
 	if err != nil {
-		return errors.New(base)
+		return fmt.Errorf("%s: %w", base, errdefs.ErrBlueprintNotFound)
 	}
 	for i := range blueprints {
 		m := &blueprints[i].Metadata
 		if m.Name == name && (m.Realm != realm || m.Space != space || m.Stack != stack) {
 			return fmt.Errorf(
-				"%s; a blueprint %q exists at realm=%q space=%q stack=%q",
-				base, name, m.Realm, m.Space, m.Stack,
+				"%s; a blueprint %q exists at realm=%q space=%q stack=%q: %w",
+				base, name, m.Realm, m.Space, m.Stack, errdefs.ErrBlueprintNotFound,
 			)
 		}
 	}
-	return errors.New(base)
+	return fmt.Errorf("%s: %w", base, errdefs.ErrBlueprintNotFound)
 }
 
 func printBlueprint(cmd *cobra.Command, blueprint *v1beta1.CellBlueprintDoc, format shared.OutputFormat) error {
@@ -200,9 +212,11 @@ func printBlueprints(cmd *cobra.Command, blueprints []v1beta1.CellBlueprintDoc,
 		return shared.PrintYAML(cmd, blueprints)
 	case shared.OutputFormatJSON:
 		return shared.PrintJSON(cmd, blueprints)
+	case shared.OutputFormatCustomColumns:
+		return shared.PrintCustomColumns(cmd, blueprints, nil)
 	case shared.OutputFormatTable, shared.OutputFormatWide:
 		if len(blueprints) == 0 {
-			cmd.Println("No blueprints found.")
+			shared.PrintEmpty(cmd, "No blueprints found.")
 			return nil
 		}
 		headers := []string{"NAME", "REALM", "SPACE", "STACK"}
@@ -73,7 +73,15 @@ cell relative to its lineage Config:
 
 The full outOfSync / outOfSyncReason / outOfSyncError status fields
 remain on ` + "`-o yaml` / `-o json`" + ` and the cgroup path stays out of the
-table — surface it with ` + "`-o yaml` / `-o json`" + ` when needed.`,
+table — surface it with ` + "`-o yaml` / `-o json`" + ` when needed.
+
+` + "`--live`" + ` queries containerd for every container's task state instead
+of trusting the persisted status. A cell recorded as Ready whose root or
+workload task has stopped shows as Degraded. If containerd is unreachable
+the persisted status is shown with a warning.
+
+With no --realm/--space/--stack filter, or with ` + "`-A`/`--all`" + `, the list
+covers every realm, space, and stack.`,
 		Args:          cobra.MaximumNArgs(1),
 		SilenceUsage:  true,
 		SilenceErrors: false,
@@ -88,10 +96,25 @@ table — surface it with ` + "`-o yaml` / `-o json`" + ` when needed.`,
 				return err
 			}
 
+			fieldSelector, err := shared.ParseFieldSelectorFlag(cmd, shared.CellSelectableFields)
+			if err != nil {
+				return err
+			}
+
 			realm := shared.ExplicitFlag(cmd, "realm", config.KUKE_GET_CELL_REALM.ViperKey)
 			space := shared.ExplicitFlag(cmd, "space", config.KUKE_GET_CELL_SPACE.ViperKey)
 			stack := shared.ExplicitFlag(cmd, "stack", config.KUKE_GET_CELL_STACK.ViperKey)
 
+			sortBy, err := shared.ParseSortByFlag(cmd)
+			if err != nil {
+				return err
+			}
+
+			columns, err := shared.ParseLabelColumnsFlags(cmd)
+			if err != nil {
+				return err
+			}
+
 			var name string
 			if len(args) > 0 {
 				name = strings.TrimSpace(args[0])
@@ -102,6 +125,13 @@ table — surface it with ` + "`-o yaml` / `-o json`" + ` when needed.`,
 			if name != "" && !selector.Empty() {
 				return errdefs.ErrSelectorWithName
 			}
+			if name != "" && !fieldSelector.Empty() {
+				return errdefs.ErrFieldSelectorWithName
+			}
+			if err = shared.ValidateAllScopesFlag(cmd, name, "realm", "space", "stack"); err != nil {
+				return err
+			}
+			live, _ := cmd.Flags().GetBool("live")
 
 			client, err := resolveClient(cmd)
 			if err != nil {
@@ -139,14 +169,17 @@ table — surface it with ` + "`-o yaml` / `-o json`" + ` when needed.`,
 				result, err := client.GetCell(cmd.Context(), doc)
 				if err != nil {
 					if errors.Is(err, errdefs.ErrCellNotFound) {
-						return fmt.Errorf("cell %q not found in stack %q/%q/%q", name, realm, space, stack)
+						return fmt.Errorf("cell %q not found in stack %q/%q/%q: %w", name, realm, space, stack, errdefs.ErrCellNotFound)
 					}
 					return err
 				}
 				if !result.MetadataExists {
-					return fmt.Errorf("cell %q not found in stack %q/%q/%q", name, realm, space, stack)
+					return fmt.Errorf("cell %q not found in stack %q/%q/%q: %w", name, realm, space, stack, errdefs.ErrCellNotFound)
+				}
+				if live {
+					applyLiveStatus(cmd, client, &result.Cell)
 				}
-				return printCell(cmd, &result.Cell, outputFormat, wide)
+				return printCell(cmd, &result.Cell, outputFormat, wide, columns)
 			}
 
 			cells, err := client.ListCells(cmd.Context(), realm, space, stack)
@@ -154,7 +187,19 @@ table — surface it with ` + "`-o yaml` / `-o json`" + ` when needed.`,
 				return err
 			}
 			cells = filterCellsBySelector(cells, selector)
-			return printCells(cmd, cells, outputFormat, wide)
+			if live {
+				for i := range cells {
+					applyLiveStatus(cmd, client, &cells[i])
+				}
+			}
+			// After --live, so status.state matches the state printed.
+			if cells, err = shared.FilterByFieldSelector(cells, fieldSelector); err != nil {
+				return err
+			}
+			if err = shared.SortItems(cells, sortBy, nil); err != nil {
+				return err
+			}
+			return printCells(cmd, cells, outputFormat, wide, columns)
 		},
 	}
 
@@ -165,11 +210,20 @@ table — surface it with ` + "`-o yaml` / `-o json`" + ` when needed.`,
 	cmd.Flags().String("stack", "", "Filter cells by stack name")
 	_ = viper.BindPFlag(config.KUKE_GET_CELL_STACK.ViperKey, cmd.Flags().Lookup("stack"))
 	cmd.Flags().
-		StringP("output", "o", "", "Output format (yaml, json, table, wide). Default: table for list, table for single resource")
+		StringP("output", "o", "", "Output format (yaml, json, table, wide, custom-columns=<spec>). "+
+			"Default: table for list, table for single resource")
 	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("output"))
 	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("o"))
+	shared.RegisterSortByFlag(cmd)
+	shared.RegisterQuietFlag(cmd)
+	shared.RegisterNoHeadersFlag(cmd)
 
 	shared.RegisterLabelSelectorFlag(cmd)
+	shared.RegisterFieldSelectorFlag(cmd)
+	shared.RegisterLabelColumnFlags(cmd)
+	shared.RegisterAllScopesFlag(cmd)
+	cmd.Flags().Bool("live", false,
+		"Query containerd for each cell's task state instead of trusting the persisted status")
 
 	cmd.ValidArgsFunction = config.CompleteCellNames
 	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
@@ -260,6 +314,28 @@ func hasConfigLineage(c *v1beta1.CellDoc) bool {
 	return strings.TrimSpace(c.Metadata.Labels[cellconfig.LabelConfig]) != ""
 }
 
+// applyLiveStatus overlays the cell's live status (CellLiveStatus) onto
+// Status.State and Status.Containers[].State in place, so every output
+// format shows it. When the live query fails, or containerd was unreachable,
+// the persisted status is kept and a warning goes to stderr.
+func applyLiveStatus(cmd *cobra.Command, client kukeonv1.Client, c *v1beta1.CellDoc) {
+	res, err := client.CellLiveStatus(cmd.Context(), *c)
+	if err != nil {
+		cmd.PrintErrln("Warning: failed to get live status for cell", c.Metadata.Name, ":", err)
+		return
+	}
+	if !res.Live {
+		cmd.PrintErrln("Warning: containerd unreachable; showing persisted status for cell", c.Metadata.Name)
+		return
+	}
+	c.Status.State = res.State
+	for i := range c.Status.Containers {
+		if state, ok := res.Containers[c.Status.Containers[i].ID]; ok {
+			c.Status.Containers[i].State = state
+		}
+	}
+}
+
 // filterCellsBySelector returns the subset of cells whose
 // Metadata.Labels satisfy selector. A nil or empty selector returns the
 // input slice unmodified so the common no-flag path skips the allocation.
@@ -276,7 +352,13 @@ func filterCellsBySelector(cells []v1beta1.CellDoc, selector *shared.LabelSelect
 	return out
 }
 
-func printCell(cmd *cobra.Command, cell *v1beta1.CellDoc, format shared.OutputFormat, wide bool) error {
+func printCell(
+	cmd *cobra.Command,
+	cell *v1beta1.CellDoc,
+	format shared.OutputFormat,
+	wide bool,
+	columns shared.LabelColumns,
+) error {
 	switch format {
 	case shared.OutputFormatJSON:
 		return shared.PrintJSON(cmd, cell)
@@ -287,7 +369,7 @@ func printCell(cmd *cobra.Command, cell *v1beta1.CellDoc, format shared.OutputFo
 		// with the same columns as the list view (kubectl parity). `wide`
 		// is carried separately because resolveOutput normalises `wide` to
 		// `table` plus a bool.
-		return printCells(cmd, []v1beta1.CellDoc{*cell}, format, wide)
+		return printCells(cmd, []v1beta1.CellDoc{*cell}, format, wide, columns)
 	}
 }
 
@@ -296,21 +378,25 @@ func printCells(
 	cells []v1beta1.CellDoc,
 	format shared.OutputFormat,
 	wide bool,
+	columns shared.LabelColumns,
 ) error {
 	switch format {
 	case shared.OutputFormatYAML:
 		return shared.PrintYAML(cmd, cells)
 	case shared.OutputFormatJSON:
 		return shared.PrintJSON(cmd, cells)
+	case shared.OutputFormatCustomColumns:
+		return shared.PrintCustomColumns(cmd, cells, nil)
 	case shared.OutputFormatTable:
 		if len(cells) == 0 {
-			cmd.Println("No cells found.")
+			shared.PrintEmpty(cmd, "No cells found.")
 			return nil
 		}
 		headers := []string{"NAME", "REALM", "SPACE", "STACK", "STATE", "SYNC", "AGE"}
 		if wide {
 			headers = append(headers, "CONTAINERS", "BRIDGE", "DIVERGENCE")
 		}
+		headers = columns.AppendHeaders(headers)
 		now := time.Now()
 		rows := make([][]string, 0, len(cells))
 		for i := range cells {
@@ -328,7 +414,7 @@ func printCells(
 			if wide {
 				row = append(row, renderContainers(c), renderBridge(c), renderDivergence(c))
 			}
-			rows = append(rows, row)
+			rows = append(rows, columns.AppendRow(row, c.Metadata.Labels))
 		}
 		shared.PrintTable(cmd, headers, rows)
 		return nil
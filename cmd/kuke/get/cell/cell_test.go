@@ -22,8 +22,10 @@ import (
 	"errors"
 	"io"
 	"log/slog"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	cell "github.com/eminwux/kukeon/cmd/kuke/get/cell"
 	"github.com/eminwux/kukeon/cmd/types"
@@ -652,11 +654,350 @@ func TestNewCellCmd_Selector(t *testing.T) {
 	})
 }
 
+// TestNewCellCmd_FieldSelector pins the `--field-selector` wiring on
+// `kuke get cell`, alone and combined with `-l`. Grammar coverage lives in
+// the shared fieldselector_test.go.
+func TestNewCellCmd_FieldSelector(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	listFn := func(_, _, _ string) ([]v1beta1.CellDoc, error) {
+		return []v1beta1.CellDoc{
+			{
+				Metadata: v1beta1.CellMetadata{Name: "web-ok", Labels: map[string]string{"role": "web"}},
+				Spec:     v1beta1.CellSpec{RealmID: "r1", SpaceID: "s1", StackID: "st1"},
+				Status:   v1beta1.CellStatus{State: v1beta1.CellStateReady},
+			},
+			{
+				Metadata: v1beta1.CellMetadata{Name: "web-broken", Labels: map[string]string{"role": "web"}},
+				Spec:     v1beta1.CellSpec{RealmID: "r1", SpaceID: "s1", StackID: "st1"},
+				Status:   v1beta1.CellStatus{State: v1beta1.CellStateFailed},
+			},
+			{
+				Metadata: v1beta1.CellMetadata{Name: "db-broken", Labels: map[string]string{"role": "db"}},
+				Spec:     v1beta1.CellSpec{RealmID: "r1", SpaceID: "s1", StackID: "st1"},
+				Status:   v1beta1.CellStatus{State: v1beta1.CellStateFailed},
+			},
+		}, nil
+	}
+
+	run := func(t *testing.T, args ...string) (string, error) {
+		t.Helper()
+		t.Cleanup(viper.Reset)
+		cmd := cell.NewCellCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		ctx := context.WithValue(context.Background(), cell.MockControllerKey{},
+			kukeonv1.Client(&fakeClient{listCellsFn: listFn}))
+		cmd.SetContext(ctx)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	t.Run("status.state combined with a label selector", func(t *testing.T) {
+		out, err := run(t, "--field-selector", "status.state=Failed", "-l", "role=web")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(out, "web-broken") {
+			t.Errorf("expected 'web-broken' in output, got:\n%s", out)
+		}
+		for _, deny := range []string{"web-ok", "db-broken"} {
+			if strings.Contains(out, deny) {
+				t.Errorf("expected %q filtered out, got:\n%s", deny, out)
+			}
+		}
+	})
+
+	t.Run("non-selectable field fails before listing", func(t *testing.T) {
+		_, err := run(t, "--field-selector", "status.cgroupPath=/x")
+		if !errors.Is(err, errdefs.ErrInvalidFieldSelector) {
+			t.Fatalf("expected ErrInvalidFieldSelector, got: %v", err)
+		}
+	})
+
+	t.Run("field selector + name is rejected", func(t *testing.T) {
+		_, err := run(t, "web-ok", "--field-selector", "status.state=Ready")
+		if !errors.Is(err, errdefs.ErrFieldSelectorWithName) {
+			t.Fatalf("expected ErrFieldSelectorWithName, got: %v", err)
+		}
+	})
+}
+
+// TestNewCellCmd_Quiet pins `-q` combined with `-l`: exactly the matching
+// names, one per line, with no header, separator, or status columns.
+func TestNewCellCmd_Quiet(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	listFn := func(_, _, _ string) ([]v1beta1.CellDoc, error) {
+		return []v1beta1.CellDoc{
+			{
+				Metadata: v1beta1.CellMetadata{Name: "web-b", Labels: map[string]string{"app": "web"}},
+				Spec:     v1beta1.CellSpec{RealmID: "r1", SpaceID: "s1", StackID: "st1"},
+				Status:   v1beta1.CellStatus{State: v1beta1.CellStateReady},
+			},
+			{
+				Metadata: v1beta1.CellMetadata{Name: "db", Labels: map[string]string{"app": "db"}},
+				Spec:     v1beta1.CellSpec{RealmID: "r1", SpaceID: "s1", StackID: "st1"},
+			},
+			{
+				Metadata: v1beta1.CellMetadata{Name: "web-a", Labels: map[string]string{"app": "web"}},
+				Spec:     v1beta1.CellSpec{RealmID: "r1", SpaceID: "s1", StackID: "st1"},
+			},
+		}, nil
+	}
+	run := func(t *testing.T, args ...string) (string, error) {
+		t.Helper()
+		t.Cleanup(viper.Reset)
+		cmd := cell.NewCellCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(&bytes.Buffer{})
+		ctx := context.WithValue(context.Background(), cell.MockControllerKey{},
+			kukeonv1.Client(&fakeClient{listCellsFn: listFn}))
+		cmd.SetContext(ctx)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	out, err := run(t, "-l", "app=web", "-q")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "web-a\nweb-b\n"; out != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+
+	out, err = run(t, "-l", "app=none", "--quiet")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != "" {
+		t.Errorf("empty match printed %q, want nothing", out)
+	}
+
+	if _, err = run(t, "-q", "-o", "wide"); !errors.Is(err, errdefs.ErrQuietWithOutput) {
+		t.Fatalf("expected ErrQuietWithOutput, got: %v", err)
+	}
+}
+
+func TestNewCellCmd_LabelColumns(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	listFn := func(_, _, _ string) ([]v1beta1.CellDoc, error) {
+		return []v1beta1.CellDoc{
+			{
+				Metadata: v1beta1.CellMetadata{
+					Name:   "prod-web",
+					Labels: map[string]string{"env": "prod", "kukeon.io/blueprint": "web"},
+				},
+				Spec: v1beta1.CellSpec{RealmID: "r1", SpaceID: "s1", StackID: "st1"},
+			},
+		}, nil
+	}
+	run := func(t *testing.T, args ...string) string {
+		t.Helper()
+		t.Cleanup(viper.Reset)
+		cmd := cell.NewCellCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		ctx := context.WithValue(context.Background(), cell.MockControllerKey{},
+			kukeonv1.Client(&fakeClient{listCellsFn: listFn}))
+		cmd.SetContext(ctx)
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return buf.String()
+	}
+
+	out := run(t, "--show-labels", "-L", "env")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if fields := strings.Fields(lines[0]); !slices.Equal(fields[len(fields)-2:], []string{"ENV", "LABELS"}) {
+		t.Errorf("header = %q, want ENV and LABELS columns last", lines[0])
+	}
+	if fields := strings.Fields(lines[len(lines)-1]); !slices.Equal(fields[len(fields)-2:], []string{"prod", "env=prod"}) {
+		t.Errorf("row = %q, want the env value and the user labels only", lines[len(lines)-1])
+	}
+
+	if out = run(t, "--show-labels=all"); !strings.Contains(out, "env=prod,kukeon.io/blueprint=web") {
+		t.Errorf("--show-labels=all output missing kukeon.io labels:\n%s", out)
+	}
+	if out = run(t); strings.Contains(out, "LABELS") {
+		t.Errorf("LABELS column rendered without --show-labels:\n%s", out)
+	}
+}
+
+func TestNewCellCmd_SortBy(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	listFn := func(_, _, _ string) ([]v1beta1.CellDoc, error) {
+		return []v1beta1.CellDoc{
+			{
+				Metadata: v1beta1.CellMetadata{Name: "bravo"},
+				Spec:     v1beta1.CellSpec{RealmID: "r1", SpaceID: "s1", StackID: "st1"},
+				Status:   v1beta1.CellStatus{State: v1beta1.CellStateStopped, CreatedAt: base},
+			},
+			{
+				Metadata: v1beta1.CellMetadata{Name: "charlie"},
+				Spec:     v1beta1.CellSpec{RealmID: "r1", SpaceID: "s1", StackID: "st1"},
+				Status:   v1beta1.CellStatus{State: v1beta1.CellStateReady, CreatedAt: base.Add(2 * time.Hour)},
+			},
+			{
+				Metadata: v1beta1.CellMetadata{Name: "alpha"},
+				Spec:     v1beta1.CellSpec{RealmID: "r1", SpaceID: "s1", StackID: "st1"},
+				Status:   v1beta1.CellStatus{State: v1beta1.CellStateStopped, CreatedAt: base.Add(time.Hour)},
+			},
+		}, nil
+	}
+
+	cases := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{name: "default sorts by name", args: nil, want: []string{"alpha", "bravo", "charlie"}},
+		{name: "descending createdAt", args: []string{"--sort-by=-createdAt"}, want: []string{"charlie", "alpha", "bravo"}},
+		{name: "state ties break by name", args: []string{"--sort-by", "state"}, want: []string{"charlie", "alpha", "bravo"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			cmd := cell.NewCellCmd()
+			buf := &bytes.Buffer{}
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			ctx := context.WithValue(context.Background(), cell.MockControllerKey{},
+				kukeonv1.Client(&fakeClient{listCellsFn: listFn}))
+			cmd.SetContext(ctx)
+			cmd.SetArgs(tc.args)
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			out := buf.String()
+			last := -1
+			for _, name := range tc.want {
+				idx := strings.Index(out, name)
+				if idx <= last {
+					t.Fatalf("expected order %v, got:\n%s", tc.want, out)
+				}
+				last = idx
+			}
+		})
+	}
+
+	t.Run("unknown field is rejected", func(t *testing.T) {
+		t.Cleanup(viper.Reset)
+		cmd := cell.NewCellCmd()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		ctx := context.WithValue(context.Background(), cell.MockControllerKey{},
+			kukeonv1.Client(&fakeClient{listCellsFn: listFn}))
+		cmd.SetContext(ctx)
+		cmd.SetArgs([]string{"--sort-by", "spec.nope"})
+		err := cmd.Execute()
+		if !errors.Is(err, errdefs.ErrInvalidSortBy) {
+			t.Fatalf("expected ErrInvalidSortBy, got: %v", err)
+		}
+	})
+}
+
+func TestNewCellCmd_Live(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	listFn := func(_, _, _ string) ([]v1beta1.CellDoc, error) {
+		return []v1beta1.CellDoc{
+			{
+				Metadata: v1beta1.CellMetadata{Name: "stale"},
+				Spec:     v1beta1.CellSpec{RealmID: "r1", SpaceID: "s1", StackID: "st1"},
+				Status: v1beta1.CellStatus{
+					State: v1beta1.CellStateReady,
+					Containers: []v1beta1.ContainerStatus{
+						{ID: "root", State: v1beta1.ContainerStateReady},
+					},
+				},
+			},
+			{
+				Metadata: v1beta1.CellMetadata{Name: "offline"},
+				Spec:     v1beta1.CellSpec{RealmID: "r1", SpaceID: "s1", StackID: "st1"},
+				Status:   v1beta1.CellStatus{State: v1beta1.CellStateReady},
+			},
+		}, nil
+	}
+	liveFn := func(doc v1beta1.CellDoc) (kukeonv1.CellLiveStatusResult, error) {
+		if doc.Metadata.Name == "offline" {
+			return kukeonv1.CellLiveStatusResult{State: doc.Status.State}, nil
+		}
+		return kukeonv1.CellLiveStatusResult{
+			State:      v1beta1.CellStateDegraded,
+			Containers: map[string]v1beta1.ContainerState{"root": v1beta1.ContainerStateStopped},
+			Live:       true,
+		}, nil
+	}
+
+	run := func(t *testing.T, args ...string) (string, string) {
+		t.Helper()
+		t.Cleanup(viper.Reset)
+		out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+		cmd := cell.NewCellCmd()
+		cmd.SetOut(out)
+		cmd.SetErr(errOut)
+		cmd.SetContext(context.WithValue(context.Background(), cell.MockControllerKey{},
+			kukeonv1.Client(&fakeClient{listCellsFn: listFn, cellLiveStatusFn: liveFn})))
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return out.String(), errOut.String()
+	}
+
+	t.Run("stale cell renders degraded", func(t *testing.T) {
+		out, errOut := run(t, "--live", "-o", "wide")
+		var staleRow, offlineRow string
+		for _, line := range strings.Split(out, "\n") {
+			switch {
+			case strings.HasPrefix(line, "stale"):
+				staleRow = line
+			case strings.HasPrefix(line, "offline"):
+				offlineRow = line
+			}
+		}
+		if !strings.Contains(staleRow, "Degraded") || !strings.Contains(staleRow, "0/1") {
+			t.Errorf("expected stale row Degraded with 0/1 containers ready, got %q", staleRow)
+		}
+		if !strings.Contains(offlineRow, "Ready") {
+			t.Errorf("expected offline row to keep its persisted Ready, got %q", offlineRow)
+		}
+		if !strings.Contains(errOut, "containerd unreachable") {
+			t.Errorf("expected an unreachable warning, got %q", errOut)
+		}
+	})
+
+	t.Run("without --live the persisted state is shown", func(t *testing.T) {
+		out, _ := run(t)
+		if strings.Contains(out, "Degraded") {
+			t.Errorf("expected no live query without --live, got:\n%s", out)
+		}
+	})
+}
+
 type fakeClient struct {
 	kukeonv1.FakeClient
 
-	getCellFn   func(doc v1beta1.CellDoc) (kukeonv1.GetCellResult, error)
-	listCellsFn func(realm, space, stack string) ([]v1beta1.CellDoc, error)
+	getCellFn        func(doc v1beta1.CellDoc) (kukeonv1.GetCellResult, error)
+	listCellsFn      func(realm, space, stack string) ([]v1beta1.CellDoc, error)
+	cellLiveStatusFn func(doc v1beta1.CellDoc) (kukeonv1.CellLiveStatusResult, error)
+}
+
+func (f *fakeClient) CellLiveStatus(_ context.Context, doc v1beta1.CellDoc) (kukeonv1.CellLiveStatusResult, error) {
+	if f.cellLiveStatusFn == nil {
+		return kukeonv1.CellLiveStatusResult{}, errors.New("unexpected CellLiveStatus call")
+	}
+	return f.cellLiveStatusFn(doc)
 }
 
 func (f *fakeClient) GetCell(_ context.Context, doc v1beta1.CellDoc) (kukeonv1.GetCellResult, error) {
@@ -672,3 +1013,73 @@ func (f *fakeClient) ListCells(_ context.Context, realm, space, stack string) ([
 	}
 	return f.listCellsFn(realm, space, stack)
 }
+
+func TestNewCellCmd_CustomColumns(t *testing.T) {
+	listFn := func(_, _, _ string) ([]v1beta1.CellDoc, error) {
+		return []v1beta1.CellDoc{
+			{
+				Metadata: v1beta1.CellMetadata{Name: "web"},
+				Spec: v1beta1.CellSpec{
+					RealmID:    "r1",
+					SpaceID:    "s1",
+					StackID:    "st1",
+					Containers: []v1beta1.ContainerSpec{{ID: "app", Image: "nginx:1.27"}},
+				},
+				Status: v1beta1.CellStatus{State: v1beta1.CellStateReady},
+			},
+			{
+				Metadata: v1beta1.CellMetadata{Name: "db"},
+				Spec:     v1beta1.CellSpec{RealmID: "r1", SpaceID: "s1", StackID: "st1"},
+				Status:   v1beta1.CellStatus{State: v1beta1.CellStateStopped},
+			},
+		}, nil
+	}
+	run := func(t *testing.T, args ...string) (string, error) {
+		t.Helper()
+		t.Cleanup(viper.Reset)
+		cmd := cell.NewCellCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(&bytes.Buffer{})
+		ctx := context.WithValue(context.Background(), cell.MockControllerKey{},
+			kukeonv1.Client(&fakeClient{listCellsFn: listFn}))
+		cmd.SetContext(ctx)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	out, err := run(t, "-o", "custom-columns=NAME:.metadata.name,IMAGE:.spec.containers[0].image")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "NAME  IMAGE     \n" +
+		"----  ----------\n" +
+		"db    <none>    \n" +
+		"web   nginx:1.27\n"
+	if out != want {
+		t.Errorf("output =\n%s\nwant\n%s", out, want)
+	}
+
+	out, err = run(t, "--no-headers", "--sort-by", "-name",
+		"-o", "custom-columns=NAME:.metadata.name,STATE:.status.state")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want = "web  Ready  \ndb   Stopped\n"; out != want {
+		t.Errorf("--no-headers output = %q, want %q", out, want)
+	}
+
+	out, err = run(t, "--no-headers")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(out), "\n"); len(lines) != 2 || strings.Contains(out, "NAME") {
+		t.Errorf("--no-headers table = %q, want two rows and no header", out)
+	}
+
+	if _, err = run(t, "-o", "custom-columns=IMAGE:.spec.containers[3].image"); !errors.Is(
+		err, errdefs.ErrInvalidCustomColumns) {
+		t.Errorf("unknown field error = %v, want ErrInvalidCustomColumns", err)
+	}
+}
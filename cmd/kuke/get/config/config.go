@@ -66,6 +66,11 @@ func NewConfigCmd() *cobra.Command {
 			space := shared.ExplicitFlag(cmd, "space", config.KUKE_GET_CONFIG_SPACE.ViperKey)
 			stack := shared.ExplicitFlag(cmd, "stack", config.KUKE_GET_CONFIG_STACK.ViperKey)
 
+			sortBy, err := shared.ParseSortByFlag(cmd)
+			if err != nil {
+				return err
+			}
+
 			var name string
 			if len(args) > 0 {
 				name = strings.TrimSpace(args[0])
@@ -121,6 +126,9 @@ func NewConfigCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if err = shared.SortItems(configs, sortBy, nil); err != nil {
+				return err
+			}
 			return printConfigs(cmd, configs, outputFormat)
 		},
 	}
@@ -132,9 +140,13 @@ func NewConfigCmd() *cobra.Command {
 	cmd.Flags().String("stack", "", "Filter configs by stack name")
 	_ = viper.BindPFlag(config.KUKE_GET_CONFIG_STACK.ViperKey, cmd.Flags().Lookup("stack"))
 	cmd.Flags().
-		StringP("output", "o", "", "Output format (yaml, json, table, wide). Default: table for list, table for single resource")
+		StringP("output", "o", "", "Output format (yaml, json, table, wide, custom-columns=<spec>). "+
+			"Default: table for list, table for single resource")
 	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("output"))
 	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("o"))
+	shared.RegisterSortByFlag(cmd)
+	shared.RegisterQuietFlag(cmd)
+	shared.RegisterNoHeadersFlag(cmd)
 
 	cmd.ValidArgsFunction = config.CompleteConfigNames
 	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
@@ -167,18 +179,18 @@ func configNotFoundErr(
 	)
 	configs, err := client.ListConfigs(cmd.Context(), realm, "", "")
 	if err != nil {
-		return errors.New(base)
+		return fmt.Errorf("%s: %w", base, errdefs.ErrConfigNotFound)
 	}
 	for i := range configs {
 		m := &configs[i].Metadata
 		if m.Name == name && (m.Realm != realm || m.Space != space || m.Stack != stack) {
 			return fmt.Errorf(
-				"%s; a config %q exists at realm=%q space=%q stack=%q",
-				base, name, m.Realm, m.Space, m.Stack,
+				"%s; a config %q exists at realm=%q space=%q stack=%q: %w",
+				base, name, m.Realm, m.Space, m.Stack, errdefs.ErrConfigNotFound,
 			)
 		}
 	}
-	return errors.New(base)
+	return fmt.Errorf("%s: %w", base, errdefs.ErrConfigNotFound)
 }
 
 func printConfig(cmd *cobra.Command, cfg *v1beta1.CellConfigDoc, format shared.OutputFormat) error {
@@ -200,9 +212,11 @@ func printConfigs(cmd *cobra.Command, configs []v1beta1.CellConfigDoc, format sh
 		return shared.PrintYAML(cmd, configs)
 	case shared.OutputFormatJSON:
 		return shared.PrintJSON(cmd, configs)
+	case shared.OutputFormatCustomColumns:
+		return shared.PrintCustomColumns(cmd, configs, nil)
 	case shared.OutputFormatTable, shared.OutputFormatWide:
 		if len(configs) == 0 {
-			cmd.Println("No configs found.")
+			shared.PrintEmpty(cmd, "No configs found.")
 			return nil
 		}
 		headers := []string{"NAME", "REALM", "SPACE", "STACK"}
@@ -48,15 +48,26 @@ func NewContainerCmd() *cobra.Command {
 		Long: `Get or list container information.
 
 The default table is ` + "`NAME REALM SPACE STACK CELL STATE RESTARTS AGE`" + `.
-` + "`-o wide`" + ` appends two container-only signals:
+` + "`-o wide`" + ` appends container-only signals:
 
-  IMAGE  spec.image (the resolved container image reference)
-  EXIT   ` + "`<exitCode>/<exitSignal>`" + ` when either field is non-zero/
-         non-empty; "-" otherwise — most meaningful on Stopped/Failed.
+  IMAGE     spec.image (the resolved container image reference)
+  STARTED   time since the current run was first observed Ready; "-" if never
+  FINISHED  time since the last exit recorded from containerd; "-" while
+            running or if it never exited
+  EXIT      ` + "`<exitCode>/<exitSignal>`" + ` when either field is non-zero/
+            non-empty; "-" otherwise — most meaningful on Stopped/Failed.
+            Suffixed with ",OOMKilled" when the last exit was an OOM kill.
 
 CGROUP, ROOT (as a column), and IMAGE (as a default column) no longer
 appear in the default table — use ` + "`-o yaml` / `-o json`" + ` for the
-full container spec.`,
+full container spec.
+
+With no --realm/--space/--stack/--cell filter, or with ` + "`-A`/`--all`" + `, the
+list covers every realm, space, and stack.
+
+--chunk-size N reads the store N containers at a time and prints each page's
+rows as it arrives; --limit N stops after N containers. An explicit --sort-by
+or -o yaml/json still pages, but prints once every page is in.`,
 		Args:          cobra.MaximumNArgs(1),
 		SilenceUsage:  true,
 		SilenceErrors: false,
@@ -72,11 +83,18 @@ full container spec.`,
 	cmd.Flags().String("cell", "", "Filter containers by cell name")
 	_ = viper.BindPFlag(config.KUKE_GET_CONTAINER_CELL.ViperKey, cmd.Flags().Lookup("cell"))
 	cmd.Flags().
-		StringP("output", "o", "", "Output format (yaml, json, table, wide). Default: table for list, table for single resource")
+		StringP("output", "o", "", "Output format (yaml, json, table, wide, custom-columns=<spec>). "+
+			"Default: table for list, table for single resource")
 	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("output"))
 	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("o"))
 
 	shared.RegisterLabelSelectorFlag(cmd)
+	shared.RegisterLabelColumnFlags(cmd)
+	shared.RegisterSortByFlag(cmd)
+	shared.RegisterQuietFlag(cmd)
+	shared.RegisterNoHeadersFlag(cmd)
+	shared.RegisterAllScopesFlag(cmd)
+	shared.RegisterChunkFlags(cmd)
 
 	cmd.ValidArgsFunction = config.CompleteContainerNames
 	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
@@ -105,6 +123,21 @@ func runContainerCmd(cmd *cobra.Command, args []string) error {
 	stack := shared.ExplicitFlag(cmd, "stack", config.KUKE_GET_CONTAINER_STACK.ViperKey)
 	cell := shared.ExplicitFlag(cmd, "cell", config.KUKE_GET_CONTAINER_CELL.ViperKey)
 
+	sortBy, err := shared.ParseSortByFlag(cmd)
+	if err != nil {
+		return err
+	}
+
+	columns, err := shared.ParseLabelColumnsFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	chunking, err := shared.ParseChunkFlags(cmd)
+	if err != nil {
+		return err
+	}
+
 	var name string
 	if len(args) > 0 {
 		name = strings.TrimSpace(args[0])
@@ -115,6 +148,9 @@ func runContainerCmd(cmd *cobra.Command, args []string) error {
 	if name != "" && !selector.Empty() {
 		return errdefs.ErrSelectorWithName
 	}
+	if err = shared.ValidateAllScopesFlag(cmd, name, "realm", "space", "stack", "cell"); err != nil {
+		return err
+	}
 
 	client, err := resolveClient(cmd)
 	if err != nil {
@@ -169,7 +205,7 @@ func runContainerCmd(cmd *cobra.Command, args []string) error {
 			return err
 		}
 		if !result.ContainerExists {
-			return fmt.Errorf("container %q not found", name)
+			return fmt.Errorf("container %q not found: %w", name, errdefs.ErrContainerNotFound)
 		}
 
 		if outputFormat == shared.OutputFormatYAML || outputFormat == shared.OutputFormatJSON {
@@ -202,8 +238,11 @@ func runContainerCmd(cmd *cobra.Command, args []string) error {
 				state:        containerStateToString(st.State),
 				restartCount: st.RestartCount,
 				createdAt:    st.CreatedAt,
+				startTime:    st.StartTime,
+				finishTime:   st.FinishTime,
 				exitCode:     st.ExitCode,
 				exitSignal:   st.ExitSignal,
+				oomKilled:    st.OOMKilled,
 				labels:       result.Container.Metadata.Labels,
 			},
 		}
@@ -214,9 +253,25 @@ func runContainerCmd(cmd *cobra.Command, args []string) error {
 			outputFormat,
 			wide,
 			"",
+			columns,
 		)
 	}
 
+	list := containerListQuery{
+		realm:    realm,
+		space:    space,
+		stack:    stack,
+		cell:     cell,
+		selector: selector,
+		sortBy:   sortBy,
+		format:   outputFormat,
+		wide:     wide,
+		columns:  columns,
+	}
+	if chunking.Enabled() {
+		return listContainersChunked(cmd, client, list, chunking)
+	}
+
 	// List path — query each container's state by calling GetContainer.
 	specs, err := client.ListContainers(cmd.Context(), realm, space, stack, cell)
 	if err != nil {
@@ -225,33 +280,87 @@ func runContainerCmd(cmd *cobra.Command, args []string) error {
 
 	emptyMsg := noContainersFoundMsg
 	if len(specs) == 0 && outputFormat == shared.OutputFormatTable {
-		emptyMsg = buildEmptyResultMessage(realm, space, stack, cell)
-		if hint := maybeBuildScopeHint(cmd.Context(), client, space, stack, cell); hint != "" {
-			emptyMsg = emptyMsg + "\n" + hint
-		}
+		emptyMsg = list.emptyMessage(cmd.Context(), client)
+	}
+
+	containerProbes := list.probe(cmd, client, specs)
+	specs = list.filter(specs, containerProbes)
+
+	if err = shared.SortItems(specs, sortBy, func(spec *v1beta1.ContainerSpec) any {
+		return containerSortView(spec, containerProbes[spec.ID])
+	}); err != nil {
+		return err
+	}
+
+	return printContainersWithState(
+		cmd,
+		specs,
+		containerProbes,
+		outputFormat,
+		wide,
+		emptyMsg,
+		columns,
+	)
+}
+
+// containerListQuery is the resolved filter and output state of a list
+// invocation, shared by the one-shot and the --chunk-size/--limit paths.
+type containerListQuery struct {
+	realm, space, stack, cell string
+	selector                  *shared.LabelSelector
+	sortBy                    shared.SortBy
+	format                    shared.OutputFormat
+	wide                      bool
+	columns                   shared.LabelColumns
+}
+
+// emptyMessage is the table's zero-row line: the queried filter set plus,
+// when the named scope exists elsewhere, a hint pointing at it.
+func (q containerListQuery) emptyMessage(ctx context.Context, client kukeonv1.Client) string {
+	msg := buildEmptyResultMessage(q.realm, q.space, q.stack, q.cell)
+	if hint := maybeBuildScopeHint(ctx, client, q.space, q.stack, q.cell); hint != "" {
+		msg = msg + "\n" + hint
 	}
+	return msg
+}
 
-	// containerProbes carries per-container Metadata.Labels alongside state
-	// for the post-loop selector filter: ListContainers returns
-	// ContainerSpec (which carries no labels), so the filter has to wait
-	// until each container's ContainerDoc is in hand. When the GetContainer
-	// probe fails the labels stay nil — the selector then treats that
-	// container as "no labels", which is the same conservative call
-	// ContainerStateUnknown already makes for state.
+// probe queries each container's state by calling GetContainer.
+//
+// The probes carry per-container Metadata.Labels alongside state for the
+// selector filter: ListContainers returns ContainerSpec (which carries no
+// labels), so the filter has to wait until each container's ContainerDoc is
+// in hand. When the GetContainer probe fails the labels stay nil — the
+// selector then treats that container as "no labels", which is the same
+// conservative call ContainerStateUnknown already makes for state.
+//
+// yaml/json print the bare specs, so without a selector or a status sort
+// key the probes (one GetContainer, and so one containerd round-trip, per
+// container) are skipped — an `-A -o yaml` over a large store stays
+// metadata-only. Custom columns may name status fields, so they probe like
+// the table does.
+func (q containerListQuery) probe(
+	cmd *cobra.Command,
+	client kukeonv1.Client,
+	specs []v1beta1.ContainerSpec,
+) map[string]containerProbe {
 	containerProbes := make(map[string]containerProbe, len(specs))
+	if q.format != shared.OutputFormatTable && q.format != shared.OutputFormatCustomColumns &&
+		q.selector.Empty() && !q.sortBy.NeedsStatus() {
+		return containerProbes
+	}
 	for i := range specs {
 		spec := specs[i]
 		if spec.RealmID == "" {
-			spec.RealmID = realm
+			spec.RealmID = q.realm
 		}
 		if spec.SpaceID == "" {
-			spec.SpaceID = space
+			spec.SpaceID = q.space
 		}
 		if spec.StackID == "" {
-			spec.StackID = stack
+			spec.StackID = q.stack
 		}
 		if spec.CellID == "" {
-			spec.CellID = cell
+			spec.CellID = q.cell
 		}
 		probe := v1beta1.ContainerDoc{
 			Metadata: v1beta1.ContainerMetadata{Name: spec.ID},
@@ -268,45 +377,138 @@ func runContainerCmd(cmd *cobra.Command, args []string) error {
 			state:        containerStateToString(st.State),
 			restartCount: st.RestartCount,
 			createdAt:    st.CreatedAt,
+			startTime:    st.StartTime,
+			finishTime:   st.FinishTime,
 			exitCode:     st.ExitCode,
 			exitSignal:   st.ExitSignal,
+			oomKilled:    st.OOMKilled,
 			labels:       probeResult.Container.Metadata.Labels,
 		}
 	}
+	return containerProbes
+}
 
-	if !selector.Empty() {
-		filtered := make([]v1beta1.ContainerSpec, 0, len(specs))
-		for i := range specs {
-			if selector.Matches(containerProbes[specs[i].ID].labels) {
-				filtered = append(filtered, specs[i])
-			}
+// filter drops the specs whose probed labels do not match --selector.
+func (q containerListQuery) filter(
+	specs []v1beta1.ContainerSpec,
+	probes map[string]containerProbe,
+) []v1beta1.ContainerSpec {
+	if q.selector.Empty() {
+		return specs
+	}
+	filtered := make([]v1beta1.ContainerSpec, 0, len(specs))
+	for i := range specs {
+		if q.selector.Matches(probes[specs[i].ID].labels) {
+			filtered = append(filtered, specs[i])
 		}
-		specs = filtered
 	}
+	return filtered
+}
 
-	return printContainersWithState(
-		cmd,
-		specs,
-		containerProbes,
-		outputFormat,
-		wide,
-		emptyMsg,
+// listContainersChunked walks the store one ListContainersPage request at a
+// time. A plain table (no explicit --sort-by) is streamed: each page is
+// probed, filtered and printed before the next is fetched, so the first
+// rows of a large `-A` listing show up without waiting for the whole walk.
+// yaml/json and explicitly sorted tables need every row before printing,
+// so they accumulate the pages and render once — still bounded by --limit.
+func listContainersChunked(
+	cmd *cobra.Command,
+	client kukeonv1.Client,
+	q containerListQuery,
+	chunking shared.Chunking,
+) error {
+	stream := q.format == shared.OutputFormatTable && !cmd.Flags().Changed(shared.SortByFlagName)
+	var (
+		table    *shared.TableStream
+		kept     []v1beta1.ContainerSpec
+		probes   = map[string]containerProbe{}
+		printed  int
+		token    string
+		anyFound bool
 	)
+	if stream {
+		table = shared.NewTableStream(cmd, containerTableHeaders(q.wide, q.columns))
+	}
+	for {
+		page, err := client.ListContainersPage(
+			cmd.Context(), q.realm, q.space, q.stack, q.cell, chunking.PageSize(), token,
+		)
+		if err != nil {
+			return err
+		}
+		anyFound = anyFound || len(page.Containers) > 0
+		pageProbes := q.probe(cmd, client, page.Containers)
+		specs := q.filter(page.Containers, pageProbes)
+		if remaining := chunking.Remaining(printed); remaining >= 0 && len(specs) > remaining {
+			specs = specs[:remaining]
+		}
+		printed += len(specs)
+		if stream {
+			table.Write(containerTableRows(specs, pageProbes, q.wide, q.columns, time.Now()))
+		} else {
+			kept = append(kept, specs...)
+			for id, p := range pageProbes {
+				probes[id] = p
+			}
+		}
+		if page.Continue == "" || chunking.Remaining(printed) == 0 {
+			break
+		}
+		token = page.Continue
+	}
+
+	emptyMsg := noContainersFoundMsg
+	if !anyFound && q.format == shared.OutputFormatTable {
+		emptyMsg = q.emptyMessage(cmd.Context(), client)
+	}
+	if stream {
+		if !table.Started() {
+			shared.PrintEmpty(cmd, emptyMsg)
+		}
+		return nil
+	}
+	if err := shared.SortItems(kept, q.sortBy, func(spec *v1beta1.ContainerSpec) any {
+		return containerSortView(spec, probes[spec.ID])
+	}); err != nil {
+		return err
+	}
+	return printContainersWithState(cmd, kept, probes, q.format, q.wide, emptyMsg, q.columns)
 }
 
 // containerProbe carries the per-container fields a list-path probe pulls
 // from GetContainer for the table renderer. State is the human-readable
-// label; the rest source the RESTARTS / AGE / EXIT columns. labels feeds
-// the post-loop selector filter — see runContainerCmd's probe loop.
+// label; the rest source the RESTARTS / AGE / STARTED / FINISHED / EXIT
+// columns. labels feeds
+// the post-loop selector filter — see runContainerCmd's probe loop — and
+// the -L / --show-labels columns.
 type containerProbe struct {
 	state        string
 	restartCount int
 	createdAt    time.Time
+	startTime    time.Time
+	finishTime   time.Time
 	exitCode     int
 	exitSignal   string
+	oomKilled    bool
 	labels       map[string]string
 }
 
+// containerSortView is the shape --sort-by and -o custom-columns read for a
+// container row: the spec plus the probed status, under the same metadata/spec/status keys as
+// the other kinds' docs so name, createdAt, and state resolve alike.
+func containerSortView(spec *v1beta1.ContainerSpec, probe containerProbe) any {
+	return map[string]any{
+		"metadata": map[string]any{"name": spec.ID, "labels": probe.labels},
+		"spec":     spec,
+		"status": map[string]any{
+			"state":        probe.state,
+			"restartCount": probe.restartCount,
+			"createdAt":    probe.createdAt,
+			"exitCode":     probe.exitCode,
+		},
+	}
+}
+
 // buildEmptyResultMessage describes the queried filter set when zero rows
 // match, so the operator sees what filter actually fired instead of a bare
 // "No containers found." Empty filters are omitted.
@@ -429,54 +631,85 @@ func printContainersWithState(
 	format shared.OutputFormat,
 	wide bool,
 	emptyMsg string,
+	columns shared.LabelColumns,
 ) error {
 	switch format {
 	case shared.OutputFormatYAML:
 		return shared.PrintYAML(cmd, containers)
 	case shared.OutputFormatJSON:
 		return shared.PrintJSON(cmd, containers)
+	case shared.OutputFormatCustomColumns:
+		return shared.PrintCustomColumns(cmd, containers, func(spec *v1beta1.ContainerSpec) any {
+			return containerSortView(spec, probes[spec.ID])
+		})
 	case shared.OutputFormatTable:
 		if len(containers) == 0 {
 			if emptyMsg == "" {
 				emptyMsg = noContainersFoundMsg
 			}
-			cmd.Println(emptyMsg)
+			shared.PrintEmpty(cmd, emptyMsg)
 			return nil
 		}
-		headers := []string{"NAME", "REALM", "SPACE", "STACK", "CELL", "STATE", "RESTARTS", "AGE"}
-		if wide {
-			headers = append(headers, "IMAGE", "EXIT")
-		}
-		now := time.Now()
-		rows := make([][]string, 0, len(containers))
-		for i := range containers {
-			c := &containers[i]
-			p, ok := probes[c.ID]
-			if !ok {
-				p = containerProbe{state: "Unknown"}
-			}
-			row := []string{
-				containerDisplayName(c),
-				c.RealmID,
-				c.SpaceID,
-				c.StackID,
-				c.CellID,
-				p.state,
-				strconv.Itoa(p.restartCount),
-				shared.RenderAge(p.createdAt, now),
-			}
-			if wide {
-				row = append(row, c.Image, renderExit(p.exitCode, p.exitSignal))
-			}
-			rows = append(rows, row)
-		}
-		shared.PrintTable(cmd, headers, rows)
+		shared.PrintTable(
+			cmd,
+			containerTableHeaders(wide, columns),
+			containerTableRows(containers, probes, wide, columns, time.Now()),
+		)
 		return nil
 	default:
 		return shared.PrintYAML(cmd, containers)
 	}
 }
 
+// containerTableHeaders is the list table's header row for the given
+// -o wide / -L / --show-labels combination.
+func containerTableHeaders(wide bool, columns shared.LabelColumns) []string {
+	headers := []string{"NAME", "REALM", "SPACE", "STACK", "CELL", "STATE", "RESTARTS", "AGE"}
+	if wide {
+		headers = append(headers, "IMAGE", "STARTED", "FINISHED", "EXIT")
+	}
+	return columns.AppendHeaders(headers)
+}
+
+// containerTableRows renders one table row per container, matching
+// containerTableHeaders. A container without a probe renders as Unknown.
+func containerTableRows(
+	containers []v1beta1.ContainerSpec,
+	probes map[string]containerProbe,
+	wide bool,
+	columns shared.LabelColumns,
+	now time.Time,
+) [][]string {
+	rows := make([][]string, 0, len(containers))
+	for i := range containers {
+		c := &containers[i]
+		p, ok := probes[c.ID]
+		if !ok {
+			p = containerProbe{state: "Unknown"}
+		}
+		row := []string{
+			containerDisplayName(c),
+			c.RealmID,
+			c.SpaceID,
+			c.StackID,
+			c.CellID,
+			p.state,
+			strconv.Itoa(p.restartCount),
+			shared.RenderAge(p.createdAt, now),
+		}
+		if wide {
+			row = append(row,
+				c.Image,
+				shared.RenderAge(p.startTime, now),
+				shared.RenderAge(p.finishTime, now),
+				renderExit(p.exitCode, p.exitSignal, p.oomKilled),
+			)
+		}
+		rows = append(rows, columns.AppendRow(row, p.labels))
+	}
+	return rows
+}
+
 // resolveOutput sits between the cobra flag and ParseOutputFormat so the
 // `wide` value is normalised to `table` plus a bool, leaving the shared
 // yaml/json/table parser untouched. Mirrors the helper in cmd/kuke/get/cell.
@@ -493,12 +726,17 @@ func resolveOutput(cmd *cobra.Command) (bool, shared.OutputFormat, error) {
 
 // renderExit returns the EXIT column value — `<code>/<signal>` when either
 // field is non-zero/non-empty, "-" when both are at their zero values.
-// Most meaningful on Stopped/Failed states. Issue #605.
-func renderExit(code int, signal string) string {
-	if code == 0 && signal == "" {
+// Most meaningful on Stopped/Failed states. Issue #605. A ",OOMKilled"
+// suffix marks an exit caused by the kernel OOM killer.
+func renderExit(code int, signal string, oomKilled bool) string {
+	if code == 0 && signal == "" && !oomKilled {
 		return "-"
 	}
-	return fmt.Sprintf("%d/%s", code, signal)
+	exit := fmt.Sprintf("%d/%s", code, signal)
+	if oomKilled {
+		exit += ",OOMKilled"
+	}
+	return exit
 }
 
 func containerStateToString(state v1beta1.ContainerState) string {
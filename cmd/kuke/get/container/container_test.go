@@ -20,8 +20,10 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -487,7 +489,7 @@ func TestNewContainerCmd_DefaultColumns(t *testing.T) {
 
 // TestNewContainerCmd_WideColumns pins the `-o wide` column set after the
 // epic:get redefinition: NAME REALM SPACE STACK CELL STATE RESTARTS AGE
-// IMAGE EXIT — ten columns. CGROUP / ROOT must NOT appear.
+// IMAGE STARTED FINISHED EXIT — twelve columns. CGROUP / ROOT must NOT appear.
 func TestNewContainerCmd_WideColumns(t *testing.T) {
 	t.Cleanup(viper.Reset)
 
@@ -503,6 +505,8 @@ func TestNewContainerCmd_WideColumns(t *testing.T) {
 					State:        v1beta1.ContainerStateStopped,
 					RestartCount: 1,
 					CreatedAt:    time.Now().Add(-30 * time.Minute),
+					StartTime:    time.Now().Add(-20 * time.Minute),
+					FinishTime:   time.Now().Add(-5 * time.Minute),
 					ExitCode:     137,
 					ExitSignal:   "SIGKILL",
 				},
@@ -526,7 +530,9 @@ func TestNewContainerCmd_WideColumns(t *testing.T) {
 	}
 
 	out := buf.String()
-	for _, h := range []string{"NAME", "REALM", "SPACE", "STACK", "CELL", "STATE", "RESTARTS", "AGE", "IMAGE", "EXIT"} {
+	for _, h := range []string{
+		"NAME", "REALM", "SPACE", "STACK", "CELL", "STATE", "RESTARTS", "AGE", "IMAGE", "STARTED", "FINISHED", "EXIT",
+	} {
 		if !strings.Contains(out, h) {
 			t.Errorf("-o wide table missing header %q\nGot:\n%s", h, out)
 		}
@@ -536,7 +542,7 @@ func TestNewContainerCmd_WideColumns(t *testing.T) {
 			t.Errorf("-o wide table must NOT contain %q; got:\n%s", denied, out)
 		}
 	}
-	for _, sub := range []string{"co1", "nginx:alpine", "137/SIGKILL"} {
+	for _, sub := range []string{"co1", "nginx:alpine", "20m", "5m", "137/SIGKILL"} {
 		if !strings.Contains(out, sub) {
 			t.Errorf("-o wide row missing %q\nGot:\n%s", sub, out)
 		}
@@ -547,7 +553,7 @@ func TestNewContainerCmd_WideColumns(t *testing.T) {
 // #605's AC: `<code>/<signal>` when either field is non-zero/non-empty;
 // "-" when both are zero. Cases: both zero -> "-"; code only -> "139/";
 // signal only -> "0/SIGTERM"; both -> "137/SIGKILL". Most meaningful on
-// Stopped/Failed.
+// Stopped/Failed. An OOM-killed exit appends ",OOMKilled".
 func TestNewContainerCmd_ExitRendering(t *testing.T) {
 	t.Cleanup(viper.Reset)
 
@@ -555,12 +561,14 @@ func TestNewContainerCmd_ExitRendering(t *testing.T) {
 		name       string
 		exitCode   int
 		exitSignal string
+		oomKilled  bool
 		wantSub    string
 	}{
-		{"both zero renders dash", 0, "", " - "},
-		{"code only", 139, "", "139/"},
-		{"signal only", 0, "SIGTERM", "0/SIGTERM"},
-		{"both set", 137, "SIGKILL", "137/SIGKILL"},
+		{"both zero renders dash", 0, "", false, " - "},
+		{"code only", 139, "", false, "139/"},
+		{"signal only", 0, "SIGTERM", false, "0/SIGTERM"},
+		{"both set", 137, "SIGKILL", false, "137/SIGKILL"},
+		{"oom killed", 137, "SIGKILL", true, "137/SIGKILL,OOMKilled"},
 	}
 
 	for _, tt := range tests {
@@ -579,6 +587,7 @@ func TestNewContainerCmd_ExitRendering(t *testing.T) {
 							State:      v1beta1.ContainerStateStopped,
 							ExitCode:   tt.exitCode,
 							ExitSignal: tt.exitSignal,
+							OOMKilled:  tt.oomKilled,
 						},
 					},
 					ContainerExists: true,
@@ -791,11 +800,203 @@ func TestNewContainerCmd_Selector(t *testing.T) {
 	})
 }
 
+func TestNewContainerCmd_AllScopes(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	listFn := func(realm, space, stack, cell string) ([]v1beta1.ContainerSpec, error) {
+		if realm != "" || space != "" || stack != "" || cell != "" {
+			return nil, fmt.Errorf("unexpected scope filter %q/%q/%q/%q", realm, space, stack, cell)
+		}
+		return []v1beta1.ContainerSpec{
+			{ID: "web", RealmID: "alpha", SpaceID: "s1", StackID: "st1", CellID: "c1"},
+			{ID: "db", RealmID: "beta", SpaceID: "s2", StackID: "st2", CellID: "c2"},
+		}, nil
+	}
+	getFn := func(doc v1beta1.ContainerDoc) (kukeonv1.GetContainerResult, error) {
+		return kukeonv1.GetContainerResult{
+			Container: v1beta1.ContainerDoc{
+				Metadata: v1beta1.ContainerMetadata{Name: doc.Metadata.Name},
+				Spec:     doc.Spec,
+				Status:   v1beta1.ContainerStatus{State: v1beta1.ContainerStateReady},
+			},
+			ContainerExists: true,
+		}, nil
+	}
+
+	run := func(t *testing.T, client kukeonv1.Client, args ...string) (string, error) {
+		t.Helper()
+		t.Cleanup(viper.Reset)
+		cmd := container.NewContainerCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetContext(context.WithValue(context.Background(), container.MockControllerKey{}, client))
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	t.Run("lists every realm with scope columns", func(t *testing.T) {
+		out, err := run(t, &fakeClient{listContainersFn: listFn, getContainerFn: getFn}, "-A")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, want := range []string{"REALM", "alpha", "s1", "c1", "beta", "s2", "c2", "Ready"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("expected %q in output, got:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("yaml output skips state probes", func(t *testing.T) {
+		// No getContainerFn: a probe would surface a warning in the output.
+		out, err := run(t, &fakeClient{listContainersFn: listFn}, "--all", "-o", "yaml")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.Contains(out, "Warning") {
+			t.Errorf("expected no GetContainer probes, got:\n%s", out)
+		}
+		if !strings.Contains(out, "realmId: beta") {
+			t.Errorf("expected the beta container in output, got:\n%s", out)
+		}
+	})
+
+	t.Run("combined with a scope flag is rejected", func(t *testing.T) {
+		_, err := run(t, &fakeClient{}, "-A", "--realm", "alpha")
+		if !errors.Is(err, errdefs.ErrAllWithScope) {
+			t.Fatalf("expected ErrAllWithScope, got: %v", err)
+		}
+	})
+}
+
+// TestNewContainerCmd_Chunked pins the --chunk-size / --limit wiring: the
+// list is walked one ListContainersPage request at a time, every container
+// is printed exactly once, and --limit stops the walk early.
+func TestNewContainerCmd_Chunked(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	const total = 25
+	all := make([]v1beta1.ContainerSpec, 0, total)
+	for i := range total {
+		all = append(all, v1beta1.ContainerSpec{
+			ID: fmt.Sprintf("c%02d", i), RealmID: "r1", SpaceID: "s1", StackID: "st1", CellID: "cell",
+		})
+	}
+	newClient := func(requests *[]int) *fakeClient {
+		return &fakeClient{
+			listPageFn: func(limit int, token string) (kukeonv1.ListContainersPageResult, error) {
+				*requests = append(*requests, limit)
+				start := 0
+				if token != "" {
+					if _, err := fmt.Sscanf(token, "%d", &start); err != nil {
+						return kukeonv1.ListContainersPageResult{}, errdefs.ErrInvalidContinueToken
+					}
+				}
+				end := min(start+limit, total)
+				result := kukeonv1.ListContainersPageResult{Containers: all[start:end]}
+				if end < total {
+					result.Continue = strconv.Itoa(end)
+				}
+				return result, nil
+			},
+			getContainerFn: func(doc v1beta1.ContainerDoc) (kukeonv1.GetContainerResult, error) {
+				return kukeonv1.GetContainerResult{
+					Container: v1beta1.ContainerDoc{
+						Metadata: v1beta1.ContainerMetadata{Name: doc.Metadata.Name},
+						Spec:     doc.Spec,
+						Status:   v1beta1.ContainerStatus{State: v1beta1.ContainerStateReady},
+					},
+					ContainerExists: true,
+				}, nil
+			},
+		}
+	}
+	run := func(t *testing.T, client kukeonv1.Client, args ...string) string {
+		t.Helper()
+		t.Cleanup(viper.Reset)
+		cmd := container.NewContainerCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetContext(context.WithValue(context.Background(), container.MockControllerKey{}, client))
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return buf.String()
+	}
+
+	t.Run("every container printed once across pages", func(t *testing.T) {
+		var requests []int
+		out := run(t, newClient(&requests), "-A", "-q", "--chunk-size", "4")
+		names := strings.Fields(out)
+		if len(names) != total {
+			t.Fatalf("got %d names, want %d:\n%s", len(names), total, out)
+		}
+		for i, name := range names {
+			if want := all[i].ID; name != want {
+				t.Fatalf("row %d = %q, want %q", i, name, want)
+			}
+		}
+		if len(requests) != 7 {
+			t.Errorf("page requests = %v, want 7 pages of 4", requests)
+		}
+	})
+
+	t.Run("table header printed once", func(t *testing.T) {
+		var requests []int
+		out := run(t, newClient(&requests), "-A", "--chunk-size", "10")
+		if got := strings.Count(out, "NAME"); got != 1 {
+			t.Errorf("header printed %d times, want 1:\n%s", got, out)
+		}
+		if got := strings.Count(out, "Ready"); got != total {
+			t.Errorf("rows = %d, want %d", got, total)
+		}
+	})
+
+	t.Run("limit stops the walk", func(t *testing.T) {
+		var requests []int
+		out := run(t, newClient(&requests), "-A", "-q", "--chunk-size", "4", "--limit", "6")
+		if got := strings.Fields(out); len(got) != 6 || got[5] != "c05" {
+			t.Errorf("names = %v, want c00..c05", got)
+		}
+		if len(requests) != 2 {
+			t.Errorf("page requests = %v, want 2", requests)
+		}
+	})
+
+	t.Run("limit alone is a single request", func(t *testing.T) {
+		var requests []int
+		out := run(t, newClient(&requests), "-A", "-o", "yaml", "--limit", "3")
+		if got := strings.Count(out, "realmId: r1"); got != 3 {
+			t.Errorf("yaml items = %d, want 3:\n%s", got, out)
+		}
+		if len(requests) != 1 || requests[0] != 3 {
+			t.Errorf("page requests = %v, want [3]", requests)
+		}
+	})
+
+	t.Run("negative chunk size rejected", func(t *testing.T) {
+		t.Cleanup(viper.Reset)
+		cmd := container.NewContainerCmd()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetContext(context.WithValue(context.Background(), container.MockControllerKey{},
+			kukeonv1.Client(&fakeClient{})))
+		cmd.SetArgs([]string{"--chunk-size", "-1"})
+		if err := cmd.Execute(); !errors.Is(err, errdefs.ErrInvalidChunkFlags) {
+			t.Fatalf("expected ErrInvalidChunkFlags, got: %v", err)
+		}
+	})
+}
+
 type fakeClient struct {
 	kukeonv1.FakeClient
 
 	getContainerFn   func(doc v1beta1.ContainerDoc) (kukeonv1.GetContainerResult, error)
 	listContainersFn func(realm, space, stack, cell string) ([]v1beta1.ContainerSpec, error)
+	listPageFn       func(limit int, continueToken string) (kukeonv1.ListContainersPageResult, error)
 }
 
 func (f *fakeClient) GetContainer(_ context.Context, doc v1beta1.ContainerDoc) (kukeonv1.GetContainerResult, error) {
@@ -814,3 +1015,15 @@ func (f *fakeClient) ListContainers(
 	}
 	return f.listContainersFn(realm, space, stack, cell)
 }
+
+func (f *fakeClient) ListContainersPage(
+	_ context.Context,
+	_, _, _, _ string,
+	limit int,
+	continueToken string,
+) (kukeonv1.ListContainersPageResult, error) {
+	if f.listPageFn == nil {
+		return kukeonv1.ListContainersPageResult{}, errors.New("unexpected ListContainersPage call")
+	}
+	return f.listPageFn(limit, continueToken)
+}
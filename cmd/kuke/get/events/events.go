@@ -0,0 +1,182 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eminwux/kukeon/cmd/config"
+	"github.com/eminwux/kukeon/cmd/kuke/get/shared"
+	kukeshared "github.com/eminwux/kukeon/cmd/kuke/shared"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// MockControllerKey is used to inject a mock kukeonv1.Client via context in tests.
+type MockControllerKey struct{}
+
+// NewEventsCmd builds `kuke get events`: the node's audit trail of what
+// kukeon itself did — realms created, spaces purged, cells that failed to
+// start — read from the event log under the run path. --realm, --space,
+// --stack and --cell narrow it to a scope, --kind to one resource kind, and
+// --since to recent entries.
+func NewEventsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "events",
+		Aliases:       []string{"event", "ev"},
+		Short:         "List the audit trail of actions kukeon took on realms, spaces, stacks and cells",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			outputFormat, err := shared.ParseOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			filter := kukeonv1.EventFilter{
+				Kind:  strings.TrimSpace(viper.GetString(config.KUKE_GET_EVENTS_KIND.ViperKey)),
+				Realm: strings.TrimSpace(viper.GetString(config.KUKE_GET_EVENTS_REALM.ViperKey)),
+				Space: strings.TrimSpace(viper.GetString(config.KUKE_GET_EVENTS_SPACE.ViperKey)),
+				Stack: strings.TrimSpace(viper.GetString(config.KUKE_GET_EVENTS_STACK.ViperKey)),
+				Cell:  strings.TrimSpace(viper.GetString(config.KUKE_GET_EVENTS_CELL.ViperKey)),
+				Limit: viper.GetInt(config.KUKE_GET_EVENTS_LIMIT.ViperKey),
+			}
+			if filter.Limit < 0 {
+				return fmt.Errorf("invalid --limit %d: must not be negative", filter.Limit)
+			}
+			filter.Since, err = parseSince(viper.GetString(config.KUKE_GET_EVENTS_SINCE.ViperKey), time.Now())
+			if err != nil {
+				return err
+			}
+
+			client, err := resolveClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = client.Close() }()
+
+			list, err := client.ListEvents(cmd.Context(), filter)
+			if err != nil {
+				return err
+			}
+			return printEvents(cmd, list, outputFormat)
+		},
+	}
+
+	cmd.Flags().String("realm", "", "Only events in this realm")
+	_ = viper.BindPFlag(config.KUKE_GET_EVENTS_REALM.ViperKey, cmd.Flags().Lookup("realm"))
+	cmd.Flags().String("space", "", "Only events in this space")
+	_ = viper.BindPFlag(config.KUKE_GET_EVENTS_SPACE.ViperKey, cmd.Flags().Lookup("space"))
+	cmd.Flags().String("stack", "", "Only events in this stack")
+	_ = viper.BindPFlag(config.KUKE_GET_EVENTS_STACK.ViperKey, cmd.Flags().Lookup("stack"))
+	cmd.Flags().String("cell", "", "Only events about this cell")
+	_ = viper.BindPFlag(config.KUKE_GET_EVENTS_CELL.ViperKey, cmd.Flags().Lookup("cell"))
+	cmd.Flags().String("kind", "", "Only events about this kind of resource (realm, space, stack, cell)")
+	_ = viper.BindPFlag(config.KUKE_GET_EVENTS_KIND.ViperKey, cmd.Flags().Lookup("kind"))
+	cmd.Flags().String("since", "", "Only events newer than a duration (e.g. 1h) or an RFC 3339 time")
+	_ = viper.BindPFlag(config.KUKE_GET_EVENTS_SINCE.ViperKey, cmd.Flags().Lookup("since"))
+	cmd.Flags().Int("limit", 0, "Show only the newest N events (0 shows all)")
+	_ = viper.BindPFlag(config.KUKE_GET_EVENTS_LIMIT.ViperKey, cmd.Flags().Lookup("limit"))
+	cmd.Flags().
+		StringP("output", "o", "", "Output format (yaml, json, table, wide, custom-columns=<spec>). Default: table")
+	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("output"))
+	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("o"))
+	shared.RegisterNoHeadersFlag(cmd)
+
+	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
+	_ = cmd.RegisterFlagCompletionFunc("output", config.CompleteOutputFormat)
+	_ = cmd.RegisterFlagCompletionFunc("o", config.CompleteOutputFormat)
+
+	return cmd
+}
+
+func resolveClient(cmd *cobra.Command) (kukeonv1.Client, error) {
+	if mockClient, ok := cmd.Context().Value(MockControllerKey{}).(kukeonv1.Client); ok {
+		return mockClient, nil
+	}
+	return kukeshared.ClientFromCmd(cmd)
+}
+
+// parseSince turns --since into the oldest event time to keep: a duration
+// counts back from now, anything else must be an RFC 3339 time. Empty keeps
+// everything.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("invalid --since %q: duration must not be negative", value)
+		}
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since %q: want a duration such as 1h or an RFC 3339 time", value)
+	}
+	return t, nil
+}
+
+func printEvents(cmd *cobra.Command, list []kukeonv1.Event, format shared.OutputFormat) error {
+	switch format {
+	case shared.OutputFormatYAML:
+		return shared.PrintYAML(cmd, list)
+	case shared.OutputFormatJSON:
+		return shared.PrintJSON(cmd, list)
+	case shared.OutputFormatCustomColumns:
+		return shared.PrintCustomColumns(cmd, list, nil)
+	case shared.OutputFormatTable, shared.OutputFormatWide:
+		if len(list) == 0 {
+			shared.PrintEmpty(cmd, "No events found.")
+			return nil
+		}
+		headers := []string{"TIME", "ACTION", "KIND", "RESOURCE", "OUTCOME", "MESSAGE"}
+		rows := make([][]string, 0, len(list))
+		for _, e := range list {
+			rows = append(rows, []string{
+				e.Time.Local().Format(time.RFC3339),
+				e.Action,
+				e.Kind,
+				resourcePath(e),
+				e.Outcome,
+				e.Message,
+			})
+		}
+		shared.PrintTable(cmd, headers, rows)
+		return nil
+	default:
+		return shared.PrintYAML(cmd, list)
+	}
+}
+
+// resourcePath names the event's resource by its place in the hierarchy,
+// realm/space/stack/cell, stopping at the resource itself.
+func resourcePath(e kukeonv1.Event) string {
+	parts := make([]string, 0, 4) //nolint:mnd // realm/space/stack/cell
+	for _, p := range []string{e.Realm, e.Space, e.Stack, e.Cell} {
+		if p == "" {
+			break
+		}
+		parts = append(parts, p)
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eminwux/kukeon/cmd/kuke/get/events"
+	"github.com/eminwux/kukeon/cmd/types"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	"github.com/spf13/viper"
+)
+
+func TestNewEventsCmd(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	started := kukeonv1.Event{
+		Time: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC), Action: "start", Kind: "Cell",
+		Realm: "main", Space: "app", Stack: "web", Cell: "api", Outcome: "Failed", Message: "image not found",
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantFilter func(t *testing.T, f kukeonv1.EventFilter)
+		events     []kukeonv1.Event
+		wantErr    string
+		wantOutput []string
+	}{
+		{
+			name:       "lists events as a table",
+			events:     []kukeonv1.Event{started},
+			wantOutput: []string{"ACTION", "RESOURCE", "start", "main/app/web/api", "Failed", "image not found"},
+		},
+		{
+			name:       "no events prints a friendly line",
+			wantOutput: []string{"No events found."},
+		},
+		{
+			name: "scope and kind flags become the filter",
+			args: []string{"--realm", "main", "--space", "app", "--cell", "api", "--kind", "cell", "--limit", "5"},
+			wantFilter: func(t *testing.T, f kukeonv1.EventFilter) {
+				want := kukeonv1.EventFilter{Kind: "cell", Realm: "main", Space: "app", Cell: "api", Limit: 5}
+				if f != want {
+					t.Errorf("filter = %+v, want %+v", f, want)
+				}
+			},
+		},
+		{
+			name: "since takes a duration",
+			args: []string{"--since", "1h"},
+			wantFilter: func(t *testing.T, f kukeonv1.EventFilter) {
+				if age := time.Since(f.Since); age < time.Hour || age > time.Hour+time.Minute {
+					t.Errorf("Since = %v, want about an hour ago", f.Since)
+				}
+			},
+		},
+		{
+			name: "since takes an RFC 3339 time",
+			args: []string{"--since", "2026-10-01T09:00:00Z"},
+			wantFilter: func(t *testing.T, f kukeonv1.EventFilter) {
+				if !f.Since.Equal(started.Time) {
+					t.Errorf("Since = %v, want %v", f.Since, started.Time)
+				}
+			},
+		},
+		{
+			name:    "invalid since is rejected",
+			args:    []string{"--since", "yesterday"},
+			wantErr: `invalid --since "yesterday"`,
+		},
+		{
+			name:       "yaml output",
+			args:       []string{"-o", "yaml"},
+			events:     []kukeonv1.Event{started},
+			wantOutput: []string{"action: start", "outcome: Failed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+
+			fake := &fakeClient{
+				listEventsFn: func(f kukeonv1.EventFilter) ([]kukeonv1.Event, error) {
+					if tt.wantFilter != nil {
+						tt.wantFilter(t, f)
+					}
+					return tt.events, nil
+				},
+			}
+
+			cmd := events.NewEventsCmd()
+			buf := &bytes.Buffer{}
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+			ctx = context.WithValue(ctx, events.MockControllerKey{}, kukeonv1.Client(fake))
+			cmd.SetContext(ctx)
+
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q\nGot:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+type fakeClient struct {
+	kukeonv1.FakeClient
+
+	listEventsFn func(filter kukeonv1.EventFilter) ([]kukeonv1.Event, error)
+}
+
+func (f *fakeClient) ListEvents(_ context.Context, filter kukeonv1.EventFilter) ([]kukeonv1.Event, error) {
+	if f.listEventsFn == nil {
+		return nil, errors.New("unexpected ListEvents call")
+	}
+	return f.listEventsFn(filter)
+}
@@ -23,7 +23,10 @@ import (
 	cellcmd "github.com/eminwux/kukeon/cmd/kuke/get/cell"
 	configcmd "github.com/eminwux/kukeon/cmd/kuke/get/config"
 	containercmd "github.com/eminwux/kukeon/cmd/kuke/get/container"
+	eventscmd "github.com/eminwux/kukeon/cmd/kuke/get/events"
 	imagecmd "github.com/eminwux/kukeon/cmd/kuke/get/image"
+	orphanscmd "github.com/eminwux/kukeon/cmd/kuke/get/orphans"
+	quotacmd "github.com/eminwux/kukeon/cmd/kuke/get/quota"
 	realmcmd "github.com/eminwux/kukeon/cmd/kuke/get/realm"
 	secretcmd "github.com/eminwux/kukeon/cmd/kuke/get/secret"
 	spacecmd "github.com/eminwux/kukeon/cmd/kuke/get/space"
@@ -40,7 +43,7 @@ func NewGetCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "get [name]",
 		Aliases: []string{"g"},
-		Short:   "Get or list Kukeon resources (realm, space, stack, cell, container, image, secret, blueprint, volume, config)",
+		Short:   "Get or list Kukeon resources (realm, space, stack, cell, container, image, secret, blueprint, volume, config, orphans, events, quota)",
 		Run: func(cmd *cobra.Command, _ []string) {
 			_ = cmd.Help()
 		},
@@ -67,6 +70,9 @@ func NewGetCmd() *cobra.Command {
 		blueprintcmd.NewBlueprintCmd(),
 		volumecmd.NewVolumeCmd(),
 		configcmd.NewConfigCmd(),
+		orphanscmd.NewOrphansCmd(),
+		eventscmd.NewEventsCmd(),
+		quotacmd.NewQuotaCmd(),
 	)
 
 	return cmd
@@ -75,7 +81,8 @@ func NewGetCmd() *cobra.Command {
 // completeGetSubcommands provides shell completion for get subcommand names.
 func completeGetSubcommands(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	subcommands := []string{
-		"realm", "space", "stack", "cell", "container", "image", "secret", "blueprint", "volume", "config",
+		"realm", "space", "stack", "cell", "container", "image", "secret", "blueprint", "volume", "config", "orphans",
+		"events", "quota",
 	}
 
 	if toComplete == "" {
@@ -41,7 +41,7 @@ func TestNewGetCmdMetadata(t *testing.T) {
 		{
 			name: "short description",
 			check: func(t *testing.T, cmd *cobra.Command) {
-				expected := "Get or list Kukeon resources (realm, space, stack, cell, container, image, secret, blueprint, volume, config)"
+				expected := "Get or list Kukeon resources (realm, space, stack, cell, container, image, secret, blueprint, volume, config, orphans, events, quota)"
 				if cmd.Short != expected {
 					t.Fatalf("expected Short to be %q, got %q", expected, cmd.Short)
 				}
@@ -83,6 +83,9 @@ func TestNewGetCmdRegistersSubcommands(t *testing.T) {
 		{name: "container"},
 		{name: "image"},
 		{name: "config"},
+		{name: "orphans"},
+		{name: "events"},
+		{name: "quota"},
 	}
 
 	for _, tt := range tests {
@@ -116,6 +119,9 @@ func TestNewGetCmd_AutocompleteRegistration(t *testing.T) {
 		"blueprint",
 		"volume",
 		"config",
+		"orphans",
+		"events",
+		"quota",
 	}
 	if len(completions) != len(expected) {
 		t.Fatalf("expected %d completions, got %d", len(expected), len(completions))
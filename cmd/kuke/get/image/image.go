@@ -81,9 +81,12 @@ func NewImageCmd() *cobra.Command {
 
 	cmd.Flags().String("realm", "", "Filter images by realm name; omit to list across every realm")
 	cmd.Flags().
-		StringP("output", "o", "", "Output format (yaml, json, table, wide). Default: table for list, table for single resource")
+		StringP("output", "o", "", "Output format (yaml, json, table, wide, custom-columns=<spec>). "+
+			"Default: table for list, table for single resource")
 	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("output"))
 	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("o"))
+	getshared.RegisterQuietFlag(cmd)
+	getshared.RegisterNoHeadersFlag(cmd)
 
 	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
 	_ = cmd.RegisterFlagCompletionFunc("output", config.CompleteOutputFormat)
@@ -183,6 +186,10 @@ func resolveClient(cmd *cobra.Command) Client {
 }
 
 func printImage(cmd *cobra.Command, img kukeonv1.ImageInfo, format getshared.OutputFormat) error {
+	if getshared.IsQuiet(cmd) {
+		cmd.Println(img.Name)
+		return nil
+	}
 	switch format {
 	case getshared.OutputFormatJSON:
 		return getshared.PrintJSON(cmd, img)
@@ -204,13 +211,21 @@ func printImages(
 		return getshared.PrintYAML(cmd, results)
 	case getshared.OutputFormatJSON:
 		return getshared.PrintJSON(cmd, results)
+	case getshared.OutputFormatCustomColumns:
+		var views []imageColumnsView
+		for _, r := range results {
+			for _, img := range r.Images {
+				views = append(views, imageColumnsView{Realm: r.Realm, ImageInfo: img})
+			}
+		}
+		return getshared.PrintCustomColumns(cmd, views, nil)
 	case getshared.OutputFormatTable:
 		total := 0
 		for _, r := range results {
 			total += len(r.Images)
 		}
 		if total == 0 {
-			cmd.Println("No images found.")
+			getshared.PrintEmpty(cmd, "No images found.")
 			return nil
 		}
 		headers := []string{"NAME", "REALM", "SIZE", "AGE"}
@@ -235,6 +250,13 @@ func printImages(
 	}
 }
 
+// imageColumnsView is the shape -o custom-columns reads for an image row:
+// the image's own fields plus the realm it was listed from.
+type imageColumnsView struct {
+	Realm string `json:"realm"`
+	kukeonv1.ImageInfo
+}
+
 // formatSize renders a size in human-friendly bytes; -1 surfaces as "-" so
 // the operator sees a missing value rather than a misleading "0 B".
 func formatSize(size int64) string {
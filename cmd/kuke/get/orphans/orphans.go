@@ -0,0 +1,141 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package orphans
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eminwux/kukeon/cmd/config"
+	"github.com/eminwux/kukeon/cmd/kuke/get/shared"
+	kukeshared "github.com/eminwux/kukeon/cmd/kuke/shared"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// MockControllerKey is used to inject a mock kukeonv1.Client via context in tests.
+type MockControllerKey struct{}
+
+// NewOrphansCmd builds `kuke get orphans`: the containers in a realm's
+// containerd namespace that carry kukeon's `kukeon.io/*` identity labels but
+// whose owning cell has no metadata, typically left behind by a purge that
+// crashed part-way. Unlabeled containers are never listed. `--purge` stops
+// and deletes every orphan found.
+func NewOrphansCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "orphans",
+		Aliases:       []string{"orphan"},
+		Short:         "List (or purge) containers left in containerd without cell metadata",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			outputFormat, err := shared.ParseOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			realm := strings.TrimSpace(viper.GetString(config.KUKE_GET_ORPHANS_REALM.ViperKey))
+			if realm == "" {
+				return fmt.Errorf("%w (--realm)", errdefs.ErrRealmNameRequired)
+			}
+			purge := viper.GetBool(config.KUKE_GET_ORPHANS_PURGE.ViperKey)
+
+			client, err := resolveClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = client.Close() }()
+
+			result, findErr := client.FindOrphans(cmd.Context(), realm, purge)
+			if findErr != nil && result.Realm == "" {
+				return findErr
+			}
+			if printErr := printOrphans(cmd, result, outputFormat, purge); printErr != nil {
+				return printErr
+			}
+			return findErr
+		},
+	}
+
+	cmd.Flags().String("realm", "", "Realm whose containerd namespace to scan (default \"default\")")
+	_ = viper.BindPFlag(config.KUKE_GET_ORPHANS_REALM.ViperKey, cmd.Flags().Lookup("realm"))
+	cmd.Flags().Bool("purge", false, "Stop and delete every orphaned container found")
+	_ = viper.BindPFlag(config.KUKE_GET_ORPHANS_PURGE.ViperKey, cmd.Flags().Lookup("purge"))
+	cmd.Flags().
+		StringP("output", "o", "", "Output format (yaml, json, table, wide, custom-columns=<spec>). Default: table")
+	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("output"))
+	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("o"))
+	shared.RegisterQuietFlag(cmd)
+	shared.RegisterNoHeadersFlag(cmd)
+
+	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
+	_ = cmd.RegisterFlagCompletionFunc("output", config.CompleteOutputFormat)
+	_ = cmd.RegisterFlagCompletionFunc("o", config.CompleteOutputFormat)
+
+	return cmd
+}
+
+func resolveClient(cmd *cobra.Command) (kukeonv1.Client, error) {
+	if mockClient, ok := cmd.Context().Value(MockControllerKey{}).(kukeonv1.Client); ok {
+		return mockClient, nil
+	}
+	return kukeshared.ClientFromCmd(cmd)
+}
+
+func printOrphans(
+	cmd *cobra.Command,
+	result kukeonv1.FindOrphansResult,
+	format shared.OutputFormat,
+	purge bool,
+) error {
+	switch format {
+	case shared.OutputFormatYAML:
+		return shared.PrintYAML(cmd, result)
+	case shared.OutputFormatJSON:
+		return shared.PrintJSON(cmd, result)
+	case shared.OutputFormatCustomColumns:
+		return shared.PrintCustomColumns(cmd, result.Orphans, nil)
+	case shared.OutputFormatTable, shared.OutputFormatWide:
+		if len(result.Orphans) == 0 {
+			shared.PrintEmpty(cmd, fmt.Sprintf("No orphaned containers found in realm %q.", result.Realm))
+			return nil
+		}
+		purged := make(map[string]bool, len(result.Purged))
+		for _, id := range result.Purged {
+			purged[id] = true
+		}
+		headers := []string{"CONTAINER", "SPACE", "STACK", "CELL"}
+		if purge {
+			headers = append(headers, "PURGED")
+		}
+		rows := make([][]string, 0, len(result.Orphans))
+		for _, o := range result.Orphans {
+			row := []string{o.ContainerdID, o.Space, o.Stack, o.Cell}
+			if purge {
+				row = append(row, fmt.Sprintf("%t", purged[o.ContainerdID]))
+			}
+			rows = append(rows, row)
+		}
+		shared.PrintTable(cmd, headers, rows)
+		return nil
+	default:
+		return shared.PrintYAML(cmd, result)
+	}
+}
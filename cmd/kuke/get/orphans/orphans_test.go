@@ -0,0 +1,150 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package orphans_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/cmd/kuke/get/orphans"
+	"github.com/eminwux/kukeon/cmd/types"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	"github.com/spf13/viper"
+)
+
+func TestNewOrphansCmd(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	oneOrphan := kukeonv1.FindOrphansResult{
+		Realm:     "main",
+		Namespace: "main.kukeon.io",
+		Orphans: []kukeonv1.OrphanContainer{
+			{ContainerdID: "app_web_ghost_root", Space: "app", Stack: "web", Cell: "ghost"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		fake       *fakeClient
+		wantErr    string
+		wantOutput []string
+	}{
+		{
+			name: "lists orphans as a table",
+			args: []string{"--realm", "main"},
+			fake: &fakeClient{
+				findOrphansFn: func(string, bool) (kukeonv1.FindOrphansResult, error) {
+					return oneOrphan, nil
+				},
+			},
+			wantOutput: []string{"CONTAINER", "app_web_ghost_root", "ghost"},
+		},
+		{
+			name: "no orphans prints a friendly line",
+			args: []string{"--realm", "main"},
+			fake: &fakeClient{
+				findOrphansFn: func(string, bool) (kukeonv1.FindOrphansResult, error) {
+					return kukeonv1.FindOrphansResult{Realm: "main"}, nil
+				},
+			},
+			wantOutput: []string{`No orphaned containers found in realm "main".`},
+		},
+		{
+			name: "purge forwards the flag and reports removals",
+			args: []string{"--realm", "main", "--purge"},
+			fake: &fakeClient{
+				findOrphansFn: func(_ string, purge bool) (kukeonv1.FindOrphansResult, error) {
+					if !purge {
+						return kukeonv1.FindOrphansResult{}, errors.New("purge not forwarded")
+					}
+					res := oneOrphan
+					res.Purged = []string{"app_web_ghost_root"}
+					return res, nil
+				},
+			},
+			wantOutput: []string{"PURGED", "true"},
+		},
+		{
+			name: "partial purge still prints the table and returns the error",
+			args: []string{"--realm", "main", "--purge"},
+			fake: &fakeClient{
+				findOrphansFn: func(string, bool) (kukeonv1.FindOrphansResult, error) {
+					return oneOrphan, errdefs.ErrPurgeOrphans
+				},
+			},
+			wantOutput: []string{"app_web_ghost_root", "false"},
+			wantErr:    errdefs.ErrPurgeOrphans.Error(),
+		},
+		{
+			name:    "missing realm is rejected",
+			fake:    &fakeClient{},
+			wantErr: errdefs.ErrRealmNameRequired.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+
+			cmd := orphans.NewOrphansCmd()
+			buf := &bytes.Buffer{}
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+			ctx = context.WithValue(ctx, orphans.MockControllerKey{}, kukeonv1.Client(tt.fake))
+			cmd.SetContext(ctx)
+
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q\nGot:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+type fakeClient struct {
+	kukeonv1.FakeClient
+
+	findOrphansFn func(realm string, purge bool) (kukeonv1.FindOrphansResult, error)
+}
+
+func (f *fakeClient) FindOrphans(_ context.Context, realm string, purge bool) (kukeonv1.FindOrphansResult, error) {
+	if f.findOrphansFn == nil {
+		return kukeonv1.FindOrphansResult{}, errors.New("unexpected FindOrphans call")
+	}
+	return f.findOrphansFn(realm, purge)
+}
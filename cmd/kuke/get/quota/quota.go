@@ -0,0 +1,154 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/eminwux/kukeon/cmd/config"
+	"github.com/eminwux/kukeon/cmd/kuke/get/shared"
+	kukeshared "github.com/eminwux/kukeon/cmd/kuke/shared"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// MockControllerKey is used to inject a mock kukeonv1.Client via context in tests.
+type MockControllerKey struct{}
+
+// NewQuotaCmd builds `kuke get quota`: every realm and space that sets a
+// resource quota, with what its cells use next to each limit. --scope
+// narrows it to one realm (and its spaces) or one realm/space.
+func NewQuotaCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "quota",
+		Aliases:       []string{"quotas"},
+		Short:         "Show realm and space resource quotas with current usage",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			outputFormat, err := shared.ParseOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			realm, space, err := parseScope(viper.GetString(config.KUKE_GET_QUOTA_SCOPE.ViperKey))
+			if err != nil {
+				return err
+			}
+
+			client, err := resolveClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = client.Close() }()
+
+			list, err := client.ListQuotas(cmd.Context(), realm, space)
+			if err != nil {
+				return err
+			}
+			return printQuotas(cmd, list, outputFormat)
+		},
+	}
+
+	cmd.Flags().String("scope", "", "Only this realm, or realm/space (default: every realm and space with a quota)")
+	_ = viper.BindPFlag(config.KUKE_GET_QUOTA_SCOPE.ViperKey, cmd.Flags().Lookup("scope"))
+	cmd.Flags().
+		StringP("output", "o", "", "Output format (yaml, json, table, wide, custom-columns=<spec>). Default: table")
+	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("output"))
+	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("o"))
+	shared.RegisterNoHeadersFlag(cmd)
+
+	_ = cmd.RegisterFlagCompletionFunc("scope", config.CompleteRealmNames)
+	_ = cmd.RegisterFlagCompletionFunc("output", config.CompleteOutputFormat)
+	_ = cmd.RegisterFlagCompletionFunc("o", config.CompleteOutputFormat)
+
+	return cmd
+}
+
+func resolveClient(cmd *cobra.Command) (kukeonv1.Client, error) {
+	if mockClient, ok := cmd.Context().Value(MockControllerKey{}).(kukeonv1.Client); ok {
+		return mockClient, nil
+	}
+	return kukeshared.ClientFromCmd(cmd)
+}
+
+// parseScope splits --scope into a realm and an optional space. Empty means
+// every scope.
+func parseScope(value string) (string, string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", "", nil
+	}
+	realm, space, hasSpace := strings.Cut(value, "/")
+	realm = strings.TrimSpace(realm)
+	space = strings.TrimSpace(space)
+	if realm == "" || (hasSpace && (space == "" || strings.Contains(space, "/"))) {
+		return "", "", fmt.Errorf("invalid --scope %q: want <realm> or <realm>/<space>", value)
+	}
+	return realm, space, nil
+}
+
+func printQuotas(cmd *cobra.Command, list []kukeonv1.QuotaStatus, format shared.OutputFormat) error {
+	switch format {
+	case shared.OutputFormatYAML:
+		return shared.PrintYAML(cmd, list)
+	case shared.OutputFormatJSON:
+		return shared.PrintJSON(cmd, list)
+	case shared.OutputFormatCustomColumns:
+		return shared.PrintCustomColumns(cmd, list, nil)
+	case shared.OutputFormatTable, shared.OutputFormatWide:
+		if len(list) == 0 {
+			shared.PrintEmpty(cmd, "No quotas found.")
+			return nil
+		}
+		headers := []string{"SCOPE", "CELLS", "MEMORY", "CPU"}
+		rows := make([][]string, 0, len(list))
+		for _, q := range list {
+			rows = append(rows, []string{
+				scopeName(q),
+				usedOf(int64(q.Used.Cells), int64(q.Limit.MaxCells)),
+				usedOf(q.Used.MemoryBytes, q.Limit.MaxMemoryBytes),
+				usedOf(q.Used.CPUShares, q.Limit.MaxCPUShares),
+			})
+		}
+		shared.PrintTable(cmd, headers, rows)
+		return nil
+	default:
+		return shared.PrintYAML(cmd, list)
+	}
+}
+
+// scopeName names a quota's scope the way --scope takes it.
+func scopeName(q kukeonv1.QuotaStatus) string {
+	if q.Space == "" {
+		return q.Realm
+	}
+	return q.Realm + "/" + q.Space
+}
+
+// usedOf renders usage against a limit as used/limit; a zero limit is
+// unlimited and shows as used/-.
+func usedOf(used, limit int64) string {
+	if limit <= 0 {
+		return strconv.FormatInt(used, 10) + "/-"
+	}
+	return strconv.FormatInt(used, 10) + "/" + strconv.FormatInt(limit, 10)
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package quota_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/cmd/kuke/get/quota"
+	"github.com/eminwux/kukeon/cmd/types"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+	"github.com/spf13/viper"
+)
+
+func TestNewQuotaCmd(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	realmQuota := kukeonv1.QuotaStatus{
+		Realm: "main",
+		Limit: v1beta1.ResourceQuota{MaxCells: 10, MaxMemoryBytes: 1073741824},
+		Used:  kukeonv1.QuotaUsage{Cells: 3, MemoryBytes: 536870912, CPUShares: 256},
+	}
+	spaceQuota := kukeonv1.QuotaStatus{
+		Realm: "main",
+		Space: "app",
+		Limit: v1beta1.ResourceQuota{MaxCPUShares: 1024},
+		Used:  kukeonv1.QuotaUsage{Cells: 2, CPUShares: 256},
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		quotas     []kukeonv1.QuotaStatus
+		wantRealm  string
+		wantSpace  string
+		wantErr    string
+		wantOutput []string
+	}{
+		{
+			name:   "lists used against limit as a table",
+			quotas: []kukeonv1.QuotaStatus{realmQuota, spaceQuota},
+			wantOutput: []string{
+				"SCOPE", "CELLS", "MEMORY", "CPU",
+				"3/10", "536870912/1073741824", "256/-",
+				"main/app", "2/-", "256/1024",
+			},
+		},
+		{
+			name:       "no quotas prints a friendly line",
+			wantOutput: []string{"No quotas found."},
+		},
+		{
+			name:      "scope takes a realm",
+			args:      []string{"--scope", "main"},
+			wantRealm: "main",
+		},
+		{
+			name:      "scope takes a realm and space",
+			args:      []string{"--scope", "main/app"},
+			wantRealm: "main",
+			wantSpace: "app",
+		},
+		{
+			name:    "scope without a space name is rejected",
+			args:    []string{"--scope", "main/"},
+			wantErr: `invalid --scope "main/"`,
+		},
+		{
+			name:    "scope deeper than a space is rejected",
+			args:    []string{"--scope", "main/app/web"},
+			wantErr: `invalid --scope "main/app/web"`,
+		},
+		{
+			name:       "yaml output",
+			args:       []string{"-o", "yaml"},
+			quotas:     []kukeonv1.QuotaStatus{spaceQuota},
+			wantOutput: []string{"space: app", "maxCpuShares: 1024", "cpuShares: 256"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+
+			fake := &fakeClient{
+				listQuotasFn: func(realm, space string) ([]kukeonv1.QuotaStatus, error) {
+					if realm != tt.wantRealm || space != tt.wantSpace {
+						t.Errorf("ListQuotas(%q, %q), want (%q, %q)", realm, space, tt.wantRealm, tt.wantSpace)
+					}
+					return tt.quotas, nil
+				},
+			}
+
+			cmd := quota.NewQuotaCmd()
+			buf := &bytes.Buffer{}
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+			ctx = context.WithValue(ctx, quota.MockControllerKey{}, kukeonv1.Client(fake))
+			cmd.SetContext(ctx)
+
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q\nGot:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+type fakeClient struct {
+	kukeonv1.FakeClient
+
+	listQuotasFn func(realm, space string) ([]kukeonv1.QuotaStatus, error)
+}
+
+func (f *fakeClient) ListQuotas(_ context.Context, realm, space string) ([]kukeonv1.QuotaStatus, error) {
+	if f.listQuotasFn == nil {
+		return nil, errors.New("unexpected ListQuotas call")
+	}
+	return f.listQuotasFn(realm, space)
+}
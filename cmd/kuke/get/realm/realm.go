@@ -54,6 +54,21 @@ func NewRealmCmd() *cobra.Command {
 				return err
 			}
 
+			fieldSelector, err := shared.ParseFieldSelectorFlag(cmd, shared.RealmSelectableFields)
+			if err != nil {
+				return err
+			}
+
+			sortBy, err := shared.ParseSortByFlag(cmd)
+			if err != nil {
+				return err
+			}
+
+			columns, err := shared.ParseLabelColumnsFlags(cmd)
+			if err != nil {
+				return err
+			}
+
 			var name string
 			if len(args) > 0 {
 				name = strings.TrimSpace(args[0])
@@ -64,6 +79,9 @@ func NewRealmCmd() *cobra.Command {
 			if name != "" && !selector.Empty() {
 				return errdefs.ErrSelectorWithName
 			}
+			if name != "" && !fieldSelector.Empty() {
+				return errdefs.ErrFieldSelectorWithName
+			}
 
 			client, err := resolveClient(cmd)
 			if err != nil {
@@ -78,14 +96,14 @@ func NewRealmCmd() *cobra.Command {
 				result, err := client.GetRealm(cmd.Context(), doc)
 				if err != nil {
 					if errors.Is(err, errdefs.ErrRealmNotFound) {
-						return fmt.Errorf("realm %q not found", name)
+						return fmt.Errorf("realm %q not found: %w", name, errdefs.ErrRealmNotFound)
 					}
 					return err
 				}
 				if !result.MetadataExists {
-					return fmt.Errorf("realm %q not found", name)
+					return fmt.Errorf("realm %q not found: %w", name, errdefs.ErrRealmNotFound)
 				}
-				return printRealm(cmd, &result.Realm, outputFormat)
+				return printRealm(cmd, &result.Realm, outputFormat, columns)
 			}
 
 			realms, err := client.ListRealms(cmd.Context())
@@ -93,16 +111,28 @@ func NewRealmCmd() *cobra.Command {
 				return err
 			}
 			realms = filterRealmsBySelector(realms, selector)
-			return printRealms(cmd, realms, outputFormat)
+			if realms, err = shared.FilterByFieldSelector(realms, fieldSelector); err != nil {
+				return err
+			}
+			if err = shared.SortItems(realms, sortBy, nil); err != nil {
+				return err
+			}
+			return printRealms(cmd, realms, outputFormat, columns)
 		},
 	}
 
 	cmd.Flags().
-		StringP("output", "o", "", "Output format (yaml, json, table, wide). Default: table for list, table for single resource")
+		StringP("output", "o", "", "Output format (yaml, json, table, wide, custom-columns=<spec>). "+
+			"Default: table for list, table for single resource")
 	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("output"))
 	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("o"))
+	shared.RegisterSortByFlag(cmd)
+	shared.RegisterQuietFlag(cmd)
+	shared.RegisterNoHeadersFlag(cmd)
 
 	shared.RegisterLabelSelectorFlag(cmd)
+	shared.RegisterFieldSelectorFlag(cmd)
+	shared.RegisterLabelColumnFlags(cmd)
 
 	// `--no-daemon` is inherited as a persistent flag from the parent `get`
 	// command (registered in cmd/kuke/get/get.go) — every `get <kind>`
@@ -140,7 +170,12 @@ func filterRealmsBySelector(realms []v1beta1.RealmDoc, selector *shared.LabelSel
 	return out
 }
 
-func printRealm(cmd *cobra.Command, realm *v1beta1.RealmDoc, format shared.OutputFormat) error {
+func printRealm(
+	cmd *cobra.Command,
+	realm *v1beta1.RealmDoc,
+	format shared.OutputFormat,
+	columns shared.LabelColumns,
+) error {
 	switch format {
 	case shared.OutputFormatJSON:
 		return shared.PrintJSON(cmd, realm)
@@ -149,7 +184,7 @@ func printRealm(cmd *cobra.Command, realm *v1beta1.RealmDoc, format shared.Outpu
 	default:
 		// table / wide: render the single found element as a one-row table
 		// with the same columns as the list view (kubectl parity).
-		return printRealms(cmd, []v1beta1.RealmDoc{*realm}, format)
+		return printRealms(cmd, []v1beta1.RealmDoc{*realm}, format, columns)
 	}
 }
 
@@ -157,15 +192,18 @@ func printRealms(
 	cmd *cobra.Command,
 	realms []v1beta1.RealmDoc,
 	format shared.OutputFormat,
+	columns shared.LabelColumns,
 ) error {
 	switch format {
 	case shared.OutputFormatYAML:
 		return shared.PrintYAML(cmd, realms)
 	case shared.OutputFormatJSON:
 		return shared.PrintJSON(cmd, realms)
+	case shared.OutputFormatCustomColumns:
+		return shared.PrintCustomColumns(cmd, realms, nil)
 	case shared.OutputFormatTable, shared.OutputFormatWide:
 		if len(realms) == 0 {
-			cmd.Println("No realms found.")
+			shared.PrintEmpty(cmd, "No realms found.")
 			return nil
 		}
 		wide := format == shared.OutputFormatWide
@@ -173,6 +211,7 @@ func printRealms(
 		if wide {
 			headers = append(headers, "NAMESPACE")
 		}
+		headers = columns.AppendHeaders(headers)
 		now := time.Now()
 		rows := make([][]string, 0, len(realms))
 		for i := range realms {
@@ -182,7 +221,7 @@ func printRealms(
 			if wide {
 				row = append(row, r.Spec.Namespace)
 			}
-			rows = append(rows, row)
+			rows = append(rows, columns.AppendRow(row, r.Metadata.Labels))
 		}
 		shared.PrintTable(cmd, headers, rows)
 		return nil
@@ -494,3 +494,68 @@ func (f *fakeClient) ListRealms(_ context.Context) ([]v1beta1.RealmDoc, error) {
 	}
 	return f.listRealmsFn()
 }
+
+func TestNewRealmCmd_CustomColumns(t *testing.T) {
+	listFn := func() ([]v1beta1.RealmDoc, error) {
+		return []v1beta1.RealmDoc{
+			{
+				Metadata: v1beta1.RealmMetadata{Name: "staging"},
+				Spec:     v1beta1.RealmSpec{Namespace: "staging.kukeon.io"},
+			},
+			{
+				Metadata: v1beta1.RealmMetadata{Name: "prod"},
+				Spec:     v1beta1.RealmSpec{Namespace: "prod.kukeon.io"},
+				Status:   v1beta1.RealmStatus{State: v1beta1.RealmStateReady},
+			},
+		}, nil
+	}
+	run := func(t *testing.T, args ...string) (string, error) {
+		t.Helper()
+		t.Cleanup(viper.Reset)
+		cmd := realm.NewRealmCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(&bytes.Buffer{})
+		ctx := context.WithValue(context.Background(), realm.MockControllerKey{},
+			kukeonv1.Client(&fakeClient{listRealmsFn: listFn}))
+		cmd.SetContext(ctx)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	out, err := run(t, "-o", "custom-columns=NAME:.metadata.name,NAMESPACE:.spec.namespace,STATE:.status.state")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "NAME     NAMESPACE          STATE  \n" +
+		"-------  -----------------  -------\n" +
+		"prod     prod.kukeon.io     Ready  \n" +
+		"staging  staging.kukeon.io  Pending\n"
+	if out != want {
+		t.Errorf("output =\n%s\nwant\n%s", out, want)
+	}
+
+	out, err = run(t, "--no-headers", "-o", "custom-columns=NAME:{.metadata.name}")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want = "prod   \nstaging\n"; out != want {
+		t.Errorf("--no-headers output = %q, want %q", out, want)
+	}
+
+	out, err = run(t, "--no-headers")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(out, "NAME") || !strings.HasPrefix(out, "prod ") {
+		t.Errorf("--no-headers table = %q, want rows only", out)
+	}
+
+	if _, err = run(t, "-o", "custom-columns=NAME:.metadata.nmae"); !errors.Is(err, errdefs.ErrInvalidCustomColumns) {
+		t.Errorf("unknown field error = %v, want ErrInvalidCustomColumns", err)
+	}
+	if _, err = run(t, "-o", "custom-columns=NAME"); !errors.Is(err, errdefs.ErrInvalidCustomColumns) {
+		t.Errorf("malformed spec error = %v, want ErrInvalidCustomColumns", err)
+	}
+}
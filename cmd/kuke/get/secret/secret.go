@@ -66,6 +66,11 @@ func NewSecretCmd() *cobra.Command {
 			stack := shared.ExplicitFlag(cmd, "stack", config.KUKE_GET_SECRET_STACK.ViperKey)
 			cell := shared.ExplicitFlag(cmd, "cell", config.KUKE_GET_SECRET_CELL.ViperKey)
 
+			sortBy, err := shared.ParseSortByFlag(cmd)
+			if err != nil {
+				return err
+			}
+
 			var name string
 			if len(args) > 0 {
 				name = strings.TrimSpace(args[0])
@@ -97,12 +102,12 @@ func NewSecretCmd() *cobra.Command {
 				result, getErr := client.GetSecret(cmd.Context(), doc)
 				if getErr != nil {
 					if errors.Is(getErr, errdefs.ErrSecretNotFound) {
-						return fmt.Errorf("secret %q not found", name)
+						return fmt.Errorf("secret %q not found: %w", name, errdefs.ErrSecretNotFound)
 					}
 					return getErr
 				}
 				if !result.MetadataExists {
-					return fmt.Errorf("secret %q not found", name)
+					return fmt.Errorf("secret %q not found: %w", name, errdefs.ErrSecretNotFound)
 				}
 				return printSecret(cmd, &result.Secret, outputFormat)
 			}
@@ -111,6 +116,9 @@ func NewSecretCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if err = shared.SortItems(secrets, sortBy, nil); err != nil {
+				return err
+			}
 			return printSecrets(cmd, secrets, outputFormat)
 		},
 	}
@@ -124,9 +132,13 @@ func NewSecretCmd() *cobra.Command {
 	cmd.Flags().String("cell", "", "Filter secrets by cell name")
 	_ = viper.BindPFlag(config.KUKE_GET_SECRET_CELL.ViperKey, cmd.Flags().Lookup("cell"))
 	cmd.Flags().
-		StringP("output", "o", "", "Output format (yaml, json, table, wide). Default: table for list, table for single resource")
+		StringP("output", "o", "", "Output format (yaml, json, table, wide, custom-columns=<spec>). "+
+			"Default: table for list, table for single resource")
 	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("output"))
 	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("o"))
+	shared.RegisterSortByFlag(cmd)
+	shared.RegisterQuietFlag(cmd)
+	shared.RegisterNoHeadersFlag(cmd)
 
 	cmd.ValidArgsFunction = config.CompleteSecretNames
 	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
@@ -165,9 +177,11 @@ func printSecrets(cmd *cobra.Command, secrets []v1beta1.SecretDoc, format shared
 		return shared.PrintYAML(cmd, secrets)
 	case shared.OutputFormatJSON:
 		return shared.PrintJSON(cmd, secrets)
+	case shared.OutputFormatCustomColumns:
+		return shared.PrintCustomColumns(cmd, secrets, nil)
 	case shared.OutputFormatTable, shared.OutputFormatWide:
 		if len(secrets) == 0 {
-			cmd.Println("No secrets found.")
+			shared.PrintEmpty(cmd, "No secrets found.")
 			return nil
 		}
 		headers := []string{"NAME", "REALM", "SPACE", "STACK", "CELL"}
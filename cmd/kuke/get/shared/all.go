@@ -0,0 +1,54 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared
+
+import (
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/spf13/cobra"
+)
+
+// AllScopesFlagName is the canonical long flag name for the `-A`/`--all`
+// cross-realm listing flag.
+const AllScopesFlagName = "all"
+
+// RegisterAllScopesFlag adds the standard `-A`/`--all` flag to cmd.
+func RegisterAllScopesFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolP(AllScopesFlagName, "A", false,
+		"List across every realm, space, and stack (cannot be combined with a name or scope flags)")
+}
+
+// ValidateAllScopesFlag refuses `-A`/`--all` alongside a positional name or
+// any explicitly set scope flag, since --all already means "no scope filter".
+// A list with no scope flags walks every realm, space, and stack, so callers
+// need nothing beyond this check to honour --all.
+func ValidateAllScopesFlag(cmd *cobra.Command, name string, scopeFlags ...string) error {
+	if cmd == nil {
+		return nil
+	}
+	if all, _ := cmd.Flags().GetBool(AllScopesFlagName); !all {
+		return nil
+	}
+	if name != "" {
+		return errdefs.ErrAllWithScope
+	}
+	for _, flag := range scopeFlags {
+		if cmd.Flags().Changed(flag) {
+			return errdefs.ErrAllWithScope
+		}
+	}
+	return nil
+}
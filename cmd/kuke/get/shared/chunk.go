@@ -0,0 +1,161 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/spf13/cobra"
+)
+
+// Flag names for paginated list output.
+const (
+	ChunkSizeFlagName = "chunk-size"
+	LimitFlagName     = "limit"
+)
+
+// Chunking is the parsed form of --chunk-size / --limit. Size is the number
+// of items fetched per page; Limit caps the total rows printed. Zero means
+// "off" for both.
+type Chunking struct {
+	Size  int
+	Limit int
+}
+
+// RegisterChunkFlags adds --chunk-size and --limit to cmd.
+func RegisterChunkFlags(cmd *cobra.Command) {
+	cmd.Flags().Int(ChunkSizeFlagName, 0,
+		"Fetch the list in pages of this many items and print rows as each page arrives (0 fetches everything at once)")
+	cmd.Flags().Int(LimitFlagName, 0,
+		"Stop after printing this many items (0 prints every match)")
+}
+
+// ParseChunkFlags reads --chunk-size and --limit. A command that does not
+// register the flags gets the zero Chunking.
+func ParseChunkFlags(cmd *cobra.Command) (Chunking, error) {
+	var c Chunking
+	if cmd == nil || cmd.Flags().Lookup(ChunkSizeFlagName) == nil {
+		return c, nil
+	}
+	c.Size, _ = cmd.Flags().GetInt(ChunkSizeFlagName)
+	c.Limit, _ = cmd.Flags().GetInt(LimitFlagName)
+	if c.Size < 0 || c.Limit < 0 {
+		return Chunking{}, errdefs.ErrInvalidChunkFlags
+	}
+	return c, nil
+}
+
+// Enabled reports whether the list should be fetched page by page.
+func (c Chunking) Enabled() bool {
+	return c.Size > 0 || c.Limit > 0
+}
+
+// PageSize is the per-request limit: --chunk-size when set, otherwise
+// --limit so a bare `--limit N` is a single bounded request.
+func (c Chunking) PageSize() int {
+	if c.Size > 0 {
+		return c.Size
+	}
+	return c.Limit
+}
+
+// Remaining trims rows to what --limit still allows after printed rows.
+func (c Chunking) Remaining(printed int) int {
+	if c.Limit <= 0 {
+		return -1
+	}
+	return max(c.Limit-printed, 0)
+}
+
+// TableStream prints a table incrementally: the header goes out with the
+// first batch of rows and each later batch is appended below it. Column
+// widths are sized from the rows seen so far and only ever grow, so a wide
+// cell in a later page shifts that page's columns rather than reflowing
+// what was already printed. Quiet mode prints names only and --no-headers
+// drops the header, like PrintTable.
+type TableStream struct {
+	cmd     *cobra.Command
+	headers []string
+	widths  []int
+	started bool
+}
+
+// NewTableStream returns a TableStream for the given headers.
+func NewTableStream(cmd *cobra.Command, headers []string) *TableStream {
+	widths := make([]int, len(headers))
+	if !NoHeaders(cmd) {
+		for i, h := range headers {
+			widths[i] = len(h)
+		}
+	}
+	return &TableStream{cmd: cmd, headers: headers, widths: widths}
+}
+
+// Started reports whether any row has been written.
+func (t *TableStream) Started() bool {
+	return t.started
+}
+
+// Write prints one batch of rows, preceded by the header on the first call.
+func (t *TableStream) Write(rows [][]string) {
+	if len(rows) == 0 {
+		return
+	}
+	if IsQuiet(t.cmd) {
+		t.started = true
+		printNames(t.cmd, rows)
+		return
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < len(t.widths) && len(cell) > t.widths[i] {
+				t.widths[i] = len(cell)
+			}
+		}
+	}
+	if !t.started && NoHeaders(t.cmd) {
+		t.started = true
+	}
+	if !t.started {
+		t.started = true
+		t.cmd.Println(t.formatRow(t.headers))
+		separator := make([]string, len(t.widths))
+		for i, w := range t.widths {
+			separator[i] = strings.Repeat("-", w)
+		}
+		t.cmd.Println(t.formatRow(separator))
+	}
+	for _, row := range rows {
+		t.cmd.Println(t.formatRow(row))
+	}
+}
+
+func (t *TableStream) formatRow(row []string) string {
+	var sb strings.Builder
+	for i, cell := range row {
+		if i >= len(t.widths) {
+			break
+		}
+		if i > 0 {
+			sb.WriteString("  ")
+		}
+		sb.WriteString(fmt.Sprintf("%-*s", t.widths[i], cell))
+	}
+	return sb.String()
+}
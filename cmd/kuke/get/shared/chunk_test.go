@@ -0,0 +1,87 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eminwux/kukeon/cmd/kuke/get/shared"
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+func TestParseChunkFlags(t *testing.T) {
+	cmd, _ := newOutputCommand()
+	shared.RegisterChunkFlags(cmd)
+
+	c, err := shared.ParseChunkFlags(cmd)
+	if err != nil || c.Enabled() {
+		t.Fatalf("defaults = %+v, %v; want disabled, nil", c, err)
+	}
+
+	_ = cmd.Flags().Set(shared.LimitFlagName, "5")
+	c, err = shared.ParseChunkFlags(cmd)
+	if err != nil || !c.Enabled() || c.PageSize() != 5 {
+		t.Fatalf("--limit 5 = %+v, %v; want page size 5", c, err)
+	}
+	if got := c.Remaining(3); got != 2 {
+		t.Errorf("Remaining(3) = %d, want 2", got)
+	}
+
+	_ = cmd.Flags().Set(shared.ChunkSizeFlagName, "2")
+	if c, _ = shared.ParseChunkFlags(cmd); c.PageSize() != 2 {
+		t.Errorf("--chunk-size 2 page size = %d, want 2", c.PageSize())
+	}
+
+	_ = cmd.Flags().Set(shared.ChunkSizeFlagName, "-1")
+	if _, err = shared.ParseChunkFlags(cmd); !errors.Is(err, errdefs.ErrInvalidChunkFlags) {
+		t.Fatalf("--chunk-size -1 error = %v, want ErrInvalidChunkFlags", err)
+	}
+}
+
+func TestTableStream(t *testing.T) {
+	cmd, buf := newOutputCommand()
+	stream := shared.NewTableStream(cmd, []string{"NAME", "STATE"})
+
+	stream.Write(nil)
+	if stream.Started() || buf.Len() != 0 {
+		t.Fatalf("empty batch printed %q", buf.String())
+	}
+
+	stream.Write([][]string{{"alpha", "Ready"}})
+	stream.Write([][]string{{"bravo", "Stopped"}})
+	want := "NAME   STATE\n" +
+		"-----  -----\n" +
+		"alpha  Ready\n" +
+		"bravo  Stopped\n"
+	if got := buf.String(); got != want {
+		t.Errorf("stream =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestTableStreamQuiet(t *testing.T) {
+	cmd, buf := newOutputCommand()
+	shared.RegisterQuietFlag(cmd)
+	_ = cmd.Flags().Set(shared.QuietFlagName, "true")
+	stream := shared.NewTableStream(cmd, []string{"NAME", "STATE"})
+
+	stream.Write([][]string{{"alpha", "Ready"}})
+	stream.Write([][]string{{"bravo", "Stopped"}})
+	if got, want := buf.String(), "alpha\nbravo\n"; got != want {
+		t.Errorf("quiet stream = %q, want %q", got, want)
+	}
+}
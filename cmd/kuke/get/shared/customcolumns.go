@@ -0,0 +1,179 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/spf13/cobra"
+)
+
+// customColumnsPrefix introduces a custom-columns spec in `-o`.
+const customColumnsPrefix = "custom-columns="
+
+// customColumnNone is what a custom column shows for an item that lacks
+// the field, so every row keeps the same number of whitespace-separated
+// cells.
+const customColumnNone = "<none>"
+
+// CustomColumn is one HEADER:FIELD pair of a `-o custom-columns=` spec.
+type CustomColumn struct {
+	Header string
+	Field  string
+	path   []string
+}
+
+// ParseCustomColumns parses the spec after `custom-columns=`: a
+// comma-separated list of HEADER:FIELD pairs. FIELD is a path into the
+// resource's JSON form, written `.metadata.name`, `metadata.name` or
+// `{.metadata.name}`, with `[N]` indexing into a list (for example
+// `.spec.containers[0].image`).
+func ParseCustomColumns(spec string) ([]CustomColumn, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, fmt.Errorf("%w: empty spec, want HEADER:FIELD[,HEADER:FIELD...]", errdefs.ErrInvalidCustomColumns)
+	}
+	parts := strings.Split(spec, ",")
+	columns := make([]CustomColumn, 0, len(parts))
+	for _, part := range parts {
+		header, field, ok := strings.Cut(part, ":")
+		header = strings.TrimSpace(header)
+		field = strings.TrimSpace(field)
+		if !ok || header == "" || field == "" {
+			return nil, fmt.Errorf("%w: %q is not HEADER:FIELD", errdefs.ErrInvalidCustomColumns, part)
+		}
+		path, ok := parseFieldPath(field)
+		if !ok {
+			return nil, fmt.Errorf("%w: column %s has invalid field %q", errdefs.ErrInvalidCustomColumns, header, field)
+		}
+		columns = append(columns, CustomColumn{Header: header, Field: field, path: path})
+	}
+	return columns, nil
+}
+
+// parseFieldPath splits a custom-columns FIELD into the segments
+// lookupPath walks; each `[N]` becomes a numeric segment of its own.
+func parseFieldPath(field string) ([]string, bool) {
+	if inner, ok := strings.CutPrefix(field, "{"); ok {
+		if field, ok = strings.CutSuffix(inner, "}"); !ok {
+			return nil, false
+		}
+	}
+	field = strings.TrimPrefix(field, ".")
+	if field == "" {
+		return nil, false
+	}
+	var path []string
+	for _, seg := range strings.Split(field, ".") {
+		name, index, hasIndex := strings.Cut(seg, "[")
+		if name == "" {
+			return nil, false
+		}
+		path = append(path, name)
+		for hasIndex {
+			n, after, closed := strings.Cut(index, "]")
+			if _, err := strconv.ParseUint(n, 10, 0); !closed || err != nil {
+				return nil, false
+			}
+			path = append(path, n)
+			if after == "" {
+				break
+			}
+			if index, hasIndex = strings.CutPrefix(after, "["); !hasIndex {
+				return nil, false
+			}
+		}
+	}
+	return path, true
+}
+
+// PrintCustomColumns renders items as the table `-o custom-columns=<spec>`
+// describes, looking each cell up in the item's JSON form with the same
+// path evaluator --sort-by and --field-selector use. view, when non-nil,
+// supplies the value to render in place of the item, as in SortItems.
+// Missing, null and empty values print as <none>; objects and lists print
+// as compact JSON. A field no listed item carries is rejected, which
+// catches typos.
+func PrintCustomColumns[T any](cmd *cobra.Command, items []T, view func(*T) any) error {
+	output, err := outputValue(cmd)
+	if err != nil {
+		return err
+	}
+	spec, _ := strings.CutPrefix(strings.TrimSpace(output), customColumnsPrefix)
+	columns, err := ParseCustomColumns(spec)
+	if err != nil {
+		return err
+	}
+
+	headers := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = c.Header
+	}
+	found := make([]bool, len(columns))
+	rows := make([][]string, 0, len(items))
+	for i := range items {
+		var v any = &items[i]
+		if view != nil {
+			v = view(&items[i])
+		}
+		generic, genericErr := toGeneric(v)
+		if genericErr != nil {
+			return genericErr
+		}
+		row := make([]string, len(columns))
+		for j, c := range columns {
+			value, ok := lookupPath(generic, c.path)
+			found[j] = found[j] || ok
+			row[j] = renderColumnValue(value)
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) > 0 {
+		for j, c := range columns {
+			if !found[j] {
+				return fmt.Errorf("%w: no listed resource has field %q", errdefs.ErrInvalidCustomColumns, c.Field)
+			}
+		}
+	}
+	PrintTable(cmd, headers, rows)
+	return nil
+}
+
+func renderColumnValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return customColumnNone
+	case string:
+		if val == "" {
+			return customColumnNone
+		}
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	default:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprint(val)
+		}
+		return string(data)
+	}
+}
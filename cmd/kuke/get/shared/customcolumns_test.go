@@ -0,0 +1,143 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/cmd/kuke/get/shared"
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+func TestParseCustomColumns(t *testing.T) {
+	valid := map[string][]string{
+		"NAME:.metadata.name":                      {"NAME"},
+		"NAME:metadata.name,STATE:{.status.state}": {"NAME", "STATE"},
+		"IMAGE:.spec.containers[0].image":          {"IMAGE"},
+		" NAME : .metadata.name , N:.a.b[1][2].c ": {"NAME", "N"},
+	}
+	for spec, headers := range valid {
+		columns, err := shared.ParseCustomColumns(spec)
+		if err != nil {
+			t.Errorf("ParseCustomColumns(%q) error = %v", spec, err)
+			continue
+		}
+		got := make([]string, len(columns))
+		for i, c := range columns {
+			got[i] = c.Header
+		}
+		if strings.Join(got, ",") != strings.Join(headers, ",") {
+			t.Errorf("ParseCustomColumns(%q) headers = %v, want %v", spec, got, headers)
+		}
+	}
+
+	for _, spec := range []string{
+		"",
+		"NAME",
+		":.metadata.name",
+		"NAME:",
+		"NAME:.",
+		"NAME:.metadata..name",
+		"NAME:{.metadata.name",
+		"NAME:.spec.containers[x]",
+		"NAME:.spec.containers[-1]",
+		"NAME:.spec.containers[0",
+		"NAME:.spec.containers[0]x",
+		"NAME:[0]",
+	} {
+		if _, err := shared.ParseCustomColumns(spec); !errors.Is(err, errdefs.ErrInvalidCustomColumns) {
+			t.Errorf("ParseCustomColumns(%q) error = %v, want ErrInvalidCustomColumns", spec, err)
+		}
+	}
+}
+
+func TestParseOutputFormatCustomColumns(t *testing.T) {
+	cmd, _ := newOutputCommand()
+	cmd.Flags().StringP("output", "o", "", "")
+
+	_ = cmd.Flags().Set("output", "custom-columns=NAME:.metadata.name")
+	if format, err := shared.ParseOutputFormat(cmd); err != nil || format != shared.OutputFormatCustomColumns {
+		t.Fatalf("ParseOutputFormat = %q, %v; want custom-columns, nil", format, err)
+	}
+
+	_ = cmd.Flags().Set("output", "custom-columns=NAME")
+	if _, err := shared.ParseOutputFormat(cmd); !errors.Is(err, errdefs.ErrInvalidCustomColumns) {
+		t.Fatalf("ParseOutputFormat(bad spec) error = %v, want ErrInvalidCustomColumns", err)
+	}
+}
+
+type customColumnsItem struct {
+	Metadata struct {
+		Name   string            `json:"name"`
+		Labels map[string]string `json:"labels,omitempty"`
+	} `json:"metadata"`
+	Spec struct {
+		Ports []int `json:"ports,omitempty"`
+		Debug bool  `json:"debug"`
+	} `json:"spec"`
+}
+
+func newCustomColumnsItem(name string, ports ...int) customColumnsItem {
+	var item customColumnsItem
+	item.Metadata.Name = name
+	item.Spec.Ports = ports
+	return item
+}
+
+func TestPrintCustomColumns(t *testing.T) {
+	items := []customColumnsItem{newCustomColumnsItem("alpha", 80, 443), newCustomColumnsItem("bravo")}
+	items[0].Metadata.Labels = map[string]string{"app": "web"}
+
+	run := func(t *testing.T, spec string, noHeaders bool) (string, error) {
+		t.Helper()
+		cmd, buf := newOutputCommand()
+		cmd.Flags().StringP("output", "o", "", "")
+		shared.RegisterNoHeadersFlag(cmd)
+		_ = cmd.Flags().Set("output", "custom-columns="+spec)
+		if noHeaders {
+			_ = cmd.Flags().Set(shared.NoHeadersFlagName, "true")
+		}
+		err := shared.PrintCustomColumns(cmd, items, nil)
+		return buf.String(), err
+	}
+
+	out, err := run(t, "NAME:.metadata.name,PORT:.spec.ports[1],APP:.metadata.labels.app,DEBUG:.spec.debug", false)
+	if err != nil {
+		t.Fatalf("PrintCustomColumns error = %v", err)
+	}
+	want := "NAME   PORT    APP     DEBUG\n" +
+		"-----  ------  ------  -----\n" +
+		"alpha  443     web     false\n" +
+		"bravo  <none>  <none>  false\n"
+	if out != want {
+		t.Errorf("output =\n%s\nwant\n%s", out, want)
+	}
+
+	out, err = run(t, "NAME:.metadata.name,PORTS:.spec.ports", true)
+	if err != nil {
+		t.Fatalf("PrintCustomColumns error = %v", err)
+	}
+	if want = "alpha  [80,443]\nbravo  <none>  \n"; out != want {
+		t.Errorf("--no-headers output = %q, want %q", out, want)
+	}
+
+	if _, err = run(t, "NAME:.metadata.nmae", false); !errors.Is(err, errdefs.ErrInvalidCustomColumns) {
+		t.Errorf("unknown field error = %v, want ErrInvalidCustomColumns", err)
+	}
+}
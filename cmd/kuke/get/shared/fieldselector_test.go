@@ -0,0 +1,95 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/eminwux/kukeon/cmd/kuke/get/shared"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+func fieldSelectorCells() []v1beta1.CellDoc {
+	mk := func(name, realm string, state v1beta1.CellState) v1beta1.CellDoc {
+		return v1beta1.CellDoc{
+			Metadata: v1beta1.CellMetadata{Name: name},
+			Spec:     v1beta1.CellSpec{RealmID: realm, SpaceID: "s", StackID: "st"},
+			Status:   v1beta1.CellStatus{State: state},
+		}
+	}
+	return []v1beta1.CellDoc{
+		mk("a", "default", v1beta1.CellStateReady),
+		mk("b", "default", v1beta1.CellStateFailed),
+		mk("c", "prod", v1beta1.CellStateFailed),
+		mk("d", "prod", v1beta1.CellStateStopped),
+	}
+}
+
+func TestFilterByFieldSelector(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "blank matches all", input: "", want: []string{"a", "b", "c", "d"}},
+		{name: "status equality", input: "status.state=Failed", want: []string{"b", "c"}},
+		{name: "double-equals alias", input: "status.state==Failed", want: []string{"b", "c"}},
+		{name: "status inequality", input: "status.state!=Failed", want: []string{"a", "d"}},
+		{name: "AND with a spec field", input: "status.state=Failed, spec.realmId=prod", want: []string{"c"}},
+		{name: "metadata name", input: "metadata.name=d", want: []string{"d"}},
+		{name: "no match", input: "status.state=Pending", want: []string{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sel, err := shared.ParseFieldSelector(tc.input, shared.CellSelectableFields)
+			if err != nil {
+				t.Fatalf("ParseFieldSelector(%q): %v", tc.input, err)
+			}
+			got, err := shared.FilterByFieldSelector(fieldSelectorCells(), sel)
+			if err != nil {
+				t.Fatalf("FilterByFieldSelector: %v", err)
+			}
+			names := make([]string, 0, len(got))
+			for _, c := range got {
+				names = append(names, c.Metadata.Name)
+			}
+			if !slices.Equal(names, tc.want) {
+				t.Errorf("got %v, want %v", names, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseFieldSelector_Rejects(t *testing.T) {
+	for _, input := range []string{
+		"status.cgroupPath=/x",                 // not selectable
+		"spec.stackId=st",                      // selectable on cells, not realms
+		"metadata.labels.env=prod",             // labels belong to -l
+		"status.state",                         // no operator
+		"=Failed",                              // empty field
+		"status.state=Failed,,metadata.name=a", // empty clause
+	} {
+		if _, err := shared.ParseFieldSelector(input, shared.RealmSelectableFields); !errors.Is(
+			err, errdefs.ErrInvalidFieldSelector,
+		) {
+			t.Errorf("ParseFieldSelector(%q) error = %v, want ErrInvalidFieldSelector", input, err)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// ShowLabelsFlagName is the long flag name for `--show-labels`.
+	ShowLabelsFlagName = "show-labels"
+	// LabelColumnsFlagName is the long flag name for `-L`/`--label-columns`.
+	LabelColumnsFlagName = "label-columns"
+
+	// showLabelsUser is the value a bare `--show-labels` takes; showLabelsAll
+	// also lists kukeon's own kukeon.io/ labels.
+	showLabelsUser = "user"
+	showLabelsAll  = "all"
+
+	// internalLabelPrefix marks the labels kukeon stamps on resources itself.
+	internalLabelPrefix = "kukeon.io/"
+)
+
+const showLabelsFlagUsage = "Append a LABELS column to table output; " +
+	"--show-labels=all also lists kukeon's own kukeon.io/ labels"
+
+const labelColumnsFlagUsage = "Add a table column with the value of each given label key " +
+	"(repeatable or comma-separated, e.g. -L env,tier)"
+
+// LabelColumns is the parsed `--show-labels` / `-L` pair: extra table
+// columns that surface each listed resource's Metadata.Labels, so a
+// selector's matches can be checked by eye. The zero value adds nothing.
+type LabelColumns struct {
+	keys         []string
+	showLabels   bool
+	showInternal bool
+}
+
+// RegisterLabelColumnFlags adds `--show-labels` and `-L`/`--label-columns`
+// to cmd, next to the `-l`/`--selector` flag they are meant to pair with.
+func RegisterLabelColumnFlags(cmd *cobra.Command) {
+	cmd.Flags().String(ShowLabelsFlagName, "", showLabelsFlagUsage)
+	cmd.Flags().Lookup(ShowLabelsFlagName).NoOptDefVal = showLabelsUser
+	cmd.Flags().StringSliceP(LabelColumnsFlagName, "L", nil, labelColumnsFlagUsage)
+}
+
+// ParseLabelColumnsFlags reads `--show-labels` and `-L` from cmd. A command
+// that does not register them gets the zero LabelColumns.
+func ParseLabelColumnsFlags(cmd *cobra.Command) (LabelColumns, error) {
+	if cmd == nil || cmd.Flags().Lookup(ShowLabelsFlagName) == nil {
+		return LabelColumns{}, nil
+	}
+	show, _ := cmd.Flags().GetString(ShowLabelsFlagName)
+	keys, _ := cmd.Flags().GetStringSlice(LabelColumnsFlagName)
+	return ParseLabelColumns(show, keys)
+}
+
+// ParseLabelColumns builds LabelColumns from a `--show-labels` value (empty,
+// "user", or "all") and the `-L` keys. Keys are trimmed and de-duplicated;
+// a blank key is an error.
+func ParseLabelColumns(show string, keys []string) (LabelColumns, error) {
+	var c LabelColumns
+	switch strings.TrimSpace(show) {
+	case "":
+	case showLabelsUser:
+		c.showLabels = true
+	case showLabelsAll:
+		c.showLabels = true
+		c.showInternal = true
+	default:
+		return LabelColumns{}, fmt.Errorf("%w: --%s must be %q or %q, got %q",
+			errdefs.ErrInvalidLabelColumns, ShowLabelsFlagName, showLabelsUser, showLabelsAll, show)
+	}
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return LabelColumns{}, fmt.Errorf("%w: -L key is empty", errdefs.ErrInvalidLabelColumns)
+		}
+		if !slices.Contains(c.keys, key) {
+			c.keys = append(c.keys, key)
+		}
+	}
+	return c, nil
+}
+
+// AppendHeaders appends one column per `-L` key — named after the key's
+// last path segment, upper-cased, as kubectl does — then LABELS when
+// `--show-labels` is set.
+func (c LabelColumns) AppendHeaders(headers []string) []string {
+	for _, key := range c.keys {
+		name := key
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			name = name[i+1:]
+		}
+		headers = append(headers, strings.ToUpper(name))
+	}
+	if c.showLabels {
+		headers = append(headers, "LABELS")
+	}
+	return headers
+}
+
+// AppendRow appends the cells matching AppendHeaders for a resource with
+// labels: each `-L` key's value (empty when unset) and the sorted
+// `key=value` list, "<none>" when it is empty. The LABELS list leaves out
+// kukeon.io/ labels unless `--show-labels=all`; an explicit `-L` key is
+// always shown.
+func (c LabelColumns) AppendRow(row []string, labels map[string]string) []string {
+	for _, key := range c.keys {
+		row = append(row, labels[key])
+	}
+	if !c.showLabels {
+		return row
+	}
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		if !c.showInternal && strings.HasPrefix(key, internalLabelPrefix) {
+			continue
+		}
+		pairs = append(pairs, key+"="+value)
+	}
+	if len(pairs) == 0 {
+		return append(row, "<none>")
+	}
+	slices.Sort(pairs)
+	return append(row, strings.Join(pairs, ","))
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/eminwux/kukeon/cmd/kuke/get/shared"
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+func TestLabelColumns(t *testing.T) {
+	labels := map[string]string{
+		"tier":                "web",
+		"env":                 "prod",
+		"kukeon.io/blueprint": "base",
+	}
+	cases := []struct {
+		name        string
+		show        string
+		keys        []string
+		wantHeaders []string
+		wantRow     []string
+	}{
+		{name: "no flags adds nothing"},
+		{
+			name:        "show-labels omits kukeon.io labels",
+			show:        "user",
+			wantHeaders: []string{"LABELS"},
+			wantRow:     []string{"env=prod,tier=web"},
+		},
+		{
+			name:        "show-labels=all includes them",
+			show:        "all",
+			wantHeaders: []string{"LABELS"},
+			wantRow:     []string{"env=prod,kukeon.io/blueprint=base,tier=web"},
+		},
+		{
+			name:        "-L keys get their own columns",
+			keys:        []string{"env", " kukeon.io/blueprint", "owner", "env"},
+			wantHeaders: []string{"ENV", "BLUEPRINT", "OWNER"},
+			wantRow:     []string{"prod", "base", ""},
+		},
+		{
+			name:        "-L columns come before LABELS",
+			show:        "user",
+			keys:        []string{"tier"},
+			wantHeaders: []string{"TIER", "LABELS"},
+			wantRow:     []string{"web", "env=prod,tier=web"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			columns, err := shared.ParseLabelColumns(tc.show, tc.keys)
+			if err != nil {
+				t.Fatalf("ParseLabelColumns: %v", err)
+			}
+			if got := columns.AppendHeaders([]string{"NAME"}); !slices.Equal(got, append([]string{"NAME"}, tc.wantHeaders...)) {
+				t.Errorf("headers = %v, want NAME + %v", got, tc.wantHeaders)
+			}
+			if got := columns.AppendRow([]string{"a"}, labels); !slices.Equal(got, append([]string{"a"}, tc.wantRow...)) {
+				t.Errorf("row = %q, want a + %q", got, tc.wantRow)
+			}
+		})
+	}
+
+	columns, _ := shared.ParseLabelColumns("user", nil)
+	if got := columns.AppendRow(nil, map[string]string{"kukeon.io/blueprint": "base"}); !slices.Equal(got, []string{"<none>"}) {
+		t.Errorf("row for internal-only labels = %q, want <none>", got)
+	}
+
+	for _, bad := range []struct {
+		show string
+		keys []string
+	}{
+		{show: "some"},
+		{keys: []string{"env", " "}},
+	} {
+		if _, err := shared.ParseLabelColumns(bad.show, bad.keys); !errors.Is(err, errdefs.ErrInvalidLabelColumns) {
+			t.Errorf("ParseLabelColumns(%q, %q) error = %v, want ErrInvalidLabelColumns", bad.show, bad.keys, err)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared
+
+import "github.com/spf13/cobra"
+
+// NoHeadersFlagName is the long flag name for `--no-headers` on every
+// `kuke get <kind>` list.
+const NoHeadersFlagName = "no-headers"
+
+// RegisterNoHeadersFlag adds `--no-headers` to cmd.
+func RegisterNoHeadersFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(NoHeadersFlagName, false,
+		"Omit the header and separator lines from table and custom-columns output")
+}
+
+// NoHeaders reports whether `--no-headers` is set on cmd. A command that
+// does not register the flag always prints headers.
+func NoHeaders(cmd *cobra.Command) bool {
+	if cmd == nil || cmd.Flags().Lookup(NoHeadersFlagName) == nil {
+		return false
+	}
+	noHeaders, _ := cmd.Flags().GetBool(NoHeadersFlagName)
+	return noHeaders
+}
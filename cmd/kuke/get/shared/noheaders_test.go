@@ -0,0 +1,47 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared_test
+
+import (
+	"testing"
+
+	"github.com/eminwux/kukeon/cmd/kuke/get/shared"
+)
+
+func TestPrintTableNoHeaders(t *testing.T) {
+	cmd, buf := newOutputCommand()
+	shared.RegisterNoHeadersFlag(cmd)
+	if err := cmd.Flags().Set(shared.NoHeadersFlagName, "true"); err != nil {
+		t.Fatalf("set --no-headers: %v", err)
+	}
+
+	shared.PrintTable(cmd, []string{"NAME", "STATE"}, [][]string{{"a", "Ready"}, {"bravo", "Stopped"}})
+	if got, want := buf.String(), "a      Ready  \nbravo  Stopped\n"; got != want {
+		t.Errorf("table = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	stream := shared.NewTableStream(cmd, []string{"NAME", "STATE"})
+	stream.Write([][]string{{"a", "Ready"}})
+	stream.Write([][]string{{"b", "Stopped"}})
+	if got, want := buf.String(), "a  Ready\nb  Stopped\n"; got != want {
+		t.Errorf("stream = %q, want %q", got, want)
+	}
+	if !stream.Started() {
+		t.Error("stream.Started() = false after writing rows")
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// QuietFlagName is the long flag name for `-q`/`--quiet` on every
+// `kuke get <kind>` list.
+const QuietFlagName = "quiet"
+
+// RegisterQuietFlag adds the standard `-q`/`--quiet` flag to cmd.
+func RegisterQuietFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolP(QuietFlagName, "q", false,
+		"Print only resource names, one per line, with no headers or status (for piping into xargs)")
+}
+
+// IsQuiet reports whether `-q`/`--quiet` is set on cmd. A command that does
+// not register the flag is never quiet.
+func IsQuiet(cmd *cobra.Command) bool {
+	if cmd == nil || cmd.Flags().Lookup(QuietFlagName) == nil {
+		return false
+	}
+	quiet, _ := cmd.Flags().GetBool(QuietFlagName)
+	return quiet
+}
+
+// PrintEmpty prints the "No <kind> found." line a table list shows when
+// nothing matched. Quiet mode prints nothing, so an empty match feeds an
+// empty stream to xargs instead of a message it would treat as a name.
+func PrintEmpty(cmd *cobra.Command, msg string) {
+	if IsQuiet(cmd) {
+		return
+	}
+	cmd.Println(msg)
+}
+
+// printNames is PrintTable's quiet short-circuit: the first column of each
+// row (the resource name or ID every table leads with), one per line.
+func printNames(cmd *cobra.Command, rows [][]string) {
+	out := cmd.OutOrStdout()
+	for _, row := range rows {
+		if len(row) > 0 {
+			_, _ = fmt.Fprintln(out, row[0])
+		}
+	}
+}
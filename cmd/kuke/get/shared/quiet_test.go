@@ -0,0 +1,62 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eminwux/kukeon/cmd/kuke/get/shared"
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+func TestPrintTableQuiet(t *testing.T) {
+	cmd, buf := newOutputCommand()
+	shared.RegisterQuietFlag(cmd)
+	if err := cmd.Flags().Set(shared.QuietFlagName, "true"); err != nil {
+		t.Fatalf("set --quiet: %v", err)
+	}
+
+	shared.PrintTable(cmd, []string{"NAME", "STATE"}, [][]string{{"alpha", "Ready"}, {"bravo", "Stopped"}})
+	if got, want := buf.String(), "alpha\nbravo\n"; got != want {
+		t.Errorf("quiet table = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	shared.PrintTable(cmd, []string{"NAME"}, nil)
+	shared.PrintEmpty(cmd, "No cells found.")
+	if buf.Len() != 0 {
+		t.Errorf("quiet empty list printed %q, want nothing", buf.String())
+	}
+}
+
+func TestParseOutputFormatQuiet(t *testing.T) {
+	cmd, _ := newOutputCommand()
+	cmd.Flags().StringP("output", "o", "", "")
+	shared.RegisterQuietFlag(cmd)
+	_ = cmd.Flags().Set(shared.QuietFlagName, "true")
+
+	format, err := shared.ParseOutputFormat(cmd)
+	if err != nil || format != shared.OutputFormatTable {
+		t.Fatalf("ParseOutputFormat(-q) = %q, %v; want table, nil", format, err)
+	}
+
+	_ = cmd.Flags().Set("output", "json")
+	if _, err = shared.ParseOutputFormat(cmd); !errors.Is(err, errdefs.ErrQuietWithOutput) {
+		t.Fatalf("ParseOutputFormat(-q -o json) error = %v, want ErrQuietWithOutput", err)
+	}
+}
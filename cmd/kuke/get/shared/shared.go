@@ -26,6 +26,7 @@ import (
 	"github.com/eminwux/kukeon/cmd/config"
 	createshared "github.com/eminwux/kukeon/cmd/kuke/create/shared"
 	"github.com/eminwux/kukeon/internal/controller"
+	"github.com/eminwux/kukeon/internal/errdefs"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
@@ -49,6 +50,10 @@ const (
 	OutputFormatJSON  OutputFormat = "json"
 	OutputFormatTable OutputFormat = "table"
 	OutputFormatWide  OutputFormat = "wide"
+
+	// OutputFormatCustomColumns is `-o custom-columns=<spec>`; the spec
+	// itself is read back from the flag by PrintCustomColumns.
+	OutputFormatCustomColumns OutputFormat = "custom-columns"
 )
 
 // ParseOutputFormat resolves the output format using kuke's standard
@@ -58,33 +63,54 @@ const (
 // shared viper key at construction time, but viper only retains the last
 // binding for a given key; reading the active command's flag directly
 // keeps the flag working for every subcommand and lets viper handle the
-// env/config fall-throughs.
+// env/config fall-throughs. `-q`/`--quiet` resolves to table, whose
+// renderer then prints names only; an explicit `--output` alongside it is
+// refused rather than silently dropped. A `custom-columns=<spec>` value is
+// validated here so a malformed spec fails before anything is fetched.
 func ParseOutputFormat(cmd *cobra.Command) (OutputFormat, error) {
-	output := ""
-	if cmd != nil && cmd.Flags().Changed("output") {
-		var err error
-		output, err = cmd.Flags().GetString("output")
-		if err != nil {
-			return OutputFormatTable, err
+	if IsQuiet(cmd) {
+		if cmd.Flags().Changed("output") {
+			return OutputFormatTable, errdefs.ErrQuietWithOutput
 		}
+		return OutputFormatTable, nil
 	}
-	if output == "" {
-		output = viper.GetString(config.KUKE_GET_OUTPUT.ViperKey)
+	output, err := outputValue(cmd)
+	if err != nil {
+		return OutputFormatTable, err
 	}
 
 	trimmed := strings.TrimSpace(output)
 	if trimmed == "" {
 		return OutputFormatTable, nil
 	}
+	if spec, ok := strings.CutPrefix(trimmed, customColumnsPrefix); ok {
+		if _, err = ParseCustomColumns(spec); err != nil {
+			return OutputFormatTable, err
+		}
+		return OutputFormatCustomColumns, nil
+	}
 	format := OutputFormat(strings.ToLower(trimmed))
 	switch format {
 	case OutputFormatYAML, OutputFormatJSON, OutputFormatTable, OutputFormatWide:
 		return format, nil
 	default:
-		return OutputFormatTable, fmt.Errorf("invalid output format: %s (supported: yaml, json, table, wide)", output)
+		return OutputFormatTable, fmt.Errorf(
+			"invalid output format: %s (supported: yaml, json, table, wide, custom-columns=<spec>)", output)
 	}
 }
 
+// outputValue is the raw `--output` value ParseOutputFormat resolves: the
+// active command's flag when set, otherwise whatever viper has bound.
+func outputValue(cmd *cobra.Command) (string, error) {
+	if cmd != nil && cmd.Flags().Changed("output") {
+		output, err := cmd.Flags().GetString("output")
+		if err != nil || output != "" {
+			return output, err
+		}
+	}
+	return viper.GetString(config.KUKE_GET_OUTPUT.ViperKey), nil
+}
+
 // PrintYAML prints the resource as YAML to cmd.OutOrStdout() so callers
 // can capture output via cobra's cmd.SetOut(buf) in tests. Matches the
 // shape PrintTable already takes.
@@ -103,17 +129,26 @@ func PrintJSON(cmd *cobra.Command, doc any) error {
 	return encoder.Encode(doc)
 }
 
-// PrintTable prints resources in a table format.
+// PrintTable prints resources in a table format. In quiet mode it prints
+// only the first column of each row; with --no-headers it drops the header
+// and separator lines and sizes the columns from the rows alone.
 func PrintTable(cmd *cobra.Command, headers []string, rows [][]string) {
+	if IsQuiet(cmd) {
+		printNames(cmd, rows)
+		return
+	}
 	if len(rows) == 0 {
 		cmd.Println("No resources found.")
 		return
 	}
+	noHeaders := NoHeaders(cmd)
 
 	// Calculate column widths
 	widths := make([]int, len(headers))
-	for i, h := range headers {
-		widths[i] = len(h)
+	if !noHeaders {
+		for i, h := range headers {
+			widths[i] = len(h)
+		}
 	}
 	for _, row := range rows {
 		for i, cell := range row {
@@ -123,29 +158,31 @@ func PrintTable(cmd *cobra.Command, headers []string, rows [][]string) {
 		}
 	}
 
-	// Print header
-	headerRow := ""
-	var headerRowSb100 strings.Builder
-	for i, h := range headers {
-		if i > 0 {
-			headerRowSb100.WriteString("  ")
+	if !noHeaders {
+		// Print header
+		headerRow := ""
+		var headerRowSb100 strings.Builder
+		for i, h := range headers {
+			if i > 0 {
+				headerRowSb100.WriteString("  ")
+			}
+			headerRowSb100.WriteString(fmt.Sprintf("%-*s", widths[i], h))
 		}
-		headerRowSb100.WriteString(fmt.Sprintf("%-*s", widths[i], h))
-	}
-	headerRow += headerRowSb100.String()
-	cmd.Println(headerRow)
-
-	// Print separator
-	separator := ""
-	var separatorSb110 strings.Builder
-	for i, w := range widths {
-		if i > 0 {
-			separatorSb110.WriteString("  ")
+		headerRow += headerRowSb100.String()
+		cmd.Println(headerRow)
+
+		// Print separator
+		separator := ""
+		var separatorSb110 strings.Builder
+		for i, w := range widths {
+			if i > 0 {
+				separatorSb110.WriteString("  ")
+			}
+			separatorSb110.WriteString(strings.Repeat("-", w))
 		}
-		separatorSb110.WriteString(strings.Repeat("-", w))
+		separator += separatorSb110.String()
+		cmd.Println(separator)
 	}
-	separator += separatorSb110.String()
-	cmd.Println(separator)
 
 	// Print rows
 	for _, row := range rows {
@@ -0,0 +1,229 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/spf13/cobra"
+)
+
+// SortByFlagName is the long flag name for `--sort-by` on every
+// `kuke get <kind>` list.
+const SortByFlagName = "sort-by"
+
+const sortByFlagUsage = "Sort list output by a field: name, createdAt, state, or a " +
+	"dotted path into the JSON form (e.g. spec.realmId); prefix with '-' to sort descending"
+
+// sortByAliases maps the short field names to their path in a resource's
+// JSON form.
+var sortByAliases = map[string]string{
+	"name":      "metadata.name",
+	"createdAt": "status.createdAt",
+	"state":     "status.state",
+}
+
+// SortBy is a parsed `--sort-by` value. The zero value is not valid; use
+// ParseSortBy, which defaults to name ascending.
+type SortBy struct {
+	raw        string
+	path       []string
+	descending bool
+}
+
+// RegisterSortByFlag adds `--sort-by` to cmd.
+func RegisterSortByFlag(cmd *cobra.Command) {
+	cmd.Flags().String(SortByFlagName, "name", sortByFlagUsage)
+}
+
+// ParseSortByFlag reads `--sort-by` from cmd. A command that does not
+// register the flag sorts by name.
+func ParseSortByFlag(cmd *cobra.Command) (SortBy, error) {
+	raw := ""
+	if cmd != nil {
+		raw, _ = cmd.Flags().GetString(SortByFlagName)
+	}
+	return ParseSortBy(raw)
+}
+
+// ParseSortBy parses a `--sort-by` value: an alias (name, createdAt,
+// state) or a dotted path such as `status.network.bridgeName`, optionally
+// prefixed with '-' for descending order. Blank means name ascending.
+func ParseSortBy(s string) (SortBy, error) {
+	raw := strings.TrimSpace(s)
+	field := raw
+	descending := strings.HasPrefix(field, "-")
+	if descending {
+		field = strings.TrimSpace(field[1:])
+	}
+	if field == "" {
+		if descending {
+			return SortBy{}, fmt.Errorf("%w: %q names no field", errdefs.ErrInvalidSortBy, raw)
+		}
+		field = "name"
+	}
+	if alias, ok := sortByAliases[field]; ok {
+		field = alias
+	}
+	path := strings.Split(field, ".")
+	if slices.Contains(path, "") {
+		return SortBy{}, fmt.Errorf("%w: %q", errdefs.ErrInvalidSortBy, raw)
+	}
+	return SortBy{raw: raw, path: path, descending: descending}, nil
+}
+
+// NeedsStatus reports whether the sort key lives under status, so callers
+// that skip status lookups for some output formats know to perform them.
+func (s SortBy) NeedsStatus() bool {
+	return len(s.path) > 0 && s.path[0] == "status"
+}
+
+// SortItems sorts items in place by s. Each item is marshaled to its JSON
+// form and the key looked up by path, so any field works without per-kind
+// code. view, when non-nil, supplies the value to marshal in place of the
+// item itself, for list rows whose sortable fields live outside the item.
+// Equal keys fall back to metadata.name. An error is returned when no item
+// carries the field, which catches typos.
+func SortItems[T any](items []T, s SortBy, view func(*T) any) error {
+	if len(items) < 2 || len(s.path) == 0 {
+		return nil
+	}
+	type keyed struct {
+		item  T
+		key   any
+		name  any
+		found bool
+	}
+	namePath := []string{"metadata", "name"}
+	rows := make([]keyed, len(items))
+	found := false
+	for i := range items {
+		var v any = &items[i]
+		if view != nil {
+			v = view(&items[i])
+		}
+		generic, err := toGeneric(v)
+		if err != nil {
+			return err
+		}
+		key, ok := lookupPath(generic, s.path)
+		name, _ := lookupPath(generic, namePath)
+		rows[i] = keyed{item: items[i], key: key, name: name, found: ok}
+		found = found || ok
+	}
+	if !found {
+		return fmt.Errorf("%w: no listed resource has field %q", errdefs.ErrInvalidSortBy, strings.Join(s.path, "."))
+	}
+
+	slices.SortStableFunc(rows, func(a, b keyed) int {
+		c := compareSortValues(a.key, b.key)
+		if s.descending {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+		return compareSortValues(a.name, b.name)
+	})
+	for i := range rows {
+		items[i] = rows[i].item
+	}
+	return nil
+}
+
+func toGeneric(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic any
+	if err = json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// lookupPath walks path through a toGeneric value. A segment names an
+// object key, or indexes into a list when it is a number.
+func lookupPath(v any, path []string) (any, bool) {
+	for _, seg := range path {
+		switch node := v.(type) {
+		case map[string]any:
+			var ok bool
+			if v, ok = node[seg]; !ok {
+				return nil, false
+			}
+		case []any:
+			i, err := strconv.Atoi(seg)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			v = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// compareSortValues orders JSON values: missing/null first, then numbers,
+// timestamps, and strings by value, and booleans false before true. Values
+// of different kinds compare by their printed form.
+func compareSortValues(a, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	switch av := a.(type) {
+	case float64:
+		if bv, ok := b.(float64); ok {
+			return cmp.Compare(av, bv)
+		}
+	case string:
+		if bv, ok := b.(string); ok {
+			at, aErr := time.Parse(time.RFC3339Nano, av)
+			bt, bErr := time.Parse(time.RFC3339Nano, bv)
+			if aErr == nil && bErr == nil {
+				return at.Compare(bt)
+			}
+			return strings.Compare(av, bv)
+		}
+	case bool:
+		if bv, ok := b.(bool); ok {
+			switch {
+			case av == bv:
+				return 0
+			case !av:
+				return -1
+			default:
+				return 1
+			}
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
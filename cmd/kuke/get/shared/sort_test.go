@@ -0,0 +1,116 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eminwux/kukeon/cmd/kuke/get/shared"
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+type sortItem struct {
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Status struct {
+		Count int `json:"count,omitempty"`
+	} `json:"status"`
+}
+
+func newSortItem(name string, count int) sortItem {
+	var it sortItem
+	it.Metadata.Name = name
+	it.Status.Count = count
+	return it
+}
+
+func sortedNames(items []sortItem) []string {
+	names := make([]string, len(items))
+	for i, it := range items {
+		names[i] = it.Metadata.Name
+	}
+	return names
+}
+
+func TestSortItems(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "blank defaults to name", input: "", want: []string{"a", "b", "c", "d"}},
+		{name: "descending name", input: "-name", want: []string{"d", "c", "b", "a"}},
+		{name: "numeric path, missing first, ties by name", input: "status.count", want: []string{"d", "b", "c", "a"}},
+		{name: "numeric descending", input: "-status.count", want: []string{"a", "b", "c", "d"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			items := []sortItem{
+				newSortItem("c", 5),
+				newSortItem("a", 10),
+				newSortItem("d", 0),
+				newSortItem("b", 5),
+			}
+			s, err := shared.ParseSortBy(tc.input)
+			if err != nil {
+				t.Fatalf("ParseSortBy(%q): %v", tc.input, err)
+			}
+			if err = shared.SortItems(items, s, nil); err != nil {
+				t.Fatalf("SortItems: %v", err)
+			}
+			got := sortedNames(items)
+			for i := range tc.want {
+				if got[i] != tc.want[i] {
+					t.Fatalf("order = %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSortItems_UnknownField(t *testing.T) {
+	items := []sortItem{newSortItem("a", 1), newSortItem("b", 2)}
+	s, err := shared.ParseSortBy("spec.missing")
+	if err != nil {
+		t.Fatalf("ParseSortBy: %v", err)
+	}
+	if err = shared.SortItems(items, s, nil); !errors.Is(err, errdefs.ErrInvalidSortBy) {
+		t.Fatalf("expected ErrInvalidSortBy, got: %v", err)
+	}
+}
+
+func TestParseSortBy_Rejects(t *testing.T) {
+	for _, in := range []string{"-", "status..state", ".name"} {
+		if _, err := shared.ParseSortBy(in); !errors.Is(err, errdefs.ErrInvalidSortBy) {
+			t.Errorf("ParseSortBy(%q): expected ErrInvalidSortBy, got: %v", in, err)
+		}
+	}
+}
+
+func TestSortBy_NeedsStatus(t *testing.T) {
+	for in, want := range map[string]bool{"name": false, "state": true, "-createdAt": true, "spec.realmId": false} {
+		s, err := shared.ParseSortBy(in)
+		if err != nil {
+			t.Fatalf("ParseSortBy(%q): %v", in, err)
+		}
+		if got := s.NeedsStatus(); got != want {
+			t.Errorf("ParseSortBy(%q).NeedsStatus() = %v, want %v", in, got, want)
+		}
+	}
+}
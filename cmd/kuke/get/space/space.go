@@ -54,8 +54,23 @@ func NewSpaceCmd() *cobra.Command {
 				return err
 			}
 
+			fieldSelector, err := shared.ParseFieldSelectorFlag(cmd, shared.SpaceSelectableFields)
+			if err != nil {
+				return err
+			}
+
 			realm := shared.ExplicitFlag(cmd, "realm", config.KUKE_GET_SPACE_REALM.ViperKey)
 
+			sortBy, err := shared.ParseSortByFlag(cmd)
+			if err != nil {
+				return err
+			}
+
+			columns, err := shared.ParseLabelColumnsFlags(cmd)
+			if err != nil {
+				return err
+			}
+
 			var name string
 			if len(args) > 0 {
 				name = strings.TrimSpace(args[0])
@@ -66,6 +81,9 @@ func NewSpaceCmd() *cobra.Command {
 			if name != "" && !selector.Empty() {
 				return errdefs.ErrSelectorWithName
 			}
+			if name != "" && !fieldSelector.Empty() {
+				return errdefs.ErrFieldSelectorWithName
+			}
 
 			client, err := resolveClient(cmd)
 			if err != nil {
@@ -87,14 +105,14 @@ func NewSpaceCmd() *cobra.Command {
 				result, err := client.GetSpace(cmd.Context(), doc)
 				if err != nil {
 					if errors.Is(err, errdefs.ErrSpaceNotFound) {
-						return fmt.Errorf("space %q not found in realm %q", name, realm)
+						return fmt.Errorf("space %q not found in realm %q: %w", name, realm, errdefs.ErrSpaceNotFound)
 					}
 					return err
 				}
 				if !result.MetadataExists {
-					return fmt.Errorf("space %q not found in realm %q", name, realm)
+					return fmt.Errorf("space %q not found in realm %q: %w", name, realm, errdefs.ErrSpaceNotFound)
 				}
-				return printSpace(cmd, &result.Space, outputFormat)
+				return printSpace(cmd, &result.Space, outputFormat, columns)
 			}
 
 			spaces, err := client.ListSpaces(cmd.Context(), realm)
@@ -102,7 +120,13 @@ func NewSpaceCmd() *cobra.Command {
 				return err
 			}
 			spaces = filterSpacesBySelector(spaces, selector)
-			return printSpaces(cmd, spaces, outputFormat)
+			if spaces, err = shared.FilterByFieldSelector(spaces, fieldSelector); err != nil {
+				return err
+			}
+			if err = shared.SortItems(spaces, sortBy, nil); err != nil {
+				return err
+			}
+			return printSpaces(cmd, spaces, outputFormat, columns)
 		},
 	}
 
@@ -110,11 +134,17 @@ func NewSpaceCmd() *cobra.Command {
 	_ = viper.BindPFlag(config.KUKE_GET_SPACE_REALM.ViperKey, cmd.Flags().Lookup("realm"))
 
 	cmd.Flags().
-		StringP("output", "o", "", "Output format (yaml, json, table, wide). Default: table for list, table for single resource")
+		StringP("output", "o", "", "Output format (yaml, json, table, wide, custom-columns=<spec>). "+
+			"Default: table for list, table for single resource")
 	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("output"))
 	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("o"))
+	shared.RegisterSortByFlag(cmd)
+	shared.RegisterQuietFlag(cmd)
+	shared.RegisterNoHeadersFlag(cmd)
 
 	shared.RegisterLabelSelectorFlag(cmd)
+	shared.RegisterFieldSelectorFlag(cmd)
+	shared.RegisterLabelColumnFlags(cmd)
 
 	cmd.ValidArgsFunction = config.CompleteSpaceNames
 	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
@@ -147,7 +177,12 @@ func filterSpacesBySelector(spaces []v1beta1.SpaceDoc, selector *shared.LabelSel
 	return out
 }
 
-func printSpace(cmd *cobra.Command, space *v1beta1.SpaceDoc, format shared.OutputFormat) error {
+func printSpace(
+	cmd *cobra.Command,
+	space *v1beta1.SpaceDoc,
+	format shared.OutputFormat,
+	columns shared.LabelColumns,
+) error {
 	switch format {
 	case shared.OutputFormatJSON:
 		return shared.PrintJSON(cmd, space)
@@ -156,7 +191,7 @@ func printSpace(cmd *cobra.Command, space *v1beta1.SpaceDoc, format shared.Outpu
 	default:
 		// table / wide: render the single found element as a one-row table
 		// with the same columns as the list view (kubectl parity).
-		return printSpaces(cmd, []v1beta1.SpaceDoc{*space}, format)
+		return printSpaces(cmd, []v1beta1.SpaceDoc{*space}, format, columns)
 	}
 }
 
@@ -164,15 +199,18 @@ func printSpaces(
 	cmd *cobra.Command,
 	spaces []v1beta1.SpaceDoc,
 	format shared.OutputFormat,
+	columns shared.LabelColumns,
 ) error {
 	switch format {
 	case shared.OutputFormatYAML:
 		return shared.PrintYAML(cmd, spaces)
 	case shared.OutputFormatJSON:
 		return shared.PrintJSON(cmd, spaces)
+	case shared.OutputFormatCustomColumns:
+		return shared.PrintCustomColumns(cmd, spaces, nil)
 	case shared.OutputFormatTable, shared.OutputFormatWide:
 		if len(spaces) == 0 {
-			cmd.Println("No spaces found.")
+			shared.PrintEmpty(cmd, "No spaces found.")
 			return nil
 		}
 		wide := format == shared.OutputFormatWide
@@ -180,6 +218,7 @@ func printSpaces(
 		if wide {
 			headers = append(headers, "EGRESS", "NET-DEFAULTS")
 		}
+		headers = columns.AppendHeaders(headers)
 		now := time.Now()
 		rows := make([][]string, 0, len(spaces))
 		for i := range spaces {
@@ -189,7 +228,7 @@ func printSpaces(
 			if wide {
 				row = append(row, egressDefault(s.Spec.Network), netDefaults(s.Spec.Defaults))
 			}
-			rows = append(rows, row)
+			rows = append(rows, columns.AppendRow(row, s.Metadata.Labels))
 		}
 		shared.PrintTable(cmd, headers, rows)
 		return nil
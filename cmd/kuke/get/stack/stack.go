@@ -54,9 +54,24 @@ func NewStackCmd() *cobra.Command {
 				return err
 			}
 
+			fieldSelector, err := shared.ParseFieldSelectorFlag(cmd, shared.StackSelectableFields)
+			if err != nil {
+				return err
+			}
+
 			realm := shared.ExplicitFlag(cmd, "realm", config.KUKE_GET_STACK_REALM.ViperKey)
 			space := shared.ExplicitFlag(cmd, "space", config.KUKE_GET_STACK_SPACE.ViperKey)
 
+			sortBy, err := shared.ParseSortByFlag(cmd)
+			if err != nil {
+				return err
+			}
+
+			columns, err := shared.ParseLabelColumnsFlags(cmd)
+			if err != nil {
+				return err
+			}
+
 			var name string
 			if len(args) > 0 {
 				name = strings.TrimSpace(args[0])
@@ -67,6 +82,9 @@ func NewStackCmd() *cobra.Command {
 			if name != "" && !selector.Empty() {
 				return errdefs.ErrSelectorWithName
 			}
+			if name != "" && !fieldSelector.Empty() {
+				return errdefs.ErrFieldSelectorWithName
+			}
 
 			client, err := resolveClient(cmd)
 			if err != nil {
@@ -97,14 +115,14 @@ func NewStackCmd() *cobra.Command {
 				result, err := client.GetStack(cmd.Context(), doc)
 				if err != nil {
 					if errors.Is(err, errdefs.ErrStackNotFound) {
-						return fmt.Errorf("stack %q not found in realm %q, space %q", name, realm, space)
+						return fmt.Errorf("stack %q not found in realm %q, space %q: %w", name, realm, space, errdefs.ErrStackNotFound)
 					}
 					return err
 				}
 				if !result.MetadataExists {
-					return fmt.Errorf("stack %q not found in realm %q, space %q", name, realm, space)
+					return fmt.Errorf("stack %q not found in realm %q, space %q: %w", name, realm, space, errdefs.ErrStackNotFound)
 				}
-				return printStack(cmd, &result.Stack, outputFormat)
+				return printStack(cmd, &result.Stack, outputFormat, columns)
 			}
 
 			stacks, err := client.ListStacks(cmd.Context(), realm, space)
@@ -112,7 +130,13 @@ func NewStackCmd() *cobra.Command {
 				return err
 			}
 			stacks = filterStacksBySelector(stacks, selector)
-			return printStacks(cmd, stacks, outputFormat)
+			if stacks, err = shared.FilterByFieldSelector(stacks, fieldSelector); err != nil {
+				return err
+			}
+			if err = shared.SortItems(stacks, sortBy, nil); err != nil {
+				return err
+			}
+			return printStacks(cmd, stacks, outputFormat, columns)
 		},
 	}
 
@@ -121,11 +145,17 @@ func NewStackCmd() *cobra.Command {
 	cmd.Flags().String("space", "", "Filter stacks by space name")
 	_ = viper.BindPFlag(config.KUKE_GET_STACK_SPACE.ViperKey, cmd.Flags().Lookup("space"))
 	cmd.Flags().
-		StringP("output", "o", "", "Output format (yaml, json, table, wide). Default: table for list, table for single resource")
+		StringP("output", "o", "", "Output format (yaml, json, table, wide, custom-columns=<spec>). "+
+			"Default: table for list, table for single resource")
 	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("output"))
 	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("o"))
+	shared.RegisterSortByFlag(cmd)
+	shared.RegisterQuietFlag(cmd)
+	shared.RegisterNoHeadersFlag(cmd)
 
 	shared.RegisterLabelSelectorFlag(cmd)
+	shared.RegisterFieldSelectorFlag(cmd)
+	shared.RegisterLabelColumnFlags(cmd)
 
 	cmd.ValidArgsFunction = config.CompleteStackNames
 	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
@@ -159,7 +189,12 @@ func filterStacksBySelector(stacks []v1beta1.StackDoc, selector *shared.LabelSel
 	return out
 }
 
-func printStack(cmd *cobra.Command, stack *v1beta1.StackDoc, format shared.OutputFormat) error {
+func printStack(
+	cmd *cobra.Command,
+	stack *v1beta1.StackDoc,
+	format shared.OutputFormat,
+	columns shared.LabelColumns,
+) error {
 	switch format {
 	case shared.OutputFormatJSON:
 		return shared.PrintJSON(cmd, stack)
@@ -168,7 +203,7 @@ func printStack(cmd *cobra.Command, stack *v1beta1.StackDoc, format shared.Outpu
 	default:
 		// table / wide: render the single found element as a one-row table
 		// with the same columns as the list view (kubectl parity).
-		return printStacks(cmd, []v1beta1.StackDoc{*stack}, format)
+		return printStacks(cmd, []v1beta1.StackDoc{*stack}, format, columns)
 	}
 }
 
@@ -176,32 +211,37 @@ func printStacks(
 	cmd *cobra.Command,
 	stacks []v1beta1.StackDoc,
 	format shared.OutputFormat,
+	columns shared.LabelColumns,
 ) error {
 	switch format {
 	case shared.OutputFormatYAML:
 		return shared.PrintYAML(cmd, stacks)
 	case shared.OutputFormatJSON:
 		return shared.PrintJSON(cmd, stacks)
+	case shared.OutputFormatCustomColumns:
+		return shared.PrintCustomColumns(cmd, stacks, nil)
 	case shared.OutputFormatTable, shared.OutputFormatWide:
 		if len(stacks) == 0 {
-			cmd.Println("No stacks found.")
+			shared.PrintEmpty(cmd, "No stacks found.")
 			return nil
 		}
 		// Stack has no per-entity wide columns — `-o wide` renders the
 		// same shape as default (#603). Investigation drops to -o yaml.
 		headers := []string{"NAME", "REALM", "SPACE", "STATE", "AGE"}
+		headers = columns.AppendHeaders(headers)
 		now := time.Now()
 		rows := make([][]string, 0, len(stacks))
 		for i := range stacks {
 			s := &stacks[i]
 			state := (&s.Status.State).String()
-			rows = append(rows, []string{
+			row := []string{
 				s.Metadata.Name,
 				s.Spec.RealmID,
 				s.Spec.SpaceID,
 				state,
 				shared.RenderAge(s.Status.CreatedAt, now),
-			})
+			}
+			rows = append(rows, columns.AppendRow(row, s.Metadata.Labels))
 		}
 		shared.PrintTable(cmd, headers, rows)
 		return nil
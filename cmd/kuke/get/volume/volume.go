@@ -65,6 +65,11 @@ func NewVolumeCmd() *cobra.Command {
 			space := shared.ExplicitFlag(cmd, "space", config.KUKE_GET_VOLUME_SPACE.ViperKey)
 			stack := shared.ExplicitFlag(cmd, "stack", config.KUKE_GET_VOLUME_STACK.ViperKey)
 
+			sortBy, err := shared.ParseSortByFlag(cmd)
+			if err != nil {
+				return err
+			}
+
 			var name string
 			if len(args) > 0 {
 				name = strings.TrimSpace(args[0])
@@ -97,12 +102,12 @@ func NewVolumeCmd() *cobra.Command {
 				result, getErr := client.GetVolume(cmd.Context(), lookup)
 				if getErr != nil {
 					if errors.Is(getErr, errdefs.ErrVolumeNotFound) {
-						return fmt.Errorf("volume %q not found", name)
+						return fmt.Errorf("volume %q not found: %w", name, errdefs.ErrVolumeNotFound)
 					}
 					return getErr
 				}
 				if !result.MetadataExists {
-					return fmt.Errorf("volume %q not found", name)
+					return fmt.Errorf("volume %q not found: %w", name, errdefs.ErrVolumeNotFound)
 				}
 				return printVolume(cmd, &result.Volume, outputFormat)
 			}
@@ -111,6 +116,9 @@ func NewVolumeCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if err = shared.SortItems(volumes, sortBy, nil); err != nil {
+				return err
+			}
 			return printVolumes(cmd, volumes, outputFormat)
 		},
 	}
@@ -122,9 +130,13 @@ func NewVolumeCmd() *cobra.Command {
 	cmd.Flags().String("stack", "", "Filter volumes by stack name")
 	_ = viper.BindPFlag(config.KUKE_GET_VOLUME_STACK.ViperKey, cmd.Flags().Lookup("stack"))
 	cmd.Flags().
-		StringP("output", "o", "", "Output format (yaml, json, table, wide). Default: table for list, table for single resource")
+		StringP("output", "o", "", "Output format (yaml, json, table, wide, custom-columns=<spec>). "+
+			"Default: table for list, table for single resource")
 	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("output"))
 	_ = viper.BindPFlag(config.KUKE_GET_OUTPUT.ViperKey, cmd.Flags().Lookup("o"))
+	shared.RegisterSortByFlag(cmd)
+	shared.RegisterQuietFlag(cmd)
+	shared.RegisterNoHeadersFlag(cmd)
 
 	cmd.ValidArgsFunction = config.CompleteVolumeNames
 	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
@@ -162,9 +174,11 @@ func printVolumes(cmd *cobra.Command, volumes []v1beta1.VolumeDoc, format shared
 		return shared.PrintYAML(cmd, volumes)
 	case shared.OutputFormatJSON:
 		return shared.PrintJSON(cmd, volumes)
+	case shared.OutputFormatCustomColumns:
+		return shared.PrintCustomColumns(cmd, volumes, nil)
 	case shared.OutputFormatTable, shared.OutputFormatWide:
 		if len(volumes) == 0 {
-			cmd.Println("No volumes found.")
+			shared.PrintEmpty(cmd, "No volumes found.")
 			return nil
 		}
 		headers := []string{"NAME", "REALM", "SPACE", "STACK"}
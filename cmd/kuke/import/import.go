@@ -0,0 +1,167 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package importcmd hosts the `kuke import` command, the all-or-nothing
+// counterpart of `kuke apply -f` meant for streams produced by `kuke export`.
+// The package is not named after its directory because `import` is a Go
+// keyword.
+package importcmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	kukshared "github.com/eminwux/kukeon/cmd/kuke/shared"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	"github.com/spf13/cobra"
+)
+
+// MockControllerKey is used to inject a mock kukeonv1.Client via context in tests.
+type MockControllerKey struct{}
+
+const (
+	outputFormatJSON = "json"
+	outputFormatYAML = "yaml"
+)
+
+// NewImportCmd builds the `kuke import` command.
+func NewImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import -f <file>",
+		Short: "Import resource definitions, rolling back on the first failure",
+		Long: "Import applies a multi-document YAML stream (typically from `kuke export`) " +
+			"in dependency order. The first resource that fails stops the import and the " +
+			"resources it already created are deleted again; --continue-on-error applies " +
+			"every document instead, like `kuke apply -f`.",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE:          runImport,
+	}
+
+	cmd.Flags().StringP("file", "f", "", "File to read YAML from (use - for stdin)")
+	cmd.Flags().Bool("continue-on-error", false, "Keep applying after a failure and skip the rollback")
+	cmd.Flags().StringP("output", "o", "", "Output format: json, yaml (default: human-readable)")
+
+	return cmd
+}
+
+type importFlags struct {
+	file            string
+	continueOnError bool
+	output          string
+}
+
+func parseImportFlags(cmd *cobra.Command) (importFlags, error) {
+	flags := importFlags{}
+	var err error
+	if flags.file, err = cmd.Flags().GetString("file"); err != nil {
+		return flags, err
+	}
+	if flags.continueOnError, err = cmd.Flags().GetBool("continue-on-error"); err != nil {
+		return flags, err
+	}
+	if flags.output, err = cmd.Flags().GetString("output"); err != nil {
+		return flags, err
+	}
+
+	if flags.file == "" {
+		return flags, errors.New("file flag is required (use -f <file> or -f - for stdin)")
+	}
+	if flags.output != "" && flags.output != outputFormatJSON && flags.output != outputFormatYAML {
+		return flags, fmt.Errorf("invalid --output %q: want json or yaml", flags.output)
+	}
+	return flags, nil
+}
+
+func runImport(cmd *cobra.Command, _ []string) error {
+	flags, err := parseImportFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	reader, cleanup, err := kukshared.ReadFileOrStdin(flags.file)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cleanup() }()
+
+	rawYAML, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("failed to read input: %w", err)
+	}
+
+	client, err := resolveClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	result, importErr := client.ImportDocuments(cmd.Context(), rawYAML, flags.continueOnError)
+
+	if flags.output == outputFormatJSON || flags.output == outputFormatYAML {
+		if err = kukshared.PrintJSONOrYAML(cmd, result, flags.output); err != nil {
+			return err
+		}
+		return importErr
+	}
+	failed := printImportResult(cmd, result)
+	if importErr != nil {
+		return importErr
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d resource(s) failed to import", failed)
+	}
+	return nil
+}
+
+// printImportResult renders the per-resource summary and returns the number
+// of resources that failed to apply.
+func printImportResult(cmd *cobra.Command, result kukeonv1.ImportDocumentsResult) int {
+	failed := 0
+	for _, resource := range result.Resources {
+		cmd.Printf("%s %q: %s\n", resource.Kind, resource.Name, resource.Action)
+		for _, change := range resource.Changes {
+			cmd.Printf("  - %s\n", change)
+		}
+		if resource.Error != "" {
+			failed++
+			cmd.Printf("  Error: %s\n", resource.Error)
+		}
+	}
+	for _, resource := range result.RolledBack {
+		switch resource.Action {
+		case "deleted":
+			cmd.Printf("%s %q: rolled back\n", resource.Kind, resource.Name)
+		case "not found":
+			cmd.Printf("%s %q: rolled back (already gone)\n", resource.Kind, resource.Name)
+		default:
+			cmd.Printf("%s %q: rollback failed\n", resource.Kind, resource.Name)
+			if resource.Error != "" {
+				cmd.Printf("  Error: %s\n", resource.Error)
+			}
+		}
+	}
+	return failed
+}
+
+func resolveClient(cmd *cobra.Command) (kukeonv1.Client, error) {
+	if mockClient, ok := cmd.Context().Value(MockControllerKey{}).(kukeonv1.Client); ok {
+		return mockClient, nil
+	}
+	return kukshared.DaemonClientFromCmd(cmd)
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package importcmd_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	importcmd "github.com/eminwux/kukeon/cmd/kuke/import"
+	"github.com/eminwux/kukeon/cmd/types"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+)
+
+const validYAML = `apiVersion: v1beta1
+kind: Realm
+metadata:
+  name: r1
+`
+
+func writeTempYAML(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "dump.yaml")
+	if err := os.WriteFile(path, []byte(validYAML), 0o600); err != nil {
+		t.Fatalf("write temp yaml: %v", err)
+	}
+	return path
+}
+
+func TestImportRunE(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		fake       *fakeClient
+		wantErr    string
+		wantOutput []string
+	}{
+		{
+			name:    "no file flag",
+			fake:    &fakeClient{},
+			wantErr: "file flag is required",
+		},
+		{
+			name: "success",
+			args: []string{"-f", writeTempYAML(t)},
+			fake: &fakeClient{
+				importFn: func(raw []byte, continueOnError bool) (kukeonv1.ImportDocumentsResult, error) {
+					if string(raw) != validYAML || continueOnError {
+						return kukeonv1.ImportDocumentsResult{}, errors.New("unexpected import arguments")
+					}
+					return kukeonv1.ImportDocumentsResult{
+						Resources: []kukeonv1.ApplyResourceResult{{Kind: "Realm", Name: "r1", Action: "created"}},
+					}, nil
+				},
+			},
+			wantOutput: []string{`Realm "r1": created`},
+		},
+		{
+			name: "failure rolls back",
+			args: []string{"-f", writeTempYAML(t)},
+			fake: &fakeClient{
+				importFn: func([]byte, bool) (kukeonv1.ImportDocumentsResult, error) {
+					return kukeonv1.ImportDocumentsResult{
+						Resources: []kukeonv1.ApplyResourceResult{
+							{Kind: "Realm", Name: "r1", Action: "created"},
+							{Kind: "Space", Name: "s1", Action: "failed", Error: "boom"},
+						},
+						RolledBack: []kukeonv1.DeleteResourceResult{{Kind: "Realm", Name: "r1", Action: "deleted"}},
+					}, errdefs.ErrImportFailed
+				},
+			},
+			wantErr:    "import failed",
+			wantOutput: []string{`Space "s1": failed`, "Error: boom", `Realm "r1": rolled back`},
+		},
+		{
+			name: "continue on error reports failures",
+			args: []string{"-f", writeTempYAML(t), "--continue-on-error"},
+			fake: &fakeClient{
+				importFn: func(_ []byte, continueOnError bool) (kukeonv1.ImportDocumentsResult, error) {
+					if !continueOnError {
+						return kukeonv1.ImportDocumentsResult{}, errors.New("continueOnError not forwarded")
+					}
+					return kukeonv1.ImportDocumentsResult{
+						Resources: []kukeonv1.ApplyResourceResult{
+							{Kind: "Space", Name: "s1", Action: "failed", Error: "boom"},
+							{Kind: "Stack", Name: "st1", Action: "created"},
+						},
+					}, nil
+				},
+			},
+			wantErr:    "1 resource(s) failed to import",
+			wantOutput: []string{`Stack "st1": created`},
+		},
+		{
+			name:    "invalid output",
+			args:    []string{"-f", writeTempYAML(t), "-o", "table"},
+			fake:    &fakeClient{},
+			wantErr: "invalid --output",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := importcmd.NewImportCmd()
+			buf := &bytes.Buffer{}
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+			ctx = context.WithValue(ctx, importcmd.MockControllerKey{}, kukeonv1.Client(tt.fake))
+			cmd.SetContext(ctx)
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("want err %q, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q\nGot:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+type fakeClient struct {
+	kukeonv1.FakeClient
+
+	importFn func(rawYAML []byte, continueOnError bool) (kukeonv1.ImportDocumentsResult, error)
+}
+
+func (f *fakeClient) ImportDocuments(
+	_ context.Context, rawYAML []byte, continueOnError bool,
+) (kukeonv1.ImportDocumentsResult, error) {
+	if f.importFn == nil {
+		return kukeonv1.ImportDocumentsResult{}, errors.New("unexpected ImportDocuments call")
+	}
+	return f.importFn(rawYAML, continueOnError)
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package inspect implements `kuke inspect`, which prints what containerd
+// holds for one container: the OCI runtime spec kukeon generated for it and
+// the containerd container record, as indented JSON. It is the ground truth
+// for the mounts, namespaces, resources, and process settings every
+// spec-building feature contributes.
+package inspect
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/eminwux/kukeon/cmd/config"
+	kukeshared "github.com/eminwux/kukeon/cmd/kuke/shared"
+	"github.com/eminwux/kukeon/internal/consts"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// MockControllerKey injects a kukeonv1.Client via context for tests.
+type MockControllerKey struct{}
+
+// NewInspectCmd builds the `kuke inspect` cobra command.
+func NewInspectCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inspect <cell> <container>",
+		Short: "Print a container's OCI runtime spec and containerd record as JSON",
+		Long: "Print what containerd holds for a container: the OCI runtime spec kukeon " +
+			"generated for it (--oci) and the containerd container record (--containerd). " +
+			"With neither flag both are printed as one JSON object with \"oci\" and " +
+			"\"containerd\" keys; with exactly one flag that document is printed alone. " +
+			"The container needs to exist in containerd but does not need a running task.",
+		Args:          cobra.ExactArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE:          runInspect,
+	}
+
+	cmd.Flags().String("realm", consts.KukeonDefaultRealmName, "Realm that owns the cell")
+	_ = viper.BindPFlag(config.KUKE_INSPECT_REALM.ViperKey, cmd.Flags().Lookup("realm"))
+	cmd.Flags().String("space", consts.KukeonDefaultSpaceName, "Space that owns the cell")
+	_ = viper.BindPFlag(config.KUKE_INSPECT_SPACE.ViperKey, cmd.Flags().Lookup("space"))
+	cmd.Flags().String("stack", consts.KukeonDefaultStackName, "Stack that owns the cell")
+	_ = viper.BindPFlag(config.KUKE_INSPECT_STACK.ViperKey, cmd.Flags().Lookup("stack"))
+	cmd.Flags().Bool("oci", false, "Print the OCI runtime spec")
+	_ = viper.BindPFlag(config.KUKE_INSPECT_OCI.ViperKey, cmd.Flags().Lookup("oci"))
+	cmd.Flags().Bool("containerd", false, "Print the containerd container record")
+	_ = viper.BindPFlag(config.KUKE_INSPECT_CONTAINERD.ViperKey, cmd.Flags().Lookup("containerd"))
+
+	cmd.ValidArgsFunction = completeArgs
+	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
+	_ = cmd.RegisterFlagCompletionFunc("space", config.CompleteSpaceNames)
+	_ = cmd.RegisterFlagCompletionFunc("stack", config.CompleteStackNames)
+
+	return cmd
+}
+
+// completeArgs completes the cell name. The container completer keys on a
+// --cell flag, so the positional container is left uncompleted.
+func completeArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return config.CompleteCellNames(cmd, args, toComplete)
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+func runInspect(cmd *cobra.Command, args []string) error {
+	cell := strings.TrimSpace(args[0])
+	container := strings.TrimSpace(args[1])
+	if cell == "" {
+		return fmt.Errorf("%w (positional cell)", errdefs.ErrCellNameRequired)
+	}
+	if container == "" {
+		return fmt.Errorf("%w (positional container)", errdefs.ErrContainerNameRequired)
+	}
+	realm := strings.TrimSpace(viper.GetString(config.KUKE_INSPECT_REALM.ViperKey))
+	space := strings.TrimSpace(viper.GetString(config.KUKE_INSPECT_SPACE.ViperKey))
+	stack := strings.TrimSpace(viper.GetString(config.KUKE_INSPECT_STACK.ViperKey))
+	wantOCI := viper.GetBool(config.KUKE_INSPECT_OCI.ViperKey)
+	wantContainerd := viper.GetBool(config.KUKE_INSPECT_CONTAINERD.ViperKey)
+
+	client, err := resolveClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	res, err := client.InspectContainer(cmd.Context(), buildContainerDoc(container, realm, space, stack, cell))
+	if err != nil {
+		if errors.Is(err, errdefs.ErrContainerNotFound) {
+			return fmt.Errorf("container %q not found in cell %q: %w", container, cell, err)
+		}
+		return err
+	}
+
+	var doc json.RawMessage
+	switch {
+	case wantOCI && !wantContainerd:
+		doc = res.OCISpec
+	case wantContainerd && !wantOCI:
+		doc = res.Containerd
+	default:
+		doc, err = json.Marshal(struct {
+			OCI        json.RawMessage `json:"oci"`
+			Containerd json.RawMessage `json:"containerd"`
+		}{OCI: res.OCISpec, Containerd: res.Containerd})
+		if err != nil {
+			return err
+		}
+	}
+
+	var out bytes.Buffer
+	if err = json.Indent(&out, doc, "", "  "); err != nil {
+		return fmt.Errorf("failed to format inspect output: %w", err)
+	}
+	out.WriteByte('\n')
+	_, err = cmd.OutOrStdout().Write(out.Bytes())
+	return err
+}
+
+func resolveClient(cmd *cobra.Command) (kukeonv1.Client, error) {
+	if mockClient, ok := cmd.Context().Value(MockControllerKey{}).(kukeonv1.Client); ok {
+		return mockClient, nil
+	}
+	return kukeshared.ClientFromCmd(cmd)
+}
+
+func buildContainerDoc(name, realm, space, stack, cell string) v1beta1.ContainerDoc {
+	return v1beta1.ContainerDoc{
+		APIVersion: v1beta1.APIVersionV1Beta1,
+		Kind:       v1beta1.KindContainer,
+		Metadata: v1beta1.ContainerMetadata{
+			Name:   name,
+			Labels: make(map[string]string),
+		},
+		Spec: v1beta1.ContainerSpec{
+			ID:      name,
+			RealmID: realm,
+			SpaceID: space,
+			StackID: stack,
+			CellID:  cell,
+		},
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inspect_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	inspectcmd "github.com/eminwux/kukeon/cmd/kuke/inspect"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+	"github.com/spf13/viper"
+)
+
+const (
+	testOCISpec    = `{"ociVersion":"1.2.0","process":{"args":["sleep","infinity"]}}`
+	testContainerd = `{"id":"r1_s1_st1_web_app","runtime":"io.containerd.runc.v2"}`
+)
+
+type fakeClient struct {
+	kukeonv1.FakeClient
+
+	inspectFn func(doc v1beta1.ContainerDoc) (kukeonv1.InspectContainerResult, error)
+}
+
+func (f *fakeClient) InspectContainer(
+	_ context.Context,
+	doc v1beta1.ContainerDoc,
+) (kukeonv1.InspectContainerResult, error) {
+	return f.inspectFn(doc)
+}
+
+func okClient(gotDoc *v1beta1.ContainerDoc) *fakeClient {
+	return &fakeClient{inspectFn: func(doc v1beta1.ContainerDoc) (kukeonv1.InspectContainerResult, error) {
+		if gotDoc != nil {
+			*gotDoc = doc
+		}
+		return kukeonv1.InspectContainerResult{
+			OCISpec:    json.RawMessage(testOCISpec),
+			Containerd: json.RawMessage(testContainerd),
+		}, nil
+	}}
+}
+
+func runInspect(t *testing.T, fc *fakeClient, args []string) (string, error) {
+	t.Helper()
+	t.Cleanup(viper.Reset)
+	cmd := inspectcmd.NewInspectCmd()
+	cmd.SetContext(context.WithValue(context.Background(), inspectcmd.MockControllerKey{}, kukeonv1.Client(fc)))
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestInspect_DefaultPrintsBothDocuments(t *testing.T) {
+	var gotDoc v1beta1.ContainerDoc
+	out, err := runInspect(t, okClient(&gotDoc), []string{"web", "app", "--realm", "r1", "--space", "s1", "--stack", "st1"})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if gotDoc.Spec.CellID != "web" || gotDoc.Metadata.Name != "app" || gotDoc.Spec.RealmID != "r1" ||
+		gotDoc.Spec.SpaceID != "s1" || gotDoc.Spec.StackID != "st1" {
+		t.Errorf("unexpected container doc: %+v", gotDoc)
+	}
+
+	var got struct {
+		OCI        map[string]any `json:"oci"`
+		Containerd map[string]any `json:"containerd"`
+	}
+	if err = json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if got.OCI["ociVersion"] != "1.2.0" {
+		t.Errorf("oci.ociVersion = %v, want 1.2.0", got.OCI["ociVersion"])
+	}
+	if got.Containerd["runtime"] != "io.containerd.runc.v2" {
+		t.Errorf("containerd.runtime = %v, want io.containerd.runc.v2", got.Containerd["runtime"])
+	}
+}
+
+func TestInspect_SingleDocumentFlags(t *testing.T) {
+	out, err := runInspect(t, okClient(nil), []string{"web", "app", "--oci"})
+	if err != nil {
+		t.Fatalf("--oci: %v", err)
+	}
+	var spec map[string]any
+	if err = json.Unmarshal([]byte(out), &spec); err != nil || spec["ociVersion"] != "1.2.0" {
+		t.Errorf("--oci output = %s (err %v), want the bare OCI spec", out, err)
+	}
+
+	out, err = runInspect(t, okClient(nil), []string{"web", "app", "--containerd"})
+	if err != nil {
+		t.Fatalf("--containerd: %v", err)
+	}
+	var record map[string]any
+	if err = json.Unmarshal([]byte(out), &record); err != nil || record["id"] != "r1_s1_st1_web_app" {
+		t.Errorf("--containerd output = %s (err %v), want the bare containerd record", out, err)
+	}
+}
+
+func TestInspect_ContainerNotFound(t *testing.T) {
+	fc := &fakeClient{inspectFn: func(v1beta1.ContainerDoc) (kukeonv1.InspectContainerResult, error) {
+		return kukeonv1.InspectContainerResult{}, errdefs.ErrContainerNotFound
+	}}
+	_, err := runInspect(t, fc, []string{"web", "app"})
+	if !errors.Is(err, errdefs.ErrContainerNotFound) {
+		t.Fatalf("err = %v, want ErrContainerNotFound", err)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/eminwux/kukeon/cmd/config"
+	getshared "github.com/eminwux/kukeon/cmd/kuke/get/shared"
 	kukeshared "github.com/eminwux/kukeon/cmd/kuke/shared"
 	"github.com/eminwux/kukeon/internal/errdefs"
 	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
@@ -32,66 +33,20 @@ import (
 // MockControllerKey is used to inject a mock kukeonv1.Client via context in tests.
 type MockControllerKey struct{}
 
-// NewKillCmd builds the `kuke kill <name>` leaf command. Cell is the only
-// resource this verb targets, so the noun is implied by the verb.
+// NewKillCmd builds the `kuke kill <name>...` leaf command. Cell is the only
+// resource this verb targets, so the noun is implied by the verb. One name
+// kills one cell; several names, or `-l <selector>` (mutually exclusive with
+// names), kill each cell individually, at most --parallel at a time, and
+// print a per-cell summary table.
 func NewKillCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:           "kill <name>",
+		Use:           "kill <name>...",
 		Aliases:       []string{"k"},
-		Short:         "Immediately force-kill a cell",
-		Args:          cobra.ExactArgs(1),
+		Short:         "Immediately force-kill one or more cells (or a fleet via -l <selector>)",
+		Args:          cobra.ArbitraryArgs,
 		SilenceUsage:  true,
 		SilenceErrors: false,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			name := strings.TrimSpace(args[0])
-			realm := strings.TrimSpace(viper.GetString(config.KUKE_KILL_CELL_REALM.ViperKey))
-			space := strings.TrimSpace(viper.GetString(config.KUKE_KILL_CELL_SPACE.ViperKey))
-			stack := strings.TrimSpace(viper.GetString(config.KUKE_KILL_CELL_STACK.ViperKey))
-
-			if realm == "" {
-				return fmt.Errorf("%w (--realm)", errdefs.ErrRealmNameRequired)
-			}
-			if space == "" {
-				return fmt.Errorf("%w (--space)", errdefs.ErrSpaceNameRequired)
-			}
-			if stack == "" {
-				return fmt.Errorf("%w (--stack)", errdefs.ErrStackNameRequired)
-			}
-
-			doc := v1beta1.CellDoc{
-				APIVersion: v1beta1.APIVersionV1Beta1,
-				Kind:       v1beta1.KindCell,
-				Metadata:   v1beta1.CellMetadata{Name: name, Labels: map[string]string{}},
-				Spec: v1beta1.CellSpec{
-					ID:      name,
-					RealmID: realm,
-					SpaceID: space,
-					StackID: stack,
-				},
-			}
-
-			client, err := resolveClient(cmd)
-			if err != nil {
-				return err
-			}
-			defer func() { _ = client.Close() }()
-
-			result, err := client.KillCell(cmd.Context(), doc)
-			if err != nil {
-				return err
-			}
-
-			cellName := result.Cell.Metadata.Name
-			if cellName == "" {
-				cellName = name
-			}
-			stackName := result.Cell.Spec.StackID
-			if stackName == "" {
-				stackName = stack
-			}
-			cmd.Printf("Killed cell %q from stack %q\n", cellName, stackName)
-			return nil
-		},
+		RunE:          runKill,
 	}
 
 	cmd.Flags().String("realm", "", "Realm that owns the cell")
@@ -101,6 +56,8 @@ func NewKillCmd() *cobra.Command {
 	cmd.Flags().String("stack", "", "Stack that owns the cell")
 	_ = viper.BindPFlag(config.KUKE_KILL_CELL_STACK.ViperKey, cmd.Flags().Lookup("stack"))
 
+	kukeshared.RegisterCellBatchFlags(cmd)
+
 	cmd.ValidArgsFunction = config.CompleteCellNames
 	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
 	_ = cmd.RegisterFlagCompletionFunc("space", config.CompleteSpaceNames)
@@ -109,6 +66,108 @@ func NewKillCmd() *cobra.Command {
 	return cmd
 }
 
+func runKill(cmd *cobra.Command, args []string) error {
+	selector, err := getshared.ParseLabelSelectorFlag(cmd)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 && !selector.Empty() {
+		return errdefs.ErrSelectorWithName
+	}
+	if len(args) == 0 && selector.Empty() {
+		return errdefs.ErrCellNameRequired
+	}
+	parallel, err := kukeshared.ParseCellBatchParallel(cmd)
+	if err != nil {
+		return err
+	}
+
+	if !selector.Empty() {
+		return killBySelector(cmd, selector, parallel)
+	}
+
+	realm := strings.TrimSpace(viper.GetString(config.KUKE_KILL_CELL_REALM.ViperKey))
+	space := strings.TrimSpace(viper.GetString(config.KUKE_KILL_CELL_SPACE.ViperKey))
+	stack := strings.TrimSpace(viper.GetString(config.KUKE_KILL_CELL_STACK.ViperKey))
+
+	if realm == "" {
+		return fmt.Errorf("%w (--realm)", errdefs.ErrRealmNameRequired)
+	}
+	if space == "" {
+		return fmt.Errorf("%w (--space)", errdefs.ErrSpaceNameRequired)
+	}
+	if stack == "" {
+		return fmt.Errorf("%w (--stack)", errdefs.ErrStackNameRequired)
+	}
+
+	refs := make([]kukeshared.CellRef, 0, len(args))
+	for _, arg := range args {
+		refs = append(refs, kukeshared.CellRef{
+			Name:  strings.TrimSpace(arg),
+			Realm: realm,
+			Space: space,
+			Stack: stack,
+		})
+	}
+
+	client, err := resolveClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	if len(refs) > 1 {
+		return kukeshared.PrintCellBatch(cmd, "Killed",
+			kukeshared.KillCells(cmd.Context(), client, refs, parallel))
+	}
+	return killOne(cmd, client, refs[0].Doc())
+}
+
+// killBySelector kills every cell whose labels match selector. Realm/space/stack
+// act as list filters here (unset = no filter), mirroring `kuke get cell`;
+// each kill uses the matched cell's own scope.
+func killBySelector(cmd *cobra.Command, selector *getshared.LabelSelector, parallel int) error {
+	client, err := resolveClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	refs, err := kukeshared.SelectCells(cmd.Context(), client, selector,
+		getshared.ExplicitFlag(cmd, "realm", config.KUKE_KILL_CELL_REALM.ViperKey),
+		getshared.ExplicitFlag(cmd, "space", config.KUKE_KILL_CELL_SPACE.ViperKey),
+		getshared.ExplicitFlag(cmd, "stack", config.KUKE_KILL_CELL_STACK.ViperKey),
+	)
+	if err != nil {
+		return err
+	}
+	if len(refs) == 0 {
+		cmd.Println("No cells matched the selector.")
+		return nil
+	}
+	return kukeshared.PrintCellBatch(cmd, "Killed",
+		kukeshared.KillCells(cmd.Context(), client, refs, parallel))
+}
+
+// killOne kills a single named cell and prints the per-cell confirmation line.
+func killOne(cmd *cobra.Command, client kukeonv1.Client, doc v1beta1.CellDoc) error {
+	result, err := client.KillCell(cmd.Context(), doc)
+	if err != nil {
+		return err
+	}
+
+	cellName := result.Cell.Metadata.Name
+	if cellName == "" {
+		cellName = doc.Metadata.Name
+	}
+	stackName := result.Cell.Spec.StackID
+	if stackName == "" {
+		stackName = doc.Spec.StackID
+	}
+	cmd.Printf("Killed cell %q from stack %q\n", cellName, stackName)
+	return nil
+}
+
 func resolveClient(cmd *cobra.Command) (kukeonv1.Client, error) {
 	if mockClient, ok := cmd.Context().Value(MockControllerKey{}).(kukeonv1.Client); ok {
 		return mockClient, nil
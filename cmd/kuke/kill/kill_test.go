@@ -22,7 +22,9 @@ import (
 	"errors"
 	"io"
 	"log/slog"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/eminwux/kukeon/cmd/config"
@@ -37,10 +39,10 @@ import (
 func TestNewKillCmdMetadata(t *testing.T) {
 	cmd := killpkg.NewKillCmd()
 
-	if cmd.Use != "kill <name>" {
-		t.Errorf("Use mismatch: got %q want %q", cmd.Use, "kill <name>")
+	if cmd.Use != "kill <name>..." {
+		t.Errorf("Use mismatch: got %q want %q", cmd.Use, "kill <name>...")
 	}
-	if cmd.Short != "Immediately force-kill a cell" {
+	if cmd.Short != "Immediately force-kill one or more cells (or a fleet via -l <selector>)" {
 		t.Errorf("Short mismatch: got %q", cmd.Short)
 	}
 	if !cmd.HasAlias("k") {
@@ -114,7 +116,7 @@ func TestKillCmd(t *testing.T) {
 		{
 			name:    "missing positional",
 			args:    []string{},
-			wantErr: "accepts 1 arg",
+			wantErr: "cell name is required",
 		},
 	}
 	for _, tt := range tests {
@@ -154,32 +156,157 @@ func TestKillCmd(t *testing.T) {
 	}
 }
 
-// TestKillCmd_RejectsCellSubcommand pins the hard CLI break: the `kill cell <name>` subcommand form
-// must fail with cobra's Args-validation error (`accepts 1 arg(s), received 2`)
-// after the collapse — the verb is now a leaf with `cobra.ExactArgs(1)` and no
-// subcommand list, so cobra's unknown-command path no longer fires.
-func TestKillCmd_RejectsCellSubcommand(t *testing.T) {
+// TestKillCmd_OldCellSubcommandFails pins that the retired `kill cell <name>`
+// form still fails: with several names accepted, "cell" is read as a cell
+// name, which does not exist, so the batch exits non-zero.
+func TestKillCmd_OldCellSubcommandFails(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Reset()
+	viper.Set(config.KUKE_KILL_CELL_REALM.ViperKey, "r1")
+	viper.Set(config.KUKE_KILL_CELL_SPACE.ViperKey, "s1")
+	viper.Set(config.KUKE_KILL_CELL_STACK.ViperKey, "st1")
+
+	fake := &fakeClient{
+		killCellFn: func(doc v1beta1.CellDoc) (kukeonv1.KillCellResult, error) {
+			if doc.Metadata.Name == "cell" {
+				return kukeonv1.KillCellResult{}, errdefs.ErrCellNotFound
+			}
+			return kukeonv1.KillCellResult{Cell: doc, Killed: true}, nil
+		},
+	}
+	_, err := runKill(t, fake, "cell", "c1")
+	if !errors.Is(err, errdefs.ErrCellNotFound) {
+		t.Fatalf("expected ErrCellNotFound for the `cell` name, got %v", err)
+	}
+}
+
+// TestKillCmd_MultipleNames pins the multi-name batch: every named cell is
+// killed, a failed cell is reported in the summary table, and the command
+// still exits non-zero.
+func TestKillCmd_MultipleNames(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Reset()
+	viper.Set(config.KUKE_KILL_CELL_REALM.ViperKey, "r1")
+	viper.Set(config.KUKE_KILL_CELL_SPACE.ViperKey, "s1")
+	viper.Set(config.KUKE_KILL_CELL_STACK.ViperKey, "st1")
+
+	fake := &fakeClient{
+		killCellFn: func(doc v1beta1.CellDoc) (kukeonv1.KillCellResult, error) {
+			if doc.Metadata.Name == "c2" {
+				return kukeonv1.KillCellResult{}, errdefs.ErrCellNotFound
+			}
+			return kukeonv1.KillCellResult{Cell: doc, Killed: true}, nil
+		},
+	}
+	out, err := runKill(t, fake, "c1", "c2", "c3", "--parallel", "2")
+	if !errors.Is(err, errdefs.ErrCellNotFound) {
+		t.Fatalf("expected the c2 failure to surface, got %v", err)
+	}
+	if got := fake.calls(); !slices.Equal(got, []string{"c1", "c2", "c3"}) {
+		t.Errorf("killed %v, want every named cell", got)
+	}
+	for _, want := range []string{"c1", "Killed", "Failed", "cell not found", "Killed 2/3 cells"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("summary missing %q\nGot:\n%s", want, out)
+		}
+	}
+}
+
+// TestKillCmd_Selector pins the selector batch: only matching cells are
+// killed, each in its own scope.
+func TestKillCmd_Selector(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Reset()
+
+	fake := &fakeClient{
+		listCellsFn: func() ([]v1beta1.CellDoc, error) {
+			return []v1beta1.CellDoc{
+				cellWithLabels("c1", "r1", "s1", "st1", map[string]string{"app": "web"}),
+				cellWithLabels("c2", "r1", "s1", "st1", map[string]string{"app": "db"}),
+				cellWithLabels("c3", "r2", "s2", "st2", map[string]string{"app": "web"}),
+			}, nil
+		},
+		killCellFn: func(doc v1beta1.CellDoc) (kukeonv1.KillCellResult, error) {
+			if doc.Metadata.Name == "c3" && doc.Spec.RealmID != "r2" {
+				return kukeonv1.KillCellResult{}, errdefs.ErrCellNotFound
+			}
+			return kukeonv1.KillCellResult{Cell: doc, Killed: true}, nil
+		},
+	}
+	out, err := runKill(t, fake, "-l", "app=web")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := fake.calls(); !slices.Equal(got, []string{"c1", "c3"}) {
+		t.Errorf("killed %v, want [c1 c3]", got)
+	}
+	if !strings.Contains(out, "Killed 2/2 cells") {
+		t.Errorf("summary missing count line\nGot:\n%s", out)
+	}
+}
+
+func runKill(t *testing.T, fake *fakeClient, args ...string) (string, error) {
+	t.Helper()
 	cmd := killpkg.NewKillCmd()
 	buf := &bytes.Buffer{}
 	cmd.SetOut(buf)
 	cmd.SetErr(buf)
-	cmd.SetArgs([]string{"cell", "c1"})
-
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+	ctx = context.WithValue(ctx, killpkg.MockControllerKey{}, kukeonv1.Client(fake))
+	cmd.SetContext(ctx)
+	cmd.SetArgs(args)
 	err := cmd.Execute()
-	if err == nil {
-		t.Fatal("expected error invoking removed `kill cell` subcommand, got nil")
-	}
+	return buf.String(), err
 }
 
 type fakeClient struct {
 	kukeonv1.FakeClient
 
-	killCellFn func(doc v1beta1.CellDoc) (kukeonv1.KillCellResult, error)
+	killCellFn  func(doc v1beta1.CellDoc) (kukeonv1.KillCellResult, error)
+	listCellsFn func() ([]v1beta1.CellDoc, error)
+
+	mu     sync.Mutex
+	called []string
 }
 
 func (f *fakeClient) KillCell(_ context.Context, doc v1beta1.CellDoc) (kukeonv1.KillCellResult, error) {
 	if f.killCellFn == nil {
 		return kukeonv1.KillCellResult{}, errors.New("unexpected KillCell call")
 	}
+	f.mu.Lock()
+	f.called = append(f.called, doc.Metadata.Name)
+	f.mu.Unlock()
 	return f.killCellFn(doc)
 }
+
+func (f *fakeClient) ListCells(_ context.Context, _, _, _ string) ([]v1beta1.CellDoc, error) {
+	if f.listCellsFn == nil {
+		return nil, errors.New("unexpected ListCells call")
+	}
+	return f.listCellsFn()
+}
+
+// calls returns the cells acted on, sorted: batches run concurrently, so
+// call order is not fixed.
+func (f *fakeClient) calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := slices.Clone(f.called)
+	slices.Sort(out)
+	return out
+}
+
+// cellWithLabels builds a minimal CellDoc carrying the given scope and labels
+// for selector fan-out tests.
+func cellWithLabels(name, realm, space, stack string, labels map[string]string) v1beta1.CellDoc {
+	return v1beta1.CellDoc{
+		Metadata: v1beta1.CellMetadata{Name: name, Labels: labels},
+		Spec: v1beta1.CellSpec{
+			ID:      name,
+			RealmID: realm,
+			SpaceID: space,
+			StackID: stack,
+		},
+	}
+}
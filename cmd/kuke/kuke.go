@@ -28,29 +28,44 @@ import (
 	attachcmd "github.com/eminwux/kukeon/cmd/kuke/attach"
 	autocompletecmd "github.com/eminwux/kukeon/cmd/kuke/autocomplete"
 	buildcmd "github.com/eminwux/kukeon/cmd/kuke/build"
+	configcmd "github.com/eminwux/kukeon/cmd/kuke/config"
+	cordoncmd "github.com/eminwux/kukeon/cmd/kuke/cordon"
+	cpcmd "github.com/eminwux/kukeon/cmd/kuke/cp"
 	createcmd "github.com/eminwux/kukeon/cmd/kuke/create"
 	daemoncmd "github.com/eminwux/kukeon/cmd/kuke/daemon"
 	deletecmd "github.com/eminwux/kukeon/cmd/kuke/delete"
+	diffcmd "github.com/eminwux/kukeon/cmd/kuke/diff"
 	doctorcmd "github.com/eminwux/kukeon/cmd/kuke/doctor"
+	draincmd "github.com/eminwux/kukeon/cmd/kuke/drain"
+	exportcmd "github.com/eminwux/kukeon/cmd/kuke/export"
 	getcmd "github.com/eminwux/kukeon/cmd/kuke/get"
 	imagecmd "github.com/eminwux/kukeon/cmd/kuke/image"
+	importcmd "github.com/eminwux/kukeon/cmd/kuke/import"
 	initcmd "github.com/eminwux/kukeon/cmd/kuke/init"
+	inspectcmd "github.com/eminwux/kukeon/cmd/kuke/inspect"
 	killcmd "github.com/eminwux/kukeon/cmd/kuke/kill"
 	logcmd "github.com/eminwux/kukeon/cmd/kuke/log"
+	patchcmd "github.com/eminwux/kukeon/cmd/kuke/patch"
 	purgecmd "github.com/eminwux/kukeon/cmd/kuke/purge"
 	refreshcmd "github.com/eminwux/kukeon/cmd/kuke/refresh"
+	renamecmd "github.com/eminwux/kukeon/cmd/kuke/rename"
 	restartcmd "github.com/eminwux/kukeon/cmd/kuke/restart"
+	restorecmd "github.com/eminwux/kukeon/cmd/kuke/restore"
 	runcmd "github.com/eminwux/kukeon/cmd/kuke/run"
+	stackcmd "github.com/eminwux/kukeon/cmd/kuke/stack"
 	startcmd "github.com/eminwux/kukeon/cmd/kuke/start"
 	statuscmd "github.com/eminwux/kukeon/cmd/kuke/status"
 	stopcmd "github.com/eminwux/kukeon/cmd/kuke/stop"
 	teamcmd "github.com/eminwux/kukeon/cmd/kuke/team"
+	topcmd "github.com/eminwux/kukeon/cmd/kuke/top"
 	uninstallcmd "github.com/eminwux/kukeon/cmd/kuke/uninstall"
+	validatecmd "github.com/eminwux/kukeon/cmd/kuke/validate"
 	"github.com/eminwux/kukeon/cmd/kuke/version"
 	"github.com/eminwux/kukeon/cmd/types"
 	"github.com/eminwux/kukeon/internal/clientconfig"
 	"github.com/eminwux/kukeon/internal/cni"
 	"github.com/eminwux/kukeon/internal/consts"
+	"github.com/eminwux/kukeon/internal/ctr"
 	"github.com/eminwux/kukeon/internal/errdefs"
 	"github.com/eminwux/kukeon/internal/logging"
 	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
@@ -74,8 +89,15 @@ func NewKukeCmd() (*cobra.Command, error) {
 				return err
 			}
 
+			logFormat, err := logging.ParseFormat(viper.GetString(config.KUKEON_ROOT_LOG_FORMAT.ViperKey))
+			if err != nil {
+				return err
+			}
+
+			// --log-format json asks for machine-readable logs, so it enables
+			// logging on its own; text output stays behind --verbose.
 			var logger *slog.Logger
-			if viper.GetBool(config.KUKEON_ROOT_VERBOSE.ViperKey) {
+			if viper.GetBool(config.KUKEON_ROOT_VERBOSE.ViperKey) || logFormat == logging.FormatJSON {
 				logLevel := viper.GetString(config.KUKEON_ROOT_LOG_LEVEL.ViperKey)
 				if logLevel == "" {
 					logLevel = "info"
@@ -85,9 +107,17 @@ func NewKukeCmd() (*cobra.Command, error) {
 				levelVar := new(slog.LevelVar)
 				levelVar.Set(logging.ParseLevel(logLevel))
 
-				textHandler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: levelVar})
-				handler := &logging.ReformatHandler{Inner: textHandler, Writer: os.Stdout}
-				logger = slog.New(handler)
+				var handler slog.Handler
+				if logFormat == logging.FormatJSON {
+					// JSON records go to stderr so stdout stays clean for
+					// command output (e.g. `-o json`).
+					logger = logging.NewJSONLogger(os.Stderr, levelVar)
+					handler = logger.Handler()
+				} else {
+					textHandler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: levelVar})
+					handler = &logging.ReformatHandler{Inner: textHandler, Writer: os.Stdout}
+					logger = slog.New(handler)
+				}
 
 				// Store both logger and levelVar in context using struct keys
 				ctx := cmd.Context()
@@ -100,6 +130,8 @@ func NewKukeCmd() (*cobra.Command, error) {
 					"enabling verbose",
 					"log-level",
 					viper.GetString(config.KUKEON_ROOT_LOG_LEVEL.ViperKey),
+					"log-format",
+					logFormat,
 				)
 			}
 
@@ -111,7 +143,7 @@ func NewKukeCmd() (*cobra.Command, error) {
 				loader = &realConfigLoader{}
 			}
 
-			err := loader.LoadConfig()
+			err = loader.LoadConfig()
 			if err != nil {
 				// Only log if logger was created (verbose mode)
 				if logger != nil {
@@ -123,7 +155,7 @@ func NewKukeCmd() (*cobra.Command, error) {
 			rebindNoDaemonViperToLeaf(cmd)
 			applyRunPathImpliesNoDaemon(cmd)
 			applyRunPathImpliesKukeondSocket(cmd)
-			return nil
+			return applyCommandTimeout(cmd)
 		},
 		Run: func(cmd *cobra.Command, _ []string) {
 			_ = cmd.Help()
@@ -148,6 +180,8 @@ func NewKukeCmd() (*cobra.Command, error) {
 func SetupKukeCmd(rootCmd *cobra.Command) error {
 	rootCmd.AddCommand(initcmd.NewInitCmd())
 	rootCmd.AddCommand(applycmd.NewApplyCmd())
+	rootCmd.AddCommand(validatecmd.NewValidateCmd())
+	rootCmd.AddCommand(diffcmd.NewDiffCmd())
 	rootCmd.AddCommand(createcmd.NewCreateCmd())
 	rootCmd.AddCommand(buildcmd.NewBuildCmd())
 	rootCmd.AddCommand(daemoncmd.NewDaemonCmd())
@@ -161,10 +195,24 @@ func SetupKukeCmd(rootCmd *cobra.Command) error {
 	rootCmd.AddCommand(killcmd.NewKillCmd())
 	rootCmd.AddCommand(purgecmd.NewPurgeCmd())
 	rootCmd.AddCommand(refreshcmd.NewRefreshCmd())
+	rootCmd.AddCommand(renamecmd.NewRenameCmd())
+	rootCmd.AddCommand(patchcmd.NewPatchCmd())
+	rootCmd.AddCommand(stackcmd.NewStackCmd())
+	rootCmd.AddCommand(topcmd.NewTopCmd())
+	rootCmd.AddCommand(cordoncmd.NewCordonCmd())
+	rootCmd.AddCommand(cordoncmd.NewUncordonCmd())
+	rootCmd.AddCommand(draincmd.NewDrainCmd())
+	rootCmd.AddCommand(draincmd.NewUndrainCmd())
+	rootCmd.AddCommand(configcmd.NewConfigCmd())
+	rootCmd.AddCommand(exportcmd.NewExportCmd())
+	rootCmd.AddCommand(importcmd.NewImportCmd())
+	rootCmd.AddCommand(restorecmd.NewRestoreCmd())
 	rootCmd.AddCommand(restartcmd.NewRestartCmd())
 	rootCmd.AddCommand(runcmd.NewRunCmd())
 	rootCmd.AddCommand(attachcmd.NewAttachCmd())
 	rootCmd.AddCommand(logcmd.NewLogCmd())
+	rootCmd.AddCommand(cpcmd.NewCpCmd())
+	rootCmd.AddCommand(inspectcmd.NewInspectCmd())
 	rootCmd.AddCommand(autocompletecmd.NewAutocompleteCmd())
 	rootCmd.AddCommand(imagecmd.NewImageCmd())
 	rootCmd.AddCommand(uninstallcmd.NewUninstallCmd())
@@ -205,6 +253,16 @@ func SetPersistentLoggingFlags(rootCmd *cobra.Command) error {
 		return err
 	}
 
+	rootCmd.PersistentFlags().Duration("containerd-timeout", ctr.DefaultConnectTimeout, "Timeout for connecting to containerd")
+	if err := viper.BindPFlag(config.KUKEON_ROOT_CONTAINERD_TIMEOUT.ViperKey, rootCmd.PersistentFlags().Lookup("containerd-timeout")); err != nil {
+		return err
+	}
+
+	rootCmd.PersistentFlags().Duration("timeout", 0, "Cancel the whole command after this long (0 means no limit)")
+	if err := viper.BindPFlag(config.KUKEON_ROOT_TIMEOUT.ViperKey, rootCmd.PersistentFlags().Lookup("timeout")); err != nil {
+		return err
+	}
+
 	rootCmd.PersistentFlags().String(
 		"host", config.KUKEON_ROOT_HOST.Default,
 		"kukeond endpoint (unix:///path or ssh://user@host)",
@@ -213,6 +271,14 @@ func SetPersistentLoggingFlags(rootCmd *cobra.Command) error {
 		return err
 	}
 
+	rootCmd.PersistentFlags().String(
+		"context", "",
+		"Client context whose realm/space/stack are the default scope; empty uses spec.currentContext",
+	)
+	if err := viper.BindPFlag(config.KUKEON_ROOT_CONTEXT.ViperKey, rootCmd.PersistentFlags().Lookup("context")); err != nil {
+		return err
+	}
+
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose logging")
 	if err := viper.BindPFlag(config.KUKEON_ROOT_VERBOSE.ViperKey, rootCmd.PersistentFlags().Lookup("verbose")); err != nil {
 		return err
@@ -223,6 +289,11 @@ func SetPersistentLoggingFlags(rootCmd *cobra.Command) error {
 		return err
 	}
 
+	rootCmd.PersistentFlags().String("log-format", "", "Log format (text, json); json enables logging without --verbose")
+	if err := viper.BindPFlag(config.KUKEON_ROOT_LOG_FORMAT.ViperKey, rootCmd.PersistentFlags().Lookup("log-format")); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -268,6 +339,8 @@ func loadConfig() error {
 		viper.Set(config.KUKEON_ROOT_LOG_LEVEL.ViperKey, "info")
 	}
 
+	_ = config.KUKEON_ROOT_LOG_FORMAT.BindEnv()
+
 	_ = config.KUKE_GET_OUTPUT.BindEnv()
 
 	// `--configuration` already binds this viper key via BindPFlag
@@ -321,6 +394,9 @@ func loadClientConfiguration(cmd *cobra.Command) error {
 		return fmt.Errorf("load client configuration: %w", err)
 	}
 	applyClientConfiguration(cmd, doc.Spec)
+	if ctxErr := applyClientContext(cmd, doc); ctxErr != nil {
+		return ctxErr
+	}
 	// Mirror the daemon-side LoadServerConfigurationFromFlag: drive
 	// consts.ConfigureRuntime from the configured (or default) suffix and
 	// cgroup root so --no-daemon workload paths read the same realm
@@ -401,6 +477,37 @@ func applyClientConfiguration(cmd *cobra.Command, spec v1beta1.ClientConfigurati
 	}
 }
 
+// applyClientContext points the realm/space/stack fallback of every scoped
+// command at the selected client context: `--context` > KUKEON_CONTEXT >
+// spec.currentContext. Only the built-in "default" moves, so precedence
+// stays explicit `--flag` > env > context > "default". Skipped for `kuke
+// config` itself, so a stale context selection can still be fixed with it.
+func applyClientContext(cmd *cobra.Command, doc *v1beta1.ClientConfigurationDoc) error {
+	if isClientConfigCmd(cmd) {
+		return nil
+	}
+	_ = config.KUKEON_ROOT_CONTEXT.BindEnv()
+	ctx, ok, err := clientconfig.Context(doc, viper.GetString(config.KUKEON_ROOT_CONTEXT.ViperKey))
+	if err != nil {
+		return err
+	}
+	if ok {
+		config.ApplyScopeDefaults(ctx.Realm, ctx.Space, ctx.Stack)
+	}
+	return nil
+}
+
+// isClientConfigCmd reports whether cmd is `kuke config` or one of its
+// subcommands (not `kuke get config`, which shares the name one level down).
+func isClientConfigCmd(cmd *cobra.Command) bool {
+	for c := cmd; c != nil && c.HasParent(); c = c.Parent() {
+		if c.Name() == "config" && !c.Parent().HasParent() {
+			return true
+		}
+	}
+	return false
+}
+
 // rebindNoDaemonViperToLeaf rebinds the `kukeon/noDaemon` viper key to the
 // `--no-daemon` flag on the leaf cmd being executed, if that cmd has one.
 // Required because #222 demoted `--no-daemon` from a single root-persistent
@@ -497,6 +604,30 @@ func applyRunPathImpliesKukeondSocket(cmd *cobra.Command) {
 	viper.Set(config.KUKEOND_SOCKET.ViperKey, filepath.Join(runPath, "kukeond.sock"))
 }
 
+// applyCommandTimeout bounds the leaf command's context with --timeout
+// (KUKEON_TIMEOUT). Everything downstream — the daemon RPC, the in-process
+// controller and the containerd client it builds — runs on cmd.Context(), so
+// the deadline cancels the whole operation. The cause is
+// errdefs.ErrCommandTimeout, which execRoot reports instead of whatever
+// primitive the deadline interrupted. The deadline must outlive this hook,
+// so its cancel func rides the context under types.CtxCancel and execRoot
+// calls it once the command returns. Zero leaves the command unbounded.
+func applyCommandTimeout(cmd *cobra.Command) error {
+	timeout := viper.GetDuration(config.KUKEON_ROOT_TIMEOUT.ViperKey)
+	if timeout < 0 {
+		return fmt.Errorf("%w: --timeout must not be negative, got %s", errdefs.ErrInvalidTimeout, timeout)
+	}
+	if timeout == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeoutCause(
+		cmd.Context(), timeout,
+		fmt.Errorf("%w after %s", errdefs.ErrCommandTimeout, timeout),
+	)
+	cmd.SetContext(context.WithValue(ctx, types.CtxCancel, cancel))
+	return nil
+}
+
 // flagChanged checks both the local and persistent flag sets so the helper
 // is correct in tests (where cmd is the root and persistent flags are not
 // yet merged into cmd.Flags()) and in production (where cmd is the leaf
@@ -413,6 +413,47 @@ func TestPersistentPreRunELoggerContext(t *testing.T) {
 	}
 }
 
+func TestPersistentPreRunEJSONLogFormat(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	cmd, err := kuke.NewKukeCmd()
+	if err != nil {
+		t.Fatalf("NewKukeCmd() error = %v", err)
+	}
+
+	// json enables logging without --verbose.
+	viper.Set(config.KUKEON_ROOT_LOG_FORMAT.ViperKey, "json")
+	cmd.SetContext(context.Background())
+
+	if err = cmd.PersistentPreRunE(cmd, []string{}); err != nil {
+		t.Fatalf("PersistentPreRunE() error = %v, want nil", err)
+	}
+
+	if _, ok := cmd.Context().Value(types.CtxLogger).(*slog.Logger); !ok {
+		t.Fatal("logger not found in context")
+	}
+	if _, ok := cmd.Context().Value(types.CtxHandler).(*slog.JSONHandler); !ok {
+		t.Errorf("handler type = %T, want *slog.JSONHandler", cmd.Context().Value(types.CtxHandler))
+	}
+}
+
+func TestPersistentPreRunEInvalidLogFormat(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	cmd, err := kuke.NewKukeCmd()
+	if err != nil {
+		t.Fatalf("NewKukeCmd() error = %v", err)
+	}
+
+	viper.Set(config.KUKEON_ROOT_LOG_FORMAT.ViperKey, "yaml")
+	cmd.SetContext(context.Background())
+
+	err = cmd.PersistentPreRunE(cmd, []string{})
+	if !errors.Is(err, errdefs.ErrInvalidLogFormat) {
+		t.Fatalf("PersistentPreRunE() error = %v, want ErrInvalidLogFormat", err)
+	}
+}
+
 func TestPersistentPreRunEConfigErrorWrapping(t *testing.T) {
 	t.Cleanup(viper.Reset)
 
@@ -733,3 +774,60 @@ func TestRunPathImpliesKukeondSocket(t *testing.T) {
 		})
 	}
 }
+
+func TestPersistentPreRunETimeout(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	cmd, err := kuke.NewKukeCmd()
+	if err != nil {
+		t.Fatalf("NewKukeCmd() error = %v", err)
+	}
+
+	viper.Set(config.KUKEON_ROOT_TIMEOUT.ViperKey, "50ms")
+	cmd.SetContext(context.Background())
+
+	if err = cmd.PersistentPreRunE(cmd, []string{}); err != nil {
+		t.Fatalf("PersistentPreRunE() error = %v, want nil", err)
+	}
+	if _, ok := cmd.Context().Deadline(); !ok {
+		t.Fatal("command context has no deadline, want one from --timeout")
+	}
+	<-cmd.Context().Done()
+	if cause := context.Cause(cmd.Context()); !errors.Is(cause, errdefs.ErrCommandTimeout) {
+		t.Errorf("context.Cause() = %v, want ErrCommandTimeout", cause)
+	}
+}
+
+func TestPersistentPreRunENoTimeoutByDefault(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	cmd, err := kuke.NewKukeCmd()
+	if err != nil {
+		t.Fatalf("NewKukeCmd() error = %v", err)
+	}
+	cmd.SetContext(context.Background())
+
+	if err = cmd.PersistentPreRunE(cmd, []string{}); err != nil {
+		t.Fatalf("PersistentPreRunE() error = %v, want nil", err)
+	}
+	if deadline, ok := cmd.Context().Deadline(); ok {
+		t.Errorf("command context deadline = %v, want none", deadline)
+	}
+}
+
+func TestPersistentPreRunENegativeTimeout(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	cmd, err := kuke.NewKukeCmd()
+	if err != nil {
+		t.Fatalf("NewKukeCmd() error = %v", err)
+	}
+
+	viper.Set(config.KUKEON_ROOT_TIMEOUT.ViperKey, "-1s")
+	cmd.SetContext(context.Background())
+
+	err = cmd.PersistentPreRunE(cmd, []string{})
+	if !errors.Is(err, errdefs.ErrInvalidTimeout) {
+		t.Fatalf("PersistentPreRunE() error = %v, want ErrInvalidTimeout", err)
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/util/logstream"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+)
+
+// ContainerLogSource is one container's log as seen by StreamCellLogs: the
+// name its lines are prefixed with and the stream file to read.
+type ContainerLogSource struct {
+	Name            string
+	Path            string
+	SeparateStreams bool
+}
+
+// StreamCellLogs multiplexes the logs of several containers into out, each
+// line prefixed with "[<name>] ". Every source goes through
+// StreamContainerLogs, so stream selection applies per container exactly as
+// it does for a single one.
+//
+// Without follow the sources are dumped one after the other in the given
+// order, keeping each container's output contiguous. With follow they are
+// tailed concurrently and their lines interleave as they are written; the
+// first failure, SIGINT/SIGTERM or ctx cancellation stops all of them, and a
+// signal or cancellation returns nil like a single-container follow.
+func StreamCellLogs(
+	ctx context.Context,
+	tail TailFn,
+	sources []ContainerLogSource,
+	stream logstream.Stream,
+	out io.Writer,
+	follow bool,
+) error {
+	var mu sync.Mutex
+	streamOne := func(ctx context.Context, src ContainerLogSource) error {
+		w := &prefixWriter{mu: &mu, out: out, prefix: []byte("[" + src.Name + "] ")}
+		err := StreamContainerLogs(ctx, tail, src.Path, src.SeparateStreams, stream, w, follow)
+		if flushErr := w.flush(); err == nil {
+			err = flushErr
+		}
+		if err != nil {
+			return fmt.Errorf("container %q: %w", src.Name, err)
+		}
+		return nil
+	}
+
+	if !follow {
+		for _, src := range sources {
+			if err := streamOne(ctx, src); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	followCtx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	followCtx, cancel := context.WithCancel(followCtx)
+	defer cancel()
+
+	errs := make([]error, len(sources))
+	var wg sync.WaitGroup
+	for i, src := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := streamOne(followCtx, src); err != nil {
+				errs[i] = err
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// prefixWriter buffers one container's output and writes it to out a whole
+// line at a time, prefixed, so lines from concurrently tailed containers
+// never interleave mid-line. mu is shared by every prefixWriter writing to
+// the same out.
+type prefixWriter struct {
+	mu     *sync.Mutex
+	out    io.Writer
+	prefix []byte
+	buf    []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	end := bytes.LastIndexByte(w.buf, '\n')
+	if end < 0 {
+		return len(p), nil
+	}
+	if err := w.writeLines(w.buf[:end+1]); err != nil {
+		return 0, err
+	}
+	w.buf = append(w.buf[:0], w.buf[end+1:]...)
+	return len(p), nil
+}
+
+// flush writes a trailing partial line, terminated with a newline so the
+// next container's output starts on a line of its own.
+func (w *prefixWriter) flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.writeLines(append(w.buf, '\n'))
+	w.buf = nil
+	return err
+}
+
+func (w *prefixWriter) writeLines(lines []byte) error {
+	var b bytes.Buffer
+	for len(lines) > 0 {
+		i := bytes.IndexByte(lines, '\n')
+		b.Write(w.prefix)
+		b.Write(lines[:i+1])
+		lines = lines[i+1:]
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err := w.out.Write(b.Bytes())
+	return err
+}
+
+// cellLogSources resolves the log of every non-root container in the cell,
+// sorted by name. With previous it picks each container's archived log and
+// leaves out containers that have none (Attachable ones, and those without
+// an archive on disk) — each with a note on errOut — instead of failing.
+func cellLogSources(
+	ctx context.Context,
+	client kukeonv1.Client,
+	realm, space, stack, cell string,
+	previous bool,
+	errOut io.Writer,
+) ([]ContainerLogSource, error) {
+	specs, err := client.ListContainers(ctx, realm, space, stack, cell)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(specs))
+	for i := range specs {
+		if !specs[i].Root {
+			names = append(names, specs[i].ID)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%w (cell %q)", errdefs.ErrAttachNoCandidate, cell)
+	}
+	sort.Strings(names)
+
+	sources := make([]ContainerLogSource, 0, len(names))
+	for _, name := range names {
+		result, logErr := client.LogContainer(ctx, buildContainerDoc(name, realm, space, stack, cell))
+		if logErr != nil {
+			return nil, fmt.Errorf("container %q: %w", name, logErr)
+		}
+		path := logSourcePath(result, previous)
+		if path == "" {
+			if previous {
+				fmt.Fprintf(errOut, "skipping container %q: its output is not archived across restarts\n", name)
+				continue
+			}
+			return nil, fmt.Errorf("daemon returned no stream path for container %q", name)
+		}
+		if _, statErr := os.Stat(path); errors.Is(statErr, os.ErrNotExist) {
+			fmt.Fprintf(errOut, "skipping container %q: no log file at %s yet\n", name, path)
+			continue
+		}
+		sources = append(sources, ContainerLogSource{
+			Name:            name,
+			Path:            path,
+			SeparateStreams: result.SeparateStreams,
+		})
+	}
+	return sources, nil
+}
+
+func logSourcePath(result kukeonv1.LogContainerResult, previous bool) string {
+	if previous {
+		return result.HostPreviousLogPath
+	}
+	if result.HostCapturePath != "" {
+		return result.HostCapturePath
+	}
+	return result.HostLogPath
+}
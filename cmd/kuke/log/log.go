@@ -30,6 +30,18 @@
 //   - Non-Attachable containers (including kukeond) have the containerd
 //     runtime shim write stdout/stderr to HostLogPath via cio.LogFile.
 //     `kuke log` reads that file. Implemented per issue #203.
+//
+// A non-Attachable container started with spec.separateStreams keeps
+// stdout and stderr apart: its log file holds stream-tagged records, and
+// `--stream=stdout|stderr` prints only one of them (StreamContainerLogs).
+// The default, combined, prints both in the order they were written.
+//
+// When a non-Attachable container starts a new task, its log file is
+// archived first; `--previous` prints that archive, i.e. the output of the
+// instance before the latest restart.
+//
+// `--all-containers` prints every non-root container of the cell instead of
+// one, each line prefixed with its container name (StreamCellLogs).
 package log
 
 import (
@@ -47,6 +59,7 @@ import (
 	kukeshared "github.com/eminwux/kukeon/cmd/kuke/shared"
 	"github.com/eminwux/kukeon/internal/consts"
 	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/util/logstream"
 	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
 	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
 	"github.com/spf13/cobra"
@@ -84,6 +97,32 @@ func TailFile(ctx context.Context, path string, out io.Writer, follow bool) erro
 	return tailFile(ctx, path, out, follow)
 }
 
+// StreamContainerLogs prints the selected stream of a container log through
+// tail. A separateStreams log is decoded record by record, keeping only
+// stream's output (both streams, in write order, for Combined). Any other log
+// is raw bytes with the streams already merged, so only Combined is
+// accepted for it.
+func StreamContainerLogs(
+	ctx context.Context,
+	tail TailFn,
+	path string,
+	separateStreams bool,
+	stream logstream.Stream,
+	out io.Writer,
+	follow bool,
+) error {
+	if !separateStreams {
+		if stream != logstream.Combined {
+			return fmt.Errorf(
+				"%w: --stream=%s needs a container started with spec.separateStreams",
+				errdefs.ErrInvalidLogStream, stream,
+			)
+		}
+		return tail(ctx, path, out, follow)
+	}
+	return tail(ctx, path, logstream.NewDecoder(out, stream), follow)
+}
+
 // NewLogCmd builds the `kuke log` cobra command.
 func NewLogCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -107,6 +146,15 @@ func NewLogCmd() *cobra.Command {
 	_ = viper.BindPFlag(config.KUKE_LOG_CONTAINER.ViperKey, cmd.Flags().Lookup("container"))
 	cmd.Flags().BoolP("follow", "f", false, "Tail the file until SIGINT instead of printing current contents and exiting")
 	_ = viper.BindPFlag(config.KUKE_LOG_FOLLOW.ViperKey, cmd.Flags().Lookup("follow"))
+	cmd.Flags().String("stream", string(logstream.Combined),
+		"Output stream to print: stdout, stderr, or combined (stdout/stderr need spec.separateStreams)")
+	_ = viper.BindPFlag(config.KUKE_LOG_STREAM.ViperKey, cmd.Flags().Lookup("stream"))
+	cmd.Flags().BoolP("previous", "p", false,
+		"Print the log of the container instance before the latest restart (non-Attachable containers only)")
+	_ = viper.BindPFlag(config.KUKE_LOG_PREVIOUS.ViperKey, cmd.Flags().Lookup("previous"))
+	cmd.Flags().Bool("all-containers", false,
+		"Print every non-root container of the cell, each line prefixed with its container name")
+	_ = viper.BindPFlag(config.KUKE_LOG_ALL_CONTAINERS.ViperKey, cmd.Flags().Lookup("all-containers"))
 
 	cmd.ValidArgsFunction = config.CompleteCellNames
 	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
@@ -124,10 +172,19 @@ func runLog(cmd *cobra.Command, args []string) error {
 	stack := strings.TrimSpace(viper.GetString(config.KUKE_LOG_STACK.ViperKey))
 	container := strings.TrimSpace(viper.GetString(config.KUKE_LOG_CONTAINER.ViperKey))
 	follow := viper.GetBool(config.KUKE_LOG_FOLLOW.ViperKey)
+	previous := viper.GetBool(config.KUKE_LOG_PREVIOUS.ViperKey)
+	allContainers := viper.GetBool(config.KUKE_LOG_ALL_CONTAINERS.ViperKey)
+	stream, err := logstream.ParseStream(strings.TrimSpace(viper.GetString(config.KUKE_LOG_STREAM.ViperKey)))
+	if err != nil {
+		return fmt.Errorf("%w: %w", errdefs.ErrInvalidLogStream, err)
+	}
 
 	if cell == "" {
 		return fmt.Errorf("%w (positional cell)", errdefs.ErrCellNameRequired)
 	}
+	if allContainers && container != "" {
+		return errors.New("--container and --all-containers are mutually exclusive")
+	}
 
 	client, err := resolveClient(cmd)
 	if err != nil {
@@ -135,6 +192,19 @@ func runLog(cmd *cobra.Command, args []string) error {
 	}
 	defer func() { _ = client.Close() }()
 
+	if allContainers {
+		sources, sourcesErr := cellLogSources(
+			cmd.Context(), client, realm, space, stack, cell, previous, cmd.ErrOrStderr(),
+		)
+		if sourcesErr != nil {
+			return sourcesErr
+		}
+		// An archive never changes, so --previous prints it once even with -f.
+		return StreamCellLogs(
+			cmd.Context(), resolveTail(cmd), sources, stream, cmd.OutOrStdout(), follow && !previous,
+		)
+	}
+
 	if container == "" {
 		container, err = kukeshared.PickContainer(cmd.Context(), client, realm, space, stack, cell,
 			func(spec v1beta1.ContainerSpec) bool {
@@ -156,6 +226,9 @@ func runLog(cmd *cobra.Command, args []string) error {
 		}
 		return err
 	}
+	if previous {
+		return printPreviousLog(cmd, result, stream, cell, container)
+	}
 	streamPath := result.HostCapturePath
 	if streamPath == "" {
 		streamPath = result.HostLogPath
@@ -165,7 +238,10 @@ func runLog(cmd *cobra.Command, args []string) error {
 	}
 
 	tail := resolveTail(cmd)
-	if tailErr := tail(cmd.Context(), streamPath, cmd.OutOrStdout(), follow); tailErr != nil {
+	tailErr := StreamContainerLogs(
+		cmd.Context(), tail, streamPath, result.SeparateStreams, stream, cmd.OutOrStdout(), follow,
+	)
+	if tailErr != nil {
 		if errors.Is(tailErr, os.ErrNotExist) {
 			return fmt.Errorf(
 				"cell %q container %q has no log file at %s (the runtime shim has not opened it yet — try again after the container produces output)",
@@ -179,6 +255,30 @@ func runLog(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// printPreviousLog dumps the archived log of the instance before the latest
+// restart. The archive is never written to again, so it is printed once even
+// with --follow.
+func printPreviousLog(
+	cmd *cobra.Command,
+	result kukeonv1.LogContainerResult,
+	stream logstream.Stream,
+	cell, container string,
+) error {
+	if result.HostPreviousLogPath == "" {
+		return fmt.Errorf("%w: cell %q container %q is Attachable; its output is not archived across restarts",
+			errdefs.ErrNoPreviousLog, cell, container)
+	}
+	err := StreamContainerLogs(
+		cmd.Context(), resolveTail(cmd), result.HostPreviousLogPath,
+		result.SeparateStreams, stream, cmd.OutOrStdout(), false,
+	)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: cell %q container %q has not restarted since it produced output",
+			errdefs.ErrNoPreviousLog, cell, container)
+	}
+	return err
+}
+
 // tailFile opens path and streams its bytes to out. With follow=false
 // (the default for `kuke log`) it dumps the current contents and
 // returns. With follow=true it dumps and then polls for new bytes until
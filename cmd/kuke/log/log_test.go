@@ -30,6 +30,7 @@ import (
 
 	logcmd "github.com/eminwux/kukeon/cmd/kuke/log"
 	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/util/logrotate"
 	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
 	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
 	"github.com/spf13/cobra"
@@ -534,3 +535,283 @@ func TestTailFile_DefaultDumpsAndReturns(t *testing.T) {
 		t.Errorf("stdout = %q, want %q", got, want)
 	}
 }
+
+// TestLog_StreamStderr_FiltersSeparateStreamsLog checks that --stream=stderr
+// on a separateStreams container prints only the stderr records' bytes.
+func TestLog_StreamStderr_FiltersSeparateStreamsLog(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	fc := &fakeClient{
+		logContainerFn: func(_ v1beta1.ContainerDoc) (kukeonv1.LogContainerResult, error) {
+			return kukeonv1.LogContainerResult{
+				HostLogPath:     "/opt/kukeon/r1/s1/st1/c1/side/log",
+				SeparateStreams: true,
+			}, nil
+		},
+	}
+	tail := &tailCapture{payload: []byte("" +
+		"2026-10-17T12:00:00.001Z stdout F out-line\n" +
+		"2026-10-17T12:00:00.002Z stderr F err-line\n" +
+		"2026-10-17T12:00:00.003Z stdout P out-partial\n" +
+		"2026-10-17T12:00:00.004Z stderr P err-partial\n")}
+	cmd, out := newCmdWithCtx(t, fc, tail)
+	cmd.SetArgs([]string{"--container", "side", "--stream", "stderr", "c1"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if got, want := out.String(), "err-line\nerr-partial"; got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+}
+
+// TestLog_StreamCombinedIsDefault checks the default keeps both streams in
+// write order, so existing invocations see no change.
+func TestLog_StreamCombinedIsDefault(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	fc := &fakeClient{
+		logContainerFn: func(_ v1beta1.ContainerDoc) (kukeonv1.LogContainerResult, error) {
+			return kukeonv1.LogContainerResult{HostLogPath: "/log", SeparateStreams: true}, nil
+		},
+	}
+	tail := &tailCapture{payload: []byte("" +
+		"2026-10-17T12:00:00.001Z stdout F a\n" +
+		"2026-10-17T12:00:00.002Z stderr F b\n")}
+	cmd, out := newCmdWithCtx(t, fc, tail)
+	cmd.SetArgs([]string{"--container", "side", "c1"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if got, want := out.String(), "a\nb\n"; got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+}
+
+// TestLog_StreamStdout_RejectsMergedLog checks that a single stream cannot be
+// requested from a container whose log merges stdout and stderr.
+func TestLog_StreamStdout_RejectsMergedLog(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	fc := &fakeClient{
+		logContainerFn: func(_ v1beta1.ContainerDoc) (kukeonv1.LogContainerResult, error) {
+			return kukeonv1.LogContainerResult{HostLogPath: "/log"}, nil
+		},
+	}
+	tail := &tailCapture{payload: []byte("raw\n")}
+	cmd, _ := newCmdWithCtx(t, fc, tail)
+	cmd.SetArgs([]string{"--container", "side", "--stream", "stdout", "c1"})
+
+	err := cmd.Execute()
+	if !errors.Is(err, errdefs.ErrInvalidLogStream) {
+		t.Fatalf("err = %v, want ErrInvalidLogStream", err)
+	}
+	if tail.calls != 0 {
+		t.Errorf("tail called %d times, want 0", tail.calls)
+	}
+}
+
+func TestLog_InvalidStream(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	cmd, _ := newCmdWithCtx(t, &fakeClient{}, &tailCapture{})
+	cmd.SetArgs([]string{"--stream", "both", "c1"})
+
+	if err := cmd.Execute(); !errors.Is(err, errdefs.ErrInvalidLogStream) {
+		t.Fatalf("err = %v, want ErrInvalidLogStream", err)
+	}
+}
+
+// TestLog_Previous_PrintsOutputFromBeforeRestart models a crash loop: the
+// first instance writes its log, the restart archives it the way
+// StartContainer does, and the new instance starts a fresh log. --previous
+// must print the first instance's output and the default the second's.
+func TestLog_Previous_PrintsOutputFromBeforeRestart(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "log")
+	prevPath := filepath.Join(dir, "log.previous")
+	if err := os.WriteFile(logPath, []byte("first instance: panic\n"), 0o600); err != nil {
+		t.Fatalf("seed log: %v", err)
+	}
+	if archived, err := logrotate.Archive(logPath, prevPath); err != nil || !archived {
+		t.Fatalf("Archive: archived=%v err=%v", archived, err)
+	}
+	if err := os.WriteFile(logPath, []byte("second instance: starting\n"), 0o600); err != nil {
+		t.Fatalf("seed restarted log: %v", err)
+	}
+	fc := &fakeClient{
+		logContainerFn: func(_ v1beta1.ContainerDoc) (kukeonv1.LogContainerResult, error) {
+			return kukeonv1.LogContainerResult{HostLogPath: logPath, HostPreviousLogPath: prevPath}, nil
+		},
+	}
+
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--container", "side", "--previous", "c1"}, "first instance: panic\n"},
+		{[]string{"--container", "side", "c1"}, "second instance: starting\n"},
+	} {
+		viper.Reset()
+		cmd, out := newCmdWithCtx(t, fc, nil)
+		cmd.SetArgs(tc.args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("%v: Execute returned error: %v", tc.args, err)
+		}
+		if got := out.String(); got != tc.want {
+			t.Errorf("%v: stdout = %q, want %q", tc.args, got, tc.want)
+		}
+	}
+}
+
+func TestLog_Previous_NoPreviousInstance(t *testing.T) {
+	dir := t.TempDir()
+	for name, result := range map[string]kukeonv1.LogContainerResult{
+		"never restarted": {
+			HostLogPath:         filepath.Join(dir, "log"),
+			HostPreviousLogPath: filepath.Join(dir, "log.previous"),
+		},
+		"attachable": {HostCapturePath: testHostCapture},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			fc := &fakeClient{
+				logContainerFn: func(_ v1beta1.ContainerDoc) (kukeonv1.LogContainerResult, error) {
+					return result, nil
+				},
+			}
+			cmd, _ := newCmdWithCtx(t, fc, nil)
+			cmd.SetArgs([]string{"--container", "side", "-p", "c1"})
+			err := cmd.Execute()
+			if !errors.Is(err, errdefs.ErrNoPreviousLog) {
+				t.Fatalf("err = %v, want ErrNoPreviousLog", err)
+			}
+			if !strings.Contains(err.Error(), `container "side"`) {
+				t.Errorf("err %q does not name the container", err)
+			}
+		})
+	}
+}
+
+// twoContainerCell returns a fakeClient whose cell holds a root container
+// and two workload containers, app and side, with logs at the given paths.
+func twoContainerCell(t *testing.T, appLog, sideLog string) *fakeClient {
+	t.Helper()
+	return &fakeClient{
+		listContainersFn: func(_, _, _, _ string) ([]v1beta1.ContainerSpec, error) {
+			return []v1beta1.ContainerSpec{
+				{ID: "side"},
+				{ID: "root", Root: true},
+				{ID: "app"},
+			}, nil
+		},
+		logContainerFn: func(doc v1beta1.ContainerDoc) (kukeonv1.LogContainerResult, error) {
+			switch doc.Metadata.Name {
+			case "app":
+				return kukeonv1.LogContainerResult{HostLogPath: appLog}, nil
+			case "side":
+				return kukeonv1.LogContainerResult{HostCapturePath: sideLog}, nil
+			}
+			t.Errorf("LogContainer called for %q", doc.Metadata.Name)
+			return kukeonv1.LogContainerResult{}, errdefs.ErrContainerNotFound
+		},
+	}
+}
+
+func TestLog_AllContainers_PrefixesEachContainer(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	dir := t.TempDir()
+	appLog := filepath.Join(dir, "app.log")
+	sideLog := filepath.Join(dir, "side.capture")
+	if err := os.WriteFile(appLog, []byte("listening\nready\n"), 0o600); err != nil {
+		t.Fatalf("seed app log: %v", err)
+	}
+	if err := os.WriteFile(sideLog, []byte("syncing"), 0o600); err != nil {
+		t.Fatalf("seed side log: %v", err)
+	}
+
+	cmd, out := newCmdWithCtx(t, twoContainerCell(t, appLog, sideLog), nil)
+	cmd.SetArgs([]string{"--all-containers", "c1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	want := "[app] listening\n[app] ready\n[side] syncing\n"
+	if got := out.String(); got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+}
+
+func TestLog_AllContainers_FollowMergesStreams(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	dir := t.TempDir()
+	appLog := filepath.Join(dir, "app.log")
+	sideLog := filepath.Join(dir, "side.capture")
+	for _, p := range []string{appLog, sideLog} {
+		if err := os.WriteFile(p, nil, 0o600); err != nil {
+			t.Fatalf("seed %s: %v", p, err)
+		}
+	}
+
+	// Each fake stream writes its lines in two chunks, splitting a line, and
+	// then blocks like the real follow loop until the fan-in is cancelled.
+	var started sync.WaitGroup
+	started.Add(2)
+	tail := func(ctx context.Context, path string, out io.Writer, follow bool) error {
+		if !follow {
+			t.Errorf("tail for %s called with follow=false", path)
+		}
+		name := filepath.Base(path)
+		_, _ = io.WriteString(out, name+" one\n"+name+" t")
+		_, _ = io.WriteString(out, "wo\n")
+		started.Done()
+		<-ctx.Done()
+		return nil
+	}
+
+	cmd, out := newCmdWithCtx(t, twoContainerCell(t, appLog, sideLog), nil)
+	ctx, cancel := context.WithCancel(context.WithValue(cmd.Context(), logcmd.MockTailKey{}, logcmd.TailFn(tail)))
+	cmd.SetContext(ctx)
+	cmd.SetArgs([]string{"--all-containers", "-f", "c1"})
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Execute() }()
+	started.Wait()
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Execute returned %v on context cancel, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Execute did not return after cancel")
+	}
+
+	got := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	want := []string{
+		"[app] app.log one", "[app] app.log two",
+		"[side] side.capture one", "[side] side.capture two",
+	}
+	var app, side []string
+	for _, line := range got {
+		if strings.HasPrefix(line, "[app] ") {
+			app = append(app, line)
+		} else {
+			side = append(side, line)
+		}
+	}
+	if strings.Join(append(app, side...), "|") != strings.Join(want, "|") {
+		t.Errorf("stdout lines = %q, want %q in per-container order", got, want)
+	}
+}
+
+func TestLog_AllContainers_RejectsContainerFlag(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	cmd, _ := newCmdWithCtx(t, &fakeClient{}, nil)
+	cmd.SetArgs([]string{"--all-containers", "--container", "app", "c1"})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Fatalf("err = %v, want mutually exclusive error", err)
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package patch hosts the `kuke patch` parent command and its per-kind
+// subcommands. A patch edits one field of a stored resource without
+// re-applying the whole document: the daemon patches the stored document,
+// re-validates it, and reconciles it through the same path as `kuke apply`.
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/eminwux/kukeon/cmd/config"
+	kukeshared "github.com/eminwux/kukeon/cmd/kuke/shared"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// MockControllerKey is used to inject a mock kukeonv1.Client via context in tests.
+type MockControllerKey struct{}
+
+// scope is which of the --realm/--space/--stack flags a kind reads.
+// Hierarchy kinds (space, stack, cell) need every level above them and
+// default each to "default"; scoped kinds (blueprint, config, volume) bind at
+// whichever level is set. A realm reads none of them.
+type scope int
+
+const (
+	scopeNone scope = iota
+	scopeRealm
+	scopeSpace
+	scopeStack
+	scopeOptional
+)
+
+// kindCmd describes one `kuke patch <kind>` subcommand.
+type kindCmd struct {
+	use       string
+	aliases   []string
+	kind      v1beta1.Kind
+	scope     scope
+	completer func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective)
+}
+
+// NewPatchCmd builds the `kuke patch` parent command and registers one
+// subcommand per patchable kind.
+func NewPatchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "patch",
+		Short: "Update fields of a stored resource with a merge or JSON patch",
+		Long: "Patch loads a resource's stored document, applies a JSON merge patch " +
+			"(--type=merge, the default) or an RFC 6902 JSON patch (--type=json), " +
+			"re-validates the result, and re-applies it like `kuke apply`. The patch " +
+			"may be written as JSON or YAML. A resource's kind, name, scope, and " +
+			"status cannot be patched.",
+		Run: func(cmd *cobra.Command, _ []string) {
+			_ = cmd.Help()
+		},
+	}
+
+	cmd.PersistentFlags().String("type", "merge", "Patch type: merge (RFC 7386) or json (RFC 6902)")
+	_ = viper.BindPFlag(config.KUKE_PATCH_TYPE.ViperKey, cmd.PersistentFlags().Lookup("type"))
+	cmd.PersistentFlags().StringP("patch", "p", "", "The patch to apply, as JSON or YAML")
+	cmd.PersistentFlags().String("realm", "", "Realm that owns the resource")
+	_ = viper.BindPFlag(config.KUKE_PATCH_REALM.ViperKey, cmd.PersistentFlags().Lookup("realm"))
+	cmd.PersistentFlags().String("space", "", "Space that owns the resource (default \"default\" for a stack or cell)")
+	_ = viper.BindPFlag(config.KUKE_PATCH_SPACE.ViperKey, cmd.PersistentFlags().Lookup("space"))
+	cmd.PersistentFlags().String("stack", "", "Stack that owns the resource (default \"default\" for a cell)")
+	_ = viper.BindPFlag(config.KUKE_PATCH_STACK.ViperKey, cmd.PersistentFlags().Lookup("stack"))
+	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
+	_ = cmd.RegisterFlagCompletionFunc("space", config.CompleteSpaceNames)
+	_ = cmd.RegisterFlagCompletionFunc("stack", config.CompleteStackNames)
+	_ = cmd.RegisterFlagCompletionFunc("type", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return []string{string(kukeonv1.PatchTypeMerge), string(kukeonv1.PatchTypeJSON)}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	for _, k := range []kindCmd{
+		{use: "realm", aliases: []string{"r"}, kind: v1beta1.KindRealm, scope: scopeNone, completer: config.CompleteRealmNames},
+		{use: "space", aliases: []string{"sp"}, kind: v1beta1.KindSpace, scope: scopeRealm, completer: config.CompleteSpaceNames},
+		{use: "stack", aliases: []string{"st"}, kind: v1beta1.KindStack, scope: scopeSpace, completer: config.CompleteStackNames},
+		{use: "cell", aliases: []string{"ce"}, kind: v1beta1.KindCell, scope: scopeStack, completer: config.CompleteCellNames},
+		{
+			use: "blueprint", aliases: []string{"bp"}, kind: v1beta1.KindCellBlueprint,
+			scope: scopeOptional, completer: config.CompleteBlueprintNames,
+		},
+		{use: "config", aliases: []string{"cfg"}, kind: v1beta1.KindCellConfig, scope: scopeOptional, completer: config.CompleteConfigNames},
+		{use: "volume", aliases: []string{"vol"}, kind: v1beta1.KindVolume, scope: scopeOptional, completer: config.CompleteVolumeNames},
+	} {
+		cmd.AddCommand(newKindCmd(k))
+	}
+
+	return cmd
+}
+
+func newKindCmd(k kindCmd) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           k.use + " <name>",
+		Aliases:       k.aliases,
+		Short:         fmt.Sprintf("Patch a %s", k.use),
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPatch(cmd, k, strings.TrimSpace(args[0]))
+		},
+	}
+
+	cmd.ValidArgsFunction = k.completer
+
+	return cmd
+}
+
+func runPatch(cmd *cobra.Command, k kindCmd, name string) error {
+	patchType := kukeonv1.PatchType(strings.TrimSpace(viper.GetString(config.KUKE_PATCH_TYPE.ViperKey)))
+	if patchType != kukeonv1.PatchTypeMerge && patchType != kukeonv1.PatchTypeJSON {
+		return fmt.Errorf("%w: --type must be %q or %q, got %q",
+			errdefs.ErrInvalidPatch, kukeonv1.PatchTypeMerge, kukeonv1.PatchTypeJSON, patchType)
+	}
+	raw, _ := cmd.Flags().GetString("patch")
+	patch, err := patchToJSON(raw)
+	if err != nil {
+		return err
+	}
+
+	ref, err := patchRef(k, name)
+	if err != nil {
+		return err
+	}
+
+	client, err := resolveClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	res, err := client.PatchResource(cmd.Context(), ref, patchType, patch)
+	if err != nil {
+		return err
+	}
+	if res.Action == "unchanged" {
+		cmd.Printf("%s %q: unchanged\n", k.use, name)
+		return nil
+	}
+	cmd.Printf("%s %q: patched\n", k.use, name)
+	for _, change := range res.Changes {
+		cmd.Printf("  - %s\n", change)
+	}
+	return nil
+}
+
+// patchRef resolves the scope flags for k. Hierarchy kinds fill an unset
+// space or stack with "default", matching the other verbs; scoped kinds leave
+// them unset so a realm-level object stays addressable.
+func patchRef(k kindCmd, name string) (kukeonv1.PatchRef, error) {
+	ref := kukeonv1.PatchRef{Kind: k.kind, Name: name}
+	if k.scope == scopeNone {
+		return ref, nil
+	}
+	ref.Realm = strings.TrimSpace(viper.GetString(config.KUKE_PATCH_REALM.ViperKey))
+	if ref.Realm == "" {
+		return ref, fmt.Errorf("%w (--realm)", errdefs.ErrRealmNameRequired)
+	}
+	ref.Space = strings.TrimSpace(viper.GetString(config.KUKE_PATCH_SPACE.ViperKey))
+	ref.Stack = strings.TrimSpace(viper.GetString(config.KUKE_PATCH_STACK.ViperKey))
+	switch k.scope {
+	case scopeStack:
+		if ref.Stack == "" {
+			ref.Stack = "default"
+		}
+		fallthrough
+	case scopeSpace:
+		if ref.Space == "" {
+			ref.Space = "default"
+		}
+	case scopeOptional:
+		if ref.Stack != "" && ref.Space == "" {
+			return ref, fmt.Errorf("%w (--space is required with --stack)", errdefs.ErrSpaceNameRequired)
+		}
+	case scopeNone, scopeRealm:
+	}
+	return ref, nil
+}
+
+// patchToJSON accepts a patch written as JSON or YAML and returns it as JSON,
+// the form the daemon applies.
+func patchToJSON(raw string) ([]byte, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, fmt.Errorf("%w: --patch is required", errdefs.ErrInvalidPatch)
+	}
+	if json.Valid([]byte(raw)) {
+		return []byte(raw), nil
+	}
+	var v any
+	if err := yaml.Unmarshal([]byte(raw), &v); err != nil {
+		return nil, fmt.Errorf("%w: --patch is neither JSON nor YAML: %w", errdefs.ErrInvalidPatch, err)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errdefs.ErrInvalidPatch, err)
+	}
+	return out, nil
+}
+
+func resolveClient(cmd *cobra.Command) (kukeonv1.Client, error) {
+	if mockClient, ok := cmd.Context().Value(MockControllerKey{}).(kukeonv1.Client); ok {
+		return mockClient, nil
+	}
+	return kukeshared.DaemonClientFromCmd(cmd)
+}
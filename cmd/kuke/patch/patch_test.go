@@ -0,0 +1,204 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package patch_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/cmd/config"
+	patchpkg "github.com/eminwux/kukeon/cmd/kuke/patch"
+	"github.com/eminwux/kukeon/cmd/types"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+	"github.com/spf13/viper"
+)
+
+func TestNewPatchCmd_RegistersSubcommands(t *testing.T) {
+	cmd := patchpkg.NewPatchCmd()
+	want := map[string]bool{
+		"realm": false, "space": false, "stack": false, "cell": false,
+		"blueprint": false, "config": false, "volume": false,
+	}
+	for _, sub := range cmd.Commands() {
+		if _, ok := want[sub.Name()]; ok {
+			want[sub.Name()] = true
+		}
+	}
+	for name, found := range want {
+		if !found {
+			t.Errorf("expected subcommand %q to be registered", name)
+		}
+	}
+}
+
+func TestPatchCmd(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		setup      func()
+		result     kukeonv1.PatchResourceResult
+		resultErr  error
+		wantRef    kukeonv1.PatchRef
+		wantType   kukeonv1.PatchType
+		wantPatch  string
+		wantErr    string
+		wantOutput string
+	}{
+		{
+			name:       "cell merge patch defaults space and stack",
+			args:       []string{"cell", "web", "--realm", "r1", "-p", `{"metadata":{"labels":{"env":"prod"}}}`},
+			result:     kukeonv1.PatchResourceResult{Action: "updated", Changes: []string{"labels changed"}},
+			wantRef:    kukeonv1.PatchRef{Kind: v1beta1.KindCell, Name: "web", Realm: "r1", Space: "default", Stack: "default"},
+			wantType:   kukeonv1.PatchTypeMerge,
+			wantPatch:  `{"metadata":{"labels":{"env":"prod"}}}`,
+			wantOutput: "cell \"web\": patched\n  - labels changed",
+		},
+		{
+			name: "realm-scoped blueprint json patch",
+			args: []string{
+				"blueprint", "web", "--type", "json",
+				"-p", `[{"op":"replace","path":"/spec/prefix","value":"api"}]`,
+			},
+			setup:      func() { viper.Set(config.KUKE_PATCH_REALM.ViperKey, "r1") },
+			result:     kukeonv1.PatchResourceResult{Action: "updated"},
+			wantRef:    kukeonv1.PatchRef{Kind: v1beta1.KindCellBlueprint, Name: "web", Realm: "r1"},
+			wantType:   kukeonv1.PatchTypeJSON,
+			wantPatch:  `[{"op":"replace","path":"/spec/prefix","value":"api"}]`,
+			wantOutput: `blueprint "web": patched`,
+		},
+		{
+			name:       "yaml patch is sent as json",
+			args:       []string{"volume", "data", "--realm", "r1", "--space", "s1", "-p", "spec:\n  reclaimPolicy: Retain\n"},
+			result:     kukeonv1.PatchResourceResult{Action: "updated"},
+			wantRef:    kukeonv1.PatchRef{Kind: v1beta1.KindVolume, Name: "data", Realm: "r1", Space: "s1"},
+			wantType:   kukeonv1.PatchTypeMerge,
+			wantPatch:  `{"spec":{"reclaimPolicy":"Retain"}}`,
+			wantOutput: `volume "data": patched`,
+		},
+		{
+			name:       "realm takes no scope",
+			args:       []string{"realm", "r1", "-p", `{"spec":{"snapshotter":"native"}}`},
+			result:     kukeonv1.PatchResourceResult{Action: "unchanged"},
+			wantRef:    kukeonv1.PatchRef{Kind: v1beta1.KindRealm, Name: "r1"},
+			wantType:   kukeonv1.PatchTypeMerge,
+			wantPatch:  `{"spec":{"snapshotter":"native"}}`,
+			wantOutput: `realm "r1": unchanged`,
+		},
+		{
+			name:      "immutable field error surfaces",
+			args:      []string{"cell", "web", "--realm", "r1", "-p", `{"spec":{"realmId":"r2"}}`},
+			resultErr: fmt.Errorf("%w: spec.realmId of a Cell cannot be changed by a patch", errdefs.ErrImmutableField),
+			wantRef:   kukeonv1.PatchRef{Kind: v1beta1.KindCell, Name: "web", Realm: "r1", Space: "default", Stack: "default"},
+			wantType:  kukeonv1.PatchTypeMerge,
+			wantPatch: `{"spec":{"realmId":"r2"}}`,
+			wantErr:   "field is immutable",
+		},
+		{
+			name:    "unknown type rejected",
+			args:    []string{"cell", "web", "--realm", "r1", "--type", "strategic", "-p", `{}`},
+			wantErr: "--type must be",
+		},
+		{
+			name:    "missing patch rejected",
+			args:    []string{"cell", "web", "--realm", "r1"},
+			wantErr: "--patch is required",
+		},
+		{
+			name:    "stack without space rejected for scoped kinds",
+			args:    []string{"config", "web", "--realm", "r1", "--stack", "st1", "-p", `{}`},
+			wantErr: "--space is required with --stack",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			viper.Reset()
+			viper.SetDefault(config.KUKE_PATCH_REALM.ViperKey, "default")
+			if tt.setup != nil {
+				tt.setup()
+			}
+
+			fake := &fakeClient{result: tt.result, err: tt.resultErr}
+			cmd := patchpkg.NewPatchCmd()
+			buf := &bytes.Buffer{}
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+			ctx = context.WithValue(ctx, patchpkg.MockControllerKey{}, kukeonv1.Client(fake))
+			cmd.SetContext(ctx)
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.wantRef.Kind != "" {
+				if fake.calls != 1 {
+					t.Fatalf("PatchResource calls = %d, want 1", fake.calls)
+				}
+				if fake.ref != tt.wantRef {
+					t.Errorf("ref = %+v, want %+v", fake.ref, tt.wantRef)
+				}
+				if fake.patchType != tt.wantType {
+					t.Errorf("type = %q, want %q", fake.patchType, tt.wantType)
+				}
+				if string(fake.patch) != tt.wantPatch {
+					t.Errorf("patch = %s, want %s", fake.patch, tt.wantPatch)
+				}
+			} else if fake.calls != 0 {
+				t.Errorf("PatchResource called %d times, want none", fake.calls)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("want err %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.wantOutput) {
+				t.Errorf("output missing %q\nGot:\n%s", tt.wantOutput, buf.String())
+			}
+		})
+	}
+}
+
+type fakeClient struct {
+	kukeonv1.FakeClient
+
+	result kukeonv1.PatchResourceResult
+	err    error
+
+	calls     int
+	ref       kukeonv1.PatchRef
+	patchType kukeonv1.PatchType
+	patch     []byte
+}
+
+func (f *fakeClient) PatchResource(
+	_ context.Context, ref kukeonv1.PatchRef, patchType kukeonv1.PatchType, patch []byte,
+) (kukeonv1.PatchResourceResult, error) {
+	f.calls++
+	f.ref, f.patchType, f.patch = ref, patchType, patch
+	return f.result, f.err
+}
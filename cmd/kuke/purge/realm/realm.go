@@ -67,6 +67,9 @@ func NewRealmCmd() *cobra.Command {
 			if len(result.Purged) > 0 {
 				cmd.Printf("Additional resources purged: %v\n", result.Purged)
 			}
+			if result.BackupPath != "" {
+				cmd.Printf("Metadata backup: %s\n", result.BackupPath)
+			}
 			return nil
 		},
 	}
@@ -0,0 +1,259 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rename hosts the `kuke rename` parent command and its per-kind
+// subcommands. A cell is renamed in place — its metadata subtree moves and the
+// containerd records keep their identity — and must be stopped first. A realm,
+// space, or stack owns name-keyed host resources (containerd namespace,
+// cgroup, CNI network) that cannot be renamed, so those are recreated under the
+// new name and are only accepted while empty.
+package rename
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eminwux/kukeon/cmd/config"
+	kukeshared "github.com/eminwux/kukeon/cmd/kuke/shared"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// MockControllerKey is used to inject a mock kukeonv1.Client via context in tests.
+type MockControllerKey struct{}
+
+// NewRenameCmd builds the `kuke rename` parent command and registers the
+// realm/space/stack/cell subcommands.
+func NewRenameCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rename",
+		Short: "Rename a realm, space, stack, or cell",
+		Run: func(cmd *cobra.Command, _ []string) {
+			_ = cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(
+		newRealmCmd(),
+		newSpaceCmd(),
+		newStackCmd(),
+		newCellCmd(),
+	)
+
+	return cmd
+}
+
+func newRealmCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "realm <name> <new-name>",
+		Aliases:       []string{"r"},
+		Short:         "Rename an empty realm (recreates its containerd namespace)",
+		Args:          cobra.ExactArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, newName := strings.TrimSpace(args[0]), strings.TrimSpace(args[1])
+			doc := v1beta1.RealmDoc{
+				APIVersion: v1beta1.APIVersionV1Beta1,
+				Kind:       v1beta1.KindRealm,
+				Metadata:   v1beta1.RealmMetadata{Name: name},
+			}
+
+			client, err := resolveClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = client.Close() }()
+
+			if _, err = client.RenameRealm(cmd.Context(), doc, newName); err != nil {
+				return err
+			}
+			cmd.Printf("Renamed realm %q to %q\n", name, newName)
+			return nil
+		},
+	}
+
+	cmd.ValidArgsFunction = config.CompleteRealmNames
+
+	return cmd
+}
+
+func newSpaceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "space <name> <new-name>",
+		Aliases:       []string{"sp"},
+		Short:         "Rename an empty space",
+		Args:          cobra.ExactArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, newName := strings.TrimSpace(args[0]), strings.TrimSpace(args[1])
+			realm := strings.TrimSpace(viper.GetString(config.KUKE_RENAME_SPACE_REALM.ViperKey))
+			if realm == "" {
+				return fmt.Errorf("%w (--realm)", errdefs.ErrRealmNameRequired)
+			}
+			doc := v1beta1.SpaceDoc{
+				APIVersion: v1beta1.APIVersionV1Beta1,
+				Kind:       v1beta1.KindSpace,
+				Metadata:   v1beta1.SpaceMetadata{Name: name},
+				Spec:       v1beta1.SpaceSpec{RealmID: realm},
+			}
+
+			client, err := resolveClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = client.Close() }()
+
+			if _, err = client.RenameSpace(cmd.Context(), doc, newName); err != nil {
+				return err
+			}
+			cmd.Printf("Renamed space %q to %q in realm %q\n", name, newName, realm)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("realm", "", "Realm that owns the space")
+	_ = viper.BindPFlag(config.KUKE_RENAME_SPACE_REALM.ViperKey, cmd.Flags().Lookup("realm"))
+
+	cmd.ValidArgsFunction = config.CompleteSpaceNames
+	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
+
+	return cmd
+}
+
+func newStackCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "stack <name> <new-name>",
+		Aliases:       []string{"st"},
+		Short:         "Rename an empty stack",
+		Args:          cobra.ExactArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, newName := strings.TrimSpace(args[0]), strings.TrimSpace(args[1])
+			realm := strings.TrimSpace(viper.GetString(config.KUKE_RENAME_STACK_REALM.ViperKey))
+			space := strings.TrimSpace(viper.GetString(config.KUKE_RENAME_STACK_SPACE.ViperKey))
+			if realm == "" {
+				return fmt.Errorf("%w (--realm)", errdefs.ErrRealmNameRequired)
+			}
+			if space == "" {
+				return fmt.Errorf("%w (--space)", errdefs.ErrSpaceNameRequired)
+			}
+			doc := v1beta1.StackDoc{
+				APIVersion: v1beta1.APIVersionV1Beta1,
+				Kind:       v1beta1.KindStack,
+				Metadata:   v1beta1.StackMetadata{Name: name},
+				Spec:       v1beta1.StackSpec{RealmID: realm, SpaceID: space},
+			}
+
+			client, err := resolveClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = client.Close() }()
+
+			if _, err = client.RenameStack(cmd.Context(), doc, newName); err != nil {
+				return err
+			}
+			cmd.Printf("Renamed stack %q to %q in space %q\n", name, newName, space)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("realm", "", "Realm that owns the stack")
+	_ = viper.BindPFlag(config.KUKE_RENAME_STACK_REALM.ViperKey, cmd.Flags().Lookup("realm"))
+	cmd.Flags().String("space", "", "Space that owns the stack")
+	_ = viper.BindPFlag(config.KUKE_RENAME_STACK_SPACE.ViperKey, cmd.Flags().Lookup("space"))
+
+	cmd.ValidArgsFunction = config.CompleteStackNames
+	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
+	_ = cmd.RegisterFlagCompletionFunc("space", config.CompleteSpaceNames)
+
+	return cmd
+}
+
+func newCellCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "cell <name> <new-name>",
+		Aliases:       []string{"ce"},
+		Short:         "Rename a stopped cell",
+		Args:          cobra.ExactArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, newName := strings.TrimSpace(args[0]), strings.TrimSpace(args[1])
+			realm := strings.TrimSpace(viper.GetString(config.KUKE_RENAME_CELL_REALM.ViperKey))
+			space := strings.TrimSpace(viper.GetString(config.KUKE_RENAME_CELL_SPACE.ViperKey))
+			stack := strings.TrimSpace(viper.GetString(config.KUKE_RENAME_CELL_STACK.ViperKey))
+			if realm == "" {
+				return fmt.Errorf("%w (--realm)", errdefs.ErrRealmNameRequired)
+			}
+			if space == "" {
+				return fmt.Errorf("%w (--space)", errdefs.ErrSpaceNameRequired)
+			}
+			if stack == "" {
+				return fmt.Errorf("%w (--stack)", errdefs.ErrStackNameRequired)
+			}
+			doc := v1beta1.CellDoc{
+				APIVersion: v1beta1.APIVersionV1Beta1,
+				Kind:       v1beta1.KindCell,
+				Metadata:   v1beta1.CellMetadata{Name: name, Labels: map[string]string{}},
+				Spec: v1beta1.CellSpec{
+					RealmID: realm,
+					SpaceID: space,
+					StackID: stack,
+				},
+			}
+
+			client, err := resolveClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = client.Close() }()
+
+			if _, err = client.RenameCell(cmd.Context(), doc, newName); err != nil {
+				return err
+			}
+			cmd.Printf("Renamed cell %q to %q in stack %q\n", name, newName, stack)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("realm", "", "Realm that owns the cell")
+	_ = viper.BindPFlag(config.KUKE_RENAME_CELL_REALM.ViperKey, cmd.Flags().Lookup("realm"))
+	cmd.Flags().String("space", "", "Space that owns the cell")
+	_ = viper.BindPFlag(config.KUKE_RENAME_CELL_SPACE.ViperKey, cmd.Flags().Lookup("space"))
+	cmd.Flags().String("stack", "", "Stack that owns the cell")
+	_ = viper.BindPFlag(config.KUKE_RENAME_CELL_STACK.ViperKey, cmd.Flags().Lookup("stack"))
+
+	cmd.ValidArgsFunction = config.CompleteCellNames
+	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
+	_ = cmd.RegisterFlagCompletionFunc("space", config.CompleteSpaceNames)
+	_ = cmd.RegisterFlagCompletionFunc("stack", config.CompleteStackNames)
+
+	return cmd
+}
+
+func resolveClient(cmd *cobra.Command) (kukeonv1.Client, error) {
+	if mockClient, ok := cmd.Context().Value(MockControllerKey{}).(kukeonv1.Client); ok {
+		return mockClient, nil
+	}
+	return kukeshared.DaemonClientFromCmd(cmd)
+}
@@ -0,0 +1,223 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rename_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/cmd/config"
+	renamepkg "github.com/eminwux/kukeon/cmd/kuke/rename"
+	"github.com/eminwux/kukeon/cmd/types"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+	"github.com/spf13/viper"
+)
+
+func TestNewRenameCmd_RegistersSubcommands(t *testing.T) {
+	cmd := renamepkg.NewRenameCmd()
+	want := map[string]bool{"realm": false, "space": false, "stack": false, "cell": false}
+	for _, sub := range cmd.Commands() {
+		if _, ok := want[sub.Name()]; ok {
+			want[sub.Name()] = true
+		}
+	}
+	for name, found := range want {
+		if !found {
+			t.Errorf("expected subcommand %q to be registered", name)
+		}
+	}
+}
+
+func TestRenameCmd(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		setup      func()
+		fake       *fakeClient
+		wantErr    string
+		wantOutput string
+	}{
+		{
+			name: "cell success",
+			args: []string{"cell", "web", "web2"},
+			setup: func() {
+				viper.Set(config.KUKE_RENAME_CELL_REALM.ViperKey, "r1")
+				viper.Set(config.KUKE_RENAME_CELL_SPACE.ViperKey, "s1")
+				viper.Set(config.KUKE_RENAME_CELL_STACK.ViperKey, "st1")
+			},
+			fake: &fakeClient{
+				renameCellFn: func(doc v1beta1.CellDoc, newName string) (kukeonv1.RenameCellResult, error) {
+					if doc.Metadata.Name != "web" || doc.Spec.StackID != "st1" || newName != "web2" {
+						return kukeonv1.RenameCellResult{}, errors.New("unexpected rename arguments")
+					}
+					return kukeonv1.RenameCellResult{OldName: "web"}, nil
+				},
+			},
+			wantOutput: `Renamed cell "web" to "web2" in stack "st1"`,
+		},
+		{
+			name: "cell running",
+			args: []string{"cell", "web", "web2"},
+			setup: func() {
+				viper.Set(config.KUKE_RENAME_CELL_REALM.ViperKey, "r1")
+				viper.Set(config.KUKE_RENAME_CELL_SPACE.ViperKey, "s1")
+				viper.Set(config.KUKE_RENAME_CELL_STACK.ViperKey, "st1")
+			},
+			fake: &fakeClient{
+				renameCellFn: func(v1beta1.CellDoc, string) (kukeonv1.RenameCellResult, error) {
+					return kukeonv1.RenameCellResult{}, errdefs.ErrRenameCellRunning
+				},
+			},
+			wantErr: "stop it before renaming",
+		},
+		{
+			name:    "cell missing space",
+			args:    []string{"cell", "web", "web2"},
+			setup:   func() { viper.Set(config.KUKE_RENAME_CELL_REALM.ViperKey, "r1") },
+			wantErr: "space name is required",
+		},
+		{
+			name: "stack success",
+			args: []string{"stack", "a", "b"},
+			setup: func() {
+				viper.Set(config.KUKE_RENAME_STACK_REALM.ViperKey, "r1")
+				viper.Set(config.KUKE_RENAME_STACK_SPACE.ViperKey, "s1")
+			},
+			fake: &fakeClient{
+				renameStackFn: func(_ v1beta1.StackDoc, _ string) (kukeonv1.RenameStackResult, error) {
+					return kukeonv1.RenameStackResult{OldName: "a"}, nil
+				},
+			},
+			wantOutput: `Renamed stack "a" to "b" in space "s1"`,
+		},
+		{
+			name: "space success",
+			args: []string{"space", "a", "b"},
+			setup: func() {
+				viper.Set(config.KUKE_RENAME_SPACE_REALM.ViperKey, "r1")
+			},
+			fake: &fakeClient{
+				renameSpaceFn: func(_ v1beta1.SpaceDoc, _ string) (kukeonv1.RenameSpaceResult, error) {
+					return kukeonv1.RenameSpaceResult{OldName: "a"}, nil
+				},
+			},
+			wantOutput: `Renamed space "a" to "b" in realm "r1"`,
+		},
+		{
+			name: "realm success",
+			args: []string{"realm", "a", "b"},
+			fake: &fakeClient{
+				renameRealmFn: func(doc v1beta1.RealmDoc, newName string) (kukeonv1.RenameRealmResult, error) {
+					return kukeonv1.RenameRealmResult{OldName: doc.Metadata.Name}, nil
+				},
+			},
+			wantOutput: `Renamed realm "a" to "b"`,
+		},
+		{
+			name:    "missing new name",
+			args:    []string{"realm", "a"},
+			wantErr: "accepts 2 arg",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			viper.Reset()
+			if tt.setup != nil {
+				tt.setup()
+			}
+
+			cmd := renamepkg.NewRenameCmd()
+			buf := &bytes.Buffer{}
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+			if tt.fake != nil {
+				ctx = context.WithValue(ctx, renamepkg.MockControllerKey{}, kukeonv1.Client(tt.fake))
+			}
+			cmd.SetContext(ctx)
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("want err %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.wantOutput) {
+				t.Errorf("output missing %q\nGot:\n%s", tt.wantOutput, buf.String())
+			}
+		})
+	}
+}
+
+type fakeClient struct {
+	kukeonv1.FakeClient
+
+	renameRealmFn func(doc v1beta1.RealmDoc, newName string) (kukeonv1.RenameRealmResult, error)
+	renameSpaceFn func(doc v1beta1.SpaceDoc, newName string) (kukeonv1.RenameSpaceResult, error)
+	renameStackFn func(doc v1beta1.StackDoc, newName string) (kukeonv1.RenameStackResult, error)
+	renameCellFn  func(doc v1beta1.CellDoc, newName string) (kukeonv1.RenameCellResult, error)
+}
+
+func (f *fakeClient) RenameRealm(
+	_ context.Context, doc v1beta1.RealmDoc, newName string,
+) (kukeonv1.RenameRealmResult, error) {
+	if f.renameRealmFn == nil {
+		return kukeonv1.RenameRealmResult{}, errors.New("unexpected RenameRealm call")
+	}
+	return f.renameRealmFn(doc, newName)
+}
+
+func (f *fakeClient) RenameSpace(
+	_ context.Context, doc v1beta1.SpaceDoc, newName string,
+) (kukeonv1.RenameSpaceResult, error) {
+	if f.renameSpaceFn == nil {
+		return kukeonv1.RenameSpaceResult{}, errors.New("unexpected RenameSpace call")
+	}
+	return f.renameSpaceFn(doc, newName)
+}
+
+func (f *fakeClient) RenameStack(
+	_ context.Context, doc v1beta1.StackDoc, newName string,
+) (kukeonv1.RenameStackResult, error) {
+	if f.renameStackFn == nil {
+		return kukeonv1.RenameStackResult{}, errors.New("unexpected RenameStack call")
+	}
+	return f.renameStackFn(doc, newName)
+}
+
+func (f *fakeClient) RenameCell(
+	_ context.Context, doc v1beta1.CellDoc, newName string,
+) (kukeonv1.RenameCellResult, error) {
+	if f.renameCellFn == nil {
+		return kukeonv1.RenameCellResult{}, errors.New("unexpected RenameCell call")
+	}
+	return f.renameCellFn(doc, newName)
+}
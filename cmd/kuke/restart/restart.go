@@ -17,6 +17,7 @@
 package restart
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -201,31 +202,69 @@ func filterCellsBySelector(cells []v1beta1.CellDoc, selector *getshared.LabelSel
 	return out
 }
 
-// restartOne runs the restart state machine for a single cell described by doc:
-// a Ready cell is stop+start; a Stopped/Exited/Error/Failed/Degraded cell is
-// start (the daemon recovers a Failed/Error/Degraded cell via a recreate-style
-// path, #1268/#1318); a Pending/Unknown cell is refused with a delete pointer.
-// Shared by the positional-name path and the per-match loop in
-// restartBySelector.
+// restartOne restarts the single cell described by doc via RestartCell and
+// reports the outcome. Shared by the positional-name path and the per-match
+// loop in restartBySelector.
 func restartOne(cmd *cobra.Command, client kukeonv1.Client, doc v1beta1.CellDoc) error {
+	startRes, inPlace, err := RestartCell(cmd.Context(), client, doc)
+	if err != nil {
+		return err
+	}
+
+	cellName := startRes.Cell.Metadata.Name
+	if cellName == "" {
+		cellName = doc.Metadata.Name
+	}
+	stackName := startRes.Cell.Spec.StackID
+	if stackName == "" {
+		stackName = doc.Spec.StackID
+	}
+	if inPlace {
+		cmd.Printf("Restarted cell %q from stack %q\n", cellName, stackName)
+	} else {
+		cmd.Printf("Started cell %q from stack %q\n", cellName, stackName)
+	}
+	return nil
+}
+
+// RestartCell runs the restart state machine for a single cell described by
+// doc (name + scope; the daemon resolves the rest from stored metadata): a
+// Ready cell is stop+start; a Stopped/Exited/Error/Failed/Degraded cell is
+// start (the daemon recovers a Failed/Error/Degraded cell via a
+// recreate-style path, #1268/#1318); a Pending/Unknown cell is refused with a
+// delete pointer. inPlace reports whether the cell was bounced (stop+start)
+// rather than started from a down state. Exported so fleet verbs such as
+// `kuke stack restart` reuse the exact per-cell semantics.
+func RestartCell(
+	ctx context.Context,
+	client kukeonv1.Client,
+	doc v1beta1.CellDoc,
+) (kukeonv1.StartCellResult, bool, error) {
 	name := doc.Metadata.Name
 	realm := doc.Spec.RealmID
 	space := doc.Spec.SpaceID
 	stack := doc.Spec.StackID
 
-	pre, err := client.GetCell(cmd.Context(), doc)
+	pre, err := client.GetCell(ctx, doc)
 	if err != nil {
-		return err
+		return kukeonv1.StartCellResult{}, false, err
 	}
 	if !pre.MetadataExists {
-		return fmt.Errorf("%w (cell %q in realm=%q space=%q stack=%q)",
+		return kukeonv1.StartCellResult{}, false, fmt.Errorf("%w (cell %q in realm=%q space=%q stack=%q)",
 			errdefs.ErrCellNotFound, name, realm, space, stack)
 	}
 
 	switch pre.Cell.Status.State {
 	case v1beta1.CellStateReady:
-		// A Ready cell bounces in place: stop then start.
-		return restartInPlace(cmd, client, doc)
+		// A Ready cell bounces in place: stop then start. When the cell is
+		// Config-lineage and Status.OutOfSync = true, the daemon's
+		// controller.StartCell re-materialises from the Config on the start
+		// step so the running cell ends up with the freshly-materialised spec.
+		if _, err = client.StopCell(ctx, doc); err != nil {
+			return kukeonv1.StartCellResult{}, false, err
+		}
+		res, startErr := client.StartCell(ctx, doc)
+		return res, true, startErr
 	case v1beta1.CellStateStopped, v1beta1.CellStateExited, v1beta1.CellStateError,
 		v1beta1.CellStateFailed, v1beta1.CellStateDegraded:
 		// These states restart without a stop-first (#1268): Stopped (operator
@@ -237,69 +276,24 @@ func restartOne(cmd *cobra.Command, client kukeonv1.Client, doc v1beta1.CellDoc)
 		// (#1318) — a live cell whose non-root workload is down/restarting —
 		// recovers the same way: the daemon's StartCell now routes Degraded
 		// through that recreate path, but only if it observes the persisted
-		// Degraded state. restartStopped calls StartCell directly (no stop-first)
-		// so the daemon sees the persisted Error/Failed/Degraded state and picks
-		// the recreate path; an in-place stop-first would flip the cell to a
+		// Degraded state. StartCell is called directly (no stop-first) so the
+		// daemon sees the persisted Error/Failed/Degraded state and picks the
+		// recreate path; an in-place stop-first would flip the cell to a
 		// transient Stopped/Error before StartCell re-reads it, skipping the
 		// recovery and leaving it stuck at a sticky Error N/N.
-		return restartStopped(cmd, client, doc)
+		res, startErr := client.StartCell(ctx, doc)
+		return res, false, startErr
 	case v1beta1.CellStatePending, v1beta1.CellStateUnknown:
 		// Same delete-then-rerun pointer as `kuke run` on a genuinely-
 		// unrecoverable cell. Restart does not reconcile a Pending/Unknown cell
 		// in place; the operator deletes and re-runs.
-		return fmt.Errorf(
+		return kukeonv1.StartCellResult{}, false, fmt.Errorf(
 			"cell %q exists in %s state; delete it with `kuke delete cell %s` before restarting",
 			name, pre.Cell.Status.State.String(), name,
 		)
 	}
-	return fmt.Errorf("cell %q exists in unrecognized state %d", name, pre.Cell.Status.State)
-}
-
-// restartInPlace bounces the cell: stop then start. The CellDoc only needs name
-// + scope — the daemon resolves both verbs from the stored cell metadata. When
-// the cell is Config-lineage and Status.OutOfSync = true, the daemon's
-// controller.StartCell re-materialises from the Config on the start step so
-// the running cell ends up with the freshly-materialised spec.
-func restartInPlace(cmd *cobra.Command, client kukeonv1.Client, doc v1beta1.CellDoc) error {
-	if _, err := client.StopCell(cmd.Context(), doc); err != nil {
-		return err
-	}
-	startRes, err := client.StartCell(cmd.Context(), doc)
-	if err != nil {
-		return err
-	}
-
-	cellName := startRes.Cell.Metadata.Name
-	if cellName == "" {
-		cellName = doc.Metadata.Name
-	}
-	stackName := startRes.Cell.Spec.StackID
-	if stackName == "" {
-		stackName = doc.Spec.StackID
-	}
-	cmd.Printf("Restarted cell %q from stack %q\n", cellName, stackName)
-	return nil
-}
-
-// restartStopped is the already-stopped path: equivalent to
-// `kuke start <name>`. The daemon's controller.StartCell handles the
-// OutOfSync reapply uniformly with restartInPlace's start step.
-func restartStopped(cmd *cobra.Command, client kukeonv1.Client, doc v1beta1.CellDoc) error {
-	startRes, err := client.StartCell(cmd.Context(), doc)
-	if err != nil {
-		return err
-	}
-
-	cellName := startRes.Cell.Metadata.Name
-	if cellName == "" {
-		cellName = doc.Metadata.Name
-	}
-	stackName := startRes.Cell.Spec.StackID
-	if stackName == "" {
-		stackName = doc.Spec.StackID
-	}
-	cmd.Printf("Started cell %q from stack %q\n", cellName, stackName)
-	return nil
+	return kukeonv1.StartCellResult{}, false, fmt.Errorf(
+		"cell %q exists in unrecognized state %d", name, pre.Cell.Status.State)
 }
 
 func resolveClient(cmd *cobra.Command) (kukeonv1.Client, error) {
@@ -0,0 +1,117 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package restore hosts the `kuke restore` command, which writes a metadata
+// backup taken before a realm purge or cascade delete back into the
+// metadata tree.
+package restore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eminwux/kukeon/cmd/config"
+	kukshared "github.com/eminwux/kukeon/cmd/kuke/shared"
+	fsutil "github.com/eminwux/kukeon/internal/util/fs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// MockControllerKey is used to inject a mock kukeonv1.Client via context in tests.
+type MockControllerKey struct{}
+
+// NewRestoreCmd builds the `kuke restore` command.
+func NewRestoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore --from <backup>",
+		Short: "Restore resource metadata from a backup taken before a purge or delete",
+		Long: "Restore writes the metadata held in a backup archive back into the metadata tree. " +
+			"kukeond takes a backup under <run-path>/backups before every realm purge and cascade " +
+			"delete. --from accepts a path or the bare name of an archive in that directory. " +
+			"Files that already exist are left untouched. Only metadata is restored: the realm's " +
+			"namespace, cgroups, networks and containers are recreated by the next reconcile or " +
+			"`kuke start`.",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE:          runRestore,
+	}
+
+	cmd.Flags().String("from", "", "Backup archive to restore (path, or name under <run-path>/backups)")
+
+	return cmd
+}
+
+func runRestore(cmd *cobra.Command, _ []string) error {
+	from, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(from) == "" {
+		return errors.New("from flag is required (use --from <backup>)")
+	}
+
+	archive, err := readBackup(from)
+	if err != nil {
+		return err
+	}
+
+	client, err := resolveClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	result, restoreErr := client.RestoreBackup(cmd.Context(), archive)
+	for _, file := range result.Restored {
+		cmd.Printf("restored %s\n", file)
+	}
+	if restoreErr != nil {
+		return restoreErr
+	}
+	cmd.Printf("Restored %d file(s), skipped %d existing file(s)\n", len(result.Restored), len(result.Skipped))
+	return nil
+}
+
+// readBackup reads the archive at from. A bare file name that does not
+// exist in the working directory is looked up in <run-path>/backups, so
+// the name printed by `kuke purge realm` can be passed as-is.
+func readBackup(from string) ([]byte, error) {
+	data, err := os.ReadFile(from)
+	if err == nil {
+		return data, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) || filepath.Base(from) != from {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+	runPath := viper.GetString(config.KUKEON_ROOT_RUN_PATH.ViperKey)
+	data, backupsErr := os.ReadFile(filepath.Join(fsutil.BackupsDir(runPath), from))
+	if backupsErr != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+	return data, nil
+}
+
+func resolveClient(cmd *cobra.Command) (kukeonv1.Client, error) {
+	if mockClient, ok := cmd.Context().Value(MockControllerKey{}).(kukeonv1.Client); ok {
+		return mockClient, nil
+	}
+	return kukshared.DaemonClientFromCmd(cmd)
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package restore_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/cmd/config"
+	"github.com/eminwux/kukeon/cmd/kuke/restore"
+	"github.com/eminwux/kukeon/cmd/types"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	fsutil "github.com/eminwux/kukeon/internal/util/fs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	"github.com/spf13/viper"
+)
+
+const backupName = "20261017T091500.000Z-r1.tar.gz"
+
+func TestRestoreRunE(t *testing.T) {
+	runPath := t.TempDir()
+	viper.Set(config.KUKEON_ROOT_RUN_PATH.ViperKey, runPath)
+	t.Cleanup(viper.Reset)
+
+	backupsDir := fsutil.BackupsDir(runPath)
+	if err := os.MkdirAll(backupsDir, 0o700); err != nil {
+		t.Fatalf("mkdir backups: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(backupsDir, backupName), []byte("archive"), 0o600); err != nil {
+		t.Fatalf("write backup: %v", err)
+	}
+	explicit := filepath.Join(t.TempDir(), "copy.tar.gz")
+	if err := os.WriteFile(explicit, []byte("archive"), 0o600); err != nil {
+		t.Fatalf("write backup: %v", err)
+	}
+
+	restored := func(archive []byte) (kukeonv1.RestoreBackupResult, error) {
+		if string(archive) != "archive" {
+			return kukeonv1.RestoreBackupResult{}, errors.New("unexpected archive")
+		}
+		return kukeonv1.RestoreBackupResult{
+			Restored: []string{"r1/metadata.json"},
+			Skipped:  []string{"r1/secrets/db.json"},
+		}, nil
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		restoreFn  func([]byte) (kukeonv1.RestoreBackupResult, error)
+		wantErr    string
+		wantOutput []string
+	}{
+		{
+			name:    "no from flag",
+			wantErr: "from flag is required",
+		},
+		{
+			name:       "explicit path",
+			args:       []string{"--from", explicit},
+			restoreFn:  restored,
+			wantOutput: []string{"restored r1/metadata.json", "Restored 1 file(s), skipped 1 existing file(s)"},
+		},
+		{
+			name:       "bare name resolves under run-path backups",
+			args:       []string{"--from", backupName},
+			restoreFn:  restored,
+			wantOutput: []string{"restored r1/metadata.json"},
+		},
+		{
+			name:    "missing backup",
+			args:    []string{"--from", "absent.tar.gz"},
+			wantErr: "failed to read backup",
+		},
+		{
+			name: "invalid archive",
+			args: []string{"--from", explicit},
+			restoreFn: func([]byte) (kukeonv1.RestoreBackupResult, error) {
+				return kukeonv1.RestoreBackupResult{}, errdefs.ErrInvalidBackup
+			},
+			wantErr: "invalid metadata backup",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := restore.NewRestoreCmd()
+			buf := &bytes.Buffer{}
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+			ctx = context.WithValue(ctx, restore.MockControllerKey{}, kukeonv1.Client(&fakeClient{restoreFn: tt.restoreFn}))
+			cmd.SetContext(ctx)
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("want err %q, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q\nGot:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+type fakeClient struct {
+	kukeonv1.FakeClient
+
+	restoreFn func(archive []byte) (kukeonv1.RestoreBackupResult, error)
+}
+
+func (f *fakeClient) RestoreBackup(_ context.Context, archive []byte) (kukeonv1.RestoreBackupResult, error) {
+	if f.restoreFn == nil {
+		return kukeonv1.RestoreBackupResult{}, errors.New("unexpected RestoreBackup call")
+	}
+	return f.restoreFn(archive)
+}
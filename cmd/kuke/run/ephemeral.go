@@ -0,0 +1,97 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+	"github.com/spf13/cobra"
+)
+
+// maxArgsBeforeDash is cobra.MaximumNArgs counted over the arguments before
+// `--` only; everything after it is the container command, not a positional.
+func maxArgsBeforeDash(n int) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+			args = args[:dash]
+		}
+		return cobra.MaximumNArgs(n)(cmd, args)
+	}
+}
+
+// runEphemeral create+start+attaches a `kuke run --rm --image` cell and purges
+// it once the run is over: after the workload ends, after a failed create or
+// attach, and after SIGINT/SIGTERM/SIGHUP. A clean ^]^] detach keeps the cell
+// alive, the same --rm contract attachAndMaybeAutoDelete honors. The purge runs
+// on a context detached from the signal so an interrupt cannot also abort the
+// cleanup it triggered.
+func runEphemeral(cmd *cobra.Command, client kukeonv1.Client, doc v1beta1.CellDoc, flags runFlags) error {
+	parent := cmd.Context()
+	ctx, stop := signal.NotifyContext(parent, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	defer stop()
+	cmd.SetContext(ctx)
+	defer cmd.SetContext(parent)
+
+	keep := false
+	defer func() {
+		if !keep {
+			purgeEphemeralCell(context.WithoutCancel(ctx), cmd, client, doc)
+		}
+	}()
+
+	result, err := client.CreateCell(ctx, doc)
+	if err != nil {
+		return err
+	}
+	if err = printRunResult(cmd, result, flags.output); err != nil {
+		return err
+	}
+	detached, attachErr := attachAfterRun(cmd, client, doc, flags.containerFlag)
+	keep = detached && ctx.Err() == nil
+	return attachErr
+}
+
+// purgeEphemeralCell force-purges doc's cell: containers, cgroup, CNI
+// resources, and metadata. A cell that was never created (the create failed
+// before persisting it) is skipped. Failures are reported, not returned: the
+// run's own outcome is what the caller surfaces.
+func purgeEphemeralCell(ctx context.Context, cmd *cobra.Command, client kukeonv1.Client, doc v1beta1.CellDoc) {
+	pre, err := client.GetCell(ctx, doc)
+	if errors.Is(err, errdefs.ErrCellNotFound) || (err == nil && !pre.MetadataExists) {
+		return
+	}
+
+	res, err := client.PurgeCell(ctx, doc, true, false)
+	switch {
+	case err != nil:
+		fmt.Fprintf(cmd.ErrOrStderr(),
+			"kuke run: --rm cleanup: failed to purge cell %q: %v\n",
+			doc.Metadata.Name, err)
+	case !res.PurgeSucceeded:
+		fmt.Fprintf(cmd.ErrOrStderr(),
+			"kuke run: --rm cleanup: purge of cell %q incomplete: %s\n",
+			doc.Metadata.Name, strings.Join(res.Purged, ", "))
+	}
+}
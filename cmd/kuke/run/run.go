@@ -70,7 +70,7 @@ type MockControllerKey struct{}
 // out of the post-start attach.
 func NewRunCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "run [<cell>]",
+		Use:   "run [<cell>] [-- <command> [args...]]",
 		Short: "Start and attach an existing cell, or create+start+attach a new one (--image/--from-blueprint/--from-config/--clone/-f)",
 		Long: "Start and attach a cell. `kuke run` is the fused docker-model verb: " +
 			"`docker create` -> `kuke create cell`, `docker start` -> `kuke start`, " +
@@ -88,18 +88,22 @@ func NewRunCmd() *cobra.Command {
 			"  - `kuke run --image <ref> [--command <cmd>]` synthesizes a single-container " +
 			"cell from a bare image ref and create+start+attaches it — the quick-start path. " +
 			"The cell name is `--name X` when given, else a generated `<prefix>-<6hex>` " +
-			"derived from the image. The positional is rejected (it names an existing cell).\n" +
+			"derived from the image. The positional is rejected (it names an existing cell). " +
+			"Arguments after `--` replace the container's command: `kuke run --rm --image " +
+			"alpine -- echo hi`.\n" +
 			"  - `kuke run -f <file>` create-or-attaches by metadata.name: a missing cell " +
 			"is created and attached; a Ready cell is attached as a no-op; a Stopped cell " +
 			"is started then attached; a divergent on-disk spec is refused (use `kuke " +
 			"apply -f` to update, or --require-synced to hard-fail); a cell in an error or " +
 			"partial state is refused with a `kuke delete cell <name>` pointer.\n\n" +
 			"--rm best-effort deletes the cell once the workload exits (a clean ^]^] " +
-			"detach keeps it alive). --env KEY=VALUE on the existing-cell and -f paths " +
+			"detach keeps it alive). With --image in attach mode the cell is ephemeral: " +
+			"--rm purges it (containers, cgroup, CNI, metadata) before `kuke run` returns, " +
+			"also when the run fails or is interrupted by SIGINT/SIGTERM/SIGHUP. --env KEY=VALUE on the existing-cell and -f paths " +
 			"injects runtime env into the attachable container at start time (does not " +
 			"persist); on the --from-config / --clone paths it is the persisted per-cell " +
 			"override baked into the materialised CellDoc.",
-		Args:              cobra.MaximumNArgs(1),
+		Args:              maxArgsBeforeDash(1),
 		ValidArgsFunction: config.CompleteCellNames,
 		SilenceUsage:      true,
 		SilenceErrors:     false,
@@ -177,6 +181,11 @@ func NewRunCmd() *cobra.Command {
 		"With --image: override the synthesized container's entrypoint (default /bin/sh). "+
 			"Only valid with --image.")
 	_ = viper.BindPFlag(config.KUKE_RUN_COMMAND.ViperKey, cmd.Flags().Lookup("command"))
+	cmd.Flags().BoolP("interactive", "i", false,
+		"With --image -d: keep the synthesized container's stdin open (spec.openStdin) so a "+
+			"workload reading stdin blocks instead of seeing EOF. The container is not attachable; "+
+			"its output goes to `kuke log`.")
+	_ = viper.BindPFlag(config.KUKE_RUN_INTERACTIVE.ViperKey, cmd.Flags().Lookup("interactive"))
 
 	cmd.Flags().Bool("rm", false,
 		"Best-effort delete the cell after it is no longer needed "+
@@ -243,6 +252,12 @@ type runFlags struct {
 	// command is `--command`: overrides the synthesized container's entrypoint.
 	// Only valid with --image.
 	command string
+	// commandArgs are the arguments after `--`: the synthesized container's
+	// command and its args. Only valid with --image; exclusive with --command.
+	commandArgs []string
+	// interactive is `-i/--interactive`: the synthesized container keeps its
+	// stdin open instead of being attachable. Only valid with --image -d.
+	interactive bool
 
 	output        string
 	detach        bool
@@ -313,6 +328,7 @@ func parseRunFlags(cmd *cobra.Command, args []string) (runFlags, error) {
 		requireSynced: viper.GetBool(config.KUKE_RUN_REQUIRE_SYNCED.ViperKey),
 		image:         strings.TrimSpace(viper.GetString(config.KUKE_RUN_IMAGE.ViperKey)),
 		command:       strings.TrimSpace(viper.GetString(config.KUKE_RUN_COMMAND.ViperKey)),
+		interactive:   viper.GetBool(config.KUKE_RUN_INTERACTIVE.ViperKey),
 	}
 
 	// The fused-source flags share their definitions with `kuke create cell`
@@ -341,6 +357,10 @@ func parseRunFlags(cmd *cobra.Command, args []string) (runFlags, error) {
 	}
 	flags.ignoreDiskPressure = idp
 
+	if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+		flags.commandArgs = args[dash:]
+		args = args[:dash]
+	}
 	if len(args) == 1 {
 		flags.cellName = strings.TrimSpace(args[0])
 	}
@@ -412,6 +432,24 @@ func validateSourceFlagCompat(flags runFlags) error {
 	if flags.command != "" && flags.image == "" {
 		return errors.New("--command is only valid with --image")
 	}
+	// -i turns the synthesized container into a detached stdin reader, so it
+	// needs both the --image source and -d (attach mode owns stdin itself).
+	if flags.interactive {
+		if flags.image == "" {
+			return errors.New("-i/--interactive is only valid with --image")
+		}
+		if !flags.detach {
+			return errors.New("-i/--interactive requires -d/--detach (attach mode already wires stdin to the terminal)")
+		}
+	}
+	if len(flags.commandArgs) > 0 {
+		if flags.image == "" {
+			return errors.New("a command after `--` is only valid with --image")
+		}
+		if flags.command != "" {
+			return errors.New("--command and a command after `--` are mutually exclusive")
+		}
+	}
 	if flags.fused() {
 		return nil
 	}
@@ -475,10 +513,21 @@ func runRun(cmd *cobra.Command, args []string) error {
 // <prefix>-<6hex> name is probed free), CreateCell create+starts, and the
 // default attach mode drops the operator into the cell.
 func runFromImage(cmd *cobra.Command, client kukeonv1.Client, flags runFlags) error {
-	cellDoc, err := cell.SynthesizeFromImage(flags.image, flags.command)
+	command := flags.command
+	if len(flags.commandArgs) > 0 {
+		command = flags.commandArgs[0]
+	}
+	cellDoc, err := cell.SynthesizeFromImage(flags.image, command)
 	if err != nil {
 		return err
 	}
+	if len(flags.commandArgs) > 1 {
+		cellDoc.Spec.Containers[0].Args = flags.commandArgs[1:]
+	}
+	if flags.interactive {
+		cellDoc.Spec.Containers[0].Attachable = false
+		cellDoc.Spec.Containers[0].OpenStdin = true
+	}
 	resolveCellLocation(&cellDoc)
 
 	name, err := kukshared.ResolveCellName(
@@ -511,6 +560,12 @@ func runFromImage(cmd *cobra.Command, client kukeonv1.Client, flags runFlags) er
 		return getErr
 	}
 
+	// --rm in attach mode makes the cell ephemeral: it is purged before run
+	// returns rather than left to the reconciler.
+	if flags.autoDelete && !flags.detach {
+		return runEphemeral(cmd, client, cellDoc, flags)
+	}
+
 	result, err := client.CreateCell(cmd.Context(), cellDoc)
 	if err != nil {
 		return err
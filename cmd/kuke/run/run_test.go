@@ -137,6 +137,7 @@ type fakeClient struct {
 	startCellFn       func(doc v1beta1.CellDoc) (kukeonv1.StartCellResult, error)
 	attachContainerFn func(doc v1beta1.ContainerDoc) (kukeonv1.AttachContainerResult, error)
 	killCellFn        func(doc v1beta1.CellDoc) (kukeonv1.KillCellResult, error)
+	purgeCellFn       func(doc v1beta1.CellDoc, force, cascade bool) (kukeonv1.PurgeCellResult, error)
 	getBlueprintFn    func(doc v1beta1.CellBlueprintDoc) (kukeonv1.GetBlueprintResult, error)
 	getConfigFn       func(doc v1beta1.CellConfigDoc) (kukeonv1.GetConfigResult, error)
 	listConfigsFn     func(realm, space, stack string) ([]v1beta1.CellConfigDoc, error)
@@ -147,12 +148,14 @@ type fakeClient struct {
 	startCalls        int
 	attachCalls       int
 	killCalls         int
+	purgeCalls        int
 	createConfigCalls int
 	createConfigDocs  []v1beta1.CellConfigDoc
 	createDoc         v1beta1.CellDoc
 	startDoc          v1beta1.CellDoc
 	attachDoc         v1beta1.ContainerDoc
 	killDoc           v1beta1.CellDoc
+	purgeDoc          v1beta1.CellDoc
 }
 
 func (f *fakeClient) GetCell(_ context.Context, doc v1beta1.CellDoc) (kukeonv1.GetCellResult, error) {
@@ -202,6 +205,19 @@ func (f *fakeClient) KillCell(_ context.Context, doc v1beta1.CellDoc) (kukeonv1.
 	return f.killCellFn(doc)
 }
 
+func (f *fakeClient) PurgeCell(
+	_ context.Context,
+	doc v1beta1.CellDoc,
+	force, cascade bool,
+) (kukeonv1.PurgeCellResult, error) {
+	f.purgeCalls++
+	f.purgeDoc = doc
+	if f.purgeCellFn == nil {
+		return kukeonv1.PurgeCellResult{}, errors.New("unexpected PurgeCell call")
+	}
+	return f.purgeCellFn(doc, force, cascade)
+}
+
 func (f *fakeClient) GetBlueprint(
 	_ context.Context,
 	doc v1beta1.CellBlueprintDoc,
@@ -4120,6 +4136,59 @@ func TestRun_FromImage_CommandOverride(t *testing.T) {
 	}
 }
 
+// TestRun_FromImage_Interactive: -i with --image -d synthesizes a detached
+// stdin reader — openStdin set, attachable cleared.
+func TestRun_FromImage_Interactive(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	fc := &fakeClient{
+		createCellFn: func(doc v1beta1.CellDoc) (kukeonv1.CreateCellResult, error) {
+			return imageCreateResult(doc), nil
+		},
+	}
+	cmd, _ := newCmd(t, fc)
+	cmd.SetArgs([]string{"--image", "docker.io/library/alpine:3", "-i", "-d", "--", "cat"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	c := fc.createDoc.Spec.Containers[0]
+	if !c.OpenStdin || c.Attachable {
+		t.Errorf("openStdin=%v attachable=%v want openStdin and not attachable", c.OpenStdin, c.Attachable)
+	}
+	if c.Command != "cat" {
+		t.Errorf("command=%q want cat", c.Command)
+	}
+}
+
+// TestRun_Interactive_Rejected: -i needs the --image source and -d.
+func TestRun_Interactive_Rejected(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"without image", []string{"mycell", "-i", "-d"}, "-i/--interactive is only valid with --image"},
+		{"attach mode", []string{"--image", "alpine", "-i"}, "-i/--interactive requires -d/--detach"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+
+			fc := &fakeClient{}
+			cmd, _ := newCmd(t, fc)
+			cmd.SetArgs(tc.args)
+
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("err=%v want %q", err, tc.want)
+			}
+			if fc.createCalls != 0 {
+				t.Errorf("CreateCell calls=%d want 0", fc.createCalls)
+			}
+		})
+	}
+}
+
 // TestRun_Command_RejectedWithoutImage: --command is meaningful only with
 // --image; combined with any other source it is rejected rather than dropped.
 func TestRun_Command_RejectedWithoutImage(t *testing.T) {
@@ -4186,3 +4255,210 @@ func TestRun_RemovedFlags_Error(t *testing.T) {
 		})
 	}
 }
+
+// cellStore is a stateful stand-in for the daemon's cell metadata: CreateCell
+// persists, GetCell reports presence, PurgeCell removes.
+type cellStore map[string]bool
+
+func (st cellStore) wire(fc *fakeClient) {
+	fc.getCellFn = func(doc v1beta1.CellDoc) (kukeonv1.GetCellResult, error) {
+		if !st[doc.Metadata.Name] {
+			return kukeonv1.GetCellResult{}, errdefs.ErrCellNotFound
+		}
+		return kukeonv1.GetCellResult{Cell: doc, MetadataExists: true}, nil
+	}
+	fc.purgeCellFn = func(doc v1beta1.CellDoc, _, _ bool) (kukeonv1.PurgeCellResult, error) {
+		delete(st, doc.Metadata.Name)
+		return kukeonv1.PurgeCellResult{Cell: doc, MetadataDeleted: true, PurgeSucceeded: true}, nil
+	}
+}
+
+// TestRun_FromImageRm_PurgesCellAfterExit: `kuke run --rm --image` in attach
+// mode is ephemeral — once the workload ends the cell is purged before run
+// returns, leaving no metadata behind (rather than KillCell + reconciler).
+func TestRun_FromImageRm_PurgesCellAfterExit(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	store := cellStore{}
+	fc := &fakeClient{
+		createCellFn: func(doc v1beta1.CellDoc) (kukeonv1.CreateCellResult, error) {
+			store[doc.Metadata.Name] = true
+			return imageCreateResult(doc), nil
+		},
+		attachContainerFn: attachSuccessFn(),
+	}
+	store.wire(fc)
+	var force bool
+	purge := fc.purgeCellFn
+	fc.purgeCellFn = func(doc v1beta1.CellDoc, f, c bool) (kukeonv1.PurgeCellResult, error) {
+		force = f
+		return purge(doc, f, c)
+	}
+	run := &runErrorCapture{err: fmt.Errorf("wrapped by harness: %w", sbshattach.ErrPeerClosed)}
+	cmd, _ := newCmdWithRunFn(t, fc, run.fn)
+	cmd.SetArgs([]string{"--rm", "--image", "docker.io/library/alpine:3", "--", "echo", "hi"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if fc.createCalls != 1 || run.calls != 1 {
+		t.Fatalf("CreateCell=%d attach=%d want 1/1", fc.createCalls, run.calls)
+	}
+	if len(store) != 0 {
+		t.Errorf("cell metadata still present after run: %v", store)
+	}
+	if fc.purgeCalls != 1 || fc.purgeDoc.Metadata.Name != fc.createDoc.Metadata.Name {
+		t.Errorf("PurgeCell calls=%d target=%q want 1 call on %q",
+			fc.purgeCalls, fc.purgeDoc.Metadata.Name, fc.createDoc.Metadata.Name)
+	}
+	if !force {
+		t.Error("PurgeCell force=false want true (the workload may still be running)")
+	}
+	if fc.killCalls != 0 {
+		t.Errorf("KillCell calls=%d want 0 (the purge replaces the kill)", fc.killCalls)
+	}
+}
+
+// TestRun_FromImageRm_PurgesOnFailure: the purge also runs when the run fails,
+// whether the attach loop errors or the create fails after persisting the cell.
+func TestRun_FromImageRm_PurgesOnFailure(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		createErr error
+		attachErr error
+	}{
+		{name: "attach error", attachErr: errors.New("control socket lost")},
+		{name: "create error", createErr: errors.New("start failed")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+
+			store := cellStore{}
+			fc := &fakeClient{
+				createCellFn: func(doc v1beta1.CellDoc) (kukeonv1.CreateCellResult, error) {
+					store[doc.Metadata.Name] = true
+					if tc.createErr != nil {
+						return kukeonv1.CreateCellResult{}, tc.createErr
+					}
+					return imageCreateResult(doc), nil
+				},
+				attachContainerFn: attachSuccessFn(),
+			}
+			store.wire(fc)
+			run := &runErrorCapture{err: tc.attachErr}
+			cmd, _ := newCmdWithRunFn(t, fc, run.fn)
+			cmd.SetArgs([]string{"--rm", "--image", "docker.io/library/alpine:3"})
+
+			if err := cmd.Execute(); err == nil {
+				t.Fatal("Execute err=nil want the run failure surfaced")
+			}
+			if len(store) != 0 {
+				t.Errorf("cell metadata still present after a failed run: %v", store)
+			}
+			if fc.purgeCalls != 1 {
+				t.Errorf("PurgeCell calls=%d want 1", fc.purgeCalls)
+			}
+		})
+	}
+}
+
+// TestRun_FromImageRm_NeverCreated_SkipsPurge: a create that fails before the
+// cell is persisted leaves nothing to purge.
+func TestRun_FromImageRm_NeverCreated_SkipsPurge(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	store := cellStore{}
+	fc := &fakeClient{
+		createCellFn: func(_ v1beta1.CellDoc) (kukeonv1.CreateCellResult, error) {
+			return kukeonv1.CreateCellResult{}, errors.New("image pull failed")
+		},
+	}
+	store.wire(fc)
+	cmd, _ := newCmd(t, fc)
+	cmd.SetArgs([]string{"--rm", "--image", "docker.io/library/alpine:3"})
+
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "image pull failed") {
+		t.Fatalf("err=%v want the create failure", err)
+	}
+	if fc.purgeCalls != 0 {
+		t.Errorf("PurgeCell calls=%d want 0 (nothing was created)", fc.purgeCalls)
+	}
+}
+
+// TestRun_FromImageRm_CleanDetachKeepsCell: a clean ^]^] detach keeps the
+// ephemeral cell alive for re-attach, the same --rm contract as the other paths.
+func TestRun_FromImageRm_CleanDetachKeepsCell(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	store := cellStore{}
+	fc := &fakeClient{
+		createCellFn: func(doc v1beta1.CellDoc) (kukeonv1.CreateCellResult, error) {
+			store[doc.Metadata.Name] = true
+			return imageCreateResult(doc), nil
+		},
+		attachContainerFn: attachSuccessFn(),
+	}
+	store.wire(fc)
+	run := &runErrorCapture{err: fmt.Errorf("wrapped by harness: %w", sbshattach.ErrDetached)}
+	cmd, _ := newCmdWithRunFn(t, fc, run.fn)
+	cmd.SetArgs([]string{"--rm", "--image", "docker.io/library/alpine:3"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if fc.purgeCalls != 0 || len(store) != 1 {
+		t.Errorf("PurgeCell calls=%d store=%v want the cell kept after a clean detach", fc.purgeCalls, store)
+	}
+}
+
+// TestRun_FromImage_TrailingCommand: arguments after `--` become the
+// synthesized container's command and args.
+func TestRun_FromImage_TrailingCommand(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	fc := &fakeClient{
+		createCellFn: func(doc v1beta1.CellDoc) (kukeonv1.CreateCellResult, error) {
+			return imageCreateResult(doc), nil
+		},
+	}
+	cmd, _ := newCmd(t, fc)
+	cmd.SetArgs([]string{"--image", "docker.io/library/alpine:3", "-d", "--", "echo", "hello", "world"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	c := fc.createDoc.Spec.Containers[0]
+	if c.Command != "echo" || !reflect.DeepEqual(c.Args, []string{"hello", "world"}) {
+		t.Errorf("command=%q args=%v want echo [hello world]", c.Command, c.Args)
+	}
+}
+
+// TestRun_TrailingCommand_Rejected: a command after `--` needs --image and
+// cannot be combined with --command.
+func TestRun_TrailingCommand_Rejected(t *testing.T) {
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"mycell", "-d", "--", "echo"}, want: "only valid with --image"},
+		{
+			args: []string{"--image", "alpine", "--command", "/bin/sh", "-d", "--", "echo"},
+			want: "mutually exclusive",
+		},
+	} {
+		t.Run(strings.Join(tc.args, "_"), func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			fc := &fakeClient{}
+			cmd, _ := newCmd(t, fc)
+			cmd.SetArgs(tc.args)
+
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("err=%v want %q", err, tc.want)
+			}
+			if fc.createCalls != 0 {
+				t.Errorf("CreateCell calls=%d want 0", fc.createCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	getshared "github.com/eminwux/kukeon/cmd/kuke/get/shared"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+	"github.com/spf13/cobra"
+)
+
+// CellBatchParallelFlagName is the long flag name for the batch concurrency
+// bound on `kuke stop/start/kill`.
+const CellBatchParallelFlagName = "parallel"
+
+// DefaultCellBatchParallel is how many cells a batch verb acts on at once
+// when --parallel is not given.
+const DefaultCellBatchParallel = 4
+
+// CellRef names one cell by its full realm/space/stack coordinate.
+type CellRef struct {
+	Name  string
+	Realm string
+	Space string
+	Stack string
+}
+
+// Doc returns the minimal CellDoc the per-cell lifecycle calls take.
+func (r CellRef) Doc() v1beta1.CellDoc {
+	return v1beta1.CellDoc{
+		APIVersion: v1beta1.APIVersionV1Beta1,
+		Kind:       v1beta1.KindCell,
+		Metadata:   v1beta1.CellMetadata{Name: r.Name, Labels: map[string]string{}},
+		Spec: v1beta1.CellSpec{
+			ID:      r.Name,
+			RealmID: r.Realm,
+			SpaceID: r.Space,
+			StackID: r.Stack,
+		},
+	}
+}
+
+// CellBatchOutcome is the per-cell result of a batch verb.
+type CellBatchOutcome struct {
+	Ref CellRef
+	Err error
+}
+
+// RegisterCellBatchFlags adds `-l`/`--selector` and `--parallel` to a
+// lifecycle verb that accepts several cells.
+func RegisterCellBatchFlags(cmd *cobra.Command) {
+	getshared.RegisterLabelSelectorFlag(cmd)
+	cmd.Flags().Int(CellBatchParallelFlagName, DefaultCellBatchParallel,
+		"Maximum number of cells acted on at once when several are named or selected")
+}
+
+// ParseCellBatchParallel reads `--parallel` from cmd, refusing values below 1.
+func ParseCellBatchParallel(cmd *cobra.Command) (int, error) {
+	parallel, _ := cmd.Flags().GetInt(CellBatchParallelFlagName)
+	if parallel < 1 {
+		return 0, fmt.Errorf("%w: %d", errdefs.ErrInvalidBatchParallel, parallel)
+	}
+	return parallel, nil
+}
+
+// SelectCells lists the cells under the realm/space/stack filters (empty
+// means no filter) and returns a ref for each whose labels match selector.
+// Each ref carries the matched cell's own scope.
+func SelectCells(
+	ctx context.Context,
+	client kukeonv1.Client,
+	selector *getshared.LabelSelector,
+	realm, space, stack string,
+) ([]CellRef, error) {
+	cells, err := client.ListCells(ctx, realm, space, stack)
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]CellRef, 0, len(cells))
+	for i := range cells {
+		c := &cells[i]
+		if !selector.Matches(c.Metadata.Labels) {
+			continue
+		}
+		refs = append(refs, CellRef{
+			Name:  c.Metadata.Name,
+			Realm: c.Spec.RealmID,
+			Space: c.Spec.SpaceID,
+			Stack: c.Spec.StackID,
+		})
+	}
+	return refs, nil
+}
+
+// RunCellBatch calls fn for every ref with at most parallel calls in
+// flight and waits for all of them. A failure does not stop the remaining
+// cells. Outcomes keep the order of refs.
+func RunCellBatch(
+	ctx context.Context,
+	refs []CellRef,
+	parallel int,
+	fn func(context.Context, v1beta1.CellDoc) error,
+) []CellBatchOutcome {
+	out := make([]CellBatchOutcome, len(refs))
+	sem := make(chan struct{}, max(parallel, 1))
+	var wg sync.WaitGroup
+	for i := range refs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			out[i] = CellBatchOutcome{Ref: refs[i], Err: fn(ctx, refs[i].Doc())}
+		}(i)
+	}
+	wg.Wait()
+	return out
+}
+
+// StopCells stops every ref through the per-cell StopCell path.
+func StopCells(ctx context.Context, client kukeonv1.Client, refs []CellRef, parallel int) []CellBatchOutcome {
+	return RunCellBatch(ctx, refs, parallel, func(ctx context.Context, doc v1beta1.CellDoc) error {
+		_, err := client.StopCell(ctx, doc)
+		return err
+	})
+}
+
+// StartCells starts every ref through the per-cell StartCell path.
+func StartCells(ctx context.Context, client kukeonv1.Client, refs []CellRef, parallel int) []CellBatchOutcome {
+	return RunCellBatch(ctx, refs, parallel, func(ctx context.Context, doc v1beta1.CellDoc) error {
+		_, err := client.StartCell(ctx, doc)
+		return err
+	})
+}
+
+// KillCells kills every ref through the per-cell KillCell path.
+func KillCells(ctx context.Context, client kukeonv1.Client, refs []CellRef, parallel int) []CellBatchOutcome {
+	return RunCellBatch(ctx, refs, parallel, func(ctx context.Context, doc v1beta1.CellDoc) error {
+		_, err := client.KillCell(ctx, doc)
+		return err
+	})
+}
+
+// PrintCellBatch renders one summary row per outcome followed by a count
+// line, e.g. "Stopped 2/3 cells". done is the past-tense verb shown for a
+// successful cell. The returned error joins the per-cell failures, so the
+// command exits non-zero when any cell failed.
+func PrintCellBatch(cmd *cobra.Command, done string, outcomes []CellBatchOutcome) error {
+	headers := []string{"NAME", "REALM", "SPACE", "STACK", "RESULT", "ERROR"}
+	rows := make([][]string, 0, len(outcomes))
+	var errs []error
+	for _, o := range outcomes {
+		result, msg := done, "-"
+		if o.Err != nil {
+			result, msg = "Failed", o.Err.Error()
+			errs = append(errs, fmt.Errorf("cell %q: %w", o.Ref.Name, o.Err))
+		}
+		rows = append(rows, []string{o.Ref.Name, o.Ref.Realm, o.Ref.Space, o.Ref.Stack, result, msg})
+	}
+	getshared.PrintTable(cmd, headers, rows)
+	cmd.Printf("%s %d/%d cells\n", done, len(outcomes)-len(errs), len(outcomes))
+	return errors.Join(errs...)
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+// TestRunCellBatch pins the batch engine: every ref runs despite a failure,
+// outcomes keep input order, and no more than parallel calls are in flight.
+func TestRunCellBatch(t *testing.T) {
+	refs := []CellRef{
+		{Name: "a", Realm: "r"}, {Name: "b", Realm: "r"}, {Name: "c", Realm: "r"},
+		{Name: "d", Realm: "r"}, {Name: "e", Realm: "r"},
+	}
+	boom := errors.New("boom")
+	var inFlight, peak atomic.Int32
+	outcomes := RunCellBatch(context.Background(), refs, 2, func(_ context.Context, doc v1beta1.CellDoc) error {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		if doc.Metadata.Name == "b" {
+			return boom
+		}
+		return nil
+	})
+
+	if got := peak.Load(); got > 2 {
+		t.Errorf("peak in-flight calls = %d, want at most 2", got)
+	}
+	if len(outcomes) != len(refs) {
+		t.Fatalf("got %d outcomes, want %d", len(outcomes), len(refs))
+	}
+	for i, o := range outcomes {
+		if o.Ref != refs[i] {
+			t.Errorf("outcomes[%d].Ref = %+v, want %+v", i, o.Ref, refs[i])
+		}
+		if wantErr := o.Ref.Name == "b"; (o.Err != nil) != wantErr {
+			t.Errorf("outcomes[%d].Err = %v, want failure only for b", i, o.Err)
+		}
+	}
+}
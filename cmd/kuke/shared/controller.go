@@ -47,8 +47,9 @@ func ControllerFromCmd(cmd *cobra.Command) (*controller.Exec, error) {
 	}
 
 	opts := controller.Options{
-		RunPath:          viper.GetString(config.KUKEON_ROOT_RUN_PATH.ViperKey),
-		ContainerdSocket: viper.GetString(config.KUKEON_ROOT_CONTAINERD_SOCKET.ViperKey),
+		RunPath:           viper.GetString(config.KUKEON_ROOT_RUN_PATH.ViperKey),
+		ContainerdSocket:  viper.GetString(config.KUKEON_ROOT_CONTAINERD_SOCKET.ViperKey),
+		ContainerdTimeout: viper.GetDuration(config.KUKEON_ROOT_CONTAINERD_TIMEOUT.ViperKey),
 	}
 
 	return controller.NewControllerExec(cmd.Context(), logger, opts), nil
@@ -118,8 +119,9 @@ func ClientFromCmd(cmd *cobra.Command) (kukeonv1.Client, error) {
 			return nil, err
 		}
 		return local.New(cmd.Context(), logger, controller.Options{
-			RunPath:          viper.GetString(config.KUKEON_ROOT_RUN_PATH.ViperKey),
-			ContainerdSocket: viper.GetString(config.KUKEON_ROOT_CONTAINERD_SOCKET.ViperKey),
+			RunPath:           viper.GetString(config.KUKEON_ROOT_RUN_PATH.ViperKey),
+			ContainerdSocket:  viper.GetString(config.KUKEON_ROOT_CONTAINERD_SOCKET.ViperKey),
+			ContainerdTimeout: viper.GetDuration(config.KUKEON_ROOT_CONTAINERD_TIMEOUT.ViperKey),
 		}), nil
 	}
 
@@ -17,9 +17,13 @@
 package shared
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/eminwux/kukeon/internal/apply/parser"
@@ -41,6 +45,98 @@ func ReadFileOrStdin(file string) (io.Reader, func() error, error) {
 	return f, f.Close, nil
 }
 
+// manifestExts are the file extensions a directory passed to ReadManifests
+// contributes; anything else in it (READMEs, editor backups) is skipped.
+var manifestExts = []string{".yaml", ".yml", ".json"}
+
+// ReadManifests reads every source into one multi-document stream. A source
+// is "-" for stdin, a file, a directory (its .yaml/.yml/.json files, not
+// recursive), or a glob pattern; directory entries and glob matches are read
+// in lexical order. Each file is separated from the next by `---`, so the
+// result parses like a single multi-document file.
+func ReadManifests(sources []string) ([]byte, error) {
+	var files []string
+	for _, src := range sources {
+		expanded, err := expandManifestSource(src)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, expanded...)
+	}
+	stdin := 0
+	for _, file := range files {
+		if file == "-" {
+			stdin++
+		}
+	}
+	if stdin > 1 {
+		return nil, errors.New("stdin (-) can only be read once")
+	}
+
+	var out bytes.Buffer
+	for i, file := range files {
+		reader, cleanup, err := ReadFileOrStdin(file)
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(reader)
+		_ = cleanup()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %q: %w", file, err)
+		}
+		if i > 0 {
+			out.WriteString("\n---\n")
+		}
+		out.Write(data)
+	}
+	return out.Bytes(), nil
+}
+
+func expandManifestSource(src string) ([]string, error) {
+	if src == "-" {
+		return []string{src}, nil
+	}
+	info, statErr := os.Stat(src)
+	switch {
+	case statErr == nil && info.IsDir():
+		entries, err := os.ReadDir(src)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read directory %q: %w", src, err)
+		}
+		var files []string
+		for _, entry := range entries {
+			if entry.Type().IsRegular() && slices.Contains(manifestExts, filepath.Ext(entry.Name())) {
+				files = append(files, filepath.Join(src, entry.Name()))
+			}
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("directory %q has no %s files", src, strings.Join(manifestExts, "/"))
+		}
+		return files, nil
+	case statErr == nil:
+		return []string{src}, nil
+	}
+
+	matches, err := filepath.Glob(src)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file pattern %q: %w", src, err)
+	}
+	if len(matches) == 0 && !strings.ContainsAny(src, "*?[") {
+		// Not a pattern either: surface the open error for the path.
+		return []string{src}, nil
+	}
+	var files []string
+	for _, match := range matches {
+		if info, err = os.Stat(match); err == nil && !info.IsDir() {
+			files = append(files, match)
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("pattern %q matches no files", src)
+	}
+	return files, nil
+}
+
 // ParseAndValidateDocuments parses and validates YAML documents from a reader.
 // Returns the parsed documents and any validation errors encountered.
 // If there are validation errors, they are returned as a slice, but the function
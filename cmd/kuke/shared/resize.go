@@ -0,0 +1,97 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"golang.org/x/term"
+)
+
+// TerminalSize reports the local terminal's size in columns and rows. An
+// error means there is no terminal to size.
+type TerminalSize func() (width, height uint32, err error)
+
+// ResizeFunc applies a terminal size to the remote side of a session — a
+// relay pty, or a containerd task via ctr.Client.ResizeProcess.
+type ResizeFunc func(width, height uint32) error
+
+// TerminalSizeOf returns a TerminalSize that queries f.
+func TerminalSizeOf(f *os.File) TerminalSize {
+	return func() (uint32, uint32, error) {
+		width, height, err := term.GetSize(int(f.Fd()))
+		if err != nil {
+			return 0, 0, err
+		}
+		return uint32(max(width, 0)), uint32(max(height, 0)), nil
+	}
+}
+
+// WatchResize forwards the local terminal's size to resize: once up front,
+// then on every SIGWINCH that changes it. The returned func stops the
+// watcher. See ForwardResize.
+func WatchResize(size TerminalSize, resize ResizeFunc) func() {
+	winch := make(chan os.Signal, 1)
+	signal.Notify(winch, syscall.SIGWINCH)
+	stop := ForwardResize(winch, size, resize)
+	return func() {
+		signal.Stop(winch)
+		stop()
+	}
+}
+
+// ForwardResize is WatchResize with the signal source supplied by the
+// caller. The initial resize happens before it returns, so the remote side
+// starts at the right size. When size fails up front the session has no
+// terminal: nothing is resized and the returned func is a no-op. Later size
+// or resize failures are dropped — a missed resize is cosmetic and the next
+// signal retries it. The returned func blocks until the watcher has exited and is safe to
+// call more than once.
+func ForwardResize(winch <-chan os.Signal, size TerminalSize, resize ResizeFunc) func() {
+	width, height, err := size()
+	if err != nil {
+		return func() {}
+	}
+	_ = resize(width, height)
+
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		for {
+			select {
+			case <-done:
+				return
+			case <-winch:
+				w, h, sizeErr := size()
+				if sizeErr != nil || (w == width && h == height) {
+					continue
+				}
+				width, height = w, h
+				_ = resize(width, height)
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+		<-exited
+	}
+}
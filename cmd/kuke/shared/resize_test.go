@@ -0,0 +1,107 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shared_test
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	kukeshared "github.com/eminwux/kukeon/cmd/kuke/shared"
+)
+
+// fakeTerminal is a TerminalSize whose dimensions the test changes between
+// SIGWINCHes.
+type fakeTerminal struct {
+	mu            sync.Mutex
+	width, height uint32
+}
+
+func (f *fakeTerminal) set(width, height uint32) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.width, f.height = width, height
+}
+
+func (f *fakeTerminal) size() (uint32, uint32, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.width, f.height, nil
+}
+
+type resizeCall struct{ width, height uint32 }
+
+func TestForwardResize_InitialAndOnSignal(t *testing.T) {
+	tty := &fakeTerminal{width: 80, height: 24}
+	calls := make(chan resizeCall, 4)
+	winch := make(chan os.Signal, 1)
+
+	stop := kukeshared.ForwardResize(winch, tty.size, func(width, height uint32) error {
+		calls <- resizeCall{width, height}
+		return nil
+	})
+	defer stop()
+
+	// The initial resize lands before ForwardResize returns.
+	select {
+	case got := <-calls:
+		if got != (resizeCall{80, 24}) {
+			t.Fatalf("initial resize = %+v, want 80x24", got)
+		}
+	default:
+		t.Fatal("no initial resize")
+	}
+
+	tty.set(132, 43)
+	winch <- syscall.SIGWINCH
+	select {
+	case got := <-calls:
+		if got != (resizeCall{132, 43}) {
+			t.Fatalf("resize after SIGWINCH = %+v, want 132x43", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no resize after SIGWINCH")
+	}
+
+	// A signal that leaves the size unchanged is not forwarded.
+	winch <- syscall.SIGWINCH
+	stop()
+	select {
+	case got := <-calls:
+		t.Fatalf("unchanged size forwarded: %+v", got)
+	default:
+	}
+}
+
+func TestForwardResize_NoTerminalIsNoop(t *testing.T) {
+	winch := make(chan os.Signal, 1)
+	called := false
+	stop := kukeshared.ForwardResize(winch,
+		func() (uint32, uint32, error) { return 0, 0, errors.New("not a terminal") },
+		func(uint32, uint32) error {
+			called = true
+			return nil
+		})
+	winch <- syscall.SIGWINCH
+	stop()
+	if called {
+		t.Fatal("resize called for a session without a terminal")
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package stack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/eminwux/kukeon/cmd/config"
+	restartpkg "github.com/eminwux/kukeon/cmd/kuke/restart"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	restartStrategyRolling  = "rolling"
+	restartStrategyParallel = "parallel"
+)
+
+// restartStackOptions controls how restartStack rolls a stack's cells.
+type restartStackOptions struct {
+	// Strategy is restartStrategyRolling (batches of MaxUnavailable, each
+	// batch Ready before the next starts) or restartStrategyParallel (every
+	// cell at once).
+	Strategy string
+	// MaxUnavailable is the rolling batch size. Ignored by parallel.
+	MaxUnavailable int
+	// ContinueOnError keeps rolling past a failed batch instead of aborting.
+	ContinueOnError bool
+}
+
+// cellRestartOutcome is the per-cell result of a stack restart. Skipped is
+// set for cells left untouched because the rollout aborted before them.
+type cellRestartOutcome struct {
+	Name    string
+	Err     error
+	Skipped bool
+}
+
+func newRestartCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restart <stack>",
+		Short: "Restart every cell in a stack (rolling or all at once)",
+		Long: "Restart every cell in a stack with the same per-cell semantics as\n" +
+			"`kuke restart`: a Ready cell is stopped and started, a stopped or\n" +
+			"failed cell is started.\n\n" +
+			"The rolling strategy (default) restarts --max-unavailable cells at a\n" +
+			"time, in name order, and only moves on once each cell in the batch is\n" +
+			"back Ready. The parallel strategy restarts every cell at once.\n" +
+			"The rollout stops at the first failed batch and leaves the remaining\n" +
+			"cells untouched unless --continue-on-error is set.",
+		Example: "  kuke stack restart web-stack --realm default --space default\n" +
+			"  kuke stack restart web-stack --max-unavailable 2 --continue-on-error\n" +
+			"  kuke stack restart web-stack --strategy parallel",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE:          runRestartStack,
+	}
+
+	cmd.Flags().String("realm", "", "Realm that owns the stack")
+	_ = viper.BindPFlag(config.KUKE_STACK_RESTART_REALM.ViperKey, cmd.Flags().Lookup("realm"))
+	cmd.Flags().String("space", "", "Space that owns the stack")
+	_ = viper.BindPFlag(config.KUKE_STACK_RESTART_SPACE.ViperKey, cmd.Flags().Lookup("space"))
+	cmd.Flags().String("strategy", restartStrategyRolling,
+		"Restart strategy: rolling (batches of --max-unavailable) or parallel (all cells at once)")
+	cmd.Flags().Int("max-unavailable", 1, "Cells restarted at once by the rolling strategy")
+	cmd.Flags().Bool("continue-on-error", false, "Keep restarting the remaining cells after a failure")
+
+	cmd.ValidArgsFunction = config.CompleteStackNames
+	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
+	_ = cmd.RegisterFlagCompletionFunc("space", config.CompleteSpaceNames)
+	_ = cmd.RegisterFlagCompletionFunc("strategy",
+		func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+			return []string{restartStrategyRolling, restartStrategyParallel}, cobra.ShellCompDirectiveNoFileComp
+		})
+
+	return cmd
+}
+
+func runRestartStack(cmd *cobra.Command, args []string) error {
+	stack := strings.TrimSpace(args[0])
+	realm := strings.TrimSpace(viper.GetString(config.KUKE_STACK_RESTART_REALM.ViperKey))
+	space := strings.TrimSpace(viper.GetString(config.KUKE_STACK_RESTART_SPACE.ViperKey))
+	if realm == "" {
+		return fmt.Errorf("%w (--realm)", errdefs.ErrRealmNameRequired)
+	}
+	if space == "" {
+		return fmt.Errorf("%w (--space)", errdefs.ErrSpaceNameRequired)
+	}
+
+	strategy, _ := cmd.Flags().GetString("strategy")
+	maxUnavailable, _ := cmd.Flags().GetInt("max-unavailable")
+	continueOnError, _ := cmd.Flags().GetBool("continue-on-error")
+	opts := restartStackOptions{
+		Strategy:        strings.TrimSpace(strategy),
+		MaxUnavailable:  maxUnavailable,
+		ContinueOnError: continueOnError,
+	}
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	client, err := resolveClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	outcomes, err := restartStack(cmd.Context(), client, realm, space, stack, opts)
+	restarted := 0
+	for _, o := range outcomes {
+		switch {
+		case o.Skipped:
+			cmd.Printf("Skipped cell %q (rollout aborted)\n", o.Name)
+		case o.Err != nil:
+			cmd.Printf("Failed to restart cell %q: %v\n", o.Name, o.Err)
+		default:
+			restarted++
+			cmd.Printf("Restarted cell %q\n", o.Name)
+		}
+	}
+	if len(outcomes) == 0 && err == nil {
+		cmd.Printf("No cells in stack %q.\n", stack)
+		return nil
+	}
+	cmd.Printf("Restarted %d/%d cells in stack %q (%s)\n", restarted, len(outcomes), stack, opts.Strategy)
+	return err
+}
+
+func (o restartStackOptions) validate() error {
+	switch o.Strategy {
+	case restartStrategyRolling, restartStrategyParallel:
+	default:
+		return fmt.Errorf("%w: %q", errdefs.ErrInvalidRestartStrategy, o.Strategy)
+	}
+	if o.MaxUnavailable < 1 {
+		return fmt.Errorf("%w: %d", errdefs.ErrInvalidMaxUnavailable, o.MaxUnavailable)
+	}
+	return nil
+}
+
+// restartStack lists the stack's cells and restarts them through
+// restart.RestartCell in name order, batch by batch. A cell counts as
+// restarted only once the daemon reports it Ready, which is what gates the
+// next rolling batch. On the first batch with a failure the rollout aborts
+// (remaining cells are reported Skipped) unless opts.ContinueOnError. The
+// returned outcomes always cover every listed cell; the error joins the
+// per-cell failures.
+func restartStack(
+	ctx context.Context,
+	client kukeonv1.Client,
+	realm, space, stack string,
+	opts restartStackOptions,
+) ([]cellRestartOutcome, error) {
+	cells, err := client.ListCells(ctx, realm, space, stack)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(cells, func(i, j int) bool { return cells[i].Metadata.Name < cells[j].Metadata.Name })
+
+	batchSize := opts.MaxUnavailable
+	if opts.Strategy == restartStrategyParallel {
+		batchSize = len(cells)
+	}
+
+	outcomes := make([]cellRestartOutcome, 0, len(cells))
+	var errs []error
+	for start := 0; start < len(cells); start += batchSize {
+		end := min(start+batchSize, len(cells))
+		batch := restartBatch(ctx, client, cells[start:end])
+		failed := false
+		for _, o := range batch {
+			if o.Err != nil {
+				failed = true
+				errs = append(errs, fmt.Errorf("restart cell %q: %w", o.Name, o.Err))
+			}
+		}
+		outcomes = append(outcomes, batch...)
+		if failed && !opts.ContinueOnError && end < len(cells) {
+			for i := end; i < len(cells); i++ {
+				outcomes = append(outcomes, cellRestartOutcome{Name: cells[i].Metadata.Name, Skipped: true})
+			}
+			errs = append(errs, fmt.Errorf("%w: %d cell(s) not restarted in stack %q",
+				errdefs.ErrStackRestartAborted, len(cells)-end, stack))
+			break
+		}
+	}
+	return outcomes, errors.Join(errs...)
+}
+
+// restartBatch restarts cells concurrently and waits for all of them.
+// Outcomes keep the input order.
+func restartBatch(ctx context.Context, client kukeonv1.Client, cells []v1beta1.CellDoc) []cellRestartOutcome {
+	out := make([]cellRestartOutcome, len(cells))
+	var wg sync.WaitGroup
+	for i := range cells {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := &cells[i]
+			out[i] = cellRestartOutcome{Name: c.Metadata.Name, Err: restartStackCell(ctx, client, c)}
+		}(i)
+	}
+	wg.Wait()
+	return out
+}
+
+// restartStackCell restarts one cell by name + scope and requires it to come
+// back Ready.
+func restartStackCell(ctx context.Context, client kukeonv1.Client, c *v1beta1.CellDoc) error {
+	doc := v1beta1.CellDoc{
+		APIVersion: v1beta1.APIVersionV1Beta1,
+		Kind:       v1beta1.KindCell,
+		Metadata:   v1beta1.CellMetadata{Name: c.Metadata.Name, Labels: map[string]string{}},
+		Spec: v1beta1.CellSpec{
+			ID:      c.Metadata.Name,
+			RealmID: c.Spec.RealmID,
+			SpaceID: c.Spec.SpaceID,
+			StackID: c.Spec.StackID,
+		},
+	}
+	res, _, err := restartpkg.RestartCell(ctx, client, doc)
+	if err != nil {
+		return err
+	}
+	if state := res.Cell.Status.State; state != v1beta1.CellStateReady {
+		return fmt.Errorf("%w (state %s)", errdefs.ErrCellNotReadyAfterRestart, state.String())
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package stack_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eminwux/kukeon/cmd/config"
+	stackpkg "github.com/eminwux/kukeon/cmd/kuke/stack"
+	"github.com/eminwux/kukeon/cmd/types"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+	"github.com/spf13/viper"
+)
+
+// restartFake serves a fixed set of Ready cells and records the order and
+// concurrency of StartCell calls. failOn names cells whose start fails.
+type restartFake struct {
+	fakeClient
+
+	names  []string
+	failOn map[string]bool
+	// startDelay keeps each start in flight long enough for overlapping
+	// restarts to be observed.
+	startDelay time.Duration
+
+	mu       sync.Mutex
+	started  []string
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+func (f *restartFake) ListCells(_ context.Context, realm, space, stack string) ([]v1beta1.CellDoc, error) {
+	if realm != "r1" || space != "s1" || stack != "st1" {
+		return nil, errors.New("unexpected list scope")
+	}
+	out := make([]v1beta1.CellDoc, 0, len(f.names))
+	for _, n := range f.names {
+		out = append(out, v1beta1.CellDoc{
+			Metadata: v1beta1.CellMetadata{Name: n},
+			Spec:     v1beta1.CellSpec{ID: n, RealmID: "r1", SpaceID: "s1", StackID: "st1"},
+		})
+	}
+	return out, nil
+}
+
+func (f *restartFake) GetCell(_ context.Context, doc v1beta1.CellDoc) (kukeonv1.GetCellResult, error) {
+	return kukeonv1.GetCellResult{
+		MetadataExists: true,
+		Cell: v1beta1.CellDoc{
+			Metadata: doc.Metadata,
+			Spec:     doc.Spec,
+			Status:   v1beta1.CellStatus{State: v1beta1.CellStateReady},
+		},
+	}, nil
+}
+
+func (f *restartFake) StopCell(_ context.Context, _ v1beta1.CellDoc) (kukeonv1.StopCellResult, error) {
+	return kukeonv1.StopCellResult{}, nil
+}
+
+func (f *restartFake) StartCell(_ context.Context, doc v1beta1.CellDoc) (kukeonv1.StartCellResult, error) {
+	n := f.inFlight.Add(1)
+	defer f.inFlight.Add(-1)
+	for {
+		peak := f.peak.Load()
+		if n <= peak || f.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	f.mu.Lock()
+	f.started = append(f.started, doc.Metadata.Name)
+	f.mu.Unlock()
+	time.Sleep(f.startDelay)
+	if f.failOn[doc.Metadata.Name] {
+		return kukeonv1.StartCellResult{}, errors.New("start boom")
+	}
+	return kukeonv1.StartCellResult{Cell: v1beta1.CellDoc{
+		Metadata: doc.Metadata,
+		Spec:     doc.Spec,
+		Status:   v1beta1.CellStatus{State: v1beta1.CellStateReady},
+	}}, nil
+}
+
+func runStackRestart(t *testing.T, fake *restartFake, args ...string) (string, error) {
+	t.Helper()
+	t.Cleanup(viper.Reset)
+	viper.Reset()
+	viper.Set(config.KUKE_STACK_RESTART_REALM.ViperKey, "r1")
+	viper.Set(config.KUKE_STACK_RESTART_SPACE.ViperKey, "s1")
+
+	cmd := stackpkg.NewStackCmd()
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+	ctx = context.WithValue(ctx, stackpkg.MockControllerKey{}, kukeonv1.Client(fake))
+	cmd.SetContext(ctx)
+	cmd.SetArgs(append([]string{"restart", "st1"}, args...))
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestStackRestart_RollingOneAtATime(t *testing.T) {
+	fake := &restartFake{names: []string{"c", "a", "b"}, startDelay: 20 * time.Millisecond}
+	out, err := runStackRestart(t, fake)
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n%s", err, out)
+	}
+	if got := strings.Join(fake.started, ","); got != "a,b,c" {
+		t.Errorf("start order = %s, want a,b,c", got)
+	}
+	if peak := fake.peak.Load(); peak != 1 {
+		t.Errorf("peak concurrent restarts = %d, want 1", peak)
+	}
+	if !strings.Contains(out, `Restarted 3/3 cells in stack "st1" (rolling)`) {
+		t.Errorf("missing summary line:\n%s", out)
+	}
+}
+
+func TestStackRestart_RollingMaxUnavailable(t *testing.T) {
+	fake := &restartFake{names: []string{"a", "b", "c", "d"}, startDelay: 50 * time.Millisecond}
+	if _, err := runStackRestart(t, fake, "--max-unavailable", "2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if peak := fake.peak.Load(); peak != 2 {
+		t.Errorf("peak concurrent restarts = %d, want 2", peak)
+	}
+}
+
+func TestStackRestart_ParallelAllAtOnce(t *testing.T) {
+	fake := &restartFake{names: []string{"a", "b", "c"}, startDelay: 50 * time.Millisecond}
+	out, err := runStackRestart(t, fake, "--strategy", "parallel")
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n%s", err, out)
+	}
+	if peak := fake.peak.Load(); peak != 3 {
+		t.Errorf("peak concurrent restarts = %d, want 3", peak)
+	}
+	if !strings.Contains(out, `Restarted 3/3 cells in stack "st1" (parallel)`) {
+		t.Errorf("missing summary line:\n%s", out)
+	}
+}
+
+func TestStackRestart_AbortsOnFirstFailure(t *testing.T) {
+	fake := &restartFake{names: []string{"a", "b", "c"}, failOn: map[string]bool{"b": true}}
+	out, err := runStackRestart(t, fake)
+	if !errors.Is(err, errdefs.ErrStackRestartAborted) {
+		t.Fatalf("err = %v, want ErrStackRestartAborted", err)
+	}
+	if got := strings.Join(fake.started, ","); got != "a,b" {
+		t.Errorf("start order = %s, want a,b (c must not be touched)", got)
+	}
+	for _, want := range []string{
+		`Restarted cell "a"`,
+		`Failed to restart cell "b": start boom`,
+		`Skipped cell "c" (rollout aborted)`,
+		`Restarted 1/3 cells in stack "st1"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\nGot:\n%s", want, out)
+		}
+	}
+}
+
+func TestStackRestart_ContinueOnError(t *testing.T) {
+	fake := &restartFake{names: []string{"a", "b", "c"}, failOn: map[string]bool{"b": true}}
+	out, err := runStackRestart(t, fake, "--continue-on-error")
+	if err == nil || errors.Is(err, errdefs.ErrStackRestartAborted) {
+		t.Fatalf("err = %v, want the per-cell failure without an abort", err)
+	}
+	if got := strings.Join(fake.started, ","); got != "a,b,c" {
+		t.Errorf("start order = %s, want a,b,c", got)
+	}
+	if !strings.Contains(out, `Restarted 2/3 cells in stack "st1"`) {
+		t.Errorf("missing summary line:\n%s", out)
+	}
+}
+
+func TestStackRestart_InvalidFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want error
+	}{
+		{"unknown strategy", []string{"--strategy", "canary"}, errdefs.ErrInvalidRestartStrategy},
+		{"zero max-unavailable", []string{"--max-unavailable", "0"}, errdefs.ErrInvalidMaxUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &restartFake{names: []string{"a"}}
+			_, err := runStackRestart(t, fake, tt.args...)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if len(fake.started) != 0 {
+				t.Errorf("cells restarted despite invalid flags: %v", fake.started)
+			}
+		})
+	}
+}
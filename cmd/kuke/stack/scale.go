@@ -0,0 +1,107 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package stack
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/eminwux/kukeon/cmd/config"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func newScaleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scale <stack> <template>=<replicas>",
+		Short: "Scale the replicas of a template cell in a stack",
+		Long: "Reconcile the replicas of a template cell to the requested count.\n\n" +
+			"The template is an existing cell in the stack. Replica i is a copy of its\n" +
+			"spec named <template>-<i> with its own cgroup and network attachment.\n" +
+			"Missing replicas are created and surplus ones deleted; scaling to 0\n" +
+			"removes every replica but leaves the template in place.",
+		Example:       "  kuke stack scale web-stack web=3 --realm default --space default",
+		Args:          cobra.ExactArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stack := strings.TrimSpace(args[0])
+			template, replicas, err := parseScaleTarget(args[1])
+			if err != nil {
+				return err
+			}
+			realm := strings.TrimSpace(viper.GetString(config.KUKE_STACK_SCALE_REALM.ViperKey))
+			space := strings.TrimSpace(viper.GetString(config.KUKE_STACK_SCALE_SPACE.ViperKey))
+			if realm == "" {
+				return fmt.Errorf("%w (--realm)", errdefs.ErrRealmNameRequired)
+			}
+			if space == "" {
+				return fmt.Errorf("%w (--space)", errdefs.ErrSpaceNameRequired)
+			}
+
+			client, err := resolveClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = client.Close() }()
+
+			res, err := client.ScaleCell(cmd.Context(), realm, space, stack, template, replicas)
+			for _, name := range res.Created {
+				cmd.Printf("Created replica %q\n", name)
+			}
+			for _, name := range res.Removed {
+				cmd.Printf("Removed replica %q\n", name)
+			}
+			if err != nil {
+				return err
+			}
+			cmd.Printf("Scaled %q in stack %q to %d replicas (%d created, %d removed, %d unchanged)\n",
+				template, stack, replicas, len(res.Created), len(res.Removed), len(res.Unchanged))
+			return nil
+		},
+	}
+
+	cmd.Flags().String("realm", "", "Realm that owns the stack")
+	_ = viper.BindPFlag(config.KUKE_STACK_SCALE_REALM.ViperKey, cmd.Flags().Lookup("realm"))
+	cmd.Flags().String("space", "", "Space that owns the stack")
+	_ = viper.BindPFlag(config.KUKE_STACK_SCALE_SPACE.ViperKey, cmd.Flags().Lookup("space"))
+
+	cmd.ValidArgsFunction = config.CompleteStackNames
+	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
+	_ = cmd.RegisterFlagCompletionFunc("space", config.CompleteSpaceNames)
+
+	return cmd
+}
+
+// parseScaleTarget splits a `<template>=<replicas>` argument.
+func parseScaleTarget(arg string) (string, int, error) {
+	template, count, ok := strings.Cut(arg, "=")
+	template = strings.TrimSpace(template)
+	if !ok || template == "" {
+		return "", 0, fmt.Errorf("invalid scale target %q: expected <template>=<replicas>", arg)
+	}
+	replicas, err := strconv.Atoi(strings.TrimSpace(count))
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid scale target %q: %w", arg, err)
+	}
+	if replicas < 0 {
+		return "", 0, fmt.Errorf("%w: %d", errdefs.ErrInvalidReplicas, replicas)
+	}
+	return template, replicas, nil
+}
@@ -0,0 +1,171 @@
+//go:build !integration
+
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package stack_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/cmd/config"
+	stackpkg "github.com/eminwux/kukeon/cmd/kuke/stack"
+	"github.com/eminwux/kukeon/cmd/types"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	"github.com/spf13/viper"
+)
+
+func TestScaleCmd(t *testing.T) {
+	scope := func() {
+		viper.Set(config.KUKE_STACK_SCALE_REALM.ViperKey, "r1")
+		viper.Set(config.KUKE_STACK_SCALE_SPACE.ViperKey, "s1")
+	}
+	tests := []struct {
+		name       string
+		args       []string
+		setup      func()
+		fake       *fakeClient
+		wantErr    string
+		wantOutput []string
+	}{
+		{
+			name:  "scale up",
+			args:  []string{"scale", "st1", "web=3"},
+			setup: scope,
+			fake: &fakeClient{
+				scaleCellFn: func(realm, space, stack, template string, replicas int) (kukeonv1.ScaleCellResult, error) {
+					if realm != "r1" || space != "s1" || stack != "st1" || template != "web" || replicas != 3 {
+						return kukeonv1.ScaleCellResult{}, errors.New("unexpected scale arguments")
+					}
+					return kukeonv1.ScaleCellResult{
+						Created:   []string{"web-1", "web-2"},
+						Unchanged: []string{"web-0"},
+					}, nil
+				},
+			},
+			wantOutput: []string{
+				`Created replica "web-1"`,
+				`Created replica "web-2"`,
+				`Scaled "web" in stack "st1" to 3 replicas (2 created, 0 removed, 1 unchanged)`,
+			},
+		},
+		{
+			name:  "scale down",
+			args:  []string{"scale", "st1", "web=0"},
+			setup: scope,
+			fake: &fakeClient{
+				scaleCellFn: func(_, _, _, _ string, _ int) (kukeonv1.ScaleCellResult, error) {
+					return kukeonv1.ScaleCellResult{Removed: []string{"web-0"}}, nil
+				},
+			},
+			wantOutput: []string{`Removed replica "web-0"`, "(0 created, 1 removed, 0 unchanged)"},
+		},
+		{
+			name:  "partial failure reports progress",
+			args:  []string{"scale", "st1", "web=2"},
+			setup: scope,
+			fake: &fakeClient{
+				scaleCellFn: func(_, _, _, _ string, _ int) (kukeonv1.ScaleCellResult, error) {
+					return kukeonv1.ScaleCellResult{Created: []string{"web-0"}}, errors.New("boom")
+				},
+			},
+			wantErr:    "boom",
+			wantOutput: []string{`Created replica "web-0"`},
+		},
+		{
+			name:    "missing replica count",
+			args:    []string{"scale", "st1", "web"},
+			wantErr: "expected <template>=<replicas>",
+		},
+		{
+			name:    "non-numeric replica count",
+			args:    []string{"scale", "st1", "web=many"},
+			wantErr: "invalid scale target",
+		},
+		{
+			name:    "negative replica count",
+			args:    []string{"scale", "st1", "web=-1"},
+			wantErr: "replica count must be zero or greater",
+		},
+		{
+			name:    "missing space",
+			args:    []string{"scale", "st1", "web=1"},
+			setup:   func() { viper.Set(config.KUKE_STACK_SCALE_REALM.ViperKey, "r1") },
+			wantErr: "space name is required",
+		},
+		{
+			name:    "missing target",
+			args:    []string{"scale", "st1"},
+			wantErr: "accepts 2 arg",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			viper.Reset()
+			if tt.setup != nil {
+				tt.setup()
+			}
+
+			cmd := stackpkg.NewStackCmd()
+			buf := &bytes.Buffer{}
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+			if tt.fake != nil {
+				ctx = context.WithValue(ctx, stackpkg.MockControllerKey{}, kukeonv1.Client(tt.fake))
+			}
+			cmd.SetContext(ctx)
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("want err %q, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q\nGot:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+type fakeClient struct {
+	kukeonv1.FakeClient
+
+	scaleCellFn func(realm, space, stack, template string, replicas int) (kukeonv1.ScaleCellResult, error)
+}
+
+func (f *fakeClient) ScaleCell(
+	_ context.Context, realm, space, stack, template string, replicas int,
+) (kukeonv1.ScaleCellResult, error) {
+	if f.scaleCellFn == nil {
+		return kukeonv1.ScaleCellResult{}, errors.New("unexpected ScaleCell call")
+	}
+	return f.scaleCellFn(realm, space, stack, template, replicas)
+}
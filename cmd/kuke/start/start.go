@@ -17,7 +17,6 @@
 package start
 
 import (
-	"errors"
 	"fmt"
 	"strings"
 
@@ -34,17 +33,17 @@ import (
 // MockControllerKey is used to inject a mock kukeonv1.Client via context in tests.
 type MockControllerKey struct{}
 
-// NewStartCmd builds the `kuke start [name]` leaf command. Cell is the only
+// NewStartCmd builds the `kuke start <name>...` leaf command. Cell is the only
 // resource this verb targets, so the noun is implied by the verb. A bare name
-// starts one cell; `-l <selector>` fans the start out across every cell whose
-// labels match (mutually exclusive with a positional name), reconciling each
-// matched cell individually — unmatched cells are untouched.
+// starts one cell; several names, or `-l <selector>` (mutually exclusive with
+// names), start each cell individually, at most --parallel at a time, and
+// print a per-cell summary table — unmatched cells are untouched.
 func NewStartCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:           "start [name]",
+		Use:           "start <name>...",
 		Aliases:       []string{"sta"},
-		Short:         "Start a cell (or a fleet via -l <selector>)",
-		Args:          cobra.MaximumNArgs(1),
+		Short:         "Start one or more cells (or a fleet via -l <selector>)",
+		Args:          cobra.ArbitraryArgs,
 		SilenceUsage:  true,
 		SilenceErrors: false,
 		RunE:          runStart,
@@ -57,7 +56,7 @@ func NewStartCmd() *cobra.Command {
 	cmd.Flags().String("stack", "", "Stack that owns the cell")
 	_ = viper.BindPFlag(config.KUKE_START_CELL_STACK.ViperKey, cmd.Flags().Lookup("stack"))
 
-	getshared.RegisterLabelSelectorFlag(cmd)
+	kukeshared.RegisterCellBatchFlags(cmd)
 
 	cmd.ValidArgsFunction = config.CompleteCellNames
 	_ = cmd.RegisterFlagCompletionFunc("realm", config.CompleteRealmNames)
@@ -72,27 +71,19 @@ func runStart(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-
-	var name string
-	if len(args) > 0 {
-		name = strings.TrimSpace(args[0])
-	}
-
-	if name != "" && !selector.Empty() {
+	if len(args) > 0 && !selector.Empty() {
 		return errdefs.ErrSelectorWithName
 	}
-	if name == "" && selector.Empty() {
+	if len(args) == 0 && selector.Empty() {
 		return errdefs.ErrCellNameRequired
 	}
-
-	client, err := resolveClient(cmd)
+	parallel, err := kukeshared.ParseCellBatchParallel(cmd)
 	if err != nil {
 		return err
 	}
-	defer func() { _ = client.Close() }()
 
 	if !selector.Empty() {
-		return startBySelector(cmd, client, selector)
+		return startBySelector(cmd, selector, parallel)
 	}
 
 	realm := strings.TrimSpace(viper.GetString(config.KUKE_START_CELL_REALM.ViperKey))
@@ -109,24 +100,31 @@ func runStart(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("%w (--stack)", errdefs.ErrStackNameRequired)
 	}
 
-	doc := v1beta1.CellDoc{
-		APIVersion: v1beta1.APIVersionV1Beta1,
-		Kind:       v1beta1.KindCell,
-		Metadata:   v1beta1.CellMetadata{Name: name, Labels: map[string]string{}},
-		Spec: v1beta1.CellSpec{
-			ID:      name,
-			RealmID: realm,
-			SpaceID: space,
-			StackID: stack,
-		},
+	refs := make([]kukeshared.CellRef, 0, len(args))
+	for _, arg := range args {
+		refs = append(refs, kukeshared.CellRef{
+			Name:  strings.TrimSpace(arg),
+			Realm: realm,
+			Space: space,
+			Stack: stack,
+		})
+	}
+
+	client, err := resolveClient(cmd)
+	if err != nil {
+		return err
 	}
+	defer func() { _ = client.Close() }()
 
-	return startOne(cmd, client, doc)
+	if len(refs) > 1 {
+		return kukeshared.PrintCellBatch(cmd, "Started",
+			kukeshared.StartCells(cmd.Context(), client, refs, parallel))
+	}
+	return startOne(cmd, client, refs[0].Doc())
 }
 
 // startOne starts a single cell described by doc and prints the per-cell
-// confirmation line. Shared by the positional-name path and the per-match
-// loop in startBySelector so both paths render identical output.
+// confirmation line.
 func startOne(cmd *cobra.Command, client kukeonv1.Client, doc v1beta1.CellDoc) error {
 	result, err := client.StartCell(cmd.Context(), doc)
 	if err != nil {
@@ -147,62 +145,32 @@ func startOne(cmd *cobra.Command, client kukeonv1.Client, doc v1beta1.CellDoc) e
 
 // startBySelector lists cells in the (optionally realm/space/stack-scoped)
 // fleet, keeps those whose labels match selector, and starts each one
-// individually by looping the per-cell verb. Realm/space/stack flags act as
+// individually through the per-cell verb. Realm/space/stack flags act as
 // list filters here (unset = no filter), mirroring `kuke get cell`; the scope
 // of each StartCell call comes from the matched cell's own spec. A per-cell
-// failure is collected and the loop continues so one bad cell does not abort
-// the rest of the fleet rollout.
-func startBySelector(cmd *cobra.Command, client kukeonv1.Client, selector *getshared.LabelSelector) error {
-	realm := getshared.ExplicitFlag(cmd, "realm", config.KUKE_START_CELL_REALM.ViperKey)
-	space := getshared.ExplicitFlag(cmd, "space", config.KUKE_START_CELL_SPACE.ViperKey)
-	stack := getshared.ExplicitFlag(cmd, "stack", config.KUKE_START_CELL_STACK.ViperKey)
-
-	cells, err := client.ListCells(cmd.Context(), realm, space, stack)
+// failure is reported in the summary and the batch continues so one bad cell
+// does not abort the rest of the fleet rollout.
+func startBySelector(cmd *cobra.Command, selector *getshared.LabelSelector, parallel int) error {
+	client, err := resolveClient(cmd)
 	if err != nil {
 		return err
 	}
-	matched := filterCellsBySelector(cells, selector)
-	if len(matched) == 0 {
+	defer func() { _ = client.Close() }()
+
+	refs, err := kukeshared.SelectCells(cmd.Context(), client, selector,
+		getshared.ExplicitFlag(cmd, "realm", config.KUKE_START_CELL_REALM.ViperKey),
+		getshared.ExplicitFlag(cmd, "space", config.KUKE_START_CELL_SPACE.ViperKey),
+		getshared.ExplicitFlag(cmd, "stack", config.KUKE_START_CELL_STACK.ViperKey),
+	)
+	if err != nil {
+		return err
+	}
+	if len(refs) == 0 {
 		cmd.Println("No cells matched the selector.")
 		return nil
 	}
-
-	var errs []error
-	for i := range matched {
-		c := &matched[i]
-		doc := v1beta1.CellDoc{
-			APIVersion: v1beta1.APIVersionV1Beta1,
-			Kind:       v1beta1.KindCell,
-			Metadata:   v1beta1.CellMetadata{Name: c.Metadata.Name, Labels: map[string]string{}},
-			Spec: v1beta1.CellSpec{
-				ID:      c.Metadata.Name,
-				RealmID: c.Spec.RealmID,
-				SpaceID: c.Spec.SpaceID,
-				StackID: c.Spec.StackID,
-			},
-		}
-		if err := startOne(cmd, client, doc); err != nil {
-			errs = append(errs, fmt.Errorf("start cell %q: %w", c.Metadata.Name, err))
-		}
-	}
-	return errors.Join(errs...)
-}
-
-// filterCellsBySelector returns the subset of cells whose Metadata.Labels
-// satisfy selector. A nil or empty selector returns the input slice
-// unmodified. Replicated inline (mirroring `kuke get cell`) rather than
-// importing the get/cell leaf, keeping the lifecycle verb off that package.
-func filterCellsBySelector(cells []v1beta1.CellDoc, selector *getshared.LabelSelector) []v1beta1.CellDoc {
-	if selector.Empty() {
-		return cells
-	}
-	out := make([]v1beta1.CellDoc, 0, len(cells))
-	for i := range cells {
-		if selector.Matches(cells[i].Metadata.Labels) {
-			out = append(out, cells[i])
-		}
-	}
-	return out
+	return kukeshared.PrintCellBatch(cmd, "Started",
+		kukeshared.StartCells(cmd.Context(), client, refs, parallel))
 }
 
 func resolveClient(cmd *cobra.Command) (kukeonv1.Client, error) {
@@ -22,7 +22,9 @@ import (
 	"errors"
 	"io"
 	"log/slog"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/eminwux/kukeon/cmd/config"
@@ -37,10 +39,10 @@ import (
 func TestNewStartCmdMetadata(t *testing.T) {
 	cmd := startpkg.NewStartCmd()
 
-	if cmd.Use != "start [name]" {
-		t.Errorf("Use mismatch: got %q want %q", cmd.Use, "start [name]")
+	if cmd.Use != "start <name>..." {
+		t.Errorf("Use mismatch: got %q want %q", cmd.Use, "start <name>...")
 	}
-	if cmd.Short != "Start a cell (or a fleet via -l <selector>)" {
+	if cmd.Short != "Start one or more cells (or a fleet via -l <selector>)" {
 		t.Errorf("Short mismatch: got %q", cmd.Short)
 	}
 	if !cmd.HasAlias("sta") {
@@ -136,7 +138,7 @@ func TestStartCmd(t *testing.T) {
 					return kukeonv1.StartCellResult{Cell: doc, Started: true}, nil
 				},
 			},
-			wantOutput: `Started cell "c1" from stack "st1"`,
+			wantOutput: "Started 2/2 cells",
 		},
 		{
 			name: "selector matching nothing reports no match",
@@ -188,22 +190,75 @@ func TestStartCmd(t *testing.T) {
 	}
 }
 
-// TestStartCmd_RejectsCellSubcommand pins the hard CLI break: the `start cell <name>` subcommand form
-// must fail with cobra's Args-validation error (`accepts at most 1 arg(s), received 2`)
-// after the collapse — the verb is now a leaf with `cobra.MaximumNArgs(1)` (a bare
-// name or a bare `-l` selector, never two positionals) and no subcommand list, so
-// cobra's unknown-command path no longer fires.
-func TestStartCmd_RejectsCellSubcommand(t *testing.T) {
+// TestStartCmd_OldCellSubcommandFails pins that the retired `start cell <name>`
+// form still fails: with several names accepted, "cell" is read as a cell
+// name, which does not exist, so the batch exits non-zero.
+func TestStartCmd_OldCellSubcommandFails(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Reset()
+	viper.Set(config.KUKE_START_CELL_REALM.ViperKey, "r1")
+	viper.Set(config.KUKE_START_CELL_SPACE.ViperKey, "s1")
+	viper.Set(config.KUKE_START_CELL_STACK.ViperKey, "st1")
+
+	fake := &fakeClient{
+		startCellFn: func(doc v1beta1.CellDoc) (kukeonv1.StartCellResult, error) {
+			if doc.Metadata.Name == "cell" {
+				return kukeonv1.StartCellResult{}, errdefs.ErrCellNotFound
+			}
+			return kukeonv1.StartCellResult{Cell: doc, Started: true}, nil
+		},
+	}
+	_, err := runStart(t, fake, "cell", "c1")
+	if !errors.Is(err, errdefs.ErrCellNotFound) {
+		t.Fatalf("expected ErrCellNotFound for the `cell` name, got %v", err)
+	}
+}
+
+// TestStartCmd_MultipleNames pins the multi-name batch: every named cell is
+// started, a failed cell is reported in the summary table, and the command
+// still exits non-zero.
+func TestStartCmd_MultipleNames(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Reset()
+	viper.Set(config.KUKE_START_CELL_REALM.ViperKey, "r1")
+	viper.Set(config.KUKE_START_CELL_SPACE.ViperKey, "s1")
+	viper.Set(config.KUKE_START_CELL_STACK.ViperKey, "st1")
+
+	fake := &fakeClient{
+		startCellFn: func(doc v1beta1.CellDoc) (kukeonv1.StartCellResult, error) {
+			if doc.Metadata.Name == "c2" {
+				return kukeonv1.StartCellResult{}, errdefs.ErrCellNotFound
+			}
+			return kukeonv1.StartCellResult{Cell: doc, Started: true}, nil
+		},
+	}
+	out, err := runStart(t, fake, "c1", "c2", "c3", "--parallel", "2")
+	if !errors.Is(err, errdefs.ErrCellNotFound) {
+		t.Fatalf("expected the c2 failure to surface, got %v", err)
+	}
+	if got := fake.calls(); !slices.Equal(got, []string{"c1", "c2", "c3"}) {
+		t.Errorf("started %v, want every named cell", got)
+	}
+	for _, want := range []string{"c1", "Started", "Failed", "cell not found", "Started 2/3 cells"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("summary missing %q\nGot:\n%s", want, out)
+		}
+	}
+}
+
+func runStart(t *testing.T, fake *fakeClient, args ...string) (string, error) {
+	t.Helper()
 	cmd := startpkg.NewStartCmd()
 	buf := &bytes.Buffer{}
 	cmd.SetOut(buf)
 	cmd.SetErr(buf)
-	cmd.SetArgs([]string{"cell", "c1"})
-
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+	ctx = context.WithValue(ctx, startpkg.MockControllerKey{}, kukeonv1.Client(fake))
+	cmd.SetContext(ctx)
+	cmd.SetArgs(args)
 	err := cmd.Execute()
-	if err == nil {
-		t.Fatal("expected error invoking removed `start cell` subcommand, got nil")
-	}
+	return buf.String(), err
 }
 
 type fakeClient struct {
@@ -211,14 +266,18 @@ type fakeClient struct {
 
 	startCellFn func(doc v1beta1.CellDoc) (kukeonv1.StartCellResult, error)
 	listCellsFn func() ([]v1beta1.CellDoc, error)
-	started     []string
+
+	mu     sync.Mutex
+	called []string
 }
 
 func (f *fakeClient) StartCell(_ context.Context, doc v1beta1.CellDoc) (kukeonv1.StartCellResult, error) {
 	if f.startCellFn == nil {
 		return kukeonv1.StartCellResult{}, errors.New("unexpected StartCell call")
 	}
-	f.started = append(f.started, doc.Metadata.Name)
+	f.mu.Lock()
+	f.called = append(f.called, doc.Metadata.Name)
+	f.mu.Unlock()
 	return f.startCellFn(doc)
 }
 
@@ -229,6 +288,16 @@ func (f *fakeClient) ListCells(_ context.Context, _, _, _ string) ([]v1beta1.Cel
 	return f.listCellsFn()
 }
 
+// calls returns the cells acted on, sorted: batches run concurrently, so
+// call order is not fixed.
+func (f *fakeClient) calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := slices.Clone(f.called)
+	slices.Sort(out)
+	return out
+}
+
 // cellWithLabels builds a minimal CellDoc carrying the given scope and labels
 // for selector fan-out tests.
 func cellWithLabels(name, realm, space, stack string, labels map[string]string) v1beta1.CellDoc {
@@ -277,17 +346,7 @@ func TestStartCmd_SelectorFanOutIndividual(t *testing.T) {
 	}
 
 	want := []string{"c1", "c3"}
-	if len(fake.started) != len(want) {
-		t.Fatalf("started %v, want %v", fake.started, want)
-	}
-	for i, name := range want {
-		if fake.started[i] != name {
-			t.Errorf("started[%d] = %q, want %q (started=%v)", i, fake.started[i], name, fake.started)
-		}
-	}
-	for _, name := range fake.started {
-		if name == "c2" {
-			t.Errorf("unmatched cell c2 was started; fan-out must leave it untouched")
-		}
+	if got := fake.calls(); !slices.Equal(got, want) {
+		t.Fatalf("started %v, want %v (unmatched cell c2 must be left untouched)", got, want)
 	}
 }
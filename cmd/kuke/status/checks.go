@@ -18,14 +18,16 @@ package status
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/eminwux/kukeon/internal/consts"
+	"github.com/eminwux/kukeon/internal/cordon"
+	"github.com/eminwux/kukeon/internal/preflight"
 	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
 )
 
@@ -113,6 +115,7 @@ func checkHost(rc *runCtx) []Result {
 		checkHostContainerd(rc),
 		checkHostCgroupV2(rc),
 		checkHostCNIPlugins(rc),
+		checkHostCNIConfig(rc),
 	}
 }
 
@@ -159,8 +162,7 @@ func checkHostCgroupV2(rc *runCtx) Result {
 		Name:    "cgroup-v2",
 	}
 
-	controllersPath := filepath.Join(rc.cgroupRoot, "cgroup.controllers")
-	data, err := os.ReadFile(controllersPath)
+	controllers, err := preflight.CgroupV2(rc.cgroupRoot)
 	if err != nil {
 		r.Status = StatusFAIL
 		r.Detail = fmt.Sprintf("%s (not mounted: %v)", rc.cgroupRoot, err)
@@ -168,7 +170,6 @@ func checkHostCgroupV2(rc *runCtx) Result {
 		return r
 	}
 
-	controllers := strings.Fields(strings.TrimSpace(string(data)))
 	if len(controllers) == 0 {
 		r.Status = StatusWARN
 		r.Detail = fmt.Sprintf("%s (mounted, no controllers advertised)", rc.cgroupRoot)
@@ -208,14 +209,8 @@ func checkHostCNIPlugins(rc *runCtx) Result {
 		Name:    "cni-plugins",
 	}
 
-	plugins := requiredCNIPlugins()
-	var missing []string
-	for _, plugin := range plugins {
-		path := filepath.Join(rc.cniBinDir, plugin)
-		if _, err := os.Stat(path); err != nil {
-			missing = append(missing, plugin)
-		}
-	}
+	plugins := preflight.RequiredCNIPlugins()
+	missing := preflight.MissingCNIPlugins(rc.cniBinDir)
 
 	if len(missing) == 0 {
 		r.Status = StatusOK
@@ -238,6 +233,28 @@ func checkHostCNIPlugins(rc *runCtx) Result {
 	return r
 }
 
+// checkHostCNIConfig checks the CNI network-config directory `kuke init`
+// creates and every space writes its conflist into. WARN rather than FAIL
+// when it is missing: nothing is broken until the next space is created,
+// and re-running `kuke init` restores it.
+func checkHostCNIConfig(rc *runCtx) Result {
+	r := Result{
+		Section: sectionHost,
+		Name:    "cni-config",
+	}
+
+	if err := preflight.CNIConfDir(rc.cniConfDir); err != nil {
+		r.Status = StatusWARN
+		r.Detail = fmt.Sprintf("%s (missing)", rc.cniConfDir)
+		r.Remediation = "re-run `kuke init` to recreate the CNI config directory"
+		return r
+	}
+
+	r.Status = StatusOK
+	r.Detail = fmt.Sprintf("%s (present)", rc.cniConfDir)
+	return r
+}
+
 // checkState runs the consistency probes the gaps doc's `kuke selftest`
 // proposal called for: stale orphan files in the run dir, and residual
 // containerd namespaces that no realm claims. WARN by default — neither
@@ -247,8 +264,100 @@ func checkHostCNIPlugins(rc *runCtx) Result {
 func checkState(ctx context.Context, rc *runCtx) []Result {
 	return []Result{
 		checkStateRunDir(rc),
+		checkStateRunPath(rc),
+		checkStateKuketty(rc),
 		checkStateNamespaces(ctx, rc),
+		checkStateCordon(rc),
+	}
+}
+
+// checkStateRunPath checks that the run path (the metadata tree) accepts
+// writes. Only whoever provisions needs that: with a reachable daemon it is
+// kukeond, so a read-only view from a non-root operator is WARN; with no
+// daemon the in-process path is the writer and an unwritable run path is
+// FAIL.
+func checkStateRunPath(rc *runCtx) Result {
+	r := Result{
+		Section: sectionState,
+		Name:    "run-path",
 	}
+
+	err := preflight.RunPathWritable(rc.runPath)
+	switch {
+	case err == nil:
+		r.Status = StatusOK
+		r.Detail = fmt.Sprintf("%s (writable)", rc.runPath)
+	case rc.daemonClient != nil:
+		r.Status = StatusWARN
+		r.Detail = fmt.Sprintf("%s (not writable by this user; the daemon writes it)", rc.runPath)
+		r.Remediation = "run as root for `--no-daemon` operations"
+	default:
+		r.Status = StatusFAIL
+		r.Detail = fmt.Sprintf("%s (not writable: %v)", rc.runPath, err)
+		r.Remediation = "run as root, or fix ownership with `kuke init`"
+	}
+	return r
+}
+
+// checkStateKuketty checks the kuketty binary the runner stages under the
+// run path for `kuke attach`. It is staged on the first attachable
+// container, so a missing copy is WARN; a staged copy that is empty or not
+// executable would break every attach and is FAIL.
+func checkStateKuketty(rc *runCtx) Result {
+	r := Result{
+		Section: sectionState,
+		Name:    "kuketty",
+	}
+
+	path := preflight.KukettyStagedPath(rc.runPath)
+	err := preflight.KukettyStaged(rc.runPath)
+	switch {
+	case err == nil:
+		r.Status = StatusOK
+		r.Detail = fmt.Sprintf("%s (staged)", path)
+	case errors.Is(err, os.ErrNotExist):
+		r.Status = StatusWARN
+		r.Detail = fmt.Sprintf("%s (not staged yet)", path)
+		r.Remediation = "staged on the first attachable container; `kuke attach` needs it"
+	default:
+		r.Status = StatusFAIL
+		r.Detail = fmt.Sprintf("%s (%v)", path, err)
+		r.Remediation = "remove the file so the next attachable container restages it"
+	}
+	return r
+}
+
+// checkStateCordon reports whether the node is cordoned (`kuke cordon`). A
+// cordoned node is WARN rather than FAIL: it is a deliberate maintenance
+// state, but one that makes every new `kuke create cell` fail, so it should
+// not go unnoticed.
+func checkStateCordon(rc *runCtx) Result {
+	r := Result{
+		Section: sectionState,
+		Name:    "cordon",
+	}
+
+	m, cordoned, err := cordon.Load(rc.runPath)
+	switch {
+	case !cordoned && err != nil:
+		r.Status = StatusWARN
+		r.Detail = fmt.Sprintf("read failed: %v", err)
+	case !cordoned:
+		r.Status = StatusOK
+		r.Detail = "schedulable"
+	case err != nil:
+		r.Status = StatusWARN
+		r.Detail = fmt.Sprintf("cordoned (unreadable marker: %v)", err)
+		r.Remediation = "run `kuke uncordon` to remove the marker and allow new cells"
+	default:
+		r.Status = StatusWARN
+		r.Detail = "cordoned since " + m.Since.Local().Format(time.RFC3339)
+		if m.Reason != "" {
+			r.Detail += ": " + m.Reason
+		}
+		r.Remediation = "run `kuke uncordon` to allow new cells and containers"
+	}
+	return r
 }
 
 // checkStateRunDir enumerates entries directly under rc.runPath/.. that
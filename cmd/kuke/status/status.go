@@ -28,6 +28,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/eminwux/kukeon/cmd/config"
 	"github.com/eminwux/kukeon/cmd/kuke/shared"
@@ -119,6 +120,7 @@ type runCtx struct {
 	containerdSocket string
 	cgroupRoot       string
 	cniBinDir        string
+	cniConfDir       string
 
 	logger *slog.Logger
 
@@ -172,9 +174,10 @@ func NewStatusCmd() *cobra.Command {
 			"`kuke get realms` vs `kuke get realms --no-daemon` diff ritual.\n\n" +
 			"Sections: daemon (socket dialable, round-trip, version), host\n" +
 			"(containerd, cgroup-v2, CNI plugins), state (orphan sockets,\n" +
-			"residual containerd namespaces), parity (every `kuke get <kind>`\n" +
-			"agrees daemon-side vs in-process). Each line is OK / WARN / FAIL\n" +
-			"with a one-line remediation hint when the status is not OK.\n\n" +
+			"residual containerd namespaces, node cordon), parity (every\n" +
+			"`kuke get <kind>` agrees daemon-side vs in-process). Each line is\n" +
+			"OK / WARN / FAIL with a one-line remediation hint when the status\n" +
+			"is not OK.\n\n" +
 			"Exit code 0 when every check is OK or WARN; non-zero when any line\n" +
 			"is FAIL. The `--json` form is the machine-readable shape for CI\n" +
 			"integration; --verbose surfaces the remediation hint on OK rows\n" +
@@ -269,6 +272,7 @@ func buildRunCtx(cmd *cobra.Command) (*runCtx, bool) {
 		containerdSocket: containerdSocket,
 		cgroupRoot:       cgroupcheck.DefaultHostRoot(),
 		cniBinDir:        defaultCniBinDir,
+		cniConfDir:       defaultCniConfDir,
 		logger:           logger,
 	}
 
@@ -292,7 +296,9 @@ func buildRunCtx(cmd *cobra.Command) (*runCtx, bool) {
 
 	// ctrClient is constructed but not connected here — the host
 	// containerd check is where Connect() runs and decides reachability.
-	rc.ctrClient = ctr.NewClient(cmd.Context(), logger, containerdSocket)
+	rc.ctrClient = ctr.NewClientWithOptions(cmd.Context(), logger, containerdSocket, ctr.ClientOptions{
+		ConnectTimeout: containerdConnectTimeout,
+	})
 
 	return rc, true
 }
@@ -342,25 +348,17 @@ type discardWriter struct{}
 
 func (discardWriter) Write(p []byte) (int, error) { return len(p), nil }
 
-// defaultCniBinDir mirrors internal/cni/types.go:98's defaultCniBinDir,
-// duplicated here so the status check doesn't force `internal/cni` to
-// export a constant just for this one consumer. The CNI bootstrap path
-// is the source of truth; this string must move in lockstep if that
-// constant ever moves.
-const defaultCniBinDir = "/opt/cni/bin"
-
-// requiredCNIPlugins lists the binaries CNI execution needs under
-// defaultCniBinDir — the kukeond image bundles them in-image (Dockerfile
-// `cni-plugins` stage) and runs CNI from there; `kuke init` does not lay
-// them onto the host. Sourced from internal/cni/{bridge,types}.go —
-// `bridge` lays the per-realm bridge network and `loopback` populates the
-// per-container lo interface (the minimum a `kuke create container` netns
-// needs). Any future required plugin lands here so the host check surfaces
-// it when it disappears.
-//
-// Function rather than a package-level var so the list isn't mutable
-// post-init (gochecknoglobals) and tests can call it for parity with
-// what the host check stats. Returns a fresh slice per call.
-func requiredCNIPlugins() []string {
-	return []string{"bridge", "loopback"}
-}
+// defaultCniBinDir and defaultCniConfDir mirror internal/cni/types.go's
+// defaults, duplicated here so the status check doesn't force
+// `internal/cni` to export constants just for this one consumer. The CNI
+// bootstrap path is the source of truth; these strings must move in
+// lockstep if those constants ever move.
+const (
+	defaultCniBinDir  = "/opt/cni/bin"
+	defaultCniConfDir = "/opt/cni/net.d"
+)
+
+// containerdConnectTimeout bounds the host containerd check, so a socket
+// that accepts but never answers reports FAIL promptly instead of stalling
+// the whole report for the client's default timeout.
+const containerdConnectTimeout = 3 * time.Second
@@ -25,9 +25,12 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/eminwux/kukeon/cmd/types"
+	"github.com/eminwux/kukeon/internal/cordon"
 	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/preflight"
 	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
 	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
 )
@@ -303,7 +306,7 @@ func TestCheckHostCgroupV2(t *testing.T) {
 // copy is advisory); host plugins missing and the daemon unreachable →
 // FAIL (no plugin set to run CNI at all).
 func TestCheckHostCNIPlugins(t *testing.T) {
-	plugins := requiredCNIPlugins()
+	plugins := preflight.RequiredCNIPlugins()
 	t.Run("all present", func(t *testing.T) {
 		dir := t.TempDir()
 		for _, name := range plugins {
@@ -396,6 +399,90 @@ func TestCheckStateRunDir_AbsentDirIsOK(t *testing.T) {
 	}
 }
 
+// TestCheckHostCNIConfig: a present CNI config dir is OK, a missing one is
+// WARN pointing at `kuke init`.
+func TestCheckHostCNIConfig(t *testing.T) {
+	dir := t.TempDir()
+	if r := checkHostCNIConfig(&runCtx{cniConfDir: dir}); r.Status != StatusOK {
+		t.Errorf("present dir: got %s (%s), want OK", r.Status, r.Detail)
+	}
+	r := checkHostCNIConfig(&runCtx{cniConfDir: filepath.Join(dir, "missing")})
+	if r.Status != StatusWARN || !strings.Contains(r.Remediation, "kuke init") {
+		t.Errorf("missing dir: got %s (%s, %q), want WARN pointing at kuke init", r.Status, r.Detail, r.Remediation)
+	}
+}
+
+// TestCheckStateRunPath: an unwritable run path is WARN while the daemon
+// (the real writer) is reachable and FAIL when the in-process path would
+// have to write it.
+func TestCheckStateRunPath(t *testing.T) {
+	dir := t.TempDir()
+	if r := checkStateRunPath(&runCtx{runPath: dir}); r.Status != StatusOK {
+		t.Errorf("writable: got %s (%s), want OK", r.Status, r.Detail)
+	}
+
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, []byte{}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if r := checkStateRunPath(&runCtx{runPath: file, daemonClient: newFakeClient()}); r.Status != StatusWARN {
+		t.Errorf("unwritable, daemon up: got %s (%s), want WARN", r.Status, r.Detail)
+	}
+	if r := checkStateRunPath(&runCtx{runPath: file}); r.Status != StatusFAIL {
+		t.Errorf("unwritable, daemon down: got %s (%s), want FAIL", r.Status, r.Detail)
+	}
+}
+
+// TestCheckStateKuketty: not staged yet is WARN, a broken staged copy is
+// FAIL, a staged executable is OK.
+func TestCheckStateKuketty(t *testing.T) {
+	rc := &runCtx{runPath: t.TempDir()}
+	if r := checkStateKuketty(rc); r.Status != StatusWARN {
+		t.Errorf("not staged: got %s (%s), want WARN", r.Status, r.Detail)
+	}
+
+	path := preflight.KukettyStagedPath(rc.runPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte{}, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if r := checkStateKuketty(rc); r.Status != StatusFAIL {
+		t.Errorf("empty staged binary: got %s (%s), want FAIL", r.Status, r.Detail)
+	}
+
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if r := checkStateKuketty(rc); r.Status != StatusOK {
+		t.Errorf("staged: got %s (%s), want OK", r.Status, r.Detail)
+	}
+}
+
+// TestCheckStateCordon covers the cordon row: an uncordoned node is OK,
+// a cordoned one is WARN naming the reason and pointing at `kuke uncordon`.
+func TestCheckStateCordon(t *testing.T) {
+	rc := &runCtx{runPath: t.TempDir()}
+	if r := checkStateCordon(rc); r.Status != StatusOK {
+		t.Errorf("uncordoned node: got %s (%s), want OK", r.Status, r.Detail)
+	}
+
+	if _, _, err := cordon.Cordon(rc.runPath, "kernel upgrade", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	r := checkStateCordon(rc)
+	if r.Status != StatusWARN {
+		t.Errorf("cordoned node: got %s (%s), want WARN", r.Status, r.Detail)
+	}
+	if !strings.Contains(r.Detail, "kernel upgrade") {
+		t.Errorf("Detail should carry the reason; got %q", r.Detail)
+	}
+	if !strings.Contains(r.Remediation, "kuke uncordon") {
+		t.Errorf("Remediation should point at kuke uncordon; got %q", r.Remediation)
+	}
+}
+
 // TestRunChecks_JSONShape confirms the JSON form is stable enough for
 // CI integration — `ok` is a boolean, every check has the four required
 // string fields, and Status renders as the human label.
@@ -561,7 +648,7 @@ func setupHealthyHost(t *testing.T) healthyHost {
 	}
 
 	cniBinDir := t.TempDir()
-	for _, name := range requiredCNIPlugins() {
+	for _, name := range preflight.RequiredCNIPlugins() {
 		if err := os.WriteFile(filepath.Join(cniBinDir, name), []byte{}, 0o755); err != nil {
 			t.Fatal(err)
 		}
@@ -22,7 +22,9 @@ import (
 	"errors"
 	"io"
 	"log/slog"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/eminwux/kukeon/cmd/config"
@@ -37,10 +39,10 @@ import (
 func TestNewStopCmdMetadata(t *testing.T) {
 	cmd := stoppkg.NewStopCmd()
 
-	if cmd.Use != "stop <name>" {
-		t.Errorf("Use mismatch: got %q want %q", cmd.Use, "stop <name>")
+	if cmd.Use != "stop <name>..." {
+		t.Errorf("Use mismatch: got %q want %q", cmd.Use, "stop <name>...")
 	}
-	if cmd.Short != "Stop a cell" {
+	if cmd.Short != "Stop one or more cells (or a fleet via -l <selector>)" {
 		t.Errorf("Short mismatch: got %q", cmd.Short)
 	}
 	if !cmd.HasAlias("sto") {
@@ -115,7 +117,7 @@ func TestStopCmd(t *testing.T) {
 		{
 			name:    "missing positional",
 			args:    []string{},
-			wantErr: "accepts 1 arg",
+			wantErr: "cell name is required",
 		},
 	}
 	for _, tt := range tests {
@@ -155,32 +157,157 @@ func TestStopCmd(t *testing.T) {
 	}
 }
 
-// TestStopCmd_RejectsCellSubcommand pins the hard CLI break: the `stop cell <name>` subcommand form
-// must fail with cobra's Args-validation error (`accepts 1 arg(s), received 2`)
-// after the collapse — the verb is now a leaf with `cobra.ExactArgs(1)` and no
-// subcommand list, so cobra's unknown-command path no longer fires.
-func TestStopCmd_RejectsCellSubcommand(t *testing.T) {
+// TestStopCmd_OldCellSubcommandFails pins that the retired `stop cell <name>`
+// form still fails: with several names accepted, "cell" is read as a cell
+// name, which does not exist, so the batch exits non-zero.
+func TestStopCmd_OldCellSubcommandFails(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Reset()
+	viper.Set(config.KUKE_STOP_CELL_REALM.ViperKey, "r1")
+	viper.Set(config.KUKE_STOP_CELL_SPACE.ViperKey, "s1")
+	viper.Set(config.KUKE_STOP_CELL_STACK.ViperKey, "st1")
+
+	fake := &fakeClient{
+		stopCellFn: func(doc v1beta1.CellDoc) (kukeonv1.StopCellResult, error) {
+			if doc.Metadata.Name == "cell" {
+				return kukeonv1.StopCellResult{}, errdefs.ErrCellNotFound
+			}
+			return kukeonv1.StopCellResult{Cell: doc, Stopped: true}, nil
+		},
+	}
+	_, err := runStop(t, fake, "cell", "c1")
+	if !errors.Is(err, errdefs.ErrCellNotFound) {
+		t.Fatalf("expected ErrCellNotFound for the `cell` name, got %v", err)
+	}
+}
+
+// TestStopCmd_MultipleNames pins the multi-name batch: every named cell is
+// stopped, a failed cell is reported in the summary table, and the command
+// still exits non-zero.
+func TestStopCmd_MultipleNames(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Reset()
+	viper.Set(config.KUKE_STOP_CELL_REALM.ViperKey, "r1")
+	viper.Set(config.KUKE_STOP_CELL_SPACE.ViperKey, "s1")
+	viper.Set(config.KUKE_STOP_CELL_STACK.ViperKey, "st1")
+
+	fake := &fakeClient{
+		stopCellFn: func(doc v1beta1.CellDoc) (kukeonv1.StopCellResult, error) {
+			if doc.Metadata.Name == "c2" {
+				return kukeonv1.StopCellResult{}, errdefs.ErrCellNotFound
+			}
+			return kukeonv1.StopCellResult{Cell: doc, Stopped: true}, nil
+		},
+	}
+	out, err := runStop(t, fake, "c1", "c2", "c3", "--parallel", "2")
+	if !errors.Is(err, errdefs.ErrCellNotFound) {
+		t.Fatalf("expected the c2 failure to surface, got %v", err)
+	}
+	if got := fake.calls(); !slices.Equal(got, []string{"c1", "c2", "c3"}) {
+		t.Errorf("stopped %v, want every named cell", got)
+	}
+	for _, want := range []string{"c1", "Stopped", "Failed", "cell not found", "Stopped 2/3 cells"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("summary missing %q\nGot:\n%s", want, out)
+		}
+	}
+}
+
+// TestStopCmd_Selector pins the selector batch: only matching cells are
+// stopped, each in its own scope.
+func TestStopCmd_Selector(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Reset()
+
+	fake := &fakeClient{
+		listCellsFn: func() ([]v1beta1.CellDoc, error) {
+			return []v1beta1.CellDoc{
+				cellWithLabels("c1", "r1", "s1", "st1", map[string]string{"app": "web"}),
+				cellWithLabels("c2", "r1", "s1", "st1", map[string]string{"app": "db"}),
+				cellWithLabels("c3", "r2", "s2", "st2", map[string]string{"app": "web"}),
+			}, nil
+		},
+		stopCellFn: func(doc v1beta1.CellDoc) (kukeonv1.StopCellResult, error) {
+			if doc.Metadata.Name == "c3" && doc.Spec.RealmID != "r2" {
+				return kukeonv1.StopCellResult{}, errdefs.ErrCellNotFound
+			}
+			return kukeonv1.StopCellResult{Cell: doc, Stopped: true}, nil
+		},
+	}
+	out, err := runStop(t, fake, "-l", "app=web")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := fake.calls(); !slices.Equal(got, []string{"c1", "c3"}) {
+		t.Errorf("stopped %v, want [c1 c3]", got)
+	}
+	if !strings.Contains(out, "Stopped 2/2 cells") {
+		t.Errorf("summary missing count line\nGot:\n%s", out)
+	}
+}
+
+func runStop(t *testing.T, fake *fakeClient, args ...string) (string, error) {
+	t.Helper()
 	cmd := stoppkg.NewStopCmd()
 	buf := &bytes.Buffer{}
 	cmd.SetOut(buf)
 	cmd.SetErr(buf)
-	cmd.SetArgs([]string{"cell", "c1"})
-
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+	ctx = context.WithValue(ctx, stoppkg.MockControllerKey{}, kukeonv1.Client(fake))
+	cmd.SetContext(ctx)
+	cmd.SetArgs(args)
 	err := cmd.Execute()
-	if err == nil {
-		t.Fatal("expected error invoking removed `stop cell` subcommand, got nil")
-	}
+	return buf.String(), err
 }
 
 type fakeClient struct {
 	kukeonv1.FakeClient
 
-	stopCellFn func(doc v1beta1.CellDoc) (kukeonv1.StopCellResult, error)
+	stopCellFn  func(doc v1beta1.CellDoc) (kukeonv1.StopCellResult, error)
+	listCellsFn func() ([]v1beta1.CellDoc, error)
+
+	mu     sync.Mutex
+	called []string
 }
 
 func (f *fakeClient) StopCell(_ context.Context, doc v1beta1.CellDoc) (kukeonv1.StopCellResult, error) {
 	if f.stopCellFn == nil {
 		return kukeonv1.StopCellResult{}, errors.New("unexpected StopCell call")
 	}
+	f.mu.Lock()
+	f.called = append(f.called, doc.Metadata.Name)
+	f.mu.Unlock()
 	return f.stopCellFn(doc)
 }
+
+func (f *fakeClient) ListCells(_ context.Context, _, _, _ string) ([]v1beta1.CellDoc, error) {
+	if f.listCellsFn == nil {
+		return nil, errors.New("unexpected ListCells call")
+	}
+	return f.listCellsFn()
+}
+
+// calls returns the cells acted on, sorted: batches run concurrently, so
+// call order is not fixed.
+func (f *fakeClient) calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := slices.Clone(f.called)
+	slices.Sort(out)
+	return out
+}
+
+// cellWithLabels builds a minimal CellDoc carrying the given scope and labels
+// for selector fan-out tests.
+func cellWithLabels(name, realm, space, stack string, labels map[string]string) v1beta1.CellDoc {
+	return v1beta1.CellDoc{
+		Metadata: v1beta1.CellMetadata{Name: name, Labels: labels},
+		Spec: v1beta1.CellSpec{
+			ID:      name,
+			RealmID: realm,
+			SpaceID: space,
+			StackID: stack,
+		},
+	}
+}
@@ -85,8 +85,9 @@ type MockResolveKey struct{}
 // ApplyForTeamFunc applies a per-(role × harness) rendered set to kukeond
 // under the project's team label, pruning that team's stale objects in the
 // same call (the per-team prune-apply contract from #1029). The default
-// implementation dials kukeond and invokes ApplyDocumentsForTeam; tests
-// inject a stub via MockApplyForTeamKey so the apply path can run hermetically.
+// implementation dials kukeond and invokes ApplyDocumentsWithOptions with
+// the team set; tests inject a stub via MockApplyForTeamKey so the apply
+// path can run hermetically.
 type ApplyForTeamFunc func(
 	ctx context.Context, rawYAML []byte, team string,
 ) (kukeonv1.ApplyDocumentsResult, error)
@@ -248,7 +249,7 @@ func validateTeam(
 //  4. Render the per-(role × harness) CellBlueprint/CellConfig pairs.
 //  5. Either print the rendered objects to stdout (--dry-run, nothing is
 //     applied and no files are written) or apply the labeled set to kukeond
-//     via ApplyDocumentsForTeam (per-team prune via #1029) and then write
+//     via ApplyDocumentsWithOptions (per-team prune via #1029) and then write
 //     the per-project drop-in entry. Nothing is written under
 //     ~/.kuke/rendered/ — the on-disk record of an applied team is the
 //     drop-in entry alone; the daemon owns the persisted blueprints/configs.
@@ -583,6 +584,8 @@ func emitApplySummary(
 			fmt.Fprintf(out, "  %s %q: unchanged\n", r.Kind, r.Name)
 		case "pruned":
 			fmt.Fprintf(out, "  %s %q: pruned\n", r.Kind, r.Name)
+		case "rolled back":
+			fmt.Fprintf(out, "  %s %q: rolled back\n", r.Kind, r.Name)
 		case "failed":
 			fmt.Fprintf(out, "  %s %q: failed", r.Kind, r.Name)
 			if r.Error != "" {
@@ -859,20 +862,26 @@ func resolveFromCmd(cmd *cobra.Command) ResolveFunc {
 
 // applyForTeamFromCmd returns the ApplyForTeamFunc the init flow uses — the
 // test mock from context when present, otherwise a function that dials
-// kukeond per invocation and forwards to ApplyDocumentsForTeam. The dial
-// happens on call (rather than eagerly at command-setup time) so a roster
-// with no (role × harness) pairs never opens the daemon connection.
+// kukeond per invocation and forwards to ApplyDocumentsWithOptions under the
+// team. The dial happens on call (rather than eagerly at command-setup time)
+// so a roster with no (role × harness) pairs never opens the daemon
+// connection.
 func applyForTeamFromCmd(cmd *cobra.Command) ApplyForTeamFunc {
 	if mock, ok := cmd.Context().Value(MockApplyForTeamKey{}).(ApplyForTeamFunc); ok && mock != nil {
 		return mock
 	}
 	return func(ctx context.Context, rawYAML []byte, team string) (kukeonv1.ApplyDocumentsResult, error) {
+		// An empty team would degrade into the no-prune apply and leave the
+		// project's stale blueprints and configs behind.
+		if team == "" {
+			return kukeonv1.ApplyDocumentsResult{}, errors.New("apply for team: team is required")
+		}
 		client, err := kukshared.DaemonClientFromCmd(cmd)
 		if err != nil {
 			return kukeonv1.ApplyDocumentsResult{}, err
 		}
 		defer func() { _ = client.Close() }()
-		return client.ApplyDocumentsForTeam(ctx, rawYAML, team)
+		return client.ApplyDocumentsWithOptions(ctx, rawYAML, kukeonv1.ApplyOptions{Team: team})
 	}
 }
 
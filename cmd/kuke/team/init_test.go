@@ -703,7 +703,7 @@ func TestComposeTeamProjectRepoURLFilledIntoConfig(t *testing.T) {
 }
 
 // TestComposeTeamAppliesRenderedSetToDaemon pins the AC: the project's
-// labeled set is handed to ApplyDocumentsForTeam with the project as the
+// labeled set is handed to ApplyDocumentsWithOptions with the project as the
 // team. The YAML the stub captures carries both kinds and the team label
 // teamrender stamped onto every object — the same payload the daemon
 // prunes against in #1029.
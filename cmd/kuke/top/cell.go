@@ -0,0 +1,222 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package top
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eminwux/kukeon/cmd/kuke/get/shared"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	"github.com/spf13/cobra"
+)
+
+const (
+	sortByCPU    = "cpu"
+	sortByMemory = "memory"
+)
+
+// cellTop is one `kuke top cell` row: the latest sample of a cell plus the
+// figures derived from it. CPUPercent is nil until the cell has been seen
+// in two consecutive samples.
+type cellTop struct {
+	kukeonv1.CellUsage `yaml:",inline"`
+
+	CPUPercent    *float64 `json:"cpuPercent,omitempty"    yaml:"cpuPercent,omitempty"`
+	MemoryPercent *float64 `json:"memoryPercent,omitempty" yaml:"memoryPercent,omitempty"`
+}
+
+type cellFlags struct {
+	sampleFlags
+
+	realm  string
+	space  string
+	stack  string
+	sortBy string
+}
+
+func newCellCmd() *cobra.Command {
+	var flags cellFlags
+	cmd := &cobra.Command{
+		Use:     "cell",
+		Aliases: []string{"cells", "ce"},
+		Short:   "Show the live memory, cpu, and pids usage of each cell",
+		Long: "Report each cell's cgroup usage: the CPU percentage over two samples taken\n" +
+			"--interval apart, memory against the cell's limit, and the pids count.\n" +
+			"--realm, --space and --stack narrow the report; without --realm every realm\n" +
+			"is shown.",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			outputFormat, err := shared.ParseOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+			if err = flags.validate(); err != nil {
+				return err
+			}
+
+			client, err := resolveClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = client.Close() }()
+
+			sample := func(ctx context.Context) ([]kukeonv1.CellUsage, error) {
+				return client.ListCellUsage(ctx, flags.realm, flags.space, flags.stack)
+			}
+			return runSampled(cmd, flags.sampleFlags, sample,
+				func(prev, cur []kukeonv1.CellUsage, elapsed time.Duration) error {
+					rows := cellTopRows(prev, cur, elapsed)
+					sortCellTop(rows, flags.sortBy)
+					return printCellTop(cmd, rows, outputFormat, flags.sampleFlags)
+				})
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.realm, "realm", "", "Only cells in this realm (default: every realm)")
+	cmd.Flags().StringVar(&flags.space, "space", "", "Only cells in this space of the realm")
+	cmd.Flags().StringVar(&flags.stack, "stack", "", "Only cells in this stack of the space (requires --space)")
+	cmd.Flags().StringVar(&flags.sortBy, "sort-by", "", "Sort cells by cpu or memory usage, highest first")
+	cmd.Flags().StringP("output", "o", "", "Output format (yaml, json, table). Default: table")
+	flags.register(cmd)
+
+	return cmd
+}
+
+func (f *cellFlags) validate() error {
+	if err := f.sampleFlags.validate(); err != nil {
+		return err
+	}
+	switch f.sortBy {
+	case "", sortByCPU, sortByMemory:
+	default:
+		return fmt.Errorf("invalid --sort-by %q: want %s or %s", f.sortBy, sortByCPU, sortByMemory)
+	}
+	if f.space != "" && f.realm == "" {
+		return fmt.Errorf("--space requires --realm")
+	}
+	if f.stack != "" && f.space == "" {
+		return fmt.Errorf("--stack requires --space")
+	}
+	return nil
+}
+
+// cellTopRows pairs every cell of cur with its reading in prev. A cell
+// missing from prev was created between the samples and a cell missing from
+// cur was deleted: the first gets no CPU percentage, the second is dropped.
+func cellTopRows(prev, cur []kukeonv1.CellUsage, elapsed time.Duration) []cellTop {
+	before := make(map[string]kukeonv1.CellUsage, len(prev))
+	for _, u := range prev {
+		before[cellKey(u)] = u
+	}
+	rows := make([]cellTop, 0, len(cur))
+	for _, u := range cur {
+		row := cellTop{CellUsage: u}
+		if p, ok := before[cellKey(u)]; ok {
+			if pct, pctOK := cpuPercent(p.CPUUsageUsec, u.CPUUsageUsec, elapsed); pctOK {
+				row.CPUPercent = &pct
+			}
+		}
+		if pct, ok := memoryPercent(u.MemoryBytes, u.MemoryLimitBytes); ok {
+			row.MemoryPercent = &pct
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func cellKey(u kukeonv1.CellUsage) string {
+	return strings.Join([]string{u.Realm, u.Space, u.Stack, u.Cell}, "/")
+}
+
+// sortCellTop orders rows by the chosen usage, highest first, with cells
+// whose figure is unknown last; ties and the default keep the hierarchy
+// order.
+func sortCellTop(rows []cellTop, by string) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, aOK := sortValue(rows[i], by)
+		b, bOK := sortValue(rows[j], by)
+		if aOK != bOK {
+			return aOK
+		}
+		if a != b {
+			return a > b
+		}
+		return cellKey(rows[i].CellUsage) < cellKey(rows[j].CellUsage)
+	})
+}
+
+func sortValue(row cellTop, by string) (float64, bool) {
+	switch by {
+	case sortByCPU:
+		if row.CPUPercent == nil {
+			return 0, false
+		}
+		return *row.CPUPercent, true
+	case sortByMemory:
+		if row.MemoryBytes == nil {
+			return 0, false
+		}
+		return float64(*row.MemoryBytes), true
+	default:
+		return 0, true
+	}
+}
+
+func printCellTop(cmd *cobra.Command, rows []cellTop, format shared.OutputFormat, flags sampleFlags) error {
+	switch format {
+	case shared.OutputFormatYAML:
+		return shared.PrintYAML(cmd, rows)
+	case shared.OutputFormatJSON:
+		return shared.PrintJSON(cmd, rows)
+	default:
+		if len(rows) == 0 {
+			shared.PrintEmpty(cmd, "No cells found.")
+			return nil
+		}
+		headers := []string{"NAME", "REALM", "SPACE", "STACK", "STATE", "CPU %", "MEMORY", "MEMORY %", "PIDS"}
+		table := make([][]string, 0, len(rows))
+		for _, row := range rows {
+			state := row.State
+			table = append(table, []string{
+				row.Cell,
+				row.Realm,
+				row.Space,
+				row.Stack,
+				state.String(),
+				formatPercentPtr(row.CPUPercent, flags.cpuThreshold),
+				formatBytes(row.MemoryBytes),
+				formatPercentPtr(row.MemoryPercent, flags.memoryThreshold),
+				formatCount(row.Pids),
+			})
+		}
+		shared.PrintTable(cmd, headers, table)
+		return nil
+	}
+}
+
+func formatPercentPtr(v *float64, threshold float64) string {
+	if v == nil {
+		return formatPercent(0, false, threshold)
+	}
+	return formatPercent(*v, true, threshold)
+}
@@ -0,0 +1,202 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package top_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	toppkg "github.com/eminwux/kukeon/cmd/kuke/top"
+	"github.com/eminwux/kukeon/cmd/types"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+	"github.com/spf13/viper"
+)
+
+// cellSamples returns a ListCellUsage fake whose CPU counters advance by
+// each cell's step on every call, so two samples yield a steady load.
+func cellSamples(steps map[string]uint64) func(string, string, string) ([]kukeonv1.CellUsage, error) {
+	var calls uint64
+	return func(string, string, string) ([]kukeonv1.CellUsage, error) {
+		calls++
+		out := []kukeonv1.CellUsage{
+			{
+				Realm: "main", Space: "app", Stack: "web", Cell: "api",
+				State: v1beta1.CellStateReady, Provisioned: true,
+				MemoryBytes: uint64Ptr(32 << 20), MemoryLimitBytes: uint64Ptr(64 << 20),
+				CPUUsageUsec: uint64Ptr(calls * steps["api"]), Pids: uint64Ptr(4),
+			},
+			{
+				Realm: "main", Space: "app", Stack: "web", Cell: "worker",
+				State: v1beta1.CellStateReady, Provisioned: true,
+				MemoryBytes:  uint64Ptr(128 << 20),
+				CPUUsageUsec: uint64Ptr(calls * steps["worker"]), Pids: uint64Ptr(9),
+			},
+		}
+		return out, nil
+	}
+}
+
+func TestCellCmd(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		fake       *fakeClient
+		wantErr    string
+		wantOutput []string
+		wantOrder  []string
+	}{
+		{
+			name: "table",
+			args: []string{"cell", "--interval", "10ms"},
+			fake: &fakeClient{listCellUsageFn: cellSamples(map[string]uint64{"api": 1, "worker": 1})},
+			wantOutput: []string{
+				"NAME", "CPU %", "MEMORY %", "PIDS",
+				"api", "32.0 MiB", "50.0%",
+				"worker", "128.0 MiB",
+			},
+		},
+		{
+			name:      "sort by cpu",
+			args:      []string{"cell", "--interval", "10ms", "--sort-by", "cpu"},
+			fake:      &fakeClient{listCellUsageFn: cellSamples(map[string]uint64{"api": 10, "worker": 1_000_000})},
+			wantOrder: []string{"worker", "api"},
+		},
+		{
+			name:      "sort by memory",
+			args:      []string{"cell", "--interval", "10ms", "--sort-by", "memory"},
+			fake:      &fakeClient{listCellUsageFn: cellSamples(map[string]uint64{})},
+			wantOrder: []string{"worker", "api"},
+		},
+		{
+			name:       "memory threshold marks the cell",
+			args:       []string{"cell", "--interval", "10ms", "--memory-threshold", "40"},
+			fake:       &fakeClient{listCellUsageFn: cellSamples(map[string]uint64{})},
+			wantOutput: []string{"50.0%!"},
+		},
+		{
+			name: "scope flags narrow the listing",
+			args: []string{"cell", "--interval", "1ms", "--realm", "main", "--space", "app", "--stack", "web"},
+			fake: &fakeClient{
+				listCellUsageFn: func(realm, space, stack string) ([]kukeonv1.CellUsage, error) {
+					if realm != "main" || space != "app" || stack != "web" {
+						t.Errorf("ListCellUsage(%q, %q, %q), want main/app/web", realm, space, stack)
+					}
+					return nil, nil
+				},
+			},
+			wantOutput: []string{"No cells found."},
+		},
+		{
+			name:       "json",
+			args:       []string{"cell", "--interval", "10ms", "-o", "json"},
+			fake:       &fakeClient{listCellUsageFn: cellSamples(map[string]uint64{"api": 1000})},
+			wantOutput: []string{`"cell": "api"`, `"cpuPercent":`, `"memoryPercent": 50`},
+		},
+		{
+			name:    "invalid sort key",
+			args:    []string{"cell", "--sort-by", "pids"},
+			fake:    &fakeClient{},
+			wantErr: `invalid --sort-by "pids"`,
+		},
+		{
+			name:    "stack without space",
+			args:    []string{"cell", "--realm", "main", "--stack", "web"},
+			fake:    &fakeClient{},
+			wantErr: "--stack requires --space",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			viper.Reset()
+
+			buf := runTop(context.Background(), t, tt.fake, tt.args, tt.wantErr)
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(buf, want) {
+					t.Errorf("output missing %q\nGot:\n%s", want, buf)
+				}
+			}
+			if len(tt.wantOrder) > 0 {
+				last := -1
+				for _, name := range tt.wantOrder {
+					idx := strings.Index(buf, "\n"+name+" ")
+					if idx <= last {
+						t.Fatalf("want rows in order %v\nGot:\n%s", tt.wantOrder, buf)
+					}
+					last = idx
+				}
+			}
+		})
+	}
+}
+
+func TestCellCmd_WatchRefreshesUntilCancelled(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Reset()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	samples := cellSamples(map[string]uint64{"api": 1})
+	var calls int
+	fake := &fakeClient{listCellUsageFn: func(realm, space, stack string) ([]kukeonv1.CellUsage, error) {
+		calls++
+		if calls == 4 {
+			cancel()
+		}
+		return samples(realm, space, stack)
+	}}
+
+	done := make(chan string)
+	go func() { done <- runTop(ctx, t, fake, []string{"cell", "--watch", "--interval", "1ms"}, "") }()
+	select {
+	case out := <-done:
+		if got := strings.Count(out, "NAME"); got != 3 {
+			t.Errorf("rendered %d reports, want 3 (one per sample after the first)\nGot:\n%s", got, out)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("--watch did not stop when the context was cancelled")
+	}
+}
+
+func runTop(ctx context.Context, t *testing.T, fake *fakeClient, args []string, wantErr string) string {
+	t.Helper()
+	cmd := toppkg.NewTopCmd()
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx = context.WithValue(ctx, types.CtxLogger, logger)
+	ctx = context.WithValue(ctx, toppkg.MockControllerKey{}, kukeonv1.Client(fake))
+	cmd.SetContext(ctx)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	if wantErr != "" {
+		if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("want err %q, got %v", wantErr, err)
+		}
+	} else if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	return buf.String()
+}
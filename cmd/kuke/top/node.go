@@ -0,0 +1,175 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package top
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eminwux/kukeon/cmd/kuke/get/shared"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	"github.com/spf13/cobra"
+)
+
+func newNodeCmd() *cobra.Command {
+	var flags sampleFlags
+	cmd := &cobra.Command{
+		Use:   "node",
+		Short: "Summarize the host resources kukeon is consuming",
+		Long: "Report the live memory, cpu, and pids usage of the kukeon root cgroup,\n" +
+			"which aggregates every realm on the host, together with how many realms,\n" +
+			"spaces, stacks, cells, and containers are recorded. The CPU percentage is\n" +
+			"computed from two samples taken --interval apart.",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			outputFormat, err := shared.ParseOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+			if err = flags.validate(); err != nil {
+				return err
+			}
+
+			client, err := resolveClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = client.Close() }()
+
+			sample := func(ctx context.Context) (kukeonv1.NodeSummaryResult, error) {
+				return client.NodeSummary(ctx)
+			}
+			structured := outputFormat == shared.OutputFormatYAML || outputFormat == shared.OutputFormatJSON
+			if structured && !flags.watch {
+				// The summary prints as read; only the table derives a
+				// CPU percentage that needs a second sample.
+				summary, sampleErr := sample(cmd.Context())
+				if sampleErr != nil {
+					return sampleErr
+				}
+				return printNodeSummary(cmd, summary, outputFormat)
+			}
+			return runSampled(cmd, flags, sample,
+				func(prev, cur kukeonv1.NodeSummaryResult, elapsed time.Duration) error {
+					if structured {
+						return printNodeSummary(cmd, cur, outputFormat)
+					}
+					printNodeTable(cmd, prev, cur, elapsed, flags)
+					return nil
+				})
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "", "Output format (yaml, json, table). Default: table")
+	flags.register(cmd)
+
+	return cmd
+}
+
+func printNodeSummary(cmd *cobra.Command, s kukeonv1.NodeSummaryResult, format shared.OutputFormat) error {
+	switch format {
+	case shared.OutputFormatYAML:
+		return shared.PrintYAML(cmd, s)
+	case shared.OutputFormatJSON:
+		return shared.PrintJSON(cmd, s)
+	default:
+		return shared.PrintYAML(cmd, s)
+	}
+}
+
+// printNodeTable renders the summary as one aligned line per figure, with
+// the CPU percentage taken over the elapsed time since prev. Counters the
+// cgroup does not expose print as "-".
+func printNodeTable(
+	cmd *cobra.Command,
+	prev, s kukeonv1.NodeSummaryResult,
+	elapsed time.Duration,
+	flags sampleFlags,
+) {
+	cgroup := "not provisioned"
+	if s.Provisioned {
+		cgroup = s.CgroupRoot
+	}
+	memory := formatBytes(s.MemoryBytes)
+	if s.Provisioned {
+		limit := "unlimited"
+		if s.MemoryLimitBytes != nil {
+			limit = formatBytes(s.MemoryLimitBytes)
+		}
+		memory += " / " + limit
+		if pct, ok := memoryPercent(s.MemoryBytes, s.MemoryLimitBytes); ok {
+			memory += " (" + formatPercent(pct, ok, flags.memoryThreshold) + ")"
+		}
+	}
+	cpu := "-"
+	if s.CPUUsageUsec != nil {
+		cpu = (time.Duration(*s.CPUUsageUsec) * time.Microsecond).Round(time.Millisecond).String()
+	}
+
+	cpuPct, cpuOK := cpuPercent(prev.CPUUsageUsec, s.CPUUsageUsec, elapsed)
+
+	rows := [][]string{
+		{"CGROUP", cgroup},
+		{"MEMORY", memory},
+		{"CPU", cpu},
+		{"CPU %", formatPercent(cpuPct, cpuOK, flags.cpuThreshold)},
+		{"PIDS", formatCount(s.Pids)},
+		{"OOM KILLS", formatCount(s.OOMKills)},
+		{"REALMS", fmt.Sprint(s.Realms)},
+		{"SPACES", fmt.Sprint(s.Spaces)},
+		{"STACKS", fmt.Sprint(s.Stacks)},
+		{"CELLS", fmt.Sprint(s.Cells)},
+		{"CONTAINERS", fmt.Sprint(s.Containers)},
+	}
+	for _, row := range rows {
+		cmd.Printf("%-11s %s\n", row[0], row[1])
+	}
+}
+
+func formatCount(v *uint64) string {
+	if v == nil {
+		return "-"
+	}
+	return fmt.Sprint(*v)
+}
+
+// formatBytes renders a byte count with a binary-IEC unit suffix, or "-"
+// when the counter was not read.
+func formatBytes(v *uint64) string {
+	if v == nil {
+		return "-"
+	}
+	const (
+		kib = uint64(1024)
+		mib = kib * 1024
+		gib = mib * 1024
+	)
+	n := *v
+	switch {
+	case n >= gib:
+		return fmt.Sprintf("%.1f GiB", float64(n)/float64(gib))
+	case n >= mib:
+		return fmt.Sprintf("%.1f MiB", float64(n)/float64(mib))
+	case n >= kib:
+		return fmt.Sprintf("%.1f KiB", float64(n)/float64(kib))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package top_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	toppkg "github.com/eminwux/kukeon/cmd/kuke/top"
+	"github.com/eminwux/kukeon/cmd/types"
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+	"github.com/spf13/viper"
+)
+
+func uint64Ptr(v uint64) *uint64 { return &v }
+
+func TestNodeCmd(t *testing.T) {
+	provisioned := kukeonv1.NodeSummaryResult{
+		CgroupRoot:   "/kukeon",
+		Provisioned:  true,
+		MemoryBytes:  uint64Ptr(64 << 20),
+		CPUUsageUsec: uint64Ptr(2_500_000),
+		Pids:         uint64Ptr(12),
+		Realms:       2,
+		Spaces:       3,
+		Stacks:       2,
+		Cells:        4,
+		Containers:   7,
+	}
+	tests := []struct {
+		name       string
+		args       []string
+		fake       *fakeClient
+		wantErr    string
+		wantOutput []string
+	}{
+		{
+			name: "table",
+			args: []string{"node", "--interval", "1ms"},
+			fake: &fakeClient{nodeSummaryFn: func() (kukeonv1.NodeSummaryResult, error) {
+				return provisioned, nil
+			}},
+			wantOutput: []string{
+				"CGROUP      /kukeon",
+				"MEMORY      64.0 MiB / unlimited",
+				"CPU         2.5s",
+				"PIDS        12",
+				"OOM KILLS   -",
+				"REALMS      2",
+				"CONTAINERS  7",
+			},
+		},
+		{
+			name: "not provisioned",
+			args: []string{"node", "--interval", "1ms"},
+			fake: &fakeClient{nodeSummaryFn: func() (kukeonv1.NodeSummaryResult, error) {
+				return kukeonv1.NodeSummaryResult{CgroupRoot: "/kukeon"}, nil
+			}},
+			wantOutput: []string{"CGROUP      not provisioned", "MEMORY      -\n", "CPU %       -\n", "REALMS      0"},
+		},
+		{
+			name: "cpu percentage and thresholds from two samples",
+			args: []string{"node", "--interval", "10ms", "--cpu-threshold", "50", "--memory-threshold", "50"},
+			fake: func() *fakeClient {
+				var calls uint64
+				return &fakeClient{nodeSummaryFn: func() (kukeonv1.NodeSummaryResult, error) {
+					calls++
+					s := provisioned
+					s.MemoryLimitBytes = uint64Ptr(256 << 20)
+					// A whole CPU second between the samples is far more
+					// than the interval allows, so the percentage is high.
+					s.CPUUsageUsec = uint64Ptr(calls * 1_000_000)
+					return s, nil
+				}}
+			}(),
+			wantOutput: []string{"MEMORY      64.0 MiB / 256.0 MiB (25.0%)\n", "%!\n"},
+		},
+		{
+			name: "json",
+			args: []string{"node", "-o", "json"},
+			fake: &fakeClient{nodeSummaryFn: func() (kukeonv1.NodeSummaryResult, error) {
+				return provisioned, nil
+			}},
+			wantOutput: []string{`"memoryBytes": 67108864`, `"containers": 7`},
+		},
+		{
+			name: "error",
+			args: []string{"node"},
+			fake: &fakeClient{nodeSummaryFn: func() (kukeonv1.NodeSummaryResult, error) {
+				return kukeonv1.NodeSummaryResult{}, errors.New("boom")
+			}},
+			wantErr: "boom",
+		},
+		{
+			name:    "invalid interval",
+			args:    []string{"node", "--interval", "0s"},
+			fake:    &fakeClient{},
+			wantErr: "invalid --interval",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			viper.Reset()
+
+			cmd := toppkg.NewTopCmd()
+			buf := &bytes.Buffer{}
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			ctx := context.WithValue(context.Background(), types.CtxLogger, logger)
+			ctx = context.WithValue(ctx, toppkg.MockControllerKey{}, kukeonv1.Client(tt.fake))
+			cmd.SetContext(ctx)
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("want err %q, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q\nGot:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+type fakeClient struct {
+	kukeonv1.FakeClient
+
+	nodeSummaryFn   func() (kukeonv1.NodeSummaryResult, error)
+	listCellUsageFn func(realm, space, stack string) ([]kukeonv1.CellUsage, error)
+}
+
+func (f *fakeClient) NodeSummary(context.Context) (kukeonv1.NodeSummaryResult, error) {
+	if f.nodeSummaryFn == nil {
+		return kukeonv1.NodeSummaryResult{}, errors.New("unexpected NodeSummary call")
+	}
+	return f.nodeSummaryFn()
+}
+
+func (f *fakeClient) ListCellUsage(_ context.Context, realm, space, stack string) ([]kukeonv1.CellUsage, error) {
+	if f.listCellUsageFn == nil {
+		return nil, errors.New("unexpected ListCellUsage call")
+	}
+	return f.listCellUsageFn(realm, space, stack)
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package top
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const (
+	defaultInterval = time.Second
+	// thresholdMark follows a figure that crossed its --cpu-threshold or
+	// --memory-threshold.
+	thresholdMark = "!"
+	// clearScreen homes the cursor and clears a terminal between --watch
+	// refreshes.
+	clearScreen = "\033[H\033[2J"
+)
+
+// sampleFlags are the flags every `kuke top` report shares.
+type sampleFlags struct {
+	interval        time.Duration
+	watch           bool
+	cpuThreshold    float64
+	memoryThreshold float64
+}
+
+func (f *sampleFlags) register(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&f.interval, "interval", defaultInterval,
+		"Time between the two samples a CPU percentage is computed from, and between --watch refreshes")
+	cmd.Flags().BoolVarP(&f.watch, "watch", "w", false, "Keep refreshing every --interval until interrupted")
+	cmd.Flags().Float64Var(&f.cpuThreshold, "cpu-threshold", 0,
+		"Mark CPU usage at or above this percentage of one CPU with "+thresholdMark+" (0 disables)")
+	cmd.Flags().Float64Var(&f.memoryThreshold, "memory-threshold", 0,
+		"Mark memory usage at or above this percentage of the memory limit with "+thresholdMark+" (0 disables)")
+}
+
+func (f *sampleFlags) validate() error {
+	if f.interval <= 0 {
+		return fmt.Errorf("invalid --interval %s: must be positive", f.interval)
+	}
+	if f.cpuThreshold < 0 {
+		return fmt.Errorf("invalid --cpu-threshold %g: must not be negative", f.cpuThreshold)
+	}
+	if f.memoryThreshold < 0 {
+		return fmt.Errorf("invalid --memory-threshold %g: must not be negative", f.memoryThreshold)
+	}
+	return nil
+}
+
+// sample reads the counters a report is rendered from, and render prints
+// one report from two consecutive samples taken elapsed apart.
+type (
+	sampleFunc[T any] func(ctx context.Context) (T, error)
+	renderFunc[T any] func(prev, cur T, elapsed time.Duration) error
+)
+
+// runSampled takes a first sample, waits an interval, and renders the
+// second against it. With --watch it keeps sampling and rendering every
+// interval, each report against the one before, until the command's
+// context is cancelled. A terminal is cleared between reports.
+func runSampled[T any](cmd *cobra.Command, f sampleFlags, sample sampleFunc[T], render renderFunc[T]) error {
+	ctx := cmd.Context()
+	prev, err := sample(ctx)
+	if err != nil {
+		return err
+	}
+	prevAt := time.Now()
+	for first := true; first || f.watch; first = false {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(f.interval):
+		}
+		cur, sampleErr := sample(ctx)
+		if sampleErr != nil {
+			return sampleErr
+		}
+		now := time.Now()
+		if f.watch && isTerminal(cmd) {
+			cmd.Print(clearScreen)
+		}
+		if err = render(prev, cur, now.Sub(prevAt)); err != nil {
+			return err
+		}
+		if f.watch && !isTerminal(cmd) {
+			cmd.Println()
+		}
+		prev, prevAt = cur, now
+	}
+	return nil
+}
+
+func isTerminal(cmd *cobra.Command) bool {
+	out, ok := cmd.OutOrStdout().(*os.File)
+	return ok && term.IsTerminal(int(out.Fd()))
+}
+
+// cpuPercent turns two cumulative cpu.stat usage_usec readings taken
+// elapsed apart into a percentage of one CPU, so a cgroup busy on two CPUs
+// reports 200. It reports false when either reading is missing, no time
+// passed, or the counter went backwards because the cgroup was recreated
+// between the samples.
+func cpuPercent(prev, cur *uint64, elapsed time.Duration) (float64, bool) {
+	if prev == nil || cur == nil || elapsed <= 0 || *cur < *prev {
+		return 0, false
+	}
+	const hundred = 100
+	return float64(*cur-*prev) / (float64(elapsed) / float64(time.Microsecond)) * hundred, true
+}
+
+// memoryPercent is used as a percentage of limit, false when either is
+// unknown or the cgroup is unlimited.
+func memoryPercent(used, limit *uint64) (float64, bool) {
+	if used == nil || limit == nil || *limit == 0 {
+		return 0, false
+	}
+	const hundred = 100
+	return float64(*used) / float64(*limit) * hundred, true
+}
+
+// formatPercent renders a percentage with one decimal, marked when it
+// reaches a non-zero threshold, or "-" when it is unknown.
+func formatPercent(v float64, ok bool, threshold float64) string {
+	if !ok {
+		return "-"
+	}
+	s := fmt.Sprintf("%.1f%%", v)
+	if threshold > 0 && v >= threshold {
+		s += thresholdMark
+	}
+	return s
+}
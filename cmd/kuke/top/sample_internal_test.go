@@ -0,0 +1,164 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package top
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
+)
+
+func usec(v uint64) *uint64 { return &v }
+
+func TestCPUPercent(t *testing.T) {
+	tests := []struct {
+		name      string
+		prev, cur *uint64
+		elapsed   time.Duration
+		want      float64
+		wantOK    bool
+	}{
+		{
+			name:    "half a CPU over one second",
+			prev:    usec(1_000_000),
+			cur:     usec(1_500_000),
+			elapsed: time.Second,
+			want:    50,
+			wantOK:  true,
+		},
+		{
+			name:    "two busy CPUs over half a second",
+			prev:    usec(10_000_000),
+			cur:     usec(11_000_000),
+			elapsed: 500 * time.Millisecond,
+			want:    200,
+			wantOK:  true,
+		},
+		{
+			name:    "idle",
+			prev:    usec(42),
+			cur:     usec(42),
+			elapsed: time.Second,
+			want:    0,
+			wantOK:  true,
+		},
+		{
+			name:    "counter went backwards after the cgroup was recreated",
+			prev:    usec(9_000_000),
+			cur:     usec(100),
+			elapsed: time.Second,
+		},
+		{
+			name:    "first sample missing",
+			cur:     usec(100),
+			elapsed: time.Second,
+		},
+		{
+			name:    "second sample missing",
+			prev:    usec(100),
+			elapsed: time.Second,
+		},
+		{
+			name: "no time passed",
+			prev: usec(100),
+			cur:  usec(200),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := cpuPercent(tt.prev, tt.cur, tt.elapsed)
+			if ok != tt.wantOK {
+				t.Fatalf("cpuPercent() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("cpuPercent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCellTopRows(t *testing.T) {
+	cell := func(name string, cpu *uint64) kukeonv1.CellUsage {
+		return kukeonv1.CellUsage{
+			Realm: "main", Space: "app", Stack: "web", Cell: name,
+			Provisioned: cpu != nil, CPUUsageUsec: cpu,
+		}
+	}
+	prev := []kukeonv1.CellUsage{
+		cell("api", usec(1_000_000)),
+		cell("gone", usec(5_000_000)),
+		cell("restarted", usec(8_000_000)),
+	}
+	cur := []kukeonv1.CellUsage{
+		cell("api", usec(1_250_000)),
+		cell("restarted", usec(10_000)),
+		cell("new", usec(300_000)),
+	}
+
+	rows := cellTopRows(prev, cur, time.Second)
+	if len(rows) != 3 {
+		t.Fatalf("cellTopRows() returned %d rows, want 3 (the deleted cell dropped)", len(rows))
+	}
+	byName := map[string]cellTop{}
+	for _, row := range rows {
+		byName[row.Cell] = row
+	}
+	if _, ok := byName["gone"]; ok {
+		t.Error("cell deleted between samples is still listed")
+	}
+	if p := byName["api"].CPUPercent; p == nil || math.Abs(*p-25) > 1e-9 {
+		t.Errorf("api CPUPercent = %v, want 25", p)
+	}
+	if p := byName["restarted"].CPUPercent; p != nil {
+		t.Errorf("restarted CPUPercent = %v, want nil after its counter reset", *p)
+	}
+	if p := byName["new"].CPUPercent; p != nil {
+		t.Errorf("new CPUPercent = %v, want nil with only one sample", *p)
+	}
+}
+
+func TestSortCellTop(t *testing.T) {
+	pct := func(v float64) *float64 { return &v }
+	rows := []cellTop{
+		{CellUsage: kukeonv1.CellUsage{Cell: "a", MemoryBytes: usec(10)}, CPUPercent: pct(5)},
+		{CellUsage: kukeonv1.CellUsage{Cell: "b", MemoryBytes: usec(30)}},
+		{CellUsage: kukeonv1.CellUsage{Cell: "c"}, CPUPercent: pct(80)},
+		{CellUsage: kukeonv1.CellUsage{Cell: "d", MemoryBytes: usec(20)}, CPUPercent: pct(40)},
+	}
+	names := func() string {
+		s := ""
+		for _, r := range rows {
+			s += r.Cell
+		}
+		return s
+	}
+
+	sortCellTop(rows, sortByCPU)
+	if got := names(); got != "cdab" {
+		t.Errorf("sorted by cpu = %s, want cdab", got)
+	}
+	sortCellTop(rows, sortByMemory)
+	if got := names(); got != "bdac" {
+		t.Errorf("sorted by memory = %s, want bdac", got)
+	}
+	sortCellTop(rows, "")
+	if got := names(); got != "abcd" {
+		t.Errorf("default order = %s, want abcd", got)
+	}
+}
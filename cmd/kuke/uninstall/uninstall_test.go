@@ -144,6 +144,21 @@ func (s *stubController) PurgeStack(intmodel.Stack, bool, bool) (controller.Purg
 func (s *stubController) PurgeCell(intmodel.Cell, bool, bool) (controller.PurgeCellResult, error) {
 	panic("not used")
 }
+func (s *stubController) RenameRealm(intmodel.Realm, string) (controller.RenameRealmResult, error) {
+	panic("not used")
+}
+func (s *stubController) RenameSpace(intmodel.Space, string) (controller.RenameSpaceResult, error) {
+	panic("not used")
+}
+func (s *stubController) RenameStack(intmodel.Stack, string) (controller.RenameStackResult, error) {
+	panic("not used")
+}
+func (s *stubController) RenameCell(intmodel.Cell, string) (controller.RenameCellResult, error) {
+	panic("not used")
+}
+func (s *stubController) ExportRealm(string, bool) (controller.ExportRealmResult, error) {
+	panic("not used")
+}
 func (s *stubController) RefreshAll() (controller.RefreshResult, error) { panic("not used") }
 func (s *stubController) ReconcileCells() (controller.ReconcileResult, error) {
 	panic("not used")
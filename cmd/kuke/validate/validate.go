@@ -0,0 +1,94 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	kukshared "github.com/eminwux/kukeon/cmd/kuke/shared"
+	"github.com/eminwux/kukeon/internal/apply/lint"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/spf13/cobra"
+)
+
+// NewValidateCmd builds the `kuke validate` cobra command. It lints a
+// manifest locally — no daemon, no store — so it can gate a commit or a CI
+// job before the same file reaches `kuke apply`.
+func NewValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate -f <file>",
+		Short: "Check resource definitions in a YAML file without applying them",
+		Long: "Parse and validate every document in a YAML file or stdin (-f) the way apply would, " +
+			"and resolve references between the documents, without contacting the daemon. " +
+			"Prints every problem found and exits non-zero on any error.",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE:          runValidate,
+	}
+
+	cmd.Flags().StringP("file", "f", "", "File to read YAML from (use - for stdin)")
+	cmd.Flags().Bool("strict", false,
+		"Treat a reference to a realm, space, stack or cell the file does not declare as an error")
+
+	return cmd
+}
+
+func runValidate(cmd *cobra.Command, _ []string) error {
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return err
+	}
+	strict, err := cmd.Flags().GetBool("strict")
+	if err != nil {
+		return err
+	}
+	if file == "" {
+		return errors.New("file flag is required (use -f <file> or -f - for stdin)")
+	}
+
+	reader, cleanup, err := kukshared.ReadFileOrStdin(file)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cleanup() }()
+
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("failed to read input: %w", err)
+	}
+
+	report, err := lint.Lint(raw, lint.Options{Strict: strict})
+	if err != nil {
+		return fmt.Errorf("%w: %w", errdefs.ErrManifestInvalid, err)
+	}
+
+	for _, problem := range report.Errors {
+		cmd.Printf("error: %s\n", problem)
+	}
+	for _, problem := range report.Warnings {
+		cmd.Printf("warning: %s\n", problem)
+	}
+	if !report.OK() {
+		return fmt.Errorf("%w: %d error(s) in %d document(s)",
+			errdefs.ErrManifestInvalid, len(report.Errors), report.Documents)
+	}
+	cmd.Printf("%d document(s) valid\n", report.Documents)
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validate_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	validate "github.com/eminwux/kukeon/cmd/kuke/validate"
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+func writeTempYAML(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write temp yaml: %v", err)
+	}
+	return path
+}
+
+func TestValidateRunE(t *testing.T) {
+	const stack = `apiVersion: v1beta1
+kind: Stack
+metadata:
+  name: web
+spec:
+  realmId: shop
+  spaceId: apps
+`
+	tests := []struct {
+		name       string
+		args       []string
+		wantIs     error
+		wantErr    string
+		wantOutput []string
+	}{
+		{
+			name:    "no file flag",
+			wantErr: "file flag is required",
+		},
+		{
+			name:       "warning only",
+			args:       []string{"-f", writeTempYAML(t, stack)},
+			wantOutput: []string{`warning: document 0 (Stack "web"): references realm "shop"`, "1 document(s) valid"},
+		},
+		{
+			name:       "strict turns the warning into an error",
+			args:       []string{"-f", writeTempYAML(t, stack), "--strict"},
+			wantIs:     errdefs.ErrManifestInvalid,
+			wantOutput: []string{`error: document 0 (Stack "web"): references realm "shop"`},
+		},
+		{
+			name: "every error is printed",
+			args: []string{"-f", writeTempYAML(t,
+				"apiVersion: v1beta1\nkind: Space\nmetadata:\n  name: a_b\n---\n"+
+					"apiVersion: v1beta1\nkind: Realm\nmetadata:\n  name: ''\n")},
+			wantIs: errdefs.ErrManifestInvalid,
+			wantOutput: []string{
+				`error: document 0 (Space "a_b"): spec.realmId is required`,
+				"error: document 1 (Realm): metadata.name is required",
+			},
+		},
+		{
+			name:   "empty input",
+			args:   []string{"-f", writeTempYAML(t, "\n")},
+			wantIs: errdefs.ErrManifestInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := validate.NewValidateCmd()
+			buf := &bytes.Buffer{}
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			switch {
+			case tt.wantIs != nil:
+				if !errors.Is(err, tt.wantIs) {
+					t.Fatalf("err = %v, want %v", err, tt.wantIs)
+				}
+			case tt.wantErr != "":
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+			case err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q\nGot:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/eminwux/kukeon/cmd/config"
 	"github.com/eminwux/kukeon/internal/cni"
 	"github.com/eminwux/kukeon/internal/consts"
+	"github.com/eminwux/kukeon/internal/ctr"
 	"github.com/eminwux/kukeon/internal/serverconfig"
 	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
 	"github.com/spf13/cobra"
@@ -103,6 +104,17 @@ func NewKukeondCmd() (*cobra.Command, error) {
 		return nil, err
 	}
 
+	cmd.PersistentFlags().Duration(
+		"containerd-timeout", ctr.DefaultConnectTimeout,
+		"Timeout for connecting to the containerd socket",
+	)
+	if err := viper.BindPFlag(
+		config.KUKEON_ROOT_CONTAINERD_TIMEOUT.ViperKey,
+		cmd.PersistentFlags().Lookup("containerd-timeout"),
+	); err != nil {
+		return nil, err
+	}
+
 	cmd.PersistentFlags().String(
 		"socket", config.KUKEOND_SOCKET.Default,
 		"Unix socket path the daemon listens on",
@@ -138,6 +150,17 @@ func NewKukeondCmd() (*cobra.Command, error) {
 		return nil, err
 	}
 
+	cmd.PersistentFlags().String(
+		"log-format", config.KUKEON_ROOT_LOG_FORMAT.Default,
+		"Log format (text, json)",
+	)
+	if err := viper.BindPFlag(
+		config.KUKEON_ROOT_LOG_FORMAT.ViperKey,
+		cmd.PersistentFlags().Lookup("log-format"),
+	); err != nil {
+		return nil, err
+	}
+
 	cmd.PersistentFlags().String(
 		"reconcile-interval", config.KUKEOND_RECONCILE_INTERVAL.Default,
 		"Period of the cell-reconciliation loop (Go duration; 0 disables)",
@@ -149,6 +172,53 @@ func NewKukeondCmd() (*cobra.Command, error) {
 		return nil, err
 	}
 
+	reconcileConcurrencyDefault, _ := strconv.Atoi(config.KUKEOND_RECONCILE_CONCURRENCY.Default)
+	cmd.PersistentFlags().Int(
+		"reconcile-concurrency", reconcileConcurrencyDefault,
+		"Maximum number of cells one reconcile pass works on in parallel (1 reconciles sequentially)",
+	)
+	if err := viper.BindPFlag(
+		config.KUKEOND_RECONCILE_CONCURRENCY.ViperKey,
+		cmd.PersistentFlags().Lookup("reconcile-concurrency"),
+	); err != nil {
+		return nil, err
+	}
+
+	cmd.PersistentFlags().String(
+		"drain-timeout", config.KUKEOND_DRAIN_TIMEOUT.Default,
+		"How long shutdown waits for an in-flight reconcile pass to finish "+
+			"(Go duration; 0 waits indefinitely)",
+	)
+	if err := viper.BindPFlag(
+		config.KUKEOND_DRAIN_TIMEOUT.ViperKey,
+		cmd.PersistentFlags().Lookup("drain-timeout"),
+	); err != nil {
+		return nil, err
+	}
+
+	backupRetentionDefault, _ := strconv.Atoi(config.KUKEOND_BACKUP_RETENTION.Default)
+	cmd.PersistentFlags().Int(
+		"backup-retention", backupRetentionDefault,
+		"Number of metadata backups kept under <run-path>/backups before the oldest are removed",
+	)
+	if err := viper.BindPFlag(
+		config.KUKEOND_BACKUP_RETENTION.ViperKey,
+		cmd.PersistentFlags().Lookup("backup-retention"),
+	); err != nil {
+		return nil, err
+	}
+
+	cmd.PersistentFlags().Bool(
+		"require-backup", false,
+		"Abort a realm purge or cascade delete when its metadata backup cannot be written",
+	)
+	if err := viper.BindPFlag(
+		config.KUKEOND_REQUIRE_BACKUP.ViperKey,
+		cmd.PersistentFlags().Lookup("require-backup"),
+	); err != nil {
+		return nil, err
+	}
+
 	cmd.PersistentFlags().String(
 		"containerd-namespace-suffix", config.KUKEON_ROOT_NAMESPACE_SUFFIX.Default,
 		"Suffix appended to every realm name to form its containerd namespace "+
@@ -256,16 +326,21 @@ func bindEnvVars() {
 		config.KUKEON_ROOT_CONTAINERD_SOCKET,
 		config.KUKEON_ROOT_RUN_PATH,
 		config.KUKEON_ROOT_LOG_LEVEL,
+		config.KUKEON_ROOT_LOG_FORMAT,
 		config.KUKEON_ROOT_NAMESPACE_SUFFIX,
 		config.KUKEON_ROOT_CGROUP_ROOT,
 		config.KUKEON_ROOT_POD_SUBNET_CIDR,
 		config.KUKEOND_SOCKET,
 		config.KUKEOND_SOCKET_GID,
 		config.KUKEOND_RECONCILE_INTERVAL,
+		config.KUKEOND_RECONCILE_CONCURRENCY,
+		config.KUKEOND_DRAIN_TIMEOUT,
 		config.KUKEOND_DEFAULT_MEMORY_LIMIT_BYTES,
 		config.KUKEOND_KUKETTY_LOG_LEVEL,
 		config.KUKEOND_DISK_PRESSURE_WARN_PCT,
 		config.KUKEOND_DISK_PRESSURE_BLOCK_PCT,
+		config.KUKEOND_BACKUP_RETENTION,
+		config.KUKEOND_REQUIRE_BACKUP,
 	} {
 		_ = v.BindEnv()
 	}
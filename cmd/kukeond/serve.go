@@ -58,10 +58,18 @@ func runServe(cmd *cobra.Command, _ []string) error {
 	if logLevel == "" {
 		logLevel = "info"
 	}
+	logFormat, err := logging.ParseFormat(viper.GetString(config.KUKEON_ROOT_LOG_FORMAT.ViperKey))
+	if err != nil {
+		return err
+	}
 	levelVar := new(slog.LevelVar)
 	levelVar.Set(logging.ParseLevel(logLevel))
-	handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: levelVar})
-	logger := slog.New(handler)
+	var logger *slog.Logger
+	if logFormat == logging.FormatJSON {
+		logger = logging.NewJSONLogger(os.Stderr, levelVar)
+	} else {
+		logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: levelVar}))
+	}
 
 	ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
@@ -91,6 +99,7 @@ func runServe(cmd *cobra.Command, _ []string) error {
 	}
 
 	reconcileInterval := parseReconcileInterval(logger, cmd.Context())
+	drainTimeout := parseDrainTimeout(logger, cmd.Context())
 
 	defaultMemoryLimitBytes := viper.GetInt64(config.KUKEOND_DEFAULT_MEMORY_LIMIT_BYTES.ViperKey)
 	if defaultMemoryLimitBytes < 0 {
@@ -118,9 +127,11 @@ func runServe(cmd *cobra.Command, _ []string) error {
 		SocketGID:         socketGID,
 		PIDFile:           filepath.Join(filepath.Dir(socketPath), "kukeond.pid"),
 		ReconcileInterval: reconcileInterval,
+		DrainTimeout:      drainTimeout,
 		Controller: controller.Options{
-			RunPath:          runPath,
-			ContainerdSocket: viper.GetString(config.KUKEON_ROOT_CONTAINERD_SOCKET.ViperKey),
+			RunPath:           runPath,
+			ContainerdSocket:  viper.GetString(config.KUKEON_ROOT_CONTAINERD_SOCKET.ViperKey),
+			ContainerdTimeout: viper.GetDuration(config.KUKEON_ROOT_CONTAINERD_TIMEOUT.ViperKey),
 			// Forward the kukeon group GID into the runner so per-container
 			// Attachable tty directories created by daemon-mediated apply/start
 			// inherit the same group-traversal layout `kuke init` applies to
@@ -139,6 +150,12 @@ func runServe(cmd *cobra.Command, _ []string) error {
 			// the guard refuses new cell creation but never deletes data.
 			DiskPressureWarnPercent:  diskPressureWarnPct,
 			DiskPressureBlockPercent: diskPressureBlockPct,
+			// Worker-pool width of each reconcile pass. Cells are still
+			// serialized against user commands by the per-cell lifecycle lock.
+			ReconcileConcurrency: viper.GetInt(config.KUKEOND_RECONCILE_CONCURRENCY.ViperKey),
+			// Metadata backups taken before a realm purge or cascade delete.
+			BackupRetention: viper.GetInt(config.KUKEOND_BACKUP_RETENTION.ViperKey),
+			RequireBackup:   viper.GetBool(config.KUKEOND_REQUIRE_BACKUP.ViperKey),
 		},
 	}
 
@@ -149,7 +166,8 @@ func runServe(cmd *cobra.Command, _ []string) error {
 
 	select {
 	case <-ctx.Done():
-		logger.InfoContext(ctx, "received shutdown signal", "signal", ctx.Err())
+		logger.InfoContext(ctx, "received shutdown signal; draining reconcile loop",
+			"signal", ctx.Err(), "drain_timeout", drainTimeout)
 	case err := <-serveErr:
 		if err != nil {
 			logger.ErrorContext(cmd.Context(), "serve exited with error", "error", err)
@@ -187,3 +205,24 @@ func parseReconcileInterval(logger *slog.Logger, ctx context.Context) time.Durat
 	}
 	return d
 }
+
+// parseDrainTimeout reads the resolved drain-timeout string out of viper
+// and parses it as a Go time.Duration. Mirrors parseReconcileInterval: an
+// empty value or a parse failure logs a warning and falls back to the
+// in-binary default. Zero or negative waits for the in-flight pass
+// indefinitely.
+func parseDrainTimeout(logger *slog.Logger, ctx context.Context) time.Duration {
+	raw := viper.GetString(config.KUKEOND_DRAIN_TIMEOUT.ViperKey)
+	if raw == "" {
+		raw = config.KUKEOND_DRAIN_TIMEOUT.Default
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		fallback, _ := time.ParseDuration(config.KUKEOND_DRAIN_TIMEOUT.Default)
+		logger.WarnContext(ctx,
+			"invalid drain-timeout; falling back to default",
+			"value", raw, "error", err, "fallback", fallback)
+		return fallback
+	}
+	return d
+}
@@ -18,14 +18,18 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/eminwux/kukeon/cmd/kuke"
 	"github.com/eminwux/kukeon/cmd/kukeond"
 	"github.com/eminwux/kukeon/cmd/types"
+	"github.com/eminwux/kukeon/internal/errdefs"
 	"github.com/eminwux/kukeon/internal/logging"
+	"github.com/eminwux/kukeon/internal/tracing"
 	"github.com/spf13/cobra"
 )
 
@@ -46,18 +50,77 @@ func getFactories(ctx context.Context) factoryMap {
 	}
 }
 
+// execRoot runs root and returns the process exit code. It prints the
+// error itself rather than leaving it to cobra so a command cut short by
+// --timeout reports the timeout, not the primitive the deadline
+// interrupted; a command that sets SilenceErrors still prints nothing.
 func execRoot(root *cobra.Command) int {
-	if err := root.Execute(); err != nil {
-		return 1
+	silenceErrors := root.SilenceErrors
+	root.SilenceErrors = true
+	cmd, err := root.ExecuteC()
+	root.SilenceErrors = silenceErrors
+	defer releaseTimeout(cmd)
+	if err == nil {
+		return exitOK
 	}
-	return 0
+	err = timeoutError(cmd.Context(), err)
+	if !root.SilenceErrors && !cmd.SilenceErrors {
+		cmd.PrintErrln(cmd.ErrPrefix(), err.Error())
+	}
+	return exitCodeFor(err)
 }
 
+// releaseTimeout stops the --timeout timer the kuke root armed on the leaf
+// command's context. It runs after the error is reported, so the cause of a
+// deadline that already fired is still ErrCommandTimeout.
+func releaseTimeout(cmd *cobra.Command) {
+	if cmd == nil || cmd.Context() == nil {
+		return
+	}
+	if cancel, ok := cmd.Context().Value(types.CtxCancel).(context.CancelFunc); ok {
+		cancel()
+	}
+}
+
+// timeoutError puts the --timeout cause in front of err when the command's
+// context expired with errdefs.ErrCommandTimeout, so both the message and
+// the exit code name the timeout.
+func timeoutError(ctx context.Context, err error) error {
+	if ctx == nil || errors.Is(err, errdefs.ErrCommandTimeout) {
+		return err
+	}
+	if cause := context.Cause(ctx); errors.Is(cause, errdefs.ErrCommandTimeout) {
+		return fmt.Errorf("%w: %w", cause, err)
+	}
+	return err
+}
+
+// traceShutdownTimeout bounds the final span flush so an unreachable
+// collector cannot hold the process open after the command finished.
+const traceShutdownTimeout = 5 * time.Second
+
 func runWithFactory(ctx context.Context, factory rootFactory) int {
 	root, err := factory()
 	if err != nil {
-		return 1
+		return exitCodeFor(err)
+	}
+
+	// Tracing is a no-op unless OTEL_EXPORTER_OTLP_ENDPOINT is set.
+	shutdown, err := tracing.Setup(ctx, root.Name())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "tracing disabled: %v\n", err)
+		shutdown = func(context.Context) error { return nil }
 	}
+	defer func() {
+		flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), traceShutdownTimeout)
+		defer cancel()
+		_ = shutdown(flushCtx)
+	}()
+
+	// Canceling on return releases anything the run derived from ctx, such
+	// as the --timeout deadline.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
 	root.SetContext(ctx)
 	return execRoot(root)
@@ -30,6 +30,7 @@ import (
 	"github.com/eminwux/kukeon/cmd/types"
 	"github.com/eminwux/kukeon/internal/logging"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 func TestExecRoot(t *testing.T) {
@@ -93,6 +94,79 @@ func TestExecRoot(t *testing.T) {
 	}
 }
 
+// nopConfigLoader stands in for the real loader so the kuke root can run
+// without touching the host's configuration.
+type nopConfigLoader struct{}
+
+func (nopConfigLoader) LoadConfig() error { return nil }
+
+func TestExecRoot_Timeout(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	t.Setenv("HOME", t.TempDir())
+
+	root, err := kuke.NewKukeCmd()
+	if err != nil {
+		t.Fatalf("NewKukeCmd() error = %v", err)
+	}
+	// hang stands in for a command stuck on containerd: it only returns
+	// once its context is done, with the bare context error.
+	root.AddCommand(&cobra.Command{
+		Use: "hang",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			<-cmd.Context().Done()
+			return fmt.Errorf("waiting on containerd: %w", cmd.Context().Err())
+		},
+	})
+	var stderr bytes.Buffer
+	root.SetErr(&stderr)
+	root.SetArgs([]string{"hang", "--timeout", "20ms"})
+	root.SetContext(context.WithValue(context.Background(), kuke.MockConfigLoaderKey{}, kuke.ConfigLoader(nopConfigLoader{})))
+
+	if got := execRoot(root); got != exitConnectivity {
+		t.Errorf("execRoot() = %d, want %d", got, exitConnectivity)
+	}
+	want := "Error: command timed out after 20ms: waiting on containerd: context deadline exceeded"
+	if got := strings.TrimSpace(stderr.String()); got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+}
+
+// TestExecRoot_TimeoutReleased pins that a command finishing well inside
+// --timeout has its deadline canceled on return instead of leaving the timer
+// armed.
+func TestExecRoot_TimeoutReleased(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	t.Setenv("HOME", t.TempDir())
+
+	root, err := kuke.NewKukeCmd()
+	if err != nil {
+		t.Fatalf("NewKukeCmd() error = %v", err)
+	}
+	var cmdCtx context.Context
+	root.AddCommand(&cobra.Command{
+		Use: "quick",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmdCtx = cmd.Context()
+			return nil
+		},
+	})
+	root.SetArgs([]string{"quick", "--timeout", "1h"})
+	root.SetContext(context.WithValue(context.Background(), kuke.MockConfigLoaderKey{}, kuke.ConfigLoader(nopConfigLoader{})))
+
+	if got := execRoot(root); got != exitOK {
+		t.Fatalf("execRoot() = %d, want %d", got, exitOK)
+	}
+	if cmdCtx == nil {
+		t.Fatal("quick never ran")
+	}
+	if _, ok := cmdCtx.Deadline(); !ok {
+		t.Fatal("command context has no deadline, want one from --timeout")
+	}
+	if err := cmdCtx.Err(); !errors.Is(err, context.Canceled) {
+		t.Errorf("command context err = %v after execRoot, want context.Canceled", err)
+	}
+}
+
 func TestRunWithFactory(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -22,6 +22,9 @@ const (
 	CtxHandler      = CtxLoggerType("textHandler")
 	CtxCloser       = CtxLoggerType("closer")
 	CtxTerminalSpec = CtxLoggerType("terminalSpec")
+	// CtxCancel carries the context.CancelFunc of the --timeout deadline so
+	// execRoot can stop its timer once the command returns.
+	CtxCancel = CtxLoggerType("cancel")
 )
 
 type CtxLoggerType string
@@ -0,0 +1,177 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package apischeme_test
+
+import (
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/apischeme"
+	ext "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+	"gopkg.in/yaml.v3"
+)
+
+// The tests below pin metadata.annotations through every converter pair that
+// persists metadata. Annotations are non-identifying: they must survive the
+// external→internal→external hop unchanged, and stay omitted from the
+// serialized form when empty so label-only manifests keep their shape.
+
+func sampleAnnotations() map[string]string {
+	return map[string]string{
+		"kukeon.io/owner": "platform",
+		"note":            "free-form text",
+	}
+}
+
+func assertAnnotations(t *testing.T, where string, got map[string]string) {
+	t.Helper()
+	if !maps.Equal(got, sampleAnnotations()) {
+		t.Errorf("%s annotations = %v, want %v", where, got, sampleAnnotations())
+	}
+}
+
+func TestRealmAnnotationsRoundTrip(t *testing.T) {
+	input := ext.RealmDoc{
+		APIVersion: ext.APIVersionV1Beta1,
+		Kind:       ext.KindRealm,
+		Metadata:   ext.RealmMetadata{Name: "realm0", Annotations: sampleAnnotations()},
+		Spec:       ext.RealmSpec{Namespace: "realm0"},
+	}
+
+	internal, version, err := apischeme.NormalizeRealm(input)
+	if err != nil {
+		t.Fatalf("NormalizeRealm: %v", err)
+	}
+	assertAnnotations(t, "internal", internal.Metadata.Annotations)
+
+	output, err := apischeme.BuildRealmExternalFromInternal(internal, version)
+	if err != nil {
+		t.Fatalf("BuildRealmExternalFromInternal: %v", err)
+	}
+	assertAnnotations(t, "external", output.Metadata.Annotations)
+}
+
+func TestSpaceAnnotationsRoundTrip(t *testing.T) {
+	input := ext.SpaceDoc{
+		APIVersion: ext.APIVersionV1Beta1,
+		Kind:       ext.KindSpace,
+		Metadata:   ext.SpaceMetadata{Name: "space0", Annotations: sampleAnnotations()},
+		Spec:       ext.SpaceSpec{RealmID: "realm0"},
+	}
+
+	internal, version, err := apischeme.NormalizeSpace(input)
+	if err != nil {
+		t.Fatalf("NormalizeSpace: %v", err)
+	}
+	assertAnnotations(t, "internal", internal.Metadata.Annotations)
+
+	output, err := apischeme.BuildSpaceExternalFromInternal(internal, version)
+	if err != nil {
+		t.Fatalf("BuildSpaceExternalFromInternal: %v", err)
+	}
+	assertAnnotations(t, "external", output.Metadata.Annotations)
+}
+
+func TestStackAnnotationsRoundTrip(t *testing.T) {
+	input := ext.StackDoc{
+		APIVersion: ext.APIVersionV1Beta1,
+		Kind:       ext.KindStack,
+		Metadata:   ext.StackMetadata{Name: "stack0", Annotations: sampleAnnotations()},
+		Spec:       ext.StackSpec{ID: "stack0", RealmID: "realm0", SpaceID: "space0"},
+	}
+
+	internal, version, err := apischeme.NormalizeStack(input)
+	if err != nil {
+		t.Fatalf("NormalizeStack: %v", err)
+	}
+	assertAnnotations(t, "internal", internal.Metadata.Annotations)
+
+	output, err := apischeme.BuildStackExternalFromInternal(internal, version)
+	if err != nil {
+		t.Fatalf("BuildStackExternalFromInternal: %v", err)
+	}
+	assertAnnotations(t, "external", output.Metadata.Annotations)
+}
+
+func TestCellAnnotationsRoundTrip(t *testing.T) {
+	input := ext.CellDoc{
+		APIVersion: ext.APIVersionV1Beta1,
+		Kind:       ext.KindCell,
+		Metadata:   ext.CellMetadata{Name: "cell0", Annotations: sampleAnnotations()},
+		Spec: ext.CellSpec{
+			ID:         "cell0",
+			RealmID:    "realm0",
+			SpaceID:    "space0",
+			StackID:    "stack0",
+			Containers: []ext.ContainerSpec{},
+		},
+	}
+
+	internal, version, err := apischeme.NormalizeCell(input)
+	if err != nil {
+		t.Fatalf("NormalizeCell: %v", err)
+	}
+	assertAnnotations(t, "internal", internal.Metadata.Annotations)
+
+	output, err := apischeme.BuildCellExternalFromInternal(internal, version)
+	if err != nil {
+		t.Fatalf("BuildCellExternalFromInternal: %v", err)
+	}
+	assertAnnotations(t, "external", output.Metadata.Annotations)
+}
+
+func TestCellBlueprintAnnotationsRoundTrip(t *testing.T) {
+	input := sampleBlueprintDoc()
+	input.Metadata.Annotations = sampleAnnotations()
+
+	internal, _, err := apischeme.NormalizeCellBlueprint(input)
+	if err != nil {
+		t.Fatalf("NormalizeCellBlueprint: %v", err)
+	}
+
+	output, err := apischeme.ConvertCellBlueprintToExternal(internal)
+	if err != nil {
+		t.Fatalf("ConvertCellBlueprintToExternal: %v", err)
+	}
+	assertAnnotations(t, "external", output.Metadata.Annotations)
+}
+
+// TestAnnotationsOmittedWhenEmpty keeps annotation-free manifests byte-stable:
+// a nil map must not render an `annotations:` key.
+func TestAnnotationsOmittedWhenEmpty(t *testing.T) {
+	internal, version, err := apischeme.NormalizeRealm(ext.RealmDoc{
+		APIVersion: ext.APIVersionV1Beta1,
+		Kind:       ext.KindRealm,
+		Metadata:   ext.RealmMetadata{Name: "realm0"},
+		Spec:       ext.RealmSpec{Namespace: "realm0"},
+	})
+	if err != nil {
+		t.Fatalf("NormalizeRealm: %v", err)
+	}
+	output, err := apischeme.BuildRealmExternalFromInternal(internal, version)
+	if err != nil {
+		t.Fatalf("BuildRealmExternalFromInternal: %v", err)
+	}
+	rendered, err := yaml.Marshal(output)
+	if err != nil {
+		t.Fatalf("yaml.Marshal: %v", err)
+	}
+	if strings.Contains(string(rendered), "annotations") {
+		t.Errorf("rendered YAML carries annotations; got:\n%s", string(rendered))
+	}
+}
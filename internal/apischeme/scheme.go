@@ -53,13 +53,19 @@ func ConvertRealmDocToInternal(in ext.RealmDoc) (intmodel.Realm, error) {
 		}
 		return intmodel.Realm{
 			Metadata: intmodel.RealmMetadata{
-				Name:       in.Metadata.Name,
-				Labels:     in.Metadata.Labels,
-				Generation: in.Metadata.Generation,
+				Name:        in.Metadata.Name,
+				Labels:      in.Metadata.Labels,
+				Annotations: in.Metadata.Annotations,
+				Generation:  in.Metadata.Generation,
 			},
 			Spec: intmodel.RealmSpec{
 				Namespace:           in.Spec.Namespace,
 				RegistryCredentials: registryCreds,
+				Snapshotter:         in.Spec.Snapshotter,
+				PauseImage:          in.Spec.PauseImage,
+				DefaultResources:    convertResourcesToInternal(in.Spec.DefaultResources),
+				AllowPrivileged:     in.Spec.AllowPrivileged,
+				Quota:               convertQuotaToInternal(in.Spec.Quota),
 			},
 			Status: intmodel.RealmStatus{
 				State:                    intmodel.RealmState(in.Status.State),
@@ -96,13 +102,19 @@ func BuildRealmExternalFromInternal(in intmodel.Realm, apiVersion ext.Version) (
 			APIVersion: VersionV1Beta1,
 			Kind:       ext.KindRealm,
 			Metadata: ext.RealmMetadata{
-				Name:       in.Metadata.Name,
-				Labels:     in.Metadata.Labels,
-				Generation: in.Metadata.Generation,
+				Name:        in.Metadata.Name,
+				Labels:      in.Metadata.Labels,
+				Annotations: in.Metadata.Annotations,
+				Generation:  in.Metadata.Generation,
 			},
 			Spec: ext.RealmSpec{
 				Namespace:           in.Spec.Namespace,
 				RegistryCredentials: registryCreds,
+				Snapshotter:         in.Spec.Snapshotter,
+				PauseImage:          in.Spec.PauseImage,
+				DefaultResources:    buildResourcesExternalFromInternal(in.Spec.DefaultResources),
+				AllowPrivileged:     in.Spec.AllowPrivileged,
+				Quota:               buildQuotaExternalFromInternal(in.Spec.Quota),
 			},
 			Status: ext.RealmStatus{
 				State:                    ext.RealmState(in.Status.State),
@@ -217,15 +229,18 @@ func ConvertSpaceDocToInternal(in ext.SpaceDoc) (intmodel.Space, error) {
 		}
 		return intmodel.Space{
 			Metadata: intmodel.SpaceMetadata{
-				Name:       in.Metadata.Name,
-				Labels:     in.Metadata.Labels,
-				Generation: in.Metadata.Generation,
+				Name:        in.Metadata.Name,
+				Labels:      in.Metadata.Labels,
+				Annotations: in.Metadata.Annotations,
+				Generation:  in.Metadata.Generation,
 			},
 			Spec: intmodel.SpaceSpec{
-				RealmName:     in.Spec.RealmID,
-				CNIConfigPath: in.Spec.CNIConfigPath,
-				Network:       convertSpaceNetworkToInternal(in.Spec.Network),
-				Defaults:      convertSpaceDefaultsToInternal(in.Spec.Defaults),
+				RealmName:        in.Spec.RealmID,
+				CNIConfigPath:    in.Spec.CNIConfigPath,
+				Network:          convertSpaceNetworkToInternal(in.Spec.Network),
+				Defaults:         convertSpaceDefaultsToInternal(in.Spec.Defaults),
+				DefaultResources: convertResourcesToInternal(in.Spec.DefaultResources),
+				Quota:            convertQuotaToInternal(in.Spec.Quota),
 			},
 			Status: intmodel.SpaceStatus{
 				State:              intState,
@@ -265,15 +280,18 @@ func BuildSpaceExternalFromInternal(in intmodel.Space, apiVersion ext.Version) (
 			APIVersion: VersionV1Beta1,
 			Kind:       ext.KindSpace,
 			Metadata: ext.SpaceMetadata{
-				Name:       in.Metadata.Name,
-				Labels:     in.Metadata.Labels,
-				Generation: in.Metadata.Generation,
+				Name:        in.Metadata.Name,
+				Labels:      in.Metadata.Labels,
+				Annotations: in.Metadata.Annotations,
+				Generation:  in.Metadata.Generation,
 			},
 			Spec: ext.SpaceSpec{
-				RealmID:       in.Spec.RealmName,
-				CNIConfigPath: in.Spec.CNIConfigPath,
-				Network:       buildSpaceNetworkExternalFromInternal(in.Spec.Network),
-				Defaults:      buildSpaceDefaultsExternalFromInternal(in.Spec.Defaults),
+				RealmID:          in.Spec.RealmName,
+				CNIConfigPath:    in.Spec.CNIConfigPath,
+				Network:          buildSpaceNetworkExternalFromInternal(in.Spec.Network),
+				Defaults:         buildSpaceDefaultsExternalFromInternal(in.Spec.Defaults),
+				DefaultResources: buildResourcesExternalFromInternal(in.Spec.DefaultResources),
+				Quota:            buildQuotaExternalFromInternal(in.Spec.Quota),
 			},
 			Status: ext.SpaceStatus{
 				State:              extState,
@@ -309,9 +327,10 @@ func ConvertStackDocToInternal(in ext.StackDoc) (intmodel.Stack, error) {
 	case VersionV1Beta1, "": // default/empty treated as v1beta1
 		return intmodel.Stack{
 			Metadata: intmodel.StackMetadata{
-				Name:       in.Metadata.Name,
-				Labels:     in.Metadata.Labels,
-				Generation: in.Metadata.Generation,
+				Name:        in.Metadata.Name,
+				Labels:      in.Metadata.Labels,
+				Annotations: in.Metadata.Annotations,
+				Generation:  in.Metadata.Generation,
 			},
 			Spec: intmodel.StackSpec{
 				ID:        in.Spec.ID,
@@ -344,9 +363,10 @@ func BuildStackExternalFromInternal(in intmodel.Stack, apiVersion ext.Version) (
 			APIVersion: VersionV1Beta1,
 			Kind:       ext.KindStack,
 			Metadata: ext.StackMetadata{
-				Name:       in.Metadata.Name,
-				Labels:     in.Metadata.Labels,
-				Generation: in.Metadata.Generation,
+				Name:        in.Metadata.Name,
+				Labels:      in.Metadata.Labels,
+				Annotations: in.Metadata.Annotations,
+				Generation:  in.Metadata.Generation,
 			},
 			Spec: ext.StackSpec{
 				ID:      in.Spec.ID,
@@ -410,6 +430,10 @@ func ConvertContainerDocToInternal(in ext.ContainerDoc) (intmodel.Container, err
 				CellName:               in.Spec.CellID,
 				Root:                   in.Spec.Root,
 				Image:                  in.Spec.Image,
+				ImagePullPolicy:        in.Spec.ImagePullPolicy,
+				Platform:               in.Spec.Platform,
+				Snapshotter:            in.Spec.Snapshotter,
+				DiskQuota:              convertDiskQuotaToInternal(in.Spec.DiskQuota),
 				Command:                in.Spec.Command,
 				Args:                   in.Spec.Args,
 				WorkingDir:             in.Spec.WorkingDir,
@@ -419,16 +443,32 @@ func ConvertContainerDocToInternal(in ext.ContainerDoc) (intmodel.Container, err
 				Networks:               in.Spec.Networks,
 				NetworksAliases:        in.Spec.NetworksAliases,
 				Privileged:             in.Spec.Privileged,
+				Hostname:               in.Spec.Hostname,
+				ExtraHosts:             in.Spec.ExtraHosts,
 				HostNetwork:            in.Spec.HostNetwork,
 				HostPID:                in.Spec.HostPID,
 				HostCgroup:             in.Spec.HostCgroup,
 				User:                   in.Spec.User,
+				SupplementaryGroups:    in.Spec.SupplementaryGroups,
 				ReadOnlyRootFilesystem: in.Spec.ReadOnlyRootFilesystem,
+				WritableTmp:            copyBoolPtr(in.Spec.WritableTmp),
 				Capabilities:           convertCapabilitiesToInternal(in.Spec.Capabilities),
 				SecurityOpts:           in.Spec.SecurityOpts,
+				SeccompProfile:         in.Spec.SeccompProfile,
+				AppArmorProfile:        in.Spec.AppArmorProfile,
+				NoNewPrivileges:        in.Spec.NoNewPrivileges,
+				Sysctls:                in.Spec.Sysctls,
+				OOMScoreAdj:            in.Spec.OOMScoreAdj,
+				TerminationMessagePath: in.Spec.TerminationMessagePath,
 				Devices:                in.Spec.Devices,
 				Tmpfs:                  convertTmpfsMountsToInternal(in.Spec.Tmpfs),
 				Resources:              convertResourcesToInternal(in.Spec.Resources),
+				LogRotation:            convertLogRotationToInternal(in.Spec.LogRotation),
+				Lifecycle:              convertContainerLifecycleToInternal(in.Spec.Lifecycle),
+				SeparateStreams:        in.Spec.SeparateStreams,
+				OpenStdin:              in.Spec.OpenStdin,
+				StdinOnce:              in.Spec.StdinOnce,
+				StdinFile:              in.Spec.StdinFile,
 				Secrets:                convertSecretsToInternal(in.Spec.Secrets),
 				Repos:                  reposToInternal(in.Spec.Repos),
 				Git:                    gitToInternal(in.Spec.Git),
@@ -440,18 +480,23 @@ func ConvertContainerDocToInternal(in ext.ContainerDoc) (intmodel.Container, err
 				Tty:                    convertContainerTtyToInternal(in.Spec.Tty),
 			},
 			Status: intmodel.ContainerStatus{
-				Name:         in.Status.Name,
-				ID:           in.Status.ID,
-				CreatedAt:    in.Status.CreatedAt,
-				State:        intmodel.ContainerState(in.Status.State),
-				RestartCount: in.Status.RestartCount,
-				RestartTime:  in.Status.RestartTime,
-				StartTime:    in.Status.StartTime,
-				FinishTime:   in.Status.FinishTime,
-				ExitCode:     in.Status.ExitCode,
-				ExitSignal:   in.Status.ExitSignal,
-				Repos:        repoStatusesToInternal(in.Status.Repos),
-				Stages:       stageStatusesToInternal(in.Status.Stages),
+				Name:               in.Status.Name,
+				ID:                 in.Status.ID,
+				CreatedAt:          in.Status.CreatedAt,
+				State:              intmodel.ContainerState(in.Status.State),
+				RestartCount:       in.Status.RestartCount,
+				RestartTime:        in.Status.RestartTime,
+				StartTime:          in.Status.StartTime,
+				FinishTime:         in.Status.FinishTime,
+				ExitCode:           in.Status.ExitCode,
+				ExitSignal:         in.Status.ExitSignal,
+				OOMKilled:          in.Status.OOMKilled,
+				LastOOM:            in.Status.LastOOM,
+				OOMKillCount:       in.Status.OOMKillCount,
+				ImageDigest:        in.Status.ImageDigest,
+				TerminationMessage: in.Status.TerminationMessage,
+				Repos:              repoStatusesToInternal(in.Status.Repos),
+				Stages:             stageStatusesToInternal(in.Status.Stages),
 			},
 		}, nil
 	default:
@@ -478,6 +523,10 @@ func BuildContainerExternalFromInternal(in intmodel.Container, apiVersion ext.Ve
 				CellID:                 in.Spec.CellName,
 				Root:                   in.Spec.Root,
 				Image:                  in.Spec.Image,
+				ImagePullPolicy:        in.Spec.ImagePullPolicy,
+				Platform:               in.Spec.Platform,
+				Snapshotter:            in.Spec.Snapshotter,
+				DiskQuota:              buildDiskQuotaExternalFromInternal(in.Spec.DiskQuota),
 				Command:                in.Spec.Command,
 				Args:                   in.Spec.Args,
 				WorkingDir:             in.Spec.WorkingDir,
@@ -487,16 +536,32 @@ func BuildContainerExternalFromInternal(in intmodel.Container, apiVersion ext.Ve
 				Networks:               in.Spec.Networks,
 				NetworksAliases:        in.Spec.NetworksAliases,
 				Privileged:             in.Spec.Privileged,
+				Hostname:               in.Spec.Hostname,
+				ExtraHosts:             in.Spec.ExtraHosts,
 				HostNetwork:            in.Spec.HostNetwork,
 				HostPID:                in.Spec.HostPID,
 				HostCgroup:             in.Spec.HostCgroup,
 				User:                   in.Spec.User,
+				SupplementaryGroups:    in.Spec.SupplementaryGroups,
 				ReadOnlyRootFilesystem: in.Spec.ReadOnlyRootFilesystem,
+				WritableTmp:            copyBoolPtr(in.Spec.WritableTmp),
 				Capabilities:           buildCapabilitiesExternalFromInternal(in.Spec.Capabilities),
 				SecurityOpts:           in.Spec.SecurityOpts,
+				SeccompProfile:         in.Spec.SeccompProfile,
+				AppArmorProfile:        in.Spec.AppArmorProfile,
+				NoNewPrivileges:        in.Spec.NoNewPrivileges,
+				Sysctls:                in.Spec.Sysctls,
+				OOMScoreAdj:            in.Spec.OOMScoreAdj,
+				TerminationMessagePath: in.Spec.TerminationMessagePath,
 				Devices:                in.Spec.Devices,
 				Tmpfs:                  buildTmpfsMountsExternalFromInternal(in.Spec.Tmpfs),
 				Resources:              buildResourcesExternalFromInternal(in.Spec.Resources),
+				LogRotation:            buildLogRotationExternalFromInternal(in.Spec.LogRotation),
+				Lifecycle:              buildContainerLifecycleExternalFromInternal(in.Spec.Lifecycle),
+				SeparateStreams:        in.Spec.SeparateStreams,
+				OpenStdin:              in.Spec.OpenStdin,
+				StdinOnce:              in.Spec.StdinOnce,
+				StdinFile:              in.Spec.StdinFile,
 				Secrets:                buildSecretsExternalFromInternal(in.Spec.Secrets),
 				Repos:                  reposToExternal(in.Spec.Repos),
 				Git:                    gitToExternal(in.Spec.Git),
@@ -508,18 +573,23 @@ func BuildContainerExternalFromInternal(in intmodel.Container, apiVersion ext.Ve
 				Tty:                    buildContainerTtyExternalFromInternal(in.Spec.Tty),
 			},
 			Status: ext.ContainerStatus{
-				Name:         in.Status.Name,
-				ID:           in.Status.ID,
-				CreatedAt:    in.Status.CreatedAt,
-				State:        ext.ContainerState(in.Status.State),
-				RestartCount: in.Status.RestartCount,
-				RestartTime:  in.Status.RestartTime,
-				StartTime:    in.Status.StartTime,
-				FinishTime:   in.Status.FinishTime,
-				ExitCode:     in.Status.ExitCode,
-				ExitSignal:   in.Status.ExitSignal,
-				Repos:        repoStatusesToExternal(in.Status.Repos),
-				Stages:       stageStatusesToExternal(in.Status.Stages),
+				Name:               in.Status.Name,
+				ID:                 in.Status.ID,
+				CreatedAt:          in.Status.CreatedAt,
+				State:              ext.ContainerState(in.Status.State),
+				RestartCount:       in.Status.RestartCount,
+				RestartTime:        in.Status.RestartTime,
+				StartTime:          in.Status.StartTime,
+				FinishTime:         in.Status.FinishTime,
+				ExitCode:           in.Status.ExitCode,
+				ExitSignal:         in.Status.ExitSignal,
+				OOMKilled:          in.Status.OOMKilled,
+				LastOOM:            in.Status.LastOOM,
+				OOMKillCount:       in.Status.OOMKillCount,
+				ImageDigest:        in.Status.ImageDigest,
+				TerminationMessage: in.Status.TerminationMessage,
+				Repos:              repoStatusesToExternal(in.Status.Repos),
+				Stages:             stageStatusesToExternal(in.Status.Stages),
 			},
 		}, nil
 	default:
@@ -549,6 +619,10 @@ func convertContainerSpecToInternal(in ext.ContainerSpec) intmodel.ContainerSpec
 		CellName:               in.CellID,
 		Root:                   in.Root,
 		Image:                  in.Image,
+		ImagePullPolicy:        in.ImagePullPolicy,
+		Platform:               in.Platform,
+		Snapshotter:            in.Snapshotter,
+		DiskQuota:              convertDiskQuotaToInternal(in.DiskQuota),
 		Command:                in.Command,
 		Args:                   in.Args,
 		WorkingDir:             in.WorkingDir,
@@ -558,16 +632,32 @@ func convertContainerSpecToInternal(in ext.ContainerSpec) intmodel.ContainerSpec
 		Networks:               in.Networks,
 		NetworksAliases:        in.NetworksAliases,
 		Privileged:             in.Privileged,
+		Hostname:               in.Hostname,
+		ExtraHosts:             in.ExtraHosts,
 		HostNetwork:            in.HostNetwork,
 		HostPID:                in.HostPID,
 		HostCgroup:             in.HostCgroup,
 		User:                   in.User,
+		SupplementaryGroups:    in.SupplementaryGroups,
 		ReadOnlyRootFilesystem: in.ReadOnlyRootFilesystem,
+		WritableTmp:            copyBoolPtr(in.WritableTmp),
 		Capabilities:           convertCapabilitiesToInternal(in.Capabilities),
 		SecurityOpts:           in.SecurityOpts,
+		SeccompProfile:         in.SeccompProfile,
+		AppArmorProfile:        in.AppArmorProfile,
+		NoNewPrivileges:        in.NoNewPrivileges,
+		Sysctls:                in.Sysctls,
+		OOMScoreAdj:            in.OOMScoreAdj,
+		TerminationMessagePath: in.TerminationMessagePath,
 		Devices:                in.Devices,
 		Tmpfs:                  convertTmpfsMountsToInternal(in.Tmpfs),
 		Resources:              convertResourcesToInternal(in.Resources),
+		LogRotation:            convertLogRotationToInternal(in.LogRotation),
+		Lifecycle:              convertContainerLifecycleToInternal(in.Lifecycle),
+		SeparateStreams:        in.SeparateStreams,
+		OpenStdin:              in.OpenStdin,
+		StdinOnce:              in.StdinOnce,
+		StdinFile:              in.StdinFile,
 		Secrets:                convertSecretsToInternal(in.Secrets),
 		Repos:                  reposToInternal(in.Repos),
 		Git:                    gitToInternal(in.Git),
@@ -592,6 +682,10 @@ func BuildContainerSpecExternalFromInternal(in intmodel.ContainerSpec) ext.Conta
 		CellID:                 in.CellName,
 		Root:                   in.Root,
 		Image:                  in.Image,
+		ImagePullPolicy:        in.ImagePullPolicy,
+		Platform:               in.Platform,
+		Snapshotter:            in.Snapshotter,
+		DiskQuota:              buildDiskQuotaExternalFromInternal(in.DiskQuota),
 		Command:                in.Command,
 		Args:                   in.Args,
 		WorkingDir:             in.WorkingDir,
@@ -601,16 +695,32 @@ func BuildContainerSpecExternalFromInternal(in intmodel.ContainerSpec) ext.Conta
 		Networks:               in.Networks,
 		NetworksAliases:        in.NetworksAliases,
 		Privileged:             in.Privileged,
+		Hostname:               in.Hostname,
+		ExtraHosts:             in.ExtraHosts,
 		HostNetwork:            in.HostNetwork,
 		HostPID:                in.HostPID,
 		HostCgroup:             in.HostCgroup,
 		User:                   in.User,
+		SupplementaryGroups:    in.SupplementaryGroups,
 		ReadOnlyRootFilesystem: in.ReadOnlyRootFilesystem,
+		WritableTmp:            copyBoolPtr(in.WritableTmp),
 		Capabilities:           buildCapabilitiesExternalFromInternal(in.Capabilities),
 		SecurityOpts:           in.SecurityOpts,
+		SeccompProfile:         in.SeccompProfile,
+		AppArmorProfile:        in.AppArmorProfile,
+		NoNewPrivileges:        in.NoNewPrivileges,
+		Sysctls:                in.Sysctls,
+		OOMScoreAdj:            in.OOMScoreAdj,
+		TerminationMessagePath: in.TerminationMessagePath,
 		Devices:                in.Devices,
 		Tmpfs:                  buildTmpfsMountsExternalFromInternal(in.Tmpfs),
 		Resources:              buildResourcesExternalFromInternal(in.Resources),
+		LogRotation:            buildLogRotationExternalFromInternal(in.LogRotation),
+		Lifecycle:              buildContainerLifecycleExternalFromInternal(in.Lifecycle),
+		SeparateStreams:        in.SeparateStreams,
+		OpenStdin:              in.OpenStdin,
+		StdinOnce:              in.StdinOnce,
+		StdinFile:              in.StdinFile,
 		Secrets:                buildSecretsExternalFromInternal(in.Secrets),
 		Repos:                  reposToExternal(in.Repos),
 		Git:                    gitToExternal(in.Git),
@@ -682,6 +792,61 @@ func buildCellTtyExternalFromInternal(in *intmodel.CellTty) *ext.CellTty {
 	return &ext.CellTty{Default: in.Default}
 }
 
+// convertCellBandwidthToInternal copies the cell bandwidth block verbatim;
+// unit parsing and validation happen in the controller. A nil input yields
+// nil.
+func convertCellBandwidthToInternal(in *ext.CellBandwidth) *intmodel.CellBandwidth {
+	if in == nil {
+		return nil
+	}
+	return &intmodel.CellBandwidth{
+		IngressRate:  in.IngressRate,
+		IngressBurst: in.IngressBurst,
+		EgressRate:   in.EgressRate,
+		EgressBurst:  in.EgressBurst,
+	}
+}
+
+// buildCellBandwidthExternalFromInternal is the inverse of
+// convertCellBandwidthToInternal.
+func buildCellBandwidthExternalFromInternal(in *intmodel.CellBandwidth) *ext.CellBandwidth {
+	if in == nil {
+		return nil
+	}
+	return &ext.CellBandwidth{
+		IngressRate:  in.IngressRate,
+		IngressBurst: in.IngressBurst,
+		EgressRate:   in.EgressRate,
+		EgressBurst:  in.EgressBurst,
+	}
+}
+
+// convertCellLifecycleToInternal copies the cell lifecycle hooks; a nil
+// input yields nil.
+func convertCellLifecycleToInternal(in *ext.CellLifecycle) *intmodel.CellLifecycle {
+	if in == nil {
+		return nil
+	}
+	return &intmodel.CellLifecycle{
+		PreStart:       append([]string(nil), in.PreStart...),
+		PostStop:       append([]string(nil), in.PostStop...),
+		TimeoutSeconds: in.TimeoutSeconds,
+	}
+}
+
+// buildCellLifecycleExternalFromInternal is the inverse of
+// convertCellLifecycleToInternal.
+func buildCellLifecycleExternalFromInternal(in *intmodel.CellLifecycle) *ext.CellLifecycle {
+	if in == nil {
+		return nil
+	}
+	return &ext.CellLifecycle{
+		PreStart:       append([]string(nil), in.PreStart...),
+		PostStop:       append([]string(nil), in.PostStop...),
+		TimeoutSeconds: in.TimeoutSeconds,
+	}
+}
+
 // validateContainerTty enforces the AC that any tty field set on a
 // container with Attachable=false is a validation error. The tty block
 // is config that only takes effect when Attachable=true (the capability
@@ -1088,6 +1253,80 @@ func buildResourcesExternalFromInternal(in *intmodel.ContainerResources) *ext.Co
 	}
 }
 
+func convertQuotaToInternal(in *ext.ResourceQuota) *intmodel.ResourceQuota {
+	if in == nil {
+		return nil
+	}
+	return &intmodel.ResourceQuota{
+		MaxCells:       in.MaxCells,
+		MaxMemoryBytes: in.MaxMemoryBytes,
+		MaxCPUShares:   in.MaxCPUShares,
+	}
+}
+
+func buildQuotaExternalFromInternal(in *intmodel.ResourceQuota) *ext.ResourceQuota {
+	if in == nil {
+		return nil
+	}
+	return &ext.ResourceQuota{
+		MaxCells:       in.MaxCells,
+		MaxMemoryBytes: in.MaxMemoryBytes,
+		MaxCPUShares:   in.MaxCPUShares,
+	}
+}
+
+func convertDiskQuotaToInternal(in *ext.ContainerDiskQuota) *intmodel.ContainerDiskQuota {
+	if in == nil {
+		return nil
+	}
+	return &intmodel.ContainerDiskQuota{SizeBytes: in.SizeBytes}
+}
+
+func buildDiskQuotaExternalFromInternal(in *intmodel.ContainerDiskQuota) *ext.ContainerDiskQuota {
+	if in == nil {
+		return nil
+	}
+	return &ext.ContainerDiskQuota{SizeBytes: in.SizeBytes}
+}
+
+func convertLogRotationToInternal(in *ext.ContainerLogRotation) *intmodel.ContainerLogRotation {
+	if in == nil {
+		return nil
+	}
+	return &intmodel.ContainerLogRotation{MaxSizeMB: in.MaxSizeMB, MaxFiles: in.MaxFiles}
+}
+
+func buildLogRotationExternalFromInternal(in *intmodel.ContainerLogRotation) *ext.ContainerLogRotation {
+	if in == nil {
+		return nil
+	}
+	return &ext.ContainerLogRotation{MaxSizeMB: in.MaxSizeMB, MaxFiles: in.MaxFiles}
+}
+
+func convertContainerLifecycleToInternal(in *ext.ContainerLifecycle) *intmodel.ContainerLifecycle {
+	if in == nil {
+		return nil
+	}
+	return &intmodel.ContainerLifecycle{
+		PostStart:            append([]string(nil), in.PostStart...),
+		PreStop:              append([]string(nil), in.PreStop...),
+		TimeoutSeconds:       in.TimeoutSeconds,
+		FailOnPostStartError: in.FailOnPostStartError,
+	}
+}
+
+func buildContainerLifecycleExternalFromInternal(in *intmodel.ContainerLifecycle) *ext.ContainerLifecycle {
+	if in == nil {
+		return nil
+	}
+	return &ext.ContainerLifecycle{
+		PostStart:            append([]string(nil), in.PostStart...),
+		PreStop:              append([]string(nil), in.PreStop...),
+		TimeoutSeconds:       in.TimeoutSeconds,
+		FailOnPostStartError: in.FailOnPostStartError,
+	}
+}
+
 // convertSecretsToInternal copies external secret references into the internal
 // model. Only the reference metadata (name + source + optional mountPath) is
 // carried; there is no value field on either side.
@@ -1547,16 +1786,21 @@ func convertContainerStatusesToInternal(in []ext.ContainerStatus) []intmodel.Con
 	result := make([]intmodel.ContainerStatus, len(in))
 	for i, status := range in {
 		result[i] = intmodel.ContainerStatus{
-			Name:         status.Name,
-			ID:           status.ID,
-			CreatedAt:    status.CreatedAt,
-			State:        intmodel.ContainerState(status.State),
-			RestartCount: status.RestartCount,
-			RestartTime:  status.RestartTime,
-			StartTime:    status.StartTime,
-			FinishTime:   status.FinishTime,
-			ExitCode:     status.ExitCode,
-			ExitSignal:   status.ExitSignal,
+			Name:               status.Name,
+			ID:                 status.ID,
+			CreatedAt:          status.CreatedAt,
+			State:              intmodel.ContainerState(status.State),
+			RestartCount:       status.RestartCount,
+			RestartTime:        status.RestartTime,
+			StartTime:          status.StartTime,
+			FinishTime:         status.FinishTime,
+			ExitCode:           status.ExitCode,
+			ExitSignal:         status.ExitSignal,
+			OOMKilled:          status.OOMKilled,
+			LastOOM:            status.LastOOM,
+			OOMKillCount:       status.OOMKillCount,
+			ImageDigest:        status.ImageDigest,
+			TerminationMessage: status.TerminationMessage,
 		}
 	}
 	return result
@@ -1570,16 +1814,21 @@ func buildContainerStatusesExternalFromInternal(in []intmodel.ContainerStatus) [
 	result := make([]ext.ContainerStatus, len(in))
 	for i, status := range in {
 		result[i] = ext.ContainerStatus{
-			Name:         status.Name,
-			ID:           status.ID,
-			CreatedAt:    status.CreatedAt,
-			State:        ext.ContainerState(status.State),
-			RestartCount: status.RestartCount,
-			RestartTime:  status.RestartTime,
-			StartTime:    status.StartTime,
-			FinishTime:   status.FinishTime,
-			ExitCode:     status.ExitCode,
-			ExitSignal:   status.ExitSignal,
+			Name:               status.Name,
+			ID:                 status.ID,
+			CreatedAt:          status.CreatedAt,
+			State:              ext.ContainerState(status.State),
+			RestartCount:       status.RestartCount,
+			RestartTime:        status.RestartTime,
+			StartTime:          status.StartTime,
+			FinishTime:         status.FinishTime,
+			ExitCode:           status.ExitCode,
+			ExitSignal:         status.ExitSignal,
+			OOMKilled:          status.OOMKilled,
+			LastOOM:            status.LastOOM,
+			OOMKillCount:       status.OOMKillCount,
+			ImageDigest:        status.ImageDigest,
+			TerminationMessage: status.TerminationMessage,
 		}
 	}
 	return result
@@ -1622,6 +1871,8 @@ func ConvertCellDocToInternal(in ext.CellDoc) (intmodel.Cell, error) {
 				Tty:                 convertCellTtyToInternal(in.Spec.Tty),
 				AutoDelete:          in.Spec.AutoDelete,
 				NestedCgroupRuntime: in.Spec.NestedCgroupRuntime,
+				Bandwidth:           convertCellBandwidthToInternal(in.Spec.Bandwidth),
+				Lifecycle:           convertCellLifecycleToInternal(in.Spec.Lifecycle),
 				// RuntimeEnv is transport-only (v1beta1 carries yaml:"-"), so
 				// the round-trip thread runs through here on the inbound RPC
 				// path and is dropped at persistence time by
@@ -1637,6 +1888,15 @@ func ConvertCellDocToInternal(in ext.CellDoc) (intmodel.Cell, error) {
 				// drops it so the per-invocation override never persists.
 				// Issue #1035.
 				IgnoreDiskPressure: in.Spec.IgnoreDiskPressure,
+				// WaitReadySeconds rides the same inbound-only path: StartCell
+				// reads it and BuildCellExternalFromInternal drops it.
+				WaitReadySeconds: in.Spec.WaitReadySeconds,
+				// WaitForParentSeconds likewise: ValidateCell reads it on
+				// the create/apply path.
+				WaitForParentSeconds: in.Spec.WaitForParentSeconds,
+				// PruneContainers likewise: ReconcileCell reads it on the
+				// apply path.
+				PruneContainers: in.Spec.PruneContainers,
 			},
 			Status: intmodel.CellStatus{
 				State:              intmodel.CellState(in.Status.State),
@@ -1644,6 +1904,8 @@ func ConvertCellDocToInternal(in ext.CellDoc) (intmodel.Cell, error) {
 				SubtreeControllers: cloneStringSlice(in.Status.SubtreeControllers),
 				Network: intmodel.CellNetworkStatus{
 					BridgeName: in.Status.Network.BridgeName,
+					IPv4:       in.Status.Network.IPv4,
+					IPv6:       in.Status.Network.IPv6,
 				},
 				Containers:         convertContainerStatusesToInternal(in.Status.Containers),
 				ReadyObserved:      in.Status.ReadyObserved,
@@ -1706,6 +1968,8 @@ func BuildCellExternalFromInternal(in intmodel.Cell, apiVersion ext.Version) (ex
 				Tty:                 buildCellTtyExternalFromInternal(in.Spec.Tty),
 				AutoDelete:          in.Spec.AutoDelete,
 				NestedCgroupRuntime: in.Spec.NestedCgroupRuntime,
+				Bandwidth:           buildCellBandwidthExternalFromInternal(in.Spec.Bandwidth),
+				Lifecycle:           buildCellLifecycleExternalFromInternal(in.Spec.Lifecycle),
 				// RuntimeEnv is deliberately NOT copied on the
 				// internal → external direction (issue #834). The
 				// v1beta1 metadata.json on disk is JSON-marshaled from
@@ -1728,7 +1992,9 @@ func BuildCellExternalFromInternal(in intmodel.Cell, apiVersion ext.Version) (ex
 				// state. Persisting it would silently exempt the cell's future
 				// rematerializations from the disk-pressure guard. The CLI →
 				// daemon direction in ConvertCellDocToInternal preserves it so
-				// the CreateCell guard sees the override.
+				// the CreateCell guard sees the override. WaitReadySeconds and
+				// WaitForParentSeconds are dropped for the same reason: they
+				// are per-invocation waits, not state. So is PruneContainers.
 			},
 			Status: ext.CellStatus{
 				State:              ext.CellState(in.Status.State),
@@ -1736,6 +2002,8 @@ func BuildCellExternalFromInternal(in intmodel.Cell, apiVersion ext.Version) (ex
 				SubtreeControllers: cloneStringSlice(in.Status.SubtreeControllers),
 				Network: ext.CellNetworkStatus{
 					BridgeName: in.Status.Network.BridgeName,
+					IPv4:       in.Status.Network.IPv4,
+					IPv6:       in.Status.Network.IPv6,
 				},
 				Containers:         buildContainerStatusesExternalFromInternal(in.Status.Containers),
 				ReadyObserved:      in.Status.ReadyObserved,
@@ -1791,7 +2059,11 @@ func convertSpaceNetworkToInternal(in *ext.SpaceNetwork) *intmodel.SpaceNetwork
 	if in == nil {
 		return nil
 	}
-	out := &intmodel.SpaceNetwork{}
+	out := &intmodel.SpaceNetwork{
+		Policy:     intmodel.NetworkPolicy(in.Policy),
+		IPv6Subnet: in.IPv6Subnet,
+		DualStack:  in.DualStack,
+	}
 	if in.Egress != nil {
 		allow := make([]intmodel.EgressAllowRule, len(in.Egress.Allow))
 		for i, r := range in.Egress.Allow {
@@ -1814,7 +2086,11 @@ func buildSpaceNetworkExternalFromInternal(in *intmodel.SpaceNetwork) *ext.Space
 	if in == nil {
 		return nil
 	}
-	out := &ext.SpaceNetwork{}
+	out := &ext.SpaceNetwork{
+		Policy:     ext.NetworkPolicy(in.Policy),
+		IPv6Subnet: in.IPv6Subnet,
+		DualStack:  in.DualStack,
+	}
 	if in.Egress != nil {
 		allow := make([]ext.EgressAllowRule, len(in.Egress.Allow))
 		for i, r := range in.Egress.Allow {
@@ -0,0 +1,396 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package docdiff compares a manifest document against the version held in
+// the store, field by field. Both sides are normalized through apischeme so
+// YAML-vs-JSON serialization noise never shows up as a change, and status
+// plus daemon-owned fields are stripped before comparing. It backs
+// `kuke diff`.
+package docdiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/apischeme"
+	"github.com/eminwux/kukeon/internal/consts"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+// Op is the kind of a single field-level change.
+type Op string
+
+const (
+	// OpAdded marks a field the manifest sets and the store does not hold.
+	OpAdded Op = "added"
+	// OpRemoved marks a field the store holds and the manifest dropped.
+	OpRemoved Op = "removed"
+	// OpChanged marks a field both sides set to different values.
+	OpChanged Op = "changed"
+)
+
+// managedLabelSuffix marks labels the daemon stamps itself; they never come
+// from a manifest, so the diff ignores them on both sides.
+const managedLabelSuffix = ".kukeon.io"
+
+// Change is one differing field. Path uses dotted keys, with list items
+// addressed by their id when they carry one (spec.containers[web].image) and
+// by index otherwise (spec.containers[web].env[1]).
+type Change struct {
+	Path    string
+	Op      Op
+	Live    any
+	Desired any
+}
+
+// Result is the outcome of comparing one manifest document.
+type Result struct {
+	Index int
+	Kind  v1beta1.Kind
+	Name  string
+	// Found is false when the store holds no such resource; Changes then
+	// lists every field the manifest sets as added.
+	Found   bool
+	Changes []Change
+}
+
+// Differs reports whether the document does not match the store.
+func (r Result) Differs() bool {
+	return !r.Found || len(r.Changes) > 0
+}
+
+// Supported reports whether Normalize understands documents of kind.
+func Supported(kind v1beta1.Kind) bool {
+	switch kind {
+	case v1beta1.KindRealm, v1beta1.KindSpace, v1beta1.KindStack, v1beta1.KindCell, v1beta1.KindContainer:
+		return true
+	default:
+		return false
+	}
+}
+
+// Normalize converts a realm, space, stack, cell or container document into
+// a generic tree ready for Compare: the document is round-tripped through
+// the internal model, then status, the generation counter, managed labels
+// and runtime identifiers are removed.
+func Normalize(doc any) (map[string]any, error) {
+	var (
+		ext any
+		err error
+	)
+	switch d := doc.(type) {
+	case v1beta1.RealmDoc:
+		ext, err = roundTrip(d, apischeme.NormalizeRealm, apischeme.BuildRealmExternalFromInternal)
+	case v1beta1.SpaceDoc:
+		ext, err = roundTrip(d, apischeme.NormalizeSpace, apischeme.BuildSpaceExternalFromInternal)
+	case v1beta1.StackDoc:
+		ext, err = roundTrip(d, apischeme.NormalizeStack, apischeme.BuildStackExternalFromInternal)
+	case v1beta1.CellDoc:
+		ext, err = roundTrip(d, apischeme.NormalizeCell, apischeme.BuildCellExternalFromInternal)
+	case v1beta1.ContainerDoc:
+		ext, err = roundTrip(d, apischeme.NormalizeContainer, apischeme.BuildContainerExternalFromInternal)
+	default:
+		return nil, fmt.Errorf("%w: %T", errdefs.ErrDiffUnsupportedKind, doc)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(ext)
+	if err != nil {
+		return nil, err
+	}
+	var tree map[string]any
+	if err = json.Unmarshal(raw, &tree); err != nil {
+		return nil, err
+	}
+	strip(tree)
+	return tree, nil
+}
+
+func roundTrip[E, I any](
+	doc E,
+	normalize func(E) (I, v1beta1.Version, error),
+	build func(I, v1beta1.Version) (E, error),
+) (E, error) {
+	in, version, err := normalize(doc)
+	if err != nil {
+		var zero E
+		return zero, err
+	}
+	return build(in, version)
+}
+
+// strip removes everything a manifest never carries: status, the store's
+// generation counter, daemon-stamped labels, apply's last-applied
+// bookkeeping annotations, and the runtime identifiers the daemon fills
+// into space and container specs.
+func strip(tree map[string]any) {
+	delete(tree, "status")
+	if metadata, ok := tree["metadata"].(map[string]any); ok {
+		delete(metadata, "generation")
+		if labels, isMap := metadata["labels"].(map[string]any); isMap {
+			for key := range labels {
+				if strings.HasSuffix(key, managedLabelSuffix) {
+					delete(labels, key)
+				}
+			}
+		}
+		if annotations, isMap := metadata["annotations"].(map[string]any); isMap {
+			delete(annotations, consts.KukeonLastAppliedAnnotationKey)
+			delete(annotations, consts.KukeonFieldManagerAnnotationKey)
+		}
+	}
+	spec, ok := tree["spec"].(map[string]any)
+	if !ok {
+		return
+	}
+	stripRuntimeIDs(spec)
+	if containers, isList := spec["containers"].([]any); isList {
+		for _, item := range containers {
+			if container, isMap := item.(map[string]any); isMap {
+				stripRuntimeIDs(container)
+			}
+		}
+	}
+}
+
+func stripRuntimeIDs(spec map[string]any) {
+	delete(spec, "containerdId")
+	delete(spec, "cniConfigPath")
+}
+
+// Compare lists the fields where desired differs from live. Only what the
+// manifest sets is compared: a field the manifest leaves empty is one the
+// daemon defaults, so live values there are not reported as removals. The
+// exceptions are labels and annotations, which the manifest owns outright,
+// and list items, where a shorter manifest list removes the trailing live
+// items. A root container the daemon synthesized is skipped when the
+// manifest declares none. A nil live tree reports every set field as added.
+func Compare(live, desired map[string]any) []Change {
+	var changes []Change
+	compareMaps("", live, desired, &changes)
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+func compareValues(path string, live, desired any, changes *[]Change) {
+	if isUnset(desired) {
+		if ownsWholeMap(path) {
+			appendRemoved(path, live, changes)
+		}
+		return
+	}
+	switch d := desired.(type) {
+	case map[string]any:
+		l, _ := live.(map[string]any)
+		if live != nil && l == nil {
+			*changes = append(*changes, Change{Path: path, Op: OpChanged, Live: live, Desired: desired})
+			return
+		}
+		compareMaps(path, l, d, changes)
+	case []any:
+		l, _ := live.([]any)
+		if live != nil && l == nil {
+			*changes = append(*changes, Change{Path: path, Op: OpChanged, Live: live, Desired: desired})
+			return
+		}
+		compareLists(path, l, d, changes)
+	default:
+		switch {
+		case live == nil:
+			*changes = append(*changes, Change{Path: path, Op: OpAdded, Desired: desired})
+		case !scalarEqual(live, desired):
+			*changes = append(*changes, Change{Path: path, Op: OpChanged, Live: live, Desired: desired})
+		}
+	}
+}
+
+func compareMaps(path string, live, desired map[string]any, changes *[]Change) {
+	for key, value := range desired {
+		compareValues(join(path, key), live[key], value, changes)
+	}
+	if !ownsWholeMap(path) {
+		return
+	}
+	for key, value := range live {
+		if _, ok := desired[key]; !ok {
+			appendRemoved(join(path, key), value, changes)
+		}
+	}
+}
+
+func compareLists(path string, live, desired []any, changes *[]Change) {
+	if keyedByID(desired) && (live == nil || keyedByID(live)) {
+		compareKeyedLists(path, live, desired, changes)
+		return
+	}
+	for i, value := range desired {
+		var l any
+		if i < len(live) {
+			l = live[i]
+		}
+		compareValues(path+"["+strconv.Itoa(i)+"]", l, value, changes)
+	}
+	for i := len(desired); i < len(live); i++ {
+		appendRemoved(path+"["+strconv.Itoa(i)+"]", live[i], changes)
+	}
+}
+
+func compareKeyedLists(path string, live, desired []any, changes *[]Change) {
+	declaresRoot := false
+	seen := make(map[string]bool, len(desired))
+	for _, item := range desired {
+		m := item.(map[string]any)
+		if root, _ := m["root"].(bool); root {
+			declaresRoot = true
+		}
+		seen[m["id"].(string)] = true
+	}
+	liveByID := make(map[string]any, len(live))
+	for _, item := range live {
+		m := item.(map[string]any)
+		id := m["id"].(string)
+		liveByID[id] = m
+		if seen[id] {
+			continue
+		}
+		if root, _ := m["root"].(bool); root && !declaresRoot {
+			continue
+		}
+		appendRemoved(path+"["+id+"]", m, changes)
+	}
+	for _, item := range desired {
+		m := item.(map[string]any)
+		id := m["id"].(string)
+		compareValues(path+"["+id+"]", liveByID[id], m, changes)
+	}
+}
+
+// appendRemoved records a removed subtree as one change per leaf so the
+// rendered diff shows exactly what goes away.
+func appendRemoved(path string, value any, changes *[]Change) {
+	if isUnset(value) {
+		return
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			appendRemoved(join(path, key), child, changes)
+		}
+	case []any:
+		for i, child := range v {
+			appendRemoved(path+"["+strconv.Itoa(i)+"]", child, changes)
+		}
+	default:
+		*changes = append(*changes, Change{Path: path, Op: OpRemoved, Live: value})
+	}
+}
+
+// ownsWholeMap reports whether the manifest is authoritative for every key
+// of the map at path, so live keys it omits count as removed.
+func ownsWholeMap(path string) bool {
+	return path == "metadata.labels" || path == "metadata.annotations"
+}
+
+// keyedByID reports whether every item of items is an object with a
+// non-empty string id, so items can be matched by id instead of position.
+func keyedByID(items []any) bool {
+	if len(items) == 0 {
+		return false
+	}
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return false
+		}
+		if id, _ := m["id"].(string); id == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// isUnset reports whether v is the JSON form of a field the manifest did not
+// set: null, an empty string, or an empty list or object.
+func isUnset(v any) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case string:
+		return t == ""
+	case []any:
+		return len(t) == 0
+	case map[string]any:
+		return len(t) == 0
+	default:
+		return false
+	}
+}
+
+func scalarEqual(a, b any) bool {
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// Render writes r as a field-level unified diff: a ---/+++ header naming
+// the document, then one -/+ line per field, old value first for changes.
+// Documents that match the store render nothing.
+func Render(w io.Writer, r Result) error {
+	if !r.Differs() {
+		return nil
+	}
+	subject := strings.ToLower(string(r.Kind)) + "/" + r.Name
+	live := "--- live " + subject
+	if !r.Found {
+		live += " (not found)"
+	}
+	if _, err := fmt.Fprintf(w, "%s\n+++ manifest %s (document %d)\n", live, subject, r.Index); err != nil {
+		return err
+	}
+	for _, c := range r.Changes {
+		if c.Op != OpAdded {
+			if _, err := fmt.Fprintf(w, "-%s: %s\n", c.Path, formatValue(c.Live)); err != nil {
+				return err
+			}
+		}
+		if c.Op != OpRemoved {
+			if _, err := fmt.Fprintf(w, "+%s: %s\n", c.Path, formatValue(c.Desired)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func formatValue(v any) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(raw)
+}
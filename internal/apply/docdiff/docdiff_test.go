@@ -0,0 +1,154 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package docdiff_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/apply/docdiff"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+func manifestCell() v1beta1.CellDoc {
+	return v1beta1.CellDoc{
+		APIVersion: v1beta1.APIVersionV1Beta1,
+		Kind:       v1beta1.KindCell,
+		Metadata: v1beta1.CellMetadata{
+			Name:   "frontend",
+			Labels: map[string]string{"tier": "web"},
+		},
+		Spec: v1beta1.CellSpec{
+			RealmID: "shop",
+			SpaceID: "apps",
+			StackID: "web",
+			Containers: []v1beta1.ContainerSpec{
+				{ID: "nginx", Image: "nginx:1.27", Env: []string{"MODE=prod"}},
+			},
+		},
+	}
+}
+
+// storedCell returns the manifest cell as the store holds it after apply:
+// the daemon has filled in ids, a root container, managed labels, the
+// generation counter and status.
+func storedCell() v1beta1.CellDoc {
+	doc := manifestCell()
+	doc.Metadata.Labels = map[string]string{"tier": "web", "cell.kukeon.io": "frontend"}
+	doc.Metadata.Generation = 3
+	doc.Spec.ID = "frontend"
+	doc.Spec.RootContainerID = "root"
+	doc.Spec.Containers = []v1beta1.ContainerSpec{
+		{
+			ID: "root", Root: true, Image: "docker.io/library/busybox:latest",
+			RealmID: "shop", SpaceID: "apps", StackID: "web", CellID: "frontend",
+			ContainerdID: "apps_web_frontend_root",
+		},
+		{
+			ID: "nginx", Image: "nginx:1.27", Env: []string{"MODE=prod"},
+			RealmID: "shop", SpaceID: "apps", StackID: "web", CellID: "frontend",
+			ContainerdID: "apps_web_frontend_nginx",
+		},
+	}
+	doc.Status.State = v1beta1.CellStateReady
+	return doc
+}
+
+func compare(t *testing.T, live *v1beta1.CellDoc, desired v1beta1.CellDoc) []docdiff.Change {
+	t.Helper()
+	want, err := docdiff.Normalize(desired)
+	if err != nil {
+		t.Fatalf("Normalize(desired) error = %v", err)
+	}
+	if live == nil {
+		return docdiff.Compare(nil, want)
+	}
+	got, err := docdiff.Normalize(*live)
+	if err != nil {
+		t.Fatalf("Normalize(live) error = %v", err)
+	}
+	return docdiff.Compare(got, want)
+}
+
+func TestCompare_IdenticalIgnoresDaemonFields(t *testing.T) {
+	live := storedCell()
+	if changes := compare(t, &live, manifestCell()); len(changes) != 0 {
+		t.Fatalf("Compare() = %+v, want no changes", changes)
+	}
+}
+
+func TestCompare_ReportsChangedFields(t *testing.T) {
+	live := storedCell()
+	desired := manifestCell()
+	desired.Metadata.Labels = nil
+	desired.Spec.Containers[0].Image = "nginx:1.28"
+	desired.Spec.Containers[0].Env = []string{"MODE=prod", "DEBUG=1"}
+
+	changes := compare(t, &live, desired)
+	want := []docdiff.Change{
+		{Path: "metadata.labels.tier", Op: docdiff.OpRemoved, Live: "web"},
+		{Path: "spec.containers[nginx].env[1]", Op: docdiff.OpAdded, Desired: "DEBUG=1"},
+		{Path: "spec.containers[nginx].image", Op: docdiff.OpChanged, Live: "nginx:1.27", Desired: "nginx:1.28"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("Compare() = %+v, want %+v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("change[%d] = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+}
+
+func TestCompare_NewResourceIsAllAdded(t *testing.T) {
+	changes := compare(t, nil, manifestCell())
+	if len(changes) == 0 {
+		t.Fatal("Compare(nil, desired) returned no changes")
+	}
+	for _, c := range changes {
+		if c.Op != docdiff.OpAdded {
+			t.Errorf("change %+v: op = %s, want %s", c, c.Op, docdiff.OpAdded)
+		}
+	}
+
+	var out bytes.Buffer
+	res := docdiff.Result{Index: 0, Kind: v1beta1.KindCell, Name: "frontend", Changes: changes}
+	if err := docdiff.Render(&out, res); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	for _, line := range []string{
+		"--- live cell/frontend (not found)",
+		"+++ manifest cell/frontend (document 0)",
+		`+spec.containers[nginx].image: "nginx:1.27"`,
+	} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("Render() output missing %q:\n%s", line, out.String())
+		}
+	}
+}
+
+func TestRender_MatchingDocumentPrintsNothing(t *testing.T) {
+	var out bytes.Buffer
+	res := docdiff.Result{Kind: v1beta1.KindRealm, Name: "shop", Found: true}
+	if err := docdiff.Render(&out, res); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("Render() = %q, want empty", out.String())
+	}
+}
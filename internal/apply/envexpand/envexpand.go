@@ -0,0 +1,116 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package envexpand substitutes environment variables into manifest bytes
+// before they are parsed, for `kuke apply -f --expand-env`.
+package envexpand
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+// Lookup resolves a variable name; os.LookupEnv satisfies it.
+type Lookup func(name string) (string, bool)
+
+// Expand rewrites the references in data:
+//
+//   - ${NAME} becomes the value of NAME, which must be set (set to an empty
+//     string counts);
+//   - ${NAME:-default} becomes the value of NAME when it is set and
+//     non-empty, else default (taken literally, no nested references);
+//   - $$ becomes a literal $, so `$${PARAM}` survives as `${PARAM}` for a
+//     blueprint to fill in later.
+//
+// Any other $ is left as is. Every undefined variable is reported in one
+// ErrManifestEnvUndefined error; a malformed reference fails with
+// ErrManifestInvalid.
+func Expand(data []byte, lookup Lookup) ([]byte, error) {
+	if !bytes.ContainsRune(data, '$') {
+		return data, nil
+	}
+
+	var (
+		out       bytes.Buffer
+		undefined []string
+		seen      = map[string]bool{}
+	)
+	out.Grow(len(data))
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if c != '$' || i+1 >= len(data) {
+			out.WriteByte(c)
+			continue
+		}
+		switch data[i+1] {
+		case '$':
+			out.WriteByte('$')
+			i++
+		case '{':
+			end := bytes.IndexByte(data[i+2:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated ${ on line %d", errdefs.ErrManifestInvalid, lineOf(data, i))
+			}
+			ref := string(data[i+2 : i+2+end])
+			name, def, hasDefault := strings.Cut(ref, ":-")
+			if !validName(name) {
+				return nil, fmt.Errorf("%w: invalid variable reference ${%s} on line %d",
+					errdefs.ErrManifestInvalid, ref, lineOf(data, i))
+			}
+			value, ok := lookup(name)
+			switch {
+			case hasDefault && value == "":
+				value = def
+			case !ok && !seen[name]:
+				seen[name] = true
+				undefined = append(undefined, fmt.Sprintf("%s (line %d)", name, lineOf(data, i)))
+			}
+			out.WriteString(value)
+			i += 2 + end
+		default:
+			out.WriteByte(c)
+		}
+	}
+	if len(undefined) > 0 {
+		return nil, fmt.Errorf("%w: %s; set them or give a default with ${NAME:-value}",
+			errdefs.ErrManifestEnvUndefined, strings.Join(undefined, ", "))
+	}
+	return out.Bytes(), nil
+}
+
+// validName accepts the POSIX shell variable alphabet, the same shape
+// blueprint ${KEY} parameters use.
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_', r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func lineOf(data []byte, offset int) int {
+	return bytes.Count(data[:offset], []byte{'\n'}) + 1
+}
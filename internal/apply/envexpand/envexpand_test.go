@@ -0,0 +1,85 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package envexpand_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/apply/envexpand"
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+func env(vars map[string]string) envexpand.Lookup {
+	return func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+}
+
+func TestExpand(t *testing.T) {
+	vars := env(map[string]string{"IMAGE": "nginx:1.27", "EMPTY": ""})
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"defined var", "image: ${IMAGE}\n", "image: nginx:1.27\n"},
+		{"defined var beats default", "image: ${IMAGE:-busybox}", "image: nginx:1.27"},
+		{"default for unset var", "realm: ${REALM:-default}", "realm: default"},
+		{"default for empty var", "realm: ${EMPTY:-default}", "realm: default"},
+		{"empty default", "tag: '${TAG:-}'", "tag: ''"},
+		{"defined empty var", "tag: '${EMPTY}'", "tag: ''"},
+		{"escape", "cmd: echo $$HOME costs $$5", "cmd: echo $HOME costs $5"},
+		{"escaped reference is kept for blueprints", "port: $${PORT}", "port: ${PORT}"},
+		{"bare dollar left alone", "cmd: echo $HOME $", "cmd: echo $HOME $"},
+		{"no references", "kind: Realm\n", "kind: Realm\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := envexpand.Expand([]byte(tt.in), vars)
+			if err != nil {
+				t.Fatalf("Expand() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Expand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandUndefined(t *testing.T) {
+	in := "image: ${IMAGE}\nrealm: ${REALM}\nspace: ${IMAGE}\n"
+	_, err := envexpand.Expand([]byte(in), env(nil))
+	if !errors.Is(err, errdefs.ErrManifestEnvUndefined) {
+		t.Fatalf("Expand() error = %v, want ErrManifestEnvUndefined", err)
+	}
+	// Every missing name is reported once, at its first line.
+	if msg := err.Error(); !strings.Contains(msg, "IMAGE (line 1), REALM (line 2);") {
+		t.Errorf("error %q does not list IMAGE and REALM once each", msg)
+	}
+}
+
+func TestExpandMalformed(t *testing.T) {
+	for _, in := range []string{"image: ${IMAGE", "image: ${}", "image: ${1IMAGE}", "image: ${IM-AGE}"} {
+		if _, err := envexpand.Expand([]byte(in), env(nil)); !errors.Is(err, errdefs.ErrManifestInvalid) {
+			t.Errorf("Expand(%q) error = %v, want ErrManifestInvalid", in, err)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package lint statically checks a manifest bundle without touching the
+// store: every document is parsed and validated the way apply would, names
+// are held to the naming rules, cells get the hierarchy-free half of
+// ValidateCell, and references between documents are resolved within the
+// bundle. It backs `kuke validate`.
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/apischeme"
+	"github.com/eminwux/kukeon/internal/apply/parser"
+	"github.com/eminwux/kukeon/internal/consts"
+	"github.com/eminwux/kukeon/internal/controller"
+	"github.com/eminwux/kukeon/internal/util/naming"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+// Options tunes a lint run.
+type Options struct {
+	// Strict reports a reference to a realm, space, stack or cell that the
+	// bundle does not declare as an error instead of a warning. Use it for
+	// bundles meant to be self-contained.
+	Strict bool
+}
+
+// Report is the outcome of linting one bundle.
+type Report struct {
+	// Documents is the number of documents found in the bundle.
+	Documents int
+	Errors    []*parser.ValidationError
+	Warnings  []*parser.ValidationError
+}
+
+// OK reports whether the bundle has no errors. Warnings do not count.
+func (r Report) OK() bool {
+	return len(r.Errors) == 0
+}
+
+// Lint checks every document in raw and collects all problems rather than
+// stopping at the first. The returned error is reserved for input that is
+// not a document stream at all (e.g. it holds no documents).
+func Lint(raw []byte, opts Options) (Report, error) {
+	rawDocs, err := parser.ParseDocuments(bytes.NewReader(raw))
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{Documents: len(rawDocs)}
+	docs := make([]*parser.Document, 0, len(rawDocs))
+	for i, rawDoc := range rawDocs {
+		doc, parseErr := parser.ParseDocument(i, rawDoc)
+		if parseErr != nil {
+			report.Errors = append(report.Errors, &parser.ValidationError{Index: i, Err: parseErr})
+			continue
+		}
+		if validationErr := parser.ValidateDocument(doc); validationErr != nil {
+			report.Errors = append(report.Errors, validationErr)
+			continue
+		}
+		report.Errors = append(report.Errors, lintDocument(doc)...)
+		docs = append(docs, doc)
+	}
+
+	idx := newIndex()
+	for _, doc := range docs {
+		if dup := idx.add(doc); dup != nil {
+			report.Errors = append(report.Errors, dup)
+		}
+	}
+	for _, doc := range docs {
+		unresolved := idx.unresolved(doc)
+		switch {
+		case unresolved == nil:
+		case opts.Strict:
+			report.Errors = append(report.Errors, unresolved)
+		default:
+			unresolved.Err = fmt.Errorf("%w; assumed to exist", unresolved.Err)
+			report.Warnings = append(report.Warnings, unresolved)
+		}
+	}
+	return report, nil
+}
+
+// lintDocument applies the checks ValidateDocument leaves to the create
+// paths: the naming rules and, for cells, the container-level validation.
+func lintDocument(doc *parser.Document) []*parser.ValidationError {
+	name := doc.Name()
+	var problems []error
+	switch doc.Kind {
+	case v1beta1.KindRealm:
+		problems = appendErr(problems, naming.ValidateRealmName(name))
+		if ns := doc.RealmDoc.Spec.Namespace; ns != "" {
+			problems = appendErr(problems, naming.ValidateRealmNamespace(ns))
+		}
+	case v1beta1.KindSpace:
+		problems = appendErr(problems, naming.ValidateHierarchyName("space", name))
+	case v1beta1.KindStack:
+		problems = appendErr(problems, naming.ValidateHierarchyName("stack", name))
+	case v1beta1.KindContainer:
+		problems = appendErr(problems, naming.ValidateHierarchyName("container", name))
+	case v1beta1.KindCell:
+		problems = appendErr(problems, naming.ValidateHierarchyName("cell", name))
+		for _, container := range doc.CellDoc.Spec.Containers {
+			problems = appendErr(problems, naming.ValidateHierarchyName("container", container.ID))
+		}
+		cell, err := apischeme.ConvertCellDocToInternal(*doc.CellDoc)
+		if err != nil {
+			problems = append(problems, err)
+			break
+		}
+		problems = appendErr(problems, controller.ValidateCellSpec(cell))
+	default:
+		// Secrets, blueprints, configs and volumes are fully covered by
+		// parser.ValidateDocument.
+	}
+
+	out := make([]*parser.ValidationError, 0, len(problems))
+	for _, problem := range problems {
+		out = append(out, &parser.ValidationError{Index: doc.Index, Kind: doc.Kind, Name: name, Err: problem})
+	}
+	return out
+}
+
+func appendErr(problems []error, err error) []error {
+	if err == nil {
+		return problems
+	}
+	return append(problems, err)
+}
+
+// index records the hierarchy the bundle declares, keyed by kind and the
+// full realm/space/stack/cell path, so a reference resolves only to a
+// resource declared under the same parents.
+type index struct {
+	declared map[string]int
+}
+
+func newIndex() *index {
+	idx := &index{declared: make(map[string]int)}
+	// kuke init provisions the default hierarchy, so references to it
+	// always resolve.
+	realm, space, stack := consts.KukeonDefaultRealmName, consts.KukeonDefaultSpaceName, consts.KukeonDefaultStackName
+	for _, key := range []string{
+		hierarchyKey(v1beta1.KindRealm, realm),
+		hierarchyKey(v1beta1.KindSpace, realm, space),
+		hierarchyKey(v1beta1.KindStack, realm, space, stack),
+	} {
+		idx.declared[key] = -1
+	}
+	return idx
+}
+
+func hierarchyKey(kind v1beta1.Kind, path ...string) string {
+	return string(kind) + ":" + strings.Join(path, "/")
+}
+
+// path returns the document's own hierarchy key, or "" for kinds that are
+// not part of the realm/space/stack/cell tree.
+func path(doc *parser.Document) string {
+	switch doc.Kind {
+	case v1beta1.KindRealm:
+		return hierarchyKey(doc.Kind, doc.RealmDoc.Metadata.Name)
+	case v1beta1.KindSpace:
+		s := doc.SpaceDoc
+		return hierarchyKey(doc.Kind, s.Spec.RealmID, s.Metadata.Name)
+	case v1beta1.KindStack:
+		s := doc.StackDoc
+		return hierarchyKey(doc.Kind, s.Spec.RealmID, s.Spec.SpaceID, s.Metadata.Name)
+	case v1beta1.KindCell:
+		c := doc.CellDoc
+		return hierarchyKey(doc.Kind, c.Spec.RealmID, c.Spec.SpaceID, c.Spec.StackID, c.Metadata.Name)
+	case v1beta1.KindContainer:
+		c := doc.ContainerDoc
+		return hierarchyKey(doc.Kind, c.Spec.RealmID, c.Spec.SpaceID, c.Spec.StackID, c.Spec.CellID,
+			c.Metadata.Name)
+	default:
+		return ""
+	}
+}
+
+// add records doc and reports a document that declares the same resource
+// twice; apply would silently let the later one win.
+func (idx *index) add(doc *parser.Document) *parser.ValidationError {
+	key := path(doc)
+	if key == "" {
+		return nil
+	}
+	if first, ok := idx.declared[key]; ok && first >= 0 {
+		return &parser.ValidationError{
+			Index: doc.Index,
+			Kind:  doc.Kind,
+			Name:  doc.Name(),
+			Err:   fmt.Errorf("duplicate of document %d", first),
+		}
+	}
+	idx.declared[key] = doc.Index
+	return nil
+}
+
+// unresolved walks doc's parents top-down and reports the first one the
+// bundle does not declare; its descendants cannot be declared either.
+func (idx *index) unresolved(doc *parser.Document) *parser.ValidationError {
+	var realm, space, stack, cell string
+	switch doc.Kind {
+	case v1beta1.KindSpace:
+		realm = doc.SpaceDoc.Spec.RealmID
+	case v1beta1.KindStack:
+		realm, space = doc.StackDoc.Spec.RealmID, doc.StackDoc.Spec.SpaceID
+	case v1beta1.KindCell:
+		s := doc.CellDoc.Spec
+		realm, space, stack = s.RealmID, s.SpaceID, s.StackID
+	case v1beta1.KindContainer:
+		s := doc.ContainerDoc.Spec
+		realm, space, stack, cell = s.RealmID, s.SpaceID, s.StackID, s.CellID
+	default:
+		return nil
+	}
+
+	parents := []struct {
+		kind v1beta1.Kind
+		path []string
+	}{
+		{v1beta1.KindRealm, []string{realm}},
+		{v1beta1.KindSpace, []string{realm, space}},
+		{v1beta1.KindStack, []string{realm, space, stack}},
+		{v1beta1.KindCell, []string{realm, space, stack, cell}},
+	}
+	for _, parent := range parents {
+		name := parent.path[len(parent.path)-1]
+		if name == "" {
+			break
+		}
+		if _, ok := idx.declared[hierarchyKey(parent.kind, parent.path...)]; ok {
+			continue
+		}
+		return &parser.ValidationError{
+			Index: doc.Index,
+			Kind:  doc.Kind,
+			Name:  doc.Name(),
+			Err: fmt.Errorf("references %s %q (%s), which this file does not declare",
+				strings.ToLower(string(parent.kind)), name, strings.Join(parent.path, "/")),
+		}
+	}
+	return nil
+}
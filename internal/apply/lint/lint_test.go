@@ -0,0 +1,201 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lint_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/apply/lint"
+)
+
+const validBundle = `apiVersion: v1beta1
+kind: Realm
+metadata:
+  name: shop
+---
+apiVersion: v1beta1
+kind: Space
+metadata:
+  name: apps
+spec:
+  realmId: shop
+---
+apiVersion: v1beta1
+kind: Stack
+metadata:
+  name: web
+spec:
+  realmId: shop
+  spaceId: apps
+---
+apiVersion: v1beta1
+kind: Cell
+metadata:
+  name: frontend
+spec:
+  realmId: shop
+  spaceId: apps
+  stackId: web
+  containers:
+    - id: nginx
+      image: nginx:1.27
+      imagePullPolicy: IfNotPresent
+`
+
+func TestLint_ValidBundle(t *testing.T) {
+	report, err := lint.Lint([]byte(validBundle), lint.Options{Strict: true})
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if !report.OK() || len(report.Warnings) != 0 {
+		t.Fatalf("Lint() errors = %v, warnings = %v; want none", report.Errors, report.Warnings)
+	}
+	if report.Documents != 4 {
+		t.Errorf("Documents = %d, want 4", report.Documents)
+	}
+}
+
+func TestLint_DefaultHierarchyResolves(t *testing.T) {
+	const cell = `apiVersion: v1beta1
+kind: Cell
+metadata:
+  name: scratch
+spec:
+  realmId: default
+  spaceId: default
+  stackId: default
+  containers:
+    - id: sh
+      image: busybox
+`
+	report, err := lint.Lint([]byte(cell), lint.Options{Strict: true})
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if !report.OK() || len(report.Warnings) != 0 {
+		t.Fatalf("Lint() errors = %v, warnings = %v; want none", report.Errors, report.Warnings)
+	}
+}
+
+func TestLint_Malformed(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		// wantErrs are substrings that must each appear in some error.
+		wantErrs []string
+	}{
+		{
+			name:     "missing required field",
+			yaml:     "apiVersion: v1beta1\nkind: Space\nmetadata:\n  name: apps\n",
+			wantErrs: []string{"spec.realmId is required"},
+		},
+		{
+			name:     "unknown kind",
+			yaml:     "apiVersion: v1beta1\nkind: Gadget\nmetadata:\n  name: x\n",
+			wantErrs: []string{"Gadget"},
+		},
+		{
+			name:     "bad name",
+			yaml:     "apiVersion: v1beta1\nkind: Realm\nmetadata:\n  name: my_realm\n",
+			wantErrs: []string{`"my_realm" contains disallowed character`},
+		},
+		{
+			name: "bad enum and duplicate container",
+			yaml: `apiVersion: v1beta1
+kind: Cell
+metadata:
+  name: frontend
+spec:
+  realmId: default
+  spaceId: default
+  stackId: default
+  containers:
+    - id: app
+      image: nginx
+      imagePullPolicy: Sometimes
+    - id: app
+      image: redis
+`,
+			wantErrs: []string{"invalid image pull policy", `container "app" is declared more than once`},
+		},
+		{
+			name:     "duplicate document",
+			yaml:     "apiVersion: v1beta1\nkind: Realm\nmetadata:\n  name: shop\n---\napiVersion: v1beta1\nkind: Realm\nmetadata:\n  name: shop\n",
+			wantErrs: []string{"duplicate of document 0"},
+		},
+		{
+			name: "errors across documents are all reported",
+			yaml: "apiVersion: v1beta1\nkind: Realm\nmetadata:\n  name: a/b\n---\n" +
+				"apiVersion: v1beta1\nkind: Stack\nmetadata:\n  name: web\nspec:\n  realmId: default\n",
+			wantErrs: []string{`"a/b" contains disallowed character`, "spec.spaceId is required"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := lint.Lint([]byte(tt.yaml), lint.Options{})
+			if err != nil {
+				t.Fatalf("Lint() error = %v", err)
+			}
+			if report.OK() {
+				t.Fatal("Lint() reported no errors")
+			}
+			var all []string
+			for _, e := range report.Errors {
+				all = append(all, e.Error())
+			}
+			joined := strings.Join(all, "\n")
+			for _, want := range tt.wantErrs {
+				if !strings.Contains(joined, want) {
+					t.Errorf("errors missing %q\nGot:\n%s", want, joined)
+				}
+			}
+		})
+	}
+}
+
+func TestLint_UnresolvedReference(t *testing.T) {
+	const stack = `apiVersion: v1beta1
+kind: Stack
+metadata:
+  name: web
+spec:
+  realmId: shop
+  spaceId: apps
+`
+	report, err := lint.Lint([]byte(stack), lint.Options{})
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if !report.OK() {
+		t.Fatalf("Lint() errors = %v, want a warning only", report.Errors)
+	}
+	if len(report.Warnings) != 1 ||
+		!strings.Contains(report.Warnings[0].Error(), `references realm "shop"`) ||
+		!strings.Contains(report.Warnings[0].Error(), "assumed to exist") {
+		t.Fatalf("Warnings = %v, want one for the undeclared realm", report.Warnings)
+	}
+
+	report, err = lint.Lint([]byte(stack), lint.Options{Strict: true})
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if report.OK() || len(report.Warnings) != 0 {
+		t.Fatalf("strict Lint() errors = %v, warnings = %v; want the reference as an error",
+			report.Errors, report.Warnings)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+	"gopkg.in/yaml.v3"
+)
+
+// EncodeDocuments writes docs as a `---`-separated multi-document YAML stream
+// that ParseDocuments reads back. Each document's top-level `status` key is
+// dropped so the stream is purely declarative.
+func EncodeDocuments(w io.Writer, docs []Document) error {
+	for i, doc := range docs {
+		typed, err := typedDocument(doc)
+		if err != nil {
+			return fmt.Errorf("document %d: %w", i, err)
+		}
+
+		var node yaml.Node
+		if err = node.Encode(typed); err != nil {
+			return fmt.Errorf("document %d: failed to encode %s: %w", i, doc.Kind, err)
+		}
+		dropMappingKey(&node, "status")
+
+		out, err := yaml.Marshal(&node)
+		if err != nil {
+			return fmt.Errorf("document %d: failed to encode %s: %w", i, doc.Kind, err)
+		}
+		if _, err = io.WriteString(w, "---\n"); err != nil {
+			return err
+		}
+		if _, err = w.Write(out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// typedDocument returns the typed doc pointer matching doc.Kind.
+func typedDocument(doc Document) (any, error) {
+	var (
+		typed any
+		isNil bool
+	)
+	switch doc.Kind {
+	case v1beta1.KindRealm:
+		typed, isNil = doc.RealmDoc, doc.RealmDoc == nil
+	case v1beta1.KindSpace:
+		typed, isNil = doc.SpaceDoc, doc.SpaceDoc == nil
+	case v1beta1.KindStack:
+		typed, isNil = doc.StackDoc, doc.StackDoc == nil
+	case v1beta1.KindCell:
+		typed, isNil = doc.CellDoc, doc.CellDoc == nil
+	case v1beta1.KindContainer:
+		typed, isNil = doc.ContainerDoc, doc.ContainerDoc == nil
+	case v1beta1.KindSecret:
+		typed, isNil = doc.SecretDoc, doc.SecretDoc == nil
+	case v1beta1.KindCellBlueprint:
+		typed, isNil = doc.CellBlueprintDoc, doc.CellBlueprintDoc == nil
+	case v1beta1.KindCellConfig:
+		typed, isNil = doc.CellConfigDoc, doc.CellConfigDoc == nil
+	case v1beta1.KindVolume:
+		typed, isNil = doc.VolumeDoc, doc.VolumeDoc == nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errdefs.ErrUnknownKind, doc.Kind)
+	}
+	if isNil {
+		return nil, fmt.Errorf("%s document is nil", doc.Kind)
+	}
+	return typed, nil
+}
+
+// dropMappingKey removes key from a mapping node in place; other node kinds
+// are left untouched.
+func dropMappingKey(node *yaml.Node, key string) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
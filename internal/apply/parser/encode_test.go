@@ -0,0 +1,88 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package parser_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/apply/parser"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+func TestEncodeDocuments_DropsStatusAndRoundTrips(t *testing.T) {
+	docs := []parser.Document{
+		{
+			Kind: v1beta1.KindRealm,
+			RealmDoc: &v1beta1.RealmDoc{
+				APIVersion: v1beta1.APIVersionV1Beta1,
+				Kind:       v1beta1.KindRealm,
+				Metadata:   v1beta1.RealmMetadata{Name: "r1"},
+				Spec:       v1beta1.RealmSpec{Namespace: "r1.kukeon.io"},
+				Status:     v1beta1.RealmStatus{CgroupPath: "/kukeon/r1"},
+			},
+		},
+		{
+			Kind: v1beta1.KindSpace,
+			SpaceDoc: &v1beta1.SpaceDoc{
+				APIVersion: v1beta1.APIVersionV1Beta1,
+				Kind:       v1beta1.KindSpace,
+				Metadata:   v1beta1.SpaceMetadata{Name: "s1"},
+				Spec:       v1beta1.SpaceSpec{RealmID: "r1"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := parser.EncodeDocuments(&buf, docs); err != nil {
+		t.Fatalf("EncodeDocuments() error = %v", err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "status:") || strings.Contains(out, "/kukeon/r1") {
+		t.Errorf("encoded stream still carries status:\n%s", out)
+	}
+
+	raws, err := parser.ParseDocuments(strings.NewReader(out))
+	if err != nil {
+		t.Fatalf("ParseDocuments() error = %v", err)
+	}
+	if len(raws) != len(docs) {
+		t.Fatalf("got %d documents, want %d", len(raws), len(docs))
+	}
+	space, err := parser.ParseDocument(1, raws[1])
+	if err != nil {
+		t.Fatalf("ParseDocument() error = %v", err)
+	}
+	if space.SpaceDoc == nil || space.SpaceDoc.Spec.RealmID != "r1" {
+		t.Errorf("space did not round-trip: %+v", space.SpaceDoc)
+	}
+}
+
+func TestEncodeDocuments_RejectsMismatchedKind(t *testing.T) {
+	var buf bytes.Buffer
+	err := parser.EncodeDocuments(&buf, []parser.Document{{Kind: v1beta1.KindRealm}})
+	if err == nil || !strings.Contains(err.Error(), "document is nil") {
+		t.Errorf("EncodeDocuments(nil realm) error = %v, want nil-document error", err)
+	}
+	err = parser.EncodeDocuments(&buf, []parser.Document{{Kind: "Bogus"}})
+	if !errors.Is(err, errdefs.ErrUnknownKind) {
+		t.Errorf("EncodeDocuments(unknown kind) error = %v, want ErrUnknownKind", err)
+	}
+}
@@ -47,6 +47,33 @@ type Document struct {
 	VolumeDoc        *v1beta1.VolumeDoc
 }
 
+// Name returns the metadata.name of the typed document, or "" for an
+// unknown kind.
+func (d *Document) Name() string {
+	switch d.Kind {
+	case v1beta1.KindRealm:
+		return d.RealmDoc.Metadata.Name
+	case v1beta1.KindSpace:
+		return d.SpaceDoc.Metadata.Name
+	case v1beta1.KindStack:
+		return d.StackDoc.Metadata.Name
+	case v1beta1.KindCell:
+		return d.CellDoc.Metadata.Name
+	case v1beta1.KindContainer:
+		return d.ContainerDoc.Metadata.Name
+	case v1beta1.KindSecret:
+		return d.SecretDoc.Metadata.Name
+	case v1beta1.KindCellBlueprint:
+		return d.CellBlueprintDoc.Metadata.Name
+	case v1beta1.KindCellConfig:
+		return d.CellConfigDoc.Metadata.Name
+	case v1beta1.KindVolume:
+		return d.VolumeDoc.Metadata.Name
+	default:
+		return ""
+	}
+}
+
 // ValidationError represents a validation error for a specific document.
 type ValidationError struct {
 	Index int
@@ -319,9 +319,15 @@ func materializeContainer(bc v1beta1.BlueprintContainer) (v1beta1.ContainerSpec,
 		HostPID:                bc.HostPID,
 		HostCgroup:             bc.HostCgroup,
 		User:                   bc.User,
+		SupplementaryGroups:    bc.SupplementaryGroups,
 		ReadOnlyRootFilesystem: bc.ReadOnlyRootFilesystem,
+		WritableTmp:            bc.WritableTmp,
 		Capabilities:           bc.Capabilities,
 		SecurityOpts:           bc.SecurityOpts,
+		NoNewPrivileges:        bc.NoNewPrivileges,
+		Sysctls:                bc.Sysctls,
+		OOMScoreAdj:            bc.OOMScoreAdj,
+		TerminationMessagePath: bc.TerminationMessagePath,
 		Devices:                bc.Devices,
 		Tmpfs:                  bc.Tmpfs,
 		Resources:              bc.Resources,
@@ -214,9 +214,15 @@ func materializeContainer(
 		HostPID:                bc.HostPID,
 		HostCgroup:             bc.HostCgroup,
 		User:                   bc.User,
+		SupplementaryGroups:    bc.SupplementaryGroups,
 		ReadOnlyRootFilesystem: bc.ReadOnlyRootFilesystem,
+		WritableTmp:            bc.WritableTmp,
 		Capabilities:           bc.Capabilities,
 		SecurityOpts:           bc.SecurityOpts,
+		NoNewPrivileges:        bc.NoNewPrivileges,
+		Sysctls:                bc.Sysctls,
+		OOMScoreAdj:            bc.OOMScoreAdj,
+		TerminationMessagePath: bc.TerminationMessagePath,
 		Devices:                bc.Devices,
 		Tmpfs:                  bc.Tmpfs,
 		Resources:              bc.Resources,
@@ -26,6 +26,7 @@ package local
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -35,6 +36,7 @@ import (
 	"github.com/eminwux/kukeon/internal/apply/parser"
 	"github.com/eminwux/kukeon/internal/controller"
 	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/events"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 	"github.com/eminwux/kukeon/internal/util/fs"
 	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
@@ -302,6 +304,48 @@ func (c *Client) GetCell(_ context.Context, doc v1beta1.CellDoc) (kukeonv1.GetCe
 	}, nil
 }
 
+func (c *Client) CellLiveStatus(_ context.Context, doc v1beta1.CellDoc) (kukeonv1.CellLiveStatusResult, error) {
+	internal, _, err := apischeme.NormalizeCell(doc)
+	if err != nil {
+		return kukeonv1.CellLiveStatusResult{}, fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+	}
+	res, err := c.ctrl.CellLiveStatus(internal)
+	if err != nil {
+		return kukeonv1.CellLiveStatusResult{}, err
+	}
+	out := kukeonv1.CellLiveStatusResult{State: v1beta1.CellState(res.State), Live: res.Live}
+	if res.Containers != nil {
+		out.Containers = make(map[string]v1beta1.ContainerState, len(res.Containers))
+		for id, state := range res.Containers {
+			out.Containers[id] = v1beta1.ContainerState(state)
+		}
+	}
+	return out, nil
+}
+
+func (c *Client) InspectContainer(
+	_ context.Context,
+	doc v1beta1.ContainerDoc,
+) (kukeonv1.InspectContainerResult, error) {
+	internal, _, err := apischeme.NormalizeContainer(doc)
+	if err != nil {
+		return kukeonv1.InspectContainerResult{}, fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+	}
+	res, err := c.ctrl.InspectContainer(internal)
+	if err != nil {
+		return kukeonv1.InspectContainerResult{}, err
+	}
+	spec, err := json.Marshal(res.Spec)
+	if err != nil {
+		return kukeonv1.InspectContainerResult{}, fmt.Errorf("failed to encode OCI spec: %w", err)
+	}
+	record, err := json.Marshal(res.Record)
+	if err != nil {
+		return kukeonv1.InspectContainerResult{}, fmt.Errorf("failed to encode containerd record: %w", err)
+	}
+	return kukeonv1.InspectContainerResult{OCISpec: spec, Containerd: record}, nil
+}
+
 func (c *Client) GetContainer(_ context.Context, doc v1beta1.ContainerDoc) (kukeonv1.GetContainerResult, error) {
 	internal, _, err := apischeme.NormalizeContainer(doc)
 	if err != nil {
@@ -495,7 +539,16 @@ func (c *Client) ListStacks(_ context.Context, realmName, spaceName string) ([]v
 }
 
 func (c *Client) ListCells(_ context.Context, realmName, spaceName, stackName string) ([]v1beta1.CellDoc, error) {
-	cells, err := c.ctrl.ListCells(realmName, spaceName, stackName)
+	var (
+		cells []intmodel.Cell
+		err   error
+	)
+	// No scope filter: walk every realm/space/stack (-A/--all).
+	if realmName == "" && spaceName == "" && stackName == "" {
+		cells, err = c.ctrl.ListAllCells()
+	} else {
+		cells, err = c.ctrl.ListCells(realmName, spaceName, stackName)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -510,7 +563,16 @@ func (c *Client) ListContainers(
 	_ context.Context,
 	realmName, spaceName, stackName, cellName string,
 ) ([]v1beta1.ContainerSpec, error) {
-	specs, err := c.ctrl.ListContainers(realmName, spaceName, stackName, cellName)
+	var (
+		specs []intmodel.ContainerSpec
+		err   error
+	)
+	// No scope filter: walk every realm/space/stack (-A/--all).
+	if realmName == "" && spaceName == "" && stackName == "" && cellName == "" {
+		specs, err = c.ctrl.ListAllContainers()
+	} else {
+		specs, err = c.ctrl.ListContainers(realmName, spaceName, stackName, cellName)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -521,6 +583,23 @@ func (c *Client) ListContainers(
 	return derefDocs(ext), nil
 }
 
+func (c *Client) ListContainersPage(
+	_ context.Context,
+	realmName, spaceName, stackName, cellName string,
+	limit int,
+	continueToken string,
+) (kukeonv1.ListContainersPageResult, error) {
+	page, err := c.ctrl.ListContainersPage(realmName, spaceName, stackName, cellName, limit, continueToken)
+	if err != nil {
+		return kukeonv1.ListContainersPageResult{}, err
+	}
+	ext, err := fs.ConvertContainerSpecListToExternal(page.Containers)
+	if err != nil {
+		return kukeonv1.ListContainersPageResult{}, fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+	}
+	return kukeonv1.ListContainersPageResult{Containers: derefDocs(ext), Continue: page.Continue}, nil
+}
+
 func (c *Client) ListSecrets(
 	_ context.Context,
 	realmName, spaceName, stackName, cellName string,
@@ -654,6 +733,7 @@ func (c *Client) DeleteRealm(
 		MetadataDeleted:            res.MetadataDeleted,
 		CgroupDeleted:              res.CgroupDeleted,
 		ContainerdNamespaceDeleted: res.ContainerdNamespaceDeleted,
+		BackupPath:                 res.BackupPath,
 	}, nil
 }
 
@@ -826,6 +906,7 @@ func (c *Client) PurgeRealm(
 		Cascade:        res.Cascade,
 		Deleted:        res.Deleted,
 		Purged:         res.Purged,
+		BackupPath:     res.BackupPath,
 	}, nil
 }
 
@@ -922,6 +1003,13 @@ func (c *Client) ReconcileCells() (controller.ReconcileResult, error) {
 	return c.ctrl.ReconcileCells()
 }
 
+// WatchContainerExits runs the daemon-side container exit watcher until ctx
+// is cancelled or the event subscription fails. Daemon-internal: not surfaced
+// over the kukeonv1 wire.
+func (c *Client) WatchContainerExits(ctx context.Context) error {
+	return c.ctrl.WatchContainerExits(ctx)
+}
+
 // ReconcileSpaceNetworks runs one pass of the daemon-side Space network
 // reconciliation loop (#1074): re-asserts each space's CNI conflist/bridge
 // and egress policy from space.Spec.Network. Daemon-internal: not surfaced
@@ -930,6 +1018,325 @@ func (c *Client) ReconcileSpaceNetworks() (controller.SpaceNetReconcileResult, e
 	return c.ctrl.ReconcileSpaceNetworks()
 }
 
+// ---- Rename ----
+
+func (c *Client) RenameRealm(_ context.Context, doc v1beta1.RealmDoc, newName string) (kukeonv1.RenameRealmResult, error) {
+	internal, version, err := apischeme.NormalizeRealm(doc)
+	if err != nil {
+		return kukeonv1.RenameRealmResult{}, fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+	}
+	res, err := c.ctrl.RenameRealm(internal, newName)
+	if err != nil {
+		return kukeonv1.RenameRealmResult{}, err
+	}
+	ext, err := apischeme.BuildRealmExternalFromInternal(res.Realm, version)
+	if err != nil {
+		return kukeonv1.RenameRealmResult{}, fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+	}
+	return kukeonv1.RenameRealmResult{Realm: ext, OldName: res.OldName}, nil
+}
+
+func (c *Client) RenameSpace(_ context.Context, doc v1beta1.SpaceDoc, newName string) (kukeonv1.RenameSpaceResult, error) {
+	internal, version, err := apischeme.NormalizeSpace(doc)
+	if err != nil {
+		return kukeonv1.RenameSpaceResult{}, fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+	}
+	res, err := c.ctrl.RenameSpace(internal, newName)
+	if err != nil {
+		return kukeonv1.RenameSpaceResult{}, err
+	}
+	ext, err := apischeme.BuildSpaceExternalFromInternal(res.Space, version)
+	if err != nil {
+		return kukeonv1.RenameSpaceResult{}, fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+	}
+	return kukeonv1.RenameSpaceResult{Space: ext, OldName: res.OldName}, nil
+}
+
+func (c *Client) RenameStack(_ context.Context, doc v1beta1.StackDoc, newName string) (kukeonv1.RenameStackResult, error) {
+	internal, version, err := apischeme.NormalizeStack(doc)
+	if err != nil {
+		return kukeonv1.RenameStackResult{}, fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+	}
+	res, err := c.ctrl.RenameStack(internal, newName)
+	if err != nil {
+		return kukeonv1.RenameStackResult{}, err
+	}
+	ext, err := apischeme.BuildStackExternalFromInternal(res.Stack, version)
+	if err != nil {
+		return kukeonv1.RenameStackResult{}, fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+	}
+	return kukeonv1.RenameStackResult{Stack: ext, OldName: res.OldName}, nil
+}
+
+func (c *Client) RenameCell(_ context.Context, doc v1beta1.CellDoc, newName string) (kukeonv1.RenameCellResult, error) {
+	internal, version, err := apischeme.NormalizeCell(doc)
+	if err != nil {
+		return kukeonv1.RenameCellResult{}, fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+	}
+	res, err := c.ctrl.RenameCell(internal, newName)
+	if err != nil {
+		return kukeonv1.RenameCellResult{}, err
+	}
+	ext, err := apischeme.BuildCellExternalFromInternal(res.Cell, version)
+	if err != nil {
+		return kukeonv1.RenameCellResult{}, fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+	}
+	return kukeonv1.RenameCellResult{Cell: ext, OldName: res.OldName}, nil
+}
+
+// ---- Scale ----
+
+func (c *Client) ScaleCell(
+	_ context.Context, realm, space, stack, template string, replicas int,
+) (kukeonv1.ScaleCellResult, error) {
+	res, err := c.ctrl.ScaleCell(realm, space, stack, template, replicas)
+	return kukeonv1.ScaleCellResult{
+		Template:  res.Template,
+		Replicas:  res.Replicas,
+		Created:   res.Created,
+		Removed:   res.Removed,
+		Unchanged: res.Unchanged,
+	}, err
+}
+
+// ---- Patch ----
+
+func (c *Client) PatchResource(
+	_ context.Context, ref kukeonv1.PatchRef, patchType kukeonv1.PatchType, patch []byte,
+) (kukeonv1.PatchResourceResult, error) {
+	res, err := c.ctrl.PatchResource(controller.PatchRef{
+		Kind:  ref.Kind,
+		Name:  ref.Name,
+		Realm: ref.Realm,
+		Space: ref.Space,
+		Stack: ref.Stack,
+	}, controller.PatchType(patchType), patch)
+	return kukeonv1.PatchResourceResult{
+		Kind:    res.Kind,
+		Name:    res.Name,
+		Action:  res.Action,
+		Changes: res.Changes,
+	}, err
+}
+
+// ---- Export ----
+
+func (c *Client) ExportRealm(
+	_ context.Context, realm string, includeSecrets bool,
+) (kukeonv1.ExportRealmResult, error) {
+	res, err := c.ctrl.ExportRealm(realm, includeSecrets)
+	if err != nil {
+		return kukeonv1.ExportRealmResult{}, err
+	}
+	var buf bytes.Buffer
+	if err = parser.EncodeDocuments(&buf, res.Documents); err != nil {
+		return kukeonv1.ExportRealmResult{}, err
+	}
+	return kukeonv1.ExportRealmResult{Manifest: buf.Bytes(), Redacted: res.Redacted}, nil
+}
+
+// ---- Orphans ----
+
+func (c *Client) FindOrphans(_ context.Context, realm string, purge bool) (kukeonv1.FindOrphansResult, error) {
+	var (
+		res controller.OrphansResult
+		err error
+	)
+	if purge {
+		res, err = c.ctrl.PurgeOrphans(realm)
+	} else {
+		res, err = c.ctrl.FindOrphans(realm)
+	}
+	out := kukeonv1.FindOrphansResult{
+		Realm:     res.Realm,
+		Namespace: res.Namespace,
+		Orphans:   make([]kukeonv1.OrphanContainer, 0, len(res.Orphans)),
+		Purged:    res.Purged,
+	}
+	for _, orphan := range res.Orphans {
+		out.Orphans = append(out.Orphans, kukeonv1.OrphanContainer{
+			ContainerdID: orphan.ContainerdID,
+			Space:        orphan.Space,
+			Stack:        orphan.Stack,
+			Cell:         orphan.Cell,
+		})
+	}
+	return out, err
+}
+
+// ---- Cell usage ----
+
+func (c *Client) ListCellUsage(_ context.Context, realm, space, stack string) ([]kukeonv1.CellUsage, error) {
+	res, err := c.ctrl.ListCellUsage(realm, space, stack)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]kukeonv1.CellUsage, 0, len(res))
+	for _, r := range res {
+		u := kukeonv1.CellUsage{
+			Realm:       r.Cell.Spec.RealmName,
+			Space:       r.Cell.Spec.SpaceName,
+			Stack:       r.Cell.Spec.StackName,
+			Cell:        r.Cell.Metadata.Name,
+			State:       v1beta1.CellState(r.Cell.Status.State),
+			Provisioned: r.Usage != nil,
+		}
+		if r.Usage != nil {
+			u.MemoryBytes = r.Usage.MemoryCurrent
+			u.MemoryLimitBytes = r.Usage.MemoryMax
+			u.CPUUsageUsec = r.Usage.CPUUsageUsec
+			u.Pids = r.Usage.PidsCurrent
+		}
+		out = append(out, u)
+	}
+	return out, nil
+}
+
+// ---- Node summary ----
+
+func (c *Client) NodeSummary(_ context.Context) (kukeonv1.NodeSummaryResult, error) {
+	res, err := c.ctrl.NodeSummary()
+	if err != nil {
+		return kukeonv1.NodeSummaryResult{}, err
+	}
+	out := kukeonv1.NodeSummaryResult{
+		CgroupRoot:  res.CgroupRoot,
+		Provisioned: res.Usage != nil,
+		Realms:      res.Realms,
+		Spaces:      res.Spaces,
+		Stacks:      res.Stacks,
+		Cells:       res.Cells,
+		Containers:  res.Containers,
+	}
+	if res.Usage != nil {
+		out.MemoryBytes = res.Usage.MemoryCurrent
+		out.MemoryLimitBytes = res.Usage.MemoryMax
+		out.CPUUsageUsec = res.Usage.CPUUsageUsec
+		out.Pids = res.Usage.PidsCurrent
+		out.OOMKills = res.Usage.OOMKills
+	}
+	return out, nil
+}
+
+// ---- Node cordon ----
+
+func (c *Client) CordonNode(_ context.Context, reason string) (kukeonv1.NodeCordonResult, error) {
+	res, err := c.ctrl.CordonNode(reason)
+	if err != nil {
+		return kukeonv1.NodeCordonResult{}, err
+	}
+	return toNodeCordonResult(res), nil
+}
+
+func (c *Client) UncordonNode(_ context.Context) (kukeonv1.NodeCordonResult, error) {
+	res, err := c.ctrl.UncordonNode()
+	if err != nil {
+		return kukeonv1.NodeCordonResult{}, err
+	}
+	return toNodeCordonResult(res), nil
+}
+
+func toNodeCordonResult(res controller.CordonResult) kukeonv1.NodeCordonResult {
+	out := kukeonv1.NodeCordonResult{
+		Cordoned: res.Cordoned,
+		Changed:  res.Changed,
+		Reason:   res.Reason,
+	}
+	if !res.Since.IsZero() {
+		since := res.Since
+		out.Since = &since
+	}
+	return out
+}
+
+// ---- Scope drain ----
+
+func (c *Client) DrainScope(_ context.Context, realm, space, stack string) (kukeonv1.DrainScopeResult, error) {
+	res, err := c.ctrl.DrainScope(controller.DrainRef{Realm: realm, Space: space, Stack: stack})
+	return toDrainScopeResult(res), err
+}
+
+func (c *Client) UndrainScope(_ context.Context, realm, space, stack string) (kukeonv1.DrainScopeResult, error) {
+	res, err := c.ctrl.UndrainScope(controller.DrainRef{Realm: realm, Space: space, Stack: stack})
+	return toDrainScopeResult(res), err
+}
+
+func toDrainScopeResult(res controller.DrainResult) kukeonv1.DrainScopeResult {
+	out := kukeonv1.DrainScopeResult{
+		Realm:   res.Realm,
+		Space:   res.Space,
+		Stack:   res.Stack,
+		Changed: res.Changed,
+		Cells:   res.Cells,
+		Skipped: res.Skipped,
+		Errors:  res.Errors,
+	}
+	if !res.Since.IsZero() {
+		since := res.Since
+		out.Since = &since
+	}
+	return out
+}
+
+// ---- Events ----
+
+func (c *Client) ListEvents(_ context.Context, filter kukeonv1.EventFilter) ([]kukeonv1.Event, error) {
+	res, err := c.ctrl.ListEvents(events.Filter{
+		Kind:  filter.Kind,
+		Realm: filter.Realm,
+		Space: filter.Space,
+		Stack: filter.Stack,
+		Cell:  filter.Cell,
+		Since: filter.Since,
+		Limit: filter.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]kukeonv1.Event, 0, len(res))
+	for _, e := range res {
+		out = append(out, kukeonv1.Event{
+			Time:    e.Time,
+			Action:  e.Action,
+			Kind:    e.Kind,
+			Realm:   e.Realm,
+			Space:   e.Space,
+			Stack:   e.Stack,
+			Cell:    e.Cell,
+			Outcome: string(e.Outcome),
+			Message: e.Message,
+		})
+	}
+	return out, nil
+}
+
+// ---- Quotas ----
+
+func (c *Client) ListQuotas(_ context.Context, realm, space string) ([]kukeonv1.QuotaStatus, error) {
+	res, err := c.ctrl.ListQuotas(realm, space)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]kukeonv1.QuotaStatus, 0, len(res))
+	for _, q := range res {
+		out = append(out, kukeonv1.QuotaStatus{
+			Realm: q.Realm,
+			Space: q.Space,
+			Limit: v1beta1.ResourceQuota{
+				MaxCells:       q.Limit.MaxCells,
+				MaxMemoryBytes: q.Limit.MaxMemoryBytes,
+				MaxCPUShares:   q.Limit.MaxCPUShares,
+			},
+			Used: kukeonv1.QuotaUsage{
+				Cells:       q.Used.Cells,
+				MemoryBytes: q.Used.MemoryBytes,
+				CPUShares:   q.Used.CPUShares,
+			},
+		})
+	}
+	return out, nil
+}
+
 // ---- Refresh ----
 
 func (c *Client) RefreshAll(_ context.Context) (kukeonv1.RefreshAllResult, error) {
@@ -955,24 +1362,29 @@ func (c *Client) RefreshAll(_ context.Context) (kukeonv1.RefreshAllResult, error
 // ---- Apply ----
 
 func (c *Client) ApplyDocuments(ctx context.Context, rawYAML []byte) (kukeonv1.ApplyDocumentsResult, error) {
-	return c.applyDocuments(ctx, rawYAML, "")
+	return c.ApplyDocumentsWithOptions(ctx, rawYAML, kukeonv1.ApplyOptions{})
 }
 
 // ApplyDocumentsForTeam runs the in-process equivalent of the wire RPC
 // per-team prune-apply path (issue #1027). Empty team rejected at the
 // boundary so a caller cannot accidentally degrade into the historical
 // no-prune apply by passing "".
+//
+// Deprecated: use ApplyDocumentsWithOptions with ApplyOptions{Team: team}.
 func (c *Client) ApplyDocumentsForTeam(
 	ctx context.Context, rawYAML []byte, team string,
 ) (kukeonv1.ApplyDocumentsResult, error) {
 	if team == "" {
 		return kukeonv1.ApplyDocumentsResult{}, errors.New("apply for team: team is required")
 	}
-	return c.applyDocuments(ctx, rawYAML, team)
+	return c.ApplyDocumentsWithOptions(ctx, rawYAML, kukeonv1.ApplyOptions{Team: team})
 }
 
-func (c *Client) applyDocuments(
-	_ context.Context, rawYAML []byte, team string,
+// ApplyDocumentsWithOptions is ApplyDocuments with every per-invocation
+// option, including the per-team prune-apply path (issue #1027) when
+// opts.Team is set.
+func (c *Client) ApplyDocumentsWithOptions(
+	_ context.Context, rawYAML []byte, opts kukeonv1.ApplyOptions,
 ) (kukeonv1.ApplyDocumentsResult, error) {
 	docs, validationErrors, err := parseAndValidate(rawYAML)
 	if err != nil {
@@ -985,15 +1397,27 @@ func (c *Client) applyDocuments(
 		return kukeonv1.ApplyDocumentsResult{}, errors.New("no valid documents found in input")
 	}
 
-	res, err := c.ctrl.ApplyDocuments(docs, team)
+	// The parent wait and the container prune are transport-only
+	// (yaml:"-"), so they are stamped on the parsed cell documents rather
+	// than read from the manifest.
+	for i := range docs {
+		if docs[i].CellDoc != nil {
+			docs[i].CellDoc.Spec.WaitForParentSeconds = opts.WaitForParentSeconds
+			docs[i].CellDoc.Spec.PruneContainers = opts.PruneContainers
+		}
+	}
+
+	res, err := c.ctrl.ApplyDocuments(docs, opts.Team, opts.FieldManager)
 	if err != nil {
 		return kukeonv1.ApplyDocumentsResult{}, err
 	}
 
-	out := kukeonv1.ApplyDocumentsResult{
-		Resources: make([]kukeonv1.ApplyResourceResult, 0, len(res.Resources)),
-	}
-	for _, r := range res.Resources {
+	return kukeonv1.ApplyDocumentsResult{Resources: applyResourcesToExternal(res.Resources)}, nil
+}
+
+func applyResourcesToExternal(in []controller.ResourceResult) []kukeonv1.ApplyResourceResult {
+	out := make([]kukeonv1.ApplyResourceResult, 0, len(in))
+	for _, r := range in {
 		item := kukeonv1.ApplyResourceResult{
 			Index:   r.Index,
 			Kind:    r.Kind,
@@ -1005,9 +1429,40 @@ func (c *Client) applyDocuments(
 		if r.Error != nil {
 			item.Error = r.Error.Error()
 		}
-		out.Resources = append(out.Resources, item)
+		out = append(out, item)
 	}
-	return out, nil
+	return out
+}
+
+// ImportDocuments runs the in-process equivalent of the wire RPC. The
+// controller's partial result is converted and returned even when the
+// import fails, so the caller can render what was applied and rolled back.
+func (c *Client) ImportDocuments(
+	_ context.Context, rawYAML []byte, continueOnError bool,
+) (kukeonv1.ImportDocumentsResult, error) {
+	docs, validationErrors, err := parseAndValidate(rawYAML)
+	if err != nil {
+		return kukeonv1.ImportDocumentsResult{}, err
+	}
+	if len(validationErrors) > 0 {
+		return kukeonv1.ImportDocumentsResult{}, formatValidationErrors(validationErrors)
+	}
+	if len(docs) == 0 {
+		return kukeonv1.ImportDocumentsResult{}, errors.New("no valid documents found in input")
+	}
+
+	res, err := c.ctrl.ImportDocuments(docs, controller.ImportOptions{ContinueOnError: continueOnError})
+	return kukeonv1.ImportDocumentsResult{
+		Resources:  applyResourcesToExternal(res.Resources),
+		RolledBack: deleteResourcesToExternal(res.RolledBack),
+	}, err
+}
+
+// RestoreBackup runs the in-process equivalent of the wire RPC. The files
+// restored before a failure are reported alongside the error.
+func (c *Client) RestoreBackup(_ context.Context, archive []byte) (kukeonv1.RestoreBackupResult, error) {
+	res, err := c.ctrl.RestoreBackup(archive)
+	return kukeonv1.RestoreBackupResult{Restored: res.Restored, Skipped: res.Skipped}, err
 }
 
 func (c *Client) DeleteDocuments(
@@ -1031,10 +1486,12 @@ func (c *Client) DeleteDocuments(
 		return kukeonv1.DeleteDocumentsResult{}, err
 	}
 
-	out := kukeonv1.DeleteDocumentsResult{
-		Resources: make([]kukeonv1.DeleteResourceResult, 0, len(res.Resources)),
-	}
-	for _, r := range res.Resources {
+	return kukeonv1.DeleteDocumentsResult{Resources: deleteResourcesToExternal(res.Resources)}, nil
+}
+
+func deleteResourcesToExternal(in []controller.ResourceDeleteResult) []kukeonv1.DeleteResourceResult {
+	out := make([]kukeonv1.DeleteResourceResult, 0, len(in))
+	for _, r := range in {
 		item := kukeonv1.DeleteResourceResult{
 			Index:    r.Index,
 			Kind:     r.Kind,
@@ -1046,9 +1503,9 @@ func (c *Client) DeleteDocuments(
 		if r.Error != nil {
 			item.Error = r.Error.Error()
 		}
-		out.Resources = append(out.Resources, item)
+		out = append(out, item)
 	}
-	return out, nil
+	return out
 }
 
 // parseAndValidate mirrors cmd/kuke/shared.ParseAndValidateDocuments, but
@@ -1257,6 +1714,29 @@ func guardAttachableTaskLiveness(container intmodel.Container) error {
 	)
 }
 
+// ---- Copy ----
+
+// ResolveContainerRootFS resolves the host path of a running container's
+// filesystem view (/proc/<pid>/root of its task) for `kuke cp`. Not on the
+// kukeonv1.Client interface: the returned path is only meaningful in the
+// host PID namespace, so `kuke cp` always runs in-process — the same
+// daemon-independent category as `kuke image *`. Refuses with
+// errdefs.ErrTaskNotRunning when the container has no running task.
+func (c *Client) ResolveContainerRootFS(
+	_ context.Context,
+	doc v1beta1.ContainerDoc,
+) (kukeonv1.ContainerRootFSResult, error) {
+	internal, _, err := apischeme.NormalizeContainer(doc)
+	if err != nil {
+		return kukeonv1.ContainerRootFSResult{}, fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+	}
+	res, err := c.ctrl.ResolveContainerRootFS(internal)
+	if err != nil {
+		return kukeonv1.ContainerRootFSResult{}, err
+	}
+	return kukeonv1.ContainerRootFSResult{PID: res.PID, HostRootPath: res.RootFS}, nil
+}
+
 // ---- Log ----
 
 // LogContainer resolves the host-side path of the per-container output
@@ -1297,6 +1777,11 @@ func (c *Client) LogContainer(_ context.Context, doc v1beta1.ContainerDoc) (kuke
 			c.ctrl.RunPath(),
 			spec.RealmName, spec.SpaceName, spec.StackName, spec.CellName, spec.ID,
 		),
+		HostPreviousLogPath: fs.ContainerPreviousLogPath(
+			c.ctrl.RunPath(),
+			spec.RealmName, spec.SpaceName, spec.StackName, spec.CellName, spec.ID,
+		),
+		SeparateStreams: spec.SeparateStreams,
 	}, nil
 }
 
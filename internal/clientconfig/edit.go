@@ -0,0 +1,234 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clientconfig
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+	"gopkg.in/yaml.v3"
+)
+
+// contextKeyPrefix starts the keys that address one context's scope:
+// contexts.<name>.realm, contexts.<name>.space, contexts.<name>.stack, and
+// contexts.<name> itself for Unset.
+const contextKeyPrefix = "contexts."
+
+// contextLevels are the scope levels a context carries, outermost first.
+//
+//nolint:gochecknoglobals // read-only lookup table
+var contextLevels = []string{"realm", "space", "stack"}
+
+// specFields maps each scalar key accepted by Set and Unset to its field on
+// the spec. Keys are the YAML field names.
+func specFields(spec *v1beta1.ClientConfigurationSpec) map[string]*string {
+	return map[string]*string{
+		"host":                      &spec.Host,
+		"runPath":                   &spec.RunPath,
+		"containerdSocket":          &spec.ContainerdSocket,
+		"logLevel":                  &spec.LogLevel,
+		"containerdNamespaceSuffix": &spec.ContainerdNamespaceSuffix,
+		"cgroupRoot":                &spec.CgroupRoot,
+		"podSubnetCIDR":             &spec.PodSubnetCIDR,
+		"currentContext":            &spec.CurrentContext,
+	}
+}
+
+// Keys lists the keys Set and Unset accept, for help text and completion.
+func Keys() []string {
+	keys := make([]string, 0, len(specFields(&v1beta1.ClientConfigurationSpec{}))+3)
+	for k := range specFields(&v1beta1.ClientConfigurationSpec{}) {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, level := range contextLevels {
+		keys = append(keys, contextKeyPrefix+"<name>."+level)
+	}
+	return keys
+}
+
+// Set assigns value to key on doc. contexts.<name>.<level> creates the
+// context when it does not exist yet. currentContext must name a context
+// already defined, so a typo cannot silently select nothing.
+func Set(doc *v1beta1.ClientConfigurationDoc, key, value string) error {
+	if rest, isContext := strings.CutPrefix(key, contextKeyPrefix); isContext {
+		name, level, _ := strings.Cut(rest, ".")
+		if name == "" || !slices.Contains(contextLevels, level) {
+			return fmt.Errorf("%w: %q (want contexts.<name>.realm, .space, or .stack)",
+				errdefs.ErrClientConfigurationKey, key)
+		}
+		field, err := contextField(contextFor(doc, name, true), level, key)
+		if err != nil {
+			return err
+		}
+		*field = value
+		return nil
+	}
+	field, ok := specFields(&doc.Spec)[key]
+	if !ok {
+		return fmt.Errorf("%w: %q (valid keys: %s)", errdefs.ErrClientConfigurationKey, key, strings.Join(Keys(), ", "))
+	}
+	if key == "currentContext" && value != "" && contextFor(doc, value, false) == nil {
+		return fmt.Errorf("%w: %q", errdefs.ErrClientContextNotFound, value)
+	}
+	*field = value
+	return nil
+}
+
+// Unset clears key on doc so the built-in default applies again.
+// contexts.<name> removes the whole context, and clears currentContext when
+// it pointed there.
+func Unset(doc *v1beta1.ClientConfigurationDoc, key string) error {
+	if rest, isContext := strings.CutPrefix(key, contextKeyPrefix); isContext {
+		name, level, hasLevel := strings.Cut(rest, ".")
+		ctx := contextFor(doc, name, false)
+		if ctx == nil {
+			return fmt.Errorf("%w: %q", errdefs.ErrClientContextNotFound, name)
+		}
+		if hasLevel {
+			field, err := contextField(ctx, level, key)
+			if err != nil {
+				return err
+			}
+			*field = ""
+			return nil
+		}
+		doc.Spec.Contexts = slices.DeleteFunc(doc.Spec.Contexts, func(c v1beta1.ClientContext) bool {
+			return c.Name == name
+		})
+		if doc.Spec.CurrentContext == name {
+			doc.Spec.CurrentContext = ""
+		}
+		return nil
+	}
+	field, ok := specFields(&doc.Spec)[key]
+	if !ok {
+		return fmt.Errorf("%w: %q (valid keys: %s)", errdefs.ErrClientConfigurationKey, key, strings.Join(Keys(), ", "))
+	}
+	*field = ""
+	return nil
+}
+
+// Context resolves the context kuke runs under: name when non-empty,
+// otherwise spec.currentContext. Returns false when neither selects one, and
+// errdefs.ErrClientContextNotFound when the selected name is not defined.
+func Context(doc *v1beta1.ClientConfigurationDoc, name string) (v1beta1.ClientContext, bool, error) {
+	if name == "" {
+		name = doc.Spec.CurrentContext
+	}
+	if name == "" {
+		return v1beta1.ClientContext{}, false, nil
+	}
+	ctx := contextFor(doc, name, false)
+	if ctx == nil {
+		return v1beta1.ClientContext{}, false, fmt.Errorf("%w: %q", errdefs.ErrClientContextNotFound, name)
+	}
+	return *ctx, true, nil
+}
+
+func contextFor(doc *v1beta1.ClientConfigurationDoc, name string, create bool) *v1beta1.ClientContext {
+	for i := range doc.Spec.Contexts {
+		if doc.Spec.Contexts[i].Name == name {
+			return &doc.Spec.Contexts[i]
+		}
+	}
+	if !create {
+		return nil
+	}
+	doc.Spec.Contexts = append(doc.Spec.Contexts, v1beta1.ClientContext{Name: name})
+	return &doc.Spec.Contexts[len(doc.Spec.Contexts)-1]
+}
+
+func contextField(ctx *v1beta1.ClientContext, level, key string) (*string, error) {
+	switch level {
+	case "realm":
+		return &ctx.Realm, nil
+	case "space":
+		return &ctx.Space, nil
+	case "stack":
+		return &ctx.Stack, nil
+	default:
+		return nil, fmt.Errorf("%w: %q (want contexts.<name>.realm, .space, or .stack)",
+			errdefs.ErrClientConfigurationKey, key)
+	}
+}
+
+// Marshal renders doc as YAML with the two-space indent the default
+// document uses, filling apiVersion, kind, and metadata.name when they are
+// empty so a document built from an absent file round-trips through Load.
+func Marshal(doc *v1beta1.ClientConfigurationDoc) ([]byte, error) {
+	out := *doc
+	if out.APIVersion == "" {
+		out.APIVersion = v1beta1.APIVersionV1Beta1
+	}
+	if out.Kind == "" {
+		out.Kind = v1beta1.KindClientConfiguration
+	}
+	if out.Metadata.Name == "" {
+		out.Metadata.Name = "default"
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&out); err != nil {
+		return nil, fmt.Errorf("marshal client configuration: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("marshal client configuration: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Save writes doc to path as Marshal renders it. The write goes through a
+// temp file and rename, so a crash never leaves a truncated configuration
+// behind. Comments in an existing file are not preserved.
+func Save(path string, doc *v1beta1.ClientConfigurationDoc) error {
+	raw, err := Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	if err = os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("create parent directory for %q: %w", path, err)
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("create temp file for %q: %w", path, err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err = tmp.Write(raw); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write %q: %w", path, err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("write %q: %w", path, err)
+	}
+	if err = os.Chmod(tmp.Name(), 0o600); err != nil {
+		return fmt.Errorf("write %q: %w", path, err)
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("write %q: %w", path, err)
+	}
+	return nil
+}
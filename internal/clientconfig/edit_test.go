@@ -0,0 +1,125 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clientconfig
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "kuke.yaml")
+	doc := &v1beta1.ClientConfigurationDoc{Spec: v1beta1.ClientConfigurationSpec{
+		Host:           "unix:///tmp/kuke.sock",
+		RunPath:        "/opt/kukeon-dev",
+		CurrentContext: "web",
+		Contexts: []v1beta1.ClientContext{
+			{Name: "web", Realm: "team-a", Space: "default", Stack: "frontend"},
+		},
+	}}
+	if err := Save(path, doc); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got.Kind != v1beta1.KindClientConfiguration || got.APIVersion != v1beta1.APIVersionV1Beta1 ||
+		got.Metadata.Name != "default" {
+		t.Errorf("header = %s/%s %q, want it filled in on save", got.APIVersion, got.Kind, got.Metadata.Name)
+	}
+	if !reflect.DeepEqual(got.Spec, doc.Spec) {
+		t.Errorf("spec round-trip:\n got  %+v\n want %+v", got.Spec, doc.Spec)
+	}
+}
+
+func TestSetAndUnset(t *testing.T) {
+	doc := &v1beta1.ClientConfigurationDoc{}
+
+	if err := Set(doc, "host", "ssh://ops@build"); err != nil || doc.Spec.Host != "ssh://ops@build" {
+		t.Fatalf("Set(host) = %v, host %q", err, doc.Spec.Host)
+	}
+	if err := Set(doc, "currentContext", "web"); !errors.Is(err, errdefs.ErrClientContextNotFound) {
+		t.Errorf("Set(currentContext) before the context exists: got %v, want ErrClientContextNotFound", err)
+	}
+	if err := Set(doc, "contexts.web.realm", "team-a"); err != nil {
+		t.Fatalf("Set(contexts.web.realm) error = %v", err)
+	}
+	if err := Set(doc, "contexts.web.stack", "frontend"); err != nil {
+		t.Fatalf("Set(contexts.web.stack) error = %v", err)
+	}
+	if want := []v1beta1.ClientContext{{Name: "web", Realm: "team-a", Stack: "frontend"}}; !reflect.DeepEqual(
+		doc.Spec.Contexts, want,
+	) {
+		t.Errorf("contexts = %+v, want %+v", doc.Spec.Contexts, want)
+	}
+	if err := Set(doc, "currentContext", "web"); err != nil {
+		t.Fatalf("Set(currentContext) error = %v", err)
+	}
+
+	for _, key := range []string{"bogus", "contexts.web.cell", "contexts..realm", "contexts.web"} {
+		if err := Set(doc, key, "x"); !errors.Is(err, errdefs.ErrClientConfigurationKey) {
+			t.Errorf("Set(%q) = %v, want ErrClientConfigurationKey", key, err)
+		}
+	}
+	if len(doc.Spec.Contexts) != 1 {
+		t.Errorf("rejected keys created contexts: %+v", doc.Spec.Contexts)
+	}
+
+	if err := Unset(doc, "contexts.web.stack"); err != nil || doc.Spec.Contexts[0].Stack != "" {
+		t.Errorf("Unset(contexts.web.stack) = %v, stack %q", err, doc.Spec.Contexts[0].Stack)
+	}
+	if err := Unset(doc, "contexts.web"); err != nil {
+		t.Fatalf("Unset(contexts.web) error = %v", err)
+	}
+	if len(doc.Spec.Contexts) != 0 || doc.Spec.CurrentContext != "" {
+		t.Errorf("after removing the current context: contexts %+v, current %q",
+			doc.Spec.Contexts, doc.Spec.CurrentContext)
+	}
+	if err := Unset(doc, "contexts.web"); !errors.Is(err, errdefs.ErrClientContextNotFound) {
+		t.Errorf("Unset(missing context) = %v, want ErrClientContextNotFound", err)
+	}
+	if err := Unset(doc, "host"); err != nil || doc.Spec.Host != "" {
+		t.Errorf("Unset(host) = %v, host %q", err, doc.Spec.Host)
+	}
+}
+
+func TestContext(t *testing.T) {
+	doc := &v1beta1.ClientConfigurationDoc{Spec: v1beta1.ClientConfigurationSpec{
+		Contexts: []v1beta1.ClientContext{{Name: "web", Realm: "team-a"}, {Name: "ops", Realm: "ops"}},
+	}}
+
+	if _, ok, err := Context(doc, ""); ok || err != nil {
+		t.Errorf("no selection: ok=%v err=%v, want none", ok, err)
+	}
+	doc.Spec.CurrentContext = "web"
+	if ctx, ok, err := Context(doc, ""); !ok || err != nil || ctx.Realm != "team-a" {
+		t.Errorf("currentContext: %+v ok=%v err=%v, want web", ctx, ok, err)
+	}
+	if ctx, ok, err := Context(doc, "ops"); !ok || err != nil || ctx.Realm != "ops" {
+		t.Errorf("explicit name: %+v ok=%v err=%v, want ops", ctx, ok, err)
+	}
+	if _, _, err := Context(doc, "missing"); !errors.Is(err, errdefs.ErrClientContextNotFound) {
+		t.Errorf("unknown name: err=%v, want ErrClientContextNotFound", err)
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cni
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+// bandwidthPluginType is the libcni "type" of the bandwidth plugin. It is
+// always the last plugin of a kukeon conflist so it shapes the host-side veth
+// the bridge plugin created.
+const bandwidthPluginType = "bandwidth"
+
+// bandwidthCapability is the capability key the bandwidth plugin declares;
+// libcni injects the runtime config under it.
+const bandwidthCapability = "bandwidth"
+
+// bandwidthUnits maps tc-style suffixes to their multiplier in bits. The
+// "bit" family is decimal (kbit = 1000 bits); the "bps" family counts bytes.
+var bandwidthUnits = map[string]uint64{
+	"":     1,
+	"bit":  1,
+	"kbit": 1_000,
+	"mbit": 1_000_000,
+	"gbit": 1_000_000_000,
+	"tbit": 1_000_000_000_000,
+	"bps":  8,
+	"kbps": 8_000,
+	"mbps": 8_000_000,
+	"gbps": 8_000_000_000,
+	"tbps": 8_000_000_000_000,
+}
+
+// BandwidthLimits is a container's traffic shaping, in the bandwidth plugin's
+// units: rates in bits per second, bursts in bits. A zero rate leaves that
+// direction unshaped.
+type BandwidthLimits struct {
+	IngressRate  uint64
+	IngressBurst uint64
+	EgressRate   uint64
+	EgressBurst  uint64
+}
+
+// ParseBandwidthQuantity parses a rate or burst such as "10Mbit", "512kbit",
+// "1Gbps" or a bare bit count. Suffixes are case-insensitive. The result is
+// in bits (per second for a rate) and must be positive.
+func ParseBandwidthQuantity(s string) (uint64, error) {
+	in := strings.TrimSpace(s)
+	split := len(in)
+	for split > 0 && (in[split-1] < '0' || in[split-1] > '9') {
+		split--
+	}
+	number, unit := in[:split], strings.ToLower(in[split:])
+	multiplier, ok := bandwidthUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q has unknown unit %q", errdefs.ErrInvalidBandwidth, s, unit)
+	}
+	value, err := strconv.ParseUint(number, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is not a whole number with an optional unit", errdefs.ErrInvalidBandwidth, s)
+	}
+	if value == 0 {
+		return 0, fmt.Errorf("%w: %q must be positive", errdefs.ErrInvalidBandwidth, s)
+	}
+	if value > math.MaxUint64/multiplier {
+		return 0, fmt.Errorf("%w: %q is out of range", errdefs.ErrInvalidBandwidth, s)
+	}
+	return value * multiplier, nil
+}
+
+// ParseBandwidthLimits parses the per-direction rate and burst strings of a
+// cell's bandwidth spec. A direction is either unset (both empty) or fully
+// set: the bandwidth plugin rejects a rate without a burst. At least one
+// direction must be set.
+func ParseBandwidthLimits(ingressRate, ingressBurst, egressRate, egressBurst string) (*BandwidthLimits, error) {
+	var limits BandwidthLimits
+	var err error
+	if limits.IngressRate, limits.IngressBurst, err = parseBandwidthDirection(
+		"ingress", ingressRate, ingressBurst,
+	); err != nil {
+		return nil, err
+	}
+	if limits.EgressRate, limits.EgressBurst, err = parseBandwidthDirection(
+		"egress", egressRate, egressBurst,
+	); err != nil {
+		return nil, err
+	}
+	if limits.IngressRate == 0 && limits.EgressRate == 0 {
+		return nil, fmt.Errorf("%w: set an ingress or egress rate", errdefs.ErrInvalidBandwidth)
+	}
+	return &limits, nil
+}
+
+func parseBandwidthDirection(direction, rate, burst string) (uint64, uint64, error) {
+	rate, burst = strings.TrimSpace(rate), strings.TrimSpace(burst)
+	if rate == "" && burst == "" {
+		return 0, 0, nil
+	}
+	if rate == "" || burst == "" {
+		return 0, 0, fmt.Errorf("%w: %s rate and burst must be set together", errdefs.ErrInvalidBandwidth, direction)
+	}
+	r, err := ParseBandwidthQuantity(rate)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s rate: %w", direction, err)
+	}
+	b, err := ParseBandwidthQuantity(burst)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s burst: %w", direction, err)
+	}
+	return r, b, nil
+}
+
+// CapabilityArgs returns the libcni capability arguments that hand l to the
+// bandwidth plugin's runtimeConfig. A nil receiver yields nil so callers can
+// pass it straight into a RuntimeConf.
+func (l *BandwidthLimits) CapabilityArgs() map[string]interface{} {
+	if l == nil {
+		return nil
+	}
+	entry := map[string]interface{}{}
+	if l.IngressRate > 0 {
+		entry["ingressRate"] = l.IngressRate
+		entry["ingressBurst"] = l.IngressBurst
+	}
+	if l.EgressRate > 0 {
+		entry["egressRate"] = l.EgressRate
+		entry["egressBurst"] = l.EgressBurst
+	}
+	return map[string]interface{}{bandwidthCapability: entry}
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cni_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	cni "github.com/eminwux/kukeon/internal/cni"
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+func TestParseBandwidthQuantity(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    uint64
+		wantErr bool
+	}{
+		{in: "10Mbit", want: 10_000_000},
+		{in: "10mbit", want: 10_000_000},
+		{in: "512kbit", want: 512_000},
+		{in: "1Gbit", want: 1_000_000_000},
+		{in: "1mbps", want: 8_000_000},
+		{in: "4096", want: 4096},
+		{in: " 2bit ", want: 2},
+		{in: "0Mbit", wantErr: true},
+		{in: "-1Mbit", wantErr: true},
+		{in: "1.5Mbit", wantErr: true},
+		{in: "10Mb", wantErr: true},
+		{in: "Mbit", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "18446744073709551615kbit", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := cni.ParseBandwidthQuantity(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, errdefs.ErrInvalidBandwidth) {
+					t.Fatalf("ParseBandwidthQuantity(%q) error = %v, want ErrInvalidBandwidth", tt.in, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseBandwidthQuantity(%q) error = %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseBandwidthQuantity(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBandwidthLimits_CapabilityArgs(t *testing.T) {
+	tests := []struct {
+		name                                               string
+		ingressRate, ingressBurst, egressRate, egressBurst string
+		want                                               map[string]interface{}
+		wantErr                                            bool
+	}{
+		{
+			name:         "both directions",
+			ingressRate:  "10Mbit",
+			ingressBurst: "1Mbit",
+			egressRate:   "5Mbit",
+			egressBurst:  "512kbit",
+			want: map[string]interface{}{"bandwidth": map[string]interface{}{
+				"ingressRate":  uint64(10_000_000),
+				"ingressBurst": uint64(1_000_000),
+				"egressRate":   uint64(5_000_000),
+				"egressBurst":  uint64(512_000),
+			}},
+		},
+		{
+			name:        "egress only",
+			egressRate:  "1Gbit",
+			egressBurst: "10Mbit",
+			want: map[string]interface{}{"bandwidth": map[string]interface{}{
+				"egressRate":  uint64(1_000_000_000),
+				"egressBurst": uint64(10_000_000),
+			}},
+		},
+		{name: "nothing set", wantErr: true},
+		{name: "burst without rate", ingressBurst: "1Mbit", wantErr: true},
+		{name: "zero rate", egressRate: "0", egressBurst: "1Mbit", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits, err := cni.ParseBandwidthLimits(tt.ingressRate, tt.ingressBurst, tt.egressRate, tt.egressBurst)
+			if tt.wantErr {
+				if !errors.Is(err, errdefs.ErrInvalidBandwidth) {
+					t.Fatalf("ParseBandwidthLimits() error = %v, want ErrInvalidBandwidth", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseBandwidthLimits() error = %v", err)
+			}
+			if got := limits.CapabilityArgs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CapabilityArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBandwidthLimits_NilCapabilityArgs(t *testing.T) {
+	var limits *cni.BandwidthLimits
+	if got := limits.CapabilityArgs(); got != nil {
+		t.Errorf("nil CapabilityArgs() = %v, want nil", got)
+	}
+}
@@ -27,9 +27,11 @@ import (
 	"github.com/eminwux/kukeon/internal/errdefs"
 )
 
-// BridgeRunner deletes a kernel bridge link by name. Implementations must be
-// idempotent: deleting an already-absent bridge is success, not an error.
+// BridgeRunner deletes a kernel bridge link, and the veth ports still
+// enslaved to it, by name. Implementations must be idempotent: deleting an
+// already-absent bridge or port is success, not an error.
 type BridgeRunner interface {
+	DeleteVethPorts(ctx context.Context, bridge string) error
 	DeleteBridge(ctx context.Context, name string) error
 }
 
@@ -57,9 +59,56 @@ func (IPBridgeRunner) DeleteBridge(ctx context.Context, name string) error {
 	return fmt.Errorf("ip link delete %s: %w: %s", name, err, msg)
 }
 
+// DeleteVethPorts deletes every veth link enslaved to bridge, found with
+// `ip -o link show master <bridge> type veth`. Deleting one end of a veth
+// pair removes its peer too. A missing bridge has no ports and is success.
+func (IPBridgeRunner) DeleteVethPorts(ctx context.Context, bridge string) error {
+	if bridge == "" {
+		return nil
+	}
+	out, err := exec.CommandContext(ctx, "ip", "-o", "link", "show", "master", bridge, "type", "veth").CombinedOutput()
+	if err != nil {
+		msg := strings.TrimSpace(string(out))
+		if strings.Contains(strings.ToLower(msg), "does not exist") {
+			return nil
+		}
+		return fmt.Errorf("ip link show master %s: %w: %s", bridge, err, msg)
+	}
+	var errs []error
+	for _, port := range parseLinkNames(string(out)) {
+		delOut, delErr := exec.CommandContext(ctx, "ip", "link", "delete", port).CombinedOutput()
+		if delErr == nil {
+			continue
+		}
+		msg := strings.TrimSpace(string(delOut))
+		if strings.Contains(strings.ToLower(msg), "cannot find device") {
+			continue
+		}
+		errs = append(errs, fmt.Errorf("ip link delete %s: %w: %s", port, delErr, msg))
+	}
+	return errors.Join(errs...)
+}
+
+// parseLinkNames extracts link names from `ip -o link show` output, where
+// each line reads "<index>: <name>[@<peer>]: <flags> ...".
+func parseLinkNames(out string) []string {
+	var names []string
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.SplitN(line, ": ", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		name, _, _ := strings.Cut(fields[1], "@")
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // TeardownNetwork removes the CNI network for networkName end-to-end: it reads
-// the bridge name from the conflist, removes the conflist file, and deletes
-// the kernel bridge link. The bridge name is read **before** the conflist is
+// the bridge name from the conflist, removes the conflist file, deletes any
+// veth ports left on the bridge, and deletes the kernel bridge link. The bridge name is read **before** the conflist is
 // removed so that a transient failure between steps still leaves enough state
 // to retry from. If configPath is empty the manager's default location is used.
 //
@@ -94,5 +143,11 @@ func (m *Manager) TeardownNetwork(
 	if bridge == "" {
 		return nil
 	}
+	// Every container on the network is gone by the time it is torn down, so
+	// a veth still enslaved to the bridge is dangling. Deleting the bridge
+	// alone would release such ports without removing them.
+	if portErr := runner.DeleteVethPorts(ctx, bridge); portErr != nil {
+		return fmt.Errorf("delete veth ports: %w", portErr)
+	}
 	return runner.DeleteBridge(ctx, bridge)
 }
@@ -26,18 +26,24 @@ import (
 	cni "github.com/eminwux/kukeon/internal/cni"
 )
 
-// fakeBridgeRunner records DeleteBridge invocations and lets tests assert on
-// the call sequence. notFoundOn names a bridge for which the runner returns
+// fakeBridgeRunner records DeleteVethPorts and DeleteBridge invocations and
+// lets tests assert on the call sequence. notFoundOn names a bridge for which the runner returns
 // an "already-absent" success — it never returns an error so the helper sees
 // the same idempotent contract as the real IPBridgeRunner does for missing
 // links.
 type fakeBridgeRunner struct {
 	calls       []string
+	portCalls   []string
 	notFoundOn  string
 	failOn      string
 	failWithErr error
 }
 
+func (f *fakeBridgeRunner) DeleteVethPorts(_ context.Context, bridge string) error {
+	f.portCalls = append(f.portCalls, bridge)
+	return nil
+}
+
 func (f *fakeBridgeRunner) DeleteBridge(_ context.Context, name string) error {
 	f.calls = append(f.calls, name)
 	if f.failOn != "" && name == f.failOn {
@@ -92,6 +98,9 @@ func TestManager_TeardownNetwork_BridgePresent(t *testing.T) {
 	if got, want := runner.calls, []string{"kuke-s-deadbeef"}; !equalStrings(got, want) {
 		t.Errorf("DeleteBridge calls = %v, want %v", got, want)
 	}
+	if got, want := runner.portCalls, []string{"kuke-s-deadbeef"}; !equalStrings(got, want) {
+		t.Errorf("DeleteVethPorts calls = %v, want %v", got, want)
+	}
 }
 
 func TestManager_TeardownNetwork_BridgeAlreadyAbsent(t *testing.T) {
@@ -79,30 +79,50 @@ func hashBridgeName(sum uint32) string {
 
 // BuildDefaultConflist generates a default conflist JSON using provided parameters.
 func BuildDefaultConflist(name, bridge, subnet string) ([]byte, error) {
+	return BuildConflist(NetworkConfig{Name: name, BridgeName: bridge, SubnetCIDR: subnet})
+}
+
+// BuildConflist generates the bridge + loopback + bandwidth conflist JSON for
+// cfg. Bandwidth is chained last so it shapes the veth the bridge plugin set
+// up; it is a no-op for containers started without limits. The IPv4 range is always present; a non-empty cfg.IPv6SubnetCIDR adds a second
+// host-local range with its own default route and sets enableIPv6 on the
+// bridge, so every container gets one address from each family.
+func BuildConflist(cfg NetworkConfig) ([]byte, error) {
+	ranges := [][]map[string]string{
+		{
+			{"subnet": cfg.SubnetCIDR},
+		},
+	}
+	routes := []RouteModel{
+		{Dst: "0.0.0.0/0"},
+	}
+	if cfg.IPv6SubnetCIDR != "" {
+		ranges = append(ranges, []map[string]string{{"subnet": cfg.IPv6SubnetCIDR}})
+		routes = append(routes, RouteModel{Dst: "::/0"})
+	}
 	conf := ConflistModel{
 		CNIVersion: defaultCNIVersion,
-		Name:       name,
+		Name:       cfg.Name,
 		Plugins: []interface{}{
 			BridgePluginModel{
-				Type:      "bridge",
-				Bridge:    bridge,
-				IsGateway: true,
-				IPMasq:    true,
+				Type:       "bridge",
+				Bridge:     cfg.BridgeName,
+				IsGateway:  true,
+				IPMasq:     true,
+				EnableIPv6: cfg.IPv6SubnetCIDR != "",
 				IPAM: BridgeIPAMConfig{
-					Type: "host-local",
-					Ranges: [][]map[string]string{
-						{
-							{"subnet": subnet},
-						},
-					},
-					Routes: []RouteModel{
-						{Dst: "0.0.0.0/0"},
-					},
+					Type:   "host-local",
+					Ranges: ranges,
+					Routes: routes,
 				},
 			},
 			LoopbackPluginModel{
 				Type: "loopback",
 			},
+			BandwidthPluginModel{
+				Type:         bandwidthPluginType,
+				Capabilities: map[string]bool{bandwidthCapability: true},
+			},
 		},
 	}
 	return json.MarshalIndent(conf, "", "  ")
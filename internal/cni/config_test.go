@@ -18,6 +18,7 @@ package cni_test
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	cni "github.com/eminwux/kukeon/internal/cni"
@@ -337,3 +338,95 @@ func TestBuildDefaultConflist(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildConflist_DualStack(t *testing.T) {
+	data, err := cni.BuildConflist(cni.NetworkConfig{
+		Name:           "r1-s1",
+		BridgeName:     "k-deadbeef",
+		SubnetCIDR:     "10.88.1.0/24",
+		IPv6SubnetCIDR: "fd00:88:1::/64",
+	})
+	if err != nil {
+		t.Fatalf("BuildConflist() error = %v", err)
+	}
+
+	var conf struct {
+		Plugins []struct {
+			Type       string `json:"type"`
+			EnableIPv6 bool   `json:"enableIPv6"`
+			IPAM       struct {
+				Ranges [][]struct {
+					Subnet string `json:"subnet"`
+				} `json:"ranges"`
+				Routes []struct {
+					Dst string `json:"dst"`
+				} `json:"routes"`
+			} `json:"ipam"`
+		} `json:"plugins"`
+	}
+	if err = json.Unmarshal(data, &conf); err != nil {
+		t.Fatalf("failed to unmarshal JSON: %v", err)
+	}
+	if len(conf.Plugins) == 0 || conf.Plugins[0].Type != "bridge" {
+		t.Fatalf("first plugin = %+v, want bridge", conf.Plugins)
+	}
+	bridge := conf.Plugins[0]
+	if !bridge.EnableIPv6 {
+		t.Error("enableIPv6 = false, want true")
+	}
+
+	var subnets []string
+	for _, r := range bridge.IPAM.Ranges {
+		for _, entry := range r {
+			subnets = append(subnets, entry.Subnet)
+		}
+	}
+	if len(subnets) != 2 || subnets[0] != "10.88.1.0/24" || subnets[1] != "fd00:88:1::/64" {
+		t.Errorf("ranges = %v, want [10.88.1.0/24 fd00:88:1::/64] as separate ranges", subnets)
+	}
+
+	var dsts []string
+	for _, route := range bridge.IPAM.Routes {
+		dsts = append(dsts, route.Dst)
+	}
+	if len(dsts) != 2 || dsts[0] != "0.0.0.0/0" || dsts[1] != "::/0" {
+		t.Errorf("routes = %v, want [0.0.0.0/0 ::/0]", dsts)
+	}
+}
+
+func TestBuildConflist_IPv4OnlyOmitsIPv6(t *testing.T) {
+	data, err := cni.BuildConflist(cni.NetworkConfig{Name: "r1-s1", BridgeName: "k-deadbeef", SubnetCIDR: "10.88.1.0/24"})
+	if err != nil {
+		t.Fatalf("BuildConflist() error = %v", err)
+	}
+	legacy, err := cni.BuildDefaultConflist("r1-s1", "k-deadbeef", "10.88.1.0/24")
+	if err != nil {
+		t.Fatalf("BuildDefaultConflist() error = %v", err)
+	}
+	if string(data) != string(legacy) {
+		t.Errorf("BuildConflist() without IPv6 differs from BuildDefaultConflist():\n%s\n---\n%s", data, legacy)
+	}
+	if strings.Contains(string(data), "enableIPv6") {
+		t.Errorf("IPv4-only conflist carries enableIPv6:\n%s", data)
+	}
+}
+
+func TestBuildConflist_BandwidthPluginIsLast(t *testing.T) {
+	data, err := cni.BuildConflist(cni.NetworkConfig{Name: "r1-s1", BridgeName: "k-deadbeef", SubnetCIDR: "10.88.1.0/24"})
+	if err != nil {
+		t.Fatalf("BuildConflist() error = %v", err)
+	}
+	var conf struct {
+		Plugins []struct {
+			Type         string          `json:"type"`
+			Capabilities map[string]bool `json:"capabilities"`
+		} `json:"plugins"`
+	}
+	if err = json.Unmarshal(data, &conf); err != nil {
+		t.Fatalf("failed to unmarshal JSON: %v", err)
+	}
+	last := conf.Plugins[len(conf.Plugins)-1]
+	if last.Type != "bandwidth" || !last.Capabilities["bandwidth"] {
+		t.Errorf("last plugin = %+v, want bandwidth with the bandwidth capability", last)
+	}
+}
@@ -26,62 +26,87 @@ import (
 	"github.com/eminwux/kukeon/internal/errdefs"
 )
 
+// ContainerAddresses holds the addresses IPAM assigned a container, one per
+// family. Either is nil when the network has no range of that family.
+type ContainerAddresses struct {
+	IPv4 net.IP
+	IPv6 net.IP
+}
+
 // AddContainerToNetwork adds a container to the CNI network and returns the
-// container's IPv4 address as assigned by IPAM, or nil when no IPv4 address
-// was returned (e.g. IPv6-only networks). The IP is the one the runner
-// renders into the cell's /etc/hosts so tools that resolve the container's
-// own hostname work without DNS-lookup timeouts (issue #345).
-func (m *Manager) AddContainerToNetwork(ctx context.Context, containerID, netnsPath string) (net.IP, error) {
+// container's addresses as assigned by IPAM: the IPv4 one, plus the IPv6 one
+// on a dual-stack network. The IPv4 address is the one the runner renders
+// into the cell's /etc/hosts so tools that resolve the container's own
+// hostname work without DNS-lookup timeouts (issue #345). A non-nil
+// bandwidth is handed to the bandwidth plugin as its runtime config; the
+// shaping it installs is torn down by the plugin on the normal DEL.
+func (m *Manager) AddContainerToNetwork(
+	ctx context.Context,
+	containerID, netnsPath string,
+	bandwidth *BandwidthLimits,
+) (ContainerAddresses, error) {
 	if m.netConf == nil {
-		return nil, errdefs.ErrNetworkConfigNotLoaded
+		return ContainerAddresses{}, errdefs.ErrNetworkConfigNotLoaded
+	}
+	if err := m.checkPlugins(); err != nil {
+		return ContainerAddresses{}, err
 	}
 
 	rt := buildRuntimeConf(containerID, netnsPath)
+	rt.CapabilityArgs = bandwidth.CapabilityArgs()
 	rawResult, err := m.cniConf.AddNetworkList(ctx, m.netConf, rt)
 	if err != nil {
-		return nil, translateCNIError(err, m.netConf.Name, bridgeNameFromNetConf(m.netConf))
+		return ContainerAddresses{}, translateCNIError(err, m.netConf.Name, bridgeNameFromNetConf(m.netConf))
 	}
-	return firstIPv4FromResult(rawResult), nil
+	return addressesFromResult(rawResult), nil
 }
 
-// CachedIPv4ForContainer returns the IPv4 address libcni cached for the
-// given containerID under the manager's current network config, or nil when
-// no usable IPv4 entry is cached. Used by the runner on the idempotent-skip
-// path (ErrCNIVethExists) — when AddContainerToNetwork failed because veth
-// setup already ran, the IPAM allocation persisted in the cache and is the
-// authoritative source for the cell IP without re-issuing CNI ADD.
-func (m *Manager) CachedIPv4ForContainer(containerID, netnsPath string) net.IP {
+// CachedAddressesForContainer returns the addresses libcni cached for the
+// given containerID under the manager's current network config; a family
+// with no usable cached entry is nil. Used by the runner on the
+// idempotent-skip path (ErrCNIVethExists) — when AddContainerToNetwork failed
+// because veth setup already ran, the IPAM allocation persisted in the cache
+// and is the authoritative source for the cell addresses without re-issuing
+// CNI ADD.
+func (m *Manager) CachedAddressesForContainer(containerID, netnsPath string) ContainerAddresses {
 	if m.netConf == nil {
-		return nil
+		return ContainerAddresses{}
 	}
 	rt := buildRuntimeConf(containerID, netnsPath)
 	rawResult, err := m.cniConf.GetNetworkListCachedResult(m.netConf, rt)
 	if err != nil || rawResult == nil {
-		return nil
+		return ContainerAddresses{}
 	}
-	return firstIPv4FromResult(rawResult)
+	return addressesFromResult(rawResult)
 }
 
-// firstIPv4FromResult extracts the first IPv4 address from a CNI result,
-// converting through the libcni current schema so callers can stay agnostic
-// of which CNI version the bridge plugin emits.
-func firstIPv4FromResult(rawResult cnitypes.Result) net.IP {
+// addressesFromResult extracts the first IPv4 and first IPv6 address from a
+// CNI result, converting through the libcni current schema so callers can
+// stay agnostic of which CNI version the bridge plugin emits.
+func addressesFromResult(rawResult cnitypes.Result) ContainerAddresses {
+	var addrs ContainerAddresses
 	if rawResult == nil {
-		return nil
+		return addrs
 	}
 	res, err := current.NewResultFromResult(rawResult)
 	if err != nil || res == nil {
-		return nil
+		return addrs
 	}
 	for _, ipc := range res.IPs {
 		if ipc == nil {
 			continue
 		}
 		if v4 := ipc.Address.IP.To4(); v4 != nil {
-			return v4
+			if addrs.IPv4 == nil {
+				addrs.IPv4 = v4
+			}
+			continue
+		}
+		if addrs.IPv6 == nil && ipc.Address.IP.To16() != nil {
+			addrs.IPv6 = ipc.Address.IP
 		}
 	}
-	return nil
+	return addrs
 }
 
 // DelContainerFromNetwork removes a container from the CNI network.
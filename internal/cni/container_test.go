@@ -18,7 +18,12 @@ package cni_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	cni "github.com/eminwux/kukeon/internal/cni"
@@ -59,7 +64,7 @@ func TestManager_AddContainerToNetwork(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mgr := tt.setup(t)
-			ip, err := mgr.AddContainerToNetwork(context.Background(), tt.containerID, tt.netnsPath)
+			addrs, err := mgr.AddContainerToNetwork(context.Background(), tt.containerID, tt.netnsPath, nil)
 
 			if tt.wantErr != nil {
 				if err == nil {
@@ -67,8 +72,8 @@ func TestManager_AddContainerToNetwork(t *testing.T) {
 				} else if !errors.Is(err, tt.wantErr) {
 					t.Errorf("AddContainerToNetwork() error = %v, want %v", err, tt.wantErr)
 				}
-				if ip != nil {
-					t.Errorf("AddContainerToNetwork() ip = %v, want nil on error", ip)
+				if addrs.IPv4 != nil || addrs.IPv6 != nil {
+					t.Errorf("AddContainerToNetwork() addresses = %+v, want none on error", addrs)
 				}
 			} else {
 				if err != nil {
@@ -79,6 +84,37 @@ func TestManager_AddContainerToNetwork(t *testing.T) {
 	}
 }
 
+// TestManager_AddContainerToNetwork_MissingPlugin: a bin dir without the
+// bridge plugin fails before ADD with ErrCNIPluginNotFound naming the
+// plugin and the directory searched.
+func TestManager_AddContainerToNetwork_MissingPlugin(t *testing.T) {
+	binDir := t.TempDir()
+	for _, name := range []string{"host-local", "loopback", "bandwidth"} {
+		if err := os.WriteFile(filepath.Join(binDir, name), []byte("#!/bin/sh\nexit 1\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mgr, err := cni.NewManager(binDir, t.TempDir(), t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	path, err := mgr.CreateNetwork("test-net", "k-test", "10.88.0.0/16")
+	if err != nil {
+		t.Fatalf("CreateNetwork() error = %v", err)
+	}
+	if err = mgr.LoadNetworkConfigList(path); err != nil {
+		t.Fatalf("LoadNetworkConfigList() error = %v", err)
+	}
+
+	_, err = mgr.AddContainerToNetwork(context.Background(), "test-container", "/proc/123/ns/net", nil)
+	if !errors.Is(err, errdefs.ErrCNIPluginNotFound) {
+		t.Fatalf("AddContainerToNetwork() error = %v, want ErrCNIPluginNotFound", err)
+	}
+	if want := fmt.Sprintf("bridge not found in %s", binDir); !strings.Contains(err.Error(), want) {
+		t.Errorf("AddContainerToNetwork() error = %q, want it to contain %q", err, want)
+	}
+}
+
 func TestManager_DelContainerFromNetwork(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -120,3 +156,56 @@ func TestManager_DelContainerFromNetwork(t *testing.T) {
 		})
 	}
 }
+
+func TestManager_CachedAddressesForContainer_DualStack(t *testing.T) {
+	const network = "r1-s1"
+	conflist := []byte(fmt.Sprintf(gcConflist, network))
+	confPath := filepath.Join(t.TempDir(), "network.conflist")
+	if err := os.WriteFile(confPath, conflist, 0o600); err != nil {
+		t.Fatalf("write conflist: %v", err)
+	}
+
+	cacheDir := t.TempDir()
+	mgr, err := cni.NewManager(t.TempDir(), t.TempDir(), cacheDir)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	if err = mgr.LoadNetworkConfigList(confPath); err != nil {
+		t.Fatalf("LoadNetworkConfigList() error = %v", err)
+	}
+
+	if got := mgr.CachedAddressesForContainer("c1", "/proc/4242/ns/net"); got.IPv4 != nil || got.IPv6 != nil {
+		t.Fatalf("CachedAddressesForContainer() without cache = %+v, want none", got)
+	}
+
+	result := `{"cniVersion":"0.4.0","ips":[` +
+		`{"version":"4","address":"10.88.1.5/24"},` +
+		`{"version":"6","address":"fd00:88:1::5/64"}]}`
+	entry, err := json.Marshal(map[string]any{
+		"kind":        "cniCacheV1",
+		"containerId": "c1",
+		"config":      conflist,
+		"ifName":      "eth0",
+		"networkName": network,
+		"netns":       "/proc/4242/ns/net",
+		"result":      json.RawMessage(result),
+	})
+	if err != nil {
+		t.Fatalf("marshal cache entry: %v", err)
+	}
+	resultsDir := filepath.Join(cacheDir, "results")
+	if err = os.MkdirAll(resultsDir, 0o755); err != nil {
+		t.Fatalf("mkdir cache dir: %v", err)
+	}
+	if err = os.WriteFile(filepath.Join(resultsDir, network+"-c1-eth0"), entry, 0o600); err != nil {
+		t.Fatalf("write cache entry: %v", err)
+	}
+
+	got := mgr.CachedAddressesForContainer("c1", "/proc/4242/ns/net")
+	if got.IPv4.String() != "10.88.1.5" {
+		t.Errorf("IPv4 = %v, want 10.88.1.5", got.IPv4)
+	}
+	if got.IPv6.String() != "fd00:88:1::5" {
+		t.Errorf("IPv6 = %v, want fd00:88:1::5", got.IPv6)
+	}
+}
@@ -72,6 +72,18 @@ func translateCNIError(err error, networkName, bridge string) error {
 		return fmt.Errorf("%w: %w", errdefs.ErrCNIVethExists, err)
 	}
 
+	// Transient lock contention on the host-local IPAM store. Concurrent
+	// ADDs serialize on a flock over the network's allocation directory;
+	// under contention the plugin can fail with EAGAIN ("resource
+	// temporarily unavailable") or EBUSY ("device or resource busy") instead
+	// of waiting. Both clear once the other ADD releases the lock, so they
+	// are marked retryable. Exhaustion ("no IP addresses available") and
+	// duplicate allocations are not contention and pass through.
+	if strings.Contains(msg, "resource temporarily unavailable") ||
+		strings.Contains(msg, "device or resource busy") {
+		return fmt.Errorf("%w: %w", errdefs.ErrCNITransient, err)
+	}
+
 	return err
 }
 
@@ -123,6 +123,35 @@ func TestTranslateCNIError(t *testing.T) {
 			bridge:      "cni0",
 			passthrough: true,
 		},
+		{
+			name: "IPAM store lock EAGAIN → ErrCNITransient",
+			err: errors.New(
+				`plugin type="host-local" failed (add): failed to lock /var/lib/cni/networks/net: resource temporarily unavailable`,
+			),
+			networkName:  "net",
+			bridge:       "cni0",
+			wantSentinel: errdefs.ErrCNITransient,
+			wantSubstr:   "resource temporarily unavailable",
+		},
+		{
+			name: "IPAM store busy → ErrCNITransient",
+			err: errors.New(
+				`plugin type="host-local" failed (add): open /var/lib/cni/networks/net/lock: device or resource busy`,
+			),
+			networkName:  "net",
+			bridge:       "cni0",
+			wantSentinel: errdefs.ErrCNITransient,
+			wantSubstr:   "device or resource busy",
+		},
+		{
+			name: "IPAM range exhausted → passthrough (not transient)",
+			err: errors.New(
+				`plugin type="host-local" failed (add): failed to allocate for range 0: no IP addresses available in range set: 10.88.1.1-10.88.1.254`,
+			),
+			networkName: "net",
+			bridge:      "cni0",
+			passthrough: true,
+		},
 		{
 			name: "iptables rule already exists → passthrough (not idempotent)",
 			err: errors.New(
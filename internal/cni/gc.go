@@ -0,0 +1,81 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cni
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	libcni "github.com/containernetworking/cni/libcni"
+)
+
+// GC reconciles the CNI result cache for networkName against the containers
+// that still exist. Every cached attachment on the network whose container
+// ID is not in knownContainerIDs is stale — typically a cell that crashed
+// without a clean detach — and gets a CNI DEL, which releases its IPAM
+// allocation and drops the cache entry. The DEL is driven from the config
+// cached with the attachment, so it works after the network's conflist has
+// been removed.
+//
+// The DEL runs without a netns: the owning container is gone, so its cached
+// /proc/<pid>/ns/net path is stale and may now name an unrelated process.
+// Its host-side veth went away with the netns; one that outlived it is left
+// for TeardownNetwork to remove with the bridge.
+//
+// Returns the container IDs whose allocations were reclaimed. A failed DEL
+// does not stop the sweep; the failures are joined into the error.
+func (m *Manager) GC(ctx context.Context, networkName string, knownContainerIDs []string) ([]string, error) {
+	if networkName == "" {
+		return nil, errors.New("network name is required")
+	}
+	attachments, err := m.cniConf.GetCachedAttachments("")
+	if err != nil {
+		return nil, fmt.Errorf("read CNI cache %q: %w", m.conf.CniCacheDir, err)
+	}
+
+	known := make(map[string]bool, len(knownContainerIDs))
+	for _, id := range knownContainerIDs {
+		known[id] = true
+	}
+
+	var reclaimed []string
+	var errs []error
+	for _, att := range attachments {
+		if att.Network != networkName || known[att.ContainerID] {
+			continue
+		}
+		list, confErr := libcni.ConfListFromBytes(att.Config)
+		if confErr != nil {
+			errs = append(errs, fmt.Errorf("container %s: parse cached config: %w", att.ContainerID, confErr))
+			continue
+		}
+		rt := &libcni.RuntimeConf{
+			ContainerID:    att.ContainerID,
+			IfName:         att.IfName,
+			Args:           att.CniArgs,
+			CapabilityArgs: att.CapabilityArgs,
+		}
+		if delErr := m.cniConf.DelNetworkList(ctx, list, rt); delErr != nil {
+			errs = append(errs, fmt.Errorf("container %s: %w",
+				att.ContainerID, translateCNIError(delErr, networkName, bridgeNameFromNetConf(list))))
+			continue
+		}
+		reclaimed = append(reclaimed, att.ContainerID)
+	}
+	return reclaimed, errors.Join(errs...)
+}
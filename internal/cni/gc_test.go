@@ -0,0 +1,129 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cni_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cni "github.com/eminwux/kukeon/internal/cni"
+)
+
+const gcConflist = `{
+  "cniVersion": "0.4.0",
+  "name": "%s",
+  "plugins": [{"type": "fakecni"}]
+}`
+
+// installFakePlugin writes a "fakecni" plugin into a temp bin dir that logs
+// each invocation as "<command> <container-id>" and succeeds.
+func installFakePlugin(t *testing.T) (string, string) {
+	t.Helper()
+	binDir := t.TempDir()
+	logPath := filepath.Join(t.TempDir(), "calls.log")
+	script := "#!/bin/sh\necho \"$CNI_COMMAND $CNI_CONTAINERID\" >> \"" + logPath + "\"\n"
+	if err := os.WriteFile(filepath.Join(binDir, "fakecni"), []byte(script), 0o700); err != nil {
+		t.Fatalf("write fake plugin: %v", err)
+	}
+	return binDir, logPath
+}
+
+// writeCachedAttachment writes a libcni result-cache entry the way AddNetworkList
+// leaves one behind.
+func writeCachedAttachment(t *testing.T, cacheDir, network, containerID string) string {
+	t.Helper()
+	entry := map[string]any{
+		"kind":        "cniCacheV1",
+		"containerId": containerID,
+		"config":      []byte(fmt.Sprintf(gcConflist, network)),
+		"ifName":      "eth0",
+		"networkName": network,
+		"netns":       "/proc/4242/ns/net",
+	}
+	body, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("marshal cache entry: %v", err)
+	}
+	dir := filepath.Join(cacheDir, "results")
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir cache dir: %v", err)
+	}
+	path := filepath.Join(dir, network+"-"+containerID+"-eth0")
+	if err = os.WriteFile(path, body, 0o600); err != nil {
+		t.Fatalf("write cache entry: %v", err)
+	}
+	return path
+}
+
+func TestManager_GC_ReclaimsStaleAttachments(t *testing.T) {
+	binDir, logPath := installFakePlugin(t)
+	cacheDir := t.TempDir()
+	stale := writeCachedAttachment(t, cacheDir, "r1-s1", "dead1")
+	live := writeCachedAttachment(t, cacheDir, "r1-s1", "live1")
+	otherNet := writeCachedAttachment(t, cacheDir, "r2-s1", "dead2")
+
+	mgr, err := cni.NewManager(binDir, t.TempDir(), cacheDir)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	reclaimed, err := mgr.GC(context.Background(), "r1-s1", []string{"live1"})
+	if err != nil {
+		t.Fatalf("GC() error = %v", err)
+	}
+	if !equalStrings(reclaimed, []string{"dead1"}) {
+		t.Errorf("GC() reclaimed = %v, want [dead1]", reclaimed)
+	}
+
+	calls, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("read plugin log: %v", err)
+	}
+	if got := strings.TrimSpace(string(calls)); got != "DEL dead1" {
+		t.Errorf("plugin calls = %q, want %q", got, "DEL dead1")
+	}
+	if _, statErr := os.Stat(stale); !os.IsNotExist(statErr) {
+		t.Errorf("stale cache entry still present: %v", statErr)
+	}
+	for _, keep := range []string{live, otherNet} {
+		if _, statErr := os.Stat(keep); statErr != nil {
+			t.Errorf("cache entry %s removed, want kept: %v", filepath.Base(keep), statErr)
+		}
+	}
+
+	// A second pass finds nothing left to reclaim.
+	reclaimed, err = mgr.GC(context.Background(), "r1-s1", []string{"live1"})
+	if err != nil || len(reclaimed) != 0 {
+		t.Errorf("second GC() = %v, %v; want nothing reclaimed", reclaimed, err)
+	}
+}
+
+func TestManager_GC_MissingCacheDir(t *testing.T) {
+	mgr, err := cni.NewManager(t.TempDir(), t.TempDir(), filepath.Join(t.TempDir(), "absent"))
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	reclaimed, err := mgr.GC(context.Background(), "r1-s1", nil)
+	if err != nil || len(reclaimed) != 0 {
+		t.Errorf("GC() = %v, %v; want nothing reclaimed and no error", reclaimed, err)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 
@@ -82,26 +83,53 @@ func (m *Manager) ExistsNetworkConfig(networkName, configPath string) (bool, str
 // when the IPAM ranges entry has no `subnet` field, which callers should
 // treat the same as a missing assignment.
 func (m *Manager) ReadSubnetCIDR(configPath string) (string, error) {
+	subnets, err := readRangeSubnets(configPath)
+	if err != nil || len(subnets) == 0 {
+		return "", err
+	}
+	return subnets[0], nil
+}
+
+// ReadIPv6SubnetCIDR parses the conflist at configPath and returns the first
+// IPv6 subnet among the bridge plugin's IPAM ranges, or "" when the network
+// is IPv4-only. Errors match ReadSubnetCIDR.
+func (m *Manager) ReadIPv6SubnetCIDR(configPath string) (string, error) {
+	subnets, err := readRangeSubnets(configPath)
+	if err != nil {
+		return "", err
+	}
+	for _, subnet := range subnets {
+		if ip, _, parseErr := net.ParseCIDR(subnet); parseErr == nil && ip.To4() == nil {
+			return subnet, nil
+		}
+	}
+	return "", nil
+}
+
+// readRangeSubnets returns the `subnet` of the first entry of each IPAM range
+// of the conflist's bridge plugin, in order. A bridge plugin without IPAM
+// ranges yields an empty slice; an entry without a subnet yields "".
+func readRangeSubnets(configPath string) ([]string, error) {
 	if configPath == "" {
-		return "", errors.New("network config path is required")
+		return nil, errors.New("network config path is required")
 	}
 
 	data, err := os.ReadFile(configPath)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return "", errdefs.ErrNetworkNotFound
+			return nil, errdefs.ErrNetworkNotFound
 		}
-		return "", err
+		return nil, err
 	}
 
 	var raw map[string]interface{}
 	if uErr := json.Unmarshal(data, &raw); uErr != nil {
-		return "", fmt.Errorf("parse conflist: %w", uErr)
+		return nil, fmt.Errorf("parse conflist: %w", uErr)
 	}
 
 	plugins, ok := raw["plugins"].([]interface{})
 	if !ok {
-		return "", errdefs.ErrBridgePluginMissing
+		return nil, errdefs.ErrBridgePluginMissing
 	}
 
 	for _, p := range plugins {
@@ -114,25 +142,65 @@ func (m *Manager) ReadSubnetCIDR(configPath string) (string, error) {
 		}
 		ipam, ipamOK := plugin["ipam"].(map[string]interface{})
 		if !ipamOK {
-			return "", nil
+			return nil, nil
 		}
 		ranges, rOK := ipam["ranges"].([]interface{})
-		if !rOK || len(ranges) == 0 {
-			return "", nil
+		if !rOK {
+			return nil, nil
 		}
-		first, fOK := ranges[0].([]interface{})
-		if !fOK || len(first) == 0 {
-			return "", nil
+		var subnets []string
+		for _, r := range ranges {
+			set, sOK := r.([]interface{})
+			if !sOK || len(set) == 0 {
+				subnets = append(subnets, "")
+				continue
+			}
+			entry, eOK := set[0].(map[string]interface{})
+			if !eOK {
+				subnets = append(subnets, "")
+				continue
+			}
+			subnet, _ := entry["subnet"].(string)
+			subnets = append(subnets, subnet)
 		}
-		entry, eOK := first[0].(map[string]interface{})
-		if !eOK {
-			return "", nil
+		return subnets, nil
+	}
+
+	return nil, errdefs.ErrBridgePluginMissing
+}
+
+// HasBandwidthPlugin reports whether the conflist at configPath chains the
+// bandwidth plugin last, which is where it must sit to shape the bridge
+// plugin's veth. Conflists written before per-cell bandwidth limits existed
+// report false. Errors match ReadBridgeName.
+func (m *Manager) HasBandwidthPlugin(configPath string) (bool, error) {
+	if configPath == "" {
+		return false, errors.New("network config path is required")
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, errdefs.ErrNetworkNotFound
 		}
-		subnet, _ := entry["subnet"].(string)
-		return subnet, nil
+		return false, err
 	}
 
-	return "", errdefs.ErrBridgePluginMissing
+	var raw map[string]interface{}
+	if uErr := json.Unmarshal(data, &raw); uErr != nil {
+		return false, fmt.Errorf("parse conflist: %w", uErr)
+	}
+
+	plugins, ok := raw["plugins"].([]interface{})
+	if !ok || len(plugins) == 0 {
+		return false, nil
+	}
+	last, ok := plugins[len(plugins)-1].(map[string]interface{})
+	if !ok {
+		return false, nil
+	}
+	t, _ := last["type"].(string)
+	return t == bandwidthPluginType, nil
 }
 
 // ReadBridgeName parses the conflist at configPath and returns the `bridge` field of
@@ -106,7 +106,7 @@ func TestManager_LoadNetworkConfigList(t *testing.T) {
 				// Verify config was loaded by trying to use it
 				// We can't directly access netConf, but we can verify it's loaded
 				// by checking that AddContainerToNetwork doesn't return ErrNetworkConfigNotLoaded
-				_, err := mgr.AddContainerToNetwork(context.Background(), "test-container", "/proc/123/ns/net")
+				_, err := mgr.AddContainerToNetwork(context.Background(), "test-container", "/proc/123/ns/net", nil)
 				if err != nil && !errors.Is(err, errdefs.ErrNetworkConfigNotLoaded) {
 					// Config is loaded (error is from libcni, not from missing config)
 					return
@@ -811,3 +811,34 @@ func TestManager_DeleteNetwork(t *testing.T) {
 		})
 	}
 }
+
+func TestManager_HasBandwidthPlugin(t *testing.T) {
+	dir := t.TempDir()
+	mgr := setupTestManager(t, dir)
+
+	current := filepath.Join(dir, "current.conflist")
+	data, err := cni.BuildDefaultConflist("r1-s1", "k-deadbeef", "10.88.1.0/24")
+	if err != nil {
+		t.Fatalf("BuildDefaultConflist() error = %v", err)
+	}
+	if err = os.WriteFile(current, data, 0o600); err != nil {
+		t.Fatalf("write conflist: %v", err)
+	}
+	legacy := filepath.Join(dir, "legacy.conflist")
+	legacyData := `{"cniVersion":"0.4.0","name":"r1-s1","plugins":[{"type":"bridge"},{"type":"loopback"}]}`
+	if err = os.WriteFile(legacy, []byte(legacyData), 0o600); err != nil {
+		t.Fatalf("write conflist: %v", err)
+	}
+
+	if has, hErr := mgr.HasBandwidthPlugin(current); hErr != nil || !has {
+		t.Errorf("HasBandwidthPlugin(current) = %v, %v; want true", has, hErr)
+	}
+	if has, hErr := mgr.HasBandwidthPlugin(legacy); hErr != nil || has {
+		t.Errorf("HasBandwidthPlugin(legacy) = %v, %v; want false", has, hErr)
+	}
+	if _, hErr := mgr.HasBandwidthPlugin(filepath.Join(dir, "absent.conflist")); !errors.Is(
+		hErr, errdefs.ErrNetworkNotFound,
+	) {
+		t.Errorf("HasBandwidthPlugin(absent) error = %v, want ErrNetworkNotFound", hErr)
+	}
+}
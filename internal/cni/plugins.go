@@ -0,0 +1,76 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cni
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	libcni "github.com/containernetworking/cni/libcni"
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+// requiredPlugins lists the plugin binaries a conflist execs, in conflist
+// order without duplicates: every plugin type plus any IPAM plugin it
+// delegates to (bridge → host-local).
+func requiredPlugins(netConf *libcni.NetworkConfigList) []string {
+	if netConf == nil {
+		return nil
+	}
+	var names []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, p := range netConf.Plugins {
+		if p == nil || p.Network == nil {
+			continue
+		}
+		add(p.Network.Type)
+		add(p.Network.IPAM.Type)
+	}
+	return names
+}
+
+// checkPlugins verifies every plugin the loaded conflist needs is an
+// executable file in the CNI bin dir. Run before ADD: a missing binary
+// otherwise surfaces from libcni as a bare "failed to find plugin" (or, with
+// an empty search path, a confusing attach failure) that names neither the
+// plugin nor where it was looked for.
+func (m *Manager) checkPlugins() error {
+	required := requiredPlugins(m.netConf)
+	var missing []string
+	for _, name := range required {
+		info, err := os.Stat(filepath.Join(m.conf.CniBinDir, name))
+		if err != nil || info.IsDir() || info.Mode().Perm()&0o111 == 0 {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return fmt.Errorf(
+		"%w: %s not found in %s (network %q needs %s); install the standard CNI plugins (e.g. apt install containernetworking-plugins) into %s",
+		errdefs.ErrCNIPluginNotFound, strings.Join(missing, ", "), m.conf.CniBinDir,
+		m.netConf.Name, strings.Join(required, ", "), m.conf.CniBinDir,
+	)
+}
@@ -379,3 +379,26 @@ func uint32ToIP(v uint32) net.IP {
 	const o1, o2, o3 = ipv4OctetBits * 3, ipv4OctetBits * 2, ipv4OctetBits
 	return net.IPv4(byte(v>>o1), byte(v>>o2), byte(v>>o3), byte(v)).To4()
 }
+
+// ParseIPv6Subnet validates a space's IPv6 subnet: it must parse as a CIDR,
+// be IPv6, and be given in its network form (no host bits set) so the IPAM
+// range and the overlap check see the same block.
+func ParseIPv6Subnet(cidr string) (*net.IPNet, error) {
+	ip, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", errdefs.ErrInvalidSubnetCIDR, cidr, err)
+	}
+	if ip.To4() != nil {
+		return nil, fmt.Errorf("%w: %q is not IPv6", errdefs.ErrInvalidSubnetCIDR, cidr)
+	}
+	if !ip.Equal(ipNet.IP) {
+		return nil, fmt.Errorf("%w: %q has host bits set, use %s", errdefs.ErrInvalidSubnetCIDR, cidr, ipNet)
+	}
+	return ipNet, nil
+}
+
+// SubnetsOverlap reports whether a and b share any address. Two CIDR blocks
+// overlap exactly when one contains the other's network address.
+func SubnetsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
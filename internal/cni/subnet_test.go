@@ -19,6 +19,7 @@ package cni_test
 import (
 	"encoding/json"
 	"errors"
+	"net"
 	"os"
 	"path/filepath"
 	"testing"
@@ -370,3 +371,59 @@ func TestConfigureSubnetParentCIDR_RejectsInvalid(t *testing.T) {
 		}
 	}
 }
+
+func TestParseIPv6Subnet(t *testing.T) {
+	tests := []struct {
+		name    string
+		cidr    string
+		want    string
+		wantErr bool
+	}{
+		{name: "valid /64", cidr: "fd00:88:1::/64", want: "fd00:88:1::/64"},
+		{name: "surrounding whitespace", cidr: " fd00:88:2::/64 ", want: "fd00:88:2::/64"},
+		{name: "not a CIDR", cidr: "fd00::1", wantErr: true},
+		{name: "IPv4 CIDR", cidr: "10.88.0.0/24", wantErr: true},
+		{name: "host bits set", cidr: "fd00:88:1::1/64", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cni.ParseIPv6Subnet(tt.cidr)
+			if tt.wantErr {
+				if !errors.Is(err, errdefs.ErrInvalidSubnetCIDR) {
+					t.Fatalf("ParseIPv6Subnet(%q) error = %v, want ErrInvalidSubnetCIDR", tt.cidr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseIPv6Subnet(%q) error = %v", tt.cidr, err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("ParseIPv6Subnet(%q) = %s, want %s", tt.cidr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubnetsOverlap(t *testing.T) {
+	parse := func(cidr string) *net.IPNet {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatalf("ParseCIDR(%q): %v", cidr, err)
+		}
+		return n
+	}
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "fd00:88:1::/64", b: "fd00:88:1::/64", want: true},
+		{a: "fd00:88::/32", b: "fd00:88:1::/64", want: true},
+		{a: "fd00:88:1::/64", b: "fd00:88::/32", want: true},
+		{a: "fd00:88:1::/64", b: "fd00:88:2::/64", want: false},
+	}
+	for _, tt := range tests {
+		if got := cni.SubnetsOverlap(parse(tt.a), parse(tt.b)); got != tt.want {
+			t.Errorf("SubnetsOverlap(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -42,6 +42,10 @@ type NetworkConfig struct {
 	BridgeName string
 	// SubnetCIDR is the IPAM subnet CIDR. Defaults to "10.88.0.0/16" when empty.
 	SubnetCIDR string
+	// IPv6SubnetCIDR, when set, adds a second IPAM range so containers get
+	// an IPv6 address alongside the IPv4 one (dual-stack). Empty keeps the
+	// network IPv4-only.
+	IPv6SubnetCIDR string
 }
 
 // BootstrapReport captures CNI environment checks and actions.
@@ -69,11 +73,12 @@ type ConflistModel struct {
 
 // BridgePluginModel represents the bridge plugin configuration in a conflist.
 type BridgePluginModel struct {
-	Type      string           `json:"type"`
-	Bridge    string           `json:"bridge"`
-	IsGateway bool             `json:"isGateway"`
-	IPMasq    bool             `json:"ipMasq"`
-	IPAM      BridgeIPAMConfig `json:"ipam"`
+	Type       string           `json:"type"`
+	Bridge     string           `json:"bridge"`
+	IsGateway  bool             `json:"isGateway"`
+	IPMasq     bool             `json:"ipMasq"`
+	EnableIPv6 bool             `json:"enableIPv6,omitempty"`
+	IPAM       BridgeIPAMConfig `json:"ipam"`
 }
 
 // BridgeIPAMConfig represents the IPAM configuration for the bridge plugin.
@@ -93,6 +98,14 @@ type LoopbackPluginModel struct {
 	Type string `json:"type"`
 }
 
+// BandwidthPluginModel represents the bandwidth plugin configuration in a
+// conflist. It only declares the capability; the limits arrive per container
+// through the runtime config.
+type BandwidthPluginModel struct {
+	Type         string          `json:"type"`
+	Capabilities map[string]bool `json:"capabilities"`
+}
+
 const (
 	defaultCniConfDir  = "/opt/cni/net.d"
 	defaultCniBinDir   = "/opt/cni/bin"
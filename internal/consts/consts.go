@@ -37,6 +37,12 @@ const (
 	// .kukeon-instance.json file — are not mistaken for realm directories.
 	KukeonMetadataSubdir = "data"
 
+	// KukeonBackupsSubdir is the basename of the subdirectory under the
+	// daemon's RunPath that holds the metadata archives taken before a realm
+	// purge or cascade delete. A sibling of KukeonMetadataSubdir, so purging
+	// a realm never removes its own backup.
+	KukeonBackupsSubdir = "backups"
+
 	// KukeonSecretsSubdir is the basename of the per-scope subdirectory that
 	// owns daemon-managed `kind: Secret` bytes (issue #619). It lives inside
 	// the scope's metadata directory (e.g. <RunPath>/data/<realm>/secrets/) so
@@ -96,6 +102,15 @@ const (
 	// host-visible.
 	KukeonContainerTTYDir = "tty"
 
+	// KukeonContainerTerminationLogFile is the basename of the per-container
+	// host file bind-mounted at the container's terminationMessagePath. The
+	// runner reads it back into ContainerStatus.TerminationMessage on exit.
+	KukeonContainerTerminationLogFile = "termination-log"
+
+	// DefaultTerminationMessagePath is the in-container path of the
+	// termination-message file when spec.terminationMessagePath is unset.
+	DefaultTerminationMessagePath = "/dev/termination-log"
+
 	// KukeonContainerSocketFile is the basename of the per-container sbsh
 	// terminal socket inside KukeonContainerTTYDir. The container sees the
 	// same inode at /run/kukeon/tty/socket via the directory bind mount
@@ -139,6 +154,11 @@ const (
 	// Attachable container.
 	KukeonContainerLogFile = "log"
 
+	// KukeonContainerPreviousLogFile is the basename the previous task
+	// instance's log file is archived under when the container starts a new
+	// task, beside KukeonContainerLogFile. `kuke log --previous` reads it.
+	KukeonContainerPreviousLogFile = "log.previous"
+
 	// KukeonContainerKukettyLogFile is the basename of the per-Attachable-
 	// container kuketty wrapper's own slog output, inside KukeonContainerTTYDir
 	// (peer to the socket and capture files, same bind-mount visibility). The
@@ -157,6 +177,23 @@ const (
 	KukeonCellLabelKey      = "cell.kukeon.io"
 	KukeonContainerLabelKey = "container.kukeon.io"
 
+	// KukeonReplicaOfLabelKey marks a cell created by `kuke stack scale` as a
+	// replica of the template cell named by the label value. ScaleCell counts
+	// only cells carrying it, so hand-built siblings are never scaled away.
+	KukeonReplicaOfLabelKey = "kukeon.io/replica-of"
+
+	// KukeonLastAppliedAnnotationKey holds the manifest `kuke apply` last
+	// wrote for a realm, space, stack or cell, as JSON. The next apply reads
+	// it back to tell a label or annotation the manifest dropped (delete it)
+	// from one another writer added (keep it).
+	KukeonLastAppliedAnnotationKey = "kukeon.io/last-applied-configuration"
+	// KukeonFieldManagerAnnotationKey names the field manager (`kuke apply
+	// --field-manager`) that wrote the last-applied configuration.
+	KukeonFieldManagerAnnotationKey = "kukeon.io/field-manager"
+	// KukeonDefaultFieldManager is the field manager recorded when apply is
+	// not given one.
+	KukeonDefaultFieldManager = "kuke"
+
 	// Default user hierarchy created by `kuke init` for user workloads.
 	KukeonDefaultRealmName = "default"
 	KukeonDefaultSpaceName = "default"
@@ -0,0 +1,406 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package containerfs copies files and directories into and out of a
+// running container's filesystem view. The view is reached through the
+// task's /proc/<pid>/root link on the host, which the kernel resolves
+// inside the container's mount namespace — so the copy sees exactly the
+// tree the workload sees (overlay rootfs plus every bind mount) without
+// kukeon having to join the namespace itself.
+//
+// Transfers are streamed as a tar archive so directories copy
+// recursively and file modes survive the round trip. Nothing is opened by
+// a path string under the container root: every lookup starts from a handle
+// on the root and goes through securejoin's pathrs-lite, the archive walk
+// descends by file descriptor with O_NOFOLLOW, and each entry is written,
+// linked and chmodded through its parent directory's descriptor. A symlink
+// planted inside the container — or swapped in while a copy is running —
+// can therefore never redirect a write (or a read) onto the host
+// filesystem.
+package containerfs
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	pathrs "github.com/cyphar/filepath-securejoin/pathrs-lite"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"golang.org/x/sys/unix"
+)
+
+// hostRoot is the root used to resolve host-side paths. Resolving host
+// paths through the same pathrs helpers keeps CopyTo and CopyFrom
+// symmetric; against "/" it degenerates to ordinary path resolution.
+const hostRoot = "/"
+
+// RootFSPath returns the host path of a task's filesystem view: the
+// /proc/<pid>/root magic link of the task's init process.
+func RootFSPath(pid uint32) string {
+	return filepath.Join("/proc", strconv.FormatUint(uint64(pid), 10), "root")
+}
+
+// CopyFrom copies srcPath, interpreted inside the container rooted at
+// rootfs, to dstPath on the host. A directory source copies recursively.
+// When dstPath is an existing directory the source lands inside it under
+// its own base name; otherwise dstPath names the copy. A relative dstPath
+// is resolved against the working directory.
+func CopyFrom(rootfs, srcPath, dstPath string) error {
+	dst, err := filepath.Abs(dstPath)
+	if err != nil {
+		return fmt.Errorf("resolve host path %q: %w", dstPath, err)
+	}
+	return stream(rootfs, srcPath, hostRoot, dst)
+}
+
+// CopyTo copies srcPath on the host into the container rooted at rootfs at
+// dstPath. A directory source copies recursively. When dstPath is an
+// existing directory inside the container the source lands inside it
+// under its own base name; otherwise dstPath names the copy.
+func CopyTo(rootfs, srcPath, dstPath string) error {
+	src, err := filepath.Abs(srcPath)
+	if err != nil {
+		return fmt.Errorf("resolve host path %q: %w", srcPath, err)
+	}
+	return stream(hostRoot, src, rootfs, dstPath)
+}
+
+// stream pipes a tar of srcPath, resolved inside srcRoot, into
+// ExtractArchive rooted at dstRoot. The writer runs on its own goroutine so
+// arbitrarily large trees never buffer in memory.
+func stream(srcRoot, srcPath, dstRoot, dstPath string) error {
+	src, err := openSource(srcRoot, srcPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+	dir, name := destination(dstRoot, dstPath, filepath.Base(src.Name()))
+
+	pr, pw := io.Pipe()
+	writeErr := make(chan error, 1)
+	go func() {
+		werr := writeArchive(pw, src, name)
+		_ = pw.CloseWithError(werr)
+		writeErr <- werr
+	}()
+	extractErr := ExtractArchive(pr, dstRoot, dir)
+	_ = pr.CloseWithError(extractErr)
+	if werr := <-writeErr; werr != nil {
+		return werr
+	}
+	return extractErr
+}
+
+// openSource returns an O_PATH handle on srcPath resolved inside root. A
+// missing source is reported as ErrCopySourceNotFound.
+func openSource(root, srcPath string) (*os.File, error) {
+	src, err := pathrs.OpenInRoot(root, srcPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %w", errdefs.ErrCopySourceNotFound, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("resolve source %q: %w", srcPath, err)
+	}
+	return src, nil
+}
+
+// destination splits dstPath into the directory the archive extracts
+// into and the top-level entry name the source takes there, following the
+// `cp` convention: an existing directory receives the source under
+// srcBase, anything else is the new name of the copy. The lookup only
+// picks the name; the writes re-resolve everything from the root.
+func destination(root, dstPath, srcBase string) (string, string) {
+	if h, err := pathrs.OpenInRoot(root, dstPath); err == nil {
+		info, statErr := h.Stat()
+		_ = h.Close()
+		if statErr == nil && info.IsDir() {
+			return dstPath, srcBase
+		}
+	}
+	clean := filepath.Clean(dstPath)
+	return filepath.Dir(clean), filepath.Base(clean)
+}
+
+// WriteArchive writes a tar archive of src, resolved inside root, to w. The
+// top-level entry is named name (src's own base name when empty);
+// directory contents are stored beneath it. Regular files, directories, and
+// symlinks are archived with their permission bits; other file types
+// (devices, sockets, fifos) are skipped since they cannot be meaningfully
+// recreated.
+func WriteArchive(w io.Writer, root, src, name string) error {
+	f, err := openSource(root, src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	if name == "" {
+		name = filepath.Base(f.Name())
+	}
+	return writeArchive(w, f, name)
+}
+
+func writeArchive(w io.Writer, src *os.File, name string) error {
+	tw := tar.NewWriter(w)
+	if err := writeTree(tw, src, name); err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// writeTree archives the file behind the O_PATH handle f as entry and, for
+// a directory, everything beneath it in name order. Children are opened
+// relative to their directory's descriptor with O_NOFOLLOW, so a directory
+// swapped for a symlink after it was opened is still read from the
+// directory that was opened.
+func writeTree(tw *tar.Writer, f *os.File, entry string) error {
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	var link string
+	switch {
+	case info.Mode().IsRegular(), info.IsDir():
+	case info.Mode()&os.ModeSymlink != 0:
+		if link, err = readlinkHandle(f); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	hdr, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+	hdr.Name = entry
+	if info.IsDir() {
+		hdr.Name += "/"
+	}
+	if err = tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	switch {
+	case info.Mode().IsRegular():
+		return copyContents(tw, f)
+	case info.IsDir():
+		return writeChildren(tw, f, entry)
+	default:
+		return nil
+	}
+}
+
+func copyContents(tw *tar.Writer, f *os.File) error {
+	r, err := pathrs.Reopen(f, unix.O_RDONLY)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+	_, err = io.Copy(tw, r)
+	return err
+}
+
+func writeChildren(tw *tar.Writer, dir *os.File, entry string) error {
+	d, err := pathrs.Reopen(dir, unix.O_RDONLY|unix.O_DIRECTORY)
+	if err != nil {
+		return err
+	}
+	names, err := d.Readdirnames(-1)
+	_ = d.Close()
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		child, openErr := openAt(dir, name, unix.O_PATH|unix.O_NOFOLLOW, 0)
+		if openErr != nil {
+			return openErr
+		}
+		err = writeTree(tw, child, path.Join(entry, name))
+		_ = child.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readlinkHandle reads the target of the symlink an O_PATH|O_NOFOLLOW
+// handle refers to.
+func readlinkHandle(f *os.File) (string, error) {
+	buf := make([]byte, unix.PathMax)
+	n, err := unix.Readlinkat(int(f.Fd()), "", buf)
+	if err != nil {
+		return "", &os.PathError{Op: "readlinkat", Path: f.Name(), Err: err}
+	}
+	return string(buf[:n]), nil
+}
+
+// openAt opens name relative to the directory handle dir.
+func openAt(dir *os.File, name string, flags int, mode uint32) (*os.File, error) {
+	full := filepath.Join(dir.Name(), name)
+	fd, err := unix.Openat(int(dir.Fd()), name, flags|unix.O_CLOEXEC, mode)
+	if err != nil {
+		return nil, &os.PathError{Op: "openat", Path: full, Err: err}
+	}
+	return os.NewFile(uintptr(fd), full), nil
+}
+
+// ExtractArchive reads a tar archive from r and materializes it under dir,
+// resolved inside root. Each entry's parent directory is created and
+// opened from a handle on root with pathrs-lite, and the entry itself is
+// written through that directory's descriptor with O_NOFOLLOW, so neither a
+// `..` entry nor a symlink present under root — or swapped in during the
+// extraction — can place bytes outside of it. Permission bits are restored
+// from the archive headers.
+func ExtractArchive(r io.Reader, root, dir string) error {
+	rootDir, err := os.OpenFile(root, unix.O_PATH|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rootDir.Close() }()
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, nextErr := tr.Next()
+		if errors.Is(nextErr, io.EOF) {
+			return nil
+		}
+		if nextErr != nil {
+			return nextErr
+		}
+		if err = extractEntry(tr, hdr, rootDir, dir); err != nil {
+			return err
+		}
+	}
+}
+
+func extractEntry(tr *tar.Reader, hdr *tar.Header, root *os.File, dir string) error {
+	name := filepath.Clean(filepath.FromSlash(hdr.Name))
+	if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("%w: %q", errdefs.ErrCopyUnsafeEntry, hdr.Name)
+	}
+	target := filepath.Join(dir, name)
+	mode := os.FileMode(hdr.Mode).Perm()
+
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		return extractDir(root, target, mode)
+	case tar.TypeReg, tar.TypeSymlink:
+	default:
+		return nil
+	}
+
+	base := filepath.Base(target)
+	if base == string(filepath.Separator) || base == "." || base == ".." {
+		return fmt.Errorf("%w: %q", errdefs.ErrCopyUnsafeEntry, hdr.Name)
+	}
+	parent, err := mkdirInRoot(root, filepath.Dir(target), 0o755)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = parent.Close() }()
+
+	if hdr.Typeflag == tar.TypeSymlink {
+		return extractSymlink(parent, base, hdr.Linkname)
+	}
+	return extractFile(tr, parent, base, mode)
+}
+
+func extractDir(root *os.File, target string, mode os.FileMode) error {
+	h, err := mkdirInRoot(root, target, mode)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = h.Close() }()
+	d, err := pathrs.Reopen(h, unix.O_RDONLY|unix.O_DIRECTORY)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = d.Close() }()
+	return d.Chmod(mode)
+}
+
+// mkdirInRoot creates dir and its missing parents inside root and returns an
+// O_PATH handle on it. pathrs-lite refuses to create through a dangling
+// symlink, so a path that meets one is first resolved inside root with
+// SecureJoin — where the container would see it point — and created from
+// the root handle again. A symlink swapped in between can only move the
+// directory elsewhere within root.
+func mkdirInRoot(root *os.File, dir string, mode os.FileMode) (*os.File, error) {
+	h, err := pathrs.MkdirAllHandle(root, dir, mode)
+	if !errors.Is(err, unix.ENOTDIR) {
+		return h, err
+	}
+	resolved, joinErr := securejoin.SecureJoin(root.Name(), dir)
+	if joinErr != nil {
+		return nil, err
+	}
+	rel, relErr := filepath.Rel(root.Name(), resolved)
+	if relErr != nil {
+		return nil, err
+	}
+	return pathrs.MkdirAllHandle(root, rel, mode)
+}
+
+// extractFile writes the entry's contents to base inside parent. A symlink
+// already at base is replaced by the file rather than written through.
+func extractFile(tr *tar.Reader, parent *os.File, base string, mode os.FileMode) error {
+	const flags = unix.O_CREAT | unix.O_TRUNC | unix.O_WRONLY | unix.O_NOFOLLOW
+	f, err := openAt(parent, base, flags, uint32(mode))
+	if errors.Is(err, unix.ELOOP) {
+		if err = unlinkAt(parent, base); err != nil {
+			return err
+		}
+		f, err = openAt(parent, base, flags, uint32(mode))
+	}
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, tr); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Chmod(mode); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func extractSymlink(parent *os.File, base, linkname string) error {
+	if err := unlinkAt(parent, base); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := unix.Symlinkat(linkname, int(parent.Fd()), base); err != nil {
+		return &os.PathError{Op: "symlinkat", Path: filepath.Join(parent.Name(), base), Err: err}
+	}
+	return nil
+}
+
+func unlinkAt(parent *os.File, base string) error {
+	if err := unix.Unlinkat(int(parent.Fd()), base, 0); err != nil {
+		return &os.PathError{Op: "unlinkat", Path: filepath.Join(parent.Name(), base), Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,321 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package containerfs_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/containerfs"
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+func writeFile(t *testing.T, path, content string, mode os.FileMode) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), mode); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		t.Fatalf("chmod %s: %v", path, err)
+	}
+}
+
+func assertFile(t *testing.T, path, content string, mode os.FileMode) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	if string(got) != content {
+		t.Errorf("%s content = %q, want %q", path, got, content)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat %s: %v", path, err)
+	}
+	if info.Mode().Perm() != mode {
+		t.Errorf("%s mode = %o, want %o", path, info.Mode().Perm(), mode)
+	}
+}
+
+func TestRootFSPath(t *testing.T) {
+	if got := containerfs.RootFSPath(123); got != "/proc/123/root" {
+		t.Errorf("RootFSPath(123) = %q", got)
+	}
+}
+
+func TestCopyFrom_SingleFilePreservesMode(t *testing.T) {
+	rootfs := t.TempDir()
+	writeFile(t, filepath.Join(rootfs, "var/log/app.log"), "hello\n", 0o640)
+	host := t.TempDir()
+	dst := filepath.Join(host, "copied.log")
+
+	if err := containerfs.CopyFrom(rootfs, "/var/log/app.log", dst); err != nil {
+		t.Fatalf("CopyFrom: %v", err)
+	}
+	assertFile(t, dst, "hello\n", 0o640)
+}
+
+func TestCopyFrom_RelativeDestinationUsesWorkingDirectory(t *testing.T) {
+	rootfs := t.TempDir()
+	writeFile(t, filepath.Join(rootfs, "var/log/app.log"), "hello\n", 0o640)
+	host := t.TempDir()
+	t.Chdir(host)
+
+	if err := containerfs.CopyFrom(rootfs, "/var/log/app.log", "app.log"); err != nil {
+		t.Fatalf("CopyFrom: %v", err)
+	}
+	assertFile(t, filepath.Join(host, "app.log"), "hello\n", 0o640)
+}
+
+func TestCopyFrom_IntoExistingDirectoryKeepsBaseName(t *testing.T) {
+	rootfs := t.TempDir()
+	writeFile(t, filepath.Join(rootfs, "etc/app.conf"), "k=v", 0o644)
+	host := t.TempDir()
+
+	if err := containerfs.CopyFrom(rootfs, "/etc/app.conf", host); err != nil {
+		t.Fatalf("CopyFrom: %v", err)
+	}
+	assertFile(t, filepath.Join(host, "app.conf"), "k=v", 0o644)
+}
+
+func TestCopyFrom_DirectoryIsRecursive(t *testing.T) {
+	rootfs := t.TempDir()
+	writeFile(t, filepath.Join(rootfs, "data/a.txt"), "a", 0o600)
+	writeFile(t, filepath.Join(rootfs, "data/sub/b.sh"), "#!/bin/sh", 0o755)
+	host := t.TempDir()
+	dst := filepath.Join(host, "backup")
+
+	if err := containerfs.CopyFrom(rootfs, "/data", dst); err != nil {
+		t.Fatalf("CopyFrom: %v", err)
+	}
+	assertFile(t, filepath.Join(dst, "a.txt"), "a", 0o600)
+	assertFile(t, filepath.Join(dst, "sub/b.sh"), "#!/bin/sh", 0o755)
+}
+
+func TestCopyFrom_MissingSource(t *testing.T) {
+	rootfs := t.TempDir()
+	err := containerfs.CopyFrom(rootfs, "/nope", filepath.Join(t.TempDir(), "x"))
+	if !errors.Is(err, errdefs.ErrCopySourceNotFound) {
+		t.Fatalf("err = %v, want ErrCopySourceNotFound", err)
+	}
+}
+
+func TestCopyFrom_SymlinkCannotEscapeRootfs(t *testing.T) {
+	rootfs := t.TempDir()
+	outside := t.TempDir()
+	writeFile(t, filepath.Join(outside, "secret"), "host-only", 0o600)
+	// An absolute symlink inside the container must resolve against the
+	// container root, not the host root.
+	if err := os.Symlink(filepath.Join(outside, "secret"), filepath.Join(rootfs, "link")); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+
+	err := containerfs.CopyFrom(rootfs, "/link", filepath.Join(t.TempDir(), "y"))
+	if !errors.Is(err, errdefs.ErrCopySourceNotFound) {
+		t.Fatalf("err = %v, want ErrCopySourceNotFound (symlink must not reach host)", err)
+	}
+}
+
+func TestCopyTo_SingleFileIntoDirectory(t *testing.T) {
+	rootfs := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(rootfs, "etc"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	host := t.TempDir()
+	src := filepath.Join(host, "nginx.conf")
+	writeFile(t, src, "server {}", 0o644)
+
+	if err := containerfs.CopyTo(rootfs, src, "/etc"); err != nil {
+		t.Fatalf("CopyTo: %v", err)
+	}
+	assertFile(t, filepath.Join(rootfs, "etc/nginx.conf"), "server {}", 0o644)
+}
+
+func TestCopyTo_DirectoryRenamed(t *testing.T) {
+	rootfs := t.TempDir()
+	host := t.TempDir()
+	writeFile(t, filepath.Join(host, "site/index.html"), "<html>", 0o644)
+
+	if err := containerfs.CopyTo(rootfs, filepath.Join(host, "site"), "/srv/www"); err != nil {
+		t.Fatalf("CopyTo: %v", err)
+	}
+	assertFile(t, filepath.Join(rootfs, "srv/www/index.html"), "<html>", 0o644)
+}
+
+func TestCopyTo_SymlinkedDestinationStaysInRootfs(t *testing.T) {
+	rootfs := t.TempDir()
+	outside := t.TempDir()
+	if err := os.Symlink(outside, filepath.Join(rootfs, "escape")); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+	host := t.TempDir()
+	src := filepath.Join(host, "payload")
+	writeFile(t, src, "x", 0o644)
+
+	if err := containerfs.CopyTo(rootfs, src, "/escape/payload"); err != nil {
+		t.Fatalf("CopyTo: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outside, "payload")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("payload escaped the rootfs into %s (stat err = %v)", outside, err)
+	}
+}
+
+func TestExtractArchive_RejectsParentTraversal(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{Name: "../evil", Mode: 0o644, Size: 1, Typeflag: tar.TypeReg}); err != nil {
+		t.Fatalf("header: %v", err)
+	}
+	if _, err := tw.Write([]byte("x")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	err := containerfs.ExtractArchive(&buf, t.TempDir(), "/")
+	if !errors.Is(err, errdefs.ErrCopyUnsafeEntry) {
+		t.Fatalf("err = %v, want ErrCopyUnsafeEntry", err)
+	}
+}
+
+// swapToSymlink moves dir aside and plants a symlink to target in its place,
+// the way a process inside the container could while a copy is running.
+func swapToSymlink(t *testing.T, dir, target string) {
+	t.Helper()
+	if err := os.Rename(dir, dir+".orig"); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if err := os.Symlink(target, dir); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+}
+
+// hookWriter calls hook once, on the first write that starts with prefix —
+// the tar header of that entry.
+type hookWriter struct {
+	bytes.Buffer
+	prefix string
+	hook   func()
+}
+
+func (w *hookWriter) Write(p []byte) (int, error) {
+	if w.hook != nil && bytes.HasPrefix(p, []byte(w.prefix)) {
+		w.hook()
+		w.hook = nil
+	}
+	return w.Buffer.Write(p)
+}
+
+func TestWriteArchive_DirectorySwappedMidWalkIsNotFollowed(t *testing.T) {
+	rootfs := t.TempDir()
+	writeFile(t, filepath.Join(rootfs, "data/sub/b.txt"), "b", 0o644)
+	outside := t.TempDir()
+	writeFile(t, filepath.Join(outside, "secret"), "host-only", 0o600)
+
+	// The swap lands after data/sub was opened and its header written, but
+	// before its entries are listed.
+	w := &hookWriter{prefix: "data/sub/", hook: func() {
+		swapToSymlink(t, filepath.Join(rootfs, "data/sub"), outside)
+	}}
+	if err := containerfs.WriteArchive(w, rootfs, "/data", ""); err != nil {
+		t.Fatalf("WriteArchive: %v", err)
+	}
+	if w.hook != nil {
+		t.Fatal("swap never ran")
+	}
+
+	var names []string
+	tr := tar.NewReader(&w.Buffer)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("read archive: %v", err)
+		}
+		names = append(names, hdr.Name)
+	}
+	got := strings.Join(names, ",")
+	if want := "data/,data/sub/,data/sub/b.txt"; got != want {
+		t.Errorf("entries = %s, want %s (the walk must not follow the swapped-in symlink)", got, want)
+	}
+}
+
+// hookReader calls hook once, when reading reaches offset at.
+type hookReader struct {
+	r    io.Reader
+	off  int
+	at   int
+	hook func()
+}
+
+func (r *hookReader) Read(p []byte) (int, error) {
+	if r.hook != nil && r.off >= r.at {
+		r.hook()
+		r.hook = nil
+	}
+	n, err := r.r.Read(p)
+	r.off += n
+	return n, err
+}
+
+func TestExtractArchive_DirectorySwappedMidExtractStaysInRoot(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{Name: "d/", Mode: 0o755, Typeflag: tar.TypeDir}); err != nil {
+		t.Fatalf("header: %v", err)
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: "d/f", Mode: 0o644, Size: 1, Typeflag: tar.TypeReg}); err != nil {
+		t.Fatalf("header: %v", err)
+	}
+	if _, err := tw.Write([]byte("x")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	root := t.TempDir()
+	outside := t.TempDir()
+	// d is created from the first header; the swap lands before the second
+	// header is read, so d/f is written after d became a host symlink.
+	r := &hookReader{r: &buf, at: 512, hook: func() {
+		swapToSymlink(t, filepath.Join(root, "d"), outside)
+	}}
+	if err := containerfs.ExtractArchive(r, root, "/"); err != nil {
+		t.Fatalf("ExtractArchive: %v", err)
+	}
+	if r.hook != nil {
+		t.Fatal("swap never ran")
+	}
+	if _, err := os.Stat(filepath.Join(outside, "f")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("f escaped the root into %s (stat err = %v)", outside, err)
+	}
+	assertFile(t, filepath.Join(root, outside, "f"), "x", 0o644)
+}
@@ -23,13 +23,18 @@ import (
 
 	"github.com/eminwux/kukeon/internal/apischeme"
 	"github.com/eminwux/kukeon/internal/apply/parser"
+	"github.com/eminwux/kukeon/internal/consts"
 	applypkg "github.com/eminwux/kukeon/internal/controller/apply"
 	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/events"
+	"github.com/eminwux/kukeon/internal/tracing"
 	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
 )
 
 const (
-	actionFailed = "failed"
+	actionFailed     = "failed"
+	actionCreated    = "created"
+	actionRolledBack = "rolled back"
 )
 
 // ApplyResult represents the result of applying a set of resources.
@@ -103,7 +108,19 @@ func (r ResourceResult) MarshalYAML() (interface{}, error) {
 // Blueprint / Config objects carrying the same team label, deleting those
 // not in the applied set. The empty-string team preserves the historical
 // no-stamp, no-prune behavior of `kuke apply -f`.
-func (b *Exec) ApplyDocuments(docs []parser.Document, team string) (ApplyResult, error) {
+//
+// Every applied realm, space, stack and cell records the manifest under the
+// `kukeon.io/last-applied-configuration` annotation, together with
+// fieldManager (consts.KukeonDefaultFieldManager when empty). The next apply
+// three-way merges labels and annotations against that record, so keys
+// dropped from the manifest are deleted while keys another writer added
+// are kept.
+//
+// A realm, space, stack or cell this call created is deleted again when a
+// document under it failed and nothing under it was applied, so a cell that
+// fails no longer leaves the stack created for it behind. Its result then
+// reports "rolled back" instead of "created".
+func (b *Exec) ApplyDocuments(docs []parser.Document, team, fieldManager string) (ApplyResult, error) {
 	result := ApplyResult{
 		Resources: make([]ResourceResult, 0, len(docs)),
 	}
@@ -114,232 +131,455 @@ func (b *Exec) ApplyDocuments(docs []parser.Document, team string) (ApplyResult,
 	// daemon objects that fell out of the applied set.
 	var appliedBlueprints, appliedConfigs []scopedRef
 
+	if fieldManager == "" {
+		fieldManager = consts.KukeonDefaultFieldManager
+	}
+
 	// Sort documents by dependency order
 	sortedDocs := SortDocumentsByKind(docs, false)
 
 	// Apply each document in order
 	for _, doc := range sortedDocs {
-		resourceResult := ResourceResult{
-			Index:   doc.Index,
-			Kind:    string(doc.Kind),
-			Details: make(map[string]string),
+		resourceResult := b.applyDocument(doc, team, fieldManager)
+		if resourceResult.Action != actionFailed {
+			if doc.Kind == v1beta1.KindCellBlueprint {
+				md := doc.CellBlueprintDoc.Metadata
+				appliedBlueprints = append(appliedBlueprints, scopedRefFromMetadata(md.Name, md.Realm, md.Space, md.Stack))
+			} else if doc.Kind == v1beta1.KindCellConfig {
+				md := doc.CellConfigDoc.Metadata
+				appliedConfigs = append(appliedConfigs, scopedRefFromMetadata(md.Name, md.Realm, md.Space, md.Stack))
+			}
 		}
+		result.Resources = append(result.Resources, resourceResult)
+	}
 
-		// Convert to internal model and reconcile
-		var reconcileResult applypkg.ReconcileResult
-		var reconcileErr error
+	b.rollbackApply(sortedDocs, result.Resources)
 
-		switch doc.Kind {
-		case v1beta1.KindRealm:
-			if doc.RealmDoc == nil {
-				resourceResult.Action = actionFailed
-				resourceResult.Error = errors.New("realm document is nil")
-				result.Resources = append(result.Resources, resourceResult)
-				continue
-			}
-			realm, _, err := apischeme.NormalizeRealm(*doc.RealmDoc)
-			if err != nil {
-				resourceResult.Action = actionFailed
-				resourceResult.Error = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
-				result.Resources = append(result.Resources, resourceResult)
-				continue
-			}
-			resourceResult.Name = realm.Metadata.Name
-			reconcileResult, reconcileErr = applypkg.ReconcileRealm(b.runner, realm)
+	if team != "" {
+		pruneResults, pruneErr := b.pruneTeamObjects(team, appliedBlueprints, appliedConfigs)
+		if pruneErr != nil {
+			return result, pruneErr
+		}
+		result.Resources = append(result.Resources, pruneResults...)
+	}
 
-		case v1beta1.KindSpace:
-			if doc.SpaceDoc == nil {
-				resourceResult.Action = actionFailed
-				resourceResult.Error = errors.New("space document is nil")
-				result.Resources = append(result.Resources, resourceResult)
-				continue
-			}
-			space, _, err := apischeme.NormalizeSpace(*doc.SpaceDoc)
-			if err != nil {
-				resourceResult.Action = actionFailed
-				resourceResult.Error = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
-				result.Resources = append(result.Resources, resourceResult)
-				continue
-			}
-			resourceResult.Name = space.Metadata.Name
-			reconcileResult, reconcileErr = applypkg.ReconcileSpace(b.runner, space)
+	return result, nil
+}
 
-		case v1beta1.KindStack:
-			if doc.StackDoc == nil {
-				resourceResult.Action = actionFailed
-				resourceResult.Error = errors.New("stack document is nil")
-				result.Resources = append(result.Resources, resourceResult)
+// rollbackApply deletes the realms, spaces, stacks and cells that this apply
+// created but that hold a failed document and no applied one. results is
+// parallel to docs. The walk runs newest first, so a cell is rolled back
+// before its stack is judged, and a scope emptied that way follows it. A
+// rollback that fails is logged and leaves the result as "created".
+func (b *Exec) rollbackApply(docs []parser.Document, results []ResourceResult) {
+	scopes := make([][]string, len(docs))
+	for i, doc := range docs {
+		scopes[i] = documentScope(doc)
+	}
+	for i := len(docs) - 1; i >= 0; i-- {
+		if results[i].Action != actionCreated || !isHierarchyKind(docs[i].Kind) || scopes[i] == nil {
+			continue
+		}
+		failedUnder, keptUnder := false, false
+		for j := range docs {
+			if j == i || !scopeUnder(scopes[j], scopes[i]) {
 				continue
 			}
-			stack, _, err := apischeme.NormalizeStack(*doc.StackDoc)
-			if err != nil {
-				resourceResult.Action = actionFailed
-				resourceResult.Error = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
-				result.Resources = append(result.Resources, resourceResult)
-				continue
+			switch results[j].Action {
+			case actionFailed:
+				failedUnder = true
+			case actionRolledBack:
+			default:
+				keptUnder = true
 			}
-			resourceResult.Name = stack.Metadata.Name
-			reconcileResult, reconcileErr = applypkg.ReconcileStack(b.runner, stack)
+		}
+		if !failedUnder || keptUnder {
+			continue
+		}
+		res := b.rollbackDocument(docs[i])
+		if res.Action == actionFailed {
+			b.logger.ErrorContext(b.ctx, "failed to roll back applied resource",
+				"kind", res.Kind, "name", res.Name, "error", res.Error)
+			continue
+		}
+		results[i].Action = actionRolledBack
+	}
+}
 
-		case v1beta1.KindCell:
-			if doc.CellDoc == nil {
-				resourceResult.Action = actionFailed
-				resourceResult.Error = errors.New("cell document is nil")
-				result.Resources = append(result.Resources, resourceResult)
-				continue
-			}
-			cell, _, err := apischeme.NormalizeCell(*doc.CellDoc)
-			if err != nil {
-				resourceResult.Action = actionFailed
-				resourceResult.Error = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
-				result.Resources = append(result.Resources, resourceResult)
-				continue
-			}
-			resourceResult.Name = cell.Metadata.Name
-			reconcileResult, reconcileErr = applypkg.ReconcileCell(b.runner, cell)
+func isHierarchyKind(kind v1beta1.Kind) bool {
+	switch kind {
+	case v1beta1.KindRealm, v1beta1.KindSpace, v1beta1.KindStack, v1beta1.KindCell:
+		return true
+	default:
+		return false
+	}
+}
 
-		case v1beta1.KindContainer:
-			if doc.ContainerDoc == nil {
-				resourceResult.Action = actionFailed
-				resourceResult.Error = errors.New("container document is nil")
-				result.Resources = append(result.Resources, resourceResult)
-				continue
-			}
-			container, _, err := apischeme.NormalizeContainer(*doc.ContainerDoc)
-			if err != nil {
-				resourceResult.Action = actionFailed
-				resourceResult.Error = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
-				result.Resources = append(result.Resources, resourceResult)
-				continue
-			}
-			resourceResult.Name = container.Metadata.Name
-			reconcileResult, reconcileErr = applypkg.ReconcileContainer(b.runner, container)
+// documentScope returns the realm → space → stack → cell names a document
+// lives at, ending with its own name for the hierarchy kinds and with its
+// target scope for the others. Names are taken after normalization, so an
+// omitted realm resolves the same way apply resolved it. nil means the
+// document could not be placed.
+func documentScope(doc parser.Document) []string {
+	if !documentSet(doc) {
+		return nil
+	}
+	switch doc.Kind {
+	case v1beta1.KindRealm:
+		realm, _, err := apischeme.NormalizeRealm(*doc.RealmDoc)
+		if err != nil {
+			return nil
+		}
+		return []string{realm.Metadata.Name}
+	case v1beta1.KindSpace:
+		space, _, err := apischeme.NormalizeSpace(*doc.SpaceDoc)
+		if err != nil {
+			return nil
+		}
+		return []string{space.Spec.RealmName, space.Metadata.Name}
+	case v1beta1.KindStack:
+		stack, _, err := apischeme.NormalizeStack(*doc.StackDoc)
+		if err != nil {
+			return nil
+		}
+		return []string{stack.Spec.RealmName, stack.Spec.SpaceName, stack.Metadata.Name}
+	case v1beta1.KindCell:
+		cell, _, err := apischeme.NormalizeCell(*doc.CellDoc)
+		if err != nil {
+			return nil
+		}
+		return []string{cell.Spec.RealmName, cell.Spec.SpaceName, cell.Spec.StackName, cell.Metadata.Name}
+	case v1beta1.KindContainer:
+		container, _, err := apischeme.NormalizeContainer(*doc.ContainerDoc)
+		if err != nil {
+			return nil
+		}
+		return []string{
+			container.Spec.RealmName, container.Spec.SpaceName, container.Spec.StackName, container.Spec.CellName,
+		}
+	case v1beta1.KindSecret:
+		md := doc.SecretDoc.Metadata
+		return trimScope(md.Realm, md.Space, md.Stack, md.Cell)
+	case v1beta1.KindCellBlueprint:
+		md := doc.CellBlueprintDoc.Metadata
+		return trimScope(md.Realm, md.Space, md.Stack)
+	case v1beta1.KindCellConfig:
+		md := doc.CellConfigDoc.Metadata
+		return trimScope(md.Realm, md.Space, md.Stack)
+	case v1beta1.KindVolume:
+		md := doc.VolumeDoc.Metadata
+		return trimScope(md.Realm, md.Space, md.Stack)
+	default:
+		return nil
+	}
+}
 
-		case v1beta1.KindSecret:
-			if doc.SecretDoc == nil {
-				resourceResult.Action = actionFailed
-				resourceResult.Error = errors.New("secret document is nil")
-				result.Resources = append(result.Resources, resourceResult)
-				continue
-			}
-			secret, _, err := apischeme.NormalizeSecret(*doc.SecretDoc)
+// documentSet reports whether the typed document for doc.Kind is present;
+// applyDocument fails a nil one without touching anything.
+func documentSet(doc parser.Document) bool {
+	switch doc.Kind {
+	case v1beta1.KindRealm:
+		return doc.RealmDoc != nil
+	case v1beta1.KindSpace:
+		return doc.SpaceDoc != nil
+	case v1beta1.KindStack:
+		return doc.StackDoc != nil
+	case v1beta1.KindCell:
+		return doc.CellDoc != nil
+	case v1beta1.KindContainer:
+		return doc.ContainerDoc != nil
+	case v1beta1.KindSecret:
+		return doc.SecretDoc != nil
+	case v1beta1.KindCellBlueprint:
+		return doc.CellBlueprintDoc != nil
+	case v1beta1.KindCellConfig:
+		return doc.CellConfigDoc != nil
+	case v1beta1.KindVolume:
+		return doc.VolumeDoc != nil
+	default:
+		return false
+	}
+}
+
+// trimScope drops the unset trailing names of a scope-targeting document.
+func trimScope(names ...string) []string {
+	for len(names) > 0 && names[len(names)-1] == "" {
+		names = names[:len(names)-1]
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return names
+}
+
+// scopeUnder reports whether scope lies at or below parent.
+func scopeUnder(scope, parent []string) bool {
+	if len(scope) < len(parent) {
+		return false
+	}
+	for i := range parent {
+		if scope[i] != parent[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// applyDocument converts one parsed document to its internal model and
+// reconciles it, reporting the outcome as a ResourceResult. A non-empty team
+// is stamped on CellBlueprint / CellConfig labels before persistence. A
+// non-empty fieldManager stamps the last-applied configuration on realms,
+// spaces, stacks and cells; patch passes none, so a patched resource keeps
+// the record of the last apply.
+func (b *Exec) applyDocument(doc parser.Document, team, fieldManager string) ResourceResult {
+	resourceResult := ResourceResult{
+		Index:   doc.Index,
+		Kind:    string(doc.Kind),
+		Details: make(map[string]string),
+	}
+
+	// event names the realm, space, stack or cell being applied once it is
+	// known; a change to one of them is recorded in the event log.
+	var event events.Event
+
+	ctx, span := tracing.Start(b.ctx, "controller.Apply", tracing.AttrKind.String(string(doc.Kind)))
+	defer func() {
+		span.SetAttributes(tracing.AttrName.String(resourceResult.Name))
+		tracing.End(span, resourceResult.Error)
+		b.recordApplyEvent(event, resourceResult)
+	}()
+
+	// Convert to internal model and reconcile
+	var reconcileResult applypkg.ReconcileResult
+	var reconcileErr error
+
+	switch doc.Kind {
+	case v1beta1.KindRealm:
+		if doc.RealmDoc == nil {
+			resourceResult.Action = actionFailed
+			resourceResult.Error = errors.New("realm document is nil")
+			return resourceResult
+		}
+		realm, _, err := apischeme.NormalizeRealm(*doc.RealmDoc)
+		if err != nil {
+			resourceResult.Action = actionFailed
+			resourceResult.Error = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+			return resourceResult
+		}
+		resourceResult.Name = realm.Metadata.Name
+		event = realmEvent(realm)
+		if fieldManager != "" {
+			realm.Metadata.Annotations, err = stampLastApplied(*doc.RealmDoc, realm.Metadata.Annotations, fieldManager)
 			if err != nil {
 				resourceResult.Action = actionFailed
 				resourceResult.Error = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
-				result.Resources = append(result.Resources, resourceResult)
-				continue
+				return resourceResult
 			}
-			resourceResult.Name = secret.Metadata.Name
-			reconcileResult, reconcileErr = applypkg.ReconcileSecret(b.runner, secret)
+		}
+		reconcileResult, reconcileErr = applypkg.ReconcileRealm(b.runner, realm)
 
-		case v1beta1.KindCellBlueprint:
-			if doc.CellBlueprintDoc == nil {
-				resourceResult.Action = actionFailed
-				resourceResult.Error = errors.New("blueprint document is nil")
-				result.Resources = append(result.Resources, resourceResult)
-				continue
-			}
-			bpDoc := *doc.CellBlueprintDoc
-			if team != "" {
-				bpDoc.Metadata.Labels = stampTeamLabel(bpDoc.Metadata.Labels, team)
-			}
-			blueprint, _, err := apischeme.NormalizeCellBlueprint(bpDoc)
+	case v1beta1.KindSpace:
+		if doc.SpaceDoc == nil {
+			resourceResult.Action = actionFailed
+			resourceResult.Error = errors.New("space document is nil")
+			return resourceResult
+		}
+		space, _, err := apischeme.NormalizeSpace(*doc.SpaceDoc)
+		if err != nil {
+			resourceResult.Action = actionFailed
+			resourceResult.Error = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+			return resourceResult
+		}
+		resourceResult.Name = space.Metadata.Name
+		event = spaceEvent(space)
+		if fieldManager != "" {
+			space.Metadata.Annotations, err = stampLastApplied(*doc.SpaceDoc, space.Metadata.Annotations, fieldManager)
 			if err != nil {
 				resourceResult.Action = actionFailed
 				resourceResult.Error = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
-				result.Resources = append(result.Resources, resourceResult)
-				continue
-			}
-			resourceResult.Name = blueprint.Metadata.Name
-			reconcileResult, reconcileErr = applypkg.ReconcileBlueprint(b.runner, blueprint)
-			if reconcileErr == nil {
-				appliedBlueprints = append(appliedBlueprints, scopedRefFromMetadata(
-					blueprint.Metadata.Name,
-					blueprint.Metadata.Realm,
-					blueprint.Metadata.Space,
-					blueprint.Metadata.Stack,
-				))
+				return resourceResult
 			}
+		}
+		reconcileResult, reconcileErr = applypkg.ReconcileSpace(b.runner, space)
 
-		case v1beta1.KindCellConfig:
-			if doc.CellConfigDoc == nil {
-				resourceResult.Action = actionFailed
-				resourceResult.Error = errors.New("config document is nil")
-				result.Resources = append(result.Resources, resourceResult)
-				continue
-			}
-			cfgDoc := *doc.CellConfigDoc
-			if team != "" {
-				cfgDoc.Metadata.Labels = stampTeamLabel(cfgDoc.Metadata.Labels, team)
-			}
-			config, _, err := apischeme.NormalizeCellConfig(cfgDoc)
+	case v1beta1.KindStack:
+		if doc.StackDoc == nil {
+			resourceResult.Action = actionFailed
+			resourceResult.Error = errors.New("stack document is nil")
+			return resourceResult
+		}
+		stack, _, err := apischeme.NormalizeStack(*doc.StackDoc)
+		if err != nil {
+			resourceResult.Action = actionFailed
+			resourceResult.Error = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+			return resourceResult
+		}
+		resourceResult.Name = stack.Metadata.Name
+		event = stackEvent(stack)
+		if fieldManager != "" {
+			stack.Metadata.Annotations, err = stampLastApplied(*doc.StackDoc, stack.Metadata.Annotations, fieldManager)
 			if err != nil {
 				resourceResult.Action = actionFailed
 				resourceResult.Error = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
-				result.Resources = append(result.Resources, resourceResult)
-				continue
-			}
-			resourceResult.Name = config.Metadata.Name
-			reconcileResult, reconcileErr = applypkg.ReconcileConfig(b.runner, config)
-			if reconcileErr == nil {
-				appliedConfigs = append(appliedConfigs, scopedRefFromMetadata(
-					config.Metadata.Name,
-					config.Metadata.Realm,
-					config.Metadata.Space,
-					config.Metadata.Stack,
-				))
+				return resourceResult
 			}
+		}
+		reconcileResult, reconcileErr = applypkg.ReconcileStack(b.runner, stack)
 
-		case v1beta1.KindVolume:
-			if doc.VolumeDoc == nil {
-				resourceResult.Action = actionFailed
-				resourceResult.Error = errors.New("volume document is nil")
-				result.Resources = append(result.Resources, resourceResult)
-				continue
-			}
-			// A Volume is deliberately not team-label-stamped or pruned: it is
-			// decoupled from any cell and outlives the apply that created it, so
-			// `kuke apply --prune` removing a volume would wipe persistent data.
-			// Volume reclaim is owning-scope cascade purge only (#1018); the
-			// `kukeon.io/team` lifecycle stays with the blueprint/config kinds.
-			volume, _, err := apischeme.NormalizeVolume(*doc.VolumeDoc)
+	case v1beta1.KindCell:
+		if doc.CellDoc == nil {
+			resourceResult.Action = actionFailed
+			resourceResult.Error = errors.New("cell document is nil")
+			return resourceResult
+		}
+		cell, _, err := apischeme.NormalizeCell(*doc.CellDoc)
+		if err != nil {
+			resourceResult.Action = actionFailed
+			resourceResult.Error = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+			return resourceResult
+		}
+		resourceResult.Name = cell.Metadata.Name
+		event = cellEvent(cell)
+		if fieldManager != "" {
+			// The parent wait and prune ride the document for this
+			// invocation only; they are not part of the manifest the record
+			// captures.
+			manifest := *doc.CellDoc
+			manifest.Spec.WaitForParentSeconds = 0
+			manifest.Spec.PruneContainers = false
+			cell.Metadata.Annotations, err = stampLastApplied(manifest, cell.Metadata.Annotations, fieldManager)
 			if err != nil {
 				resourceResult.Action = actionFailed
 				resourceResult.Error = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
-				result.Resources = append(result.Resources, resourceResult)
-				continue
+				return resourceResult
 			}
-			resourceResult.Name = volume.Metadata.Name
-			reconcileResult, reconcileErr = applypkg.ReconcileVolume(b.runner, volume)
+		}
+		if err = b.ValidateCell(cell); err != nil {
+			resourceResult.Action = actionFailed
+			resourceResult.Error = err
+			return resourceResult
+		}
+		err = b.withCellQuota(cell, func() error {
+			reconcileResult, reconcileErr = applypkg.ReconcileCell(ctx, b.runner, cell)
+			return nil
+		})
+		if err != nil {
+			resourceResult.Action = actionFailed
+			resourceResult.Error = err
+			return resourceResult
+		}
 
-		default:
+	case v1beta1.KindContainer:
+		if doc.ContainerDoc == nil {
 			resourceResult.Action = actionFailed
-			resourceResult.Error = fmt.Errorf("%w: %s", errdefs.ErrUnknownKind, doc.Kind)
-			result.Resources = append(result.Resources, resourceResult)
-			continue
+			resourceResult.Error = errors.New("container document is nil")
+			return resourceResult
+		}
+		container, _, err := apischeme.NormalizeContainer(*doc.ContainerDoc)
+		if err != nil {
+			resourceResult.Action = actionFailed
+			resourceResult.Error = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+			return resourceResult
+		}
+		resourceResult.Name = container.Metadata.Name
+		err = b.withContainerQuota(container, func() error {
+			reconcileResult, reconcileErr = applypkg.ReconcileContainer(ctx, b.runner, container)
+			return nil
+		})
+		if err != nil {
+			resourceResult.Action = actionFailed
+			resourceResult.Error = err
+			return resourceResult
 		}
 
-		if reconcileErr != nil {
+	case v1beta1.KindSecret:
+		if doc.SecretDoc == nil {
+			resourceResult.Action = actionFailed
+			resourceResult.Error = errors.New("secret document is nil")
+			return resourceResult
+		}
+		secret, _, err := apischeme.NormalizeSecret(*doc.SecretDoc)
+		if err != nil {
 			resourceResult.Action = actionFailed
-			resourceResult.Error = reconcileErr
-		} else {
-			resourceResult.Action = reconcileResult.Action
-			resourceResult.Changes = reconcileResult.Changes
-			resourceResult.Details = reconcileResult.Details
+			resourceResult.Error = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+			return resourceResult
 		}
+		resourceResult.Name = secret.Metadata.Name
+		reconcileResult, reconcileErr = applypkg.ReconcileSecret(b.runner, secret)
 
-		result.Resources = append(result.Resources, resourceResult)
-	}
+	case v1beta1.KindCellBlueprint:
+		if doc.CellBlueprintDoc == nil {
+			resourceResult.Action = actionFailed
+			resourceResult.Error = errors.New("blueprint document is nil")
+			return resourceResult
+		}
+		bpDoc := *doc.CellBlueprintDoc
+		if team != "" {
+			bpDoc.Metadata.Labels = stampTeamLabel(bpDoc.Metadata.Labels, team)
+		}
+		blueprint, _, err := apischeme.NormalizeCellBlueprint(bpDoc)
+		if err != nil {
+			resourceResult.Action = actionFailed
+			resourceResult.Error = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+			return resourceResult
+		}
+		resourceResult.Name = blueprint.Metadata.Name
+		reconcileResult, reconcileErr = applypkg.ReconcileBlueprint(b.runner, blueprint)
 
-	if team != "" {
-		pruneResults, pruneErr := b.pruneTeamObjects(team, appliedBlueprints, appliedConfigs)
-		if pruneErr != nil {
-			return result, pruneErr
+	case v1beta1.KindCellConfig:
+		if doc.CellConfigDoc == nil {
+			resourceResult.Action = actionFailed
+			resourceResult.Error = errors.New("config document is nil")
+			return resourceResult
 		}
-		result.Resources = append(result.Resources, pruneResults...)
+		cfgDoc := *doc.CellConfigDoc
+		if team != "" {
+			cfgDoc.Metadata.Labels = stampTeamLabel(cfgDoc.Metadata.Labels, team)
+		}
+		config, _, err := apischeme.NormalizeCellConfig(cfgDoc)
+		if err != nil {
+			resourceResult.Action = actionFailed
+			resourceResult.Error = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+			return resourceResult
+		}
+		resourceResult.Name = config.Metadata.Name
+		reconcileResult, reconcileErr = applypkg.ReconcileConfig(b.runner, config)
+
+	case v1beta1.KindVolume:
+		if doc.VolumeDoc == nil {
+			resourceResult.Action = actionFailed
+			resourceResult.Error = errors.New("volume document is nil")
+			return resourceResult
+		}
+		// A Volume is deliberately not team-label-stamped or pruned: it is
+		// decoupled from any cell and outlives the apply that created it, so
+		// `kuke apply --prune` removing a volume would wipe persistent data.
+		// Volume reclaim is owning-scope cascade purge only (#1018); the
+		// `kukeon.io/team` lifecycle stays with the blueprint/config kinds.
+		volume, _, err := apischeme.NormalizeVolume(*doc.VolumeDoc)
+		if err != nil {
+			resourceResult.Action = actionFailed
+			resourceResult.Error = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+			return resourceResult
+		}
+		resourceResult.Name = volume.Metadata.Name
+		reconcileResult, reconcileErr = applypkg.ReconcileVolume(b.runner, volume)
+
+	default:
+		resourceResult.Action = actionFailed
+		resourceResult.Error = fmt.Errorf("%w: %s", errdefs.ErrUnknownKind, doc.Kind)
+		return resourceResult
 	}
 
-	return result, nil
+	if reconcileErr != nil {
+		resourceResult.Action = actionFailed
+		resourceResult.Error = reconcileErr
+	} else {
+		resourceResult.Action = reconcileResult.Action
+		resourceResult.Changes = reconcileResult.Changes
+		resourceResult.Details = reconcileResult.Details
+	}
+	return resourceResult
 }
 
 // scopedRef identifies one Blueprint or Config by its scope-coordinate tuple
@@ -18,13 +18,17 @@ package apply
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
 
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 )
 
 const (
-	labelsChangedMsg = "labels changed"
+	labelsChangedMsg      = "labels changed"
+	annotationsChangedMsg = "annotations changed"
 )
 
 // ChangeType classifies the type of change detected.
@@ -111,6 +115,16 @@ func DiffRealm(desired, actual intmodel.Realm) DiffResult {
 		result.Details["metadata.labels"] = labelsChangedMsg
 	}
 
+	// Compatible changes: annotations. Non-identifying, so only persisted.
+	if annotationsDiffer(desired.Metadata.Annotations, actual.Metadata.Annotations) {
+		result.HasChanges = true
+		if result.ChangeType == ChangeTypeNone {
+			result.ChangeType = ChangeTypeCompatible
+		}
+		result.ChangedFields = append(result.ChangedFields, "metadata.annotations")
+		result.Details["metadata.annotations"] = annotationsChangedMsg
+	}
+
 	// Registry credentials changes are compatible
 	if !registryCredentialsEqual(desired.Spec.RegistryCredentials, actual.Spec.RegistryCredentials) {
 		result.HasChanges = true
@@ -121,6 +135,63 @@ func DiffRealm(desired, actual intmodel.Realm) DiffResult {
 		result.Details["spec.registryCredentials"] = "registry credentials changed"
 	}
 
+	// The realm snapshotter is a default for containers created afterwards;
+	// existing containers keep the snapshotter they were created on.
+	if desired.Spec.Snapshotter != actual.Spec.Snapshotter {
+		result.HasChanges = true
+		if result.ChangeType == ChangeTypeNone {
+			result.ChangeType = ChangeTypeCompatible
+		}
+		result.ChangedFields = append(result.ChangedFields, "spec.snapshotter")
+		result.Details["spec.snapshotter"] = fmt.Sprintf("snapshotter changed from %q to %q",
+			actual.Spec.Snapshotter, desired.Spec.Snapshotter)
+	}
+
+	// Like the snapshotter, the pause image only applies to cell root
+	// containers created afterwards.
+	if desired.Spec.PauseImage != actual.Spec.PauseImage {
+		result.HasChanges = true
+		if result.ChangeType == ChangeTypeNone {
+			result.ChangeType = ChangeTypeCompatible
+		}
+		result.ChangedFields = append(result.ChangedFields, "spec.pauseImage")
+		result.Details["spec.pauseImage"] = fmt.Sprintf("pause image changed from %q to %q",
+			actual.Spec.PauseImage, desired.Spec.PauseImage)
+	}
+
+	// Default cell limits apply to cell cgroups created afterwards.
+	if !resourcesEqual(desired.Spec.DefaultResources, actual.Spec.DefaultResources) {
+		result.HasChanges = true
+		if result.ChangeType == ChangeTypeNone {
+			result.ChangeType = ChangeTypeCompatible
+		}
+		result.ChangedFields = append(result.ChangedFields, "spec.defaultResources")
+		result.Details["spec.defaultResources"] = "default cell resources changed"
+	}
+
+	// The privileged policy is checked when a cell is created or applied;
+	// running containers are left alone either way.
+	if desired.Spec.AllowPrivileged != actual.Spec.AllowPrivileged {
+		result.HasChanges = true
+		if result.ChangeType == ChangeTypeNone {
+			result.ChangeType = ChangeTypeCompatible
+		}
+		result.ChangedFields = append(result.ChangedFields, "spec.allowPrivileged")
+		result.Details["spec.allowPrivileged"] = fmt.Sprintf("allowPrivileged changed from %v to %v",
+			actual.Spec.AllowPrivileged, desired.Spec.AllowPrivileged)
+	}
+
+	// Like the privileged policy, the quota is checked when a cell or
+	// container is created; existing cells are never evicted.
+	if !quotaEqual(desired.Spec.Quota, actual.Spec.Quota) {
+		result.HasChanges = true
+		if result.ChangeType == ChangeTypeNone {
+			result.ChangeType = ChangeTypeCompatible
+		}
+		result.ChangedFields = append(result.ChangedFields, "spec.quota")
+		result.Details["spec.quota"] = "resource quota changed"
+	}
+
 	return result
 }
 
@@ -178,6 +249,16 @@ func DiffSpace(desired, actual intmodel.Space) DiffResult {
 		result.Details["metadata.labels"] = labelsChangedMsg
 	}
 
+	// Compatible changes: annotations. Non-identifying, so only persisted.
+	if annotationsDiffer(desired.Metadata.Annotations, actual.Metadata.Annotations) {
+		result.HasChanges = true
+		if result.ChangeType == ChangeTypeNone {
+			result.ChangeType = ChangeTypeCompatible
+		}
+		result.ChangedFields = append(result.ChangedFields, "metadata.annotations")
+		result.Details["metadata.annotations"] = annotationsChangedMsg
+	}
+
 	// Compatible changes: spec.defaults.container. Inheritance is computed
 	// at container-create/update time, so changing defaults is non-breaking
 	// for already-running containers — only new or updated containers pick
@@ -191,6 +272,26 @@ func DiffSpace(desired, actual intmodel.Space) DiffResult {
 		result.Details["spec.defaults.container"] = "container defaults changed"
 	}
 
+	// Like the realm's, the space's default cell limits apply to cell
+	// cgroups created afterwards.
+	if !resourcesEqual(desired.Spec.DefaultResources, actual.Spec.DefaultResources) {
+		result.HasChanges = true
+		if result.ChangeType == ChangeTypeNone {
+			result.ChangeType = ChangeTypeCompatible
+		}
+		result.ChangedFields = append(result.ChangedFields, "spec.defaultResources")
+		result.Details["spec.defaultResources"] = "default cell resources changed"
+	}
+
+	if !quotaEqual(desired.Spec.Quota, actual.Spec.Quota) {
+		result.HasChanges = true
+		if result.ChangeType == ChangeTypeNone {
+			result.ChangeType = ChangeTypeCompatible
+		}
+		result.ChangedFields = append(result.ChangedFields, "spec.quota")
+		result.Details["spec.quota"] = "resource quota changed"
+	}
+
 	return result
 }
 
@@ -247,6 +348,16 @@ func DiffStack(desired, actual intmodel.Stack) DiffResult {
 		result.Details["metadata.labels"] = "labels changed"
 	}
 
+	// Compatible changes: annotations. Non-identifying, so only persisted.
+	if annotationsDiffer(desired.Metadata.Annotations, actual.Metadata.Annotations) {
+		result.HasChanges = true
+		if result.ChangeType == ChangeTypeNone {
+			result.ChangeType = ChangeTypeCompatible
+		}
+		result.ChangedFields = append(result.ChangedFields, "metadata.annotations")
+		result.Details["metadata.annotations"] = annotationsChangedMsg
+	}
+
 	if desired.Spec.ID != "" && desired.Spec.ID != actual.Spec.ID {
 		result.HasChanges = true
 		if result.ChangeType == ChangeTypeNone {
@@ -368,6 +479,17 @@ func DiffCell(desired, actual intmodel.Cell) CellDiffResult {
 		)
 	}
 
+	// Compatible: Lifecycle. The runner reads the hooks from the stored cell
+	// on each start and stop, so an edit only needs persisting.
+	if !cellLifecycleEqual(desired.Spec.Lifecycle, actual.Spec.Lifecycle) {
+		result.HasChanges = true
+		if result.ChangeType == ChangeTypeNone {
+			result.ChangeType = ChangeTypeCompatible
+		}
+		result.ChangedFields = append(result.ChangedFields, "spec.lifecycle")
+		result.Details["spec.lifecycle"] = "lifecycle hooks changed"
+	}
+
 	// Breaking: NestedCgroupRuntime. Flipping the flag re-runs the
 	// EnableCellAllSubtreeControllers delegation (#318) and recomputes the
 	// in-container /sys/fs/cgroup mount per BuildContainerSpec; the namespace
@@ -384,6 +506,16 @@ func DiffCell(desired, actual intmodel.Cell) CellDiffResult {
 		)
 	}
 
+	// Breaking: Bandwidth. The limits are handed to the CNI bandwidth plugin
+	// when the root container is attached, so new limits only take effect on
+	// a fresh CNI ADD — RecreateCell re-attaches the cell.
+	if !cellBandwidthEqual(desired.Spec.Bandwidth, actual.Spec.Bandwidth) {
+		result.HasChanges = true
+		result.ChangeType = ChangeTypeBreaking
+		result.BreakingChanges = append(result.BreakingChanges, "spec.bandwidth")
+		result.Details["spec.bandwidth"] = "bandwidth limits changed (breaking)"
+	}
+
 	// Find root container in desired and actual
 	desiredRoot := findRootContainer(desired.Spec.Containers)
 	actualRoot := findRootContainer(actual.Spec.Containers)
@@ -624,6 +756,24 @@ func diffContainerSpec(desired, actual *intmodel.ContainerSpec, rootContainer bo
 		recordSpecFieldChange(&result, rootContainer, true, "image",
 			fmt.Sprintf("image changed from %q to %q", actual.Image, desired.Image))
 	}
+	// platform — same classification as image: the rootfs is unpacked
+	// from the selected platform's manifest at create.
+	if desired.Platform != actual.Platform {
+		recordSpecFieldChange(&result, rootContainer, true, "platform",
+			fmt.Sprintf("platform changed from %q to %q", actual.Platform, desired.Platform))
+	}
+	// snapshotter — same classification as image: the rootfs snapshot is
+	// prepared on the snapshotter at create time.
+	if desired.Snapshotter != actual.Snapshotter {
+		recordSpecFieldChange(&result, rootContainer, true, "snapshotter",
+			fmt.Sprintf("snapshotter changed from %q to %q", actual.Snapshotter, desired.Snapshotter))
+	}
+	// diskQuota — also a property of the rootfs snapshot, fixed at create.
+	if diskQuotaBytes(desired.DiskQuota) != diskQuotaBytes(actual.DiskQuota) {
+		recordSpecFieldChange(&result, rootContainer, true, "diskQuota",
+			fmt.Sprintf("diskQuota changed from %d to %d bytes",
+				diskQuotaBytes(actual.DiskQuota), diskQuotaBytes(desired.DiskQuota)))
+	}
 	if desired.Command != actual.Command {
 		recordSpecFieldChange(&result, rootContainer, true, "command",
 			fmt.Sprintf("command changed from %q to %q", actual.Command, desired.Command))
@@ -670,6 +820,14 @@ func diffContainerSpec(desired, actual *intmodel.ContainerSpec, rootContainer bo
 			fmt.Sprintf("user changed from %q to %q", actual.User, desired.User))
 	}
 
+	// supplementaryGroups — Breaking on root (OCI Process.user.additionalGids
+	// is fixed at create). Compatible on non-root.
+	if !slices.Equal(desired.SupplementaryGroups, actual.SupplementaryGroups) {
+		recordSpecFieldChange(&result, rootContainer, true, "supplementaryGroups",
+			fmt.Sprintf("supplementaryGroups changed from %v to %v",
+				actual.SupplementaryGroups, desired.SupplementaryGroups))
+	}
+
 	// readOnlyRootFilesystem — Breaking on root (OCI Root.readonly is
 	// fixed at create). Compatible on non-root.
 	if desired.ReadOnlyRootFilesystem != actual.ReadOnlyRootFilesystem {
@@ -693,6 +851,52 @@ func diffContainerSpec(desired, actual *intmodel.ContainerSpec, rootContainer bo
 		recordSpecFieldChange(&result, rootContainer, true, "securityOpts", "securityOpts changed")
 	}
 
+	// seccompProfile — Breaking on root, for the same reason as
+	// securityOpts: the profile is loaded into the cell root's OCI
+	// Linux.Seccomp at StartCell. Compatible on non-root.
+	if desired.SeccompProfile != actual.SeccompProfile {
+		recordSpecFieldChange(&result, rootContainer, true, "seccompProfile",
+			fmt.Sprintf("seccompProfile changed from %q to %q", actual.SeccompProfile, desired.SeccompProfile))
+	}
+
+	// appArmorProfile — Breaking on root: it bakes into the cell root's
+	// OCI Process.ApparmorProfile at StartCell. Compatible on non-root.
+	if desired.AppArmorProfile != actual.AppArmorProfile {
+		recordSpecFieldChange(&result, rootContainer, true, "appArmorProfile",
+			fmt.Sprintf("appArmorProfile changed from %q to %q", actual.AppArmorProfile, desired.AppArmorProfile))
+	}
+
+	// noNewPrivileges — Breaking on root, for the same reason as
+	// securityOpts: it bakes into the cell root's OCI Process at StartCell.
+	// Compatible on non-root.
+	if desired.NoNewPrivileges != actual.NoNewPrivileges {
+		recordSpecFieldChange(&result, rootContainer, true, "noNewPrivileges",
+			fmt.Sprintf("noNewPrivileges changed from %v to %v", actual.NoNewPrivileges, desired.NoNewPrivileges))
+	}
+
+	// sysctls — Breaking on root (OCI Linux.Sysctl is applied when the
+	// root task creates the cell's namespaces). Compatible on non-root.
+	if !maps.Equal(desired.Sysctls, actual.Sysctls) {
+		recordSpecFieldChange(&result, rootContainer, true, "sysctls", "sysctls changed")
+	}
+
+	// oomScoreAdj — Breaking on root (OCI Process.OOMScoreAdj is fixed when
+	// the task is created). Compatible on non-root.
+	if !intPtrEqual(desired.OOMScoreAdj, actual.OOMScoreAdj) {
+		recordSpecFieldChange(&result, rootContainer, true, "oomScoreAdj",
+			fmt.Sprintf("oomScoreAdj changed from %s to %s",
+				formatIntPtr(actual.OOMScoreAdj), formatIntPtr(desired.OOMScoreAdj)))
+	}
+
+	// terminationMessagePath — Compatible everywhere: the root container gets
+	// no termination-log mount, and UpdateCell recreates a non-root child to
+	// move it.
+	if desired.TerminationMessagePath != actual.TerminationMessagePath {
+		recordSpecFieldChange(&result, rootContainer, false, "terminationMessagePath",
+			fmt.Sprintf("terminationMessagePath changed from %q to %q",
+				actual.TerminationMessagePath, desired.TerminationMessagePath))
+	}
+
 	// devices — Breaking on root. Per-device passthrough bakes into the cell
 	// root's OCI Linux.Devices + Linux.Resources.Devices at StartCell, stat'd
 	// from the host node at create; a change only reaches the running container
@@ -708,6 +912,13 @@ func diffContainerSpec(desired, actual *intmodel.ContainerSpec, rootContainer bo
 		recordSpecFieldChange(&result, rootContainer, true, "tmpfs", "tmpfs mounts changed")
 	}
 
+	// writableTmp — Breaking on root for the same reason as tmpfs: the
+	// implicit /tmp tmpfs is part of the OCI Mounts table. Compatible on
+	// non-root.
+	if !boolPtrEqual(desired.WritableTmp, actual.WritableTmp) {
+		recordSpecFieldChange(&result, rootContainer, true, "writableTmp", "writableTmp changed")
+	}
+
 	// resources — Breaking on root (cgroup v2 limits are applied at the
 	// cell cgroup level when the root container creates the cell
 	// namespace; the cgroup-shape change requires a recreate).
@@ -729,6 +940,42 @@ func diffContainerSpec(desired, actual *intmodel.ContainerSpec, rootContainer bo
 		recordSpecFieldChange(&result, rootContainer, true, "secrets", "secrets changed")
 	}
 
+	// logRotation — Compatible on root and non-root. The reconcile loop
+	// reads the policy from the stored spec on every tick; the task's log
+	// file is untouched by the change itself.
+	if !logRotationEqual(desired.LogRotation, actual.LogRotation) {
+		recordSpecFieldChange(&result, rootContainer, false, "logRotation", "log rotation changed")
+	}
+
+	// lifecycle — Compatible on root and non-root. The hooks are read from
+	// the stored spec at the next task start and stop; the running task is
+	// unaffected.
+	if !containerLifecycleEqual(desired.Lifecycle, actual.Lifecycle) {
+		recordSpecFieldChange(&result, rootContainer, false, "lifecycle", "lifecycle hooks changed")
+	}
+
+	// separateStreams — Compatible on root and non-root. The flag only
+	// shapes the task IO at the next task start; root containers ignore it
+	// and a running task keeps the log format it was started with.
+	if desired.SeparateStreams != actual.SeparateStreams {
+		recordSpecFieldChange(&result, rootContainer, false, "separateStreams", "separate streams changed")
+	}
+
+	// openStdin / stdinOnce / stdinFile — Compatible on root and non-root.
+	// Like separateStreams they only shape the task IO at the next task
+	// start; a running task keeps the stdin it was started with.
+	if desired.OpenStdin != actual.OpenStdin || desired.StdinOnce != actual.StdinOnce ||
+		desired.StdinFile != actual.StdinFile {
+		recordSpecFieldChange(&result, rootContainer, false, "stdin", "stdin changed")
+	}
+
+	// imagePullPolicy — Compatible on root and non-root. The policy is only
+	// consulted when a container is created; the running task is untouched
+	// and the next create honours the new policy.
+	if desired.ImagePullPolicy != actual.ImagePullPolicy {
+		recordSpecFieldChange(&result, rootContainer, false, "imagePullPolicy", "image pull policy changed")
+	}
+
 	// repos — Compatible on root and non-root. Repos are handled by
 	// kuketty's pre-Serve clone/fetch step at start time, not at OCI
 	// spec creation; an edit takes effect on the next start.
@@ -770,6 +1017,28 @@ func diffContainerSpec(desired, actual *intmodel.ContainerSpec, rootContainer bo
 	// in-place. Route through ChangeTypeBreaking so the apply layer drives
 	// RecreateCell instead of UpdateCell's child stop-remove-recreate-start
 	// path (which only re-enters the existing namespaces).
+	// hostname — Breaking on root and non-root alike: every container joins
+	// the root's UTS namespace, so whichever container declares it, the
+	// value is the root's OCI hostname, fixed at create; a change only
+	// reaches the cell via RecreateCell.
+	if desired.Hostname != actual.Hostname {
+		result.HasChanges = true
+		result.ChangeType = ChangeTypeBreaking
+		result.BreakingChanges = append(result.BreakingChanges, "hostname")
+		result.Details["hostname"] = fmt.Sprintf(
+			"hostname changed from %q to %q (breaking)",
+			actual.Hostname,
+			desired.Hostname,
+		)
+	}
+
+	// extraHosts — Compatible on root and non-root: the entries live in the
+	// cell's generated /etc/hosts, which every StartCell re-renders in place
+	// behind the containers' bind-mounts.
+	if !slicesEqual(desired.ExtraHosts, actual.ExtraHosts) {
+		recordSpecFieldChange(&result, rootContainer, false, "extraHosts", "extraHosts changed")
+	}
+
 	if desired.HostNetwork != actual.HostNetwork {
 		result.HasChanges = true
 		result.ChangeType = ChangeTypeBreaking
@@ -913,6 +1182,21 @@ func cellTtyEqual(a, b *intmodel.CellTty) bool {
 	return a.Default == b.Default
 }
 
+func cellBandwidthEqual(a, b *intmodel.CellBandwidth) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func cellLifecycleEqual(a, b *intmodel.CellLifecycle) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return slicesEqual(a.PreStart, b.PreStart) && slicesEqual(a.PostStop, b.PostStop) &&
+		a.TimeoutSeconds == b.TimeoutSeconds
+}
+
 func capabilitiesEqual(a, b *intmodel.ContainerCapabilities) bool {
 	if a == nil && b == nil {
 		return true
@@ -967,6 +1251,28 @@ func resourcesEqual(a, b *intmodel.ContainerResources) bool {
 		int64PtrEqual(a.PidsLimit, b.PidsLimit)
 }
 
+// quotaEqual treats an unset quota and one with every field zero as equal:
+// both leave the scope unbounded.
+func quotaEqual(a, b *intmodel.ResourceQuota) bool {
+	var zero intmodel.ResourceQuota
+	if a == nil {
+		a = &zero
+	}
+	if b == nil {
+		b = &zero
+	}
+	return *a == *b
+}
+
+// diskQuotaBytes collapses an unset diskQuota and a zero sizeBytes, which
+// both mean no limit.
+func diskQuotaBytes(q *intmodel.ContainerDiskQuota) int64 {
+	if q == nil {
+		return 0
+	}
+	return q.SizeBytes
+}
+
 func resourcesAreZero(r *intmodel.ContainerResources) bool {
 	if r == nil {
 		return true
@@ -974,6 +1280,41 @@ func resourcesAreZero(r *intmodel.ContainerResources) bool {
 	return r.MemoryLimitBytes == nil && r.CPUShares == nil && r.PidsLimit == nil
 }
 
+func logRotationEqual(a, b *intmodel.ContainerLogRotation) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func containerLifecycleEqual(a, b *intmodel.ContainerLifecycle) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return slicesEqual(a.PostStart, b.PostStart) &&
+		slicesEqual(a.PreStop, b.PreStop) &&
+		a.TimeoutSeconds == b.TimeoutSeconds &&
+		a.FailOnPostStartError == b.FailOnPostStartError
+}
+
+func intPtrEqual(a, b *int) bool {
+	if a == nil && b == nil {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return *a == *b
+}
+
+// formatIntPtr renders an optional int for diff details, "unset" for nil.
+func formatIntPtr(v *int) string {
+	if v == nil {
+		return "unset"
+	}
+	return strconv.Itoa(*v)
+}
+
 func int64PtrEqual(a, b *int64) bool {
 	if a == nil && b == nil {
 		return true
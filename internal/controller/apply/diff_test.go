@@ -17,6 +17,7 @@
 package apply_test
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/eminwux/kukeon/internal/controller/apply"
@@ -91,6 +92,72 @@ func TestDiffRealm_CompatibleChange_Labels(t *testing.T) {
 	}
 }
 
+func TestDiffRealm_CompatibleChange_PauseImage(t *testing.T) {
+	desired := intmodel.Realm{
+		Metadata: intmodel.RealmMetadata{Name: "test-realm"},
+		Spec:     intmodel.RealmSpec{PauseImage: "registry.example.com/pause:3.10"},
+	}
+	actual := intmodel.Realm{
+		Metadata: intmodel.RealmMetadata{Name: "test-realm"},
+	}
+
+	diff := apply.DiffRealm(desired, actual)
+	if diff.ChangeType != apply.ChangeTypeCompatible {
+		t.Errorf("expected compatible change, got %v", diff.ChangeType)
+	}
+	if !slices.Contains(diff.ChangedFields, "spec.pauseImage") {
+		t.Errorf("expected spec.pauseImage in changed fields, got %v", diff.ChangedFields)
+	}
+}
+
+func TestDiffSpace_CompatibleChange_DefaultResources(t *testing.T) {
+	pids := int64(128)
+	desired := intmodel.Space{
+		Metadata: intmodel.SpaceMetadata{Name: "test-space"},
+		Spec: intmodel.SpaceSpec{
+			RealmName:        "test-realm",
+			DefaultResources: &intmodel.ContainerResources{PidsLimit: &pids},
+		},
+	}
+	actual := intmodel.Space{
+		Metadata: intmodel.SpaceMetadata{Name: "test-space"},
+		Spec:     intmodel.SpaceSpec{RealmName: "test-realm"},
+	}
+
+	diff := apply.DiffSpace(desired, actual)
+	if diff.ChangeType != apply.ChangeTypeCompatible {
+		t.Errorf("expected compatible change, got %v", diff.ChangeType)
+	}
+	if !slices.Contains(diff.ChangedFields, "spec.defaultResources") {
+		t.Errorf("expected spec.defaultResources in changed fields, got %v", diff.ChangedFields)
+	}
+}
+
+// TestDiffStack_CompatibleChange_Annotations confirms an annotation edit is
+// reported as a compatible change so apply persists it without recreating.
+func TestDiffStack_CompatibleChange_Annotations(t *testing.T) {
+	desired := intmodel.Stack{
+		Metadata: intmodel.StackMetadata{
+			Name:        "test-stack",
+			Annotations: map[string]string{"owner": "platform"},
+		},
+	}
+
+	actual := intmodel.Stack{
+		Metadata: intmodel.StackMetadata{
+			Name: "test-stack",
+		},
+	}
+
+	diff := apply.DiffStack(desired, actual)
+	if diff.ChangeType != apply.ChangeTypeCompatible {
+		t.Errorf("expected compatible change, got %v", diff.ChangeType)
+	}
+	if !slices.Contains(diff.ChangedFields, "metadata.annotations") {
+		t.Errorf("expected metadata.annotations in ChangedFields, got %v", diff.ChangedFields)
+	}
+}
+
 func TestDiffCell_RootContainerChanged(t *testing.T) {
 	desired := intmodel.Cell{
 		Metadata: intmodel.CellMetadata{
@@ -734,6 +801,9 @@ func TestDiffCell_RootContainerWorkingDirVolumesSecurityOpts_Breaking(t *testing
 		{"devices", func(s *intmodel.ContainerSpec) {
 			s.Devices = []string{"/dev/kvm"}
 		}, "rootContainer.devices"},
+		{"hostname", func(s *intmodel.ContainerSpec) {
+			s.Hostname = "web.internal"
+		}, "rootContainer.hostname"},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1073,6 +1143,37 @@ func TestDiffCell_AutoDelete_CompatibleChange(t *testing.T) {
 	}
 }
 
+// TestDiffCell_Lifecycle_CompatibleChange pins that editing the lifecycle
+// hooks is persisted without a recreate: the runner reads them on the next
+// start or stop.
+func TestDiffCell_Lifecycle_CompatibleChange(t *testing.T) {
+	desired := intmodel.Cell{
+		Metadata: intmodel.CellMetadata{Name: "hello-world"},
+		Spec: intmodel.CellSpec{
+			RealmName: "default",
+			SpaceName: "default",
+			StackName: "default",
+			Lifecycle: &intmodel.CellLifecycle{PreStart: []string{"mount /data"}},
+			Containers: []intmodel.ContainerSpec{
+				{ID: "root", Root: true, Image: "busybox:latest"},
+			},
+		},
+	}
+
+	actual := desired
+	actual.Spec.Lifecycle = nil
+
+	diff := apply.DiffCell(desired, actual)
+	if diff.ChangeType != apply.ChangeTypeCompatible || !hasChangedField(diff.DiffResult, "spec.lifecycle") {
+		t.Fatalf("expected a compatible spec.lifecycle change, got %v %v", diff.ChangeType, diff.ChangedFields)
+	}
+
+	actual.Spec.Lifecycle = &intmodel.CellLifecycle{PreStart: []string{"mount /data"}}
+	if diff = apply.DiffCell(desired, actual); diff.HasChanges {
+		t.Errorf("identical hooks must not register drift, got %+v", diff)
+	}
+}
+
 // TestDiffCell_NestedCgroupRuntime_BreakingChange pins AC #1+#2 of issue
 // #992: a toggle of `Spec.NestedCgroupRuntime` must classify as Breaking and
 // surface in BreakingChanges as `spec.nestedCgroupRuntime` — the runner's
@@ -1117,6 +1218,38 @@ func TestDiffCell_NestedCgroupRuntime_BreakingChange(t *testing.T) {
 	}
 }
 
+// TestDiffCell_Bandwidth_BreakingChange pins that a bandwidth edit is
+// Breaking: the limits only reach the bandwidth plugin on a fresh CNI ADD.
+func TestDiffCell_Bandwidth_BreakingChange(t *testing.T) {
+	desired := intmodel.Cell{
+		Metadata: intmodel.CellMetadata{Name: "hello-world"},
+		Spec: intmodel.CellSpec{
+			RealmName: "default",
+			SpaceName: "default",
+			StackName: "default",
+			Bandwidth: &intmodel.CellBandwidth{EgressRate: "10Mbit", EgressBurst: "1Mbit"},
+			Containers: []intmodel.ContainerSpec{
+				{ID: "root", Root: true, Image: "busybox:latest"},
+			},
+		},
+	}
+
+	actual := desired
+	actual.Spec.Bandwidth = &intmodel.CellBandwidth{EgressRate: "10Mbit", EgressBurst: "1Mbit"}
+	if diff := apply.DiffCell(desired, actual); diff.HasChanges {
+		t.Fatalf("equal bandwidth reported changes: %v", diff.Details)
+	}
+
+	actual.Spec.Bandwidth = nil
+	diff := apply.DiffCell(desired, actual)
+	if diff.ChangeType != apply.ChangeTypeBreaking {
+		t.Fatalf("expected breaking change, got %v", diff.ChangeType)
+	}
+	if diff.Details["spec.bandwidth"] == "" {
+		t.Errorf("expected Details[spec.bandwidth] to be populated, got %v", diff.Details)
+	}
+}
+
 func hasChangedField(diff apply.DiffResult, field string) bool {
 	for _, f := range diff.ChangedFields {
 		if f == field {
@@ -1246,6 +1379,43 @@ func TestDiffContainer_HostNetworkChange(t *testing.T) {
 	}
 }
 
+// TestDiffContainer_HostnameAndExtraHosts pins the hostname / extraHosts
+// classification: every container joins the root's UTS namespace, so a
+// hostname edit is Breaking even off the root, while extraHosts only feeds
+// the cell's re-rendered /etc/hosts and stays Compatible.
+func TestDiffContainer_HostnameAndExtraHosts(t *testing.T) {
+	desired := intmodel.Container{
+		Metadata: intmodel.ContainerMetadata{Name: "web"},
+		Spec: intmodel.ContainerSpec{
+			ID:        "web",
+			RealmName: "default", SpaceName: "default", StackName: "default", CellName: "hello-world",
+			Image:      "nginx:1.27",
+			Hostname:   "web.internal",
+			ExtraHosts: []string{"db:10.0.0.5"},
+		},
+	}
+
+	renamed := desired
+	renamed.Spec.Hostname = ""
+	diff := apply.DiffContainer(desired, renamed)
+	if diff.ChangeType != apply.ChangeTypeBreaking {
+		t.Fatalf("expected breaking change for hostname edit, got %v", diff.ChangeType)
+	}
+	if !slices.Contains(diff.BreakingChanges, "hostname") {
+		t.Errorf("expected BreakingChanges to include hostname, got %v", diff.BreakingChanges)
+	}
+
+	rehosted := desired
+	rehosted.Spec.ExtraHosts = []string{"db:10.0.0.6"}
+	diff = apply.DiffContainer(desired, rehosted)
+	if diff.ChangeType != apply.ChangeTypeCompatible {
+		t.Fatalf("expected compatible change for extraHosts edit, got %v", diff.ChangeType)
+	}
+	if len(diff.BreakingChanges) != 0 {
+		t.Errorf("extraHosts edit must not populate BreakingChanges, got %v", diff.BreakingChanges)
+	}
+}
+
 // TestDiffContainer_TtyChange exercises the *ContainerTty pointer-field
 // equality helper added by issue #991: a tty edit on the pointer-backed
 // stage list must register as drift, and an identical block (including a
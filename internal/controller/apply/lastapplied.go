@@ -0,0 +1,108 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"encoding/json"
+
+	"github.com/eminwux/kukeon/internal/consts"
+)
+
+// lastAppliedMetadata is the part of a last-applied configuration the
+// three-way merge reads back: the labels and annotations the manifest held.
+type lastAppliedMetadata struct {
+	Metadata struct {
+		Labels      map[string]string `json:"labels"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+}
+
+// mergeLastApplied three-way merges the labels and annotations of an
+// apply's desired resource with the live ones. It only acts when desired
+// carries a last-applied configuration (stamped by ApplyDocuments); every
+// other caller's desired is returned as given. The result starts from live,
+// drops what the previous manifest set and the new one no longer does, and
+// lays the new manifest on top, so labels added by another writer survive
+// while labels removed from the manifest are deleted. A live resource with
+// no readable record (one that predates last-applied tracking) keeps the
+// two-way behavior: the manifest replaces its labels and annotations.
+func mergeLastApplied(liveLabels, liveAnnotations, desiredLabels, desiredAnnotations map[string]string) (
+	map[string]string, map[string]string,
+) {
+	if _, ok := desiredAnnotations[consts.KukeonLastAppliedAnnotationKey]; !ok {
+		return desiredLabels, desiredAnnotations
+	}
+	raw, ok := liveAnnotations[consts.KukeonLastAppliedAnnotationKey]
+	if !ok {
+		return desiredLabels, desiredAnnotations
+	}
+	var previous lastAppliedMetadata
+	if err := json.Unmarshal([]byte(raw), &previous); err != nil {
+		return desiredLabels, desiredAnnotations
+	}
+	labels := threeWayMergeMap(previous.Metadata.Labels, liveLabels, desiredLabels)
+	annotations := threeWayMergeMap(previous.Metadata.Annotations, liveAnnotations, desiredAnnotations)
+	return labels, annotations
+}
+
+// threeWayMergeMap returns live with the keys previous set but desired does
+// not removed, and desired's keys written over the rest.
+func threeWayMergeMap(previous, live, desired map[string]string) map[string]string {
+	if len(live) == 0 {
+		return desired
+	}
+	out := make(map[string]string, len(live)+len(desired))
+	for k, v := range live {
+		if _, dropped := previous[k]; dropped {
+			if _, kept := desired[k]; !kept {
+				continue
+			}
+		}
+		out[k] = v
+	}
+	for k, v := range desired {
+		out[k] = v
+	}
+	return out
+}
+
+// annotationsDiffer compares two annotation maps for the diff. Apply's
+// bookkeeping annotations change with every manifest edit, so they are left
+// out: a manifest edit the diff does not otherwise see is not an update.
+func annotationsDiffer(desired, actual map[string]string) bool {
+	return !mapsEqual(userAnnotations(desired), userAnnotations(actual))
+}
+
+// isApplyBookkeepingAnnotation reports whether key is one of the
+// annotations apply stamps to track its own writes.
+func isApplyBookkeepingAnnotation(key string) bool {
+	return key == consts.KukeonLastAppliedAnnotationKey || key == consts.KukeonFieldManagerAnnotationKey
+}
+
+// userAnnotations returns m without apply's bookkeeping annotations.
+func userAnnotations(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return m
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		if !isApplyBookkeepingAnnotation(k) {
+			out[k] = v
+		}
+	}
+	return out
+}
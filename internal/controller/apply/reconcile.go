@@ -17,6 +17,7 @@
 package apply
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -57,6 +58,11 @@ func ReconcileRealm(r runner.Runner, desired intmodel.Realm) (ReconcileResult, e
 		return result, fmt.Errorf("failed to get realm: %w", err)
 	}
 
+	desired.Metadata.Labels, desired.Metadata.Annotations = mergeLastApplied(
+		actual.Metadata.Labels, actual.Metadata.Annotations,
+		desired.Metadata.Labels, desired.Metadata.Annotations,
+	)
+
 	// Diff desired vs actual
 	diff := DiffRealm(desired, actual)
 	if !diff.HasChanges {
@@ -130,6 +136,11 @@ func ReconcileSpace(r runner.Runner, desired intmodel.Space) (ReconcileResult, e
 		return result, fmt.Errorf("failed to get space: %w", err)
 	}
 
+	desired.Metadata.Labels, desired.Metadata.Annotations = mergeLastApplied(
+		actual.Metadata.Labels, actual.Metadata.Annotations,
+		desired.Metadata.Labels, desired.Metadata.Annotations,
+	)
+
 	// Diff desired vs actual
 	diff := DiffSpace(desired, actual)
 	if !diff.HasChanges {
@@ -222,6 +233,11 @@ func ReconcileStack(r runner.Runner, desired intmodel.Stack) (ReconcileResult, e
 		return result, fmt.Errorf("failed to get stack: %w", err)
 	}
 
+	desired.Metadata.Labels, desired.Metadata.Annotations = mergeLastApplied(
+		actual.Metadata.Labels, actual.Metadata.Annotations,
+		desired.Metadata.Labels, desired.Metadata.Annotations,
+	)
+
 	// Diff desired vs actual
 	diff := DiffStack(desired, actual)
 	if !diff.HasChanges {
@@ -334,12 +350,41 @@ func ensureCellParents(r runner.Runner, desired intmodel.Cell) error {
 // (#1185): apply must never report a success action (created/updated/unchanged)
 // for a cell whose reconcile left it Failed, so the inner result is run through
 // failedCellReconcileError before returning — see its doc for the rationale.
-func ReconcileCell(r runner.Runner, desired intmodel.Cell) (ReconcileResult, error) {
-	result, err := reconcileCell(r, desired)
+//
+// With desired.Spec.PruneContainers (`kuke apply --prune`) a successful
+// reconcile is followed by pruneCellContainers.
+func ReconcileCell(ctx context.Context, r runner.Runner, desired intmodel.Cell) (ReconcileResult, error) {
+	result, err := reconcileCell(ctx, r, desired)
+	if err == nil && desired.Spec.PruneContainers {
+		err = pruneCellContainers(r, desired, &result)
+	}
 	return result, failedCellReconcileError(result, desired.Metadata.Name, err)
 }
 
-func reconcileCell(r runner.Runner, desired intmodel.Cell) (ReconcileResult, error) {
+// pruneCellContainers removes the workload containers still in containerd
+// for a cell whose spec no longer declares them. UpdateCell already deletes
+// the ones the stored spec lists; this catches those whose delete failed or
+// that the stored spec lost track of. A pruned container turns an unchanged
+// result into an updated one.
+func pruneCellContainers(r runner.Runner, desired intmodel.Cell, result *ReconcileResult) error {
+	cell, ok := result.Resource.(intmodel.Cell)
+	if !ok {
+		cell = desired
+	}
+	pruned, err := r.PruneCellContainers(cell)
+	for _, id := range pruned {
+		result.Changes = append(result.Changes, fmt.Sprintf("container %q pruned", id))
+	}
+	if len(pruned) > 0 && result.Action == "unchanged" {
+		result.Action = actionUpdated
+	}
+	if err != nil {
+		return fmt.Errorf("failed to prune undeclared containers: %w", err)
+	}
+	return nil
+}
+
+func reconcileCell(ctx context.Context, r runner.Runner, desired intmodel.Cell) (ReconcileResult, error) {
 	result := ReconcileResult{
 		Action: "unchanged",
 		Kind:   "Cell",
@@ -356,7 +401,7 @@ func reconcileCell(r runner.Runner, desired intmodel.Cell) (ReconcileResult, err
 	if err != nil {
 		if errors.Is(err, errdefs.ErrCellNotFound) {
 			// Cell doesn't exist, create and start it.
-			started, createErr := createAndStartCell(r, desired)
+			started, createErr := createAndStartCell(ctx, r, desired)
 			if createErr != nil {
 				return result, createErr
 			}
@@ -367,6 +412,11 @@ func reconcileCell(r runner.Runner, desired intmodel.Cell) (ReconcileResult, err
 		return result, fmt.Errorf("failed to get cell: %w", err)
 	}
 
+	desired.Metadata.Labels, desired.Metadata.Annotations = mergeLastApplied(
+		actual.Metadata.Labels, actual.Metadata.Annotations,
+		desired.Metadata.Labels, desired.Metadata.Annotations,
+	)
+
 	// Diff desired vs actual
 	diff := DiffCell(desired, actual)
 	if !diff.HasChanges {
@@ -378,7 +428,7 @@ func reconcileCell(r runner.Runner, desired intmodel.Cell) (ReconcileResult, err
 		// "Apply (declarative, multi-document)" names the kill-then-apply
 		// flow as a divergence apply must reconcile).
 		if cellNeedsRematerialize(actual) {
-			rematerialized, startErr := r.StartCell(actual)
+			rematerialized, startErr := r.StartCell(ctx, actual)
 			if startErr != nil {
 				return result, fmt.Errorf("failed to re-materialize cell: %w", startErr)
 			}
@@ -442,12 +492,12 @@ func reconcileCell(r runner.Runner, desired intmodel.Cell) (ReconcileResult, err
 // which also starts cells), and UpdateCellMetadata persists the runner-set
 // status. Pulled out of ReconcileCell to keep that function under the funlen
 // budget.
-func createAndStartCell(r runner.Runner, desired intmodel.Cell) (intmodel.Cell, error) {
-	created, createErr := r.CreateCell(desired)
+func createAndStartCell(ctx context.Context, r runner.Runner, desired intmodel.Cell) (intmodel.Cell, error) {
+	created, createErr := r.CreateCell(ctx, desired)
 	if createErr != nil {
 		return intmodel.Cell{}, fmt.Errorf("failed to create cell: %w", createErr)
 	}
-	started, startErr := r.StartCell(created)
+	started, startErr := r.StartCell(ctx, created)
 	if startErr != nil {
 		return intmodel.Cell{}, fmt.Errorf("failed to start cell after creation: %w", startErr)
 	}
@@ -489,7 +539,11 @@ func appendContainerChangeSummaries(changes []string, diff CellDiffResult) []str
 }
 
 // ReconcileContainer reconciles a desired container state with the actual state.
-func ReconcileContainer(r runner.Runner, desired intmodel.Container) (ReconcileResult, error) {
+func ReconcileContainer(
+	ctx context.Context,
+	r runner.Runner,
+	desired intmodel.Container,
+) (ReconcileResult, error) {
 	result := ReconcileResult{
 		Action: "unchanged",
 		Kind:   "Container",
@@ -529,7 +583,7 @@ func ReconcileContainer(r runner.Runner, desired intmodel.Container) (ReconcileR
 
 	if actualContainer == nil {
 		// Container doesn't exist, create it
-		updatedCell, createErr := r.CreateContainer(cell, desired.Spec)
+		updatedCell, createErr := r.CreateContainer(ctx, cell, desired.Spec)
 		if createErr != nil {
 			return result, fmt.Errorf("failed to create container: %w", createErr)
 		}
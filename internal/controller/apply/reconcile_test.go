@@ -17,6 +17,8 @@
 package apply_test
 
 import (
+	"context"
+	"slices"
 	"testing"
 
 	"github.com/eminwux/kukeon/internal/controller/apply"
@@ -36,6 +38,11 @@ type reconcileFakeRunner struct {
 
 	updateCellCalled   bool
 	recreateCellCalled bool
+
+	// pruned is what PruneCellContainers reports; prunedCell records the
+	// cell it was called with.
+	pruned     []string
+	prunedCell *intmodel.Cell
 }
 
 func (f *reconcileFakeRunner) GetRealm(realm intmodel.Realm) (intmodel.Realm, error) {
@@ -64,6 +71,11 @@ func (f *reconcileFakeRunner) RecreateCell(cell intmodel.Cell) (intmodel.Cell, e
 	return cell, nil
 }
 
+func (f *reconcileFakeRunner) PruneCellContainers(cell intmodel.Cell) ([]string, error) {
+	f.prunedCell = &cell
+	return f.pruned, nil
+}
+
 // TestReconcileCell_RootEnvCompatible_RoutesToUpdate pins the issue-#990
 // reconciler-gate fix: a Compatible-on-root edit (env) sets
 // `RootContainerChanged=true` so the diff readout qualifies the divergence
@@ -102,7 +114,7 @@ func TestReconcileCell_RootEnvCompatible_RoutesToUpdate(t *testing.T) {
 	}
 
 	r := &reconcileFakeRunner{cellState: actual}
-	result, err := apply.ReconcileCell(r, desired)
+	result, err := apply.ReconcileCell(context.Background(), r, desired)
 	if err != nil {
 		t.Fatalf("ReconcileCell returned unexpected error: %v", err)
 	}
@@ -147,7 +159,7 @@ func TestReconcileCell_RootImageBreaking_RoutesToRecreate(t *testing.T) {
 	}
 
 	r := &reconcileFakeRunner{cellState: actual}
-	result, err := apply.ReconcileCell(r, desired)
+	result, err := apply.ReconcileCell(context.Background(), r, desired)
 	if err != nil {
 		t.Fatalf("ReconcileCell returned unexpected error: %v", err)
 	}
@@ -162,3 +174,66 @@ func TestReconcileCell_RootImageBreaking_RoutesToRecreate(t *testing.T) {
 		t.Errorf("expected result.Action=updated, got %q", result.Action)
 	}
 }
+
+// TestReconcileCell_PruneContainers covers `kuke apply --prune`: a container
+// dropped from the spec is removed by UpdateCell, and with PruneContainers
+// the reconcile then prunes what containerd still holds for the cell, even
+// when the spec is otherwise unchanged.
+func TestReconcileCell_PruneContainers(t *testing.T) {
+	cellWith := func(ids ...string) intmodel.Cell {
+		cell := intmodel.Cell{
+			Metadata: intmodel.CellMetadata{Name: "web"},
+			Spec:     intmodel.CellSpec{RealmName: "default", SpaceName: "default", StackName: "default"},
+		}
+		for _, id := range ids {
+			cell.Spec.Containers = append(cell.Spec.Containers, intmodel.ContainerSpec{ID: id, Image: "busybox:latest"})
+		}
+		return cell
+	}
+
+	t.Run("container removed from the spec", func(t *testing.T) {
+		desired := cellWith("app")
+		desired.Spec.PruneContainers = true
+		r := &reconcileFakeRunner{
+			cellState: cellWith("app", "sidecar"),
+			pruned:    []string{"default_default_web_sidecar"},
+		}
+		result, err := apply.ReconcileCell(context.Background(), r, desired)
+		if err != nil {
+			t.Fatalf("ReconcileCell returned unexpected error: %v", err)
+		}
+		if !r.updateCellCalled {
+			t.Error("removing a container must route through UpdateCell")
+		}
+		if r.prunedCell == nil || len(r.prunedCell.Spec.Containers) != 1 {
+			t.Fatalf("PruneCellContainers called with %+v, want the updated one-container cell", r.prunedCell)
+		}
+		if !slices.Contains(result.Changes, `container "default_default_web_sidecar" pruned`) {
+			t.Errorf("Changes = %q, want the pruned container", result.Changes)
+		}
+	})
+
+	t.Run("unchanged spec with a leftover container", func(t *testing.T) {
+		desired := cellWith("app")
+		desired.Spec.PruneContainers = true
+		r := &reconcileFakeRunner{cellState: cellWith("app"), pruned: []string{"default_default_web_sidecar"}}
+		result, err := apply.ReconcileCell(context.Background(), r, desired)
+		if err != nil {
+			t.Fatalf("ReconcileCell returned unexpected error: %v", err)
+		}
+		if result.Action != "updated" {
+			t.Errorf("Action = %q, want updated once a container is pruned", result.Action)
+		}
+	})
+
+	t.Run("without prune", func(t *testing.T) {
+		r := &reconcileFakeRunner{cellState: cellWith("app")}
+		result, err := apply.ReconcileCell(context.Background(), r, cellWith("app"))
+		if err != nil {
+			t.Fatalf("ReconcileCell returned unexpected error: %v", err)
+		}
+		if r.prunedCell != nil || result.Action != "unchanged" {
+			t.Errorf("prune ran without PruneContainers (action %q)", result.Action)
+		}
+	})
+}
@@ -19,6 +19,7 @@
 package controller_test
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
@@ -61,7 +62,7 @@ func TestReconcileCell_RematerializesStoppedCell(t *testing.T) {
 		},
 	}
 
-	result, err := applypkg.ReconcileCell(f, desired)
+	result, err := applypkg.ReconcileCell(context.Background(), f, desired)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -122,7 +123,7 @@ func TestReconcileCell_NoRegressionForReadyCell(t *testing.T) {
 		},
 	}
 
-	result, err := applypkg.ReconcileCell(f, desired)
+	result, err := applypkg.ReconcileCell(context.Background(), f, desired)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -161,7 +162,7 @@ func TestReconcileCell_FailedCellDoesNotRematerialize(t *testing.T) {
 		},
 	}
 
-	_, err := applypkg.ReconcileCell(f, desired)
+	_, err := applypkg.ReconcileCell(context.Background(), f, desired)
 	if err == nil {
 		t.Fatal("expected a terminal error for a cell left in Failed state, got nil")
 	}
@@ -213,7 +214,7 @@ func TestReconcileCell_CNIConfigPathDriftRematerializes(t *testing.T) {
 		},
 	}
 
-	result, err := applypkg.ReconcileCell(f, desired)
+	result, err := applypkg.ReconcileCell(context.Background(), f, desired)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
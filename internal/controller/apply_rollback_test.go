@@ -0,0 +1,101 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/apply/parser"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+// cellDoc returns a cell document placed under r1/s1/st1.
+func cellDoc(index int, name string) parser.Document {
+	return parser.Document{Index: index, Kind: v1beta1.KindCell, CellDoc: &v1beta1.CellDoc{
+		APIVersion: v1beta1.APIVersionV1Beta1, Kind: v1beta1.KindCell,
+		Metadata: v1beta1.CellMetadata{Name: name},
+		Spec:     v1beta1.CellSpec{RealmID: "r1", SpaceID: "s1", StackID: "st1"},
+	}}
+}
+
+// failingCellRunner wires a memStore whose CreateCell fails for the cell
+// named bad.
+func failingCellRunner(t *testing.T) (*memStore, *fakeRunner) {
+	t.Helper()
+	store := newMemStore(t)
+	r := store.runner()
+	create := r.CreateCellFn
+	r.CreateCellFn = func(c intmodel.Cell) (intmodel.Cell, error) {
+		if c.Metadata.Name == "bad" {
+			return intmodel.Cell{}, errors.New("boom")
+		}
+		return create(c)
+	}
+	return store, r
+}
+
+// TestApplyDocuments_FailedCellRollsBackItsStack pins the default rollback:
+// the cell fails, so the stack, space and realm this apply created for it
+// are deleted again and reported as rolled back.
+func TestApplyDocuments_FailedCellRollsBackItsStack(t *testing.T) {
+	store, r := failingCellRunner(t)
+	ctrl := setupTestController(t, r)
+
+	docs := append(importDocs()[:3], cellDoc(3, "bad"))
+	res, err := ctrl.ApplyDocuments(docs, "", "")
+	if err != nil {
+		t.Fatalf("ApplyDocuments() error = %v", err)
+	}
+
+	want := []string{"rolled back", "rolled back", "rolled back", "failed"}
+	if len(res.Resources) != len(want) {
+		t.Fatalf("got %d results, want %d", len(res.Resources), len(want))
+	}
+	for i, action := range want {
+		if got := res.Resources[i]; got.Action != action {
+			t.Errorf("%s %q action = %q, want %q", got.Kind, got.Name, got.Action, action)
+		}
+	}
+	if len(store.realms) != 0 || len(store.spaces) != 0 || len(store.stacks) != 0 {
+		t.Errorf("store not rolled back: realms=%d spaces=%d stacks=%d",
+			len(store.realms), len(store.spaces), len(store.stacks))
+	}
+}
+
+// TestApplyDocuments_RollbackKeepsScopeWithAppliedChild checks that a
+// created stack stays when another cell under it was applied in the same run.
+func TestApplyDocuments_RollbackKeepsScopeWithAppliedChild(t *testing.T) {
+	store, r := failingCellRunner(t)
+	ctrl := setupTestController(t, r)
+
+	docs := append(importDocs()[:3], cellDoc(3, "good"), cellDoc(4, "bad"))
+	res, err := ctrl.ApplyDocuments(docs, "", "")
+	if err != nil {
+		t.Fatalf("ApplyDocuments() error = %v", err)
+	}
+
+	for _, got := range res.Resources {
+		if got.Action == "rolled back" {
+			t.Errorf("%s %q rolled back, want kept", got.Kind, got.Name)
+		}
+	}
+	if len(store.stacks) != 1 || len(store.cells) != 1 {
+		t.Errorf("store = stacks=%d cells=%d, want 1 / 1", len(store.stacks), len(store.cells))
+	}
+}
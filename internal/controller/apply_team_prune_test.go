@@ -82,7 +82,7 @@ func TestApplyDocuments_TeamPruneScopedToLabel(t *testing.T) {
 	docs := []parser.Document{
 		blueprintDoc(0, "alpha-keep", "default"),
 	}
-	result, err := exec.ApplyDocuments(docs, "alpha")
+	result, err := exec.ApplyDocuments(docs, "alpha", "")
 	if err != nil {
 		t.Fatalf("ApplyDocuments(team=alpha) error = %v", err)
 	}
@@ -154,7 +154,7 @@ func TestApplyDocuments_TeamPruneEmptySet(t *testing.T) {
 	// the parseAndValidate "no valid documents" gate at the wire layer,
 	// but the controller-level ApplyDocuments accepts an empty slice —
 	// pass nil to exercise the pure prune path.
-	result, err := exec.ApplyDocuments(nil, "alpha")
+	result, err := exec.ApplyDocuments(nil, "alpha", "")
 	if err != nil {
 		t.Fatalf("ApplyDocuments(nil, alpha) error = %v", err)
 	}
@@ -198,7 +198,7 @@ func TestApplyDocuments_NoTeamSkipsPrune(t *testing.T) {
 	exec := setupTestController(t, mock)
 
 	docs := []parser.Document{blueprintDoc(0, "any", "default")}
-	if _, err := exec.ApplyDocuments(docs, ""); err != nil {
+	if _, err := exec.ApplyDocuments(docs, "", ""); err != nil {
 		t.Fatalf("ApplyDocuments(team='') error = %v", err)
 	}
 	if listCalled {
@@ -0,0 +1,346 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/eminwux/kukeon/internal/consts"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	fsutil "github.com/eminwux/kukeon/internal/util/fs"
+)
+
+// DefaultBackupRetention is how many metadata backups are kept when
+// Options.BackupRetention is unset.
+const DefaultBackupRetention = 10
+
+const (
+	backupSuffix     = ".tar.gz"
+	backupTimeFormat = "20060102T150405.000Z"
+	backupFileMode   = 0o600
+	backupDirMode    = 0o700
+)
+
+// backupResourceSubdirs are the per-scope subdirectories whose files are
+// resource documents. Together with every metadata.json they make up what a
+// backup holds; container logs, tty sockets, lock sidecars, generated
+// /etc/hosts files and volume contents are runtime state and are left out.
+//
+//nolint:gochecknoglobals // read-only table
+var backupResourceSubdirs = []string{
+	consts.KukeonSecretsSubdir,
+	consts.KukeonBlueprintsSubdir,
+	consts.KukeonConfigsSubdir,
+	consts.KukeonVolumeMetaSubdir,
+}
+
+// RestoreBackupResult reports the files a restore wrote and the ones it left
+// alone because they already exist. Paths are relative to the metadata root.
+type RestoreBackupResult struct {
+	Restored []string
+	Skipped  []string
+}
+
+// backupBeforeDestroy snapshots a realm's metadata subtree ahead of a purge
+// or cascade delete and returns the archive path. A failed backup is logged
+// and the operation goes ahead, unless Options.RequireBackup is set, in
+// which case the error (wrapping ErrBackupFailed) stops it.
+func (b *Exec) backupBeforeDestroy(realmName string) (string, error) {
+	archive, err := b.backupRealm(realmName, time.Now())
+	if err != nil {
+		if b.opts.RequireBackup {
+			return "", fmt.Errorf("%w: realm %q: %w", errdefs.ErrBackupFailed, realmName, err)
+		}
+		b.logger.WarnContext(b.ctx, "metadata backup failed; continuing without one",
+			"realm", realmName, "error", err)
+		return "", nil
+	}
+	if archive != "" {
+		b.logger.InfoContext(b.ctx, "metadata backup written", "realm", realmName, "path", archive)
+	}
+	return archive, nil
+}
+
+// backupRealm writes <RunPath>/backups/<timestamp>-<realm>.tar.gz holding
+// the realm's metadata documents, then prunes the oldest backups beyond the
+// retention count. Entries are stored relative to the metadata root with
+// their modes, so a restore recreates root-only secret files as root-only.
+// A realm with no metadata directory yields no archive and an empty path.
+func (b *Exec) backupRealm(realmName string, now time.Time) (string, error) {
+	root := fsutil.MetadataRoot(b.opts.RunPath)
+	realmDir := fsutil.RealmMetadataDir(b.opts.RunPath, realmName)
+	if _, err := os.Stat(realmDir); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	dir := fsutil.BackupsDir(b.opts.RunPath)
+	if err := os.MkdirAll(dir, backupDirMode); err != nil {
+		return "", fmt.Errorf("create backups dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".backup-*")
+	if err != nil {
+		return "", fmt.Errorf("create backup: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if err = writeBackupArchive(tmp, root, realmDir); err != nil {
+		_ = tmp.Close()
+		return "", err
+	}
+	if err = tmp.Close(); err != nil {
+		return "", fmt.Errorf("write backup: %w", err)
+	}
+
+	archive := filepath.Join(dir, now.UTC().Format(backupTimeFormat)+"-"+realmName+backupSuffix)
+	if err = os.Rename(tmp.Name(), archive); err != nil {
+		return "", fmt.Errorf("write backup: %w", err)
+	}
+	b.pruneBackups(dir)
+	return archive, nil
+}
+
+// writeBackupArchive streams the metadata files under realmDir into a
+// gzipped tar, naming entries relative to root. Each file's parent
+// directories are written ahead of it so their modes survive a restore.
+func writeBackupArchive(w io.Writer, root, realmDir string) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	written := map[string]bool{}
+
+	writeHeader := func(file string, info fs.FileInfo) (string, error) {
+		rel, err := filepath.Rel(root, file)
+		if err != nil {
+			return "", err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return "", err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		return rel, tw.WriteHeader(hdr)
+	}
+	var writeDirs func(dir string) error
+	writeDirs = func(dir string) error {
+		if written[dir] || !strings.HasPrefix(dir, realmDir) {
+			return nil
+		}
+		if err := writeDirs(filepath.Dir(dir)); err != nil {
+			return err
+		}
+		info, err := os.Stat(dir)
+		if err != nil {
+			return err
+		}
+		written[dir] = true
+		_, err = writeHeader(dir, info)
+		return err
+	}
+
+	walkErr := filepath.WalkDir(realmDir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == consts.KukeonVolumesSubdir && file != realmDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || !isBackupFile(file) {
+			return nil
+		}
+		if err = writeDirs(filepath.Dir(file)); err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if _, err = writeHeader(file, info); err != nil {
+			return err
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+		return err
+	})
+	if walkErr != nil {
+		return fmt.Errorf("archive metadata: %w", walkErr)
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("archive metadata: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("archive metadata: %w", err)
+	}
+	return nil
+}
+
+// isBackupFile reports whether file is a resource document: a
+// metadata.json, or a file directly inside one of backupResourceSubdirs.
+func isBackupFile(file string) bool {
+	return filepath.Base(file) == consts.KukeonMetadataFile ||
+		slices.Contains(backupResourceSubdirs, filepath.Base(filepath.Dir(file)))
+}
+
+// pruneBackups removes the oldest archives beyond the retention count. The
+// timestamp prefix makes name order creation order. Failures are logged: a
+// stale backup left behind is no reason to fail the caller.
+func (b *Exec) pruneBackups(dir string) {
+	retention := b.opts.BackupRetention
+	if retention <= 0 {
+		retention = DefaultBackupRetention
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		b.logger.WarnContext(b.ctx, "list metadata backups", "dir", dir, "error", err)
+		return
+	}
+	var archives []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && strings.HasSuffix(entry.Name(), backupSuffix) {
+			archives = append(archives, entry.Name())
+		}
+	}
+	slices.Sort(archives)
+	for len(archives) > retention {
+		if err = os.Remove(filepath.Join(dir, archives[0])); err != nil {
+			b.logger.WarnContext(b.ctx, "remove old metadata backup", "file", archives[0], "error", err)
+		}
+		archives = archives[1:]
+	}
+}
+
+// RestoreBackup re-imports the metadata documents held in a backup archive
+// into the metadata tree. Only the entries a backup writes — resource
+// documents and their directories — are accepted. It restores metadata only: the realm's containerd
+// namespace, cgroups, networks and containers are not recreated. Files that
+// already exist are left untouched and reported as skipped, so restoring an
+// archive next to live state only brings back what was removed.
+func (b *Exec) RestoreBackup(archive []byte) (RestoreBackupResult, error) {
+	var res RestoreBackupResult
+
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return res, fmt.Errorf("%w: %w", errdefs.ErrInvalidBackup, err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	root := fsutil.MetadataRoot(b.opts.RunPath)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, nextErr := tr.Next()
+		if errors.Is(nextErr, io.EOF) {
+			break
+		}
+		if nextErr != nil {
+			return res, fmt.Errorf("%w: %w", errdefs.ErrInvalidBackup, nextErr)
+		}
+		name := path.Clean(strings.TrimSuffix(hdr.Name, "/"))
+		if !fs.ValidPath(name) || name == "." {
+			return res, fmt.Errorf("%w: entry %q is outside the metadata tree", errdefs.ErrInvalidBackup, hdr.Name)
+		}
+		target := filepath.Join(root, filepath.FromSlash(name))
+		mode := hdr.FileInfo().Mode()
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err = restoreDir(target, mode); err != nil {
+				return res, err
+			}
+		case tar.TypeReg:
+			// Backups only ever hold resource documents; anything else
+			// (state, lock or socket-adjacent files) has no business landing
+			// in the metadata tree.
+			if !isBackupFile(filepath.FromSlash(name)) {
+				return res, fmt.Errorf("%w: entry %q is not a resource document", errdefs.ErrInvalidBackup, hdr.Name)
+			}
+			restored, restoreErr := restoreFile(target, mode, tr)
+			if restoreErr != nil {
+				return res, restoreErr
+			}
+			if restored {
+				res.Restored = append(res.Restored, name)
+			} else {
+				res.Skipped = append(res.Skipped, name)
+			}
+		default:
+			return res, fmt.Errorf("%w: entry %q is not a file or directory", errdefs.ErrInvalidBackup, hdr.Name)
+		}
+	}
+	return res, nil
+}
+
+// restoreDir creates a directory missing from the metadata tree with its
+// archived mode; an existing directory keeps its own.
+func restoreDir(target string, mode fs.FileMode) error {
+	if err := os.Mkdir(target, mode.Perm()); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return nil
+		}
+		return fmt.Errorf("restore %s: %w", target, err)
+	}
+	// Mkdir drops the setgid bit the metadata directories carry.
+	if err := os.Chmod(target, mode&(fs.ModePerm|fs.ModeSetgid)); err != nil {
+		return fmt.Errorf("restore %s: %w", target, err)
+	}
+	return nil
+}
+
+// restoreFile writes a file missing from the metadata tree and reports
+// whether it did. Parent directories absent from the archive are created
+// with the default backup directory mode.
+func restoreFile(target string, mode fs.FileMode, r io.Reader) (bool, error) {
+	if err := os.MkdirAll(filepath.Dir(target), backupDirMode); err != nil {
+		return false, fmt.Errorf("restore %s: %w", target, err)
+	}
+	f, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode.Perm())
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("restore %s: %w", target, err)
+	}
+	if _, err = io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return false, fmt.Errorf("restore %s: %w", target, err)
+	}
+	if err = f.Close(); err != nil {
+		return false, fmt.Errorf("restore %s: %w", target, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,314 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/controller"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	fsutil "github.com/eminwux/kukeon/internal/util/fs"
+)
+
+// backupFixture lays out a realm metadata subtree under runPath: resource
+// documents that a backup must carry, plus runtime files it must leave out.
+func backupFixture(t *testing.T, runPath string) map[string]os.FileMode {
+	t.Helper()
+	root := fsutil.MetadataRoot(runPath)
+	dirs := map[string]os.FileMode{
+		"r1":                0o750,
+		"r1/secrets":        0o700,
+		"r1/s1":             0o750,
+		"r1/s1/volumes/v1":  0o750,
+		"r1/s1/volume-meta": 0o750,
+		"r1/s1/st1/c1/app":  0o750,
+	}
+	for dir, mode := range dirs {
+		if err := os.MkdirAll(filepath.Join(root, dir), mode); err != nil {
+			t.Fatalf("mkdir %s: %v", dir, err)
+		}
+	}
+	// MkdirAll applies the umask; pin the modes the backup must reproduce.
+	if err := os.Chmod(filepath.Join(root, "r1/secrets"), 0o700); err != nil {
+		t.Fatalf("chmod secrets: %v", err)
+	}
+	want := map[string]os.FileMode{
+		"r1/metadata.json":             0o644,
+		"r1/secrets/db.json":           0o600,
+		"r1/s1/metadata.json":          0o644,
+		"r1/s1/volume-meta/v1.json":    0o644,
+		"r1/s1/st1/c1/metadata.json":   0o644,
+		"r1/s1/st1/c1/app/app.log":     0,
+		"r1/s1/volumes/v1/data.bin":    0,
+		"r1/s1/st1/c1/app/hosts":       0,
+		"r1/s1/st1/c1/metadata.json.l": 0,
+	}
+	for file, mode := range want {
+		perm := mode
+		if perm == 0 {
+			perm = 0o644
+		}
+		if err := os.WriteFile(filepath.Join(root, file), []byte(file), perm); err != nil {
+			t.Fatalf("write %s: %v", file, err)
+		}
+		if err := os.Chmod(filepath.Join(root, file), perm); err != nil {
+			t.Fatalf("chmod %s: %v", file, err)
+		}
+	}
+	return want
+}
+
+// purgeRunner is a fakeRunner whose realm delete removes the realm's
+// metadata directory, as the real runner does.
+func purgeRunner(runPath string) *fakeRunner {
+	existing := buildTestRealm("r1", "")
+	return &fakeRunner{
+		GetRealmFn:     func(intmodel.Realm) (intmodel.Realm, error) { return existing, nil },
+		ExistsCgroupFn: func(any) (bool, error) { return true, nil },
+		ListSpacesFn:   func(string) ([]intmodel.Space, error) { return nil, nil },
+		DeleteRealmFn: func(intmodel.Realm) error {
+			return os.RemoveAll(fsutil.RealmMetadataDir(runPath, "r1"))
+		},
+		PurgeRealmFn: func(intmodel.Realm) (bool, error) { return true, nil },
+		ExistsRealmContainerdNamespaceFn: func(string) (bool, error) {
+			return true, nil
+		},
+	}
+}
+
+func setupBackupController(t *testing.T, runPath string, opts controller.Options) *controller.Exec {
+	t.Helper()
+	opts.RunPath = runPath
+	opts.ContainerdSocket = "/test/containerd.sock"
+	return controller.NewControllerExecForTesting(setupTestContext(t), setupTestLogger(t), opts, purgeRunner(runPath))
+}
+
+func TestPurgeRealm_BackupIsRestorable(t *testing.T) {
+	runPath := t.TempDir()
+	files := backupFixture(t, runPath)
+	ctrl := setupBackupController(t, runPath, controller.Options{})
+
+	result, err := ctrl.PurgeRealm(buildTestRealm("r1", ""), false, false)
+	if err != nil {
+		t.Fatalf("PurgeRealm() error = %v", err)
+	}
+	if filepath.Dir(result.BackupPath) != fsutil.BackupsDir(runPath) ||
+		!strings.HasSuffix(result.BackupPath, "-r1.tar.gz") {
+		t.Fatalf("BackupPath = %q, want <run-path>/backups/<timestamp>-r1.tar.gz", result.BackupPath)
+	}
+	if _, err = os.Stat(fsutil.RealmMetadataDir(runPath, "r1")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("realm metadata dir still present after purge: %v", err)
+	}
+
+	archive, err := os.ReadFile(result.BackupPath)
+	if err != nil {
+		t.Fatalf("read backup: %v", err)
+	}
+	restored, err := ctrl.RestoreBackup(archive)
+	if err != nil {
+		t.Fatalf("RestoreBackup() error = %v", err)
+	}
+
+	root := fsutil.MetadataRoot(runPath)
+	var wantRestored []string
+	for file, mode := range files {
+		info, statErr := os.Stat(filepath.Join(root, file))
+		if mode == 0 {
+			if !errors.Is(statErr, os.ErrNotExist) {
+				t.Errorf("runtime file %s restored (stat err %v)", file, statErr)
+			}
+			continue
+		}
+		wantRestored = append(wantRestored, file)
+		if statErr != nil {
+			t.Errorf("%s not restored: %v", file, statErr)
+			continue
+		}
+		if info.Mode().Perm() != mode {
+			t.Errorf("%s mode = %v, want %v", file, info.Mode().Perm(), mode)
+		}
+		if data, _ := os.ReadFile(filepath.Join(root, file)); string(data) != file {
+			t.Errorf("%s content = %q, want %q", file, data, file)
+		}
+	}
+	slices.Sort(wantRestored)
+	slices.Sort(restored.Restored)
+	if !slices.Equal(restored.Restored, wantRestored) {
+		t.Errorf("Restored = %v, want %v", restored.Restored, wantRestored)
+	}
+	info, err := os.Stat(filepath.Join(root, "r1/secrets"))
+	if err != nil || info.Mode().Perm() != 0o700 {
+		t.Errorf("secrets dir mode = %v (err %v), want 0700", info, err)
+	}
+
+	again, err := ctrl.RestoreBackup(archive)
+	if err != nil {
+		t.Fatalf("second RestoreBackup() error = %v", err)
+	}
+	if len(again.Restored) != 0 || len(again.Skipped) != len(wantRestored) {
+		t.Errorf("second restore = %+v, want every file skipped", again)
+	}
+}
+
+func TestPurgeRealm_BackupRetention(t *testing.T) {
+	runPath := t.TempDir()
+	backupFixture(t, runPath)
+	dir := fsutil.BackupsDir(runPath)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatalf("mkdir backups: %v", err)
+	}
+	old := []string{
+		"20200101T000000.000Z-r1.tar.gz",
+		"20200102T000000.000Z-r2.tar.gz",
+		"20200103T000000.000Z-r1.tar.gz",
+	}
+	for _, name := range old {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	ctrl := setupBackupController(t, runPath, controller.Options{BackupRetention: 2})
+
+	result, err := ctrl.PurgeRealm(buildTestRealm("r1", ""), false, false)
+	if err != nil {
+		t.Fatalf("PurgeRealm() error = %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read backups dir: %v", err)
+	}
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.Name())
+	}
+	want := []string{old[2], filepath.Base(result.BackupPath)}
+	if !slices.Equal(got, want) {
+		t.Errorf("backups = %v, want %v", got, want)
+	}
+}
+
+func TestPurgeRealm_BackupFailure(t *testing.T) {
+	tests := []struct {
+		name          string
+		requireBackup bool
+		wantErr       error
+	}{
+		{name: "logged and purge continues"},
+		{name: "require backup aborts purge", requireBackup: true, wantErr: errdefs.ErrBackupFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runPath := t.TempDir()
+			backupFixture(t, runPath)
+			// A regular file where the backups directory belongs makes the
+			// backup unwritable.
+			if err := os.WriteFile(fsutil.BackupsDir(runPath), nil, 0o600); err != nil {
+				t.Fatalf("block backups dir: %v", err)
+			}
+			ctrl := setupBackupController(t, runPath, controller.Options{RequireBackup: tt.requireBackup})
+
+			result, err := ctrl.PurgeRealm(buildTestRealm("r1", ""), false, false)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("PurgeRealm() error = %v, want %v", err, tt.wantErr)
+				}
+				if _, statErr := os.Stat(fsutil.RealmMetadataDir(runPath, "r1")); statErr != nil {
+					t.Errorf("realm metadata removed despite failed backup: %v", statErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PurgeRealm() error = %v", err)
+			}
+			if result.BackupPath != "" {
+				t.Errorf("BackupPath = %q, want empty", result.BackupPath)
+			}
+		})
+	}
+}
+
+func TestRestoreBackup_RejectsInvalidArchive(t *testing.T) {
+	tests := []struct {
+		name    string
+		archive []byte
+	}{
+		{name: "not gzip", archive: []byte("not a backup")},
+		{name: "entry escapes metadata root", archive: tarGz(t, "../escape.json")},
+		{name: "absolute entry", archive: tarGz(t, "/etc/escape.json")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runPath := t.TempDir()
+			ctrl := setupBackupController(t, runPath, controller.Options{})
+			if _, err := ctrl.RestoreBackup(tt.archive); !errors.Is(err, errdefs.ErrInvalidBackup) {
+				t.Fatalf("RestoreBackup() error = %v, want ErrInvalidBackup", err)
+			}
+			if _, err := os.Stat(filepath.Join(runPath, "escape.json")); !errors.Is(err, os.ErrNotExist) {
+				t.Errorf("entry written outside the metadata root: %v", err)
+			}
+		})
+	}
+}
+
+// TestRestoreBackup_RejectsNonDocumentEntry pins that restore accepts only
+// what a backup writes: a file under the metadata root that is not a
+// resource document is refused instead of dropped into the tree.
+func TestRestoreBackup_RejectsNonDocumentEntry(t *testing.T) {
+	runPath := t.TempDir()
+	ctrl := setupBackupController(t, runPath, controller.Options{})
+
+	_, err := ctrl.RestoreBackup(tarGz(t, "r1/kukeond.lock"))
+	if !errors.Is(err, errdefs.ErrInvalidBackup) {
+		t.Fatalf("RestoreBackup() error = %v, want ErrInvalidBackup", err)
+	}
+	target := filepath.Join(fsutil.MetadataRoot(runPath), "r1", "kukeond.lock")
+	if _, err = os.Stat(target); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("non-document entry written to the metadata tree: %v", err)
+	}
+}
+
+// tarGz builds a gzipped tar holding one regular file named name.
+func tarGz(t *testing.T, name string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	body := []byte("{}")
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(body))}); err != nil {
+		t.Fatalf("write header: %v", err)
+	}
+	if _, err := tw.Write(body); err != nil {
+		t.Fatalf("write body: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("close tar: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("close gzip: %v", err)
+	}
+	return buf.Bytes()
+}
@@ -189,6 +189,8 @@ func (b *Exec) bootstrapRealm(section *RealmSection, realmName, realmNamespace s
 		},
 		Spec: v1beta1.RealmSpec{
 			Namespace: realmNamespace,
+			// kukeond itself runs privileged in the system realm.
+			AllowPrivileged: realmName == consts.KukeSystemRealmName,
 		},
 	}
 
@@ -751,14 +753,14 @@ func (b *Exec) bootstrapCell(section *CellSection, cellDoc *v1beta1.CellDoc) err
 			return fmt.Errorf("%w: %w", errdefs.ErrCreateCell, err)
 		}
 	default:
-		ensuredCell, err = b.runner.CreateCell(cell)
+		ensuredCell, err = b.runner.CreateCell(b.ctx, cell)
 		if err != nil {
 			return fmt.Errorf("%w: %w", errdefs.ErrCreateCell, err)
 		}
 	}
 
 	if startCellAfterEnsure {
-		if _, err = b.runner.StartCell(ensuredCell); err != nil {
+		if _, err = b.runner.StartCell(b.ctx, ensuredCell); err != nil {
 			return fmt.Errorf("failed to start cell containers: %w", err)
 		}
 	}
@@ -0,0 +1,42 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// checkCanceled is the step boundary of a multi-resource operation (cascade
+// delete, scale, reconcile pass). The boundary is where cancellation is
+// observed — the caller stops before starting the next step and returns what
+// it finished alongside an error wrapping context.Canceled (or
+// context.DeadlineExceeded). A runner call already in flight fails with the
+// canceled context and is reported as that step's error.
+func (b *Exec) checkCanceled(next string) error {
+	if err := b.ctx.Err(); err != nil {
+		return fmt.Errorf("canceled before %s: %w", next, err)
+	}
+	return nil
+}
+
+// isCanceled reports whether err carries a parent-context cancellation
+// surfaced by checkCanceled.
+func isCanceled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
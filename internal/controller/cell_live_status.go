@@ -0,0 +1,104 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// LiveStatus is a cell's persisted status reconciled against the live
+// containerd task state of its containers.
+type LiveStatus struct {
+	// State is the cell state to display: the persisted Status.State, or
+	// CellStateDegraded when the metadata says Ready but a container's task
+	// is gone.
+	State intmodel.CellState
+	// Containers maps each container's spec ID to its live state. Nil when
+	// Live is false.
+	Containers map[string]intmodel.ContainerState
+	// Live reports whether containerd answered. When false, State is the
+	// persisted state unchanged.
+	Live bool
+}
+
+// CellLiveStatus queries containerd for the task state of every container in
+// cell and reconciles it with the persisted Status.State, so a container that
+// died out-of-band shows up before the next reconcile pass. The cell is taken
+// as read from metadata (GetCell / ListCells); nothing is written back. When
+// containerd is unreachable the persisted state is returned with Live=false
+// rather than an error.
+func (b *Exec) CellLiveStatus(cell intmodel.Cell) (LiveStatus, error) {
+	status := LiveStatus{State: cell.Status.State}
+	if strings.TrimSpace(cell.Metadata.Name) == "" {
+		return status, errdefs.ErrCellNameRequired
+	}
+	if strings.TrimSpace(cell.Spec.RealmName) == "" {
+		return status, errdefs.ErrRealmNameRequired
+	}
+	if strings.TrimSpace(cell.Spec.SpaceName) == "" {
+		return status, errdefs.ErrSpaceNameRequired
+	}
+	if strings.TrimSpace(cell.Spec.StackName) == "" {
+		return status, errdefs.ErrStackNameRequired
+	}
+
+	containers := make(map[string]intmodel.ContainerState, len(cell.Spec.Containers))
+	for i := range cell.Spec.Containers {
+		id := cell.Spec.Containers[i].ID
+		state, err := b.runner.GetContainerState(cell, id)
+		if err != nil {
+			b.logger.DebugContext(b.ctx, "live container state unavailable, using metadata",
+				"cell", cell.Metadata.Name, "container", id, "error", err)
+			return status, nil
+		}
+		containers[id] = state
+	}
+
+	status.Live = true
+	status.Containers = containers
+	status.State = liveCellState(cell.Status.State, containers)
+	return status, nil
+}
+
+// liveCellState downgrades a persisted Ready to Degraded when any container's
+// task is stopped, exited, failed, or missing. Unknown (a transient containerd
+// error) is inconclusive and ignored, as are paused and pending containers.
+// Any other persisted state is kept: only a stale Ready misleads the operator.
+func liveCellState(persisted intmodel.CellState, containers map[string]intmodel.ContainerState) intmodel.CellState {
+	if persisted != intmodel.CellStateReady {
+		return persisted
+	}
+	for _, state := range containers {
+		switch state {
+		case intmodel.ContainerStateStopped,
+			intmodel.ContainerStateExited,
+			intmodel.ContainerStateError,
+			intmodel.ContainerStateFailed,
+			intmodel.ContainerStateNotCreated:
+			return intmodel.CellStateDegraded
+		case intmodel.ContainerStatePending,
+			intmodel.ContainerStateReady,
+			intmodel.ContainerStatePaused,
+			intmodel.ContainerStatePausing,
+			intmodel.ContainerStateUnknown:
+		}
+	}
+	return persisted
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+func TestCellLiveStatus(t *testing.T) {
+	newCell := func(state intmodel.CellState) intmodel.Cell {
+		cell := buildTestCell("web", "r1", "s1", "st1")
+		cell.Spec.Containers = []intmodel.ContainerSpec{
+			{ID: "root", Root: true},
+			{ID: "app"},
+		}
+		cell.Status.State = state
+		return cell
+	}
+	liveStates := func(states map[string]intmodel.ContainerState) func(intmodel.Cell, string) (intmodel.ContainerState, error) {
+		return func(_ intmodel.Cell, id string) (intmodel.ContainerState, error) {
+			return states[id], nil
+		}
+	}
+
+	tests := []struct {
+		name           string
+		cell           intmodel.Cell
+		stateFn        func(intmodel.Cell, string) (intmodel.ContainerState, error)
+		wantState      intmodel.CellState
+		wantLive       bool
+		wantContainers map[string]intmodel.ContainerState
+	}{
+		{
+			name: "healthy cell stays ready",
+			cell: newCell(intmodel.CellStateReady),
+			stateFn: liveStates(map[string]intmodel.ContainerState{
+				"root": intmodel.ContainerStateReady,
+				"app":  intmodel.ContainerStateReady,
+			}),
+			wantState: intmodel.CellStateReady,
+			wantLive:  true,
+			wantContainers: map[string]intmodel.ContainerState{
+				"root": intmodel.ContainerStateReady,
+				"app":  intmodel.ContainerStateReady,
+			},
+		},
+		{
+			name: "stale ready with a stopped root task is degraded",
+			cell: newCell(intmodel.CellStateReady),
+			stateFn: liveStates(map[string]intmodel.ContainerState{
+				"root": intmodel.ContainerStateStopped,
+				"app":  intmodel.ContainerStateReady,
+			}),
+			wantState: intmodel.CellStateDegraded,
+			wantLive:  true,
+			wantContainers: map[string]intmodel.ContainerState{
+				"root": intmodel.ContainerStateStopped,
+				"app":  intmodel.ContainerStateReady,
+			},
+		},
+		{
+			name: "stale ready with a missing workload is degraded",
+			cell: newCell(intmodel.CellStateReady),
+			stateFn: liveStates(map[string]intmodel.ContainerState{
+				"root": intmodel.ContainerStateReady,
+				"app":  intmodel.ContainerStateNotCreated,
+			}),
+			wantState: intmodel.CellStateDegraded,
+			wantLive:  true,
+			wantContainers: map[string]intmodel.ContainerState{
+				"root": intmodel.ContainerStateReady,
+				"app":  intmodel.ContainerStateNotCreated,
+			},
+		},
+		{
+			name: "unknown task state is inconclusive",
+			cell: newCell(intmodel.CellStateReady),
+			stateFn: liveStates(map[string]intmodel.ContainerState{
+				"root": intmodel.ContainerStateReady,
+				"app":  intmodel.ContainerStateUnknown,
+			}),
+			wantState: intmodel.CellStateReady,
+			wantLive:  true,
+			wantContainers: map[string]intmodel.ContainerState{
+				"root": intmodel.ContainerStateReady,
+				"app":  intmodel.ContainerStateUnknown,
+			},
+		},
+		{
+			name: "non-ready persisted state is kept",
+			cell: newCell(intmodel.CellStateStopped),
+			stateFn: liveStates(map[string]intmodel.ContainerState{
+				"root": intmodel.ContainerStateStopped,
+				"app":  intmodel.ContainerStateStopped,
+			}),
+			wantState: intmodel.CellStateStopped,
+			wantLive:  true,
+			wantContainers: map[string]intmodel.ContainerState{
+				"root": intmodel.ContainerStateStopped,
+				"app":  intmodel.ContainerStateStopped,
+			},
+		},
+		{
+			name: "containerd unreachable falls back to metadata",
+			cell: newCell(intmodel.CellStateReady),
+			stateFn: func(intmodel.Cell, string) (intmodel.ContainerState, error) {
+				return intmodel.ContainerStateUnknown, errors.New("dial containerd: connection refused")
+			},
+			wantState: intmodel.CellStateReady,
+			wantLive:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRunner := &fakeRunner{GetContainerStateFn: tt.stateFn}
+			ctrl := setupTestController(t, mockRunner)
+
+			got, err := ctrl.CellLiveStatus(tt.cell)
+			if err != nil {
+				t.Fatalf("CellLiveStatus: %v", err)
+			}
+			if got.State != tt.wantState {
+				t.Errorf("State = %v, want %v", got.State, tt.wantState)
+			}
+			if got.Live != tt.wantLive {
+				t.Errorf("Live = %v, want %v", got.Live, tt.wantLive)
+			}
+			if len(got.Containers) != len(tt.wantContainers) {
+				t.Fatalf("Containers = %v, want %v", got.Containers, tt.wantContainers)
+			}
+			for id, want := range tt.wantContainers {
+				if got.Containers[id] != want {
+					t.Errorf("Containers[%q] = %v, want %v", id, got.Containers[id], want)
+				}
+			}
+		})
+	}
+}
+
+func TestCellLiveStatus_RequiresScope(t *testing.T) {
+	ctrl := setupTestController(t, &fakeRunner{})
+	cell := buildTestCell("web", "", "s1", "st1")
+	if _, err := ctrl.CellLiveStatus(cell); !errors.Is(err, errdefs.ErrRealmNameRequired) {
+		t.Fatalf("err = %v, want ErrRealmNameRequired", err)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// CellUsageResult is one cell next to its live cgroup usage snapshot.
+type CellUsageResult struct {
+	Cell intmodel.Cell
+	// Usage is nil when the cell has no cgroup or its counters could not
+	// be read, for instance because the cell was deleted mid-listing.
+	Usage *ctr.CgroupUsage
+}
+
+// ListCellUsage reads the live cgroup counters of every cell under realm,
+// optionally narrowed to space and then stack. An empty realm walks every
+// realm. A read failure on one cell leaves its Usage nil instead of failing
+// the listing, so a cell that goes away while `kuke top` samples does not
+// hide the others.
+func (b *Exec) ListCellUsage(realm, space, stack string) ([]CellUsageResult, error) {
+	realm = strings.TrimSpace(realm)
+	space = strings.TrimSpace(space)
+	stack = strings.TrimSpace(stack)
+	if space != "" && realm == "" {
+		return nil, errdefs.ErrRealmNameRequired
+	}
+	if stack != "" && space == "" {
+		return nil, errdefs.ErrSpaceNameRequired
+	}
+
+	cells, err := b.runner.ListCells(realm, space, stack)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cells: %w", err)
+	}
+	out := make([]CellUsageResult, 0, len(cells))
+	for _, cell := range cells {
+		out = append(out, CellUsageResult{Cell: cell, Usage: b.cellCgroupUsage(cell)})
+	}
+	return out, nil
+}
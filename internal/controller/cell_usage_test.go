@@ -0,0 +1,72 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+func TestListCellUsage(t *testing.T) {
+	mock := &fakeRunner{
+		ListCellsFn: func(realmName, spaceName, stackName string) ([]intmodel.Cell, error) {
+			if realmName != "r1" || spaceName != "s1" || stackName != "" {
+				t.Errorf("ListCells(%q, %q, %q), want r1/s1", realmName, spaceName, stackName)
+			}
+			return []intmodel.Cell{
+				buildTestCell("api", "r1", "s1", "st1"),
+				buildTestCell("gone", "r1", "s1", "st1"),
+			}, nil
+		},
+		CellCgroupUsageFn: func(cell intmodel.Cell) (ctr.CgroupUsage, error) {
+			if cell.Metadata.Name == "gone" {
+				return ctr.CgroupUsage{}, errors.New("cgroup path does not exist")
+			}
+			return ctr.CgroupUsage{CPUUsageUsec: uint64Ptr(1_500_000)}, nil
+		},
+	}
+	ctrl := setupTestController(t, mock)
+
+	res, err := ctrl.ListCellUsage("r1", "s1", "")
+	if err != nil {
+		t.Fatalf("ListCellUsage: %v", err)
+	}
+	if len(res) != 2 {
+		t.Fatalf("ListCellUsage returned %d cells, want 2", len(res))
+	}
+	if res[0].Usage == nil || *res[0].Usage.CPUUsageUsec != 1_500_000 {
+		t.Errorf("api usage = %+v, want the cgroup snapshot", res[0].Usage)
+	}
+	if res[1].Usage != nil {
+		t.Errorf("gone usage = %+v, want nil when its cgroup cannot be read", res[1].Usage)
+	}
+}
+
+func TestListCellUsage_ScopeNeedsParents(t *testing.T) {
+	ctrl := setupTestController(t, &fakeRunner{})
+
+	if _, err := ctrl.ListCellUsage("", "s1", ""); !errors.Is(err, errdefs.ErrRealmNameRequired) {
+		t.Errorf("space without realm: error = %v, want %v", err, errdefs.ErrRealmNameRequired)
+	}
+	if _, err := ctrl.ListCellUsage("r1", "", "st1"); !errors.Is(err, errdefs.ErrSpaceNameRequired) {
+		t.Errorf("stack without space: error = %v, want %v", err, errdefs.ErrSpaceNameRequired)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// InspectContainer returns the OCI runtime spec and containerd record of a
+// container for `kuke inspect`. The container only needs to exist in
+// containerd; a stopped container is inspected the same as a running one.
+func (b *Exec) InspectContainer(container intmodel.Container) (ctr.ContainerInspection, error) {
+	name := strings.TrimSpace(container.Metadata.Name)
+	if name == "" {
+		return ctr.ContainerInspection{}, errdefs.ErrContainerNameRequired
+	}
+	cellName := strings.TrimSpace(container.Spec.CellName)
+	if cellName == "" {
+		return ctr.ContainerInspection{}, errdefs.ErrCellNameRequired
+	}
+
+	cell, err := b.runner.GetCell(intmodel.Cell{
+		Metadata: intmodel.CellMetadata{Name: cellName},
+		Spec: intmodel.CellSpec{
+			RealmName: container.Spec.RealmName,
+			SpaceName: container.Spec.SpaceName,
+			StackName: container.Spec.StackName,
+		},
+	})
+	if err != nil {
+		if errors.Is(err, errdefs.ErrCellNotFound) {
+			return ctr.ContainerInspection{}, fmt.Errorf("%w: %q", errdefs.ErrCellNotFound, cellName)
+		}
+		return ctr.ContainerInspection{}, fmt.Errorf("failed to get cell %q: %w", cellName, err)
+	}
+
+	return b.runner.InspectContainer(cell, name)
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	runtimespec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+func TestInspectContainer_PassesThroughRunnerInspection(t *testing.T) {
+	f := &fakeRunner{}
+	f.GetCellFn = func(_ intmodel.Cell) (intmodel.Cell, error) {
+		return buildTestCell("web", "test-realm", "test-space", "test-stack"), nil
+	}
+	var gotContainer string
+	f.InspectContainerFn = func(_ intmodel.Cell, containerID string) (ctr.ContainerInspection, error) {
+		gotContainer = containerID
+		return ctr.ContainerInspection{
+			Spec:   &runtimespec.Spec{Version: "1.2.0"},
+			Record: ctr.ContainerRecord{ID: "test-realm_test-space_test-stack_web_app"},
+		}, nil
+	}
+	ctrl := setupTestController(t, f)
+
+	res, err := ctrl.InspectContainer(buildRootFSLookup("app", "web"))
+	if err != nil {
+		t.Fatalf("InspectContainer: %v", err)
+	}
+	if gotContainer != "app" {
+		t.Errorf("runner asked for container %q, want app", gotContainer)
+	}
+	if res.Spec == nil || res.Spec.Version != "1.2.0" {
+		t.Errorf("Spec = %+v, want version 1.2.0", res.Spec)
+	}
+	if res.Record.ID != "test-realm_test-space_test-stack_web_app" {
+		t.Errorf("Record.ID = %q", res.Record.ID)
+	}
+}
+
+func TestInspectContainer_CellNotFound(t *testing.T) {
+	f := &fakeRunner{}
+	f.GetCellFn = func(_ intmodel.Cell) (intmodel.Cell, error) {
+		return intmodel.Cell{}, errdefs.ErrCellNotFound
+	}
+	ctrl := setupTestController(t, f)
+
+	_, err := ctrl.InspectContainer(buildRootFSLookup("app", "missing"))
+	if !errors.Is(err, errdefs.ErrCellNotFound) {
+		t.Fatalf("err = %v, want ErrCellNotFound", err)
+	}
+}
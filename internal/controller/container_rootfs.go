@@ -0,0 +1,78 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/containerfs"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// ContainerRootFSResult reports where a running container's filesystem view
+// is reachable on the host.
+type ContainerRootFSResult struct {
+	// PID is the host PID of the container's running task.
+	PID uint32
+	// RootFS is the /proc/<pid>/root path resolving into the container's
+	// mount namespace.
+	RootFS string
+}
+
+// ResolveContainerRootFS resolves the host-side path of a running
+// container's filesystem view for `kuke cp`. Refuses with
+// errdefs.ErrTaskNotRunning when the container has no running task: the
+// rootfs of a stopped container is not mounted, and copying into the
+// snapshot behind containerd's back would be lost on the next recreate.
+func (b *Exec) ResolveContainerRootFS(container intmodel.Container) (ContainerRootFSResult, error) {
+	var res ContainerRootFSResult
+
+	name := strings.TrimSpace(container.Metadata.Name)
+	if name == "" {
+		return res, errdefs.ErrContainerNameRequired
+	}
+	cellName := strings.TrimSpace(container.Spec.CellName)
+	if cellName == "" {
+		return res, errdefs.ErrCellNameRequired
+	}
+
+	cell, err := b.runner.GetCell(intmodel.Cell{
+		Metadata: intmodel.CellMetadata{Name: cellName},
+		Spec: intmodel.CellSpec{
+			RealmName: container.Spec.RealmName,
+			SpaceName: container.Spec.SpaceName,
+			StackName: container.Spec.StackName,
+		},
+	})
+	if err != nil {
+		if errors.Is(err, errdefs.ErrCellNotFound) {
+			return res, fmt.Errorf("%w: %q", errdefs.ErrCellNotFound, cellName)
+		}
+		return res, fmt.Errorf("failed to get cell %q: %w", cellName, err)
+	}
+
+	pid, err := b.runner.ContainerTaskPID(cell, name)
+	if err != nil {
+		return res, err
+	}
+	res.PID = pid
+	res.RootFS = containerfs.RootFSPath(pid)
+	return res, nil
+}
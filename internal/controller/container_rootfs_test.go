@@ -0,0 +1,108 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+func buildRootFSLookup(name, cell string) intmodel.Container {
+	return intmodel.Container{
+		Metadata: intmodel.ContainerMetadata{Name: name},
+		Spec: intmodel.ContainerSpec{
+			ID:        name,
+			RealmName: "test-realm",
+			SpaceName: "test-space",
+			StackName: "test-stack",
+			CellName:  cell,
+		},
+	}
+}
+
+func TestResolveContainerRootFS_RunningTask(t *testing.T) {
+	f := &fakeRunner{}
+	f.GetCellFn = func(_ intmodel.Cell) (intmodel.Cell, error) {
+		return buildTestCell("web", "test-realm", "test-space", "test-stack"), nil
+	}
+	f.ContainerTaskPIDFn = func(_ intmodel.Cell, containerID string) (uint32, error) {
+		if containerID != "app" {
+			return 0, fmt.Errorf("unexpected container %q", containerID)
+		}
+		return 4242, nil
+	}
+	ctrl := setupTestController(t, f)
+
+	res, err := ctrl.ResolveContainerRootFS(buildRootFSLookup("app", "web"))
+	if err != nil {
+		t.Fatalf("ResolveContainerRootFS: %v", err)
+	}
+	if res.PID != 4242 {
+		t.Errorf("PID = %d, want 4242", res.PID)
+	}
+	if res.RootFS != "/proc/4242/root" {
+		t.Errorf("RootFS = %q, want /proc/4242/root", res.RootFS)
+	}
+}
+
+func TestResolveContainerRootFS_TaskNotRunning(t *testing.T) {
+	f := &fakeRunner{}
+	f.GetCellFn = func(_ intmodel.Cell) (intmodel.Cell, error) {
+		return buildTestCell("web", "test-realm", "test-space", "test-stack"), nil
+	}
+	f.ContainerTaskPIDFn = func(_ intmodel.Cell, _ string) (uint32, error) {
+		return 0, errdefs.ErrTaskNotRunning
+	}
+	ctrl := setupTestController(t, f)
+
+	_, err := ctrl.ResolveContainerRootFS(buildRootFSLookup("app", "web"))
+	if !errors.Is(err, errdefs.ErrTaskNotRunning) {
+		t.Fatalf("err = %v, want ErrTaskNotRunning", err)
+	}
+}
+
+func TestResolveContainerRootFS_CellNotFound(t *testing.T) {
+	f := &fakeRunner{}
+	f.GetCellFn = func(_ intmodel.Cell) (intmodel.Cell, error) {
+		return intmodel.Cell{}, errdefs.ErrCellNotFound
+	}
+	ctrl := setupTestController(t, f)
+
+	_, err := ctrl.ResolveContainerRootFS(buildRootFSLookup("app", "missing"))
+	if !errors.Is(err, errdefs.ErrCellNotFound) {
+		t.Fatalf("err = %v, want ErrCellNotFound", err)
+	}
+}
+
+func TestResolveContainerRootFS_RequiresNames(t *testing.T) {
+	ctrl := setupTestController(t, &fakeRunner{})
+
+	if _, err := ctrl.ResolveContainerRootFS(buildRootFSLookup("", "web")); !errors.Is(
+		err, errdefs.ErrContainerNameRequired,
+	) {
+		t.Errorf("empty container: err = %v, want ErrContainerNameRequired", err)
+	}
+	if _, err := ctrl.ResolveContainerRootFS(buildRootFSLookup("app", "")); !errors.Is(
+		err, errdefs.ErrCellNameRequired,
+	) {
+		t.Errorf("empty cell: err = %v, want ErrCellNameRequired", err)
+	}
+}
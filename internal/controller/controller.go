@@ -20,10 +20,12 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/eminwux/kukeon/internal/consts"
 	"github.com/eminwux/kukeon/internal/controller/runner"
+	"github.com/eminwux/kukeon/internal/events"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 	"github.com/eminwux/kukeon/internal/util/diskpressure"
 )
@@ -62,6 +64,11 @@ type Controller interface {
 	PurgeSpace(space intmodel.Space, force, cascade bool) (PurgeSpaceResult, error)
 	PurgeStack(stack intmodel.Stack, force, cascade bool) (PurgeStackResult, error)
 	PurgeCell(cell intmodel.Cell, force, cascade bool) (PurgeCellResult, error)
+	RenameRealm(realm intmodel.Realm, newName string) (RenameRealmResult, error)
+	RenameSpace(space intmodel.Space, newName string) (RenameSpaceResult, error)
+	RenameStack(stack intmodel.Stack, newName string) (RenameStackResult, error)
+	RenameCell(cell intmodel.Cell, newName string) (RenameCellResult, error)
+	ExportRealm(name string, includeSecrets bool) (ExportRealmResult, error)
 	RefreshAll() (RefreshResult, error)
 	ReconcileCells() (ReconcileResult, error)
 	Uninstall(opts UninstallOptions) (UninstallReport, error)
@@ -82,11 +89,20 @@ type Exec struct {
 	// warn/threshold/rate-limit branches without a real full volume. Issue
 	// #1035.
 	diskSampler func(string) (diskpressure.Usage, error)
+	// events is the audit trail under RunPath that create, delete, purge,
+	// start, stop, kill and apply append to; `kuke get events` reads it.
+	events *events.Log
+	// quotaLocks holds a *sync.Mutex per realm name; withCellQuota takes it
+	// across the quota check and the write of a cell under quota.
+	quotaLocks sync.Map
 }
 
 type Options struct {
 	RunPath          string
 	ContainerdSocket string
+	// ContainerdTimeout bounds each connection attempt to containerd. Zero
+	// falls back to ctr.DefaultConnectTimeout.
+	ContainerdTimeout time.Duration
 	// KukeondImage is the container image for the kukeond system cell. If empty,
 	// bootstrap will skip provisioning the system cell (but still provision the
 	// system realm/space/stack).
@@ -133,6 +149,19 @@ type Options struct {
 	// via `kukeond serve --disk-pressure-block-percent` /
 	// KUKEOND_DISK_PRESSURE_BLOCK_PCT. Zero disables the guard. Issue #1035.
 	DiskPressureBlockPercent int
+	// ReconcileConcurrency bounds how many cells ReconcileCells works on in
+	// parallel. Values below 2 reconcile cells one at a time. Surfaces via
+	// `kukeond serve --reconcile-concurrency` / KUKEOND_RECONCILE_CONCURRENCY.
+	ReconcileConcurrency int
+	// BackupRetention is how many metadata backups under <RunPath>/backups
+	// are kept; older ones are removed after each new backup. Zero or less
+	// falls back to DefaultBackupRetention. Surfaces via
+	// `kukeond serve --backup-retention` / KUKEOND_BACKUP_RETENTION.
+	BackupRetention int
+	// RequireBackup makes a failed metadata backup abort the purge or
+	// cascade delete it precedes instead of only logging a warning. Surfaces
+	// via `kukeond serve --require-backup` / KUKEOND_REQUIRE_BACKUP.
+	RequireBackup bool
 }
 
 func NewControllerExec(ctx context.Context, logger *slog.Logger, opts Options) *Exec {
@@ -142,6 +171,7 @@ func NewControllerExec(ctx context.Context, logger *slog.Logger, opts Options) *
 		opts:   opts,
 		runner: runner.NewRunner(ctx, logger, runner.Options{
 			ContainerdSocket:         opts.ContainerdSocket,
+			ContainerdTimeout:        opts.ContainerdTimeout,
 			RunPath:                  opts.RunPath,
 			ForceRegenerateCNI:       opts.ForceRegenerateCNI,
 			KukeonGroupGID:           opts.KukeondSocketGID,
@@ -150,6 +180,7 @@ func NewControllerExec(ctx context.Context, logger *slog.Logger, opts Options) *
 			DiskPressureBlockPercent: opts.DiskPressureBlockPercent,
 		}),
 		diskWarner: diskpressure.NewWarner(diskPressureWarnInterval),
+		events:     events.NewLog(opts.RunPath, events.Options{}),
 	}
 }
 
@@ -162,6 +193,7 @@ func NewControllerExecForTesting(ctx context.Context, logger *slog.Logger, opts
 		opts:       opts,
 		runner:     r,
 		diskWarner: diskpressure.NewWarner(diskPressureWarnInterval),
+		events:     events.NewLog(opts.RunPath, events.Options{}),
 	}
 }
 
@@ -89,19 +89,27 @@ type fakeRunner struct {
 	// Cell methods
 	GetCellFn                 func(cell intmodel.Cell) (intmodel.Cell, error)
 	ListCellsFn               func(realmName, spaceName, stackName string) ([]intmodel.Cell, error)
+	ListAllCellsFn            func() ([]intmodel.Cell, error)
 	CreateCellFn              func(cell intmodel.Cell) (intmodel.Cell, error)
 	EnsureCellFn              func(cell intmodel.Cell) (intmodel.Cell, error)
 	StartCellFn               func(cell intmodel.Cell) (intmodel.Cell, error)
 	StopCellFn                func(cell intmodel.Cell) (intmodel.Cell, error)
 	KillCellFn                func(cell intmodel.Cell) (intmodel.Cell, error)
 	DeleteCellFn              func(cell intmodel.Cell) error
+	RenameCellFn              func(cell intmodel.Cell, newName string) (intmodel.Cell, error)
 	ExistsCellRootContainerFn func(cell intmodel.Cell) (bool, error)
 	UpdateCellMetadataFn      func(cell intmodel.Cell) error
 
 	ReapplyAttachableSocketPermsFn func(spec intmodel.ContainerSpec)
 
 	// Container methods
-	ListContainersFn    func(realmName, spaceName, stackName, cellName string) ([]intmodel.ContainerSpec, error)
+	ListContainersFn     func(realmName, spaceName, stackName, cellName string) ([]intmodel.ContainerSpec, error)
+	ListAllContainersFn  func() ([]intmodel.ContainerSpec, error)
+	ListContainersPageFn func(
+		realmName, spaceName, stackName, cellName string,
+		limit int,
+		continueToken string,
+	) ([]intmodel.ContainerSpec, string, error)
 	CreateContainerFn   func(cell intmodel.Cell, container intmodel.ContainerSpec) (intmodel.Cell, error)
 	EnsureContainerFn   func(cell intmodel.Cell, container intmodel.ContainerSpec) (intmodel.Cell, error)
 	StartContainerFn    func(cell intmodel.Cell, containerID string) (intmodel.Cell, error)
@@ -109,9 +117,14 @@ type fakeRunner struct {
 	KillContainerFn     func(cell intmodel.Cell, containerID string) error
 	DeleteContainerFn   func(cell intmodel.Cell, containerID string) error
 	GetContainerStateFn func(cell intmodel.Cell, containerID string) (intmodel.ContainerState, error)
+	ContainerTaskPIDFn  func(cell intmodel.Cell, containerID string) (uint32, error)
+	InspectContainerFn  func(cell intmodel.Cell, containerID string) (ctr.ContainerInspection, error)
 
 	// Utility methods
-	ExistsCgroupFn func(doc any) (bool, error)
+	ExistsCgroupFn    func(doc any) (bool, error)
+	CellCgroupUsageFn func(cell intmodel.Cell) (ctr.CgroupUsage, error)
+	// KukeonCgroupUsageFn backs the node summary's root cgroup read.
+	KukeonCgroupUsageFn func() (ctr.CgroupUsage, error)
 
 	// Purge methods
 	PurgeRealmFn     func(realm intmodel.Realm) (bool, error)
@@ -142,6 +155,8 @@ type fakeRunner struct {
 	RefreshCellFn   func(cell intmodel.Cell) (intmodel.Cell, int, error)
 	ReconcileCellFn func(cell intmodel.Cell) (intmodel.Cell, runner.ReconcileOutcome, error)
 
+	WatchContainerExitsFn func(ctx context.Context) error
+
 	// Image methods
 	LoadImageFn            func(namespace string, reader io.Reader) ([]string, error)
 	ListImagesFn           func(namespace string) ([]ctr.ImageInfo, error)
@@ -150,6 +165,11 @@ type fakeRunner struct {
 	ContainerRootChainIDFn func(namespace, containerID string) (string, error)
 	DeleteImageFn          func(namespace, ref string) error
 	PruneImagesFn          func(namespace string) (ctr.PruneResult, error)
+
+	// Orphan container methods
+	FindOrphanContainersFn  func(realm intmodel.Realm) ([]runner.OrphanContainer, error)
+	PruneCellContainersFn   func(cell intmodel.Cell) ([]string, error)
+	DeleteOrphanContainerFn func(realm intmodel.Realm, orphan runner.OrphanContainer) error
 }
 
 // Realm methods
@@ -445,7 +465,14 @@ func (f *fakeRunner) ListCells(realmName, spaceName, stackName string) ([]intmod
 	return nil, errors.New("unexpected call to ListCells")
 }
 
-func (f *fakeRunner) CreateCell(cell intmodel.Cell) (intmodel.Cell, error) {
+func (f *fakeRunner) ListAllCells() ([]intmodel.Cell, error) {
+	if f.ListAllCellsFn != nil {
+		return f.ListAllCellsFn()
+	}
+	return nil, errors.New("unexpected call to ListAllCells")
+}
+
+func (f *fakeRunner) CreateCell(_ context.Context, cell intmodel.Cell) (intmodel.Cell, error) {
 	if f.CreateCellFn != nil {
 		return f.CreateCellFn(cell)
 	}
@@ -459,7 +486,7 @@ func (f *fakeRunner) EnsureCell(cell intmodel.Cell) (intmodel.Cell, error) {
 	return intmodel.Cell{}, errors.New("unexpected call to EnsureCell")
 }
 
-func (f *fakeRunner) StartCell(cell intmodel.Cell) (intmodel.Cell, error) {
+func (f *fakeRunner) StartCell(_ context.Context, cell intmodel.Cell) (intmodel.Cell, error) {
 	if f.StartCellFn != nil {
 		return f.StartCellFn(cell)
 	}
@@ -487,6 +514,13 @@ func (f *fakeRunner) DeleteCell(cell intmodel.Cell) error {
 	return errors.New("unexpected call to DeleteCell")
 }
 
+func (f *fakeRunner) RenameCell(cell intmodel.Cell, newName string) (intmodel.Cell, error) {
+	if f.RenameCellFn != nil {
+		return f.RenameCellFn(cell, newName)
+	}
+	return intmodel.Cell{}, errors.New("unexpected call to RenameCell")
+}
+
 func (f *fakeRunner) ExistsCellRootContainer(cell intmodel.Cell) (bool, error) {
 	if f.ExistsCellRootContainerFn != nil {
 		return f.ExistsCellRootContainerFn(cell)
@@ -518,7 +552,29 @@ func (f *fakeRunner) ListContainers(
 	return nil, errors.New("unexpected call to ListContainers")
 }
 
-func (f *fakeRunner) CreateContainer(cell intmodel.Cell, container intmodel.ContainerSpec) (intmodel.Cell, error) {
+func (f *fakeRunner) ListAllContainers() ([]intmodel.ContainerSpec, error) {
+	if f.ListAllContainersFn != nil {
+		return f.ListAllContainersFn()
+	}
+	return nil, errors.New("unexpected call to ListAllContainers")
+}
+
+func (f *fakeRunner) ListContainersPage(
+	realmName, spaceName, stackName, cellName string,
+	limit int,
+	continueToken string,
+) ([]intmodel.ContainerSpec, string, error) {
+	if f.ListContainersPageFn != nil {
+		return f.ListContainersPageFn(realmName, spaceName, stackName, cellName, limit, continueToken)
+	}
+	return nil, "", errors.New("unexpected call to ListContainersPage")
+}
+
+func (f *fakeRunner) CreateContainer(
+	_ context.Context,
+	cell intmodel.Cell,
+	container intmodel.ContainerSpec,
+) (intmodel.Cell, error) {
 	if f.CreateContainerFn != nil {
 		return f.CreateContainerFn(cell, container)
 	}
@@ -567,6 +623,20 @@ func (f *fakeRunner) GetContainerState(cell intmodel.Cell, containerID string) (
 	return intmodel.ContainerStateUnknown, errors.New("unexpected call to GetContainerState")
 }
 
+func (f *fakeRunner) ContainerTaskPID(cell intmodel.Cell, containerID string) (uint32, error) {
+	if f.ContainerTaskPIDFn != nil {
+		return f.ContainerTaskPIDFn(cell, containerID)
+	}
+	return 0, errors.New("unexpected call to ContainerTaskPID")
+}
+
+func (f *fakeRunner) InspectContainer(cell intmodel.Cell, containerID string) (ctr.ContainerInspection, error) {
+	if f.InspectContainerFn != nil {
+		return f.InspectContainerFn(cell, containerID)
+	}
+	return ctr.ContainerInspection{}, errors.New("unexpected call to InspectContainer")
+}
+
 // Utility methods
 
 func (f *fakeRunner) ExistsCgroup(doc any) (bool, error) {
@@ -576,9 +646,23 @@ func (f *fakeRunner) ExistsCgroup(doc any) (bool, error) {
 	return false, errors.New("unexpected call to ExistsCgroup")
 }
 
+func (f *fakeRunner) CellCgroupUsage(cell intmodel.Cell) (ctr.CgroupUsage, error) {
+	if f.CellCgroupUsageFn != nil {
+		return f.CellCgroupUsageFn(cell)
+	}
+	return ctr.CgroupUsage{}, errors.New("unexpected call to CellCgroupUsage")
+}
+
+func (f *fakeRunner) KukeonCgroupUsage() (ctr.CgroupUsage, error) {
+	if f.KukeonCgroupUsageFn != nil {
+		return f.KukeonCgroupUsageFn()
+	}
+	return ctr.CgroupUsage{}, errors.New("unexpected call to KukeonCgroupUsage")
+}
+
 // Purge methods
 
-func (f *fakeRunner) PurgeRealm(realm intmodel.Realm) (bool, error) {
+func (f *fakeRunner) PurgeRealm(_ context.Context, realm intmodel.Realm) (bool, error) {
 	if f.PurgeRealmFn != nil {
 		return f.PurgeRealmFn(realm)
 	}
@@ -719,6 +803,13 @@ func (f *fakeRunner) ReconcileCell(cell intmodel.Cell) (intmodel.Cell, runner.Re
 	return intmodel.Cell{}, runner.ReconcileOutcome{}, errors.New("unexpected call to ReconcileCell")
 }
 
+func (f *fakeRunner) WatchContainerExits(ctx context.Context) error {
+	if f.WatchContainerExitsFn != nil {
+		return f.WatchContainerExitsFn(ctx)
+	}
+	return errors.New("unexpected call to WatchContainerExits")
+}
+
 func (f *fakeRunner) LoadImage(namespace string, reader io.Reader) ([]string, error) {
 	if f.LoadImageFn != nil {
 		return f.LoadImageFn(namespace, reader)
@@ -772,6 +863,27 @@ func (f *fakeRunner) PruneImages(namespace string) (ctr.PruneResult, error) {
 	return ctr.PruneResult{}, errors.New("unexpected call to PruneImages")
 }
 
+func (f *fakeRunner) FindOrphanContainers(realm intmodel.Realm) ([]runner.OrphanContainer, error) {
+	if f.FindOrphanContainersFn != nil {
+		return f.FindOrphanContainersFn(realm)
+	}
+	return nil, errors.New("unexpected call to FindOrphanContainers")
+}
+
+func (f *fakeRunner) PruneCellContainers(cell intmodel.Cell) ([]string, error) {
+	if f.PruneCellContainersFn != nil {
+		return f.PruneCellContainersFn(cell)
+	}
+	return nil, errors.New("unexpected call to PruneCellContainers")
+}
+
+func (f *fakeRunner) DeleteOrphanContainer(realm intmodel.Realm, orphan runner.OrphanContainer) error {
+	if f.DeleteOrphanContainerFn != nil {
+		return f.DeleteOrphanContainerFn(realm, orphan)
+	}
+	return errors.New("unexpected call to DeleteOrphanContainer")
+}
+
 // Test helper functions
 
 // setupTestLogger creates a test logger that discards output.
@@ -889,6 +1001,30 @@ func buildTestCell(name, realmName, spaceName, stackName string) intmodel.Cell {
 	}
 }
 
+// withReadyParents stubs GetRealm, GetSpace and GetStack, when the test has
+// not, to report Ready parents so ValidateCell lets the cell through.
+func withReadyParents(f *fakeRunner) *fakeRunner {
+	if f.GetRealmFn == nil {
+		f.GetRealmFn = func(realm intmodel.Realm) (intmodel.Realm, error) {
+			realm.Status.State = intmodel.RealmStateReady
+			return realm, nil
+		}
+	}
+	if f.GetSpaceFn == nil {
+		f.GetSpaceFn = func(space intmodel.Space) (intmodel.Space, error) {
+			space.Status.State = intmodel.SpaceStateReady
+			return space, nil
+		}
+	}
+	if f.GetStackFn == nil {
+		f.GetStackFn = func(stack intmodel.Stack) (intmodel.Stack, error) {
+			stack.Status.State = intmodel.StackStateReady
+			return stack, nil
+		}
+	}
+	return f
+}
+
 // buildTestContainer creates a test container with the specified parameters.
 func buildTestContainer(name, realmName, spaceName, stackName, cellName, image string) intmodel.Container {
 	return intmodel.Container{
@@ -0,0 +1,59 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"time"
+
+	"github.com/eminwux/kukeon/internal/cordon"
+)
+
+// CordonResult reports the node's cordon state after a cordon or uncordon.
+// Changed is false when the node was already in the requested state.
+type CordonResult struct {
+	Cordoned bool
+	Changed  bool
+	Since    time.Time
+	Reason   string
+}
+
+// CordonNode closes the node to new cells and containers. Cells that already
+// exist keep running and can still be started, stopped, and deleted; the
+// reconcile loop keeps ensuring them. Cordoning a cordoned node keeps the
+// original timestamp and reason.
+func (b *Exec) CordonNode(reason string) (CordonResult, error) {
+	m, changed, err := cordon.Cordon(b.opts.RunPath, reason, time.Now())
+	if err != nil {
+		return CordonResult{}, err
+	}
+	if changed {
+		b.logger.InfoContext(b.ctx, "node cordoned", "reason", reason)
+	}
+	return CordonResult{Cordoned: true, Changed: changed, Since: m.Since, Reason: m.Reason}, nil
+}
+
+// UncordonNode reopens a cordoned node to new cells and containers.
+func (b *Exec) UncordonNode() (CordonResult, error) {
+	changed, err := cordon.Uncordon(b.opts.RunPath)
+	if err != nil {
+		return CordonResult{}, err
+	}
+	if changed {
+		b.logger.InfoContext(b.ctx, "node uncordoned")
+	}
+	return CordonResult{Changed: changed}, nil
+}
@@ -17,13 +17,16 @@
 package controller
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/eminwux/kukeon/internal/consts"
 	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/events"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/tracing"
 	"github.com/eminwux/kukeon/internal/util/naming"
 )
 
@@ -58,7 +61,13 @@ type ContainerCreationOutcome struct {
 // then starts the cell's containers. See createCellInternal for the full
 // contract.
 func (b *Exec) CreateCell(cell intmodel.Cell) (CreateCellResult, error) {
-	return b.createCellInternal(cell, true)
+	ctx, span := tracing.Start(b.ctx, "controller.CreateCell", cellSpanAttributes(cell)...)
+	res, err := b.createCellInternal(ctx, cell, true)
+	tracing.End(span, err)
+	if res.Created || err != nil {
+		b.recordEvent(events.ActionCreate, cellEvent(cell), err)
+	}
+	return res, err
 }
 
 // MaterializeCell creates a new cell record (or ensures an existing cell's
@@ -69,7 +78,11 @@ func (b *Exec) CreateCell(cell intmodel.Cell) (CreateCellResult, error) {
 // `kuke run <cfg>` (materialise + start + attach) and (for Config-lineage
 // cells) `kuke restart <name>` (reconcile + start on OutOfSync).
 func (b *Exec) MaterializeCell(cell intmodel.Cell) (CreateCellResult, error) {
-	return b.createCellInternal(cell, false)
+	res, err := b.createCellInternal(b.ctx, cell, false)
+	if res.Created || err != nil {
+		b.recordEvent(events.ActionCreate, cellEvent(cell), err)
+	}
+	return res, err
 }
 
 // normalizeCellInputs validates the cell's required identity fields (name,
@@ -165,6 +178,7 @@ func applyDefaultCellLabels(cell *intmodel.Cell, realm, space, stack, name strin
 // Extracted from createCellInternal to keep that function under the funlen
 // budget after #818's startAfterCreate branch was added.
 func (b *Exec) acquireOrCreateCell(
+	ctx context.Context,
 	cell, lookupCell intmodel.Cell,
 	res *CreateCellResult,
 	preContainerExists map[string]bool,
@@ -175,9 +189,16 @@ func (b *Exec) acquireOrCreateCell(
 			return intmodel.Cell{}, false, fmt.Errorf("%w: %w", errdefs.ErrGetCell, getErr)
 		}
 		res.MetadataExistsPre = false
-		resultCell, createErr := b.runner.CreateCell(cell)
+		var resultCell intmodel.Cell
+		createErr := b.withCellQuota(cell, func() error {
+			var err error
+			if resultCell, err = b.runner.CreateCell(ctx, cell); err != nil {
+				return fmt.Errorf("%w: %w", errdefs.ErrCreateCell, err)
+			}
+			return nil
+		})
 		if createErr != nil {
-			return intmodel.Cell{}, false, fmt.Errorf("%w: %w", errdefs.ErrCreateCell, createErr)
+			return intmodel.Cell{}, false, createErr
 		}
 		return resultCell, true, nil
 	}
@@ -218,7 +239,11 @@ func (b *Exec) acquireOrCreateCell(
 // is required, the realm name is required, the space name is required, the
 // stack name is required, the cell cgroup does not exist, the root container
 // does not exist, or the cell creation fails.
-func (b *Exec) createCellInternal(cell intmodel.Cell, startAfterCreate bool) (CreateCellResult, error) {
+func (b *Exec) createCellInternal(
+	ctx context.Context,
+	cell intmodel.Cell,
+	startAfterCreate bool,
+) (CreateCellResult, error) {
 	var res CreateCellResult
 
 	name, realm, space, stack, err := normalizeCellInputs(&cell)
@@ -226,6 +251,10 @@ func (b *Exec) createCellInternal(cell intmodel.Cell, startAfterCreate bool) (Cr
 		return res, err
 	}
 
+	if err = b.ValidateCell(cell); err != nil {
+		return res, err
+	}
+
 	// Auto-provision per-cell (ensure) volumes before any container spec is
 	// built, so the volume-reference resolver finds the on-disk directory at
 	// container-create time (#1017).
@@ -247,7 +276,7 @@ func (b *Exec) createCellInternal(cell intmodel.Cell, startAfterCreate bool) (Cr
 		},
 	}
 
-	resultCell, wasCreated, err := b.acquireOrCreateCell(cell, lookupCell, &res, preContainerExists)
+	resultCell, wasCreated, err := b.acquireOrCreateCell(ctx, cell, lookupCell, &res, preContainerExists)
 	if err != nil {
 		return res, err
 	}
@@ -264,7 +293,7 @@ func (b *Exec) createCellInternal(cell intmodel.Cell, startAfterCreate bool) (Cr
 		// downstream runner.StartCell rebuilds the non-root container OCI
 		// specs, so it needs the runtime env from the inbound RPC. Issue #834.
 		resultCell.Spec.RuntimeEnv = cell.Spec.RuntimeEnv
-		resultCell, err = b.runner.StartCell(resultCell)
+		resultCell, err = b.runner.StartCell(ctx, resultCell)
 		if err != nil {
 			return res, fmt.Errorf("failed to start cell containers: %w", err)
 		}
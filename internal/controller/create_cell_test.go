@@ -100,7 +100,7 @@ func TestCreateCell_NewCellCreation(t *testing.T) {
 				tt.setupRunner(mockRunner)
 			}
 
-			ctrl := setupTestController(t, mockRunner)
+			ctrl := setupTestController(t, withReadyParents(mockRunner))
 			cell := buildTestCell(tt.cellName, tt.realmName, tt.spaceName, tt.stackName)
 
 			result, err := ctrl.CreateCell(cell)
@@ -246,7 +246,7 @@ func TestCreateCell_ExistingCellReconciliation(t *testing.T) {
 				tt.setupRunner(mockRunner)
 			}
 
-			ctrl := setupTestController(t, mockRunner)
+			ctrl := setupTestController(t, withReadyParents(mockRunner))
 			cell := buildTestCell(tt.cellName, tt.realmName, tt.spaceName, tt.stackName)
 
 			result, err := ctrl.CreateCell(cell)
@@ -512,7 +512,7 @@ func TestCreateCell_ContainerOutcomes(t *testing.T) {
 				tt.setupRunner(mockRunner)
 			}
 
-			ctrl := setupTestController(t, mockRunner)
+			ctrl := setupTestController(t, withReadyParents(mockRunner))
 
 			result, err := ctrl.CreateCell(tt.cell)
 
@@ -612,7 +612,7 @@ func TestCreateCell_ValidationErrors(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRunner := &fakeRunner{}
-			ctrl := setupTestController(t, mockRunner)
+			ctrl := setupTestController(t, withReadyParents(mockRunner))
 
 			cell := intmodel.Cell{
 				Metadata: intmodel.CellMetadata{
@@ -861,7 +861,7 @@ func TestCreateCell_DefaultLabels(t *testing.T) {
 				tt.setupRunner(mockRunner)
 			}
 
-			ctrl := setupTestController(t, mockRunner)
+			ctrl := setupTestController(t, withReadyParents(mockRunner))
 
 			result, err := ctrl.CreateCell(tt.cell)
 			if err != nil {
@@ -961,7 +961,7 @@ func TestCreateCell_DefaultSpecID(t *testing.T) {
 				tt.setupRunner(mockRunner)
 			}
 
-			ctrl := setupTestController(t, mockRunner)
+			ctrl := setupTestController(t, withReadyParents(mockRunner))
 
 			result, err := ctrl.CreateCell(tt.cell)
 			if err != nil {
@@ -1083,7 +1083,7 @@ func TestCreateCell_ContainerOwnership(t *testing.T) {
 				tt.setupRunner(mockRunner)
 			}
 
-			ctrl := setupTestController(t, mockRunner)
+			ctrl := setupTestController(t, withReadyParents(mockRunner))
 
 			result, err := ctrl.CreateCell(tt.cell)
 			if err != nil {
@@ -1231,7 +1231,7 @@ func TestCreateCell_RunnerErrors(t *testing.T) {
 				tt.setupRunner(mockRunner)
 			}
 
-			ctrl := setupTestController(t, mockRunner)
+			ctrl := setupTestController(t, withReadyParents(mockRunner))
 			cell := buildTestCell(tt.cellName, tt.realmName, tt.spaceName, tt.stackName)
 
 			_, err := ctrl.CreateCell(cell)
@@ -1380,7 +1380,7 @@ func TestCreateCell_NameTrimming(t *testing.T) {
 				tt.setupRunner(mockRunner)
 			}
 
-			ctrl := setupTestController(t, mockRunner)
+			ctrl := setupTestController(t, withReadyParents(mockRunner))
 			cell := intmodel.Cell{
 				Metadata: intmodel.CellMetadata{
 					Name: tt.cellName,
@@ -1446,7 +1446,7 @@ func TestCreateCell_RejectsInvalidCharacters(t *testing.T) {
 				},
 			}
 
-			ctrl := setupTestController(t, mockRunner)
+			ctrl := setupTestController(t, withReadyParents(mockRunner))
 			cell := intmodel.Cell{
 				Metadata: intmodel.CellMetadata{Name: tt.cellName},
 				Spec: intmodel.CellSpec{
@@ -1492,7 +1492,7 @@ func TestCreateCell_RejectsInvalidContainerCharacters(t *testing.T) {
 				},
 			}
 
-			ctrl := setupTestController(t, mockRunner)
+			ctrl := setupTestController(t, withReadyParents(mockRunner))
 			cell := intmodel.Cell{
 				Metadata: intmodel.CellMetadata{Name: "valid-cell"},
 				Spec: intmodel.CellSpec{
@@ -23,6 +23,7 @@ import (
 
 	"github.com/eminwux/kukeon/internal/consts"
 	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/events"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 	"github.com/eminwux/kukeon/internal/util/naming"
 )
@@ -49,6 +50,14 @@ type CreateRealmResult struct {
 // The error is returned if the realm name is required, the namespace is required,
 // the realm cgroup does not exist, the containerd namespace does not exist, or the realm creation fails.
 func (b *Exec) CreateRealm(realm intmodel.Realm) (CreateRealmResult, error) {
+	res, err := b.createRealm(realm)
+	if res.Created || err != nil {
+		b.recordEvent(events.ActionCreate, realmEvent(realm), err)
+	}
+	return res, err
+}
+
+func (b *Exec) createRealm(realm intmodel.Realm) (CreateRealmResult, error) {
 	var res CreateRealmResult
 
 	name := strings.TrimSpace(realm.Metadata.Name)
@@ -62,6 +71,9 @@ func (b *Exec) CreateRealm(realm intmodel.Realm) (CreateRealmResult, error) {
 		// Update realm with default namespace
 		realm.Spec.Namespace = namespace
 	}
+	if err := naming.ValidateRealmNamespace(namespace); err != nil {
+		return res, err
+	}
 
 	// Ensure default labels are set
 	if realm.Metadata.Labels == nil {
@@ -98,7 +110,12 @@ func (b *Exec) CreateRealm(realm intmodel.Realm) (CreateRealmResult, error) {
 		if err != nil {
 			return res, fmt.Errorf("failed to check if realm cgroup exists: %w", err)
 		}
-		res.ContainerdNamespaceExistsPre, err = b.runner.ExistsRealmContainerdNamespace(namespace)
+		// Probe the stored namespace, not the requested one: the namespace is
+		// fixed at creation, so an override on a re-run must not redirect the
+		// pre-state check at a namespace this realm does not own.
+		res.ContainerdNamespaceExistsPre, err = b.runner.ExistsRealmContainerdNamespace(
+			internalRealmPre.Spec.Namespace,
+		)
 		if err != nil {
 			return res, fmt.Errorf("%w: %w", errdefs.ErrCheckNamespaceExists, err)
 		}
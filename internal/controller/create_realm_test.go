@@ -329,6 +329,28 @@ func TestCreateRealm_ValidationErrors(t *testing.T) {
 	}
 }
 
+// TestCreateRealm_RejectsInvalidNamespace covers a Spec.Namespace override
+// that containerd would refuse: it must fail before the runner is reached.
+func TestCreateRealm_RejectsInvalidNamespace(t *testing.T) {
+	mockRunner := &fakeRunner{
+		GetRealmFn: func(_ intmodel.Realm) (intmodel.Realm, error) {
+			t.Fatal("GetRealm called despite invalid namespace — validation should run first")
+			return intmodel.Realm{}, nil
+		},
+	}
+
+	ctrl := setupTestController(t, mockRunner)
+	realm := intmodel.Realm{
+		Metadata: intmodel.RealmMetadata{Name: "test-realm"},
+		Spec:     intmodel.RealmSpec{Namespace: "team/alpha"},
+	}
+
+	_, err := ctrl.CreateRealm(realm)
+	if !errors.Is(err, errdefs.ErrInvalidRealmNamespace) {
+		t.Errorf("expected ErrInvalidRealmNamespace, got %v", err)
+	}
+}
+
 // TestCreateRealm_RejectsInvalidCharacters covers the AC for #168: realm
 // names containing "_" or "/" must be rejected at the controller boundary
 // before any runner state mutation. The fakeRunner deliberately fails the
@@ -407,6 +429,27 @@ func TestCreateRealm_DefaultNamespace(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:      "custom namespace overrides the default",
+			realmName: "test-realm",
+			namespace: "shared-images",
+			setupRunner: func(f *fakeRunner) {
+				f.GetRealmFn = func(_ intmodel.Realm) (intmodel.Realm, error) {
+					return intmodel.Realm{}, errdefs.ErrRealmNotFound
+				}
+				f.CreateRealmFn = func(realm intmodel.Realm) (intmodel.Realm, error) {
+					if realm.Spec.Namespace != "shared-images" {
+						return intmodel.Realm{}, errors.New("custom namespace not passed to runner")
+					}
+					return buildTestRealm("test-realm", "shared-images"), nil
+				}
+			},
+			wantResult: func(t *testing.T, result controller.CreateRealmResult) {
+				if result.Realm.Spec.Namespace != "shared-images" {
+					t.Errorf("expected namespace to be %q, got %q", "shared-images", result.Realm.Spec.Namespace)
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
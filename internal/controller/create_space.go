@@ -23,6 +23,7 @@ import (
 
 	"github.com/eminwux/kukeon/internal/consts"
 	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/events"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 	"github.com/eminwux/kukeon/internal/util/naming"
 )
@@ -49,6 +50,14 @@ type CreateSpaceResult struct {
 // The error is returned if the space name is required, the realm name is required,
 // the space cgroup does not exist, the cni network does not exist, or the space creation fails.
 func (b *Exec) CreateSpace(space intmodel.Space) (CreateSpaceResult, error) {
+	res, err := b.createSpace(space)
+	if res.Created || err != nil {
+		b.recordEvent(events.ActionCreate, spaceEvent(space), err)
+	}
+	return res, err
+}
+
+func (b *Exec) createSpace(space intmodel.Space) (CreateSpaceResult, error) {
 	var res CreateSpaceResult
 
 	name := strings.TrimSpace(space.Metadata.Name)
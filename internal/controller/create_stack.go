@@ -23,6 +23,7 @@ import (
 
 	"github.com/eminwux/kukeon/internal/consts"
 	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/events"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 	"github.com/eminwux/kukeon/internal/util/naming"
 )
@@ -46,6 +47,14 @@ type CreateStackResult struct {
 // The error is returned if the stack name is required, the realm name is required,
 // the space name is required, the stack cgroup does not exist, or the stack creation fails.
 func (b *Exec) CreateStack(stack intmodel.Stack) (CreateStackResult, error) {
+	res, err := b.createStack(stack)
+	if res.Created || err != nil {
+		b.recordEvent(events.ActionCreate, stackEvent(stack), err)
+	}
+	return res, err
+}
+
+func (b *Exec) createStack(stack intmodel.Stack) (CreateStackResult, error) {
 	var res CreateStackResult
 
 	name := strings.TrimSpace(stack.Metadata.Name)
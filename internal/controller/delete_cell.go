@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/events"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 )
 
@@ -33,6 +34,12 @@ type DeleteCellResult struct {
 
 // DeleteCell deletes a cell. Always deletes all containers first.
 func (b *Exec) DeleteCell(cell intmodel.Cell) (DeleteCellResult, error) {
+	res, err := b.deleteCell(cell)
+	b.recordEvent(events.ActionDelete, cellEvent(cell), err)
+	return res, err
+}
+
+func (b *Exec) deleteCell(cell intmodel.Cell) (DeleteCellResult, error) {
 	var res DeleteCellResult
 
 	internalCell, err := b.validateAndGetCell(cell)
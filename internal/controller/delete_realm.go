@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/events"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 )
 
@@ -32,11 +33,20 @@ type DeleteRealmResult struct {
 	MetadataDeleted            bool
 	CgroupDeleted              bool
 	ContainerdNamespaceDeleted bool
+	// BackupPath is the metadata backup taken before a cascade or forced
+	// delete; empty when none was written.
+	BackupPath string
 }
 
 // DeleteRealm deletes a realm. If cascade is true, deletes all spaces first.
 // If force is true, skips validation of child resources.
 func (b *Exec) DeleteRealm(realm intmodel.Realm, force, cascade bool) (DeleteRealmResult, error) {
+	res, err := b.deleteRealm(realm, force, cascade)
+	b.recordEvent(events.ActionDelete, realmEvent(realm), err)
+	return res, err
+}
+
+func (b *Exec) deleteRealm(realm intmodel.Realm, force, cascade bool) (DeleteRealmResult, error) {
 	var res DeleteRealmResult
 
 	name := strings.TrimSpace(realm.Metadata.Name)
@@ -59,21 +69,20 @@ func (b *Exec) DeleteRealm(realm intmodel.Realm, force, cascade bool) (DeleteRea
 		Deleted: []string{},
 	}
 
-	// Use private cascade method for deletion
-	// Track deleted spaces for result building (list before deletion to know what will be deleted)
-	if cascade {
-		spaces, listErr := b.runner.ListSpaces(name)
-		if listErr != nil {
-			return res, fmt.Errorf("failed to list spaces: %w", listErr)
-		}
-		// Track spaces that will be deleted
-		for _, space := range spaces {
-			res.Deleted = append(res.Deleted, fmt.Sprintf("space:%s", space.Metadata.Name))
+	if cascade || force {
+		backupPath, backupErr := b.backupBeforeDestroy(name)
+		if backupErr != nil {
+			return res, backupErr
 		}
+		res.BackupPath = backupPath
 	}
 
-	// Delete the resource itself (private method handles cascade deletion)
-	if err = b.deleteRealmCascade(getResult, force, cascade); err != nil {
+	// Delete the resource itself (private method handles cascade deletion).
+	// Only the spaces actually removed are reported, so an interrupted cascade
+	// returns an accurate partial result.
+	deleted, err := b.deleteRealmCascade(getResult, force, cascade)
+	res.Deleted = append(res.Deleted, deleted...)
+	if err != nil {
 		return res, fmt.Errorf("%w: %w", errdefs.ErrDeleteRealm, err)
 	}
 
@@ -88,34 +97,44 @@ func (b *Exec) DeleteRealm(realm intmodel.Realm, force, cascade bool) (DeleteRea
 }
 
 // deleteRealmCascade handles cascade deletion logic using runner methods directly.
-// It returns an error if deletion fails, but does not return result types.
-func (b *Exec) deleteRealmCascade(realm intmodel.Realm, force, cascade bool) error {
+// It returns the spaces it removed ("space:<name>") and an error if deletion
+// fails. Cancellation of the parent context is checked between spaces; each
+// space's own cascade is completed before the check.
+func (b *Exec) deleteRealmCascade(realm intmodel.Realm, force, cascade bool) ([]string, error) {
 	realmName := strings.TrimSpace(realm.Metadata.Name)
 
 	// If cascade is true, list and delete child resources (spaces)
+	var deleted []string
 	if cascade {
 		spaces, listErr := b.runner.ListSpaces(realmName)
 		if listErr != nil {
-			return fmt.Errorf("failed to list spaces: %w", listErr)
+			return nil, fmt.Errorf("failed to list spaces: %w", listErr)
 		}
 		for _, space := range spaces {
-			if delErr := b.deleteSpaceCascade(space, force, cascade); delErr != nil {
-				return fmt.Errorf("failed to delete space %q: %w", space.Metadata.Name, delErr)
+			if cancelErr := b.checkCanceled(fmt.Sprintf("space %q", space.Metadata.Name)); cancelErr != nil {
+				return deleted, cancelErr
+			}
+			if _, delErr := b.deleteSpaceCascade(space, force, cascade); delErr != nil {
+				return deleted, fmt.Errorf("failed to delete space %q: %w", space.Metadata.Name, delErr)
 			}
+			deleted = append(deleted, fmt.Sprintf("space:%s", space.Metadata.Name))
 		}
 	} else if !force {
 		// Validate no child resources exist
 		spaces, listErr := b.runner.ListSpaces(realmName)
 		if listErr != nil {
-			return fmt.Errorf("failed to list spaces: %w", listErr)
+			return nil, fmt.Errorf("failed to list spaces: %w", listErr)
 		}
 		if len(spaces) > 0 {
-			return fmt.Errorf("%w: realm %q has %d space(s). Use --cascade to delete them or --force to skip validation",
+			return nil, fmt.Errorf("%w: realm %q has %d space(s). Use --cascade to delete them or --force to skip validation",
 				errdefs.ErrResourceHasDependencies, realmName, len(spaces))
 		}
 	}
 
+	if cancelErr := b.checkCanceled(fmt.Sprintf("realm %q", realmName)); cancelErr != nil {
+		return deleted, cancelErr
+	}
+
 	// Delete the resource itself via runner
-	err := b.runner.DeleteRealm(realm)
-	return err
+	return deleted, b.runner.DeleteRealm(realm)
 }
@@ -19,6 +19,7 @@
 package controller_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -854,3 +855,59 @@ func TestDeleteRealm_PartialCascadeFailure(t *testing.T) {
 		})
 	}
 }
+
+// TestDeleteRealm_CascadeCanceledBetweenSpaces cancels the parent context
+// while the first space is being deleted: that space's cascade completes,
+// the second space and the realm itself are left in place, and the result
+// reports only what was actually removed.
+func TestDeleteRealm_CascadeCanceledBetweenSpaces(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	mockRunner := &fakeRunner{}
+	mockRunner.GetRealmFn = func(_ intmodel.Realm) (intmodel.Realm, error) {
+		return buildTestRealm("test-realm", "test-namespace"), nil
+	}
+	mockRunner.ListSpacesFn = func(_ string) ([]intmodel.Space, error) {
+		return []intmodel.Space{
+			buildTestSpace("space1", "test-realm"),
+			buildTestSpace("space2", "test-realm"),
+		}, nil
+	}
+	mockRunner.ListStacksFn = func(_, _ string) ([]intmodel.Stack, error) {
+		return []intmodel.Stack{}, nil
+	}
+	var deletedSpaces []string
+	mockRunner.DeleteSpaceFn = func(space intmodel.Space) error {
+		deletedSpaces = append(deletedSpaces, space.Metadata.Name)
+		cancel()
+		return nil
+	}
+	realmDeleted := false
+	mockRunner.DeleteRealmFn = func(_ intmodel.Realm) error {
+		realmDeleted = true
+		return nil
+	}
+
+	ctrl := controller.NewControllerExecForTesting(ctx, setupTestLogger(t), controller.Options{}, mockRunner)
+	result, err := ctrl.DeleteRealm(buildTestRealm("test-realm", ""), false, true)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected error to wrap context.Canceled, got %v", err)
+	}
+	if !errors.Is(err, errdefs.ErrDeleteRealm) {
+		t.Errorf("expected error to wrap ErrDeleteRealm, got %v", err)
+	}
+	if len(deletedSpaces) != 1 || deletedSpaces[0] != "space1" {
+		t.Errorf("expected only space1 to be deleted, got %v", deletedSpaces)
+	}
+	if realmDeleted {
+		t.Error("expected runner.DeleteRealm not to be called after cancellation")
+	}
+	if len(result.Deleted) != 1 || result.Deleted[0] != "space:space1" {
+		t.Errorf("expected Deleted to be [space:space1], got %v", result.Deleted)
+	}
+	if result.MetadataDeleted || result.CgroupDeleted || result.ContainerdNamespaceDeleted {
+		t.Error("expected realm deletion flags to stay false on a canceled cascade")
+	}
+}
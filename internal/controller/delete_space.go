@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/events"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 )
 
@@ -41,6 +42,12 @@ type DeleteSpaceResult struct {
 // DeleteSpace deletes a space. If cascade is true, deletes all stacks first.
 // If force is true, skips validation of child resources.
 func (b *Exec) DeleteSpace(space intmodel.Space, force, cascade bool) (DeleteSpaceResult, error) {
+	res, err := b.deleteSpace(space, force, cascade)
+	b.recordEvent(events.ActionDelete, spaceEvent(space), err)
+	return res, err
+}
+
+func (b *Exec) deleteSpace(space intmodel.Space, force, cascade bool) (DeleteSpaceResult, error) {
 	var res DeleteSpaceResult
 
 	name := strings.TrimSpace(space.Metadata.Name)
@@ -68,21 +75,12 @@ func (b *Exec) DeleteSpace(space intmodel.Space, force, cascade bool) (DeleteSpa
 		Deleted:   []string{},
 	}
 
-	// Use private cascade method for deletion
-	// Track deleted stacks for result building (list before deletion to know what will be deleted)
-	if cascade {
-		stacks, listErr := b.runner.ListStacks(realmName, name)
-		if listErr != nil {
-			return res, fmt.Errorf("failed to list stacks: %w", listErr)
-		}
-		// Track stacks that will be deleted
-		for _, stack := range stacks {
-			res.Deleted = append(res.Deleted, fmt.Sprintf("stack:%s", stack.Metadata.Name))
-		}
-	}
-
-	// Delete the resource itself (private method handles cascade deletion)
-	if err = b.deleteSpaceCascade(internalSpace, force, cascade); err != nil {
+	// Delete the resource itself (private method handles cascade deletion).
+	// Only the stacks actually removed are reported, so an interrupted
+	// cascade returns an accurate partial result.
+	deleted, err := b.deleteSpaceCascade(internalSpace, force, cascade)
+	res.Deleted = append(res.Deleted, deleted...)
+	if err != nil {
 		return res, fmt.Errorf("%w: %w", errdefs.ErrDeleteSpace, err)
 	}
 
@@ -94,34 +92,44 @@ func (b *Exec) DeleteSpace(space intmodel.Space, force, cascade bool) (DeleteSpa
 }
 
 // deleteSpaceCascade handles cascade deletion logic using runner methods directly.
-// It returns an error if deletion fails, but does not return result types.
-func (b *Exec) deleteSpaceCascade(space intmodel.Space, force, cascade bool) error {
+// It returns the stacks it removed ("stack:<name>") and an error if deletion
+// fails. Cancellation of the parent context is checked between stacks.
+func (b *Exec) deleteSpaceCascade(space intmodel.Space, force, cascade bool) ([]string, error) {
 	realmName := strings.TrimSpace(space.Spec.RealmName)
 	spaceName := strings.TrimSpace(space.Metadata.Name)
 
 	// If cascade is true, list and delete child resources (stacks)
+	var deleted []string
 	if cascade {
 		stacks, listErr := b.runner.ListStacks(realmName, spaceName)
 		if listErr != nil {
-			return fmt.Errorf("failed to list stacks: %w", listErr)
+			return nil, fmt.Errorf("failed to list stacks: %w", listErr)
 		}
 		for _, stack := range stacks {
-			if delErr := b.deleteStackCascade(stack, force, cascade); delErr != nil {
-				return fmt.Errorf("failed to delete stack %q: %w", stack.Metadata.Name, delErr)
+			if cancelErr := b.checkCanceled(fmt.Sprintf("stack %q", stack.Metadata.Name)); cancelErr != nil {
+				return deleted, cancelErr
 			}
+			if _, delErr := b.deleteStackCascade(stack, force, cascade); delErr != nil {
+				return deleted, fmt.Errorf("failed to delete stack %q: %w", stack.Metadata.Name, delErr)
+			}
+			deleted = append(deleted, fmt.Sprintf("stack:%s", stack.Metadata.Name))
 		}
 	} else if !force {
 		// Validate no child resources exist
 		stacks, listErr := b.runner.ListStacks(realmName, spaceName)
 		if listErr != nil {
-			return fmt.Errorf("failed to list stacks: %w", listErr)
+			return nil, fmt.Errorf("failed to list stacks: %w", listErr)
 		}
 		if len(stacks) > 0 {
-			return fmt.Errorf("%w: space %q has %d stack(s). Use --cascade to delete them or --force to skip validation",
+			return nil, fmt.Errorf("%w: space %q has %d stack(s). Use --cascade to delete them or --force to skip validation",
 				errdefs.ErrResourceHasDependencies, spaceName, len(stacks))
 		}
 	}
 
+	if cancelErr := b.checkCanceled(fmt.Sprintf("space %q", spaceName)); cancelErr != nil {
+		return deleted, cancelErr
+	}
+
 	// Delete the resource itself via runner
-	return b.runner.DeleteSpace(space)
+	return deleted, b.runner.DeleteSpace(space)
 }
@@ -1060,3 +1060,56 @@ func TestDeleteSpace_GetSpaceMetadataExistsFalse(t *testing.T) {
 		})
 	}
 }
+
+// TestDeleteSpace_CascadeDeletesCellsBeforeParents pins the cascade order: a
+// space cascade walks into every stack and deletes its cells (which stops
+// their containers and detaches them from CNI in the runner) before the stack,
+// and every stack before the space.
+func TestDeleteSpace_CascadeDeletesCellsBeforeParents(t *testing.T) {
+	var calls []string
+	mockRunner := &fakeRunner{
+		GetSpaceFn: func(_ intmodel.Space) (intmodel.Space, error) {
+			return buildTestSpace("test-space", "test-realm"), nil
+		},
+		ListStacksFn: func(_, _ string) ([]intmodel.Stack, error) {
+			return []intmodel.Stack{
+				buildTestStack("stack1", "test-realm", "test-space"),
+				buildTestStack("stack2", "test-realm", "test-space"),
+			}, nil
+		},
+		ListCellsFn: func(_, _, stack string) ([]intmodel.Cell, error) {
+			return []intmodel.Cell{buildTestCell(stack+"-cell", "test-realm", "test-space", stack)}, nil
+		},
+		DeleteCellFn: func(cell intmodel.Cell) error {
+			calls = append(calls, "cell:"+cell.Metadata.Name)
+			return nil
+		},
+		DeleteStackFn: func(stack intmodel.Stack) error {
+			calls = append(calls, "stack:"+stack.Metadata.Name)
+			return nil
+		},
+		DeleteSpaceFn: func(space intmodel.Space) error {
+			calls = append(calls, "space:"+space.Metadata.Name)
+			return nil
+		},
+	}
+	ctrl := setupTestController(t, mockRunner)
+
+	if _, err := ctrl.DeleteSpace(buildTestSpace("test-space", "test-realm"), false, true); err != nil {
+		t.Fatalf("DeleteSpace() error = %v", err)
+	}
+
+	want := []string{
+		"cell:stack1-cell", "stack:stack1",
+		"cell:stack2-cell", "stack:stack2",
+		"space:test-space",
+	}
+	if len(calls) != len(want) {
+		t.Fatalf("runner calls = %v, want %v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("runner calls = %v, want %v", calls, want)
+		}
+	}
+}
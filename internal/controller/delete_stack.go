@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/events"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 )
 
@@ -41,6 +42,12 @@ type DeleteStackResult struct {
 // DeleteStack deletes a stack. If cascade is true, deletes all cells first.
 // If force is true, skips validation of child resources.
 func (b *Exec) DeleteStack(stack intmodel.Stack, force, cascade bool) (DeleteStackResult, error) {
+	res, err := b.deleteStack(stack, force, cascade)
+	b.recordEvent(events.ActionDelete, stackEvent(stack), err)
+	return res, err
+}
+
+func (b *Exec) deleteStack(stack intmodel.Stack, force, cascade bool) (DeleteStackResult, error) {
 	var res DeleteStackResult
 
 	name := strings.TrimSpace(stack.Metadata.Name)
@@ -74,21 +81,12 @@ func (b *Exec) DeleteStack(stack intmodel.Stack, force, cascade bool) (DeleteSta
 		Deleted:   []string{},
 	}
 
-	// Use private cascade method for deletion
-	// Track deleted cells for result building (list before deletion to know what will be deleted)
-	if cascade {
-		cells, listErr := b.runner.ListCells(realmName, spaceName, name)
-		if listErr != nil {
-			return res, fmt.Errorf("failed to list cells: %w", listErr)
-		}
-		// Track cells that will be deleted
-		for _, cell := range cells {
-			res.Deleted = append(res.Deleted, fmt.Sprintf("cell:%s", cell.Metadata.Name))
-		}
-	}
-
-	// Delete the resource itself (private method handles cascade deletion)
-	if err = b.deleteStackCascade(internalStack, force, cascade); err != nil {
+	// Delete the resource itself (private method handles cascade deletion).
+	// Only the cells actually removed are reported, so an interrupted
+	// cascade returns an accurate partial result.
+	deleted, err := b.deleteStackCascade(internalStack, force, cascade)
+	res.Deleted = append(res.Deleted, deleted...)
+	if err != nil {
 		return res, fmt.Errorf("%w: %w", errdefs.ErrDeleteStack, err)
 	}
 
@@ -99,35 +97,46 @@ func (b *Exec) DeleteStack(stack intmodel.Stack, force, cascade bool) (DeleteSta
 }
 
 // deleteStackCascade handles cascade deletion logic using runner methods directly.
-// It returns an error if deletion fails, but does not return result types.
-func (b *Exec) deleteStackCascade(stack intmodel.Stack, force, cascade bool) error {
+// It returns the cells it removed ("cell:<name>") and an error if deletion
+// fails. Cancellation of the parent context is checked between cells; a cell
+// deletion already in flight is completed first.
+func (b *Exec) deleteStackCascade(stack intmodel.Stack, force, cascade bool) ([]string, error) {
 	realmName := strings.TrimSpace(stack.Spec.RealmName)
 	spaceName := strings.TrimSpace(stack.Spec.SpaceName)
 	stackName := strings.TrimSpace(stack.Metadata.Name)
 
 	// If cascade is true, list and delete child resources (cells)
+	var deleted []string
 	if cascade {
 		cells, listErr := b.runner.ListCells(realmName, spaceName, stackName)
 		if listErr != nil {
-			return fmt.Errorf("failed to list cells: %w", listErr)
+			return nil, fmt.Errorf("failed to list cells: %w", listErr)
 		}
 		for _, cell := range cells {
+			if cancelErr := b.checkCanceled(fmt.Sprintf("cell %q", cell.Metadata.Name)); cancelErr != nil {
+				return deleted, cancelErr
+			}
 			if delErr := b.deleteCellInternal(cell); delErr != nil {
-				return fmt.Errorf("failed to delete cell %q: %w", cell.Metadata.Name, delErr)
+				return deleted, fmt.Errorf("failed to delete cell %q: %w", cell.Metadata.Name, delErr)
 			}
+			deleted = append(deleted, fmt.Sprintf("cell:%s", cell.Metadata.Name))
 		}
 	} else if !force {
 		// Validate no child resources exist
 		cells, listErr := b.runner.ListCells(realmName, spaceName, stackName)
 		if listErr != nil {
-			return fmt.Errorf("failed to list cells: %w", listErr)
+			return nil, fmt.Errorf("failed to list cells: %w", listErr)
 		}
 		if len(cells) > 0 {
-			return fmt.Errorf("%w: stack %q has %d cell(s). Use --cascade to delete them or --force to skip validation",
+			return nil, fmt.Errorf("%w: stack %q has %d cell(s). Use --cascade to delete them or --force to skip validation",
 				errdefs.ErrResourceHasDependencies, stackName, len(cells))
 		}
 	}
 
+	if cancelErr := b.checkCanceled(fmt.Sprintf("stack %q", stackName)); cancelErr != nil {
+		return deleted, cancelErr
+	}
+
 	// Delete the resource itself via runner
-	return b.runner.DeleteStack(stack)
+	return deleted, b.runner.DeleteStack(stack)
 }
@@ -0,0 +1,251 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/controller"
+	"github.com/eminwux/kukeon/internal/controller/runner"
+	"github.com/eminwux/kukeon/internal/drain"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// drainFixture backs a fakeRunner with an in-memory cell store so drain and
+// undrain can be observed end to end: stops and starts flip the stored state,
+// and any delete fails the test.
+type drainFixture struct {
+	mu    sync.Mutex
+	cells map[string]intmodel.Cell
+	order []string
+}
+
+func newDrainFixture(t *testing.T, cells ...intmodel.Cell) (*drainFixture, *fakeRunner) {
+	t.Helper()
+	fx := &drainFixture{cells: map[string]intmodel.Cell{}}
+	for _, c := range cells {
+		fx.cells[c.Metadata.Name] = c
+		fx.order = append(fx.order, c.Metadata.Name)
+	}
+	f := &fakeRunner{}
+	f.GetRealmFn = func(realm intmodel.Realm) (intmodel.Realm, error) {
+		if realm.Metadata.Name != "r1" {
+			return intmodel.Realm{}, errdefs.ErrRealmNotFound
+		}
+		return realm, nil
+	}
+	f.GetSpaceFn = func(space intmodel.Space) (intmodel.Space, error) { return space, nil }
+	f.GetStackFn = func(stack intmodel.Stack) (intmodel.Stack, error) { return stack, nil }
+	f.ListRealmsFn = func() ([]intmodel.Realm, error) {
+		return []intmodel.Realm{{Metadata: intmodel.RealmMetadata{Name: "r1"}}}, nil
+	}
+	f.ListSpacesFn = func(string) ([]intmodel.Space, error) {
+		return []intmodel.Space{{Metadata: intmodel.SpaceMetadata{Name: "s1"}}}, nil
+	}
+	f.ListStacksFn = func(string, string) ([]intmodel.Stack, error) {
+		return []intmodel.Stack{{Metadata: intmodel.StackMetadata{Name: "st1"}}}, nil
+	}
+	f.ListCellsFn = func(string, string, string) ([]intmodel.Cell, error) {
+		fx.mu.Lock()
+		defer fx.mu.Unlock()
+		out := make([]intmodel.Cell, 0, len(fx.order))
+		for _, name := range fx.order {
+			out = append(out, fx.cells[name])
+		}
+		return out, nil
+	}
+	f.GetCellFn = func(cell intmodel.Cell) (intmodel.Cell, error) {
+		fx.mu.Lock()
+		defer fx.mu.Unlock()
+		c, ok := fx.cells[cell.Metadata.Name]
+		if !ok {
+			return intmodel.Cell{}, errdefs.ErrCellNotFound
+		}
+		return c, nil
+	}
+	f.ExistsCgroupFn = func(any) (bool, error) { return true, nil }
+	f.ExistsCellRootContainerFn = func(intmodel.Cell) (bool, error) { return true, nil }
+	f.StopCellFn = func(cell intmodel.Cell) (intmodel.Cell, error) {
+		cell.Status.State = intmodel.CellStateStopped
+		return cell, nil
+	}
+	f.StartCellFn = func(cell intmodel.Cell) (intmodel.Cell, error) {
+		cell.Status.State = intmodel.CellStateReady
+		return cell, nil
+	}
+	f.UpdateCellMetadataFn = func(cell intmodel.Cell) error {
+		fx.mu.Lock()
+		defer fx.mu.Unlock()
+		fx.cells[cell.Metadata.Name] = cell
+		return nil
+	}
+	f.DeleteCellFn = func(cell intmodel.Cell) error {
+		t.Errorf("drain must not delete cell %q", cell.Metadata.Name)
+		return errors.New("unexpected delete")
+	}
+	return fx, f
+}
+
+func (fx *drainFixture) state(name string) intmodel.CellState {
+	fx.mu.Lock()
+	defer fx.mu.Unlock()
+	return fx.cells[name].Status.State
+}
+
+func drainTestCell(name string, state intmodel.CellState) intmodel.Cell {
+	c := buildTestCell(name, "r1", "s1", "st1")
+	c.Status.State = state
+	return c
+}
+
+func TestDrainScope_StopsCellsAndPreservesMetadata(t *testing.T) {
+	runPath := t.TempDir()
+	fx, f := newDrainFixture(t,
+		drainTestCell("a", intmodel.CellStateReady),
+		drainTestCell("b", intmodel.CellStateDegraded),
+		drainTestCell("c", intmodel.CellStateStopped),
+	)
+	ctrl := setupTestControllerWithRunPath(t, f, runPath)
+
+	res, err := ctrl.DrainScope(controller.DrainRef{Realm: "r1"})
+	if err != nil {
+		t.Fatalf("DrainScope: %v", err)
+	}
+	if !res.Changed {
+		t.Error("Changed = false on first drain")
+	}
+	if len(res.Cells) != 2 || res.Cells[0] != "s1/st1/a" || res.Cells[1] != "s1/st1/b" {
+		t.Errorf("stopped cells = %v, want [s1/st1/a s1/st1/b]", res.Cells)
+	}
+	if res.Skipped != 1 {
+		t.Errorf("Skipped = %d, want 1 (c was already stopped)", res.Skipped)
+	}
+	for _, name := range []string{"a", "b", "c"} {
+		if got := fx.state(name); got != intmodel.CellStateStopped {
+			t.Errorf("cell %s state = %v, want Stopped", name, got)
+		}
+	}
+	if len(fx.cells) != 3 {
+		t.Errorf("cell metadata count = %d, want 3 (drain must not delete)", len(fx.cells))
+	}
+
+	st, err := drain.Load(runPath)
+	if err != nil {
+		t.Fatalf("drain.Load: %v", err)
+	}
+	scope, ok := st.Covering("r1", "s1", "st1")
+	if !ok {
+		t.Fatal("scope r1 not recorded as drained")
+	}
+	if len(scope.Cells) != 2 {
+		t.Errorf("recorded cells = %v, want a and b only", scope.Cells)
+	}
+}
+
+func TestDrainScope_ReconcileAndStartLeaveDrainedCellsAlone(t *testing.T) {
+	runPath := t.TempDir()
+	_, f := newDrainFixture(t, drainTestCell("a", intmodel.CellStateReady))
+	var reconciled int
+	f.ReconcileCellFn = func(cell intmodel.Cell) (intmodel.Cell, runner.ReconcileOutcome, error) {
+		reconciled++
+		return cell, runner.ReconcileOutcome{}, nil
+	}
+	ctrl := setupTestControllerWithRunPath(t, f, runPath)
+
+	if _, err := ctrl.DrainScope(controller.DrainRef{Realm: "r1", Space: "s1"}); err != nil {
+		t.Fatalf("DrainScope: %v", err)
+	}
+
+	res, err := ctrl.ReconcileCells()
+	if err != nil {
+		t.Fatalf("ReconcileCells: %v", err)
+	}
+	if reconciled != 0 || res.CellsScanned != 0 {
+		t.Errorf("reconcile touched drained cells: calls=%d scanned=%d", reconciled, res.CellsScanned)
+	}
+
+	_, err = ctrl.StartCell(drainTestCell("a", intmodel.CellStateStopped))
+	if !errors.Is(err, errdefs.ErrScopeDrained) {
+		t.Errorf("StartCell err = %v, want ErrScopeDrained", err)
+	}
+}
+
+func TestUndrainScope_StartsOnlyDrainedCells(t *testing.T) {
+	runPath := t.TempDir()
+	fx, f := newDrainFixture(t,
+		drainTestCell("a", intmodel.CellStateReady),
+		drainTestCell("c", intmodel.CellStateStopped),
+	)
+	ctrl := setupTestControllerWithRunPath(t, f, runPath)
+
+	if _, err := ctrl.DrainScope(controller.DrainRef{Realm: "r1"}); err != nil {
+		t.Fatalf("DrainScope: %v", err)
+	}
+	res, err := ctrl.UndrainScope(controller.DrainRef{Realm: "r1"})
+	if err != nil {
+		t.Fatalf("UndrainScope: %v", err)
+	}
+	if !res.Changed || len(res.Cells) != 1 || res.Cells[0] != "s1/st1/a" {
+		t.Errorf("undrain result = %+v, want a started", res)
+	}
+	if got := fx.state("a"); got != intmodel.CellStateReady {
+		t.Errorf("cell a state = %v, want Ready", got)
+	}
+	if got := fx.state("c"); got != intmodel.CellStateStopped {
+		t.Errorf("cell c state = %v, want Stopped (it was down before the drain)", got)
+	}
+	st, err := drain.Load(runPath)
+	if err != nil {
+		t.Fatalf("drain.Load: %v", err)
+	}
+	if len(st.Scopes) != 0 {
+		t.Errorf("drain marker still has scopes after undrain: %+v", st.Scopes)
+	}
+
+	again, err := ctrl.UndrainScope(controller.DrainRef{Realm: "r1"})
+	if err != nil || again.Changed {
+		t.Errorf("second undrain = %+v, %v; want unchanged no-op", again, err)
+	}
+}
+
+func TestDrainScope_ValidatesScope(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  controller.DrainRef
+		want error
+	}{
+		{"missing realm", controller.DrainRef{}, errdefs.ErrRealmNameRequired},
+		{"stack without space", controller.DrainRef{Realm: "r1", Stack: "st1"}, errdefs.ErrSpaceNameRequired},
+		{"unknown realm", controller.DrainRef{Realm: "nope"}, errdefs.ErrRealmNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runPath := t.TempDir()
+			_, f := newDrainFixture(t)
+			ctrl := setupTestControllerWithRunPath(t, f, runPath)
+			if _, err := ctrl.DrainScope(tt.ref); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if st, _ := drain.Load(runPath); len(st.Scopes) != 0 {
+				t.Errorf("invalid drain left a marker: %+v", st.Scopes)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/events"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// recordEvent appends what the controller just did to the node's event
+// log: action on the resource e names, succeeded unless err is set. A
+// failed write is logged and otherwise ignored — the audit trail never fails
+// the action it records.
+func (b *Exec) recordEvent(action string, e events.Event, err error) {
+	e.Time = time.Now().UTC()
+	e.Action = action
+	e.Outcome = events.OutcomeSucceeded
+	if err != nil {
+		e.Outcome = events.OutcomeFailed
+		e.Message = err.Error()
+	}
+	if appendErr := b.events.Append(e); appendErr != nil {
+		b.logger.WarnContext(b.ctx, "failed to record event",
+			"action", action, "kind", e.Kind, "error", appendErr)
+	}
+}
+
+// recordApplyEvent records an applied realm, space, stack or cell that was
+// created, changed or failed; an unchanged resource leaves no event, so a
+// repeated apply does not flood the log. The message carries the apply
+// action ("created", "updated") on success.
+func (b *Exec) recordApplyEvent(e events.Event, res ResourceResult) {
+	if e.Kind == "" || res.Action == actionUnchanged {
+		return
+	}
+	if res.Error == nil {
+		e.Message = res.Action
+	}
+	b.recordEvent(events.ActionApply, e, res.Error)
+}
+
+// ListEvents returns the recorded events that pass filter, oldest first.
+func (b *Exec) ListEvents(filter events.Filter) ([]events.Event, error) {
+	out, err := b.events.List(filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errdefs.ErrListEvents, err)
+	}
+	return out, nil
+}
+
+func realmEvent(realm intmodel.Realm) events.Event {
+	return events.Event{Kind: events.KindRealm, Realm: realm.Metadata.Name}
+}
+
+func spaceEvent(space intmodel.Space) events.Event {
+	return events.Event{Kind: events.KindSpace, Realm: space.Spec.RealmName, Space: space.Metadata.Name}
+}
+
+func stackEvent(stack intmodel.Stack) events.Event {
+	return events.Event{
+		Kind:  events.KindStack,
+		Realm: stack.Spec.RealmName,
+		Space: stack.Spec.SpaceName,
+		Stack: stack.Metadata.Name,
+	}
+}
+
+func cellEvent(cell intmodel.Cell) events.Event {
+	return events.Event{
+		Kind:  events.KindCell,
+		Realm: cell.Spec.RealmName,
+		Space: cell.Spec.SpaceName,
+		Stack: cell.Spec.StackName,
+		Cell:  cell.Metadata.Name,
+	}
+}
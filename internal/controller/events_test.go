@@ -0,0 +1,87 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/controller"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/events"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+func TestCreateRealm_RecordsEvent(t *testing.T) {
+	mockRunner := &fakeRunner{}
+	realms := map[string]bool{}
+	mockRunner.GetRealmFn = func(realm intmodel.Realm) (intmodel.Realm, error) {
+		if !realms[realm.Metadata.Name] {
+			return intmodel.Realm{}, errdefs.ErrRealmNotFound
+		}
+		return realm, nil
+	}
+	mockRunner.CreateRealmFn = func(realm intmodel.Realm) (intmodel.Realm, error) {
+		if realm.Metadata.Name == "broken" {
+			return intmodel.Realm{}, errors.New("cgroup setup failed")
+		}
+		realms[realm.Metadata.Name] = true
+		return realm, nil
+	}
+	mockRunner.ExistsCgroupFn = func(any) (bool, error) { return true, nil }
+	mockRunner.ExistsRealmContainerdNamespaceFn = func(string) (bool, error) { return true, nil }
+	mockRunner.EnsureRealmFn = func(realm intmodel.Realm) (intmodel.Realm, error) { return realm, nil }
+	ctrl := controller.NewControllerExecForTesting(context.Background(), setupTestLogger(t),
+		controller.Options{RunPath: t.TempDir()}, mockRunner)
+
+	if _, err := ctrl.CreateRealm(buildTestRealm("main", "")); err != nil {
+		t.Fatalf("CreateRealm(main): %v", err)
+	}
+	// Re-creating an existing realm changes nothing and records nothing.
+	if _, err := ctrl.CreateRealm(buildTestRealm("main", "")); err != nil {
+		t.Fatalf("second CreateRealm(main): %v", err)
+	}
+	if _, err := ctrl.CreateRealm(buildTestRealm("broken", "")); err == nil {
+		t.Fatal("CreateRealm(broken) succeeded, want an error")
+	}
+
+	got, err := ctrl.ListEvents(events.Filter{})
+	if err != nil {
+		t.Fatalf("ListEvents: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("ListEvents returned %d events, want 2: %+v", len(got), got)
+	}
+	created := got[0]
+	if created.Action != events.ActionCreate || created.Kind != events.KindRealm ||
+		created.Realm != "main" || created.Outcome != events.OutcomeSucceeded || created.Time.IsZero() {
+		t.Errorf("first event = %+v, want a succeeded create of realm main", created)
+	}
+	failed := got[1]
+	if failed.Realm != "broken" || failed.Outcome != events.OutcomeFailed || failed.Message == "" {
+		t.Errorf("second event = %+v, want a failed create of realm broken with a message", failed)
+	}
+
+	only, err := ctrl.ListEvents(events.Filter{Realm: "main"})
+	if err != nil {
+		t.Fatalf("ListEvents(realm=main): %v", err)
+	}
+	if len(only) != 1 || only[0].Realm != "main" {
+		t.Errorf("ListEvents(realm=main) = %+v, want only the main realm's event", only)
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/apischeme"
+	"github.com/eminwux/kukeon/internal/apply/parser"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/util/fs"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+// ExportRealmResult carries the declarative snapshot of one realm. Documents
+// are in apply dependency order and hold spec only — the status subtree is
+// dropped by the encoder and runtime-derived spec fields (containerd IDs, CNI
+// config paths) are cleared — so the stream can be fed to `kuke apply -f`
+// as-is. Redacted names each secret-bearing item left out of the snapshot
+// because includeSecrets was false.
+type ExportRealmResult struct {
+	Documents []parser.Document
+	Redacted  []string
+}
+
+// ExportRealm walks the metadata subtree of one realm and assembles a
+// Document for every realm, space, stack, cell, secret, blueprint, config,
+// and volume it holds.
+//
+// Secret material is never exported unless includeSecrets is set: without it
+// Secret documents are omitted (apply rejects a Secret with no data, so an
+// emptied one would break the round trip) and realm registry credentials are
+// dropped, each omission being reported in Redacted.
+func (b *Exec) ExportRealm(name string, includeSecrets bool) (ExportRealmResult, error) {
+	var res ExportRealmResult
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return res, errdefs.ErrRealmNameRequired
+	}
+
+	realm, err := b.runner.GetRealm(intmodel.Realm{Metadata: intmodel.RealmMetadata{Name: name}})
+	if err != nil {
+		return res, err
+	}
+	realmDoc, err := apischeme.BuildRealmExternalFromInternal(realm, v1beta1.APIVersionV1Beta1)
+	if err != nil {
+		return res, fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+	}
+	realmDoc.Status = v1beta1.RealmStatus{}
+	realmDoc.Metadata.Annotations = withoutApplyBookkeeping(realmDoc.Metadata.Annotations)
+	if !includeSecrets && len(realmDoc.Spec.RegistryCredentials) > 0 {
+		realmDoc.Spec.RegistryCredentials = nil
+		res.Redacted = append(res.Redacted, fmt.Sprintf("realm %s registryCredentials", name))
+	}
+	res.add(parser.Document{Kind: v1beta1.KindRealm, RealmDoc: &realmDoc})
+
+	if err = b.exportHierarchy(&res, name); err != nil {
+		return res, err
+	}
+	if err = b.exportScopedResources(&res, name, includeSecrets); err != nil {
+		return res, err
+	}
+
+	res.Documents = SortDocumentsByKind(res.Documents, false)
+	return res, nil
+}
+
+// add appends doc with the next traversal index so SortDocumentsByKind keeps
+// the walk order within each kind.
+func (r *ExportRealmResult) add(doc parser.Document) {
+	doc.Index = len(r.Documents)
+	doc.APIVersion = v1beta1.APIVersionV1Beta1
+	r.Documents = append(r.Documents, doc)
+}
+
+// exportHierarchy appends the spaces, stacks, and cells under realmName.
+func (b *Exec) exportHierarchy(res *ExportRealmResult, realmName string) error {
+	spaces, err := b.runner.ListSpaces(realmName)
+	if err != nil {
+		return fmt.Errorf("failed to list spaces: %w", err)
+	}
+	for _, space := range spaces {
+		spaceDoc, convErr := apischeme.BuildSpaceExternalFromInternal(space, v1beta1.APIVersionV1Beta1)
+		if convErr != nil {
+			return fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, convErr)
+		}
+		spaceDoc.Spec.CNIConfigPath = ""
+		spaceDoc.Status = v1beta1.SpaceStatus{}
+		spaceDoc.Metadata.Annotations = withoutApplyBookkeeping(spaceDoc.Metadata.Annotations)
+		res.add(parser.Document{Kind: v1beta1.KindSpace, SpaceDoc: &spaceDoc})
+
+		stacks, listErr := b.runner.ListStacks(realmName, space.Metadata.Name)
+		if listErr != nil {
+			return fmt.Errorf("failed to list stacks: %w", listErr)
+		}
+		for _, stack := range stacks {
+			stackDoc, stackErr := apischeme.BuildStackExternalFromInternal(stack, v1beta1.APIVersionV1Beta1)
+			if stackErr != nil {
+				return fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, stackErr)
+			}
+			stackDoc.Status = v1beta1.StackStatus{}
+			stackDoc.Metadata.Annotations = withoutApplyBookkeeping(stackDoc.Metadata.Annotations)
+			res.add(parser.Document{Kind: v1beta1.KindStack, StackDoc: &stackDoc})
+
+			cells, cellsErr := b.runner.ListCells(realmName, space.Metadata.Name, stack.Metadata.Name)
+			if cellsErr != nil {
+				return fmt.Errorf("failed to list cells: %w", cellsErr)
+			}
+			for _, cell := range cells {
+				cellDoc, cellErr := apischeme.BuildCellExternalFromInternal(cell, v1beta1.APIVersionV1Beta1)
+				if cellErr != nil {
+					return fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, cellErr)
+				}
+				for i := range cellDoc.Spec.Containers {
+					cellDoc.Spec.Containers[i].ContainerdID = ""
+					cellDoc.Spec.Containers[i].CNIConfigPath = ""
+				}
+				cellDoc.Status = v1beta1.CellStatus{}
+				cellDoc.Metadata.Annotations = withoutApplyBookkeeping(cellDoc.Metadata.Annotations)
+				res.add(parser.Document{Kind: v1beta1.KindCell, CellDoc: &cellDoc})
+			}
+		}
+	}
+	return nil
+}
+
+// exportScopedResources appends the secrets, blueprints, configs, and volumes
+// bound anywhere under realmName. Each runner List call already walks the
+// nested scopes, so one call per kind covers the whole subtree.
+func (b *Exec) exportScopedResources(res *ExportRealmResult, realmName string, includeSecrets bool) error {
+	secrets, err := b.runner.ListSecrets(realmName, "", "", "")
+	if err != nil {
+		return err
+	}
+	for _, secret := range secrets {
+		md := secret.Metadata
+		if !includeSecrets {
+			res.Redacted = append(res.Redacted, "secret "+secretScopePath(md))
+			continue
+		}
+		data, readErr := os.ReadFile(fs.SecretPath(b.opts.RunPath, md.Realm, md.Space, md.Stack, md.Cell, md.Name))
+		if readErr != nil {
+			return fmt.Errorf("read secret %q: %w", md.Name, readErr)
+		}
+		secretDoc := apischeme.ConvertSecretToExternal(secret)
+		secretDoc.Spec.Data = string(data)
+		res.add(parser.Document{Kind: v1beta1.KindSecret, SecretDoc: &secretDoc})
+	}
+
+	blueprints, err := b.runner.ListBlueprints(realmName, "", "")
+	if err != nil {
+		return err
+	}
+	for _, bp := range blueprints {
+		full, getErr := b.runner.GetBlueprint(bp)
+		if getErr != nil {
+			return getErr
+		}
+		bpDoc, convErr := apischeme.ConvertCellBlueprintToExternal(full)
+		if convErr != nil {
+			return fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, convErr)
+		}
+		res.add(parser.Document{Kind: v1beta1.KindCellBlueprint, CellBlueprintDoc: &bpDoc})
+	}
+
+	configs, err := b.runner.ListConfigs(realmName, "", "")
+	if err != nil {
+		return err
+	}
+	for _, cfg := range configs {
+		full, getErr := b.runner.GetConfig(cfg)
+		if getErr != nil {
+			return getErr
+		}
+		cfgDoc, convErr := apischeme.ConvertCellConfigToExternal(full)
+		if convErr != nil {
+			return fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, convErr)
+		}
+		res.add(parser.Document{Kind: v1beta1.KindCellConfig, CellConfigDoc: &cfgDoc})
+	}
+
+	volumes, err := b.runner.ListVolumes(realmName, "", "")
+	if err != nil {
+		return err
+	}
+	for _, vol := range volumes {
+		full, getErr := b.runner.GetVolume(vol)
+		if getErr != nil {
+			return getErr
+		}
+		volDoc := apischeme.ConvertVolumeToExternal(full)
+		res.add(parser.Document{Kind: v1beta1.KindVolume, VolumeDoc: &volDoc})
+	}
+	return nil
+}
+
+// secretScopePath renders a secret's scope coordinates and name as a
+// slash-joined path, skipping unset levels.
+func secretScopePath(md intmodel.SecretMetadata) string {
+	parts := []string{md.Realm}
+	for _, p := range []string{md.Space, md.Stack, md.Cell} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(append(parts, md.Name), "/")
+}
@@ -0,0 +1,479 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/apply/parser"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/util/fs"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+const exportBlueprintDoc = `apiVersion: v1beta1
+kind: CellBlueprint
+metadata:
+  name: web
+  realm: r1
+  space: s1
+spec:
+  prefix: web
+  parameters:
+    - name: TAG
+      default: latest
+  cell:
+    containers:
+      - id: main
+        image: registry.example.com/web:${TAG}
+`
+
+const exportConfigDoc = `apiVersion: v1beta1
+kind: CellConfig
+metadata:
+  name: web-prod
+  realm: r1
+  space: s1
+spec:
+  blueprint:
+    name: web
+    realm: r1
+    space: s1
+  values:
+    TAG: v2
+`
+
+// memStore is an in-memory metadata store wired into a fakeRunner so export
+// and apply can be exercised end to end without containerd. Secret bytes go to
+// disk under runPath, where ExportRealm reads them.
+type memStore struct {
+	runPath    string
+	realms     map[string]intmodel.Realm
+	spaces     map[string]intmodel.Space
+	stacks     map[string]intmodel.Stack
+	cells      map[string]intmodel.Cell
+	secrets    map[string]intmodel.Secret
+	blueprints map[string]intmodel.CellBlueprint
+	configs    map[string]intmodel.CellConfig
+	volumes    map[string]intmodel.Volume
+	order      []string
+}
+
+func newMemStore(t *testing.T) *memStore {
+	t.Helper()
+	return &memStore{
+		runPath:    t.TempDir(),
+		realms:     map[string]intmodel.Realm{},
+		spaces:     map[string]intmodel.Space{},
+		stacks:     map[string]intmodel.Stack{},
+		cells:      map[string]intmodel.Cell{},
+		secrets:    map[string]intmodel.Secret{},
+		blueprints: map[string]intmodel.CellBlueprint{},
+		configs:    map[string]intmodel.CellConfig{},
+		volumes:    map[string]intmodel.Volume{},
+	}
+}
+
+func memKey(parts ...string) string { return strings.Join(parts, "/") }
+
+// values returns the entries of m whose key sits under prefix, in insertion
+// order so repeated exports of the same store are byte-identical.
+func values[T any](s *memStore, m map[string]T, prefix string) []T {
+	var out []T
+	for _, k := range s.order {
+		if v, ok := m[k]; ok && strings.HasPrefix(k, prefix+"/") {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func (s *memStore) track(key string) {
+	for _, k := range s.order {
+		if k == key {
+			return
+		}
+	}
+	s.order = append(s.order, key)
+}
+
+func (s *memStore) runner() *fakeRunner {
+	return &fakeRunner{
+		GetRealmFn: func(r intmodel.Realm) (intmodel.Realm, error) {
+			got, ok := s.realms[r.Metadata.Name]
+			if !ok {
+				return intmodel.Realm{}, errdefs.ErrRealmNotFound
+			}
+			return got, nil
+		},
+		CreateRealmFn: func(r intmodel.Realm) (intmodel.Realm, error) {
+			r.Status.State = intmodel.RealmStateReady
+			s.realms[r.Metadata.Name] = r
+			return r, nil
+		},
+		UpdateRealmFn: func(r intmodel.Realm) (intmodel.Realm, error) {
+			// Like the runner's UpdateRealm: metadata and compatible spec
+			// fields come from r, status stays as stored.
+			got, ok := s.realms[r.Metadata.Name]
+			if !ok {
+				return intmodel.Realm{}, errdefs.ErrRealmNotFound
+			}
+			got.Metadata.Labels = r.Metadata.Labels
+			got.Metadata.Annotations = r.Metadata.Annotations
+			got.Spec.Snapshotter = r.Spec.Snapshotter
+			s.realms[r.Metadata.Name] = got
+			return got, nil
+		},
+		DeleteRealmFn: func(r intmodel.Realm) error {
+			delete(s.realms, r.Metadata.Name)
+			return nil
+		},
+		GetSpaceFn: func(sp intmodel.Space) (intmodel.Space, error) {
+			got, ok := s.spaces[memKey("sp", sp.Spec.RealmName, sp.Metadata.Name)]
+			if !ok {
+				return intmodel.Space{}, errdefs.ErrSpaceNotFound
+			}
+			return got, nil
+		},
+		CreateSpaceFn: func(sp intmodel.Space) (intmodel.Space, error) {
+			sp.Status.State = intmodel.SpaceStateReady
+			key := memKey("sp", sp.Spec.RealmName, sp.Metadata.Name)
+			s.spaces[key] = sp
+			s.track(key)
+			return sp, nil
+		},
+		DeleteSpaceFn: func(sp intmodel.Space) error {
+			delete(s.spaces, memKey("sp", sp.Spec.RealmName, sp.Metadata.Name))
+			return nil
+		},
+		ListSpacesFn: func(realm string) ([]intmodel.Space, error) {
+			return values(s, s.spaces, memKey("sp", realm)), nil
+		},
+		GetStackFn: func(st intmodel.Stack) (intmodel.Stack, error) {
+			got, ok := s.stacks[memKey("st", st.Spec.RealmName, st.Spec.SpaceName, st.Metadata.Name)]
+			if !ok {
+				return intmodel.Stack{}, errdefs.ErrStackNotFound
+			}
+			return got, nil
+		},
+		CreateStackFn: func(st intmodel.Stack) (intmodel.Stack, error) {
+			st.Status.State = intmodel.StackStateReady
+			key := memKey("st", st.Spec.RealmName, st.Spec.SpaceName, st.Metadata.Name)
+			s.stacks[key] = st
+			s.track(key)
+			return st, nil
+		},
+		DeleteStackFn: func(st intmodel.Stack) error {
+			delete(s.stacks, memKey("st", st.Spec.RealmName, st.Spec.SpaceName, st.Metadata.Name))
+			return nil
+		},
+		ListStacksFn: func(realm, space string) ([]intmodel.Stack, error) {
+			return values(s, s.stacks, memKey("st", realm, space)), nil
+		},
+		GetCellFn: func(c intmodel.Cell) (intmodel.Cell, error) {
+			got, ok := s.cells[memKey("ce", c.Spec.RealmName, c.Spec.SpaceName, c.Spec.StackName, c.Metadata.Name)]
+			if !ok {
+				return intmodel.Cell{}, errdefs.ErrCellNotFound
+			}
+			return got, nil
+		},
+		CreateCellFn: func(c intmodel.Cell) (intmodel.Cell, error) {
+			key := memKey("ce", c.Spec.RealmName, c.Spec.SpaceName, c.Spec.StackName, c.Metadata.Name)
+			s.cells[key] = c
+			s.track(key)
+			return c, nil
+		},
+		StartCellFn:          func(c intmodel.Cell) (intmodel.Cell, error) { return c, nil },
+		UpdateCellMetadataFn: func(intmodel.Cell) error { return nil },
+		ListCellsFn: func(realm, space, stack string) ([]intmodel.Cell, error) {
+			return values(s, s.cells, memKey("ce", realm, space, stack)), nil
+		},
+		WriteSecretFn: func(sec intmodel.Secret) (bool, error) {
+			md := sec.Metadata
+			path := fs.SecretPath(s.runPath, md.Realm, md.Space, md.Stack, md.Cell, md.Name)
+			if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+				return false, err
+			}
+			if err := os.WriteFile(path, []byte(sec.Spec.Data), 0o600); err != nil {
+				return false, err
+			}
+			key := memKey("sec", md.Realm, md.Space, md.Stack, md.Cell, md.Name)
+			s.secrets[key] = intmodel.Secret{Metadata: md}
+			s.track(key)
+			return true, nil
+		},
+		ListSecretsFn: func(realm, _, _, _ string) ([]intmodel.Secret, error) {
+			return values(s, s.secrets, memKey("sec", realm)), nil
+		},
+		WriteBlueprintFn: func(bp intmodel.CellBlueprint) (bool, error) {
+			md := bp.Metadata
+			key := memKey("bp", md.Realm, md.Space, md.Stack, md.Name)
+			s.blueprints[key] = bp
+			s.track(key)
+			return true, nil
+		},
+		GetBlueprintFn: func(bp intmodel.CellBlueprint) (intmodel.CellBlueprint, error) {
+			md := bp.Metadata
+			got, ok := s.blueprints[memKey("bp", md.Realm, md.Space, md.Stack, md.Name)]
+			if !ok {
+				return intmodel.CellBlueprint{}, errdefs.ErrBlueprintNotFound
+			}
+			return got, nil
+		},
+		ListBlueprintsFn: func(realm, _, _ string) ([]intmodel.CellBlueprint, error) {
+			var out []intmodel.CellBlueprint
+			for _, bp := range values(s, s.blueprints, memKey("bp", realm)) {
+				out = append(out, intmodel.CellBlueprint{Metadata: bp.Metadata})
+			}
+			return out, nil
+		},
+		WriteConfigFn: func(cfg intmodel.CellConfig) (bool, error) {
+			md := cfg.Metadata
+			key := memKey("cfg", md.Realm, md.Space, md.Stack, md.Name)
+			s.configs[key] = cfg
+			s.track(key)
+			return true, nil
+		},
+		GetConfigFn: func(cfg intmodel.CellConfig) (intmodel.CellConfig, error) {
+			md := cfg.Metadata
+			got, ok := s.configs[memKey("cfg", md.Realm, md.Space, md.Stack, md.Name)]
+			if !ok {
+				return intmodel.CellConfig{}, errdefs.ErrConfigNotFound
+			}
+			return got, nil
+		},
+		ListConfigsFn: func(realm, _, _ string) ([]intmodel.CellConfig, error) {
+			var out []intmodel.CellConfig
+			for _, cfg := range values(s, s.configs, memKey("cfg", realm)) {
+				out = append(out, intmodel.CellConfig{Metadata: cfg.Metadata})
+			}
+			return out, nil
+		},
+		WriteVolumeFn: func(v intmodel.Volume) (bool, error) {
+			md := v.Metadata
+			key := memKey("vol", md.Realm, md.Space, md.Stack, md.Name)
+			s.volumes[key] = v
+			s.track(key)
+			return true, nil
+		},
+		GetVolumeFn: func(v intmodel.Volume) (intmodel.Volume, error) {
+			md := v.Metadata
+			got, ok := s.volumes[memKey("vol", md.Realm, md.Space, md.Stack, md.Name)]
+			if !ok {
+				return intmodel.Volume{}, errdefs.ErrVolumeNotFound
+			}
+			return got, nil
+		},
+		ListVolumesFn: func(realm, _, _ string) ([]intmodel.Volume, error) {
+			return values(s, s.volumes, memKey("vol", realm)), nil
+		},
+	}
+}
+
+// seedExportTree populates s with one of every exported kind under realm r1,
+// including runtime-only fields that the export must drop.
+func seedExportTree(t *testing.T, s *memStore) {
+	t.Helper()
+	r := s.runner()
+
+	realm := buildTestRealm("r1", "")
+	realm.Spec.RegistryCredentials = []intmodel.RegistryCredentials{
+		{Username: "bot", Password: "hunter2", ServerAddress: "registry.example.com"},
+	}
+	mustDo(t, func() error { _, err := r.CreateRealm(realm); return err })
+
+	space := buildTestSpace("s1", "r1")
+	space.Spec.CNIConfigPath = "/opt/cni/net.d/s1.conflist"
+	mustDo(t, func() error { _, err := r.CreateSpace(space); return err })
+	mustDo(t, func() error { _, err := r.CreateStack(buildTestStack("st1", "r1", "s1")); return err })
+
+	cell := buildTestCell("c1", "r1", "s1", "st1")
+	cell.Status.CgroupPath = "/kukeon/r1/s1/st1/c1"
+	cell.Spec.Containers = []intmodel.ContainerSpec{{
+		ID:            "main",
+		RealmName:     "r1",
+		SpaceName:     "s1",
+		StackName:     "st1",
+		CellName:      "c1",
+		Image:         "docker.io/library/busybox:latest",
+		ContainerdID:  "s1-st1-c1-main",
+		CNIConfigPath: "/opt/cni/net.d/s1.conflist",
+	}}
+	mustDo(t, func() error { _, err := r.CreateCell(context.Background(), cell); return err })
+
+	mustDo(t, func() error {
+		_, err := r.WriteSecret(intmodel.Secret{
+			Metadata: intmodel.SecretMetadata{Name: "api-key", Realm: "r1", Space: "s1"},
+			Spec:     intmodel.SecretSpec{Data: "s3cr3t"},
+		})
+		return err
+	})
+	mustDo(t, func() error {
+		_, err := r.WriteBlueprint(intmodel.CellBlueprint{
+			Metadata: intmodel.CellBlueprintMetadata{Name: "web", Realm: "r1", Space: "s1"},
+			Document: []byte(exportBlueprintDoc),
+		})
+		return err
+	})
+	mustDo(t, func() error {
+		_, err := r.WriteConfig(intmodel.CellConfig{
+			Metadata: intmodel.CellConfigMetadata{Name: "web-prod", Realm: "r1", Space: "s1"},
+			Document: []byte(exportConfigDoc),
+		})
+		return err
+	})
+	mustDo(t, func() error {
+		_, err := r.WriteVolume(intmodel.Volume{
+			Metadata: intmodel.VolumeMetadata{Name: "data", Realm: "r1", Space: "s1"},
+		})
+		return err
+	})
+}
+
+func mustDo(t *testing.T, fn func() error) {
+	t.Helper()
+	if err := fn(); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+}
+
+func exportYAML(t *testing.T, s *memStore, includeSecrets bool) (string, []string) {
+	t.Helper()
+	ctrl := setupTestControllerWithRunPath(t, s.runner(), s.runPath)
+	res, err := ctrl.ExportRealm("r1", includeSecrets)
+	if err != nil {
+		t.Fatalf("ExportRealm() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err = parser.EncodeDocuments(&buf, res.Documents); err != nil {
+		t.Fatalf("EncodeDocuments() error = %v", err)
+	}
+	return buf.String(), res.Redacted
+}
+
+func TestExportRealm_DependencyOrderAndDeclarative(t *testing.T) {
+	src := newMemStore(t)
+	seedExportTree(t, src)
+
+	ctrl := setupTestControllerWithRunPath(t, src.runner(), src.runPath)
+	res, err := ctrl.ExportRealm("r1", false)
+	if err != nil {
+		t.Fatalf("ExportRealm() error = %v", err)
+	}
+
+	var kinds []v1beta1.Kind
+	for _, doc := range res.Documents {
+		kinds = append(kinds, doc.Kind)
+	}
+	want := []v1beta1.Kind{
+		v1beta1.KindRealm, v1beta1.KindSpace, v1beta1.KindStack, v1beta1.KindCell,
+		v1beta1.KindCellBlueprint, v1beta1.KindCellConfig, v1beta1.KindVolume,
+	}
+	if len(kinds) != len(want) {
+		t.Fatalf("kinds = %v, want %v", kinds, want)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Fatalf("kinds = %v, want %v", kinds, want)
+		}
+	}
+
+	wantRedacted := []string{"realm r1 registryCredentials", "secret r1/s1/api-key"}
+	if strings.Join(res.Redacted, ",") != strings.Join(wantRedacted, ",") {
+		t.Errorf("Redacted = %v, want %v", res.Redacted, wantRedacted)
+	}
+
+	out, _ := exportYAML(t, src, false)
+	for _, leaked := range []string{"status:", "s3cr3t", "hunter2", "containerdId", "cniConfigPath", "cgroupPath"} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("export contains %q:\n%s", leaked, out)
+		}
+	}
+}
+
+func TestExportRealm_IncludeSecrets(t *testing.T) {
+	src := newMemStore(t)
+	seedExportTree(t, src)
+
+	out, redacted := exportYAML(t, src, true)
+	if len(redacted) != 0 {
+		t.Errorf("Redacted = %v, want none", redacted)
+	}
+	for _, want := range []string{"kind: Secret", "data: s3cr3t", "password: hunter2"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("export missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestExportRealm_NotFound(t *testing.T) {
+	ctrl := setupTestController(t, newMemStore(t).runner())
+	if _, err := ctrl.ExportRealm("missing", false); err == nil {
+		t.Fatal("ExportRealm(missing) error = nil, want ErrRealmNotFound")
+	}
+	if _, err := ctrl.ExportRealm(" ", false); err == nil {
+		t.Fatal("ExportRealm(\" \") error = nil, want ErrRealmNameRequired")
+	}
+}
+
+// TestExportRealm_RoundTrip pins the contract: exporting a realm, parsing the
+// stream back, and applying it into an empty store reproduces the tree, so a
+// second export of the rebuilt store is byte-identical to the first.
+func TestExportRealm_RoundTrip(t *testing.T) {
+	src := newMemStore(t)
+	seedExportTree(t, src)
+	first, _ := exportYAML(t, src, true)
+
+	raws, err := parser.ParseDocuments(strings.NewReader(first))
+	if err != nil {
+		t.Fatalf("ParseDocuments() error = %v", err)
+	}
+	docs := make([]parser.Document, 0, len(raws))
+	for i, raw := range raws {
+		doc, parseErr := parser.ParseDocument(i, raw)
+		if parseErr != nil {
+			t.Fatalf("ParseDocument(%d) error = %v", i, parseErr)
+		}
+		if vErr := parser.ValidateDocument(doc); vErr != nil {
+			t.Fatalf("ValidateDocument(%d) error = %v", i, vErr)
+		}
+		docs = append(docs, *doc)
+	}
+
+	dst := newMemStore(t)
+	ctrl := setupTestControllerWithRunPath(t, dst.runner(), dst.runPath)
+	result, err := ctrl.ApplyDocuments(docs, "", "")
+	if err != nil {
+		t.Fatalf("ApplyDocuments() error = %v", err)
+	}
+	for _, r := range result.Resources {
+		if r.Error != nil {
+			t.Fatalf("apply %s %q: %v", r.Kind, r.Name, r.Error)
+		}
+	}
+
+	second, _ := exportYAML(t, dst, true)
+	if first != second {
+		t.Errorf("round trip diverged\nfirst:\n%s\nsecond:\n%s", first, second)
+	}
+}
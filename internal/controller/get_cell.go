@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/eminwux/kukeon/internal/ctr"
 	"github.com/eminwux/kukeon/internal/errdefs"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 )
@@ -38,6 +39,10 @@ type GetCellResult struct {
 	// even when attaching would land on a dead socket. Callers gating an attach
 	// must consult this task-liveness signal, not record existence.
 	RootContainerTaskRunning bool
+	// Usage is the live cgroup usage snapshot of the cell, nil when the
+	// cgroup does not exist or its counters could not be read. It is
+	// informational only: a read failure never fails GetCell.
+	Usage *ctr.CgroupUsage
 }
 
 // GetCell retrieves a single cell and reports its current state.
@@ -99,6 +104,9 @@ func (b *Exec) GetCell(cell intmodel.Cell) (GetCellResult, error) {
 		if err != nil {
 			return res, fmt.Errorf("failed to check if cell cgroup exists: %w", err)
 		}
+		if res.CgroupExists {
+			res.Usage = b.cellCgroupUsage(internalCell)
+		}
 		res.RootContainerExists, err = b.runner.ExistsCellRootContainer(internalCell)
 		if err != nil {
 			return res, fmt.Errorf("failed to check root container: %w", err)
@@ -115,6 +123,19 @@ func (b *Exec) GetCell(cell intmodel.Cell) (GetCellResult, error) {
 	return res, nil
 }
 
+// cellCgroupUsage reads the cell's live cgroup counters, logging and
+// returning nil on failure so a transient read error does not hide the rest
+// of the cell's state.
+func (b *Exec) cellCgroupUsage(cell intmodel.Cell) *ctr.CgroupUsage {
+	usage, err := b.runner.CellCgroupUsage(cell)
+	if err != nil {
+		b.logger.DebugContext(b.ctx, "failed to read cell cgroup usage",
+			"cell", cell.Metadata.Name, "error", err)
+		return nil
+	}
+	return &usage
+}
+
 // rootContainerTaskRunning reports whether the cell's root container has a live
 // containerd task. It locates the root container in the cell spec (Root=true,
 // falling back to RootContainerID) and queries its actual task status; only a
@@ -169,6 +190,12 @@ func (b *Exec) ListCells(realmName, spaceName, stackName string) ([]intmodel.Cel
 	return b.runner.ListCells(realmName, spaceName, stackName)
 }
 
+// ListAllCells lists every cell across all realms, spaces, and stacks from the
+// metadata store alone, without touching containerd.
+func (b *Exec) ListAllCells() ([]intmodel.Cell, error) {
+	return b.runner.ListAllCells()
+}
+
 // validateAndGetCell validates cell input parameters and retrieves the cell.
 // It performs validation of name, realmName, spaceName, and stackName,
 // builds a lookup cell, calls GetCell, and handles errors appropriately.
@@ -24,6 +24,7 @@ import (
 	"testing"
 
 	"github.com/eminwux/kukeon/internal/controller"
+	"github.com/eminwux/kukeon/internal/ctr"
 	"github.com/eminwux/kukeon/internal/errdefs"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 )
@@ -54,6 +55,10 @@ func TestGetCell_SuccessfulRetrieval(t *testing.T) {
 				f.ExistsCgroupFn = func(_ any) (bool, error) {
 					return true, nil
 				}
+				f.CellCgroupUsageFn = func(_ intmodel.Cell) (ctr.CgroupUsage, error) {
+					current := uint64(4096)
+					return ctr.CgroupUsage{MemoryCurrent: &current, MemoryUnlimited: true}, nil
+				}
 				f.ExistsCellRootContainerFn = func(_ intmodel.Cell) (bool, error) {
 					return true, nil
 				}
@@ -65,6 +70,9 @@ func TestGetCell_SuccessfulRetrieval(t *testing.T) {
 				if !result.CgroupExists {
 					t.Error("expected CgroupExists to be true")
 				}
+				if result.Usage == nil || result.Usage.MemoryCurrent == nil || *result.Usage.MemoryCurrent != 4096 {
+					t.Errorf("expected Usage.MemoryCurrent 4096, got %+v", result.Usage)
+				}
 				if !result.RootContainerExists {
 					t.Error("expected RootContainerExists to be true")
 				}
@@ -99,6 +107,9 @@ func TestGetCell_SuccessfulRetrieval(t *testing.T) {
 				if result.CgroupExists {
 					t.Error("expected CgroupExists to be false")
 				}
+				if result.Usage != nil {
+					t.Errorf("expected nil Usage without a cgroup, got %+v", result.Usage)
+				}
 				if result.RootContainerExists {
 					t.Error("expected RootContainerExists to be false")
 				}
@@ -222,6 +233,37 @@ func TestGetCell_SuccessfulRetrieval(t *testing.T) {
 	}
 }
 
+// TestGetCell_UsageReadFailure asserts a failing cgroup usage read leaves
+// Usage nil without failing the lookup.
+func TestGetCell_UsageReadFailure(t *testing.T) {
+	mockRunner := &fakeRunner{}
+	cell := buildTestCell("test-cell", "test-realm", "test-space", "test-stack")
+	mockRunner.GetCellFn = func(_ intmodel.Cell) (intmodel.Cell, error) {
+		return cell, nil
+	}
+	mockRunner.ExistsCgroupFn = func(_ any) (bool, error) {
+		return true, nil
+	}
+	mockRunner.CellCgroupUsageFn = func(_ intmodel.Cell) (ctr.CgroupUsage, error) {
+		return ctr.CgroupUsage{}, errors.New("permission denied")
+	}
+	mockRunner.ExistsCellRootContainerFn = func(_ intmodel.Cell) (bool, error) {
+		return false, nil
+	}
+
+	ctrl := setupTestController(t, mockRunner)
+	result, err := ctrl.GetCell(cell)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.CgroupExists {
+		t.Error("expected CgroupExists to be true")
+	}
+	if result.Usage != nil {
+		t.Errorf("expected nil Usage on read failure, got %+v", result.Usage)
+	}
+}
+
 // TestGetCell_ValidationErrors tests validation errors for cell name, realm name, space name, and stack name.
 func TestGetCell_ValidationErrors(t *testing.T) {
 	tests := []struct {
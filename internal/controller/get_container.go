@@ -145,6 +145,14 @@ func (b *Exec) GetContainer(container intmodel.Container) (GetContainerResult, e
 				Stages:    stageStatusesForContainer(internalCell, name),
 			},
 		}
+		// The OOM fields likewise ride through from the cell's per-container
+		// status, where populateCellContainerStatuses folds the container
+		// cgroup's memory.events oom_kill count into them.
+		if prev, ok := containerStatusForContainer(internalCell, name); ok {
+			res.Container.Status.OOMKilled = prev.OOMKilled
+			res.Container.Status.LastOOM = prev.LastOOM
+			res.Container.Status.OOMKillCount = prev.OOMKillCount
+		}
 	} else {
 		res.ContainerExists = false
 	}
@@ -170,6 +178,18 @@ func containerCreatedAtForContainer(cell intmodel.Cell, name string) time.Time {
 	return time.Time{}
 }
 
+// containerStatusForContainer returns the cell's persisted per-container
+// status for the named container, or false when the cell has no status entry
+// for it yet.
+func containerStatusForContainer(cell intmodel.Cell, name string) (intmodel.ContainerStatus, bool) {
+	for i := range cell.Status.Containers {
+		if cell.Status.Containers[i].ID == name {
+			return cell.Status.Containers[i], true
+		}
+	}
+	return intmodel.ContainerStatus{}, false
+}
+
 // repoStatusesForContainer returns the per-repo clone/fetch outcome that
 // GetCell pulled into the cell's container statuses (over the GetSetupStatus
 // RPC, issue #642) for the named container, or nil when the container has no
@@ -201,6 +221,36 @@ func (b *Exec) ListContainers(realmName, spaceName, stackName, cellName string)
 	return b.runner.ListContainers(realmName, spaceName, stackName, cellName)
 }
 
+// ListAllContainers lists the containers of every cell across all realms,
+// spaces, and stacks from the metadata store alone, without touching containerd.
+func (b *Exec) ListAllContainers() ([]intmodel.ContainerSpec, error) {
+	return b.runner.ListAllContainers()
+}
+
+// ListContainersPageResult is one page of a paginated container listing.
+// Continue is the opaque token that fetches the next page; it is empty once
+// the listing is complete.
+type ListContainersPageResult struct {
+	Containers []intmodel.ContainerSpec
+	Continue   string
+}
+
+// ListContainersPage returns at most limit containers matching the scope
+// filters, resuming after continueToken. Empty filters match every name at
+// their level, so an all-empty scope pages through the whole store. Pages
+// are read from the metadata store alone, without touching containerd.
+func (b *Exec) ListContainersPage(
+	realmName, spaceName, stackName, cellName string,
+	limit int,
+	continueToken string,
+) (ListContainersPageResult, error) {
+	containers, next, err := b.runner.ListContainersPage(realmName, spaceName, stackName, cellName, limit, continueToken)
+	if err != nil {
+		return ListContainersPageResult{}, err
+	}
+	return ListContainersPageResult{Containers: containers, Continue: next}, nil
+}
+
 // ReapplyAttachableSocketPerms heals a single attachable container's live
 // tty control socket inode to the connect(2)-able mode/group on the attach
 // path (#1169). AttachContainer calls it before handing back the socket
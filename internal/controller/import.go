@@ -0,0 +1,163 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"fmt"
+
+	"github.com/eminwux/kukeon/internal/apischeme"
+	"github.com/eminwux/kukeon/internal/apply/parser"
+	"github.com/eminwux/kukeon/internal/consts"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+// ImportOptions tunes ImportDocuments.
+type ImportOptions struct {
+	// ContinueOnError applies every document even after one fails and skips
+	// the rollback, matching `kuke apply -f`.
+	ContinueOnError bool
+}
+
+// ImportResult reports the per-resource outcome of an import. RolledBack
+// lists the deletions performed to undo the resources the import created
+// before it stopped on a failure; it is empty on success and with
+// ContinueOnError.
+type ImportResult struct {
+	Resources  []ResourceResult
+	RolledBack []ResourceDeleteResult
+}
+
+// ImportDocuments applies docs in dependency order like ApplyDocuments, but
+// all-or-nothing: the first resource that fails to apply stops the import,
+// and every resource the import created is deleted again in reverse order.
+// Resources that already existed and were updated in place keep their new
+// state — the prior spec is not retained — and parents that reconcile
+// creates implicitly for a cell (rather than from a document in docs) are
+// not tracked.
+func (b *Exec) ImportDocuments(docs []parser.Document, opts ImportOptions) (ImportResult, error) {
+	result := ImportResult{
+		Resources: make([]ResourceResult, 0, len(docs)),
+	}
+
+	var created []parser.Document
+	for _, doc := range SortDocumentsByKind(docs, false) {
+		resourceResult := b.applyDocument(doc, "", consts.KukeonDefaultFieldManager)
+		result.Resources = append(result.Resources, resourceResult)
+
+		if resourceResult.Action == actionCreated {
+			created = append(created, doc)
+			continue
+		}
+		if resourceResult.Action != actionFailed || opts.ContinueOnError {
+			continue
+		}
+
+		result.RolledBack = b.rollbackImport(created)
+		return result, fmt.Errorf("%w: %s %q: %w",
+			errdefs.ErrImportFailed, resourceResult.Kind, resourceResult.Name, resourceResult.Error)
+	}
+	return result, nil
+}
+
+// rollbackImport deletes the created documents newest first, so children go
+// before the scopes that hold them. Failures are recorded and the walk
+// continues: a partial rollback still removes as much as it can.
+func (b *Exec) rollbackImport(created []parser.Document) []ResourceDeleteResult {
+	out := make([]ResourceDeleteResult, 0, len(created))
+	for i := len(created) - 1; i >= 0; i-- {
+		res := b.rollbackDocument(created[i])
+		if res.Error != nil {
+			b.logger.ErrorContext(b.ctx, "failed to roll back imported resource",
+				"kind", res.Kind, "name", res.Name, "error", res.Error)
+		}
+		out = append(out, res)
+	}
+	return out
+}
+
+// rollbackDocument deletes the single resource doc describes. Hierarchy kinds
+// go through DeleteDocuments without cascade — their imported children were
+// already removed — and the scope-targeting kinds through their own deletes.
+func (b *Exec) rollbackDocument(doc parser.Document) ResourceDeleteResult {
+	res := ResourceDeleteResult{
+		Index:   doc.Index,
+		Kind:    string(doc.Kind),
+		Details: make(map[string]string),
+	}
+
+	var err error
+	switch doc.Kind {
+	case v1beta1.KindRealm, v1beta1.KindSpace, v1beta1.KindStack, v1beta1.KindCell:
+		deleted, delErr := b.DeleteDocuments([]parser.Document{doc}, false, false)
+		if delErr != nil {
+			err = delErr
+			break
+		}
+		return deleted.Resources[0]
+
+	case v1beta1.KindSecret:
+		secret, _, convErr := apischeme.NormalizeSecret(*doc.SecretDoc)
+		if convErr != nil {
+			err = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, convErr)
+			break
+		}
+		res.Name = secret.Metadata.Name
+		_, err = b.DeleteSecret(secret)
+
+	case v1beta1.KindCellBlueprint:
+		blueprint, _, convErr := apischeme.NormalizeCellBlueprint(*doc.CellBlueprintDoc)
+		if convErr != nil {
+			err = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, convErr)
+			break
+		}
+		res.Name = blueprint.Metadata.Name
+		_, err = b.DeleteBlueprint(blueprint)
+
+	case v1beta1.KindCellConfig:
+		config, _, convErr := apischeme.NormalizeCellConfig(*doc.CellConfigDoc)
+		if convErr != nil {
+			err = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, convErr)
+			break
+		}
+		res.Name = config.Metadata.Name
+		_, err = b.DeleteConfig(config)
+
+	case v1beta1.KindVolume:
+		volume, _, convErr := apischeme.NormalizeVolume(*doc.VolumeDoc)
+		if convErr != nil {
+			err = fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, convErr)
+			break
+		}
+		res.Name = volume.Metadata.Name
+		_, err = b.DeleteVolume(volume)
+
+	default:
+		err = fmt.Errorf("%w: %s", errdefs.ErrUnknownKind, doc.Kind)
+	}
+
+	switch {
+	case err == nil:
+		res.Action = actionDeleted
+	case isNotFoundError(err):
+		res.Action = actionNotFound
+	default:
+		res.Action = actionFailed
+		res.Error = err
+	}
+	return res
+}
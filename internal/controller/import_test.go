@@ -0,0 +1,138 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/apply/parser"
+	"github.com/eminwux/kukeon/internal/controller"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+// importDocs returns a realm → space → stack → volume stream; the stack is
+// the third document in dependency order.
+func importDocs() []parser.Document {
+	return []parser.Document{
+		{Index: 0, Kind: v1beta1.KindRealm, RealmDoc: &v1beta1.RealmDoc{
+			APIVersion: v1beta1.APIVersionV1Beta1, Kind: v1beta1.KindRealm,
+			Metadata: v1beta1.RealmMetadata{Name: "r1"},
+		}},
+		{Index: 1, Kind: v1beta1.KindSpace, SpaceDoc: &v1beta1.SpaceDoc{
+			APIVersion: v1beta1.APIVersionV1Beta1, Kind: v1beta1.KindSpace,
+			Metadata: v1beta1.SpaceMetadata{Name: "s1"},
+			Spec:     v1beta1.SpaceSpec{RealmID: "r1"},
+		}},
+		{Index: 2, Kind: v1beta1.KindStack, StackDoc: &v1beta1.StackDoc{
+			APIVersion: v1beta1.APIVersionV1Beta1, Kind: v1beta1.KindStack,
+			Metadata: v1beta1.StackMetadata{Name: "st1"},
+			Spec:     v1beta1.StackSpec{RealmID: "r1", SpaceID: "s1"},
+		}},
+		{Index: 3, Kind: v1beta1.KindVolume, VolumeDoc: &v1beta1.VolumeDoc{
+			APIVersion: v1beta1.APIVersionV1Beta1, Kind: v1beta1.KindVolume,
+			Metadata: v1beta1.VolumeMetadata{Name: "data", Realm: "r1"},
+		}},
+	}
+}
+
+// failingStackRunner wires a memStore whose CreateStack always fails.
+func failingStackRunner(t *testing.T) (*memStore, *fakeRunner) {
+	t.Helper()
+	store := newMemStore(t)
+	r := store.runner()
+	r.CreateStackFn = func(intmodel.Stack) (intmodel.Stack, error) {
+		return intmodel.Stack{}, errors.New("boom")
+	}
+	return store, r
+}
+
+func TestImportDocuments_Success(t *testing.T) {
+	store := newMemStore(t)
+	ctrl := setupTestController(t, store.runner())
+
+	res, err := ctrl.ImportDocuments(importDocs(), controller.ImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportDocuments() error = %v", err)
+	}
+	if len(res.Resources) != 4 || len(res.RolledBack) != 0 {
+		t.Fatalf("got %d results / %d rollbacks, want 4 / 0", len(res.Resources), len(res.RolledBack))
+	}
+	for _, r := range res.Resources {
+		if r.Action != "created" {
+			t.Errorf("%s %q action = %q, want created", r.Kind, r.Name, r.Action)
+		}
+	}
+	if len(store.stacks) != 1 || len(store.volumes) != 1 {
+		t.Errorf("store not populated: stacks=%d volumes=%d", len(store.stacks), len(store.volumes))
+	}
+}
+
+// TestImportDocuments_RollsBackOnFailure pins the all-or-nothing contract:
+// the third document fails, the import stops there, and the realm and space it
+// already created are deleted again, newest first.
+func TestImportDocuments_RollsBackOnFailure(t *testing.T) {
+	store, r := failingStackRunner(t)
+	ctrl := setupTestController(t, r)
+
+	res, err := ctrl.ImportDocuments(importDocs(), controller.ImportOptions{})
+	if !errors.Is(err, errdefs.ErrImportFailed) {
+		t.Fatalf("ImportDocuments() error = %v, want ErrImportFailed", err)
+	}
+
+	if len(res.Resources) != 3 {
+		t.Fatalf("got %d results, want 3 (import stops at the failure)", len(res.Resources))
+	}
+	if got := res.Resources[2]; got.Kind != string(v1beta1.KindStack) || got.Action != "failed" {
+		t.Errorf("third result = %s %q, want failed Stack", got.Kind, got.Action)
+	}
+
+	if len(res.RolledBack) != 2 {
+		t.Fatalf("got %d rollbacks, want 2", len(res.RolledBack))
+	}
+	for i, wantKind := range []v1beta1.Kind{v1beta1.KindSpace, v1beta1.KindRealm} {
+		got := res.RolledBack[i]
+		if got.Kind != string(wantKind) || got.Action != "deleted" {
+			t.Errorf("rollback[%d] = %s %q (err %v), want deleted %s", i, got.Kind, got.Action, got.Error, wantKind)
+		}
+	}
+	if len(store.realms) != 0 || len(store.spaces) != 0 || len(store.volumes) != 0 {
+		t.Errorf("store not rolled back: realms=%d spaces=%d volumes=%d",
+			len(store.realms), len(store.spaces), len(store.volumes))
+	}
+}
+
+func TestImportDocuments_ContinueOnError(t *testing.T) {
+	store, r := failingStackRunner(t)
+	ctrl := setupTestController(t, r)
+
+	res, err := ctrl.ImportDocuments(importDocs(), controller.ImportOptions{ContinueOnError: true})
+	if err != nil {
+		t.Fatalf("ImportDocuments() error = %v, want nil with ContinueOnError", err)
+	}
+	if len(res.Resources) != 4 || len(res.RolledBack) != 0 {
+		t.Fatalf("got %d results / %d rollbacks, want 4 / 0", len(res.Resources), len(res.RolledBack))
+	}
+	if res.Resources[2].Action != "failed" || res.Resources[3].Action != "created" {
+		t.Errorf("actions = %q, %q; want failed, created", res.Resources[2].Action, res.Resources[3].Action)
+	}
+	if len(store.realms) != 1 || len(store.volumes) != 1 {
+		t.Errorf("applied resources removed: realms=%d volumes=%d", len(store.realms), len(store.volumes))
+	}
+}
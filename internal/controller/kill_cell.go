@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/events"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 )
 
@@ -33,6 +34,12 @@ type KillCellResult struct {
 
 // KillCell immediately force-kills all containers in a cell and updates the cell metadata state.
 func (b *Exec) KillCell(cell intmodel.Cell) (KillCellResult, error) {
+	res, err := b.killCell(cell)
+	b.recordEvent(events.ActionKill, cellEvent(cell), err)
+	return res, err
+}
+
+func (b *Exec) killCell(cell intmodel.Cell) (KillCellResult, error) {
 	var res KillCellResult
 
 	name := strings.TrimSpace(cell.Metadata.Name)
@@ -0,0 +1,90 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"encoding/json"
+	"maps"
+
+	"github.com/eminwux/kukeon/internal/consts"
+)
+
+// stampLastApplied returns annotations with the last-applied configuration
+// of doc and the field manager that applied it added. doc is the manifest
+// document as parsed; its status, generation and any stale bookkeeping
+// annotations are left out of the record. The input map is not mutated —
+// it may alias the caller-owned parser document.
+func stampLastApplied(doc any, annotations map[string]string, fieldManager string) (map[string]string, error) {
+	record, err := lastAppliedConfiguration(doc)
+	if err != nil {
+		return nil, err
+	}
+	if fieldManager == "" {
+		fieldManager = consts.KukeonDefaultFieldManager
+	}
+	out := make(map[string]string, len(annotations)+2)
+	maps.Copy(out, annotations)
+	out[consts.KukeonLastAppliedAnnotationKey] = record
+	out[consts.KukeonFieldManagerAnnotationKey] = fieldManager
+	return out, nil
+}
+
+// lastAppliedConfiguration renders doc as the compact JSON stored under
+// KukeonLastAppliedAnnotationKey. Going through a generic map keeps the
+// keys sorted, so the same manifest always yields the same record.
+func lastAppliedConfiguration(doc any) (string, error) {
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	var tree map[string]any
+	if err = json.Unmarshal(raw, &tree); err != nil {
+		return "", err
+	}
+	delete(tree, "status")
+	if metadata, ok := tree["metadata"].(map[string]any); ok {
+		delete(metadata, "generation")
+		if annotations, isMap := metadata["annotations"].(map[string]any); isMap {
+			delete(annotations, consts.KukeonLastAppliedAnnotationKey)
+			delete(annotations, consts.KukeonFieldManagerAnnotationKey)
+			if len(annotations) == 0 {
+				delete(metadata, "annotations")
+			}
+		}
+	}
+	raw, err = json.Marshal(tree)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+// withoutApplyBookkeeping returns annotations without the last-applied
+// configuration and field manager, for output meant to be applied again.
+// A map left empty becomes nil so it drops out of the rendered document.
+func withoutApplyBookkeeping(annotations map[string]string) map[string]string {
+	out := make(map[string]string, len(annotations))
+	for k, v := range annotations {
+		if k != consts.KukeonLastAppliedAnnotationKey && k != consts.KukeonFieldManagerAnnotationKey {
+			out[k] = v
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
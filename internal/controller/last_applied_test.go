@@ -0,0 +1,167 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/apply/parser"
+	"github.com/eminwux/kukeon/internal/consts"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// applyYAML parses raw and applies it through ctrl, failing on any
+// per-resource error.
+func applyYAML(t *testing.T, s *memStore, raw, fieldManager string) {
+	t.Helper()
+	raws, err := parser.ParseDocuments(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ParseDocuments() error = %v", err)
+	}
+	docs := make([]parser.Document, 0, len(raws))
+	for i, rawDoc := range raws {
+		doc, parseErr := parser.ParseDocument(i, rawDoc)
+		if parseErr != nil {
+			t.Fatalf("ParseDocument(%d) error = %v", i, parseErr)
+		}
+		docs = append(docs, *doc)
+	}
+	ctrl := setupTestController(t, s.runner())
+	result, err := ctrl.ApplyDocuments(docs, "", fieldManager)
+	if err != nil {
+		t.Fatalf("ApplyDocuments() error = %v", err)
+	}
+	for _, r := range result.Resources {
+		if r.Error != nil {
+			t.Fatalf("apply %s %q: %v", r.Kind, r.Name, r.Error)
+		}
+	}
+}
+
+const lastAppliedRealmV1 = `apiVersion: v1beta1
+kind: Realm
+metadata:
+  name: r1
+  labels:
+    team: payments
+    tier: web
+  annotations:
+    owner: alice
+spec:
+  snapshotter: overlayfs
+`
+
+const lastAppliedRealmV2 = `apiVersion: v1beta1
+kind: Realm
+metadata:
+  name: r1
+  labels:
+    team: payments
+`
+
+func TestApplyDocuments_RecordsLastAppliedConfiguration(t *testing.T) {
+	s := newMemStore(t)
+	applyYAML(t, s, lastAppliedRealmV1, "")
+
+	realm := s.realms["r1"]
+	if got := realm.Metadata.Annotations[consts.KukeonFieldManagerAnnotationKey]; got != consts.KukeonDefaultFieldManager {
+		t.Errorf("field manager = %q, want %q", got, consts.KukeonDefaultFieldManager)
+	}
+	var record struct {
+		Metadata struct {
+			Labels      map[string]string `json:"labels"`
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+		Status *json.RawMessage `json:"status"`
+	}
+	raw := realm.Metadata.Annotations[consts.KukeonLastAppliedAnnotationKey]
+	if err := json.Unmarshal([]byte(raw), &record); err != nil {
+		t.Fatalf("last-applied %q is not JSON: %v", raw, err)
+	}
+	if record.Metadata.Labels["tier"] != "web" || record.Metadata.Annotations["owner"] != "alice" {
+		t.Errorf("last-applied metadata = %+v, want the manifest's labels and annotations", record.Metadata)
+	}
+	if _, ok := record.Metadata.Annotations[consts.KukeonLastAppliedAnnotationKey]; ok {
+		t.Error("last-applied record nests itself")
+	}
+	if record.Status != nil {
+		t.Errorf("last-applied record carries status %s", *record.Status)
+	}
+}
+
+func TestApplyDocuments_ThreeWayMergeRemovesDroppedFields(t *testing.T) {
+	s := newMemStore(t)
+	applyYAML(t, s, lastAppliedRealmV1, "")
+
+	// Another writer labels the realm; the controller owns status.
+	realm := s.realms["r1"]
+	realm.Metadata.Labels["patched"] = "yes"
+	realm.Metadata.Labels[consts.KukeonRealmLabelKey] = "r1"
+	realm.Status.State = intmodel.RealmStateReady
+	s.realms["r1"] = realm
+
+	applyYAML(t, s, lastAppliedRealmV2, "ci")
+
+	got := s.realms["r1"]
+	wantLabels := map[string]string{"team": "payments", "patched": "yes", consts.KukeonRealmLabelKey: "r1"}
+	if len(got.Metadata.Labels) != len(wantLabels) {
+		t.Errorf("labels = %v, want %v", got.Metadata.Labels, wantLabels)
+	}
+	for k, v := range wantLabels {
+		if got.Metadata.Labels[k] != v {
+			t.Errorf("labels[%q] = %q, want %q", k, got.Metadata.Labels[k], v)
+		}
+	}
+	if _, ok := got.Metadata.Annotations["owner"]; ok {
+		t.Errorf("annotation owner survived its removal from the manifest: %v", got.Metadata.Annotations)
+	}
+	if got.Spec.Snapshotter != "" {
+		t.Errorf("spec.snapshotter = %q, want it removed with the manifest field", got.Spec.Snapshotter)
+	}
+	if got.Status.State != intmodel.RealmStateReady {
+		t.Errorf("status.state = %v, want controller-set %v preserved", got.Status.State, intmodel.RealmStateReady)
+	}
+	if fm := got.Metadata.Annotations[consts.KukeonFieldManagerAnnotationKey]; fm != "ci" {
+		t.Errorf("field manager = %q, want ci", fm)
+	}
+}
+
+// TestApplyDocuments_NoRecordFallsBackToReplace pins the upgrade path: a
+// realm stored before last-applied tracking has no record to merge against,
+// so the manifest replaces its labels as apply always did.
+func TestApplyDocuments_NoRecordFallsBackToReplace(t *testing.T) {
+	s := newMemStore(t)
+	s.realms["r1"] = intmodel.Realm{
+		Metadata: intmodel.RealmMetadata{
+			Name:   "r1",
+			Labels: map[string]string{"team": "payments", "legacy": "yes"},
+		},
+		Status: intmodel.RealmStatus{State: intmodel.RealmStateReady},
+	}
+
+	applyYAML(t, s, lastAppliedRealmV2, "")
+
+	got := s.realms["r1"]
+	if _, ok := got.Metadata.Labels["legacy"]; ok {
+		t.Errorf("labels = %v, want the manifest's labels only", got.Metadata.Labels)
+	}
+	if _, ok := got.Metadata.Annotations[consts.KukeonLastAppliedAnnotationKey]; !ok {
+		t.Error("apply did not record the last-applied configuration")
+	}
+}
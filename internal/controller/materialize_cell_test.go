@@ -56,7 +56,7 @@ func TestMaterializeCell_NewCell_SkipsStart(t *testing.T) {
 		},
 	}
 
-	ctrl := setupTestController(t, mockRunner)
+	ctrl := setupTestController(t, withReadyParents(mockRunner))
 	cell := buildTestCell("mz-cell", "test-realm", "test-space", "test-stack")
 
 	result, err := ctrl.MaterializeCell(cell)
@@ -120,7 +120,7 @@ func TestMaterializeCell_ExistingCell_SkipsStart(t *testing.T) {
 		},
 	}
 
-	ctrl := setupTestController(t, mockRunner)
+	ctrl := setupTestController(t, withReadyParents(mockRunner))
 
 	result, err := ctrl.MaterializeCell(existingCell)
 	if err != nil {
@@ -0,0 +1,90 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/eminwux/kukeon/internal/consts"
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+// NodeSummaryResult is the host-level view of what kukeon is consuming: the
+// live counters of the kukeon root cgroup plus how many resources the
+// metadata store holds.
+type NodeSummaryResult struct {
+	// CgroupRoot is the kukeon root cgroup the usage was read from.
+	CgroupRoot string
+	// Usage is the root cgroup's usage snapshot, nil when the cgroup does
+	// not exist yet because nothing has been provisioned.
+	Usage      *ctr.CgroupUsage
+	Realms     int
+	Spaces     int
+	Stacks     int
+	Cells      int
+	Containers int
+}
+
+// NodeSummary reads the kukeon root cgroup, which cgroup v2 charges with the
+// usage of every realm beneath it, and counts the realms, spaces, stacks,
+// cells, and containers recorded in the metadata store. A host with no
+// kukeon cgroup yet reports zero counts and a nil Usage rather than failing.
+func (b *Exec) NodeSummary() (NodeSummaryResult, error) {
+	res := NodeSummaryResult{CgroupRoot: consts.KukeonCgroupRoot}
+
+	usage, err := b.runner.KukeonCgroupUsage()
+	switch {
+	case err == nil:
+		res.Usage = &usage
+	case errors.Is(err, errdefs.ErrKukeonCgroupNotFound):
+		b.logger.DebugContext(b.ctx, "kukeon cgroup not provisioned", "cgroup", res.CgroupRoot)
+	default:
+		return res, err
+	}
+
+	realms, err := b.runner.ListRealms()
+	if err != nil {
+		return res, fmt.Errorf("failed to list realms: %w", err)
+	}
+	res.Realms = len(realms)
+
+	// An empty realm (and space, stack) name walks every realm.
+	spaces, err := b.runner.ListSpaces("")
+	if err != nil {
+		return res, fmt.Errorf("failed to list spaces: %w", err)
+	}
+	res.Spaces = len(spaces)
+
+	stacks, err := b.runner.ListStacks("", "")
+	if err != nil {
+		return res, fmt.Errorf("failed to list stacks: %w", err)
+	}
+	res.Stacks = len(stacks)
+
+	cells, err := b.runner.ListCells("", "", "")
+	if err != nil {
+		return res, fmt.Errorf("failed to list cells: %w", err)
+	}
+	res.Cells = len(cells)
+	for _, cell := range cells {
+		res.Containers += len(cell.Spec.Containers)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+func uint64Ptr(v uint64) *uint64 { return &v }
+
+// nodeSummaryRunner seeds a fake metadata store with two realms, three
+// spaces, two stacks, and two cells carrying three containers between them.
+func nodeSummaryRunner(usageFn func() (ctr.CgroupUsage, error)) *fakeRunner {
+	return &fakeRunner{
+		KukeonCgroupUsageFn: usageFn,
+		ListRealmsFn: func() ([]intmodel.Realm, error) {
+			return []intmodel.Realm{buildTestRealm("default", ""), buildTestRealm("kuke-system", "")}, nil
+		},
+		ListSpacesFn: func(realmName string) ([]intmodel.Space, error) {
+			if realmName != "" {
+				return nil, errors.New("expected an all-realms walk")
+			}
+			return make([]intmodel.Space, 3), nil
+		},
+		ListStacksFn: func(realmName, spaceName string) ([]intmodel.Stack, error) {
+			if realmName != "" || spaceName != "" {
+				return nil, errors.New("expected an all-realms walk")
+			}
+			return make([]intmodel.Stack, 2), nil
+		},
+		ListCellsFn: func(realmName, spaceName, stackName string) ([]intmodel.Cell, error) {
+			if realmName != "" || spaceName != "" || stackName != "" {
+				return nil, errors.New("expected an all-realms walk")
+			}
+			return []intmodel.Cell{
+				{Spec: intmodel.CellSpec{Containers: make([]intmodel.ContainerSpec, 2)}},
+				{Spec: intmodel.CellSpec{Containers: make([]intmodel.ContainerSpec, 1)}},
+			}, nil
+		},
+	}
+}
+
+func TestNodeSummary_ReportsRootUsageAndCounts(t *testing.T) {
+	mock := nodeSummaryRunner(func() (ctr.CgroupUsage, error) {
+		return ctr.CgroupUsage{
+			MemoryCurrent:   uint64Ptr(64 << 20),
+			MemoryUnlimited: true,
+			PidsCurrent:     uint64Ptr(12),
+			CPUUsageUsec:    uint64Ptr(5_000_000),
+		}, nil
+	})
+	ctrl := setupTestController(t, mock)
+
+	res, err := ctrl.NodeSummary()
+	if err != nil {
+		t.Fatalf("NodeSummary: %v", err)
+	}
+	if res.Usage == nil {
+		t.Fatal("Usage = nil, want the root cgroup snapshot")
+	}
+	if got := *res.Usage.MemoryCurrent; got != 64<<20 {
+		t.Errorf("MemoryCurrent = %d, want %d", got, 64<<20)
+	}
+	if got := *res.Usage.PidsCurrent; got != 12 {
+		t.Errorf("PidsCurrent = %d, want 12", got)
+	}
+	if res.Realms != 2 || res.Spaces != 3 || res.Stacks != 2 || res.Cells != 2 || res.Containers != 3 {
+		t.Errorf("counts = %d realms/%d spaces/%d stacks/%d cells/%d containers, want 2/3/2/2/3",
+			res.Realms, res.Spaces, res.Stacks, res.Cells, res.Containers)
+	}
+}
+
+func TestNodeSummary_NotProvisioned(t *testing.T) {
+	mock := nodeSummaryRunner(func() (ctr.CgroupUsage, error) {
+		return ctr.CgroupUsage{}, errdefs.ErrKukeonCgroupNotFound
+	})
+	mock.ListRealmsFn = func() ([]intmodel.Realm, error) { return nil, nil }
+	mock.ListSpacesFn = func(string) ([]intmodel.Space, error) { return nil, nil }
+	mock.ListStacksFn = func(string, string) ([]intmodel.Stack, error) { return nil, nil }
+	mock.ListCellsFn = func(string, string, string) ([]intmodel.Cell, error) { return nil, nil }
+	ctrl := setupTestController(t, mock)
+
+	res, err := ctrl.NodeSummary()
+	if err != nil {
+		t.Fatalf("NodeSummary: %v", err)
+	}
+	if res.Usage != nil {
+		t.Errorf("Usage = %+v, want nil before anything is provisioned", res.Usage)
+	}
+	if res.Realms != 0 || res.Cells != 0 {
+		t.Errorf("counts = %d realms/%d cells, want zero", res.Realms, res.Cells)
+	}
+}
+
+func TestNodeSummary_CgroupReadError(t *testing.T) {
+	mock := nodeSummaryRunner(func() (ctr.CgroupUsage, error) {
+		return ctr.CgroupUsage{}, errors.New("permission denied")
+	})
+	ctrl := setupTestController(t, mock)
+
+	if _, err := ctrl.NodeSummary(); err == nil {
+		t.Fatal("expected the cgroup read error to surface")
+	}
+}
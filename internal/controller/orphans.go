@@ -0,0 +1,105 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/controller/runner"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// OrphansResult reports the kukeon-labeled containers in a realm's
+// containerd namespace that no cell metadata owns. Purged lists the
+// containerd IDs PurgeOrphans removed; it is empty for FindOrphans.
+type OrphansResult struct {
+	Realm     string
+	Namespace string
+	Orphans   []runner.OrphanContainer
+	Purged    []string
+}
+
+// FindOrphans lists the realm's orphaned containers without touching them.
+// The realm is validated up-front so callers see ErrRealmNotFound before any
+// containerd round-trip, and its stored namespace is scanned so a realm
+// created with a namespace override is covered.
+func (b *Exec) FindOrphans(realm string) (OrphansResult, error) {
+	var res OrphansResult
+
+	internalRealm, err := b.lookupOrphanRealm(realm)
+	if err != nil {
+		return res, err
+	}
+
+	orphans, err := b.runner.FindOrphanContainers(internalRealm)
+	if err != nil {
+		return res, fmt.Errorf("%w: %w", errdefs.ErrFindOrphans, err)
+	}
+
+	res.Realm = internalRealm.Metadata.Name
+	res.Namespace = internalRealm.Spec.Namespace
+	res.Orphans = orphans
+	return res, nil
+}
+
+// PurgeOrphans finds the realm's orphaned containers and deletes each one.
+// Every orphan is attempted; failures are joined under ErrPurgeOrphans and
+// the successfully removed IDs are still reported in Purged.
+func (b *Exec) PurgeOrphans(realm string) (OrphansResult, error) {
+	res, err := b.FindOrphans(realm)
+	if err != nil {
+		return res, err
+	}
+
+	internalRealm := intmodel.Realm{
+		Metadata: intmodel.RealmMetadata{Name: res.Realm},
+		Spec:     intmodel.RealmSpec{Namespace: res.Namespace},
+	}
+	var purgeErrs []error
+	for _, orphan := range res.Orphans {
+		if delErr := b.runner.DeleteOrphanContainer(internalRealm, orphan); delErr != nil {
+			purgeErrs = append(purgeErrs, fmt.Errorf("%s: %w", orphan.ContainerdID, delErr))
+			continue
+		}
+		res.Purged = append(res.Purged, orphan.ContainerdID)
+	}
+	if len(purgeErrs) > 0 {
+		return res, fmt.Errorf("%w: %w", errdefs.ErrPurgeOrphans, errors.Join(purgeErrs...))
+	}
+	return res, nil
+}
+
+func (b *Exec) lookupOrphanRealm(realm string) (intmodel.Realm, error) {
+	realmName := strings.TrimSpace(realm)
+	if realmName == "" {
+		return intmodel.Realm{}, errdefs.ErrRealmNameRequired
+	}
+
+	internalRealm, err := b.runner.GetRealm(intmodel.Realm{
+		Metadata: intmodel.RealmMetadata{Name: realmName},
+	})
+	if err != nil {
+		if errors.Is(err, errdefs.ErrRealmNotFound) {
+			return intmodel.Realm{}, fmt.Errorf("%w: %s", errdefs.ErrRealmNotFound, realmName)
+		}
+		return intmodel.Realm{}, fmt.Errorf("%w: %w", errdefs.ErrGetRealm, err)
+	}
+	return internalRealm, nil
+}
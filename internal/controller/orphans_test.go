@@ -0,0 +1,87 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/controller/runner"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// TestPurgeOrphans_DeletesEveryOrphanAndJoinsFailures covers the purge loop:
+// every orphan is attempted against the realm's stored namespace, a failure
+// does not stop the rest, and the removed IDs are reported alongside the
+// joined ErrPurgeOrphans.
+func TestPurgeOrphans_DeletesEveryOrphanAndJoinsFailures(t *testing.T) {
+	orphans := []runner.OrphanContainer{
+		{ContainerdID: "app_web_a_root", Space: "app", Stack: "web", Cell: "a"},
+		{ContainerdID: "app_web_b_root", Space: "app", Stack: "web", Cell: "b"},
+	}
+	var attempted []string
+	mock := &fakeRunner{
+		GetRealmFn: func(_ intmodel.Realm) (intmodel.Realm, error) {
+			return buildTestRealm("main", "shared-ns"), nil
+		},
+		FindOrphanContainersFn: func(realm intmodel.Realm) ([]runner.OrphanContainer, error) {
+			if realm.Spec.Namespace != "shared-ns" {
+				t.Errorf("scanned namespace %q, want the stored override", realm.Spec.Namespace)
+			}
+			return orphans, nil
+		},
+		DeleteOrphanContainerFn: func(realm intmodel.Realm, orphan runner.OrphanContainer) error {
+			if realm.Spec.Namespace != "shared-ns" {
+				t.Errorf("delete namespace %q, want the stored override", realm.Spec.Namespace)
+			}
+			attempted = append(attempted, orphan.ContainerdID)
+			if orphan.Cell == "a" {
+				return errors.New("task refused to die")
+			}
+			return nil
+		},
+	}
+
+	ctrl := setupTestController(t, mock)
+	res, err := ctrl.PurgeOrphans("main")
+	if !errors.Is(err, errdefs.ErrPurgeOrphans) {
+		t.Fatalf("PurgeOrphans error = %v, want ErrPurgeOrphans", err)
+	}
+	if !reflect.DeepEqual(attempted, []string{"app_web_a_root", "app_web_b_root"}) {
+		t.Errorf("attempted = %v, want both orphans", attempted)
+	}
+	if !reflect.DeepEqual(res.Purged, []string{"app_web_b_root"}) {
+		t.Errorf("Purged = %v, want only the successful delete", res.Purged)
+	}
+	if len(res.Orphans) != 2 {
+		t.Errorf("Orphans = %v, want both reported", res.Orphans)
+	}
+}
+
+func TestFindOrphans_RealmNotFound(t *testing.T) {
+	mock := &fakeRunner{
+		GetRealmFn: func(_ intmodel.Realm) (intmodel.Realm, error) {
+			return intmodel.Realm{}, errdefs.ErrRealmNotFound
+		},
+	}
+	ctrl := setupTestController(t, mock)
+	if _, err := ctrl.FindOrphans("ghost"); !errors.Is(err, errdefs.ErrRealmNotFound) {
+		t.Fatalf("FindOrphans error = %v, want ErrRealmNotFound", err)
+	}
+}
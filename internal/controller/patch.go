@@ -0,0 +1,284 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/apischeme"
+	"github.com/eminwux/kukeon/internal/apply/parser"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/util/jsonpatch"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+const actionUnchanged = "unchanged"
+
+// PatchType selects how PatchResource reads a patch.
+type PatchType string
+
+const (
+	// PatchTypeMerge is an RFC 7386 JSON merge patch.
+	PatchTypeMerge PatchType = "merge"
+	// PatchTypeJSON is an RFC 6902 JSON patch.
+	PatchTypeJSON PatchType = "json"
+)
+
+// PatchRef names the stored resource a patch targets. Realm, Space, and Stack
+// are the scope coordinates of the resource; a kind ignores the ones it does
+// not live under.
+type PatchRef struct {
+	Kind  v1beta1.Kind
+	Name  string
+	Realm string
+	Space string
+	Stack string
+}
+
+// PatchResource applies a merge or JSON patch to the stored document of one
+// resource and re-applies the result. The stored document is patched in its
+// JSON form, the patched document is re-validated like an applied one, and
+// the apply reconcile persists it and updates the runtime when a changed
+// field needs it. A patch that changes the resource's identity (kind, name,
+// or scope coordinates) or its status is rejected with ErrImmutableField; one
+// that changes nothing reports Action "unchanged" without touching the store.
+//
+// Secrets and standalone containers cannot be patched: a secret's stored
+// document does not carry its data, and a container is edited through its
+// cell's spec.containers.
+func (b *Exec) PatchResource(ref PatchRef, patchType PatchType, patch []byte) (ResourceResult, error) {
+	result := ResourceResult{Kind: string(ref.Kind), Name: ref.Name, Details: make(map[string]string)}
+
+	ref.Name = strings.TrimSpace(ref.Name)
+	if ref.Name == "" {
+		return result, fmt.Errorf("%w: a resource name is required", errdefs.ErrInvalidPatch)
+	}
+
+	stored, err := b.loadPatchTarget(ref)
+	if err != nil {
+		return result, err
+	}
+	original, err := json.Marshal(stored)
+	if err != nil {
+		return result, fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+	}
+
+	var patched []byte
+	switch patchType {
+	case PatchTypeMerge:
+		patched, err = jsonpatch.MergePatch(original, patch)
+	case PatchTypeJSON:
+		patched, err = jsonpatch.Apply(original, patch)
+	default:
+		return result, fmt.Errorf("%w: unknown patch type %q (want %q or %q)",
+			errdefs.ErrInvalidPatch, patchType, PatchTypeMerge, PatchTypeJSON)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	if err = checkImmutableFields(ref.Kind, original, patched); err != nil {
+		return result, err
+	}
+	if jsonEqual(original, patched) {
+		result.Action = actionUnchanged
+		return result, nil
+	}
+
+	doc, err := decodePatchedDocument(ref.Kind, patched)
+	if err != nil {
+		return result, err
+	}
+	if validationErr := parser.ValidateDocument(&doc); validationErr != nil {
+		return result, fmt.Errorf("%w: %w", errdefs.ErrInvalidPatch, validationErr)
+	}
+
+	result = b.applyDocument(doc, "", "")
+	if result.Action == actionFailed {
+		return result, result.Error
+	}
+	return result, nil
+}
+
+// loadPatchTarget reads the stored external document named by ref.
+func (b *Exec) loadPatchTarget(ref PatchRef) (any, error) {
+	switch ref.Kind {
+	case v1beta1.KindRealm:
+		realm, err := b.runner.GetRealm(intmodel.Realm{Metadata: intmodel.RealmMetadata{Name: ref.Name}})
+		if err != nil {
+			return nil, err
+		}
+		return convertForPatch(apischeme.BuildRealmExternalFromInternal(realm, v1beta1.APIVersionV1Beta1))
+	case v1beta1.KindSpace:
+		space, err := b.runner.GetSpace(intmodel.Space{
+			Metadata: intmodel.SpaceMetadata{Name: ref.Name},
+			Spec:     intmodel.SpaceSpec{RealmName: ref.Realm},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return convertForPatch(apischeme.BuildSpaceExternalFromInternal(space, v1beta1.APIVersionV1Beta1))
+	case v1beta1.KindStack:
+		stack, err := b.runner.GetStack(intmodel.Stack{
+			Metadata: intmodel.StackMetadata{Name: ref.Name},
+			Spec:     intmodel.StackSpec{RealmName: ref.Realm, SpaceName: ref.Space},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return convertForPatch(apischeme.BuildStackExternalFromInternal(stack, v1beta1.APIVersionV1Beta1))
+	case v1beta1.KindCell:
+		cell, err := b.runner.GetCell(intmodel.Cell{
+			Metadata: intmodel.CellMetadata{Name: ref.Name},
+			Spec:     intmodel.CellSpec{RealmName: ref.Realm, SpaceName: ref.Space, StackName: ref.Stack},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return convertForPatch(apischeme.BuildCellExternalFromInternal(cell, v1beta1.APIVersionV1Beta1))
+	case v1beta1.KindCellBlueprint:
+		bp, err := b.runner.GetBlueprint(intmodel.CellBlueprint{Metadata: intmodel.CellBlueprintMetadata{
+			Name: ref.Name, Realm: ref.Realm, Space: ref.Space, Stack: ref.Stack,
+		}})
+		if err != nil {
+			return nil, err
+		}
+		return convertForPatch(apischeme.ConvertCellBlueprintToExternal(bp))
+	case v1beta1.KindCellConfig:
+		cfg, err := b.runner.GetConfig(intmodel.CellConfig{Metadata: intmodel.CellConfigMetadata{
+			Name: ref.Name, Realm: ref.Realm, Space: ref.Space, Stack: ref.Stack,
+		}})
+		if err != nil {
+			return nil, err
+		}
+		return convertForPatch(apischeme.ConvertCellConfigToExternal(cfg))
+	case v1beta1.KindVolume:
+		vol, err := b.runner.GetVolume(intmodel.Volume{Metadata: intmodel.VolumeMetadata{
+			Name: ref.Name, Realm: ref.Realm, Space: ref.Space, Stack: ref.Stack,
+		}})
+		if err != nil {
+			return nil, err
+		}
+		return apischeme.ConvertVolumeToExternal(vol), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errdefs.ErrPatchUnsupportedKind, ref.Kind)
+	}
+}
+
+func convertForPatch[T any](doc T, err error) (any, error) {
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+	}
+	return doc, nil
+}
+
+// decodePatchedDocument decodes a patched JSON document back into the typed
+// parser.Document the apply path consumes.
+func decodePatchedDocument(kind v1beta1.Kind, data []byte) (parser.Document, error) {
+	doc := parser.Document{Kind: kind, Raw: data, APIVersion: v1beta1.APIVersionV1Beta1}
+	var err error
+	switch kind {
+	case v1beta1.KindRealm:
+		doc.RealmDoc, err = decodePatched[v1beta1.RealmDoc](data)
+	case v1beta1.KindSpace:
+		doc.SpaceDoc, err = decodePatched[v1beta1.SpaceDoc](data)
+	case v1beta1.KindStack:
+		doc.StackDoc, err = decodePatched[v1beta1.StackDoc](data)
+	case v1beta1.KindCell:
+		doc.CellDoc, err = decodePatched[v1beta1.CellDoc](data)
+	case v1beta1.KindCellBlueprint:
+		doc.CellBlueprintDoc, err = decodePatched[v1beta1.CellBlueprintDoc](data)
+	case v1beta1.KindCellConfig:
+		doc.CellConfigDoc, err = decodePatched[v1beta1.CellConfigDoc](data)
+	case v1beta1.KindVolume:
+		doc.VolumeDoc, err = decodePatched[v1beta1.VolumeDoc](data)
+	default:
+		err = fmt.Errorf("%w: %s", errdefs.ErrPatchUnsupportedKind, kind)
+	}
+	return doc, err
+}
+
+func decodePatched[T any](data []byte) (*T, error) {
+	var out T
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("%w: patched document does not decode: %w", errdefs.ErrInvalidPatch, err)
+	}
+	return &out, nil
+}
+
+// immutablePatchFields lists the dotted JSON paths of kind's document that a
+// patch may not change: its identity, the scope coordinates that key where it
+// is stored, runtime-derived spec fields, and the daemon-owned status.
+func immutablePatchFields(kind v1beta1.Kind) []string {
+	fields := []string{"apiVersion", "kind", "metadata.name", "status"}
+	switch kind {
+	case v1beta1.KindRealm:
+		fields = append(fields, "spec.namespace")
+	case v1beta1.KindSpace:
+		fields = append(fields, "spec.realmId", "spec.cniConfigPath")
+	case v1beta1.KindStack:
+		fields = append(fields, "spec.realmId", "spec.spaceId")
+	case v1beta1.KindCell:
+		fields = append(fields, "spec.realmId", "spec.spaceId", "spec.stackId")
+	case v1beta1.KindCellBlueprint, v1beta1.KindCellConfig, v1beta1.KindVolume:
+		fields = append(fields, "metadata.realm", "metadata.space", "metadata.stack")
+	default:
+	}
+	return fields
+}
+
+// checkImmutableFields compares every immutable field of kind between the
+// original and patched documents and rejects the patch on the first change.
+func checkImmutableFields(kind v1beta1.Kind, original, patched []byte) error {
+	var before, after any
+	if err := json.Unmarshal(original, &before); err != nil {
+		return fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, err)
+	}
+	if err := json.Unmarshal(patched, &after); err != nil {
+		return fmt.Errorf("%w: %w", errdefs.ErrInvalidPatch, err)
+	}
+	for _, field := range immutablePatchFields(kind) {
+		path := strings.Split(field, ".")
+		if !reflect.DeepEqual(lookupJSONPath(before, path), lookupJSONPath(after, path)) {
+			return fmt.Errorf("%w: %s of a %s cannot be changed by a patch", errdefs.ErrImmutableField, field, kind)
+		}
+	}
+	return nil
+}
+
+func lookupJSONPath(v any, path []string) any {
+	for _, seg := range path {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[seg]
+	}
+	return v
+}
+
+func jsonEqual(a, b []byte) bool {
+	var av, bv any
+	if json.Unmarshal(a, &av) != nil || json.Unmarshal(b, &bv) != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
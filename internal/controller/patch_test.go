@@ -0,0 +1,203 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/controller"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+func TestPatchResource_MergePatch(t *testing.T) {
+	store := newMemStore(t)
+	seedExportTree(t, store)
+	ctrl := setupTestControllerWithRunPath(t, store.runner(), store.runPath)
+
+	ref := controller.PatchRef{Kind: v1beta1.KindCellConfig, Name: "web-prod", Realm: "r1", Space: "s1"}
+	res, err := ctrl.PatchResource(ref, controller.PatchTypeMerge,
+		[]byte(`{"metadata":{"labels":{"env":"prod"}},"spec":{"values":{"TAG":"v3"}}}`))
+	if err != nil {
+		t.Fatalf("PatchResource() error = %v", err)
+	}
+	if res.Action == "unchanged" || res.Action == "failed" {
+		t.Fatalf("Action = %q, want a persisted change", res.Action)
+	}
+
+	stored := string(store.configs[memKey("cfg", "r1", "s1", "", "web-prod")].Document)
+	for _, want := range []string{"TAG: v3", "env: prod", "name: web"} {
+		if !strings.Contains(stored, want) {
+			t.Errorf("stored config missing %q:\n%s", want, stored)
+		}
+	}
+}
+
+func TestPatchResource_JSONPatch(t *testing.T) {
+	store := newMemStore(t)
+	seedExportTree(t, store)
+	ctrl := setupTestControllerWithRunPath(t, store.runner(), store.runPath)
+
+	ref := controller.PatchRef{Kind: v1beta1.KindCellBlueprint, Name: "web", Realm: "r1", Space: "s1"}
+	patch := `[
+		{"op":"test","path":"/spec/prefix","value":"web"},
+		{"op":"replace","path":"/spec/prefix","value":"frontend"},
+		{"op":"add","path":"/spec/parameters/-","value":{"name":"REPLICAS","default":"1"}}
+	]`
+	if _, err := ctrl.PatchResource(ref, controller.PatchTypeJSON, []byte(patch)); err != nil {
+		t.Fatalf("PatchResource() error = %v", err)
+	}
+
+	stored := string(store.blueprints[memKey("bp", "r1", "s1", "", "web")].Document)
+	for _, want := range []string{"prefix: frontend", "name: REPLICAS", "name: TAG"} {
+		if !strings.Contains(stored, want) {
+			t.Errorf("stored blueprint missing %q:\n%s", want, stored)
+		}
+	}
+}
+
+func TestPatchResource_NoChange(t *testing.T) {
+	store := newMemStore(t)
+	seedExportTree(t, store)
+	ctrl := setupTestControllerWithRunPath(t, store.runner(), store.runPath)
+
+	ref := controller.PatchRef{Kind: v1beta1.KindCellConfig, Name: "web-prod", Realm: "r1", Space: "s1"}
+	res, err := ctrl.PatchResource(ref, controller.PatchTypeMerge, []byte(`{"spec":{"values":{"TAG":"v2"}}}`))
+	if err != nil {
+		t.Fatalf("PatchResource() error = %v", err)
+	}
+	if res.Action != "unchanged" {
+		t.Errorf("Action = %q, want unchanged", res.Action)
+	}
+}
+
+func TestPatchResource_RejectsImmutableFields(t *testing.T) {
+	store := newMemStore(t)
+	seedExportTree(t, store)
+	ctrl := setupTestControllerWithRunPath(t, store.runner(), store.runPath)
+
+	cellRef := controller.PatchRef{Kind: v1beta1.KindCell, Name: "c1", Realm: "r1", Space: "s1", Stack: "st1"}
+	cases := []struct {
+		name      string
+		ref       controller.PatchRef
+		patchType controller.PatchType
+		patch     string
+	}{
+		{name: "cell realm", ref: cellRef, patchType: controller.PatchTypeMerge, patch: `{"spec":{"realmId":"r2"}}`},
+		{name: "cell space", ref: cellRef, patchType: controller.PatchTypeJSON, patch: `[{"op":"replace","path":"/spec/spaceId","value":"s2"}]`},
+		{name: "cell name", ref: cellRef, patchType: controller.PatchTypeMerge, patch: `{"metadata":{"name":"c2"}}`},
+		{name: "cell status", ref: cellRef, patchType: controller.PatchTypeMerge, patch: `{"status":{"cgroupPath":"/elsewhere"}}`},
+		{
+			name:      "config scope",
+			ref:       controller.PatchRef{Kind: v1beta1.KindCellConfig, Name: "web-prod", Realm: "r1", Space: "s1"},
+			patchType: controller.PatchTypeJSON,
+			patch:     `[{"op":"remove","path":"/metadata/space"}]`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			before := store.cells[memKey("ce", "r1", "s1", "st1", "c1")]
+			_, err := ctrl.PatchResource(tc.ref, tc.patchType, []byte(tc.patch))
+			if !errors.Is(err, errdefs.ErrImmutableField) {
+				t.Fatalf("PatchResource() error = %v, want ErrImmutableField", err)
+			}
+			if after := store.cells[memKey("ce", "r1", "s1", "st1", "c1")]; after.Spec.RealmName != before.Spec.RealmName {
+				t.Errorf("rejected patch changed the stored cell")
+			}
+		})
+	}
+}
+
+func TestPatchResource_Rejects(t *testing.T) {
+	store := newMemStore(t)
+	seedExportTree(t, store)
+	ctrl := setupTestControllerWithRunPath(t, store.runner(), store.runPath)
+
+	cases := []struct {
+		name      string
+		ref       controller.PatchRef
+		patchType controller.PatchType
+		patch     string
+		want      error
+	}{
+		{
+			name:      "secret",
+			ref:       controller.PatchRef{Kind: v1beta1.KindSecret, Name: "api-key", Realm: "r1", Space: "s1"},
+			patchType: controller.PatchTypeMerge,
+			patch:     `{}`,
+			want:      errdefs.ErrPatchUnsupportedKind,
+		},
+		{
+			name:      "unknown patch type",
+			ref:       controller.PatchRef{Kind: v1beta1.KindVolume, Name: "data", Realm: "r1", Space: "s1"},
+			patchType: "strategic",
+			patch:     `{}`,
+			want:      errdefs.ErrInvalidPatch,
+		},
+		{
+			name:      "missing resource",
+			ref:       controller.PatchRef{Kind: v1beta1.KindVolume, Name: "nope", Realm: "r1", Space: "s1"},
+			patchType: controller.PatchTypeMerge,
+			patch:     `{}`,
+			want:      errdefs.ErrVolumeNotFound,
+		},
+		{
+			name:      "failed test op",
+			ref:       controller.PatchRef{Kind: v1beta1.KindCellBlueprint, Name: "web", Realm: "r1", Space: "s1"},
+			patchType: controller.PatchTypeJSON,
+			patch:     `[{"op":"test","path":"/spec/prefix","value":"api"}]`,
+			want:      errdefs.ErrInvalidPatch,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ctrl.PatchResource(tc.ref, tc.patchType, []byte(tc.patch)); !errors.Is(err, tc.want) {
+				t.Fatalf("PatchResource() error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestPatchResource_CellReconciles(t *testing.T) {
+	store := newMemStore(t)
+	seedExportTree(t, store)
+	runner := store.runner()
+	var updated []string
+	runner.UpdateCellFn = func(c intmodel.Cell) (intmodel.Cell, error) {
+		for _, ctr := range c.Spec.Containers {
+			updated = append(updated, ctr.Image)
+		}
+		return c, nil
+	}
+	ctrl := setupTestControllerWithRunPath(t, runner, store.runPath)
+
+	ref := controller.PatchRef{Kind: v1beta1.KindCell, Name: "c1", Realm: "r1", Space: "s1", Stack: "st1"}
+	res, err := ctrl.PatchResource(ref, controller.PatchTypeJSON,
+		[]byte(`[{"op":"replace","path":"/spec/containers/0/image","value":"docker.io/library/busybox:1.36"}]`))
+	if err != nil {
+		t.Fatalf("PatchResource() error = %v", err)
+	}
+	if res.Kind != string(v1beta1.KindCell) || res.Name != "c1" {
+		t.Errorf("result = %s/%s, want Cell/c1", res.Kind, res.Name)
+	}
+	if len(updated) != 1 || updated[0] != "docker.io/library/busybox:1.36" {
+		t.Errorf("UpdateCell images = %v, want the patched image", updated)
+	}
+}
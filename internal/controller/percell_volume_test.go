@@ -233,7 +233,7 @@ func (s *reapplyStubRunner) RecreateCell(_ intmodel.Cell) (intmodel.Cell, error)
 	return s.out, nil
 }
 
-func (s *reapplyStubRunner) StartCell(_ intmodel.Cell) (intmodel.Cell, error) {
+func (s *reapplyStubRunner) StartCell(_ context.Context, _ intmodel.Cell) (intmodel.Cell, error) {
 	*s.order = append(*s.order, "StartCell")
 	return s.out, nil
 }
@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/events"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 )
 
@@ -41,6 +42,12 @@ type PurgeCellResult struct {
 // PurgeCell purges a cell with comprehensive cleanup. Always purges all containers first.
 // If force is true, skips validation (currently unused but recorded for auditing).
 func (b *Exec) PurgeCell(cell intmodel.Cell, force, cascade bool) (PurgeCellResult, error) {
+	res, err := b.purgeCell(cell, force, cascade)
+	b.recordEvent(events.ActionPurge, cellEvent(cell), err)
+	return res, err
+}
+
+func (b *Exec) purgeCell(cell intmodel.Cell, force, cascade bool) (PurgeCellResult, error) {
 	var result PurgeCellResult
 
 	name := strings.TrimSpace(cell.Metadata.Name)
@@ -17,13 +17,16 @@
 package controller
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/eminwux/kukeon/internal/consts"
 	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/events"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/tracing"
 )
 
 // PurgeRealmResult reports what was purged during realm purging.
@@ -36,11 +39,20 @@ type PurgeRealmResult struct {
 	Cascade          bool     // Cascade flag that was used
 	Deleted          []string // Resources that were deleted (standard cleanup)
 	Purged           []string // Additional resources purged (CNI, orphaned containers, etc.)
+	BackupPath       string   // Metadata backup taken before the purge; empty when none was written
 }
 
 // PurgeRealm purges a realm with comprehensive cleanup. If cascade is true, purges all spaces first.
 // If force is true, skips validation of child resources.
 func (b *Exec) PurgeRealm(realm intmodel.Realm, force, cascade bool) (PurgeRealmResult, error) {
+	ctx, span := tracing.Start(b.ctx, "controller.PurgeRealm", tracing.AttrRealm.String(realm.Metadata.Name))
+	result, err := b.purgeRealm(ctx, realm, force, cascade)
+	tracing.End(span, err)
+	b.recordEvent(events.ActionPurge, realmEvent(realm), err)
+	return result, err
+}
+
+func (b *Exec) purgeRealm(ctx context.Context, realm intmodel.Realm, force, cascade bool) (PurgeRealmResult, error) {
 	var result PurgeRealmResult
 
 	name := strings.TrimSpace(realm.Metadata.Name)
@@ -107,8 +119,14 @@ func (b *Exec) PurgeRealm(realm intmodel.Realm, force, cascade bool) (PurgeRealm
 		}
 	}
 
+	backupPath, err := b.backupBeforeDestroy(name)
+	if err != nil {
+		return result, err
+	}
+	result.BackupPath = backupPath
+
 	// Call private cascade method (handles cascade deletion, standard delete, and comprehensive purge)
-	namespaceRemoved, err := b.purgeRealmCascade(internalRealm, force, cascade, metadataExists)
+	namespaceRemoved, err := b.purgeRealmCascade(ctx, internalRealm, force, cascade, metadataExists)
 	result.NamespaceRemoved = namespaceRemoved
 	if err != nil {
 		result.Purged = append(result.Purged, fmt.Sprintf("purge-error:%v", err))
@@ -137,7 +155,11 @@ func (b *Exec) PurgeRealm(realm intmodel.Realm, force, cascade bool) (PurgeRealm
 // It returns whether the containerd namespace was actually removed and an
 // error if deletion/purging failed. metadataExists indicates whether realm
 // metadata exists (affects cascade and delete operations).
-func (b *Exec) purgeRealmCascade(realm intmodel.Realm, force, cascade, metadataExists bool) (bool, error) {
+func (b *Exec) purgeRealmCascade(
+	ctx context.Context,
+	realm intmodel.Realm,
+	force, cascade, metadataExists bool,
+) (bool, error) {
 	realmName := strings.TrimSpace(realm.Metadata.Name)
 
 	// If cascade is true, list and purge child resources (spaces) recursively (only if metadata exists)
@@ -174,7 +196,12 @@ func (b *Exec) purgeRealmCascade(realm intmodel.Realm, force, cascade, metadataE
 	// no longer load-bearing — log it and treat the operation as a success.
 	var deleteErr error
 	if metadataExists {
-		if err := b.deleteRealmCascade(realm, force, cascade); err != nil {
+		if _, err := b.deleteRealmCascade(realm, force, cascade); err != nil {
+			// An interrupted cascade must not escalate into the longer
+			// runner purge; stop at the step boundary instead.
+			if isCanceled(err) {
+				return false, fmt.Errorf("failed to delete realm: %w", err)
+			}
 			deleteErr = fmt.Errorf("failed to delete realm: %w", err)
 			b.logger.WarnContext(
 				b.ctx,
@@ -191,7 +218,7 @@ func (b *Exec) purgeRealmCascade(realm intmodel.Realm, force, cascade, metadataE
 	// Note: namespaceRemoved is the load-bearing signal — even when purgeErr
 	// is nil, a residual namespace surfaces here so callers can render
 	// "namespace not empty" instead of a misleading "purged" outcome.
-	namespaceRemoved, purgeErr := b.runner.PurgeRealm(realm)
+	namespaceRemoved, purgeErr := b.runner.PurgeRealm(ctx, realm)
 	if purgeErr != nil {
 		if deleteErr != nil {
 			return namespaceRemoved, fmt.Errorf("%w; runner purge also failed: %w", deleteErr, purgeErr)
@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/events"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 )
 
@@ -43,6 +44,12 @@ type PurgeSpaceResult struct {
 // PurgeSpace purges a space with comprehensive cleanup. If cascade is true, purges all stacks first.
 // If force is true, skips validation of child resources.
 func (b *Exec) PurgeSpace(space intmodel.Space, force, cascade bool) (PurgeSpaceResult, error) {
+	res, err := b.purgeSpace(space, force, cascade)
+	b.recordEvent(events.ActionPurge, spaceEvent(space), err)
+	return res, err
+}
+
+func (b *Exec) purgeSpace(space intmodel.Space, force, cascade bool) (PurgeSpaceResult, error) {
 	var result PurgeSpaceResult
 
 	name := strings.TrimSpace(space.Metadata.Name)
@@ -131,7 +138,7 @@ func (b *Exec) purgeSpaceCascade(space intmodel.Space, force, cascade bool) erro
 	}
 
 	// Perform standard delete first (using deleteSpaceCascade)
-	if err := b.deleteSpaceCascade(space, force, cascade); err != nil {
+	if _, err := b.deleteSpaceCascade(space, force, cascade); err != nil {
 		return fmt.Errorf("failed to delete space: %w", err)
 	}
 
@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/events"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 )
 
@@ -35,6 +36,12 @@ type PurgeStackResult struct {
 // PurgeStack purges a stack with comprehensive cleanup. If cascade is true, purges all cells first.
 // If force is true, skips validation of child resources.
 func (b *Exec) PurgeStack(stack intmodel.Stack, force, cascade bool) (PurgeStackResult, error) {
+	res, err := b.purgeStack(stack, force, cascade)
+	b.recordEvent(events.ActionPurge, stackEvent(stack), err)
+	return res, err
+}
+
+func (b *Exec) purgeStack(stack intmodel.Stack, force, cascade bool) (PurgeStackResult, error) {
 	var result PurgeStackResult
 
 	name := strings.TrimSpace(stack.Metadata.Name)
@@ -123,7 +130,7 @@ func (b *Exec) purgeStackCascade(stack intmodel.Stack, force, cascade bool) erro
 	}
 
 	// Perform standard delete first (using deleteStackCascade)
-	if err := b.deleteStackCascade(stack, force, cascade); err != nil {
+	if _, err := b.deleteStackCascade(stack, force, cascade); err != nil {
 		return fmt.Errorf("failed to delete stack: %w", err)
 	}
 
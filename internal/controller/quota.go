@@ -0,0 +1,391 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// QuotaUsage is what the cells of a realm or space use against its
+// ResourceQuota: the cell count and the memoryLimitBytes and cpuShares
+// their workload containers declare.
+type QuotaUsage struct {
+	Cells       int
+	MemoryBytes int64
+	CPUShares   int64
+}
+
+// QuotaStatus is one realm or space quota next to its current usage. Space
+// is empty for a realm quota.
+type QuotaStatus struct {
+	Realm string
+	Space string
+	Limit intmodel.ResourceQuota
+	Used  QuotaUsage
+}
+
+// ListQuotas reports every realm and space that sets a quota, with what its
+// cells use. realm narrows the report to one realm, and space, which needs
+// realm, to one space. Usage is summed from the metadata store the same way
+// the create-time check sums it.
+func (b *Exec) ListQuotas(realm, space string) ([]QuotaStatus, error) {
+	realm = strings.TrimSpace(realm)
+	space = strings.TrimSpace(space)
+	if space != "" && realm == "" {
+		return nil, errdefs.ErrRealmNameRequired
+	}
+
+	var realms []intmodel.Realm
+	if realm == "" {
+		all, err := b.runner.ListRealms()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errdefs.ErrListQuotas, err)
+		}
+		realms = all
+	} else {
+		r, err := b.runner.GetRealm(intmodel.Realm{Metadata: intmodel.RealmMetadata{Name: realm}})
+		if err != nil {
+			return nil, err
+		}
+		realms = []intmodel.Realm{r}
+	}
+
+	var out []QuotaStatus
+	for _, r := range realms {
+		statuses, err := b.realmQuotaStatuses(r, space)
+		if err != nil {
+			return nil, fmt.Errorf("%w: realm %q: %w", errdefs.ErrListQuotas, r.Metadata.Name, err)
+		}
+		out = append(out, statuses...)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Realm != out[j].Realm {
+			return out[i].Realm < out[j].Realm
+		}
+		return out[i].Space < out[j].Space
+	})
+	return out, nil
+}
+
+// realmQuotaStatuses reports the realm's own quota, unless space narrows the
+// report, and the quota of each of its spaces that sets one.
+func (b *Exec) realmQuotaStatuses(realm intmodel.Realm, space string) ([]QuotaStatus, error) {
+	realmName := realm.Metadata.Name
+	spaces, err := b.runner.ListSpaces(realmName)
+	if err != nil {
+		return nil, err
+	}
+	byName := spacesByName(spaces)
+	if space != "" {
+		if _, ok := byName[space]; !ok {
+			return nil, fmt.Errorf("%w: %q", errdefs.ErrSpaceNotFound, space)
+		}
+	}
+
+	var out []QuotaStatus
+	if space == "" && realm.Spec.Quota != nil {
+		cells, listErr := b.runner.ListCells(realmName, "", "")
+		if listErr != nil {
+			return nil, listErr
+		}
+		out = append(out, QuotaStatus{
+			Realm: realmName,
+			Limit: *realm.Spec.Quota,
+			Used:  sumQuotaUsage(cells, byName, nil),
+		})
+	}
+	for _, s := range spaces {
+		if s.Spec.Quota == nil || (space != "" && s.Metadata.Name != space) {
+			continue
+		}
+		cells, listErr := b.runner.ListCells(realmName, s.Metadata.Name, "")
+		if listErr != nil {
+			return nil, listErr
+		}
+		out = append(out, QuotaStatus{
+			Realm: realmName,
+			Space: s.Metadata.Name,
+			Limit: *s.Spec.Quota,
+			Used:  sumQuotaUsage(cells, byName, nil),
+		})
+	}
+	return out, nil
+}
+
+// checkCellQuota rejects a create or update of cell that would take its
+// realm or space over quota. The stored cells are summed with the stored
+// copy of cell replaced by the desired one, and a limit is only enforced
+// when the change grows that figure, so re-applying an unchanged cell, or
+// shrinking one, still works after a quota is lowered below current use.
+// A missing realm or space is left to ValidateCell to report.
+//
+// The check alone is only advisory: the write that follows must go through
+// withCellQuota, which repeats it under the realm's quota lock.
+func (b *Exec) checkCellQuota(cell intmodel.Cell) error {
+	realm, space, found, err := b.cellQuotaScope(cell)
+	if err != nil || !found {
+		return err
+	}
+	realmName := strings.TrimSpace(cell.Spec.RealmName)
+	spaceName := strings.TrimSpace(cell.Spec.SpaceName)
+
+	if realm.Spec.Quota != nil {
+		spaces, listErr := b.runner.ListSpaces(realmName)
+		if listErr != nil {
+			return fmt.Errorf("%w: %w", errdefs.ErrGetSpace, listErr)
+		}
+		byName := spacesByName(spaces)
+		byName[spaceName] = space
+		scope := fmt.Sprintf("realm %q", realmName)
+		if err = b.checkScopeQuota(scope, *realm.Spec.Quota, realmName, "", cell, byName); err != nil {
+			return err
+		}
+	}
+	if space.Spec.Quota != nil {
+		byName := map[string]intmodel.Space{spaceName: space}
+		scope := fmt.Sprintf("space %q", realmName+"/"+spaceName)
+		if err = b.checkScopeQuota(scope, *space.Spec.Quota, realmName, spaceName, cell, byName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cellQuotaScope reads the realm and space cell lives in. found is false
+// when either is missing.
+func (b *Exec) cellQuotaScope(cell intmodel.Cell) (intmodel.Realm, intmodel.Space, bool, error) {
+	realmName := strings.TrimSpace(cell.Spec.RealmName)
+	spaceName := strings.TrimSpace(cell.Spec.SpaceName)
+	realm, err := b.runner.GetRealm(intmodel.Realm{Metadata: intmodel.RealmMetadata{Name: realmName}})
+	if err != nil {
+		if errors.Is(err, errdefs.ErrRealmNotFound) {
+			return intmodel.Realm{}, intmodel.Space{}, false, nil
+		}
+		return intmodel.Realm{}, intmodel.Space{}, false, fmt.Errorf("%w: %w", errdefs.ErrGetRealm, err)
+	}
+	space, err := b.runner.GetSpace(intmodel.Space{
+		Metadata: intmodel.SpaceMetadata{Name: spaceName},
+		Spec:     intmodel.SpaceSpec{RealmName: realmName},
+	})
+	if err != nil {
+		if errors.Is(err, errdefs.ErrSpaceNotFound) {
+			return intmodel.Realm{}, intmodel.Space{}, false, nil
+		}
+		return intmodel.Realm{}, intmodel.Space{}, false, fmt.Errorf("%w: %w", errdefs.ErrGetSpace, err)
+	}
+	return realm, space, true, nil
+}
+
+// withCellQuota runs write, the metadata write that creates or updates
+// cell, holding its realm's quota lock across a repeat of checkCellQuota and
+// the write. Without it two concurrent creates can both count the same
+// stored cells, both pass, and together land over quota. Every mutation goes
+// through the one controller kukeond holds, so the in-process lock is the
+// serialization point. A cell whose realm and space carry no quota, or whose
+// realm or space is missing, runs write without the lock.
+func (b *Exec) withCellQuota(cell intmodel.Cell, write func() error) error {
+	realm, space, found, err := b.cellQuotaScope(cell)
+	if err != nil {
+		return err
+	}
+	if !found || (realm.Spec.Quota == nil && space.Spec.Quota == nil) {
+		return write()
+	}
+	lock, _ := b.quotaLocks.LoadOrStore(strings.TrimSpace(cell.Spec.RealmName), &sync.Mutex{})
+	mu, _ := lock.(*sync.Mutex)
+	mu.Lock()
+	defer mu.Unlock()
+	if err = b.checkCellQuota(cell); err != nil {
+		return err
+	}
+	return write()
+}
+
+// withContainerQuota is withCellQuota for adding or replacing container in
+// its cell, checked as the cell update it amounts to. A missing cell is left
+// to the reconcile to report.
+func (b *Exec) withContainerQuota(container intmodel.Container, write func() error) error {
+	lookup := intmodel.Cell{
+		Metadata: intmodel.CellMetadata{Name: container.Spec.CellName},
+		Spec: intmodel.CellSpec{
+			RealmName: container.Spec.RealmName,
+			SpaceName: container.Spec.SpaceName,
+			StackName: container.Spec.StackName,
+		},
+	}
+	cell, err := b.runner.GetCell(lookup)
+	if err != nil {
+		if errors.Is(err, errdefs.ErrCellNotFound) {
+			return write()
+		}
+		return fmt.Errorf("%w: %w", errdefs.ErrGetCell, err)
+	}
+	desired := cell
+	desired.Spec.Containers = make([]intmodel.ContainerSpec, 0, len(cell.Spec.Containers)+1)
+	replaced := false
+	for _, c := range cell.Spec.Containers {
+		if c.ID == container.Spec.ID {
+			c = container.Spec
+			replaced = true
+		}
+		desired.Spec.Containers = append(desired.Spec.Containers, c)
+	}
+	if !replaced {
+		desired.Spec.Containers = append(desired.Spec.Containers, container.Spec)
+	}
+	return b.withCellQuota(desired, write)
+}
+
+// checkScopeQuota enforces quota on the cells listed under realm (and
+// space, when set) with cell's stored copy swapped for the desired one.
+func (b *Exec) checkScopeQuota(
+	scope string,
+	quota intmodel.ResourceQuota,
+	realm, space string,
+	cell intmodel.Cell,
+	spaces map[string]intmodel.Space,
+) error {
+	cells, err := b.runner.ListCells(realm, space, "")
+	if err != nil {
+		return fmt.Errorf("%w: %w", errdefs.ErrGetCell, err)
+	}
+	var stored *intmodel.Cell
+	for i := range cells {
+		if sameCell(cells[i], cell) {
+			stored = &cells[i]
+			break
+		}
+	}
+	before := sumQuotaUsage(cells, spaces, nil)
+	after := sumQuotaUsage(cells, spaces, &cell)
+
+	if quota.MaxCells > 0 && after.Cells > quota.MaxCells && after.Cells > before.Cells {
+		return fmt.Errorf("%w: %s allows %d cells, cell %q would make %d",
+			errdefs.ErrQuotaExceeded, scope, quota.MaxCells, cell.Metadata.Name, after.Cells)
+	}
+	if err = checkUnlimitedContainers(scope, quota, cell, stored, spaces[cell.Spec.SpaceName]); err != nil {
+		return err
+	}
+	if quota.MaxMemoryBytes > 0 && after.MemoryBytes > quota.MaxMemoryBytes && after.MemoryBytes > before.MemoryBytes {
+		return fmt.Errorf("%w: %s allows %d bytes of memory, cell %q would bring its cells to %d",
+			errdefs.ErrQuotaExceeded, scope, quota.MaxMemoryBytes, cell.Metadata.Name, after.MemoryBytes)
+	}
+	if quota.MaxCPUShares > 0 && after.CPUShares > quota.MaxCPUShares && after.CPUShares > before.CPUShares {
+		return fmt.Errorf("%w: %s allows %d CPU shares, cell %q would bring its cells to %d",
+			errdefs.ErrQuotaExceeded, scope, quota.MaxCPUShares, cell.Metadata.Name, after.CPUShares)
+	}
+	return nil
+}
+
+// checkUnlimitedContainers rejects a workload container that declares no
+// memory (or CPU) limit under a quota that caps memory (or CPU): it would
+// escape the accounting. A container stored without the limit already is
+// let through, so a quota added later does not block re-applying a cell.
+func checkUnlimitedContainers(
+	scope string,
+	quota intmodel.ResourceQuota,
+	cell intmodel.Cell,
+	stored *intmodel.Cell,
+	space intmodel.Space,
+) error {
+	if quota.MaxMemoryBytes <= 0 && quota.MaxCPUShares <= 0 {
+		return nil
+	}
+	storedMemory, storedCPU := map[string]bool{}, map[string]bool{}
+	if stored != nil {
+		for _, c := range workloadContainers(*stored, space) {
+			storedMemory[c.ID] = c.Resources == nil || c.Resources.MemoryLimitBytes == nil
+			storedCPU[c.ID] = c.Resources == nil || c.Resources.CPUShares == nil
+		}
+	}
+	for _, c := range workloadContainers(cell, space) {
+		if quota.MaxMemoryBytes > 0 && (c.Resources == nil || c.Resources.MemoryLimitBytes == nil) &&
+			!storedMemory[c.ID] {
+			return fmt.Errorf("%w: %s caps memory, container %q of cell %q must set resources.memoryLimitBytes",
+				errdefs.ErrQuotaExceeded, scope, c.ID, cell.Metadata.Name)
+		}
+		if quota.MaxCPUShares > 0 && (c.Resources == nil || c.Resources.CPUShares == nil) && !storedCPU[c.ID] {
+			return fmt.Errorf("%w: %s caps CPU, container %q of cell %q must set resources.cpuShares",
+				errdefs.ErrQuotaExceeded, scope, c.ID, cell.Metadata.Name)
+		}
+	}
+	return nil
+}
+
+// sumQuotaUsage totals cells, with the stored copy of replace, when set,
+// swapped for replace (or replace added when it is not stored yet).
+func sumQuotaUsage(cells []intmodel.Cell, spaces map[string]intmodel.Space, replace *intmodel.Cell) QuotaUsage {
+	var total QuotaUsage
+	add := func(cell intmodel.Cell) {
+		total.Cells++
+		for _, c := range workloadContainers(cell, spaces[cell.Spec.SpaceName]) {
+			if c.Resources == nil {
+				continue
+			}
+			if c.Resources.MemoryLimitBytes != nil {
+				total.MemoryBytes += *c.Resources.MemoryLimitBytes
+			}
+			if c.Resources.CPUShares != nil {
+				total.CPUShares += *c.Resources.CPUShares
+			}
+		}
+	}
+	for _, cell := range cells {
+		if replace == nil || !sameCell(cell, *replace) {
+			add(cell)
+		}
+	}
+	if replace != nil {
+		add(*replace)
+	}
+	return total
+}
+
+// workloadContainers returns copies of the cell's non-root containers with
+// the space's container defaults filled in, which is how they are created.
+func workloadContainers(cell intmodel.Cell, space intmodel.Space) []intmodel.ContainerSpec {
+	out := make([]intmodel.ContainerSpec, 0, len(cell.Spec.Containers))
+	for _, c := range cell.Spec.Containers {
+		if c.Root {
+			continue
+		}
+		intmodel.ApplySpaceDefaultsToContainer(space, &c)
+		out = append(out, c)
+	}
+	return out
+}
+
+func sameCell(a, b intmodel.Cell) bool {
+	return a.Metadata.Name == b.Metadata.Name &&
+		a.Spec.SpaceName == b.Spec.SpaceName &&
+		a.Spec.StackName == b.Spec.StackName
+}
+
+func spacesByName(spaces []intmodel.Space) map[string]intmodel.Space {
+	out := make(map[string]intmodel.Space, len(spaces))
+	for _, s := range spaces {
+		out[s.Metadata.Name] = s
+	}
+	return out
+}
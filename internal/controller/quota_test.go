@@ -0,0 +1,261 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+func int64Ptr(v int64) *int64 { return &v }
+
+// quotaCell is a cell in r1/s1/st1 with one workload container limited to
+// memory bytes and cpu shares.
+func quotaCell(name string, memory, cpu int64) intmodel.Cell {
+	cell := validCellWithContainers(
+		intmodel.ContainerSpec{ID: "root", Root: true, Image: "alpine"},
+		intmodel.ContainerSpec{
+			ID: "app", Image: "nginx",
+			Resources: &intmodel.ContainerResources{MemoryLimitBytes: int64Ptr(memory), CPUShares: int64Ptr(cpu)},
+		},
+	)
+	cell.Metadata.Name = name
+	cell.Spec.ID = name
+	return cell
+}
+
+// quotaRunner serves a ready r1 and s1 carrying realmQuota and spaceQuota,
+// with stored as the cells of s1.
+func quotaRunner(realmQuota, spaceQuota *intmodel.ResourceQuota, stored ...intmodel.Cell) *fakeRunner {
+	space := intmodel.Space{
+		Metadata: intmodel.SpaceMetadata{Name: "s1"},
+		Spec:     intmodel.SpaceSpec{RealmName: "r1", Quota: spaceQuota},
+		Status:   intmodel.SpaceStatus{State: intmodel.SpaceStateReady},
+	}
+	return withReadyParents(&fakeRunner{
+		GetRealmFn: func(realm intmodel.Realm) (intmodel.Realm, error) {
+			realm.Spec.Quota = realmQuota
+			realm.Status.State = intmodel.RealmStateReady
+			return realm, nil
+		},
+		ListRealmsFn: func() ([]intmodel.Realm, error) {
+			return []intmodel.Realm{{
+				Metadata: intmodel.RealmMetadata{Name: "r1"},
+				Spec:     intmodel.RealmSpec{Quota: realmQuota},
+			}}, nil
+		},
+		GetSpaceFn: func(intmodel.Space) (intmodel.Space, error) {
+			return space, nil
+		},
+		ListSpacesFn: func(string) ([]intmodel.Space, error) {
+			return []intmodel.Space{space}, nil
+		},
+		ListCellsFn: func(string, string, string) ([]intmodel.Cell, error) {
+			return stored, nil
+		},
+	})
+}
+
+func TestValidateCell_Quota(t *testing.T) {
+	const mib = 1 << 20
+
+	tests := []struct {
+		name       string
+		realmQuota *intmodel.ResourceQuota
+		spaceQuota *intmodel.ResourceQuota
+		stored     []intmodel.Cell
+		cell       intmodel.Cell
+		wantMsg    string
+	}{
+		{
+			name:       "within the realm cell quota",
+			realmQuota: &intmodel.ResourceQuota{MaxCells: 2},
+			stored:     []intmodel.Cell{quotaCell("db", 64*mib, 128)},
+			cell:       quotaCell("web", 64*mib, 128),
+		},
+		{
+			name:       "crossing the realm cell quota",
+			realmQuota: &intmodel.ResourceQuota{MaxCells: 1},
+			stored:     []intmodel.Cell{quotaCell("db", 64*mib, 128)},
+			cell:       quotaCell("web", 64*mib, 128),
+			wantMsg:    `realm "r1" allows 1 cells, cell "web" would make 2`,
+		},
+		{
+			name:       "re-applying a stored cell does not count it twice",
+			realmQuota: &intmodel.ResourceQuota{MaxCells: 1},
+			stored:     []intmodel.Cell{quotaCell("web", 64*mib, 128)},
+			cell:       quotaCell("web", 64*mib, 128),
+		},
+		{
+			name:       "crossing the space memory quota",
+			spaceQuota: &intmodel.ResourceQuota{MaxMemoryBytes: 128 * mib},
+			stored:     []intmodel.Cell{quotaCell("db", 64*mib, 128)},
+			cell:       quotaCell("web", 96*mib, 128),
+			wantMsg:    `space "r1/s1" allows 134217728 bytes of memory, cell "web" would bring its cells to 167772160`,
+		},
+		{
+			name:       "growing a stored cell past the space CPU quota",
+			spaceQuota: &intmodel.ResourceQuota{MaxCPUShares: 512},
+			stored: []intmodel.Cell{
+				quotaCell("db", 64*mib, 256),
+				quotaCell("web", 64*mib, 128),
+			},
+			cell:    quotaCell("web", 64*mib, 512),
+			wantMsg: `space "r1/s1" allows 512 CPU shares, cell "web" would bring its cells to 768`,
+		},
+		{
+			name:       "shrinking a cell already over quota is allowed",
+			spaceQuota: &intmodel.ResourceQuota{MaxCPUShares: 256},
+			stored:     []intmodel.Cell{quotaCell("web", 64*mib, 512)},
+			cell:       quotaCell("web", 64*mib, 384),
+		},
+		{
+			name:       "container without a memory limit under a memory quota",
+			realmQuota: &intmodel.ResourceQuota{MaxMemoryBytes: 128 * mib},
+			cell:       validCellWithContainers(intmodel.ContainerSpec{ID: "app", Image: "nginx"}),
+			wantMsg:    `realm "r1" caps memory, container "app" of cell "web" must set resources.memoryLimitBytes`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := setupTestController(t, quotaRunner(tt.realmQuota, tt.spaceQuota, tt.stored...))
+
+			err := ctrl.ValidateCell(tt.cell)
+			if tt.wantMsg == "" {
+				if err != nil {
+					t.Fatalf("ValidateCell() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, errdefs.ErrQuotaExceeded) {
+				t.Fatalf("ValidateCell() error = %v, want %v", err, errdefs.ErrQuotaExceeded)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("ValidateCell() error = %q, want it to mention %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+// TestCreateCell_QuotaConcurrent races two creates for the last cell a realm
+// allows. Both pass ValidateCell's check before either lands, so only the
+// check repeated under the realm's quota lock, across the write, keeps the
+// second one out.
+func TestCreateCell_QuotaConcurrent(t *testing.T) {
+	const mib = 1 << 20
+
+	var (
+		mu     sync.Mutex
+		stored []intmodel.Cell
+	)
+	f := quotaRunner(&intmodel.ResourceQuota{MaxCells: 1}, nil)
+	f.ListCellsFn = func(string, string, string) ([]intmodel.Cell, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(stored), nil
+	}
+	f.GetCellFn = func(intmodel.Cell) (intmodel.Cell, error) {
+		return intmodel.Cell{}, errdefs.ErrCellNotFound
+	}
+	f.CreateCellFn = func(cell intmodel.Cell) (intmodel.Cell, error) {
+		// Keep the write open long enough for the other create to run its
+		// ValidateCell check against a store without this cell.
+		time.Sleep(50 * time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		stored = append(stored, cell)
+		return cell, nil
+	}
+	f.StartCellFn = func(cell intmodel.Cell) (intmodel.Cell, error) {
+		return cell, nil
+	}
+	ctrl := setupTestController(t, f)
+
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i, name := range []string{"web", "db"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = ctrl.CreateCell(quotaCell(name, 64*mib, 128))
+		}()
+	}
+	wg.Wait()
+
+	var created, rejected int
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case errors.Is(err, errdefs.ErrQuotaExceeded):
+			rejected++
+		default:
+			t.Fatalf("CreateCell() error = %v, want nil or ErrQuotaExceeded", err)
+		}
+	}
+	if created != 1 || rejected != 1 {
+		t.Errorf("created %d and rejected %d cells, want 1 and 1", created, rejected)
+	}
+	if len(stored) != 1 {
+		t.Errorf("stored %d cells, want 1 under MaxCells 1", len(stored))
+	}
+}
+
+func TestListQuotas(t *testing.T) {
+	const mib = 1 << 20
+	realmQuota := &intmodel.ResourceQuota{MaxCells: 10}
+	spaceQuota := &intmodel.ResourceQuota{MaxMemoryBytes: 512 * mib}
+	stored := []intmodel.Cell{
+		quotaCell("db", 64*mib, 128),
+		quotaCell("web", 96*mib, 256),
+	}
+	ctrl := setupTestController(t, quotaRunner(realmQuota, spaceQuota, stored...))
+
+	got, err := ctrl.ListQuotas("", "")
+	if err != nil {
+		t.Fatalf("ListQuotas() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("ListQuotas() returned %d quotas, want 2: %+v", len(got), got)
+	}
+	if got[0].Realm != "r1" || got[0].Space != "" || got[0].Limit != *realmQuota {
+		t.Errorf("first quota = %+v, want the r1 realm quota", got[0])
+	}
+	if got[1].Space != "s1" || got[1].Limit != *spaceQuota {
+		t.Errorf("second quota = %+v, want the r1/s1 space quota", got[1])
+	}
+	for _, q := range got {
+		if q.Used.Cells != 2 || q.Used.MemoryBytes != 160*mib || q.Used.CPUShares != 384 {
+			t.Errorf("%s/%s usage = %+v, want 2 cells, %d bytes, 384 shares", q.Realm, q.Space, q.Used, 160*mib)
+		}
+	}
+
+	if _, err = ctrl.ListQuotas("", "s1"); !errors.Is(err, errdefs.ErrRealmNameRequired) {
+		t.Errorf("ListQuotas(\"\", \"s1\") error = %v, want %v", err, errdefs.ErrRealmNameRequired)
+	}
+	if _, err = ctrl.ListQuotas("r1", "missing"); !errors.Is(err, errdefs.ErrSpaceNotFound) {
+		t.Errorf("ListQuotas(\"r1\", \"missing\") error = %v, want %v", err, errdefs.ErrSpaceNotFound)
+	}
+}
@@ -17,8 +17,11 @@
 package controller
 
 import (
+	"context"
 	"fmt"
+	"sync"
 
+	"github.com/eminwux/kukeon/internal/drain"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 	"github.com/eminwux/kukeon/internal/util/diskpressure"
 	"github.com/eminwux/kukeon/internal/util/fs"
@@ -95,10 +98,23 @@ func (b *Exec) ReconcileSpaceNetworks() (SpaceNetReconcileResult, error) {
 	return result, nil
 }
 
+// WatchContainerExits records container exits into cell status as containerd
+// publishes them, complementing the periodic ReconcileCells pass. Blocks until
+// ctx is cancelled or the subscription fails.
+func (b *Exec) WatchContainerExits(ctx context.Context) error {
+	return b.runner.WatchContainerExits(ctx)
+}
+
 // ReconcileCells walks every realm/space/stack and reconciles each cell's
 // status against observed container state. Errors at any level are logged
 // and recorded in Errors; the walk continues so a single bad cell does not
 // silence the rest of the host.
+//
+// The hierarchy walk is sequential; the per-cell reconciles then run on up to
+// Options.ReconcileConcurrency workers. Concurrent workers never touch the same
+// cell, and each runner.ReconcileCell holds the per-cell lifecycle lock and
+// persists through the metadata flock + generation guard, so a pass cannot
+// race a user command on the same cell.
 func (b *Exec) ReconcileCells() (ReconcileResult, error) {
 	result := ReconcileResult{Errors: []string{}}
 
@@ -114,6 +130,7 @@ func (b *Exec) ReconcileCells() (ReconcileResult, error) {
 	// (issue #1035).
 	b.checkDiskPressure(realms)
 
+	var cells []intmodel.Cell
 	for _, realm := range realms {
 		realmName := realm.Metadata.Name
 		spaces, listErr := b.runner.ListSpaces(realmName)
@@ -132,70 +149,123 @@ func (b *Exec) ReconcileCells() (ReconcileResult, error) {
 			}
 			for _, stack := range stacks {
 				stackName := stack.Metadata.Name
-				cells, cellsErr := b.runner.ListCells(realmName, spaceName, stackName)
+				stackCells, cellsErr := b.runner.ListCells(realmName, spaceName, stackName)
 				if cellsErr != nil {
 					result.Errors = append(result.Errors,
 						fmt.Sprintf("list cells in %s/%s/%s: %v",
 							realmName, spaceName, stackName, cellsErr))
 					continue
 				}
-				for _, cell := range cells {
-					result.CellsScanned++
-					reconciled, outcome, reconcileErr := b.runner.ReconcileCell(cell)
-					if reconcileErr != nil {
-						result.CellsErrored++
-						result.Errors = append(result.Errors,
-							fmt.Sprintf("reconcile cell %s/%s/%s/%s: %v",
-								realmName, spaceName, stackName,
-								cell.Metadata.Name, reconcileErr))
-						continue
-					}
-					switch {
-					case outcome.Deleted:
-						result.CellsDeleted++
-					case outcome.Updated:
-						result.CellsUpdated++
-					}
-					// OutOfSync detection (issue #820, foundation phase of
-					// #819's umbrella): for Config-lineage cells, surface a
-					// persistent OutOfSync flag in status by re-deriving
-					// the would-be cell from the daemon-stored Config +
-					// Blueprint and diffing against the live spec. Skips
-					// deleted cells (the reconcile outcome already wiped
-					// them) and cells without the kukeon.io/config label.
-					// A persisted write counts toward CellsUpdated so the
-					// reconcile summary reflects the metadata flip.
-					//
-					// Vanished cells (#1251) short-circuit the same way: the
-					// metadata is already gone, so re-deriving OutOfSync and
-					// persisting it would rewrite the just-deleted
-					// metadata.json — the very resurrection the post-lock
-					// recheck exists to prevent. Unlike Deleted, a Vanished
-					// outcome is left out of CellsDeleted (the reconciler
-					// observed an external delete, it did not perform one).
-					if outcome.Deleted || outcome.Vanished {
-						continue
-					}
-					syncUpdated, syncErr := reconcileCellOutOfSync(b.runner, reconciled)
-					if syncErr != nil {
-						result.CellsErrored++
-						result.Errors = append(result.Errors,
-							fmt.Sprintf("OutOfSync detect cell %s/%s/%s/%s: %v",
-								realmName, spaceName, stackName,
-								cell.Metadata.Name, syncErr))
-						continue
-					}
-					if syncUpdated && !outcome.Updated {
-						result.CellsUpdated++
-					}
-				}
+				cells = append(cells, stackCells...)
+			}
+		}
+	}
+
+	// Cells under a drained scope were stopped for maintenance; the pass
+	// must neither re-derive them (a Stopped cell would settle at Exited and
+	// AutoDelete could reap it) nor restart them. An unreadable drain marker
+	// fails closed, like the cordon marker: nothing is reconciled until it
+	// is fixed.
+	drained, drainErr := drain.Load(b.opts.RunPath)
+	if drainErr != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("load drain marker: %v", drainErr))
+		return result, nil
+	}
+	if len(drained.Scopes) > 0 {
+		kept := cells[:0]
+		for _, cell := range cells {
+			if _, ok := drained.Covering(cell.Spec.RealmName, cell.Spec.SpaceName, cell.Spec.StackName); ok {
+				continue
+			}
+			kept = append(kept, cell)
+		}
+		cells = kept
+	}
+
+	outcomes := make([]cellReconcileOutcome, len(cells))
+	workers := min(max(b.opts.ReconcileConcurrency, 1), len(cells))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				outcomes[i] = b.reconcileOneCell(cells[i])
 			}
+		}()
+	}
+	for i := range cells {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	// Fold in list order so Errors reads the same regardless of which worker
+	// finished first.
+	for _, outcome := range outcomes {
+		result.CellsScanned++
+		switch {
+		case outcome.deleted:
+			result.CellsDeleted++
+		case outcome.updated:
+			result.CellsUpdated++
+		}
+		if outcome.err != "" {
+			result.CellsErrored++
+			result.Errors = append(result.Errors, outcome.err)
 		}
 	}
 
 	return result, nil
 }
 
+// cellReconcileOutcome is one cell's contribution to a ReconcileResult.
+type cellReconcileOutcome struct {
+	updated bool
+	deleted bool
+	err     string
+}
+
+// reconcileOneCell reconciles a single cell and reports how it should be
+// counted. Safe to call from concurrent workers for distinct cells.
+func (b *Exec) reconcileOneCell(cell intmodel.Cell) cellReconcileOutcome {
+	cellPath := fmt.Sprintf("%s/%s/%s/%s",
+		cell.Spec.RealmName, cell.Spec.SpaceName, cell.Spec.StackName, cell.Metadata.Name)
+	reconciled, outcome, reconcileErr := b.runner.ReconcileCell(cell)
+	if reconcileErr != nil {
+		return cellReconcileOutcome{err: fmt.Sprintf("reconcile cell %s: %v", cellPath, reconcileErr)}
+	}
+	if outcome.Deleted {
+		return cellReconcileOutcome{deleted: true}
+	}
+	// OutOfSync detection (issue #820, foundation phase of #819's umbrella):
+	// for Config-lineage cells, surface a persistent OutOfSync flag in status
+	// by re-deriving the would-be cell from the daemon-stored Config +
+	// Blueprint and diffing against the live spec. Skips deleted cells (the
+	// reconcile outcome already wiped them) and cells without the
+	// kukeon.io/config label. A persisted write counts toward CellsUpdated so
+	// the reconcile summary reflects the metadata flip.
+	//
+	// Vanished cells (#1251) short-circuit the same way: the metadata is
+	// already gone, so re-deriving OutOfSync and persisting it would rewrite
+	// the just-deleted metadata.json — the very resurrection the post-lock
+	// recheck exists to prevent. Unlike Deleted, a Vanished outcome is left
+	// out of CellsDeleted (the reconciler observed an external delete, it did
+	// not perform one).
+	if outcome.Vanished {
+		return cellReconcileOutcome{updated: outcome.Updated}
+	}
+	syncUpdated, syncErr := reconcileCellOutOfSync(b.runner, reconciled)
+	if syncErr != nil {
+		return cellReconcileOutcome{
+			updated: outcome.Updated,
+			err:     fmt.Sprintf("OutOfSync detect cell %s: %v", cellPath, syncErr),
+		}
+	}
+	return cellReconcileOutcome{updated: outcome.Updated || syncUpdated}
+}
+
 // checkDiskPressure samples the data volume backing each realm's metadata tree
 // and emits a rate-limited WARN for any realm whose usage is at or above the
 // configured warn threshold. It deletes nothing — the WARN is the entire
@@ -19,10 +19,13 @@
 package controller_test
 
 import (
+	"context"
 	"errors"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/eminwux/kukeon/internal/controller"
 	"github.com/eminwux/kukeon/internal/controller/runner"
@@ -342,3 +345,61 @@ func TestReconcileCells_AutoDeleteFailureSurfacedAndLoopContinues(t *testing.T)
 		t.Errorf("Errors: got %v, want one entry naming rm-cell", res.Errors)
 	}
 }
+
+// TestReconcileCells_ConcurrencyBoundsWorkers pins the worker-pool contract:
+// with ReconcileConcurrency=2 two cells are reconciled at once, never more,
+// and every cell is still counted exactly once.
+func TestReconcileCells_ConcurrencyBoundsWorkers(t *testing.T) {
+	realm := buildTestRealm("realm-a", "")
+	space := buildTestSpace("space-a", "realm-a")
+	stack := buildTestStack("stack-a", "realm-a", "space-a")
+	var cells []intmodel.Cell
+	for _, name := range []string{"c0", "c1", "c2", "c3", "c4"} {
+		cells = append(cells, buildTestCell(name, "realm-a", "space-a", "stack-a"))
+	}
+
+	var inFlight, peak atomic.Int32
+	bothRunning := make(chan struct{})
+	var once sync.Once
+	mock := &fakeRunner{
+		ListRealmsFn: func() ([]intmodel.Realm, error) { return []intmodel.Realm{realm}, nil },
+		ListSpacesFn: func(string) ([]intmodel.Space, error) { return []intmodel.Space{space}, nil },
+		ListStacksFn: func(string, string) ([]intmodel.Stack, error) {
+			return []intmodel.Stack{stack}, nil
+		},
+		ListCellsFn: func(string, string, string) ([]intmodel.Cell, error) { return cells, nil },
+		ReconcileCellFn: func(cell intmodel.Cell) (intmodel.Cell, runner.ReconcileOutcome, error) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			if n == 2 {
+				once.Do(func() { close(bothRunning) })
+			}
+			// Hold the first workers until two overlap so the pool width is
+			// observable; later cells pass straight through.
+			select {
+			case <-bothRunning:
+			case <-time.After(2 * time.Second):
+			}
+			return cell, runner.ReconcileOutcome{Updated: true}, nil
+		},
+	}
+
+	ctrl := controller.NewControllerExecForTesting(context.Background(), setupTestLogger(t),
+		controller.Options{ReconcileConcurrency: 2}, mock)
+	res, err := ctrl.ReconcileCells()
+	if err != nil {
+		t.Fatalf("ReconcileCells() error = %v", err)
+	}
+	if got := peak.Load(); got != 2 {
+		t.Errorf("peak concurrent reconciles = %d, want 2", got)
+	}
+	if res.CellsScanned != len(cells) || res.CellsUpdated != len(cells) {
+		t.Errorf("counters = %+v, want %d scanned and updated", res, len(cells))
+	}
+}
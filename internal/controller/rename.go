@@ -0,0 +1,373 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/consts"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/util/naming"
+)
+
+// RenameCellResult reports the outcome of a cell rename.
+type RenameCellResult struct {
+	Cell    intmodel.Cell
+	OldName string
+}
+
+// RenameStackResult reports the outcome of a stack rename.
+type RenameStackResult struct {
+	Stack   intmodel.Stack
+	OldName string
+}
+
+// RenameSpaceResult reports the outcome of a space rename.
+type RenameSpaceResult struct {
+	Space   intmodel.Space
+	OldName string
+}
+
+// RenameRealmResult reports the outcome of a realm rename.
+type RenameRealmResult struct {
+	Realm   intmodel.Realm
+	OldName string
+}
+
+// RenameCell renames a stopped cell within its stack. The metadata subtree
+// moves with the cell and the containerd records keep their identity; see
+// runner.RenameCell for what is carried over and what is recreated on the
+// next start.
+func (b *Exec) RenameCell(cell intmodel.Cell, newName string) (RenameCellResult, error) {
+	var res RenameCellResult
+
+	name := strings.TrimSpace(cell.Metadata.Name)
+	if name == "" {
+		return res, errdefs.ErrCellNameRequired
+	}
+	if strings.TrimSpace(cell.Spec.RealmName) == "" {
+		return res, errdefs.ErrRealmNameRequired
+	}
+	if strings.TrimSpace(cell.Spec.SpaceName) == "" {
+		return res, errdefs.ErrSpaceNameRequired
+	}
+	if strings.TrimSpace(cell.Spec.StackName) == "" {
+		return res, errdefs.ErrStackNameRequired
+	}
+
+	renamed, err := b.runner.RenameCell(cell, newName)
+	if err != nil {
+		return res, err
+	}
+	res.Cell = renamed
+	res.OldName = name
+	return res, nil
+}
+
+// RenameStack renames an empty stack. A stack's cgroup is keyed by its name,
+// so the rename recreates the stack under the new name and deletes the old
+// one; it is refused while the stack still holds cells or stack-scoped
+// secrets, blueprints, configs, or volumes.
+func (b *Exec) RenameStack(stack intmodel.Stack, newName string) (RenameStackResult, error) {
+	var res RenameStackResult
+
+	name := strings.TrimSpace(stack.Metadata.Name)
+	if name == "" {
+		return res, errdefs.ErrStackNameRequired
+	}
+	realmName := strings.TrimSpace(stack.Spec.RealmName)
+	if realmName == "" {
+		return res, errdefs.ErrRealmNameRequired
+	}
+	spaceName := strings.TrimSpace(stack.Spec.SpaceName)
+	if spaceName == "" {
+		return res, errdefs.ErrSpaceNameRequired
+	}
+	newName = strings.TrimSpace(newName)
+	if err := naming.ValidateHierarchyName("stack", newName); err != nil {
+		return res, err
+	}
+	if newName == name {
+		return res, fmt.Errorf("%w: stack %q", errdefs.ErrRenameSameName, name)
+	}
+
+	internalStack, err := b.runner.GetStack(stack)
+	if err != nil {
+		return res, err
+	}
+	target := intmodel.Stack{
+		Metadata: intmodel.StackMetadata{Name: newName},
+		Spec:     intmodel.StackSpec{RealmName: realmName, SpaceName: spaceName},
+	}
+	if _, err = b.runner.GetStack(target); err == nil {
+		return res, fmt.Errorf("%w: stack %q in space %q", errdefs.ErrRenameTargetExists, newName, spaceName)
+	} else if !errors.Is(err, errdefs.ErrStackNotFound) {
+		return res, fmt.Errorf("%w: %w", errdefs.ErrGetStack, err)
+	}
+
+	cells, err := b.runner.ListCells(realmName, spaceName, name)
+	if err != nil {
+		return res, fmt.Errorf("failed to list cells: %w", err)
+	}
+	blockers := make([]string, 0, len(cells))
+	for _, cell := range cells {
+		blockers = append(blockers, "cell:"+cell.Metadata.Name)
+	}
+	scoped, err := b.scopedResourceNames(realmName, spaceName, name)
+	if err != nil {
+		return res, err
+	}
+	if blockers = append(blockers, scoped...); len(blockers) > 0 {
+		return res, fmt.Errorf("%w: stack %q holds %v; only an empty stack can be renamed",
+			errdefs.ErrResourceHasDependencies, name, blockers)
+	}
+
+	target.Metadata.Labels = relabel(internalStack.Metadata.Labels, consts.KukeonStackLabelKey, name, newName)
+	target.Metadata.Annotations = maps.Clone(internalStack.Metadata.Annotations)
+	target.Spec.ID = newName
+	if err = b.runner.DeleteStack(internalStack); err != nil {
+		return res, fmt.Errorf("%w: %w", errdefs.ErrDeleteStack, err)
+	}
+	created, err := b.CreateStack(target)
+	if err != nil {
+		if _, rbErr := b.CreateStack(restoreStack(internalStack)); rbErr != nil {
+			b.logger.ErrorContext(b.ctx, "failed to restore stack after rename failure",
+				"stack", name, "error", rbErr)
+		}
+		return res, err
+	}
+	res.Stack = created.Stack
+	res.OldName = name
+	return res, nil
+}
+
+// RenameSpace renames an empty space. The space's cgroup, CNI network, and
+// subnet allocation are all keyed by its name, so the rename recreates the
+// space under the new name and deletes the old one; it is refused while the
+// space still holds stacks or space-scoped secrets, blueprints, configs, or
+// volumes.
+func (b *Exec) RenameSpace(space intmodel.Space, newName string) (RenameSpaceResult, error) {
+	var res RenameSpaceResult
+
+	name := strings.TrimSpace(space.Metadata.Name)
+	if name == "" {
+		return res, errdefs.ErrSpaceNameRequired
+	}
+	realmName := strings.TrimSpace(space.Spec.RealmName)
+	if realmName == "" {
+		return res, errdefs.ErrRealmNameRequired
+	}
+	newName = strings.TrimSpace(newName)
+	if err := naming.ValidateHierarchyName("space", newName); err != nil {
+		return res, err
+	}
+	if newName == name {
+		return res, fmt.Errorf("%w: space %q", errdefs.ErrRenameSameName, name)
+	}
+
+	internalSpace, err := b.runner.GetSpace(space)
+	if err != nil {
+		return res, err
+	}
+	target := intmodel.Space{
+		Metadata: intmodel.SpaceMetadata{Name: newName},
+		Spec:     intmodel.SpaceSpec{RealmName: realmName},
+	}
+	if _, err = b.runner.GetSpace(target); err == nil {
+		return res, fmt.Errorf("%w: space %q in realm %q", errdefs.ErrRenameTargetExists, newName, realmName)
+	} else if !errors.Is(err, errdefs.ErrSpaceNotFound) {
+		return res, fmt.Errorf("%w: %w", errdefs.ErrGetSpace, err)
+	}
+
+	stacks, err := b.runner.ListStacks(realmName, name)
+	if err != nil {
+		return res, fmt.Errorf("failed to list stacks: %w", err)
+	}
+	blockers := make([]string, 0, len(stacks))
+	for _, stack := range stacks {
+		blockers = append(blockers, "stack:"+stack.Metadata.Name)
+	}
+	scoped, err := b.scopedResourceNames(realmName, name, "")
+	if err != nil {
+		return res, err
+	}
+	if blockers = append(blockers, scoped...); len(blockers) > 0 {
+		return res, fmt.Errorf("%w: space %q holds %v; only an empty space can be renamed",
+			errdefs.ErrResourceHasDependencies, name, blockers)
+	}
+
+	target.Metadata.Labels = relabel(internalSpace.Metadata.Labels, consts.KukeonSpaceLabelKey, name, newName)
+	target.Metadata.Annotations = maps.Clone(internalSpace.Metadata.Annotations)
+	// The spec carries over whole; only the CNI config path is derived from
+	// the name, and the create re-derives it.
+	target.Spec = internalSpace.Spec
+	target.Spec.CNIConfigPath = ""
+	if err = b.runner.DeleteSpace(internalSpace); err != nil {
+		return res, fmt.Errorf("%w: %w", errdefs.ErrDeleteSpace, err)
+	}
+	created, err := b.CreateSpace(target)
+	if err != nil {
+		if _, rbErr := b.CreateSpace(restoreSpace(internalSpace)); rbErr != nil {
+			b.logger.ErrorContext(b.ctx, "failed to restore space after rename failure",
+				"space", name, "error", rbErr)
+		}
+		return res, err
+	}
+	res.Space = created.Space
+	res.OldName = name
+	return res, nil
+}
+
+// RenameRealm renames an empty realm. containerd cannot rename a namespace,
+// so the rename creates a fresh <new>.kukeon.io namespace, cgroup, and
+// metadata tree and deletes the old realm. The realm must hold no spaces or
+// realm-scoped secrets, blueprints, configs, or volumes — nothing of it can
+// be running — and images loaded into the old namespace are not carried over.
+func (b *Exec) RenameRealm(realm intmodel.Realm, newName string) (RenameRealmResult, error) {
+	var res RenameRealmResult
+
+	name := strings.TrimSpace(realm.Metadata.Name)
+	if name == "" {
+		return res, errdefs.ErrRealmNameRequired
+	}
+	newName = strings.TrimSpace(newName)
+	if err := naming.ValidateRealmName(newName); err != nil {
+		return res, err
+	}
+	if newName == name {
+		return res, fmt.Errorf("%w: realm %q", errdefs.ErrRenameSameName, name)
+	}
+
+	internalRealm, err := b.runner.GetRealm(realm)
+	if err != nil {
+		return res, err
+	}
+	target := intmodel.Realm{Metadata: intmodel.RealmMetadata{Name: newName}}
+	if _, err = b.runner.GetRealm(target); err == nil {
+		return res, fmt.Errorf("%w: realm %q", errdefs.ErrRenameTargetExists, newName)
+	} else if !errors.Is(err, errdefs.ErrRealmNotFound) {
+		return res, fmt.Errorf("%w: %w", errdefs.ErrGetRealm, err)
+	}
+
+	spaces, err := b.runner.ListSpaces(name)
+	if err != nil {
+		return res, fmt.Errorf("failed to list spaces: %w", err)
+	}
+	blockers := make([]string, 0, len(spaces))
+	for _, space := range spaces {
+		blockers = append(blockers, "space:"+space.Metadata.Name)
+	}
+	scoped, err := b.scopedResourceNames(name, "", "")
+	if err != nil {
+		return res, err
+	}
+	if blockers = append(blockers, scoped...); len(blockers) > 0 {
+		return res, fmt.Errorf("%w: realm %q holds %v; only an empty realm can be renamed",
+			errdefs.ErrResourceHasDependencies, name, blockers)
+	}
+
+	target.Metadata.Labels = relabel(internalRealm.Metadata.Labels, consts.KukeonRealmLabelKey, name, newName)
+	target.Metadata.Annotations = maps.Clone(internalRealm.Metadata.Annotations)
+	// A namespace that was derived from the old name follows the rename; an
+	// explicitly chosen one would collide with the realm being deleted.
+	if ns := internalRealm.Spec.Namespace; ns != "" && ns != consts.RealmNamespace(name) {
+		return res, fmt.Errorf("realm %q uses the custom namespace %q; recreate it instead of renaming", name, ns)
+	}
+	// The spec carries over whole; the namespace is cleared so the create
+	// derives it from the new name.
+	target.Spec = internalRealm.Spec
+	target.Spec.Namespace = ""
+	created, err := b.CreateRealm(target)
+	if err != nil {
+		return res, err
+	}
+	if err = b.runner.DeleteRealm(internalRealm); err != nil {
+		return res, fmt.Errorf("%w: %w", errdefs.ErrDeleteRealm, err)
+	}
+	res.Realm = created.Realm
+	res.OldName = name
+	return res, nil
+}
+
+// scopedResourceNames lists the secrets, blueprints, configs, and volumes
+// bound to the given scope or any scope nested in it, as kind:name pairs.
+// A scope-keyed rename refuses to proceed while any of them exist, since
+// their files live under — and their documents name — the old scope.
+func (b *Exec) scopedResourceNames(realmName, spaceName, stackName string) ([]string, error) {
+	var names []string
+	secrets, err := b.runner.ListSecrets(realmName, spaceName, stackName, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+	for _, s := range secrets {
+		names = append(names, "secret:"+s.Metadata.Name)
+	}
+	blueprints, err := b.runner.ListBlueprints(realmName, spaceName, stackName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blueprints: %w", err)
+	}
+	for _, bp := range blueprints {
+		names = append(names, "blueprint:"+bp.Metadata.Name)
+	}
+	configs, err := b.runner.ListConfigs(realmName, spaceName, stackName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configs: %w", err)
+	}
+	for _, c := range configs {
+		names = append(names, "config:"+c.Metadata.Name)
+	}
+	volumes, err := b.runner.ListVolumes(realmName, spaceName, stackName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volumes: %w", err)
+	}
+	for _, v := range volumes {
+		names = append(names, "volume:"+v.Metadata.Name)
+	}
+	return names, nil
+}
+
+// relabel copies labels, pointing key at newName when it carried oldName.
+// Other labels — including a key a user deliberately set to something else —
+// are preserved verbatim.
+func relabel(labels map[string]string, key, oldName, newName string) map[string]string {
+	out := make(map[string]string, len(labels))
+	for k, v := range labels {
+		out[k] = v
+	}
+	if v, ok := out[key]; !ok || v == oldName {
+		out[key] = newName
+	}
+	return out
+}
+
+// restoreStack strips the runtime status from a stack read off disk so it
+// can be fed back into CreateStack as a rollback.
+func restoreStack(stack intmodel.Stack) intmodel.Stack {
+	return intmodel.Stack{Metadata: stack.Metadata, Spec: stack.Spec}
+}
+
+// restoreSpace is the Space counterpart of restoreStack. The CNI config
+// path is cleared because the rollback create re-derives it.
+func restoreSpace(space intmodel.Space) intmodel.Space {
+	out := intmodel.Space{Metadata: space.Metadata, Spec: space.Spec}
+	out.Spec.CNIConfigPath = ""
+	return out
+}
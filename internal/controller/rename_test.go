@@ -0,0 +1,286 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/consts"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// emptyScopeRunner wires the List* calls scopedResourceNames makes to return
+// nothing, so a test only has to stub the kind-specific child listing.
+func emptyScopeRunner() *fakeRunner {
+	return &fakeRunner{
+		ListSecretsFn: func(_, _, _, _ string) ([]intmodel.Secret, error) { return nil, nil },
+		ListBlueprintsFn: func(_, _, _ string) ([]intmodel.CellBlueprint, error) {
+			return nil, nil
+		},
+		ListConfigsFn: func(_, _, _ string) ([]intmodel.CellConfig, error) { return nil, nil },
+		ListVolumesFn: func(_, _, _ string) ([]intmodel.Volume, error) { return nil, nil },
+	}
+}
+
+func TestRenameCell_DelegatesToRunner(t *testing.T) {
+	var gotName string
+	mockRunner := &fakeRunner{
+		RenameCellFn: func(cell intmodel.Cell, newName string) (intmodel.Cell, error) {
+			gotName = newName
+			cell.Metadata.Name = newName
+			return cell, nil
+		},
+	}
+	ctrl := setupTestController(t, mockRunner)
+
+	res, err := ctrl.RenameCell(buildTestCell("web", "r1", "s1", "st1"), "api")
+	if err != nil {
+		t.Fatalf("RenameCell: %v", err)
+	}
+	if gotName != "api" || res.Cell.Metadata.Name != "api" || res.OldName != "web" {
+		t.Errorf("unexpected result: runner got %q, result %+v", gotName, res)
+	}
+}
+
+func TestRenameCell_RequiresScope(t *testing.T) {
+	ctrl := setupTestController(t, &fakeRunner{})
+	_, err := ctrl.RenameCell(buildTestCell("web", "r1", "s1", ""), "api")
+	if !errors.Is(err, errdefs.ErrStackNameRequired) {
+		t.Fatalf("err = %v, want ErrStackNameRequired", err)
+	}
+}
+
+func TestRenameStack_RefusesStackWithCells(t *testing.T) {
+	mockRunner := emptyScopeRunner()
+	mockRunner.GetStackFn = func(stack intmodel.Stack) (intmodel.Stack, error) {
+		if stack.Metadata.Name == "old" {
+			return buildTestStack("old", "r1", "s1"), nil
+		}
+		return intmodel.Stack{}, errdefs.ErrStackNotFound
+	}
+	mockRunner.ListCellsFn = func(_, _, _ string) ([]intmodel.Cell, error) {
+		return []intmodel.Cell{buildTestCell("web", "r1", "s1", "old")}, nil
+	}
+	mockRunner.DeleteStackFn = func(intmodel.Stack) error {
+		t.Fatal("DeleteStack must not run for a non-empty stack")
+		return nil
+	}
+	ctrl := setupTestController(t, mockRunner)
+
+	_, err := ctrl.RenameStack(buildTestStack("old", "r1", "s1"), "new")
+	if !errors.Is(err, errdefs.ErrResourceHasDependencies) {
+		t.Fatalf("err = %v, want ErrResourceHasDependencies", err)
+	}
+}
+
+func TestRenameStack_RecreatesUnderNewName(t *testing.T) {
+	old := buildTestStack("old", "r1", "s1")
+	old.Spec.ID = "old"
+	old.Metadata.Labels = map[string]string{
+		consts.KukeonStackLabelKey: "old",
+		"team":                     "blue",
+	}
+	old.Metadata.Annotations = map[string]string{"owner": "platform"}
+
+	mockRunner := emptyScopeRunner()
+	mockRunner.GetStackFn = func(stack intmodel.Stack) (intmodel.Stack, error) {
+		if stack.Metadata.Name == "old" {
+			return old, nil
+		}
+		return intmodel.Stack{}, errdefs.ErrStackNotFound
+	}
+	mockRunner.ListCellsFn = func(_, _, _ string) ([]intmodel.Cell, error) { return nil, nil }
+	var deleted string
+	mockRunner.DeleteStackFn = func(stack intmodel.Stack) error {
+		deleted = stack.Metadata.Name
+		return nil
+	}
+	var created intmodel.Stack
+	mockRunner.CreateStackFn = func(stack intmodel.Stack) (intmodel.Stack, error) {
+		created = stack
+		return stack, nil
+	}
+	ctrl := setupTestController(t, mockRunner)
+
+	res, err := ctrl.RenameStack(old, "new")
+	if err != nil {
+		t.Fatalf("RenameStack: %v", err)
+	}
+	if deleted != "old" {
+		t.Errorf("deleted stack = %q, want %q", deleted, "old")
+	}
+	if created.Metadata.Name != "new" || created.Spec.ID != "new" {
+		t.Errorf("created stack name/id = %q/%q, want new/new", created.Metadata.Name, created.Spec.ID)
+	}
+	if got := created.Metadata.Labels[consts.KukeonStackLabelKey]; got != "new" {
+		t.Errorf("stack label = %q, want %q", got, "new")
+	}
+	if got := created.Metadata.Labels["team"]; got != "blue" {
+		t.Errorf("user label dropped: team = %q", got)
+	}
+	if got := created.Metadata.Annotations["owner"]; got != "platform" {
+		t.Errorf("annotation dropped: owner = %q", got)
+	}
+	if res.OldName != "old" || res.Stack.Metadata.Name != "new" {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
+
+func TestRenameRealm_RefusesRealmWithSpaces(t *testing.T) {
+	mockRunner := emptyScopeRunner()
+	mockRunner.GetRealmFn = func(realm intmodel.Realm) (intmodel.Realm, error) {
+		if realm.Metadata.Name == "old" {
+			return buildTestRealm("old", ""), nil
+		}
+		return intmodel.Realm{}, errdefs.ErrRealmNotFound
+	}
+	mockRunner.ListSpacesFn = func(string) ([]intmodel.Space, error) {
+		return []intmodel.Space{buildTestSpace("default", "old")}, nil
+	}
+	ctrl := setupTestController(t, mockRunner)
+
+	_, err := ctrl.RenameRealm(buildTestRealm("old", ""), "new")
+	if !errors.Is(err, errdefs.ErrResourceHasDependencies) {
+		t.Fatalf("err = %v, want ErrResourceHasDependencies", err)
+	}
+}
+
+func TestRenameSpace_RefusesTakenName(t *testing.T) {
+	mockRunner := emptyScopeRunner()
+	mockRunner.GetSpaceFn = func(space intmodel.Space) (intmodel.Space, error) {
+		return buildTestSpace(space.Metadata.Name, "r1"), nil
+	}
+	ctrl := setupTestController(t, mockRunner)
+
+	_, err := ctrl.RenameSpace(buildTestSpace("a", "r1"), "b")
+	if !errors.Is(err, errdefs.ErrRenameTargetExists) {
+		t.Fatalf("err = %v, want ErrRenameTargetExists", err)
+	}
+}
+
+func TestRenameSpace_RecreatesUnderNewName(t *testing.T) {
+	old := buildTestSpace("old", "r1")
+	old.Metadata.Labels["team"] = "blue"
+	old.Metadata.Annotations = map[string]string{"owner": "platform"}
+	old.Spec.DefaultResources = &intmodel.ContainerResources{MemoryLimitBytes: int64Ptr(256 << 20)}
+	old.Spec.Quota = &intmodel.ResourceQuota{MaxCells: 4}
+
+	mockRunner := emptyScopeRunner()
+	mockRunner.GetSpaceFn = func(space intmodel.Space) (intmodel.Space, error) {
+		if space.Metadata.Name == "old" {
+			return old, nil
+		}
+		return intmodel.Space{}, errdefs.ErrSpaceNotFound
+	}
+	mockRunner.ListStacksFn = func(_, _ string) ([]intmodel.Stack, error) { return nil, nil }
+	var deleted string
+	mockRunner.DeleteSpaceFn = func(space intmodel.Space) error {
+		deleted = space.Metadata.Name
+		return nil
+	}
+	var created intmodel.Space
+	mockRunner.CreateSpaceFn = func(space intmodel.Space) (intmodel.Space, error) {
+		created = space
+		return space, nil
+	}
+	ctrl := setupTestController(t, mockRunner)
+
+	res, err := ctrl.RenameSpace(old, "new")
+	if err != nil {
+		t.Fatalf("RenameSpace: %v", err)
+	}
+	if deleted != "old" {
+		t.Errorf("deleted space = %q, want %q", deleted, "old")
+	}
+	if created.Metadata.Name != "new" || created.Spec.RealmName != "r1" {
+		t.Errorf("created space = %q in %q, want new in r1", created.Metadata.Name, created.Spec.RealmName)
+	}
+	if got := created.Metadata.Labels[consts.KukeonSpaceLabelKey]; got != "new" {
+		t.Errorf("space label = %q, want %q", got, "new")
+	}
+	if got := created.Metadata.Labels["team"]; got != "blue" {
+		t.Errorf("user label dropped: team = %q", got)
+	}
+	if got := created.Metadata.Annotations["owner"]; got != "platform" {
+		t.Errorf("annotation dropped: owner = %q", got)
+	}
+	if dr := created.Spec.DefaultResources; dr == nil || dr.MemoryLimitBytes == nil || *dr.MemoryLimitBytes != 256<<20 {
+		t.Errorf("defaultResources dropped: %+v", dr)
+	}
+	if q := created.Spec.Quota; q == nil || q.MaxCells != 4 {
+		t.Errorf("quota dropped: %+v", q)
+	}
+	if res.OldName != "old" || res.Space.Metadata.Name != "new" {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
+
+func TestRenameRealm_RecreatesUnderNewName(t *testing.T) {
+	old := buildTestRealm("old", "")
+	old.Metadata.Labels["team"] = "blue"
+	old.Metadata.Annotations = map[string]string{"owner": "platform"}
+	old.Spec.DefaultResources = &intmodel.ContainerResources{MemoryLimitBytes: int64Ptr(256 << 20)}
+	old.Spec.Quota = &intmodel.ResourceQuota{MaxCells: 4}
+
+	mockRunner := emptyScopeRunner()
+	mockRunner.GetRealmFn = func(realm intmodel.Realm) (intmodel.Realm, error) {
+		if realm.Metadata.Name == "old" {
+			return old, nil
+		}
+		return intmodel.Realm{}, errdefs.ErrRealmNotFound
+	}
+	mockRunner.ListSpacesFn = func(string) ([]intmodel.Space, error) { return nil, nil }
+	var created intmodel.Realm
+	mockRunner.CreateRealmFn = func(realm intmodel.Realm) (intmodel.Realm, error) {
+		created = realm
+		return realm, nil
+	}
+	var deleted string
+	mockRunner.DeleteRealmFn = func(realm intmodel.Realm) error {
+		deleted = realm.Metadata.Name
+		return nil
+	}
+	ctrl := setupTestController(t, mockRunner)
+
+	res, err := ctrl.RenameRealm(old, "new")
+	if err != nil {
+		t.Fatalf("RenameRealm: %v", err)
+	}
+	if deleted != "old" {
+		t.Errorf("deleted realm = %q, want %q", deleted, "old")
+	}
+	if created.Metadata.Name != "new" || created.Spec.Namespace != consts.RealmNamespace("new") {
+		t.Errorf("created realm = %q in namespace %q", created.Metadata.Name, created.Spec.Namespace)
+	}
+	if got := created.Metadata.Labels["team"]; got != "blue" {
+		t.Errorf("user label dropped: team = %q", got)
+	}
+	if got := created.Metadata.Annotations["owner"]; got != "platform" {
+		t.Errorf("annotation dropped: owner = %q", got)
+	}
+	if dr := created.Spec.DefaultResources; dr == nil || dr.MemoryLimitBytes == nil || *dr.MemoryLimitBytes != 256<<20 {
+		t.Errorf("defaultResources dropped: %+v", dr)
+	}
+	if q := created.Spec.Quota; q == nil || q.MaxCells != 4 {
+		t.Errorf("quota dropped: %+v", q)
+	}
+	if res.OldName != "old" || res.Realm.Metadata.Name != "new" {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/eminwux/kukeon/internal/ctr"
 	"github.com/eminwux/kukeon/internal/errdefs"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/preflight"
 	"github.com/eminwux/kukeon/internal/util/fs"
 	extmodel "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
 )
@@ -350,8 +351,7 @@ func kukettyMetadataLabels(spec intmodel.ContainerSpec) map[string]string {
 // never see a partial binary at the destination. The rename is on the same
 // filesystem as the destination so it is a single-syscall atomic move.
 func stageKukettyBinary(runPath string) (string, error) {
-	dstDir := filepath.Join(runPath, kukettyBinaryStagedSubdir)
-	dst := filepath.Join(dstDir, "kuketty")
+	dst := preflight.KukettyStagedPath(runPath)
 
 	src, err := resolveKukettyBinary()
 	if err != nil {
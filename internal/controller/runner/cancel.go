@@ -0,0 +1,33 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner
+
+import "fmt"
+
+// checkCanceled is the step boundary of a multi-primitive runner operation.
+// The containerd client shares r.ctx, so a primitive in flight when the
+// parent context is canceled fails with it. Loops over several primitives
+// (for example creating a cell's containers one by one) call checkCanceled
+// between iterations and, once the parent context is done, stop before the
+// next primitive and return what they finished alongside an error wrapping
+// context.Canceled (or context.DeadlineExceeded).
+func (r *Exec) checkCanceled(next string) error {
+	if err := r.ctx.Err(); err != nil {
+		return fmt.Errorf("canceled before %s: %w", next, err)
+	}
+	return nil
+}
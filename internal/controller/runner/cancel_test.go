@@ -0,0 +1,156 @@
+//go:build !integration
+
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // drives unexported createCellContainers against a fake ctr client
+package runner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/metadata"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/util/fs"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+// cancelingCreateClient records every container create and cancels the
+// parent context from inside the create named by cancelOn, modelling a
+// SIGINT that lands while a containerd primitive is in flight.
+type cancelingCreateClient struct {
+	*recreateCellFakeClient
+	cancelOn string
+	cancel   context.CancelFunc
+	created  []string
+}
+
+func (c *cancelingCreateClient) CreateContainer(
+	_ string, spec ctr.ContainerSpec, _ []ctr.RegistryCredentials,
+) (containerd.Container, error) {
+	c.record(spec.ID)
+	//nolint:nilnil // the provisioning path only checks the error
+	return nil, nil
+}
+
+func (c *cancelingCreateClient) CreateContainerFromSpec(
+	_ string, spec intmodel.ContainerSpec, _ []ctr.RegistryCredentials, _ ...ctr.BuildOption,
+) (containerd.Container, error) {
+	c.record(spec.ContainerdID)
+	//nolint:nilnil // the provisioning path only checks the error
+	return nil, nil
+}
+
+func (c *cancelingCreateClient) record(id string) {
+	c.created = append(c.created, id)
+	if id == c.cancelOn {
+		c.cancel()
+	}
+}
+
+// TestCreateCellContainers_CanceledMidLoop cancels the parent context while
+// the second container is being created: that create completes, the third is
+// never attempted, and the error wraps context.Canceled.
+func TestCreateCellContainers_CanceledMidLoop(t *testing.T) {
+	const (
+		realm = "default"
+		space = "default"
+		stack = "default"
+		cell  = "web"
+	)
+	rootID := space + "_" + stack + "_" + cell + "_root"
+	appID := space + "_" + stack + "_" + cell + "_app"
+	sidecarID := space + "_" + stack + "_" + cell + "_sidecar"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	fake := &cancelingCreateClient{
+		recreateCellFakeClient: &recreateCellFakeClient{deleteCellFakeClient: &deleteCellFakeClient{}},
+		cancelOn:               appID,
+		cancel:                 cancel,
+	}
+	r := newRecreateCellTestExec(t, fake.recreateCellFakeClient)
+	r.ctrClient = fake
+
+	realmDoc := v1beta1.RealmDoc{
+		APIVersion: v1beta1.APIVersionV1Beta1,
+		Kind:       v1beta1.KindRealm,
+		Metadata:   v1beta1.RealmMetadata{Name: realm},
+		Spec:       v1beta1.RealmSpec{Namespace: realm + ".kukeon.io"},
+	}
+	path := fs.RealmMetadataPath(r.opts.RunPath, realm)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir realm metadata dir: %v", err)
+	}
+	if err := metadata.WriteMetadata(r.ctx, r.logger, realmDoc, path); err != nil {
+		t.Fatalf("write realm metadata: %v", err)
+	}
+	seedRecreateCellSpace(t, r, realm, space)
+	r.ctx = ctx
+
+	c := intmodel.Cell{
+		Metadata: intmodel.CellMetadata{Name: cell},
+		Spec: intmodel.CellSpec{
+			ID:              cell,
+			RealmName:       realm,
+			SpaceName:       space,
+			StackName:       stack,
+			RootContainerID: "root",
+			Containers: []intmodel.ContainerSpec{
+				{ID: "root", Root: true, HostNetwork: true, Image: "alpine:3.18", ContainerdID: rootID},
+				{ID: "app", Image: "alpine:3.18", ContainerdID: appID},
+				{ID: "sidecar", Image: "alpine:3.18", ContainerdID: sidecarID},
+			},
+		},
+	}
+
+	_, err := r.createCellContainers(r.ctx, &c)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("createCellContainers error = %v, want context.Canceled", err)
+	}
+
+	want := []string{rootID, appID}
+	if len(fake.created) != len(want) {
+		t.Fatalf("created = %v, want %v", fake.created, want)
+	}
+	for i, id := range want {
+		if fake.created[i] != id {
+			t.Fatalf("created = %v, want %v", fake.created, want)
+		}
+	}
+}
+
+// TestCheckCanceled covers the step-boundary helper on a live and a
+// canceled context.
+func TestCheckCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &Exec{ctx: ctx}
+
+	if err := r.checkCanceled("next step"); err != nil {
+		t.Fatalf("checkCanceled on live context = %v, want nil", err)
+	}
+	cancel()
+	if err := r.checkCanceled("next step"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("checkCanceled on canceled context = %v, want context.Canceled", err)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/eminwux/kukeon/internal/ctr"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 	utilfs "github.com/eminwux/kukeon/internal/util/fs"
 )
@@ -39,34 +40,36 @@ ff02::1	ip6-allnodes
 ff02::2	ip6-allrouters
 `
 
-// renderCellEtcHostname writes the cell name plus a trailing newline to the
-// given path, atomically replacing whatever was there. The bind-mount in the
-// container's OCI spec resolves to the destination path's inode at mount
+// renderCellEtcHostname writes the cell hostname plus a trailing newline to
+// the given path, atomically replacing whatever was there. The bind-mount in
+// the container's OCI spec resolves to the destination path's inode at mount
 // time, so an in-place rewrite (truncate + write) is what propagates an
-// updated cell-name to running containers.
-func renderCellEtcHostname(path, cellName string) error {
-	cellName = strings.TrimSpace(cellName)
-	if cellName == "" {
-		return fmt.Errorf("cell name is empty")
+// updated hostname to running containers.
+func renderCellEtcHostname(path, hostname string) error {
+	hostname = strings.TrimSpace(hostname)
+	if hostname == "" {
+		return fmt.Errorf("cell hostname is empty")
 	}
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return fmt.Errorf("create cell metadata dir: %w", err)
 	}
-	if err := os.WriteFile(path, []byte(cellName+"\n"), 0o644); err != nil {
+	if err := os.WriteFile(path, []byte(hostname+"\n"), 0o644); err != nil {
 		return fmt.Errorf("write %s: %w", path, err)
 	}
 	return nil
 }
 
-// renderCellEtcHosts writes the localhost block plus an optional
-// "<cellIP>\t<cellName>" line. cellIP may be nil — used at cell-create time
-// before CNI ADD has assigned an address; the post-CNI render replaces the
-// file with the IP populated. Truncate-on-write so the inode the container's
-// bind-mount resolves to keeps reflecting the latest content.
-func renderCellEtcHosts(path, cellName string, cellIP net.IP) error {
-	cellName = strings.TrimSpace(cellName)
-	if cellName == "" {
-		return fmt.Errorf("cell name is empty")
+// renderCellEtcHosts writes the localhost block, an optional
+// "<cellIP>\t<hostname>" line, then one "<ip>\t<host>" line per extra host.
+// cellIP may be nil — used at cell-create time before CNI ADD has assigned
+// an address; the post-CNI render replaces the file with the IP populated.
+// Extra hosts are written on every render since they do not depend on CNI.
+// Truncate-on-write so the inode the container's bind-mount resolves to
+// keeps reflecting the latest content.
+func renderCellEtcHosts(path, hostname string, cellIP net.IP, extraHosts []ctr.ExtraHost) error {
+	hostname = strings.TrimSpace(hostname)
+	if hostname == "" {
+		return fmt.Errorf("cell hostname is empty")
 	}
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return fmt.Errorf("create cell metadata dir: %w", err)
@@ -76,7 +79,13 @@ func renderCellEtcHosts(path, cellName string, cellIP net.IP) error {
 	if cellIP != nil {
 		b.WriteString(cellIP.String())
 		b.WriteByte('\t')
-		b.WriteString(cellName)
+		b.WriteString(hostname)
+		b.WriteByte('\n')
+	}
+	for _, extra := range extraHosts {
+		b.WriteString(extra.IP.String())
+		b.WriteByte('\t')
+		b.WriteString(extra.Host)
 		b.WriteByte('\n')
 	}
 	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
@@ -121,7 +130,8 @@ func (r *Exec) stampCellEtcFilePathsOnContainers(cell *intmodel.Cell) {
 		return
 	}
 	for i := range cell.Spec.Containers {
-		stampEtcFilePathsOnContainerSpec(&cell.Spec.Containers[i], hostnamePath, hostsPath, suppressHosts)
+		stampEtcFilePathsOnContainerSpec(&cell.Spec.Containers[i], cell, hostnamePath, hostsPath, suppressHosts)
+		r.stampTerminationMessageHostPath(&cell.Spec.Containers[i], cell)
 	}
 }
 
@@ -130,11 +140,17 @@ func (r *Exec) stampCellEtcFilePathsOnContainers(cell *intmodel.Cell) {
 // container spec value (e.g. a root spec built fresh by
 // ensureCellRootContainerSpec) and need it to carry the same bind-mount
 // paths the cell-wide stamp would apply.
-func stampEtcFilePathsOnContainerSpec(spec *intmodel.ContainerSpec, hostnamePath, hostsPath string, suppressHosts bool) {
+func stampEtcFilePathsOnContainerSpec(
+	spec *intmodel.ContainerSpec,
+	cell *intmodel.Cell,
+	hostnamePath, hostsPath string,
+	suppressHosts bool,
+) {
 	if spec == nil || hostnamePath == "" {
 		return
 	}
 	spec.EtcHostnamePath = hostnamePath
+	spec.CellHostname = cellHostname(cell)
 	if suppressHosts {
 		spec.EtcHostsPath = ""
 	} else {
@@ -150,10 +166,11 @@ func stampEtcFilePathsOnContainerSpec(spec *intmodel.ContainerSpec, hostnamePath
 // StartContainer recreate path of issue #354, the StartCell root-recreate
 // path, and the ensureCellContainers root-creation path). Without this,
 // the recreated/created container drops its /etc/hosts + /etc/hostname
-// bind-mounts.
+// bind-mounts. Also stamps the termination-log bind-mount source.
 func (r *Exec) stampContainerRecreateRuntimeFields(spec *intmodel.ContainerSpec, cell *intmodel.Cell) {
 	hostnamePath, hostsPath, suppressHosts := r.cellEtcFilePaths(cell)
-	stampEtcFilePathsOnContainerSpec(spec, hostnamePath, hostsPath, suppressHosts)
+	stampEtcFilePathsOnContainerSpec(spec, cell, hostnamePath, hostsPath, suppressHosts)
+	r.stampTerminationMessageHostPath(spec, cell)
 }
 
 // renderCellEtcFilesPreCNI writes the per-cell /etc/hostname and an initial
@@ -174,14 +191,14 @@ func (r *Exec) renderCellEtcFilesPreCNI(cell *intmodel.Cell) error {
 	}
 	hostnamePath := utilfs.CellEtcHostnamePath(r.opts.RunPath, realmName, spaceName, stackName, cellName)
 	hostsPath := utilfs.CellEtcHostsPath(r.opts.RunPath, realmName, spaceName, stackName, cellName)
-	if err := renderCellEtcHostname(hostnamePath, cellName); err != nil {
+	if err := renderCellEtcHostname(hostnamePath, cellHostname(cell)); err != nil {
 		return err
 	}
 	if cellRootHostNetwork(cell) {
 		// Host-network cells use the host's /etc/hosts; nothing to render.
 		return nil
 	}
-	return renderCellEtcHosts(hostsPath, cellName, nil)
+	return renderCellEtcHosts(hostsPath, cellHostname(cell), nil, cellExtraHosts(cell))
 }
 
 // ensureCellEtcFilesExistPreCNI guarantees the per-cell /etc/hostname and
@@ -198,12 +215,12 @@ func (r *Exec) ensureCellEtcFilesExistPreCNI(cell *intmodel.Cell) error {
 	if hostnamePath == "" {
 		return nil
 	}
-	cellName := strings.TrimSpace(cell.Metadata.Name)
+	hostname := cellHostname(cell)
 	if _, err := os.Stat(hostnamePath); err != nil {
 		if !os.IsNotExist(err) {
 			return fmt.Errorf("stat %s: %w", hostnamePath, err)
 		}
-		if rerr := renderCellEtcHostname(hostnamePath, cellName); rerr != nil {
+		if rerr := renderCellEtcHostname(hostnamePath, hostname); rerr != nil {
 			return rerr
 		}
 	}
@@ -214,7 +231,7 @@ func (r *Exec) ensureCellEtcFilesExistPreCNI(cell *intmodel.Cell) error {
 		if !os.IsNotExist(err) {
 			return fmt.Errorf("stat %s: %w", hostsPath, err)
 		}
-		return renderCellEtcHosts(hostsPath, cellName, nil)
+		return renderCellEtcHosts(hostsPath, hostname, nil, cellExtraHosts(cell))
 	}
 	return nil
 }
@@ -238,7 +255,7 @@ func (r *Exec) renderCellEtcHostsWithIP(cell *intmodel.Cell, cellIP net.IP) erro
 		return nil
 	}
 	hostsPath := utilfs.CellEtcHostsPath(r.opts.RunPath, realmName, spaceName, stackName, cellName)
-	return renderCellEtcHosts(hostsPath, cellName, cellIP)
+	return renderCellEtcHosts(hostsPath, cellHostname(cell), cellIP, cellExtraHosts(cell))
 }
 
 // cellRootHostNetwork reports whether the cell's root container runs with
@@ -260,3 +277,58 @@ func cellRootHostNetwork(cell *intmodel.Cell) bool {
 	}
 	return false
 }
+
+// cellHostname returns the hostname shared by every container in the cell:
+// the root container's Hostname, else the first Hostname declared on any
+// other container, else the cell name. Validation rejects cells whose
+// containers declare conflicting hostnames, so the first match is the only
+// one.
+func cellHostname(cell *intmodel.Cell) string {
+	if cell == nil {
+		return ""
+	}
+	rootID := strings.TrimSpace(cell.Spec.RootContainerID)
+	hostname := ""
+	for _, c := range cell.Spec.Containers {
+		h := strings.TrimSpace(c.Hostname)
+		if h == "" {
+			continue
+		}
+		if c.Root || (rootID != "" && c.ID == rootID) {
+			return h
+		}
+		if hostname == "" {
+			hostname = h
+		}
+	}
+	if hostname != "" {
+		return hostname
+	}
+	return strings.TrimSpace(cell.Metadata.Name)
+}
+
+// cellExtraHosts merges the ExtraHosts entries declared across the cell's
+// containers in declaration order, dropping duplicates and entries that do
+// not parse (validation rejects those before they reach the runner).
+func cellExtraHosts(cell *intmodel.Cell) []ctr.ExtraHost {
+	if cell == nil {
+		return nil
+	}
+	var out []ctr.ExtraHost
+	seen := make(map[string]struct{})
+	for _, c := range cell.Spec.Containers {
+		for _, entry := range c.ExtraHosts {
+			extra, err := ctr.ParseExtraHost(entry)
+			if err != nil {
+				continue
+			}
+			key := extra.Host + "\t" + extra.IP.String()
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			out = append(out, extra)
+		}
+	}
+	return out
+}
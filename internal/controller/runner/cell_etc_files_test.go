@@ -20,6 +20,7 @@
 package runner
 
 import (
+	"net"
 	"os"
 	"strings"
 	"testing"
@@ -261,3 +262,100 @@ func TestEnsureCellEtcFilesExistPreCNI_HostNetworkSkipsHosts(t *testing.T) {
 		t.Errorf("/etc/hosts should be suppressed on host-network cells; stat err = %v", err)
 	}
 }
+
+// TestRenderCellEtcFiles_HostnameAndExtraHosts verifies a declared
+// spec.hostname replaces the cell name in /etc/hostname and on the cell-IP
+// line, and that spec.extraHosts from every container land in the
+// generated /etc/hosts, de-duplicated, on both the pre-CNI and the post-CNI
+// render.
+func TestRenderCellEtcFiles_HostnameAndExtraHosts(t *testing.T) {
+	runPath := t.TempDir()
+	r := newProvisionTestExec(t, runPath, false)
+	cell := &intmodel.Cell{
+		Metadata: intmodel.CellMetadata{Name: "web"},
+		Spec: intmodel.CellSpec{
+			RealmName:       "default",
+			SpaceName:       "team-a",
+			StackName:       "web",
+			RootContainerID: "root",
+			Containers: []intmodel.ContainerSpec{
+				{ID: "root", Root: true, Hostname: "web.internal", ExtraHosts: []string{"db:10.0.0.5"}},
+				{ID: "app", ExtraHosts: []string{"db:10.0.0.5", "cache:fd00::7"}},
+			},
+		},
+	}
+	hostnamePath, hostsPath, _ := r.cellEtcFilePaths(cell)
+	extraLines := "10.0.0.5\tdb\nfd00::7\tcache\n"
+
+	if err := r.renderCellEtcFilesPreCNI(cell); err != nil {
+		t.Fatalf("renderCellEtcFilesPreCNI: %v", err)
+	}
+	hn, err := os.ReadFile(hostnamePath)
+	if err != nil {
+		t.Fatalf("read /etc/hostname: %v", err)
+	}
+	if string(hn) != "web.internal\n" {
+		t.Errorf("/etc/hostname = %q, want %q", hn, "web.internal\n")
+	}
+	hosts, err := os.ReadFile(hostsPath)
+	if err != nil {
+		t.Fatalf("read /etc/hosts: %v", err)
+	}
+	if want := etcHostsLocalhostBlock + extraLines; string(hosts) != want {
+		t.Errorf("pre-CNI /etc/hosts:\n--- got ---\n%s\n--- want ---\n%s", hosts, want)
+	}
+
+	if err = r.renderCellEtcHostsWithIP(cell, net.ParseIP("10.22.0.9")); err != nil {
+		t.Fatalf("renderCellEtcHostsWithIP: %v", err)
+	}
+	hosts, err = os.ReadFile(hostsPath)
+	if err != nil {
+		t.Fatalf("read /etc/hosts: %v", err)
+	}
+	if want := etcHostsLocalhostBlock + "10.22.0.9\tweb.internal\n" + extraLines; string(hosts) != want {
+		t.Errorf("post-CNI /etc/hosts:\n--- got ---\n%s\n--- want ---\n%s", hosts, want)
+	}
+
+	app := &cell.Spec.Containers[1]
+	r.stampContainerRecreateRuntimeFields(app, cell)
+	if app.CellHostname != "web.internal" {
+		t.Errorf("CellHostname = %q, want %q", app.CellHostname, "web.internal")
+	}
+}
+
+// TestCellHostname covers the precedence: the root container's hostname,
+// then one declared on another container, then the cell name.
+func TestCellHostname(t *testing.T) {
+	tests := []struct {
+		name       string
+		containers []intmodel.ContainerSpec
+		want       string
+	}{
+		{
+			name:       "defaults to cell name",
+			containers: []intmodel.ContainerSpec{{ID: "root", Root: true}, {ID: "app"}},
+			want:       "web",
+		},
+		{
+			name:       "root hostname",
+			containers: []intmodel.ContainerSpec{{ID: "root", Root: true, Hostname: "edge"}, {ID: "app"}},
+			want:       "edge",
+		},
+		{
+			name:       "workload hostname when root has none",
+			containers: []intmodel.ContainerSpec{{ID: "root", Root: true}, {ID: "app", Hostname: "api"}},
+			want:       "api",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cell := &intmodel.Cell{
+				Metadata: intmodel.CellMetadata{Name: "web"},
+				Spec:     intmodel.CellSpec{Containers: tt.containers},
+			}
+			if got := cellHostname(cell); got != tt.want {
+				t.Errorf("cellHostname = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+//go:build !integration
+
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // tests exercise private create-cgroup helpers on *Exec
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/consts"
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/metadata"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/util/fs"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+func writeTestMetadata(t *testing.T, r *Exec, doc any, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir metadata dir: %v", err)
+	}
+	if err := metadata.WriteMetadata(r.ctx, r.logger, doc, path); err != nil {
+		t.Fatalf("write metadata: %v", err)
+	}
+}
+
+// TestCellCgroupSpecInheritsRealmDefault pins that a cell whose space sets
+// no DefaultResources gets the realm's default limits on its own cgroup,
+// and that a space limit wins field by field over the realm's.
+func TestCellCgroupSpecInheritsRealmDefault(t *testing.T) {
+	memory := int64(512 * 1024 * 1024)
+	pids := int64(256)
+	spacePids := int64(64)
+
+	r := newSubtreeTestExec(t, &subtreeRecorderClient{
+		mountpoint:        "/sys/fs/cgroup",
+		currentCgroupPath: consts.KukeonCgroupRoot,
+	})
+	writeTestMetadata(t, r, v1beta1.RealmDoc{
+		APIVersion: v1beta1.APIVersionV1Beta1,
+		Kind:       v1beta1.KindRealm,
+		Metadata:   v1beta1.RealmMetadata{Name: "main"},
+		Spec: v1beta1.RealmSpec{
+			Namespace:        "main.kukeon.io",
+			DefaultResources: &v1beta1.ContainerResources{MemoryLimitBytes: &memory, PidsLimit: &pids},
+		},
+	}, fs.RealmMetadataPath(r.opts.RunPath, "main"))
+	writeTestMetadata(t, r, v1beta1.SpaceDoc{
+		APIVersion: v1beta1.APIVersionV1Beta1,
+		Kind:       v1beta1.KindSpace,
+		Metadata:   v1beta1.SpaceMetadata{Name: "plain"},
+		Spec:       v1beta1.SpaceSpec{RealmID: "main"},
+	}, fs.SpaceMetadataPath(r.opts.RunPath, "main", "plain"))
+	writeTestMetadata(t, r, v1beta1.SpaceDoc{
+		APIVersion: v1beta1.APIVersionV1Beta1,
+		Kind:       v1beta1.KindSpace,
+		Metadata:   v1beta1.SpaceMetadata{Name: "tight"},
+		Spec: v1beta1.SpaceSpec{
+			RealmID:          "main",
+			DefaultResources: &v1beta1.ContainerResources{PidsLimit: &spacePids},
+		},
+	}, fs.SpaceMetadataPath(r.opts.RunPath, "main", "tight"))
+
+	tests := []struct {
+		space    string
+		wantPids int64
+	}{
+		{space: "plain", wantPids: pids},
+		{space: "tight", wantPids: spacePids},
+	}
+	for _, tt := range tests {
+		t.Run(tt.space, func(t *testing.T) {
+			cell := intmodel.Cell{
+				Metadata: intmodel.CellMetadata{Name: "web"},
+				Spec:     intmodel.CellSpec{RealmName: "main", SpaceName: tt.space, StackName: "default"},
+			}
+			limits, err := r.cellCgroupLimits(cell)
+			if err != nil {
+				t.Fatalf("cellCgroupLimits() error = %v", err)
+			}
+			spec := ctr.CellCgroupSpec(cell, limits)
+
+			if want := "/main/" + tt.space + "/default/web"; spec.Group != want {
+				t.Errorf("Group = %q, want %q", spec.Group, want)
+			}
+			if spec.Resources.Memory == nil || spec.Resources.Memory.Max == nil ||
+				*spec.Resources.Memory.Max != memory {
+				t.Errorf("Memory = %+v, want max %d inherited from the realm", spec.Resources.Memory, memory)
+			}
+			if spec.Resources.Pids == nil || spec.Resources.Pids.Max != tt.wantPids {
+				t.Errorf("Pids = %+v, want max %d", spec.Resources.Pids, tt.wantPids)
+			}
+			if spec.Resources.CPU != nil {
+				t.Errorf("CPU = %+v, want nil (no cpuShares default)", spec.Resources.CPU)
+			}
+		})
+	}
+}
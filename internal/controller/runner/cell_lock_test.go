@@ -208,7 +208,7 @@ func TestCellLifecycleLock_StartCellWaitsForReconcile(t *testing.T) {
 	// G2: StartCell on the same cell must block on the per-cell lock G1 holds.
 	startDone := make(chan struct{})
 	go func() {
-		_, _ = r.StartCell(cell)
+		_, _ = r.StartCell(r.ctx, cell)
 		close(startDone)
 	}()
 
@@ -0,0 +1,83 @@
+//go:build !integration
+
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // tests exercise private create-cgroup helpers on *Exec
+package runner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/consts"
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// TestCgroupV1HostFailsFast forces a legacy or hybrid cgroup mode and
+// asserts that the kukeon root and realm cgroup paths refuse with
+// ErrCgroupV1Unsupported before creating anything, instead of building
+// unified-hierarchy paths on a v1 mount.
+func TestCgroupV1HostFailsFast(t *testing.T) {
+	for _, mode := range []ctr.CgroupMode{ctr.CgroupModeLegacy, ctr.CgroupModeHybrid} {
+		t.Run(mode.String(), func(t *testing.T) {
+			fake := &subtreeRecorderClient{
+				mountpoint:        "/sys/fs/cgroup",
+				currentCgroupPath: consts.KukeonCgroupRoot,
+				cgroupMode:        mode,
+			}
+			r := newSubtreeTestExec(t, fake)
+
+			if _, _, err := r.EnsureKukeonRootCgroup(); !errors.Is(err, errdefs.ErrCgroupV1Unsupported) {
+				t.Errorf("EnsureKukeonRootCgroup() err = %v, want ErrCgroupV1Unsupported", err)
+			}
+			_, _, err := r.createRealmCgroup(intmodel.Realm{
+				Metadata: intmodel.RealmMetadata{Name: "main"},
+				Spec:     intmodel.RealmSpec{Namespace: "main.kukeon.io"},
+			}, nil)
+			if !errors.Is(err, errdefs.ErrCgroupV1Unsupported) {
+				t.Errorf("createRealmCgroup() err = %v, want ErrCgroupV1Unsupported", err)
+			}
+			if fake.newCgroupCalls != 0 || len(fake.ensureCalls) != 0 {
+				t.Errorf("cgroup writes on a %s host: NewCgroup=%d EnsureSubtreeControllers=%d, want none",
+					mode, fake.newCgroupCalls, len(fake.ensureCalls))
+			}
+		})
+	}
+}
+
+// TestProvisionNewRealmRejectsCgroupV1 pins that realm provision refuses a
+// v1 host before persisting the realm, so no Failed realm is left behind.
+func TestProvisionNewRealmRejectsCgroupV1(t *testing.T) {
+	fake := &subtreeRecorderClient{
+		mountpoint:        "/sys/fs/cgroup",
+		currentCgroupPath: consts.KukeonCgroupRoot,
+		cgroupMode:        ctr.CgroupModeLegacy,
+	}
+	r := newSubtreeTestExec(t, fake)
+
+	_, err := r.provisionNewRealm(intmodel.Realm{Metadata: intmodel.RealmMetadata{Name: "main"}})
+	if !errors.Is(err, errdefs.ErrCgroupV1Unsupported) {
+		t.Fatalf("provisionNewRealm() err = %v, want ErrCgroupV1Unsupported", err)
+	}
+	if _, getErr := r.GetRealm(intmodel.Realm{Metadata: intmodel.RealmMetadata{Name: "main"}}); !errors.Is(
+		getErr, errdefs.ErrRealmNotFound,
+	) {
+		t.Errorf("GetRealm() err = %v, want ErrRealmNotFound (nothing persisted)", getErr)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner
+
+import (
+	"fmt"
+
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// CellCgroupUsage returns the live cgroup v2 usage counters of the cell's
+// cgroup. Metrics whose controller is not enabled for the cell are left nil
+// in the returned snapshot.
+func (r *Exec) CellCgroupUsage(cell intmodel.Cell) (ctr.CgroupUsage, error) {
+	if cell.Metadata.Name == "" {
+		return ctr.CgroupUsage{}, errdefs.ErrCellNotFound
+	}
+	if err := r.ensureClientConnected(); err != nil {
+		return ctr.CgroupUsage{}, fmt.Errorf("%w: %w", errdefs.ErrConnectContainerd, err)
+	}
+
+	spec, _, err := r.buildCgroupPath(ctr.DefaultCellSpec(cell))
+	if err != nil {
+		return ctr.CgroupUsage{}, fmt.Errorf("failed to build cgroup path: %w", err)
+	}
+
+	usage, err := r.ctrClient.CgroupUsage(spec.Group, spec.Mountpoint)
+	if err != nil {
+		return ctr.CgroupUsage{}, fmt.Errorf("failed to read cell cgroup usage: %w", err)
+	}
+	return usage, nil
+}
+
+// KukeonCgroupUsage returns the live usage counters of the kukeon root
+// cgroup. cgroup v2 charges every descendant to its ancestors, so the
+// snapshot covers every realm on the host. Returns
+// errdefs.ErrKukeonCgroupNotFound when the root cgroup has not been created
+// yet (nothing provisioned).
+func (r *Exec) KukeonCgroupUsage() (ctr.CgroupUsage, error) {
+	if err := r.ensureClientConnected(); err != nil {
+		return ctr.CgroupUsage{}, fmt.Errorf("%w: %w", errdefs.ErrConnectContainerd, err)
+	}
+
+	// "/" is relative to the kukeon root, so it resolves to the root itself.
+	spec, _, err := r.buildCgroupPath(ctr.CgroupSpec{Group: "/"})
+	if err != nil {
+		return ctr.CgroupUsage{}, fmt.Errorf("failed to build cgroup path: %w", err)
+	}
+
+	usage, err := r.ctrClient.CgroupUsage(spec.Group, spec.Mountpoint)
+	if err != nil {
+		if err.Error() == "cgroup path does not exist" {
+			return ctr.CgroupUsage{}, fmt.Errorf("%w: %s", errdefs.ErrKukeonCgroupNotFound, spec.Group)
+		}
+		return ctr.CgroupUsage{}, fmt.Errorf("failed to read kukeon cgroup usage: %w", err)
+	}
+	return usage, nil
+}
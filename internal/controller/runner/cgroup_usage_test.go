@@ -0,0 +1,69 @@
+//go:build !integration
+
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // drives KukeonCgroupUsage against an in-package ctr.Client fake
+package runner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+func TestKukeonCgroupUsage(t *testing.T) {
+	t.Run("returns the root cgroup counters", func(t *testing.T) {
+		pids := uint64(9)
+		r := newDeleteCellTestExec(t, &deleteCellFakeClient{
+			cgroupUsageFn: func(_, _ string) (ctr.CgroupUsage, error) {
+				return ctr.CgroupUsage{PidsCurrent: &pids}, nil
+			},
+		})
+		usage, err := r.KukeonCgroupUsage()
+		if err != nil {
+			t.Fatalf("KukeonCgroupUsage: %v", err)
+		}
+		if usage.PidsCurrent == nil || *usage.PidsCurrent != 9 {
+			t.Errorf("PidsCurrent = %v, want 9", usage.PidsCurrent)
+		}
+	})
+
+	t.Run("missing root cgroup", func(t *testing.T) {
+		r := newDeleteCellTestExec(t, &deleteCellFakeClient{
+			cgroupUsageFn: func(_, _ string) (ctr.CgroupUsage, error) {
+				return ctr.CgroupUsage{}, errors.New("cgroup path does not exist")
+			},
+		})
+		if _, err := r.KukeonCgroupUsage(); !errors.Is(err, errdefs.ErrKukeonCgroupNotFound) {
+			t.Fatalf("err = %v, want ErrKukeonCgroupNotFound", err)
+		}
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		r := newDeleteCellTestExec(t, &deleteCellFakeClient{
+			cgroupUsageFn: func(_, _ string) (ctr.CgroupUsage, error) {
+				return ctr.CgroupUsage{}, errors.New("failed to read memory.current: permission denied")
+			},
+		})
+		_, err := r.KukeonCgroupUsage()
+		if err == nil || errors.Is(err, errdefs.ErrKukeonCgroupNotFound) {
+			t.Fatalf("err = %v, want a read failure", err)
+		}
+	})
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/cni"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// procRoot is where the runner reads per-process kernel views. A var so
+// tests can point the netns address lookup at a fixture tree.
+//
+//nolint:gochecknoglobals // test seam
+var procRoot = "/proc"
+
+// attachedStoredAddresses reports whether the root container's netns
+// already holds every address the cell's status recorded on its last
+// start — i.e. a previous CNI ADD into this very netns completed, so
+// running ADD again would only trip over the existing eth0. It returns the
+// stored addresses when they are all present. ok is false, and the caller
+// runs CNI ADD, when the status carries no address (first start, or a cell
+// written before addresses were recorded), when any stored address is
+// missing (a fresh netns after the root task was recreated), or when the
+// netns views cannot be read.
+func attachedStoredAddresses(pid uint32, status intmodel.CellNetworkStatus) (cni.ContainerAddresses, bool) {
+	var addrs cni.ContainerAddresses
+	if status.IPv4 == "" && status.IPv6 == "" {
+		return addrs, false
+	}
+	if status.IPv4 != "" {
+		ip := net.ParseIP(status.IPv4).To4()
+		if ip == nil {
+			return cni.ContainerAddresses{}, false
+		}
+		local, err := netnsLocalIPv4(pid)
+		if err != nil || !containsIP(local, ip) {
+			return cni.ContainerAddresses{}, false
+		}
+		addrs.IPv4 = ip
+	}
+	if status.IPv6 != "" {
+		ip := net.ParseIP(status.IPv6)
+		if ip == nil || ip.To4() != nil {
+			return cni.ContainerAddresses{}, false
+		}
+		local, err := netnsLocalIPv6(pid)
+		if err != nil || !containsIP(local, ip) {
+			return cni.ContainerAddresses{}, false
+		}
+		addrs.IPv6 = ip
+	}
+	return addrs, true
+}
+
+func containsIP(ips []net.IP, ip net.IP) bool {
+	for _, candidate := range ips {
+		if candidate.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// netnsLocalIPv4 lists the IPv4 addresses configured in pid's network
+// namespace. /proc/<pid>/net is the netns-scoped view, so this reads the
+// container's addresses without entering its netns. Each local address
+// appears in fib_trie as a "|-- <ip>" leaf followed by a "/32 host LOCAL"
+// entry.
+func netnsLocalIPv4(pid uint32) ([]net.IP, error) {
+	f, err := os.Open(filepath.Join(procRoot, fmt.Sprint(pid), "net", "fib_trie"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var (
+		out  []net.IP
+		leaf net.IP
+	)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if rest, ok := strings.CutPrefix(line, "|-- "); ok {
+			leaf = net.ParseIP(rest).To4()
+			continue
+		}
+		if leaf != nil && strings.HasPrefix(line, "/32 host LOCAL") {
+			if !containsIP(out, leaf) {
+				out = append(out, leaf)
+			}
+		}
+	}
+	return out, scanner.Err()
+}
+
+// netnsLocalIPv6 lists the IPv6 addresses configured in pid's network
+// namespace from /proc/<pid>/net/if_inet6, whose first column is the
+// address as 32 hex digits.
+func netnsLocalIPv6(pid uint32) ([]net.IP, error) {
+	f, err := os.Open(filepath.Join(procRoot, fmt.Sprint(pid), "net", "if_inet6"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var out []net.IP
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		raw, decErr := hex.DecodeString(fields[0])
+		if decErr != nil || len(raw) != net.IPv6len {
+			continue
+		}
+		out = append(out, net.IP(raw))
+	}
+	return out, scanner.Err()
+}
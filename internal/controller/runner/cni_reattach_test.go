@@ -0,0 +1,146 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // tests exercise private helpers
+package runner
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// fibTrieWithEth0 is a trimmed /proc/<pid>/net/fib_trie of a cell netns
+// holding 10.88.1.5 on eth0 plus loopback.
+const fibTrieWithEth0 = `Main:
+  +-- 0.0.0.0/0 3 0 5
+     |-- 0.0.0.0
+        /0 universe UNICAST
+     +-- 10.88.1.0/24 2 0 2
+        +-- 10.88.1.0/29 2 0 2
+           |-- 10.88.1.0
+              /24 link UNICAST
+           |-- 10.88.1.5
+              /32 host LOCAL
+        |-- 10.88.1.255
+           /32 link BROADCAST
+     +-- 127.0.0.0/8 2 0 2
+        |-- 127.0.0.1
+           /32 host LOCAL
+Local:
+  +-- 0.0.0.0/0 3 0 5
+     |-- 10.88.1.5
+        /32 host LOCAL
+`
+
+// fibTrieLoopbackOnly is a fresh netns before CNI ADD: loopback only.
+const fibTrieLoopbackOnly = `Local:
+  +-- 127.0.0.0/8 2 0 2
+     |-- 127.0.0.1
+        /32 host LOCAL
+`
+
+const ifInet6WithEth0 = `fd00008800010000000000000000000a 02 40 00 80     eth0
+fe80000000000000d0c1f2fffe0a0b0c 02 40 20 80     eth0
+00000000000000000000000000000001 01 80 10 80       lo
+`
+
+func writeNetnsFixture(t *testing.T, pid, fibTrie, ifInet6 string) {
+	t.Helper()
+	root := t.TempDir()
+	dir := filepath.Join(root, pid, "net")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "fib_trie"), []byte(fibTrie), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "if_inet6"), []byte(ifInet6), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	prev := procRoot
+	procRoot = root
+	t.Cleanup(func() { procRoot = prev })
+}
+
+func TestAttachedStoredAddresses_SkipsWhenNetnsHoldsRecordedIP(t *testing.T) {
+	writeNetnsFixture(t, "4242", fibTrieWithEth0, ifInet6WithEth0)
+
+	addrs, ok := attachedStoredAddresses(4242, intmodel.CellNetworkStatus{IPv4: "10.88.1.5"})
+	if !ok {
+		t.Fatal("attached = false, want true: the netns already holds the recorded IPv4")
+	}
+	if !addrs.IPv4.Equal(net.ParseIP("10.88.1.5")) {
+		t.Errorf("IPv4 = %v, want 10.88.1.5", addrs.IPv4)
+	}
+
+	addrs, ok = attachedStoredAddresses(4242, intmodel.CellNetworkStatus{IPv4: "10.88.1.5", IPv6: "fd00:88:1::a"})
+	if !ok || !addrs.IPv6.Equal(net.ParseIP("fd00:88:1::a")) {
+		t.Errorf("dual-stack: attached = %v, IPv6 = %v; want true, fd00:88:1::a", ok, addrs.IPv6)
+	}
+}
+
+func TestAttachedStoredAddresses_AttachesOtherwise(t *testing.T) {
+	cases := map[string]struct {
+		fibTrie string
+		status  intmodel.CellNetworkStatus
+	}{
+		"no recorded address": {
+			fibTrie: fibTrieWithEth0,
+		},
+		"fresh netns without the recorded IP": {
+			fibTrie: fibTrieLoopbackOnly,
+			status:  intmodel.CellNetworkStatus{IPv4: "10.88.1.5"},
+		},
+		"netns holds a different IP": {
+			fibTrie: fibTrieWithEth0,
+			status:  intmodel.CellNetworkStatus{IPv4: "10.88.1.9"},
+		},
+		"broadcast entry is not a local address": {
+			fibTrie: fibTrieWithEth0,
+			status:  intmodel.CellNetworkStatus{IPv4: "10.88.1.255"},
+		},
+		"recorded IPv6 missing": {
+			fibTrie: fibTrieWithEth0,
+			status:  intmodel.CellNetworkStatus{IPv4: "10.88.1.5", IPv6: "fd00:88:1::b"},
+		},
+		"unparseable recorded IP": {
+			fibTrie: fibTrieWithEth0,
+			status:  intmodel.CellNetworkStatus{IPv4: "not-an-ip"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			writeNetnsFixture(t, "4242", tc.fibTrie, ifInet6WithEth0)
+			if _, ok := attachedStoredAddresses(4242, tc.status); ok {
+				t.Errorf("attached = true, want false so CNI ADD runs")
+			}
+		})
+	}
+}
+
+func TestAttachedStoredAddresses_UnreadableNetnsAttaches(t *testing.T) {
+	prev := procRoot
+	procRoot = t.TempDir()
+	t.Cleanup(func() { procRoot = prev })
+
+	if _, ok := attachedStoredAddresses(4242, intmodel.CellNetworkStatus{IPv4: "10.88.1.5"}); ok {
+		t.Error("attached = true with no /proc view, want false so CNI ADD runs")
+	}
+}
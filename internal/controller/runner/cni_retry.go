@@ -0,0 +1,95 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/eminwux/kukeon/internal/cni"
+	internalerrdefs "github.com/eminwux/kukeon/internal/errdefs"
+)
+
+// cniAddMaxAttempts bounds the CNI ADD attempts StartCell makes for the
+// root container when the failure is transient (host-local IPAM lock
+// contention under concurrent cell starts). cniAddRetryBaseDelay is the
+// wait before the second attempt; it doubles per attempt, so the whole
+// budget stays well under a second of added start latency.
+const (
+	cniAddMaxAttempts    = 4
+	cniAddRetryBaseDelay = 50 * time.Millisecond
+)
+
+// cniNetworkAttacher is the slice of *cni.Manager the CNI ADD retry drives.
+type cniNetworkAttacher interface {
+	AddContainerToNetwork(
+		ctx context.Context,
+		containerID, netnsPath string,
+		bandwidth *cni.BandwidthLimits,
+	) (cni.ContainerAddresses, error)
+	DelContainerFromNetwork(ctx context.Context, containerID, netnsPath string) error
+}
+
+// addContainerToNetworkWithRetry runs CNI ADD for the root container and
+// retries it, with exponential backoff, while the failure classifies as
+// errdefs.ErrCNITransient. Every other failure — missing plugins, a bad
+// conflist, ErrCNIVethExists, a duplicate allocation — returns on the first
+// attempt so the caller's existing handling applies unchanged.
+//
+// A failed ADD can leave part of the plugin chain applied (the bridge
+// plugin creates the veth before IPAM runs), so each retry is preceded by a
+// best-effort CNI DEL, as the CNI spec asks of a runtime after a failed ADD.
+// The addresses returned are those of the attempt that succeeded.
+//
+// Decoupled from real time (sleepFn) so tests drive the retry without
+// waiting — same shape as verifyCellTasksLiveAfterStart.
+func addContainerToNetworkWithRetry(
+	ctx context.Context,
+	logger *slog.Logger,
+	mgr cniNetworkAttacher,
+	containerID, netnsPath string,
+	bandwidth *cni.BandwidthLimits,
+	maxAttempts int,
+	baseDelay time.Duration,
+	sleepFn func(time.Duration),
+	logFields []any,
+) (cni.ContainerAddresses, error) {
+	delay := baseDelay
+	for attempt := 1; ; attempt++ {
+		addrs, err := mgr.AddContainerToNetwork(ctx, containerID, netnsPath, bandwidth)
+		if err == nil {
+			if attempt > 1 {
+				logger.InfoContext(ctx, "CNI ADD succeeded after retry",
+					append(logFields, "attempt", attempt)...)
+			}
+			return addrs, nil
+		}
+		if !errors.Is(err, internalerrdefs.ErrCNITransient) || attempt >= maxAttempts || ctx.Err() != nil {
+			return addrs, err
+		}
+		logger.WarnContext(ctx, "transient CNI ADD failure, retrying",
+			append(logFields, "attempt", attempt, "maxAttempts", maxAttempts, "retryIn", delay, "err", err.Error())...)
+		if delErr := mgr.DelContainerFromNetwork(ctx, containerID, netnsPath); delErr != nil {
+			logger.DebugContext(ctx, "CNI DEL before retry failed",
+				append(logFields, "attempt", attempt, "err", delErr.Error())...)
+		}
+		sleepFn(delay)
+		delay *= 2
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // tests exercise private helpers
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/eminwux/kukeon/internal/cni"
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+// flakyCNIManager fails the first len(addErrs) ADDs with the queued errors,
+// then succeeds with addrs. It records every ADD and DEL in call order.
+type flakyCNIManager struct {
+	addErrs []error
+	addrs   cni.ContainerAddresses
+	calls   []string
+}
+
+func (m *flakyCNIManager) AddContainerToNetwork(
+	_ context.Context,
+	_, _ string,
+	_ *cni.BandwidthLimits,
+) (cni.ContainerAddresses, error) {
+	m.calls = append(m.calls, "add")
+	if len(m.addErrs) > 0 {
+		err := m.addErrs[0]
+		m.addErrs = m.addErrs[1:]
+		return cni.ContainerAddresses{}, err
+	}
+	return m.addrs, nil
+}
+
+func (m *flakyCNIManager) DelContainerFromNetwork(context.Context, string, string) error {
+	m.calls = append(m.calls, "del")
+	return nil
+}
+
+func transientCNIErr() error {
+	return fmt.Errorf("%w: failed to lock: resource temporarily unavailable", errdefs.ErrCNITransient)
+}
+
+func runCNIAddRetry(mgr *flakyCNIManager) (cni.ContainerAddresses, []time.Duration, error) {
+	var slept []time.Duration
+	addrs, err := addContainerToNetworkWithRetry(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		mgr,
+		"root",
+		"/proc/1/ns/net",
+		nil,
+		cniAddMaxAttempts,
+		10*time.Millisecond,
+		func(d time.Duration) { slept = append(slept, d) },
+		nil,
+	)
+	return addrs, slept, err
+}
+
+func TestAddContainerToNetworkWithRetry_TransientThenSuccessKeepsIP(t *testing.T) {
+	mgr := &flakyCNIManager{
+		addErrs: []error{transientCNIErr()},
+		addrs:   cni.ContainerAddresses{IPv4: net.IPv4(10, 88, 1, 5).To4()},
+	}
+
+	addrs, slept, err := runCNIAddRetry(mgr)
+	if err != nil {
+		t.Fatalf("addContainerToNetworkWithRetry: %v", err)
+	}
+	if !addrs.IPv4.Equal(net.IPv4(10, 88, 1, 5)) {
+		t.Errorf("IPv4 = %v, want the address of the successful attempt 10.88.1.5", addrs.IPv4)
+	}
+	if want := []string{"add", "del", "add"}; !slices.Equal(mgr.calls, want) {
+		t.Errorf("calls = %v, want %v (DEL cleans up the failed ADD before the retry)", mgr.calls, want)
+	}
+	if want := []time.Duration{10 * time.Millisecond}; !slices.Equal(slept, want) {
+		t.Errorf("backoff = %v, want %v", slept, want)
+	}
+}
+
+func TestAddContainerToNetworkWithRetry_NonTransientIsNotRetried(t *testing.T) {
+	for _, addErr := range []error{
+		fmt.Errorf("%w: bridge missing", errdefs.ErrCNIPluginNotFound),
+		fmt.Errorf("%w: eth0", errdefs.ErrCNIVethExists),
+		errors.New("duplicate allocation is not allowed"),
+	} {
+		mgr := &flakyCNIManager{addErrs: []error{addErr}}
+		_, slept, err := runCNIAddRetry(mgr)
+		if !errors.Is(err, addErr) {
+			t.Errorf("err = %v, want %v", err, addErr)
+		}
+		if len(mgr.calls) != 1 || len(slept) != 0 {
+			t.Errorf("%v: calls = %v, slept = %v; want a single ADD and no backoff", addErr, mgr.calls, slept)
+		}
+	}
+}
+
+func TestAddContainerToNetworkWithRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	errs := make([]error, cniAddMaxAttempts)
+	for i := range errs {
+		errs[i] = transientCNIErr()
+	}
+	mgr := &flakyCNIManager{addErrs: errs}
+
+	_, slept, err := runCNIAddRetry(mgr)
+	if !errors.Is(err, errdefs.ErrCNITransient) {
+		t.Fatalf("err = %v, want ErrCNITransient after exhausting the retries", err)
+	}
+	adds := 0
+	for _, c := range mgr.calls {
+		if c == "add" {
+			adds++
+		}
+	}
+	if adds != cniAddMaxAttempts {
+		t.Errorf("ADD attempts = %d, want %d", adds, cniAddMaxAttempts)
+	}
+	want := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond}
+	if !slices.Equal(slept, want) {
+		t.Errorf("backoff = %v, want doubling %v", slept, want)
+	}
+}
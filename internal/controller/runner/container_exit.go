@@ -0,0 +1,246 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/apischeme"
+	"github.com/eminwux/kukeon/internal/consts"
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/metadata"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/util/fs"
+	"github.com/eminwux/kukeon/internal/util/naming"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+// containerdIDParts is the number of "_"-separated segments in a kukeon
+// containerd ID ({space}_{stack}_{cell}_{container|root}).
+const containerdIDParts = 4
+
+// WatchContainerExits subscribes to containerd's task-exit stream and records
+// every exit of a kukeon-managed container into its cell's ContainerStatus as
+// it happens, instead of waiting for the next status read to find the task
+// stopped. That matters most for a task that is reaped before the next pass:
+// the event is then the only observation of its exit code. Blocks until ctx
+// is cancelled (nil) or the subscription fails (the error); the caller
+// re-subscribes. A single exit that cannot be recorded is logged and skipped.
+func (r *Exec) WatchContainerExits(ctx context.Context) error {
+	if err := r.ensureClientConnected(); err != nil {
+		return fmt.Errorf("%w: %w", errdefs.ErrConnectContainerd, err)
+	}
+	exits, errs := r.ctrClient.SubscribeTaskExits(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			return err
+		case exit, ok := <-exits:
+			if !ok {
+				select {
+				case err := <-errs:
+					return err
+				default:
+					return nil
+				}
+			}
+			if _, err := r.RecordContainerExit(exit); err != nil {
+				r.logger.WarnContext(r.ctx, "failed to record container exit",
+					"namespace", exit.Namespace,
+					"containerdID", exit.ContainerID,
+					"exitCode", exit.ExitCode,
+					"error", err)
+			}
+		}
+	}
+}
+
+// RecordContainerExit folds a task exit into the owning cell's persisted
+// ContainerStatus: FinishTime, ExitCode, ExitSignal, the terminal State, and
+// the TerminationMessage the container left in its termination log.
+// It reports false without error for an exit it does not own (a namespace no
+// realm claims, an ID outside kukeon's naming scheme, a cell or container
+// that no longer exists) and for an exit older than the container's current
+// run. The read-modify-write runs under the cell metadata file's exclusive
+// lock so it cannot interleave with a concurrent reconcile or spec write.
+func (r *Exec) RecordContainerExit(exit ctr.TaskExit) (bool, error) {
+	parts := strings.Split(exit.ContainerID, "_")
+	if len(parts) != containerdIDParts {
+		return false, nil
+	}
+	spaceName, stackName, cellName := parts[0], parts[1], parts[2]
+
+	realmName, err := r.realmForNamespace(exit.Namespace)
+	if err != nil || realmName == "" {
+		return false, err
+	}
+
+	path := fs.CellMetadataPath(r.opts.RunPath, realmName, spaceName, stackName, cellName)
+	// The exclusive lock creates its sidecar (and parent directories), so
+	// check first rather than resurrect the directory of a deleted cell.
+	if _, statErr := os.Stat(path); statErr != nil {
+		if os.IsNotExist(statErr) {
+			return false, nil
+		}
+		return false, statErr
+	}
+	var recorded bool
+	err = metadata.WithExclusiveLock(r.ctx, r.logger, path, func() error {
+		raw, readErr := metadata.ReadRawNoLock(r.ctx, r.logger, path)
+		if readErr != nil {
+			return readErr
+		}
+		var cellDoc v1beta1.CellDoc
+		if unmarshalErr := json.Unmarshal(raw, &cellDoc); unmarshalErr != nil {
+			return wrapConversionErr(unmarshalErr)
+		}
+		cell, convertErr := apischeme.ConvertCellDocToInternal(cellDoc)
+		if convertErr != nil {
+			return wrapConversionErr(convertErr)
+		}
+		if !applyContainerExit(&cell, exit) {
+			return nil
+		}
+		r.captureTerminationMessage(&cell, exit.ContainerID)
+		out, buildErr := apischeme.BuildCellExternalFromInternal(cell, apischeme.VersionV1Beta1)
+		if buildErr != nil {
+			return fmt.Errorf("%w: %w", errdefs.ErrConversionFailed, buildErr)
+		}
+		if writeErr := metadata.WriteMetadataNoLock(r.ctx, r.logger, out, path); writeErr != nil {
+			return fmt.Errorf("%w: %w", errdefs.ErrWriteMetadata, writeErr)
+		}
+		recorded = true
+		return nil
+	})
+	if errors.Is(err, errdefs.ErrMissingMetadataFile) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if recorded {
+		r.logger.InfoContext(r.ctx, "recorded container exit",
+			"realm", realmName,
+			"cell", cellName,
+			"containerdID", exit.ContainerID,
+			"exitCode", exit.ExitCode,
+			"finishedAt", exit.ExitedAt)
+	}
+	return recorded, nil
+}
+
+// realmForNamespace returns the realm whose containerd namespace is ns, or ""
+// when no realm claims it.
+func (r *Exec) realmForNamespace(ns string) (string, error) {
+	realms, err := r.ListRealms()
+	if err != nil {
+		return "", err
+	}
+	for _, realm := range realms {
+		namespace := realm.Spec.Namespace
+		if namespace == "" {
+			namespace = consts.RealmNamespace(realm.Metadata.Name)
+		}
+		if namespace == ns {
+			return realm.Metadata.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// applyContainerExit updates the status of the container whose containerd ID
+// is exit.ContainerID. It reports whether anything changed: false when no
+// container matches, when the same exit was already recorded, or when the
+// container has been observed starting again since the exit (a late event for
+// a previous run must not overwrite the current one).
+func applyContainerExit(cell *intmodel.Cell, exit ctr.TaskExit) bool {
+	containerID := ""
+	for _, spec := range cell.Spec.Containers {
+		if containerdIDForSpec(*cell, spec) == exit.ContainerID {
+			containerID = spec.ID
+			break
+		}
+	}
+	if containerID == "" {
+		return false
+	}
+
+	idx := -1
+	for i := range cell.Status.Containers {
+		if cell.Status.Containers[i].ID == containerID {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		cell.Status.Containers = append(cell.Status.Containers, intmodel.ContainerStatus{
+			Name: containerID,
+			ID:   containerID,
+		})
+		idx = len(cell.Status.Containers) - 1
+	}
+	status := &cell.Status.Containers[idx]
+	if status.FinishTime.Equal(exit.ExitedAt) && status.ExitCode == exit.ExitCode {
+		return false
+	}
+	if !status.StartTime.IsZero() && status.StartTime.After(exit.ExitedAt) {
+		return false
+	}
+
+	status.FinishTime = exit.ExitedAt
+	status.ExitCode = exit.ExitCode
+	status.ExitSignal = exitSignalName(exit.ExitCode)
+	status.State = intmodel.ContainerStateExited
+	if exit.ExitCode != 0 {
+		status.State = intmodel.ContainerStateError
+	}
+	return true
+}
+
+// containerdIDForSpec returns the containerd ID of a container in cell,
+// preferring the ID recorded at provision time and otherwise rebuilding it the
+// way provisioning would.
+func containerdIDForSpec(cell intmodel.Cell, spec intmodel.ContainerSpec) string {
+	if spec.ContainerdID != "" {
+		return spec.ContainerdID
+	}
+	cellID := cell.Spec.ID
+	if cellID == "" {
+		cellID = cell.Metadata.Name
+	}
+	var (
+		id  string
+		err error
+	)
+	if spec.Root {
+		id, err = naming.BuildRootContainerdID(cell.Spec.SpaceName, cell.Spec.StackName, cellID)
+	} else {
+		id, err = naming.BuildContainerdID(cell.Spec.SpaceName, cell.Spec.StackName, cellID, spec.ID)
+	}
+	if err != nil {
+		return ""
+	}
+	return id
+}
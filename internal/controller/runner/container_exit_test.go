@@ -0,0 +1,212 @@
+//go:build !integration
+
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // exercises *Exec.WatchContainerExits against an in-package ctr.Client fake
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eminwux/kukeon/internal/ctr"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/util/fs"
+)
+
+// TestWatchContainerExits_RecordsInjectedExit injects a task-exit event on
+// the containerd event stream and asserts the watcher persists FinishTime,
+// ExitCode, ExitSignal, and the terminal state into the cell's
+// ContainerStatus.
+func TestWatchContainerExits_RecordsInjectedExit(t *testing.T) {
+	exits := make(chan ctr.TaskExit)
+	fake := &deleteCellFakeClient{
+		subscribeTaskExitsFn: func(context.Context) (<-chan ctr.TaskExit, <-chan error) {
+			return exits, nil
+		},
+	}
+	r := newDeleteCellTestExec(t, fake)
+	seedDeleteCellRealm(t, r, "main")
+	seedDeleteCellCell(t, r, "main", "web", "app", "c1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- r.WatchContainerExits(ctx) }()
+
+	exitedAt := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	exits <- ctr.TaskExit{
+		Namespace:   "main.kukeon.io",
+		ContainerID: "web_app_c1_workload",
+		ExitCode:    137,
+		ExitedAt:    exitedAt,
+	}
+	// Events from a foreign namespace or outside the naming scheme are ignored.
+	exits <- ctr.TaskExit{Namespace: "other.io", ContainerID: "web_app_c1_workload", ExitCode: 1}
+	exits <- ctr.TaskExit{Namespace: "main.kukeon.io", ContainerID: "unrelated", ExitCode: 1}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("WatchContainerExits() error = %v", err)
+	}
+
+	cell, err := r.readCellInternal(fs.CellMetadataPath(r.opts.RunPath, "main", "web", "app", "c1"))
+	if err != nil {
+		t.Fatalf("read cell metadata: %v", err)
+	}
+	if len(cell.Status.Containers) != 1 {
+		t.Fatalf("Status.Containers = %+v, want one entry", cell.Status.Containers)
+	}
+	got := cell.Status.Containers[0]
+	if got.ID != "workload" {
+		t.Errorf("ID = %q, want workload", got.ID)
+	}
+	if !got.FinishTime.Equal(exitedAt) {
+		t.Errorf("FinishTime = %v, want %v", got.FinishTime, exitedAt)
+	}
+	if got.ExitCode != 137 {
+		t.Errorf("ExitCode = %d, want 137", got.ExitCode)
+	}
+	if got.ExitSignal != "SIGKILL" {
+		t.Errorf("ExitSignal = %q, want SIGKILL", got.ExitSignal)
+	}
+	if got.State != intmodel.ContainerStateError {
+		t.Errorf("State = %v, want Error", got.State)
+	}
+}
+
+// TestRecordContainerExit_IgnoresExitBeforeCurrentRun guards the late-event
+// case: an exit older than the container's current StartTime belongs to a
+// previous run and must not overwrite the running container's status.
+func TestRecordContainerExit_IgnoresExitBeforeCurrentRun(t *testing.T) {
+	started := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	cell := intmodel.Cell{
+		Metadata: intmodel.CellMetadata{Name: "c1"},
+		Spec: intmodel.CellSpec{
+			SpaceName:  "web",
+			StackName:  "app",
+			Containers: []intmodel.ContainerSpec{{ID: "workload"}},
+		},
+		Status: intmodel.CellStatus{Containers: []intmodel.ContainerStatus{{
+			ID:        "workload",
+			State:     intmodel.ContainerStateReady,
+			StartTime: started,
+		}}},
+	}
+	late := ctr.TaskExit{ContainerID: "web_app_c1_workload", ExitCode: 1, ExitedAt: started.Add(-time.Minute)}
+	if applyContainerExit(&cell, late) {
+		t.Fatal("applyContainerExit() = true for an exit before StartTime, want false")
+	}
+	if cell.Status.Containers[0].State != intmodel.ContainerStateReady {
+		t.Errorf("State = %v, want Ready", cell.Status.Containers[0].State)
+	}
+
+	current := ctr.TaskExit{ContainerID: "web_app_c1_workload", ExitCode: 0, ExitedAt: started.Add(time.Minute)}
+	if !applyContainerExit(&cell, current) {
+		t.Fatal("applyContainerExit() = false for the current run's exit, want true")
+	}
+	if applyContainerExit(&cell, current) {
+		t.Error("applyContainerExit() = true when re-applying the same exit, want false")
+	}
+	if cell.Status.Containers[0].State != intmodel.ContainerStateExited {
+		t.Errorf("State = %v, want Exited", cell.Status.Containers[0].State)
+	}
+}
+
+// TestWatchContainerExits_CapturesTerminationMessage simulates a container
+// that wrote an exit reason to its termination log before dying: the exit
+// watcher copies it into ContainerStatus.TerminationMessage and truncates the
+// host file so the next run starts from an empty log.
+func TestWatchContainerExits_CapturesTerminationMessage(t *testing.T) {
+	exits := make(chan ctr.TaskExit)
+	fake := &deleteCellFakeClient{
+		subscribeTaskExitsFn: func(context.Context) (<-chan ctr.TaskExit, <-chan error) {
+			return exits, nil
+		},
+	}
+	r := newDeleteCellTestExec(t, fake)
+	seedDeleteCellRealm(t, r, "main")
+	seedDeleteCellCell(t, r, "main", "web", "app", "c1")
+
+	logPath := fs.ContainerTerminationLogPath(r.opts.RunPath, "main", "web", "app", "c1", "workload")
+	if err := ensureTerminationLogFile(logPath); err != nil {
+		t.Fatalf("ensureTerminationLogFile: %v", err)
+	}
+	if err := os.WriteFile(logPath, []byte("database migration failed\nsee logs\n"), 0o600); err != nil {
+		t.Fatalf("write termination log: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- r.WatchContainerExits(ctx) }()
+	exits <- ctr.TaskExit{
+		Namespace:   "main.kukeon.io",
+		ContainerID: "web_app_c1_workload",
+		ExitCode:    3,
+		ExitedAt:    time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC),
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("WatchContainerExits() error = %v", err)
+	}
+
+	cell, err := r.readCellInternal(fs.CellMetadataPath(r.opts.RunPath, "main", "web", "app", "c1"))
+	if err != nil {
+		t.Fatalf("read cell metadata: %v", err)
+	}
+	if len(cell.Status.Containers) != 1 {
+		t.Fatalf("Status.Containers = %+v, want one entry", cell.Status.Containers)
+	}
+	if got, want := cell.Status.Containers[0].TerminationMessage, "database migration failed\nsee logs"; got != want {
+		t.Errorf("TerminationMessage = %q, want %q", got, want)
+	}
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("read termination log: %v", err)
+	}
+	if len(data) != 0 {
+		t.Errorf("termination log = %q after capture, want truncated", data)
+	}
+}
+
+func TestConsumeTerminationMessage_BoundsAndAbsence(t *testing.T) {
+	dir := t.TempDir()
+	if got := consumeTerminationMessage(filepath.Join(dir, "missing")); got != "" {
+		t.Errorf("absent file: message = %q, want empty", got)
+	}
+
+	path := filepath.Join(dir, "termination-log")
+	if err := os.WriteFile(path, []byte(strings.Repeat("x", terminationMessageMaxBytes+100)), 0o600); err != nil {
+		t.Fatalf("write termination log: %v", err)
+	}
+	if got := consumeTerminationMessage(path); len(got) != terminationMessageMaxBytes {
+		t.Errorf("message length = %d, want capped at %d", len(got), terminationMessageMaxBytes)
+	}
+}
+
+func TestFormatContainerFailure_AppendsTerminationMessage(t *testing.T) {
+	got := formatContainerFailure(intmodel.ContainerStatus{
+		ID:                 "app",
+		ExitCode:           2,
+		TerminationMessage: "config missing\nstack trace...",
+	})
+	if want := `container "app" exited with code 2: config missing`; got != want {
+		t.Errorf("formatContainerFailure() = %q, want %q", got, want)
+	}
+}
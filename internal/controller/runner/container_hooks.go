@@ -0,0 +1,137 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+const (
+	hookPostStart = "postStart"
+	hookPreStop   = "preStop"
+
+	// stopGracePeriod is the SIGTERM-to-SIGKILL window a workload container
+	// gets on stop when it declares no preStop hooks.
+	stopGracePeriod = 5 * time.Second
+
+	// minStopSignalGrace is the least a task gets after SIGTERM when its
+	// preStop hooks used up the whole grace period.
+	minStopSignalGrace = time.Second
+)
+
+// containerHookTimeout is the per-command bound for postStart and the stop
+// grace period for a container with preStop hooks.
+func containerHookTimeout(lc *intmodel.ContainerLifecycle) time.Duration {
+	if lc.TimeoutSeconds > 0 {
+		return time.Duration(lc.TimeoutSeconds) * time.Second
+	}
+	return defaultHookTimeout
+}
+
+// runPostStartHooks runs the container's postStart commands in its freshly
+// started task. A failure is logged and the remaining commands still run,
+// unless the container sets failOnPostStartError, in which case the first
+// failure is returned so the start fails.
+func (r *Exec) runPostStartHooks(namespace string, container intmodel.ContainerSpec) error {
+	lc := container.Lifecycle
+	if lc == nil {
+		return nil
+	}
+	timeout := containerHookTimeout(lc)
+	for i, command := range lc.PostStart {
+		err := r.execContainerHook(namespace, container, hookPostStart, command, timeout)
+		if err == nil {
+			continue
+		}
+		err = fmt.Errorf("%w: %s[%d] of container %q: %w", errdefs.ErrContainerHookFailed, hookPostStart, i,
+			container.ID, err)
+		if lc.FailOnPostStartError {
+			return err
+		}
+		r.logger.WarnContext(r.ctx, "container lifecycle hook failed", "container", container.ID, "error", err)
+	}
+	return nil
+}
+
+// runPreStopHooks runs the container's preStop commands before it is sent
+// the stop signal and returns the SIGTERM-to-SIGKILL timeout to stop it
+// with. The commands share one grace period with the signal: each is
+// bounded by what is left of it, and the task gets the remainder (never
+// less than minStopSignalGrace). Failures are logged; the stop proceeds.
+func (r *Exec) runPreStopHooks(namespace string, container intmodel.ContainerSpec) time.Duration {
+	lc := container.Lifecycle
+	if lc == nil || len(lc.PreStop) == 0 {
+		return stopGracePeriod
+	}
+	deadline := time.Now().Add(containerHookTimeout(lc))
+	for i, command := range lc.PreStop {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			r.logger.WarnContext(r.ctx, "preStop hooks used up the stop grace period",
+				"container", container.ID, "skipped", len(lc.PreStop)-i)
+			break
+		}
+		err := r.execContainerHook(namespace, container, hookPreStop, command, remaining)
+		if errors.Is(err, errdefs.ErrTaskNotFound) || errors.Is(err, errdefs.ErrTaskNotRunning) {
+			// Nothing is running to prepare for the stop.
+			break
+		}
+		if err != nil {
+			r.logger.WarnContext(r.ctx, "container lifecycle hook failed", "container", container.ID,
+				"hook", fmt.Sprintf("%s[%d]", hookPreStop, i), "error", err)
+		}
+	}
+	return max(time.Until(deadline), minStopSignalGrace)
+}
+
+// execContainerHook runs one command with /bin/sh -c inside the container's
+// task. A non-zero exit becomes an error quoting the tail of its output.
+func (r *Exec) execContainerHook(
+	namespace string,
+	container intmodel.ContainerSpec,
+	phase, command string,
+	timeout time.Duration,
+) error {
+	r.logger.DebugContext(r.ctx, "running container lifecycle hook",
+		"container", container.ID, "hook", phase, "command", command)
+	result, err := r.ctrClient.ExecProcess(namespace, container.ContainerdID, []string{"/bin/sh", "-c", command},
+		timeout)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("timed out after %s", timeout)
+		}
+		return err
+	}
+	if result.ExitCode == 0 {
+		return nil
+	}
+	output := strings.TrimSpace(result.Output)
+	if len(output) > hookOutputLimit {
+		output = output[len(output)-hookOutputLimit:]
+	}
+	if output == "" {
+		return fmt.Errorf("exit status %d", result.ExitCode)
+	}
+	return fmt.Errorf("exit status %d: %s", result.ExitCode, output)
+}
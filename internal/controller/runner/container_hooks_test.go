@@ -0,0 +1,227 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // drives *Exec.RecreateCell / *Exec.StopCell against in-package ctr.Client fakes
+package runner
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// execRecordingClient layers an ExecProcess recorder over the recreate fake
+// so a test can see where hook execs land relative to task starts.
+type execRecordingClient struct {
+	*recreateCellFakeClient
+
+	execFn func(namespace, id string, args []string, timeout time.Duration) (ctr.ExecResult, error)
+}
+
+func (c *execRecordingClient) ExecProcess(
+	namespace, id string,
+	args []string,
+	timeout time.Duration,
+) (ctr.ExecResult, error) {
+	return c.execFn(namespace, id, args, timeout)
+}
+
+func TestStartCell_RunsPostStartHooksAfterTaskStart(t *testing.T) {
+	const (
+		realm = "default"
+		space = "default"
+		stack = "default"
+		cell  = "web"
+	)
+
+	var events []string
+	started := map[string]bool{}
+	recreate := &recreateCellFakeClient{
+		deleteCellFakeClient: &deleteCellFakeClient{
+			taskStatusFn: func(_, id string) (containerd.Status, error) {
+				if started[id] {
+					return containerd.Status{Status: containerd.Running}, nil
+				}
+				return containerd.Status{}, nil
+			},
+		},
+		startContainerFn: func(_ string, spec ctr.ContainerSpec, _ ctr.TaskSpec) (containerd.Task, error) {
+			started[spec.ID] = true
+			events = append(events, "start "+spec.ID)
+			return recreateCellTask{pid: 4242}, nil
+		},
+	}
+	fake := &execRecordingClient{
+		recreateCellFakeClient: recreate,
+		execFn: func(_, id string, args []string, _ time.Duration) (ctr.ExecResult, error) {
+			if !started[id] {
+				return ctr.ExecResult{}, errdefs.ErrTaskNotRunning
+			}
+			events = append(events, "exec "+id+" "+args[len(args)-1])
+			return ctr.ExecResult{}, nil
+		},
+	}
+	existing, desired, rootID, workloadID := recreateCellMultiContainerCell(
+		t, realm, space, stack, cell, "alpine:3.18", "alpine:3.19",
+	)
+	desired.Spec.Containers[1].Lifecycle = &intmodel.ContainerLifecycle{
+		PostStart: []string{"warm-cache", "register"},
+	}
+	r := newRecreateCellTestExec(t, recreate)
+	r.ctrClient = fake
+	seedDeleteCellRealm(t, r, realm)
+	seedRecreateCellSpace(t, r, realm, space)
+	if err := r.UpdateCellMetadata(existing); err != nil {
+		t.Fatalf("seed existing cell: %v", err)
+	}
+
+	if _, err := r.RecreateCell(desired); err != nil {
+		t.Fatalf("RecreateCell: %v", err)
+	}
+
+	want := []string{
+		"start " + rootID,
+		"start " + workloadID,
+		"exec " + workloadID + " warm-cache",
+		"exec " + workloadID + " register",
+	}
+	if !slices.Equal(events, want) {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+}
+
+func TestStopCell_RunsPreStopHooksBeforeSignal(t *testing.T) {
+	fake := &stopKillFakeClient{}
+	r := newStopKillTestExec(t, fake)
+	req := seedHookCell(t, r, nil)
+
+	cell, err := r.GetCell(req)
+	if err != nil {
+		t.Fatalf("GetCell: %v", err)
+	}
+	var workloadID string
+	for i := range cell.Spec.Containers {
+		if !cell.Spec.Containers[i].Root {
+			cell.Spec.Containers[i].Lifecycle = &intmodel.ContainerLifecycle{
+				PreStop:        []string{"drain", "deregister"},
+				TimeoutSeconds: 20,
+			}
+			workloadID = cell.Spec.Containers[i].ContainerdID
+		}
+	}
+	if err = r.UpdateCellMetadata(cell); err != nil {
+		t.Fatalf("UpdateCellMetadata: %v", err)
+	}
+
+	var events []string
+	var workloadGrace time.Duration
+	fake.execProcessFn = func(_, id string, args []string, _ time.Duration) (ctr.ExecResult, error) {
+		events = append(events, "exec "+id+" "+args[len(args)-1])
+		if args[len(args)-1] == "drain" {
+			return ctr.ExecResult{ExitCode: 1, Output: "drain refused"}, nil
+		}
+		return ctr.ExecResult{}, nil
+	}
+	fake.stopContainerFn = func(_, id string, opts ctr.StopContainerOptions) (*containerd.ExitStatus, error) {
+		events = append(events, "SIGTERM "+id)
+		if id == workloadID {
+			workloadGrace = *opts.Timeout
+		}
+		return &containerd.ExitStatus{}, nil
+	}
+
+	if _, err = r.StopCell(req); err != nil {
+		t.Fatalf("StopCell: a failing preStop hook must not fail the stop, got %v", err)
+	}
+	if len(events) < 3 || !slices.Equal(events[:3], []string{
+		"exec " + workloadID + " drain",
+		"exec " + workloadID + " deregister",
+		"SIGTERM " + workloadID,
+	}) {
+		t.Errorf("events = %v, want both preStop hooks before the workload's SIGTERM", events)
+	}
+	if workloadGrace <= 0 || workloadGrace > 20*time.Second {
+		t.Errorf("workload stop grace = %s, want what preStop left of 20s", workloadGrace)
+	}
+}
+
+func TestRunPostStartHooks_FailOnPostStartError(t *testing.T) {
+	fake := &stopKillFakeClient{}
+	r := newStopKillTestExec(t, fake)
+	var ran []string
+	fake.execProcessFn = func(_, _ string, args []string, _ time.Duration) (ctr.ExecResult, error) {
+		ran = append(ran, args[len(args)-1])
+		if args[len(args)-1] == "migrate" {
+			return ctr.ExecResult{ExitCode: 2, Output: "schema locked\n"}, nil
+		}
+		return ctr.ExecResult{}, nil
+	}
+	container := intmodel.ContainerSpec{
+		ID:           "app",
+		ContainerdID: "default_default_web_app",
+		Lifecycle:    &intmodel.ContainerLifecycle{PostStart: []string{"migrate", "register"}},
+	}
+
+	if err := r.runPostStartHooks("ns", container); err != nil {
+		t.Fatalf("runPostStartHooks without failOnPostStartError = %v, want nil", err)
+	}
+	if !slices.Equal(ran, []string{"migrate", "register"}) {
+		t.Errorf("hooks run = %v, want every command despite the failure", ran)
+	}
+
+	ran = nil
+	container.Lifecycle.FailOnPostStartError = true
+	err := r.runPostStartHooks("ns", container)
+	if !errors.Is(err, errdefs.ErrContainerHookFailed) {
+		t.Fatalf("runPostStartHooks error = %v, want ErrContainerHookFailed", err)
+	}
+	for _, want := range []string{"postStart[0]", "exit status 2", "schema locked"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+	if !slices.Equal(ran, []string{"migrate"}) {
+		t.Errorf("hooks run = %v, want to stop at the failing command", ran)
+	}
+}
+
+func TestRunPreStopHooks_GraceFloor(t *testing.T) {
+	fake := &stopKillFakeClient{}
+	r := newStopKillTestExec(t, fake)
+	fake.execProcessFn = func(_, _ string, _ []string, timeout time.Duration) (ctr.ExecResult, error) {
+		time.Sleep(timeout)
+		return ctr.ExecResult{}, context.DeadlineExceeded
+	}
+	container := intmodel.ContainerSpec{
+		ID:        "app",
+		Lifecycle: &intmodel.ContainerLifecycle{PreStop: []string{"slow", "skipped"}, TimeoutSeconds: 1},
+	}
+
+	if got := r.runPreStopHooks("ns", container); got != minStopSignalGrace {
+		t.Errorf("grace after an overrunning preStop = %s, want %s", got, minStopSignalGrace)
+	}
+	if got := r.runPreStopHooks("ns", intmodel.ContainerSpec{ID: "plain"}); got != stopGracePeriod {
+		t.Errorf("grace without preStop = %s, want %s", got, stopGracePeriod)
+	}
+}
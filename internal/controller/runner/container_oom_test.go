@@ -0,0 +1,189 @@
+//go:build !integration
+
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // exercises unexported populateCellContainerStatuses and observeOOM
+package runner
+
+import (
+	"testing"
+	"time"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/eminwux/kukeon/internal/ctr"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// TestPopulateCellContainerStatuses_DetectsOOMKill injects an oom_kill event
+// into the container cgroup's memory.events and asserts the status flags it:
+// the SIGKILLed container is marked OOMKilled with LastOOM stamped, and once
+// the container is restarted (fresh cgroup, zero counter, Ready) the flag
+// clears while LastOOM is preserved.
+func TestPopulateCellContainerStatuses_DetectsOOMKill(t *testing.T) {
+	realm, space, stack, cellName := "default", "kukeon", "kukeon", "web"
+	containerID, containerdID := "root", "kukeon_kukeon_web_root"
+	cellCgroup := "/kukeon/default/kukeon/kukeon/web"
+
+	oomKills := uint64(1)
+	taskStatus := containerd.Status{Status: containerd.Stopped, ExitStatus: 137, ExitTime: time.Now()}
+	var gotGroup string
+	fake := &deleteCellFakeClient{
+		existsContainerFn: func(_, _ string) (bool, error) { return true, nil },
+		taskStatusFn: func(_, _ string) (containerd.Status, error) {
+			return taskStatus, nil
+		},
+		cgroupUsageFn: func(group, _ string) (ctr.CgroupUsage, error) {
+			gotGroup = group
+			kills := oomKills
+			return ctr.CgroupUsage{OOMKills: &kills}, nil
+		},
+	}
+	r := newDeleteCellTestExec(t, fake)
+	seedDeleteCellRealm(t, r, realm)
+
+	oomAt := time.Date(2026, 6, 7, 20, 35, 4, 0, time.UTC)
+	r.nowFn = func() time.Time { return oomAt }
+
+	cell := containerStateCell(realm, space, stack, cellName, containerID, containerdID)
+	cell.Status.CgroupPath = cellCgroup
+
+	if err := r.populateCellContainerStatuses(&cell); err != nil {
+		t.Fatalf("populateCellContainerStatuses (oom): unexpected error: %v", err)
+	}
+	if want := cellCgroup + "/" + containerdID; gotGroup != want {
+		t.Errorf("CgroupUsage group = %q, want container cgroup %q", gotGroup, want)
+	}
+	got := cell.Status.Containers[0]
+	if !got.OOMKilled {
+		t.Error("OOMKilled = false, want true after an oom_kill event on a stopped container")
+	}
+	if !got.LastOOM.Equal(oomAt) {
+		t.Errorf("LastOOM = %v, want %v", got.LastOOM, oomAt)
+	}
+	if got.OOMKillCount != 1 {
+		t.Errorf("OOMKillCount = %d, want 1", got.OOMKillCount)
+	}
+
+	// A second pull with the same counter is not a new OOM: LastOOM keeps the
+	// first observation time.
+	r.nowFn = func() time.Time { return oomAt.Add(time.Minute) }
+	if err := r.populateCellContainerStatuses(&cell); err != nil {
+		t.Fatalf("populateCellContainerStatuses (repeat): unexpected error: %v", err)
+	}
+	if got = cell.Status.Containers[0]; !got.OOMKilled || !got.LastOOM.Equal(oomAt) {
+		t.Errorf("repeat pull: OOMKilled=%v LastOOM=%v, want true/%v", got.OOMKilled, got.LastOOM, oomAt)
+	}
+
+	// Restart: the cgroup is recreated with a zero counter and the task runs.
+	oomKills = 0
+	taskStatus = containerd.Status{Status: containerd.Running}
+	if err := r.populateCellContainerStatuses(&cell); err != nil {
+		t.Fatalf("populateCellContainerStatuses (restart): unexpected error: %v", err)
+	}
+	got = cell.Status.Containers[0]
+	if got.OOMKilled {
+		t.Error("OOMKilled = true, want cleared once the container is Ready again")
+	}
+	if !got.LastOOM.Equal(oomAt) {
+		t.Errorf("LastOOM = %v, want preserved %v across the restart", got.LastOOM, oomAt)
+	}
+	if got.OOMKillCount != 0 {
+		t.Errorf("OOMKillCount = %d, want re-baselined to 0", got.OOMKillCount)
+	}
+}
+
+// TestPopulateCellContainerStatuses_NoCgroupPathSkipsOOMRead confirms a cell
+// without a recorded cgroup path never reads memory.events, leaving the OOM
+// fields at their zero values.
+func TestPopulateCellContainerStatuses_NoCgroupPathSkipsOOMRead(t *testing.T) {
+	realm, space, stack, cellName := "default", "kukeon", "kukeon", "web"
+	containerID, containerdID := "root", "kukeon_kukeon_web_root"
+
+	fake := &deleteCellFakeClient{
+		existsContainerFn: func(_, _ string) (bool, error) { return true, nil },
+		taskStatusFn: func(_, _ string) (containerd.Status, error) {
+			return containerd.Status{Status: containerd.Stopped, ExitStatus: 137}, nil
+		},
+		cgroupUsageFn: func(_, _ string) (ctr.CgroupUsage, error) {
+			t.Fatal("CgroupUsage called for a cell without a cgroup path")
+			return ctr.CgroupUsage{}, nil
+		},
+	}
+	r := newDeleteCellTestExec(t, fake)
+	seedDeleteCellRealm(t, r, realm)
+
+	cell := containerStateCell(realm, space, stack, cellName, containerID, containerdID)
+	if err := r.populateCellContainerStatuses(&cell); err != nil {
+		t.Fatalf("populateCellContainerStatuses: unexpected error: %v", err)
+	}
+	if got := cell.Status.Containers[0]; got.OOMKilled || !got.LastOOM.IsZero() || got.OOMKillCount != 0 {
+		t.Errorf("OOM fields = %v/%v/%d, want zero values", got.OOMKilled, got.LastOOM, got.OOMKillCount)
+	}
+}
+
+// TestObserveOOM pins the fold rules for a single memory.events read.
+func TestObserveOOM(t *testing.T) {
+	now := time.Date(2026, 6, 7, 20, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+	count := func(n uint64) *uint64 { return &n }
+
+	cases := []struct {
+		name  string
+		prior oomStatus
+		obs   ContainerObservation
+		want  oomStatus
+	}{
+		{
+			name:  "no_reading_preserves_prior",
+			prior: oomStatus{killed: true, last: earlier, count: 2},
+			obs:   ContainerObservation{State: intmodel.ContainerStateStopped},
+			want:  oomStatus{killed: true, last: earlier, count: 2},
+		},
+		{
+			name:  "new_kill_on_stopped_container",
+			prior: oomStatus{count: 0},
+			obs:   ContainerObservation{State: intmodel.ContainerStateError, OOMKills: count(1)},
+			want:  oomStatus{killed: true, last: now, count: 1},
+		},
+		{
+			name:  "child_killed_while_running",
+			prior: oomStatus{count: 0},
+			obs:   ContainerObservation{State: intmodel.ContainerStateReady, OOMKills: count(1)},
+			want:  oomStatus{killed: false, last: now, count: 1},
+		},
+		{
+			name:  "unchanged_count_is_not_new",
+			prior: oomStatus{killed: true, last: earlier, count: 1},
+			obs:   ContainerObservation{State: intmodel.ContainerStateError, OOMKills: count(1)},
+			want:  oomStatus{killed: true, last: earlier, count: 1},
+		},
+		{
+			name:  "recreated_cgroup_with_new_kill",
+			prior: oomStatus{last: earlier, count: 3},
+			obs:   ContainerObservation{State: intmodel.ContainerStateError, OOMKills: count(1)},
+			want:  oomStatus{killed: true, last: now, count: 1},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := observeOOM(tc.prior, tc.obs, now)
+			if got.killed != tc.want.killed || !got.last.Equal(tc.want.last) || got.count != tc.want.count {
+				t.Errorf("observeOOM = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package runner
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"syscall"
 	"time"
@@ -71,6 +72,16 @@ type ContainerObservation struct {
 	// ExitTime is the wall-clock time containerd recorded the task's death,
 	// surfaced as ContainerStatus.FinishTime. Zero until the task is Stopped.
 	ExitTime time.Time
+	// OOMKills is the oom_kill count read from the container cgroup's
+	// memory.events, nil when the cgroup or the memory controller is not
+	// available. populateCellContainerStatuses diffs it against the persisted
+	// OOMKillCount to detect new OOM kills.
+	OOMKills *uint64
+	// ImageDigest is the manifest digest of the image the container was
+	// created from, empty when the container record is absent or the lookup
+	// fails. populateCellContainerStatuses records it as
+	// ContainerStatus.ImageDigest.
+	ImageDigest string
 }
 
 // GetContainerState queries containerd for the actual task status of a container
@@ -224,6 +235,8 @@ func (r *Exec) GetContainerObservation(cell intmodel.Cell, containerID string) (
 		return ContainerObservation{State: intmodel.ContainerStateNotCreated}, nil
 	}
 
+	imageDigest := r.containerImageDigest(namespace, containerdID)
+
 	// Get container state using TaskStatus from ctr package
 	taskStatus, taskStatusErr := r.ctrClient.TaskStatus(namespace, containerdID)
 	if taskStatusErr == nil {
@@ -245,7 +258,13 @@ func (r *Exec) GetContainerObservation(cell intmodel.Cell, containerID string) (
 		// ExitTime is only stamped by containerd once the task is Stopped; on a
 		// Running/Created/Paused task it is the zero time, which surfaces as a
 		// zero FinishTime (the container has not finished). Issue #1137.
-		return ContainerObservation{State: state, ExitCode: exitCode, ExitTime: taskStatus.ExitTime}, nil
+		return ContainerObservation{
+			State:       state,
+			ExitCode:    exitCode,
+			ExitTime:    taskStatus.ExitTime,
+			OOMKills:    r.containerOOMKills(cell, containerdID),
+			ImageDigest: imageDigest,
+		}, nil
 	}
 
 	// TaskStatus failed against an existing container: the container record
@@ -263,7 +282,7 @@ func (r *Exec) GetContainerObservation(cell intmodel.Cell, containerID string) (
 			"containerdID", containerdID,
 			"namespace", namespace,
 			"error", taskStatusErr)
-		return ContainerObservation{State: intmodel.ContainerStateStopped}, nil
+		return ContainerObservation{State: intmodel.ContainerStateStopped, ImageDigest: imageDigest}, nil
 	}
 
 	// TaskStatus failed - return Unknown since we can't determine the state
@@ -272,5 +291,38 @@ func (r *Exec) GetContainerObservation(cell intmodel.Cell, containerID string) (
 		"containerdID", containerdID,
 		"namespace", namespace,
 		"error", taskStatusErr)
-	return ContainerObservation{State: intmodel.ContainerStateUnknown}, nil
+	return ContainerObservation{State: intmodel.ContainerStateUnknown, ImageDigest: imageDigest}, nil
+}
+
+// containerImageDigest returns the manifest digest of the image a container
+// was created from. Best-effort: returns "" when the lookup fails.
+func (r *Exec) containerImageDigest(namespace, containerdID string) string {
+	dgst, err := r.ctrClient.ContainerImageDigest(namespace, containerdID)
+	if err != nil {
+		r.logger.DebugContext(r.ctx, "failed to read container image digest",
+			"containerdID", containerdID,
+			"error", err)
+		return ""
+	}
+	return dgst
+}
+
+// containerOOMKills reads the oom_kill counter of a container's cgroup, which
+// runc places at <cell cgroup>/<containerd id> (see ctr.cellCgroupsPath). The
+// cgroup survives until the task is deleted, so a Stopped-but-not-reaped
+// task still reports the kill that ended it. Best-effort: returns nil when
+// the cell has no recorded cgroup path or the read fails.
+func (r *Exec) containerOOMKills(cell intmodel.Cell, containerdID string) *uint64 {
+	cellCgroup := strings.TrimSpace(cell.Status.CgroupPath)
+	if cellCgroup == "" || containerdID == "" {
+		return nil
+	}
+	usage, err := r.ctrClient.CgroupUsage(filepath.Join(cellCgroup, containerdID), r.ctrClient.GetCgroupMountpoint())
+	if err != nil {
+		r.logger.DebugContext(r.ctx, "failed to read container cgroup oom count",
+			"containerdID", containerdID,
+			"error", err)
+		return nil
+	}
+	return usage.OOMKills
 }
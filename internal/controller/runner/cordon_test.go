@@ -0,0 +1,94 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // drives *Exec create/delete paths against an in-package ctr.Client fake
+package runner
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/eminwux/kukeon/internal/cordon"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/util/fs"
+)
+
+func cordonTestNode(t *testing.T, r *Exec) {
+	t.Helper()
+	if _, _, err := cordon.Cordon(r.opts.RunPath, "kernel upgrade", time.Now()); err != nil {
+		t.Fatalf("cordon: %v", err)
+	}
+}
+
+func TestCreateCell_CordonedRefusesNewCell(t *testing.T) {
+	realm, space, stack, cellName := "default", "default", "default", "web"
+	r := newDeleteCellTestExec(t, &deleteCellFakeClient{})
+	seedDeleteCellRealm(t, r, realm)
+	cordonTestNode(t, r)
+
+	_, err := r.CreateCell(r.ctx, buildDeleteCellRequest(realm, space, stack, cellName))
+	if !errors.Is(err, errdefs.ErrNodeCordoned) {
+		t.Fatalf("CreateCell on a cordoned node: got %v, want ErrNodeCordoned", err)
+	}
+	if _, statErr := os.Stat(fs.CellMetadataPath(r.opts.RunPath, realm, space, stack, cellName)); !os.IsNotExist(statErr) {
+		t.Errorf("refused cell left metadata behind: stat err=%v", statErr)
+	}
+}
+
+func TestCreateContainer_CordonedRefusesUndeclaredContainer(t *testing.T) {
+	realm, space, stack, cellName := "default", "default", "default", "web"
+	r := newDeleteCellTestExec(t, &deleteCellFakeClient{})
+	seedDeleteCellRealm(t, r, realm)
+	seedDeleteCellCell(t, r, realm, space, stack, cellName)
+	cordonTestNode(t, r)
+
+	_, err := r.CreateContainer(r.ctx, buildDeleteCellRequest(realm, space, stack, cellName),
+		intmodel.ContainerSpec{ID: "sidecar", Image: "alpine:latest"})
+	if !errors.Is(err, errdefs.ErrNodeCordoned) {
+		t.Fatalf("CreateContainer for a new container on a cordoned node: got %v, want ErrNodeCordoned", err)
+	}
+}
+
+func TestDeleteCell_ProceedsWhileCordoned(t *testing.T) {
+	realm, space, stack, cellName := "default", "default", "default", "web"
+	r := newDeleteCellTestExec(t, &deleteCellFakeClient{})
+	seedDeleteCellRealm(t, r, realm)
+	metadataPath := seedDeleteCellCell(t, r, realm, space, stack, cellName)
+	cordonTestNode(t, r)
+
+	if _, err := r.GetCell(buildDeleteCellRequest(realm, space, stack, cellName)); err != nil {
+		t.Fatalf("GetCell on a cordoned node: %v", err)
+	}
+	if err := r.DeleteCell(buildDeleteCellRequest(realm, space, stack, cellName)); err != nil {
+		t.Fatalf("DeleteCell on a cordoned node: %v", err)
+	}
+	if _, statErr := os.Stat(metadataPath); !os.IsNotExist(statErr) {
+		t.Errorf("cell metadata still present after delete: stat err=%v", statErr)
+	}
+}
+
+func TestCellDeclaresContainer(t *testing.T) {
+	cell := intmodel.Cell{Spec: intmodel.CellSpec{Containers: []intmodel.ContainerSpec{{ID: "app"}}}}
+	if !cellDeclaresContainer(cell, "app") {
+		t.Error("declared container reported as new")
+	}
+	if cellDeclaresContainer(cell, "sidecar") {
+		t.Error("undeclared container reported as declared")
+	}
+}
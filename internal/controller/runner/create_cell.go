@@ -17,18 +17,30 @@
 package runner
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/eminwux/kukeon/internal/cordon"
 	"github.com/eminwux/kukeon/internal/errdefs"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/tracing"
 	"github.com/eminwux/kukeon/internal/util/diskpressure"
 	"github.com/eminwux/kukeon/internal/util/fs"
 	"github.com/eminwux/kukeon/internal/util/naming"
 )
 
-func (r *Exec) CreateCell(cell intmodel.Cell) (intmodel.Cell, error) {
+// CreateCell creates the cell, or ensures the resources of an existing one,
+// under a "runner.CreateCell" span parented by ctx.
+func (r *Exec) CreateCell(ctx context.Context, cell intmodel.Cell) (intmodel.Cell, error) {
+	ctx, span := tracing.Start(ctx, "runner.CreateCell", cellSpanAttributes(cell)...)
+	out, err := r.createCell(ctx, cell)
+	tracing.End(span, err)
+	return out, err
+}
+
+func (r *Exec) createCell(ctx context.Context, cell intmodel.Cell) (intmodel.Cell, error) {
 	defer r.lockCell(cell)()
 
 	if err := r.ensureClientConnected(); err != nil {
@@ -132,12 +144,18 @@ func (r *Exec) CreateCell(cell intmodel.Cell) (intmodel.Cell, error) {
 	// (cell.Spec.IgnoreDiskPressure) bypasses it. The existing-cell branch above
 	// (EnsureCell) is deliberately not guarded — ensuring resources for a cell
 	// that already exists is not "digging the hole deeper".
+	// A cordoned node (`kuke cordon`) refuses new cells the same way; like the
+	// disk-pressure guard it leaves the existing-cell branch alone, so reconcile
+	// keeps ensuring what is already provisioned.
+	if err = cordon.Guard(r.opts.RunPath, fmt.Sprintf("cell %q", cell.Metadata.Name)); err != nil {
+		return intmodel.Cell{}, err
+	}
 	if err = r.guardDiskPressure(cell); err != nil {
 		return intmodel.Cell{}, err
 	}
 
 	// Cell not found, create new cell
-	resultCell, err := r.provisionNewCell(cell)
+	resultCell, err := r.provisionNewCell(ctx, cell)
 	if err != nil {
 		return intmodel.Cell{}, err
 	}
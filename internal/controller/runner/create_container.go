@@ -17,12 +17,15 @@
 package runner
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/eminwux/kukeon/internal/cordon"
 	"github.com/eminwux/kukeon/internal/errdefs"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/tracing"
 	"github.com/eminwux/kukeon/internal/util/naming"
 )
 
@@ -33,7 +36,18 @@ import (
 // ensureCellContainers, which is the single choke point every creation and
 // update path traverses — so the post-merge (effective) configuration is
 // what gets persisted and what `kuke get container -o yaml` displays.
-func (r *Exec) CreateContainer(cell intmodel.Cell, container intmodel.ContainerSpec) (intmodel.Cell, error) {
+func (r *Exec) CreateContainer(
+	ctx context.Context,
+	cell intmodel.Cell,
+	container intmodel.ContainerSpec,
+) (intmodel.Cell, error) {
+	_, span := tracing.Start(ctx, "runner.CreateContainer", containerSpanAttributes(cell, container)...)
+	out, err := r.createContainer(cell, container)
+	tracing.End(span, err)
+	return out, err
+}
+
+func (r *Exec) createContainer(cell intmodel.Cell, container intmodel.ContainerSpec) (intmodel.Cell, error) {
 	trimmedID := strings.TrimSpace(container.ID)
 	if err := naming.ValidateHierarchyName("container", trimmedID); err != nil {
 		return intmodel.Cell{}, err
@@ -49,6 +63,14 @@ func (r *Exec) CreateContainer(cell intmodel.Cell, container intmodel.ContainerS
 		return intmodel.Cell{}, fmt.Errorf("%w: %w", errdefs.ErrGetCell, err)
 	}
 
+	// A cordoned node refuses containers the cell does not already declare;
+	// re-ensuring a declared one is not new provisioning.
+	if !cellDeclaresContainer(existingCell, container.ID) {
+		if err = cordon.Guard(r.opts.RunPath, fmt.Sprintf("container %q", container.ID)); err != nil {
+			return intmodel.Cell{}, err
+		}
+	}
+
 	// Cell found, ensure container is merged
 	ensuredCell, ensureErr := r.EnsureContainer(existingCell, container)
 	if ensureErr != nil {
@@ -125,3 +147,14 @@ func (r *Exec) EnsureContainer(cell intmodel.Cell, container intmodel.ContainerS
 
 	return cell, nil
 }
+
+// cellDeclaresContainer reports whether cell's spec already lists a container
+// with the given ID.
+func cellDeclaresContainer(cell intmodel.Cell, id string) bool {
+	for _, c := range cell.Spec.Containers {
+		if c.ID == id {
+			return true
+		}
+	}
+	return false
+}
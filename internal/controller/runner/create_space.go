@@ -74,7 +74,8 @@ func (r *Exec) CreateSpace(space intmodel.Space) (intmodel.Space, error) {
 }
 
 // EnsureSpace ensures that all required resources for a space exist.
-// It ensures the CNI config and cgroup exist.
+// It ensures the CNI config and cgroup exist, and transitions a space whose
+// metadata was written ahead of its resources (Pending or Creating) to Ready.
 func (r *Exec) EnsureSpace(space intmodel.Space) (intmodel.Space, error) {
 	// Ensure CNI config exists
 	ensuredSpace, ensureErr := r.ensureSpaceCNIConfig(space)
@@ -88,5 +89,13 @@ func (r *Exec) EnsureSpace(space intmodel.Space) (intmodel.Space, error) {
 		return intmodel.Space{}, ensureErr
 	}
 
+	if ensuredSpace.Status.State == intmodel.SpaceStatePending ||
+		ensuredSpace.Status.State == intmodel.SpaceStateCreating {
+		ensuredSpace.Status.State = intmodel.SpaceStateReady
+		if updateErr := r.UpdateSpaceMetadata(ensuredSpace); updateErr != nil {
+			return intmodel.Space{}, fmt.Errorf("%w: %w", errdefs.ErrUpdateSpaceMetadata, updateErr)
+		}
+	}
+
 	return ensuredSpace, nil
 }
@@ -63,7 +63,8 @@ func (r *Exec) CreateStack(stack intmodel.Stack) (intmodel.Stack, error) {
 }
 
 // EnsureStack ensures that all required resources for a stack exist.
-// It ensures the cgroup exists.
+// It ensures the cgroup exists and transitions a Pending stack, whose
+// metadata was written ahead of its cgroup, to Ready.
 func (r *Exec) EnsureStack(stack intmodel.Stack) (intmodel.Stack, error) {
 	// Ensure cgroup exists
 	ensuredStack, ensureErr := r.ensureStackCgroup(stack)
@@ -71,5 +72,12 @@ func (r *Exec) EnsureStack(stack intmodel.Stack) (intmodel.Stack, error) {
 		return intmodel.Stack{}, ensureErr
 	}
 
+	if ensuredStack.Status.State == intmodel.StackStatePending {
+		ensuredStack.Status.State = intmodel.StackStateReady
+		if updateErr := r.UpdateStackMetadata(ensuredStack); updateErr != nil {
+			return intmodel.Stack{}, fmt.Errorf("%w: %w", errdefs.ErrUpdateStackMetadata, updateErr)
+		}
+	}
+
 	return ensuredStack, nil
 }
@@ -27,6 +27,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	cgroup2 "github.com/containerd/cgroups/v2/cgroup2"
 	apitypes "github.com/containerd/containerd/api/types"
@@ -62,6 +63,15 @@ type deleteCellFakeClient struct {
 	// <ns>_history companion is torn down alongside the realm namespace.
 	deleteNamespaceFn  func(namespace string) error
 	cleanupNamespaceFn func(namespace, snapshotter string) error
+	// cgroupUsageFn backs CgroupUsage so the OOM detection tests can inject a
+	// memory.events oom_kill count for a container cgroup.
+	cgroupUsageFn func(group, mountpoint string) (ctr.CgroupUsage, error)
+	// pullImageFn backs PullImage so the realm pause-image tests can
+	// observe (or fail) the provision-time pull.
+	pullImageFn func(namespace, ref string, creds []ctr.RegistryCredentials) (ctr.ImageInfo, error)
+	// subscribeTaskExitsFn backs SubscribeTaskExits so the exit-watch tests
+	// can inject task-exit events.
+	subscribeTaskExitsFn func(ctx context.Context) (<-chan ctr.TaskExit, <-chan error)
 }
 
 func (c *deleteCellFakeClient) Connect() error { return nil }
@@ -87,9 +97,16 @@ func (c *deleteCellFakeClient) CleanupNamespaceResources(namespace, snapshotter
 	return nil
 }
 
+func (c *deleteCellFakeClient) GetCgroupMode() ctr.CgroupMode             { return ctr.CgroupModeUnified }
 func (c *deleteCellFakeClient) GetCgroupMountpoint() string               { return "" }
 func (c *deleteCellFakeClient) GetCurrentCgroupPath() (string, error)     { return "", nil }
 func (c *deleteCellFakeClient) CgroupPath(string, string) (string, error) { return "", nil }
+func (c *deleteCellFakeClient) CgroupUsage(group, mountpoint string) (ctr.CgroupUsage, error) {
+	if c.cgroupUsageFn != nil {
+		return c.cgroupUsageFn(group, mountpoint)
+	}
+	return ctr.CgroupUsage{}, nil
+}
 func (c *deleteCellFakeClient) NewCgroup(spec ctr.CgroupSpec) (*cgroup2.Manager, error) {
 	if c.newCgroupFn != nil {
 		return c.newCgroupFn(spec)
@@ -192,6 +209,18 @@ func (c *deleteCellFakeClient) TaskMetrics(string, string) (*apitypes.Metric, er
 	return nil, nil
 }
 
+func (c *deleteCellFakeClient) TaskPID(string, string) (uint32, error) {
+	return 0, nil
+}
+
+func (c *deleteCellFakeClient) ResizeProcess(string, string, string, uint32, uint32) error {
+	return nil
+}
+
+func (c *deleteCellFakeClient) ExecProcess(string, string, []string, time.Duration) (ctr.ExecResult, error) {
+	return ctr.ExecResult{}, nil
+}
+
 func (c *deleteCellFakeClient) ContainerProcessUID(string, containerd.Container) (uint32, error) {
 	return 0, nil
 }
@@ -208,6 +237,16 @@ func (c *deleteCellFakeClient) GetImage(string, string) (ctr.ImageInfo, error) {
 	return ctr.ImageInfo{}, nil
 }
 
+func (c *deleteCellFakeClient) PullImage(
+	namespace, ref string,
+	creds []ctr.RegistryCredentials,
+) (ctr.ImageInfo, error) {
+	if c.pullImageFn != nil {
+		return c.pullImageFn(namespace, ref, creds)
+	}
+	return ctr.ImageInfo{}, nil
+}
+
 func (c *deleteCellFakeClient) ImageChainID(string, string) (string, error) {
 	return "", nil
 }
@@ -216,6 +255,14 @@ func (c *deleteCellFakeClient) ContainerRootChainID(string, string) (string, err
 	return "", nil
 }
 
+func (c *deleteCellFakeClient) ContainerImageDigest(string, string) (string, error) {
+	return "", nil
+}
+
+func (c *deleteCellFakeClient) InspectContainer(string, string) (ctr.ContainerInspection, error) {
+	return ctr.ContainerInspection{}, nil
+}
+
 func (c *deleteCellFakeClient) DeleteImage(string, string) error { return nil }
 func (c *deleteCellFakeClient) PruneImages(string) (ctr.PruneResult, error) {
 	return ctr.PruneResult{}, nil
@@ -225,6 +272,13 @@ func (c *deleteCellFakeClient) NamespaceStorage(string) (ctr.StorageStats, error
 	return ctr.StorageStats{}, nil
 }
 
+func (c *deleteCellFakeClient) SubscribeTaskExits(ctx context.Context) (<-chan ctr.TaskExit, <-chan error) {
+	if c.subscribeTaskExitsFn != nil {
+		return c.subscribeTaskExitsFn(ctx)
+	}
+	return nil, nil
+}
+
 var _ ctr.Client = (*deleteCellFakeClient)(nil)
 
 // newDeleteCellTestExec builds a *Exec wired to the fake ctr.Client and an
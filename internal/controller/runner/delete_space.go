@@ -92,6 +92,9 @@ func (r *Exec) DeleteSpace(space intmodel.Space) error {
 		r.logger.WarnContext(r.ctx, "failed to remove space egress policy", "error", policyErr)
 		// Continue teardown even if policy removal fails.
 	}
+	if isoErr := r.removeSpaceIsolation(internalSpace); isoErr != nil {
+		r.logger.WarnContext(r.ctx, "failed to remove space isolation", "error", isoErr)
+	}
 
 	// Delete CNI network config (and the bridge link it references) and
 	// perform comprehensive CNI cleanup.
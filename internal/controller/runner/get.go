@@ -511,6 +511,60 @@ func (r *Exec) ListContainers(realmName, spaceName, stackName, cellName string)
 	return r.ExtractContainersFromCells(cells), nil
 }
 
+// ListAllCells returns every cell in the metadata store, across all realms,
+// spaces, and stacks. Each level is enumerated with childScopeNames, so the
+// reserved resource subdirs (secrets/, blueprints/, configs/, ...) are never
+// walked as scopes. Only metadata files are read; no containerd connection is
+// opened. A cell whose metadata cannot be read is skipped, matching ListCells.
+func (r *Exec) ListAllCells() ([]intmodel.Cell, error) {
+	base := fs.MetadataRoot(r.opts.RunPath)
+	realmNames, err := r.childScopeNames(base, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var cells []intmodel.Cell
+	for _, realmName := range realmNames {
+		realmDir := filepath.Join(base, realmName)
+		spaceNames, spaceErr := r.childScopeNames(realmDir, "")
+		if spaceErr != nil {
+			return nil, spaceErr
+		}
+		for _, spaceName := range spaceNames {
+			spaceDir := filepath.Join(realmDir, spaceName)
+			stackNames, stackErr := r.childScopeNames(spaceDir, "")
+			if stackErr != nil {
+				return nil, stackErr
+			}
+			for _, stackName := range stackNames {
+				cellNames, cellErr := r.childScopeNames(filepath.Join(spaceDir, stackName), "")
+				if cellErr != nil {
+					return nil, cellErr
+				}
+				for _, cellName := range cellNames {
+					metadataPath := fs.CellMetadataPath(r.opts.RunPath, realmName, spaceName, stackName, cellName)
+					cell, readErr := r.readCellInternal(metadataPath)
+					if readErr != nil {
+						r.logger.DebugContext(r.ctx, "skipping cell metadata file", "path", metadataPath, "error", readErr)
+						continue
+					}
+					cells = append(cells, cell)
+				}
+			}
+		}
+	}
+	return cells, nil
+}
+
+// ListAllContainers returns the containers of every cell ListAllCells finds.
+func (r *Exec) ListAllContainers() ([]intmodel.ContainerSpec, error) {
+	cells, err := r.ListAllCells()
+	if err != nil {
+		return nil, err
+	}
+	return r.ExtractContainersFromCells(cells), nil
+}
+
 func (r *Exec) ListRealms() ([]intmodel.Realm, error) {
 	// Scope the walk to <RunPath>/data so non-metadata siblings of the
 	// RunPath (e.g. /opt/kukeon/bin staging kuketty, or the
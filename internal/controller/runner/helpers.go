@@ -413,6 +413,57 @@ func (r *Exec) teardownRealmCNI(realmName string) {
 	}
 }
 
+// gcRealmCNI reclaims CNI allocations the realm's containers leaked: for
+// each space network it runs cni.Manager.GC against the containers still in
+// the realm's containerd namespace, so a cell that crashed without a clean
+// detach gets its CNI DEL (IPAM release, cache entry) here rather than only
+// having its files removed. Must run before teardownRealmCNI and
+// purgeCNIForNetwork wipe the cache it reads. Best-effort: failures are
+// logged and skipped.
+func (r *Exec) gcRealmCNI(realmName, namespace string) {
+	if realmName == "" {
+		return
+	}
+	realmDir := fs.RealmMetadataDir(r.opts.RunPath, realmName)
+	entries, err := os.ReadDir(realmDir)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			r.logger.WarnContext(r.ctx, "failed to read realm metadata dir", "dir", realmDir, "error", err)
+		}
+		return
+	}
+
+	live, err := r.findOrphanedContainers(namespace, "")
+	if err != nil {
+		// Without the live set every cached attachment would look stale.
+		r.logger.WarnContext(r.ctx, "skipping CNI GC: failed to list containers", "namespace", namespace, "error", err)
+		return
+	}
+
+	mgr, err := cni.NewManager(r.cniConf.CniBinDir, r.cniConf.CniConfigDir, r.cniConf.CniCacheDir)
+	if err != nil {
+		r.logger.WarnContext(r.ctx, "failed to create CNI manager for GC", "realm", realmName, "error", err)
+		return
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		networkName, nerr := naming.BuildSpaceNetworkName(realmName, entry.Name())
+		if nerr != nil {
+			continue
+		}
+		reclaimed, gcErr := mgr.GC(r.ctx, networkName, live)
+		for _, containerID := range reclaimed {
+			r.logger.InfoContext(r.ctx, "reclaimed stale CNI allocation",
+				"network", networkName, "container", containerID)
+		}
+		if gcErr != nil {
+			r.logger.WarnContext(r.ctx, "CNI GC incomplete", "network", networkName, "error", gcErr)
+		}
+	}
+}
+
 // purgeCNIForNetwork removes all CNI-related resources for an entire network.
 func (r *Exec) purgeCNIForNetwork(networkName string) error {
 	if networkName == "" {
@@ -998,6 +1049,18 @@ func (r *Exec) populateCellContainerStatuses(cell *intmodel.Cell) error {
 	// survives every reconciliation pass. Issue #1234 (epic #1151).
 	priorRestartCount := make(map[string]int, len(cell.Status.Containers))
 	priorRestartTime := make(map[string]time.Time, len(cell.Status.Containers))
+	// Snapshot prior OOM fields by container ID: the OOM kill count is the
+	// baseline a fresh memory.events read is diffed against, and LastOOM /
+	// OOMKilled must survive a pull that cannot read the cgroup (task reaped,
+	// memory controller not enabled).
+	priorOOM := make(map[string]oomStatus, len(cell.Status.Containers))
+	// Snapshot prior ImageDigest so a pull that cannot read it (record
+	// absent, transient lookup failure) keeps the last digest observed.
+	priorImageDigest := make(map[string]string, len(cell.Status.Containers))
+	// Snapshot prior TerminationMessage: the termination log is consumed on
+	// first read (by the exit watcher or an earlier pass), so later passes
+	// over the same exit must carry the recorded message forward.
+	priorTermMsg := make(map[string]string, len(cell.Status.Containers))
 	for _, prev := range cell.Status.Containers {
 		priorStages[prev.ID] = prev.Stages
 		priorCreatedAt[prev.ID] = prev.CreatedAt
@@ -1006,6 +1069,9 @@ func (r *Exec) populateCellContainerStatuses(cell *intmodel.Cell) error {
 		priorExitCode[prev.ID] = prev.ExitCode
 		priorRestartCount[prev.ID] = prev.RestartCount
 		priorRestartTime[prev.ID] = prev.RestartTime
+		priorOOM[prev.ID] = oomStatus{killed: prev.OOMKilled, last: prev.LastOOM, count: prev.OOMKillCount}
+		priorImageDigest[prev.ID] = prev.ImageDigest
+		priorTermMsg[prev.ID] = prev.TerminationMessage
 	}
 
 	statuses := make([]intmodel.ContainerStatus, 0, len(cell.Spec.Containers))
@@ -1053,15 +1119,27 @@ func (r *Exec) populateCellContainerStatuses(cell *intmodel.Cell) error {
 		// across transient NotCreated/Unknown/reaped-task observations. Preserving
 		// ExitCode here — not re-reading the obs value below — is what keeps a
 		// reaped SIGKILL from showing FinishTime=T with exit code 0. Issue #1137.
+		//
+		// TerminationMessage follows the same lifecycle: cleared when Ready,
+		// read from the container's termination log on a genuine exit
+		// observation (keeping the prior message when the log was already
+		// consumed), preserved otherwise.
 		finishTime := priorFinishTime[containerSpec.ID]
 		exitCode := priorExitCode[containerSpec.ID]
+		termMsg := priorTermMsg[containerSpec.ID]
 		switch {
 		case obs.State == intmodel.ContainerStateReady:
 			finishTime = time.Time{}
 			exitCode = 0
+			termMsg = ""
 		case !obs.ExitTime.IsZero():
 			finishTime = obs.ExitTime
 			exitCode = obs.ExitCode
+			if !containerSpec.Root {
+				if msg := consumeTerminationMessage(r.terminationLogHostPath(cell, containerSpec.ID)); msg != "" {
+					termMsg = msg
+				}
+			}
 		}
 
 		// RestartCount/RestartTime: pure preserve. The reconciler's
@@ -1072,16 +1150,23 @@ func (r *Exec) populateCellContainerStatuses(cell *intmodel.Cell) error {
 		// monotonic and survives reconciliation. A container that never restarted
 		// preserves a zero from a zero. Issue #1234 (epic #1151).
 		status := intmodel.ContainerStatus{
-			Name:         containerSpec.ID,
-			ID:           containerSpec.ID,
-			CreatedAt:    createdAt,
-			State:        obs.State,
-			RestartCount: priorRestartCount[containerSpec.ID],
-			RestartTime:  priorRestartTime[containerSpec.ID],
-			StartTime:    startTime,
-			FinishTime:   finishTime,
-			ExitCode:     exitCode,
-			ExitSignal:   exitSignalName(exitCode),
+			Name:               containerSpec.ID,
+			ID:                 containerSpec.ID,
+			CreatedAt:          createdAt,
+			State:              obs.State,
+			RestartCount:       priorRestartCount[containerSpec.ID],
+			RestartTime:        priorRestartTime[containerSpec.ID],
+			StartTime:          startTime,
+			FinishTime:         finishTime,
+			ExitCode:           exitCode,
+			ExitSignal:         exitSignalName(exitCode),
+			TerminationMessage: termMsg,
+		}
+		oom := observeOOM(priorOOM[containerSpec.ID], obs, now)
+		status.OOMKilled, status.LastOOM, status.OOMKillCount = oom.killed, oom.last, oom.count
+		status.ImageDigest = obs.ImageDigest
+		if status.ImageDigest == "" {
+			status.ImageDigest = priorImageDigest[containerSpec.ID]
 		}
 		// Pull per-repo clone/fetch and per-create-stage outcomes over the
 		// kuketty control socket (issues #642, #689) in a single dial.
@@ -1101,6 +1186,37 @@ func (r *Exec) populateCellContainerStatuses(cell *intmodel.Cell) error {
 	return nil
 }
 
+// oomStatus is the OOM triple populateCellContainerStatuses carries across
+// the unconditional status overwrite.
+type oomStatus struct {
+	killed bool
+	last   time.Time
+	count  int
+}
+
+// observeOOM folds a fresh memory.events oom_kill read into the prior OOM
+// status. A count that differs from the persisted baseline and is non-zero
+// is a new OOM kill: LastOOM is stamped and, unless the container is still
+// running (the kernel killed a child, not the container), OOMKilled is set.
+// The comparison is != rather than > because a restart recreates the cgroup
+// and resets its counter; a zero read just re-baselines. OOMKilled is cleared once the container
+// is Ready again; LastOOM is kept so operators can still see the last kill.
+func observeOOM(prior oomStatus, obs ContainerObservation, now time.Time) oomStatus {
+	next := prior
+	if obs.OOMKills != nil {
+		observed := int(*obs.OOMKills)
+		if observed > 0 && observed != prior.count {
+			next.last = now
+			next.killed = obs.State != intmodel.ContainerStateReady
+		}
+		next.count = observed
+	}
+	if obs.State == intmodel.ContainerStateReady {
+		next.killed = false
+	}
+	return next
+}
+
 // setupStatuses pulls the per-repo clone/fetch outcome and the per-create-stage
 // outcome from a container's kuketty control socket via the GetSetupStatus RPC
 // (issues #642, #689) in a single dial, mapping the wire payload into the
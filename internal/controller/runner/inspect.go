@@ -0,0 +1,41 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner
+
+import (
+	"fmt"
+
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// InspectContainer returns the OCI runtime spec and containerd record of
+// the named container in cell, as containerd stores them. Unlike
+// ContainerTaskPID it does not require a running task: the spec is fixed at
+// create time. Returns errdefs.ErrContainerNotFound when the container is
+// not part of the cell's spec or has no containerd record.
+func (r *Exec) InspectContainer(cell intmodel.Cell, containerID string) (ctr.ContainerInspection, error) {
+	namespace, containerdID, err := r.containerdTarget(cell, containerID)
+	if err != nil {
+		return ctr.ContainerInspection{}, err
+	}
+	if err = r.ensureClientConnected(); err != nil {
+		return ctr.ContainerInspection{}, fmt.Errorf("%w: %w", errdefs.ErrConnectContainerd, err)
+	}
+	return r.ctrClient.InspectContainer(namespace, containerdID)
+}
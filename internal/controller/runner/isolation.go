@@ -0,0 +1,81 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner
+
+import (
+	"fmt"
+
+	"github.com/eminwux/kukeon/internal/cni"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/netpolicy"
+)
+
+// applySpaceIsolation realizes space.Spec.Network.Policy on the host
+// firewall for the space's IPv4 subnet. An isolated space (the default)
+// drops traffic to and from the allocator's parent pool, which holds every
+// other space's subnet — including spaces created later, so existing spaces
+// never need their rules recomputed. An open space has any isolation chain
+// left over from an earlier apply removed.
+func (r *Exec) applySpaceIsolation(space intmodel.Space, subnet string) error {
+	var policy intmodel.NetworkPolicy
+	if space.Spec.Network != nil {
+		policy = space.Spec.Network.Policy
+	}
+	policy, err := netpolicy.ParseNetworkPolicy(policy)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errdefs.ErrIsolationApply, err)
+	}
+	if policy == intmodel.NetworkPolicyOpen {
+		return r.removeSpaceIsolation(space)
+	}
+	iso, err := netpolicy.NewIsolation(
+		space.Spec.RealmName, space.Metadata.Name, subnet, r.spaceIsolationPeers(),
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errdefs.ErrIsolationApply, err)
+	}
+	return r.netPolicyEnforcer().ApplyIsolation(r.ctx, iso)
+}
+
+// ensureSpaceIsolation re-applies the isolation of an existing space, reading
+// its subnet back from the conflist. A conflist without an IPAM subnet has
+// nothing to isolate.
+func (r *Exec) ensureSpaceIsolation(mgr *cni.Manager, space intmodel.Space, confPath string) error {
+	subnet, err := mgr.ReadSubnetCIDR(confPath)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errdefs.ErrIsolationApply, err)
+	}
+	if subnet == "" {
+		return nil
+	}
+	return r.applySpaceIsolation(space, subnet)
+}
+
+// removeSpaceIsolation tears down any isolation previously installed for
+// this space. Idempotent; safe to call on open spaces.
+func (r *Exec) removeSpaceIsolation(space intmodel.Space) error {
+	return r.netPolicyEnforcer().RemoveIsolation(r.ctx, space.Spec.RealmName, space.Metadata.Name)
+}
+
+// spaceIsolationPeers returns the CIDRs an isolated space is cut off from.
+func (r *Exec) spaceIsolationPeers() []string {
+	if r.subnetAllocator == nil {
+		return nil
+	}
+	return []string{r.subnetAllocator.ParentCIDR()}
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // tests exercise private methods on *Exec
+package runner
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/cni"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/netpolicy"
+)
+
+// recordingEnforcer captures isolation calls; egress calls are ignored.
+type recordingEnforcer struct {
+	netpolicy.NoopEnforcer
+
+	applied []*netpolicy.Isolation
+	removed []string
+}
+
+func (e *recordingEnforcer) ApplyIsolation(_ context.Context, i *netpolicy.Isolation) error {
+	e.applied = append(e.applied, i)
+	return nil
+}
+
+func (e *recordingEnforcer) RemoveIsolation(_ context.Context, realmName, spaceName string) error {
+	e.removed = append(e.removed, realmName+"/"+spaceName)
+	return nil
+}
+
+func isolationTestSpace(name string, policy intmodel.NetworkPolicy) intmodel.Space {
+	space := intmodel.Space{
+		Metadata: intmodel.SpaceMetadata{Name: name},
+		Spec:     intmodel.SpaceSpec{RealmName: "main"},
+	}
+	if policy != "" {
+		space.Spec.Network = &intmodel.SpaceNetwork{Policy: policy}
+	}
+	return space
+}
+
+func TestCreateSpaceCNIConfig_AppliesIsolationFromSubnet(t *testing.T) {
+	r := newProvisionTestExec(t, t.TempDir(), false)
+	enforcer := &recordingEnforcer{}
+	r.netPolicy = enforcer
+
+	if _, err := r.createSpaceCNIConfig(isolationTestSpace("blog", "")); err != nil {
+		t.Fatalf("createSpaceCNIConfig: %v", err)
+	}
+	if len(enforcer.applied) != 1 {
+		t.Fatalf("ApplyIsolation calls = %d, want 1", len(enforcer.applied))
+	}
+	iso := enforcer.applied[0]
+	subnet, err := r.subnetAllocator.LoadAssigned("main", "blog")
+	if err != nil {
+		t.Fatalf("LoadAssigned: %v", err)
+	}
+	if iso.Subnet != subnet {
+		t.Errorf("isolation subnet = %q, want the allocated %q", iso.Subnet, subnet)
+	}
+	if !slices.Equal(iso.Peers, []string{cni.DefaultSubnetParentCIDR}) {
+		t.Errorf("isolation peers = %v, want the subnet pool %s", iso.Peers, cni.DefaultSubnetParentCIDR)
+	}
+}
+
+func TestApplySpaceIsolation_Policy(t *testing.T) {
+	r := newProvisionTestExec(t, t.TempDir(), false)
+	enforcer := &recordingEnforcer{}
+	r.netPolicy = enforcer
+
+	if err := r.applySpaceIsolation(isolationTestSpace("web", intmodel.NetworkPolicyOpen), "10.88.3.0/24"); err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if len(enforcer.applied) != 0 || !slices.Equal(enforcer.removed, []string{"main/web"}) {
+		t.Errorf("open space: applied %d, removed %v; want no apply and one remove", len(enforcer.applied), enforcer.removed)
+	}
+
+	err := r.applySpaceIsolation(isolationTestSpace("web", "closed"), "10.88.3.0/24")
+	if !errors.Is(err, errdefs.ErrInvalidNetworkPolicy) {
+		t.Errorf("unknown policy: err = %v, want ErrInvalidNetworkPolicy", err)
+	}
+}
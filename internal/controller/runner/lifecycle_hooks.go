@@ -0,0 +1,131 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+const (
+	hookPreStart = "preStart"
+	hookPostStop = "postStop"
+
+	// defaultHookTimeout bounds each lifecycle command when the cell leaves
+	// spec.lifecycle.timeoutSeconds unset.
+	defaultHookTimeout = 30 * time.Second
+
+	// hookOutputLimit caps how much of a failing hook's output is quoted in
+	// the error.
+	hookOutputLimit = 512
+)
+
+// runPreStartHooks runs the cell's preStart commands in order and stops at
+// the first failure, which aborts StartCell.
+func (r *Exec) runPreStartHooks(cell intmodel.Cell) error {
+	if cell.Spec.Lifecycle == nil {
+		return nil
+	}
+	for i, command := range cell.Spec.Lifecycle.PreStart {
+		if err := r.runCellHook(cell, hookPreStart, command); err != nil {
+			return fmt.Errorf("%w: %s[%d] of cell %q: %w", errdefs.ErrCellHookFailed, hookPreStart, i,
+				cell.Metadata.Name, err)
+		}
+	}
+	return nil
+}
+
+// runPostStopHooks runs every postStop command in order. The cell is already
+// stopped, so a failure is logged and the remaining commands still run.
+func (r *Exec) runPostStopHooks(cell intmodel.Cell) {
+	if cell.Spec.Lifecycle == nil {
+		return
+	}
+	for i, command := range cell.Spec.Lifecycle.PostStop {
+		if err := r.runCellHook(cell, hookPostStop, command); err != nil {
+			r.logger.WarnContext(r.ctx, "cell lifecycle hook failed",
+				"cell", cell.Metadata.Name, "hook", fmt.Sprintf("%s[%d]", hookPostStop, i), "error", err)
+		}
+	}
+}
+
+// runCellHook runs one command through hookRunFn (runHookCommand unless a
+// test overrides it) with the cell's identifiers in its environment.
+func (r *Exec) runCellHook(cell intmodel.Cell, phase, command string) error {
+	timeout := defaultHookTimeout
+	if secs := cell.Spec.Lifecycle.TimeoutSeconds; secs > 0 {
+		timeout = time.Duration(secs) * time.Second
+	}
+	env := []string{
+		"KUKEON_HOOK=" + phase,
+		"KUKEON_CELL=" + cell.Metadata.Name,
+		"KUKEON_CELL_ID=" + cell.Spec.ID,
+		"KUKEON_REALM=" + cell.Spec.RealmName,
+		"KUKEON_SPACE=" + cell.Spec.SpaceName,
+		"KUKEON_STACK=" + cell.Spec.StackName,
+	}
+
+	ctx, cancel := context.WithTimeout(r.ctx, timeout)
+	defer cancel()
+	run := r.hookRunFn
+	if run == nil {
+		run = runHookCommand
+	}
+	r.logger.DebugContext(r.ctx, "running cell lifecycle hook",
+		"cell", cell.Metadata.Name, "hook", phase, "command", command)
+	if err := run(ctx, command, env); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("timed out after %s", timeout)
+		}
+		return err
+	}
+	return nil
+}
+
+// runHookCommand executes command with /bin/sh -c on the host, adding env to
+// the daemon's environment. The command's combined output is quoted in the
+// error when it fails.
+func runHookCommand(ctx context.Context, command string, env []string) error {
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	cmd.Env = append(os.Environ(), env...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	// Don't wait on children that inherited the output pipe once the
+	// shell itself has been killed on timeout.
+	cmd.WaitDelay = time.Second
+	if err := cmd.Run(); err != nil {
+		output := strings.TrimSpace(out.String())
+		if len(output) > hookOutputLimit {
+			output = output[len(output)-hookOutputLimit:]
+		}
+		if output == "" {
+			return err
+		}
+		return fmt.Errorf("%w: %s", err, output)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // drives *Exec.StartCell / *Exec.StopCell with an in-package hook runner
+package runner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// hookFakeClient counts the containerd writes StartCell would make, so a
+// test can assert an aborted start touched nothing.
+type hookFakeClient struct {
+	*stopKillFakeClient
+
+	creates int64
+}
+
+func (c *hookFakeClient) CreateContainerFromSpec(
+	string, intmodel.ContainerSpec, []ctr.RegistryCredentials, ...ctr.BuildOption,
+) (containerd.Container, error) {
+	atomic.AddInt64(&c.creates, 1)
+	return nil, errors.New("unexpected CreateContainerFromSpec")
+}
+
+func (c *hookFakeClient) CreateContainer(
+	string, ctr.ContainerSpec, []ctr.RegistryCredentials,
+) (containerd.Container, error) {
+	atomic.AddInt64(&c.creates, 1)
+	return nil, errors.New("unexpected CreateContainer")
+}
+
+type hookCall struct {
+	command string
+	env     []string
+}
+
+// seedHookCell seeds the stop/kill fixture hierarchy and gives its cell the
+// lifecycle hooks, returning the request to start or stop it with.
+func seedHookCell(t *testing.T, r *Exec, lifecycle *intmodel.CellLifecycle) intmodel.Cell {
+	t.Helper()
+	realm, space, stack, cellName := "main", "apps", "web", "demo"
+	seedStopKillRealm(t, r, realm)
+	seedStopKillSpace(t, r, realm, space)
+	seedStopKillCell(t, r, realm, space, stack, cellName)
+
+	req := buildStopKillCellRequest(realm, space, stack, cellName)
+	cell, err := r.GetCell(req)
+	if err != nil {
+		t.Fatalf("GetCell: %v", err)
+	}
+	cell.Spec.Lifecycle = lifecycle
+	if err = r.UpdateCellMetadata(cell); err != nil {
+		t.Fatalf("UpdateCellMetadata: %v", err)
+	}
+	return req
+}
+
+// recordHooks makes r record each hook and fail the commands in failing.
+func recordHooks(r *Exec, failing ...string) *[]hookCall {
+	var calls []hookCall
+	r.hookRunFn = func(_ context.Context, command string, env []string) error {
+		calls = append(calls, hookCall{command: command, env: env})
+		if slices.Contains(failing, command) {
+			return errors.New("exit status 1")
+		}
+		return nil
+	}
+	return &calls
+}
+
+func commands(calls []hookCall) []string {
+	out := make([]string, 0, len(calls))
+	for _, c := range calls {
+		out = append(out, c.command)
+	}
+	return out
+}
+
+func TestStartCell_FailingPreStartHookAborts(t *testing.T) {
+	fake := &hookFakeClient{stopKillFakeClient: &stopKillFakeClient{}}
+	r := newStopKillTestExec(t, fake.stopKillFakeClient)
+	r.ctrClient = fake
+	req := seedHookCell(t, r, &intmodel.CellLifecycle{
+		PreStart: []string{"mount-prep", "check-quota", "never-runs"},
+		PostStop: []string{"unmount"},
+	})
+	calls := recordHooks(r, "check-quota")
+
+	_, err := r.StartCell(context.Background(), req)
+	if !errors.Is(err, errdefs.ErrCellHookFailed) {
+		t.Fatalf("StartCell error = %v, want ErrCellHookFailed", err)
+	}
+	if !strings.Contains(err.Error(), "preStart[1]") {
+		t.Errorf("error %q does not name the failing hook", err)
+	}
+	if got, want := commands(*calls), []string{"mount-prep", "check-quota"}; !slices.Equal(got, want) {
+		t.Errorf("hooks run = %v, want %v", got, want)
+	}
+	if n := atomic.LoadInt64(&fake.creates); n != 0 {
+		t.Errorf("StartCell created %d containers after a failed preStart hook, want 0", n)
+	}
+	if n := atomic.LoadInt64(&fake.stopContainerCalls); n != 0 {
+		t.Errorf("StartCell stopped %d containers after a failed preStart hook, want 0", n)
+	}
+	stored, getErr := r.GetCell(req)
+	if getErr != nil {
+		t.Fatalf("GetCell: %v", getErr)
+	}
+	if stored.Status.State == intmodel.CellStateFailed {
+		t.Error("a failed preStart hook marked the cell Failed; it should leave it untouched")
+	}
+}
+
+func TestStopCell_RunsPostStopHooksInOrder(t *testing.T) {
+	fake := &stopKillFakeClient{}
+	r := newStopKillTestExec(t, fake)
+	req := seedHookCell(t, r, &intmodel.CellLifecycle{
+		PreStart: []string{"mount-prep"},
+		PostStop: []string{"flush", "unmount", "notify"},
+	})
+	calls := recordHooks(r, "unmount")
+
+	if _, err := r.StopCell(req); err != nil {
+		t.Fatalf("StopCell: a failing postStop hook must not fail the stop, got %v", err)
+	}
+	if got, want := commands(*calls), []string{"flush", "unmount", "notify"}; !slices.Equal(got, want) {
+		t.Errorf("hooks run = %v, want %v", got, want)
+	}
+	if n := atomic.LoadInt64(&fake.stopContainerCalls); n == 0 {
+		t.Error("StopCell ran the hooks without stopping the containers")
+	}
+	for _, want := range []string{
+		"KUKEON_HOOK=postStop", "KUKEON_CELL=demo", "KUKEON_CELL_ID=demo",
+		"KUKEON_REALM=main", "KUKEON_SPACE=apps", "KUKEON_STACK=web",
+	} {
+		if !slices.Contains((*calls)[0].env, want) {
+			t.Errorf("hook env %v missing %q", (*calls)[0].env, want)
+		}
+	}
+}
+
+func TestRunHookCommand(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out")
+	err := runHookCommand(context.Background(), `echo "$KUKEON_CELL" > "$OUT"`, []string{"KUKEON_CELL=demo", "OUT=" + out})
+	if err != nil {
+		t.Fatalf("runHookCommand: %v", err)
+	}
+	if got, _ := os.ReadFile(out); string(got) != "demo\n" {
+		t.Errorf("hook wrote %q, want the cell name from its env", got)
+	}
+
+	err = runHookCommand(context.Background(), "echo no space left >&2; exit 3", nil)
+	if err == nil || !strings.Contains(err.Error(), "no space left") {
+		t.Errorf("runHookCommand error = %v, want the command's output", err)
+	}
+}
+
+func TestRunCellHook_Timeout(t *testing.T) {
+	r := newStopKillTestExec(t, &stopKillFakeClient{})
+	cell := intmodel.Cell{Spec: intmodel.CellSpec{Lifecycle: &intmodel.CellLifecycle{TimeoutSeconds: 1}}}
+
+	start := time.Now()
+	err := r.runCellHook(cell, hookPreStart, "sleep 30")
+	if err == nil || !strings.Contains(err.Error(), "timed out after 1s") {
+		t.Fatalf("runCellHook error = %v, want a timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("runCellHook took %s, want it bounded by the timeout", elapsed)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/consts"
+	"github.com/eminwux/kukeon/internal/controller/runner"
+	"github.com/eminwux/kukeon/internal/metadata"
+	"github.com/eminwux/kukeon/internal/util/fs"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+// TestListAllCells_WalksEveryRealmAndSkipsReservedSubdirs seeds cells across
+// two realms plus a cell-shaped document beneath a reserved blueprints/ subdir,
+// and asserts the all-scopes walk returns exactly the real cells — a reserved
+// subdir must never be read as a space.
+func TestListAllCells_WalksEveryRealmAndSkipsReservedSubdirs(t *testing.T) {
+	runPath := t.TempDir()
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	seedCell := func(path, realm, space, stack, cell string) {
+		t.Helper()
+		doc := v1beta1.CellDoc{
+			APIVersion: v1beta1.APIVersionV1Beta1,
+			Kind:       v1beta1.KindCell,
+			Metadata:   v1beta1.CellMetadata{Name: cell},
+			Spec: v1beta1.CellSpec{
+				ID:         cell,
+				RealmID:    realm,
+				SpaceID:    space,
+				StackID:    stack,
+				Containers: []v1beta1.ContainerSpec{{ID: cell + "-app", Image: "busybox"}},
+			},
+		}
+		if err := metadata.WriteMetadata(ctx, logger, doc, path); err != nil {
+			t.Fatalf("seed cell %s: %v", path, err)
+		}
+	}
+	seedCell(fs.CellMetadataPath(runPath, "alpha", "s1", "st1", "web"), "alpha", "s1", "st1", "web")
+	seedCell(fs.CellMetadataPath(runPath, "alpha", "s1", "st2", "db"), "alpha", "s1", "st2", "db")
+	seedCell(fs.CellMetadataPath(runPath, "beta", "s2", "st3", "cache"), "beta", "s2", "st3", "cache")
+
+	// A document shaped like a cell under <realm>/blueprints/ — only a walker
+	// that mistakes the reserved subdir for a space would find it.
+	phantom := filepath.Join(fs.RealmMetadataDir(runPath, "beta"), consts.KukeonBlueprintsSubdir,
+		"st", "phantom", consts.KukeonMetadataFile)
+	seedCell(phantom, "beta", consts.KukeonBlueprintsSubdir, "st", "phantom")
+
+	r := runner.NewRunner(ctx, logger, runner.Options{RunPath: runPath})
+
+	cells, err := r.ListAllCells()
+	if err != nil {
+		t.Fatalf("ListAllCells: %v", err)
+	}
+	got := make([]string, 0, len(cells))
+	for _, c := range cells {
+		got = append(got, c.Spec.RealmName+"/"+c.Spec.SpaceName+"/"+c.Spec.StackName+"/"+c.Metadata.Name)
+	}
+	slices.Sort(got)
+	want := []string{"alpha/s1/st1/web", "alpha/s1/st2/db", "beta/s2/st3/cache"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("ListAllCells = %v, want %v", got, want)
+	}
+
+	containers, err := r.ListAllContainers()
+	if err != nil {
+		t.Fatalf("ListAllContainers: %v", err)
+	}
+	ids := make([]string, 0, len(containers))
+	for _, c := range containers {
+		ids = append(ids, c.ID)
+	}
+	slices.Sort(ids)
+	if wantIDs := []string{"cache-app", "db-app", "web-app"}; !slices.Equal(ids, wantIDs) {
+		t.Fatalf("ListAllContainers IDs = %v, want %v", ids, wantIDs)
+	}
+}
+
+// TestListAllCells_EmptyStore returns no cells and no error when the metadata
+// root does not exist yet.
+func TestListAllCells_EmptyStore(t *testing.T) {
+	r := runner.NewRunner(context.Background(), slog.New(slog.NewTextHandler(os.Stderr, nil)),
+		runner.Options{RunPath: t.TempDir()})
+	cells, err := r.ListAllCells()
+	if err != nil {
+		t.Fatalf("ListAllCells: %v", err)
+	}
+	if len(cells) != 0 {
+		t.Fatalf("ListAllCells = %v, want none", cells)
+	}
+}
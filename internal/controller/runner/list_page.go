@@ -0,0 +1,185 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/util/fs"
+)
+
+// containerListPosition is the decoded form of a container-list continue
+// token: the full coordinates of the last container a page returned. The
+// next page resumes strictly after it.
+type containerListPosition struct {
+	Realm     string `json:"r"`
+	Space     string `json:"s"`
+	Stack     string `json:"t"`
+	Cell      string `json:"c"`
+	Container string `json:"n"`
+}
+
+func (p containerListPosition) encode() string {
+	raw, _ := json.Marshal(p)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeContainerListPosition(token string) (containerListPosition, error) {
+	var pos containerListPosition
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return pos, fmt.Errorf("%w: %w", errdefs.ErrInvalidContinueToken, err)
+	}
+	if err = json.Unmarshal(raw, &pos); err != nil {
+		return pos, fmt.Errorf("%w: %w", errdefs.ErrInvalidContinueToken, err)
+	}
+	return pos, nil
+}
+
+// ListContainersPage returns up to limit containers matching the scope
+// filters (an empty filter matches every name at that level), starting after
+// the position continueToken encodes, plus the token for the next page ("" when
+// the listing is complete). Containers come in realm, space, stack, cell,
+// container-ID order, so concatenating every page yields each container once
+// even across calls. The metadata tree is walked incrementally: cell
+// documents before the token are never read and the walk stops as soon as
+// the page is full, so memory stays bounded by limit rather than the store
+// size. limit <= 0 returns everything in one page. A cell whose metadata
+// cannot be read is skipped, matching ListAllCells.
+func (r *Exec) ListContainersPage(
+	realmName, spaceName, stackName, cellName string,
+	limit int,
+	continueToken string,
+) ([]intmodel.ContainerSpec, string, error) {
+	var after *containerListPosition
+	if continueToken = strings.TrimSpace(continueToken); continueToken != "" {
+		pos, err := decodeContainerListPosition(continueToken)
+		if err != nil {
+			return nil, "", err
+		}
+		after = &pos
+	}
+	// afterCell is compared with slices.Compare, which orders scope-name
+	// tuples the way os.ReadDir (and so childScopeNames) yields entries.
+	var afterCell []string
+	if after != nil {
+		afterCell = []string{after.Realm, after.Space, after.Stack, after.Cell}
+	}
+
+	var (
+		items     []intmodel.ContainerSpec
+		positions []containerListPosition
+	)
+	// The walk stops once the page holds limit+1 items: the extra one only
+	// proves another page exists and is trimmed before returning.
+	full := func() bool { return limit > 0 && len(items) > limit }
+	visitCell := func(realm, space, stack, cell string) {
+		coords := []string{realm, space, stack, cell}
+		cmp := 1
+		if afterCell != nil {
+			cmp = slices.Compare(coords, afterCell)
+		}
+		if cmp < 0 {
+			return
+		}
+		metadataPath := fs.CellMetadataPath(r.opts.RunPath, realm, space, stack, cell)
+		internalCell, readErr := r.readCellInternal(metadataPath)
+		if readErr != nil {
+			r.logger.DebugContext(r.ctx, "skipping cell metadata file", "path", metadataPath, "error", readErr)
+			return
+		}
+		containers := r.ExtractContainersFromCells([]intmodel.Cell{internalCell})
+		slices.SortStableFunc(containers, func(a, b intmodel.ContainerSpec) int {
+			return strings.Compare(a.ID, b.ID)
+		})
+		for _, c := range containers {
+			if cmp == 0 && c.ID <= after.Container {
+				continue
+			}
+			// Backfill scope from the walk so every row is addressable even
+			// when the cell document left the per-container copies unset.
+			if c.RealmName == "" {
+				c.RealmName = realm
+			}
+			if c.SpaceName == "" {
+				c.SpaceName = space
+			}
+			if c.StackName == "" {
+				c.StackName = stack
+			}
+			if c.CellName == "" {
+				c.CellName = cell
+			}
+			items = append(items, c)
+			positions = append(positions, containerListPosition{
+				Realm: realm, Space: space, Stack: stack, Cell: cell, Container: c.ID,
+			})
+			if full() {
+				return
+			}
+		}
+	}
+
+	base := fs.MetadataRoot(r.opts.RunPath)
+	realmNames, err := r.childScopeNames(base, realmName)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, realm := range realmNames {
+		if afterCell != nil && realm < after.Realm {
+			continue
+		}
+		realmDir := filepath.Join(base, realm)
+		spaceNames, spaceErr := r.childScopeNames(realmDir, spaceName)
+		if spaceErr != nil {
+			return nil, "", spaceErr
+		}
+		for _, space := range spaceNames {
+			if afterCell != nil && slices.Compare([]string{realm, space}, afterCell[:2]) < 0 {
+				continue
+			}
+			spaceDir := filepath.Join(realmDir, space)
+			stackNames, stackErr := r.childScopeNames(spaceDir, stackName)
+			if stackErr != nil {
+				return nil, "", stackErr
+			}
+			for _, stack := range stackNames {
+				if afterCell != nil && slices.Compare([]string{realm, space, stack}, afterCell[:3]) < 0 {
+					continue
+				}
+				cellNames, cellErr := r.childScopeNames(filepath.Join(spaceDir, stack), cellName)
+				if cellErr != nil {
+					return nil, "", cellErr
+				}
+				for _, cell := range cellNames {
+					visitCell(realm, space, stack, cell)
+					if full() {
+						return items[:limit], positions[limit-1].encode(), nil
+					}
+				}
+			}
+		}
+	}
+	return items, "", nil
+}
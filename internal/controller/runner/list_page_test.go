@@ -0,0 +1,167 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/controller/runner"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/metadata"
+	"github.com/eminwux/kukeon/internal/util/fs"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+// seedPagedStore writes cellsPerStack cells with containersPerCell containers
+// each into two realms, two spaces, and two stacks, returning every
+// container's "realm/space/stack/cell/container" key.
+func seedPagedStore(t *testing.T, runPath string, cellsPerStack, containersPerCell int) []string {
+	t.Helper()
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	var keys []string
+	for _, realm := range []string{"alpha", "beta"} {
+		for _, space := range []string{"s1", "s2"} {
+			for _, stack := range []string{"st1", "st2"} {
+				for c := range cellsPerStack {
+					cell := fmt.Sprintf("cell-%03d", c)
+					containers := make([]v1beta1.ContainerSpec, 0, containersPerCell)
+					// Declared in reverse so the page order cannot lean on
+					// spec order.
+					for n := containersPerCell - 1; n >= 0; n-- {
+						id := fmt.Sprintf("ctr-%d", n)
+						containers = append(containers, v1beta1.ContainerSpec{ID: id, Image: "busybox"})
+						keys = append(keys, realm+"/"+space+"/"+stack+"/"+cell+"/"+id)
+					}
+					doc := v1beta1.CellDoc{
+						APIVersion: v1beta1.APIVersionV1Beta1,
+						Kind:       v1beta1.KindCell,
+						Metadata:   v1beta1.CellMetadata{Name: cell},
+						Spec: v1beta1.CellSpec{
+							ID:         cell,
+							RealmID:    realm,
+							SpaceID:    space,
+							StackID:    stack,
+							Containers: containers,
+						},
+					}
+					path := fs.CellMetadataPath(runPath, realm, space, stack, cell)
+					if err := metadata.WriteMetadata(ctx, logger, doc, path); err != nil {
+						t.Fatalf("seed cell %s: %v", path, err)
+					}
+				}
+			}
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// TestListContainersPage_ReturnsEveryContainerOnceAcrossPages pages through a
+// store of 1200 containers with page sizes that do and do not divide the
+// total (and do not align with cell boundaries), asserting the concatenated
+// pages hold every container exactly once, in order, with no page over the
+// limit and a final empty continue token.
+func TestListContainersPage_ReturnsEveryContainerOnceAcrossPages(t *testing.T) {
+	runPath := t.TempDir()
+	want := seedPagedStore(t, runPath, 50, 3)
+	r := runner.NewRunner(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)),
+		runner.Options{RunPath: runPath})
+
+	for _, limit := range []int{1, 7, 100, 1200, 5000} {
+		t.Run(fmt.Sprintf("limit=%d", limit), func(t *testing.T) {
+			var (
+				got   []string
+				token string
+				pages int
+			)
+			for {
+				items, next, err := r.ListContainersPage("", "", "", "", limit, token)
+				if err != nil {
+					t.Fatalf("ListContainersPage(page %d): %v", pages, err)
+				}
+				pages++
+				if len(items) > limit {
+					t.Fatalf("page %d holds %d items, over limit %d", pages, len(items), limit)
+				}
+				for _, c := range items {
+					got = append(got, c.RealmName+"/"+c.SpaceName+"/"+c.StackName+"/"+c.CellName+"/"+c.ID)
+				}
+				if next == "" {
+					break
+				}
+				if len(items) == 0 {
+					t.Fatalf("page %d is empty but returned continue token %q", pages, next)
+				}
+				token = next
+			}
+			if !slices.Equal(got, want) {
+				t.Fatalf("paged listing returned %d keys (want %d); first mismatch at %d",
+					len(got), len(want), firstMismatch(got, want))
+			}
+			if wantPages := (len(want) + limit - 1) / limit; pages != wantPages {
+				t.Errorf("pages = %d, want %d", pages, wantPages)
+			}
+		})
+	}
+}
+
+func TestListContainersPage_ScopeFilterAndNoLimit(t *testing.T) {
+	runPath := t.TempDir()
+	seedPagedStore(t, runPath, 4, 2)
+	r := runner.NewRunner(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)),
+		runner.Options{RunPath: runPath})
+
+	items, next, err := r.ListContainersPage("beta", "s2", "", "", 0, "")
+	if err != nil {
+		t.Fatalf("ListContainersPage: %v", err)
+	}
+	if next != "" {
+		t.Errorf("continue = %q with no limit, want empty", next)
+	}
+	if len(items) != 2*4*2 {
+		t.Fatalf("got %d containers, want %d", len(items), 2*4*2)
+	}
+	for _, c := range items {
+		if c.RealmName != "beta" || c.SpaceName != "s2" {
+			t.Fatalf("container %s outside the beta/s2 filter: %s/%s", c.ID, c.RealmName, c.SpaceName)
+		}
+	}
+}
+
+func TestListContainersPage_RejectsMalformedToken(t *testing.T) {
+	r := runner.NewRunner(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)),
+		runner.Options{RunPath: t.TempDir()})
+	if _, _, err := r.ListContainersPage("", "", "", "", 10, "not base64!"); !errors.Is(err, errdefs.ErrInvalidContinueToken) {
+		t.Fatalf("err = %v, want ErrInvalidContinueToken", err)
+	}
+}
+
+func firstMismatch(a, b []string) int {
+	for i := range min(len(a), len(b)) {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return min(len(a), len(b))
+}
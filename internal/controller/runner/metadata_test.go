@@ -26,7 +26,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/eminwux/kukeon/internal/apischeme"
+	"github.com/eminwux/kukeon/internal/metadata"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/util/fs"
 )
 
 // newMetadataTestExec builds a minimal *Exec wired with a frozen clock
@@ -343,6 +346,67 @@ func TestUpdateRealmMetadataPersistsLifecycleFields(t *testing.T) {
 	})
 }
 
+// TestUpdateSpaceMetadataBackfillsLegacyTimestamps covers metadata
+// written before CreatedAt/UpdatedAt existed: the legacy document reads
+// back with zero timestamps (rendered as unknown), and the next write
+// backfills CreatedAt once and bumps UpdatedAt on every later write.
+func TestUpdateSpaceMetadataBackfillsLegacyTimestamps(t *testing.T) {
+	t0 := time.Date(2026, 5, 11, 12, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+	runPath := t.TempDir()
+	ref := intmodel.Space{
+		Metadata: intmodel.SpaceMetadata{Name: "s-legacy"},
+		Spec:     intmodel.SpaceSpec{RealmName: "r-legacy"},
+	}
+
+	legacy := ref
+	legacy.Status = intmodel.SpaceStatus{State: intmodel.SpaceStateReady}
+	doc, err := apischeme.BuildSpaceExternalFromInternal(legacy, apischeme.VersionV1Beta1)
+	if err != nil {
+		t.Fatalf("BuildSpaceExternalFromInternal: %v", err)
+	}
+	file := fs.SpaceMetadataPath(runPath, "r-legacy", "s-legacy")
+	if err = metadata.WriteMetadata(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), doc, file); err != nil {
+		t.Fatalf("WriteMetadata: %v", err)
+	}
+
+	r0 := newMetadataTestExec(t, runPath, t0)
+	got, err := r0.GetSpace(ref)
+	if err != nil {
+		t.Fatalf("GetSpace legacy: %v", err)
+	}
+	if !got.Status.CreatedAt.IsZero() || !got.Status.UpdatedAt.IsZero() {
+		t.Fatalf("legacy timestamps = %v/%v, want zero", got.Status.CreatedAt, got.Status.UpdatedAt)
+	}
+
+	if err = r0.UpdateSpaceMetadata(got); err != nil {
+		t.Fatalf("UpdateSpaceMetadata backfill: %v", err)
+	}
+	backfilled, err := r0.GetSpace(ref)
+	if err != nil {
+		t.Fatalf("GetSpace after backfill: %v", err)
+	}
+	if !backfilled.Status.CreatedAt.Equal(t0) || !backfilled.Status.UpdatedAt.Equal(t0) {
+		t.Errorf("backfill = %v/%v, want %v/%v",
+			backfilled.Status.CreatedAt, backfilled.Status.UpdatedAt, t0, t0)
+	}
+
+	r1 := newMetadataTestExec(t, runPath, t1)
+	if err = r1.UpdateSpaceMetadata(backfilled); err != nil {
+		t.Fatalf("UpdateSpaceMetadata bump: %v", err)
+	}
+	bumped, err := r1.GetSpace(ref)
+	if err != nil {
+		t.Fatalf("GetSpace after bump: %v", err)
+	}
+	if !bumped.Status.CreatedAt.Equal(t0) {
+		t.Errorf("CreatedAt moved: got %v, want %v", bumped.Status.CreatedAt, t0)
+	}
+	if !bumped.Status.UpdatedAt.Equal(t1) {
+		t.Errorf("UpdatedAt = %v, want %v", bumped.Status.UpdatedAt, t1)
+	}
+}
+
 // TestCarryRealmLifecycle covers the helper the refresh path uses to
 // carry set-once timestamps + Reason/Message through the locally-built
 // newStatus that drops everything not derived from a probe. Without
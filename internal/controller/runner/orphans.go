@@ -0,0 +1,204 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/containerd/containerd/v2/pkg/namespaces"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/util/fs"
+	"github.com/eminwux/kukeon/internal/util/naming"
+)
+
+// OrphanContainer is a kukeon-labeled containerd container in a realm's
+// namespace whose owning cell has no metadata, e.g. one left behind by a
+// purge that crashed between removing the cell metadata and tearing down
+// containerd. The coordinates come from the container's `kukeon.io/*`
+// labels.
+type OrphanContainer struct {
+	ContainerdID string
+	Space        string
+	Stack        string
+	Cell         string
+}
+
+// FindOrphanContainers lists the containers in the realm's containerd
+// namespace that carry the `kukeon.io/*` identity labels stamped by
+// buildRootContainerLabels / the container spec builder and that no cell in
+// the realm's metadata owns. Containers without the labels, or labeled for
+// a different realm, are never reported: they are not kukeon's to judge. A
+// cell whose metadata directory exists but whose document cannot be read
+// still counts as an owner, so a corrupt file never turns a live cell's
+// containers into purge candidates.
+func (r *Exec) FindOrphanContainers(realm intmodel.Realm) ([]OrphanContainer, error) {
+	realmName := realm.Metadata.Name
+	namespace := realm.Spec.Namespace
+
+	cells, err := r.ListCells(realmName, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errdefs.ErrGetCell, err)
+	}
+	owned := make(map[[3]string]struct{}, len(cells)*2)
+	for _, cell := range cells {
+		owned[[3]string{cell.Spec.SpaceName, cell.Spec.StackName, cell.Spec.ID}] = struct{}{}
+		owned[[3]string{cell.Spec.SpaceName, cell.Spec.StackName, cell.Metadata.Name}] = struct{}{}
+	}
+
+	if err = r.ensureClientConnected(); err != nil {
+		return nil, fmt.Errorf("%w: %w", errdefs.ErrConnectContainerd, err)
+	}
+	containers, err := r.ctrClient.ListContainers(namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	nsCtx := namespaces.WithNamespace(r.ctx, namespace)
+	var orphans []OrphanContainer
+	for _, container := range containers {
+		labels, labelErr := container.Labels(nsCtx)
+		if labelErr != nil {
+			r.logger.WarnContext(r.ctx, "failed to read container labels, skipping",
+				"container", container.ID(), "error", labelErr)
+			continue
+		}
+		if labels["kukeon.io/realm"] != realmName {
+			continue
+		}
+		space, stack, cell := labels["kukeon.io/space"], labels["kukeon.io/stack"], labels["kukeon.io/cell"]
+		if space == "" || stack == "" || cell == "" {
+			continue
+		}
+		if _, ok := owned[[3]string{space, stack, cell}]; ok {
+			continue
+		}
+		if _, statErr := os.Stat(fs.CellMetadataDir(r.opts.RunPath, realmName, space, stack, cell)); statErr == nil {
+			continue
+		}
+		orphans = append(orphans, OrphanContainer{
+			ContainerdID: container.ID(),
+			Space:        space,
+			Stack:        stack,
+			Cell:         cell,
+		})
+	}
+
+	sort.Slice(orphans, func(i, j int) bool {
+		return orphans[i].ContainerdID < orphans[j].ContainerdID
+	})
+	return orphans, nil
+}
+
+// DeleteOrphanContainer stops and deletes one container reported by
+// FindOrphanContainers, releasing its CNI allocation on the orphan's space
+// network. A container that is already gone is not an error.
+func (r *Exec) DeleteOrphanContainer(realm intmodel.Realm, orphan OrphanContainer) error {
+	if err := r.ensureClientConnected(); err != nil {
+		return fmt.Errorf("%w: %w", errdefs.ErrConnectContainerd, err)
+	}
+	networkName, err := naming.BuildSpaceNetworkName(realm.Metadata.Name, orphan.Space)
+	if err != nil {
+		networkName = ""
+	}
+	return r.stopAndDeleteContainer(realm.Spec.Namespace, orphan.ContainerdID, networkName, true)
+}
+
+// PruneCellContainers stops and deletes the workload containers in the
+// cell's realm namespace that carry the cell's `kukeon.io/*` labels but that
+// no container in cell.Spec.Containers accounts for, e.g. one that was
+// dropped from the spec while its containerd delete failed. The root
+// container is never pruned: only containers labeled
+// `kukeon.io/container-type=container` qualify, and the cell's deterministic
+// root ID is excluded on top of that. It returns the containerd IDs it
+// removed, sorted, and the joined teardown failures of the rest.
+func (r *Exec) PruneCellContainers(cell intmodel.Cell) ([]string, error) {
+	realmName := cell.Spec.RealmName
+	spaceName := cell.Spec.SpaceName
+	stackName := cell.Spec.StackName
+	cellID := cell.Spec.ID
+	if cellID == "" {
+		cellID = cell.Metadata.Name
+	}
+
+	realm, err := r.GetRealm(intmodel.Realm{Metadata: intmodel.RealmMetadata{Name: realmName}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get realm: %w", err)
+	}
+	namespace := realm.Spec.Namespace
+
+	keep := make(map[string]struct{}, len(cell.Spec.Containers)*2+1)
+	if rootID, rootErr := naming.BuildRootContainerdID(spaceName, stackName, cellID); rootErr == nil {
+		keep[rootID] = struct{}{}
+	}
+	for _, container := range cell.Spec.Containers {
+		if container.ContainerdID != "" {
+			keep[container.ContainerdID] = struct{}{}
+		}
+		if id, idErr := naming.BuildContainerdID(spaceName, stackName, cellID, container.ID); idErr == nil {
+			keep[id] = struct{}{}
+		}
+	}
+
+	if err = r.ensureClientConnected(); err != nil {
+		return nil, fmt.Errorf("%w: %w", errdefs.ErrConnectContainerd, err)
+	}
+	containers, err := r.ctrClient.ListContainers(namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	nsCtx := namespaces.WithNamespace(r.ctx, namespace)
+	var undeclared []string
+	for _, container := range containers {
+		if _, ok := keep[container.ID()]; ok {
+			continue
+		}
+		labels, labelErr := container.Labels(nsCtx)
+		if labelErr != nil {
+			r.logger.WarnContext(r.ctx, "failed to read container labels, skipping",
+				"container", container.ID(), "error", labelErr)
+			continue
+		}
+		if labels["kukeon.io/container-type"] != "container" ||
+			labels["kukeon.io/realm"] != realmName ||
+			labels["kukeon.io/space"] != spaceName ||
+			labels["kukeon.io/stack"] != stackName ||
+			labels["kukeon.io/cell"] != cellID {
+			continue
+		}
+		undeclared = append(undeclared, container.ID())
+	}
+	sort.Strings(undeclared)
+
+	networkName := r.buildRootCNINetworkName(realmName, spaceName)
+	pruned := make([]string, 0, len(undeclared))
+	var pruneErrors []error
+	for _, id := range undeclared {
+		r.logger.InfoContext(r.ctx, "pruning container not declared in cell spec",
+			"container", id, "cell", cellID, "namespace", namespace)
+		if delErr := r.stopAndDeleteContainer(namespace, id, networkName, false); delErr != nil {
+			pruneErrors = append(pruneErrors, fmt.Errorf("prune container %q: %w", id, delErr))
+			continue
+		}
+		pruned = append(pruned, id)
+	}
+	return pruned, errors.Join(pruneErrors...)
+}
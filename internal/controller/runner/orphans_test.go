@@ -0,0 +1,152 @@
+//go:build !integration
+
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // drives *Exec.FindOrphanContainers against an in-package ctr.Client fake
+package runner
+
+import (
+	"reflect"
+	"testing"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/eminwux/kukeon/internal/ctr"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+func orphanTestLabels(realm, space, stack, cell string) map[string]string {
+	return map[string]string{
+		"kukeon.io/realm": realm,
+		"kukeon.io/space": space,
+		"kukeon.io/stack": stack,
+		"kukeon.io/cell":  cell,
+	}
+}
+
+// TestFindOrphanContainers_ReportsOnlyUnownedKukeonContainers seeds one cell
+// with metadata and lists four containerd containers: that cell's root, a
+// kukeon-labeled container for a cell with no metadata, an unlabeled
+// container, and one labeled for another realm sharing the namespace. Only
+// the second is an orphan, and DeleteOrphanContainer removes it by ID.
+func TestFindOrphanContainers_ReportsOnlyUnownedKukeonContainers(t *testing.T) {
+	realmName, space, stack := "main", "app", "web"
+	namespace := realmName + ".kukeon.io"
+
+	var deleted []string
+	fake := &deleteCellFakeClient{
+		listContainersFn: func(ns string, _ ...string) ([]containerd.Container, error) {
+			if ns != namespace {
+				t.Errorf("ListContainers namespace = %q, want %q", ns, namespace)
+			}
+			return []containerd.Container{
+				stubLabeledContainer{
+					id:     "app_web_live_root",
+					labels: orphanTestLabels(realmName, space, stack, "live"),
+				},
+				stubLabeledContainer{
+					id:     "app_web_ghost_root",
+					labels: orphanTestLabels(realmName, space, stack, "ghost"),
+				},
+				stubLabeledContainer{id: "hand-made", labels: map[string]string{"owner": "ops"}},
+				stubLabeledContainer{
+					id:     "app_web_other_root",
+					labels: orphanTestLabels("other", space, stack, "other"),
+				},
+			}, nil
+		},
+		deleteContainerFn: func(_, id string, _ ctr.ContainerDeleteOptions) error {
+			deleted = append(deleted, id)
+			return nil
+		},
+	}
+	r := newDeleteCellTestExec(t, fake)
+	seedDeleteCellRealm(t, r, realmName)
+	seedDeleteCellCell(t, r, realmName, space, stack, "live")
+
+	realm := intmodel.Realm{
+		Metadata: intmodel.RealmMetadata{Name: realmName},
+		Spec:     intmodel.RealmSpec{Namespace: namespace},
+	}
+	orphans, err := r.FindOrphanContainers(realm)
+	if err != nil {
+		t.Fatalf("FindOrphanContainers: %v", err)
+	}
+	want := []OrphanContainer{
+		{ContainerdID: "app_web_ghost_root", Space: space, Stack: stack, Cell: "ghost"},
+	}
+	if !reflect.DeepEqual(orphans, want) {
+		t.Fatalf("FindOrphanContainers = %+v, want %+v", orphans, want)
+	}
+
+	if err = r.DeleteOrphanContainer(realm, orphans[0]); err != nil {
+		t.Fatalf("DeleteOrphanContainer: %v", err)
+	}
+	if !reflect.DeepEqual(deleted, []string{"app_web_ghost_root"}) {
+		t.Errorf("deleted containers = %v, want only the orphan", deleted)
+	}
+}
+
+// TestPruneCellContainers_DeletesOnlyUndeclaredWorkloads lists the cell's
+// root container, its declared workload, a workload dropped from the spec,
+// and a same-named workload of another cell. Only the dropped workload is
+// pruned; the root container is kept even though the spec omits it.
+func TestPruneCellContainers_DeletesOnlyUndeclaredWorkloads(t *testing.T) {
+	realmName, space, stack := "main", "app", "web"
+	workloadLabels := func(cell string) map[string]string {
+		labels := orphanTestLabels(realmName, space, stack, cell)
+		labels["kukeon.io/container-type"] = "container"
+		return labels
+	}
+	rootLabels := orphanTestLabels(realmName, space, stack, "live")
+	rootLabels["kukeon.io/container-type"] = "root"
+
+	var deleted []string
+	fake := &deleteCellFakeClient{
+		listContainersFn: func(string, ...string) ([]containerd.Container, error) {
+			return []containerd.Container{
+				stubLabeledContainer{id: "app_web_live_root", labels: rootLabels},
+				stubLabeledContainer{id: "app_web_live_workload", labels: workloadLabels("live")},
+				stubLabeledContainer{id: "app_web_live_sidecar", labels: workloadLabels("live")},
+				stubLabeledContainer{id: "app_web_other_sidecar", labels: workloadLabels("other")},
+			}, nil
+		},
+		deleteContainerFn: func(_, id string, _ ctr.ContainerDeleteOptions) error {
+			deleted = append(deleted, id)
+			return nil
+		},
+	}
+	r := newDeleteCellTestExec(t, fake)
+	seedDeleteCellRealm(t, r, realmName)
+
+	cell := intmodel.Cell{
+		Metadata: intmodel.CellMetadata{Name: "live"},
+		Spec: intmodel.CellSpec{
+			ID:         "live",
+			RealmName:  realmName,
+			SpaceName:  space,
+			StackName:  stack,
+			Containers: []intmodel.ContainerSpec{{ID: "workload"}},
+		},
+	}
+	pruned, err := r.PruneCellContainers(cell)
+	if err != nil {
+		t.Fatalf("PruneCellContainers: %v", err)
+	}
+	if want := []string{"app_web_live_sidecar"}; !reflect.DeepEqual(pruned, want) || !reflect.DeepEqual(deleted, want) {
+		t.Errorf("pruned = %v, deleted = %v; want only %v", pruned, deleted, want)
+	}
+}
@@ -0,0 +1,135 @@
+//go:build !integration
+
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // exercises unexported pause-image helpers against an in-package ctr.Client fake
+package runner
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// stageFakeKukepause drops an executable placeholder where
+// stageKukepauseBinary looks first, so building a default root spec never
+// has to find a real kukepause binary on the host.
+func stageFakeKukepause(t *testing.T, runPath string) {
+	t.Helper()
+	dir := filepath.Join(runPath, kukettyBinaryStagedSubdir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir staged bin dir: %v", err)
+	}
+	//nolint:gosec // the placeholder must be executable to count as staged
+	if err := os.WriteFile(filepath.Join(dir, ctr.RootContainerPauseBinaryName), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatalf("write staged kukepause: %v", err)
+	}
+}
+
+func TestEnsureCellRootContainerSpec_UsesRealmPauseImage(t *testing.T) {
+	tests := []struct {
+		name       string
+		pauseImage string
+		want       string
+	}{
+		{name: "default", pauseImage: "", want: ctr.DefaultRootContainerImage},
+		{name: "configured", pauseImage: "registry.example.com/pause:3.10", want: "registry.example.com/pause:3.10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newDeleteCellTestExec(t, &deleteCellFakeClient{})
+			stageFakeKukepause(t, r.opts.RunPath)
+			seedRecreateCellSpace(t, r, "r1", "s1")
+
+			realm := intmodel.Realm{
+				Metadata: intmodel.RealmMetadata{Name: "r1"},
+				Spec:     intmodel.RealmSpec{Namespace: "r1.kukeon.io", PauseImage: tt.pauseImage},
+			}
+			cell := intmodel.Cell{
+				Metadata: intmodel.CellMetadata{Name: "c1"},
+				Spec: intmodel.CellSpec{
+					ID:        "c1",
+					RealmName: "r1",
+					SpaceName: "s1",
+					StackName: "st1",
+				},
+			}
+
+			spec, err := r.ensureCellRootContainerSpec(realm, cell)
+			if err != nil {
+				t.Fatalf("ensureCellRootContainerSpec: %v", err)
+			}
+			if spec.Image != tt.want {
+				t.Errorf("root image = %q, want %q", spec.Image, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnsureRealmPauseImage(t *testing.T) {
+	realm := intmodel.Realm{
+		Metadata: intmodel.RealmMetadata{Name: "r1"},
+		Spec:     intmodel.RealmSpec{Namespace: "r1.kukeon.io", PauseImage: "registry.example.com/pause:3.10"},
+	}
+
+	t.Run("pulls into the realm namespace", func(t *testing.T) {
+		var gotNamespace, gotRef string
+		r := newDeleteCellTestExec(t, &deleteCellFakeClient{
+			pullImageFn: func(namespace, ref string, _ []ctr.RegistryCredentials) (ctr.ImageInfo, error) {
+				gotNamespace, gotRef = namespace, ref
+				return ctr.ImageInfo{Name: ref}, nil
+			},
+		})
+		if err := r.ensureRealmPauseImage(realm); err != nil {
+			t.Fatalf("ensureRealmPauseImage: %v", err)
+		}
+		if gotNamespace != "r1.kukeon.io" || gotRef != "registry.example.com/pause:3.10" {
+			t.Errorf("pulled %q into %q, want the pause image into the realm namespace", gotRef, gotNamespace)
+		}
+	})
+
+	t.Run("default image is not pulled up front", func(t *testing.T) {
+		r := newDeleteCellTestExec(t, &deleteCellFakeClient{
+			pullImageFn: func(string, string, []ctr.RegistryCredentials) (ctr.ImageInfo, error) {
+				t.Fatal("unexpected pull for a realm without a pause image")
+				return ctr.ImageInfo{}, nil
+			},
+		})
+		noPause := realm
+		noPause.Spec.PauseImage = ""
+		if err := r.ensureRealmPauseImage(noPause); err != nil {
+			t.Fatalf("ensureRealmPauseImage: %v", err)
+		}
+	})
+
+	t.Run("pull failure", func(t *testing.T) {
+		r := newDeleteCellTestExec(t, &deleteCellFakeClient{
+			pullImageFn: func(string, string, []ctr.RegistryCredentials) (ctr.ImageInfo, error) {
+				return ctr.ImageInfo{}, errors.New("not found")
+			},
+		})
+		err := r.ensureRealmPauseImage(realm)
+		if !errors.Is(err, errdefs.ErrPauseImageUnavailable) {
+			t.Fatalf("err = %v, want ErrPauseImageUnavailable", err)
+		}
+	})
+}
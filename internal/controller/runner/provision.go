@@ -17,6 +17,7 @@
 package runner
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -32,6 +33,9 @@ import (
 	"github.com/eminwux/kukeon/internal/errdefs"
 	"github.com/eminwux/kukeon/internal/metadata"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/netpolicy"
+	"github.com/eminwux/kukeon/internal/preflight"
+	"github.com/eminwux/kukeon/internal/tracing"
 	"github.com/eminwux/kukeon/internal/util/fs"
 	"github.com/eminwux/kukeon/internal/util/naming"
 )
@@ -59,32 +63,57 @@ func (r *Exec) provisionNewRealm(realm intmodel.Realm) (intmodel.Realm, error) {
 	if strings.TrimSpace(realm.Spec.Namespace) == "" {
 		realm.Spec.Namespace = consts.RealmNamespace(strings.TrimSpace(realm.Metadata.Name))
 	}
+	if err := naming.ValidateRealmNamespace(realm.Spec.Namespace); err != nil {
+		return intmodel.Realm{}, err
+	}
+	if err := r.ensureRealmNamespaceUnowned(realm); err != nil {
+		return intmodel.Realm{}, err
+	}
+	if err := r.preflightRealm(); err != nil {
+		return intmodel.Realm{}, err
+	}
 
 	// Update realm metadata with Creating state
 	if err := r.UpdateRealmMetadata(realm); err != nil {
 		return intmodel.Realm{}, fmt.Errorf("%w: %w", errdefs.ErrUpdateRealmMetadata, err)
 	}
 
+	// From here on every failure tears down what this call created before
+	// recording the realm as Failed, so a retry starts clean instead of
+	// tripping over a half-made namespace or cgroup.
+	var rb realmRollback
+	fail := func(err error) (intmodel.Realm, error) {
+		r.undo(&rb)
+		realm.Status.State = intmodel.RealmStateFailed
+		realm.Status.CgroupPath = ""
+		realm.Status.SubtreeControllers = nil
+		_ = r.UpdateRealmMetadata(realm) // Best effort to save failed state
+		return intmodel.Realm{}, err
+	}
+
 	// Create realm namespace. A pre-existing containerd namespace is benign on the
 	// idempotent kuke init path — kuke-system.kukeon.io is routinely seeded by
 	// `ctr -n kuke-system.kukeon.io images import` before init runs. Treat it as
 	// a no-op so we still proceed to cgroup creation and the Ready transition,
-	// matching the symmetry of ensureRealmContainerdNamespace.
-	if err := r.createRealmContainerdNamespace(realm); err != nil &&
-		!errors.Is(err, errdefs.ErrNamespaceAlreadyExists) {
-		// Set state to Failed and update metadata before returning error
-		realm.Status.State = intmodel.RealmStateFailed
-		_ = r.UpdateRealmMetadata(realm) // Best effort to save failed state
-		return intmodel.Realm{}, fmt.Errorf("%w: %w", errdefs.ErrCreateRealmNamespace, err)
+	// matching the symmetry of ensureRealmContainerdNamespace. Only a namespace
+	// this call created is rolled back.
+	switch err := r.createRealmContainerdNamespace(realm); {
+	case err == nil:
+		rb.namespaceCreated(realm.Spec.Namespace)
+	case !errors.Is(err, errdefs.ErrNamespaceAlreadyExists):
+		return fail(fmt.Errorf("%w: %w", errdefs.ErrCreateRealmNamespace, err))
+	}
+
+	// Pull a configured pause image now so a typo or an unreachable registry
+	// fails the realm instead of every cell created in it later.
+	if err := r.ensureRealmPauseImage(realm); err != nil {
+		return fail(err)
 	}
 
 	// Create realm cgroup
-	cgroupPath, subtreeControllers, err := r.createRealmCgroup(realm)
+	cgroupPath, subtreeControllers, err := r.createRealmCgroup(realm, &rb)
 	if err != nil {
-		// Set state to Failed and update metadata before returning error
-		realm.Status.State = intmodel.RealmStateFailed
-		_ = r.UpdateRealmMetadata(realm) // Best effort to save failed state
-		return intmodel.Realm{}, err
+		return fail(err)
 	}
 
 	// Update CgroupPath, SubtreeControllers (issue #328), and state in internal model
@@ -95,12 +124,71 @@ func (r *Exec) provisionNewRealm(realm intmodel.Realm) (intmodel.Realm, error) {
 	// Always update metadata after cgroup creation to ensure CgroupPath is saved
 	// (similar to ensureRealmCgroup pattern for consistency)
 	if err = r.UpdateRealmMetadata(realm); err != nil {
-		return intmodel.Realm{}, fmt.Errorf("%w: %w", errdefs.ErrUpdateRealmMetadata, err)
+		return fail(fmt.Errorf("%w: %w", errdefs.ErrUpdateRealmMetadata, err))
 	}
 
 	return realm, nil
 }
 
+// preflightRealm refuses an unwritable run path, an unreachable containerd,
+// or a cgroup v1 host before anything is persisted, rather than leaving a
+// half-provisioned realm (namespace made, cgroup failed) behind at the step
+// that trips over it. Every check runs; the failures come back as one
+// errdefs.ErrPreflightFailed so the operator sees them all at once.
+func (r *Exec) preflightRealm() error {
+	runPathErr := preflight.RunPathWritable(r.opts.RunPath)
+	connectErr := r.ensureClientConnected()
+	if connectErr != nil {
+		connectErr = fmt.Errorf("%w: %w", errdefs.ErrConnectContainerd, connectErr)
+	}
+	// The cgroup mode comes from /proc/self/mountinfo, not containerd, so
+	// it is checked even when the connect failed.
+	return preflight.Join(runPathErr, connectErr, r.requireCgroupV2())
+}
+
+// ensureRealmNamespaceUnowned rejects a new realm whose containerd namespace
+// is already recorded in another realm's metadata. Two realms sharing a
+// namespace would see each other's images and containers, and deleting one
+// would tear down the namespace under the other, so the first owner wins.
+// A namespace that exists in containerd but belongs to no realm (seeded by
+// `ctr images import`, or managed outside kukeon) is still adopted.
+func (r *Exec) ensureRealmNamespaceUnowned(realm intmodel.Realm) error {
+	realms, err := r.ListRealms()
+	if err != nil {
+		return fmt.Errorf("%w: %w", errdefs.ErrGetRealm, err)
+	}
+	for _, other := range realms {
+		if other.Metadata.Name == realm.Metadata.Name {
+			continue
+		}
+		if other.Spec.Namespace == realm.Spec.Namespace {
+			return fmt.Errorf("%w: namespace %q is used by realm %q",
+				errdefs.ErrRealmNamespaceInUse, realm.Spec.Namespace, other.Metadata.Name)
+		}
+	}
+	return nil
+}
+
+// ensureRealmPauseImage pulls the realm's configured pause image into its
+// containerd namespace with the realm's registry credentials. The pull is a
+// no-op once the image is present, so every cell root container in the
+// namespace reuses the one copy. Realms on the built-in default image pull it
+// lazily with their first cell, as before.
+func (r *Exec) ensureRealmPauseImage(realm intmodel.Realm) error {
+	image := strings.TrimSpace(realm.Spec.PauseImage)
+	if image == "" {
+		return nil
+	}
+	if err := r.ensureClientConnected(); err != nil {
+		return fmt.Errorf("%w: %w", errdefs.ErrConnectContainerd, err)
+	}
+	if _, err := r.ctrClient.PullImage(realm.Spec.Namespace, image, r.registryCredentials(realm)); err != nil {
+		return fmt.Errorf("%w: realm %q cannot use pause image %q: %w",
+			errdefs.ErrPauseImageUnavailable, realm.Metadata.Name, image, err)
+	}
+	return nil
+}
+
 func (r *Exec) createRealmContainerdNamespace(realm intmodel.Realm) error {
 	// Create realm containerd namespace
 	if err := r.ensureClientConnected(); err != nil {
@@ -230,7 +318,11 @@ func (r *Exec) ensureSpaceCNIConfig(space intmodel.Space) (intmodel.Space, error
 	if err != nil && !errors.Is(err, errdefs.ErrNetworkNotFound) {
 		return intmodel.Space{}, fmt.Errorf("%w: %w", errdefs.ErrCheckNetworkExists, err)
 	}
-	regenerate, regenReason, regenErr := r.shouldRegenerateSpaceCNI(mgr, space, networkName, confPath, exists)
+	ipv6Subnet, v6Err := r.spaceIPv6Subnet(space)
+	if v6Err != nil {
+		return intmodel.Space{}, fmt.Errorf("%w: %w", errdefs.ErrCreateNetwork, v6Err)
+	}
+	regenerate, regenReason, regenErr := r.shouldRegenerateSpaceCNI(mgr, networkName, confPath, ipv6Subnet, exists)
 	if regenErr != nil {
 		return intmodel.Space{}, regenErr
 	}
@@ -248,7 +340,7 @@ func (r *Exec) ensureSpaceCNIConfig(space intmodel.Space) (intmodel.Space, error
 		if subnetErr != nil {
 			return intmodel.Space{}, fmt.Errorf("%w: %w", errdefs.ErrCreateNetwork, subnetErr)
 		}
-		if writeErr := fs.WriteSpaceNetworkConfig(confPath, networkName, subnet); writeErr != nil {
+		if writeErr := fs.WriteSpaceNetworkConfig(confPath, networkName, subnet, ipv6Subnet); writeErr != nil {
 			return intmodel.Space{}, fmt.Errorf("%w: %w", errdefs.ErrCreateNetwork, writeErr)
 		}
 	}
@@ -302,9 +394,12 @@ func (r *Exec) ensureSpaceCNIConfig(space intmodel.Space) (intmodel.Space, error
 		"conf",
 		confPath,
 	)
-	// Re-apply egress policy on every Ensure so a daemon restart (or a
-	// manual `kuke apply` with a changed policy) converges the host
-	// firewall to the spec. iptables rules are idempotent via ensureRule.
+	// Re-apply isolation and egress policy on every Ensure so a daemon
+	// restart (or a manual `kuke apply` with a changed policy) converges the
+	// host firewall to the spec. iptables rules are idempotent via ensureRule.
+	if isoErr := r.ensureSpaceIsolation(mgr, space, confPath); isoErr != nil {
+		return intmodel.Space{}, isoErr
+	}
 	if policyErr := r.applySpaceEgressPolicy(space); policyErr != nil {
 		return intmodel.Space{}, policyErr
 	}
@@ -342,14 +437,63 @@ func (r *Exec) subnetForRegenerate(
 	return alloc.Allocate(space.Spec.RealmName, space.Metadata.Name)
 }
 
+// spaceIPv6Subnet returns the space's IPv6 subnet when the space is
+// dual-stack and "" otherwise. DualStack and IPv6Subnet must be set together,
+// the subnet must be an IPv6 CIDR, and it must not overlap the IPv6 subnet of
+// any other dual-stack space in the same realm. Isolation is only enforced
+// with iptables rules for the IPv4 subnet, so a dual-stack space must opt
+// out of it explicitly with the open policy rather than silently leak over
+// IPv6.
+func (r *Exec) spaceIPv6Subnet(space intmodel.Space) (string, error) {
+	network := space.Spec.Network
+	if network == nil || (!network.DualStack && strings.TrimSpace(network.IPv6Subnet) == "") {
+		return "", nil
+	}
+	if !network.DualStack || strings.TrimSpace(network.IPv6Subnet) == "" {
+		return "", fmt.Errorf("%w: space %q", errdefs.ErrDualStackConfig, space.Metadata.Name)
+	}
+	policy, err := netpolicy.ParseNetworkPolicy(network.Policy)
+	if err != nil {
+		return "", err
+	}
+	if policy == intmodel.NetworkPolicyIsolated {
+		return "", fmt.Errorf("%w: space %q", errdefs.ErrDualStackIsolated, space.Metadata.Name)
+	}
+	subnet, err := cni.ParseIPv6Subnet(network.IPv6Subnet)
+	if err != nil {
+		return "", err
+	}
+
+	spaces, err := r.ListSpaces(space.Spec.RealmName)
+	if err != nil {
+		return "", fmt.Errorf("failed to list spaces: %w", err)
+	}
+	for _, other := range spaces {
+		if other.Metadata.Name == space.Metadata.Name || other.Spec.Network == nil ||
+			!other.Spec.Network.DualStack {
+			continue
+		}
+		otherSubnet, parseErr := cni.ParseIPv6Subnet(other.Spec.Network.IPv6Subnet)
+		if parseErr != nil {
+			continue
+		}
+		if cni.SubnetsOverlap(subnet, otherSubnet) {
+			return "", fmt.Errorf("%w: %s overlaps space %q (%s)",
+				errdefs.ErrSubnetOverlap, subnet, other.Metadata.Name, otherSubnet)
+		}
+	}
+	return subnet.String(), nil
+}
+
 // shouldRegenerateSpaceCNI decides whether ensureSpaceCNIConfig needs to
 // rewrite the on-disk conflist. Returns (regenerate, humanReason, fatalErr).
 // The reason is empty when the decision is the trivial "file missing" case so
-// callers can suppress logging on first-time provision.
+// callers can suppress logging on first-time provision. ipv6Subnet is the
+// spec's validated IPv6 subnet ("" for IPv4-only spaces); a conflist whose
+// IPv6 range disagrees with it is regenerated so toggling dual-stack converges.
 func (r *Exec) shouldRegenerateSpaceCNI(
 	mgr *cni.Manager,
-	space intmodel.Space,
-	networkName, confPath string,
+	networkName, confPath, ipv6Subnet string,
 	exists bool,
 ) (bool, string, error) {
 	if !exists {
@@ -368,6 +512,20 @@ func (r *Exec) shouldRegenerateSpaceCNI(
 				onDiskBridge, expected,
 			), nil
 		}
+		hasBandwidth, bwErr := mgr.HasBandwidthPlugin(confPath)
+		if bwErr != nil {
+			return false, "", fmt.Errorf("%w: %w", errdefs.ErrCheckNetworkExists, bwErr)
+		}
+		if !hasBandwidth {
+			return true, "bandwidth plugin missing from conflist", nil
+		}
+		onDiskV6, v6Err := mgr.ReadIPv6SubnetCIDR(confPath)
+		if v6Err != nil {
+			return false, "", fmt.Errorf("%w: %w", errdefs.ErrCheckNetworkExists, v6Err)
+		}
+		if onDiskV6 != ipv6Subnet {
+			return true, fmt.Sprintf("IPv6 subnet %q does not match spec %q", onDiskV6, ipv6Subnet), nil
+		}
 		return false, "", nil
 	case errors.Is(readErr, errdefs.ErrBridgePluginMissing):
 		return true, "bridge plugin missing from conflist", nil
@@ -409,16 +567,26 @@ func (r *Exec) createSpaceCNIConfig(space intmodel.Space) (string, error) {
 		return "", errdefs.ErrNetworkAlreadyExists
 	}
 
+	ipv6Subnet, v6Err := r.spaceIPv6Subnet(space)
+	if v6Err != nil {
+		return "", fmt.Errorf("%w: %w", errdefs.ErrCreateNetwork, v6Err)
+	}
+
 	subnet, allocErr := r.subnetAllocator.Allocate(space.Spec.RealmName, space.Metadata.Name)
 	if allocErr != nil {
 		return "", fmt.Errorf("%w: %w", errdefs.ErrCreateNetwork, allocErr)
 	}
 
 	fmt.Fprintf(os.Stdout, "Creating space network '%s'\n", networkName)
-	if writeErr := fs.WriteSpaceNetworkConfig(confPath, networkName, subnet); writeErr != nil {
+	if writeErr := fs.WriteSpaceNetworkConfig(confPath, networkName, subnet, ipv6Subnet); writeErr != nil {
 		r.logger.InfoContext(r.ctx, "failed to create space network", "err", fmt.Sprintf("%v", writeErr))
 		return "", fmt.Errorf("%w: %w", errdefs.ErrCreateNetwork, writeErr)
 	}
+	// Like the egress chain, the isolation rules can be installed before the
+	// bridge exists; they match nothing until the first cell gets an address.
+	if isoErr := r.applySpaceIsolation(space, subnet); isoErr != nil {
+		return "", isoErr
+	}
 	r.logger.InfoContext(
 		r.ctx,
 		"created space network",
@@ -430,6 +598,8 @@ func (r *Exec) createSpaceCNIConfig(space intmodel.Space) (string, error) {
 		confPath,
 		"subnet",
 		subnet,
+		"ipv6Subnet",
+		ipv6Subnet,
 	)
 	return confPath, nil
 }
@@ -443,6 +613,9 @@ func (r *Exec) EnsureKukeonRootCgroup() (bool, bool, error) {
 	if err := r.ensureClientConnected(); err != nil {
 		return false, false, fmt.Errorf("%w: %w", errdefs.ErrConnectContainerd, err)
 	}
+	if err := r.requireCgroupV2(); err != nil {
+		return false, false, err
+	}
 
 	mountpoint := r.ctrClient.GetCgroupMountpoint()
 	group := consts.KukeonCgroupRoot
@@ -460,10 +633,31 @@ func (r *Exec) EnsureKukeonRootCgroup() (bool, bool, error) {
 	return false, true, nil
 }
 
+// requireCgroupV2 fails with ErrCgroupV1Unsupported on a legacy or hybrid
+// cgroup host. Every path kukeon builds assumes the unified hierarchy, so on
+// v1 it would otherwise create directories that no controller honours. An
+// undetectable mode is let through so discovery quirks keep the existing
+// mountpoint fallback.
+func (r *Exec) requireCgroupV2() error {
+	mode := r.ctrClient.GetCgroupMode()
+	if mode != ctr.CgroupModeLegacy && mode != ctr.CgroupModeHybrid {
+		return nil
+	}
+	return fmt.Errorf(
+		"%w: host cgroup mode is %s; boot with systemd.unified_cgroup_hierarchy=1 "+
+			"(and without cgroup_no_v1) to enable cgroup v2",
+		errdefs.ErrCgroupV1Unsupported,
+		mode,
+	)
+}
+
 // buildCgroupPath discovers the cgroup mountpoint and current process cgroup path,
 // then combines the spec's Group path relative to the current process's cgroup.
 // Returns the updated spec with Group and Mountpoint set, and the full filesystem path.
 func (r *Exec) buildCgroupPath(spec ctr.CgroupSpec) (ctr.CgroupSpec, string, error) {
+	if err := r.requireCgroupV2(); err != nil {
+		return spec, "", err
+	}
 	mountpoint := r.ctrClient.GetCgroupMountpoint()
 	currentCgroupPath, err := r.ctrClient.GetCurrentCgroupPath()
 	if err != nil {
@@ -502,6 +696,11 @@ func (r *Exec) createCgroupInternal(spec ctr.CgroupSpec) (string, error) {
 
 // ensureCgroupInternal handles the common ensure cgroup logic: checking existence,
 // creating if missing, verifying creation, and backfilling metadata if needed.
+// A stored path that no longer matches the computed one (e.g. the host's
+// cgroup layout changed) is repaired to the computed path, but only when the
+// stored path's filesystem location is gone: two processes sitting in
+// different cgroups compute different paths, and relocating a live cgroup
+// on every mismatch would flap the metadata between them.
 func (r *Exec) ensureCgroupInternal(params ensureCgroupParams) error {
 	// Build the cgroup path
 	spec, fullCgroupPath, err := r.buildCgroupPath(params.spec)
@@ -509,6 +708,22 @@ func (r *Exec) ensureCgroupInternal(params ensureCgroupParams) error {
 		return err
 	}
 
+	storedPath := *params.cgroupPath
+	stale := storedPath != "" && storedPath != spec.Group
+	if stale && cgroupLocationExists(spec.Mountpoint, storedPath) {
+		r.logger.WarnContext(
+			r.ctx,
+			fmt.Sprintf("stored %s cgroup path differs from computed path but still exists, keeping it", params.logLabel),
+			params.logLabel,
+			params.docName,
+			"metadata_cgroup_path",
+			storedPath,
+			"cgroup_path",
+			spec.Group,
+		)
+		return nil
+	}
+
 	r.logger.DebugContext(
 		r.ctx,
 		fmt.Sprintf("checking if %s cgroup exists", params.logLabel),
@@ -627,6 +842,19 @@ func (r *Exec) ensureCgroupInternal(params ensureCgroupParams) error {
 			)
 			return fmt.Errorf("%w: %w", params.updateErr, err)
 		}
+		if stale {
+			r.logger.InfoContext(
+				r.ctx,
+				fmt.Sprintf("relocated stale %s cgroup path", params.logLabel),
+				params.logLabel,
+				params.docName,
+				"previous_path",
+				storedPath,
+				"path",
+				spec.Group,
+			)
+			return nil
+		}
 		r.logger.InfoContext(
 			r.ctx,
 			fmt.Sprintf("recreated missing %s cgroup", params.logLabel),
@@ -672,9 +900,35 @@ func (r *Exec) ensureCgroupInternal(params ensureCgroupParams) error {
 		)
 	}
 
+	// Repair a stale stored path; the guard above already established that
+	// its filesystem location is gone.
+	if stale {
+		*params.cgroupPath = spec.Group
+		if err = params.updateMetadata(); err != nil {
+			return fmt.Errorf("%w: %w", params.updateErr, err)
+		}
+		r.logger.InfoContext(
+			r.ctx,
+			fmt.Sprintf("repaired stale %s cgroup path", params.logLabel),
+			params.logLabel,
+			params.docName,
+			"previous_path",
+			storedPath,
+			"path",
+			spec.Group,
+		)
+	}
+
 	return nil
 }
 
+// cgroupLocationExists reports whether the cgroup group resolves to a
+// directory under mountpoint.
+func cgroupLocationExists(mountpoint, group string) bool {
+	info, err := os.Stat(filepath.Join(mountpoint, group))
+	return err == nil && info.IsDir()
+}
+
 func (r *Exec) ensureSpaceCgroup(space intmodel.Space) (intmodel.Space, error) {
 	// Extract realm name from space and validate
 	if space.Spec.RealmName == "" {
@@ -803,7 +1057,10 @@ func (r *Exec) createSpaceCgroup(space intmodel.Space) (string, []string, error)
 	return cgroupPath, subtreeControllers, nil
 }
 
-func (r *Exec) createRealmCgroup(realm intmodel.Realm) (string, []string, error) {
+// createRealmCgroup creates the realm cgroup and delegates the kukeon
+// controllers on it. When rb is non-nil and the cgroup did not exist
+// beforehand, it is recorded there so provisionNewRealm can roll it back.
+func (r *Exec) createRealmCgroup(realm intmodel.Realm, rb *realmRollback) (string, []string, error) {
 	spec := ctr.DefaultRealmSpec(realm)
 
 	// Ensure client is initialized and connected
@@ -818,11 +1075,22 @@ func (r *Exec) createRealmCgroup(realm intmodel.Realm) (string, []string, error)
 		return "", nil, err
 	}
 
+	// Only probe for a pre-existing cgroup when someone is tracking what
+	// this call creates.
+	preExisting := false
+	if rb != nil {
+		_, loadErr := r.ctrClient.LoadCgroup(spec.Group, spec.Mountpoint)
+		preExisting = loadErr == nil
+	}
+
 	// Create the cgroup
 	cgroupPath, createErr := r.createCgroupInternal(spec)
 	if createErr != nil {
 		return "", nil, fmt.Errorf("%w: %w", errdefs.ErrCreateRealmCgroup, createErr)
 	}
+	if !preExisting {
+		rb.cgroupCreated(spec.Group, spec.Mountpoint)
+	}
 
 	// Delegate the kukeon resource subset on the realm's own subtree_control
 	// so an empty realm (no descendant space/stack/cell yet) still carries
@@ -1082,7 +1350,7 @@ func cellSpaceBridgeName(cell intmodel.Cell) (string, error) {
 	return cni.SafeBridgeName(networkName), nil
 }
 
-func (r *Exec) provisionNewCell(cell intmodel.Cell) (intmodel.Cell, error) {
+func (r *Exec) provisionNewCell(ctx context.Context, cell intmodel.Cell) (intmodel.Cell, error) {
 	// The inner closure owns the actual provisioning work. The outer
 	// function captures provisionStarted via closure and, on any error
 	// past the stub write, flips the on-disk record to Failed with a
@@ -1105,7 +1373,9 @@ func (r *Exec) provisionNewCell(cell intmodel.Cell) (intmodel.Cell, error) {
 		provisionStarted = true
 
 		// Create cell cgroup
+		_, cgroupSpan := tracing.Start(ctx, "runner.createCellCgroup", cellSpanAttributes(cell)...)
 		cgroupPath, subtreeControllers, err := r.createCellCgroup(cell)
+		tracing.End(cgroupSpan, err)
 		if err != nil {
 			return intmodel.Cell{}, err
 		}
@@ -1134,7 +1404,7 @@ func (r *Exec) provisionNewCell(cell intmodel.Cell) (intmodel.Cell, error) {
 		}
 
 		// Create pause container and all containers for the cell
-		if _, err = r.createCellContainers(&cell); err != nil {
+		if _, err = r.createCellContainers(ctx, &cell); err != nil {
 			return intmodel.Cell{}, err
 		}
 
@@ -1165,15 +1435,18 @@ func (r *Exec) createCellCgroup(cell intmodel.Cell) (string, []string, error) {
 		return "", nil, errdefs.ErrStackNameRequired
 	}
 
-	spec := ctr.DefaultCellSpec(cell)
+	limits, err := r.cellCgroupLimits(cell)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %w", errdefs.ErrCreateCellCgroup, err)
+	}
+	spec := ctr.CellCgroupSpec(cell, limits)
 
 	// Ensure client is initialized and connected
-	if err := r.ensureClientConnected(); err != nil {
+	if err = r.ensureClientConnected(); err != nil {
 		return "", nil, fmt.Errorf("%w: %w", errdefs.ErrConnectContainerd, err)
 	}
 
 	// Build the cgroup path
-	var err error
 	spec, _, err = r.buildCgroupPath(spec)
 	if err != nil {
 		return "", nil, err
@@ -1210,6 +1483,27 @@ func (r *Exec) createCellCgroup(cell intmodel.Cell) (string, []string, error) {
 	return cgroupPath, subtreeControllers, nil
 }
 
+// cellCgroupLimits resolves the DefaultResources a cell inherits from its
+// space and realm. The cell cgroup nests under theirs (see buildCgroupPath),
+// so a limit set on the realm or space cgroup itself would cap the sum of
+// all cells; DefaultResources instead gives each cell its own cap. A parent
+// whose metadata is missing contributes no limits rather than failing the
+// cell, matching how the cgroup tree is ensured level by level.
+func (r *Exec) cellCgroupLimits(cell intmodel.Cell) (*intmodel.ContainerResources, error) {
+	realm, err := r.GetRealm(intmodel.Realm{Metadata: intmodel.RealmMetadata{Name: cell.Spec.RealmName}})
+	if err != nil && !errors.Is(err, errdefs.ErrRealmNotFound) {
+		return nil, err
+	}
+	space, err := r.GetSpace(intmodel.Space{
+		Metadata: intmodel.SpaceMetadata{Name: cell.Spec.SpaceName},
+		Spec:     intmodel.SpaceSpec{RealmName: cell.Spec.RealmName},
+	})
+	if err != nil && !errors.Is(err, errdefs.ErrSpaceNotFound) {
+		return nil, err
+	}
+	return intmodel.ResolveCellResources(realm, space), nil
+}
+
 // enableCellControllers picks between the resource-subset and full-set
 // subtree-control delegation based on cell.Spec.NestedCgroupRuntime, and
 // invokes the matching ctr.Client entry point. The two paths share the
@@ -1284,7 +1578,11 @@ func (r *Exec) ensureCellCgroup(cell intmodel.Cell) (intmodel.Cell, error) {
 		return intmodel.Cell{}, fmt.Errorf("%w: %w", errdefs.ErrConnectContainerd, err)
 	}
 
-	spec := ctr.DefaultCellSpec(cell)
+	limits, err := r.cellCgroupLimits(cell)
+	if err != nil {
+		return intmodel.Cell{}, fmt.Errorf("%w: %w", errdefs.ErrCreateCellCgroup, err)
+	}
+	spec := ctr.CellCgroupSpec(cell, limits)
 
 	// Capture cell for closure
 	cellForUpdate := cell
@@ -1343,7 +1641,9 @@ func (r *Exec) ensureCellCgroup(cell intmodel.Cell) (intmodel.Cell, error) {
 // The root container is treated uniformly with other containers in the loop, but uses a different
 // creation method (BuildRootContainerSpec + CreateContainer vs CreateContainerFromSpec).
 // Modifies the cell in place: ensures root container is in Containers array and sets RootContainerID.
-func (r *Exec) createCellContainers(cell *intmodel.Cell) (containerd.Container, error) {
+// Each container create is atomic; cancellation of the parent context is checked between them.
+// Every create (image pull included) is recorded as a "runner.createContainer" child span of ctx.
+func (r *Exec) createCellContainers(ctx context.Context, cell *intmodel.Cell) (containerd.Container, error) {
 	cellName := strings.TrimSpace(cell.Metadata.Name)
 	if cellName == "" {
 		return nil, errdefs.ErrCellNameRequired
@@ -1398,7 +1698,7 @@ func (r *Exec) createCellContainers(cell *intmodel.Cell) (containerd.Container,
 		return nil, fmt.Errorf("realm %q has no namespace", realmName)
 	}
 
-	creds := ctr.ConvertRealmCredentials(internalRealm.Spec.RegistryCredentials)
+	creds := r.registryCredentials(internalRealm)
 
 	// Prepare root container: ensure it's in Containers array and RootContainerID is set
 	rootContainerdID, err := naming.BuildRootContainerdID(spaceName, stackName, cellID)
@@ -1406,7 +1706,7 @@ func (r *Exec) createCellContainers(cell *intmodel.Cell) (containerd.Container,
 		return nil, fmt.Errorf("failed to build root container containerd ID: %w", err)
 	}
 
-	rootContainerSpec, err := r.ensureCellRootContainerSpec(*cell)
+	rootContainerSpec, err := r.ensureCellRootContainerSpec(internalRealm, *cell)
 	if err != nil {
 		return nil, err
 	}
@@ -1462,10 +1762,18 @@ func (r *Exec) createCellContainers(cell *intmodel.Cell) (containerd.Container,
 	// containers keep their authored env verbatim.
 	attachableID := resolveAttachableContainerID(*cell)
 
-	// Create all containers defined in the cell (including root container)
+	// Create all containers defined in the cell (including root container).
+	// Cancellation is observed between containers: an interrupted create
+	// leaves every container before the one named in the error in place
+	// (they are created in cell.Spec.Containers order) and returns an error
+	// wrapping context.Canceled.
 	for i := range cell.Spec.Containers {
 		containerSpec := cell.Spec.Containers[i]
 
+		if err = r.checkCanceled(fmt.Sprintf("creating container %q", containerSpec.ID)); err != nil {
+			return rootContainer, err
+		}
+
 		// Determine if this is the root container
 		isRoot := cell.Spec.RootContainerID != "" && containerSpec.ID == cell.Spec.RootContainerID
 
@@ -1510,9 +1818,11 @@ func (r *Exec) createCellContainers(cell *intmodel.Cell) (containerd.Container,
 			}
 
 			rootLabels := stampSpecHashOnLabels(buildRootContainerLabels(*cell), containerSpec)
-			ctrContainerSpec := ctr.BuildRootContainerSpec(containerSpec, rootLabels, r.daemonDefaultBuildOpts()...)
+			ctrContainerSpec := ctr.BuildRootContainerSpec(containerSpec, rootLabels, r.containerBuildOpts(internalRealm)...)
 
+			_, createSpan := tracing.Start(ctx, "runner.createContainer", containerSpanAttributes(*cell, containerSpec)...)
 			createdContainer, createErr = r.ctrClient.CreateContainer(namespace, ctrContainerSpec, creds)
+			tracing.End(createSpan, createErr)
 			if createErr != nil {
 				logFields := appendCellLogFields([]any{"id", containerdID}, cellID, cellName)
 				logFields = append(
@@ -1572,7 +1882,7 @@ func (r *Exec) createCellContainers(cell *intmodel.Cell) (containerd.Container,
 			if attachErr != nil {
 				return nil, fmt.Errorf("failed to prepare attachable container %s: %w", containerdID, attachErr)
 			}
-			buildOpts := append(r.daemonDefaultBuildOpts(), attachOpts...)
+			buildOpts := append(r.containerBuildOpts(internalRealm), attachOpts...)
 			buildOpts = append(buildOpts, ctr.WithExtraLabels(specHashLabels(containerSpec)))
 			// Merge `kuke run --env` runtime env into the attachable
 			// container's spec env (issue #834). Returns containerSpec
@@ -1581,12 +1891,14 @@ func (r *Exec) createCellContainers(cell *intmodel.Cell) (containerd.Container,
 			// back to cell.Spec.Containers[i] (the OCI build sees the
 			// runtime entries, on-disk metadata stays clean).
 			ociSpec := mergeRuntimeEnvForContainer(containerSpec, attachableID, cell.Spec.RuntimeEnv)
+			_, createSpan := tracing.Start(ctx, "runner.createContainer", containerSpanAttributes(*cell, containerSpec)...)
 			_, createErr = r.ctrClient.CreateContainerFromSpec(
 				namespace,
 				ociSpec,
 				creds,
 				buildOpts...,
 			)
+			tracing.End(createSpan, createErr)
 			if createErr != nil {
 				fields := appendCellLogFields([]any{"id", containerdID}, cellID, cellName)
 				fields = append(
@@ -1706,7 +2018,7 @@ func (r *Exec) ensureCellContainers(cell *intmodel.Cell) (containerd.Container,
 		return nil, fmt.Errorf("failed to get realm: %w", err)
 	}
 
-	creds := ctr.ConvertRealmCredentials(internalRealm.Spec.RegistryCredentials)
+	creds := r.registryCredentials(internalRealm)
 
 	// Generate containerd ID with cell identifier for uniqueness
 	containerID, err := naming.BuildRootContainerdID(spaceName, stackName, cellID)
@@ -1763,7 +2075,7 @@ func (r *Exec) ensureCellContainers(cell *intmodel.Cell) (containerd.Container,
 		)
 
 		var rootContainerSpec intmodel.ContainerSpec
-		rootContainerSpec, err = r.ensureCellRootContainerSpec(*cell)
+		rootContainerSpec, err = r.ensureCellRootContainerSpec(internalRealm, *cell)
 		if err != nil {
 			return nil, err
 		}
@@ -1810,7 +2122,7 @@ func (r *Exec) ensureCellContainers(cell *intmodel.Cell) (containerd.Container,
 		r.stampContainerRecreateRuntimeFields(&rootContainerSpec, cell)
 
 		rootLabels := stampSpecHashOnLabels(buildRootContainerLabels(*cell), rootContainerSpec)
-		containerSpec := ctr.BuildRootContainerSpec(rootContainerSpec, rootLabels, r.daemonDefaultBuildOpts()...)
+		containerSpec := ctr.BuildRootContainerSpec(rootContainerSpec, rootLabels, r.containerBuildOpts(internalRealm)...)
 
 		var createErr error
 		container, createErr = r.ctrClient.CreateContainer(internalRealm.Spec.Namespace, containerSpec, creds)
@@ -2053,7 +2365,7 @@ func (r *Exec) ensureCellContainers(cell *intmodel.Cell) (containerd.Container,
 			if attachErr != nil {
 				return nil, fmt.Errorf("failed to prepare attachable container %s: %w", containerdID, attachErr)
 			}
-			buildOpts := append(r.daemonDefaultBuildOpts(), attachOpts...)
+			buildOpts := append(r.containerBuildOpts(internalRealm), attachOpts...)
 			buildOpts = append(buildOpts, ctr.WithExtraLabels(specHashLabels(containerSpec)))
 			createdContainer, containerCreateErr := r.ctrClient.CreateContainerFromSpec(
 				internalRealm.Spec.Namespace,
@@ -2136,7 +2448,10 @@ func (r *Exec) ensureCellContainers(cell *intmodel.Cell) (containerd.Container,
 	return container, nil
 }
 
-func (r *Exec) ensureCellRootContainerSpec(cell intmodel.Cell) (intmodel.ContainerSpec, error) {
+func (r *Exec) ensureCellRootContainerSpec(
+	realm intmodel.Realm,
+	cell intmodel.Cell,
+) (intmodel.ContainerSpec, error) {
 	// Extract cell ID and validate
 	cellID := strings.TrimSpace(cell.Spec.ID)
 	if cellID == "" {
@@ -2219,6 +2534,9 @@ func (r *Exec) ensureCellRootContainerSpec(cell intmodel.Cell) (intmodel.Contain
 			cniConfigPath,
 			kukepauseHostPath,
 		)
+		if pauseImage := strings.TrimSpace(realm.Spec.PauseImage); pauseImage != "" {
+			rootSpec.Image = pauseImage
+		}
 		// Propagate HostNetwork from any cell container to the default root
 		// container. Non-root containers join the root container's netns
 		// (see JoinContainerNamespaces), so the netns-owning root is the
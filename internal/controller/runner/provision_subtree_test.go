@@ -27,6 +27,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	cgroup2 "github.com/containerd/cgroups/v2/cgroup2"
 	apitypes "github.com/containerd/containerd/api/types"
@@ -43,12 +44,14 @@ import (
 // subtreeRecorderClient is a minimal ctr.Client stub for the per-level
 // delegation test (issue #327). It records EnsureSubtreeControllers calls
 // and returns success for the few methods exercised by the create paths
-// (Connect, GetCgroupMountpoint, GetCurrentCgroupPath, NewCgroup); every
-// other interface method panics so that an inadvertent code path change
-// gets caught loudly instead of silently passing through a no-op stub.
+// (Connect, GetCgroupMode, GetCgroupMountpoint, GetCurrentCgroupPath,
+// NewCgroup); every other interface method panics so that an inadvertent
+// code path change gets caught loudly instead of silently passing through a no-op stub.
 type subtreeRecorderClient struct {
 	mountpoint        string
 	currentCgroupPath string
+	cgroupMode        ctr.CgroupMode
+	newCgroupCalls    int
 	ensureCalls       []ensureSubtreeCall
 }
 
@@ -61,7 +64,8 @@ type ensureSubtreeCall struct {
 func (c *subtreeRecorderClient) Connect() error { return nil }
 func (c *subtreeRecorderClient) Close() error   { return nil }
 
-func (c *subtreeRecorderClient) GetCgroupMountpoint() string { return c.mountpoint }
+func (c *subtreeRecorderClient) GetCgroupMode() ctr.CgroupMode { return c.cgroupMode }
+func (c *subtreeRecorderClient) GetCgroupMountpoint() string   { return c.mountpoint }
 func (c *subtreeRecorderClient) GetCurrentCgroupPath() (string, error) {
 	return c.currentCgroupPath, nil
 }
@@ -73,6 +77,7 @@ func (c *subtreeRecorderClient) GetCurrentCgroupPath() (string, error) {
 //
 //nolint:nilnil // see comment above; (nil, nil) is the only valid stub here
 func (c *subtreeRecorderClient) NewCgroup(_ ctr.CgroupSpec) (*cgroup2.Manager, error) {
+	c.newCgroupCalls++
 	return nil, nil
 }
 
@@ -109,6 +114,10 @@ func (c *subtreeRecorderClient) CgroupPath(string, string) (string, error) {
 func (c *subtreeRecorderClient) LoadCgroup(string, string) (*cgroup2.Manager, error) {
 	panic("unexpected")
 }
+
+func (c *subtreeRecorderClient) CgroupUsage(string, string) (ctr.CgroupUsage, error) {
+	panic("unexpected")
+}
 func (c *subtreeRecorderClient) DeleteCgroup(string, string) error { panic("unexpected") }
 func (c *subtreeRecorderClient) EnableCellSubtreeControllers(string, string, []string) ([]string, error) {
 	panic("unexpected")
@@ -170,6 +179,18 @@ func (c *subtreeRecorderClient) TaskMetrics(string, string) (*apitypes.Metric, e
 	panic("unexpected")
 }
 
+func (c *subtreeRecorderClient) TaskPID(string, string) (uint32, error) {
+	panic("unexpected")
+}
+
+func (c *subtreeRecorderClient) ResizeProcess(string, string, string, uint32, uint32) error {
+	panic("unexpected")
+}
+
+func (c *subtreeRecorderClient) ExecProcess(string, string, []string, time.Duration) (ctr.ExecResult, error) {
+	return ctr.ExecResult{}, nil
+}
+
 func (c *subtreeRecorderClient) ContainerProcessUID(string, containerd.Container) (uint32, error) {
 	panic("unexpected")
 }
@@ -186,6 +207,10 @@ func (c *subtreeRecorderClient) GetImage(string, string) (ctr.ImageInfo, error)
 	panic("unexpected")
 }
 
+func (c *subtreeRecorderClient) PullImage(string, string, []ctr.RegistryCredentials) (ctr.ImageInfo, error) {
+	panic("unexpected")
+}
+
 func (c *subtreeRecorderClient) ImageChainID(string, string) (string, error) {
 	panic("unexpected")
 }
@@ -194,6 +219,14 @@ func (c *subtreeRecorderClient) ContainerRootChainID(string, string) (string, er
 	panic("unexpected")
 }
 
+func (c *subtreeRecorderClient) ContainerImageDigest(string, string) (string, error) {
+	panic("unexpected")
+}
+
+func (c *subtreeRecorderClient) InspectContainer(string, string) (ctr.ContainerInspection, error) {
+	panic("unexpected")
+}
+
 func (c *subtreeRecorderClient) DeleteImage(string, string) error {
 	panic("unexpected")
 }
@@ -207,6 +240,10 @@ func (c *subtreeRecorderClient) NamespaceStorage(string) (ctr.StorageStats, erro
 }
 
 // Compile-time: the stub satisfies the full Client surface.
+func (c *subtreeRecorderClient) SubscribeTaskExits(context.Context) (<-chan ctr.TaskExit, <-chan error) {
+	return nil, nil
+}
+
 var _ ctr.Client = (*subtreeRecorderClient)(nil)
 
 // newSubtreeTestExec builds a minimal *Exec backed by subtreeRecorderClient.
@@ -291,7 +328,7 @@ func TestCreateRealmCgroupEmptyRealmDelegates(t *testing.T) {
 	if _, _, err := r.createRealmCgroup(intmodel.Realm{
 		Metadata: intmodel.RealmMetadata{Name: "empty"},
 		Spec:     intmodel.RealmSpec{Namespace: "empty.kukeon.io"},
-	}); err != nil {
+	}, nil); err != nil {
 		t.Fatalf("createRealmCgroup() unexpected error: %v", err)
 	}
 
@@ -327,7 +364,7 @@ func TestCreateLevelCgroupsDelegateSubtreeControllers(t *testing.T) {
 				return r.createRealmCgroup(intmodel.Realm{
 					Metadata: intmodel.RealmMetadata{Name: "alpha"},
 					Spec:     intmodel.RealmSpec{Namespace: "alpha.kukeon.io"},
-				})
+				}, nil)
 			},
 			wantGroup: consts.KukeonCgroupRoot + "/alpha",
 		},
@@ -21,15 +21,21 @@ package runner
 
 import (
 	"context"
+	"errors"
 	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/containerd/cgroups/v2/cgroup2"
 	"github.com/eminwux/kukeon/internal/cni"
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/metadata"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 	"github.com/eminwux/kukeon/internal/util/fs"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
 )
 
 // newProvisionTestExec builds a minimal *Exec suitable for exercising
@@ -139,8 +145,13 @@ func TestEnsureSpaceCNIConfig_LeavesMatchingConflistAlone(t *testing.T) {
 
 	const realmName = "kuke-system"
 	const spaceName = "kukeon"
-	correctBridge := cni.SafeBridgeName(realmName + "-" + spaceName)
-	confPath := writeStaleConflist(t, runPath, realmName, spaceName, correctBridge)
+	confPath, err := fs.SpaceNetworkConfigPath(runPath, realmName, spaceName)
+	if err != nil {
+		t.Fatalf("SpaceNetworkConfigPath: %v", err)
+	}
+	if err = fs.WriteSpaceNetworkConfig(confPath, realmName+"-"+spaceName, "10.22.0.0/16", ""); err != nil {
+		t.Fatalf("WriteSpaceNetworkConfig: %v", err)
+	}
 
 	before, err := os.ReadFile(confPath)
 	if err != nil {
@@ -164,6 +175,39 @@ func TestEnsureSpaceCNIConfig_LeavesMatchingConflistAlone(t *testing.T) {
 	}
 }
 
+// TestEnsureSpaceCNIConfig_AddsMissingBandwidthPlugin covers conflists
+// written before per-cell bandwidth limits: the bridge name matches, but the
+// chain lacks the bandwidth plugin, so the conflist is regenerated in place
+// and keeps its subnet.
+func TestEnsureSpaceCNIConfig_AddsMissingBandwidthPlugin(t *testing.T) {
+	runPath := t.TempDir()
+	r := newProvisionTestExec(t, runPath, false)
+
+	const realmName = "kuke-system"
+	const spaceName = "kukeon"
+	correctBridge := cni.SafeBridgeName(realmName + "-" + spaceName)
+	confPath := writeStaleConflist(t, runPath, realmName, spaceName, correctBridge)
+
+	space := intmodel.Space{
+		Metadata: intmodel.SpaceMetadata{Name: spaceName},
+		Spec:     intmodel.SpaceSpec{RealmName: realmName},
+	}
+	if _, err := r.ensureSpaceCNIConfig(space); err != nil {
+		t.Fatalf("ensureSpaceCNIConfig: %v", err)
+	}
+
+	mgr, err := cni.NewManager(r.cniConf.CniBinDir, r.cniConf.CniConfigDir, r.cniConf.CniCacheDir)
+	if err != nil {
+		t.Fatalf("cni.NewManager: %v", err)
+	}
+	if has, hErr := mgr.HasBandwidthPlugin(confPath); hErr != nil || !has {
+		t.Errorf("HasBandwidthPlugin after ensure = %v, %v; want true", has, hErr)
+	}
+	if subnet, rsErr := mgr.ReadSubnetCIDR(confPath); rsErr != nil || subnet != "10.22.0.0/16" {
+		t.Errorf("ReadSubnetCIDR after ensure = %q, %v; want the legacy 10.22.0.0/16 kept", subnet, rsErr)
+	}
+}
+
 func TestEnsureSpaceCNIConfig_ForceRegenerateCNIOverwrites(t *testing.T) {
 	runPath := t.TempDir()
 	r := newProvisionTestExec(t, runPath, true)
@@ -431,3 +475,151 @@ func TestEnsureSpaceCNIConfig_ForceRegenerateUsesAllocatorWhenLegacyMissing(t *t
 		t.Errorf("recovery did not persist allocator state: got %q, want %q", got, subnet)
 	}
 }
+
+// TestCreateSpaceCNIConfig_DualStack covers the IPv6 space network: a
+// dual-stack space gets its IPv6 subnet as a second IPAM range, a subnet that
+// overlaps another space in the realm is rejected, and DualStack without an
+// IPv6Subnet is a config error, and a dual-stack space left on the isolated
+// policy, which only covers IPv4, is refused.
+func TestCreateSpaceCNIConfig_DualStack(t *testing.T) {
+	runPath := t.TempDir()
+	r := newProvisionTestExec(t, runPath, false)
+
+	const realmName = "default"
+	existing := v1beta1.SpaceDoc{
+		APIVersion: v1beta1.APIVersionV1Beta1,
+		Kind:       v1beta1.KindSpace,
+		Metadata:   v1beta1.SpaceMetadata{Name: "alpha"},
+		Spec: v1beta1.SpaceSpec{
+			RealmID: realmName,
+			Network: &v1beta1.SpaceNetwork{
+				Policy: v1beta1.NetworkPolicyOpen, DualStack: true, IPv6Subnet: "fd00:88:1::/64",
+			},
+		},
+	}
+	metaPath := fs.SpaceMetadataPath(runPath, realmName, "alpha")
+	if err := os.MkdirAll(filepath.Dir(metaPath), 0o755); err != nil {
+		t.Fatalf("mkdir space metadata dir: %v", err)
+	}
+	if err := metadata.WriteMetadata(r.ctx, r.logger, existing, metaPath); err != nil {
+		t.Fatalf("write space metadata: %v", err)
+	}
+
+	dualStackSpace := func(name, v6 string) intmodel.Space {
+		return intmodel.Space{
+			Metadata: intmodel.SpaceMetadata{Name: name},
+			Spec: intmodel.SpaceSpec{
+				RealmName: realmName,
+				Network: &intmodel.SpaceNetwork{
+					Policy: intmodel.NetworkPolicyOpen, DualStack: true, IPv6Subnet: v6,
+				},
+			},
+		}
+	}
+
+	confPath, err := r.createSpaceCNIConfig(dualStackSpace("beta", "fd00:88:2::/64"))
+	if err != nil {
+		t.Fatalf("createSpaceCNIConfig beta: %v", err)
+	}
+	mgr, err := cni.NewManager(r.cniConf.CniBinDir, r.cniConf.CniConfigDir, r.cniConf.CniCacheDir)
+	if err != nil {
+		t.Fatalf("cni.NewManager: %v", err)
+	}
+	if v6, rsErr := mgr.ReadIPv6SubnetCIDR(confPath); rsErr != nil || v6 != "fd00:88:2::/64" {
+		t.Errorf("ReadIPv6SubnetCIDR = %q, %v; want fd00:88:2::/64", v6, rsErr)
+	}
+	if v4, rsErr := mgr.ReadSubnetCIDR(confPath); rsErr != nil || v4 == "" {
+		t.Errorf("ReadSubnetCIDR = %q, %v; want the allocated IPv4 subnet", v4, rsErr)
+	}
+
+	if _, err = r.createSpaceCNIConfig(dualStackSpace("gamma", "fd00:88:1::/64")); !errors.Is(
+		err, errdefs.ErrSubnetOverlap,
+	) {
+		t.Errorf("createSpaceCNIConfig overlapping subnet error = %v, want ErrSubnetOverlap", err)
+	}
+	if _, err = r.createSpaceCNIConfig(dualStackSpace("delta", "")); !errors.Is(err, errdefs.ErrDualStackConfig) {
+		t.Errorf("createSpaceCNIConfig without IPv6Subnet error = %v, want ErrDualStackConfig", err)
+	}
+	for _, policy := range []intmodel.NetworkPolicy{"", intmodel.NetworkPolicyIsolated} {
+		isolated := dualStackSpace("epsilon", "fd00:88:5::/64")
+		isolated.Spec.Network.Policy = policy
+		if _, err = r.createSpaceCNIConfig(isolated); !errors.Is(err, errdefs.ErrDualStackIsolated) {
+			t.Errorf("createSpaceCNIConfig with policy %q error = %v, want ErrDualStackIsolated", policy, err)
+		}
+	}
+}
+
+// TestEnsureCgroupInternal_StaleCgroupPath pins the stale-path repair: a
+// stored Status.CgroupPath that differs from the freshly computed one is
+// rewritten only when its filesystem location is gone, so a still-present
+// cgroup computed from another process's cgroup never flaps.
+func TestEnsureCgroupInternal_StaleCgroupPath(t *testing.T) {
+	const group = "/kukeon/default/web"
+	presentPath := t.TempDir()
+	absentPath := filepath.Join(t.TempDir(), "gone")
+
+	cases := []struct {
+		name         string
+		stored       string
+		computedLive bool
+		wantPath     string
+		wantUpdates  int
+		wantCreates  int
+	}{
+		{name: "matching", stored: group, computedLive: true, wantPath: group},
+		{name: "stale but present", stored: presentPath, computedLive: true, wantPath: presentPath},
+		{name: "stale and absent", stored: absentPath, computedLive: true, wantPath: group, wantUpdates: 1},
+		{
+			name:        "stale and absent, computed missing",
+			stored:      absentPath,
+			wantPath:    group,
+			wantUpdates: 1,
+			wantCreates: 1,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			creates := 0
+			fake := &deleteCellFakeClient{
+				loadCgroupFn: func(string, string) (*cgroup2.Manager, error) {
+					if tc.computedLive {
+						return &cgroup2.Manager{}, nil
+					}
+					return nil, errors.New("cgroup path does not exist")
+				},
+				newCgroupFn: func(ctr.CgroupSpec) (*cgroup2.Manager, error) {
+					creates++
+					return &cgroup2.Manager{}, nil
+				},
+			}
+			r := newDeleteCellTestExec(t, fake)
+
+			path := tc.stored
+			updates := 0
+			err := r.ensureCgroupInternal(ensureCgroupParams{
+				spec:       ctr.CgroupSpec{Group: group},
+				docName:    "web",
+				cgroupPath: &path,
+				createErr:  errdefs.ErrCreateCellCgroup,
+				updateErr:  errdefs.ErrUpdateCellMetadata,
+				logLabel:   "cell",
+				updateMetadata: func() error {
+					updates++
+					return nil
+				},
+			})
+			if err != nil {
+				t.Fatalf("ensureCgroupInternal: %v", err)
+			}
+			if path != tc.wantPath {
+				t.Errorf("cgroup path = %q, want %q", path, tc.wantPath)
+			}
+			if updates != tc.wantUpdates {
+				t.Errorf("metadata updates = %d, want %d", updates, tc.wantUpdates)
+			}
+			if creates != tc.wantCreates {
+				t.Errorf("NewCgroup calls = %d, want %d", creates, tc.wantCreates)
+			}
+		})
+	}
+}
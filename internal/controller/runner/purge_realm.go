@@ -17,6 +17,7 @@
 package runner
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -27,6 +28,7 @@ import (
 	"github.com/eminwux/kukeon/internal/ctr"
 	"github.com/eminwux/kukeon/internal/errdefs"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/tracing"
 	"github.com/eminwux/kukeon/internal/util/fs"
 )
 
@@ -40,7 +42,14 @@ import (
 // load-bearing piece of "purge". Best-effort cleanups (cgroup removal, CNI
 // teardown, orphaned-container drain) log warnings and do not surface as err
 // so a fully-cleaned namespace is never reported as a failed purge.
-func (r *Exec) PurgeRealm(realm intmodel.Realm) (bool, error) {
+func (r *Exec) PurgeRealm(ctx context.Context, realm intmodel.Realm) (bool, error) {
+	_, span := tracing.Start(ctx, "runner.PurgeRealm", tracing.AttrRealm.String(realm.Metadata.Name))
+	removed, err := r.purgeRealm(realm)
+	tracing.End(span, err)
+	return removed, err
+}
+
+func (r *Exec) purgeRealm(realm intmodel.Realm) (bool, error) {
 	realmName := strings.TrimSpace(realm.Metadata.Name)
 	if realmName == "" {
 		return false, errdefs.ErrRealmNameRequired
@@ -74,6 +83,10 @@ func (r *Exec) PurgeRealm(realm intmodel.Realm) (bool, error) {
 		r.processOrphanedContainers(r.ctx, realmForOps.Spec.Namespace, containers)
 	}
 
+	// Release CNI allocations still cached for containers that are gone —
+	// cells that crashed without a clean detach never ran their CNI DEL.
+	r.gcRealmCNI(realmForOps.Metadata.Name, realmForOps.Spec.Namespace)
+
 	// Tear down each conflist + bridge link for this realm. Driven from
 	// CniConfigDir because that is the source of truth for which bridges
 	// were created — IPAM dirs under CNINetworksDir can be missing if a
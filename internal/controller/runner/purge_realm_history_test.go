@@ -54,7 +54,7 @@ func TestPurgeRealm_DrainsAndDeletesBuildKitHistoryCompanion(t *testing.T) {
 	// No metadata is seeded, so GetRealm returns ErrRealmNotFound and PurgeRealm
 	// falls back to the provided realm — exactly the partial-uninstall path the
 	// companion leak was observed on.
-	removed, err := r.PurgeRealm(intmodel.Realm{
+	removed, err := r.PurgeRealm(r.ctx, intmodel.Realm{
 		Metadata: intmodel.RealmMetadata{Name: "kuke-system"},
 		Spec:     intmodel.RealmSpec{Namespace: namespace},
 	})
@@ -95,7 +95,7 @@ func TestPurgeRealm_HistoryCompanionFailureFoldsIntoNamespaceRemoved(t *testing.
 	}
 	r := newDeleteCellTestExec(t, fake)
 
-	removed, err := r.PurgeRealm(intmodel.Realm{
+	removed, err := r.PurgeRealm(r.ctx, intmodel.Realm{
 		Metadata: intmodel.RealmMetadata{Name: "kuke-system"},
 		Spec:     intmodel.RealmSpec{Namespace: namespace},
 	})
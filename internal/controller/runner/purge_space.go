@@ -82,6 +82,11 @@ func (r *Exec) PurgeSpace(space intmodel.Space) error {
 		}
 	}
 
+	// DeleteSpace removes the isolation chain too, unless it failed early.
+	if isoErr := r.removeSpaceIsolation(spaceForOps); isoErr != nil {
+		r.logger.WarnContext(r.ctx, "failed to remove space isolation", "error", isoErr)
+	}
+
 	// Find all containers in space
 	if err = r.ensureClientConnected(); err == nil {
 		pattern := fmt.Sprintf("%s-%s", spaceForOps.Spec.RealmName, spaceForOps.Metadata.Name)
@@ -0,0 +1,91 @@
+//go:build !integration
+
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/controller/runner"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/metadata"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/util/fs"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+// TestCreateRealm_RejectsNamespaceOwnedByAnotherRealm covers the conflict
+// check on a Spec.Namespace override: a namespace already recorded in another
+// realm's metadata must be refused before any metadata or containerd state
+// is written for the new realm.
+func TestCreateRealm_RejectsNamespaceOwnedByAnotherRealm(t *testing.T) {
+	runPath := t.TempDir()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	owner := v1beta1.RealmDoc{
+		APIVersion: v1beta1.APIVersionV1Beta1,
+		Kind:       v1beta1.KindRealm,
+		Metadata:   v1beta1.RealmMetadata{Name: "owner"},
+		Spec:       v1beta1.RealmSpec{Namespace: "shared-ns"},
+	}
+	if err := metadata.WriteMetadata(
+		context.Background(), logger, owner, fs.RealmMetadataPath(runPath, "owner"),
+	); err != nil {
+		t.Fatalf("seed realm metadata: %v", err)
+	}
+
+	r := runner.NewRunner(context.Background(), logger, runner.Options{RunPath: runPath})
+
+	_, err := r.CreateRealm(intmodel.Realm{
+		Metadata: intmodel.RealmMetadata{Name: "intruder"},
+		Spec:     intmodel.RealmSpec{Namespace: "shared-ns"},
+	})
+	if !errors.Is(err, errdefs.ErrRealmNamespaceInUse) {
+		t.Fatalf("CreateRealm() error = %v, want ErrRealmNamespaceInUse", err)
+	}
+	if !strings.Contains(err.Error(), `"owner"`) {
+		t.Errorf("CreateRealm() error = %v, want it to name the owning realm", err)
+	}
+
+	if _, statErr := os.Stat(fs.RealmMetadataPath(runPath, "intruder")); !os.IsNotExist(statErr) {
+		t.Errorf("intruder realm metadata written despite conflict (stat err = %v)", statErr)
+	}
+}
+
+// TestCreateRealm_RejectsInvalidNamespace covers runner callers that bypass
+// the controller (the apply reconcile path): an illegal containerd namespace
+// name is refused before provisioning.
+func TestCreateRealm_RejectsInvalidNamespace(t *testing.T) {
+	runPath := t.TempDir()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	r := runner.NewRunner(context.Background(), logger, runner.Options{RunPath: runPath})
+
+	_, err := r.CreateRealm(intmodel.Realm{
+		Metadata: intmodel.RealmMetadata{Name: "bad-ns"},
+		Spec:     intmodel.RealmSpec{Namespace: "..bad"},
+	})
+	if !errors.Is(err, errdefs.ErrInvalidRealmNamespace) {
+		t.Fatalf("CreateRealm() error = %v, want ErrInvalidRealmNamespace", err)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // drives unexported provisionNewRealm against an in-package ctr.Client fake
+package runner
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/consts"
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// preflightRecorderClient layers a failing Connect and a namespace recorder
+// over subtreeRecorderClient, so the realm preflight tests can prove the gate
+// fired before containerd was asked to create anything.
+type preflightRecorderClient struct {
+	*subtreeRecorderClient
+
+	connectErr error
+	namespaces []string
+}
+
+func (c *preflightRecorderClient) Connect() error { return c.connectErr }
+
+func (c *preflightRecorderClient) CreateNamespace(namespace string) error {
+	c.namespaces = append(c.namespaces, namespace)
+	return nil
+}
+
+func (c *preflightRecorderClient) ListNamespaces() ([]string, error) { return c.namespaces, nil }
+
+func newPreflightRecorderClient(mode ctr.CgroupMode) *preflightRecorderClient {
+	return &preflightRecorderClient{subtreeRecorderClient: &subtreeRecorderClient{
+		mountpoint:        "/sys/fs/cgroup",
+		currentCgroupPath: consts.KukeonCgroupRoot,
+		cgroupMode:        mode,
+	}}
+}
+
+// TestPreflightRealm_UnwritableRunPath pins the run-path check of the gate.
+// It drives preflightRealm directly: a run path that is a regular file would
+// already fail provisionNewRealm's namespace-ownership read.
+func TestPreflightRealm_UnwritableRunPath(t *testing.T) {
+	r := newDeleteCellTestExec(t, &deleteCellFakeClient{})
+	if err := r.preflightRealm(); err != nil {
+		t.Fatalf("preflightRealm() on a healthy host = %v, want nil", err)
+	}
+
+	runPath := filepath.Join(t.TempDir(), "run")
+	if err := os.WriteFile(runPath, []byte{}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r.opts.RunPath = runPath
+	err := r.preflightRealm()
+	if !errors.Is(err, errdefs.ErrPreflightFailed) || !errors.Is(err, errdefs.ErrRunPathNotWritable) {
+		t.Fatalf("preflightRealm() with a run path that is a file = %v, want ErrPreflightFailed wrapping ErrRunPathNotWritable", err)
+	}
+}
+
+// TestProvisionNewRealm_CgroupPreflightCreatesNoNamespace pins that a failed
+// cgroup check stops realm creation before the containerd namespace is made,
+// so no half-provisioned realm is left behind.
+func TestProvisionNewRealm_CgroupPreflightCreatesNoNamespace(t *testing.T) {
+	fake := newPreflightRecorderClient(ctr.CgroupModeLegacy)
+	r := newSubtreeTestExec(t, fake.subtreeRecorderClient)
+	r.ctrClient = fake
+
+	realm := intmodel.Realm{Metadata: intmodel.RealmMetadata{Name: "main"}}
+	_, err := r.provisionNewRealm(realm)
+	if !errors.Is(err, errdefs.ErrPreflightFailed) || !errors.Is(err, errdefs.ErrCgroupV1Unsupported) {
+		t.Fatalf("provisionNewRealm() err = %v, want ErrPreflightFailed wrapping ErrCgroupV1Unsupported", err)
+	}
+	if len(fake.namespaces) != 0 {
+		t.Errorf("namespaces created = %v, want none", fake.namespaces)
+	}
+	if fake.newCgroupCalls != 0 {
+		t.Errorf("NewCgroup calls = %d, want 0", fake.newCgroupCalls)
+	}
+	if _, getErr := r.GetRealm(realm); !errors.Is(getErr, errdefs.ErrRealmNotFound) {
+		t.Errorf("GetRealm() err = %v, want ErrRealmNotFound (nothing persisted)", getErr)
+	}
+}
+
+// TestPreflightRealm_ReportsEveryProblem pins that the gate runs every check
+// and returns them together rather than stopping at the first.
+func TestPreflightRealm_ReportsEveryProblem(t *testing.T) {
+	fake := newPreflightRecorderClient(ctr.CgroupModeHybrid)
+	fake.connectErr = errors.New("dial unix /run/containerd/containerd.sock: connection refused")
+	r := newSubtreeTestExec(t, fake.subtreeRecorderClient)
+	r.ctrClient = fake
+	runPath := filepath.Join(t.TempDir(), "run")
+	if err := os.WriteFile(runPath, []byte{}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r.opts.RunPath = runPath
+
+	err := r.preflightRealm()
+	for _, want := range []error{
+		errdefs.ErrPreflightFailed,
+		errdefs.ErrRunPathNotWritable,
+		errdefs.ErrConnectContainerd,
+		errdefs.ErrCgroupV1Unsupported,
+	} {
+		if !errors.Is(err, want) {
+			t.Errorf("preflightRealm() = %v, want it to wrap %v", err, want)
+		}
+	}
+}
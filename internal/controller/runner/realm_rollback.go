@@ -0,0 +1,69 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner
+
+// realmRollback records what one provisionNewRealm call created, so a later
+// step's failure can tear exactly that back down and a retry starts clean.
+// Anything the call found already in place — a containerd namespace seeded
+// by `ctr images import`, a leftover cgroup — is never recorded and so never
+// removed.
+type realmRollback struct {
+	namespace        string
+	cgroupGroup      string
+	cgroupMountpoint string
+}
+
+// namespaceCreated records that this call created the containerd namespace.
+func (rb *realmRollback) namespaceCreated(namespace string) {
+	if rb != nil {
+		rb.namespace = namespace
+	}
+}
+
+// cgroupCreated records that this call created the realm cgroup.
+func (rb *realmRollback) cgroupCreated(group, mountpoint string) {
+	if rb != nil {
+		rb.cgroupGroup = group
+		rb.cgroupMountpoint = mountpoint
+	}
+}
+
+// undo removes what was recorded, cgroup first (the reverse of creation).
+// It is best effort: a teardown failure is logged and left for `kuke purge
+// realm`, and the caller still returns the provisioning error that
+// triggered the rollback.
+func (r *Exec) undo(rb *realmRollback) {
+	if rb.cgroupGroup != "" {
+		if err := r.ctrClient.DeleteCgroup(rb.cgroupGroup, rb.cgroupMountpoint); err != nil {
+			r.logger.WarnContext(r.ctx, "failed to roll back realm cgroup",
+				"cgroup", rb.cgroupGroup, "error", err)
+		}
+	}
+	if rb.namespace != "" {
+		// A pause-image pull may have landed content in the namespace, and
+		// containerd refuses to delete a non-empty one. "" walks every known
+		// snapshotter, as DeleteRealm does.
+		if err := r.ctrClient.CleanupNamespaceResources(rb.namespace, ""); err != nil {
+			r.logger.WarnContext(r.ctx, "failed to clean up rolled-back realm namespace",
+				"namespace", rb.namespace, "error", err)
+		}
+		if err := r.ctrClient.DeleteNamespace(rb.namespace); err != nil {
+			r.logger.WarnContext(r.ctx, "failed to roll back realm namespace",
+				"namespace", rb.namespace, "error", err)
+		}
+	}
+}
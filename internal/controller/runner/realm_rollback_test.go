@@ -0,0 +1,249 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // drives unexported provisionNewRealm against an in-package ctr.Client fake
+package runner
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	cgroup2 "github.com/containerd/cgroups/v2/cgroup2"
+
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// rollbackFakeClient tracks the containerd namespaces and cgroups that exist
+// and records every create and delete, so the rollback tests can assert that
+// exactly what provisionNewRealm created is undone.
+type rollbackFakeClient struct {
+	*deleteCellFakeClient
+
+	namespaces map[string]bool
+	cgroups    map[string]bool
+
+	createNamespaceErr error
+	newCgroupErr       error
+	ensureSubtreeErr   error
+
+	deletedNamespaces []string
+	deletedCgroups    []string
+}
+
+func newRollbackFakeClient() *rollbackFakeClient {
+	return &rollbackFakeClient{
+		deleteCellFakeClient: &deleteCellFakeClient{},
+		namespaces:           map[string]bool{},
+		cgroups:              map[string]bool{},
+	}
+}
+
+func (c *rollbackFakeClient) ExistsNamespace(namespace string) (bool, error) {
+	return c.namespaces[namespace], nil
+}
+
+func (c *rollbackFakeClient) CreateNamespace(namespace string) error {
+	if c.createNamespaceErr != nil {
+		return c.createNamespaceErr
+	}
+	c.namespaces[namespace] = true
+	return nil
+}
+
+func (c *rollbackFakeClient) DeleteNamespace(namespace string) error {
+	c.deletedNamespaces = append(c.deletedNamespaces, namespace)
+	delete(c.namespaces, namespace)
+	return nil
+}
+
+func (c *rollbackFakeClient) NewCgroup(spec ctr.CgroupSpec) (*cgroup2.Manager, error) {
+	if c.newCgroupErr != nil {
+		return nil, c.newCgroupErr
+	}
+	c.cgroups[spec.Group] = true
+	//nolint:nilnil // cgroup2.Manager has unexported fields; the caller discards it
+	return nil, nil
+}
+
+func (c *rollbackFakeClient) LoadCgroup(group, _ string) (*cgroup2.Manager, error) {
+	if !c.cgroups[group] {
+		return nil, errors.New("cgroup path does not exist")
+	}
+	//nolint:nilnil // same as NewCgroup
+	return nil, nil
+}
+
+func (c *rollbackFakeClient) DeleteCgroup(group, _ string) error {
+	c.deletedCgroups = append(c.deletedCgroups, group)
+	delete(c.cgroups, group)
+	return nil
+}
+
+func (c *rollbackFakeClient) EnsureSubtreeControllers(_, _ string, controllers []string) ([]string, error) {
+	if c.ensureSubtreeErr != nil {
+		return nil, c.ensureSubtreeErr
+	}
+	return controllers, nil
+}
+
+func rollbackTestRealm() intmodel.Realm {
+	return intmodel.Realm{
+		Metadata: intmodel.RealmMetadata{Name: "main"},
+		Spec:     intmodel.RealmSpec{Namespace: "main.kukeon.io"},
+	}
+}
+
+// assertRealmFailed checks the realm was recorded Failed with no cgroup path,
+// so a retry takes the fresh-provision route.
+func assertRealmFailed(t *testing.T, r *Exec) {
+	t.Helper()
+	got, err := r.GetRealm(rollbackTestRealm())
+	if err != nil {
+		t.Fatalf("GetRealm() after failed provision: %v", err)
+	}
+	if got.Status.State != intmodel.RealmStateFailed || got.Status.CgroupPath != "" {
+		t.Errorf("realm status = %v (cgroup %q), want Failed with no cgroup path",
+			got.Status.State, got.Status.CgroupPath)
+	}
+}
+
+func TestProvisionNewRealm_RollsBackOnFailure(t *testing.T) {
+	boom := errors.New("boom")
+	cases := []struct {
+		name              string
+		setup             func(c *rollbackFakeClient, realm *intmodel.Realm)
+		wantErr           error
+		wantNamespaceGone bool
+		wantCgroupGone    bool
+	}{
+		{
+			name:    "namespace create fails",
+			setup:   func(c *rollbackFakeClient, _ *intmodel.Realm) { c.createNamespaceErr = boom },
+			wantErr: errdefs.ErrCreateRealmNamespace,
+		},
+		{
+			name: "pause image pull fails",
+			setup: func(c *rollbackFakeClient, realm *intmodel.Realm) {
+				realm.Spec.PauseImage = "registry.example/pause:missing"
+				c.pullImageFn = func(string, string, []ctr.RegistryCredentials) (ctr.ImageInfo, error) {
+					return ctr.ImageInfo{}, boom
+				}
+			},
+			wantErr:           errdefs.ErrPauseImageUnavailable,
+			wantNamespaceGone: true,
+		},
+		{
+			name:              "cgroup create fails",
+			setup:             func(c *rollbackFakeClient, _ *intmodel.Realm) { c.newCgroupErr = boom },
+			wantErr:           errdefs.ErrCreateRealmCgroup,
+			wantNamespaceGone: true,
+		},
+		{
+			name:              "subtree delegation fails",
+			setup:             func(c *rollbackFakeClient, _ *intmodel.Realm) { c.ensureSubtreeErr = boom },
+			wantErr:           errdefs.ErrCreateRealmCgroup,
+			wantNamespaceGone: true,
+			wantCgroupGone:    true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := newRollbackFakeClient()
+			r := newDeleteCellTestExec(t, fake.deleteCellFakeClient)
+			r.ctrClient = fake
+			realm := rollbackTestRealm()
+			tc.setup(fake, &realm)
+
+			if _, err := r.provisionNewRealm(realm); !errors.Is(err, tc.wantErr) {
+				t.Fatalf("provisionNewRealm() err = %v, want %v", err, tc.wantErr)
+			}
+
+			if gone := slices.Contains(fake.deletedNamespaces, realm.Spec.Namespace); gone != tc.wantNamespaceGone {
+				t.Errorf("namespace deleted = %v, want %v", gone, tc.wantNamespaceGone)
+			}
+			if len(fake.namespaces) != 0 {
+				t.Errorf("namespaces left behind: %v", fake.namespaces)
+			}
+			if gone := len(fake.deletedCgroups) > 0; gone != tc.wantCgroupGone {
+				t.Errorf("cgroups deleted = %v, want deleted=%v", fake.deletedCgroups, tc.wantCgroupGone)
+			}
+			if len(fake.cgroups) != 0 {
+				t.Errorf("cgroups left behind: %v", fake.cgroups)
+			}
+			assertRealmFailed(t, r)
+		})
+	}
+}
+
+// TestProvisionNewRealm_RollbackKeepsPreExistingResources pins that the
+// rollback only undoes what the failing call created: a namespace seeded
+// before `kuke init` and a leftover cgroup both survive.
+func TestProvisionNewRealm_RollbackKeepsPreExistingResources(t *testing.T) {
+	fake := newRollbackFakeClient()
+	fake.ensureSubtreeErr = errors.New("boom")
+	r := newDeleteCellTestExec(t, fake.deleteCellFakeClient)
+	r.ctrClient = fake
+	realm := rollbackTestRealm()
+	fake.namespaces[realm.Spec.Namespace] = true
+	spec, _, err := r.buildCgroupPath(ctr.DefaultRealmSpec(realm))
+	if err != nil {
+		t.Fatalf("buildCgroupPath: %v", err)
+	}
+	fake.cgroups[spec.Group] = true
+
+	if _, err = r.provisionNewRealm(realm); !errors.Is(err, errdefs.ErrCreateRealmCgroup) {
+		t.Fatalf("provisionNewRealm() err = %v, want ErrCreateRealmCgroup", err)
+	}
+	if len(fake.deletedNamespaces) != 0 || len(fake.deletedCgroups) != 0 {
+		t.Errorf("pre-existing resources torn down: namespaces %v, cgroups %v",
+			fake.deletedNamespaces, fake.deletedCgroups)
+	}
+	if !fake.namespaces[realm.Spec.Namespace] || !fake.cgroups[spec.Group] {
+		t.Errorf("pre-existing resources missing after rollback: namespaces %v, cgroups %v",
+			fake.namespaces, fake.cgroups)
+	}
+}
+
+// TestProvisionNewRealm_RetryAfterRollbackSucceeds pins the point of the
+// rollback: once the failing step is fixed, the next attempt provisions from
+// a clean slate.
+func TestProvisionNewRealm_RetryAfterRollbackSucceeds(t *testing.T) {
+	fake := newRollbackFakeClient()
+	fake.ensureSubtreeErr = errors.New("boom")
+	r := newDeleteCellTestExec(t, fake.deleteCellFakeClient)
+	r.ctrClient = fake
+
+	if _, err := r.provisionNewRealm(rollbackTestRealm()); err == nil {
+		t.Fatal("provisionNewRealm() succeeded, want the injected subtree failure")
+	}
+	fake.ensureSubtreeErr = nil
+	fake.deletedNamespaces, fake.deletedCgroups = nil, nil
+
+	got, err := r.provisionNewRealm(rollbackTestRealm())
+	if err != nil {
+		t.Fatalf("retry: provisionNewRealm() = %v", err)
+	}
+	if got.Status.State != intmodel.RealmStateReady {
+		t.Errorf("retry: realm state = %v, want Ready", got.Status.State)
+	}
+	if len(fake.deletedNamespaces) != 0 || len(fake.deletedCgroups) != 0 {
+		t.Errorf("retry tore down resources: namespaces %v, cgroups %v",
+			fake.deletedNamespaces, fake.deletedCgroups)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner
+
+import (
+	"fmt"
+
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// recreateMissingContainers is the reconcile loop's container drift heal. A
+// non-root container whose containerd record is gone (ContainerStateNotCreated
+// — e.g. removed out-of-band with `ctr containers rm`) while its cell is live
+// has drifted from the declared spec, so it is relaunched through the same
+// StartContainer path `kuke start` uses, which recreates the record and starts
+// the task. It returns the IDs it recreated, in spec order.
+//
+// The heal only acts on a live cell: the ReadyObserved latch must be set (an
+// in-flight CreateCell has not registered its containers yet) and the root
+// task must be running (StartContainer joins the root's namespaces; a stopped
+// or rebooted cell is recovered at cell level by `kuke start`). Containers the
+// operator stopped keep their record and read back Stopped, so they are never
+// touched. Callers hold the per-cell lock; statuses must be populated first.
+func (r *Exec) recreateMissingContainers(cell intmodel.Cell) (intmodel.Cell, []string, error) {
+	if !cell.Status.ReadyObserved {
+		return cell, nil, nil
+	}
+	rootSpec := findRootContainerSpec(cell)
+	if rootSpec == nil || !rootContainerStillRunning(rootSpec.ID, cell.Status.Containers) {
+		return cell, nil, nil
+	}
+
+	missing := make(map[string]bool)
+	for i := range cell.Status.Containers {
+		if cell.Status.Containers[i].State == intmodel.ContainerStateNotCreated {
+			missing[cell.Status.Containers[i].ID] = true
+		}
+	}
+
+	var recreated []string
+	for i := range cell.Spec.Containers {
+		spec := cell.Spec.Containers[i]
+		if spec.Root || !missing[spec.ID] {
+			continue
+		}
+		r.logger.InfoContext(r.ctx, "reconcile: recreating missing container",
+			"cell", cell.Metadata.Name,
+			"realm", cell.Spec.RealmName,
+			"space", cell.Spec.SpaceName,
+			"stack", cell.Spec.StackName,
+			"container", spec.ID,
+			"containerdID", spec.ContainerdID)
+		started, err := r.restartContainer(cell, spec.ID)
+		if err != nil {
+			return cell, recreated, fmt.Errorf("recreate missing container %q: %w", spec.ID, err)
+		}
+		cell = started
+		recreated = append(recreated, spec.ID)
+	}
+	return cell, recreated, nil
+}
@@ -0,0 +1,136 @@
+//go:build !integration
+
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // exercises the unexported container drift heal inside *Exec.ReconcileCell
+package runner
+
+import (
+	"log/slog"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cgroup2 "github.com/containerd/cgroups/v2/cgroup2"
+	containerd "github.com/containerd/containerd/v2/client"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// TestReconcileCell_RecreatesMissingContainer is the one-tick drift guard: a
+// live cell whose workload container record was removed out-of-band must have
+// that container relaunched by a single ReconcileCell pass and come out Ready,
+// without the operator running `kuke start`.
+func TestReconcileCell_RecreatesMissingContainer(t *testing.T) {
+	realm, space, stack, cellName := "default", "kukeon", "kukeon", "web"
+	rootID, workloadID := "root", "workload"
+	rootContainerdID := space + "_" + stack + "_" + cellName + "_" + rootID
+	workloadContainerdID := space + "_" + stack + "_" + cellName + "_" + workloadID
+
+	var workloadExists atomic.Bool
+	fake := &deleteCellFakeClient{
+		loadCgroupFn: func(string, string) (*cgroup2.Manager, error) {
+			return &cgroup2.Manager{}, nil
+		},
+		existsContainerFn: func(_, id string) (bool, error) {
+			if id == workloadContainerdID {
+				return workloadExists.Load(), nil
+			}
+			return true, nil
+		},
+		taskStatusFn: func(_, _ string) (containerd.Status, error) {
+			return containerd.Status{Status: containerd.Running}, nil
+		},
+	}
+	r := newDeleteCellTestExec(t, fake)
+	var relaunched []string
+	r.restartContainerFn = func(cell intmodel.Cell, containerID string) (intmodel.Cell, error) {
+		relaunched = append(relaunched, containerID)
+		workloadExists.Store(true)
+		return cell, nil
+	}
+	seedDeleteCellRealm(t, r, realm)
+	seedPostRebootCell(t, r, realm, space, stack, cellName, rootID, workloadID, rootContainerdID, workloadContainerdID)
+
+	cell := intmodel.Cell{
+		Metadata: intmodel.CellMetadata{Name: cellName},
+		Spec: intmodel.CellSpec{
+			ID:        cellName,
+			RealmName: realm,
+			SpaceName: space,
+			StackName: stack,
+			Containers: []intmodel.ContainerSpec{
+				{ID: rootID, ContainerdID: rootContainerdID, Root: true},
+				{ID: workloadID, ContainerdID: workloadContainerdID},
+			},
+		},
+		Status: intmodel.CellStatus{State: intmodel.CellStateReady, ReadyObserved: true},
+	}
+
+	got, _, err := r.ReconcileCell(cell)
+	if err != nil {
+		t.Fatalf("ReconcileCell: unexpected error: %v", err)
+	}
+	if want := []string{workloadID}; !reflect.DeepEqual(relaunched, want) {
+		t.Fatalf("relaunched = %v, want %v", relaunched, want)
+	}
+	if got.Status.State != intmodel.CellStateReady {
+		t.Errorf("cell state = %q, want Ready after the drift heal", got.Status.State)
+	}
+	for _, status := range got.Status.Containers {
+		if status.ID == workloadID && status.State != intmodel.ContainerStateReady {
+			t.Errorf("workload state = %q, want Ready after recreate", status.State)
+		}
+	}
+}
+
+func TestRecreateMissingContainers_Gating(t *testing.T) {
+	ready, stopped, missing := intmodel.ContainerStateReady, intmodel.ContainerStateStopped, intmodel.ContainerStateNotCreated
+	cases := []struct {
+		name          string
+		readyObserved bool
+		rootState     intmodel.ContainerState
+		workState     intmodel.ContainerState
+		want          []string
+	}{
+		{"live_cell_missing_workload", true, ready, missing, []string{"work"}},
+		{"never_ready_cell_skipped", false, ready, missing, nil},
+		{"root_down_skipped", true, stopped, missing, nil},
+		{"operator_stopped_workload_untouched", true, ready, stopped, nil},
+		{"running_workload_untouched", true, ready, ready, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, fired := recordingRestarter(time.Unix(1_700_000_000, 0).UTC(), nil)
+			r.logger = slog.New(slog.DiscardHandler)
+			cell := restartTestCell("", tc.workState, 0)
+			cell.Status.ReadyObserved = tc.readyObserved
+			cell.Status.Containers[0].State = tc.rootState
+
+			_, recreated, err := r.recreateMissingContainers(cell)
+			if err != nil {
+				t.Fatalf("recreateMissingContainers: unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(recreated, tc.want) {
+				t.Errorf("recreated = %v, want %v", recreated, tc.want)
+			}
+			if !reflect.DeepEqual(*fired, tc.want) {
+				t.Errorf("relaunch calls = %v, want %v", *fired, tc.want)
+			}
+		})
+	}
+}
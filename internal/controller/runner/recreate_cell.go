@@ -197,7 +197,7 @@ func (r *Exec) RecreateCell(desired intmodel.Cell) (_ intmodel.Cell, retErr erro
 	provisionStarted = true
 
 	// Recreate cell containers (this will create root container and all child containers)
-	_, err = r.createCellContainers(&desired)
+	_, err = r.createCellContainers(r.ctx, &desired)
 	if err != nil {
 		return intmodel.Cell{}, fmt.Errorf("failed to recreate cell containers: %w", err)
 	}
@@ -221,7 +221,7 @@ func (r *Exec) RecreateCell(desired intmodel.Cell) (_ intmodel.Cell, retErr erro
 	// "StartCellFailed", issue #407), so disarm the local gate to avoid a
 	// redundant kill cycle on the start path.
 	provisionStarted = false
-	started, startErr := r.startCellLocked(desired)
+	started, startErr := r.startCellLocked(r.ctx, desired)
 	if startErr != nil {
 		return intmodel.Cell{}, fmt.Errorf("failed to start recreated cell: %w", startErr)
 	}
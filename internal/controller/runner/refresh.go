@@ -679,6 +679,8 @@ func (r *Exec) ReconcileCell(cell intmodel.Cell) (intmodel.Cell, ReconcileOutcom
 			"cell", cell.Metadata.Name, "error", err)
 	}
 
+	r.rotateCellLogs(cell)
+
 	originalStatus := cell.Status
 
 	// Sticky terminal states are preserved instead of re-derived
@@ -760,6 +762,23 @@ func (r *Exec) ReconcileCell(cell intmodel.Cell) (intmodel.Cell, ReconcileOutcom
 			"cell", cell.Metadata.Name, "error", err)
 	}
 
+	// Container drift heal: relaunch non-root containers whose containerd
+	// record vanished from a live cell, then re-read statuses so the
+	// derivation below sees the converged state rather than the drift. A
+	// failed relaunch is surfaced like a failed restart (StartContainer has
+	// already marked the cell Failed) so the loop records it and retries.
+	healedCell, recreated, healErr := r.recreateMissingContainers(cell)
+	if healErr != nil {
+		return healedCell, ReconcileOutcome{}, healErr
+	}
+	if len(recreated) > 0 {
+		cell = healedCell
+		if err := r.populateCellContainerStatuses(&cell); err != nil {
+			r.logger.DebugContext(r.ctx, "populate container statuses failed",
+				"cell", cell.Metadata.Name, "error", err)
+		}
+	}
+
 	// Run derivation whenever the filesystem check succeeded, even when the
 	// cgroup is absent — a missing cgroup with surviving containerd
 	// containers and no tasks is the post-reboot signature (#543), and
@@ -1809,13 +1828,20 @@ func describeCellFailure(cell intmodel.Cell) string {
 
 // formatContainerFailure renders a single container's exit triple as a human
 // breadcrumb, surfacing the decoded signal when the exit code carries one
-// (128+signum, per ContainerStatus.ExitSignal).
+// (128+signum, per ContainerStatus.ExitSignal) and the first line of the
+// container's termination message when it left one.
 func formatContainerFailure(st intmodel.ContainerStatus) string {
+	var reason string
 	if st.ExitSignal != "" {
-		return fmt.Sprintf("container %q terminated by %s (exit code %d)",
+		reason = fmt.Sprintf("container %q terminated by %s (exit code %d)",
 			st.ID, st.ExitSignal, st.ExitCode)
+	} else {
+		reason = fmt.Sprintf("container %q exited with code %d", st.ID, st.ExitCode)
+	}
+	if st.TerminationMessage != "" {
+		reason += ": " + firstLine(st.TerminationMessage)
 	}
-	return fmt.Sprintf("container %q exited with code %d", st.ID, st.ExitCode)
+	return reason
 }
 
 func findRootContainerSpec(cell intmodel.Cell) *intmodel.ContainerSpec {
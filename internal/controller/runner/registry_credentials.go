@@ -0,0 +1,41 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner
+
+import (
+	"github.com/eminwux/kukeon/internal/ctr"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// registryCredentials returns the credentials image pulls for realm use: the
+// realm's own registryCredentials, plus any registry the operator's Docker
+// config can authenticate that the realm does not already cover. The Docker
+// config is re-read on every call so `docker login` takes effect without a
+// daemon restart. A config that cannot be read, or a credential helper that
+// fails, is logged and skipped — the pull may still succeed anonymously.
+func (r *Exec) registryCredentials(realm intmodel.Realm) []ctr.RegistryCredentials {
+	path := r.opts.DockerConfigPath
+	if path == "" {
+		path = ctr.DockerConfigPath()
+	}
+	docker, err := ctr.LoadDockerConfigCredentials(path)
+	if err != nil {
+		r.logger.WarnContext(r.ctx, "failed to load docker config credentials",
+			"path", path, "realm", realm.Metadata.Name, "error", err)
+	}
+	return ctr.MergeRegistryCredentials(ctr.ConvertRealmCredentials(realm.Spec.RegistryCredentials), docker)
+}
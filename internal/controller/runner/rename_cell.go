@@ -0,0 +1,139 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/util/fs"
+	"github.com/eminwux/kukeon/internal/util/naming"
+)
+
+// RenameCell moves a stopped cell to newName within its stack. The cell's
+// metadata directory — and with it the per-cell secrets, rendered /etc
+// files, and container logs — is renamed in place, and the persisted document
+// is rewritten with the new Metadata.Name and every container's CellName.
+//
+// The containerd identity is deliberately left alone: Spec.ID and each
+// container's ContainerdID are pinned to their current values before the
+// rewrite, so the existing containerd records keep resolving and the next
+// StartCell tears them down and recreates them as usual. The name-derived
+// cell cgroup cannot follow the rename, so it is removed and Status.CgroupPath
+// cleared; the next start recreates it under the new name.
+//
+// Returns errdefs.ErrRenameCellRunning when any container task is running or
+// paused, and errdefs.ErrRenameTargetExists when newName is already taken in
+// the stack.
+func (r *Exec) RenameCell(cell intmodel.Cell, newName string) (intmodel.Cell, error) {
+	newName = strings.TrimSpace(newName)
+	if err := naming.ValidateHierarchyName("cell", newName); err != nil {
+		return intmodel.Cell{}, err
+	}
+
+	unlock := r.lockCell(cell)
+	defer unlock()
+
+	internalCell, err := r.GetCell(cell)
+	if err != nil {
+		return intmodel.Cell{}, err
+	}
+	oldName := internalCell.Metadata.Name
+	if newName == oldName {
+		return intmodel.Cell{}, fmt.Errorf("%w: cell %q", errdefs.ErrRenameSameName, oldName)
+	}
+
+	realmName := internalCell.Spec.RealmName
+	spaceName := internalCell.Spec.SpaceName
+	stackName := internalCell.Spec.StackName
+	oldDir := fs.CellMetadataDir(r.opts.RunPath, realmName, spaceName, stackName, oldName)
+	newDir := fs.CellMetadataDir(r.opts.RunPath, realmName, spaceName, stackName, newName)
+	if _, statErr := os.Stat(newDir); statErr == nil {
+		return intmodel.Cell{}, fmt.Errorf("%w: cell %q in stack %q", errdefs.ErrRenameTargetExists, newName, stackName)
+	} else if !errors.Is(statErr, os.ErrNotExist) {
+		return intmodel.Cell{}, fmt.Errorf("failed to stat %s: %w", newDir, statErr)
+	}
+
+	for i := range internalCell.Spec.Containers {
+		containerID := internalCell.Spec.Containers[i].ID
+		obs, obsErr := r.GetContainerObservation(internalCell, containerID)
+		if obsErr != nil {
+			return intmodel.Cell{}, fmt.Errorf("failed to get state of container %q: %w", containerID, obsErr)
+		}
+		switch obs.State {
+		case intmodel.ContainerStateReady, intmodel.ContainerStatePaused, intmodel.ContainerStatePausing:
+			return intmodel.Cell{}, fmt.Errorf("%w: cell %q, container %q",
+				errdefs.ErrRenameCellRunning, oldName, containerID)
+		default:
+		}
+	}
+
+	// Pin the containerd identity before the name changes underneath it.
+	if strings.TrimSpace(internalCell.Spec.ID) == "" {
+		internalCell.Spec.ID = oldName
+	}
+	for i := range internalCell.Spec.Containers {
+		if internalCell.Spec.Containers[i].ContainerdID != "" {
+			continue
+		}
+		_, containerdID, idErr := r.containerdTarget(internalCell, internalCell.Spec.Containers[i].ID)
+		if idErr != nil {
+			return intmodel.Cell{}, idErr
+		}
+		internalCell.Spec.Containers[i].ContainerdID = containerdID
+	}
+
+	if err = r.ensureClientConnected(); err != nil {
+		return intmodel.Cell{}, fmt.Errorf("%w: %w", errdefs.ErrConnectContainerd, err)
+	}
+	cgroupGroup := internalCell.Status.CgroupPath
+	if cgroupGroup == "" {
+		cgroupGroup = ctr.DefaultCellSpec(internalCell).Group
+	}
+	if cgErr := r.ctrClient.DeleteCgroup(cgroupGroup, r.ctrClient.GetCgroupMountpoint()); cgErr != nil {
+		r.logger.WarnContext(r.ctx, "failed to delete cell cgroup before rename",
+			"cell", oldName, "cgroup", cgroupGroup, "error", cgErr)
+	}
+	internalCell.Status.CgroupPath = ""
+
+	if err = os.Rename(oldDir, newDir); err != nil {
+		return intmodel.Cell{}, fmt.Errorf("failed to move cell metadata directory: %w", err)
+	}
+
+	internalCell.Metadata.Name = newName
+	for i := range internalCell.Spec.Containers {
+		internalCell.Spec.Containers[i].CellName = newName
+	}
+	if err = r.UpdateCellMetadata(internalCell); err != nil {
+		// Put the directory back so the cell stays reachable under its old
+		// name rather than stranding a document whose name disagrees with
+		// its path.
+		if rbErr := os.Rename(newDir, oldDir); rbErr != nil {
+			r.logger.ErrorContext(r.ctx, "failed to roll back cell metadata directory",
+				"from", newDir, "to", oldDir, "error", rbErr)
+		}
+		return intmodel.Cell{}, err
+	}
+	r.refreshCellGeneration(&internalCell)
+
+	return internalCell, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/util/fs"
+)
+
+func renameLookupCell(realm, space, stack, name string) intmodel.Cell {
+	return intmodel.Cell{
+		Metadata: intmodel.CellMetadata{Name: name},
+		Spec:     intmodel.CellSpec{RealmName: realm, SpaceName: space, StackName: stack},
+	}
+}
+
+// TestRenameCell_MovesSubtreeAndRewritesReferences is the reference-integrity
+// guard: after a rename the cell is only reachable under the new name, the
+// per-cell files travel with it, every container names the new cell, and the
+// containerd identity (Spec.ID, ContainerdID) is unchanged so the existing
+// records still resolve.
+func TestRenameCell_MovesSubtreeAndRewritesReferences(t *testing.T) {
+	realm, space, stack := "r1", "s1", "st1"
+	r := newDeleteCellTestExec(t, &deleteCellFakeClient{})
+	seedDeleteCellRealm(t, r, realm)
+	oldPath := seedDeleteCellCell(t, r, realm, space, stack, "web")
+	oldDir := fs.CellMetadataDir(r.opts.RunPath, realm, space, stack, "web")
+	secretFile := fs.SecretPath(r.opts.RunPath, realm, space, stack, "web", "token")
+	if err := os.MkdirAll(fs.SecretsDir(r.opts.RunPath, realm, space, stack, "web"), 0o700); err != nil {
+		t.Fatalf("mkdir secrets: %v", err)
+	}
+	if err := os.WriteFile(secretFile, []byte("s3cr3t"), 0o600); err != nil {
+		t.Fatalf("write secret: %v", err)
+	}
+
+	renamed, err := r.RenameCell(renameLookupCell(realm, space, stack, "web"), "api")
+	if err != nil {
+		t.Fatalf("RenameCell: %v", err)
+	}
+
+	if _, statErr := os.Stat(oldPath); !errors.Is(statErr, os.ErrNotExist) {
+		t.Errorf("old metadata file still present: %v", statErr)
+	}
+	if _, statErr := os.Stat(oldDir); !errors.Is(statErr, os.ErrNotExist) {
+		t.Errorf("old metadata dir still present: %v", statErr)
+	}
+	got, err := r.GetCell(renameLookupCell(realm, space, stack, "api"))
+	if err != nil {
+		t.Fatalf("GetCell(new name): %v", err)
+	}
+	if got.Metadata.Name != "api" || renamed.Metadata.Name != "api" {
+		t.Errorf("Metadata.Name = %q (returned %q), want %q", got.Metadata.Name, renamed.Metadata.Name, "api")
+	}
+	if got.Spec.ID != "web" {
+		t.Errorf("Spec.ID = %q, want the pinned original %q", got.Spec.ID, "web")
+	}
+	for _, c := range got.Spec.Containers {
+		if c.CellName != "api" {
+			t.Errorf("container %q CellName = %q, want %q", c.ID, c.CellName, "api")
+		}
+		if c.ContainerdID != space+"_"+stack+"_web_workload" {
+			t.Errorf("container %q ContainerdID = %q, want it unchanged", c.ID, c.ContainerdID)
+		}
+	}
+	data, err := os.ReadFile(fs.SecretPath(r.opts.RunPath, realm, space, stack, "api", "token"))
+	if err != nil || string(data) != "s3cr3t" {
+		t.Errorf("cell-scoped secret did not move with the cell: %q, %v", data, err)
+	}
+	if _, err = r.GetCell(renameLookupCell(realm, space, stack, "web")); !errors.Is(err, errdefs.ErrCellNotFound) {
+		t.Errorf("GetCell(old name) err = %v, want ErrCellNotFound", err)
+	}
+}
+
+func TestRenameCell_RefusesRunningCell(t *testing.T) {
+	realm, space, stack := "r1", "s1", "st1"
+	fake := &deleteCellFakeClient{
+		existsContainerFn: func(string, string) (bool, error) { return true, nil },
+		taskStatusFn: func(string, string) (containerd.Status, error) {
+			return containerd.Status{Status: containerd.Running}, nil
+		},
+	}
+	r := newDeleteCellTestExec(t, fake)
+	seedDeleteCellRealm(t, r, realm)
+	oldPath := seedDeleteCellCell(t, r, realm, space, stack, "web")
+
+	_, err := r.RenameCell(renameLookupCell(realm, space, stack, "web"), "api")
+	if !errors.Is(err, errdefs.ErrRenameCellRunning) {
+		t.Fatalf("err = %v, want ErrRenameCellRunning", err)
+	}
+	if _, statErr := os.Stat(oldPath); statErr != nil {
+		t.Errorf("metadata must stay in place on refusal: %v", statErr)
+	}
+}
+
+func TestRenameCell_RefusesTakenName(t *testing.T) {
+	realm, space, stack := "r1", "s1", "st1"
+	r := newDeleteCellTestExec(t, &deleteCellFakeClient{})
+	seedDeleteCellRealm(t, r, realm)
+	seedDeleteCellCell(t, r, realm, space, stack, "web")
+	seedDeleteCellCell(t, r, realm, space, stack, "api")
+
+	_, err := r.RenameCell(renameLookupCell(realm, space, stack, "web"), "api")
+	if !errors.Is(err, errdefs.ErrRenameTargetExists) {
+		t.Fatalf("err = %v, want ErrRenameTargetExists", err)
+	}
+}
+
+func TestRenameCell_RejectsInvalidAndSameName(t *testing.T) {
+	realm, space, stack := "r1", "s1", "st1"
+	r := newDeleteCellTestExec(t, &deleteCellFakeClient{})
+	seedDeleteCellRealm(t, r, realm)
+	seedDeleteCellCell(t, r, realm, space, stack, "web")
+
+	if _, err := r.RenameCell(renameLookupCell(realm, space, stack, "web"), "bad_name"); !errors.Is(
+		err, errdefs.ErrInvalidName,
+	) {
+		t.Errorf("invalid name: err = %v, want ErrInvalidName", err)
+	}
+	if _, err := r.RenameCell(renameLookupCell(realm, space, stack, "web"), "web"); !errors.Is(
+		err, errdefs.ErrRenameSameName,
+	) {
+		t.Errorf("same name: err = %v, want ErrRenameSameName", err)
+	}
+}
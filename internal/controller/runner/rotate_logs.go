@@ -0,0 +1,53 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner
+
+import (
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/util/fs"
+	"github.com/eminwux/kukeon/internal/util/logrotate"
+)
+
+// rotateCellLogs applies each container's LogRotation policy to the log file
+// the runtime shim writes for it (see containerLogTaskSpec). It runs from
+// ReconcileCell, so a log is checked once per reconcile tick and may overshoot
+// MaxSizeMB by whatever the task writes between ticks. Failures are logged
+// and otherwise ignored: a rotation problem must never change cell state.
+func (r *Exec) rotateCellLogs(cell intmodel.Cell) {
+	for _, container := range cell.Spec.Containers {
+		if container.LogRotation == nil || container.Attachable || container.Root {
+			continue
+		}
+		path := fs.ContainerLogPath(
+			r.opts.RunPath,
+			cell.Spec.RealmName, cell.Spec.SpaceName, cell.Spec.StackName, cell.Metadata.Name, container.ID,
+		)
+		rotated, err := logrotate.Rotate(path, logrotate.Policy{
+			MaxSizeMB: container.LogRotation.MaxSizeMB,
+			MaxFiles:  container.LogRotation.MaxFiles,
+		})
+		if err != nil {
+			r.logger.WarnContext(r.ctx, "failed to rotate container log",
+				"cell", cell.Metadata.Name, "container", container.ID, "path", path, "error", err)
+			continue
+		}
+		if rotated {
+			r.logger.DebugContext(r.ctx, "rotated container log",
+				"cell", cell.Metadata.Name, "container", container.ID, "path", path)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/util/fs"
+	"github.com/eminwux/kukeon/internal/util/logrotate"
+)
+
+func TestRotateCellLogs_RotatesOnlyContainersWithPolicy(t *testing.T) {
+	runPath := t.TempDir()
+	r := &Exec{
+		ctx:    context.Background(),
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		opts:   Options{RunPath: runPath},
+	}
+	policy := &intmodel.ContainerLogRotation{MaxSizeMB: 1, MaxFiles: 2}
+	cell := intmodel.Cell{
+		Metadata: intmodel.CellMetadata{Name: "c1"},
+		Spec: intmodel.CellSpec{
+			RealmName: "r1", SpaceName: "s1", StackName: "st1",
+			Containers: []intmodel.ContainerSpec{
+				{ID: "root", Root: true, LogRotation: policy},
+				{ID: "app", LogRotation: policy},
+				{ID: "plain"},
+			},
+		},
+	}
+
+	logs := make(map[string]string, len(cell.Spec.Containers))
+	for _, container := range cell.Spec.Containers {
+		path := fs.ContainerLogPath(runPath, "r1", "s1", "st1", "c1", container.ID)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, bytes.Repeat([]byte("x"), 2<<20), 0o640); err != nil {
+			t.Fatalf("write log: %v", err)
+		}
+		logs[container.ID] = path
+	}
+
+	r.rotateCellLogs(cell)
+
+	if _, err := os.Stat(logrotate.Segment(logs["app"], 1)); err != nil {
+		t.Errorf("app log was not rotated: %v", err)
+	}
+	for _, id := range []string{"root", "plain"} {
+		if _, err := os.Stat(logrotate.Segment(logs[id], 1)); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s log rotated without an applicable policy: %v", id, err)
+		}
+	}
+}
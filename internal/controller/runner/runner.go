@@ -89,16 +89,30 @@ type Runner interface {
 	GetCell(cell intmodel.Cell) (intmodel.Cell, error)
 	ListCells(realmName, spaceName, stackName string) ([]intmodel.Cell, error)
 	ListContainers(realmName, spaceName, stackName, cellName string) ([]intmodel.ContainerSpec, error)
-	CreateCell(cell intmodel.Cell) (intmodel.Cell, error)
+	ListAllCells() ([]intmodel.Cell, error)
+	ListAllContainers() ([]intmodel.ContainerSpec, error)
+	// ListContainersPage is the bounded-memory form of ListContainers: one
+	// page of at most limit containers after continueToken, plus the token
+	// for the next page ("" once the listing is complete).
+	ListContainersPage(
+		realmName, spaceName, stackName, cellName string,
+		limit int,
+		continueToken string,
+	) ([]intmodel.ContainerSpec, string, error)
+	// CreateCell, StartCell, CreateContainer, and PurgeRealm take the
+	// caller's context so the runner's step spans (cgroup create, container
+	// create, CNI attach) record as children of the caller's trace span.
+	// Cancellation and logging still follow the runner's own context.
+	CreateCell(ctx context.Context, cell intmodel.Cell) (intmodel.Cell, error)
 	EnsureCell(cell intmodel.Cell) (intmodel.Cell, error)
-	StartCell(cell intmodel.Cell) (intmodel.Cell, error)
+	StartCell(ctx context.Context, cell intmodel.Cell) (intmodel.Cell, error)
 	StopCell(cell intmodel.Cell) (intmodel.Cell, error)
 	StartContainer(cell intmodel.Cell, containerID string) (intmodel.Cell, error)
 	StopContainer(cell intmodel.Cell, containerID string) error
 	KillCell(cell intmodel.Cell) (intmodel.Cell, error)
 	KillContainer(cell intmodel.Cell, containerID string) error
 	DeleteContainer(cell intmodel.Cell, containerID string) error
-	CreateContainer(cell intmodel.Cell, container intmodel.ContainerSpec) (intmodel.Cell, error)
+	CreateContainer(ctx context.Context, cell intmodel.Cell, container intmodel.ContainerSpec) (intmodel.Cell, error)
 	EnsureContainer(cell intmodel.Cell, container intmodel.ContainerSpec) (intmodel.Cell, error)
 	UpdateCell(cell intmodel.Cell) (intmodel.Cell, error)
 	RecreateCell(cell intmodel.Cell) (intmodel.Cell, error)
@@ -106,6 +120,9 @@ type Runner interface {
 	UpdateCellMetadata(cell intmodel.Cell) error
 	ExistsCellRootContainer(cell intmodel.Cell) (bool, error)
 	DeleteCell(cell intmodel.Cell) error
+	// RenameCell moves a stopped cell to newName within its stack, carrying
+	// its metadata subtree and rewriting the child container references.
+	RenameCell(cell intmodel.Cell, newName string) (intmodel.Cell, error)
 
 	// ReapplyAttachableSocketPerms re-asserts the mode and group of a single
 	// live attachable container's tty control socket inode on the attach
@@ -234,8 +251,15 @@ type Runner interface {
 	DeleteConfig(config intmodel.CellConfig) error
 
 	ExistsCgroup(doc any) (bool, error)
-
-	PurgeRealm(realm intmodel.Realm) (namespaceRemoved bool, err error)
+	// CellCgroupUsage reads the live memory, pids, and cpu counters of the
+	// cell's cgroup. Metrics whose controller is not enabled are left nil.
+	CellCgroupUsage(cell intmodel.Cell) (ctr.CgroupUsage, error)
+	// KukeonCgroupUsage reads the same counters from the kukeon root cgroup,
+	// which aggregates every realm. Returns errdefs.ErrKukeonCgroupNotFound
+	// when nothing has been provisioned yet.
+	KukeonCgroupUsage() (ctr.CgroupUsage, error)
+
+	PurgeRealm(ctx context.Context, realm intmodel.Realm) (namespaceRemoved bool, err error)
 	PurgeSpace(space intmodel.Space) error
 	PurgeStack(stack intmodel.Stack) error
 	PurgeCell(cell intmodel.Cell) error
@@ -256,8 +280,21 @@ type Runner interface {
 	// cell whose Spec.AutoDelete=true survives a daemon restart without
 	// needing the daemon to re-install per-cell goroutines on startup.
 	ReconcileCell(cell intmodel.Cell) (intmodel.Cell, ReconcileOutcome, error)
+	// WatchContainerExits records container exits into ContainerStatus as
+	// containerd publishes them. Blocks until ctx is cancelled or the event
+	// subscription fails.
+	WatchContainerExits(ctx context.Context) error
 
 	GetContainerState(cell intmodel.Cell, containerID string) (intmodel.ContainerState, error)
+	// ContainerTaskPID returns the host PID of the named container's running
+	// task. Returns errdefs.ErrTaskNotRunning when the task is not Running.
+	// Used by `kuke cp` to reach the container's filesystem view through
+	// /proc/<pid>/root.
+	ContainerTaskPID(cell intmodel.Cell, containerID string) (uint32, error)
+	// InspectContainer returns the named container's OCI runtime spec and
+	// containerd record for `kuke inspect`. Works whether or not a task is
+	// running.
+	InspectContainer(cell intmodel.Cell, containerID string) (ctr.ContainerInspection, error)
 
 	// LoadImage imports an OCI/docker image tarball into the given
 	// containerd namespace and returns the names of the imported images.
@@ -292,6 +329,15 @@ type Runner interface {
 	// images and snapshots backing live containers untouched.
 	PruneImages(namespace string) (ctr.PruneResult, error)
 
+	// FindOrphanContainers reports the kukeon-labeled containers in the
+	// realm's containerd namespace that no cell metadata owns;
+	// DeleteOrphanContainer tears one of them down.
+	FindOrphanContainers(realm intmodel.Realm) ([]OrphanContainer, error)
+	DeleteOrphanContainer(realm intmodel.Realm, orphan OrphanContainer) error
+	// PruneCellContainers tears down the workload containers labeled for
+	// the cell that its spec no longer declares (`kuke apply --prune`).
+	PruneCellContainers(cell intmodel.Cell) ([]string, error)
+
 	Close() error
 }
 
@@ -367,6 +413,11 @@ type Exec struct {
 	// reconstructing the full containerd fake StartContainer needs — the same
 	// injection pattern nowFn / diskSampler use.
 	restartContainerFn func(cell intmodel.Cell, containerID string) (intmodel.Cell, error)
+
+	// hookRunFn executes one cell lifecycle hook command. nil falls through
+	// to runHookCommand (/bin/sh -c on the host); tests override it to record
+	// invocations without spawning a shell.
+	hookRunFn func(ctx context.Context, command string, env []string) error
 }
 
 type Options struct {
@@ -377,6 +428,9 @@ type Options struct {
 	// even when one is present and its bridge name matches SafeBridgeName. Set by
 	// `kuke init --force-regenerate-cni` as an operator escape hatch.
 	ForceRegenerateCNI bool
+	// ContainerdTimeout bounds each connection attempt to containerd. Zero
+	// falls back to ctr.DefaultConnectTimeout.
+	ContainerdTimeout time.Duration
 	// KukeonGroupGID, when non-zero, is the numeric GID of the kukeon system
 	// group. The runner uses it to chown the host-side per-container tty
 	// directory created for Attachable containers, so that members of the
@@ -404,6 +458,10 @@ type Options struct {
 	// set bypasses the guard. Zero (the default) disables it. Plumbed from
 	// controller.Options of the same name. Issue #1035.
 	DiskPressureBlockPercent int
+	// DockerConfigPath is the Docker CLI config whose registry credentials
+	// are merged under each realm's own for image pulls. Empty falls back to
+	// ctr.DockerConfigPath ($DOCKER_CONFIG, then ~/.docker/config.json).
+	DockerConfigPath string
 }
 
 func NewRunner(ctx context.Context, logger *slog.Logger, opts Options) Runner {
@@ -448,6 +506,13 @@ func (r *Exec) daemonDefaultBuildOpts() []ctr.BuildOption {
 	return opts
 }
 
+// containerBuildOpts is daemonDefaultBuildOpts plus the realm-scoped
+// defaults every container spec build needs: currently the realm's default
+// containerd snapshotter, which a container-level Snapshotter overrides.
+func (r *Exec) containerBuildOpts(realm intmodel.Realm) []ctr.BuildOption {
+	return append(r.daemonDefaultBuildOpts(), ctr.WithDefaultSnapshotter(realm.Spec.Snapshotter))
+}
+
 func (r *Exec) BootstrapCNI(cfgDir, cacheDir, binDir string) (cni.BootstrapReport, error) {
 	// Delegate to cni package bootstrap; empty params will default.
 	return cni.BootstrapCNI(cfgDir, cacheDir, binDir)
@@ -468,7 +533,11 @@ func (r *Exec) ensureClientConnected() error {
 		// &Exec{ctrClient: fake}) survives — only a runner that started with
 		// no client builds the real one here.
 		if r.ctrClient == nil {
-			r.ctrClient = ctr.NewClient(r.ctx, r.logger, r.opts.ContainerdSocket)
+			r.ctrClient = ctr.NewClientWithOptions(r.ctx, r.logger, r.opts.ContainerdSocket, ctr.ClientOptions{
+				ConnectTimeout:      r.opts.ContainerdTimeout,
+				MaxReconnects:       ctr.DefaultMaxReconnects,
+				HealthCheckInterval: ctr.DefaultHealthCheckInterval,
+			})
 		}
 	})
 	return r.ctrClient.Connect()
@@ -0,0 +1,132 @@
+//go:build !integration
+
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // drives unexported createCellContainers against a fake ctr client
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/metadata"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/util/fs"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+)
+
+// snapshotterRecorderClient records the snapshotter each container create
+// call resolves to: the root goes through CreateContainer with a built
+// ctr.ContainerSpec, workloads through CreateContainerFromSpec with the
+// build options the client would apply.
+type snapshotterRecorderClient struct {
+	*recreateCellFakeClient
+	got map[string]string
+}
+
+func (c *snapshotterRecorderClient) CreateContainer(
+	_ string, spec ctr.ContainerSpec, _ []ctr.RegistryCredentials,
+) (containerd.Container, error) {
+	c.got[spec.ID] = spec.Snapshotter
+	//nolint:nilnil // the provisioning path only checks the error
+	return nil, nil
+}
+
+func (c *snapshotterRecorderClient) CreateContainerFromSpec(
+	_ string, spec intmodel.ContainerSpec, _ []ctr.RegistryCredentials, opts ...ctr.BuildOption,
+) (containerd.Container, error) {
+	built := ctr.BuildContainerSpec(spec, opts...)
+	c.got[built.ID] = built.Snapshotter
+	//nolint:nilnil // the provisioning path only checks the error
+	return nil, nil
+}
+
+// TestCreateCellContainers_ThreadsSnapshotter asserts the realm's default
+// snapshotter reaches the create call of every container that does not pick
+// its own, and that a container-level override wins.
+func TestCreateCellContainers_ThreadsSnapshotter(t *testing.T) {
+	const (
+		realm = "default"
+		space = "default"
+		stack = "default"
+		cell  = "web"
+	)
+	rootID := space + "_" + stack + "_" + cell + "_root"
+	appID := space + "_" + stack + "_" + cell + "_app"
+	sidecarID := space + "_" + stack + "_" + cell + "_sidecar"
+
+	fake := &snapshotterRecorderClient{
+		recreateCellFakeClient: &recreateCellFakeClient{deleteCellFakeClient: &deleteCellFakeClient{}},
+		got:                    map[string]string{},
+	}
+	r := newRecreateCellTestExec(t, fake.recreateCellFakeClient)
+	r.ctrClient = fake
+
+	realmDoc := v1beta1.RealmDoc{
+		APIVersion: v1beta1.APIVersionV1Beta1,
+		Kind:       v1beta1.KindRealm,
+		Metadata:   v1beta1.RealmMetadata{Name: realm},
+		Spec:       v1beta1.RealmSpec{Namespace: realm + ".kukeon.io", Snapshotter: "native"},
+	}
+	path := fs.RealmMetadataPath(r.opts.RunPath, realm)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir realm metadata dir: %v", err)
+	}
+	if err := metadata.WriteMetadata(r.ctx, r.logger, realmDoc, path); err != nil {
+		t.Fatalf("write realm metadata: %v", err)
+	}
+	seedRecreateCellSpace(t, r, realm, space)
+
+	c := intmodel.Cell{
+		Metadata: intmodel.CellMetadata{Name: cell},
+		Spec: intmodel.CellSpec{
+			ID:              cell,
+			RealmName:       realm,
+			SpaceName:       space,
+			StackName:       stack,
+			RootContainerID: "root",
+			Containers: []intmodel.ContainerSpec{
+				{ID: "root", Root: true, HostNetwork: true, Image: "alpine:3.18", ContainerdID: rootID},
+				{ID: "app", Image: "alpine:3.18", ContainerdID: appID},
+				{ID: "sidecar", Image: "alpine:3.18", ContainerdID: sidecarID, Snapshotter: "overlayfs"},
+			},
+		},
+	}
+
+	if _, err := r.createCellContainers(r.ctx, &c); err != nil {
+		t.Fatalf("createCellContainers: %v", err)
+	}
+
+	want := map[string]string{
+		rootID:    "native",
+		appID:     "native",
+		sidecarID: "overlayfs",
+	}
+	for id, snap := range want {
+		got, ok := fake.got[id]
+		if !ok {
+			t.Errorf("container %q was not created", id)
+			continue
+		}
+		if got != snap {
+			t.Errorf("container %q snapshotter = %q, want %q", id, got, snap)
+		}
+	}
+}
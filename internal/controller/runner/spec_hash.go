@@ -55,10 +55,15 @@ const SpecHashVersionLabelKey = "kukeon.io/spec-hash-version"
 // the same edit by pinning the version to the payload's reflected field set.
 // History: "1" (issue #867, original domain) → "2" (#1001, widened the
 // root-container diff to all spec fields) → "3" (#1155, added Volumes,
-// Secrets, WorkingDir, SecurityOpts) → "4" (#1252, added Devices). A cell
-// stamped under an older version is re-stamped from its authoritative on-disk
-// spec on the next start rather than refused. Issue #1171.
-const SpecHashDomainVersion = "4"
+// Secrets, WorkingDir, SecurityOpts) → "4" (#1252, added Devices) → "5"
+// (added Snapshotter) → "6" (added NoNewPrivileges) → "7" (added WritableTmp)
+// → "8" (added SupplementaryGroups) → "9" (added Sysctls) → "10" (added
+// DiskQuota) → "11" (added OOMScoreAdj) → "12" (added
+// TerminationMessagePath) → "13" (added SeccompProfile) → "14" (added
+// AppArmorProfile). A cell stamped under an older version is re-stamped from
+// its authoritative on-disk spec on the next start rather than refused.
+// Issue #1171.
+const SpecHashDomainVersion = "14"
 
 // containerSpecHashPayload is the deterministic projection of an
 // intmodel.ContainerSpec that ComputeContainerSpecHash hashes. The field set
@@ -86,14 +91,24 @@ type containerSpecHashPayload struct {
 	WorkingDir             string                  `json:"workingDir"`
 	Privileged             bool                    `json:"privileged"`
 	User                   string                  `json:"user"`
+	SupplementaryGroups    []int                   `json:"supplementaryGroups"`
 	ReadOnlyRootFilesystem bool                    `json:"readOnlyRootFilesystem"`
+	WritableTmp            *bool                   `json:"writableTmp"`
 	Capabilities           capabilitiesHashPayload `json:"capabilities"`
 	SecurityOpts           []string                `json:"securityOpts"`
+	SeccompProfile         string                  `json:"seccompProfile"`
+	AppArmorProfile        string                  `json:"appArmorProfile"`
+	NoNewPrivileges        bool                    `json:"noNewPrivileges"`
+	Sysctls                map[string]string       `json:"sysctls"`
+	OOMScoreAdj            *int                    `json:"oomScoreAdj"`
+	TerminationMessagePath string                  `json:"terminationMessagePath"`
 	Devices                []string                `json:"devices"`
 	Tmpfs                  []tmpfsHashPayload      `json:"tmpfs"`
 	Resources              resourcesHashPayload    `json:"resources"`
 	Volumes                []volumeHashPayload     `json:"volumes"`
 	Secrets                []secretHashPayload     `json:"secrets"`
+	Snapshotter            string                  `json:"snapshotter"`
+	DiskQuotaBytes         int64                   `json:"diskQuota"`
 }
 
 type capabilitiesHashPayload struct {
@@ -155,14 +170,24 @@ func ComputeContainerSpecHash(spec intmodel.ContainerSpec) string {
 		WorkingDir:             spec.WorkingDir,
 		Privileged:             spec.Privileged,
 		User:                   spec.User,
+		SupplementaryGroups:    normalizeInts(spec.SupplementaryGroups),
 		ReadOnlyRootFilesystem: spec.ReadOnlyRootFilesystem,
+		WritableTmp:            spec.WritableTmp,
 		Capabilities:           projectCapabilities(spec.Capabilities),
 		SecurityOpts:           normalizeStrings(spec.SecurityOpts),
+		SeccompProfile:         spec.SeccompProfile,
+		AppArmorProfile:        spec.AppArmorProfile,
+		NoNewPrivileges:        spec.NoNewPrivileges,
+		Sysctls:                normalizeStringMap(spec.Sysctls),
+		OOMScoreAdj:            spec.OOMScoreAdj,
+		TerminationMessagePath: spec.TerminationMessagePath,
 		Devices:                normalizeStrings(spec.Devices),
 		Tmpfs:                  projectTmpfs(spec.Tmpfs),
 		Resources:              projectResources(spec.Resources),
 		Volumes:                projectVolumes(spec.Volumes),
 		Secrets:                projectSecrets(spec.Secrets),
+		Snapshotter:            spec.Snapshotter,
+		DiskQuotaBytes:         projectDiskQuota(spec.DiskQuota),
 	}
 	// json.Marshal on a struct with a fixed field order is deterministic.
 	// Errors are not possible here (payload is plain comparable types).
@@ -181,6 +206,24 @@ func normalizeStrings(s []string) []string {
 	return s
 }
 
+// normalizeStringMap replaces a nil map with a non-nil empty map so the JSON
+// projection produces `{}` rather than `null`. json.Marshal sorts map keys, so
+// the projection is deterministic.
+func normalizeStringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
+
+// normalizeInts is normalizeStrings for int slices.
+func normalizeInts(s []int) []int {
+	if s == nil {
+		return []int{}
+	}
+	return s
+}
+
 func projectCapabilities(c *intmodel.ContainerCapabilities) capabilitiesHashPayload {
 	if c == nil {
 		return capabilitiesHashPayload{Add: []string{}, Drop: []string{}}
@@ -214,6 +257,13 @@ func projectResources(r *intmodel.ContainerResources) resourcesHashPayload {
 	}
 }
 
+func projectDiskQuota(q *intmodel.ContainerDiskQuota) int64 {
+	if q == nil {
+		return 0
+	}
+	return q.SizeBytes
+}
+
 func derefInt64(p *int64) int64 {
 	if p == nil {
 		return 0
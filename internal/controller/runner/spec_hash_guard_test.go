@@ -27,6 +27,7 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	cgroup2 "github.com/containerd/cgroups/v2/cgroup2"
 	apitypes "github.com/containerd/containerd/api/types"
@@ -96,9 +97,13 @@ func (c *specHashFakeClient) CleanupNamespaceResources(string, string) error {
 	return nil
 }
 
+func (c *specHashFakeClient) GetCgroupMode() ctr.CgroupMode             { return ctr.CgroupModeUnified }
 func (c *specHashFakeClient) GetCgroupMountpoint() string               { return "" }
 func (c *specHashFakeClient) GetCurrentCgroupPath() (string, error)     { return "", nil }
 func (c *specHashFakeClient) CgroupPath(string, string) (string, error) { return "", nil }
+func (c *specHashFakeClient) CgroupUsage(string, string) (ctr.CgroupUsage, error) {
+	return ctr.CgroupUsage{}, nil
+}
 
 // NewCgroup / LoadCgroup return nil, nil — cgroup2.Manager has unexported
 // fields so a zero value satisfies *Exec call sites the spec-hash guard
@@ -176,6 +181,18 @@ func (c *specHashFakeClient) TaskMetrics(string, string) (*apitypes.Metric, erro
 	return nil, nil //nolint:nilnil
 }
 
+func (c *specHashFakeClient) TaskPID(string, string) (uint32, error) {
+	return 0, nil
+}
+
+func (c *specHashFakeClient) ResizeProcess(string, string, string, uint32, uint32) error {
+	return nil
+}
+
+func (c *specHashFakeClient) ExecProcess(string, string, []string, time.Duration) (ctr.ExecResult, error) {
+	return ctr.ExecResult{}, nil
+}
+
 func (c *specHashFakeClient) ContainerProcessUID(string, containerd.Container) (uint32, error) {
 	return 0, nil
 }
@@ -184,9 +201,16 @@ func (c *specHashFakeClient) ListImages(string) ([]ctr.ImageInfo, error)    { re
 func (c *specHashFakeClient) GetImage(string, string) (ctr.ImageInfo, error) {
 	return ctr.ImageInfo{}, nil
 }
+func (c *specHashFakeClient) PullImage(string, string, []ctr.RegistryCredentials) (ctr.ImageInfo, error) {
+	return ctr.ImageInfo{}, nil
+}
 func (c *specHashFakeClient) ImageChainID(string, string) (string, error)         { return "", nil }
 func (c *specHashFakeClient) ContainerRootChainID(string, string) (string, error) { return "", nil }
+func (c *specHashFakeClient) ContainerImageDigest(string, string) (string, error) { return "", nil }
 func (c *specHashFakeClient) DeleteImage(string, string) error                    { return nil }
+func (c *specHashFakeClient) InspectContainer(string, string) (ctr.ContainerInspection, error) {
+	return ctr.ContainerInspection{}, nil
+}
 func (c *specHashFakeClient) PruneImages(string) (ctr.PruneResult, error) {
 	return ctr.PruneResult{}, nil
 }
@@ -195,6 +219,10 @@ func (c *specHashFakeClient) NamespaceStorage(string) (ctr.StorageStats, error)
 	return ctr.StorageStats{}, nil
 }
 
+func (c *specHashFakeClient) SubscribeTaskExits(context.Context) (<-chan ctr.TaskExit, <-chan error) {
+	return nil, nil
+}
+
 var _ ctr.Client = (*specHashFakeClient)(nil)
 
 func newSpecHashTestExec(fake *specHashFakeClient) *Exec {
@@ -474,6 +502,67 @@ func TestSpecHashDomainVersionPinsToPayload(t *testing.T) {
 			"readOnlyRootFilesystem", "resources", "secrets", "securityOpts",
 			"tmpfs", "user", "volumes", "workingDir",
 		},
+		"5": {
+			"args", "capabilities", "command", "devices", "image", "privileged",
+			"readOnlyRootFilesystem", "resources", "secrets", "securityOpts",
+			"snapshotter", "tmpfs", "user", "volumes", "workingDir",
+		},
+		"6": {
+			"args", "capabilities", "command", "devices", "image", "noNewPrivileges",
+			"privileged", "readOnlyRootFilesystem", "resources", "secrets",
+			"securityOpts", "snapshotter", "tmpfs", "user", "volumes", "workingDir",
+		},
+		"7": {
+			"args", "capabilities", "command", "devices", "image", "noNewPrivileges",
+			"privileged", "readOnlyRootFilesystem", "resources", "secrets",
+			"securityOpts", "snapshotter", "tmpfs", "user", "volumes", "workingDir",
+			"writableTmp",
+		},
+		"8": {
+			"args", "capabilities", "command", "devices", "image", "noNewPrivileges",
+			"privileged", "readOnlyRootFilesystem", "resources", "secrets",
+			"securityOpts", "snapshotter", "supplementaryGroups", "tmpfs", "user",
+			"volumes", "workingDir", "writableTmp",
+		},
+		"9": {
+			"args", "capabilities", "command", "devices", "image", "noNewPrivileges",
+			"privileged", "readOnlyRootFilesystem", "resources", "secrets",
+			"securityOpts", "snapshotter", "supplementaryGroups", "sysctls", "tmpfs",
+			"user", "volumes", "workingDir", "writableTmp",
+		},
+		"10": {
+			"args", "capabilities", "command", "devices", "diskQuota", "image",
+			"noNewPrivileges", "privileged", "readOnlyRootFilesystem", "resources",
+			"secrets", "securityOpts", "snapshotter", "supplementaryGroups", "sysctls",
+			"tmpfs", "user", "volumes", "workingDir", "writableTmp",
+		},
+		"11": {
+			"args", "capabilities", "command", "devices", "diskQuota", "image",
+			"noNewPrivileges", "oomScoreAdj", "privileged", "readOnlyRootFilesystem",
+			"resources", "secrets", "securityOpts", "snapshotter", "supplementaryGroups",
+			"sysctls", "tmpfs", "user", "volumes", "workingDir", "writableTmp",
+		},
+		"12": {
+			"args", "capabilities", "command", "devices", "diskQuota", "image",
+			"noNewPrivileges", "oomScoreAdj", "privileged", "readOnlyRootFilesystem",
+			"resources", "secrets", "securityOpts", "snapshotter", "supplementaryGroups",
+			"sysctls", "terminationMessagePath", "tmpfs", "user", "volumes", "workingDir",
+			"writableTmp",
+		},
+		"13": {
+			"args", "capabilities", "command", "devices", "diskQuota", "image",
+			"noNewPrivileges", "oomScoreAdj", "privileged", "readOnlyRootFilesystem",
+			"resources", "seccompProfile", "secrets", "securityOpts", "snapshotter",
+			"supplementaryGroups", "sysctls", "terminationMessagePath", "tmpfs", "user",
+			"volumes", "workingDir", "writableTmp",
+		},
+		"14": {
+			"appArmorProfile", "args", "capabilities", "command", "devices", "diskQuota",
+			"image", "noNewPrivileges", "oomScoreAdj", "privileged", "readOnlyRootFilesystem",
+			"resources", "seccompProfile", "secrets", "securityOpts", "snapshotter",
+			"supplementaryGroups", "sysctls", "terminationMessagePath", "tmpfs", "user",
+			"volumes", "workingDir", "writableTmp",
+		},
 	}
 
 	want, ok := domainFieldSets[SpecHashDomainVersion]
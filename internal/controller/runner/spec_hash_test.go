@@ -109,6 +109,8 @@ func TestComputeContainerSpecHash_BreakingFieldsChangeHash(t *testing.T) {
 		// OCI-baked fields reclassified Breaking-on-root by issue #1154.
 		{"workingDir", func(s *intmodel.ContainerSpec) { s.WorkingDir = "/opt/app" }},
 		{"securityOpts", func(s *intmodel.ContainerSpec) { s.SecurityOpts = []string{"no-new-privileges"} }},
+		{"seccompProfile", func(s *intmodel.ContainerSpec) { s.SeccompProfile = "unconfined" }},
+		{"appArmorProfile", func(s *intmodel.ContainerSpec) { s.AppArmorProfile = "unconfined" }},
 		{"volumes", func(s *intmodel.ContainerSpec) {
 			s.Volumes = []intmodel.VolumeMount{{Source: "/host", Target: "/cell"}}
 		}},
@@ -238,6 +240,8 @@ func TestSpecHashDomainPinsToDiffCellBreakingFields(t *testing.T) {
 		// must change the hash so the bare-start drift guard catches them too.
 		{"workingDir", func(s *intmodel.ContainerSpec) { s.WorkingDir = "/opt/app" }, true},
 		{"securityOpts", func(s *intmodel.ContainerSpec) { s.SecurityOpts = []string{"no-new-privileges"} }, true},
+		{"seccompProfile", func(s *intmodel.ContainerSpec) { s.SeccompProfile = "unconfined" }, true},
+		{"appArmorProfile", func(s *intmodel.ContainerSpec) { s.AppArmorProfile = "unconfined" }, true},
 		{"devices", func(s *intmodel.ContainerSpec) { s.Devices = []string{"/dev/kvm"} }, true},
 		{"volumes", func(s *intmodel.ContainerSpec) {
 			s.Volumes = []intmodel.VolumeMount{{Source: "/host", Target: "/cell"}}
@@ -17,6 +17,7 @@
 package runner
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
@@ -30,6 +31,7 @@ import (
 	"github.com/eminwux/kukeon/internal/ctr"
 	internalerrdefs "github.com/eminwux/kukeon/internal/errdefs"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/tracing"
 	"github.com/eminwux/kukeon/internal/util/fs"
 	"github.com/eminwux/kukeon/internal/util/naming"
 )
@@ -210,13 +212,107 @@ func verifyCellTasksLiveAfterStart(
 	}
 }
 
+// cellReadyPollInterval is the cadence of waitCellTasksReady. Coarser than
+// the liveness poll: the wait is opt-in and measured in seconds, so there is
+// no latency budget to protect.
+const cellReadyPollInterval = 250 * time.Millisecond
+
+// waitCellTasksReady polls statusFn until the root task and every non-root
+// task in nonRootSpecs report containerd.Running, or until timeout lapses.
+// The cell model has no readiness probe, so a running task is the readiness
+// signal. A task observed Stopped fails the wait at once — nothing in
+// StartCell will restart it — while Created, Unknown and status-probe errors
+// are retried. Either failure wraps internalerrdefs.ErrCellNotReady with one
+// diagnostic per container that is not ready, so `kuke create cell --wait`
+// can say which ones to look at. Same injectable shape as
+// verifyCellTasksLiveAfterStart.
+func waitCellTasksReady(
+	cellName, rootContainerdID string,
+	nonRootSpecs []intmodel.ContainerSpec,
+	statusFn func(id string) (containerd.Status, error),
+	timeout, pollInterval time.Duration,
+	nowFn func() time.Time,
+	sleepFn func(time.Duration),
+) error {
+	waiting := []*notReadyContainer{
+		{label: fmt.Sprintf("root container %q", rootContainerdID), id: rootContainerdID},
+	}
+	for _, c := range nonRootSpecs {
+		if c.Root {
+			continue
+		}
+		waiting = append(waiting, &notReadyContainer{
+			label: fmt.Sprintf("container %q", c.ID),
+			id:    strings.TrimSpace(c.ContainerdID),
+		})
+	}
+
+	deadline := nowFn().Add(timeout)
+	for {
+		var stillWaiting, exited []*notReadyContainer
+		for _, p := range waiting {
+			if p.id == "" {
+				p.last = "no containerd ID"
+				exited = append(exited, p)
+				continue
+			}
+			st, err := statusFn(p.id)
+			switch {
+			case err != nil:
+				p.last = fmt.Sprintf("status probe failed: %v", err)
+			case st.Status == containerd.Running:
+				continue
+			default:
+				p.last = "task status=" + string(st.Status)
+			}
+			if err == nil && st.Status == containerd.Stopped {
+				exited = append(exited, p)
+				continue
+			}
+			stillWaiting = append(stillWaiting, p)
+		}
+		if len(exited) > 0 {
+			return formatNotReady(cellName, "exited before becoming ready", exited)
+		}
+		if len(stillWaiting) == 0 {
+			return nil
+		}
+		if !nowFn().Before(deadline) {
+			return formatNotReady(cellName, fmt.Sprintf("not ready after %s", timeout), stillWaiting)
+		}
+		waiting = stillWaiting
+		sleepFn(pollInterval)
+	}
+}
+
+// notReadyContainer tracks one task waitCellTasksReady has not yet seen
+// running, with the last status it observed.
+type notReadyContainer struct {
+	label string
+	id    string
+	last  string
+}
+
+// formatNotReady renders the per-container diagnostic for waitCellTasksReady.
+func formatNotReady(cellName, reason string, containers []*notReadyContainer) error {
+	parts := make([]string, 0, len(containers))
+	for _, c := range containers {
+		parts = append(parts, c.label+" ("+c.last+")")
+	}
+	return fmt.Errorf("%w: cell %q: %s: %s; run `kuke log %s` for details",
+		internalerrdefs.ErrCellNotReady, cellName, reason, strings.Join(parts, ", "), cellName)
+}
+
 // containerLogTaskSpec returns a TaskSpec with cio.LogFile IO pointed at the
-// per-container log path for a non-Attachable container. Returns the zero
-// TaskSpec for Attachable containers (sbsh's capture file already covers
+// per-container log path for a non-Attachable container, and has the log of
+// the task it replaces archived to the previous-log path (`kuke log
+// --previous`). Returns the zero TaskSpec for Attachable containers (sbsh's capture file already covers
 // them) and for Root containers (pause-style — no useful stdout). Bytes flow
-// from the runtime shim into the file; `kuke log` later reads from the same
-// path. Centralised here so all three StartContainer call sites pick up the
-// same policy.
+// from the runtime shim into the file — or, with SeparateStreams or
+// OpenStdin, from the task's stdout/stderr fifos copied by the daemon, which
+// then also feeds the task's stdin — and `kuke log` later
+// reads from the same path. Centralised here so all three StartContainer call
+// sites pick up the same policy.
 func (r *Exec) containerLogTaskSpec(spec intmodel.ContainerSpec) ctr.TaskSpec {
 	if spec.Attachable || spec.Root {
 		return ctr.TaskSpec{}
@@ -227,6 +323,14 @@ func (r *Exec) containerLogTaskSpec(spec intmodel.ContainerSpec) ctr.TaskSpec {
 				r.opts.RunPath,
 				spec.RealmName, spec.SpaceName, spec.StackName, spec.CellName, spec.ID,
 			),
+			PreviousLogFilePath: fs.ContainerPreviousLogPath(
+				r.opts.RunPath,
+				spec.RealmName, spec.SpaceName, spec.StackName, spec.CellName, spec.ID,
+			),
+			SeparateStreams: spec.SeparateStreams,
+			OpenStdin:       spec.OpenStdin,
+			StdinOnce:       spec.StdinOnce,
+			StdinFile:       spec.StdinFile,
 		},
 	}
 }
@@ -471,15 +575,18 @@ func (r *Exec) markCellFailed(cell intmodel.Cell, reason string, cause error) {
 // any containers that did start are killed — issue #407. Errors raised before
 // the provisioning phase (input validation, realm lookup, idempotent-skip
 // path) leave the cell's persisted state alone.
-func (r *Exec) StartCell(cell intmodel.Cell) (intmodel.Cell, error) {
+func (r *Exec) StartCell(ctx context.Context, cell intmodel.Cell) (intmodel.Cell, error) {
+	ctx, span := tracing.Start(ctx, "runner.StartCell", cellSpanAttributes(cell)...)
 	defer r.lockCell(cell)()
-	return r.startCellLocked(cell)
+	out, err := r.startCellLocked(ctx, cell)
+	tracing.End(span, err)
+	return out, err
 }
 
 // startCellLocked is the body of StartCell. The caller must hold the per-cell
 // lifecycle lock (the StartCell wrapper, or a nesting op such as RecreateCell
 // that already holds it). It must not be called without the lock held.
-func (r *Exec) startCellLocked(cell intmodel.Cell) (_ intmodel.Cell, retErr error) {
+func (r *Exec) startCellLocked(ctx context.Context, cell intmodel.Cell) (_ intmodel.Cell, retErr error) {
 	// provisionStarted gates the defer below: only flip the cell to Failed
 	// when we've already entered the destructive recreate path. Validation
 	// errors (missing cell name, missing realm) and the idempotent-skip
@@ -533,6 +640,8 @@ func (r *Exec) startCellLocked(cell intmodel.Cell) (_ intmodel.Cell, retErr erro
 	// own preservation hop) threaded in, so copy it forward before the OCI
 	// build path observes internalCell. Issue #834.
 	internalCell.Spec.RuntimeEnv = cell.Spec.RuntimeEnv
+	// WaitReadySeconds is transport-only for the same reason.
+	internalCell.Spec.WaitReadySeconds = cell.Spec.WaitReadySeconds
 	cellForCleanup = internalCell
 
 	cellSpec := internalCell.Spec
@@ -574,7 +683,7 @@ func (r *Exec) startCellLocked(cell intmodel.Cell) (_ intmodel.Cell, retErr erro
 		return intmodel.Cell{}, fmt.Errorf("realm %q has no namespace", realmID)
 	}
 
-	creds := ctr.ConvertRealmCredentials(internalRealm.Spec.RegistryCredentials)
+	creds := r.registryCredentials(internalRealm)
 
 	// Generate containerd ID with cell identifier for uniqueness
 	containerID, err := naming.BuildRootContainerdID(spaceID, stackID, cellID)
@@ -616,10 +725,17 @@ func (r *Exec) startCellLocked(cell intmodel.Cell) (_ intmodel.Cell, retErr erro
 		return internalCell, nil
 	}
 
+	// Host-side preStart hooks run once the cell is known to need starting
+	// and before any containerd state is touched, so a failing hook leaves
+	// the cell exactly as it was.
+	if err = r.runPreStartHooks(internalCell); err != nil {
+		return intmodel.Cell{}, err
+	}
+
 	// Resolve rootContainerSpec early so we can compute the spec-hash and
 	// decide whether to reuse the existing containerd record (overlay
 	// preserved) or create a fresh one — issue #867.
-	rootContainerSpec, err := r.ensureCellRootContainerSpec(internalCell)
+	rootContainerSpec, err := r.ensureCellRootContainerSpec(internalRealm, internalCell)
 	if err != nil {
 		return intmodel.Cell{}, fmt.Errorf("failed to get root container spec: %w", err)
 	}
@@ -767,7 +883,7 @@ func (r *Exec) startCellLocked(cell intmodel.Cell) (_ intmodel.Cell, retErr erro
 	// #867 AC #4.
 	if !reuseExistingRoot {
 		rootLabels := stampSpecHashOnLabels(buildRootContainerLabels(internalCell), rootContainerSpec)
-		ctrContainerSpec := ctr.BuildRootContainerSpec(rootContainerSpec, rootLabels, r.daemonDefaultBuildOpts()...)
+		ctrContainerSpec := ctr.BuildRootContainerSpec(rootContainerSpec, rootLabels, r.containerBuildOpts(internalRealm)...)
 
 		_, err = r.ctrClient.CreateContainer(namespace, ctrContainerSpec, creds)
 		if err != nil {
@@ -814,11 +930,12 @@ func (r *Exec) startCellLocked(cell intmodel.Cell) (_ intmodel.Cell, retErr erro
 		UTS: fmt.Sprintf("/proc/%d/ns/uts", rootPID),
 	}
 
-	// CNI ADD's IPv4 result, if any. Captured here so the post-attach
-	// /etc/hosts re-render below can read it after the if/else block. nil
-	// for host-network cells (CNI skipped) and on the idempotent-skip path
-	// when the libcni cache lookup also fails. Issue #345.
-	var cellIP net.IP
+	// CNI ADD's addresses, if any. Captured here so the post-attach
+	// /etc/hosts re-render and the status update below can read them after
+	// the if/else block. Empty for host-network cells (CNI skipped) and on
+	// the idempotent-skip path when the libcni cache lookup also fails.
+	// Issue #345.
+	var cellAddrs cni.ContainerAddresses
 
 	// Host-netns root containers (e.g. kukeond) have no per-container veth to
 	// wire up — CNI attach would create a host-side bridge inside the daemon's
@@ -910,82 +1027,123 @@ func (r *Exec) startCellLocked(cell intmodel.Cell) (_ intmodel.Cell, retErr erro
 			return intmodel.Cell{}, fmt.Errorf("failed to load CNI config %s: %w", cniConfigPath, loadErr)
 		}
 
+		bandwidth, bwErr := cellBandwidthLimits(internalCell)
+		if bwErr != nil {
+			return intmodel.Cell{}, fmt.Errorf("cell %q: %w", cellName, bwErr)
+		}
+
 		netnsPath := namespacePaths.Net
-		var addErr error
-		cellIP, addErr = cniMgr.AddContainerToNetwork(r.ctx, containerID, netnsPath)
-		if addErr != nil {
-			// The bridge plugin's "container veth name … already exists" is
-			// the one genuinely idempotent failure — a prior ADD reached veth
-			// setup before crashing, so eth0 and its IPAM record are intact
-			// and the retry can proceed. Match it via the typed sentinel so
-			// unrelated "already exists" / "file exists" plugin errors (IP
-			// conflicts, route duplicates, IPAM duplicate allocation,
-			// iptables) surface instead of being silently swallowed.
-			if errors.Is(addErr, internalerrdefs.ErrCNIVethExists) {
-				fields = appendCellLogFields([]any{"id", containerID}, cellID, cellName)
-				fields = append(
-					fields,
-					"space",
-					spaceID,
-					"realm",
-					realmID,
-					"cniConfig",
-					cniConfigPath,
-					"netns",
-					netnsPath,
-					"err",
-					addErr.Error(),
-				)
-				// INFO so the idempotent-skip is visible without
-				// --log-level debug; the original error message goes in the
-				// "err" field so a real failure misclassified as idempotent
-				// is still traceable.
-				r.logger.InfoContext(
-					r.ctx,
-					"root container already attached to network, skipping CNI ADD",
-					fields...,
-				)
-				// Idempotent-skip path: the CNI ADD didn't run, so no fresh
-				// result was returned. The IPAM allocation persisted in the
-				// libcni cache from the prior successful run — recover it so
-				// /etc/hosts can still carry the cell IP. Issue #345.
-				if cellIP == nil {
-					cellIP = cniMgr.CachedIPv4ForContainer(containerID, netnsPath)
-				}
-			} else {
-				// Log the actual CNI bin dir value being used (may be empty, which causes the error)
-				// Note: NewManager creates CNI config with this value BEFORE applying defaults,
-				// so if empty, the CNI config will search in an empty path array
-				cniBinDirValue := r.cniConf.CniBinDir
-				fields = appendCellLogFields([]any{"id", containerID}, cellID, cellName)
-				fields = append(
-					fields,
-					"space",
-					spaceID,
-					"realm",
-					realmID,
-					"cniConfig",
-					cniConfigPath,
-					"netns",
-					netnsPath,
-					"cniBinDir",
-					cniBinDirValue,
-					"err",
-					fmt.Sprintf("%v", addErr),
-				)
-				if cniBinDirValue == "" {
+		// A restart that reuses a live root task keeps its netns, and with it
+		// the eth0 and address a previous CNI ADD configured. When the netns
+		// still holds every address the cell status recorded, skip ADD
+		// outright instead of re-running it and recognizing the failure by its
+		// message. Without a recorded address (first start, older metadata)
+		// or when it is gone (new netns), ADD runs as before.
+		if storedAddrs, attached := attachedStoredAddresses(rootPID, internalCell.Status.Network); attached {
+			skipFields := appendCellLogFields([]any{"id", containerID}, cellID, cellName)
+			skipFields = append(skipFields, "space", spaceID, "realm", realmID, "netns", netnsPath,
+				"ipv4", ipString(storedAddrs.IPv4), "ipv6", ipString(storedAddrs.IPv6))
+			r.logger.InfoContext(
+				r.ctx,
+				"root container netns already holds its recorded address, skipping CNI ADD",
+				skipFields...,
+			)
+			cellAddrs = storedAddrs
+		} else {
+			var addErr error
+			_, cniSpan := tracing.Start(ctx, "runner.cniAttach", cellSpanAttributes(internalCell)...)
+			retryFields := appendCellLogFields([]any{"id", containerID}, cellID, cellName)
+			retryFields = append(retryFields, "space", spaceID, "realm", realmID, "netns", netnsPath)
+			cellAddrs, addErr = addContainerToNetworkWithRetry(
+				r.ctx,
+				r.logger,
+				cniMgr,
+				containerID,
+				netnsPath,
+				bandwidth,
+				cniAddMaxAttempts,
+				cniAddRetryBaseDelay,
+				time.Sleep,
+				retryFields,
+			)
+			tracing.End(cniSpan, addErr)
+			if addErr != nil {
+				// The bridge plugin's "container veth name … already exists" is
+				// the one genuinely idempotent failure — a prior ADD reached veth
+				// setup before crashing, so eth0 and its IPAM record are intact
+				// and the retry can proceed. Match it via the typed sentinel so
+				// unrelated "already exists" / "file exists" plugin errors (IP
+				// conflicts, route duplicates, IPAM duplicate allocation,
+				// iptables) surface instead of being silently swallowed. With
+				// a recorded address the skip above normally catches this
+				// case first; the match remains the fallback for cells
+				// without one.
+				if errors.Is(addErr, internalerrdefs.ErrCNIVethExists) {
+					fields = appendCellLogFields([]any{"id", containerID}, cellID, cellName)
+					fields = append(
+						fields,
+						"space",
+						spaceID,
+						"realm",
+						realmID,
+						"cniConfig",
+						cniConfigPath,
+						"netns",
+						netnsPath,
+						"err",
+						addErr.Error(),
+					)
+					// INFO so the idempotent-skip is visible without
+					// --log-level debug; the original error message goes in the
+					// "err" field so a real failure misclassified as idempotent
+					// is still traceable.
+					r.logger.InfoContext(
+						r.ctx,
+						"root container already attached to network, skipping CNI ADD",
+						fields...,
+					)
+					// Idempotent-skip path: the CNI ADD didn't run, so no fresh
+					// result was returned. The IPAM allocation persisted in the
+					// libcni cache from the prior successful run — recover it so
+					// /etc/hosts can still carry the cell IP. Issue #345.
+					if cellAddrs.IPv4 == nil && cellAddrs.IPv6 == nil {
+						cellAddrs = cniMgr.CachedAddressesForContainer(containerID, netnsPath)
+					}
+				} else {
+					// Log the actual CNI bin dir value being used (may be empty, which causes the error)
+					// Note: NewManager creates CNI config with this value BEFORE applying defaults,
+					// so if empty, the CNI config will search in an empty path array
+					cniBinDirValue := r.cniConf.CniBinDir
+					fields = appendCellLogFields([]any{"id", containerID}, cellID, cellName)
 					fields = append(
 						fields,
-						"cniBinDirNote",
-						"empty path - CNI config was created with empty plugin search path, default /opt/cni/bin not applied to CNI config",
+						"space",
+						spaceID,
+						"realm",
+						realmID,
+						"cniConfig",
+						cniConfigPath,
+						"netns",
+						netnsPath,
+						"cniBinDir",
+						cniBinDirValue,
+						"err",
+						fmt.Sprintf("%v", addErr),
+					)
+					if cniBinDirValue == "" {
+						fields = append(
+							fields,
+							"cniBinDirNote",
+							"empty path - CNI config was created with empty plugin search path, default /opt/cni/bin not applied to CNI config",
+						)
+					}
+					r.logger.ErrorContext(
+						r.ctx,
+						"failed to attach root container to network",
+						fields...,
 					)
+					return intmodel.Cell{}, fmt.Errorf("failed to attach root container %s to network: %w", containerID, addErr)
 				}
-				r.logger.ErrorContext(
-					r.ctx,
-					"failed to attach root container to network",
-					fields...,
-				)
-				return intmodel.Cell{}, fmt.Errorf("failed to attach root container %s to network: %w", containerID, addErr)
 			}
 		}
 	}
@@ -1006,7 +1164,9 @@ func (r *Exec) startCellLocked(cell intmodel.Cell) (_ intmodel.Cell, retErr erro
 	// renderer no-ops in either case. Stamp source paths onto every non-root
 	// containerSpec next so the bind-mount entries make it onto their OCI
 	// specs as the loop below recreates them. Issue #345.
-	if cellIP != nil {
+	internalCell.Status.Network.IPv4 = ipString(cellAddrs.IPv4)
+	internalCell.Status.Network.IPv6 = ipString(cellAddrs.IPv6)
+	if cellIP := cellAddrs.IPv4; cellIP != nil {
 		if etcErr := r.renderCellEtcHostsWithIP(&internalCell, cellIP); etcErr != nil {
 			r.logger.WarnContext(r.ctx,
 				"failed to re-render cell /etc/hosts with cell IP",
@@ -1091,7 +1251,7 @@ func (r *Exec) startCellLocked(cell intmodel.Cell) (_ intmodel.Cell, retErr erro
 			)
 		}
 		if !reuseExistingChild {
-			buildOpts := append(r.daemonDefaultBuildOpts(), attachOpts...)
+			buildOpts := append(r.containerBuildOpts(internalRealm), attachOpts...)
 			buildOpts = append(buildOpts, ctr.WithExtraLabels(specHashLabels(containerSpec)))
 			// `kuke run --env` runtime-env merge (issue #834). Same shape as the
 			// CreateCell-side merge in provision.go: returns containerSpec
@@ -1181,6 +1341,10 @@ func (r *Exec) startCellLocked(cell intmodel.Cell) (_ intmodel.Cell, retErr erro
 			"started container",
 			fields...,
 		)
+
+		if hookErr := r.runPostStartHooks(namespace, containerSpec); hookErr != nil {
+			return intmodel.Cell{}, hookErr
+		}
 	}
 
 	// Post-start liveness probe (issue #851). containerd accepting task
@@ -1218,6 +1382,33 @@ func (r *Exec) startCellLocked(cell intmodel.Cell) (_ intmodel.Cell, retErr erro
 		return intmodel.Cell{}, liveErr
 	}
 
+	// Opt-in readiness wait (`kuke create cell --wait`): the liveness probe
+	// above only proves nothing died instantly; this holds the reply until
+	// every task is running, or fails the start like the liveness probe does.
+	if wait := internalCell.Spec.WaitReadySeconds; wait > 0 {
+		if readyErr := waitCellTasksReady(
+			cellName,
+			containerID,
+			nonRootContainerSpecsFromCell(internalCell),
+			func(id string) (containerd.Status, error) {
+				return r.ctrClient.TaskStatus(namespace, id)
+			},
+			time.Duration(wait)*time.Second,
+			cellReadyPollInterval,
+			time.Now,
+			time.Sleep,
+		); readyErr != nil {
+			readyFields := appendCellLogFields([]any{"id", containerID}, cellID, cellName)
+			readyFields = append(readyFields, "space", spaceID, "realm", realmID, "err", readyErr.Error())
+			r.logger.ErrorContext(
+				r.ctx,
+				"readiness wait failed; cell will transition to Failed",
+				readyFields...,
+			)
+			return intmodel.Cell{}, readyErr
+		}
+	}
+
 	markCellReady(&internalCell)
 
 	// Populate container statuses after starting cell and persist them
@@ -1289,7 +1480,7 @@ func (r *Exec) StartContainer(cell intmodel.Cell, containerID string) (_ intmode
 		return intmodel.Cell{}, fmt.Errorf("realm %q has no namespace", realmName)
 	}
 
-	creds := ctr.ConvertRealmCredentials(internalRealm.Spec.RegistryCredentials)
+	creds := r.registryCredentials(internalRealm)
 
 	// Find container in cell spec by ID (base name)
 	var foundContainerSpec *intmodel.ContainerSpec
@@ -1409,7 +1600,7 @@ func (r *Exec) StartContainer(cell intmodel.Cell, containerID string) (_ intmode
 		return intmodel.Cell{}, fmt.Errorf("failed to prepare attachable container %s: %w", containerID, attachErr)
 	}
 	if !reuseExistingChild {
-		buildOpts := append(r.daemonDefaultBuildOpts(), attachOpts...)
+		buildOpts := append(r.containerBuildOpts(internalRealm), attachOpts...)
 		buildOpts = append(buildOpts, ctr.WithExtraLabels(specHashLabels(*foundContainerSpec)))
 		_, err = r.ctrClient.CreateContainerFromSpec(namespace, *foundContainerSpec, creds, buildOpts...)
 		if err != nil {
@@ -1499,6 +1690,10 @@ func (r *Exec) StartContainer(cell intmodel.Cell, containerID string) (_ intmode
 		startedFields...,
 	)
 
+	if hookErr := r.runPostStartHooks(namespace, *foundContainerSpec); hookErr != nil {
+		return intmodel.Cell{}, hookErr
+	}
+
 	// Get the cell again to ensure we have the latest state
 	lookupCell := intmodel.Cell{
 		Metadata: intmodel.CellMetadata{
@@ -1567,3 +1762,21 @@ func (r *Exec) StartContainer(cell intmodel.Cell, containerID string) (_ intmode
 
 	return updatedCell, nil
 }
+
+// ipString renders ip for the cell's network status, "" when nil.
+func ipString(ip net.IP) string {
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
+
+// cellBandwidthLimits parses the cell's bandwidth spec into the limits the
+// CNI bandwidth plugin expects, or nil when the cell has none.
+func cellBandwidthLimits(cell intmodel.Cell) (*cni.BandwidthLimits, error) {
+	bw := cell.Spec.Bandwidth
+	if bw == nil {
+		return nil, nil //nolint:nilnil // nil limits leave the cell unshaped
+	}
+	return cni.ParseBandwidthLimits(bw.IngressRate, bw.IngressBurst, bw.EgressRate, bw.EgressBurst)
+}
@@ -787,6 +787,117 @@ func TestVerifyCellTasksLiveAfterStart_SingleContainerVariant(t *testing.T) {
 	})
 }
 
+// TestWaitCellTasksReady pins the opt-in readiness wait behind
+// `kuke create cell --wait`: it returns once every task runs, fails fast on
+// a task that exited, and names each straggler when the timeout lapses.
+func TestWaitCellTasksReady(t *testing.T) {
+	const (
+		cellName = "web"
+		rootID   = "space_stack_web_root"
+		appID    = "cid_app"
+		sideID   = "cid_side"
+	)
+	nonRoot := []intmodel.ContainerSpec{
+		{ID: "app", ContainerdID: appID},
+		{ID: "side", ContainerdID: sideID},
+	}
+	fakeClock := func() (func() time.Time, func(time.Duration), *time.Duration) {
+		var elapsed time.Duration
+		return func() time.Time { return time.Unix(0, 0).Add(elapsed) },
+			func(d time.Duration) { elapsed += d },
+			&elapsed
+	}
+
+	t.Run("all_ready", func(t *testing.T) {
+		// app is still Created on the first two probes, then comes up.
+		appProbes := 0
+		statusFn := func(id string) (containerd.Status, error) {
+			if id == appID {
+				appProbes++
+				if appProbes <= 2 {
+					return containerd.Status{Status: containerd.Created}, nil
+				}
+			}
+			return containerd.Status{Status: containerd.Running}, nil
+		}
+		now, sleep, elapsed := fakeClock()
+
+		err := waitCellTasksReady(cellName, rootID, nonRoot, statusFn, 10*time.Second, time.Second, now, sleep)
+		if err != nil {
+			t.Fatalf("waitCellTasksReady returned %v, want nil", err)
+		}
+		if *elapsed != 2*time.Second {
+			t.Errorf("elapsed = %v, want 2s (return as soon as the last task runs)", *elapsed)
+		}
+	})
+
+	t.Run("one_never_ready_times_out", func(t *testing.T) {
+		statusFn := func(id string) (containerd.Status, error) {
+			if id == sideID {
+				return containerd.Status{Status: containerd.Created}, nil
+			}
+			return containerd.Status{Status: containerd.Running}, nil
+		}
+		now, sleep, elapsed := fakeClock()
+
+		err := waitCellTasksReady(cellName, rootID, nonRoot, statusFn, 5*time.Second, time.Second, now, sleep)
+		if !errors.Is(err, internalerrdefs.ErrCellNotReady) {
+			t.Fatalf("err = %v, want errors.Is(_, ErrCellNotReady)", err)
+		}
+		msg := err.Error()
+		for _, want := range []string{`"web"`, "not ready after 5s", `container "side" (task status=created)`} {
+			if !strings.Contains(msg, want) {
+				t.Errorf("error %q missing fragment %q", msg, want)
+			}
+		}
+		if strings.Contains(msg, `"app"`) {
+			t.Errorf("error %q names ready container app", msg)
+		}
+		if *elapsed < 5*time.Second {
+			t.Errorf("elapsed = %v, want the full 5s timeout", *elapsed)
+		}
+	})
+
+	t.Run("exited_task_fails_fast", func(t *testing.T) {
+		statusFn := func(id string) (containerd.Status, error) {
+			if id == appID {
+				return containerd.Status{Status: containerd.Stopped}, nil
+			}
+			return containerd.Status{Status: containerd.Running}, nil
+		}
+		now, sleep, elapsed := fakeClock()
+
+		err := waitCellTasksReady(cellName, rootID, nonRoot, statusFn, time.Minute, time.Second, now, sleep)
+		if !errors.Is(err, internalerrdefs.ErrCellNotReady) {
+			t.Fatalf("err = %v, want errors.Is(_, ErrCellNotReady)", err)
+		}
+		if !strings.Contains(err.Error(), `container "app" (task status=stopped)`) {
+			t.Errorf("error %q does not name the exited container", err)
+		}
+		if *elapsed != 0 {
+			t.Errorf("elapsed = %v, want 0 (a stopped task cannot become ready)", *elapsed)
+		}
+	})
+
+	t.Run("status_errors_are_retried", func(t *testing.T) {
+		rootProbes := 0
+		statusFn := func(id string) (containerd.Status, error) {
+			if id == rootID {
+				rootProbes++
+				if rootProbes == 1 {
+					return containerd.Status{}, errors.New("transient")
+				}
+			}
+			return containerd.Status{Status: containerd.Running}, nil
+		}
+		now, sleep, _ := fakeClock()
+
+		if err := waitCellTasksReady(cellName, rootID, nonRoot, statusFn, 5*time.Second, time.Second, now, sleep); err != nil {
+			t.Fatalf("waitCellTasksReady returned %v, want nil after the retry", err)
+		}
+	})
+}
+
 // TestNonRootContainerSpecsFromCell verifies the StartCell-side adapter
 // that flattens cell.Spec.Containers to the non-root subset the liveness
 // probe iterates. Two shapes matter: cells with no non-root entries
@@ -1127,3 +1238,43 @@ func TestMarkCellFailed_PreStampsBeforeKillCell(t *testing.T) {
 		)
 	}
 }
+
+// TestCellBandwidthLimits covers the spec → CNI runtime-config step of
+// StartCell: the cell's unit-suffixed bandwidth strings become the bits and
+// bits-per-second the bandwidth plugin reads from its capability args.
+func TestCellBandwidthLimits(t *testing.T) {
+	cell := intmodel.Cell{Spec: intmodel.CellSpec{Bandwidth: &intmodel.CellBandwidth{
+		IngressRate:  "10Mbit",
+		IngressBurst: "1Mbit",
+		EgressRate:   "2mbps",
+		EgressBurst:  "64kbit",
+	}}}
+	limits, err := cellBandwidthLimits(cell)
+	if err != nil {
+		t.Fatalf("cellBandwidthLimits() error = %v", err)
+	}
+	args, ok := limits.CapabilityArgs()["bandwidth"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("CapabilityArgs() = %v, want a bandwidth entry", limits.CapabilityArgs())
+	}
+	want := map[string]uint64{
+		"ingressRate":  10_000_000,
+		"ingressBurst": 1_000_000,
+		"egressRate":   16_000_000,
+		"egressBurst":  64_000,
+	}
+	for key, value := range want {
+		if args[key] != value {
+			t.Errorf("bandwidth %s = %v, want %d", key, args[key], value)
+		}
+	}
+
+	if limits, err = cellBandwidthLimits(intmodel.Cell{}); err != nil || limits != nil {
+		t.Errorf("cellBandwidthLimits() without spec = %v, %v; want nil, nil", limits, err)
+	}
+
+	cell.Spec.Bandwidth.EgressRate = "fast"
+	if _, err = cellBandwidthLimits(cell); !errors.Is(err, internalerrdefs.ErrInvalidBandwidth) {
+		t.Errorf("cellBandwidthLimits() with bad unit error = %v, want ErrInvalidBandwidth", err)
+	}
+}
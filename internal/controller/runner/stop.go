@@ -149,7 +149,7 @@ func (r *Exec) stopCellLocked(cell intmodel.Cell) (intmodel.Cell, error) {
 		}
 
 		// Use container name with UUID for containerd operations
-		timeout := 5 * time.Second
+		timeout := r.runPreStopHooks(namespace, containerSpec)
 		_, err = r.ctrClient.StopContainer(namespace, containerID, ctr.StopContainerOptions{
 			Force:   true,
 			Timeout: &timeout,
@@ -320,6 +320,8 @@ func (r *Exec) stopCellLocked(cell intmodel.Cell) (intmodel.Cell, error) {
 		// Continue anyway - status population is best-effort
 	}
 
+	r.runPostStopHooks(internalCell)
+
 	return internalCell, nil
 }
 
@@ -404,7 +406,7 @@ func (r *Exec) StopContainer(cell intmodel.Cell, containerID string) error {
 	}
 
 	// Use containerd ID for containerd operations
-	timeout := 5 * time.Second
+	timeout := r.runPreStopHooks(namespace, *foundContainerSpec)
 	_, err = r.ctrClient.StopContainer(namespace, containerdID, ctr.StopContainerOptions{
 		Force:   true,
 		Timeout: &timeout,
@@ -28,6 +28,7 @@ import (
 	"path/filepath"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	cgroup2 "github.com/containerd/cgroups/v2/cgroup2"
 	apitypes "github.com/containerd/containerd/api/types"
@@ -48,6 +49,7 @@ import (
 type stopKillFakeClient struct {
 	stopContainerFn       func(namespace, id string, opts ctr.StopContainerOptions) (*containerd.ExitStatus, error)
 	killContainerTaskFn   func(namespace, id string) error
+	execProcessFn         func(namespace, id string, args []string, timeout time.Duration) (ctr.ExecResult, error)
 	deleteContainerCalls  int64
 	stopContainerCalls    int64
 	killContainerTaskHits int64
@@ -65,9 +67,13 @@ func (c *stopKillFakeClient) CleanupNamespaceResources(string, string) error {
 	return nil
 }
 
+func (c *stopKillFakeClient) GetCgroupMode() ctr.CgroupMode             { return ctr.CgroupModeUnified }
 func (c *stopKillFakeClient) GetCgroupMountpoint() string               { return "" }
 func (c *stopKillFakeClient) GetCurrentCgroupPath() (string, error)     { return "", nil }
 func (c *stopKillFakeClient) CgroupPath(string, string) (string, error) { return "", nil }
+func (c *stopKillFakeClient) CgroupUsage(string, string) (ctr.CgroupUsage, error) {
+	return ctr.CgroupUsage{}, nil
+}
 func (c *stopKillFakeClient) NewCgroup(ctr.CgroupSpec) (*cgroup2.Manager, error) {
 	//nolint:nilnil // cgroup2.Manager has unexported fields; the test path discards the value
 	return nil, nil
@@ -145,6 +151,25 @@ func (c *stopKillFakeClient) TaskMetrics(string, string) (*apitypes.Metric, erro
 	return nil, nil
 }
 
+func (c *stopKillFakeClient) TaskPID(string, string) (uint32, error) {
+	return 0, nil
+}
+
+func (c *stopKillFakeClient) ResizeProcess(string, string, string, uint32, uint32) error {
+	return nil
+}
+
+func (c *stopKillFakeClient) ExecProcess(
+	namespace, id string,
+	args []string,
+	timeout time.Duration,
+) (ctr.ExecResult, error) {
+	if c.execProcessFn != nil {
+		return c.execProcessFn(namespace, id, args, timeout)
+	}
+	return ctr.ExecResult{}, nil
+}
+
 func (c *stopKillFakeClient) ContainerProcessUID(string, containerd.Container) (uint32, error) {
 	return 0, nil
 }
@@ -159,6 +184,10 @@ func (c *stopKillFakeClient) GetImage(string, string) (ctr.ImageInfo, error) {
 	return ctr.ImageInfo{}, nil
 }
 
+func (c *stopKillFakeClient) PullImage(string, string, []ctr.RegistryCredentials) (ctr.ImageInfo, error) {
+	return ctr.ImageInfo{}, nil
+}
+
 func (c *stopKillFakeClient) ImageChainID(string, string) (string, error) {
 	return "", nil
 }
@@ -167,6 +196,14 @@ func (c *stopKillFakeClient) ContainerRootChainID(string, string) (string, error
 	return "", nil
 }
 
+func (c *stopKillFakeClient) ContainerImageDigest(string, string) (string, error) {
+	return "", nil
+}
+
+func (c *stopKillFakeClient) InspectContainer(string, string) (ctr.ContainerInspection, error) {
+	return ctr.ContainerInspection{}, nil
+}
+
 func (c *stopKillFakeClient) DeleteImage(string, string) error { return nil }
 func (c *stopKillFakeClient) PruneImages(string) (ctr.PruneResult, error) {
 	return ctr.PruneResult{}, nil
@@ -176,6 +213,10 @@ func (c *stopKillFakeClient) NamespaceStorage(string) (ctr.StorageStats, error)
 	return ctr.StorageStats{}, nil
 }
 
+func (c *stopKillFakeClient) SubscribeTaskExits(context.Context) (<-chan ctr.TaskExit, <-chan error) {
+	return nil, nil
+}
+
 var _ ctr.Client = (*stopKillFakeClient)(nil)
 
 func newStopKillTestExec(t *testing.T, fake *stopKillFakeClient) *Exec {
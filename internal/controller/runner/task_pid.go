@@ -0,0 +1,108 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/consts"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/util/naming"
+)
+
+// ContainerTaskPID returns the host PID of the running task backing the
+// named container in cell. Returns errdefs.ErrContainerNotFound when the
+// container is not part of the cell's spec and errdefs.ErrTaskNotRunning
+// when containerd has no Running task for it — the PID of a dead task is
+// never surfaced because /proc/<pid> may already belong to an unrelated
+// process.
+func (r *Exec) ContainerTaskPID(cell intmodel.Cell, containerID string) (uint32, error) {
+	namespace, containerdID, err := r.containerdTarget(cell, containerID)
+	if err != nil {
+		return 0, err
+	}
+	if err = r.ensureClientConnected(); err != nil {
+		return 0, fmt.Errorf("%w: %w", errdefs.ErrConnectContainerd, err)
+	}
+	pid, err := r.ctrClient.TaskPID(namespace, containerdID)
+	if err != nil {
+		return 0, err
+	}
+	if pid == 0 {
+		return 0, fmt.Errorf("%w: container %q reported no pid", errdefs.ErrTaskNotRunning, containerID)
+	}
+	return pid, nil
+}
+
+// containerdTarget resolves the containerd namespace and containerd ID of
+// the named container in cell. The namespace comes from the owning realm's
+// persisted spec (falling back to the derived <realm> namespace for realms
+// written before the field existed); the ID comes from the container spec's
+// ContainerdID, or is rebuilt from the cell coordinates when unset.
+func (r *Exec) containerdTarget(cell intmodel.Cell, containerID string) (string, string, error) {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return "", "", errdefs.ErrContainerNameRequired
+	}
+	realmName := strings.TrimSpace(cell.Spec.RealmName)
+	if realmName == "" {
+		return "", "", errdefs.ErrRealmNameRequired
+	}
+
+	var spec *intmodel.ContainerSpec
+	for i := range cell.Spec.Containers {
+		if cell.Spec.Containers[i].ID == containerID {
+			spec = &cell.Spec.Containers[i]
+			break
+		}
+	}
+	if spec == nil {
+		return "", "", fmt.Errorf("%w: %q in cell %q", errdefs.ErrContainerNotFound, containerID, cell.Metadata.Name)
+	}
+
+	realm, err := r.GetRealm(intmodel.Realm{Metadata: intmodel.RealmMetadata{Name: realmName}})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get realm: %w", err)
+	}
+	namespace := realm.Spec.Namespace
+	if namespace == "" {
+		namespace = consts.RealmNamespace(realmName)
+	}
+
+	containerdID := spec.ContainerdID
+	if containerdID != "" {
+		return namespace, containerdID, nil
+	}
+	cellID := strings.TrimSpace(cell.Spec.ID)
+	if cellID == "" {
+		cellID = strings.TrimSpace(cell.Metadata.Name)
+	}
+	if cellID == "" {
+		return "", "", errdefs.ErrCellIDRequired
+	}
+	if spec.Root {
+		containerdID, err = naming.BuildRootContainerdID(cell.Spec.SpaceName, cell.Spec.StackName, cellID)
+	} else {
+		containerdID, err = naming.BuildContainerdID(cell.Spec.SpaceName, cell.Spec.StackName, cellID, spec.ID)
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to build containerd ID: %w", err)
+	}
+	return namespace, containerdID, nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	utilfs "github.com/eminwux/kukeon/internal/util/fs"
+)
+
+// terminationMessageMaxBytes caps how much of a container's termination-log
+// file is copied into ContainerStatus.TerminationMessage. Matches the
+// kubelet's per-container limit; the cell document is not a log store.
+const terminationMessageMaxBytes = 4096
+
+// terminationLogFileMode is world-writable so a workload running as a
+// non-root user can still write its exit reason through the bind-mount.
+const terminationLogFileMode = 0o666
+
+// terminationLogHostPath returns the host-side termination-log file of the
+// container, or "" when the cell's identity fields are incomplete.
+func (r *Exec) terminationLogHostPath(cell *intmodel.Cell, containerID string) string {
+	if cell == nil {
+		return ""
+	}
+	cellName := strings.TrimSpace(cell.Metadata.Name)
+	realmName := strings.TrimSpace(cell.Spec.RealmName)
+	spaceName := strings.TrimSpace(cell.Spec.SpaceName)
+	stackName := strings.TrimSpace(cell.Spec.StackName)
+	containerID = strings.TrimSpace(containerID)
+	if cellName == "" || realmName == "" || spaceName == "" || stackName == "" || containerID == "" {
+		return ""
+	}
+	return utilfs.ContainerTerminationLogPath(r.opts.RunPath, realmName, spaceName, stackName, cellName, containerID)
+}
+
+// stampTerminationMessageHostPath creates the container's termination-log
+// host file when absent and stamps TerminationMessageHostPath so
+// BuildContainerSpec emits the bind-mount. Root containers are skipped: they
+// hold the cell's namespaces and never carry a workload exit reason. A file
+// that cannot be created only disables the mount — the container still
+// starts, it just reports no termination message.
+func (r *Exec) stampTerminationMessageHostPath(spec *intmodel.ContainerSpec, cell *intmodel.Cell) {
+	if spec == nil || spec.Root {
+		return
+	}
+	path := r.terminationLogHostPath(cell, spec.ID)
+	if path == "" {
+		return
+	}
+	if err := ensureTerminationLogFile(path); err != nil {
+		r.logger.WarnContext(r.ctx, "failed to prepare termination-log file; termination message disabled",
+			"container", spec.ID,
+			"path", path,
+			"error", err)
+		spec.TerminationMessageHostPath = ""
+		return
+	}
+	spec.TerminationMessageHostPath = path
+}
+
+// ensureTerminationLogFile creates path (and its parent directory) as an
+// empty world-writable file when it does not exist yet. An existing file is
+// left untouched: a running container's bind-mount resolves to its inode.
+func ensureTerminationLogFile(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("stat %s: %w", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create container metadata dir: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, terminationLogFileMode)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", path, err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", path, err)
+	}
+	// OpenFile's mode is filtered through the umask; set it explicitly.
+	if err = os.Chmod(path, terminationLogFileMode); err != nil {
+		return fmt.Errorf("chmod %s: %w", path, err)
+	}
+	return nil
+}
+
+// consumeTerminationMessage returns the first terminationMessageMaxBytes of
+// the termination-log file at path with trailing whitespace trimmed, then
+// truncates the file in place so the container's next run starts from an
+// empty log and a later exit never re-reports a stale message. An absent or
+// unreadable file yields "".
+func consumeTerminationMessage(path string) string {
+	if path == "" {
+		return ""
+	}
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = f.Close() }()
+	data, err := io.ReadAll(io.LimitReader(f, terminationMessageMaxBytes))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return ""
+	}
+	if len(data) > 0 {
+		_ = f.Truncate(0)
+	}
+	return strings.TrimRight(string(data), " \t\r\n")
+}
+
+// captureTerminationMessage reads the termination log of the container whose
+// containerd ID is containerdID into its ContainerStatus.TerminationMessage.
+// An empty read keeps the recorded message: the log is consumed on first
+// read, so a second observation of the same exit finds it empty.
+func (r *Exec) captureTerminationMessage(cell *intmodel.Cell, containerdID string) {
+	for _, spec := range cell.Spec.Containers {
+		if spec.Root || containerdIDForSpec(*cell, spec) != containerdID {
+			continue
+		}
+		msg := consumeTerminationMessage(r.terminationLogHostPath(cell, spec.ID))
+		if msg == "" {
+			return
+		}
+		for i := range cell.Status.Containers {
+			if cell.Status.Containers[i].ID == spec.ID {
+				cell.Status.Containers[i].TerminationMessage = msg
+				return
+			}
+		}
+		return
+	}
+}
+
+// firstLine returns s up to its first newline, for one-line breadcrumbs.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return strings.TrimRight(s[:i], "\r")
+	}
+	return s
+}
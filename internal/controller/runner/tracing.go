@@ -0,0 +1,42 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runner
+
+import (
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// cellSpanAttributes tags a runner span with the cell's place in the
+// realm/space/stack hierarchy.
+func cellSpanAttributes(cell intmodel.Cell) []attribute.KeyValue {
+	return tracing.CellAttributes(
+		cell.Spec.RealmName, cell.Spec.SpaceName, cell.Spec.StackName, cell.Metadata.Name,
+	)
+}
+
+// containerSpanAttributes adds the container ID and image to the cell's
+// attributes. The image is included because a create on a cold node is
+// dominated by the image pull.
+func containerSpanAttributes(cell intmodel.Cell, spec intmodel.ContainerSpec) []attribute.KeyValue {
+	return append(
+		cellSpanAttributes(cell),
+		tracing.AttrContainer.String(spec.ID),
+		tracing.AttrImage.String(spec.Image),
+	)
+}
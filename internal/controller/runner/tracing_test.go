@@ -0,0 +1,122 @@
+//go:build !integration
+
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // drives unexported createCellContainers against a fake ctr client
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/metadata"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/tracing"
+	"github.com/eminwux/kukeon/internal/util/fs"
+	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// TestCreateCellContainers_RecordsChildSpans asserts every container create
+// is recorded as a child of the caller's span and tagged with the cell
+// hierarchy, the container ID, and its image.
+func TestCreateCellContainers_RecordsChildSpans(t *testing.T) {
+	const (
+		realm = "default"
+		space = "default"
+		stack = "default"
+		cell  = "web"
+	)
+	rec := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	fake := &snapshotterRecorderClient{
+		recreateCellFakeClient: &recreateCellFakeClient{deleteCellFakeClient: &deleteCellFakeClient{}},
+		got:                    map[string]string{},
+	}
+	r := newRecreateCellTestExec(t, fake.recreateCellFakeClient)
+	r.ctrClient = fake
+
+	realmDoc := v1beta1.RealmDoc{
+		APIVersion: v1beta1.APIVersionV1Beta1,
+		Kind:       v1beta1.KindRealm,
+		Metadata:   v1beta1.RealmMetadata{Name: realm},
+		Spec:       v1beta1.RealmSpec{Namespace: realm + ".kukeon.io"},
+	}
+	path := fs.RealmMetadataPath(r.opts.RunPath, realm)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir realm metadata dir: %v", err)
+	}
+	if err := metadata.WriteMetadata(r.ctx, r.logger, realmDoc, path); err != nil {
+		t.Fatalf("write realm metadata: %v", err)
+	}
+	seedRecreateCellSpace(t, r, realm, space)
+
+	c := intmodel.Cell{
+		Metadata: intmodel.CellMetadata{Name: cell},
+		Spec: intmodel.CellSpec{
+			ID:              cell,
+			RealmName:       realm,
+			SpaceName:       space,
+			StackName:       stack,
+			RootContainerID: "root",
+			Containers: []intmodel.ContainerSpec{
+				{ID: "root", Root: true, HostNetwork: true, Image: "alpine:3.18"},
+				{ID: "app", Image: "nginx:1.27"},
+			},
+		},
+	}
+
+	ctx, parent := tracing.Start(context.Background(), "controller.CreateCell")
+	if _, err := r.createCellContainers(ctx, &c); err != nil {
+		t.Fatalf("createCellContainers: %v", err)
+	}
+	parent.End()
+
+	images := map[string]string{}
+	for _, span := range rec.Ended() {
+		if span.Name() != "runner.createContainer" {
+			continue
+		}
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("span %q is not parented by the caller's span", span.Name())
+		}
+		attrs := map[string]string{}
+		for _, kv := range span.Attributes() {
+			attrs[string(kv.Key)] = kv.Value.AsString()
+		}
+		if attrs[string(tracing.AttrCell)] != cell || attrs[string(tracing.AttrRealm)] != realm {
+			t.Errorf("span attributes %v missing cell hierarchy", attrs)
+		}
+		images[attrs[string(tracing.AttrContainer)]] = attrs[string(tracing.AttrImage)]
+	}
+	want := map[string]string{"root": "alpine:3.18", "app": "nginx:1.27"}
+	if len(images) != len(want) {
+		t.Fatalf("container spans = %v, want %v", images, want)
+	}
+	for id, image := range want {
+		if images[id] != image {
+			t.Errorf("container %q image attribute = %q, want %q", id, images[id], image)
+		}
+	}
+}
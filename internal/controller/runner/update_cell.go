@@ -18,14 +18,16 @@ package runner
 
 import (
 	"fmt"
+	"maps"
 
+	"github.com/eminwux/kukeon/internal/consts"
 	"github.com/eminwux/kukeon/internal/errdefs"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 )
 
 // UpdateCell updates an existing cell with new metadata and container changes.
 // It handles:
-// - Metadata updates (labels)
+// - Metadata updates (labels, and annotations when applied)
 // - Container additions (containers in desired but not in actual)
 // - Container updates (containers in both, with spec changes)
 // - Container removals (orphans: containers in actual but not in desired)
@@ -45,6 +47,13 @@ func (r *Exec) UpdateCell(desired intmodel.Cell) (intmodel.Cell, error) {
 	// genuine spec change that legitimately routes through UpdateCell
 	// strips the canonical labels (issue #455).
 	existing.Metadata.Labels = mergeManagedLabels(existing.Metadata.Labels, desired.Metadata.Labels)
+	// Annotations are only taken from an apply, whose desired map is already
+	// three-way merged with the live one and carries the last-applied
+	// record. Other callers (the OutOfSync reapply on start) build desired
+	// from a config and would otherwise drop provenance annotations.
+	if _, applied := desired.Metadata.Annotations[consts.KukeonLastAppliedAnnotationKey]; applied {
+		existing.Metadata.Annotations = desired.Metadata.Annotations
+	}
 
 	// Build maps of desired and actual containers by ID
 	desiredContainers := make(map[string]*intmodel.ContainerSpec)
@@ -284,8 +293,11 @@ func (r *Exec) UpdateCell(desired intmodel.Cell) (intmodel.Cell, error) {
 // runner fixes at container create and never re-resolves on the in-place
 // task-restart path: image/command/args (snapshot + Process), workingDir
 // (Process.Cwd), securityOpts (Process.NoNewPrivileges / Linux.Seccomp),
-// devices (Linux.Devices + Linux.Resources.Devices, stat'd from the host node
-// at create), volumes (OCI Mounts), and secrets (env-injected Process.Env via
+// seccompProfile (Linux.Seccomp), appArmorProfile (Process.ApparmorProfile),
+// noNewPrivileges (Process.NoNewPrivileges), sysctls (Linux.Sysctl),
+// oomScoreAdj (Process.OOMScoreAdj), terminationMessagePath (the
+// termination-log bind-mount in OCI Mounts), devices (Linux.Devices +
+// Linux.Resources.Devices, stat'd from the host node at create), volumes (OCI Mounts), and secrets (env-injected Process.Env via
 // resolveSecrets, plus file-form Mounts). Without recreating, a secrets edit
 // on a workload container never reaches the running OCI Process.Env — the
 // defect issue #1154 fixes on the non-root side (the root side routes through
@@ -300,6 +312,12 @@ func containerSpecChanged(desired, actual *intmodel.ContainerSpec) bool {
 		!stringSlicesEqual(desired.Args, actual.Args) ||
 		desired.WorkingDir != actual.WorkingDir ||
 		!stringSlicesEqual(desired.SecurityOpts, actual.SecurityOpts) ||
+		desired.SeccompProfile != actual.SeccompProfile ||
+		desired.AppArmorProfile != actual.AppArmorProfile ||
+		desired.NoNewPrivileges != actual.NoNewPrivileges ||
+		!maps.Equal(desired.Sysctls, actual.Sysctls) ||
+		!intPtrEqual(desired.OOMScoreAdj, actual.OOMScoreAdj) ||
+		desired.TerminationMessagePath != actual.TerminationMessagePath ||
 		!stringSlicesEqual(desired.Devices, actual.Devices) ||
 		!volumeMountsEqual(desired.Volumes, actual.Volumes) ||
 		!containerSecretsEqual(desired.Secrets, actual.Secrets)
@@ -360,3 +378,12 @@ func stringSlicesEqual(a, b []string) bool {
 	}
 	return true // Same content means equal
 }
+
+// intPtrEqual reports whether two optional ints are both unset or both set to
+// the same value.
+func intPtrEqual(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -49,6 +49,8 @@ func TestContainerSpecChanged_OCIBakedFields(t *testing.T) {
 		// Fields newly gated by #1154 — must trigger a recreate.
 		{"workingDir", func(s *intmodel.ContainerSpec) { s.WorkingDir = "/opt/app" }, true},
 		{"securityOpts", func(s *intmodel.ContainerSpec) { s.SecurityOpts = []string{"no-new-privileges"} }, true},
+		{"seccompProfile", func(s *intmodel.ContainerSpec) { s.SeccompProfile = "unconfined" }, true},
+		{"appArmorProfile", func(s *intmodel.ContainerSpec) { s.AppArmorProfile = "unconfined" }, true},
 		{"volumes", func(s *intmodel.ContainerSpec) {
 			s.Volumes = []intmodel.VolumeMount{{Source: "/host", Target: "/cell"}}
 		}, true},
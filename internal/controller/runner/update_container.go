@@ -46,7 +46,7 @@ func (r *Exec) UpdateContainer(cell intmodel.Cell, desiredContainer intmodel.Con
 
 	if actualContainer == nil {
 		// Container doesn't exist, create it
-		return r.CreateContainer(cell, desiredContainer)
+		return r.CreateContainer(r.ctx, cell, desiredContainer)
 	}
 
 	// Check if container spec has breaking changes
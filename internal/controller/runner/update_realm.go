@@ -24,7 +24,9 @@ import (
 )
 
 // UpdateRealm updates an existing realm with new metadata and compatible spec fields.
-// It only updates fields that are backward-compatible (labels, registry credentials).
+// It only updates fields that are backward-compatible (labels, annotations,
+// registry credentials, the default snapshotter, the pause image, the
+// privileged policy).
 // Breaking changes (name, namespace) should be rejected before calling this method.
 func (r *Exec) UpdateRealm(desired intmodel.Realm) (intmodel.Realm, error) {
 	// Get existing realm
@@ -36,7 +38,18 @@ func (r *Exec) UpdateRealm(desired intmodel.Realm) (intmodel.Realm, error) {
 	// Update compatible fields. Preserve controller-managed `*.kukeon.io`
 	// canonical labels when the user's desired doc omits them (issue #455).
 	existing.Metadata.Labels = mergeManagedLabels(existing.Metadata.Labels, desired.Metadata.Labels)
+	existing.Metadata.Annotations = desired.Metadata.Annotations
 	existing.Spec.RegistryCredentials = desired.Spec.RegistryCredentials
+	existing.Spec.Snapshotter = desired.Spec.Snapshotter
+	existing.Spec.DefaultResources = desired.Spec.DefaultResources
+	existing.Spec.AllowPrivileged = desired.Spec.AllowPrivileged
+	existing.Spec.Quota = desired.Spec.Quota
+	if desired.Spec.PauseImage != existing.Spec.PauseImage {
+		existing.Spec.PauseImage = desired.Spec.PauseImage
+		if err = r.ensureRealmPauseImage(existing); err != nil {
+			return intmodel.Realm{}, err
+		}
+	}
 
 	// Update metadata file
 	if updateErr := r.UpdateRealmMetadata(existing); updateErr != nil {
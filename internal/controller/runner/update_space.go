@@ -24,7 +24,7 @@ import (
 )
 
 // UpdateSpace updates an existing space with new metadata and compatible spec fields.
-// It only updates fields that are backward-compatible (labels).
+// It only updates fields that are backward-compatible (labels, annotations).
 // Breaking changes (name, realm association, CNI config path) should be rejected before calling this method.
 func (r *Exec) UpdateSpace(desired intmodel.Space) (intmodel.Space, error) {
 	// Get existing space
@@ -36,7 +36,10 @@ func (r *Exec) UpdateSpace(desired intmodel.Space) (intmodel.Space, error) {
 	// Update compatible fields. Preserve controller-managed `*.kukeon.io`
 	// canonical labels when the user's desired doc omits them (issue #455).
 	existing.Metadata.Labels = mergeManagedLabels(existing.Metadata.Labels, desired.Metadata.Labels)
+	existing.Metadata.Annotations = desired.Metadata.Annotations
 	existing.Spec.Defaults = desired.Spec.Defaults
+	existing.Spec.DefaultResources = desired.Spec.DefaultResources
+	existing.Spec.Quota = desired.Spec.Quota
 	// Note: CNIConfigPath is not updated as it's a breaking change
 
 	// Update metadata file
@@ -24,7 +24,7 @@ import (
 )
 
 // UpdateStack updates an existing stack with new metadata and compatible spec fields.
-// It only updates fields that are backward-compatible (labels, ID).
+// It only updates fields that are backward-compatible (labels, annotations, ID).
 // Breaking changes (name, realm/space association) should be rejected before calling this method.
 func (r *Exec) UpdateStack(desired intmodel.Stack) (intmodel.Stack, error) {
 	// Get existing stack
@@ -36,6 +36,7 @@ func (r *Exec) UpdateStack(desired intmodel.Stack) (intmodel.Stack, error) {
 	// Update compatible fields. Preserve controller-managed `*.kukeon.io`
 	// canonical labels when the user's desired doc omits them (issue #455).
 	existing.Metadata.Labels = mergeManagedLabels(existing.Metadata.Labels, desired.Metadata.Labels)
+	existing.Metadata.Annotations = desired.Metadata.Annotations
 	if desired.Spec.ID != "" {
 		existing.Spec.ID = desired.Spec.ID
 	}
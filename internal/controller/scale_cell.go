@@ -0,0 +1,197 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/consts"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// ScaleCellResult reports how ScaleCell reconciled a template's replicas.
+// Each slice holds replica cell names in index order.
+type ScaleCellResult struct {
+	Template  string
+	Replicas  int
+	Created   []string
+	Removed   []string
+	Unchanged []string
+}
+
+// ScaleCell reconciles the replicas of a template cell to the requested
+// count. The template is an existing cell in the stack; replica i is a copy
+// of its spec named <template>-<i>, created through CreateCell so it gets its
+// own cgroup, root container and network attachment. Replicas are tracked by
+// the KukeonReplicaOfLabelKey label: indexes at or above replicas are deleted
+// via DeleteCell, missing indexes below it are created, and the rest are left
+// untouched. Scaling to zero removes every replica but never the template.
+func (b *Exec) ScaleCell(realm, space, stack, template string, replicas int) (ScaleCellResult, error) {
+	res := ScaleCellResult{Template: strings.TrimSpace(template), Replicas: replicas}
+	if replicas < 0 {
+		return res, fmt.Errorf("%w: %d", errdefs.ErrInvalidReplicas, replicas)
+	}
+
+	tmpl, err := b.getScaleTemplate(realm, space, stack, res.Template)
+	if err != nil {
+		return res, err
+	}
+
+	existing, err := b.listReplicas(tmpl)
+	if err != nil {
+		return res, err
+	}
+
+	for i := range replicas {
+		name := replicaName(tmpl.Metadata.Name, i)
+		if _, ok := existing[i]; ok {
+			res.Unchanged = append(res.Unchanged, name)
+			continue
+		}
+		if err = b.checkCanceled(fmt.Sprintf("creating replica %q", name)); err != nil {
+			return res, err
+		}
+		if _, err = b.CreateCell(buildReplicaCell(tmpl, name)); err != nil {
+			return res, fmt.Errorf("failed to create replica %q: %w", name, err)
+		}
+		res.Created = append(res.Created, name)
+	}
+
+	surplus := make([]int, 0, len(existing))
+	for i := range existing {
+		if i >= replicas {
+			surplus = append(surplus, i)
+		}
+	}
+	// Remove from the highest index down so an interrupted scale-down
+	// leaves a contiguous 0..k-1 replica set behind.
+	sort.Sort(sort.Reverse(sort.IntSlice(surplus)))
+	for _, i := range surplus {
+		cell := existing[i]
+		if err = b.checkCanceled(fmt.Sprintf("removing replica %q", cell.Metadata.Name)); err != nil {
+			sort.Strings(res.Removed)
+			return res, err
+		}
+		if _, err = b.DeleteCell(cell); err != nil {
+			return res, fmt.Errorf("failed to remove replica %q: %w", cell.Metadata.Name, err)
+		}
+		res.Removed = append(res.Removed, cell.Metadata.Name)
+	}
+	sort.Strings(res.Removed)
+
+	return res, nil
+}
+
+// getScaleTemplate validates the scale scope and loads the template cell.
+func (b *Exec) getScaleTemplate(realm, space, stack, template string) (intmodel.Cell, error) {
+	lookup := intmodel.Cell{
+		Metadata: intmodel.CellMetadata{Name: template},
+		Spec: intmodel.CellSpec{
+			RealmName: strings.TrimSpace(realm),
+			SpaceName: strings.TrimSpace(space),
+			StackName: strings.TrimSpace(stack),
+		},
+	}
+	switch {
+	case lookup.Metadata.Name == "":
+		return intmodel.Cell{}, errdefs.ErrCellNameRequired
+	case lookup.Spec.RealmName == "":
+		return intmodel.Cell{}, errdefs.ErrRealmNameRequired
+	case lookup.Spec.SpaceName == "":
+		return intmodel.Cell{}, errdefs.ErrSpaceNameRequired
+	case lookup.Spec.StackName == "":
+		return intmodel.Cell{}, errdefs.ErrStackNameRequired
+	}
+
+	tmpl, err := b.runner.GetCell(lookup)
+	if err != nil {
+		if errors.Is(err, errdefs.ErrCellNotFound) {
+			return intmodel.Cell{}, fmt.Errorf("%w: template %q", errdefs.ErrCellNotFound, template)
+		}
+		return intmodel.Cell{}, fmt.Errorf("%w: %w", errdefs.ErrGetCell, err)
+	}
+	if owner := tmpl.Metadata.Labels[consts.KukeonReplicaOfLabelKey]; owner != "" {
+		return intmodel.Cell{}, fmt.Errorf("%w: %q is a replica of %q", errdefs.ErrScaleTemplateIsReplica, template, owner)
+	}
+	return tmpl, nil
+}
+
+// listReplicas returns the template's current replicas keyed by index. A
+// cell named like a replica that does not carry the template's replica label
+// is reported as a conflict rather than silently adopted or deleted.
+func (b *Exec) listReplicas(tmpl intmodel.Cell) (map[int]intmodel.Cell, error) {
+	cells, err := b.runner.ListCells(tmpl.Spec.RealmName, tmpl.Spec.SpaceName, tmpl.Spec.StackName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cells: %w", err)
+	}
+
+	prefix := tmpl.Metadata.Name + "-"
+	replicas := make(map[int]intmodel.Cell)
+	for _, cell := range cells {
+		suffix, ok := strings.CutPrefix(cell.Metadata.Name, prefix)
+		if !ok {
+			continue
+		}
+		i, convErr := strconv.Atoi(suffix)
+		if convErr != nil || i < 0 || replicaName(tmpl.Metadata.Name, i) != cell.Metadata.Name {
+			continue
+		}
+		if cell.Metadata.Labels[consts.KukeonReplicaOfLabelKey] != tmpl.Metadata.Name {
+			return nil, fmt.Errorf("%w: %q", errdefs.ErrScaleReplicaNameTaken, cell.Metadata.Name)
+		}
+		replicas[i] = cell
+	}
+	return replicas, nil
+}
+
+func replicaName(template string, i int) string {
+	return template + "-" + strconv.Itoa(i)
+}
+
+// buildReplicaCell copies the template's spec under a new name. Runtime
+// identity (containerd IDs, status) is dropped so CreateCell provisions the
+// replica's own cgroup, root container and network attachment. The template's
+// cell label is replaced and the replica label added; provenance is not
+// carried because a replica is owned by its template, not by a binding.
+func buildReplicaCell(tmpl intmodel.Cell, name string) intmodel.Cell {
+	labels := make(map[string]string, len(tmpl.Metadata.Labels)+1)
+	for k, v := range tmpl.Metadata.Labels {
+		labels[k] = v
+	}
+	labels[consts.KukeonCellLabelKey] = name
+	labels[consts.KukeonReplicaOfLabelKey] = tmpl.Metadata.Name
+
+	spec := tmpl.Spec
+	spec.ID = name
+	spec.Provenance = nil
+	spec.Containers = make([]intmodel.ContainerSpec, len(tmpl.Spec.Containers))
+	for i, container := range tmpl.Spec.Containers {
+		container.ContainerdID = ""
+		container.CellName = name
+		spec.Containers[i] = container
+	}
+
+	return intmodel.Cell{
+		Metadata: intmodel.CellMetadata{Name: name, Labels: labels},
+		Spec:     spec,
+	}
+}
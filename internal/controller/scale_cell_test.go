@@ -0,0 +1,236 @@
+//go:build !integration
+
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/consts"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// scaleFixture backs a fakeRunner with an in-memory stack so ScaleCell's
+// create and delete paths observe each other's effects.
+type scaleFixture struct {
+	cells   map[string]intmodel.Cell
+	created []intmodel.Cell
+	deleted []string
+}
+
+func newScaleFixture(cells ...intmodel.Cell) *scaleFixture {
+	f := &scaleFixture{cells: make(map[string]intmodel.Cell)}
+	for _, c := range cells {
+		f.cells[c.Metadata.Name] = c
+	}
+	return f
+}
+
+func (s *scaleFixture) runner() *fakeRunner {
+	return &fakeRunner{
+		GetCellFn: func(cell intmodel.Cell) (intmodel.Cell, error) {
+			c, ok := s.cells[cell.Metadata.Name]
+			if !ok {
+				return intmodel.Cell{}, errdefs.ErrCellNotFound
+			}
+			return c, nil
+		},
+		ListCellsFn: func(string, string, string) ([]intmodel.Cell, error) {
+			out := make([]intmodel.Cell, 0, len(s.cells))
+			for _, c := range s.cells {
+				out = append(out, c)
+			}
+			return out, nil
+		},
+		CreateCellFn: func(cell intmodel.Cell) (intmodel.Cell, error) {
+			s.created = append(s.created, cell)
+			s.cells[cell.Metadata.Name] = cell
+			return cell, nil
+		},
+		StartCellFn: func(cell intmodel.Cell) (intmodel.Cell, error) {
+			return cell, nil
+		},
+		ExistsCgroupFn:            func(any) (bool, error) { return true, nil },
+		ExistsCellRootContainerFn: func(intmodel.Cell) (bool, error) { return true, nil },
+		DeleteCellFn: func(cell intmodel.Cell) error {
+			s.deleted = append(s.deleted, cell.Metadata.Name)
+			delete(s.cells, cell.Metadata.Name)
+			return nil
+		},
+	}
+}
+
+func scaleTemplateCell() intmodel.Cell {
+	cell := buildTestCell("web", "r", "s", "st")
+	cell.Spec.Containers = []intmodel.ContainerSpec{{
+		ID:           "app",
+		ContainerdID: "s_st_web_app",
+		CellName:     "web",
+		Image:        "docker.io/library/nginx:latest",
+	}}
+	return cell
+}
+
+func scaleReplicaCell(name string) intmodel.Cell {
+	cell := buildTestCell(name, "r", "s", "st")
+	cell.Metadata.Labels[consts.KukeonReplicaOfLabelKey] = "web"
+	return cell
+}
+
+func TestScaleCell_ScaleUp(t *testing.T) {
+	fx := newScaleFixture(scaleTemplateCell(), scaleReplicaCell("web-0"))
+	ctrl := setupTestController(t, withReadyParents(fx.runner()))
+
+	res, err := ctrl.ScaleCell("r", "s", "st", "web", 3)
+	if err != nil {
+		t.Fatalf("ScaleCell() error = %v", err)
+	}
+	if want := []string{"web-1", "web-2"}; !reflect.DeepEqual(res.Created, want) {
+		t.Errorf("Created = %v, want %v", res.Created, want)
+	}
+	if want := []string{"web-0"}; !reflect.DeepEqual(res.Unchanged, want) {
+		t.Errorf("Unchanged = %v, want %v", res.Unchanged, want)
+	}
+	if len(res.Removed) != 0 {
+		t.Errorf("Removed = %v, want none", res.Removed)
+	}
+
+	replica := fx.created[0]
+	if got := replica.Metadata.Labels[consts.KukeonReplicaOfLabelKey]; got != "web" {
+		t.Errorf("replica-of label = %q, want %q", got, "web")
+	}
+	if got := replica.Metadata.Labels[consts.KukeonCellLabelKey]; got != "web-1" {
+		t.Errorf("cell label = %q, want %q", got, "web-1")
+	}
+	if replica.Spec.ID != "web-1" {
+		t.Errorf("Spec.ID = %q, want %q", replica.Spec.ID, "web-1")
+	}
+	if len(replica.Spec.Containers) != 1 {
+		t.Fatalf("replica containers = %d, want 1", len(replica.Spec.Containers))
+	}
+	c := replica.Spec.Containers[0]
+	if c.ContainerdID != "" || c.CellName != "web-1" || c.Image != "docker.io/library/nginx:latest" {
+		t.Errorf("replica container = %+v, want template spec re-owned by web-1", c)
+	}
+	if tmpl := fx.cells["web"]; tmpl.Spec.Containers[0].CellName != "web" {
+		t.Errorf("template container mutated: CellName = %q", tmpl.Spec.Containers[0].CellName)
+	}
+}
+
+func TestScaleCell_ScaleDown(t *testing.T) {
+	fx := newScaleFixture(
+		scaleTemplateCell(),
+		scaleReplicaCell("web-0"),
+		scaleReplicaCell("web-1"),
+		scaleReplicaCell("web-2"),
+		buildTestCell("web-extra", "r", "s", "st"),
+	)
+	ctrl := setupTestController(t, withReadyParents(fx.runner()))
+
+	res, err := ctrl.ScaleCell("r", "s", "st", "web", 1)
+	if err != nil {
+		t.Fatalf("ScaleCell() error = %v", err)
+	}
+	if want := []string{"web-1", "web-2"}; !reflect.DeepEqual(res.Removed, want) {
+		t.Errorf("Removed = %v, want %v", res.Removed, want)
+	}
+	if want := []string{"web-2", "web-1"}; !reflect.DeepEqual(fx.deleted, want) {
+		t.Errorf("delete order = %v, want %v", fx.deleted, want)
+	}
+	if len(res.Created) != 0 {
+		t.Errorf("Created = %v, want none", res.Created)
+	}
+	if _, ok := fx.cells["web"]; !ok {
+		t.Error("template cell was deleted")
+	}
+	if _, ok := fx.cells["web-extra"]; !ok {
+		t.Error("unrelated cell was deleted")
+	}
+}
+
+func TestScaleCell_NoOp(t *testing.T) {
+	fx := newScaleFixture(scaleTemplateCell(), scaleReplicaCell("web-0"), scaleReplicaCell("web-1"))
+	ctrl := setupTestController(t, withReadyParents(fx.runner()))
+
+	res, err := ctrl.ScaleCell("r", "s", "st", "web", 2)
+	if err != nil {
+		t.Fatalf("ScaleCell() error = %v", err)
+	}
+	if want := []string{"web-0", "web-1"}; !reflect.DeepEqual(res.Unchanged, want) {
+		t.Errorf("Unchanged = %v, want %v", res.Unchanged, want)
+	}
+	if len(fx.created) != 0 || len(fx.deleted) != 0 {
+		t.Errorf("expected no changes, created %d, deleted %v", len(fx.created), fx.deleted)
+	}
+}
+
+func TestScaleCell_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		cells    []intmodel.Cell
+		template string
+		replicas int
+		wantErr  error
+	}{
+		{
+			name:     "negative replicas",
+			cells:    []intmodel.Cell{scaleTemplateCell()},
+			template: "web",
+			replicas: -1,
+			wantErr:  errdefs.ErrInvalidReplicas,
+		},
+		{
+			name:     "missing template",
+			template: "web",
+			replicas: 1,
+			wantErr:  errdefs.ErrCellNotFound,
+		},
+		{
+			name:     "template is a replica",
+			cells:    []intmodel.Cell{scaleReplicaCell("web-0")},
+			template: "web-0",
+			replicas: 1,
+			wantErr:  errdefs.ErrScaleTemplateIsReplica,
+		},
+		{
+			name:     "replica name taken",
+			cells:    []intmodel.Cell{scaleTemplateCell(), buildTestCell("web-0", "r", "s", "st")},
+			template: "web",
+			replicas: 1,
+			wantErr:  errdefs.ErrScaleReplicaNameTaken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fx := newScaleFixture(tt.cells...)
+			ctrl := setupTestController(t, withReadyParents(fx.runner()))
+
+			_, err := ctrl.ScaleCell("r", "s", "st", tt.template, tt.replicas)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ScaleCell() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(fx.created) != 0 || len(fx.deleted) != 0 {
+				t.Errorf("expected no changes on error, created %d, deleted %v", len(fx.created), fx.deleted)
+			}
+		})
+	}
+}
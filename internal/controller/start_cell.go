@@ -20,6 +20,8 @@ import (
 	"fmt"
 
 	"github.com/eminwux/kukeon/internal/controller/apply"
+	"github.com/eminwux/kukeon/internal/drain"
+	"github.com/eminwux/kukeon/internal/events"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
 )
@@ -39,6 +41,12 @@ type StartCellResult struct {
 // cell — issue #983. The reapply is daemon-side so every client that issues
 // StartCell (CLI, future API consumers) gets the reconcile-on-start behaviour.
 func (b *Exec) StartCell(cell intmodel.Cell) (StartCellResult, error) {
+	res, err := b.startCell(cell)
+	b.recordEvent(events.ActionStart, cellEvent(cell), err)
+	return res, err
+}
+
+func (b *Exec) startCell(cell intmodel.Cell) (StartCellResult, error) {
 	var res StartCellResult
 
 	internalCell, err := b.validateAndGetCell(cell)
@@ -46,6 +54,16 @@ func (b *Exec) StartCell(cell intmodel.Cell) (StartCellResult, error) {
 		return res, err
 	}
 
+	// A drained scope stays down until `kuke undrain`, which lifts the drain
+	// before it starts the cells it stopped.
+	if err = drain.Guard(
+		b.opts.RunPath,
+		internalCell.Spec.RealmName, internalCell.Spec.SpaceName, internalCell.Spec.StackName,
+		fmt.Sprintf("start cell %q", internalCell.Metadata.Name),
+	); err != nil {
+		return res, err
+	}
+
 	// Carry `kuke run --env` runtime entries from the inbound RPC cell onto
 	// the disk-read internalCell so the runner's OCI build path sees them
 	// (issue #834). v1beta1.CellSpec.RuntimeEnv is yaml:"-", so the disk
@@ -55,6 +73,8 @@ func (b *Exec) StartCell(cell intmodel.Cell) (StartCellResult, error) {
 	// attach) silently drops the per-tick env. Empty input is a no-op (a
 	// bare `kuke start <cell>` never sets RuntimeEnv).
 	internalCell.Spec.RuntimeEnv = cell.Spec.RuntimeEnv
+	// `kuke create cell --wait` rides the same transport-only hop.
+	internalCell.Spec.WaitReadySeconds = cell.Spec.WaitReadySeconds
 
 	// Auto-provision the on-disk spec's per-cell (ensure) volumes before any
 	// start/recreate path rebuilds a container OCI spec, so the volume-reference
@@ -191,7 +211,7 @@ func (b *Exec) StartCell(cell intmodel.Cell) (StartCellResult, error) {
 	}
 
 	// Start all containers in the cell
-	internalCell, err = b.runner.StartCell(internalCell)
+	internalCell, err = b.runner.StartCell(b.ctx, internalCell)
 	if err != nil {
 		return res, fmt.Errorf("failed to start cell containers: %w", err)
 	}
@@ -365,7 +385,7 @@ func (b *Exec) reapplyBreaking(cell, desired intmodel.Cell, configName string) (
 func (b *Exec) reapplyCompatibleInPlace(
 	cell, desired intmodel.Cell, configName string,
 ) (intmodel.Cell, bool, bool) {
-	started, err := b.runner.StartCell(cell)
+	started, err := b.runner.StartCell(b.ctx, cell)
 	if err != nil {
 		b.logger.WarnContext(b.ctx,
 			"OutOfSync reapply StartCell failed; falling back to on-disk spec",
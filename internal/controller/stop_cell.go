@@ -19,6 +19,7 @@ package controller
 import (
 	"fmt"
 
+	"github.com/eminwux/kukeon/internal/events"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 )
 
@@ -30,6 +31,12 @@ type StopCellResult struct {
 
 // StopCell stops all containers in a cell and updates the cell metadata state.
 func (b *Exec) StopCell(cell intmodel.Cell) (StopCellResult, error) {
+	res, err := b.stopCell(cell)
+	b.recordEvent(events.ActionStop, cellEvent(cell), err)
+	return res, err
+}
+
+func (b *Exec) stopCell(cell intmodel.Cell) (StopCellResult, error) {
 	var result StopCellResult
 
 	internalCell, err := b.validateAndGetCell(cell)
@@ -0,0 +1,468 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/eminwux/kukeon/internal/cni"
+	"github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// cellParentPollInterval is how often waitForCellParents re-reads the
+// parent chain while a cell waits for it to turn Ready.
+const cellParentPollInterval = 250 * time.Millisecond
+
+// ValidateCell checks a cell against the rest of the hierarchy before any of
+// it is created: the parent realm, space and stack must exist and be Ready,
+// container IDs must be unique, every container needs an image, and
+// rootContainerId must name one of the declared containers, and any bandwidth
+// limits must parse to positive rates with matching bursts. Every problem
+// found is reported in a single error wrapping ErrCellValidation, so an
+// operator fixes the document in one pass instead of hitting each failure
+// deep inside container creation. Runner errors other than "not found" are
+// returned as-is.
+//
+// A privileged container is only accepted when its realm sets
+// allowPrivileged; see validateCellPrivileged. A cell that passes is then
+// checked against its realm's and space's resource quota, which fails with
+// ErrQuotaExceeded; see checkCellQuota.
+//
+// A positive Spec.WaitForParentSeconds (`--wait-for-parent`) first polls the
+// parent chain until it is Ready, so a cell applied right behind its stack
+// does not lose the race; see waitForCellParents.
+func (b *Exec) ValidateCell(cell intmodel.Cell) error {
+	problems, err := b.waitForCellParents(cell)
+	if err != nil {
+		return err
+	}
+	privileged, err := b.validateCellPrivileged(cell)
+	if err != nil {
+		return err
+	}
+	problems = append(problems, privileged...)
+	if err = cellValidationError(append(problems, validateCellSpec(cell)...)); err != nil {
+		return err
+	}
+	return b.checkCellQuota(cell)
+}
+
+// validateCellPrivileged reports an ErrPrivilegedNotAllowed for every
+// privileged container when the cell's realm does not set allowPrivileged.
+// The realm is only read when some container asks for privileged mode; a
+// missing realm is left to validateCellParents to report.
+func (b *Exec) validateCellPrivileged(cell intmodel.Cell) ([]error, error) {
+	var ids []string
+	for _, container := range cell.Spec.Containers {
+		if container.Privileged {
+			ids = append(ids, container.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	realmName := strings.TrimSpace(cell.Spec.RealmName)
+	realm, err := b.runner.GetRealm(intmodel.Realm{Metadata: intmodel.RealmMetadata{Name: realmName}})
+	if err != nil {
+		if errors.Is(err, errdefs.ErrRealmNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: %w", errdefs.ErrGetRealm, err)
+	}
+	if realm.Spec.AllowPrivileged {
+		return nil, nil
+	}
+	problems := make([]error, 0, len(ids))
+	for _, id := range ids {
+		problems = append(problems, fmt.Errorf("%w: container %q is privileged, realm %q does not set allowPrivileged",
+			errdefs.ErrPrivilegedNotAllowed, id, realmName))
+	}
+	return problems, nil
+}
+
+// ValidateCellSpec is the hierarchy-free half of ValidateCell: it checks the
+// cell's containers and bandwidth limits only, so a manifest can be linted
+// without a store. Problems are folded into one ErrCellValidation error.
+func ValidateCellSpec(cell intmodel.Cell) error {
+	return cellValidationError(validateCellSpec(cell))
+}
+
+// validateCellSpec checks what a cell document can be judged on without the
+// hierarchy: its containers and bandwidth limits.
+func validateCellSpec(cell intmodel.Cell) []error {
+	problems := validateCellContainers(cell)
+	if bw := cell.Spec.Bandwidth; bw != nil {
+		if _, bwErr := cni.ParseBandwidthLimits(
+			bw.IngressRate, bw.IngressBurst, bw.EgressRate, bw.EgressBurst,
+		); bwErr != nil {
+			problems = append(problems, fmt.Errorf("bandwidth: %w", bwErr))
+		}
+	}
+	if lc := cell.Spec.Lifecycle; lc != nil {
+		problems = append(problems, validateCellLifecycle(lc)...)
+	}
+	return problems
+}
+
+// validateCellLifecycle rejects blank hook commands and a negative timeout.
+func validateCellLifecycle(lc *intmodel.CellLifecycle) []error {
+	var problems []error
+	check := func(phase string, commands []string) {
+		for i, command := range commands {
+			if strings.TrimSpace(command) == "" {
+				problems = append(problems, fmt.Errorf("lifecycle.%s[%d]: command is empty", phase, i))
+			}
+		}
+	}
+	check("preStart", lc.PreStart)
+	check("postStop", lc.PostStop)
+	if lc.TimeoutSeconds < 0 {
+		problems = append(problems, fmt.Errorf("lifecycle.timeoutSeconds: %d is negative", lc.TimeoutSeconds))
+	}
+	return problems
+}
+
+// validateContainerLifecycle checks a container's in-container hooks the
+// same way validateCellLifecycle checks the cell's host-side ones.
+func validateContainerLifecycle(lc *intmodel.ContainerLifecycle) []error {
+	var problems []error
+	check := func(phase string, commands []string) {
+		for i, command := range commands {
+			if strings.TrimSpace(command) == "" {
+				problems = append(problems, fmt.Errorf("lifecycle.%s[%d]: command is empty", phase, i))
+			}
+		}
+	}
+	check("postStart", lc.PostStart)
+	check("preStop", lc.PreStop)
+	if lc.TimeoutSeconds < 0 {
+		problems = append(problems, fmt.Errorf("lifecycle.timeoutSeconds: %d is negative", lc.TimeoutSeconds))
+	}
+	return problems
+}
+
+// cellValidationError folds problems into one error wrapping
+// ErrCellValidation, or returns nil when there are none.
+func cellValidationError(problems []error) error {
+	if len(problems) == 0 {
+		return nil
+	}
+
+	format := "%w: " + strings.TrimSuffix(strings.Repeat("%w; ", len(problems)), "; ")
+	args := make([]any, 0, len(problems)+1)
+	args = append(args, errdefs.ErrCellValidation)
+	for _, problem := range problems {
+		args = append(args, problem)
+	}
+	return fmt.Errorf(format, args...)
+}
+
+// waitForCellParents re-runs validateCellParents until the parent chain has
+// no problems or Spec.WaitForParentSeconds elapses. A parent that is missing
+// counts as not ready yet while waiting: it may be the document applied just
+// before this one. On timeout the last problems are returned behind an
+// ErrParentWaitTimeout entry. With no wait set it is validateCellParents.
+func (b *Exec) waitForCellParents(cell intmodel.Cell) ([]error, error) {
+	wait := time.Duration(cell.Spec.WaitForParentSeconds) * time.Second
+	deadline := time.Now().Add(wait)
+	for {
+		problems, err := b.validateCellParents(cell)
+		if err != nil || len(problems) == 0 || wait <= 0 {
+			return problems, err
+		}
+		if !time.Now().Before(deadline) {
+			timeout := fmt.Errorf("%w after %s", errdefs.ErrParentWaitTimeout, wait)
+			return append([]error{timeout}, problems...), nil
+		}
+		b.logger.DebugContext(b.ctx, "waiting for cell parents to become ready",
+			"cell", cell.Metadata.Name, "problems", len(problems))
+		select {
+		case <-b.ctx.Done():
+			return nil, b.ctx.Err()
+		case <-time.After(min(cellParentPollInterval, time.Until(deadline))):
+		}
+	}
+}
+
+// validateCellParents walks realm → space → stack and stops at the first
+// missing parent, since its children cannot exist either.
+func (b *Exec) validateCellParents(cell intmodel.Cell) ([]error, error) {
+	realmName := strings.TrimSpace(cell.Spec.RealmName)
+	spaceName := strings.TrimSpace(cell.Spec.SpaceName)
+	stackName := strings.TrimSpace(cell.Spec.StackName)
+
+	realm, err := b.runner.GetRealm(intmodel.Realm{Metadata: intmodel.RealmMetadata{Name: realmName}})
+	if err != nil {
+		if errors.Is(err, errdefs.ErrRealmNotFound) {
+			return []error{fmt.Errorf("%w: %q", errdefs.ErrRealmNotFound, realmName)}, nil
+		}
+		return nil, fmt.Errorf("%w: %w", errdefs.ErrGetRealm, err)
+	}
+	var problems []error
+	if realm.Status.State != intmodel.RealmStateReady {
+		problems = append(problems, fmt.Errorf("%w: realm %q is %s",
+			errdefs.ErrParentNotReady, realmName, realmStateName(realm.Status.State)))
+	}
+
+	space, err := b.runner.GetSpace(intmodel.Space{
+		Metadata: intmodel.SpaceMetadata{Name: spaceName},
+		Spec:     intmodel.SpaceSpec{RealmName: realmName},
+	})
+	if err != nil {
+		if errors.Is(err, errdefs.ErrSpaceNotFound) {
+			return append(problems, fmt.Errorf("%w: %q in realm %q", errdefs.ErrSpaceNotFound, spaceName, realmName)), nil
+		}
+		return nil, fmt.Errorf("%w: %w", errdefs.ErrGetSpace, err)
+	}
+	if space.Status.State != intmodel.SpaceStateReady {
+		problems = append(problems, fmt.Errorf("%w: space %q in realm %q is %s",
+			errdefs.ErrParentNotReady, spaceName, realmName, spaceStateName(space.Status.State)))
+	}
+
+	stack, err := b.runner.GetStack(intmodel.Stack{
+		Metadata: intmodel.StackMetadata{Name: stackName},
+		Spec:     intmodel.StackSpec{RealmName: realmName, SpaceName: spaceName},
+	})
+	if err != nil {
+		if errors.Is(err, errdefs.ErrStackNotFound) {
+			return append(problems, fmt.Errorf("%w: %q in space %q", errdefs.ErrStackNotFound, stackName, spaceName)), nil
+		}
+		return nil, fmt.Errorf("%w: %w", errdefs.ErrGetStack, err)
+	}
+	if stack.Status.State != intmodel.StackStateReady {
+		problems = append(problems, fmt.Errorf("%w: stack %q in space %q, realm %q is %s",
+			errdefs.ErrParentNotReady, stackName, spaceName, realmName, stackStateName(stack.Status.State)))
+	}
+	return problems, nil
+}
+
+// realmStateName, spaceStateName and stackStateName spell out a parent's
+// state for the not-Ready message.
+func realmStateName(state intmodel.RealmState) string {
+	switch state {
+	case intmodel.RealmStatePending:
+		return "Pending"
+	case intmodel.RealmStateCreating:
+		return "Creating"
+	case intmodel.RealmStateReady:
+		return "Ready"
+	case intmodel.RealmStateDeleting:
+		return "Deleting"
+	case intmodel.RealmStateFailed:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+func spaceStateName(state intmodel.SpaceState) string {
+	switch state {
+	case intmodel.SpaceStatePending:
+		return "Pending"
+	case intmodel.SpaceStateCreating:
+		return "Creating"
+	case intmodel.SpaceStateReady:
+		return "Ready"
+	case intmodel.SpaceStateDeleting:
+		return "Deleting"
+	case intmodel.SpaceStateFailed:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+func stackStateName(state intmodel.StackState) string {
+	switch state {
+	case intmodel.StackStatePending:
+		return "Pending"
+	case intmodel.StackStateReady:
+		return "Ready"
+	case intmodel.StackStateFailed:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+// validateCellContainers checks the container list on its own: unique IDs,
+// non-empty images, sane log rotation, and a rootContainerId that resolves.
+func validateCellContainers(cell intmodel.Cell) []error {
+	var problems []error
+	seen := make(map[string]bool, len(cell.Spec.Containers))
+	for _, container := range cell.Spec.Containers {
+		id := strings.TrimSpace(container.ID)
+		if seen[id] {
+			problems = append(problems, fmt.Errorf("container %q is declared more than once", id))
+			continue
+		}
+		seen[id] = true
+		if strings.TrimSpace(container.Image) == "" {
+			problems = append(problems, fmt.Errorf("container %q has no image", id))
+		}
+		if lr := container.LogRotation; lr != nil && (lr.MaxSizeMB < 1 || lr.MaxFiles < 1) {
+			problems = append(problems, fmt.Errorf("%w: container %q needs maxSizeMB and maxFiles of at least 1",
+				errdefs.ErrInvalidLogRotation, id))
+		}
+		if lc := container.Lifecycle; lc != nil {
+			for _, problem := range validateContainerLifecycle(lc) {
+				problems = append(problems, fmt.Errorf("container %q: %w", id, problem))
+			}
+		}
+		if err := ctr.ValidateUser(container.User); err != nil {
+			problems = append(problems, fmt.Errorf("container %q: %w", id, err))
+		}
+		if err := ctr.ValidateSupplementaryGroups(container.SupplementaryGroups); err != nil {
+			problems = append(problems, fmt.Errorf("container %q: %w", id, err))
+		}
+		if container.OOMScoreAdj != nil {
+			if err := ctr.ValidateOOMScoreAdj(*container.OOMScoreAdj); err != nil {
+				problems = append(problems, fmt.Errorf("container %q: %w", id, err))
+			}
+		}
+		if err := ctr.ValidateTerminationMessagePath(container.TerminationMessagePath); err != nil {
+			problems = append(problems, fmt.Errorf("container %q: %w", id, err))
+		}
+		root := container.Root || id == strings.TrimSpace(cell.Spec.RootContainerID)
+		problems = append(problems, validateContainerSysctls(id, root, container.Sysctls)...)
+		if caps := container.Capabilities; caps != nil {
+			if err := ctr.ValidateCapabilities(append(slices.Clone(caps.Add), caps.Drop...)); err != nil {
+				problems = append(problems, fmt.Errorf("container %q: %w", id, err))
+			}
+		}
+		if err := ctr.ValidateImagePlatform(container.Platform); err != nil {
+			problems = append(problems, fmt.Errorf("container %q: %w", id, err))
+		}
+		for _, err := range ctr.ValidateDevices(container.Devices) {
+			problems = append(problems, fmt.Errorf("container %q: %w", id, err))
+		}
+		if err := ctr.ValidateHostname(container.Hostname); err != nil {
+			problems = append(problems, fmt.Errorf("container %q: %w", id, err))
+		}
+		for _, err := range ctr.ValidateExtraHosts(container.ExtraHosts) {
+			problems = append(problems, fmt.Errorf("container %q: %w", id, err))
+		}
+		if err := ctr.ValidateSeccompProfile(container.SeccompProfile, container.SecurityOpts); err != nil {
+			problems = append(problems, fmt.Errorf("container %q: %w", id, err))
+		}
+		if err := ctr.ValidateAppArmorProfile(container.AppArmorProfile); err != nil {
+			problems = append(problems, fmt.Errorf("container %q: %w", id, err))
+		}
+		if err := ctr.ValidateStdin(
+			container.OpenStdin, container.StdinOnce, container.StdinFile, container.Attachable, root,
+		); err != nil {
+			problems = append(problems, fmt.Errorf("container %q: %w", id, err))
+		}
+		switch container.ImagePullPolicy {
+		case "", intmodel.ImagePullPolicyAlways, intmodel.ImagePullPolicyIfNotPresent, intmodel.ImagePullPolicyNever:
+		default:
+			problems = append(problems, fmt.Errorf("%w: container %q has %q, want %s, %s or %s",
+				errdefs.ErrInvalidImagePullPolicy, id, container.ImagePullPolicy,
+				intmodel.ImagePullPolicyAlways, intmodel.ImagePullPolicyIfNotPresent, intmodel.ImagePullPolicyNever))
+		}
+	}
+
+	rootID := strings.TrimSpace(cell.Spec.RootContainerID)
+	if rootID != "" && !seen[rootID] {
+		problems = append(problems, fmt.Errorf("rootContainerId %q does not match any container", rootID))
+	}
+	return append(problems, validateCellHostNames(cell)...)
+}
+
+// validateCellHostNames checks hostname and extraHosts across the cell.
+// Every container joins the root's UTS namespace, so the cell has exactly
+// one hostname: declaring different values on different containers is
+// rejected rather than silently applying one of them. Extra hosts land in
+// the single /etc/hosts the cell shares, so one host may not map to two
+// IPs, and a host-network cell keeps the host's /etc/hosts and cannot take
+// any.
+func validateCellHostNames(cell intmodel.Cell) []error {
+	var problems []error
+	hostnameOwner, hostname := "", ""
+	extraHostOwners := make(map[string]string)
+	extraHostIPs := make(map[string]string)
+	hostNetwork := false
+	rootID := strings.TrimSpace(cell.Spec.RootContainerID)
+	for _, container := range cell.Spec.Containers {
+		id := strings.TrimSpace(container.ID)
+		if container.Root || id == rootID {
+			hostNetwork = container.HostNetwork
+		}
+		if h := strings.TrimSpace(container.Hostname); h != "" {
+			switch {
+			case hostname == "":
+				hostnameOwner, hostname = id, h
+			case h != hostname:
+				problems = append(problems, fmt.Errorf(
+					"%w: container %q sets %q but container %q sets %q; "+
+						"containers share one UTS namespace, set hostname on the root container only",
+					errdefs.ErrInvalidHostname, id, h, hostnameOwner, hostname))
+			}
+		}
+		for _, entry := range container.ExtraHosts {
+			extra, err := ctr.ParseExtraHost(entry)
+			if err != nil {
+				continue
+			}
+			ip := extra.IP.String()
+			prev, ok := extraHostIPs[extra.Host]
+			if !ok {
+				extraHostOwners[extra.Host], extraHostIPs[extra.Host] = id, ip
+				continue
+			}
+			if prev != ip {
+				problems = append(problems, fmt.Errorf("%w: container %q maps %q to %s but container %q maps it to %s",
+					errdefs.ErrInvalidExtraHost, id, extra.Host, ip, extraHostOwners[extra.Host], prev))
+			}
+		}
+	}
+	if len(extraHostIPs) > 0 && hostNetwork {
+		problems = append(problems, fmt.Errorf(
+			"%w: the root container uses the host network and the host's /etc/hosts",
+			errdefs.ErrInvalidExtraHost))
+	}
+	return problems
+}
+
+// validateContainerSysctls checks a container's sysctl keys. The root
+// container owns the cell's network namespace and every workload container
+// joins it, so net.* sysctls are only accepted on the root.
+func validateContainerSysctls(id string, root bool, sysctls map[string]string) []error {
+	var problems []error
+	if err := ctr.ValidateSysctls(sysctls); err != nil {
+		problems = append(problems, fmt.Errorf("container %q: %w", id, err))
+	}
+	if root {
+		return problems
+	}
+	for _, key := range slices.Sorted(maps.Keys(sysctls)) {
+		if ctr.IsNetSysctl(key) {
+			problems = append(problems, fmt.Errorf(
+				"%w: container %q sets %q, but net.* sysctls apply to the cell's shared network namespace; set it on the root container",
+				errdefs.ErrInvalidSysctl, id, key))
+		}
+	}
+	return problems
+}
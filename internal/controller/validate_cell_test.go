@@ -0,0 +1,559 @@
+//go:build !integration
+
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller_test
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+func intPtr(v int) *int { return &v }
+
+func validCellWithContainers(containers ...intmodel.ContainerSpec) intmodel.Cell {
+	cell := buildTestCell("web", "r1", "s1", "st1")
+	cell.Spec.Containers = containers
+	return cell
+}
+
+func TestValidateCell(t *testing.T) {
+	tests := []struct {
+		name        string
+		cell        intmodel.Cell
+		setupRunner func(*fakeRunner)
+		wantIs      []error
+		wantMsgs    []string
+	}{
+		{
+			name: "valid cell",
+			cell: validCellWithContainers(
+				intmodel.ContainerSpec{ID: "root", Root: true, Image: "alpine"},
+				intmodel.ContainerSpec{ID: "app", Image: "nginx"},
+			),
+		},
+		{
+			name: "missing realm stops the parent walk",
+			cell: validCellWithContainers(intmodel.ContainerSpec{ID: "app", Image: "nginx"}),
+			setupRunner: func(f *fakeRunner) {
+				f.GetRealmFn = func(intmodel.Realm) (intmodel.Realm, error) {
+					return intmodel.Realm{}, errdefs.ErrRealmNotFound
+				}
+			},
+			wantIs:   []error{errdefs.ErrCellValidation, errdefs.ErrRealmNotFound},
+			wantMsgs: []string{`realm not found: "r1"`},
+		},
+		{
+			name: "missing stack",
+			cell: validCellWithContainers(intmodel.ContainerSpec{ID: "app", Image: "nginx"}),
+			setupRunner: func(f *fakeRunner) {
+				f.GetStackFn = func(intmodel.Stack) (intmodel.Stack, error) {
+					return intmodel.Stack{}, errdefs.ErrStackNotFound
+				}
+			},
+			wantIs:   []error{errdefs.ErrCellValidation, errdefs.ErrStackNotFound},
+			wantMsgs: []string{`stack not found: "st1" in space "s1"`},
+		},
+		{
+			name: "parent not ready",
+			cell: validCellWithContainers(intmodel.ContainerSpec{ID: "app", Image: "nginx"}),
+			setupRunner: func(f *fakeRunner) {
+				f.GetSpaceFn = func(space intmodel.Space) (intmodel.Space, error) {
+					space.Status.State = intmodel.SpaceStateFailed
+					return space, nil
+				}
+			},
+			wantIs:   []error{errdefs.ErrCellValidation, errdefs.ErrParentNotReady},
+			wantMsgs: []string{`parent not ready: space "s1" in realm "r1" is Failed`},
+		},
+		{
+			name: "duplicate container id",
+			cell: validCellWithContainers(
+				intmodel.ContainerSpec{ID: "app", Image: "nginx"},
+				intmodel.ContainerSpec{ID: "app", Image: "redis"},
+			),
+			wantIs:   []error{errdefs.ErrCellValidation},
+			wantMsgs: []string{`container "app" is declared more than once`},
+		},
+		{
+			name: "dangling root container reference",
+			cell: func() intmodel.Cell {
+				cell := validCellWithContainers(intmodel.ContainerSpec{ID: "app", Image: "nginx"})
+				cell.Spec.RootContainerID = "init"
+				return cell
+			}(),
+			wantIs:   []error{errdefs.ErrCellValidation},
+			wantMsgs: []string{`rootContainerId "init" does not match any container`},
+		},
+		{
+			name: "log rotation without max files",
+			cell: validCellWithContainers(intmodel.ContainerSpec{
+				ID: "app", Image: "nginx",
+				LogRotation: &intmodel.ContainerLogRotation{MaxSizeMB: 10},
+			}),
+			wantIs:   []error{errdefs.ErrCellValidation, errdefs.ErrInvalidLogRotation},
+			wantMsgs: []string{`container "app" needs maxSizeMB and maxFiles of at least 1`},
+		},
+		{
+			name: "malformed user",
+			cell: validCellWithContainers(intmodel.ContainerSpec{
+				ID: "app", Image: "nginx", User: "1000:", SupplementaryGroups: []int{-1},
+			}),
+			wantIs: []error{errdefs.ErrCellValidation, errdefs.ErrInvalidUser, errdefs.ErrInvalidGroup},
+			wantMsgs: []string{
+				`container "app": invalid user "1000:": want uid, uid:gid, or a name`,
+				`container "app": invalid supplementary group: -1`,
+			},
+		},
+		{
+			name: "unknown capability",
+			cell: validCellWithContainers(intmodel.ContainerSpec{
+				ID: "app", Image: "nginx",
+				Capabilities: &intmodel.ContainerCapabilities{Drop: []string{"NET_RAW", "NET_WIZARD"}},
+			}),
+			wantIs:   []error{errdefs.ErrCellValidation, errdefs.ErrInvalidCapability},
+			wantMsgs: []string{`container "app": unknown capability: "NET_WIZARD"`},
+		},
+		{
+			name: "net sysctl on the root container",
+			cell: validCellWithContainers(
+				intmodel.ContainerSpec{
+					ID: "root", Root: true, Image: "alpine",
+					Sysctls: map[string]string{"net.core.somaxconn": "1024"},
+				},
+				intmodel.ContainerSpec{ID: "app", Image: "nginx", Sysctls: map[string]string{"kernel.msgmax": "65536"}},
+			),
+		},
+		{
+			name: "net sysctl on a workload container",
+			cell: validCellWithContainers(
+				intmodel.ContainerSpec{ID: "root", Root: true, Image: "alpine"},
+				intmodel.ContainerSpec{
+					ID: "app", Image: "nginx",
+					Sysctls: map[string]string{"net.core.somaxconn": "1024", "kernel msgmax": "1"},
+				},
+			),
+			wantIs: []error{errdefs.ErrCellValidation, errdefs.ErrInvalidSysctl},
+			wantMsgs: []string{
+				`container "app": invalid sysctl: "kernel msgmax" is not a kernel parameter name`,
+				`container "app" sets "net.core.somaxconn", but net.* sysctls apply to the cell's shared network namespace`,
+			},
+		},
+		{
+			name: "oomScoreAdj at the range bounds",
+			cell: validCellWithContainers(
+				intmodel.ContainerSpec{ID: "root", Root: true, Image: "alpine", OOMScoreAdj: intPtr(-1000)},
+				intmodel.ContainerSpec{ID: "app", Image: "nginx", OOMScoreAdj: intPtr(1000)},
+			),
+		},
+		{
+			name: "terminationMessagePath relative",
+			cell: validCellWithContainers(
+				intmodel.ContainerSpec{ID: "root", Root: true, Image: "alpine"},
+				intmodel.ContainerSpec{ID: "app", Image: "nginx", TerminationMessagePath: "var/log/reason"},
+			),
+			wantIs: []error{errdefs.ErrCellValidation, errdefs.ErrInvalidTermMsgPath},
+			wantMsgs: []string{
+				`container "app": invalid terminationMessagePath: "var/log/reason" must be an absolute file path`,
+			},
+		},
+		{
+			name: "oomScoreAdj out of range",
+			cell: validCellWithContainers(
+				intmodel.ContainerSpec{ID: "root", Root: true, Image: "alpine", OOMScoreAdj: intPtr(-1001)},
+				intmodel.ContainerSpec{ID: "app", Image: "nginx", OOMScoreAdj: intPtr(1001)},
+			),
+			wantIs: []error{errdefs.ErrCellValidation, errdefs.ErrInvalidOOMScoreAdj},
+			wantMsgs: []string{
+				`container "root": invalid oomScoreAdj: -1001 is outside -1000..1000`,
+				`container "app": invalid oomScoreAdj: 1001 is outside -1000..1000`,
+			},
+		},
+		{
+			name: "unknown image pull policy",
+			cell: validCellWithContainers(intmodel.ContainerSpec{
+				ID: "app", Image: "nginx", ImagePullPolicy: "sometimes",
+			}),
+			wantIs:   []error{errdefs.ErrCellValidation, errdefs.ErrInvalidImagePullPolicy},
+			wantMsgs: []string{`container "app" has "sometimes", want Always, IfNotPresent or Never`},
+		},
+		{
+			name: "privileged container in a realm without allowPrivileged",
+			cell: validCellWithContainers(
+				intmodel.ContainerSpec{ID: "app", Image: "nginx"},
+				intmodel.ContainerSpec{ID: "dind", Image: "docker:dind", Privileged: true},
+			),
+			wantIs:   []error{errdefs.ErrCellValidation, errdefs.ErrPrivilegedNotAllowed},
+			wantMsgs: []string{`container "dind" is privileged, realm "r1" does not set allowPrivileged`},
+		},
+		{
+			name: "privileged container in a realm that allows it",
+			cell: validCellWithContainers(intmodel.ContainerSpec{ID: "dind", Image: "docker:dind", Privileged: true}),
+			setupRunner: func(f *fakeRunner) {
+				f.GetRealmFn = func(realm intmodel.Realm) (intmodel.Realm, error) {
+					realm.Spec.AllowPrivileged = true
+					realm.Status.State = intmodel.RealmStateReady
+					return realm, nil
+				}
+			},
+		},
+		{
+			name: "unparseable image platform",
+			cell: validCellWithContainers(intmodel.ContainerSpec{
+				ID: "app", Image: "nginx", Platform: "linux/arm64/v8/extra",
+			}),
+			wantIs:   []error{errdefs.ErrCellValidation, errdefs.ErrInvalidPlatform},
+			wantMsgs: []string{`container "app": invalid image platform: "linux/arm64/v8/extra"`},
+		},
+		{
+			name: "malformed device entry",
+			cell: validCellWithContainers(intmodel.ContainerSpec{
+				ID: "app", Image: "nginx", Devices: []string{"/dev/kvm:rx"},
+			}),
+			wantIs:   []error{errdefs.ErrCellValidation, errdefs.ErrInvalidDevice},
+			wantMsgs: []string{`container "app": invalid device: "/dev/kvm:rx": permissions "rx" must combine r, w and m`},
+		},
+		{
+			name: "invalid hostname",
+			cell: validCellWithContainers(intmodel.ContainerSpec{
+				ID: "app", Image: "nginx", Hostname: "web_1",
+			}),
+			wantIs:   []error{errdefs.ErrCellValidation, errdefs.ErrInvalidHostname},
+			wantMsgs: []string{`container "app": invalid hostname: "web_1"`},
+		},
+		{
+			name: "conflicting hostnames",
+			cell: validCellWithContainers(
+				intmodel.ContainerSpec{ID: "app", Image: "nginx", Hostname: "web"},
+				intmodel.ContainerSpec{ID: "worker", Image: "nginx", Hostname: "worker"},
+			),
+			wantIs: []error{errdefs.ErrCellValidation, errdefs.ErrInvalidHostname},
+			wantMsgs: []string{
+				`container "worker" sets "worker" but container "app" sets "web"`,
+				"set hostname on the root container only",
+			},
+		},
+		{
+			name: "matching hostnames",
+			cell: validCellWithContainers(
+				intmodel.ContainerSpec{ID: "app", Image: "nginx", Hostname: "web"},
+				intmodel.ContainerSpec{ID: "worker", Image: "nginx", Hostname: "web"},
+			),
+		},
+		{
+			name: "malformed extra host",
+			cell: validCellWithContainers(intmodel.ContainerSpec{
+				ID: "app", Image: "nginx", ExtraHosts: []string{"db=10.0.0.5"},
+			}),
+			wantIs:   []error{errdefs.ErrCellValidation, errdefs.ErrInvalidExtraHost},
+			wantMsgs: []string{`container "app": invalid extra host: "db=10.0.0.5": want host:ip`},
+		},
+		{
+			name: "extra host mapped to two addresses",
+			cell: validCellWithContainers(
+				intmodel.ContainerSpec{ID: "app", Image: "nginx", ExtraHosts: []string{"db:10.0.0.5"}},
+				intmodel.ContainerSpec{ID: "worker", Image: "nginx", ExtraHosts: []string{"db:10.0.0.6"}},
+			),
+			wantIs:   []error{errdefs.ErrCellValidation, errdefs.ErrInvalidExtraHost},
+			wantMsgs: []string{`container "worker" maps "db" to 10.0.0.6 but container "app" maps it to 10.0.0.5`},
+		},
+		{
+			name: "extra hosts on host-network cell",
+			cell: validCellWithContainers(intmodel.ContainerSpec{
+				ID: "root", Image: "nginx", Root: true, HostNetwork: true, ExtraHosts: []string{"db:10.0.0.5"},
+			}),
+			wantIs:   []error{errdefs.ErrCellValidation, errdefs.ErrInvalidExtraHost},
+			wantMsgs: []string{"the root container uses the host network"},
+		},
+		{
+			name: "relative seccomp profile path",
+			cell: validCellWithContainers(intmodel.ContainerSpec{
+				ID: "app", Image: "nginx", SeccompProfile: "profiles/app.json",
+			}),
+			wantIs: []error{errdefs.ErrCellValidation, errdefs.ErrInvalidSeccompProfile},
+			wantMsgs: []string{
+				`container "app": invalid seccomp profile: "profiles/app.json" is not runtime-default, unconfined or an absolute profile path`,
+			},
+		},
+		{
+			name: "seccomp profile with seccomp securityOpt",
+			cell: validCellWithContainers(intmodel.ContainerSpec{
+				ID: "app", Image: "nginx", SeccompProfile: "runtime-default",
+				SecurityOpts: []string{"seccomp=unconfined"},
+			}),
+			wantIs: []error{errdefs.ErrCellValidation, errdefs.ErrInvalidSeccompProfile},
+			wantMsgs: []string{
+				`container "app": invalid seccomp profile: seccompProfile "runtime-default" conflicts with a seccomp securityOpts entry`,
+			},
+		},
+		{
+			name: "apparmor profile with whitespace",
+			cell: validCellWithContainers(intmodel.ContainerSpec{
+				ID: "app", Image: "nginx", AppArmorProfile: "docker default",
+			}),
+			wantIs:   []error{errdefs.ErrCellValidation, errdefs.ErrInvalidAppArmorProfile},
+			wantMsgs: []string{`container "app": invalid apparmor profile: "docker default" contains whitespace or control characters`},
+		},
+		{
+			name: "open stdin on attachable container",
+			cell: validCellWithContainers(intmodel.ContainerSpec{
+				ID: "app", Image: "nginx", Attachable: true, OpenStdin: true,
+			}),
+			wantIs:   []error{errdefs.ErrCellValidation, errdefs.ErrInvalidStdin},
+			wantMsgs: []string{`container "app": invalid container stdin: openStdin is not supported on attachable containers`},
+		},
+		{
+			name: "stdin file without open stdin",
+			cell: validCellWithContainers(intmodel.ContainerSpec{
+				ID: "app", Image: "nginx", StdinFile: "/srv/input",
+			}),
+			wantIs:   []error{errdefs.ErrCellValidation, errdefs.ErrInvalidStdin},
+			wantMsgs: []string{"stdinOnce and stdinFile require openStdin"},
+		},
+		{
+			name: "bandwidth rate without burst",
+			cell: func() intmodel.Cell {
+				cell := validCellWithContainers(intmodel.ContainerSpec{ID: "app", Image: "nginx"})
+				cell.Spec.Bandwidth = &intmodel.CellBandwidth{EgressRate: "10Mbit"}
+				return cell
+			}(),
+			wantIs:   []error{errdefs.ErrCellValidation, errdefs.ErrInvalidBandwidth},
+			wantMsgs: []string{"egress rate and burst must be set together"},
+		},
+		{
+			name: "valid bandwidth",
+			cell: func() intmodel.Cell {
+				cell := validCellWithContainers(intmodel.ContainerSpec{ID: "app", Image: "nginx"})
+				cell.Spec.Bandwidth = &intmodel.CellBandwidth{IngressRate: "10Mbit", IngressBurst: "1Mbit"}
+				return cell
+			}(),
+		},
+		{
+			name: "blank lifecycle hook and negative timeout",
+			cell: func() intmodel.Cell {
+				cell := validCellWithContainers(intmodel.ContainerSpec{ID: "app", Image: "nginx"})
+				cell.Spec.Lifecycle = &intmodel.CellLifecycle{
+					PreStart: []string{"mount /data"}, PostStop: []string{" "}, TimeoutSeconds: -1,
+				}
+				return cell
+			}(),
+			wantIs: []error{errdefs.ErrCellValidation},
+			wantMsgs: []string{
+				"lifecycle.postStop[0]: command is empty",
+				"lifecycle.timeoutSeconds: -1 is negative",
+			},
+		},
+		{
+			name: "blank container lifecycle hook",
+			cell: validCellWithContainers(intmodel.ContainerSpec{
+				ID:        "app",
+				Image:     "nginx",
+				Lifecycle: &intmodel.ContainerLifecycle{PostStart: []string{""}, PreStop: []string{"nginx -s quit"}},
+			}),
+			wantIs:   []error{errdefs.ErrCellValidation},
+			wantMsgs: []string{`container "app": lifecycle.postStart[0]: command is empty`},
+		},
+		{
+			name: "all problems are reported together",
+			cell: func() intmodel.Cell {
+				cell := validCellWithContainers(
+					intmodel.ContainerSpec{ID: "app"},
+					intmodel.ContainerSpec{ID: "app", Image: "nginx"},
+				)
+				cell.Spec.RootContainerID = "init"
+				return cell
+			}(),
+			setupRunner: func(f *fakeRunner) {
+				f.GetRealmFn = func(intmodel.Realm) (intmodel.Realm, error) {
+					return intmodel.Realm{}, errdefs.ErrRealmNotFound
+				}
+			},
+			wantIs: []error{errdefs.ErrCellValidation, errdefs.ErrRealmNotFound},
+			wantMsgs: []string{
+				`realm not found: "r1"`,
+				`container "app" has no image`,
+				`container "app" is declared more than once`,
+				`rootContainerId "init" does not match any container`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRunner := &fakeRunner{}
+			if tt.setupRunner != nil {
+				tt.setupRunner(mockRunner)
+			}
+			ctrl := setupTestController(t, withReadyParents(mockRunner))
+
+			err := ctrl.ValidateCell(tt.cell)
+			if len(tt.wantIs) == 0 {
+				if err != nil {
+					t.Fatalf("ValidateCell() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("ValidateCell() error = nil, want validation error")
+			}
+			for _, want := range tt.wantIs {
+				if !errors.Is(err, want) {
+					t.Errorf("ValidateCell() error = %v, want errors.Is %v", err, want)
+				}
+			}
+			for _, msg := range tt.wantMsgs {
+				if !strings.Contains(err.Error(), msg) {
+					t.Errorf("ValidateCell() error = %q, want it to mention %q", err, msg)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCell_RunnerErrorIsNotAValidationProblem(t *testing.T) {
+	runnerErr := errors.New("metadata unreadable")
+	mockRunner := &fakeRunner{
+		GetRealmFn: func(intmodel.Realm) (intmodel.Realm, error) {
+			return intmodel.Realm{}, runnerErr
+		},
+	}
+	ctrl := setupTestController(t, mockRunner)
+
+	err := ctrl.ValidateCell(validCellWithContainers(intmodel.ContainerSpec{ID: "app", Image: "nginx"}))
+	if !errors.Is(err, runnerErr) {
+		t.Fatalf("ValidateCell() error = %v, want %v", err, runnerErr)
+	}
+	if errors.Is(err, errdefs.ErrCellValidation) {
+		t.Errorf("ValidateCell() error = %v, should not wrap ErrCellValidation", err)
+	}
+}
+
+// TestValidateCell_WaitForParent covers `--wait-for-parent`: a stack that
+// turns Ready while the cell waits lets validation pass, and one that never
+// does fails once the wait lapses, naming the stack and its state.
+func TestValidateCell_WaitForParent(t *testing.T) {
+	t.Run("parent becomes ready", func(t *testing.T) {
+		var polls atomic.Int32
+		mockRunner := withReadyParents(&fakeRunner{
+			GetStackFn: func(stack intmodel.Stack) (intmodel.Stack, error) {
+				if polls.Add(1) >= 3 {
+					stack.Status.State = intmodel.StackStateReady
+				}
+				return stack, nil
+			},
+		})
+		ctrl := setupTestController(t, mockRunner)
+
+		cell := validCellWithContainers(intmodel.ContainerSpec{ID: "app", Image: "nginx"})
+		cell.Spec.WaitForParentSeconds = 5
+		if err := ctrl.ValidateCell(cell); err != nil {
+			t.Fatalf("ValidateCell() error = %v, want nil", err)
+		}
+		if got := polls.Load(); got != 3 {
+			t.Errorf("stack polled %d times, want 3", got)
+		}
+	})
+
+	t.Run("missing parent appears", func(t *testing.T) {
+		var polls atomic.Int32
+		mockRunner := withReadyParents(&fakeRunner{
+			GetStackFn: func(stack intmodel.Stack) (intmodel.Stack, error) {
+				if polls.Add(1) == 1 {
+					return intmodel.Stack{}, errdefs.ErrStackNotFound
+				}
+				stack.Status.State = intmodel.StackStateReady
+				return stack, nil
+			},
+		})
+		ctrl := setupTestController(t, mockRunner)
+
+		cell := validCellWithContainers(intmodel.ContainerSpec{ID: "app", Image: "nginx"})
+		cell.Spec.WaitForParentSeconds = 5
+		if err := ctrl.ValidateCell(cell); err != nil {
+			t.Fatalf("ValidateCell() error = %v, want nil", err)
+		}
+	})
+
+	t.Run("timeout names the parent", func(t *testing.T) {
+		mockRunner := withReadyParents(&fakeRunner{
+			GetStackFn: func(stack intmodel.Stack) (intmodel.Stack, error) {
+				stack.Status.State = intmodel.StackStatePending
+				return stack, nil
+			},
+		})
+		ctrl := setupTestController(t, mockRunner)
+
+		cell := validCellWithContainers(intmodel.ContainerSpec{ID: "app", Image: "nginx"})
+		cell.Spec.WaitForParentSeconds = 1
+		start := time.Now()
+		err := ctrl.ValidateCell(cell)
+		if elapsed := time.Since(start); elapsed < time.Second {
+			t.Errorf("ValidateCell() returned after %s, want it to wait the full second", elapsed)
+		}
+		for _, want := range []error{errdefs.ErrCellValidation, errdefs.ErrParentWaitTimeout, errdefs.ErrParentNotReady} {
+			if !errors.Is(err, want) {
+				t.Errorf("ValidateCell() error = %v, want errors.Is %v", err, want)
+			}
+		}
+		if msg := `stack "st1" in space "s1", realm "r1" is Pending`; err == nil || !strings.Contains(err.Error(), msg) {
+			t.Errorf("ValidateCell() error = %v, want it to mention %q", err, msg)
+		}
+	})
+
+	t.Run("no wait fails fast", func(t *testing.T) {
+		var polls atomic.Int32
+		mockRunner := withReadyParents(&fakeRunner{
+			GetStackFn: func(stack intmodel.Stack) (intmodel.Stack, error) {
+				polls.Add(1)
+				return stack, nil
+			},
+		})
+		ctrl := setupTestController(t, mockRunner)
+
+		err := ctrl.ValidateCell(validCellWithContainers(intmodel.ContainerSpec{ID: "app", Image: "nginx"}))
+		if !errors.Is(err, errdefs.ErrParentNotReady) || errors.Is(err, errdefs.ErrParentWaitTimeout) {
+			t.Fatalf("ValidateCell() error = %v, want ErrParentNotReady without a wait", err)
+		}
+		if got := polls.Load(); got != 1 {
+			t.Errorf("stack polled %d times, want 1", got)
+		}
+	})
+}
+
+func TestCreateCell_RejectsInvalidCellBeforeRunner(t *testing.T) {
+	mockRunner := &fakeRunner{
+		GetStackFn: func(intmodel.Stack) (intmodel.Stack, error) {
+			return intmodel.Stack{}, errdefs.ErrStackNotFound
+		},
+		CreateCellFn: func(intmodel.Cell) (intmodel.Cell, error) {
+			t.Fatal("runner.CreateCell must not be called for an invalid cell")
+			return intmodel.Cell{}, nil
+		},
+	}
+	ctrl := setupTestController(t, withReadyParents(mockRunner))
+
+	_, err := ctrl.CreateCell(validCellWithContainers(intmodel.ContainerSpec{ID: "app", Image: "nginx"}))
+	if !errors.Is(err, errdefs.ErrCellValidation) || !errors.Is(err, errdefs.ErrStackNotFound) {
+		t.Fatalf("CreateCell() error = %v, want ErrCellValidation wrapping ErrStackNotFound", err)
+	}
+}
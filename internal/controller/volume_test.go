@@ -363,7 +363,7 @@ func TestApplyDocuments_VolumeRoutesToReconcile(t *testing.T) {
 		},
 	}
 
-	result, err := exec.ApplyDocuments(docs, "")
+	result, err := exec.ApplyDocuments(docs, "", "")
 	if err != nil {
 		t.Fatalf("ApplyDocuments() error = %v", err)
 	}
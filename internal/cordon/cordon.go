@@ -0,0 +1,150 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cordon records whether the node is cordoned: closed to new cells
+// and containers for maintenance while everything already provisioned keeps
+// running. The state is a marker file under runPath, so the daemon and the
+// in-process `--no-daemon` path see the same answer without an RPC.
+package cordon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+// MarkerFile is the basename of the cordon marker written under runPath. Its
+// presence is what cordons the node; the dot prefix keeps it out of the way
+// of realm directories, like the instance metadata file.
+const MarkerFile = ".kukeon-cordon.json"
+
+// markerFileMode mirrors the instance metadata file's mode so the kukeon
+// group can read the marker without world access.
+const markerFileMode os.FileMode = 0o640
+
+// Marker is the on-disk shape of the cordon marker.
+type Marker struct {
+	// Since is when the node was cordoned.
+	Since time.Time `json:"since"`
+	// Reason is the operator's optional note (`kuke cordon --reason`).
+	Reason string `json:"reason,omitempty"`
+}
+
+// Path returns the absolute path of the cordon marker under runPath.
+func Path(runPath string) string {
+	return filepath.Join(runPath, MarkerFile)
+}
+
+// Load reads the cordon marker at runPath. Returns (zero, false, nil) when
+// the node is not cordoned. A marker that exists but does not parse still
+// reports the node cordoned, with the parse error, so a damaged file never
+// silently reopens the node.
+func Load(runPath string) (Marker, bool, error) {
+	path := Path(runPath)
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Marker{}, false, nil
+		}
+		return Marker{}, false, fmt.Errorf("read cordon marker %q: %w", path, err)
+	}
+	var m Marker
+	if unmarshalErr := json.Unmarshal(raw, &m); unmarshalErr != nil {
+		return Marker{}, true, fmt.Errorf("parse cordon marker %q: %w", path, unmarshalErr)
+	}
+	return m, true, nil
+}
+
+// Cordon writes the cordon marker under runPath and returns it. Cordoning an
+// already cordoned node keeps the original marker and reports changed=false,
+// so a repeated `kuke cordon` does not reset Since.
+func Cordon(runPath, reason string, now time.Time) (Marker, bool, error) {
+	prior, found, err := Load(runPath)
+	if found && err == nil {
+		return prior, false, nil
+	}
+
+	m := Marker{Since: now.UTC(), Reason: reason}
+	raw, marshalErr := json.MarshalIndent(m, "", "  ")
+	if marshalErr != nil {
+		return Marker{}, false, fmt.Errorf("marshal cordon marker: %w", marshalErr)
+	}
+	raw = append(raw, '\n')
+
+	if mkErr := os.MkdirAll(runPath, 0o750); mkErr != nil {
+		return Marker{}, false, fmt.Errorf("create runPath %q: %w", runPath, mkErr)
+	}
+	tmp, createErr := os.CreateTemp(runPath, ".kukeon-cordon-*.tmp")
+	if createErr != nil {
+		return Marker{}, false, fmt.Errorf("create temp file under %q: %w", runPath, createErr)
+	}
+	tmpPath := tmp.Name()
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+	}()
+	if chmodErr := tmp.Chmod(markerFileMode); chmodErr != nil {
+		return Marker{}, false, fmt.Errorf("chmod %q: %w", tmpPath, chmodErr)
+	}
+	if _, writeErr := tmp.Write(raw); writeErr != nil {
+		return Marker{}, false, fmt.Errorf("write %q: %w", tmpPath, writeErr)
+	}
+	if closeErr := tmp.Close(); closeErr != nil {
+		return Marker{}, false, fmt.Errorf("close %q: %w", tmpPath, closeErr)
+	}
+	if renameErr := os.Rename(tmpPath, Path(runPath)); renameErr != nil {
+		return Marker{}, false, fmt.Errorf("rename %q -> %q: %w", tmpPath, Path(runPath), renameErr)
+	}
+	return m, true, nil
+}
+
+// Uncordon removes the cordon marker under runPath. Reports changed=false
+// when the node was not cordoned.
+func Uncordon(runPath string) (bool, error) {
+	err := os.Remove(Path(runPath))
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, os.ErrNotExist):
+		return false, nil
+	default:
+		return false, fmt.Errorf("remove cordon marker %q: %w", Path(runPath), err)
+	}
+}
+
+// Guard returns an errdefs.ErrNodeCordoned-wrapped error naming what was
+// refused when the node under runPath is cordoned, and nil otherwise. An
+// unreadable marker fails closed.
+func Guard(runPath, what string) error {
+	m, cordoned, err := Load(runPath)
+	if !cordoned {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("%w: refusing to create %s: %w", errdefs.ErrNodeCordoned, what, err)
+	}
+	reason := ""
+	if m.Reason != "" {
+		reason = ": " + m.Reason
+	}
+	return fmt.Errorf("%w: refusing to create %s (cordoned since %s%s); run `kuke uncordon` to allow it",
+		errdefs.ErrNodeCordoned, what, m.Since.Format(time.RFC3339), reason)
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cordon_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eminwux/kukeon/internal/cordon"
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+func TestCordonUncordonRoundTrip(t *testing.T) {
+	runPath := t.TempDir()
+	since := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	if _, cordoned, err := cordon.Load(runPath); err != nil || cordoned {
+		t.Fatalf("fresh runPath: cordoned=%v err=%v, want false, nil", cordoned, err)
+	}
+
+	m, changed, err := cordon.Cordon(runPath, "kernel upgrade", since)
+	if err != nil || !changed {
+		t.Fatalf("Cordon: changed=%v err=%v, want true, nil", changed, err)
+	}
+	if !m.Since.Equal(since) || m.Reason != "kernel upgrade" {
+		t.Errorf("Cordon marker = %+v", m)
+	}
+
+	// A repeated cordon keeps the original marker.
+	again, changed, err := cordon.Cordon(runPath, "other", since.Add(time.Hour))
+	if err != nil || changed {
+		t.Fatalf("second Cordon: changed=%v err=%v, want false, nil", changed, err)
+	}
+	if !again.Since.Equal(since) || again.Reason != "kernel upgrade" {
+		t.Errorf("second Cordon replaced the marker: %+v", again)
+	}
+
+	if changed, err = cordon.Uncordon(runPath); err != nil || !changed {
+		t.Fatalf("Uncordon: changed=%v err=%v, want true, nil", changed, err)
+	}
+	if changed, err = cordon.Uncordon(runPath); err != nil || changed {
+		t.Fatalf("second Uncordon: changed=%v err=%v, want false, nil", changed, err)
+	}
+}
+
+func TestGuard(t *testing.T) {
+	runPath := t.TempDir()
+	if err := cordon.Guard(runPath, `cell "web"`); err != nil {
+		t.Fatalf("Guard on an uncordoned node: %v", err)
+	}
+
+	if _, _, err := cordon.Cordon(runPath, "maintenance", time.Now()); err != nil {
+		t.Fatalf("Cordon: %v", err)
+	}
+	err := cordon.Guard(runPath, `cell "web"`)
+	if !errors.Is(err, errdefs.ErrNodeCordoned) {
+		t.Fatalf("Guard on a cordoned node: got %v, want ErrNodeCordoned", err)
+	}
+	for _, want := range []string{`cell "web"`, "maintenance", "kuke uncordon"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Guard error %q does not mention %q", err, want)
+		}
+	}
+}
+
+func TestGuard_UnreadableMarkerFailsClosed(t *testing.T) {
+	runPath := t.TempDir()
+	if err := os.WriteFile(cordon.Path(runPath), []byte("{not json"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	if err := cordon.Guard(runPath, `cell "web"`); !errors.Is(err, errdefs.ErrNodeCordoned) {
+		t.Fatalf("Guard with a damaged marker: got %v, want ErrNodeCordoned", err)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/containerd/containerd/v2/core/containers"
+	"github.com/containerd/containerd/v2/pkg/oci"
+	internalerrdefs "github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	runtimespec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// apparmorEnabledPath and apparmorProfilesPath are the kernel files the
+// create-time AppArmor check reads. Vars so tests can point the check at
+// fixtures.
+//
+//nolint:gochecknoglobals // test seam
+var (
+	apparmorEnabledPath  = "/sys/module/apparmor/parameters/enabled"
+	apparmorProfilesPath = "/sys/kernel/security/apparmor/profiles"
+)
+
+// ValidateAppArmorProfile checks a spec.appArmorProfile value without
+// reading the host: it must not contain whitespace or control characters.
+// Whether the profile is loaded is checked at create, where the host is
+// known. Errors wrap errdefs.ErrInvalidAppArmorProfile.
+func ValidateAppArmorProfile(profile string) error {
+	if strings.IndexFunc(profile, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) >= 0 {
+		return fmt.Errorf("%w: %q contains whitespace or control characters",
+			internalerrdefs.ErrInvalidAppArmorProfile, profile)
+	}
+	return nil
+}
+
+// appArmorProfileSpecOpt sets Process.ApparmorProfile to profile after
+// checking the host can honour it. "unconfined" is always accepted; on a
+// host without AppArmor it leaves the field empty, which is the same thing
+// there. A named profile requires AppArmor to be enabled and, when the
+// kernel's profile list is readable, the profile to be loaded.
+func appArmorProfileSpecOpt(profile string) oci.SpecOpts {
+	if err := ValidateAppArmorProfile(profile); err != nil {
+		return errorSpecOpt(err)
+	}
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *runtimespec.Spec) error {
+		enabled := appArmorEnabled()
+		if profile == intmodel.AppArmorProfileUnconfined {
+			if s.Process == nil {
+				s.Process = &runtimespec.Process{}
+			}
+			if enabled {
+				s.Process.ApparmorProfile = profile
+			} else {
+				s.Process.ApparmorProfile = ""
+			}
+			return nil
+		}
+		if !enabled {
+			return fmt.Errorf("%w: %q: AppArmor is not enabled on this host",
+				internalerrdefs.ErrInvalidAppArmorProfile, profile)
+		}
+		loaded, err := appArmorProfileLoaded(profile)
+		if err != nil {
+			return err
+		}
+		if !loaded {
+			return fmt.Errorf("%w: %q is not loaded on this host (load it with apparmor_parser)",
+				internalerrdefs.ErrInvalidAppArmorProfile, profile)
+		}
+		if s.Process == nil {
+			s.Process = &runtimespec.Process{}
+		}
+		s.Process.ApparmorProfile = profile
+		return nil
+	}
+}
+
+// appArmorEnabled reports whether the AppArmor LSM is enabled on the host.
+func appArmorEnabled() bool {
+	data, err := os.ReadFile(apparmorEnabledPath)
+	return err == nil && strings.HasPrefix(strings.TrimSpace(string(data)), "Y")
+}
+
+// appArmorProfileLoaded reports whether profile appears in the kernel's
+// profile list. The list lives in securityfs and is readable only by root
+// with securityfs mounted, so a missing or unreadable file reports loaded
+// and leaves the final word to the runtime.
+func appArmorProfileLoaded(profile string) (bool, error) {
+	file, err := os.Open(apparmorProfilesPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) || errors.Is(err, os.ErrPermission) {
+			return true, nil
+		}
+		return false, fmt.Errorf("read apparmor profiles: %w", err)
+	}
+	defer file.Close()
+
+	// Each line is "<name> (<mode>)"; the name itself may contain spaces.
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.LastIndex(line, " ("); i >= 0 {
+			line = line[:i]
+		}
+		if line == profile {
+			return true, nil
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return false, fmt.Errorf("read apparmor profiles: %w", err)
+	}
+	return false, nil
+}
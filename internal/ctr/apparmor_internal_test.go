@@ -0,0 +1,129 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	runtimespec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// pinAppArmorHost points the AppArmor host check at fixture files for the
+// duration of a test. An empty enabled value leaves the enabled file absent,
+// as on a host without the AppArmor module.
+func pinAppArmorHost(t *testing.T, enabled, profiles string) {
+	t.Helper()
+	dir := t.TempDir()
+	origEnabled, origProfiles := apparmorEnabledPath, apparmorProfilesPath
+	t.Cleanup(func() { apparmorEnabledPath, apparmorProfilesPath = origEnabled, origProfiles })
+	apparmorEnabledPath = filepath.Join(dir, "enabled")
+	apparmorProfilesPath = filepath.Join(dir, "profiles")
+	if enabled != "" {
+		if err := os.WriteFile(apparmorEnabledPath, []byte(enabled), 0o600); err != nil {
+			t.Fatalf("write enabled fixture: %v", err)
+		}
+	}
+	if err := os.WriteFile(apparmorProfilesPath, []byte(profiles), 0o600); err != nil {
+		t.Fatalf("write profiles fixture: %v", err)
+	}
+}
+
+func applyAppArmorProfile(profile string) (*runtimespec.Spec, error) {
+	spec := &runtimespec.Spec{Process: &runtimespec.Process{}, Linux: &runtimespec.Linux{}}
+	built := BuildContainerSpec(intmodel.ContainerSpec{
+		ID:              "c1",
+		Image:           "registry.eminwux.com/busybox:latest",
+		CellName:        "cell",
+		SpaceName:       "space",
+		RealmName:       "realm",
+		StackName:       "stack",
+		AppArmorProfile: profile,
+	})
+	for _, opt := range built.SpecOpts {
+		if err := opt(context.Background(), nil, nil, spec); err != nil {
+			return spec, err
+		}
+	}
+	return spec, nil
+}
+
+const appArmorProfilesFixture = "docker-default (enforce)\nkukeon-app (complain)\n/usr/bin/man (enforce)\n"
+
+func TestBuildContainerSpec_AppArmorProfileLoaded(t *testing.T) {
+	pinAppArmorHost(t, "Y\n", appArmorProfilesFixture)
+	for _, profile := range []string{"docker-default", "kukeon-app", "/usr/bin/man"} {
+		spec, err := applyAppArmorProfile(profile)
+		if err != nil {
+			t.Fatalf("profile %q: SpecOpts returned error: %v", profile, err)
+		}
+		if spec.Process.ApparmorProfile != profile {
+			t.Errorf("Process.ApparmorProfile = %q, want %q", spec.Process.ApparmorProfile, profile)
+		}
+	}
+}
+
+func TestBuildContainerSpec_AppArmorProfileUnknownErrors(t *testing.T) {
+	pinAppArmorHost(t, "Y\n", appArmorProfilesFixture)
+	_, err := applyAppArmorProfile("no-such-profile")
+	if !errors.Is(err, errdefs.ErrInvalidAppArmorProfile) {
+		t.Fatalf("SpecOpts error = %v, want ErrInvalidAppArmorProfile", err)
+	}
+}
+
+func TestBuildContainerSpec_AppArmorProfileDisabledHost(t *testing.T) {
+	pinAppArmorHost(t, "", "")
+	_, err := applyAppArmorProfile("docker-default")
+	if !errors.Is(err, errdefs.ErrInvalidAppArmorProfile) {
+		t.Fatalf("SpecOpts error = %v, want ErrInvalidAppArmorProfile", err)
+	}
+
+	// unconfined is the escape hatch: accepted whether or not AppArmor is on.
+	spec, err := applyAppArmorProfile(intmodel.AppArmorProfileUnconfined)
+	if err != nil {
+		t.Fatalf("unconfined on a host without AppArmor: %v", err)
+	}
+	if spec.Process.ApparmorProfile != "" {
+		t.Errorf("Process.ApparmorProfile = %q, want empty without AppArmor", spec.Process.ApparmorProfile)
+	}
+
+	pinAppArmorHost(t, "Y\n", "")
+	spec, err = applyAppArmorProfile(intmodel.AppArmorProfileUnconfined)
+	if err != nil {
+		t.Fatalf("unconfined on an AppArmor host: %v", err)
+	}
+	if spec.Process.ApparmorProfile != intmodel.AppArmorProfileUnconfined {
+		t.Errorf("Process.ApparmorProfile = %q, want %q",
+			spec.Process.ApparmorProfile, intmodel.AppArmorProfileUnconfined)
+	}
+}
+
+func TestBuildContainerSpec_AppArmorProfileUnset(t *testing.T) {
+	pinAppArmorHost(t, "Y\n", appArmorProfilesFixture)
+	spec, err := applyAppArmorProfile("")
+	if err != nil {
+		t.Fatalf("SpecOpts returned error: %v", err)
+	}
+	if spec.Process.ApparmorProfile != "" {
+		t.Errorf("Process.ApparmorProfile = %q, want the runtime default (empty)", spec.Process.ApparmorProfile)
+	}
+}
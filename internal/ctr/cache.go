@@ -114,3 +114,14 @@ func (c *client) dropTask(namespace, id string) {
 		delete(c.tasks, cacheKey(namespace, id))
 	}
 }
+
+// resetCaches drops every cached container and task.
+func (c *client) resetCaches() {
+	c.containersMu.Lock()
+	c.containers = make(map[string]containerd.Container)
+	c.containersMu.Unlock()
+
+	c.tasksMu.Lock()
+	c.tasks = make(map[string]containerd.Task)
+	c.tasksMu.Unlock()
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+)
+
+// CgroupMode is the cgroup hierarchy layout of the host.
+type CgroupMode int
+
+const (
+	// CgroupModeUnknown means neither a cgroup v1 nor a cgroup v2 mount was
+	// found, or /proc/self/mountinfo could not be read.
+	CgroupModeUnknown CgroupMode = iota
+	// CgroupModeUnified is the cgroup v2 hierarchy kukeon requires.
+	CgroupModeUnified
+	// CgroupModeHybrid mounts the v1 controllers alongside an empty cgroup2
+	// tree (systemd's "hybrid" layout). The controllers kukeon delegates
+	// are bound to v1, so it is unsupported like Legacy.
+	CgroupModeHybrid
+	// CgroupModeLegacy mounts only cgroup v1 hierarchies.
+	CgroupModeLegacy
+)
+
+func (m CgroupMode) String() string {
+	switch m {
+	case CgroupModeUnified:
+		return "unified"
+	case CgroupModeHybrid:
+		return "hybrid"
+	case CgroupModeLegacy:
+		return "legacy"
+	default:
+		return "unknown"
+	}
+}
+
+// GetCgroupMode returns the host cgroup mode, detected once from
+// /proc/self/mountinfo and cached for the life of the client.
+func (c *client) GetCgroupMode() CgroupMode {
+	c.cgroupModeOnce.Do(func() {
+		file, err := os.Open("/proc/self/mountinfo")
+		if err != nil {
+			c.logger.WarnContext(c.ctx, "failed to open /proc/self/mountinfo", "error", err)
+			return
+		}
+		defer file.Close()
+		c.cgroupMode, err = cgroupModeFromMountinfo(file)
+		if err != nil {
+			c.logger.WarnContext(c.ctx, "failed to detect cgroup mode", "error", err)
+			return
+		}
+		c.logger.DebugContext(c.ctx, "detected cgroup mode", "mode", c.cgroupMode.String())
+	})
+	return c.cgroupMode
+}
+
+// cgroupModeFromMountinfo classifies the mount table: any cgroup v1
+// hierarchy makes the host Legacy, or Hybrid when a cgroup2 tree is also
+// mounted; a cgroup2 mount alone makes it Unified.
+func cgroupModeFromMountinfo(r io.Reader) (CgroupMode, error) {
+	var hasV1, hasV2 bool
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// The filesystem type is the field after the "-" separator; see
+		// parseMountinfo for the line layout.
+		_, after, found := strings.Cut(scanner.Text(), " - ")
+		if !found {
+			continue
+		}
+		fields := strings.Fields(after)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "cgroup":
+			hasV1 = true
+		case "cgroup2":
+			hasV2 = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return CgroupModeUnknown, err
+	}
+	switch {
+	case hasV1 && hasV2:
+		return CgroupModeHybrid, nil
+	case hasV1:
+		return CgroupModeLegacy, nil
+	case hasV2:
+		return CgroupModeUnified, nil
+	default:
+		return CgroupModeUnknown, nil
+	}
+}
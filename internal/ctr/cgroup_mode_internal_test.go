@@ -0,0 +1,54 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCgroupModeFromMountinfo(t *testing.T) {
+	const (
+		rootfs  = "22 1 259:2 / / rw,relatime shared:1 - ext4 /dev/root rw\n"
+		unified = "30 22 0:26 / /sys/fs/cgroup rw,nosuid shared:4 - cgroup2 cgroup2 rw,nsdelegate\n"
+		hybrid  = "31 30 0:27 / /sys/fs/cgroup/unified rw,nosuid shared:5 - cgroup2 cgroup2 rw\n"
+		v1mem   = "32 30 0:28 / /sys/fs/cgroup/memory rw,nosuid shared:6 - cgroup cgroup rw,memory\n"
+		v1cpu   = "33 30 0:29 / /sys/fs/cgroup/cpu rw,nosuid shared:7 - cgroup cgroup rw,cpu,cpuacct\n"
+	)
+	tests := []struct {
+		name      string
+		mountinfo string
+		want      CgroupMode
+	}{
+		{name: "unified", mountinfo: rootfs + unified, want: CgroupModeUnified},
+		{name: "hybrid", mountinfo: rootfs + hybrid + v1mem + v1cpu, want: CgroupModeHybrid},
+		{name: "legacy", mountinfo: rootfs + v1mem + v1cpu, want: CgroupModeLegacy},
+		{name: "no cgroup mounts", mountinfo: rootfs, want: CgroupModeUnknown},
+		{name: "malformed lines ignored", mountinfo: "garbage\n" + unified, want: CgroupModeUnified},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cgroupModeFromMountinfo(strings.NewReader(tt.mountinfo))
+			if err != nil {
+				t.Fatalf("cgroupModeFromMountinfo() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("cgroupModeFromMountinfo() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
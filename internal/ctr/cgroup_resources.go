@@ -45,6 +45,10 @@ func (r CgroupResources) toResources() (*cgroup2.Resources, error) {
 		resources.IO = io
 	}
 
+	if r.Pids != nil && r.Pids.Max > 0 {
+		resources.Pids = &cgroup2.Pids{Max: r.Pids.Max}
+	}
+
 	return resources, nil
 }
 
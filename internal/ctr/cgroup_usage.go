@@ -0,0 +1,144 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// CgroupUsage is a point-in-time snapshot of a cgroup v2 group's resource
+// usage. Each metric is a pointer so a missing interface file (the controller
+// is not enabled in the parent's subtree_control) is reported as "absent"
+// rather than as a misleading zero.
+type CgroupUsage struct {
+	// MemoryCurrent is memory.current in bytes.
+	MemoryCurrent *uint64
+	// MemoryMax is memory.max in bytes. Nil when the file is missing or the
+	// limit is "max" (unlimited); MemoryUnlimited distinguishes the two.
+	MemoryMax       *uint64
+	MemoryUnlimited bool
+	// PidsCurrent is pids.current.
+	PidsCurrent *uint64
+	// CPUUsageUsec is the usage_usec line of cpu.stat.
+	CPUUsageUsec *uint64
+	// OOMKills is the oom_kill line of memory.events: the number of
+	// processes in the group (or its descendants) the OOM killer has killed.
+	OOMKills *uint64
+}
+
+// CgroupUsage reads the live memory, pids, and cpu usage counters and the
+// OOM kill count of the named cgroup. Metrics whose interface file is absent are left nil; a
+// missing cgroup directory is reported as an error.
+func (c *client) CgroupUsage(group, mountpoint string) (CgroupUsage, error) {
+	var usage CgroupUsage
+
+	cgroupPath, err := c.CgroupPath(group, mountpoint)
+	if err != nil {
+		return usage, err
+	}
+	if _, err = os.Stat(cgroupPath); err != nil {
+		if os.IsNotExist(err) {
+			return usage, errors.New("cgroup path does not exist")
+		}
+		return usage, err
+	}
+
+	if usage.MemoryCurrent, err = readCgroupUint(cgroupPath, "memory.current"); err != nil {
+		return usage, err
+	}
+	raw, err := readCgroupValue(cgroupPath, "memory.max")
+	if err != nil {
+		return usage, err
+	}
+	if raw == "max" {
+		usage.MemoryUnlimited = true
+	} else if raw != "" {
+		if usage.MemoryMax, err = parseCgroupUint("memory.max", raw); err != nil {
+			return usage, err
+		}
+	}
+	if usage.PidsCurrent, err = readCgroupUint(cgroupPath, "pids.current"); err != nil {
+		return usage, err
+	}
+	if usage.CPUUsageUsec, err = readFlatKey(cgroupPath, "cpu.stat", "usage_usec"); err != nil {
+		return usage, err
+	}
+	if usage.OOMKills, err = readFlatKey(cgroupPath, "memory.events", "oom_kill"); err != nil {
+		return usage, err
+	}
+	return usage, nil
+}
+
+// readCgroupValue returns the trimmed content of a single-value interface
+// file, or "" when the file does not exist.
+func readCgroupValue(cgroupPath, name string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(cgroupPath, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func readCgroupUint(cgroupPath, name string) (*uint64, error) {
+	raw, err := readCgroupValue(cgroupPath, name)
+	if err != nil || raw == "" {
+		return nil, err
+	}
+	return parseCgroupUint(name, raw)
+}
+
+func parseCgroupUint(name, raw string) (*uint64, error) {
+	v, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s value %q: %w", name, raw, err)
+	}
+	return &v, nil
+}
+
+// readFlatKey extracts a single key from a flat-keyed interface file such
+// as cpu.stat or memory.events. A missing file or key is treated as absent.
+func readFlatKey(cgroupPath, name, key string) (*uint64, error) {
+	f, err := os.Open(filepath.Join(cgroupPath, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == key {
+			return parseCgroupUint(name+" "+key, fields[1])
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return nil, nil
+}
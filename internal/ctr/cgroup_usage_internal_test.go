@@ -0,0 +1,131 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeCgroupFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", dir, err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+}
+
+func TestCgroupUsageReadsAllMetrics(t *testing.T) {
+	c := newTestClient(t)
+	mountpoint := t.TempDir()
+	writeCgroupFiles(t, filepath.Join(mountpoint, "kukeon", "r1", "s1", "st1", "c1"), map[string]string{
+		"memory.current": "1048576\n",
+		"memory.max":     "67108864\n",
+		"pids.current":   "7\n",
+		"cpu.stat":       "usage_usec 123456\nuser_usec 100000\nsystem_usec 23456\n",
+		"memory.events":  "low 0\nhigh 0\nmax 3\noom 2\noom_kill 2\noom_group_kill 0\n",
+	})
+
+	usage, err := c.CgroupUsage("/kukeon/r1/s1/st1/c1", mountpoint)
+	if err != nil {
+		t.Fatalf("CgroupUsage: %v", err)
+	}
+	assertUsageValue(t, "memory.current", usage.MemoryCurrent, 1048576)
+	assertUsageValue(t, "memory.max", usage.MemoryMax, 67108864)
+	assertUsageValue(t, "pids.current", usage.PidsCurrent, 7)
+	assertUsageValue(t, "usage_usec", usage.CPUUsageUsec, 123456)
+	assertUsageValue(t, "oom_kill", usage.OOMKills, 2)
+	if usage.MemoryUnlimited {
+		t.Errorf("MemoryUnlimited = true, want false")
+	}
+}
+
+func TestCgroupUsageUnlimitedMemory(t *testing.T) {
+	c := newTestClient(t)
+	mountpoint := t.TempDir()
+	writeCgroupFiles(t, filepath.Join(mountpoint, "g"), map[string]string{
+		"memory.current": "4096\n",
+		"memory.max":     "max\n",
+	})
+
+	usage, err := c.CgroupUsage("/g", mountpoint)
+	if err != nil {
+		t.Fatalf("CgroupUsage: %v", err)
+	}
+	if !usage.MemoryUnlimited {
+		t.Errorf("MemoryUnlimited = false, want true")
+	}
+	if usage.MemoryMax != nil {
+		t.Errorf("MemoryMax = %d, want nil", *usage.MemoryMax)
+	}
+}
+
+func TestCgroupUsageOmitsMissingControllers(t *testing.T) {
+	c := newTestClient(t)
+	mountpoint := t.TempDir()
+	// Only the core cpu.stat is present: memory and pids controllers are not
+	// enabled for this group.
+	writeCgroupFiles(t, filepath.Join(mountpoint, "g"), map[string]string{
+		"cpu.stat": "usage_usec 42\n",
+	})
+
+	usage, err := c.CgroupUsage("/g", mountpoint)
+	if err != nil {
+		t.Fatalf("CgroupUsage: %v", err)
+	}
+	if usage.MemoryCurrent != nil || usage.MemoryMax != nil || usage.PidsCurrent != nil || usage.OOMKills != nil {
+		t.Errorf("expected memory/pids metrics to be omitted, got %+v", usage)
+	}
+	if usage.MemoryUnlimited {
+		t.Errorf("MemoryUnlimited = true, want false for a missing memory.max")
+	}
+	assertUsageValue(t, "usage_usec", usage.CPUUsageUsec, 42)
+}
+
+func TestCgroupUsageMissingGroup(t *testing.T) {
+	c := newTestClient(t)
+	if _, err := c.CgroupUsage("/absent", t.TempDir()); err == nil {
+		t.Fatal("expected error for a missing cgroup directory")
+	}
+}
+
+func TestCgroupUsageMalformedValue(t *testing.T) {
+	c := newTestClient(t)
+	mountpoint := t.TempDir()
+	writeCgroupFiles(t, filepath.Join(mountpoint, "g"), map[string]string{
+		"pids.current": "not-a-number\n",
+	})
+	if _, err := c.CgroupUsage("/g", mountpoint); err == nil {
+		t.Fatal("expected error for a malformed pids.current")
+	}
+}
+
+func assertUsageValue(t *testing.T, name string, got *uint64, want uint64) {
+	t.Helper()
+	if got == nil {
+		t.Errorf("%s = nil, want %d", name, want)
+		return
+	}
+	if *got != want {
+		t.Errorf("%s = %d, want %d", name, *got, want)
+	}
+}
@@ -83,3 +83,46 @@ func DefaultCellSpec(cell intmodel.Cell) CgroupSpec {
 		},
 	}
 }
+
+// CellCgroupSpec is DefaultCellSpec with limits applied to the cell cgroup.
+// limits is the set the cell inherits from its space and realm (see
+// intmodel.ResolveCellResources); nil yields DefaultCellSpec unchanged.
+func CellCgroupSpec(cell intmodel.Cell, limits *intmodel.ContainerResources) CgroupSpec {
+	spec := DefaultCellSpec(cell)
+	spec.Resources = CgroupResourcesFromLimits(limits)
+	return spec
+}
+
+// CgroupResourcesFromLimits maps the container-style limit set onto cgroup
+// v2 knobs: memoryLimitBytes to memory.max, cpuShares to cpu.weight (with
+// runc's cgroup v1 shares conversion), pidsLimit to pids.max. Unset and
+// non-positive values leave the knob alone.
+func CgroupResourcesFromLimits(limits *intmodel.ContainerResources) CgroupResources {
+	var out CgroupResources
+	if limits == nil {
+		return out
+	}
+	if limits.MemoryLimitBytes != nil && *limits.MemoryLimitBytes > 0 {
+		limit := *limits.MemoryLimitBytes
+		out.Memory = &MemoryResources{Max: &limit}
+	}
+	if limits.CPUShares != nil && *limits.CPUShares > 0 {
+		weight := cpuSharesToWeight(*limits.CPUShares)
+		out.CPU = &CPUResources{Weight: &weight}
+	}
+	if limits.PidsLimit != nil && *limits.PidsLimit > 0 {
+		out.Pids = &PidsResources{Max: *limits.PidsLimit}
+	}
+	return out
+}
+
+// cpuSharesToWeight converts cgroup v1 cpu.shares [2, 262144] to cgroup v2
+// cpu.weight [1, 10000], clamping out-of-range shares.
+func cpuSharesToWeight(shares int64) uint64 {
+	const (
+		minShares = 2
+		maxShares = 262144
+	)
+	shares = min(max(shares, minShares), maxShares)
+	return uint64(1 + ((shares-minShares)*9999)/(maxShares-minShares))
+}
@@ -302,3 +302,36 @@ func TestDefaultCellSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestCellCgroupSpec_AppliesLimits(t *testing.T) {
+	memory := int64(256 * 1024 * 1024)
+	shares := int64(1024)
+	pids := int64(100)
+	cell := intmodel.Cell{
+		Metadata: intmodel.CellMetadata{Name: "web"},
+		Spec:     intmodel.CellSpec{RealmName: "main", SpaceName: "apps", StackName: "default"},
+	}
+
+	spec := ctr.CellCgroupSpec(cell, &intmodel.ContainerResources{
+		MemoryLimitBytes: &memory,
+		CPUShares:        &shares,
+		PidsLimit:        &pids,
+	})
+	if spec.Group != ctr.DefaultCellSpec(cell).Group {
+		t.Errorf("Group = %q, want %q", spec.Group, ctr.DefaultCellSpec(cell).Group)
+	}
+	if spec.Resources.Memory == nil || *spec.Resources.Memory.Max != memory {
+		t.Errorf("Memory = %+v, want max %d", spec.Resources.Memory, memory)
+	}
+	// 1024 shares is runc's default and maps to cpu.weight 39.
+	if spec.Resources.CPU == nil || *spec.Resources.CPU.Weight != 39 {
+		t.Errorf("CPU = %+v, want weight 39", spec.Resources.CPU)
+	}
+	if spec.Resources.Pids == nil || spec.Resources.Pids.Max != pids {
+		t.Errorf("Pids = %+v, want max %d", spec.Resources.Pids, pids)
+	}
+
+	if got := ctr.CellCgroupSpec(cell, nil); got.Resources != (ctr.CgroupResources{}) {
+		t.Errorf("CellCgroupSpec(nil).Resources = %+v, want zero", got.Resources)
+	}
+}
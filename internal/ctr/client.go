@@ -23,19 +23,74 @@ import (
 	"io"
 	"log/slog"
 	"sync"
+	"time"
 
 	cgroup2 "github.com/containerd/cgroups/v2/cgroup2"
 	apitypes "github.com/containerd/containerd/api/types"
 	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/eminwux/kukeon/internal/errdefs"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 )
 
+// DefaultConnectTimeout bounds Connect when ClientOptions.ConnectTimeout is
+// unset, so a present-but-unresponsive containerd socket fails the call
+// instead of blocking it forever.
+const DefaultConnectTimeout = 10 * time.Second
+
+// DefaultMaxReconnects is the reconnect budget long-running callers (the
+// daemon's runner) pass via WithReconnect.
+const DefaultMaxReconnects = 3
+
+// DefaultHealthCheckInterval is how long long-running callers (the daemon's
+// runner) trust a verified connection before Connect checks it again.
+const DefaultHealthCheckInterval = 5 * time.Second
+
+// ClientOptions tunes how a Client reaches containerd.
+type ClientOptions struct {
+	// ConnectTimeout bounds each Connect call: dialing the socket plus the
+	// namespace listing that verifies the connection. Zero means
+	// DefaultConnectTimeout.
+	ConnectTimeout time.Duration
+	// MaxReconnects is how many times a read operation that failed because
+	// containerd became unreachable re-dials and retries. Zero disables
+	// reconnection.
+	MaxReconnects int
+	// HealthCheckInterval is how long a verified connection is reused by
+	// Connect without another namespace listing. Every runner operation
+	// calls Connect first, so this bounds the health-check round trips a
+	// busy daemon makes; a connection that drops inside the window is still
+	// re-dialed by the reconnect path of the next read. Zero checks on every
+	// Connect.
+	HealthCheckInterval time.Duration
+}
+
+// ClientOption mutates ClientOptions for NewClient.
+type ClientOption func(*ClientOptions)
+
+// WithReconnect lets read operations re-dial containerd and retry up to
+// maxRetries times when the connection drops, e.g. across a containerd
+// restart.
+func WithReconnect(maxRetries int) ClientOption {
+	return func(o *ClientOptions) {
+		o.MaxReconnects = maxRetries
+	}
+}
+
+// dialFunc opens a containerd client on socket. It is a seam for tests that
+// need a dialer which never answers.
+type dialFunc func(ctx context.Context, socket string, timeout time.Duration) (*containerd.Client, error)
+
 type client struct {
 	ctx                  context.Context
 	logger               *slog.Logger
 	socket               string
+	connectTimeout       time.Duration
+	maxReconnects        int
+	healthCheckInterval  time.Duration
+	dial                 dialFunc
 	cClientMu            sync.Mutex
 	cClient              *containerd.Client
+	verifiedAt           time.Time // last successful health check; zero while disconnected
 	cgroupsMu            sync.RWMutex
 	cgroups              map[string]*cgroup2.Manager
 	containersMu         sync.RWMutex
@@ -45,6 +100,12 @@ type client struct {
 	cgroupMountpointOnce sync.Once
 	cgroupMountpoint     string
 	cgroupMountpointErr  error
+	// streamsAttached maps a task's cache key to the PID whose
+	// SeparateStreams / OpenStdin fifos this process is draining, so StartContainer
+	// only re-attaches to tasks it lost after a daemon restart.
+	streamsAttached sync.Map
+	cgroupModeOnce  sync.Once
+	cgroupMode      CgroupMode
 }
 
 type Client interface {
@@ -58,11 +119,18 @@ type Client interface {
 	ExistsNamespace(namespace string) (bool, error)
 	CleanupNamespaceResources(namespace, snapshotter string) error
 
+	// GetCgroupMode reports whether the host runs the unified cgroup v2
+	// hierarchy kukeon builds its cgroup paths against.
+	GetCgroupMode() CgroupMode
 	GetCgroupMountpoint() string
 	GetCurrentCgroupPath() (string, error)
 	CgroupPath(group, mountpoint string) (string, error)
 	NewCgroup(spec CgroupSpec) (*cgroup2.Manager, error)
 	LoadCgroup(group string, mountpoint string) (*cgroup2.Manager, error)
+	// CgroupUsage reads the group's live memory, pids, and cpu usage
+	// counters and its OOM kill count; metrics whose controller is not
+	// enabled are left nil.
+	CgroupUsage(group, mountpoint string) (CgroupUsage, error)
 	DeleteCgroup(group, mountpoint string) error
 	// EnsureSubtreeControllers writes "+<ctrl>" to the named group's own
 	// cgroup.subtree_control AND to every ancestor up to the unified cgroup
@@ -116,7 +184,26 @@ type Client interface {
 	StopContainer(namespace, id string, opts StopContainerOptions) (*containerd.ExitStatus, error)
 
 	TaskStatus(namespace, id string) (containerd.Status, error)
+	// SubscribeTaskExits streams init-process exits from every namespace
+	// until ctx is cancelled; see TaskExit.
+	SubscribeTaskExits(ctx context.Context) (<-chan TaskExit, <-chan error)
 	TaskMetrics(namespace, id string) (*apitypes.Metric, error)
+	// TaskPID returns the host PID of the container's task. Returns
+	// errdefs.ErrTaskNotRunning when the task is not Running, so the
+	// PID is never handed out for a task whose process has gone.
+	TaskPID(namespace, id string) (uint32, error)
+	// ResizeProcess resizes the pseudo-terminal of a container's task, or of
+	// one of its exec processes when execID is set, to width columns by
+	// height rows. A task started without a terminal has nothing to resize
+	// and returns nil.
+	ResizeProcess(namespace, containerID, execID string, width, height uint32) error
+	// ExecProcess runs args as an extra process in the container's running
+	// task, with the task's own user, env and cwd, and waits up to timeout
+	// for it to exit. A non-zero exit is reported in the result, not as an
+	// error; running out of time kills the process and returns an error
+	// wrapping context.DeadlineExceeded. Returns errdefs.ErrTaskNotRunning
+	// when the task is not Running.
+	ExecProcess(namespace, containerID string, args []string, timeout time.Duration) (ExecResult, error)
 
 	// ContainerProcessUID returns the resolved process.User.UID from the
 	// given container's OCI runtime spec. Used after CreateContainerFromSpec
@@ -139,6 +226,11 @@ type Client interface {
 	// the ref is absent.
 	GetImage(namespace, ref string) (ImageInfo, error)
 
+	// PullImage makes ref available in the specified containerd namespace,
+	// pulling it with creds only when no local image matches, and returns
+	// its metadata.
+	PullImage(namespace, ref string, creds []RegistryCredentials) (ImageInfo, error)
+
 	// ImageChainID returns the chainID the image at ref would unpack to
 	// today, computed from the current rootfs DiffIDs in the namespace's
 	// content store. Pair with ContainerRootChainID to detect that an
@@ -156,6 +248,18 @@ type Client interface {
 	// absent.
 	ContainerRootChainID(namespace, containerID string) (string, error)
 
+	// ContainerImageDigest returns the manifest digest of the image the
+	// container was created from, as recorded on the container at create
+	// time. Containers created before the digest was recorded fall back to
+	// whatever the container's image ref resolves to today. Returns
+	// errdefs.ErrContainerNotFound if the container is absent.
+	ContainerImageDigest(namespace, containerID string) (string, error)
+
+	// InspectContainer returns the decoded OCI runtime spec of the container
+	// together with its containerd record, for `kuke inspect`. Returns
+	// errdefs.ErrContainerNotFound if the container is absent.
+	InspectContainer(namespace, containerID string) (ContainerInspection, error)
+
 	// DeleteImage removes the named image ref from the specified
 	// containerd namespace. Returns errdefs.ErrImageNotFound if the ref
 	// is absent so callers can distinguish missing from operational
@@ -180,30 +284,93 @@ type Client interface {
 	NamespaceStorage(namespace string) (StorageStats, error)
 }
 
-func NewClient(ctx context.Context, logger *slog.Logger, socket string) Client {
+func NewClient(ctx context.Context, logger *slog.Logger, socket string, opts ...ClientOption) Client {
+	var o ClientOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return NewClientWithOptions(ctx, logger, socket, o)
+}
+
+// NewClientWithOptions is NewClient with explicit ClientOptions.
+func NewClientWithOptions(ctx context.Context, logger *slog.Logger, socket string, opts ClientOptions) Client {
+	return newClient(ctx, logger, socket, opts, dialContainerd)
+}
+
+func newClient(ctx context.Context, logger *slog.Logger, socket string, opts ClientOptions, dial dialFunc) *client {
+	timeout := opts.ConnectTimeout
+	if timeout <= 0 {
+		timeout = DefaultConnectTimeout
+	}
 	return &client{
-		ctx:        ctx,
-		logger:     logger,
-		socket:     socket,
-		cgroups:    make(map[string]*cgroup2.Manager),
-		containers: make(map[string]containerd.Container),
-		tasks:      make(map[string]containerd.Task),
+		ctx:            ctx,
+		logger:         logger,
+		socket:         socket,
+		connectTimeout: timeout,
+		maxReconnects:  max(opts.MaxReconnects, 0),
+		dial:           dial,
+
+		healthCheckInterval: max(opts.HealthCheckInterval, 0),
+		cgroups:             make(map[string]*cgroup2.Manager),
+		containers:          make(map[string]containerd.Container),
+		tasks:               make(map[string]containerd.Task),
 	}
 }
 
+func dialContainerd(_ context.Context, socket string, timeout time.Duration) (*containerd.Client, error) {
+	return containerd.New(socket, containerd.WithTimeout(timeout))
+}
+
 // verifyConnection checks if the containerd client connection is still valid
 // by performing a lightweight operation (listing namespaces).
-func (c *client) verifyConnection() error {
-	if c.cClient == nil {
+func verifyConnection(ctx context.Context, cClient *containerd.Client) error {
+	if cClient == nil {
 		return errors.New("client is nil")
 	}
 	// Use a simple operation to verify connection - list namespaces
 	// This is lightweight and will fail if connection is broken
-	namespaces := c.cClient.NamespaceService()
-	_, err := namespaces.List(c.ctx)
+	namespaces := cClient.NamespaceService()
+	_, err := namespaces.List(ctx)
 	return err
 }
 
+type dialResult struct {
+	cClient *containerd.Client
+	err     error
+}
+
+// dialAndVerify opens and verifies a new containerd connection, giving up once
+// ctx expires. The dial runs on its own goroutine so a dialer that ignores ctx
+// still cannot hold Connect past the deadline; a connection that completes
+// after the caller gave up is closed rather than leaked.
+func (c *client) dialAndVerify(ctx context.Context) (*containerd.Client, error) {
+	done := make(chan dialResult, 1)
+	go func() {
+		cClient, err := c.dial(ctx, c.socket, c.connectTimeout)
+		if err == nil {
+			if err = verifyConnection(ctx, cClient); err != nil {
+				_ = cClient.Close()
+				cClient = nil
+				err = fmt.Errorf("failed to verify new connection: %w", err)
+			}
+		}
+		done <- dialResult{cClient: cClient, err: err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.cClient, res.err
+	case <-ctx.Done():
+		go func() {
+			if res := <-done; res.cClient != nil {
+				_ = res.cClient.Close()
+			}
+		}()
+		return nil, fmt.Errorf("%w: %s: no answer within %s: %w",
+			errdefs.ErrConnectContainerd, c.socket, c.connectTimeout, ctx.Err())
+	}
+}
+
 func (c *client) Connect() error {
 	// cClientMu serializes the read-modify-write of c.cClient so concurrent
 	// first-use callers (the first RPC handler and the first reconcile tick,
@@ -214,11 +381,19 @@ func (c *client) Connect() error {
 	c.cClientMu.Lock()
 	defer c.cClientMu.Unlock()
 
+	// A connection verified within the health-check interval is reused as is.
+	if c.cClient != nil && c.healthCheckInterval > 0 && time.Since(c.verifiedAt) < c.healthCheckInterval {
+		return nil
+	}
+
 	// If already connected, verify the connection is still valid
 	if c.cClient != nil {
-		err := c.verifyConnection()
+		verifyCtx, cancelVerify := context.WithTimeout(c.ctx, c.connectTimeout)
+		err := verifyConnection(verifyCtx, c.cClient)
+		cancelVerify()
 		if err == nil {
 			// Connection is valid, reuse it
+			c.verifiedAt = time.Now()
 			c.logger.DebugContext(c.ctx, "containerd client already connected, reusing connection", "socket", c.socket)
 			return nil
 		}
@@ -234,19 +409,16 @@ func (c *client) Connect() error {
 		_ = c.closeLocked() // Close the invalid connection (lock already held)
 	}
 
-	// Create new connection
-	var err error
-	c.cClient, err = containerd.New(c.socket)
+	// Create and verify a new connection, bounded by the connect timeout
+	ctx, cancel := context.WithTimeout(c.ctx, c.connectTimeout)
+	defer cancel()
+	cClient, err := c.dialAndVerify(ctx)
 	if err != nil {
 		c.logger.Error("failed to connect to containerd: %v", "err", fmt.Sprintf("%v", err))
 		return err
 	}
-
-	// Verify the new connection works
-	if err = c.verifyConnection(); err != nil {
-		_ = c.closeLocked() // lock already held
-		return fmt.Errorf("failed to verify new connection: %w", err)
-	}
+	c.cClient = cClient
+	c.verifiedAt = time.Now()
 
 	c.logger.InfoContext(c.ctx, "connected to containerd", "socket", c.socket)
 	return nil
@@ -277,7 +449,11 @@ func (c *client) conn() *containerd.Client {
 // reconnect path (which already holds the lock), so the teardown stays guarded
 // without re-entrant locking.
 func (c *client) closeLocked() error {
+	c.verifiedAt = time.Time{}
 	if c.cClient != nil {
+		// Cached containers and tasks are bound to the connection being
+		// closed; drop them so the next lookup reloads through its successor.
+		c.resetCaches()
 		err := c.cClient.Close()
 		if err != nil {
 			c.logger.Error("failed to close containerd client: %v", "err", fmt.Sprintf("%v", err))
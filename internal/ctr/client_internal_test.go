@@ -0,0 +1,441 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	containersapi "github.com/containerd/containerd/api/services/containers/v1"
+	namespacesapi "github.com/containerd/containerd/api/services/namespaces/v1"
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/pkg/namespaces"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestConnect_TimesOutOnBlockingDialer(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	blocking := func(_ context.Context, _ string, _ time.Duration) (*containerd.Client, error) {
+		<-release // ignores ctx, like a socket that accepts but never answers
+		return nil, errors.New("released")
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	c := newClient(context.Background(), logger, "/test/socket",
+		ClientOptions{ConnectTimeout: 50 * time.Millisecond}, blocking)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- c.Connect() }()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, errdefs.ErrConnectContainerd) {
+			t.Errorf("expected ErrConnectContainerd, got %v", err)
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Connect did not return after the connect timeout expired")
+	}
+	if c.conn() != nil {
+		t.Error("expected no client to be stored after a timed-out connect")
+	}
+}
+
+func TestConnect_DialErrorReturnedBeforeTimeout(t *testing.T) {
+	dialErr := errors.New("connection refused")
+	failing := func(_ context.Context, _ string, _ time.Duration) (*containerd.Client, error) {
+		return nil, dialErr
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	c := newClient(context.Background(), logger, "/test/socket", ClientOptions{}, failing)
+
+	err := c.Connect()
+	if !errors.Is(err, dialErr) {
+		t.Errorf("expected the dial error, got %v", err)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected no deadline error for an immediate dial failure, got %v", err)
+	}
+}
+
+func TestNewClientWithOptions_DefaultConnectTimeout(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	for _, d := range []time.Duration{0, -time.Second} {
+		c, ok := NewClientWithOptions(context.Background(), logger, "/test/socket",
+			ClientOptions{ConnectTimeout: d}).(*client)
+		if !ok {
+			t.Fatal("NewClientWithOptions did not return *client")
+		}
+		if c.connectTimeout != DefaultConnectTimeout {
+			t.Errorf("ConnectTimeout %s: got %s, want %s", d, c.connectTimeout, DefaultConnectTimeout)
+		}
+	}
+}
+
+// fakeNamespaces is a containerd namespaces service that only answers List.
+// lists counts the calls, which is how Connect health-checks a connection.
+type fakeNamespaces struct {
+	namespacesapi.UnimplementedNamespacesServer
+
+	names []string
+	lists atomic.Int64
+}
+
+func (f *fakeNamespaces) List(
+	context.Context, *namespacesapi.ListNamespacesRequest,
+) (*namespacesapi.ListNamespacesResponse, error) {
+	f.lists.Add(1)
+	resp := &namespacesapi.ListNamespacesResponse{}
+	for _, name := range f.names {
+		resp.Namespaces = append(resp.Namespaces, &namespacesapi.Namespace{Name: name})
+	}
+	return resp, nil
+}
+
+// fakeContainers is a containerd containers service whose List returns a
+// single container named after the namespace the request carried, so tests
+// can tell which namespace a call on a shared client was scoped to.
+type fakeContainers struct {
+	containersapi.UnimplementedContainersServer
+}
+
+func (fakeContainers) List(
+	ctx context.Context, _ *containersapi.ListContainersRequest,
+) (*containersapi.ListContainersResponse, error) {
+	ns, ok := namespaces.Namespace(ctx)
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, "namespace is required")
+	}
+	return &containersapi.ListContainersResponse{
+		Containers: []*containersapi.Container{{
+			ID:      ns,
+			Runtime: &containersapi.Container_Runtime{Name: "io.containerd.runc.v2"},
+		}},
+	}, nil
+}
+
+// startFakeContainerd serves a fakeNamespaces and a fakeContainers on a unix
+// socket at path. It returns the namespaces service, for its call count, and
+// a func that stops the server, dropping every open connection.
+func startFakeContainerd(tb testing.TB, path string, names ...string) (*fakeNamespaces, func()) {
+	tb.Helper()
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		tb.Fatalf("listen %s: %v", path, err)
+	}
+	ns := &fakeNamespaces{names: names}
+	srv := grpc.NewServer()
+	namespacesapi.RegisterNamespacesServer(srv, ns)
+	containersapi.RegisterContainersServer(srv, fakeContainers{})
+	go func() { _ = srv.Serve(lis) }()
+	return ns, srv.Stop
+}
+
+// serveFakeContainerd is startFakeContainerd for tests that only need the
+// stop func.
+func serveFakeContainerd(tb testing.TB, path string, names ...string) func() {
+	tb.Helper()
+	_, stop := startFakeContainerd(tb, path, names...)
+	return stop
+}
+
+// countingDial wraps dialContainerd, counting the connections it opens.
+func countingDial(dials *atomic.Int64) dialFunc {
+	return func(ctx context.Context, socket string, timeout time.Duration) (*containerd.Client, error) {
+		dials.Add(1)
+		return dialContainerd(ctx, socket, timeout)
+	}
+}
+
+func TestConnect_ReusesVerifiedConnectionWithinHealthCheckInterval(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		interval time.Duration
+		want     int64
+	}{
+		{name: "zero interval checks every connect", interval: 0, want: 10},
+		{name: "interval skips checks", interval: time.Hour, want: 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			socket := filepath.Join(t.TempDir(), "containerd.sock")
+			ns, stop := startFakeContainerd(t, socket, "default")
+			t.Cleanup(stop)
+
+			var dials atomic.Int64
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			c := newClient(context.Background(), logger, socket,
+				ClientOptions{HealthCheckInterval: tt.interval}, countingDial(&dials))
+			t.Cleanup(func() { _ = c.Close() })
+
+			if err := c.Connect(); err != nil {
+				t.Fatalf("Connect: %v", err)
+			}
+			base := ns.lists.Load()
+			for range 10 {
+				if err := c.Connect(); err != nil {
+					t.Fatalf("Connect: %v", err)
+				}
+			}
+			if got := ns.lists.Load() - base; got != tt.want {
+				t.Errorf("health checks after the first connect: got %d, want %d", got, tt.want)
+			}
+			if got := dials.Load(); got != 1 {
+				t.Errorf("dials: got %d, want 1", got)
+			}
+		})
+	}
+}
+
+func TestConnect_HealthCheckIntervalResetByClose(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "containerd.sock")
+	_, stop := startFakeContainerd(t, socket, "default")
+	t.Cleanup(stop)
+
+	var dials atomic.Int64
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	c := newClient(context.Background(), logger, socket,
+		ClientOptions{HealthCheckInterval: time.Hour}, countingDial(&dials))
+	t.Cleanup(func() { _ = c.Close() })
+
+	if err := c.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := c.Connect(); err != nil {
+		t.Fatalf("Connect after Close: %v", err)
+	}
+	if got := dials.Load(); got != 2 {
+		t.Errorf("dials: got %d, want 2 (Close must drop the verified connection)", got)
+	}
+}
+
+func TestSharedClient_ConcurrentCallsKeepTheirNamespace(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "containerd.sock")
+	_, stop := startFakeContainerd(t, socket, "default")
+	t.Cleanup(stop)
+
+	var dials atomic.Int64
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	c := newClient(context.Background(), logger, socket, ClientOptions{
+		MaxReconnects:       DefaultMaxReconnects,
+		HealthCheckInterval: DefaultHealthCheckInterval,
+	}, countingDial(&dials))
+	t.Cleanup(func() { _ = c.Close() })
+
+	const workers, calls = 16, 25
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*calls)
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ns := fmt.Sprintf("realm-%d", w)
+			for range calls {
+				if err := c.Connect(); err != nil {
+					errs <- err
+					return
+				}
+				got, err := c.ListContainers(ns)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if len(got) != 1 || got[0].ID() != ns {
+					errs <- fmt.Errorf("call in namespace %q was served from %v", ns, containerIDs(got))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	if got := dials.Load(); got != 1 {
+		t.Errorf("dials: got %d, want 1 shared connection", got)
+	}
+}
+
+func containerIDs(cs []containerd.Container) []string {
+	ids := make([]string, 0, len(cs))
+	for _, c := range cs {
+		ids = append(ids, c.ID())
+	}
+	return ids
+}
+
+// BenchmarkRunnerCall measures one runner-style call (Connect, then a
+// namespaced read) under three client lifetimes, reporting the dials and
+// health checks each call costs.
+func BenchmarkRunnerCall(b *testing.B) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	call := func(b *testing.B, c *client) {
+		if err := c.Connect(); err != nil {
+			b.Fatalf("Connect: %v", err)
+		}
+		if _, err := c.ListContainers("default"); err != nil {
+			b.Fatalf("ListContainers: %v", err)
+		}
+	}
+
+	for _, bb := range []struct {
+		name   string
+		shared bool
+		opts   ClientOptions
+	}{
+		{name: "client-per-call"},
+		{name: "shared-check-every-call", shared: true},
+		{name: "shared-health-interval", shared: true, opts: ClientOptions{HealthCheckInterval: DefaultHealthCheckInterval}},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			socket := filepath.Join(b.TempDir(), "containerd.sock")
+			ns, stop := startFakeContainerd(b, socket, "default")
+			b.Cleanup(stop)
+			var dials atomic.Int64
+			dial := countingDial(&dials)
+
+			shared := newClient(context.Background(), logger, socket, bb.opts, dial)
+			b.Cleanup(func() { _ = shared.Close() })
+			b.ResetTimer()
+			for range b.N {
+				if bb.shared {
+					call(b, shared)
+					continue
+				}
+				c := newClient(context.Background(), logger, socket, bb.opts, dial)
+				call(b, c)
+				_ = c.Close()
+			}
+			b.StopTimer()
+			b.ReportMetric(float64(dials.Load())/float64(b.N), "dials/op")
+			b.ReportMetric(float64(ns.lists.Load())/float64(b.N), "healthchecks/op")
+		})
+	}
+}
+func TestWithReconnect_RetriesAfterDroppedConnection(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "containerd.sock")
+	stop := serveFakeContainerd(t, socket, "before")
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	c, ok := NewClient(context.Background(), logger, socket, WithReconnect(2)).(*client)
+	if !ok {
+		t.Fatal("NewClient did not return *client")
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	if err := c.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	first := c.conn()
+
+	// Simulate a containerd restart: the old server goes away and a new one
+	// comes up on the same socket.
+	stop()
+	stop = serveFakeContainerd(t, socket, "after")
+	t.Cleanup(stop)
+
+	var calls int
+	var names []string
+	err := c.withReconnect(func() error {
+		calls++
+		if calls == 1 {
+			return status.Error(codes.Unavailable, "connection reset")
+		}
+		var listErr error
+		names, listErr = c.listNamespaces()
+		return listErr
+	})
+	if err != nil {
+		t.Fatalf("expected the retry to succeed, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 attempts, got %d", calls)
+	}
+	if len(names) != 1 || names[0] != "after" {
+		t.Errorf("expected the restarted server's namespaces, got %v", names)
+	}
+	if c.conn() == first {
+		t.Error("expected the connection to be replaced after reconnect")
+	}
+}
+
+func TestWithReconnect_BoundedRetries(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "containerd.sock")
+	t.Cleanup(serveFakeContainerd(t, socket))
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	for _, maxRetries := range []int{0, 2} {
+		c, ok := NewClient(context.Background(), logger, socket, WithReconnect(maxRetries)).(*client)
+		if !ok {
+			t.Fatal("NewClient did not return *client")
+		}
+		if err := c.Connect(); err != nil {
+			t.Fatalf("Connect: %v", err)
+		}
+
+		var calls int
+		err := c.withReconnect(func() error {
+			calls++
+			return status.Error(codes.Unavailable, "connection reset")
+		})
+		if status.Code(err) != codes.Unavailable {
+			t.Errorf("maxRetries=%d: expected the Unavailable error back, got %v", maxRetries, err)
+		}
+		if calls != maxRetries+1 {
+			t.Errorf("maxRetries=%d: expected %d attempts, got %d", maxRetries, maxRetries+1, calls)
+		}
+		_ = c.Close()
+	}
+}
+
+func TestWithReconnect_IgnoresNonConnectionErrors(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	c, ok := NewClient(context.Background(), logger, "/test/socket", WithReconnect(3)).(*client)
+	if !ok {
+		t.Fatal("NewClient did not return *client")
+	}
+
+	var calls int
+	notFound := status.Error(codes.NotFound, "no such container")
+	err := c.withReconnect(func() error {
+		calls++
+		return notFound
+	})
+	if !errors.Is(err, notFound) {
+		t.Errorf("expected the NotFound error back, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected no retry for a non-connection error, got %d attempts", calls)
+	}
+}
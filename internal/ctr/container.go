@@ -20,8 +20,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"sync"
 	"syscall"
 	"time"
 
@@ -31,6 +33,7 @@ import (
 	"github.com/containerd/errdefs"
 	internalerrdefs "github.com/eminwux/kukeon/internal/errdefs"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/util/logrotate"
 )
 
 // StartContainer creates and starts a task for the container.
@@ -64,6 +67,17 @@ func (c *client) StartContainer(
 		status, err = existingTask.Status(nsCtx)
 		if err == nil && status.Status == containerd.Running {
 			c.logger.WarnContext(c.ctx, "task already running", "id", containerSpec.ID)
+			if taskSpec.IO != nil && taskSpec.IO.inProcessStreams() &&
+				!c.drainsStreams(namespace, containerSpec.ID, existingTask.Pid()) {
+				// The stream copy lives in this process; after a daemon
+				// restart nobody drains the fifos, so pick them up again.
+				// A stdin the previous process fed is not reopened.
+				existingTask, err = c.reattachStreams(nsCtx, container, taskSpec.IO)
+				if err != nil {
+					return nil, err
+				}
+				c.streamsAttached.Store(cacheKey(namespace, containerSpec.ID), existingTask.Pid())
+			}
 			c.storeTask(namespace, containerSpec.ID, existingTask)
 			return existingTask, nil
 		}
@@ -87,11 +101,17 @@ func (c *client) StartContainer(
 	//   1. LogFilePath  — cio.LogFile, shim appends stdout+stderr to a host
 	//      file kuke can tail (used for non-Attachable containers including
 	//      kukeond — see internal/util/fs/metadata.go ContainerLogPath).
+	//      With SeparateStreams the streams stay on distinct fifos and this
+	//      process writes them to the same path as stream-tagged records.
+	//      With OpenStdin this process also feeds the task's stdin fifo and
+	//      copies the output fifos itself, since cio.LogFile has no stdin.
 	//   2. Terminal     — TTY-attached IO with no streams wired (sbsh later
 	//      claims stdio inside the container).
 	//   3. IO non-nil   — bare IO creator with no streams wired.
 	//   4. default      — cio.NullIO (output discarded).
 	var ioCreator cio.Creator
+	// releaseStdin ends a held-open stdin; it fires when the task exits.
+	releaseStdin := func() {}
 	switch {
 	case taskSpec.IO != nil && taskSpec.IO.LogFilePath != "":
 		// The shim opens the path on first task write; create the parent
@@ -100,7 +120,34 @@ func (c *client) StartContainer(
 		if err = os.MkdirAll(filepath.Dir(taskSpec.IO.LogFilePath), 0o750); err != nil {
 			return nil, fmt.Errorf("create container log dir: %w", err)
 		}
-		ioCreator = cio.LogFile(taskSpec.IO.LogFilePath)
+		if taskSpec.IO.PreviousLogFilePath != "" {
+			// Losing the archive only costs `kuke log --previous` its
+			// output; it must not keep the container from starting.
+			if _, archiveErr := logrotate.Archive(
+				taskSpec.IO.LogFilePath, taskSpec.IO.PreviousLogFilePath,
+			); archiveErr != nil {
+				c.logger.WarnContext(c.ctx, "failed to archive previous container log",
+					"id", containerSpec.ID, "path", taskSpec.IO.LogFilePath, "err", archiveErr)
+			}
+		}
+		switch {
+		case taskSpec.IO.OpenStdin:
+			stdinDone := make(chan struct{})
+			releaseStdin = sync.OnceFunc(func() { close(stdinDone) })
+			var streams cio.Opt
+			if streams, err = stdinStreamsOpt(taskSpec.IO, stdinDone); err != nil {
+				return nil, err
+			}
+			ioCreator = cio.NewCreator(streams)
+		case taskSpec.IO.SeparateStreams:
+			var streams cio.Opt
+			if streams, err = logStreamsOpt(taskSpec.IO, nil); err != nil {
+				return nil, err
+			}
+			ioCreator = cio.NewCreator(streams)
+		default:
+			ioCreator = cio.LogFile(taskSpec.IO.LogFilePath)
+		}
 	case taskSpec.IO != nil && taskSpec.IO.Terminal:
 		ioCreator = cio.NewCreator(cio.WithStreams(nil, nil, nil), cio.WithTerminal)
 	case taskSpec.IO != nil:
@@ -116,8 +163,24 @@ func (c *client) StartContainer(
 	task, err := container.NewTask(nsCtx, ioCreator, taskOpts...)
 	if err != nil {
 		c.logger.ErrorContext(c.ctx, "failed to create task", "id", containerSpec.ID, "err", formatError(err))
+		releaseStdin()
 		return nil, fmt.Errorf("failed to create task: %w", err)
 	}
+	if taskSpec.IO != nil && taskSpec.IO.OpenStdin {
+		// Wait is registered before Start so a task that exits at once
+		// still releases its held-open stdin.
+		exitC, waitErr := task.Wait(nsCtx)
+		if waitErr != nil {
+			c.logger.WarnContext(c.ctx, "failed to wait on task; stdin will not be held open",
+				"id", containerSpec.ID, "err", formatError(waitErr))
+			releaseStdin()
+		} else {
+			go func() {
+				<-exitC
+				releaseStdin()
+			}()
+		}
+	}
 
 	// Start the task
 	err = task.Start(nsCtx)
@@ -125,8 +188,12 @@ func (c *client) StartContainer(
 		c.logger.ErrorContext(c.ctx, "failed to start task", "id", containerSpec.ID, "err", formatError(err))
 		// Clean up task on failure
 		_, _ = task.Delete(nsCtx, containerd.WithProcessKill)
+		releaseStdin()
 		return nil, fmt.Errorf("failed to start task: %w", err)
 	}
+	if taskSpec.IO != nil && taskSpec.IO.inProcessStreams() {
+		c.streamsAttached.Store(cacheKey(namespace, containerSpec.ID), task.Pid())
+	}
 
 	// Drain the container's task PID into a _payload leaf cgroup so the
 	// container-root cgroup has no internal processes (issue #336 scenario
@@ -152,6 +219,31 @@ func (c *client) StartContainer(
 	return task, nil
 }
 
+// drainsStreams reports whether this process already copies the stream
+// fifos of the task running as pid.
+func (c *client) drainsStreams(namespace, id string, pid uint32) bool {
+	attached, ok := c.streamsAttached.Load(cacheKey(namespace, id))
+	return ok && attached == pid
+}
+
+// reattachStreams reloads a running task with fresh copy goroutines on its
+// existing stdout/stderr fifos.
+func (c *client) reattachStreams(
+	nsCtx context.Context,
+	container containerd.Container,
+	taskIO *TaskIO,
+) (containerd.Task, error) {
+	streams, err := logStreamsOpt(taskIO, nil)
+	if err != nil {
+		return nil, err
+	}
+	task, err := container.Task(nsCtx, cio.NewAttach(streams))
+	if err != nil {
+		return nil, fmt.Errorf("failed to reattach task streams: %w", err)
+	}
+	return task, nil
+}
+
 // relocateContainerTaskToLeaf moves the just-started container's PIDs into
 // a _payload leaf cgroup under its OCI Linux.CgroupsPath. See StartContainer
 // for the rationale (issue #336 scenario A). Returns nil when the container
@@ -335,8 +427,19 @@ func (c *client) CreateContainer(namespace string, spec ContainerSpec, creds []R
 		return nil, internalerrdefs.ErrContainerExists
 	}
 
+	snapshotOpts, err := snapshotQuotaOpts(spec.Snapshotter, spec.DiskQuotaBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if spec.Snapshotter != "" {
+		if err = c.ensureSnapshotterAvailable(nsCtx, spec.Snapshotter); err != nil {
+			return nil, err
+		}
+	}
+
 	// Pull the image if needed
-	image, err := c.pullImage(namespace, spec.Image, creds)
+	image, err := c.pullImage(namespace, spec.Image, spec.ImagePullPolicy, spec.Platform, creds)
 	if err != nil {
 		return nil, err
 	}
@@ -367,24 +470,27 @@ func (c *client) CreateContainer(namespace string, spec ContainerSpec, creds []R
 		}))
 	}
 
-	// Build container options
-	opts := []containerd.NewContainerOpts{
-		containerd.WithImage(image),
-		containerd.WithNewSnapshot(snapshotKey, image),
-		containerd.WithNewSpec(specOpts...),
-	}
-
+	// Build container options. WithSnapshotter must precede WithNewSnapshot:
+	// the snapshot is prepared on whichever snapshotter the container record
+	// names at that point, so appending it afterwards would prepare the
+	// rootfs on the default snapshotter and record a different one.
+	opts := []containerd.NewContainerOpts{containerd.WithImage(image)}
 	if spec.Snapshotter != "" {
 		opts = append(opts, containerd.WithSnapshotter(spec.Snapshotter))
 	}
+	opts = append(opts,
+		containerd.WithNewSnapshot(snapshotKey, image, snapshotOpts...),
+		containerd.WithNewSpec(specOpts...),
+	)
 
 	if spec.Runtime != nil && spec.Runtime.Name != "" {
 		opts = append(opts, containerd.WithRuntime(spec.Runtime.Name, spec.Runtime.Options))
 	}
 
-	if spec.Labels != nil {
-		opts = append(opts, containerd.WithContainerLabels(spec.Labels))
-	}
+	labels := make(map[string]string, len(spec.Labels)+1)
+	maps.Copy(labels, spec.Labels)
+	labels[imageDigestLabelKey] = image.Target().Digest.String()
+	opts = append(opts, containerd.WithContainerLabels(labels))
 
 	container, err := cc.NewContainer(nsCtx, spec.ID, opts...)
 	if err != nil {
@@ -402,11 +508,27 @@ func (c *client) GetContainer(namespace, id string) (containerd.Container, error
 	if id == "" {
 		return nil, internalerrdefs.ErrEmptyContainerID
 	}
-	return c.loadContainer(namespace, id)
+	var container containerd.Container
+	err := c.withReconnect(func() error {
+		var err error
+		container, err = c.loadContainer(namespace, id)
+		return err
+	})
+	return container, err
 }
 
 // ListContainers lists all containers matching the provided filters.
 func (c *client) ListContainers(namespace string, filters ...string) ([]containerd.Container, error) {
+	var containers []containerd.Container
+	err := c.withReconnect(func() error {
+		var err error
+		containers, err = c.listContainers(namespace, filters...)
+		return err
+	})
+	return containers, err
+}
+
+func (c *client) listContainers(namespace string, filters ...string) ([]containerd.Container, error) {
 	nsCtx := c.namespaceCtx(namespace)
 
 	containers, err := c.conn().Containers(nsCtx, filters...)
@@ -426,6 +548,16 @@ func (c *client) ListContainers(namespace string, filters ...string) ([]containe
 
 // ExistsContainer checks if a container exists.
 func (c *client) ExistsContainer(namespace, id string) (bool, error) {
+	var exists bool
+	err := c.withReconnect(func() error {
+		var err error
+		exists, err = c.existsContainer(namespace, id)
+		return err
+	})
+	return exists, err
+}
+
+func (c *client) existsContainer(namespace, id string) (bool, error) {
 	if id == "" {
 		return false, internalerrdefs.ErrEmptyContainerID
 	}
@@ -596,6 +728,15 @@ func (c *client) CreateContainerFromSpec(
 		return nil, fmt.Errorf("failed to resolve volume references: %w", err)
 	}
 
+	if !HasWritableMount(containerSpec) {
+		c.logger.WarnContext(
+			c.ctx,
+			"container has a read-only root filesystem and no writable mount; images that write to disk will fail",
+			"id", containerSpec.ID,
+			"cell", cellID,
+		)
+	}
+
 	// Convert to ctr.ContainerSpec using BuildContainerSpec
 	ctrSpec := BuildContainerSpec(containerSpec, opts...)
 
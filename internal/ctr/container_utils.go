@@ -49,6 +49,10 @@ const (
 
 	rootContainerLabelKey   = "kukeon.io/container-type"
 	rootContainerLabelValue = "root"
+
+	// imageDigestLabelKey records the manifest digest of the image a
+	// container was created from; see ContainerImageDigest.
+	imageDigestLabelKey = "kukeon.io/image-digest"
 )
 
 // DefaultRootContainerSpec returns a minimal ContainerSpec suitable for keeping
@@ -132,11 +136,15 @@ func BuildRootContainerSpec(
 
 	// Hostname identifies the cell, not the hierarchical containerd ID. All
 	// containers in the cell share this root's UTS namespace via
-	// JoinContainerNamespaces, so the cell-name hostname is what `hostname`
-	// returns for every container. Falls back to the containerd ID only when
-	// the runner failed to populate CellName (defensive — every CreateCell /
-	// StartCell path stamps it). Issue #345.
-	hostname := strings.TrimSpace(rootSpec.CellName)
+	// JoinContainerNamespaces, so this hostname is what `hostname` returns
+	// for every container. CellHostname carries a declared spec.hostname
+	// (or the cell name) stamped by the runner; CellName and then the
+	// containerd ID are defensive fallbacks for callers that skip the
+	// stamp. Issue #345.
+	hostname := strings.TrimSpace(rootSpec.CellHostname)
+	if hostname == "" {
+		hostname = strings.TrimSpace(rootSpec.CellName)
+	}
 	if hostname == "" {
 		hostname = containerdID
 	}
@@ -248,11 +256,15 @@ func BuildRootContainerSpec(
 	rootLabels[rootContainerLabelKey] = rootContainerLabelValue
 
 	return ContainerSpec{
-		ID:            containerdID,
-		Image:         image,
-		Labels:        rootLabels,
-		SpecOpts:      specOpts,
-		CNIConfigPath: rootSpec.CNIConfigPath,
+		ID:              containerdID,
+		Image:           image,
+		Snapshotter:     resolveSnapshotter(rootSpec, opts),
+		DiskQuotaBytes:  resolveDiskQuota(rootSpec),
+		ImagePullPolicy: rootSpec.ImagePullPolicy,
+		Platform:        rootSpec.Platform,
+		Labels:          rootLabels,
+		SpecOpts:        specOpts,
+		CNIConfigPath:   rootSpec.CNIConfigPath,
 	}
 }
 
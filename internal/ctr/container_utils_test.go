@@ -375,6 +375,11 @@ func TestNormalizeImageReference(t *testing.T) {
 			image: "docker.io/library/debian:latest",
 			want:  "docker.io/library/debian:latest",
 		},
+		{
+			name:  "digest-pinned bare name keeps digest without tag",
+			image: "busybox@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			want:  "docker.io/library/busybox@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		},
 		{
 			name:  "custom registry with port",
 			image: "registry.example.com:5000/image:tag",
@@ -26,6 +26,7 @@ import (
 
 	"github.com/containerd/containerd/v2/core/containers"
 	"github.com/containerd/containerd/v2/pkg/oci"
+	internalerrdefs "github.com/eminwux/kukeon/internal/errdefs"
 	runtimespec "github.com/opencontainers/runtime-spec/specs-go"
 )
 
@@ -86,26 +87,117 @@ func daemonInPrivateMountNS() bool {
 // Lstat's the node and reads its type/major/minor/mode.
 var deviceFromPath = oci.DeviceFromPath
 
-// hostLinuxDeviceOpt returns a SpecOpts that replicates the host device node at
-// containerPath into the container — appending a Linux.Devices entry (so the
-// node is visible at containerPath inside the container) and a matching
-// Linux.Resources.Devices allow rule (so open() is not denied by the default
-// deny-all device cgroup). It is the host-root-aware replacement for
-// oci.WithLinuxDevice, which stat's the path in the kukeond process's own mount
-// namespace and so fails for any host device absent from the containerized
-// daemon cell's minimal /dev. The node is stat'd via the host-root prefix but
-// exposed at the un-prefixed containerPath. A missing node fails container
-// create with an error naming the requested path. Issue #1261.
-func hostLinuxDeviceOpt(containerPath, access string) oci.SpecOpts {
+// DeviceMapping is one parsed spec.devices entry: the host node, where it
+// appears in the container, and the device-cgroup access granted on it.
+type DeviceMapping struct {
+	HostPath      string
+	ContainerPath string
+	Permissions   string
+}
+
+// ParseDeviceMapping parses a spec.devices entry. The short form "/dev/kvm"
+// maps the node to the same path with rwm access; the long form follows
+// Docker's --device, "hostPath:containerPath[:permissions]", and
+// "hostPath:permissions" keeps the host path. Both paths must be absolute and
+// clean, and permissions a non-empty combination of r, w and m. Errors wrap
+// errdefs.ErrInvalidDevice.
+func ParseDeviceMapping(entry string) (DeviceMapping, error) {
+	parts := strings.Split(strings.TrimSpace(entry), ":")
+	m := DeviceMapping{HostPath: parts[0], Permissions: deviceAccessRWM}
+	switch len(parts) {
+	case 1:
+		m.ContainerPath = m.HostPath
+	case 2: //nolint:mnd // hostPath:containerPath or hostPath:permissions
+		if strings.HasPrefix(parts[1], "/") {
+			m.ContainerPath = parts[1]
+		} else {
+			m.ContainerPath, m.Permissions = m.HostPath, parts[1]
+		}
+	case 3: //nolint:mnd // hostPath:containerPath:permissions
+		m.ContainerPath, m.Permissions = parts[1], parts[2]
+	default:
+		return DeviceMapping{}, fmt.Errorf("%w: %q has more than three ':'-separated fields", internalerrdefs.ErrInvalidDevice, entry)
+	}
+	for _, path := range []string{m.HostPath, m.ContainerPath} {
+		if !filepath.IsAbs(path) || filepath.Clean(path) != path || path == "/" {
+			return DeviceMapping{}, fmt.Errorf("%w: %q: %q must be an absolute device path",
+				internalerrdefs.ErrInvalidDevice, entry, path)
+		}
+	}
+	if !validDevicePermissions(m.Permissions) {
+		return DeviceMapping{}, fmt.Errorf("%w: %q: permissions %q must combine r, w and m",
+			internalerrdefs.ErrInvalidDevice, entry, m.Permissions)
+	}
+	return m, nil
+}
+
+// validDevicePermissions reports whether perms is a non-empty set of r, w
+// and m with no letter repeated.
+func validDevicePermissions(perms string) bool {
+	if perms == "" {
+		return false
+	}
+	for i, c := range perms {
+		if !strings.ContainsRune(deviceAccessRWM, c) || strings.ContainsRune(perms[i+1:], c) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateDevices parses every non-blank spec.devices entry and returns one
+// error per malformed entry. It does not touch the host: whether the node
+// exists and is a device is only known at container create.
+func ValidateDevices(devices []string) []error {
+	var errs []error
+	for _, d := range devices {
+		if strings.TrimSpace(d) == "" {
+			continue
+		}
+		if _, err := ParseDeviceMapping(d); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// resolveHostDevice stat's hostPath through the host root and resolves it
+// into its OCI LinuxDevice, still carrying the host-root-prefixed Path. A
+// missing node, or one that is not a block or character device, wraps
+// errdefs.ErrInvalidDevice.
+func resolveHostDevice(hostPath string) (*runtimespec.LinuxDevice, error) {
+	dev, err := deviceFromPath(filepath.Join(deviceHostRoot(), strings.TrimPrefix(hostPath, "/")))
+	switch {
+	case errors.Is(err, oci.ErrNotADevice), err == nil && dev.Type != "b" && dev.Type != "c":
+		return nil, fmt.Errorf("%w: %q is not a device node", internalerrdefs.ErrInvalidDevice, hostPath)
+	case errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("%w: %q does not exist on the host: %w", internalerrdefs.ErrInvalidDevice, hostPath, err)
+	case err != nil:
+		return nil, fmt.Errorf("resolve device %q: %w", hostPath, err)
+	}
+	return dev, nil
+}
+
+// hostLinuxDeviceOpt returns a SpecOpts that replicates the host device node
+// m.HostPath into the container — appending a Linux.Devices entry (so the
+// node is visible at m.ContainerPath inside the container) and a matching
+// Linux.Resources.Devices allow rule with m.Permissions (so open() is not
+// denied by the default deny-all device cgroup). It is the host-root-aware
+// replacement for oci.WithLinuxDevice, which stat's the path in the kukeond
+// process's own mount namespace and so fails for any host device absent from
+// the containerized daemon cell's minimal /dev. The node is stat'd via the
+// host-root prefix but exposed at the un-prefixed container path. A missing
+// node, or a host path that is not a device node, fails container create
+// with an error naming the requested path. Issue #1261.
+func hostLinuxDeviceOpt(m DeviceMapping) oci.SpecOpts {
 	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *runtimespec.Spec) error {
-		hostPath := filepath.Join(deviceHostRoot(), strings.TrimPrefix(containerPath, "/"))
-		dev, err := deviceFromPath(hostPath)
+		dev, err := resolveHostDevice(m.HostPath)
 		if err != nil {
-			return fmt.Errorf("resolve device %q: %w", containerPath, err)
+			return err
 		}
 		// Expose the node at the path the caller asked for, not the
 		// host-root-prefixed path it was stat'd through.
-		dev.Path = containerPath
+		dev.Path = m.ContainerPath
 
 		if s.Linux == nil {
 			s.Linux = &runtimespec.Linux{}
@@ -119,7 +211,7 @@ func hostLinuxDeviceOpt(containerPath, access string) oci.SpecOpts {
 			Allow:  true,
 			Major:  &dev.Major,
 			Minor:  &dev.Minor,
-			Access: access,
+			Access: m.Permissions,
 		})
 		return nil
 	}
@@ -55,7 +55,7 @@ func TestHostLinuxDeviceOpt_ResolvesViaHostRoot(t *testing.T) {
 	})
 
 	spec := &runtimespec.Spec{Linux: &runtimespec.Linux{}}
-	if err := hostLinuxDeviceOpt("/dev/kvm", deviceAccessRWM)(context.Background(), nil, nil, spec); err != nil {
+	if err := hostLinuxDeviceOpt(DeviceMapping{HostPath: "/dev/kvm", ContainerPath: "/dev/kvm", Permissions: deviceAccessRWM})(context.Background(), nil, nil, spec); err != nil {
 		t.Fatalf("hostLinuxDeviceOpt returned error: %v", err)
 	}
 
@@ -83,7 +83,7 @@ func TestHostLinuxDeviceOpt_MissingNodeNamesPath(t *testing.T) {
 		return nil, os.ErrNotExist
 	})
 
-	err := hostLinuxDeviceOpt("/dev/kvm", deviceAccessRWM)(context.Background(), nil, nil, &runtimespec.Spec{Linux: &runtimespec.Linux{}})
+	err := hostLinuxDeviceOpt(DeviceMapping{HostPath: "/dev/kvm", ContainerPath: "/dev/kvm", Permissions: deviceAccessRWM})(context.Background(), nil, nil, &runtimespec.Spec{Linux: &runtimespec.Linux{}})
 	if err == nil {
 		t.Fatal("expected a create-time error for a missing host node, got nil")
 	}
@@ -0,0 +1,243 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	// dockerHubHost is the canonical form every Docker Hub alias normalizes
+	// to, so "https://index.docker.io/v1/" in a Docker config matches the
+	// registry-1.docker.io host containerd's authorizer asks about.
+	dockerHubHost = "docker.io"
+
+	// dockerTokenUsername is the username a credential helper or config
+	// entry reports for an identity (refresh) token instead of a password.
+	dockerTokenUsername = "<token>"
+
+	// credentialHelperPrefix names the binary a credsStore / credHelpers
+	// entry refers to: "desktop" runs docker-credential-desktop.
+	credentialHelperPrefix = "docker-credential-"
+)
+
+// dockerConfig is the subset of ~/.docker/config.json kukeon reads.
+type dockerConfig struct {
+	Auths       map[string]dockerAuthEntry `json:"auths"`
+	CredsStore  string                     `json:"credsStore"`
+	CredHelpers map[string]string          `json:"credHelpers"`
+}
+
+type dockerAuthEntry struct {
+	Auth          string `json:"auth"`
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+	IdentityToken string `json:"identitytoken"`
+}
+
+// credentialHelperOutput is what `docker-credential-<helper> get` prints.
+type credentialHelperOutput struct {
+	Username string `json:"Username"`
+	Secret   string `json:"Secret"`
+}
+
+// DockerConfigPath returns where the Docker CLI keeps its config:
+// $DOCKER_CONFIG/config.json when DOCKER_CONFIG is set, otherwise
+// ~/.docker/config.json. Returns "" when neither can be determined.
+func DockerConfigPath() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return ""
+	}
+	return filepath.Join(home, ".docker", "config.json")
+}
+
+// LoadDockerConfigCredentials reads a Docker CLI config file and returns one
+// RegistryCredentials per registry it can authenticate, sorted by server
+// address. Inline "auths" entries are decoded directly; registries listed in
+// "credHelpers", or in "auths" while a "credsStore" is set, are resolved by
+// running the named docker-credential-* helper. A missing file yields no
+// credentials and no error. A helper that fails drops only its own registry:
+// the other credentials are still returned, alongside an error naming each
+// failure, so callers can log it and carry on.
+func LoadDockerConfigCredentials(path string) ([]RegistryCredentials, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read docker config: %w", err)
+	}
+	var cfg dockerConfig
+	if err = json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse docker config %s: %w", path, err)
+	}
+
+	// Registry key (as written in the config) → helper name, "" for inline.
+	servers := make(map[string]string, len(cfg.Auths)+len(cfg.CredHelpers))
+	for server := range cfg.Auths {
+		servers[server] = cfg.CredsStore
+	}
+	for server, helper := range cfg.CredHelpers {
+		servers[server] = helper
+	}
+
+	byHost := make(map[string]RegistryCredentials, len(servers))
+	var errs []error
+	for _, server := range sortedKeys(servers) {
+		cred, ok, credErr := resolveDockerCredential(server, servers[server], cfg.Auths[server])
+		if credErr != nil {
+			errs = append(errs, credErr)
+			continue
+		}
+		// A key with no host would become a catch-all credential in
+		// buildResolver; aliases of one registry keep the first entry.
+		host := normalizeRegistryHost(server)
+		if _, dup := byHost[host]; !ok || host == "" || dup {
+			continue
+		}
+		cred.ServerAddress = host
+		byHost[host] = cred
+	}
+
+	creds := make([]RegistryCredentials, 0, len(byHost))
+	for _, host := range sortedKeys(byHost) {
+		creds = append(creds, byHost[host])
+	}
+	return creds, errors.Join(errs...)
+}
+
+// resolveDockerCredential produces the credential for one config entry. An
+// entry with neither inline secrets nor a helper reports ok=false.
+func resolveDockerCredential(server, helper string, entry dockerAuthEntry) (RegistryCredentials, bool, error) {
+	if helper != "" {
+		username, secret, err := runCredentialHelper(helper, server)
+		if err != nil {
+			return RegistryCredentials{}, false, fmt.Errorf("credential helper %q for %s: %w", helper, server, err)
+		}
+		return tokenAwareCredential(username, secret), secret != "", nil
+	}
+
+	if entry.IdentityToken != "" {
+		return RegistryCredentials{Password: entry.IdentityToken}, true, nil
+	}
+	username, password := entry.Username, entry.Password
+	if entry.Auth != "" {
+		decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+		if err != nil {
+			return RegistryCredentials{}, false, fmt.Errorf("decode auth for %s: %w", server, err)
+		}
+		var found bool
+		username, password, found = strings.Cut(string(decoded), ":")
+		if !found {
+			return RegistryCredentials{}, false, fmt.Errorf("decode auth for %s: missing ':' separator", server)
+		}
+	}
+	if username == "" && password == "" {
+		return RegistryCredentials{}, false, nil
+	}
+	return RegistryCredentials{Username: username, Password: password}, true, nil
+}
+
+// tokenAwareCredential maps the helper protocol's "<token>" username to an
+// empty one: containerd's authorizer treats a credential with no username
+// as an identity token to exchange, which is what "<token>" means.
+func tokenAwareCredential(username, secret string) RegistryCredentials {
+	if username == dockerTokenUsername {
+		username = ""
+	}
+	return RegistryCredentials{Username: username, Password: secret}
+}
+
+// runCredentialHelper runs `docker-credential-<helper> get`, writing the
+// server to its stdin as the Docker CLI does.
+func runCredentialHelper(helper, server string) (string, string, error) {
+	cmd := exec.Command(credentialHelperPrefix+helper, "get")
+	cmd.Stdin = strings.NewReader(server)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", "", err
+	}
+	var resp credentialHelperOutput
+	if err = json.Unmarshal(out, &resp); err != nil {
+		return "", "", fmt.Errorf("parse helper output: %w", err)
+	}
+	return resp.Username, resp.Secret, nil
+}
+
+// MergeRegistryCredentials combines realm-specified credentials with ones
+// loaded from a Docker config. Realm credentials come first and win: a
+// Docker entry whose registry a realm entry already covers is dropped.
+func MergeRegistryCredentials(realm, docker []RegistryCredentials) []RegistryCredentials {
+	if len(docker) == 0 {
+		return realm
+	}
+	covered := make(map[string]bool, len(realm))
+	for _, cred := range realm {
+		covered[normalizeRegistryHost(cred.ServerAddress)] = true
+	}
+	merged := append([]RegistryCredentials(nil), realm...)
+	for _, cred := range docker {
+		if !covered[normalizeRegistryHost(cred.ServerAddress)] {
+			merged = append(merged, cred)
+		}
+	}
+	return merged
+}
+
+// normalizeRegistryHost reduces a registry reference as it appears in a
+// Docker config, a realm spec, or an authorizer callback to a bare host:
+// scheme and path are dropped and Docker Hub aliases collapse to docker.io.
+func normalizeRegistryHost(server string) string {
+	host := strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	host, _, _ = strings.Cut(host, "/")
+	host = strings.ToLower(host)
+	switch host {
+	case "index.docker.io", "registry-1.docker.io":
+		return dockerHubHost
+	}
+	return host
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/ctr"
+)
+
+// installCredentialHelper puts a fake docker-credential-kuketest on PATH. It
+// answers registry.example.com with a password and token.example.com with an
+// identity token, and fails for anything else, like a real helper asked
+// about a server it has no entry for.
+func installCredentialHelper(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	script := `#!/bin/sh
+[ "$1" = get ] || exit 2
+read -r server
+case "$server" in
+registry.example.com) echo '{"ServerURL":"registry.example.com","Username":"robot","Secret":"s3cret"}' ;;
+token.example.com) echo '{"ServerURL":"token.example.com","Username":"<token>","Secret":"refresh"}' ;;
+*) echo "credentials not found in native keychain" >&2; exit 1 ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(dir, "docker-credential-kuketest"), []byte(script), 0o755); err != nil {
+		t.Fatalf("write helper: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func writeDockerConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	return path
+}
+
+func TestLoadDockerConfigCredentials_Fixture(t *testing.T) {
+	installCredentialHelper(t)
+
+	creds, err := ctr.LoadDockerConfigCredentials(filepath.Join("testdata", "docker-config.json"))
+	if err != nil {
+		t.Fatalf("LoadDockerConfigCredentials: %v", err)
+	}
+	want := []ctr.RegistryCredentials{
+		{ServerAddress: "docker.io", Username: "hubuser", Password: "hubpass"},
+		{ServerAddress: "ghcr.io", Username: "gh-user", Password: "gh-pass"},
+		{ServerAddress: "quay.io", Password: "quay-refresh-token"},
+		{ServerAddress: "registry.example.com", Username: "robot", Password: "s3cret"},
+	}
+	if !reflect.DeepEqual(creds, want) {
+		t.Fatalf("creds = %+v\nwant %+v", creds, want)
+	}
+}
+
+func TestLoadDockerConfigCredentials_CredsStoreAndFailingHelper(t *testing.T) {
+	installCredentialHelper(t)
+	path := writeDockerConfig(t, `{
+  "auths": {"token.example.com": {}, "unknown.example.com": {}},
+  "credsStore": "kuketest",
+  "credHelpers": {"ghcr.io": "missing-helper"}
+}`)
+
+	creds, err := ctr.LoadDockerConfigCredentials(path)
+	if err == nil {
+		t.Fatal("expected an error for the failing helpers")
+	}
+	// The identity token comes back with no username; the registries whose
+	// helper failed are dropped without losing the one that resolved.
+	want := []ctr.RegistryCredentials{{ServerAddress: "token.example.com", Password: "refresh"}}
+	if !reflect.DeepEqual(creds, want) {
+		t.Fatalf("creds = %+v, want %+v", creds, want)
+	}
+}
+
+func TestLoadDockerConfigCredentials_MissingFile(t *testing.T) {
+	creds, err := ctr.LoadDockerConfigCredentials(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil || creds != nil {
+		t.Fatalf("LoadDockerConfigCredentials(missing) = %+v, %v; want nil, nil", creds, err)
+	}
+}
+
+func TestLoadDockerConfigCredentials_MalformedAuth(t *testing.T) {
+	path := writeDockerConfig(t, `{"auths": {"ghcr.io": {"auth": "not base64!"}}}`)
+	if _, err := ctr.LoadDockerConfigCredentials(path); err == nil {
+		t.Fatal("expected an error for an undecodable auth field")
+	}
+}
+
+func TestDockerConfigPath_HonorsDockerConfigEnv(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", "/etc/kukeon/docker")
+	if got := ctr.DockerConfigPath(); got != "/etc/kukeon/docker/config.json" {
+		t.Fatalf("DockerConfigPath() = %q", got)
+	}
+}
+
+func TestMergeRegistryCredentials_RealmWins(t *testing.T) {
+	realm := []ctr.RegistryCredentials{
+		{ServerAddress: "https://index.docker.io/v1/", Username: "realm-hub", Password: "r"},
+	}
+	docker := []ctr.RegistryCredentials{
+		{ServerAddress: "docker.io", Username: "docker-hub", Password: "d"},
+		{ServerAddress: "ghcr.io", Username: "docker-gh", Password: "d"},
+	}
+	got := ctr.MergeRegistryCredentials(realm, docker)
+	want := []ctr.RegistryCredentials{realm[0], docker[1]}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("merged = %+v, want %+v", got, want)
+	}
+	if len(ctr.MergeRegistryCredentials(nil, nil)) != 0 {
+		t.Fatal("merging nothing produced credentials")
+	}
+}
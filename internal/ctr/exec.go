@@ -0,0 +1,161 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"syscall"
+	"time"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/pkg/cio"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/util/naming"
+)
+
+const (
+	// execOutputLimit caps how much of an exec process's output ExecProcess
+	// keeps; only the tail is useful in an error message.
+	execOutputLimit = 4096
+
+	// execIDPrefix marks exec processes started by kukeon itself.
+	execIDPrefix = "kukeon-exec-"
+)
+
+// ExecProcess runs args inside the container's running task; it returns
+// errdefs.ErrTaskNotFound or errdefs.ErrTaskNotRunning when there is no
+// running task to join. The process
+// inherits the task's OCI process spec (user, env, cwd, capabilities) with
+// only the argv replaced and the terminal turned off; its output is captured
+// through the standard cio fifos and trimmed to the last execOutputLimit
+// bytes. The process is always deleted before returning, and killed first
+// when timeout expires.
+func (c *client) ExecProcess(
+	namespace, containerID string,
+	args []string,
+	timeout time.Duration,
+) (ExecResult, error) {
+	if containerID == "" {
+		return ExecResult{}, errdefs.ErrEmptyContainerID
+	}
+	if len(args) == 0 {
+		return ExecResult{}, errors.New("exec args are required")
+	}
+
+	task, err := c.loadTask(namespace, containerID)
+	if err != nil {
+		return ExecResult{}, err
+	}
+	container, err := c.loadContainer(namespace, containerID)
+	if err != nil {
+		return ExecResult{}, err
+	}
+
+	nsCtx := c.namespaceCtx(namespace)
+	status, err := task.Status(nsCtx)
+	if err != nil {
+		return ExecResult{}, fmt.Errorf("failed to get task status: %w", err)
+	}
+	if status.Status != containerd.Running {
+		return ExecResult{}, fmt.Errorf("%w: task %q is %s", errdefs.ErrTaskNotRunning, containerID, status.Status)
+	}
+	spec, err := container.Spec(nsCtx)
+	if err != nil {
+		return ExecResult{}, fmt.Errorf("failed to load container spec: %w", err)
+	}
+	if spec.Process == nil {
+		return ExecResult{}, fmt.Errorf("container %q has no process spec", containerID)
+	}
+	processSpec := *spec.Process
+	processSpec.Args = args
+	processSpec.Terminal = false
+	processSpec.ConsoleSize = nil
+
+	suffix, err := naming.RandomHexSuffix(naming.DefaultCellNameSuffixBytes)
+	if err != nil {
+		return ExecResult{}, fmt.Errorf("failed to generate exec id: %w", err)
+	}
+	execID := execIDPrefix + suffix
+
+	ctx := nsCtx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(nsCtx, timeout)
+		defer cancel()
+	}
+
+	out := &tailBuffer{limit: execOutputLimit}
+	process, err := task.Exec(ctx, execID, &processSpec, cio.NewCreator(cio.WithStreams(nil, out, out)))
+	if err != nil {
+		return ExecResult{}, fmt.Errorf("failed to create exec process: %w", err)
+	}
+	// Delete on nsCtx so cleanup still runs after ctx has expired.
+	defer func() {
+		if _, delErr := process.Delete(nsCtx, containerd.WithProcessKill); delErr != nil {
+			c.logger.WarnContext(c.ctx, "failed to delete exec process", "id", containerID,
+				"exec", execID, "err", formatError(delErr))
+		}
+	}()
+
+	statusC, err := process.Wait(nsCtx)
+	if err != nil {
+		return ExecResult{}, fmt.Errorf("failed to wait for exec process: %w", err)
+	}
+	if err = process.Start(ctx); err != nil {
+		return ExecResult{}, fmt.Errorf("failed to start exec process: %w", err)
+	}
+
+	select {
+	case status := <-statusC:
+		code, _, resultErr := status.Result()
+		if resultErr != nil {
+			return ExecResult{}, fmt.Errorf("failed to get exec process result: %w", resultErr)
+		}
+		process.IO().Wait()
+		return ExecResult{ExitCode: code, Output: out.String()}, nil
+	case <-ctx.Done():
+		_ = process.Kill(nsCtx, syscall.SIGKILL)
+		return ExecResult{Output: out.String()}, fmt.Errorf("exec process %s: %w", execID, ctx.Err())
+	}
+}
+
+// tailBuffer is an io.Writer, safe for the concurrent stdout and stderr
+// copiers, that keeps only the last limit bytes written to it.
+type tailBuffer struct {
+	mu    sync.Mutex
+	limit int
+	buf   []byte
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf = append(b.buf, p...)
+	if over := len(b.buf) - b.limit; over > 0 {
+		b.buf = append(b.buf[:0], b.buf[over:]...)
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.buf)
+}
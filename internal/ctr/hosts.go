@@ -0,0 +1,88 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	internalerrdefs "github.com/eminwux/kukeon/internal/errdefs"
+)
+
+// maxHostnameLength is the kernel's HOST_NAME_MAX: sethostname(2) rejects
+// anything longer.
+const maxHostnameLength = 64
+
+// hostnamePattern accepts RFC 1123 host names: dot-separated labels of
+// letters, digits and inner hyphens.
+var hostnamePattern = regexp.MustCompile(
+	`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+
+// ExtraHost is one parsed spec.extraHosts entry: an /etc/hosts line mapping
+// Host to IP.
+type ExtraHost struct {
+	Host string
+	IP   net.IP
+}
+
+// ValidateHostname checks a container's hostname field. Empty is valid and
+// keeps the cell-name default. Errors wrap errdefs.ErrInvalidHostname.
+func ValidateHostname(hostname string) error {
+	if hostname == "" {
+		return nil
+	}
+	if len(hostname) > maxHostnameLength || !hostnamePattern.MatchString(hostname) {
+		return fmt.Errorf("%w: %q must be an RFC 1123 host name of at most %d characters",
+			internalerrdefs.ErrInvalidHostname, hostname, maxHostnameLength)
+	}
+	return nil
+}
+
+// ParseExtraHost parses a spec.extraHosts entry, "host:ip" as Docker's
+// --add-host takes it. The host is split off at the first colon, so an IPv6
+// address needs no brackets ("db:fd00::5"). Errors wrap
+// errdefs.ErrInvalidExtraHost.
+func ParseExtraHost(entry string) (ExtraHost, error) {
+	host, addr, ok := strings.Cut(strings.TrimSpace(entry), ":")
+	if !ok {
+		return ExtraHost{}, fmt.Errorf("%w: %q: want host:ip", internalerrdefs.ErrInvalidExtraHost, entry)
+	}
+	if !hostnamePattern.MatchString(host) {
+		return ExtraHost{}, fmt.Errorf("%w: %q: %q is not a valid host name",
+			internalerrdefs.ErrInvalidExtraHost, entry, host)
+	}
+	ip := net.ParseIP(strings.Trim(addr, "[]"))
+	if ip == nil {
+		return ExtraHost{}, fmt.Errorf("%w: %q: %q is not an IP address",
+			internalerrdefs.ErrInvalidExtraHost, entry, addr)
+	}
+	return ExtraHost{Host: host, IP: ip}, nil
+}
+
+// ValidateExtraHosts parses every spec.extraHosts entry and reports each
+// malformed one.
+func ValidateExtraHosts(entries []string) []error {
+	var errs []error
+	for _, e := range entries {
+		if _, err := ParseExtraHost(e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
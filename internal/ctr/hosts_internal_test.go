@@ -0,0 +1,89 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"errors"
+	"testing"
+
+	internalerrdefs "github.com/eminwux/kukeon/internal/errdefs"
+)
+
+func TestValidateHostname(t *testing.T) {
+	tests := []struct {
+		name     string
+		hostname string
+		wantErr  bool
+	}{
+		{name: "empty keeps default", hostname: ""},
+		{name: "single label", hostname: "web"},
+		{name: "dotted", hostname: "web-1.internal.example"},
+		{name: "leading hyphen", hostname: "-web", wantErr: true},
+		{name: "underscore", hostname: "web_1", wantErr: true},
+		{name: "space", hostname: "web 1", wantErr: true},
+		{name: "too long", hostname: "a123456789.b123456789.c123456789.d123456789.e123456789.f123456789", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateHostname(tt.hostname)
+			if tt.wantErr {
+				if !errors.Is(err, internalerrdefs.ErrInvalidHostname) {
+					t.Fatalf("ValidateHostname(%q) = %v, want ErrInvalidHostname", tt.hostname, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateHostname(%q) = %v, want nil", tt.hostname, err)
+			}
+		})
+	}
+}
+
+func TestParseExtraHost(t *testing.T) {
+	tests := []struct {
+		name     string
+		entry    string
+		wantHost string
+		wantIP   string
+		wantErr  bool
+	}{
+		{name: "ipv4", entry: "db:10.0.0.5", wantHost: "db", wantIP: "10.0.0.5"},
+		{name: "ipv6", entry: "db:fd00::5", wantHost: "db", wantIP: "fd00::5"},
+		{name: "bracketed ipv6", entry: "db.internal:[fd00::5]", wantHost: "db.internal", wantIP: "fd00::5"},
+		{name: "no separator", entry: "db", wantErr: true},
+		{name: "empty host", entry: ":10.0.0.5", wantErr: true},
+		{name: "bad ip", entry: "db:10.0.0", wantErr: true},
+		{name: "bad host", entry: "d b:10.0.0.5", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseExtraHost(tt.entry)
+			if tt.wantErr {
+				if !errors.Is(err, internalerrdefs.ErrInvalidExtraHost) {
+					t.Fatalf("ParseExtraHost(%q) error = %v, want ErrInvalidExtraHost", tt.entry, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseExtraHost(%q) error = %v", tt.entry, err)
+			}
+			if got.Host != tt.wantHost || got.IP.String() != tt.wantIP {
+				t.Errorf("ParseExtraHost(%q) = %s %s, want %s %s", tt.entry, got.Host, got.IP, tt.wantHost, tt.wantIP)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
 	"time"
 
 	containerd "github.com/containerd/containerd/v2/client"
@@ -28,8 +29,11 @@ import (
 	"github.com/containerd/containerd/v2/defaults"
 	"github.com/containerd/errdefs"
 	"github.com/containerd/platforms"
+	"github.com/distribution/reference"
 	"github.com/eminwux/kukeon/internal/consts"
 	internalerrdefs "github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/opencontainers/go-digest"
 	"github.com/opencontainers/image-spec/identity"
 )
 
@@ -91,22 +95,44 @@ func (c *client) ensureImageUnpacked(namespace string, image containerd.Image, s
 	return nil
 }
 
-// pullImage pulls an image from a registry if it's not found locally.
-// Returns the image and any error encountered.
+// pullImage resolves imageRef to a local image, pulling it from its registry
+// according to policy (one of the intmodel.ImagePullPolicy* values; empty is
+// IfNotPresent). Returns the image and any error encountered.
+//
+// platform (os/arch[/variant], empty for the host) selects the manifest of a
+// multi-arch image: the pull fetches only that platform's content and the
+// returned image unpacks it. Under IfNotPresent a local image that lacks the
+// requested platform's content counts as absent and is pulled again.
+//
+// A reference pinned by digest (name@sha256:...) is verified against the
+// manifest digest of the image it resolves to, whether that image was found
+// locally or just pulled, and fails with ErrImageDigestMismatch when they
+// differ. Under IfNotPresent a pinned reference also reuses any local image
+// whose manifest carries that digest — e.g. one pulled by tag — instead of
+// pulling it again.
 //
 // Refs hosted under the local-only kukeon.internal registry (see
-// consts.InternalImageRegistry) are never pulled: they are built into this
-// realm's namespace by `kuke team init --build` (internal/teambuild), not
-// published anywhere a pull could reach. A local miss on such a ref is an
-// operator error — the image was never built — so pullImage short-circuits
-// with ErrInternalImageNotBuilt ("build it") instead of attempting a doomed
-// network pull against the non-routable host. The full build→bind→run path is
-// exercised by the `kuke team init --build` two-project compose e2e and the
-// dev-init smoke; this layer's contract is the no-pull short-circuit itself.
-func (c *client) pullImage(namespace string, imageRef string, creds []RegistryCredentials) (containerd.Image, error) {
+// consts.InternalImageRegistry) are never pulled, whatever the policy: they
+// are built into this realm's namespace by `kuke team init --build`
+// (internal/teambuild), not published anywhere a pull could reach. A local
+// miss on such a ref is an operator error — the image was never built — so
+// pullImage short-circuits with ErrInternalImageNotBuilt ("build it") instead
+// of attempting a doomed network pull against the non-routable host. The full
+// build→bind→run path is exercised by the `kuke team init --build`
+// two-project compose e2e and the dev-init smoke; this layer's contract is the
+// no-pull short-circuit itself.
+func (c *client) pullImage(
+	namespace, imageRef, policy, platform string,
+	creds []RegistryCredentials,
+) (containerd.Image, error) {
 	nsCtx := c.namespaceCtx(namespace)
 	cc := c.conn()
 
+	matcher, err := imagePlatformMatcher(platform)
+	if err != nil {
+		return nil, err
+	}
+
 	// Canonicalize bare references (e.g. "busybox:latest",
 	// "docker.io/busybox:latest") to the fully qualified docker.io/library form
 	// before either the local lookup or the network pull — containerd's resolver
@@ -114,16 +140,24 @@ func (c *client) pullImage(namespace string, imageRef string, creds []RegistryCr
 	// kukebuild stores images under this same normalized name, so the local
 	// GetImage hit below stays consistent with built/loaded images.
 	imageRef = NormalizeImageReference(imageRef)
+	internalRef := consts.IsInternalImageRef(imageRef)
 
-	// Try to get the image locally first
-	image, err := cc.GetImage(nsCtx, imageRef)
-	if err == nil {
-		return image, nil
+	if policy != intmodel.ImagePullPolicyAlways || internalRef {
+		image, err := c.localImage(nsCtx, imageRef, platform, matcher)
+		if err != nil {
+			return nil, err
+		}
+		if image != nil {
+			if err = verifyImageDigest(imageRef, image.Target().Digest); err != nil {
+				return nil, err
+			}
+			return image, nil
+		}
 	}
 
 	// Local-only kukeon.internal refs are never pulled — a miss means the
 	// image was supposed to be built locally and was not.
-	if consts.IsInternalImageRef(imageRef) {
+	if internalRef {
 		c.logger.WarnContext(
 			c.ctx,
 			"local-only image not present in realm; not pulling",
@@ -132,8 +166,11 @@ func (c *client) pullImage(namespace string, imageRef string, creds []RegistryCr
 		return nil, fmt.Errorf("%w: %s", internalerrdefs.ErrInternalImageNotBuilt, imageRef)
 	}
 
-	// Image not found locally, pull it
-	c.logger.DebugContext(c.ctx, "image not found locally, pulling", "image", imageRef)
+	if policy == intmodel.ImagePullPolicyNever {
+		return nil, fmt.Errorf("%w: %s", internalerrdefs.ErrImageNotPresent, imageRef)
+	}
+
+	c.logger.DebugContext(c.ctx, "pulling image", "image", imageRef, "policy", policy, "platform", platform)
 
 	// Create a lease for the pull operation to avoid lease management issues
 	// The lease will be automatically cleaned up when the context is done
@@ -164,13 +201,10 @@ func (c *client) pullImage(namespace string, imageRef string, creds []RegistryCr
 		nsCtx = leaseCtx
 	}
 
-	// Use default platform for pull
-	// The image will be unpacked separately after pull
-	platform := platforms.DefaultSpec()
-
-	// Build pull options with resolver if credentials are available
+	// The image will be unpacked separately after pull, through the same
+	// matcher the pulled image carries.
 	pullOpts := []containerd.RemoteOpt{
-		containerd.WithPlatform(platforms.Format(platform)),
+		containerd.WithPlatformMatcher(matcher),
 	}
 
 	// Use credentials passed as parameter
@@ -182,15 +216,138 @@ func (c *client) pullImage(namespace string, imageRef string, creds []RegistryCr
 		c.logger.DebugContext(c.ctx, "pulling image anonymously", "image", imageRef)
 	}
 
-	image, err = cc.Pull(nsCtx, imageRef, pullOpts...)
+	image, err := cc.Pull(nsCtx, imageRef, pullOpts...)
 	if err != nil {
 		c.logger.ErrorContext(c.ctx, "failed to pull image", "image", imageRef, "err", formatError(err))
 		return nil, fmt.Errorf("failed to pull image %s: %w", imageRef, err)
 	}
 
+	if err = verifyImageDigest(imageRef, image.Target().Digest); err != nil {
+		c.logger.ErrorContext(c.ctx, "pulled image does not match its pinned digest", "image", imageRef,
+			"resolved", image.Target().Digest.String())
+		return nil, err
+	}
 	return image, nil
 }
 
+// localImage looks imageRef up in the namespace's image store. A miss on a
+// digest-pinned ref falls back to any image whose manifest carries the pinned
+// digest. The image is returned bound to matcher; with an explicit platform,
+// an image whose content for it is not all present is treated as a miss.
+// Returns a nil image, and no error, when nothing matches.
+func (c *client) localImage(
+	nsCtx context.Context,
+	imageRef, platform string,
+	matcher platforms.MatchComparer,
+) (containerd.Image, error) {
+	cc := c.conn()
+	var img images.Image
+	image, err := cc.GetImage(nsCtx, imageRef)
+	switch {
+	case err == nil:
+		img = image.Metadata()
+	case !errdefs.IsNotFound(err):
+		c.logger.DebugContext(c.ctx, "local image lookup failed, pulling", "image", imageRef, "err", formatError(err))
+		return nil, nil
+	default:
+		pinned := imageRefDigest(imageRef)
+		if pinned == "" {
+			return nil, nil
+		}
+		var found bool
+		img, found, err = findImageByDigest(nsCtx, cc.ImageService(), pinned)
+		if err != nil || !found {
+			return nil, err
+		}
+		c.logger.DebugContext(c.ctx, "pinned digest already present locally, skipping pull",
+			"image", imageRef, "localImage", img.Name)
+	}
+
+	if platform != "" {
+		available, _, _, _, checkErr := images.Check(nsCtx, cc.ContentStore(), img.Target, matcher)
+		if checkErr != nil || !available {
+			c.logger.DebugContext(c.ctx, "local image lacks the requested platform, pulling",
+				"image", imageRef, "platform", platform)
+			return nil, nil
+		}
+	}
+	return containerd.NewImageWithPlatform(cc, img, matcher), nil
+}
+
+// imagePlatformMatcher returns the matcher an image is pulled and unpacked
+// with: the host platform for an empty platform, otherwise exactly the
+// requested os/arch[/variant].
+func imagePlatformMatcher(platform string) (platforms.MatchComparer, error) {
+	if platform == "" {
+		return platforms.Default(), nil
+	}
+	if err := ValidateImagePlatform(platform); err != nil {
+		return nil, err
+	}
+	p, _ := platforms.Parse(platform)
+	return platforms.Only(platforms.Normalize(p)), nil
+}
+
+// ValidateImagePlatform reports an ErrInvalidPlatform when platform is set
+// but is not a parseable os/arch[/variant] specifier for a linux image.
+// Empty is valid and means the host platform.
+func ValidateImagePlatform(platform string) error {
+	if platform == "" {
+		return nil
+	}
+	p, err := platforms.Parse(platform)
+	if err != nil {
+		return fmt.Errorf("%w: %q: %w", internalerrdefs.ErrInvalidPlatform, platform, err)
+	}
+	if p.OS != "linux" {
+		return fmt.Errorf("%w: %q: only linux images can run, got os %q",
+			internalerrdefs.ErrInvalidPlatform, platform, p.OS)
+	}
+	return nil
+}
+
+// findImageByDigest returns an image in store whose manifest target is dgst.
+// Images are sorted by name so the pick is deterministic when several names
+// share the digest.
+func findImageByDigest(nsCtx context.Context, store images.Store, dgst digest.Digest) (images.Image, bool, error) {
+	imgs, err := store.List(nsCtx, "target.digest=="+dgst.String())
+	if err != nil {
+		return images.Image{}, false, fmt.Errorf("%w: %w", internalerrdefs.ErrListImages, err)
+	}
+	sort.Slice(imgs, func(i, j int) bool { return imgs[i].Name < imgs[j].Name })
+	for _, img := range imgs {
+		if img.Target.Digest == dgst {
+			return img, true, nil
+		}
+	}
+	return images.Image{}, false, nil
+}
+
+// imageRefDigest returns the digest an image reference is pinned to, or ""
+// for a tag-only (or unparseable) reference.
+func imageRefDigest(imageRef string) digest.Digest {
+	named, err := reference.ParseNormalizedNamed(imageRef)
+	if err != nil {
+		return ""
+	}
+	canonical, ok := named.(reference.Canonical)
+	if !ok {
+		return ""
+	}
+	return canonical.Digest()
+}
+
+// verifyImageDigest checks that resolved, the manifest digest imageRef
+// resolved to, matches the digest imageRef is pinned to. Tag-only references
+// always pass.
+func verifyImageDigest(imageRef string, resolved digest.Digest) error {
+	pinned := imageRefDigest(imageRef)
+	if pinned == "" || pinned == resolved {
+		return nil
+	}
+	return fmt.Errorf("%w: %s resolved to %s", internalerrdefs.ErrImageDigestMismatch, imageRef, resolved)
+}
+
 // LoadImage imports an OCI/docker image tarball into the specified
 // containerd namespace and returns the names of the imported images.
 //
@@ -227,6 +384,16 @@ func (c *client) LoadImage(namespace string, reader io.Reader) ([]string, error)
 // because content is missing locally), the entry is still surfaced with
 // Size=-1 so listing degrades gracefully instead of failing the whole call.
 func (c *client) ListImages(namespace string) ([]ImageInfo, error) {
+	var infos []ImageInfo
+	err := c.withReconnect(func() error {
+		var err error
+		infos, err = c.listImages(namespace)
+		return err
+	})
+	return infos, err
+}
+
+func (c *client) listImages(namespace string) ([]ImageInfo, error) {
 	nsCtx := c.namespaceCtx(namespace)
 
 	imgs, err := c.conn().ListImages(nsCtx)
@@ -254,6 +421,29 @@ func (c *client) ListImages(namespace string) ([]ImageInfo, error) {
 // sentinel) when containerd reports the ref absent so upper layers can map
 // to a clean error message.
 func (c *client) GetImage(namespace, ref string) (ImageInfo, error) {
+	var info ImageInfo
+	err := c.withReconnect(func() error {
+		var err error
+		info, err = c.getImage(namespace, ref)
+		return err
+	})
+	return info, err
+}
+
+func (c *client) PullImage(namespace, ref string, creds []RegistryCredentials) (ImageInfo, error) {
+	var info ImageInfo
+	err := c.withReconnect(func() error {
+		img, err := c.pullImage(namespace, ref, intmodel.ImagePullPolicyIfNotPresent, "", creds)
+		if err != nil {
+			return err
+		}
+		info = c.imageToInfo(namespace, img)
+		return nil
+	})
+	return info, err
+}
+
+func (c *client) getImage(namespace, ref string) (ImageInfo, error) {
 	nsCtx := c.namespaceCtx(namespace)
 
 	img, err := c.conn().GetImage(nsCtx, ref)
@@ -405,6 +595,32 @@ func (c *client) ContainerRootChainID(namespace, containerID string) (string, er
 	return snapInfo.Parent, nil
 }
 
+// ContainerImageDigest returns the manifest digest of the image the container
+// was created from. CreateContainer records it as the imageDigestLabelKey
+// label; containers created before that label existed fall back to the digest
+// the container's image ref resolves to today.
+func (c *client) ContainerImageDigest(namespace, containerID string) (string, error) {
+	container, err := c.loadContainer(namespace, containerID)
+	if err != nil {
+		return "", err
+	}
+
+	nsCtx := c.namespaceCtx(namespace)
+	labels, err := container.Labels(nsCtx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get container labels for %s: %w", containerID, err)
+	}
+	if dgst := labels[imageDigestLabelKey]; dgst != "" {
+		return dgst, nil
+	}
+
+	image, err := container.Image(nsCtx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get container image for %s: %w", containerID, err)
+	}
+	return image.Target().Digest.String(), nil
+}
+
 // imageToInfo extracts the ImageInfo subset from a containerd Image. Size is
 // resolved via the platform-default Size() helper; failure leaves Size=-1
 // rather than aborting because partial-content tarballs are common with
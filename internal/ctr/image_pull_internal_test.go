@@ -0,0 +1,165 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/containerd/platforms"
+	internalerrdefs "github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestVerifyImageDigest(t *testing.T) {
+	pinned := digest.FromString("manifest")
+	other := digest.FromString("other manifest")
+
+	tests := []struct {
+		name     string
+		ref      string
+		resolved digest.Digest
+		wantErr  error
+	}{
+		{
+			name:     "matching digest",
+			ref:      "docker.io/library/busybox@" + pinned.String(),
+			resolved: pinned,
+		},
+		{
+			name:     "matching digest alongside a tag",
+			ref:      "docker.io/library/busybox:1.36@" + pinned.String(),
+			resolved: pinned,
+		},
+		{
+			name:     "digest mismatch",
+			ref:      "docker.io/library/busybox@" + pinned.String(),
+			resolved: other,
+			wantErr:  internalerrdefs.ErrImageDigestMismatch,
+		},
+		{
+			name:     "tag-only reference accepts any digest",
+			ref:      "docker.io/library/busybox:latest",
+			resolved: other,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyImageDigest(tt.ref, tt.resolved)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("verifyImageDigest(%q, %s) = %v, want %v", tt.ref, tt.resolved, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestImageRefDigest(t *testing.T) {
+	pinned := digest.FromString("manifest")
+
+	if got := imageRefDigest("busybox@" + pinned.String()); got != pinned {
+		t.Errorf("imageRefDigest(pinned) = %q, want %q", got, pinned)
+	}
+	if got := imageRefDigest("busybox:latest"); got != "" {
+		t.Errorf("imageRefDigest(tag-only) = %q, want empty", got)
+	}
+}
+
+// TestFindImageByDigest covers the IfNotPresent skip for digest-pinned refs:
+// an image pulled under any name satisfies the pin when its manifest carries
+// the pinned digest.
+func TestFindImageByDigest(t *testing.T) {
+	pinned := digest.FromString("manifest")
+
+	srv := newFakeServices()
+	srv.addImage("docker.io/library/busybox:latest", digest.FromString("other manifest"))
+	srv.addImage("docker.io/library/busybox:1.36", pinned)
+
+	img, found, err := findImageByDigest(context.Background(), srv.ImageService(), pinned)
+	if err != nil {
+		t.Fatalf("findImageByDigest: %v", err)
+	}
+	if !found || img.Name != "docker.io/library/busybox:1.36" {
+		t.Fatalf("findImageByDigest = (%q, %v), want busybox:1.36", img.Name, found)
+	}
+
+	_, found, err = findImageByDigest(context.Background(), srv.ImageService(), digest.FromString("absent"))
+	if err != nil || found {
+		t.Fatalf("findImageByDigest(absent) = (%v, %v), want not found", found, err)
+	}
+}
+
+func TestImagePlatformMatcher(t *testing.T) {
+	arm64 := ocispec.Platform{OS: "linux", Architecture: "arm64"}
+	amd64 := ocispec.Platform{OS: "linux", Architecture: "amd64"}
+
+	t.Run("empty matches the host", func(t *testing.T) {
+		matcher, err := imagePlatformMatcher("")
+		if err != nil {
+			t.Fatalf("imagePlatformMatcher(\"\") error = %v", err)
+		}
+		if !matcher.Match(platforms.DefaultSpec()) {
+			t.Errorf("matcher does not match the host platform %s", platforms.Format(platforms.DefaultSpec()))
+		}
+	})
+
+	t.Run("explicit platform matches only itself", func(t *testing.T) {
+		matcher, err := imagePlatformMatcher("linux/arm64")
+		if err != nil {
+			t.Fatalf("imagePlatformMatcher(linux/arm64) error = %v", err)
+		}
+		if !matcher.Match(arm64) {
+			t.Error("matcher does not match linux/arm64")
+		}
+		if matcher.Match(amd64) {
+			t.Error("matcher matches linux/amd64, want only linux/arm64")
+		}
+	})
+
+	t.Run("invalid platform", func(t *testing.T) {
+		if _, err := imagePlatformMatcher("windows/amd64"); !errors.Is(err, internalerrdefs.ErrInvalidPlatform) {
+			t.Errorf("imagePlatformMatcher(windows/amd64) error = %v, want ErrInvalidPlatform", err)
+		}
+	})
+}
+
+func TestValidateImagePlatform(t *testing.T) {
+	tests := []struct {
+		platform string
+		wantErr  error
+	}{
+		{platform: ""},
+		{platform: "linux/amd64"},
+		{platform: "linux/arm64/v8"},
+		{platform: "linux/arm/v7"},
+		{platform: "arm64"},
+		{platform: "linux/arm64/v8/extra", wantErr: internalerrdefs.ErrInvalidPlatform},
+		{platform: "windows/amd64", wantErr: internalerrdefs.ErrInvalidPlatform},
+		{platform: "linux/!!", wantErr: internalerrdefs.ErrInvalidPlatform},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.platform, func(t *testing.T) {
+			err := ValidateImagePlatform(tt.platform)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateImagePlatform(%q) = %v, want %v", tt.platform, err, tt.wantErr)
+			}
+		})
+	}
+}
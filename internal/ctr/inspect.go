@@ -0,0 +1,123 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/containerd/containerd/v2/core/containers"
+	internalerrdefs "github.com/eminwux/kukeon/internal/errdefs"
+	runtimespec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// ContainerInspection is the ctr-layer view of one containerd container for
+// `kuke inspect`: the decoded OCI runtime spec kukeon produced for it and the
+// containerd container record it is stored under.
+type ContainerInspection struct {
+	// Spec is the OCI runtime spec as stored on the container.
+	Spec *runtimespec.Spec
+	// Record is the containerd container metadata, with the spec itself
+	// omitted (it is carried decoded in Spec).
+	Record ContainerRecord
+}
+
+// ContainerRecord is the JSON-friendly subset of containerd's
+// containers.Container. The typeurl.Any payloads (runtime options,
+// extensions) are reduced to their type URLs: their bodies are opaque
+// protobuf and the spec is already surfaced decoded.
+type ContainerRecord struct {
+	ID                 string            `json:"id"`
+	Labels             map[string]string `json:"labels,omitempty"`
+	Image              string            `json:"image"`
+	Runtime            string            `json:"runtime"`
+	RuntimeOptionsType string            `json:"runtimeOptionsType,omitempty"`
+	Snapshotter        string            `json:"snapshotter"`
+	SnapshotKey        string            `json:"snapshotKey"`
+	SandboxID          string            `json:"sandboxID,omitempty"`
+	Extensions         map[string]string `json:"extensions,omitempty"`
+	CreatedAt          time.Time         `json:"createdAt"`
+	UpdatedAt          time.Time         `json:"updatedAt"`
+}
+
+// InspectContainer loads the container from containerd and returns its
+// decoded OCI spec alongside its containerd record. Returns
+// errdefs.ErrContainerNotFound if the container is absent.
+func (c *client) InspectContainer(namespace, containerID string) (ContainerInspection, error) {
+	if containerID == "" {
+		return ContainerInspection{}, internalerrdefs.ErrEmptyContainerID
+	}
+	var out ContainerInspection
+	err := c.withReconnect(func() error {
+		container, err := c.loadContainer(namespace, containerID)
+		if err != nil {
+			return err
+		}
+		nsCtx := c.namespaceCtx(namespace)
+		info, err := container.Info(nsCtx)
+		if err != nil {
+			return fmt.Errorf("failed to get container info for %s: %w", containerID, err)
+		}
+		out, err = newContainerInspection(info)
+		if err != nil {
+			return fmt.Errorf("failed to decode OCI spec for %s: %w", containerID, err)
+		}
+		return nil
+	})
+	return out, err
+}
+
+// newContainerInspection decodes the OCI spec stored on a containerd record
+// and assembles the ContainerInspection. The decode is the one
+// containerd.Container.Spec performs, done here on the record already
+// fetched so inspect costs a single round-trip.
+func newContainerInspection(info containers.Container) (ContainerInspection, error) {
+	if info.Spec == nil {
+		return ContainerInspection{}, errors.New("container record carries no spec")
+	}
+	var spec runtimespec.Spec
+	if err := json.Unmarshal(info.Spec.GetValue(), &spec); err != nil {
+		return ContainerInspection{}, err
+	}
+	record := ContainerRecord{
+		ID:          info.ID,
+		Labels:      info.Labels,
+		Image:       info.Image,
+		Runtime:     info.Runtime.Name,
+		Snapshotter: info.Snapshotter,
+		SnapshotKey: info.SnapshotKey,
+		SandboxID:   info.SandboxID,
+		CreatedAt:   info.CreatedAt,
+		UpdatedAt:   info.UpdatedAt,
+	}
+	if info.Runtime.Options != nil {
+		record.RuntimeOptionsType = info.Runtime.Options.GetTypeUrl()
+	}
+	if len(info.Extensions) > 0 {
+		record.Extensions = make(map[string]string, len(info.Extensions))
+		for name, ext := range info.Extensions {
+			if ext == nil {
+				record.Extensions[name] = ""
+				continue
+			}
+			record.Extensions[name] = ext.GetTypeUrl()
+		}
+	}
+	return ContainerInspection{Spec: &spec, Record: record}, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/v2/core/containers"
+	"github.com/containerd/containerd/v2/pkg/namespaces"
+	"github.com/containerd/containerd/v2/pkg/oci"
+	"github.com/containerd/typeurl/v2"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// TestNewContainerInspection_DecodesSpecJSON builds a spec through the same
+// BuildContainerSpec options CreateContainer applies, stores it on a
+// containerd record the way containerd does, and asserts the inspection
+// decodes it back into JSON carrying the fields the spec builders set.
+func TestNewContainerInspection_DecodesSpecJSON(t *testing.T) {
+	built := BuildContainerSpec(intmodel.ContainerSpec{
+		ID:        "app",
+		Image:     "registry.eminwux.com/busybox:latest",
+		CellName:  "web",
+		SpaceName: "space",
+		RealmName: "realm",
+		StackName: "stack",
+		Command:   "sleep",
+		Args:      []string{"infinity"},
+		Env:       []string{"GREETING=hello"},
+		Sysctls:   map[string]string{"net.ipv4.ip_forward": "1"},
+	})
+
+	ctx := namespaces.WithNamespace(context.Background(), "realm")
+	record := containers.Container{
+		ID:          "realm_space_stack_web_app",
+		Image:       "registry.eminwux.com/busybox:latest",
+		Labels:      map[string]string{"kukeon.io/cell": "web"},
+		Runtime:     containers.RuntimeInfo{Name: "io.containerd.runc.v2"},
+		Snapshotter: "overlayfs",
+		SnapshotKey: "realm_space_stack_web_app",
+		CreatedAt:   time.Unix(1700000000, 0).UTC(),
+	}
+	spec, err := oci.GenerateSpec(ctx, nil, &record, built.SpecOpts...)
+	if err != nil {
+		t.Fatalf("GenerateSpec: %v", err)
+	}
+	if record.Spec, err = typeurl.MarshalAny(spec); err != nil {
+		t.Fatalf("MarshalAny: %v", err)
+	}
+
+	got, err := newContainerInspection(record)
+	if err != nil {
+		t.Fatalf("newContainerInspection: %v", err)
+	}
+
+	specJSON, err := json.Marshal(got.Spec)
+	if err != nil {
+		t.Fatalf("marshal spec: %v", err)
+	}
+	for _, want := range []string{
+		`"ociVersion"`,
+		`"process"`,
+		`"args":["sleep","infinity"]`,
+		`"GREETING=hello"`,
+		`"mounts"`,
+		`"namespaces"`,
+		`"net.ipv4.ip_forward":"1"`,
+	} {
+		if !strings.Contains(string(specJSON), want) {
+			t.Errorf("decoded spec JSON missing %s:\n%s", want, specJSON)
+		}
+	}
+
+	recordJSON, err := json.Marshal(got.Record)
+	if err != nil {
+		t.Fatalf("marshal record: %v", err)
+	}
+	for _, want := range []string{
+		`"id":"realm_space_stack_web_app"`,
+		`"runtime":"io.containerd.runc.v2"`,
+		`"snapshotter":"overlayfs"`,
+	} {
+		if !strings.Contains(string(recordJSON), want) {
+			t.Errorf("record JSON missing %s:\n%s", want, recordJSON)
+		}
+	}
+	if strings.Contains(string(recordJSON), `"spec"`) {
+		t.Errorf("record JSON should not repeat the spec:\n%s", recordJSON)
+	}
+}
+
+func TestNewContainerInspection_RequiresSpec(t *testing.T) {
+	if _, err := newContainerInspection(containers.Container{ID: "app"}); err == nil {
+		t.Fatal("expected an error for a record without a spec")
+	}
+}
@@ -117,6 +117,16 @@ func (c *client) DeleteNamespace(namespace string) error {
 }
 
 func (c *client) ListNamespaces() ([]string, error) {
+	var nsList []string
+	err := c.withReconnect(func() error {
+		var err error
+		nsList, err = c.listNamespaces()
+		return err
+	})
+	return nsList, err
+}
+
+func (c *client) listNamespaces() ([]string, error) {
 	c.logger.DebugContext(c.ctx, "listing namespaces")
 
 	namespaces := c.conn().NamespaceService()
@@ -141,6 +151,16 @@ func (c *client) ExistsNamespace(namespace string) (bool, error) {
 }
 
 func (c *client) GetNamespace(namespace string) (string, error) {
+	var ns string
+	err := c.withReconnect(func() error {
+		var err error
+		ns, err = c.getNamespace(namespace)
+		return err
+	})
+	return ns, err
+}
+
+func (c *client) getNamespace(namespace string) (string, error) {
 	c.logger.DebugContext(c.ctx, "getting namespace", "namespace", namespace)
 	namespaces := c.conn().NamespaceService()
 
@@ -0,0 +1,88 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/errdefs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// isConnectionError reports whether err means containerd could not be reached
+// (gRPC Unavailable), as opposed to an error containerd itself returned.
+func isConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errdefs.IsUnavailable(err) {
+		return true
+	}
+	var se interface{ GRPCStatus() *status.Status }
+	return errors.As(err, &se) && se.GRPCStatus().Code() == codes.Unavailable
+}
+
+// withReconnect runs op and, while it fails with a connection error and the
+// reconnect budget allows, re-dials containerd and runs op again. op must
+// resolve everything connection-bound (c.conn(), cached containers and tasks)
+// on each call: the namespace is re-applied through namespaceCtx and
+// credentials are passed per call, so a retry after reconnect carries the same
+// namespace and credentials as the first attempt. Only use it for operations
+// that are safe to repeat.
+func (c *client) withReconnect(op func() error) error {
+	for attempt := 1; ; attempt++ {
+		stale := c.conn()
+		err := op()
+		if attempt > c.maxReconnects || !isConnectionError(err) {
+			return err
+		}
+		c.logger.WarnContext(c.ctx, "containerd connection lost, reconnecting",
+			"socket", c.socket, "attempt", attempt, "maxReconnects", c.maxReconnects, "err", formatError(err))
+		if reconnectErr := c.reconnect(stale); reconnectErr != nil {
+			return fmt.Errorf("%w (reconnect failed: %w)", err, reconnectErr)
+		}
+	}
+}
+
+// reconnect replaces the broken connection stale with a freshly dialed one.
+// When another caller already swapped stale out, its replacement is kept and
+// no second dial happens.
+func (c *client) reconnect(stale *containerd.Client) error {
+	c.cClientMu.Lock()
+	defer c.cClientMu.Unlock()
+
+	if c.cClient != nil && c.cClient != stale {
+		return nil
+	}
+	_ = c.closeLocked()
+
+	ctx, cancel := context.WithTimeout(c.ctx, c.connectTimeout)
+	defer cancel()
+	cClient, err := c.dialAndVerify(ctx)
+	if err != nil {
+		return err
+	}
+	c.cClient = cClient
+	c.verifiedAt = time.Now()
+	c.logger.InfoContext(c.ctx, "reconnected to containerd", "socket", c.socket)
+	return nil
+}
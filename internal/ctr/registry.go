@@ -67,9 +67,12 @@ func buildResolver(creds []RegistryCredentials) remotes.Resolver {
 	return docker.NewResolver(docker.ResolverOptions{
 		Authorizer: docker.NewDockerAuthorizer(
 			docker.WithAuthCreds(func(host string) (string, string, error) {
-				// First, try to find exact match by ServerAddress
+				// First, try to find a match by ServerAddress. Both sides are
+				// normalized so "https://index.docker.io/v1/" from a Docker
+				// config matches the registry-1.docker.io host asked here.
+				wantHost := normalizeRegistryHost(host)
 				for _, cred := range creds {
-					if cred.ServerAddress != "" && host == cred.ServerAddress {
+					if cred.ServerAddress != "" && normalizeRegistryHost(cred.ServerAddress) == wantHost {
 						return cred.Username, cred.Password, nil
 					}
 				}
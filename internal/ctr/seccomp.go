@@ -0,0 +1,142 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containerd/containerd/v2/contrib/seccomp"
+	"github.com/containerd/containerd/v2/core/containers"
+	"github.com/containerd/containerd/v2/pkg/oci"
+	internalerrdefs "github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	runtimespec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// ValidateSeccompProfile checks a spec.seccompProfile value without reading
+// the host: it must be empty, one of the intmodel.SeccompProfile* modes, or
+// an absolute, clean path. A profile may not be combined with a seccomp=
+// securityOpts entry, which would make the effective filter depend on
+// option order. Errors wrap errdefs.ErrInvalidSeccompProfile.
+func ValidateSeccompProfile(profile string, securityOpts []string) error {
+	switch profile {
+	case "":
+		return nil
+	case intmodel.SeccompProfileRuntimeDefault, intmodel.SeccompProfileUnconfined:
+	default:
+		if !filepath.IsAbs(profile) || filepath.Clean(profile) != profile {
+			return fmt.Errorf("%w: %q is not %s, %s or an absolute profile path",
+				internalerrdefs.ErrInvalidSeccompProfile, profile,
+				intmodel.SeccompProfileRuntimeDefault, intmodel.SeccompProfileUnconfined)
+		}
+	}
+	if securityOptsSetSeccomp(securityOpts) {
+		return fmt.Errorf("%w: seccompProfile %q conflicts with a seccomp securityOpts entry",
+			internalerrdefs.ErrInvalidSeccompProfile, profile)
+	}
+	return nil
+}
+
+// securityOptsSetSeccomp reports whether a securityOpts list carries a
+// seccomp= entry.
+func securityOptsSetSeccomp(securityOpts []string) bool {
+	for _, entry := range securityOpts {
+		key, _, _ := splitSecurityOpt(strings.TrimSpace(entry))
+		if strings.EqualFold(key, "seccomp") {
+			return true
+		}
+	}
+	return false
+}
+
+// seccompProfileSpecOpt applies spec.SeccompProfile to Linux.Seccomp. It
+// must run after the capability options: the runtime-default profile allows
+// extra syscalls for the capabilities the process holds. An empty profile
+// means runtime-default, except that a privileged container stays
+// unconfined and a seccomp= securityOpts entry keeps the filter it sets;
+// both return nil.
+func seccompProfileSpecOpt(spec intmodel.ContainerSpec) oci.SpecOpts {
+	if err := ValidateSeccompProfile(spec.SeccompProfile, spec.SecurityOpts); err != nil {
+		return errorSpecOpt(err)
+	}
+	switch spec.SeccompProfile {
+	case "":
+		if spec.Privileged || securityOptsSetSeccomp(spec.SecurityOpts) {
+			return nil
+		}
+		return withRuntimeDefaultSeccomp
+	case intmodel.SeccompProfileRuntimeDefault:
+		return withRuntimeDefaultSeccomp
+	case intmodel.SeccompProfileUnconfined:
+		return func(_ context.Context, _ oci.Client, _ *containers.Container, s *runtimespec.Spec) error {
+			if s.Linux != nil {
+				s.Linux.Seccomp = nil
+			}
+			return nil
+		}
+	default:
+		path := spec.SeccompProfile
+		return func(_ context.Context, _ oci.Client, _ *containers.Container, s *runtimespec.Spec) error {
+			profile, err := loadSeccompProfile(path)
+			if err != nil {
+				return err
+			}
+			if s.Linux == nil {
+				s.Linux = &runtimespec.Linux{}
+			}
+			s.Linux.Seccomp = profile
+			return nil
+		}
+	}
+}
+
+// withRuntimeDefaultSeccomp sets containerd's default seccomp profile,
+// tailored to the capabilities already on the spec.
+func withRuntimeDefaultSeccomp(_ context.Context, _ oci.Client, _ *containers.Container, s *runtimespec.Spec) error {
+	if s.Linux == nil {
+		s.Linux = &runtimespec.Linux{}
+	}
+	// DefaultProfile reads Process.Capabilities.Bounding unguarded.
+	probe := *s
+	if probe.Process == nil || probe.Process.Capabilities == nil {
+		probe.Process = &runtimespec.Process{Capabilities: &runtimespec.LinuxCapabilities{}}
+	}
+	s.Linux.Seccomp = seccomp.DefaultProfile(&probe)
+	return nil
+}
+
+// loadSeccompProfile reads and parses the JSON seccomp profile at path.
+// Errors wrap errdefs.ErrInvalidSeccompProfile and name the path.
+func loadSeccompProfile(path string) (*runtimespec.LinuxSeccomp, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: read %q: %w", internalerrdefs.ErrInvalidSeccompProfile, path, err)
+	}
+	profile := &runtimespec.LinuxSeccomp{}
+	if err = json.Unmarshal(data, profile); err != nil {
+		return nil, fmt.Errorf("%w: parse %q: %w", internalerrdefs.ErrInvalidSeccompProfile, path, err)
+	}
+	if profile.DefaultAction == "" {
+		return nil, fmt.Errorf("%w: %q has no defaultAction", internalerrdefs.ErrInvalidSeccompProfile, path)
+	}
+	return profile, nil
+}
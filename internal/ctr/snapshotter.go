@@ -0,0 +1,133 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/containerd/containerd/v2/core/snapshots"
+	"github.com/containerd/containerd/v2/defaults"
+	"github.com/containerd/containerd/v2/plugins"
+	internalerrdefs "github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// WithDefaultSnapshotter configures the realm-level containerd snapshotter
+// applied to every container built with this option whose spec does not
+// name its own. An empty name keeps containerd's default snapshotter.
+func WithDefaultSnapshotter(name string) BuildOption {
+	return func(o *buildOpts) {
+		if name = strings.TrimSpace(name); name != "" {
+			o.defaultSnapshotter = name
+		}
+	}
+}
+
+// resolveSnapshotter returns the snapshotter a container should be created
+// with: the container's own override first, then the realm default carried
+// by WithDefaultSnapshotter, else "" for containerd's default.
+func resolveSnapshotter(spec intmodel.ContainerSpec, opts buildOpts) string {
+	if name := strings.TrimSpace(spec.Snapshotter); name != "" {
+		return name
+	}
+	return opts.defaultSnapshotter
+}
+
+// SnapshotDiskQuotaLabel is the snapshot label carrying a container's rootfs
+// size limit in bytes. The containerd.io/snapshot/ prefix makes containerd
+// forward it to the snapshotter's Prepare call.
+const SnapshotDiskQuotaLabel = "containerd.io/snapshot/overlay.quota.size"
+
+// quotaSnapshotters lists the snapshotters kukeon hands a disk quota to. The
+// overlayfs snapshotter enforces SnapshotDiskQuotaLabel through XFS project
+// quotas on its backing filesystem; every other snapshotter would silently
+// ignore the label, so a quota on them is refused instead.
+var quotaSnapshotters = map[string]string{
+	"overlayfs": SnapshotDiskQuotaLabel,
+}
+
+// resolveDiskQuota returns the rootfs size limit a container should be
+// created with, or 0 when it declares none (a zero sizeBytes also means no
+// limit).
+func resolveDiskQuota(spec intmodel.ContainerSpec) int64 {
+	if spec.DiskQuota == nil {
+		return 0
+	}
+	return spec.DiskQuota.SizeBytes
+}
+
+// snapshotQuotaOpts builds the snapshot options that apply a sizeBytes limit
+// to a rootfs prepared on snapshotter ("" is containerd's default). A zero
+// size yields no options; a negative one, or a snapshotter absent from
+// quotaSnapshotters, is an error.
+func snapshotQuotaOpts(snapshotter string, sizeBytes int64) ([]snapshots.Opt, error) {
+	if sizeBytes == 0 {
+		return nil, nil
+	}
+	if sizeBytes < 0 {
+		return nil, fmt.Errorf("%w: got %d", internalerrdefs.ErrInvalidDiskQuota, sizeBytes)
+	}
+	if snapshotter == "" {
+		snapshotter = defaults.DefaultSnapshotter
+	}
+	label, ok := quotaSnapshotters[snapshotter]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", internalerrdefs.ErrQuotaUnsupported, snapshotter)
+	}
+	return []snapshots.Opt{
+		snapshots.WithLabels(map[string]string{label: strconv.FormatInt(sizeBytes, 10)}),
+	}, nil
+}
+
+// ensureSnapshotterAvailable queries containerd's introspection service for
+// the snapshotter plugins that initialized successfully and fails with a
+// listing of them when name is not among them. Checking up front turns the
+// opaque "snapshotter not loaded" error containerd returns mid-create into an
+// actionable message.
+func (c *client) ensureSnapshotterAvailable(ctx context.Context, name string) error {
+	resp, err := c.conn().IntrospectionService().Plugins(ctx, "type=="+plugins.SnapshotPlugin.String())
+	if err != nil {
+		return fmt.Errorf("failed to list containerd snapshotters: %w", err)
+	}
+	available := make([]string, 0, len(resp.GetPlugins()))
+	for _, p := range resp.GetPlugins() {
+		if p.GetInitErr() != nil {
+			continue
+		}
+		available = append(available, p.GetID())
+	}
+	return checkSnapshotterAvailable(name, available)
+}
+
+// checkSnapshotterAvailable reports whether name is in the available set,
+// naming the alternatives in the error when it is not.
+func checkSnapshotterAvailable(name string, available []string) error {
+	if slices.Contains(available, name) {
+		return nil
+	}
+	sorted := slices.Clone(available)
+	slices.Sort(sorted)
+	list := strings.Join(sorted, ", ")
+	if list == "" {
+		list = "none"
+	}
+	return fmt.Errorf("%w: %q (available: %s)", internalerrdefs.ErrSnapshotterUnavailable, name, list)
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/containerd/containerd/v2/core/snapshots"
+	internalerrdefs "github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+func TestCheckSnapshotterAvailable(t *testing.T) {
+	if err := checkSnapshotterAvailable("native", []string{"overlayfs", "native"}); err != nil {
+		t.Fatalf("available snapshotter: unexpected error %v", err)
+	}
+
+	err := checkSnapshotterAvailable("zfs", []string{"overlayfs", "native"})
+	if !errors.Is(err, internalerrdefs.ErrSnapshotterUnavailable) {
+		t.Fatalf("missing snapshotter: got %v, want ErrSnapshotterUnavailable", err)
+	}
+	if !strings.Contains(err.Error(), `"zfs" (available: native, overlayfs)`) {
+		t.Errorf("error %q does not list the sorted alternatives", err)
+	}
+
+	err = checkSnapshotterAvailable("zfs", nil)
+	if err == nil || !strings.Contains(err.Error(), "(available: none)") {
+		t.Errorf("no snapshotters: got %v, want an error naming none", err)
+	}
+}
+
+func TestBuildSpecsResolveSnapshotter(t *testing.T) {
+	base := intmodel.ContainerSpec{
+		ID:        "app",
+		Image:     "alpine:3.18",
+		RealmName: "r",
+		SpaceName: "s",
+		StackName: "st",
+		CellName:  "c",
+	}
+	override := base
+	override.Snapshotter = "overlayfs"
+
+	cases := []struct {
+		name string
+		spec intmodel.ContainerSpec
+		opts []BuildOption
+		want string
+	}{
+		{"no selection uses containerd default", base, nil, ""},
+		{"realm default", base, []BuildOption{WithDefaultSnapshotter("native")}, "native"},
+		{"container override wins", override, []BuildOption{WithDefaultSnapshotter("native")}, "overlayfs"},
+		{"blank realm default ignored", base, []BuildOption{WithDefaultSnapshotter("  ")}, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := BuildContainerSpec(tc.spec, tc.opts...).Snapshotter; got != tc.want {
+				t.Errorf("BuildContainerSpec Snapshotter = %q, want %q", got, tc.want)
+			}
+			if got := BuildRootContainerSpec(tc.spec, nil, tc.opts...).Snapshotter; got != tc.want {
+				t.Errorf("BuildRootContainerSpec Snapshotter = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSnapshotQuotaOpts(t *testing.T) {
+	opts, err := snapshotQuotaOpts("overlayfs", 1<<30)
+	if err != nil {
+		t.Fatalf("overlayfs quota: unexpected error %v", err)
+	}
+	var info snapshots.Info
+	for _, o := range opts {
+		if err = o(&info); err != nil {
+			t.Fatalf("apply snapshot opt: %v", err)
+		}
+	}
+	if got := info.Labels[SnapshotDiskQuotaLabel]; got != "1073741824" {
+		t.Errorf("snapshot label %s = %q, want 1073741824 (labels %v)", SnapshotDiskQuotaLabel, got, info.Labels)
+	}
+
+	if opts, err = snapshotQuotaOpts("", 1<<20); err != nil || len(opts) != 1 {
+		t.Errorf("default snapshotter: opts=%d err=%v, want one opt (containerd defaults to overlayfs)", len(opts), err)
+	}
+	if opts, err = snapshotQuotaOpts("native", 0); err != nil || opts != nil {
+		t.Errorf("no quota: opts=%v err=%v, want none", opts, err)
+	}
+	if _, err = snapshotQuotaOpts("native", 1<<20); !errors.Is(err, internalerrdefs.ErrQuotaUnsupported) {
+		t.Errorf("native quota: got %v, want ErrQuotaUnsupported", err)
+	}
+	if _, err = snapshotQuotaOpts("overlayfs", -1); !errors.Is(err, internalerrdefs.ErrInvalidDiskQuota) {
+		t.Errorf("negative quota: got %v, want ErrInvalidDiskQuota", err)
+	}
+}
+
+func TestBuildSpecsCarryDiskQuota(t *testing.T) {
+	spec := intmodel.ContainerSpec{
+		ID:        "app",
+		Image:     "alpine:3.18",
+		RealmName: "r",
+		SpaceName: "s",
+		StackName: "st",
+		CellName:  "c",
+		DiskQuota: &intmodel.ContainerDiskQuota{SizeBytes: 512 << 20},
+	}
+	if got := BuildContainerSpec(spec).DiskQuotaBytes; got != 512<<20 {
+		t.Errorf("BuildContainerSpec DiskQuotaBytes = %d, want %d", got, 512<<20)
+	}
+	if got := BuildRootContainerSpec(spec, nil).DiskQuotaBytes; got != 512<<20 {
+		t.Errorf("BuildRootContainerSpec DiskQuotaBytes = %d, want %d", got, 512<<20)
+	}
+}
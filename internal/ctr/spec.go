@@ -21,15 +21,22 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"math"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"unicode"
 
 	containerd "github.com/containerd/containerd/v2/client"
 	"github.com/containerd/containerd/v2/core/containers"
+	capability "github.com/containerd/containerd/v2/pkg/cap"
 	"github.com/containerd/containerd/v2/pkg/oci"
 	"github.com/containerd/typeurl/v2"
+	"github.com/eminwux/kukeon/internal/consts"
+	internalerrdefs "github.com/eminwux/kukeon/internal/errdefs"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 	runtimespec "github.com/opencontainers/runtime-spec/specs-go"
 )
@@ -179,6 +186,9 @@ type BuildOption func(*buildOpts)
 type buildOpts struct {
 	attachable              AttachableInjection
 	defaultMemoryLimitBytes int64
+	// defaultSnapshotter is the realm-level snapshotter applied when the
+	// container spec does not select one. See WithDefaultSnapshotter.
+	defaultSnapshotter string
 	// runPath is the daemon's RunPath, used to resolve scoped references off
 	// disk: a ContainerSecret.secretRef from its scope's secrets tree (#623)
 	// and a kind: volume VolumeMount from its scope's volumes tree (#1016).
@@ -260,10 +270,16 @@ func WithKukeonGroupGID(gid uint32) BuildOption {
 // BuildRootContainerSpec append it after securitySpecOpts to enforce that
 // order.
 func withKukeonGroupGIDSpecOpt(gid uint32) oci.SpecOpts {
+	if gid == 0 {
+		return func(context.Context, oci.Client, *containers.Container, *runtimespec.Spec) error { return nil }
+	}
+	return withAdditionalGIDSpecOpt(gid)
+}
+
+// withAdditionalGIDSpecOpt appends gid to Process.User.AdditionalGids unless
+// it is already there.
+func withAdditionalGIDSpecOpt(gid uint32) oci.SpecOpts {
 	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *runtimespec.Spec) error {
-		if gid == 0 {
-			return nil
-		}
 		if s.Process == nil {
 			return nil
 		}
@@ -358,6 +374,12 @@ func BuildContainerSpec(
 		specOpts = append(specOpts, oci.WithMounts(mounts))
 	}
 
+	// Termination-message file: a per-container host file mounted read-write
+	// at spec.terminationMessagePath, read back by the runner on task exit.
+	if mounts := terminationMessageMount(containerSpec); len(mounts) > 0 {
+		specOpts = append(specOpts, oci.WithMounts(mounts))
+	}
+
 	// Set command and args
 	if containerSpec.Command != "" {
 		args := []string{containerSpec.Command}
@@ -495,11 +517,15 @@ func BuildContainerSpec(
 	}
 
 	return ContainerSpec{
-		ID:            containerdID,
-		Image:         containerSpec.Image,
-		Labels:        labels,
-		SpecOpts:      specOpts,
-		CNIConfigPath: containerSpec.CNIConfigPath,
+		ID:              containerdID,
+		Image:           containerSpec.Image,
+		Snapshotter:     resolveSnapshotter(containerSpec, opts),
+		DiskQuotaBytes:  resolveDiskQuota(containerSpec),
+		ImagePullPolicy: containerSpec.ImagePullPolicy,
+		Platform:        containerSpec.Platform,
+		Labels:          labels,
+		SpecOpts:        specOpts,
+		CNIConfigPath:   containerSpec.CNIConfigPath,
 	}
 }
 
@@ -759,17 +785,19 @@ func tmpfsVolumeMount(v intmodel.VolumeMount) (runtimespec.Mount, bool) {
 	}, true
 }
 
-// deviceAccessRWM is the default device-cgroup access granted to a short-form
-// devices[] entry — read, write, mknod — matching Docker's --device default.
+// deviceAccessRWM is the default device-cgroup access granted to a devices[]
+// entry that names none — read, write, mknod — matching Docker's --device
+// default.
 const deviceAccessRWM = "rwm"
 
-// deviceSpecOpts builds one OCI spec option per short-form devices[] entry.
-// Each entry is a host device path (e.g. "/dev/kvm"); hostLinuxDeviceOpt stat's
-// the node at create time — against the host root when kukeond is containerized
-// (issue #1261) — and appends both a Linux.Devices entry (so the node is
-// visible in the container at the same path) and a matching
-// Linux.Resources.Devices allow rule (so open() is not denied by the default
-// deny-all device cgroup). A missing host node surfaces as a create-time error
+// deviceSpecOpts builds one OCI spec option per devices[] entry, short form
+// ("/dev/kvm") or long form ("hostPath:containerPath[:permissions]", see
+// ParseDeviceMapping). hostLinuxDeviceOpt stat's the host node at create time
+// — against the host root when kukeond is containerized (issue #1261) — and
+// appends both a Linux.Devices entry (so the node is visible in the container
+// at the container path) and a matching Linux.Resources.Devices allow rule
+// (so open() is not denied by the default deny-all device cgroup). A
+// malformed entry or a missing host node surfaces as a create-time error
 // naming the path. Empty/blank entries are skipped. Issue #1252.
 func deviceSpecOpts(devices []string) []oci.SpecOpts {
 	if len(devices) == 0 {
@@ -777,18 +805,23 @@ func deviceSpecOpts(devices []string) []oci.SpecOpts {
 	}
 	opts := make([]oci.SpecOpts, 0, len(devices))
 	for _, d := range devices {
-		path := strings.TrimSpace(d)
-		if path == "" {
+		if strings.TrimSpace(d) == "" {
 			continue
 		}
-		opts = append(opts, hostLinuxDeviceOpt(path, deviceAccessRWM))
+		m, err := ParseDeviceMapping(d)
+		if err != nil {
+			opts = append(opts, errorSpecOpt(err))
+			continue
+		}
+		opts = append(opts, hostLinuxDeviceOpt(m))
 	}
 	return opts
 }
 
 // securitySpecOpts translates the security/isolation fields on the internal
 // ContainerSpec (user, readOnlyRootFilesystem, capabilities, securityOpts,
-// tmpfs, resources) into OCI spec options.
+// seccompProfile, appArmorProfile, sysctls, oomScoreAdj, tmpfs, resources)
+// into OCI spec options.
 func securitySpecOpts(spec intmodel.ContainerSpec) []oci.SpecOpts {
 	var opts []oci.SpecOpts
 
@@ -796,32 +829,71 @@ func securitySpecOpts(spec intmodel.ContainerSpec) []oci.SpecOpts {
 		opts = append(opts, oci.WithUser(spec.User))
 	}
 
+	// Supplementary groups run after WithUser, which resets AdditionalGids
+	// to the groups the image's /etc/group grants the user.
+	if len(spec.SupplementaryGroups) > 0 {
+		if err := ValidateSupplementaryGroups(spec.SupplementaryGroups); err != nil {
+			opts = append(opts, errorSpecOpt(err))
+		} else {
+			for _, gid := range spec.SupplementaryGroups {
+				//nolint:gosec // range-checked by ValidateSupplementaryGroups above
+				opts = append(opts, withAdditionalGIDSpecOpt(uint32(gid)))
+			}
+		}
+	}
+
 	if spec.ReadOnlyRootFilesystem {
 		opts = append(opts, oci.WithRootFSReadonly())
 	}
 
 	if spec.Capabilities != nil {
-		drop := normalizeCapabilities(spec.Capabilities.Drop)
-		add := normalizeCapabilities(spec.Capabilities.Add)
-		// "ALL" is not a real capability name — containerd's
-		// WithDroppedCapabilities does a literal string-match removal, so
-		// dropping "ALL" would leave the default cap set intact. Clear the
-		// whole set instead, then layer the add list on top.
-		if containsAllCaps(drop) {
-			opts = append(opts, oci.WithCapabilities(nil))
-		} else if len(drop) > 0 {
-			opts = append(opts, oci.WithDroppedCapabilities(drop))
-		}
-		if len(add) > 0 {
-			opts = append(opts, oci.WithAddedCapabilities(add))
-		}
+		opts = append(opts, capabilitiesSpecOpts(spec.Capabilities)...)
 	}
 
 	for _, entry := range spec.SecurityOpts {
 		opts = append(opts, securityOptSpecOpt(entry))
 	}
 
-	if mounts := buildTmpfsMounts(spec.Tmpfs); len(mounts) > 0 {
+	if opt := seccompProfileSpecOpt(spec); opt != nil {
+		opts = append(opts, opt)
+	}
+	if spec.AppArmorProfile != "" {
+		opts = append(opts, appArmorProfileSpecOpt(spec.AppArmorProfile))
+	}
+
+	// NoNewPrivileges runs after SecurityOpts so the dedicated field wins
+	// over a "no-new-privileges=false" entry.
+	if spec.NoNewPrivileges {
+		opts = append(opts, func(_ context.Context, _ oci.Client, _ *containers.Container, s *runtimespec.Spec) error {
+			if s.Process == nil {
+				s.Process = &runtimespec.Process{}
+			}
+			s.Process.NoNewPrivileges = true
+			return nil
+		})
+	}
+
+	if len(spec.Sysctls) > 0 {
+		if err := ValidateSysctls(spec.Sysctls); err != nil {
+			opts = append(opts, errorSpecOpt(err))
+		} else {
+			opts = append(opts, withSysctlsSpecOpt(spec.Sysctls))
+		}
+	}
+
+	if spec.OOMScoreAdj != nil {
+		if err := ValidateOOMScoreAdj(*spec.OOMScoreAdj); err != nil {
+			opts = append(opts, errorSpecOpt(err))
+		} else {
+			opts = append(opts, withOOMScoreAdjSpecOpt(*spec.OOMScoreAdj))
+		}
+	}
+
+	tmpfs := spec.Tmpfs
+	if tmp, ok := implicitTmpTmpfs(spec); ok {
+		tmpfs = append(slices.Clone(tmpfs), tmp)
+	}
+	if mounts := buildTmpfsMounts(tmpfs); len(mounts) > 0 {
 		opts = append(opts, oci.WithMounts(mounts))
 	}
 
@@ -840,6 +912,201 @@ func securitySpecOpts(spec intmodel.ContainerSpec) []oci.SpecOpts {
 	return opts
 }
 
+// ValidateUser checks a ContainerSpec.User value: empty, "user" or
+// "user:group", where each part is either a numeric ID that fits a uint32 or
+// a name the runtime resolves from the image. A part starting with a digit,
+// "-" or "+" is numeric. Names are not resolved here.
+func ValidateUser(user string) error {
+	if user == "" {
+		return nil
+	}
+	parts := strings.Split(user, ":")
+	if len(parts) > 2 { //nolint:mnd // user[:group]
+		return fmt.Errorf("%w %q: want uid, uid:gid, or a name", internalerrdefs.ErrInvalidUser, user)
+	}
+	for _, part := range parts {
+		if part == "" || strings.ContainsFunc(part, unicode.IsSpace) {
+			return fmt.Errorf("%w %q: want uid, uid:gid, or a name", internalerrdefs.ErrInvalidUser, user)
+		}
+		if !strings.ContainsAny(part[:1], "0123456789-+") {
+			continue
+		}
+		if _, err := strconv.ParseUint(part, 10, 32); err != nil {
+			return fmt.Errorf("%w %q: %q is not a valid numeric ID", internalerrdefs.ErrInvalidUser, user, part)
+		}
+	}
+	return nil
+}
+
+// ValidateSupplementaryGroups reports an ErrInvalidGroup for every GID outside
+// the uint32 range.
+func ValidateSupplementaryGroups(groups []int) error {
+	var errs []error
+	for _, gid := range groups {
+		if gid < 0 || int64(gid) > math.MaxUint32 {
+			errs = append(errs, fmt.Errorf("%w: %d", internalerrdefs.ErrInvalidGroup, gid))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Bounds of the kernel's /proc/<pid>/oom_score_adj.
+const (
+	minOOMScoreAdj = -1000
+	maxOOMScoreAdj = 1000
+)
+
+// ValidateOOMScoreAdj reports an ErrInvalidOOMScoreAdj when score is outside
+// the kernel's -1000..1000 range.
+func ValidateOOMScoreAdj(score int) error {
+	if score < minOOMScoreAdj || score > maxOOMScoreAdj {
+		return fmt.Errorf("%w: %d is outside %d..%d",
+			internalerrdefs.ErrInvalidOOMScoreAdj, score, minOOMScoreAdj, maxOOMScoreAdj)
+	}
+	return nil
+}
+
+// ValidateTerminationMessagePath reports an ErrInvalidTermMsgPath when path
+// is set but is not an absolute, clean, non-root in-container path. Empty is
+// valid and resolves to consts.DefaultTerminationMessagePath.
+func ValidateTerminationMessagePath(path string) error {
+	if path == "" {
+		return nil
+	}
+	if !filepath.IsAbs(path) || filepath.Clean(path) != path || path == "/" {
+		return fmt.Errorf("%w: %q must be an absolute file path", internalerrdefs.ErrInvalidTermMsgPath, path)
+	}
+	return nil
+}
+
+// terminationMessageMount returns the read-write bind-mount of the
+// runner-provisioned host file at the container's terminationMessagePath.
+// Zero-length when the runner did not stamp a host path (root containers,
+// or a host file that could not be created).
+func terminationMessageMount(spec intmodel.ContainerSpec) []runtimespec.Mount {
+	if spec.TerminationMessageHostPath == "" {
+		return nil
+	}
+	dest := spec.TerminationMessagePath
+	if dest == "" {
+		dest = consts.DefaultTerminationMessagePath
+	}
+	return []runtimespec.Mount{{
+		Destination: dest,
+		Source:      spec.TerminationMessageHostPath,
+		Type:        "bind",
+		Options:     []string{"rbind", "rw"},
+	}}
+}
+
+// withOOMScoreAdjSpecOpt sets the OCI Process.OOMScoreAdj.
+func withOOMScoreAdjSpecOpt(score int) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *runtimespec.Spec) error {
+		if s.Process == nil {
+			s.Process = &runtimespec.Process{}
+		}
+		s.Process.OOMScoreAdj = &score
+		return nil
+	}
+}
+
+// withSysctlsSpecOpt merges sysctls into the OCI Linux.Sysctl map.
+func withSysctlsSpecOpt(sysctls map[string]string) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *runtimespec.Spec) error {
+		if s.Linux == nil {
+			s.Linux = &runtimespec.Linux{}
+		}
+		if s.Linux.Sysctl == nil {
+			s.Linux.Sysctl = make(map[string]string, len(sysctls))
+		}
+		maps.Copy(s.Linux.Sysctl, sysctls)
+		return nil
+	}
+}
+
+// ValidateSysctls reports an ErrInvalidSysctl for every key that is not a
+// dotted kernel parameter name (e.g. "net.core.somaxconn"). Values are passed
+// to the runtime as-is. Which keys a container may set is checked by the
+// caller, which knows whether the container owns the cell's namespaces.
+func ValidateSysctls(sysctls map[string]string) error {
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(sysctls)) {
+		if key == "" || strings.HasPrefix(key, ".") || strings.HasSuffix(key, ".") ||
+			strings.ContainsAny(key, "/=") || strings.ContainsFunc(key, unicode.IsSpace) {
+			errs = append(errs, fmt.Errorf("%w: %q is not a kernel parameter name", internalerrdefs.ErrInvalidSysctl, key))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// IsNetSysctl reports whether key names a network-namespace sysctl.
+func IsNetSysctl(key string) bool {
+	return strings.HasPrefix(key, "net.")
+}
+
+// capabilitiesSpecOpts translates ContainerSpec.Capabilities into OCI spec
+// options. Caps not named start from containerd's default set. Unknown names
+// fail at spec-apply time.
+func capabilitiesSpecOpts(caps *intmodel.ContainerCapabilities) []oci.SpecOpts {
+	if err := ValidateCapabilities(caps.Add); err != nil {
+		return []oci.SpecOpts{errorSpecOpt(err)}
+	}
+	if err := ValidateCapabilities(caps.Drop); err != nil {
+		return []oci.SpecOpts{errorSpecOpt(err)}
+	}
+
+	var opts []oci.SpecOpts
+	drop := normalizeCapabilities(caps.Drop)
+	if len(drop) > 0 {
+		opts = append(opts, withDroppedCapabilities(drop))
+	}
+	if add := normalizeCapabilities(caps.Add); len(add) > 0 {
+		opts = append(opts, oci.WithAddedCapabilities(add))
+	}
+	return opts
+}
+
+// withDroppedCapabilities removes drop from all five capability sets. "ALL" is
+// not a real capability name — containerd's WithDroppedCapabilities does a
+// literal string-match removal, so dropping "ALL" through it would leave the
+// default cap set intact — and clears every set instead. containerd's helper
+// also leaves the ambient set alone, which is why this does not wrap it.
+func withDroppedCapabilities(drop []string) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *runtimespec.Spec) error {
+		if s.Process == nil {
+			s.Process = &runtimespec.Process{}
+		}
+		if s.Process.Capabilities == nil {
+			s.Process.Capabilities = &runtimespec.LinuxCapabilities{}
+		}
+		c := s.Process.Capabilities
+		all := containsAllCaps(drop)
+		for _, set := range []*[]string{&c.Bounding, &c.Effective, &c.Permitted, &c.Inheritable, &c.Ambient} {
+			if all {
+				*set = nil
+				continue
+			}
+			*set = slices.DeleteFunc(*set, func(capName string) bool { return slices.Contains(drop, capName) })
+		}
+		return nil
+	}
+}
+
+// ValidateCapabilities reports an ErrInvalidCapability for every name in caps
+// that is neither "ALL" nor a capability the kernel knows, in any of the
+// spellings normalizeCapabilities accepts ("NET_ADMIN", "cap_net_admin").
+func ValidateCapabilities(caps []string) error {
+	var errs []error
+	for _, raw := range caps {
+		normalized := normalizeCapabilities([]string{raw})
+		if len(normalized) == 0 || containsAllCaps(normalized) || slices.Contains(capability.Known(), normalized[0]) {
+			continue
+		}
+		errs = append(errs, fmt.Errorf("%w: %q", internalerrdefs.ErrInvalidCapability, raw))
+	}
+	return errors.Join(errs...)
+}
+
 // containsAllCaps reports whether the normalized capability list names the
 // "ALL" sentinel in any of its accepted spellings.
 func containsAllCaps(caps []string) bool {
@@ -871,6 +1138,53 @@ func normalizeCapabilities(in []string) []string {
 	return out
 }
 
+// readOnlyRootTmpPath is where a read-only root filesystem gets its implicit
+// writable tmpfs.
+const readOnlyRootTmpPath = "/tmp"
+
+// implicitTmpTmpfs returns the tmpfs a read-only root filesystem gets at /tmp,
+// and false when the rootfs is writable, WritableTmp is false, or a tmpfs or
+// volume entry already targets /tmp (the explicit mount wins).
+func implicitTmpTmpfs(spec intmodel.ContainerSpec) (intmodel.ContainerTmpfsMount, bool) {
+	if !spec.ReadOnlyRootFilesystem || (spec.WritableTmp != nil && !*spec.WritableTmp) {
+		return intmodel.ContainerTmpfsMount{}, false
+	}
+	for _, t := range spec.Tmpfs {
+		if filepath.Clean(strings.TrimSpace(t.Path)) == readOnlyRootTmpPath {
+			return intmodel.ContainerTmpfsMount{}, false
+		}
+	}
+	for _, v := range spec.Volumes {
+		if filepath.Clean(strings.TrimSpace(v.Target)) == readOnlyRootTmpPath {
+			return intmodel.ContainerTmpfsMount{}, false
+		}
+	}
+	return intmodel.ContainerTmpfsMount{Path: readOnlyRootTmpPath, Options: []string{"mode=1777"}}, true
+}
+
+// HasWritableMount reports whether a container can write anywhere: its root
+// filesystem is writable, or it has a tmpfs (explicit or the implicit /tmp)
+// or a volume not mounted read-only.
+func HasWritableMount(spec intmodel.ContainerSpec) bool {
+	if !spec.ReadOnlyRootFilesystem {
+		return true
+	}
+	if _, ok := implicitTmpTmpfs(spec); ok {
+		return true
+	}
+	for _, t := range spec.Tmpfs {
+		if strings.TrimSpace(t.Path) != "" {
+			return true
+		}
+	}
+	for _, v := range spec.Volumes {
+		if !v.ReadOnly {
+			return true
+		}
+	}
+	return false
+}
+
 // buildTmpfsMounts returns OCI mounts for each declared tmpfs entry. Size is
 // emitted as the standard tmpfs "size=N" option when set.
 func buildTmpfsMounts(entries []intmodel.ContainerTmpfsMount) []runtimespec.Mount {
@@ -18,10 +18,13 @@ package ctr_test
 
 import (
 	"context"
+	"errors"
 	"os"
+	"path/filepath"
 	"testing"
 
 	ctr "github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 	runtimespec "github.com/opencontainers/runtime-spec/specs-go"
 )
@@ -137,3 +140,124 @@ func TestBuildContainerSpec_DevicesEmptySkipped(t *testing.T) {
 		t.Errorf("expected no Linux.Devices for blank-only devices[], got %+v", spec.Linux.Devices)
 	}
 }
+
+// TestBuildContainerSpec_DevicesLongForm asserts that a
+// hostPath:containerPath:permissions entry exposes the host node at the
+// container path and emits a cgroup allow rule carrying exactly the requested
+// permissions, and that it composes with a read-only root filesystem.
+func TestBuildContainerSpec_DevicesLongForm(t *testing.T) {
+	const host, inContainer = "/dev/null", "/dev/sink"
+	if _, err := os.Stat(host); err != nil {
+		t.Skipf("%s not present on test host: %v", host, err)
+	}
+
+	spec := applyBuiltSpec(t, intmodel.ContainerSpec{
+		ID:                     "c1",
+		Image:                  "registry.eminwux.com/busybox:latest",
+		CellName:               "cell",
+		SpaceName:              "space",
+		RealmName:              "realm",
+		StackName:              "stack",
+		ReadOnlyRootFilesystem: true,
+		Devices:                []string{host + ":" + inContainer + ":rw"},
+	})
+
+	if spec.Root == nil || !spec.Root.Readonly {
+		t.Errorf("Root.Readonly = %+v, want readonly=true", spec.Root)
+	}
+	if len(spec.Linux.Devices) != 1 {
+		t.Fatalf("Linux.Devices = %+v, want exactly one entry", spec.Linux.Devices)
+	}
+	node := spec.Linux.Devices[0]
+	if node.Path != inContainer || node.Type != "c" {
+		t.Errorf("device = %s (%s), want %s (c)", node.Path, node.Type, inContainer)
+	}
+	if spec.Linux.Resources == nil || len(spec.Linux.Resources.Devices) != 1 {
+		t.Fatalf("Linux.Resources = %+v, want exactly one device-cgroup rule", spec.Linux.Resources)
+	}
+	rule := spec.Linux.Resources.Devices[0]
+	if !rule.Allow || rule.Type != "c" || rule.Access != "rw" ||
+		rule.Major == nil || *rule.Major != node.Major ||
+		rule.Minor == nil || *rule.Minor != node.Minor {
+		t.Errorf("device-cgroup rule = %+v, want allow c %d:%d rw", rule, node.Major, node.Minor)
+	}
+}
+
+// TestBuildContainerSpec_DevicesRejectsNonDevice asserts that a host path
+// that exists but is not a device node fails create with ErrInvalidDevice
+// instead of being passed through as a device.
+func TestBuildContainerSpec_DevicesRejectsNonDevice(t *testing.T) {
+	regular := filepath.Join(t.TempDir(), "not-a-device")
+	if err := os.WriteFile(regular, nil, 0o600); err != nil {
+		t.Fatalf("write %s: %v", regular, err)
+	}
+
+	spec := &runtimespec.Spec{Process: &runtimespec.Process{}, Linux: &runtimespec.Linux{}}
+	built := ctr.BuildContainerSpec(intmodel.ContainerSpec{
+		ID:        "c1",
+		Image:     "registry.eminwux.com/busybox:latest",
+		CellName:  "cell",
+		SpaceName: "space",
+		RealmName: "realm",
+		StackName: "stack",
+		Devices:   []string{regular + ":/dev/fake"},
+	})
+
+	var err error
+	for _, opt := range built.SpecOpts {
+		if err = opt(context.Background(), nil, nil, spec); err != nil {
+			break
+		}
+	}
+	if !errors.Is(err, errdefs.ErrInvalidDevice) {
+		t.Fatalf("err = %v, want ErrInvalidDevice", err)
+	}
+	if len(spec.Linux.Devices) != 0 {
+		t.Errorf("Linux.Devices = %+v, want none for a rejected entry", spec.Linux.Devices)
+	}
+}
+
+func TestParseDeviceMapping(t *testing.T) {
+	tests := []struct {
+		entry   string
+		want    ctr.DeviceMapping
+		wantErr bool
+	}{
+		{entry: "/dev/kvm", want: ctr.DeviceMapping{HostPath: "/dev/kvm", ContainerPath: "/dev/kvm", Permissions: "rwm"}},
+		{entry: " /dev/kvm ", want: ctr.DeviceMapping{HostPath: "/dev/kvm", ContainerPath: "/dev/kvm", Permissions: "rwm"}},
+		{
+			entry: "/dev/ttyUSB0:/dev/ttyS0",
+			want:  ctr.DeviceMapping{HostPath: "/dev/ttyUSB0", ContainerPath: "/dev/ttyS0", Permissions: "rwm"},
+		},
+		{
+			entry: "/dev/sda:/dev/xvda:r",
+			want:  ctr.DeviceMapping{HostPath: "/dev/sda", ContainerPath: "/dev/xvda", Permissions: "r"},
+		},
+		{entry: "/dev/fuse:rw", want: ctr.DeviceMapping{HostPath: "/dev/fuse", ContainerPath: "/dev/fuse", Permissions: "rw"}},
+		{entry: "dev/kvm", wantErr: true},
+		{entry: "/dev/kvm:dev/kvm:r", wantErr: true},
+		{entry: "/dev/../kvm", wantErr: true},
+		{entry: "/", wantErr: true},
+		{entry: "/dev/kvm:/dev/kvm:", wantErr: true},
+		{entry: "/dev/kvm:rx", wantErr: true},
+		{entry: "/dev/kvm:rr", wantErr: true},
+		{entry: "/dev/kvm:/dev/kvm:r:w", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.entry, func(t *testing.T) {
+			got, err := ctr.ParseDeviceMapping(tt.entry)
+			if tt.wantErr {
+				if !errors.Is(err, errdefs.ErrInvalidDevice) {
+					t.Fatalf("err = %v, want ErrInvalidDevice", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
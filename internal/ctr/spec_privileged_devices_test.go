@@ -18,6 +18,7 @@ package ctr_test
 
 import (
 	"context"
+	"slices"
 	"testing"
 
 	ctr "github.com/eminwux/kukeon/internal/ctr"
@@ -67,6 +68,34 @@ func TestBuildContainerSpec_PrivilegedHostDevices(t *testing.T) {
 	}
 }
 
+// TestBuildContainerSpec_PrivilegedUnconfined asserts a privileged
+// container gets every capability in all sets and no seccomp filter.
+func TestBuildContainerSpec_PrivilegedUnconfined(t *testing.T) {
+	spec := applyBuiltSpec(t, intmodel.ContainerSpec{
+		ID:         "c1",
+		Image:      "registry.eminwux.com/busybox:latest",
+		CellName:   "cell",
+		SpaceName:  "space",
+		RealmName:  "realm",
+		StackName:  "stack",
+		Privileged: true,
+	})
+
+	caps := spec.Process.Capabilities
+	if caps == nil {
+		t.Fatal("privileged spec has no capabilities")
+	}
+	for _, want := range []string{"CAP_SYS_ADMIN", "CAP_NET_ADMIN", "CAP_SYS_MODULE"} {
+		if !slices.Contains(caps.Bounding, want) || !slices.Contains(caps.Effective, want) ||
+			!slices.Contains(caps.Permitted, want) {
+			t.Errorf("privileged spec missing %s: %+v", want, caps)
+		}
+	}
+	if spec.Linux.Seccomp != nil {
+		t.Errorf("privileged spec carries a seccomp profile: %+v", spec.Linux.Seccomp)
+	}
+}
+
 // TestBuildRootContainerSpec_PrivilegedHostDevices asserts the root-container
 // path gets the same allow-all rule + host-device replication. Issue #1261.
 func TestBuildRootContainerSpec_PrivilegedHostDevices(t *testing.T) {
@@ -0,0 +1,156 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ctr "github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	runtimespec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+func seccompContainer(profile string) intmodel.ContainerSpec {
+	return intmodel.ContainerSpec{
+		ID:             "c1",
+		Image:          "registry.eminwux.com/busybox:latest",
+		CellName:       "cell",
+		SpaceName:      "space",
+		RealmName:      "realm",
+		StackName:      "stack",
+		SeccompProfile: profile,
+	}
+}
+
+func writeSeccompProfile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "profile.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write profile: %v", err)
+	}
+	return path
+}
+
+func TestBuildContainerSpec_SeccompProfileDefaultsToRuntimeDefault(t *testing.T) {
+	for _, profile := range []string{"", intmodel.SeccompProfileRuntimeDefault} {
+		spec := applyBuiltSpec(t, seccompContainer(profile))
+		if spec.Linux.Seccomp == nil {
+			t.Fatalf("seccompProfile %q: Linux.Seccomp is nil, want the runtime default profile", profile)
+		}
+		if spec.Linux.Seccomp.DefaultAction != runtimespec.ActErrno {
+			t.Errorf("seccompProfile %q: DefaultAction = %q, want %q",
+				profile, spec.Linux.Seccomp.DefaultAction, runtimespec.ActErrno)
+		}
+		if len(spec.Linux.Seccomp.Syscalls) == 0 {
+			t.Errorf("seccompProfile %q: runtime default profile allows no syscalls", profile)
+		}
+	}
+}
+
+func TestBuildContainerSpec_SeccompProfileUnconfined(t *testing.T) {
+	spec := &runtimespec.Spec{
+		Process: &runtimespec.Process{},
+		Linux: &runtimespec.Linux{
+			Seccomp: &runtimespec.LinuxSeccomp{DefaultAction: runtimespec.ActErrno},
+		},
+	}
+	for _, opt := range ctr.BuildContainerSpec(seccompContainer(intmodel.SeccompProfileUnconfined)).SpecOpts {
+		if err := opt(context.Background(), nil, nil, spec); err != nil {
+			t.Fatalf("SpecOpts returned error: %v", err)
+		}
+	}
+	if spec.Linux.Seccomp != nil {
+		t.Fatalf("Linux.Seccomp = %+v, want nil for seccompProfile unconfined", spec.Linux.Seccomp)
+	}
+}
+
+func TestBuildContainerSpec_SeccompProfileCustom(t *testing.T) {
+	path := writeSeccompProfile(t, `{
+		"defaultAction": "SCMP_ACT_ALLOW",
+		"syscalls": [{"names": ["ptrace"], "action": "SCMP_ACT_ERRNO"}]
+	}`)
+	spec := applyBuiltSpec(t, seccompContainer(path))
+	if spec.Linux.Seccomp == nil {
+		t.Fatalf("Linux.Seccomp is nil, want the profile from %s", path)
+	}
+	if spec.Linux.Seccomp.DefaultAction != runtimespec.ActAllow {
+		t.Errorf("DefaultAction = %q, want %q", spec.Linux.Seccomp.DefaultAction, runtimespec.ActAllow)
+	}
+	if len(spec.Linux.Seccomp.Syscalls) != 1 || spec.Linux.Seccomp.Syscalls[0].Names[0] != "ptrace" {
+		t.Errorf("Syscalls = %+v, want the single ptrace rule", spec.Linux.Seccomp.Syscalls)
+	}
+}
+
+func TestBuildContainerSpec_SeccompProfileRejectsMalformed(t *testing.T) {
+	cases := []struct {
+		name string
+		mut  func(t *testing.T, in *intmodel.ContainerSpec)
+	}{
+		{"malformed JSON", func(t *testing.T, in *intmodel.ContainerSpec) {
+			in.SeccompProfile = writeSeccompProfile(t, `{"defaultAction": "SCMP_ACT_ERRNO",`)
+		}},
+		{"no defaultAction", func(t *testing.T, in *intmodel.ContainerSpec) {
+			in.SeccompProfile = writeSeccompProfile(t, `{"syscalls": []}`)
+		}},
+		{"missing file", func(t *testing.T, in *intmodel.ContainerSpec) {
+			in.SeccompProfile = filepath.Join(t.TempDir(), "absent.json")
+		}},
+		{"relative path", func(_ *testing.T, in *intmodel.ContainerSpec) {
+			in.SeccompProfile = "profile.json"
+		}},
+		{"seccomp securityOpt conflict", func(_ *testing.T, in *intmodel.ContainerSpec) {
+			in.SeccompProfile = intmodel.SeccompProfileUnconfined
+			in.SecurityOpts = []string{"seccomp=unconfined"}
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			in := seccompContainer("")
+			tc.mut(t, &in)
+			spec := &runtimespec.Spec{Process: &runtimespec.Process{}, Linux: &runtimespec.Linux{}}
+			var got error
+			for _, opt := range ctr.BuildContainerSpec(in).SpecOpts {
+				if err := opt(context.Background(), nil, nil, spec); err != nil {
+					got = err
+					break
+				}
+			}
+			if !errors.Is(got, errdefs.ErrInvalidSeccompProfile) {
+				t.Fatalf("SpecOpts error = %v, want ErrInvalidSeccompProfile", got)
+			}
+		})
+	}
+}
+
+func TestBuildContainerSpec_SeccompProfileDefaultSkips(t *testing.T) {
+	privileged := seccompContainer("")
+	privileged.Privileged = true
+	if spec := applyBuiltSpec(t, privileged); spec.Linux.Seccomp != nil {
+		t.Errorf("privileged container without seccompProfile carries a profile: %+v", spec.Linux.Seccomp)
+	}
+
+	unconfinedOpt := seccompContainer("")
+	unconfinedOpt.SecurityOpts = []string{"seccomp=unconfined"}
+	if spec := applyBuiltSpec(t, unconfinedOpt); spec.Linux.Seccomp != nil {
+		t.Errorf("seccomp=unconfined securityOpt overridden by the default profile: %+v", spec.Linux.Seccomp)
+	}
+}
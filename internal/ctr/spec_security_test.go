@@ -19,12 +19,15 @@ package ctr_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
 	ctr "github.com/eminwux/kukeon/internal/ctr"
+	"github.com/eminwux/kukeon/internal/errdefs"
 	intmodel "github.com/eminwux/kukeon/internal/modelhub"
 	runtimespec "github.com/opencontainers/runtime-spec/specs-go"
 )
@@ -69,6 +72,66 @@ func TestBuildContainerSpec_UserAndReadonlyRootfs(t *testing.T) {
 	}
 }
 
+func TestBuildContainerSpec_UserAndSupplementaryGroups(t *testing.T) {
+	spec := applyBuiltSpec(t, intmodel.ContainerSpec{
+		ID:                  "c1",
+		Image:               "registry.eminwux.com/busybox:latest",
+		CellName:            "cell",
+		SpaceName:           "space",
+		RealmName:           "realm",
+		StackName:           "stack",
+		User:                "1234:5678",
+		SupplementaryGroups: []int{44, 107, 44},
+	})
+
+	user := spec.Process.User
+	if user.UID != 1234 || user.GID != 5678 {
+		t.Fatalf("Process.User = %+v, want UID=1234 GID=5678", user)
+	}
+	// containerd's WithUser seeds AdditionalGids with the primary GID; the
+	// declared groups follow it, deduplicated.
+	if !slices.Equal(user.AdditionalGids, []uint32{5678, 44, 107}) {
+		t.Errorf("AdditionalGids = %v, want [5678 44 107]", user.AdditionalGids)
+	}
+}
+
+func TestBuildContainerSpec_InvalidSupplementaryGroupErrors(t *testing.T) {
+	built := ctr.BuildContainerSpec(intmodel.ContainerSpec{
+		ID:                  "c1",
+		Image:               "registry.eminwux.com/busybox:latest",
+		CellName:            "cell",
+		SpaceName:           "space",
+		RealmName:           "realm",
+		StackName:           "stack",
+		SupplementaryGroups: []int{-1},
+	})
+	spec := &runtimespec.Spec{Process: &runtimespec.Process{}, Linux: &runtimespec.Linux{}}
+	var err error
+	for _, opt := range built.SpecOpts {
+		if err = opt(context.Background(), nil, nil, spec); err != nil {
+			break
+		}
+	}
+	if !errors.Is(err, errdefs.ErrInvalidGroup) {
+		t.Fatalf("SpecOpts error = %v, want ErrInvalidGroup", err)
+	}
+}
+
+func TestValidateUser(t *testing.T) {
+	valid := []string{"", "1000", "1000:1000", "0:0", "nginx", "nginx:www-data", "app:1000"}
+	for _, user := range valid {
+		if err := ctr.ValidateUser(user); err != nil {
+			t.Errorf("ValidateUser(%q) = %v, want nil", user, err)
+		}
+	}
+	invalid := []string{":", "1000:", ":1000", "1:2:3", "-1", "1000:-5", "4294967296", "10x", "my user"}
+	for _, user := range invalid {
+		if err := ctr.ValidateUser(user); !errors.Is(err, errdefs.ErrInvalidUser) {
+			t.Errorf("ValidateUser(%q) = %v, want ErrInvalidUser", user, err)
+		}
+	}
+}
+
 func TestBuildContainerSpec_Capabilities(t *testing.T) {
 	// Pre-populate a realistic default cap set so drop-ALL has something to
 	// clear. containerd's populateDefaultUnixSpec seeds this set at
@@ -136,6 +199,89 @@ func TestBuildContainerSpec_Capabilities(t *testing.T) {
 	}
 }
 
+// TestBuildContainerSpec_DroppedCapabilitiesAllSets asserts a named drop
+// reaches every capability set, ambient and inheritable included — the two
+// containerd's own WithDroppedCapabilities leaves partly untouched.
+func TestBuildContainerSpec_DroppedCapabilitiesAllSets(t *testing.T) {
+	seeded := func() []string { return []string{"CAP_CHOWN", "CAP_NET_RAW", "CAP_KILL"} }
+	spec := &runtimespec.Spec{
+		Process: &runtimespec.Process{
+			Capabilities: &runtimespec.LinuxCapabilities{
+				Bounding:    seeded(),
+				Effective:   seeded(),
+				Permitted:   seeded(),
+				Inheritable: seeded(),
+				Ambient:     seeded(),
+			},
+		},
+		Linux: &runtimespec.Linux{},
+	}
+	built := ctr.BuildContainerSpec(intmodel.ContainerSpec{
+		ID:        "c1",
+		Image:     "registry.eminwux.com/busybox:latest",
+		CellName:  "cell",
+		SpaceName: "space",
+		RealmName: "realm",
+		StackName: "stack",
+		Capabilities: &intmodel.ContainerCapabilities{
+			Drop: []string{"net_raw", "CAP_KILL"},
+		},
+	})
+	for _, opt := range built.SpecOpts {
+		if err := opt(context.Background(), nil, nil, spec); err != nil {
+			t.Fatalf("SpecOpts returned error: %v", err)
+		}
+	}
+
+	caps := spec.Process.Capabilities
+	sets := map[string][]string{
+		"Bounding":    caps.Bounding,
+		"Effective":   caps.Effective,
+		"Permitted":   caps.Permitted,
+		"Inheritable": caps.Inheritable,
+		"Ambient":     caps.Ambient,
+	}
+	for name, set := range sets {
+		if !containsOnly(set, "CAP_CHOWN") {
+			t.Errorf("%s caps = %v, want only CAP_CHOWN", name, set)
+		}
+	}
+}
+
+func TestBuildContainerSpec_UnknownCapabilityErrors(t *testing.T) {
+	built := ctr.BuildContainerSpec(intmodel.ContainerSpec{
+		ID:        "c1",
+		Image:     "registry.eminwux.com/busybox:latest",
+		CellName:  "cell",
+		SpaceName: "space",
+		RealmName: "realm",
+		StackName: "stack",
+		Capabilities: &intmodel.ContainerCapabilities{
+			Add: []string{"NET_ADMIN", "NET_WIZARD"},
+		},
+	})
+	spec := &runtimespec.Spec{Process: &runtimespec.Process{}, Linux: &runtimespec.Linux{}}
+	var err error
+	for _, opt := range built.SpecOpts {
+		if err = opt(context.Background(), nil, nil, spec); err != nil {
+			break
+		}
+	}
+	if !errors.Is(err, errdefs.ErrInvalidCapability) || !strings.Contains(err.Error(), `"NET_WIZARD"`) {
+		t.Fatalf("SpecOpts error = %v, want ErrInvalidCapability naming NET_WIZARD", err)
+	}
+}
+
+func TestValidateCapabilities(t *testing.T) {
+	if err := ctr.ValidateCapabilities([]string{"ALL", "net_admin", "CAP_SYS_PTRACE", " "}); err != nil {
+		t.Fatalf("ValidateCapabilities(known) = %v, want nil", err)
+	}
+	err := ctr.ValidateCapabilities([]string{"CAP_BOGUS", "chown"})
+	if !errors.Is(err, errdefs.ErrInvalidCapability) || !strings.Contains(err.Error(), `"CAP_BOGUS"`) {
+		t.Fatalf("ValidateCapabilities(unknown) = %v, want ErrInvalidCapability naming CAP_BOGUS", err)
+	}
+}
+
 func TestBuildContainerSpec_TmpfsMounts(t *testing.T) {
 	spec := applyBuiltSpec(t, intmodel.ContainerSpec{
 		ID:        "c1",
@@ -170,6 +316,78 @@ func TestBuildContainerSpec_TmpfsMounts(t *testing.T) {
 	}
 }
 
+func readOnlyRootSpec() intmodel.ContainerSpec {
+	return intmodel.ContainerSpec{
+		ID:                     "c1",
+		Image:                  "registry.eminwux.com/busybox:latest",
+		CellName:               "cell",
+		SpaceName:              "space",
+		RealmName:              "realm",
+		StackName:              "stack",
+		ReadOnlyRootFilesystem: true,
+	}
+}
+
+func mountsAt(mounts []runtimespec.Mount, destination string) []runtimespec.Mount {
+	var out []runtimespec.Mount
+	for _, m := range mounts {
+		if m.Destination == destination {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func TestBuildContainerSpec_ReadOnlyRootImplicitTmp(t *testing.T) {
+	spec := applyBuiltSpec(t, readOnlyRootSpec())
+
+	if spec.Root == nil || !spec.Root.Readonly {
+		t.Fatalf("Root.Readonly = %+v, want readonly=true", spec.Root)
+	}
+	tmp := mountsAt(spec.Mounts, "/tmp")
+	if len(tmp) != 1 || tmp[0].Type != "tmpfs" {
+		t.Fatalf("/tmp mounts = %+v, want one tmpfs", tmp)
+	}
+	if !containsString(tmp[0].Options, "mode=1777") {
+		t.Errorf("/tmp tmpfs options = %v, want mode=1777", tmp[0].Options)
+	}
+}
+
+func TestBuildContainerSpec_ReadOnlyRootWritableTmpDisabled(t *testing.T) {
+	in := readOnlyRootSpec()
+	disabled := false
+	in.WritableTmp = &disabled
+	spec := applyBuiltSpec(t, in)
+
+	if tmp := mountsAt(spec.Mounts, "/tmp"); len(tmp) != 0 {
+		t.Fatalf("/tmp mounts = %+v, want none with writableTmp=false", tmp)
+	}
+	if ctr.HasWritableMount(in) {
+		t.Errorf("HasWritableMount = true, want false for a read-only rootfs with no writable mount")
+	}
+}
+
+// TestBuildContainerSpec_ReadOnlyRootComposesWithVolumes asserts a writable
+// bind mount lands on a read-only rootfs, and that a volume already covering
+// /tmp replaces the implicit tmpfs instead of stacking a second mount on it.
+func TestBuildContainerSpec_ReadOnlyRootComposesWithVolumes(t *testing.T) {
+	in := readOnlyRootSpec()
+	in.Volumes = []intmodel.VolumeMount{
+		{Source: "/srv/data", Target: "/data"},
+		{Source: "/srv/scratch", Target: "/tmp/"},
+	}
+	spec := applyBuiltSpec(t, in)
+
+	if data := mountsAt(spec.Mounts, "/data"); len(data) != 1 || data[0].Type != "bind" {
+		t.Fatalf("/data mounts = %+v, want one bind", data)
+	}
+	tmp := mountsAt(spec.Mounts, "/tmp/")
+	tmp = append(tmp, mountsAt(spec.Mounts, "/tmp")...)
+	if len(tmp) != 1 || tmp[0].Type != "bind" {
+		t.Fatalf("/tmp mounts = %+v, want only the declared bind", tmp)
+	}
+}
+
 func TestBuildContainerSpec_Resources(t *testing.T) {
 	spec := applyBuiltSpec(t, intmodel.ContainerSpec{
 		ID:        "c1",
@@ -216,6 +434,108 @@ func TestBuildContainerSpec_SecurityOptsNoNewPrivileges(t *testing.T) {
 	}
 }
 
+func TestBuildContainerSpec_NoNewPrivilegesWinsOverSecurityOpt(t *testing.T) {
+	spec := applyBuiltSpec(t, intmodel.ContainerSpec{
+		ID:              "c1",
+		Image:           "registry.eminwux.com/busybox:latest",
+		CellName:        "cell",
+		SpaceName:       "space",
+		RealmName:       "realm",
+		StackName:       "stack",
+		SecurityOpts:    []string{"no-new-privileges=false"},
+		NoNewPrivileges: true,
+	})
+	if !spec.Process.NoNewPrivileges {
+		t.Fatalf("Process.NoNewPrivileges = false, want true")
+	}
+}
+
+func TestBuildContainerSpec_Sysctls(t *testing.T) {
+	spec := applyBuiltSpec(t, intmodel.ContainerSpec{
+		ID:        "c1",
+		Image:     "registry.eminwux.com/busybox:latest",
+		CellName:  "cell",
+		SpaceName: "space",
+		RealmName: "realm",
+		StackName: "stack",
+		Root:      true,
+		Sysctls:   map[string]string{"net.core.somaxconn": "1024"},
+	})
+	if got := spec.Linux.Sysctl["net.core.somaxconn"]; got != "1024" {
+		t.Fatalf("Linux.Sysctl[net.core.somaxconn] = %q, want %q", got, "1024")
+	}
+}
+
+func TestBuildContainerSpec_InvalidSysctlErrors(t *testing.T) {
+	built := ctr.BuildContainerSpec(intmodel.ContainerSpec{
+		ID:        "c1",
+		Image:     "registry.eminwux.com/busybox:latest",
+		CellName:  "cell",
+		SpaceName: "space",
+		RealmName: "realm",
+		StackName: "stack",
+		Sysctls:   map[string]string{"net/core/somaxconn": "1024"},
+	})
+	spec := &runtimespec.Spec{Process: &runtimespec.Process{}, Linux: &runtimespec.Linux{}}
+	var err error
+	for _, opt := range built.SpecOpts {
+		if err = opt(context.Background(), nil, nil, spec); err != nil {
+			break
+		}
+	}
+	if !errors.Is(err, errdefs.ErrInvalidSysctl) {
+		t.Fatalf("SpecOpts error = %v, want ErrInvalidSysctl", err)
+	}
+}
+
+func TestBuildContainerSpec_OOMScoreAdj(t *testing.T) {
+	base := intmodel.ContainerSpec{
+		ID:        "c1",
+		Image:     "registry.eminwux.com/busybox:latest",
+		CellName:  "cell",
+		SpaceName: "space",
+		RealmName: "realm",
+		StackName: "stack",
+	}
+
+	unset := applyBuiltSpec(t, base)
+	if unset.Process.OOMScoreAdj != nil {
+		t.Fatalf("Process.OOMScoreAdj = %d, want unset", *unset.Process.OOMScoreAdj)
+	}
+
+	score := -900
+	withScore := base
+	withScore.OOMScoreAdj = &score
+	spec := applyBuiltSpec(t, withScore)
+	if spec.Process.OOMScoreAdj == nil || *spec.Process.OOMScoreAdj != score {
+		t.Fatalf("Process.OOMScoreAdj = %v, want %d", spec.Process.OOMScoreAdj, score)
+	}
+}
+
+func TestBuildContainerSpec_InvalidOOMScoreAdjErrors(t *testing.T) {
+	for _, score := range []int{-1001, 1001} {
+		built := ctr.BuildContainerSpec(intmodel.ContainerSpec{
+			ID:          "c1",
+			Image:       "registry.eminwux.com/busybox:latest",
+			CellName:    "cell",
+			SpaceName:   "space",
+			RealmName:   "realm",
+			StackName:   "stack",
+			OOMScoreAdj: &score,
+		})
+		spec := &runtimespec.Spec{Process: &runtimespec.Process{}, Linux: &runtimespec.Linux{}}
+		var err error
+		for _, opt := range built.SpecOpts {
+			if err = opt(context.Background(), nil, nil, spec); err != nil {
+				break
+			}
+		}
+		if !errors.Is(err, errdefs.ErrInvalidOOMScoreAdj) {
+			t.Fatalf("oomScoreAdj %d: SpecOpts error = %v, want ErrInvalidOOMScoreAdj", score, err)
+		}
+	}
+}
+
 func TestBuildContainerSpec_SecurityOptsSeccompUnconfined(t *testing.T) {
 	// Pre-populate Linux.Seccomp so we can observe it being cleared.
 	spec := &runtimespec.Spec{
@@ -311,3 +631,55 @@ func containsString(xs []string, want string) bool {
 func containsOnly(xs []string, want string) bool {
 	return len(xs) == 1 && xs[0] == want
 }
+
+func TestBuildContainerSpec_TerminationMessageMount(t *testing.T) {
+	base := intmodel.ContainerSpec{
+		ID:        "c1",
+		Image:     "registry.eminwux.com/busybox:latest",
+		CellName:  "cell",
+		SpaceName: "space",
+		RealmName: "realm",
+		StackName: "stack",
+	}
+	findMount := func(spec *runtimespec.Spec, dest string) *runtimespec.Mount {
+		for i := range spec.Mounts {
+			if spec.Mounts[i].Destination == dest {
+				return &spec.Mounts[i]
+			}
+		}
+		return nil
+	}
+
+	if m := findMount(applyBuiltSpec(t, base), "/dev/termination-log"); m != nil {
+		t.Fatalf("termination-log mounted without a host path: %+v", *m)
+	}
+
+	withHost := base
+	withHost.TerminationMessageHostPath = "/run/kukeon/c1/termination-log"
+	m := findMount(applyBuiltSpec(t, withHost), "/dev/termination-log")
+	if m == nil {
+		t.Fatal("default /dev/termination-log mount missing")
+	}
+	if m.Source != withHost.TerminationMessageHostPath || !slices.Contains(m.Options, "rw") {
+		t.Errorf("mount = %+v, want rw bind of %s", *m, withHost.TerminationMessageHostPath)
+	}
+
+	custom := withHost
+	custom.TerminationMessagePath = "/tmp/exit-reason"
+	if findMount(applyBuiltSpec(t, custom), "/tmp/exit-reason") == nil {
+		t.Error("custom terminationMessagePath not mounted")
+	}
+}
+
+func TestValidateTerminationMessagePath(t *testing.T) {
+	for _, path := range []string{"", "/dev/termination-log", "/tmp/reason"} {
+		if err := ctr.ValidateTerminationMessagePath(path); err != nil {
+			t.Errorf("ValidateTerminationMessagePath(%q) = %v, want nil", path, err)
+		}
+	}
+	for _, path := range []string{"relative/log", "/", "/tmp/../etc/log"} {
+		if err := ctr.ValidateTerminationMessagePath(path); !errors.Is(err, errdefs.ErrInvalidTermMsgPath) {
+			t.Errorf("ValidateTerminationMessagePath(%q) = %v, want ErrInvalidTermMsgPath", path, err)
+		}
+	}
+}
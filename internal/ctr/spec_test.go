@@ -326,6 +326,18 @@ func TestBuildContainerSpec(t *testing.T) {
 // TestBuildContainerSpec_WorkingDir verifies the OCI spec produced by
 // BuildContainerSpec sets process.cwd from ContainerSpec.WorkingDir when set,
 // and leaves it untouched when empty so the image's WORKDIR survives.
+func TestBuildContainerSpec_Platform(t *testing.T) {
+	spec := ctr.BuildContainerSpec(intmodel.ContainerSpec{
+		ID:       "test-id",
+		Image:    "registry.eminwux.com/busybox:latest",
+		Platform: "linux/arm64",
+		CellName: "c", SpaceName: "s", RealmName: "r", StackName: "st",
+	})
+	if spec.Platform != "linux/arm64" {
+		t.Errorf("Platform = %q, want %q", spec.Platform, "linux/arm64")
+	}
+}
+
 func TestBuildContainerSpec_WorkingDir(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -1018,18 +1030,28 @@ func TestBuildRootContainerSpec_CgroupsPath(t *testing.T) {
 // cell name (so `hostname` returns `kuke-app` instead of the hierarchical
 // containerd id `default_default_kuke-app_root`), and a missing CellName
 // falls back defensively to the containerd id so a misrouted spec still
-// produces a usable hostname instead of an empty one. All non-root
-// containers in the cell join this UTS namespace and inherit the value.
+// produces a usable hostname instead of an empty one. A declared
+// spec.hostname, stamped as CellHostname, takes precedence over the cell
+// name. All non-root containers in the cell join this UTS namespace and
+// inherit the value.
 func TestBuildRootContainerSpec_Hostname(t *testing.T) {
 	tests := []struct {
 		name         string
 		cellName     string
+		cellHostname string
 		containerdID string
 		wantHostname string
 	}{
 		{name: "cell name sets hostname", cellName: "kuke-app", containerdID: "s_st_kuke-app_root", wantHostname: "kuke-app"},
 		{name: "empty cell name falls back to containerd id", cellName: "", containerdID: "s_st_kuke-app_root", wantHostname: "s_st_kuke-app_root"},
 		{name: "whitespace cell name treated as empty", cellName: "   ", containerdID: "s_st_kuke-app_root", wantHostname: "s_st_kuke-app_root"},
+		{
+			name:         "declared hostname overrides cell name",
+			cellName:     "kuke-app",
+			cellHostname: "web.internal",
+			containerdID: "s_st_kuke-app_root",
+			wantHostname: "web.internal",
+		},
 	}
 
 	for _, tt := range tests {
@@ -1039,6 +1061,7 @@ func TestBuildRootContainerSpec_Hostname(t *testing.T) {
 				ContainerdID: tt.containerdID,
 				Image:        "registry.eminwux.com/busybox:latest",
 				CellName:     tt.cellName,
+				CellHostname: tt.cellHostname,
 			}, nil)
 
 			ociSpec := &runtimespec.Spec{
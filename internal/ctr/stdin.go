@@ -0,0 +1,160 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/containerd/containerd/v2/pkg/cio"
+	internalerrdefs "github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/util/logstream"
+)
+
+// ValidateStdin checks a container's openStdin, stdinOnce and stdinFile
+// fields. stdinOnce and stdinFile only make sense on a container that keeps
+// stdin open, stdinOnce needs a source to drain, and the source must be an
+// absolute host path. Attachable containers get their stdin from the
+// terminal and root containers run no workload, so neither may open it.
+func ValidateStdin(openStdin, stdinOnce bool, stdinFile string, attachable, root bool) error {
+	switch {
+	case !openStdin && (stdinOnce || stdinFile != ""):
+		return fmt.Errorf("%w: stdinOnce and stdinFile require openStdin", internalerrdefs.ErrInvalidStdin)
+	case !openStdin:
+		return nil
+	case attachable:
+		return fmt.Errorf("%w: openStdin is not supported on attachable containers", internalerrdefs.ErrInvalidStdin)
+	case root:
+		return fmt.Errorf("%w: openStdin is not supported on the root container", internalerrdefs.ErrInvalidStdin)
+	case stdinOnce && stdinFile == "":
+		return fmt.Errorf("%w: stdinOnce requires stdinFile", internalerrdefs.ErrInvalidStdin)
+	case stdinFile != "" && (!filepath.IsAbs(stdinFile) || filepath.Clean(stdinFile) != stdinFile):
+		return fmt.Errorf("%w: stdinFile %q must be an absolute, clean path", internalerrdefs.ErrInvalidStdin, stdinFile)
+	}
+	return nil
+}
+
+// inProcessStreams reports whether this process, rather than the runtime
+// shim, copies the task's output fifos into the log file.
+func (t *TaskIO) inProcessStreams() bool {
+	return t.LogFilePath != "" && (t.SeparateStreams || t.OpenStdin)
+}
+
+// logStreamsOpt wires the task's stdout and stderr fifos to the log file at
+// taskIO.LogFilePath: as stream-tagged records with SeparateStreams, as raw
+// appended bytes otherwise (the layout cio.LogFile would have written).
+// stdin is handed to the task as-is; nil leaves it unwired.
+func logStreamsOpt(taskIO *TaskIO, stdin io.Reader) (cio.Opt, error) {
+	if !taskIO.SeparateStreams {
+		out := &appendWriter{path: taskIO.LogFilePath}
+		return cio.WithStreams(stdin, out, out), nil
+	}
+	stdout, err := logstream.NewWriter(taskIO.LogFilePath, logstream.Stdout)
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := logstream.NewWriter(taskIO.LogFilePath, logstream.Stderr)
+	if err != nil {
+		return nil, err
+	}
+	return cio.WithStreams(stdin, stdout, stderr), nil
+}
+
+// stdinStreamsOpt wires the task's stdin fifo to the container's stdin
+// source and its output to the log file. The fifo is fed from
+// taskIO.StdinFile when set; once the source is drained, stdin is closed
+// with StdinOnce and otherwise held open until done is closed.
+func stdinStreamsOpt(taskIO *TaskIO, done <-chan struct{}) (cio.Opt, error) {
+	var source io.Reader = eofReader{}
+	if taskIO.StdinFile != "" {
+		// Fail the start here rather than handing the task an empty stdin.
+		if _, err := os.Stat(taskIO.StdinFile); err != nil {
+			return nil, fmt.Errorf("container stdin file: %w", err)
+		}
+		source = &lazyFileReader{path: taskIO.StdinFile}
+	}
+	if !taskIO.StdinOnce {
+		source = io.MultiReader(source, holdOpenReader{done: done})
+	}
+	return logStreamsOpt(taskIO, source)
+}
+
+// appendWriter appends raw bytes to a file, opening it per Write like
+// logstream.Writer so the log can be rotated between writes.
+type appendWriter struct {
+	path string
+}
+
+func (w *appendWriter) Write(p []byte) (int, error) {
+	f, err := os.OpenFile(w.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o640)
+	if err != nil {
+		return 0, err
+	}
+	n, err := f.Write(p)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return n, err
+}
+
+// lazyFileReader opens its file on the first Read, so a named pipe does not
+// block the task start waiting for a writer, and closes it at EOF.
+type lazyFileReader struct {
+	path string
+	f    *os.File
+	done bool
+}
+
+func (r *lazyFileReader) Read(p []byte) (int, error) {
+	if r.done {
+		return 0, io.EOF
+	}
+	if r.f == nil {
+		f, err := os.Open(r.path)
+		if err != nil {
+			r.done = true
+			return 0, err
+		}
+		r.f = f
+	}
+	n, err := r.f.Read(p)
+	if errors.Is(err, io.EOF) {
+		r.done = true
+		_ = r.f.Close()
+	}
+	return n, err
+}
+
+// holdOpenReader blocks until done is closed and then reports EOF. Placed
+// after the stdin source it keeps the task's stdin open once the source is
+// drained.
+type holdOpenReader struct {
+	done <-chan struct{}
+}
+
+func (r holdOpenReader) Read([]byte) (int, error) {
+	<-r.done
+	return 0, io.EOF
+}
+
+// eofReader is an empty stdin source.
+type eofReader struct{}
+
+func (eofReader) Read([]byte) (int, error) { return 0, io.EOF }
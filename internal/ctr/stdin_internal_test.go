@@ -0,0 +1,188 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/v2/pkg/cio"
+	internalerrdefs "github.com/eminwux/kukeon/internal/errdefs"
+)
+
+// newStdinIO builds the task IO StartContainer hands to NewTask for an
+// OpenStdin container, with its fifos in a temp dir. The test plays the
+// shim: it reads the stdin fifo and writes the stdout fifo.
+func newStdinIO(t *testing.T, taskIO *TaskIO, done <-chan struct{}) cio.IO {
+	t.Helper()
+	streams, err := stdinStreamsOpt(taskIO, done)
+	if err != nil {
+		t.Fatalf("stdinStreamsOpt() error = %v", err)
+	}
+	taskIOSet, err := cio.NewCreator(streams, cio.WithFIFODir(t.TempDir()))("c1")
+	if err != nil {
+		t.Fatalf("create task IO: %v", err)
+	}
+	t.Cleanup(func() { _ = taskIOSet.Close() })
+	return taskIOSet
+}
+
+// readStdin reads the task's stdin fifo until it is closed.
+func readStdin(t *testing.T, path string) <-chan string {
+	t.Helper()
+	got := make(chan string, 1)
+	go func() {
+		f, err := os.Open(path)
+		if err != nil {
+			got <- "open: " + err.Error()
+			return
+		}
+		defer f.Close()
+		data, err := io.ReadAll(f)
+		if err != nil {
+			got <- "read: " + err.Error()
+			return
+		}
+		got <- string(data)
+	}()
+	return got
+}
+
+func writeStdinSource(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "stdin")
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("write stdin source: %v", err)
+	}
+	return path
+}
+
+func TestStdinStreams_StdinOnceFeedsSourceAndCloses(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "c1.log")
+	taskIO := &TaskIO{
+		LogFilePath: logPath,
+		OpenStdin:   true,
+		StdinOnce:   true,
+		StdinFile:   writeStdinSource(t, "line one\nline two\n"),
+	}
+	taskIOSet := newStdinIO(t, taskIO, make(chan struct{}))
+
+	select {
+	case got := <-readStdin(t, taskIOSet.Config().Stdin):
+		if got != "line one\nline two\n" {
+			t.Fatalf("stdin = %q, want the source contents", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("stdin was not closed after the source ended")
+	}
+
+	// The output fifos are copied into the log file in-process.
+	stdout, err := os.OpenFile(taskIOSet.Config().Stdout, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("open stdout fifo: %v", err)
+	}
+	if _, err = stdout.WriteString("read 2 lines\n"); err != nil {
+		t.Fatalf("write stdout fifo: %v", err)
+	}
+	_ = stdout.Close()
+	stderr, err := os.OpenFile(taskIOSet.Config().Stderr, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("open stderr fifo: %v", err)
+	}
+	_ = stderr.Close()
+	taskIOSet.Wait()
+	if data, _ := os.ReadFile(logPath); string(data) != "read 2 lines\n" {
+		t.Errorf("log = %q, want the task's stdout", data)
+	}
+}
+
+func TestStdinStreams_HeldOpenUntilTaskExits(t *testing.T) {
+	taskIO := &TaskIO{
+		LogFilePath: filepath.Join(t.TempDir(), "c1.log"),
+		OpenStdin:   true,
+		StdinFile:   writeStdinSource(t, "payload"),
+	}
+	done := make(chan struct{})
+	taskIOSet := newStdinIO(t, taskIO, done)
+
+	got := readStdin(t, taskIOSet.Config().Stdin)
+	select {
+	case data := <-got:
+		t.Fatalf("stdin closed with %q while the task is running", data)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	close(done)
+	select {
+	case data := <-got:
+		if data != "payload" {
+			t.Fatalf("stdin = %q, want %q", data, "payload")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("stdin was not closed after the task exited")
+	}
+}
+
+func TestStdinStreams_MissingSource(t *testing.T) {
+	taskIO := &TaskIO{
+		LogFilePath: filepath.Join(t.TempDir(), "c1.log"),
+		OpenStdin:   true,
+		StdinFile:   filepath.Join(t.TempDir(), "absent"),
+	}
+	if _, err := stdinStreamsOpt(taskIO, make(chan struct{})); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("stdinStreamsOpt() error = %v, want ErrNotExist", err)
+	}
+}
+
+func TestValidateStdin(t *testing.T) {
+	tests := []struct {
+		name       string
+		openStdin  bool
+		stdinOnce  bool
+		stdinFile  string
+		attachable bool
+		root       bool
+		wantErr    bool
+	}{
+		{name: "unset"},
+		{name: "open", openStdin: true},
+		{name: "open with source", openStdin: true, stdinFile: "/srv/input"},
+		{name: "once with source", openStdin: true, stdinOnce: true, stdinFile: "/srv/input"},
+		{name: "once without open", stdinOnce: true, wantErr: true},
+		{name: "source without open", stdinFile: "/srv/input", wantErr: true},
+		{name: "once without source", openStdin: true, stdinOnce: true, wantErr: true},
+		{name: "relative source", openStdin: true, stdinFile: "input", wantErr: true},
+		{name: "unclean source", openStdin: true, stdinFile: "/srv/../input", wantErr: true},
+		{name: "attachable", openStdin: true, attachable: true, wantErr: true},
+		{name: "root", openStdin: true, root: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateStdin(tt.openStdin, tt.stdinOnce, tt.stdinFile, tt.attachable, tt.root)
+			if tt.wantErr != errors.Is(err, internalerrdefs.ErrInvalidStdin) {
+				t.Fatalf("ValidateStdin() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("ValidateStdin() error = %v", err)
+			}
+		})
+	}
+}
@@ -27,6 +27,16 @@ import (
 
 // TaskStatus returns the current status of a task.
 func (c *client) TaskStatus(namespace, id string) (containerd.Status, error) {
+	var status containerd.Status
+	err := c.withReconnect(func() error {
+		var err error
+		status, err = c.taskStatus(namespace, id)
+		return err
+	})
+	return status, err
+}
+
+func (c *client) taskStatus(namespace, id string) (containerd.Status, error) {
 	if id == "" {
 		return containerd.Status{}, errdefs.ErrEmptyContainerID
 	}
@@ -48,6 +58,16 @@ func (c *client) TaskStatus(namespace, id string) (containerd.Status, error) {
 
 // TaskMetrics returns the metrics for a task.
 func (c *client) TaskMetrics(namespace, id string) (*apitypes.Metric, error) {
+	var metrics *apitypes.Metric
+	err := c.withReconnect(func() error {
+		var err error
+		metrics, err = c.taskMetrics(namespace, id)
+		return err
+	})
+	return metrics, err
+}
+
+func (c *client) taskMetrics(namespace, id string) (*apitypes.Metric, error) {
 	if id == "" {
 		return nil, errdefs.ErrEmptyContainerID
 	}
@@ -67,6 +87,91 @@ func (c *client) TaskMetrics(namespace, id string) (*apitypes.Metric, error) {
 	return metrics, nil
 }
 
+// TaskPID returns the host PID of a container's running task. It refuses
+// with errdefs.ErrTaskNotRunning when the task exists but is not Running:
+// a stopped task's PID is stale and /proc/<pid> may already belong to an
+// unrelated process, so callers that enter the container's filesystem view
+// (kuke cp) must never act on it.
+func (c *client) TaskPID(namespace, id string) (uint32, error) {
+	var pid uint32
+	err := c.withReconnect(func() error {
+		var err error
+		pid, err = c.taskPID(namespace, id)
+		return err
+	})
+	return pid, err
+}
+
+func (c *client) taskPID(namespace, id string) (uint32, error) {
+	if id == "" {
+		return 0, errdefs.ErrEmptyContainerID
+	}
+
+	task, err := c.loadTask(namespace, id)
+	if err != nil {
+		return 0, err
+	}
+
+	nsCtx := c.namespaceCtx(namespace)
+	status, err := task.Status(nsCtx)
+	if err != nil {
+		c.logger.ErrorContext(c.ctx, "failed to get task status", "id", id, "namespace", namespace, "err", formatError(err))
+		return 0, fmt.Errorf("failed to get task status: %w", err)
+	}
+	if status.Status != containerd.Running {
+		return 0, fmt.Errorf("%w: task %q is %s", errdefs.ErrTaskNotRunning, id, status.Status)
+	}
+
+	return task.Pid(), nil
+}
+
+// ResizeProcess resizes the pseudo-terminal behind a container's task or one
+// of its exec processes. The task-level call first checks the container's
+// OCI spec and returns nil when the process was not given a terminal, so a
+// client-side SIGWINCH forwarder can call it unconditionally. Exec processes
+// are resized as-is; the runtime shim ignores a resize for a process without
+// a console.
+func (c *client) ResizeProcess(namespace, containerID, execID string, width, height uint32) error {
+	if containerID == "" {
+		return errdefs.ErrEmptyContainerID
+	}
+
+	task, err := c.loadTask(namespace, containerID)
+	if err != nil {
+		return err
+	}
+
+	nsCtx := c.namespaceCtx(namespace)
+	if execID != "" {
+		process, loadErr := task.LoadProcess(nsCtx, execID, nil)
+		if loadErr != nil {
+			return fmt.Errorf("failed to load process %q: %w", execID, loadErr)
+		}
+		if err = process.Resize(nsCtx, width, height); err != nil {
+			return fmt.Errorf("failed to resize process %q: %w", execID, err)
+		}
+		return nil
+	}
+
+	container, err := c.loadContainer(namespace, containerID)
+	if err != nil {
+		return err
+	}
+	spec, err := container.Spec(nsCtx)
+	if err != nil {
+		return fmt.Errorf("failed to load container spec: %w", err)
+	}
+	if spec.Process == nil || !spec.Process.Terminal {
+		return nil
+	}
+	if err = task.Resize(nsCtx, width, height); err != nil {
+		c.logger.ErrorContext(c.ctx, "failed to resize task", "id", containerID, "namespace", namespace,
+			"err", formatError(err))
+		return fmt.Errorf("failed to resize task: %w", err)
+	}
+	return nil
+}
+
 // ConvertContainerdStatusToContainerState converts a containerd task status to internal ContainerState.
 //
 // A stopped task is split by its exit code (#1267): a clean exit (0) maps to
@@ -0,0 +1,121 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apievents "github.com/containerd/containerd/api/events"
+	"github.com/containerd/containerd/v2/core/events"
+	"github.com/containerd/typeurl/v2"
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+// taskExitTopic is the containerd event topic published when a task's
+// process exits.
+const taskExitTopic = "/tasks/exit"
+
+// TaskExit is an init-process exit observed on containerd's event stream.
+// Exec'd processes publish on the same topic; they are dropped before a
+// TaskExit is emitted so a finished `kuke exec` never reads as the container
+// exiting.
+type TaskExit struct {
+	Namespace   string
+	ContainerID string
+	ExitCode    int
+	ExitedAt    time.Time
+}
+
+// SubscribeTaskExits streams task exits from every namespace until ctx is
+// cancelled or the subscription fails. The exits channel is closed when the
+// stream ends; a failure is delivered on the error channel first. Callers
+// re-subscribe after an error — a containerd restart drops the stream.
+func (c *client) SubscribeTaskExits(ctx context.Context) (<-chan TaskExit, <-chan error) {
+	exits := make(chan TaskExit)
+	errs := make(chan error, 1)
+
+	cClient := c.conn()
+	if cClient == nil {
+		errs <- errdefs.ErrConnectContainerd
+		close(exits)
+		return exits, errs
+	}
+
+	envelopes, subErrs := cClient.Subscribe(ctx, fmt.Sprintf("topic==%q", taskExitTopic))
+	go func() {
+		defer close(exits)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-subErrs:
+				if err != nil && ctx.Err() == nil {
+					errs <- fmt.Errorf("task exit subscription: %w", err)
+				}
+				return
+			case env, ok := <-envelopes:
+				if !ok {
+					return
+				}
+				exit, keep := c.taskExitFromEnvelope(env)
+				if !keep {
+					continue
+				}
+				select {
+				case exits <- exit:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return exits, errs
+}
+
+// taskExitFromEnvelope decodes a /tasks/exit envelope. It reports false for
+// envelopes on other topics, payloads that fail to decode, and exits of
+// exec'd processes (whose ID differs from the container ID).
+func (c *client) taskExitFromEnvelope(env *events.Envelope) (TaskExit, bool) {
+	if env == nil || env.Topic != taskExitTopic || env.Event == nil {
+		return TaskExit{}, false
+	}
+	decoded, err := typeurl.UnmarshalAny(env.Event)
+	if err != nil {
+		c.logger.DebugContext(c.ctx, "failed to decode task exit event",
+			"namespace", env.Namespace, "err", formatError(err))
+		return TaskExit{}, false
+	}
+	ev, ok := decoded.(*apievents.TaskExit)
+	if !ok || ev.GetContainerID() == "" {
+		return TaskExit{}, false
+	}
+	if ev.GetID() != "" && ev.GetID() != ev.GetContainerID() {
+		return TaskExit{}, false
+	}
+	exitedAt := env.Timestamp
+	if ts := ev.GetExitedAt(); ts != nil {
+		exitedAt = ts.AsTime()
+	}
+	return TaskExit{
+		Namespace:   env.Namespace,
+		ContainerID: ev.GetContainerID(),
+		ExitCode:    int(ev.GetExitStatus()),
+		ExitedAt:    exitedAt.UTC(),
+	}, true
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctr
+
+import (
+	"testing"
+	"time"
+
+	apievents "github.com/containerd/containerd/api/events"
+	"github.com/containerd/containerd/v2/core/events"
+	"github.com/containerd/typeurl/v2"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestTaskExitFromEnvelope(t *testing.T) {
+	exitedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	envelope := func(topic string, ev *apievents.TaskExit) *events.Envelope {
+		t.Helper()
+		payload, err := typeurl.MarshalAnyToProto(ev)
+		if err != nil {
+			t.Fatalf("marshal event: %v", err)
+		}
+		return &events.Envelope{Namespace: "main.kukeon.io", Topic: topic, Event: payload}
+	}
+
+	tests := []struct {
+		name     string
+		env      *events.Envelope
+		want     TaskExit
+		wantKeep bool
+	}{
+		{
+			name: "init process exit",
+			env: envelope(taskExitTopic, &apievents.TaskExit{
+				ContainerID: "web_app_c1_app",
+				ID:          "web_app_c1_app",
+				ExitStatus:  137,
+				ExitedAt:    timestamppb.New(exitedAt),
+			}),
+			want: TaskExit{
+				Namespace:   "main.kukeon.io",
+				ContainerID: "web_app_c1_app",
+				ExitCode:    137,
+				ExitedAt:    exitedAt,
+			},
+			wantKeep: true,
+		},
+		{
+			name: "exec process exit dropped",
+			env: envelope(taskExitTopic, &apievents.TaskExit{
+				ContainerID: "web_app_c1_app",
+				ID:          "exec-1",
+				ExitStatus:  1,
+			}),
+		},
+		{
+			name: "other topic dropped",
+			env:  envelope("/tasks/start", &apievents.TaskExit{ContainerID: "web_app_c1_app"}),
+		},
+		{name: "nil envelope dropped"},
+	}
+	c := newTestClient(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, keep := c.taskExitFromEnvelope(tt.env)
+			if keep != tt.wantKeep {
+				t.Fatalf("keep = %v, want %v", keep, tt.wantKeep)
+			}
+			if got != tt.want {
+				t.Errorf("taskExitFromEnvelope() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -45,12 +45,21 @@ type RegistryCredentials struct {
 type ContainerSpec struct {
 	// ID is the unique identifier for the container.
 	ID string
-	// Image is the image reference to use for the container.
+	// Image is the image reference to use for the container. A reference
+	// pinned with @sha256:... is verified against the pulled manifest.
 	Image string
+	// ImagePullPolicy selects when Image is pulled; see the
+	// intmodel.ImagePullPolicy* constants. Empty means IfNotPresent.
+	ImagePullPolicy string
+	// Platform is the os/arch[/variant] the image is pulled and unpacked
+	// for. Empty means the host platform.
+	Platform string
 	// SnapshotKey is the key for the snapshot. If empty, defaults to ID.
 	SnapshotKey string
 	// Snapshotter is the snapshotter to use. If empty, uses default.
 	Snapshotter string
+	// DiskQuotaBytes caps the rootfs snapshot size. Zero means no limit.
+	DiskQuotaBytes int64
 	// Runtime is the runtime configuration.
 	Runtime *ContainerRuntime
 	// SpecOpts are OCI spec options to apply.
@@ -93,6 +102,28 @@ type TaskIO struct {
 	// created. Mutually exclusive with Terminal — log files do not
 	// pair with a TTY.
 	LogFilePath string
+	// PreviousLogFilePath, alongside LogFilePath, is where the log left by
+	// the container's previous task is archived before the new task opens
+	// LogFilePath. Empty keeps appending to the existing log.
+	PreviousLogFilePath string
+	// SeparateStreams, alongside LogFilePath, keeps stdout and stderr on
+	// distinct fifos instead of handing the merged log to the shim: the
+	// client copies each fifo into LogFilePath as timestamped,
+	// stream-tagged records (see internal/util/logstream). The copy runs
+	// in this process, so a restarted daemon re-attaches to the fifos the
+	// next time StartContainer finds the task running.
+	SeparateStreams bool
+	// OpenStdin, alongside LogFilePath, gives the task a stdin fifo this
+	// process feeds: from StdinFile when set, then — unless StdinOnce —
+	// held open until the task exits. The output fifos are copied into
+	// LogFilePath in-process as with SeparateStreams, merged unless
+	// SeparateStreams is also set.
+	OpenStdin bool
+	// StdinOnce closes the task's stdin once StdinFile is drained.
+	StdinOnce bool
+	// StdinFile is the host path (regular file or named pipe) fed into the
+	// task's stdin. It is opened on the first read, after the task starts.
+	StdinFile string
 }
 
 // ContainerDeleteOptions describes options for deleting a container.
@@ -111,6 +142,14 @@ type StopContainerOptions struct {
 	Force bool
 }
 
+// ExecResult is the outcome of a command ExecProcess ran to completion
+// inside a container. Output holds the tail of its combined stdout and
+// stderr, at most execOutputLimit bytes.
+type ExecResult struct {
+	ExitCode uint32
+	Output   string
+}
+
 // NamespacePaths describes the namespace file paths a container should join.
 type NamespacePaths struct {
 	Net string
@@ -134,6 +173,7 @@ type CgroupResources struct {
 	CPU    *CPUResources
 	Memory *MemoryResources
 	IO     *IOResources
+	Pids   *PidsResources
 }
 
 // CPUResources maps to cpu*, cpuset* controllers.
@@ -154,6 +194,11 @@ type MemoryResources struct {
 	Swap *int64
 }
 
+// PidsResources maps to the pids controller.
+type PidsResources struct {
+	Max int64
+}
+
 // IOResources exposes IO weight + throttling.
 type IOResources struct {
 	Weight   uint16
@@ -185,6 +185,10 @@ func TestServer_SpaceNetworkReconcileReinstallsWipedForwardChain(t *testing.T) {
 	srv.spaceNetReconcileFn = func() (controller.SpaceNetReconcileResult, error) {
 		return controller.SpaceNetReconcileResult{}, nil
 	}
+	srv.exitWatchFn = func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}
 	// Wire the FORWARD re-assert to the real Installer over the fake runner so
 	// the startup pass exercises the genuine re-install code path.
 	srv.forwardAdmissionFn = func() error {
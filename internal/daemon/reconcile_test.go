@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/eminwux/kukeon/internal/controller"
+	"github.com/eminwux/kukeon/internal/errdefs"
 )
 
 // TestServer_ReconcileLoopFires confirms the daemon's background ticker
@@ -146,6 +147,92 @@ func TestServer_ReconcileLoopStopsWithContext(t *testing.T) {
 	}
 }
 
+// TestServer_ExitWatcherRunsWithLoop confirms the container exit watcher is
+// started alongside an enabled reconcile loop and is cancelled by Stop, so
+// core.Close never races an open event subscription.
+func TestServer_ExitWatcherRunsWithLoop(t *testing.T) {
+	srv := newTestServer(t, 10*time.Second)
+	var calls atomic.Int32
+	stopped := make(chan struct{})
+	srv.exitWatchFn = func(ctx context.Context) error {
+		calls.Add(1)
+		<-ctx.Done()
+		close(stopped)
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve() }()
+	waitForCalls(t, &calls, 1, 2*time.Second)
+
+	if err := srv.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("exit watcher was not cancelled by Stop")
+	}
+	<-done
+}
+
+// TestServer_StopDrainsInFlightReconcile simulates a SIGTERM landing in
+// the middle of a long reconcile pass: Stop must let the pass finish and
+// return cleanly within the drain window instead of tearing the
+// controller down under it.
+func TestServer_StopDrainsInFlightReconcile(t *testing.T) {
+	srv := newTestServer(t, time.Hour)
+	srv.opts.DrainTimeout = 2 * time.Second
+	started := make(chan struct{})
+	var finished atomic.Bool
+	srv.reconcileFn = func() (controller.ReconcileResult, error) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		finished.Store(true)
+		return controller.ReconcileResult{}, nil
+	}
+	startServer(t, srv)
+	<-started
+
+	begin := time.Now()
+	if err := srv.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v, want nil", err)
+	}
+	if !finished.Load() {
+		t.Error("Stop returned before the in-flight reconcile pass finished")
+	}
+	if elapsed := time.Since(begin); elapsed > srv.opts.DrainTimeout {
+		t.Errorf("Stop took %s, want within drain window %s", elapsed, srv.opts.DrainTimeout)
+	}
+}
+
+// TestServer_StopDrainTimeout pins the bounded side: a pass that outlives
+// DrainTimeout must not hold shutdown hostage — Stop gives up at the
+// deadline and surfaces ErrDrainTimeout.
+func TestServer_StopDrainTimeout(t *testing.T) {
+	srv := newTestServer(t, time.Hour)
+	srv.opts.DrainTimeout = 50 * time.Millisecond
+	started := make(chan struct{})
+	release := make(chan struct{})
+	srv.reconcileFn = func() (controller.ReconcileResult, error) {
+		close(started)
+		<-release
+		return controller.ReconcileResult{}, nil
+	}
+	startServer(t, srv)
+	t.Cleanup(func() { close(release) })
+	<-started
+
+	begin := time.Now()
+	err := srv.Stop()
+	if !errors.Is(err, errdefs.ErrDrainTimeout) {
+		t.Fatalf("Stop() error = %v, want ErrDrainTimeout", err)
+	}
+	if elapsed := time.Since(begin); elapsed > time.Second {
+		t.Errorf("Stop took %s, want it bounded by the drain timeout", elapsed)
+	}
+}
+
 func newTestServer(t *testing.T, interval time.Duration) *Server {
 	t.Helper()
 	ctx, cancel := context.WithCancel(context.Background())
@@ -172,6 +259,12 @@ func newTestServerWithCtx(t *testing.T, ctx context.Context, interval time.Durat
 	srv.spaceNetReconcileFn = func() (controller.SpaceNetReconcileResult, error) {
 		return controller.SpaceNetReconcileResult{}, nil
 	}
+	// The exit watcher starts with the loop; park it on ctx so it never
+	// dials containerd. Tests that exercise the watcher set it explicitly.
+	srv.exitWatchFn = func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}
 	return srv
 }
 
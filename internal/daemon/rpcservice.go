@@ -146,6 +146,15 @@ func (s *KukeonV1Service) GetCell(args *kukeonv1.GetCellArgs, reply *kukeonv1.Ge
 	return nil
 }
 
+func (s *KukeonV1Service) CellLiveStatus(
+	args *kukeonv1.CellLiveStatusArgs, reply *kukeonv1.CellLiveStatusReply,
+) error {
+	result, err := s.core.CellLiveStatus(s.ctx, args.Doc)
+	reply.Result = result
+	reply.Err = kukeonv1.ToAPIError(err)
+	return nil
+}
+
 func (s *KukeonV1Service) GetContainer(args *kukeonv1.GetContainerArgs, reply *kukeonv1.GetContainerReply) error {
 	result, err := s.core.GetContainer(s.ctx, args.Doc)
 	reply.Result = result
@@ -153,6 +162,15 @@ func (s *KukeonV1Service) GetContainer(args *kukeonv1.GetContainerArgs, reply *k
 	return nil
 }
 
+func (s *KukeonV1Service) InspectContainer(
+	args *kukeonv1.InspectContainerArgs, reply *kukeonv1.InspectContainerReply,
+) error {
+	result, err := s.core.InspectContainer(s.ctx, args.Doc)
+	reply.Result = result
+	reply.Err = kukeonv1.ToAPIError(err)
+	return nil
+}
+
 func (s *KukeonV1Service) GetSecret(args *kukeonv1.GetSecretArgs, reply *kukeonv1.GetSecretReply) error {
 	result, err := s.core.GetSecret(s.ctx, args.Doc)
 	reply.Result = result
@@ -245,6 +263,17 @@ func (s *KukeonV1Service) ListContainers(args *kukeonv1.ListContainersArgs, repl
 	return nil
 }
 
+func (s *KukeonV1Service) ListContainersPage(
+	args *kukeonv1.ListContainersPageArgs, reply *kukeonv1.ListContainersPageReply,
+) error {
+	result, err := s.core.ListContainersPage(
+		s.ctx, args.RealmName, args.SpaceName, args.StackName, args.CellName, args.Limit, args.Continue,
+	)
+	reply.Result = result
+	reply.Err = kukeonv1.ToAPIError(err)
+	return nil
+}
+
 func (s *KukeonV1Service) ListSecrets(args *kukeonv1.ListSecretsArgs, reply *kukeonv1.ListSecretsReply) error {
 	secrets, err := s.core.ListSecrets(s.ctx, args.RealmName, args.SpaceName, args.StackName, args.CellName)
 	reply.Secrets = secrets
@@ -427,6 +456,163 @@ func (s *KukeonV1Service) PurgeCell(args *kukeonv1.PurgeCellArgs, reply *kukeonv
 	return nil
 }
 
+// ---- Rename ----
+
+func (s *KukeonV1Service) RenameRealm(args *kukeonv1.RenameRealmArgs, reply *kukeonv1.RenameRealmReply) error {
+	result, err := s.core.RenameRealm(s.ctx, args.Doc, args.NewName)
+	reply.Result = result
+	reply.Err = kukeonv1.ToAPIError(err)
+	return nil
+}
+
+func (s *KukeonV1Service) RenameSpace(args *kukeonv1.RenameSpaceArgs, reply *kukeonv1.RenameSpaceReply) error {
+	result, err := s.core.RenameSpace(s.ctx, args.Doc, args.NewName)
+	reply.Result = result
+	reply.Err = kukeonv1.ToAPIError(err)
+	return nil
+}
+
+func (s *KukeonV1Service) RenameStack(args *kukeonv1.RenameStackArgs, reply *kukeonv1.RenameStackReply) error {
+	result, err := s.core.RenameStack(s.ctx, args.Doc, args.NewName)
+	reply.Result = result
+	reply.Err = kukeonv1.ToAPIError(err)
+	return nil
+}
+
+func (s *KukeonV1Service) RenameCell(args *kukeonv1.RenameCellArgs, reply *kukeonv1.RenameCellReply) error {
+	result, err := s.core.RenameCell(s.ctx, args.Doc, args.NewName)
+	reply.Result = result
+	reply.Err = kukeonv1.ToAPIError(err)
+	return nil
+}
+
+// ---- Scale ----
+
+func (s *KukeonV1Service) ScaleCell(args *kukeonv1.ScaleCellArgs, reply *kukeonv1.ScaleCellReply) error {
+	result, err := s.core.ScaleCell(s.ctx, args.Realm, args.Space, args.Stack, args.Template, args.Replicas)
+	reply.Result = result
+	reply.Err = kukeonv1.ToAPIError(err)
+	return nil
+}
+
+// ---- Patch ----
+
+func (s *KukeonV1Service) PatchResource(
+	args *kukeonv1.PatchResourceArgs,
+	reply *kukeonv1.PatchResourceReply,
+) error {
+	result, err := s.core.PatchResource(s.ctx, args.Ref, args.Type, args.Patch)
+	reply.Result = result
+	reply.Err = kukeonv1.ToAPIError(err)
+	return nil
+}
+
+// ---- Export ----
+
+func (s *KukeonV1Service) ExportRealm(args *kukeonv1.ExportRealmArgs, reply *kukeonv1.ExportRealmReply) error {
+	result, err := s.core.ExportRealm(s.ctx, args.Realm, args.IncludeSecrets)
+	reply.Result = result
+	reply.Err = kukeonv1.ToAPIError(err)
+	return nil
+}
+
+// ---- Orphans ----
+
+func (s *KukeonV1Service) FindOrphans(args *kukeonv1.FindOrphansArgs, reply *kukeonv1.FindOrphansReply) error {
+	result, err := s.core.FindOrphans(s.ctx, args.Realm, args.Purge)
+	reply.Result = result
+	reply.Err = kukeonv1.ToAPIError(err)
+	return nil
+}
+
+// ---- Cell usage ----
+
+func (s *KukeonV1Service) ListCellUsage(args *kukeonv1.ListCellUsageArgs, reply *kukeonv1.ListCellUsageReply) error {
+	cells, err := s.core.ListCellUsage(s.ctx, args.Realm, args.Space, args.Stack)
+	reply.Cells = cells
+	reply.Err = kukeonv1.ToAPIError(err)
+	return nil
+}
+
+// ---- Node summary ----
+
+func (s *KukeonV1Service) NodeSummary(_ *kukeonv1.NodeSummaryArgs, reply *kukeonv1.NodeSummaryReply) error {
+	result, err := s.core.NodeSummary(s.ctx)
+	reply.Result = result
+	reply.Err = kukeonv1.ToAPIError(err)
+	return nil
+}
+
+// ---- Node cordon ----
+
+func (s *KukeonV1Service) CordonNode(args *kukeonv1.CordonNodeArgs, reply *kukeonv1.NodeCordonReply) error {
+	result, err := s.core.CordonNode(s.ctx, args.Reason)
+	reply.Result = result
+	reply.Err = kukeonv1.ToAPIError(err)
+	return nil
+}
+
+func (s *KukeonV1Service) UncordonNode(_ *kukeonv1.UncordonNodeArgs, reply *kukeonv1.NodeCordonReply) error {
+	result, err := s.core.UncordonNode(s.ctx)
+	reply.Result = result
+	reply.Err = kukeonv1.ToAPIError(err)
+	return nil
+}
+
+// ---- Scope drain ----
+
+func (s *KukeonV1Service) DrainScope(args *kukeonv1.DrainScopeArgs, reply *kukeonv1.DrainScopeReply) error {
+	result, err := s.core.DrainScope(s.ctx, args.Realm, args.Space, args.Stack)
+	reply.Result = result
+	reply.Err = kukeonv1.ToAPIError(err)
+	return nil
+}
+
+func (s *KukeonV1Service) UndrainScope(args *kukeonv1.DrainScopeArgs, reply *kukeonv1.DrainScopeReply) error {
+	result, err := s.core.UndrainScope(s.ctx, args.Realm, args.Space, args.Stack)
+	reply.Result = result
+	reply.Err = kukeonv1.ToAPIError(err)
+	return nil
+}
+
+// ---- Events ----
+
+func (s *KukeonV1Service) ListEvents(args *kukeonv1.ListEventsArgs, reply *kukeonv1.ListEventsReply) error {
+	result, err := s.core.ListEvents(s.ctx, args.Filter)
+	reply.Events = result
+	reply.Err = kukeonv1.ToAPIError(err)
+	return nil
+}
+
+// ---- Quotas ----
+
+func (s *KukeonV1Service) ListQuotas(args *kukeonv1.ListQuotasArgs, reply *kukeonv1.ListQuotasReply) error {
+	result, err := s.core.ListQuotas(s.ctx, args.Realm, args.Space)
+	reply.Quotas = result
+	reply.Err = kukeonv1.ToAPIError(err)
+	return nil
+}
+
+func (s *KukeonV1Service) ImportDocuments(
+	args *kukeonv1.ImportDocumentsArgs,
+	reply *kukeonv1.ImportDocumentsReply,
+) error {
+	result, err := s.core.ImportDocuments(s.ctx, args.RawYAML, args.ContinueOnError)
+	reply.Result = result
+	reply.Err = kukeonv1.ToAPIError(err)
+	return nil
+}
+
+func (s *KukeonV1Service) RestoreBackup(
+	args *kukeonv1.RestoreBackupArgs,
+	reply *kukeonv1.RestoreBackupReply,
+) error {
+	result, err := s.core.RestoreBackup(s.ctx, args.Archive)
+	reply.Result = result
+	reply.Err = kukeonv1.ToAPIError(err)
+	return nil
+}
+
 // ---- Refresh ----
 
 func (s *KukeonV1Service) RefreshAll(_ *kukeonv1.RefreshAllArgs, reply *kukeonv1.RefreshAllReply) error {
@@ -449,15 +635,12 @@ func (s *KukeonV1Service) Ping(_ *kukeonv1.PingArgs, reply *kukeonv1.PingReply)
 // ---- Apply ----
 
 func (s *KukeonV1Service) ApplyDocuments(args *kukeonv1.ApplyDocumentsArgs, reply *kukeonv1.ApplyDocumentsReply) error {
-	var (
-		result kukeonv1.ApplyDocumentsResult
-		err    error
-	)
-	if args.Team != "" {
-		result, err = s.core.ApplyDocumentsForTeam(s.ctx, args.RawYAML, args.Team)
-	} else {
-		result, err = s.core.ApplyDocuments(s.ctx, args.RawYAML)
-	}
+	result, err := s.core.ApplyDocumentsWithOptions(s.ctx, args.RawYAML, kukeonv1.ApplyOptions{
+		Team:                 args.Team,
+		FieldManager:         args.FieldManager,
+		WaitForParentSeconds: args.WaitForParentSeconds,
+		PruneContainers:      args.PruneContainers,
+	})
 	reply.Result = result
 	reply.Err = kukeonv1.ToAPIError(err)
 	return nil
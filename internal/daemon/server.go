@@ -34,6 +34,7 @@ import (
 
 	"github.com/eminwux/kukeon/internal/client/local"
 	"github.com/eminwux/kukeon/internal/controller"
+	"github.com/eminwux/kukeon/internal/errdefs"
 	"github.com/eminwux/kukeon/internal/firewall"
 	"github.com/eminwux/kukeon/pkg/api/kukeonv1"
 )
@@ -55,6 +56,11 @@ type Options struct {
 	// loop. Zero or negative disables the loop — useful for tests and for
 	// operators who explicitly opt out via `--reconcile-interval 0`.
 	ReconcileInterval time.Duration
+	// DrainTimeout bounds how long Stop waits for an in-flight reconcile
+	// pass (and the exit watcher) to return. Zero or negative waits
+	// indefinitely. On expiry Stop leaves the controller open — closing it
+	// under a running pass would race — and returns errdefs.ErrDrainTimeout.
+	DrainTimeout time.Duration
 	// Controller is forwarded to controller.NewControllerExec.
 	Controller controller.Options
 }
@@ -86,6 +92,12 @@ type Server struct {
 	// Serve so they exercise the hook without touching iptables.
 	forwardAdmissionFn func() error
 
+	// exitWatchFn runs the container exit watcher until its context is
+	// cancelled or the event subscription fails. Defaults to
+	// core.WatchContainerExits; tests overwrite it before Serve so the
+	// watcher never dials a real containerd.
+	exitWatchFn func(ctx context.Context) error
+
 	// stopCh is closed by Stop to terminate the reconcile loop independently
 	// of s.ctx; loopWG lets Stop block until the loop exits, so core.Close
 	// never races an in-flight reconcile pass.
@@ -113,6 +125,7 @@ func NewServer(ctx context.Context, logger *slog.Logger, opts Options) *Server {
 	srv.reconcileFn = srv.core.ReconcileCells
 	srv.spaceNetReconcileFn = srv.core.ReconcileSpaceNetworks
 	srv.forwardAdmissionFn = srv.reassertForwardAdmission
+	srv.exitWatchFn = srv.core.WatchContainerExits
 	return srv
 }
 
@@ -171,6 +184,7 @@ func (s *Server) Serve() error {
 	// single bad space must not block the daemon from serving.
 	s.runSpaceNetworkReconcileOnce()
 	s.startReconcileLoop()
+	s.startExitWatcher()
 	s.acceptLoop(listener)
 	return nil
 }
@@ -257,11 +271,78 @@ func (s *Server) runReconcileLoop(interval time.Duration) {
 			return
 		case <-ticker.C:
 			s.runReconcileOnce()
+			// A shutdown requested during the cell pass must not start
+			// the Space network pass — the drain window covers only the
+			// pass already in flight.
+			if s.stopping() {
+				s.logger.InfoContext(s.ctx, "reconcile loop stopped")
+				return
+			}
 			s.runSpaceNetworkReconcileOnce()
 		}
 	}
 }
 
+// stopping reports whether Stop has been called or the daemon context is done.
+func (s *Server) stopping() bool {
+	select {
+	case <-s.stopCh:
+		return true
+	case <-s.ctx.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// exitWatchRetryDelay is how long the exit watcher waits before
+// re-subscribing after the containerd event stream drops (for example
+// across a containerd restart).
+const exitWatchRetryDelay = 5 * time.Second
+
+// startExitWatcher spawns the container exit watcher, which records task
+// exits into cell status as containerd publishes them. It runs alongside the
+// reconcile loop and is disabled with it: the periodic pass still converges
+// status on its own, the watcher only closes the gap between ticks.
+func (s *Server) startExitWatcher() {
+	if s.opts.ReconcileInterval <= 0 {
+		return
+	}
+	s.loopWG.Add(1)
+	go s.runExitWatcher()
+}
+
+func (s *Server) runExitWatcher() {
+	defer s.loopWG.Done()
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-s.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		err := s.exitWatchFn(ctx)
+		if ctx.Err() != nil {
+			s.logger.InfoContext(s.ctx, "container exit watcher stopped")
+			return
+		}
+		s.logger.WarnContext(s.ctx, "container exit watcher interrupted; resubscribing",
+			"error", err,
+			"retry_in", exitWatchRetryDelay)
+		select {
+		case <-ctx.Done():
+			s.logger.InfoContext(s.ctx, "container exit watcher stopped")
+			return
+		case <-time.After(exitWatchRetryDelay):
+		}
+	}
+}
+
 func (s *Server) runReconcileOnce() {
 	defer func() {
 		if r := recover(); r != nil {
@@ -374,19 +455,26 @@ func (s *Server) Stop() error {
 	s.listener = nil
 	s.mu.Unlock()
 
-	// Signal the reconcile loop to exit and wait for any in-flight pass to
-	// return before tearing down the controller — otherwise a tick already
-	// inside reconcileFn would race s.core.Close.
-	close(s.stopCh)
-	s.loopWG.Wait()
-
+	// Stop accepting new work first so no RPC starts while the loop drains.
 	var firstErr error
 	if listener != nil {
 		if err := listener.Close(); err != nil {
 			firstErr = err
 		}
 	}
-	if s.core != nil {
+
+	// Signal the reconcile loop to exit and wait for any in-flight pass to
+	// return before tearing down the controller — otherwise a tick already
+	// inside reconcileFn would race s.core.Close. The pass releases its own
+	// per-cell metadata locks on return.
+	close(s.stopCh)
+	drained := s.waitLoops()
+	if !drained {
+		s.logger.WarnContext(s.ctx, "reconcile pass did not drain; exiting without closing controller",
+			"drain_timeout", s.opts.DrainTimeout)
+		firstErr = errdefs.ErrDrainTimeout
+	}
+	if drained && s.core != nil {
 		if err := s.core.Close(); err != nil && firstErr == nil {
 			firstErr = err
 		}
@@ -400,6 +488,28 @@ func (s *Server) Stop() error {
 	return firstErr
 }
 
+// waitLoops blocks until the reconcile loop and exit watcher return, or
+// until DrainTimeout elapses. Reports whether they drained.
+func (s *Server) waitLoops() bool {
+	if s.opts.DrainTimeout <= 0 {
+		s.loopWG.Wait()
+		return true
+	}
+	done := make(chan struct{})
+	go func() {
+		s.loopWG.Wait()
+		close(done)
+	}()
+	timer := time.NewTimer(s.opts.DrainTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
 func (s *Server) prepareSocketDir() error {
 	dir := filepath.Dir(s.opts.SocketPath)
 	if dir == "" || dir == "." {
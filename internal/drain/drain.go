@@ -0,0 +1,260 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package drain records which scopes (a realm, a space, or a stack) are
+// drained for maintenance: their cells were stopped by `kuke drain` and the
+// reconcile loop must leave them alone until `kuke undrain`. Like the cordon
+// marker, the state is a file under runPath so the daemon and the in-process
+// `--no-daemon` path see the same answer without an RPC.
+package drain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+// MarkerFile is the basename of the drain marker written under runPath. The
+// dot prefix keeps it out of the way of realm directories, like the cordon
+// marker.
+const MarkerFile = ".kukeon-drain.json"
+
+// markerFileMode mirrors the cordon marker's mode so the kukeon group can
+// read it without world access.
+const markerFileMode os.FileMode = 0o640
+
+// CellRef names a cell that drain stopped, so undrain starts exactly those
+// cells and leaves ones the operator had stopped beforehand alone.
+type CellRef struct {
+	Realm string `json:"realm"`
+	Space string `json:"space"`
+	Stack string `json:"stack"`
+	Name  string `json:"name"`
+}
+
+// Scope is one drained scope. Space and Stack are empty when the whole realm
+// (or the whole space) is drained.
+type Scope struct {
+	Realm string    `json:"realm"`
+	Space string    `json:"space,omitempty"`
+	Stack string    `json:"stack,omitempty"`
+	Since time.Time `json:"since"`
+	// Cells lists the cells drain stopped under this scope.
+	Cells []CellRef `json:"cells,omitempty"`
+}
+
+// State is the on-disk shape of the drain marker.
+type State struct {
+	Scopes []Scope `json:"scopes"`
+}
+
+// Covers reports whether a cell in realm/space/stack lives under s.
+func (s Scope) Covers(realm, space, stack string) bool {
+	if s.Realm != realm {
+		return false
+	}
+	if s.Space != "" && s.Space != space {
+		return false
+	}
+	return s.Stack == "" || s.Stack == stack
+}
+
+// String renders the scope as realm[/space[/stack]].
+func (s Scope) String() string {
+	out := s.Realm
+	if s.Space != "" {
+		out += "/" + s.Space
+	}
+	if s.Stack != "" {
+		out += "/" + s.Stack
+	}
+	return out
+}
+
+func (s Scope) same(realm, space, stack string) bool {
+	return s.Realm == realm && s.Space == space && s.Stack == stack
+}
+
+// Path returns the absolute path of the drain marker under runPath.
+func Path(runPath string) string {
+	return filepath.Join(runPath, MarkerFile)
+}
+
+// Load reads the drain marker at runPath. A missing marker is an empty State.
+func Load(runPath string) (State, error) {
+	path := Path(runPath)
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return State{}, nil
+		}
+		return State{}, fmt.Errorf("read drain marker %q: %w", path, err)
+	}
+	var st State
+	if unmarshalErr := json.Unmarshal(raw, &st); unmarshalErr != nil {
+		return State{}, fmt.Errorf("parse drain marker %q: %w", path, unmarshalErr)
+	}
+	return st, nil
+}
+
+// Covering returns the drained scope a cell in realm/space/stack lives under,
+// if any.
+func (st State) Covering(realm, space, stack string) (Scope, bool) {
+	for _, s := range st.Scopes {
+		if s.Covers(realm, space, stack) {
+			return s, true
+		}
+	}
+	return Scope{}, false
+}
+
+// Drain records realm/space/stack as drained and returns the stored scope.
+// Draining an already drained scope keeps its Since and reports
+// changed=false.
+func Drain(runPath, realm, space, stack string, now time.Time) (Scope, bool, error) {
+	st, err := Load(runPath)
+	if err != nil {
+		return Scope{}, false, err
+	}
+	for _, s := range st.Scopes {
+		if s.same(realm, space, stack) {
+			return s, false, nil
+		}
+	}
+	s := Scope{Realm: realm, Space: space, Stack: stack, Since: now.UTC()}
+	st.Scopes = append(st.Scopes, s)
+	if err = write(runPath, st); err != nil {
+		return Scope{}, false, err
+	}
+	return s, true, nil
+}
+
+// RecordCells appends cells to the drained scope realm/space/stack, skipping
+// ones already recorded.
+func RecordCells(runPath, realm, space, stack string, cells []CellRef) error {
+	if len(cells) == 0 {
+		return nil
+	}
+	st, err := Load(runPath)
+	if err != nil {
+		return err
+	}
+	for i := range st.Scopes {
+		if !st.Scopes[i].same(realm, space, stack) {
+			continue
+		}
+		for _, c := range cells {
+			if !containsCell(st.Scopes[i].Cells, c) {
+				st.Scopes[i].Cells = append(st.Scopes[i].Cells, c)
+			}
+		}
+		return write(runPath, st)
+	}
+	return fmt.Errorf("%w: scope %s is not drained", errdefs.ErrScopeNotDrained,
+		Scope{Realm: realm, Space: space, Stack: stack})
+}
+
+// Undrain removes the drained scope realm/space/stack and returns it.
+// Reports changed=false when the scope was not drained.
+func Undrain(runPath, realm, space, stack string) (Scope, bool, error) {
+	st, err := Load(runPath)
+	if err != nil {
+		return Scope{}, false, err
+	}
+	for i, s := range st.Scopes {
+		if !s.same(realm, space, stack) {
+			continue
+		}
+		st.Scopes = append(st.Scopes[:i], st.Scopes[i+1:]...)
+		if len(st.Scopes) == 0 {
+			if rmErr := os.Remove(Path(runPath)); rmErr != nil && !errors.Is(rmErr, os.ErrNotExist) {
+				return Scope{}, false, fmt.Errorf("remove drain marker %q: %w", Path(runPath), rmErr)
+			}
+			return s, true, nil
+		}
+		if err = write(runPath, st); err != nil {
+			return Scope{}, false, err
+		}
+		return s, true, nil
+	}
+	return Scope{}, false, nil
+}
+
+// Guard returns an errdefs.ErrScopeDrained-wrapped error naming what was
+// refused when a cell in realm/space/stack lives under a drained scope, and
+// nil otherwise. An unreadable marker fails closed.
+func Guard(runPath, realm, space, stack, what string) error {
+	st, err := Load(runPath)
+	if err != nil {
+		return fmt.Errorf("%w: refusing to %s: %w", errdefs.ErrScopeDrained, what, err)
+	}
+	s, drained := st.Covering(realm, space, stack)
+	if !drained {
+		return nil
+	}
+	return fmt.Errorf("%w: refusing to %s (%s drained since %s); run `kuke undrain` to allow it",
+		errdefs.ErrScopeDrained, what, s, s.Since.Format(time.RFC3339))
+}
+
+func containsCell(cells []CellRef, c CellRef) bool {
+	for _, existing := range cells {
+		if existing == c {
+			return true
+		}
+	}
+	return false
+}
+
+// write persists st atomically (temp file + rename), matching the cordon
+// marker.
+func write(runPath string, st State) error {
+	raw, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal drain marker: %w", err)
+	}
+	raw = append(raw, '\n')
+
+	if mkErr := os.MkdirAll(runPath, 0o750); mkErr != nil {
+		return fmt.Errorf("create runPath %q: %w", runPath, mkErr)
+	}
+	tmp, err := os.CreateTemp(runPath, ".kukeon-drain-*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file under %q: %w", runPath, err)
+	}
+	tmpPath := tmp.Name()
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+	}()
+	if err = tmp.Chmod(markerFileMode); err != nil {
+		return fmt.Errorf("chmod %q: %w", tmpPath, err)
+	}
+	if _, err = tmp.Write(raw); err != nil {
+		return fmt.Errorf("write %q: %w", tmpPath, err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("close %q: %w", tmpPath, err)
+	}
+	if err = os.Rename(tmpPath, Path(runPath)); err != nil {
+		return fmt.Errorf("rename %q -> %q: %w", tmpPath, Path(runPath), err)
+	}
+	return nil
+}
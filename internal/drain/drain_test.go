@@ -0,0 +1,122 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package drain_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eminwux/kukeon/internal/drain"
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+func TestDrainUndrainRoundTrip(t *testing.T) {
+	runPath := t.TempDir()
+	since := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	s, changed, err := drain.Drain(runPath, "r1", "s1", "", since)
+	if err != nil || !changed {
+		t.Fatalf("Drain: changed=%v err=%v, want true, nil", changed, err)
+	}
+	if !s.Since.Equal(since) || s.String() != "r1/s1" {
+		t.Errorf("Drain scope = %+v", s)
+	}
+
+	again, changed, err := drain.Drain(runPath, "r1", "s1", "", since.Add(time.Hour))
+	if err != nil || changed || !again.Since.Equal(since) {
+		t.Errorf("re-Drain: scope=%+v changed=%v err=%v, want original Since unchanged", again, changed, err)
+	}
+
+	cells := []drain.CellRef{{Realm: "r1", Space: "s1", Stack: "st1", Name: "a"}}
+	for range 2 {
+		if err = drain.RecordCells(runPath, "r1", "s1", "", cells); err != nil {
+			t.Fatalf("RecordCells: %v", err)
+		}
+	}
+	st, err := drain.Load(runPath)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(st.Scopes) != 1 || len(st.Scopes[0].Cells) != 1 {
+		t.Errorf("state = %+v, want one scope with one deduplicated cell", st)
+	}
+
+	got, changed, err := drain.Undrain(runPath, "r1", "s1", "")
+	if err != nil || !changed || len(got.Cells) != 1 {
+		t.Fatalf("Undrain: scope=%+v changed=%v err=%v", got, changed, err)
+	}
+	if _, statErr := os.Stat(drain.Path(runPath)); !errors.Is(statErr, os.ErrNotExist) {
+		t.Errorf("marker still present after last undrain: %v", statErr)
+	}
+	if _, changed, err = drain.Undrain(runPath, "r1", "s1", ""); err != nil || changed {
+		t.Errorf("second Undrain: changed=%v err=%v, want false, nil", changed, err)
+	}
+}
+
+func TestScopeCovers(t *testing.T) {
+	tests := []struct {
+		scope               drain.Scope
+		realm, space, stack string
+		want                bool
+	}{
+		{drain.Scope{Realm: "r1"}, "r1", "s1", "st1", true},
+		{drain.Scope{Realm: "r1"}, "r2", "s1", "st1", false},
+		{drain.Scope{Realm: "r1", Space: "s1"}, "r1", "s1", "st9", true},
+		{drain.Scope{Realm: "r1", Space: "s1"}, "r1", "s2", "st1", false},
+		{drain.Scope{Realm: "r1", Space: "s1", Stack: "st1"}, "r1", "s1", "st1", true},
+		{drain.Scope{Realm: "r1", Space: "s1", Stack: "st1"}, "r1", "s1", "st2", false},
+	}
+	for _, tt := range tests {
+		if got := tt.scope.Covers(tt.realm, tt.space, tt.stack); got != tt.want {
+			t.Errorf("%s.Covers(%s/%s/%s) = %v, want %v", tt.scope, tt.realm, tt.space, tt.stack, got, tt.want)
+		}
+	}
+}
+
+func TestRecordCellsRequiresDrainedScope(t *testing.T) {
+	err := drain.RecordCells(t.TempDir(), "r1", "", "", []drain.CellRef{{Realm: "r1", Name: "a"}})
+	if !errors.Is(err, errdefs.ErrScopeNotDrained) {
+		t.Fatalf("err = %v, want ErrScopeNotDrained", err)
+	}
+}
+
+func TestGuard(t *testing.T) {
+	runPath := t.TempDir()
+	if err := drain.Guard(runPath, "r1", "s1", "st1", "start cell"); err != nil {
+		t.Fatalf("Guard with no marker: %v", err)
+	}
+	if _, _, err := drain.Drain(runPath, "r1", "s1", "st1", time.Now()); err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	if err := drain.Guard(runPath, "r1", "s1", "st2", "start cell"); err != nil {
+		t.Errorf("Guard on sibling stack: %v", err)
+	}
+	err := drain.Guard(runPath, "r1", "s1", "st1", "start cell")
+	if !errors.Is(err, errdefs.ErrScopeDrained) || !strings.Contains(err.Error(), "kuke undrain") {
+		t.Errorf("Guard = %v, want ErrScopeDrained pointing at kuke undrain", err)
+	}
+
+	if err = os.WriteFile(drain.Path(runPath), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err = drain.Guard(runPath, "r9", "", "", "start cell"); !errors.Is(err, errdefs.ErrScopeDrained) {
+		t.Errorf("Guard with corrupt marker = %v, want fail-closed ErrScopeDrained", err)
+	}
+}
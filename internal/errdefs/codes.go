@@ -0,0 +1,325 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errdefs
+
+// Code is the stable, machine-readable name of an errdefs sentinel. Codes
+// never change once published, so scripts and API clients can branch on
+// them where the human-readable message is free to be reworded.
+type Code string
+
+// CodeUnknown is what CodeOf returns for an error that wraps no registered
+// sentinel.
+const CodeUnknown Code = "UNKNOWN"
+
+// Not found.
+const (
+	CodeRealmNotFound     Code = "REALM_NOT_FOUND"
+	CodeSpaceNotFound     Code = "SPACE_NOT_FOUND"
+	CodeStackNotFound     Code = "STACK_NOT_FOUND"
+	CodeCellNotFound      Code = "CELL_NOT_FOUND"
+	CodeContainerNotFound Code = "CONTAINER_NOT_FOUND"
+	CodeNetworkNotFound   Code = "NETWORK_NOT_FOUND"
+	CodeImageNotFound     Code = "IMAGE_NOT_FOUND"
+	CodeSecretNotFound    Code = "SECRET_NOT_FOUND"
+	CodeBlueprintNotFound Code = "BLUEPRINT_NOT_FOUND"
+	CodeConfigNotFound    Code = "CONFIG_NOT_FOUND"
+	CodeVolumeNotFound    Code = "VOLUME_NOT_FOUND"
+	CodeTaskNotFound      Code = "TASK_NOT_FOUND"
+	CodeResourceNotFound  Code = "RESOURCE_NOT_FOUND"
+)
+
+// Invalid input.
+const (
+	CodeRealmNameRequired      Code = "REALM_NAME_REQUIRED"
+	CodeSpaceNameRequired      Code = "SPACE_NAME_REQUIRED"
+	CodeStackNameRequired      Code = "STACK_NAME_REQUIRED"
+	CodeCellNameRequired       Code = "CELL_NAME_REQUIRED"
+	CodeContainerNameRequired  Code = "CONTAINER_NAME_REQUIRED"
+	CodeInvalidName            Code = "INVALID_NAME"
+	CodeInvalidRealmName       Code = "INVALID_REALM_NAME"
+	CodeInvalidImage           Code = "INVALID_IMAGE"
+	CodeInvalidPlatform        Code = "INVALID_PLATFORM"
+	CodeInvalidDevice          Code = "INVALID_DEVICE"
+	CodeInvalidSeccompProfile  Code = "INVALID_SECCOMP_PROFILE"
+	CodeInvalidAppArmorProfile Code = "INVALID_APPARMOR_PROFILE"
+	CodeInvalidStdin           Code = "INVALID_STDIN"
+	CodeInvalidHostname        Code = "INVALID_HOSTNAME"
+	CodeInvalidExtraHost       Code = "INVALID_EXTRA_HOST"
+	CodeCellValidation         Code = "CELL_VALIDATION"
+	CodeManifestInvalid        Code = "MANIFEST_INVALID"
+	CodeBlueprintInvalid       Code = "BLUEPRINT_INVALID"
+	CodeUnknownKind            Code = "UNKNOWN_KIND"
+	CodeUnsupportedAPIVersion  Code = "UNSUPPORTED_API_VERSION"
+	CodeConversionFailed       Code = "CONVERSION_FAILED"
+	CodeInvalidPatch           Code = "INVALID_PATCH"
+	CodeImmutableField         Code = "IMMUTABLE_FIELD"
+	CodePatchUnsupportedKind   Code = "PATCH_UNSUPPORTED_KIND"
+	CodeInvalidContinueToken   Code = "INVALID_CONTINUE_TOKEN"
+	CodeInvalidTimeout         Code = "INVALID_TIMEOUT"
+	CodeInvalidSortBy          Code = "INVALID_SORT_BY"
+	CodeInvalidFieldSelector   Code = "INVALID_FIELD_SELECTOR"
+	CodeInvalidBackup          Code = "INVALID_BACKUP"
+	CodeInvalidLabelColumns    Code = "INVALID_LABEL_COLUMNS"
+	CodeInvalidCustomColumns   Code = "INVALID_CUSTOM_COLUMNS"
+	CodeInvalidChunkFlags      Code = "INVALID_CHUNK_FLAGS"
+	CodeSelectorWithName       Code = "SELECTOR_WITH_NAME"
+	CodeFieldSelectorWithName  Code = "FIELD_SELECTOR_WITH_NAME"
+	CodeAllWithScope           Code = "ALL_WITH_SCOPE"
+	CodeQuietWithOutput        Code = "QUIET_WITH_OUTPUT"
+)
+
+// Conflicts with existing state.
+const (
+	CodeResourceHasDependencies Code = "RESOURCE_HAS_DEPENDENCIES"
+	CodeContainerExists         Code = "CONTAINER_EXISTS"
+	CodeConfigExists            Code = "CONFIG_EXISTS"
+	CodeNamespaceAlreadyExists  Code = "NAMESPACE_ALREADY_EXISTS"
+	CodeNetworkAlreadyExists    Code = "NETWORK_ALREADY_EXISTS"
+	CodeRealmNamespaceInUse     Code = "REALM_NAMESPACE_IN_USE"
+	CodeVolumeInUse             Code = "VOLUME_IN_USE"
+	CodeSecretInUse             Code = "SECRET_IN_USE"
+	CodeRenameTargetExists      Code = "RENAME_TARGET_EXISTS"
+	CodeRenameCellRunning       Code = "RENAME_CELL_RUNNING"
+	CodeScaffoldFileExists      Code = "SCAFFOLD_FILE_EXISTS"
+	CodeStaleResource           Code = "STALE_RESOURCE"
+)
+
+// Not in a state that allows the operation.
+const (
+	CodeNodeCordoned         Code = "NODE_CORDONED"
+	CodeScopeDrained         Code = "SCOPE_DRAINED"
+	CodeScopeNotDrained      Code = "SCOPE_NOT_DRAINED"
+	CodeDiskPressure         Code = "DISK_PRESSURE"
+	CodeParentNotReady       Code = "PARENT_NOT_READY"
+	CodeParentWaitTimeout    Code = "PARENT_WAIT_TIMEOUT"
+	CodePrivilegedNotAllowed Code = "PRIVILEGED_NOT_ALLOWED"
+	CodeQuotaExceeded        Code = "QUOTA_EXCEEDED"
+	CodeCellNotReady         Code = "CELL_NOT_READY"
+	CodeTaskNotRunning       Code = "TASK_NOT_RUNNING"
+	CodeAttachTaskNotRunning Code = "ATTACH_TASK_NOT_RUNNING"
+)
+
+// Host and runtime.
+const (
+	CodeConnectContainerd     Code = "CONNECT_CONTAINERD"
+	CodeDaemonUnreachable     Code = "DAEMON_UNREACHABLE"
+	CodeCommandTimeout        Code = "COMMAND_TIMEOUT"
+	CodeMustRunAsRoot         Code = "MUST_RUN_AS_ROOT"
+	CodeHostNotInitialized    Code = "HOST_NOT_INITIALIZED"
+	CodePreflightFailed       Code = "PREFLIGHT_FAILED"
+	CodePauseImageUnavailable Code = "PAUSE_IMAGE_UNAVAILABLE"
+)
+
+// Operation failures.
+const (
+	CodeCreateRealm         Code = "CREATE_REALM"
+	CodeCreateSpace         Code = "CREATE_SPACE"
+	CodeCreateStack         Code = "CREATE_STACK"
+	CodeCreateCell          Code = "CREATE_CELL"
+	CodeDeleteRealm         Code = "DELETE_REALM"
+	CodeDeleteSpace         Code = "DELETE_SPACE"
+	CodeDeleteStack         Code = "DELETE_STACK"
+	CodeDeleteCell          Code = "DELETE_CELL"
+	CodeCellHookFailed      Code = "CELL_HOOK_FAILED"
+	CodeContainerHookFailed Code = "CONTAINER_HOOK_FAILED"
+)
+
+// codeEntry pairs a sentinel with its code.
+type codeEntry struct {
+	err  error
+	code Code
+}
+
+// codeTable is the sentinel → code registry. A sentinel missing here
+// resolves to the code of whatever registered sentinel it is wrapped with,
+// or CodeUnknown.
+//
+//nolint:gochecknoglobals // read-only registry
+var codeTable = []codeEntry{
+	{ErrRealmNotFound, CodeRealmNotFound},
+	{ErrSpaceNotFound, CodeSpaceNotFound},
+	{ErrStackNotFound, CodeStackNotFound},
+	{ErrCellNotFound, CodeCellNotFound},
+	{ErrContainerNotFound, CodeContainerNotFound},
+	{ErrNetworkNotFound, CodeNetworkNotFound},
+	{ErrImageNotFound, CodeImageNotFound},
+	{ErrSecretNotFound, CodeSecretNotFound},
+	{ErrBlueprintNotFound, CodeBlueprintNotFound},
+	{ErrConfigNotFound, CodeConfigNotFound},
+	{ErrVolumeNotFound, CodeVolumeNotFound},
+	{ErrTaskNotFound, CodeTaskNotFound},
+	{ErrDeleteResourceNotFound, CodeResourceNotFound},
+
+	{ErrRealmNameRequired, CodeRealmNameRequired},
+	{ErrSpaceNameRequired, CodeSpaceNameRequired},
+	{ErrStackNameRequired, CodeStackNameRequired},
+	{ErrCellNameRequired, CodeCellNameRequired},
+	{ErrContainerNameRequired, CodeContainerNameRequired},
+	{ErrInvalidName, CodeInvalidName},
+	{ErrInvalidRealmName, CodeInvalidRealmName},
+	{ErrInvalidImage, CodeInvalidImage},
+	{ErrInvalidPlatform, CodeInvalidPlatform},
+	{ErrInvalidDevice, CodeInvalidDevice},
+	{ErrInvalidSeccompProfile, CodeInvalidSeccompProfile},
+	{ErrInvalidAppArmorProfile, CodeInvalidAppArmorProfile},
+	{ErrInvalidStdin, CodeInvalidStdin},
+	{ErrInvalidHostname, CodeInvalidHostname},
+	{ErrInvalidExtraHost, CodeInvalidExtraHost},
+	{ErrCellValidation, CodeCellValidation},
+	{ErrManifestInvalid, CodeManifestInvalid},
+	{ErrBlueprintInvalid, CodeBlueprintInvalid},
+	{ErrUnknownKind, CodeUnknownKind},
+	{ErrUnsupportedAPIVersion, CodeUnsupportedAPIVersion},
+	{ErrConversionFailed, CodeConversionFailed},
+	{ErrInvalidPatch, CodeInvalidPatch},
+	{ErrImmutableField, CodeImmutableField},
+	{ErrPatchUnsupportedKind, CodePatchUnsupportedKind},
+	{ErrInvalidContinueToken, CodeInvalidContinueToken},
+	{ErrInvalidTimeout, CodeInvalidTimeout},
+	{ErrInvalidSortBy, CodeInvalidSortBy},
+	{ErrInvalidFieldSelector, CodeInvalidFieldSelector},
+	{ErrInvalidBackup, CodeInvalidBackup},
+	{ErrInvalidLabelColumns, CodeInvalidLabelColumns},
+	{ErrInvalidCustomColumns, CodeInvalidCustomColumns},
+	{ErrInvalidChunkFlags, CodeInvalidChunkFlags},
+	{ErrSelectorWithName, CodeSelectorWithName},
+	{ErrFieldSelectorWithName, CodeFieldSelectorWithName},
+	{ErrAllWithScope, CodeAllWithScope},
+	{ErrQuietWithOutput, CodeQuietWithOutput},
+
+	{ErrResourceHasDependencies, CodeResourceHasDependencies},
+	{ErrContainerExists, CodeContainerExists},
+	{ErrConfigExists, CodeConfigExists},
+	{ErrNamespaceAlreadyExists, CodeNamespaceAlreadyExists},
+	{ErrNetworkAlreadyExists, CodeNetworkAlreadyExists},
+	{ErrRealmNamespaceInUse, CodeRealmNamespaceInUse},
+	{ErrVolumeInUse, CodeVolumeInUse},
+	{ErrSecretInUse, CodeSecretInUse},
+	{ErrRenameTargetExists, CodeRenameTargetExists},
+	{ErrRenameCellRunning, CodeRenameCellRunning},
+	{ErrScaffoldFileExists, CodeScaffoldFileExists},
+	{ErrStaleResource, CodeStaleResource},
+
+	{ErrNodeCordoned, CodeNodeCordoned},
+	{ErrScopeDrained, CodeScopeDrained},
+	{ErrScopeNotDrained, CodeScopeNotDrained},
+	{ErrDiskPressure, CodeDiskPressure},
+	{ErrParentNotReady, CodeParentNotReady},
+	{ErrParentWaitTimeout, CodeParentWaitTimeout},
+	{ErrPrivilegedNotAllowed, CodePrivilegedNotAllowed},
+	{ErrQuotaExceeded, CodeQuotaExceeded},
+	{ErrCellNotReady, CodeCellNotReady},
+	{ErrTaskNotRunning, CodeTaskNotRunning},
+	{ErrAttachTaskNotRunning, CodeAttachTaskNotRunning},
+
+	{ErrConnectContainerd, CodeConnectContainerd},
+	{ErrDaemonUnreachable, CodeDaemonUnreachable},
+	{ErrCommandTimeout, CodeCommandTimeout},
+	{ErrMustRunAsRoot, CodeMustRunAsRoot},
+	{ErrHostNotInitialized, CodeHostNotInitialized},
+	{ErrPreflightFailed, CodePreflightFailed},
+	{ErrPauseImageUnavailable, CodePauseImageUnavailable},
+
+	{ErrCreateRealm, CodeCreateRealm},
+	{ErrCreateSpace, CodeCreateSpace},
+	{ErrCreateStack, CodeCreateStack},
+	{ErrCreateCell, CodeCreateCell},
+	{ErrDeleteRealm, CodeDeleteRealm},
+	{ErrDeleteSpace, CodeDeleteSpace},
+	{ErrDeleteStack, CodeDeleteStack},
+	{ErrDeleteCell, CodeDeleteCell},
+	{ErrCellHookFailed, CodeCellHookFailed},
+	{ErrContainerHookFailed, CodeContainerHookFailed},
+}
+
+// CodeOf returns the code of the outermost registered sentinel in err's
+// chain, walking wrapped errors (including multi-%w and errors.Join
+// branches) depth-first in order. The outermost sentinel is the one that
+// names what failed — `ErrCreateCell: ErrStackNotFound` is CREATE_CELL —
+// so the answer does not depend on map iteration order. Returns "" for nil
+// and CodeUnknown when nothing registered matches.
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+	if code, ok := walkCode(err); ok {
+		return code
+	}
+	return CodeUnknown
+}
+
+// CodesOf returns the code of every registered sentinel in err's chain, in
+// the order CodeOf walks it: outermost first, so the last entry is the
+// innermost cause. Returns nil when nothing registered matches.
+func CodesOf(err error) []Code {
+	var codes []Code
+	walkCodes(err, func(code Code) bool {
+		codes = append(codes, code)
+		return true
+	})
+	return codes
+}
+
+// Sentinel returns the sentinel registered for code, or nil. It lets a
+// wire client rebuild an error that errors.Is-matches the server's.
+func Sentinel(code Code) error {
+	for _, entry := range codeTable {
+		if entry.code == code {
+			return entry.err
+		}
+	}
+	return nil
+}
+
+func walkCode(err error) (Code, bool) {
+	var found Code
+	walkCodes(err, func(code Code) bool {
+		found = code
+		return false
+	})
+	return found, found != ""
+}
+
+// walkCodes calls visit with the code of each registered sentinel in err's
+// chain, depth-first in order, until visit returns false. It reports
+// whether the walk ran to the end.
+func walkCodes(err error, visit func(Code) bool) bool {
+	if err == nil {
+		return true
+	}
+	for _, entry := range codeTable {
+		// Sentinels are *errors.errorString, so == is an identity check and
+		// never panics on an uncomparable error type.
+		if err == entry.err { //nolint:errorlint // identity match, the chain is walked below
+			if !visit(entry.code) {
+				return false
+			}
+			break
+		}
+	}
+	switch wrapped := err.(type) { //nolint:errorlint // walking the chain by hand
+	case interface{ Unwrap() []error }:
+		for _, inner := range wrapped.Unwrap() {
+			if !walkCodes(inner, visit) {
+				return false
+			}
+		}
+	case interface{ Unwrap() error }:
+		return walkCodes(wrapped.Unwrap(), visit)
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errdefs_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want errdefs.Code
+	}{
+		{name: "nil", err: nil, want: ""},
+		{name: "bare sentinel", err: errdefs.ErrRealmNotFound, want: errdefs.CodeRealmNotFound},
+		{
+			name: "wrapped once",
+			err:  fmt.Errorf("realm %q: %w", "r1", errdefs.ErrRealmNotFound),
+			want: errdefs.CodeRealmNotFound,
+		},
+		{
+			name: "wrapped twice",
+			err: fmt.Errorf("delete: %w",
+				fmt.Errorf("stack %q: %w", "st1", errdefs.ErrResourceHasDependencies)),
+			want: errdefs.CodeResourceHasDependencies,
+		},
+		{
+			name: "outermost registered sentinel wins",
+			err:  fmt.Errorf("%w: %w", errdefs.ErrCreateCell, errdefs.ErrStackNotFound),
+			want: errdefs.CodeCreateCell,
+		},
+		{
+			name: "unregistered wrapper falls through to the inner sentinel",
+			err:  fmt.Errorf("%w: %w", errdefs.ErrGetCell, errdefs.ErrCellNotFound),
+			want: errdefs.CodeCellNotFound,
+		},
+		{
+			name: "joined errors",
+			err:  errors.Join(errors.New("first"), fmt.Errorf("x: %w", errdefs.ErrVolumeInUse)),
+			want: errdefs.CodeVolumeInUse,
+		},
+		{name: "unregistered", err: errors.New("boom"), want: errdefs.CodeUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errdefs.CodeOf(tt.err); got != tt.want {
+				t.Errorf("CodeOf(%v) = %q, want %q", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCodesOf(t *testing.T) {
+	err := fmt.Errorf("%w: %w", errdefs.ErrCreateCell,
+		fmt.Errorf("%w: %w", errdefs.ErrCellValidation, errdefs.ErrStackNotFound))
+	got := errdefs.CodesOf(err)
+	want := []errdefs.Code{errdefs.CodeCreateCell, errdefs.CodeCellValidation, errdefs.CodeStackNotFound}
+	if !slices.Equal(got, want) {
+		t.Errorf("CodesOf() = %v, want %v", got, want)
+	}
+	if got = errdefs.CodesOf(errors.New("boom")); got != nil {
+		t.Errorf("CodesOf(unregistered) = %v, want nil", got)
+	}
+}
+
+func TestSentinel(t *testing.T) {
+	if got := errdefs.Sentinel(errdefs.CodeCellValidation); !errors.Is(got, errdefs.ErrCellValidation) {
+		t.Errorf("Sentinel(CELL_VALIDATION) = %v, want ErrCellValidation", got)
+	}
+	if got := errdefs.Sentinel(errdefs.CodeUnknown); got != nil {
+		t.Errorf("Sentinel(UNKNOWN) = %v, want nil", got)
+	}
+}
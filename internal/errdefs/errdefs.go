@@ -29,7 +29,32 @@ var (
 	ErrSubnetExhausted        = errors.New("no free subnet available in parent CIDR")
 	ErrInvalidSubnetCIDR      = errors.New("invalid subnet CIDR")
 	ErrSubnetStateCorrupt     = errors.New("subnet allocator state is malformed")
+	ErrSubnetOverlap          = errors.New("subnet overlaps another space in the realm")
+	ErrDualStackConfig        = errors.New("dualStack and ipv6Subnet must be set together")
+	ErrDualStackIsolated      = errors.New("isolated network policy covers IPv4 only; a dual-stack space must set policy open")
+	ErrInvalidBandwidth       = errors.New("invalid bandwidth limit")
+	ErrInvalidLogRotation     = errors.New("invalid log rotation")
+	ErrInvalidImagePullPolicy = errors.New("invalid image pull policy")
+	ErrInvalidPlatform        = errors.New("invalid image platform")
+	ErrInvalidDevice          = errors.New("invalid device")
+	ErrInvalidSeccompProfile  = errors.New("invalid seccomp profile")
+	ErrInvalidAppArmorProfile = errors.New("invalid apparmor profile")
+	ErrInvalidStdin           = errors.New("invalid container stdin")
+	ErrInvalidHostname        = errors.New("invalid hostname")
+	ErrInvalidExtraHost       = errors.New("invalid extra host")
+	ErrPrivilegedNotAllowed   = errors.New("privileged containers are not allowed in this realm")
+	ErrQuotaExceeded          = errors.New("resource quota exceeded")
+	ErrInvalidCapability      = errors.New("unknown capability")
+	ErrInvalidUser            = errors.New("invalid user")
+	ErrInvalidGroup           = errors.New("invalid supplementary group")
+	ErrInvalidSysctl          = errors.New("invalid sysctl")
+	ErrInvalidOOMScoreAdj     = errors.New("invalid oomScoreAdj")
+	ErrInvalidTermMsgPath     = errors.New("invalid terminationMessagePath")
+	ErrInvalidContinueToken   = errors.New("invalid continue token")
+	ErrInvalidTimeout         = errors.New("invalid timeout")
+	ErrCommandTimeout         = errors.New("command timed out")
 	ErrConnectContainerd      = errors.New("failed to connect to containerd")
+	ErrDaemonUnreachable      = errors.New("kukeond unreachable")
 	ErrCheckNamespaceExists   = errors.New("failed to check if namespace exists")
 	ErrNamespaceAlreadyExists = errors.New("namespace already exists")
 	ErrCreateNamespace        = errors.New("failed to create namespace")
@@ -79,6 +104,9 @@ var (
 	ErrGetCell                = errors.New("failed to get cell")
 	ErrCreateCell             = errors.New("failed to create cell")
 	ErrDiskPressure           = errors.New("data volume is under disk pressure")
+	ErrNodeCordoned           = errors.New("node is cordoned")
+	ErrScopeDrained           = errors.New("scope is drained")
+	ErrScopeNotDrained        = errors.New("scope is not drained")
 	ErrCreateRootContainer    = errors.New("failed to create root container")
 	ErrNetworkConfigNotLoaded = errors.New("network config not loaded")
 	// ErrExplicitRootHostNetworkMismatch fires when a cell pins its root via
@@ -114,6 +142,25 @@ var (
 	// future selector-aware verbs (`kuke describe`, `kuke delete`) reuse
 	// the same surface text and errors.Is identity.
 	ErrSelectorWithName        = errors.New("--selector cannot be combined with a resource name")
+	ErrFieldSelectorWithName   = errors.New("--field-selector cannot be combined with a resource name")
+	ErrAllWithScope            = errors.New("--all cannot be combined with a resource name or scope flags")
+	ErrQuietWithOutput         = errors.New("--quiet cannot be combined with --output")
+	ErrInvalidSortBy           = errors.New("invalid --sort-by field")
+	ErrInvalidFieldSelector    = errors.New("invalid --field-selector")
+	ErrInvalidLabelColumns     = errors.New("invalid label columns")
+	ErrInvalidCustomColumns    = errors.New("invalid custom columns")
+	ErrInvalidChunkFlags       = errors.New("--chunk-size and --limit must not be negative")
+	ErrInvalidPatch            = errors.New("invalid patch")
+	ErrImmutableField          = errors.New("field is immutable")
+	ErrPatchUnsupportedKind    = errors.New("kind cannot be patched")
+	ErrInvalidRealmNamespace   = errors.New("realm namespace is invalid")
+	ErrRealmNamespaceInUse     = errors.New("containerd namespace is owned by another realm")
+	ErrFindOrphans             = errors.New("failed to find orphaned containers")
+	ErrPurgeOrphans            = errors.New("failed to purge orphaned containers")
+	ErrListEvents              = errors.New("failed to list events")
+	ErrListQuotas              = errors.New("failed to list resource quotas")
+	ErrPauseImageUnavailable   = errors.New("realm pause image is unavailable")
+	ErrKukeonCgroupNotFound    = errors.New("kukeon cgroup does not exist")
 	ErrInvalidName             = errors.New("name is invalid")
 	ErrInvalidImage            = errors.New("invalid image reference")
 	ErrDeleteRealm             = errors.New("failed to delete realm")
@@ -132,6 +179,8 @@ var (
 	ErrInvalidIOWeight  = errors.New("io weight must be within [1, 1000]")
 	ErrInvalidThrottle  = errors.New("io throttle entries require type, major, minor and rate")
 
+	ErrCgroupV1Unsupported = errors.New("cgroup v1 is not supported; kukeon requires cgroup v2")
+
 	// Container-related errors.
 
 	ErrEmptyContainerID  = errors.New("container id is required")
@@ -158,6 +207,18 @@ var (
 	// of the misleading Ready→Stopped→reaped cycle. Issue #851.
 	ErrCellWindDownImmediate = errors.New("cell wound down immediately after start")
 
+	// ErrParentNotReady fires when a cell's realm, space or stack exists but
+	// is not Ready; the wrapping message names the parent and its state.
+	ErrParentNotReady = errors.New("parent not ready")
+	// ErrParentWaitTimeout fires when `--wait-for-parent` gave up before the
+	// cell's parent chain turned Ready.
+	ErrParentWaitTimeout = errors.New("timed out waiting for parents to become ready")
+	// ErrCellNotReady fires when a StartCell that asked to wait for
+	// readiness (CellSpec.WaitReadySeconds, `kuke create cell --wait`)
+	// finds a container task that exited, or that is still not running when
+	// the timeout lapses. The wrapped message names every such container.
+	ErrCellNotReady = errors.New("cell containers did not become ready")
+
 	// ErrCellReconcileFailed is the apply-layer sentinel raised when a cell's
 	// reconcile completed without a runner-level error yet left the cell in
 	// CellStateFailed. A compatible-change apply that routes through UpdateCell
@@ -230,6 +291,11 @@ var (
 	// duplicates, IPAM duplicate-allocation, iptables) are real failures
 	// that must surface.
 	ErrCNIVethExists = errors.New("cni container veth already exists in netns")
+	// ErrCNITransient marks a CNI ADD failure that is expected to clear on
+	// its own — host-local IPAM lock contention when several cells start at
+	// once (EAGAIN / EBUSY on the allocation store). StartCell retries these
+	// with backoff; every other CNI failure surfaces on the first attempt.
+	ErrCNITransient = errors.New("transient cni failure")
 
 	// Network-policy-related errors.
 
@@ -242,6 +308,10 @@ var (
 	ErrEgressHostResolution     = errors.New("failed to resolve egress allow rule host")
 	ErrEgressApply              = errors.New("failed to apply egress policy")
 	ErrEgressRemove             = errors.New("failed to remove egress policy")
+	ErrInvalidNetworkPolicy     = errors.New("space network policy must be 'isolated' or 'open'")
+	ErrIsolationInvalidSubnet   = errors.New("space isolation subnet is not a valid IPv4 CIDR")
+	ErrIsolationApply           = errors.New("failed to apply space isolation")
+	ErrIsolationRemove          = errors.New("failed to remove space isolation")
 
 	// Firewall (FORWARD admission) errors.
 
@@ -276,6 +346,15 @@ var (
 			"build it with `kuke team init --build`",
 	)
 
+	// ErrImageDigestMismatch is returned when a container image pinned by
+	// digest (name@sha256:...) resolves to a manifest with a different
+	// digest, either after a pull or for the local image of that name.
+	ErrImageDigestMismatch = errors.New("image digest does not match the pinned digest")
+
+	// ErrImageNotPresent is returned when a container's imagePullPolicy is
+	// Never and its image is absent from the realm's containerd namespace.
+	ErrImageNotPresent = errors.New("image is not present and imagePullPolicy is Never")
+
 	// ErrGetImage wraps the underlying containerd error when fetching
 	// one image's metadata fails for reasons other than not-found.
 	ErrGetImage = errors.New("failed to get image")
@@ -383,6 +462,21 @@ var (
 			"retrying cannot fix this — heal the socket with `kuke restart <cell>`",
 	)
 
+	// ErrInvalidDetachKeys is returned by `kuke attach` when --detach-keys
+	// does not parse as a comma-separated list of single characters and
+	// ctrl-<key> combinations.
+	ErrInvalidDetachKeys = errors.New("invalid detach keys")
+
+	// ErrInvalidLogStream is returned by `kuke log` when --stream is not
+	// stdout, stderr, or combined, or asks for a single stream of a
+	// container whose log merges them (no spec.separateStreams).
+	ErrInvalidLogStream = errors.New("invalid log stream")
+
+	// ErrNoPreviousLog is returned by `kuke log --previous` when the
+	// container has no archived log from an earlier instance: it has not
+	// restarted since producing output, or it is Attachable.
+	ErrNoPreviousLog = errors.New("no previous container instance log")
+
 	// ErrSocketPathTooLong fires when the resolved host-side path of a
 	// per-container kuketty control socket would overflow Linux's
 	// sockaddr_un.sun_path buffer (consts.KukeonMaxSocketPath bytes plus
@@ -411,6 +505,13 @@ var (
 	// ErrClientConfigurationInvalid is returned when a ClientConfiguration
 	// YAML fails to parse or violates the schema (wrong kind, etc.).
 	ErrClientConfigurationInvalid = errors.New("client configuration is invalid")
+	// ErrClientConfigurationKey is returned by `kuke config set|unset` for a
+	// key the ClientConfiguration does not carry.
+	ErrClientConfigurationKey = errors.New("unknown client configuration key")
+	// ErrClientContextNotFound is returned when `--context`, KUKEON_CONTEXT,
+	// or spec.currentContext names a context the ClientConfiguration does
+	// not define.
+	ErrClientContextNotFound = errors.New("client context not found")
 
 	// Secret-related errors.
 
@@ -600,6 +701,9 @@ var (
 	// of the same name already lives in the target scope — the caller
 	// (`kuke create config`) surfaces it as a hard collision.
 	ErrConfigExists = errors.New("config already exists")
+	// ErrScaffoldFileExists fires when `kuke create --output-dir` would
+	// overwrite an existing file and --force was not given.
+	ErrScaffoldFileExists = errors.New("scaffold file already exists")
 	// ErrCreateConfig wraps a failure on the controller-level atomic
 	// create-only CellConfig endpoint. Scope/blueprint/slot validation
 	// failures propagate their own sentinels (ErrConfigScopeNotFound,
@@ -633,6 +737,13 @@ var (
 	// boundary without diverging the surface text.
 	ErrControllerNoChange = errors.New("controller reported no change")
 
+	// ErrDrainTimeout fires from kukeond's shutdown when an in-flight
+	// reconcile pass does not return within the configured drain window.
+	// The daemon exits anyway; the kernel drops the pass's metadata flocks
+	// with the process and the next daemon's first pass converges whatever
+	// the interrupted pass left behind.
+	ErrDrainTimeout = errors.New("reconcile pass did not drain before shutdown timeout")
+
 	// Team-distribution parse/validation errors (kuketeams.io/v1 kinds —
 	// ProjectTeam, TeamsConfig, TeamEntry, Role, Harness, ImageCatalog).
 	// Issue #793, epic #792.
@@ -801,4 +912,116 @@ var (
 	ErrTeamApplyFailed = errors.New(
 		"team init: one or more documents failed to apply",
 	)
+	// ErrCopySourceNotFound fires when the source of a `kuke cp` does not
+	// exist — inside the container for a copy out, on the host for a copy in.
+	ErrCopySourceNotFound = errors.New("copy source not found")
+	// ErrCopyUnsafeEntry fires when a tar entry streamed by `kuke cp` carries
+	// an absolute path or a `..` component that would land outside the
+	// extraction directory.
+	ErrCopyUnsafeEntry = errors.New("archive entry escapes the destination directory")
+	// ErrCopyPathSpec fires when `kuke cp` is given arguments that are not
+	// exactly one `<cell>:<container>:<path>` operand and one local path.
+	ErrCopyPathSpec = errors.New(
+		"exactly one of source or destination must be <cell>:<container>:<path>",
+	)
+	// ErrRenameSameName fires when `kuke rename` is asked to rename a
+	// resource to the name it already carries.
+	ErrRenameSameName = errors.New("new name is the same as the current name")
+	// ErrRenameTargetExists fires when the rename target name is already
+	// taken by a sibling resource in the same parent scope.
+	ErrRenameTargetExists = errors.New("a resource with the new name already exists")
+	// ErrRenameCellRunning fires when a cell still has a running (or paused)
+	// container task. Renaming moves the cell's cgroup and the per-cell files
+	// its containers bind-mount, so the cell must be stopped first.
+	ErrRenameCellRunning = errors.New("cell has running containers; stop it before renaming")
+	// ErrImportFailed fires when `kuke import` stops on the first resource
+	// that fails to apply; the resources it already created are rolled back.
+	ErrImportFailed = errors.New("import failed")
+	// ErrBackupFailed fires when the metadata backup taken before a realm
+	// purge or cascade delete cannot be written and the daemon runs with
+	// --require-backup; the destructive operation is not started.
+	ErrBackupFailed = errors.New("metadata backup failed")
+	// ErrInvalidBackup fires when `kuke restore` is given something that is
+	// not a metadata backup archive, or an archive entry that would land
+	// outside the metadata tree.
+	ErrInvalidBackup = errors.New("invalid metadata backup")
+	// ErrSnapshotterUnavailable is returned when a realm or container selects a
+	// containerd snapshotter that is not registered (or failed to initialize)
+	// in the connected containerd daemon.
+	ErrSnapshotterUnavailable = errors.New("snapshotter is not available in containerd")
+	// ErrQuotaUnsupported is returned when a container declares a diskQuota
+	// but its snapshotter cannot enforce a rootfs size limit.
+	ErrQuotaUnsupported = errors.New("snapshotter does not support disk quotas")
+	// ErrInvalidDiskQuota fires when a container's diskQuota.sizeBytes is
+	// negative.
+	ErrInvalidDiskQuota = errors.New("diskQuota.sizeBytes must not be negative")
+	// ErrInvalidReplicas fires when `kuke stack scale` is given a negative
+	// replica count.
+	ErrInvalidReplicas = errors.New("replica count must be zero or greater")
+	// ErrScaleTemplateIsReplica fires when the scale template is itself a
+	// replica of another cell; replicas of replicas are not supported.
+	ErrScaleTemplateIsReplica = errors.New("scale template is itself a replica")
+	// ErrInvalidRestartStrategy fires when `kuke stack restart --strategy`
+	// is neither "rolling" nor "parallel".
+	ErrInvalidRestartStrategy = errors.New("restart strategy must be \"rolling\" or \"parallel\"")
+	// ErrInvalidMaxUnavailable fires when `kuke stack restart
+	// --max-unavailable` is below 1.
+	ErrInvalidMaxUnavailable = errors.New("max-unavailable must be 1 or greater")
+	// ErrCellNotReadyAfterRestart fires when a rolling stack restart brings a
+	// cell back but the daemon does not report it Ready, so the rollout must
+	// not advance to the next batch.
+	ErrCellNotReadyAfterRestart = errors.New("cell is not Ready after restart")
+	// ErrStackRestartAborted fires when a stack rollout stops on the first
+	// failed batch (no --continue-on-error); cells after it are not touched.
+	ErrStackRestartAborted = errors.New("stack restart aborted")
+	// ErrInvalidBatchParallel fires when `kuke stop/start/kill --parallel`
+	// is below 1.
+	ErrInvalidBatchParallel = errors.New("parallel must be 1 or greater")
+	// ErrScaleReplicaNameTaken fires when a replica name (<template>-<n>) is
+	// already used by a cell that is not a replica of the template.
+	ErrScaleReplicaNameTaken = errors.New(
+		"replica name is taken by a cell that is not a replica of the template",
+	)
+	ErrInvalidLogFormat = errors.New("invalid log format")
+	// ErrCellValidation wraps the aggregated problems ValidateCell found in
+	// a cell before any of it is created.
+	ErrCellValidation = errors.New("cell validation failed")
+	// ErrManifestInvalid is returned by `kuke validate` when a manifest
+	// has at least one error.
+	ErrManifestInvalid = errors.New("manifest is invalid")
+	// ErrManifestEnvUndefined is returned by `kuke apply --expand-env` when
+	// a manifest references a variable that is unset and has no default.
+	ErrManifestEnvUndefined = errors.New("manifest references undefined environment variable")
+	// ErrDiffUnsupportedKind fires when `kuke diff` is given a kind it
+	// cannot compare against the store.
+	ErrDiffUnsupportedKind = errors.New("kind cannot be diffed")
+	// ErrManifestDiffers is returned by `kuke diff` when at least one
+	// document does not match the store.
+	ErrManifestDiffers = errors.New("manifest differs from the store")
+	// ErrDeleteResourceNotFound is returned by `kuke delete -f
+	// --ignore-not-found=false` when a document names a resource that does
+	// not exist.
+	ErrDeleteResourceNotFound = errors.New("resource to delete does not exist")
+	// ErrRunPathNotWritable is the preflight failure for a run path kukeon
+	// cannot create files under; realm creation checks it before writing
+	// any metadata.
+	ErrRunPathNotWritable = errors.New("run path is not writable")
+	// ErrCgroupV2NotMounted is the preflight failure for a cgroup root with
+	// no readable cgroup.controllers file.
+	ErrCgroupV2NotMounted = errors.New("cgroup v2 is not mounted")
+	// ErrCNIConfDirNotFound is the preflight failure for a missing CNI
+	// network-config directory.
+	ErrCNIConfDirNotFound = errors.New("cni config directory not found")
+	// ErrKukettyNotStaged is the preflight finding for a run path with no
+	// staged kuketty binary, which `kuke attach` needs inside the cell.
+	ErrKukettyNotStaged = errors.New("kuketty binary is not staged")
+	// ErrPreflightFailed wraps every problem realm-creation preflight found;
+	// errors.Is still matches each underlying sentinel.
+	ErrPreflightFailed = errors.New("preflight failed")
+	// ErrCellHookFailed wraps a cell lifecycle hook that exited non-zero or
+	// timed out; a failing preStart hook aborts StartCell.
+	ErrCellHookFailed = errors.New("cell lifecycle hook failed")
+	// ErrContainerHookFailed wraps an in-container postStart or preStop
+	// command that exited non-zero, timed out, or could not be exec'd.
+	ErrContainerHookFailed = errors.New("container lifecycle hook failed")
 )
@@ -0,0 +1,319 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package events keeps kukeon's own audit trail: an append-only log of the
+// actions the controller took (created a realm, purged a space, failed to
+// start a cell) and how each one ended. Live containerd task events say what
+// a container did; this log says what kukeon did. It lives under
+// <runPath>/events as JSON lines, so the daemon and the in-process
+// `--no-daemon` path append to, and `kuke get events` reads, the same record.
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// DirName is the directory under runPath that holds the event log.
+const DirName = "events"
+
+const (
+	// logFile is the active log; rotated generations are logFile.1 (newest)
+	// through logFile.<MaxFiles-1> (oldest).
+	logFile = "events.jsonl"
+	// lockFile anchors the flock that serialises writers and rotation
+	// across processes. Rotation renames logFile, so the lock cannot live on
+	// it.
+	lockFile = ".events.lock"
+
+	dirMode  os.FileMode = 0o750
+	fileMode os.FileMode = 0o640
+)
+
+const (
+	// DefaultMaxFileBytes is the size at which the active log is rotated.
+	DefaultMaxFileBytes int64 = 1 << 20
+	// DefaultMaxFiles is how many log files are kept, the active one
+	// included. The oldest generation is dropped on rotation.
+	DefaultMaxFiles = 5
+)
+
+// Actions the controller records.
+const (
+	ActionApply  = "apply"
+	ActionCreate = "create"
+	ActionDelete = "delete"
+	ActionPurge  = "purge"
+	ActionStart  = "start"
+	ActionStop   = "stop"
+	ActionKill   = "kill"
+)
+
+// Kinds of resource an event is about.
+const (
+	KindRealm = "Realm"
+	KindSpace = "Space"
+	KindStack = "Stack"
+	KindCell  = "Cell"
+)
+
+// Outcome says whether the recorded action succeeded.
+type Outcome string
+
+const (
+	OutcomeSucceeded Outcome = "Succeeded"
+	OutcomeFailed    Outcome = "Failed"
+)
+
+// Event is one entry of the log. Realm, Space, Stack and Cell place the
+// resource in the hierarchy; the field matching Kind is its own name.
+// Message carries the error of a failed action, or a short note.
+type Event struct {
+	Time    time.Time `json:"time"`
+	Action  string    `json:"action"`
+	Kind    string    `json:"kind"`
+	Realm   string    `json:"realm,omitempty"`
+	Space   string    `json:"space,omitempty"`
+	Stack   string    `json:"stack,omitempty"`
+	Cell    string    `json:"cell,omitempty"`
+	Outcome Outcome   `json:"outcome"`
+	Message string    `json:"message,omitempty"`
+}
+
+// Filter narrows List. Empty fields match everything; Kind compares
+// case-insensitively. Realm, Space, Stack and Cell match the event's place
+// in the hierarchy, so Realm "main" selects the realm's own events and those
+// of everything under it. A positive Limit keeps only the newest entries.
+type Filter struct {
+	Kind  string
+	Realm string
+	Space string
+	Stack string
+	Cell  string
+	Since time.Time
+	Limit int
+}
+
+// Matches reports whether e passes the filter. Limit is applied by List.
+func (f Filter) Matches(e Event) bool {
+	switch {
+	case f.Kind != "" && !strings.EqualFold(f.Kind, e.Kind),
+		f.Realm != "" && f.Realm != e.Realm,
+		f.Space != "" && f.Space != e.Space,
+		f.Stack != "" && f.Stack != e.Stack,
+		f.Cell != "" && f.Cell != e.Cell,
+		!f.Since.IsZero() && e.Time.Before(f.Since):
+		return false
+	}
+	return true
+}
+
+// Options bounds the log's retention. Zero fields take the defaults.
+type Options struct {
+	MaxFileBytes int64
+	MaxFiles     int
+}
+
+// Log is the event log under one runPath. It is safe for concurrent use: an
+// in-process mutex orders goroutines and a flock on a sidecar lock file
+// orders processes. A nil *Log records and returns nothing.
+type Log struct {
+	dir          string
+	maxFileBytes int64
+	maxFiles     int
+
+	mu sync.Mutex
+}
+
+// Dir returns the event log directory under runPath.
+func Dir(runPath string) string {
+	return filepath.Join(runPath, DirName)
+}
+
+// NewLog returns the event log under runPath, or nil when runPath is empty.
+func NewLog(runPath string, opts Options) *Log {
+	if runPath == "" {
+		return nil
+	}
+	if opts.MaxFileBytes <= 0 {
+		opts.MaxFileBytes = DefaultMaxFileBytes
+	}
+	if opts.MaxFiles <= 0 {
+		opts.MaxFiles = DefaultMaxFiles
+	}
+	return &Log{dir: Dir(runPath), maxFileBytes: opts.MaxFileBytes, maxFiles: opts.MaxFiles}
+}
+
+// Append writes e to the log, rotating first when it would push the active
+// file past MaxFileBytes. The events directory is created on demand but
+// runPath itself is not: before `kuke init` there is no log to write to.
+func (l *Log) Append(e Event) error {
+	if l == nil {
+		return nil
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if mkErr := os.Mkdir(l.dir, dirMode); mkErr != nil && !errors.Is(mkErr, os.ErrExist) {
+		return fmt.Errorf("create event log directory %q: %w", l.dir, mkErr)
+	}
+	release, err := l.lock(syscall.LOCK_EX)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	active := l.file(0)
+	if info, statErr := os.Stat(active); statErr == nil && info.Size() > 0 &&
+		info.Size()+int64(len(line)) > l.maxFileBytes {
+		if rotErr := l.rotate(); rotErr != nil {
+			return rotErr
+		}
+	}
+
+	f, err := os.OpenFile(active, os.O_WRONLY|os.O_APPEND|os.O_CREATE, fileMode)
+	if err != nil {
+		return fmt.Errorf("open event log %q: %w", active, err)
+	}
+	if _, err = f.Write(line); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write event log %q: %w", active, err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("close event log %q: %w", active, err)
+	}
+	return nil
+}
+
+// List returns the events that pass f, oldest first. A missing log is
+// empty, not an error; a line that does not parse is skipped.
+func (l *Log) List(f Filter) ([]Event, error) {
+	if l == nil {
+		return nil, nil
+	}
+	if _, err := os.Stat(l.dir); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	release, err := l.lock(syscall.LOCK_SH)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var out []Event
+	for gen := l.maxFiles - 1; gen >= 0; gen-- {
+		if out, err = readEvents(l.file(gen), f, out); err != nil {
+			return nil, err
+		}
+	}
+	if f.Limit > 0 && len(out) > f.Limit {
+		out = out[len(out)-f.Limit:]
+	}
+	return out, nil
+}
+
+// readEvents appends the events of one log file that pass f to out.
+func readEvents(path string, f Filter, out []Event) ([]Event, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return out, nil
+		}
+		return out, fmt.Errorf("open event log %q: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var e Event
+		if json.Unmarshal(scanner.Bytes(), &e) != nil {
+			continue
+		}
+		if f.Matches(e) {
+			out = append(out, e)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return out, fmt.Errorf("read event log %q: %w", path, err)
+	}
+	return out, nil
+}
+
+// rotate shifts every generation one step older, dropping the oldest, and
+// leaves no active file behind. The caller holds the exclusive lock.
+func (l *Log) rotate() error {
+	if err := os.Remove(l.file(l.maxFiles - 1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("drop oldest event log: %w", err)
+	}
+	for gen := l.maxFiles - 2; gen >= 0; gen-- {
+		if err := os.Rename(l.file(gen), l.file(gen+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("rotate event log: %w", err)
+		}
+	}
+	return nil
+}
+
+// file returns the path of log generation gen; 0 is the active file.
+func (l *Log) file(gen int) string {
+	name := logFile
+	if gen > 0 {
+		name += "." + strconv.Itoa(gen)
+	}
+	return filepath.Join(l.dir, name)
+}
+
+// lock takes a flock of the given mode on the sidecar lock file; calling
+// the returned release drops it. A shared lock opens the file read-only and
+// is a no-op when no writer has created it yet.
+func (l *Log) lock(mode int) (func(), error) {
+	path := filepath.Join(l.dir, lockFile)
+	flag := os.O_RDWR | os.O_CREATE
+	if mode == syscall.LOCK_SH {
+		flag = os.O_RDONLY
+	}
+	f, err := os.OpenFile(path, flag, fileMode)
+	if err != nil {
+		if mode == syscall.LOCK_SH && errors.Is(err, os.ErrNotExist) {
+			return func() {}, nil
+		}
+		return nil, fmt.Errorf("open event log lock %q: %w", path, err)
+	}
+	if err = syscall.Flock(int(f.Fd()), mode); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("flock %q: %w", path, err)
+	}
+	return func() { _ = f.Close() }, nil
+}
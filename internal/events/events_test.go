@@ -0,0 +1,198 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eminwux/kukeon/internal/events"
+)
+
+var base = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func TestLogAppendAndFilter(t *testing.T) {
+	log := events.NewLog(t.TempDir(), events.Options{})
+
+	recorded := []events.Event{
+		{Time: base, Action: events.ActionCreate, Kind: events.KindRealm, Realm: "main", Outcome: events.OutcomeSucceeded},
+		{
+			Time: base.Add(time.Minute), Action: events.ActionCreate, Kind: events.KindSpace,
+			Realm: "main", Space: "app", Outcome: events.OutcomeSucceeded,
+		},
+		{
+			Time: base.Add(2 * time.Minute), Action: events.ActionStart, Kind: events.KindCell,
+			Realm: "main", Space: "app", Stack: "web", Cell: "api",
+			Outcome: events.OutcomeFailed, Message: "image not found",
+		},
+		{
+			Time: base.Add(3 * time.Minute), Action: events.ActionPurge, Kind: events.KindRealm,
+			Realm: "lab", Outcome: events.OutcomeSucceeded,
+		},
+	}
+	for _, e := range recorded {
+		if err := log.Append(e); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter events.Filter
+		want   []int
+	}{
+		{name: "no filter returns everything oldest first", want: []int{0, 1, 2, 3}},
+		{name: "realm selects the realm and everything under it", filter: events.Filter{Realm: "main"}, want: []int{0, 1, 2}},
+		{name: "space narrows further", filter: events.Filter{Realm: "main", Space: "app"}, want: []int{1, 2}},
+		{name: "cell", filter: events.Filter{Cell: "api"}, want: []int{2}},
+		{name: "kind is case-insensitive", filter: events.Filter{Kind: "realm"}, want: []int{0, 3}},
+		{name: "since drops older events", filter: events.Filter{Since: base.Add(2 * time.Minute)}, want: []int{2, 3}},
+		{name: "limit keeps the newest", filter: events.Filter{Limit: 2}, want: []int{2, 3}},
+		{name: "no match", filter: events.Filter{Realm: "ghost"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := log.List(tt.filter)
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("List returned %d events, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, idx := range tt.want {
+				if !got[i].Time.Equal(recorded[idx].Time) || got[i] != withUTC(got[i], recorded[idx]) {
+					t.Errorf("event %d = %+v, want %+v", i, got[i], recorded[idx])
+				}
+			}
+		})
+	}
+}
+
+// withUTC returns want with its Time taken from got, so == compares every
+// other field without tripping on the decoded time's location.
+func withUTC(got, want events.Event) events.Event {
+	want.Time = got.Time
+	return want
+}
+
+func TestLogRotationBoundsRetention(t *testing.T) {
+	runPath := t.TempDir()
+	log := events.NewLog(runPath, events.Options{MaxFileBytes: 256, MaxFiles: 3})
+
+	const total = 50
+	for i := range total {
+		e := events.Event{
+			Time: base.Add(time.Duration(i) * time.Second), Action: events.ActionStart,
+			Kind: events.KindCell, Realm: "main", Cell: fmt.Sprintf("cell-%02d", i), Outcome: events.OutcomeSucceeded,
+		}
+		if err := log.Append(e); err != nil {
+			t.Fatalf("Append %d: %v", i, err)
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(events.Dir(runPath), "events.jsonl*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 3 {
+		t.Errorf("log files = %v, want 3 (active + 2 rotated)", files)
+	}
+	for _, f := range files {
+		info, statErr := os.Stat(f)
+		if statErr != nil {
+			t.Fatal(statErr)
+		}
+		if info.Size() > 256 {
+			t.Errorf("%s is %d bytes, want at most 256", f, info.Size())
+		}
+	}
+
+	got, err := log.List(events.Filter{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(got) == 0 || len(got) >= total {
+		t.Fatalf("List returned %d events, want a bounded tail of %d", len(got), total)
+	}
+	if last := got[len(got)-1].Cell; last != fmt.Sprintf("cell-%02d", total-1) {
+		t.Errorf("newest event is %q, want cell-%02d", last, total-1)
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i].Time.Before(got[i-1].Time) {
+			t.Fatalf("events out of order at %d: %v before %v", i, got[i].Time, got[i-1].Time)
+		}
+	}
+}
+
+func TestLogConcurrentAppends(t *testing.T) {
+	runPath := t.TempDir()
+	// Two Logs over one runPath stand in for the daemon and an in-process
+	// client: only the flock orders them.
+	logs := []*events.Log{
+		events.NewLog(runPath, events.Options{}),
+		events.NewLog(runPath, events.Options{}),
+	}
+
+	const perWriter = 40
+	var wg sync.WaitGroup
+	for w := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perWriter {
+				e := events.Event{
+					Time: base, Action: events.ActionCreate, Kind: events.KindCell,
+					Realm: "main", Cell: fmt.Sprintf("w%d-%d", w, i), Outcome: events.OutcomeSucceeded,
+				}
+				if err := logs[w%2].Append(e); err != nil {
+					t.Errorf("Append: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, err := logs[0].List(events.Filter{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(got) != 4*perWriter {
+		t.Errorf("List returned %d events, want %d — an append was lost or torn", len(got), 4*perWriter)
+	}
+}
+
+func TestLogWithoutRunPath(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "not-initialized")
+	if err := events.NewLog(missing, events.Options{}).Append(events.Event{Action: events.ActionCreate}); err == nil {
+		t.Error("Append under a missing runPath succeeded, want an error")
+	}
+	if _, err := os.Stat(missing); !os.IsNotExist(err) {
+		t.Errorf("Append created the missing runPath (stat err %v)", err)
+	}
+	if got, err := events.NewLog(missing, events.Options{}).List(events.Filter{}); err != nil || got != nil {
+		t.Errorf("List on a missing log = %v, %v; want nil, nil", got, err)
+	}
+
+	var disabled *events.Log
+	if err := disabled.Append(events.Event{}); err != nil {
+		t.Errorf("nil Log Append = %v, want nil", err)
+	}
+}
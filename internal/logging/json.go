@@ -0,0 +1,50 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+// Log output formats accepted by --log-format.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// ParseFormat validates a --log-format value. Empty selects FormatText.
+func ParseFormat(format string) (string, error) {
+	switch format {
+	case "", FormatText:
+		return FormatText, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("%w: %q (want %q or %q)", errdefs.ErrInvalidLogFormat, format, FormatText, FormatJSON)
+	}
+}
+
+// NewJSONLogger returns a logger that writes one JSON object per record to w,
+// with the record's key/value fields as top-level keys next to time, level and
+// msg. Passing a *slog.LevelVar as level keeps the threshold adjustable.
+func NewJSONLogger(w io.Writer, level slog.Leveler) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}))
+}
@@ -0,0 +1,93 @@
+//go:build !integration
+
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/logging"
+)
+
+func TestNewJSONLogger_EmitsStructuredRecord(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.NewJSONLogger(&buf, slog.LevelInfo)
+
+	logger.InfoContext(context.Background(), "created root container", "id", "default_web_root", "realm", "default")
+	logger.DebugContext(context.Background(), "below threshold", "id", "dropped")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 log line, got %d: %q", len(lines), buf.String())
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal(lines[0], &record); err != nil {
+		t.Fatalf("log line is not valid JSON: %v (%q)", err, lines[0])
+	}
+	want := map[string]string{
+		"level": "INFO",
+		"msg":   "created root container",
+		"id":    "default_web_root",
+		"realm": "default",
+	}
+	for key, value := range want {
+		if got, _ := record[key].(string); got != value {
+			t.Errorf("record[%q] = %v, want %q", key, record[key], value)
+		}
+	}
+	if _, ok := record["time"]; !ok {
+		t.Error("record is missing the time key")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "", want: logging.FormatText},
+		{in: "text", want: logging.FormatText},
+		{in: "json", want: logging.FormatJSON},
+		{in: "yaml", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := logging.ParseFormat(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, errdefs.ErrInvalidLogFormat) {
+					t.Fatalf("ParseFormat(%q) error = %v, want ErrInvalidLogFormat", tt.in, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseFormat(%q) error = %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseFormat(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
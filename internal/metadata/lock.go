@@ -153,7 +153,11 @@ func WithSharedLock(_ context.Context, _ *slog.Logger, file string, fn func() er
 // the sidecar flock via WithExclusiveLock. The caller is responsible
 // for the flock; if you are not sure, call WriteMetadata instead.
 func WriteMetadataNoLock(ctx context.Context, logger *slog.Logger, metadata any, file string) error {
-	return writeMetadataUnlocked(ctx, logger, metadata, file)
+	data, err := encodeDocument(metadata, file)
+	if err != nil {
+		return err
+	}
+	return writeMetadataUnlocked(ctx, logger, data, file)
 }
 
 // ReadRawNoLock is the inner read helper used by ReadRaw and
@@ -197,6 +201,10 @@ func WriteMetadataCAS(
 	metadata any,
 	file string,
 ) error {
+	data, err := encodeDocument(metadata, file)
+	if err != nil {
+		return err
+	}
 	return WithExclusiveLock(ctx, logger, file, func() error {
 		var current []byte
 		if existsFilePath(file) {
@@ -209,6 +217,6 @@ func WriteMetadataCAS(
 		if !bytes.Equal(prior, current) {
 			return fmt.Errorf("cas check failed for %s: %w", file, errdefs.ErrStaleResource)
 		}
-		return writeMetadataUnlocked(ctx, logger, metadata, file)
+		return writeMetadataUnlocked(ctx, logger, data, file)
 	})
 }
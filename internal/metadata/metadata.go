@@ -52,8 +52,12 @@ func existsFilePath(filepath string) bool {
 // prevents torn writes when more than one process — or more than one
 // goroutine — tries to update the same metadata document concurrently.
 func WriteMetadata(ctx context.Context, logger *slog.Logger, metadata any, file string) error {
+	data, err := encodeDocument(metadata, file)
+	if err != nil {
+		return err
+	}
 	return WithExclusiveLock(ctx, logger, file, func() error {
-		return writeMetadataUnlocked(ctx, logger, metadata, file)
+		return writeMetadataUnlocked(ctx, logger, data, file)
 	})
 }
 
@@ -61,12 +65,13 @@ func WriteMetadata(ctx context.Context, logger *slog.Logger, metadata any, file
 // sidecar exclusive flock. It assumes the caller already holds the lock
 // — calling it without the lock is a torn-write footgun and is only
 // safe through WriteMetadata, WriteMetadataNoLock, or WriteMetadataCAS.
-func writeMetadataUnlocked(ctx context.Context, logger *slog.Logger, metadata any, file string) error {
+// data is the already-encoded document (see encodeDocument).
+func writeMetadataUnlocked(ctx context.Context, logger *slog.Logger, data []byte, file string) error {
 	logger.DebugContext(ctx, "creating metadata", "file", file)
 
 	if existsFilePath(file) {
 		logger.InfoContext(ctx, "metadata file already exists, overwriting", "file", file)
-		err := writeMetadataFile(ctx, logger, metadata, file)
+		err := writeMetadataFile(ctx, logger, data, file)
 		if err != nil {
 			logger.ErrorContext(ctx, "failed to write metadata file", "file", file, "error", err)
 			return fmt.Errorf("failed to write metadata file: %w", err)
@@ -93,7 +98,7 @@ func writeMetadataUnlocked(ctx context.Context, logger *slog.Logger, metadata an
 		return fmt.Errorf("chmod metadata dir: %w", err)
 	}
 
-	err := writeMetadataFile(ctx, logger, metadata, file)
+	err := writeMetadataFile(ctx, logger, data, file)
 	if err != nil {
 		logger.ErrorContext(ctx, "failed to write metadata file", "file", file, "error", err)
 		return fmt.Errorf("failed to write metadata file: %w", err)
@@ -102,15 +107,21 @@ func writeMetadataUnlocked(ctx context.Context, logger *slog.Logger, metadata an
 	return nil
 }
 
-func writeMetadataFile(ctx context.Context, logger *slog.Logger, metadata any, file string) error {
+// encodeDocument renders a metadata document the way it is persisted:
+// indented JSON with a trailing newline. Encoding happens before the lock is
+// taken, so a document that cannot be marshaled never holds it. name only
+// labels the error.
+func encodeDocument(metadata any, name string) ([]byte, error) {
 	marshaled, marshalErr := json.MarshalIndent(metadata, "", "  ")
 	if marshalErr != nil {
-		return fmt.Errorf("marshal %s: %w", file, marshalErr)
+		return nil, fmt.Errorf("marshal %s: %w", name, marshalErr)
 	}
-	marshaled = append(marshaled, '\n') // gocritic: assign result to same slice
+	return append(marshaled, '\n'), nil
+}
 
+func writeMetadataFile(ctx context.Context, logger *slog.Logger, data []byte, file string) error {
 	const filePerm = 0o644 // mnd: magic number
-	if writeErr := atomicWriteFile(ctx, logger, file, marshaled, filePerm); writeErr != nil {
+	if writeErr := atomicWriteFile(ctx, logger, file, data, filePerm); writeErr != nil {
 		return fmt.Errorf("write %s: %w", file, writeErr)
 	}
 	return nil
@@ -55,6 +55,14 @@ type CellSpec struct {
 	// metadata so the daemon can re-toggle the full subtree controller set
 	// on the ensure-pass after a restart.
 	NestedCgroupRuntime bool
+	// Bandwidth mirrors v1beta1.CellSpec.Bandwidth: ingress/egress caps the
+	// runner hands to the CNI bandwidth plugin when it attaches the root
+	// container. Nil leaves the cell unshaped.
+	Bandwidth *CellBandwidth
+	// Lifecycle mirrors v1beta1.CellSpec.Lifecycle: host-side commands the
+	// runner executes before StartCell starts containers and after StopCell
+	// stops them. Nil runs none.
+	Lifecycle *CellLifecycle
 	// RuntimeEnv mirrors v1beta1.CellSpec.RuntimeEnv. The wire side carries
 	// `kuke run --env KEY=VALUE` from the CLI; the daemon merges these
 	// entries into the attachable container's OCI process env at create /
@@ -80,6 +88,20 @@ type CellSpec struct {
 	// is per-invocation, so the disk-read paths return cells with it false.
 	// Issue #1035.
 	IgnoreDiskPressure bool
+	// WaitReadySeconds mirrors v1beta1.CellSpec.WaitReadySeconds: when
+	// positive, the runner's StartCell polls every container's task until it
+	// is running or the timeout lapses. NOT persisted — the disk-read paths
+	// return cells with it zero, so StartCell carries it from the inbound
+	// cell.
+	WaitReadySeconds int
+	// WaitForParentSeconds mirrors v1beta1.CellSpec.WaitForParentSeconds:
+	// when positive, ValidateCell polls the parent chain until it is Ready
+	// or the timeout lapses. NOT persisted, like WaitReadySeconds.
+	WaitForParentSeconds int
+	// PruneContainers mirrors v1beta1.CellSpec.PruneContainers: when set,
+	// ReconcileCell removes the containers labeled for the cell that its
+	// spec does not declare. NOT persisted, like WaitReadySeconds.
+	PruneContainers bool
 }
 
 // CellProvenance mirrors v1beta1.CellProvenance. See that type for the
@@ -122,6 +144,22 @@ func CloneCellProvenance(in *CellProvenance) *CellProvenance {
 	return out
 }
 
+// CellBandwidth mirrors v1beta1.CellBandwidth. Values keep their unit
+// suffixes ("10Mbit"); cni.ParseBandwidthLimits converts them.
+type CellBandwidth struct {
+	IngressRate  string
+	IngressBurst string
+	EgressRate   string
+	EgressBurst  string
+}
+
+// CellLifecycle mirrors v1beta1.CellLifecycle.
+type CellLifecycle struct {
+	PreStart       []string
+	PostStop       []string
+	TimeoutSeconds int
+}
+
 // CellTty mirrors the v1beta1 CellTty payload. See the v1beta1 type for
 // field semantics.
 type CellTty struct {
@@ -180,9 +218,13 @@ type CellStatus struct {
 // BridgeName is the host-side Linux bridge derived via cni.SafeBridgeName
 // from the cell's space network — persisting it lets `kuke describe`/
 // `kuke get cell -o yaml` recover the human→iface mapping without
-// recomputing the hash.
+// recomputing the hash. IPv4 and IPv6 are the addresses IPAM assigned the
+// root container on the last StartCell; IPv6 is only set on a dual-stack
+// space.
 type CellNetworkStatus struct {
 	BridgeName string
+	IPv4       string
+	IPv6       string
 }
 
 type CellState int
@@ -30,14 +30,24 @@ type ContainerMetadata struct {
 }
 
 type ContainerSpec struct {
-	ID              string
-	ContainerdID    string
-	RealmName       string
-	SpaceName       string
-	StackName       string
-	CellName        string
-	Root            bool
-	Image           string
+	ID           string
+	ContainerdID string
+	RealmName    string
+	SpaceName    string
+	StackName    string
+	CellName     string
+	Root         bool
+	Image        string
+	// ImagePullPolicy selects when Image is pulled. See the ImagePullPolicy*
+	// constants below; empty/unset is treated as ImagePullPolicyIfNotPresent.
+	ImagePullPolicy string
+	// Platform is the os/arch[/variant] image platform; empty means the
+	// host platform.
+	Platform    string
+	Snapshotter string // overrides RealmSpec.Snapshotter when set
+	// DiskQuota mirrors the v1beta1 ContainerSpec.DiskQuota payload: the
+	// size limit applied to the rootfs snapshot at create.
+	DiskQuota       *ContainerDiskQuota
 	Command         string
 	Args            []string
 	WorkingDir      string
@@ -47,6 +57,8 @@ type ContainerSpec struct {
 	Networks        []string
 	NetworksAliases []string
 	Privileged      bool
+	Hostname        string
+	ExtraHosts      []string
 	HostNetwork     bool
 	HostPID         bool
 	HostCgroup      bool
@@ -59,21 +71,69 @@ type ContainerSpec struct {
 	// EnableCellAllSubtreeControllers (#318). Propagated by the runner
 	// from cell.Spec.NestedCgroupRuntime at every BuildContainerSpec
 	// call site; not part of the persisted container document.
-	NestedCgroupRuntime    bool
-	User                   string
+	NestedCgroupRuntime bool
+	User                string
+	// SupplementaryGroups mirrors the v1beta1 ContainerSpec.SupplementaryGroups
+	// payload: numeric GIDs appended to the process's additional groups.
+	SupplementaryGroups    []int
 	ReadOnlyRootFilesystem bool
-	Capabilities           *ContainerCapabilities
-	SecurityOpts           []string
+	// WritableTmp mirrors the v1beta1 ContainerSpec.WritableTmp payload: with
+	// ReadOnlyRootFilesystem, nil or true mounts an implicit tmpfs at /tmp and
+	// false leaves /tmp read-only.
+	WritableTmp  *bool
+	Capabilities *ContainerCapabilities
+	SecurityOpts []string
+	// SeccompProfile mirrors the v1beta1 ContainerSpec.SeccompProfile
+	// payload: one of the SeccompProfile* constants or the path of a JSON
+	// profile. Empty means SeccompProfileRuntimeDefault.
+	SeccompProfile string
+	// AppArmorProfile mirrors the v1beta1 ContainerSpec.AppArmorProfile
+	// payload: a loaded profile name or AppArmorProfileUnconfined. Empty
+	// keeps the runtime default.
+	AppArmorProfile string
+	NoNewPrivileges bool
+	// Sysctls mirrors the v1beta1 ContainerSpec.Sysctls payload: kernel
+	// parameters written to the OCI linux.sysctl map at create.
+	Sysctls map[string]string
+	// OOMScoreAdj mirrors the v1beta1 ContainerSpec.OOMScoreAdj payload:
+	// written to the OCI process oomScoreAdj at create. Nil leaves the
+	// runtime default.
+	OOMScoreAdj *int
+	// TerminationMessagePath mirrors the v1beta1
+	// ContainerSpec.TerminationMessagePath payload. Empty resolves to
+	// consts.DefaultTerminationMessagePath.
+	TerminationMessagePath string
 	// Devices mirrors the v1beta1 ContainerSpec.Devices payload — individual
 	// host device nodes granted to the container (least-privilege alternative
 	// to Privileged). Each entry is a host device path (short form, e.g.
-	// "/dev/kvm") replicated at the same in-container path with "rwm" access.
+	// "/dev/kvm", replicated at the same in-container path with "rwm" access)
+	// or a "hostPath:containerPath[:permissions]" mapping; see
+	// ctr.ParseDeviceMapping.
 	// BuildContainerSpec emits a Linux.Devices entry + Linux.Resources.Devices
 	// allow rule per entry, stat'd from the host node at create time. Issue
 	// #1252.
 	Devices   []string
 	Tmpfs     []ContainerTmpfsMount
 	Resources *ContainerResources
+	// LogRotation mirrors the v1beta1 ContainerSpec.LogRotation payload —
+	// size-based rotation of the container's file-backed stdout/stderr log.
+	// Nil leaves the log unbounded. Consumed by the runner's reconcile pass
+	// (rotate_logs.go).
+	LogRotation *ContainerLogRotation
+	// Lifecycle mirrors the v1beta1 ContainerSpec.Lifecycle payload —
+	// in-container postStart/preStop commands run by the runner
+	// (container_hooks.go) through ctr.Client.ExecProcess.
+	Lifecycle *ContainerLifecycle
+	// SeparateStreams mirrors the v1beta1 ContainerSpec.SeparateStreams
+	// flag — the runner asks for distinct stdout/stderr fifos and a
+	// stream-tagged log (containerLogTaskSpec).
+	SeparateStreams bool
+	// OpenStdin, StdinOnce and StdinFile mirror the v1beta1 ContainerSpec
+	// stdin fields — the runner asks for a daemon-fed stdin fifo
+	// (containerLogTaskSpec).
+	OpenStdin bool
+	StdinOnce bool
+	StdinFile string
 	Secrets   []ContainerSecret
 	// Repos mirrors the v1beta1 ContainerSpec.Repos payload — git
 	// repositories kuketty clones/fetches in its pre-Serve step. See the
@@ -134,6 +194,16 @@ type ContainerSpec struct {
 	// Empty disables the bind-mount. Same lifecycle and storage location as
 	// EtcHostsPath; not part of the persisted document.
 	EtcHostnamePath string
+	// CellHostname is the hostname the cell's containers share: the Hostname
+	// declared on the cell's containers, or the cell name when none is set.
+	// BuildRootContainerSpec applies it through the OCI hostname. Populated by
+	// the runner at container-create time; not part of the persisted document.
+	CellHostname string
+	// TerminationMessageHostPath is the host-side file bind-mounted read-write
+	// at TerminationMessagePath inside the container; the runner reads it
+	// back when the task exits. Empty disables the bind-mount. Populated by
+	// the runner at container-create time; not part of the persisted document.
+	TerminationMessageHostPath string
 }
 
 // ContainerTty mirrors the v1beta1 ContainerTty payload. See the v1beta1
@@ -320,6 +390,11 @@ type ContainerTmpfsMount struct {
 	Options   []string
 }
 
+// ContainerDiskQuota mirrors the v1beta1 ContainerDiskQuota payload.
+type ContainerDiskQuota struct {
+	SizeBytes int64
+}
+
 // ContainerResources exposes the cgroup v2 knobs supported per container.
 type ContainerResources struct {
 	MemoryLimitBytes *int64
@@ -327,6 +402,20 @@ type ContainerResources struct {
 	PidsLimit        *int64
 }
 
+// ContainerLogRotation mirrors the v1beta1 ContainerLogRotation payload.
+type ContainerLogRotation struct {
+	MaxSizeMB int64
+	MaxFiles  int
+}
+
+// ContainerLifecycle mirrors the v1beta1 ContainerLifecycle payload.
+type ContainerLifecycle struct {
+	PostStart            []string
+	PreStop              []string
+	TimeoutSeconds       int
+	FailOnPostStartError bool
+}
+
 type ContainerStatus struct {
 	Name string // Container name/ID
 	ID   string // Container ID (same as Name)
@@ -342,6 +431,18 @@ type ContainerStatus struct {
 	FinishTime   time.Time
 	ExitCode     int
 	ExitSignal   string
+	// OOMKilled, LastOOM, and OOMKillCount mirror the v1beta1 OOM fields:
+	// whether the most recent exit was an OOM kill, when the latest OOM kill
+	// was first observed, and the memory.events oom_kill count last read.
+	OOMKilled    bool
+	LastOOM      time.Time
+	OOMKillCount int
+	// ImageDigest is the manifest digest of the image the container was
+	// created from. Mirrors the v1beta1 ContainerStatus.ImageDigest field.
+	ImageDigest string
+	// TerminationMessage mirrors the v1beta1 ContainerStatus.TerminationMessage
+	// field.
+	TerminationMessage string
 	// Repos reports the per-repo outcome of kuketty's pre-Serve clone/fetch
 	// step. Mirrors the v1beta1 ContainerStatus.Repos payload. Issue #617.
 	Repos []RepoStatus
@@ -408,3 +509,22 @@ const (
 	RestartPolicyOnFailure = "on-failure"
 	RestartPolicyNever     = "never"
 )
+
+// ImagePullPolicy values for ContainerSpec.ImagePullPolicy. Empty/unset is
+// treated as ImagePullPolicyIfNotPresent, the pre-policy behavior.
+const (
+	ImagePullPolicyAlways       = "Always"
+	ImagePullPolicyIfNotPresent = "IfNotPresent"
+	ImagePullPolicyNever        = "Never"
+)
+
+// SeccompProfile values for ContainerSpec.SeccompProfile. Any other value is
+// the absolute path of a JSON seccomp profile.
+const (
+	SeccompProfileRuntimeDefault = "runtime-default"
+	SeccompProfileUnconfined     = "unconfined"
+)
+
+// AppArmorProfileUnconfined runs the container without an AppArmor profile.
+// Any other non-empty ContainerSpec.AppArmorProfile names a loaded profile.
+const AppArmorProfileUnconfined = "unconfined"
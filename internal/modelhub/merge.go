@@ -54,6 +54,36 @@ func ApplySpaceDefaultsToContainer(space Space, container *ContainerSpec) {
 	}
 }
 
+// ResolveCellResources returns the cgroup limits a cell inherits from its
+// space and realm. Precedence, field by field:
+//
+//	Space.Spec.DefaultResources > Realm.Spec.DefaultResources
+//
+// Container resources are not merged here: they limit the container's own
+// cgroup, which nests under the cell's, so the kernel enforces the tighter
+// of the two. Returns nil when neither level sets a limit.
+func ResolveCellResources(realm Realm, space Space) *ContainerResources {
+	out := cloneResources(realm.Spec.DefaultResources)
+	override := space.Spec.DefaultResources
+	if override == nil {
+		return out
+	}
+	if out == nil {
+		return cloneResources(override)
+	}
+	merged := cloneResources(override)
+	if merged.MemoryLimitBytes == nil {
+		merged.MemoryLimitBytes = out.MemoryLimitBytes
+	}
+	if merged.CPUShares == nil {
+		merged.CPUShares = out.CPUShares
+	}
+	if merged.PidsLimit == nil {
+		merged.PidsLimit = out.PidsLimit
+	}
+	return merged
+}
+
 func cloneCapabilities(in *ContainerCapabilities) *ContainerCapabilities {
 	if in == nil {
 		return nil
@@ -254,3 +254,47 @@ func TestApplySpaceDefaultsToContainer_DefaultsDeepCopied(t *testing.T) {
 		t.Errorf("Resources.MemoryLimitBytes leaked: got %d", *defaults.Resources.MemoryLimitBytes)
 	}
 }
+
+func TestResolveCellResources(t *testing.T) {
+	realmLimits := &intmodel.ContainerResources{
+		MemoryLimitBytes: ptrInt64(1 << 30),
+		PidsLimit:        ptrInt64(512),
+	}
+	tests := []struct {
+		name  string
+		realm *intmodel.ContainerResources
+		space *intmodel.ContainerResources
+		want  *intmodel.ContainerResources
+	}{
+		{name: "neither level sets limits", want: nil},
+		{name: "realm default inherited", realm: realmLimits, want: realmLimits},
+		{
+			name:  "space only",
+			space: &intmodel.ContainerResources{CPUShares: ptrInt64(512)},
+			want:  &intmodel.ContainerResources{CPUShares: ptrInt64(512)},
+		},
+		{
+			name:  "space overrides realm field by field",
+			realm: realmLimits,
+			space: &intmodel.ContainerResources{PidsLimit: ptrInt64(64), CPUShares: ptrInt64(512)},
+			want: &intmodel.ContainerResources{
+				MemoryLimitBytes: ptrInt64(1 << 30),
+				CPUShares:        ptrInt64(512),
+				PidsLimit:        ptrInt64(64),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			realm := intmodel.Realm{Spec: intmodel.RealmSpec{DefaultResources: tt.realm}}
+			space := intmodel.Space{Spec: intmodel.SpaceSpec{DefaultResources: tt.space}}
+			got := intmodel.ResolveCellResources(realm, space)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveCellResources() = %+v, want %+v", got, tt.want)
+			}
+			if got != nil && (got == tt.realm || got == tt.space) {
+				t.Error("ResolveCellResources() aliases a parent's limits; want a copy")
+			}
+		})
+	}
+}
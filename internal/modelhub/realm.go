@@ -27,6 +27,9 @@ type Realm struct {
 type RealmMetadata struct {
 	Name   string
 	Labels map[string]string
+	// Annotations mirror v1beta1.RealmMetadata.Annotations:
+	// non-identifying metadata that round-trips but is never selected on.
+	Annotations map[string]string
 	// Generation is a monotonic counter bumped by a writer on each
 	// spec-changing update. Defaults to zero; phase 3 (issue #596 follow-up)
 	// wires the writers to populate it. See ObservedGeneration on the status.
@@ -36,6 +39,25 @@ type RealmMetadata struct {
 type RealmSpec struct {
 	Namespace           string
 	RegistryCredentials []RegistryCredentials
+	// Snapshotter is the default containerd snapshotter for the realm's
+	// containers. Empty uses containerd's default.
+	Snapshotter string
+	// PauseImage is the image of the realm's default cell root containers.
+	// Empty uses ctr.DefaultRootContainerImage.
+	PauseImage string
+	// DefaultResources mirrors v1beta1.RealmSpec.DefaultResources.
+	DefaultResources *ContainerResources
+	// AllowPrivileged permits privileged containers in the realm's cells.
+	AllowPrivileged bool
+	// Quota mirrors v1beta1.RealmSpec.Quota.
+	Quota *ResourceQuota
+}
+
+// ResourceQuota mirrors v1beta1.ResourceQuota.
+type ResourceQuota struct {
+	MaxCells       int
+	MaxMemoryBytes int64
+	MaxCPUShares   int64
 }
 
 // RegistryCredentials contains authentication information for a container registry.
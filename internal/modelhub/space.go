@@ -27,6 +27,9 @@ type Space struct {
 type SpaceMetadata struct {
 	Name   string
 	Labels map[string]string
+	// Annotations mirror v1beta1.SpaceMetadata.Annotations:
+	// non-identifying metadata that round-trips but is never selected on.
+	Annotations map[string]string
 	// Generation is a monotonic counter bumped by a writer on each
 	// spec-changing update. Defaults to zero; phase 3 wires the writers to
 	// populate it. See ObservedGeneration on the status.
@@ -38,13 +41,31 @@ type SpaceSpec struct {
 	CNIConfigPath string
 	Network       *SpaceNetwork
 	Defaults      *SpaceDefaults
+	// DefaultResources mirrors v1beta1.SpaceSpec.DefaultResources.
+	DefaultResources *ContainerResources
+	// Quota mirrors v1beta1.SpaceSpec.Quota.
+	Quota *ResourceQuota
 }
 
 // SpaceNetwork groups network-scoped policy applied to the space bridge.
+// DualStack adds IPv6Subnet as a second host-local IPAM range next to the
+// space's IPv4 subnet; one is not accepted without the other.
 type SpaceNetwork struct {
-	Egress *EgressPolicy
+	Policy     NetworkPolicy
+	Egress     *EgressPolicy
+	IPv6Subnet string
+	DualStack  bool
 }
 
+// NetworkPolicy is a space's cross-space reachability policy. Empty means
+// NetworkPolicyIsolated.
+type NetworkPolicy string
+
+const (
+	NetworkPolicyIsolated NetworkPolicy = "isolated"
+	NetworkPolicyOpen     NetworkPolicy = "open"
+)
+
 // EgressPolicy constrains outbound traffic leaving the space bridge. nil
 // means unconstrained; EgressDefaultAllow with no allow rules matches the
 // same unconstrained behavior.
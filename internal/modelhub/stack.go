@@ -27,6 +27,9 @@ type Stack struct {
 type StackMetadata struct {
 	Name   string
 	Labels map[string]string
+	// Annotations mirror v1beta1.StackMetadata.Annotations:
+	// non-identifying metadata that round-trips but is never selected on.
+	Annotations map[string]string
 	// Generation is a monotonic counter bumped by a writer on each
 	// spec-changing update. Defaults to zero; phase 3 wires the writers to
 	// populate it. See ObservedGeneration on the status.
@@ -28,15 +28,21 @@ import (
 	"github.com/eminwux/kukeon/internal/errdefs"
 )
 
-// Enforcer applies and removes per-space egress policies on the host
-// firewall. The interface is narrow so tests and `--no-daemon` paths can
-// substitute a no-op implementation.
+// Enforcer applies and removes per-space egress policies and cross-space
+// isolation on the host firewall. The interface is narrow so tests and
+// `--no-daemon` paths can substitute a no-op implementation.
 type Enforcer interface {
 	// Apply installs the policy idempotently. A nil policy is a no-op.
 	Apply(ctx context.Context, p *Policy) error
 	// Remove tears down the policy for the given realm+space. It is safe
 	// to call when no policy was installed.
 	Remove(ctx context.Context, realmName, spaceName string) error
+	// ApplyIsolation installs the space's isolation idempotently. A nil
+	// isolation is a no-op.
+	ApplyIsolation(ctx context.Context, i *Isolation) error
+	// RemoveIsolation tears down the isolation for the given realm+space.
+	// It is safe to call when none was installed.
+	RemoveIsolation(ctx context.Context, realmName, spaceName string) error
 }
 
 // NoopEnforcer satisfies Enforcer without touching the host firewall. It is
@@ -44,8 +50,10 @@ type Enforcer interface {
 // mutate iptables (e.g., `--no-daemon` read-only clients).
 type NoopEnforcer struct{}
 
-func (NoopEnforcer) Apply(_ context.Context, _ *Policy) error    { return nil }
-func (NoopEnforcer) Remove(_ context.Context, _, _ string) error { return nil }
+func (NoopEnforcer) Apply(_ context.Context, _ *Policy) error             { return nil }
+func (NoopEnforcer) Remove(_ context.Context, _, _ string) error          { return nil }
+func (NoopEnforcer) ApplyIsolation(_ context.Context, _ *Isolation) error { return nil }
+func (NoopEnforcer) RemoveIsolation(_ context.Context, _, _ string) error { return nil }
 
 // CommandRunner executes an iptables invocation and returns its combined
 // stdout+stderr. Tests inject a fake to capture invocations and return
@@ -135,7 +143,7 @@ func (e *IptablesEnforcer) Remove(ctx context.Context, realmName, spaceName stri
 
 	e.logDropCounter(ctx, chain, realmName, spaceName)
 
-	if err := e.deleteJumpsToChain(ctx, chain); err != nil {
+	if err := e.deleteJumpsToChain(ctx, MasterChainName, chain); err != nil {
 		return fmt.Errorf("%w: %w", errdefs.ErrEgressRemove, err)
 	}
 
@@ -148,6 +156,77 @@ func (e *IptablesEnforcer) Remove(ctx context.Context, realmName, spaceName stri
 	return nil
 }
 
+// ApplyIsolation creates/flushes the per-space isolation chain, loads its
+// rules, and wires it into KUKEON-ISOLATION, which is hooked at the head of
+// KUKEON-EGRESS. A nil isolation is a no-op.
+func (e *IptablesEnforcer) ApplyIsolation(ctx context.Context, i *Isolation) error {
+	if i == nil {
+		return nil
+	}
+	if err := e.ensureIsolationMasterChain(ctx); err != nil {
+		return fmt.Errorf("%w: %w", errdefs.ErrIsolationApply, err)
+	}
+	chain := i.ChainName()
+
+	if err := e.ensureChain(ctx, chain); err != nil {
+		return fmt.Errorf("%w: %w", errdefs.ErrIsolationApply, err)
+	}
+	if _, err := e.runner.Run(ctx, "-F", chain); err != nil {
+		return fmt.Errorf("%w: flush %s: %w", errdefs.ErrIsolationApply, chain, err)
+	}
+	for _, rule := range BuildIsolationRules(i) {
+		if _, err := e.runner.Run(ctx, ruleArgs(rule)...); err != nil {
+			return fmt.Errorf("%w: %w", errdefs.ErrIsolationApply, err)
+		}
+	}
+	if err := e.ensureRule(ctx, IsolationDispatchRule(i)); err != nil {
+		return fmt.Errorf("%w: dispatch: %w", errdefs.ErrIsolationApply, err)
+	}
+
+	e.logger.InfoContext(ctx, "applied space isolation",
+		"realm", i.RealmName,
+		"space", i.SpaceName,
+		"chain", chain,
+		"subnet", i.Subnet,
+		"peers", i.Peers,
+	)
+	return nil
+}
+
+// RemoveIsolation deletes every KUKEON-ISOLATION jump to the per-space chain,
+// then flushes and deletes the chain. The shared KUKEON-ISOLATION chain and
+// its hook stay in place. Idempotent.
+func (e *IptablesEnforcer) RemoveIsolation(ctx context.Context, realmName, spaceName string) error {
+	chain := isolationChainName(realmName, spaceName)
+	if err := e.deleteJumpsToChain(ctx, IsolationMasterChainName, chain); err != nil {
+		return fmt.Errorf("%w: %w", errdefs.ErrIsolationRemove, err)
+	}
+	if _, err := e.runner.Run(ctx, "-F", chain); err != nil {
+		e.logger.DebugContext(ctx, "flush chain (likely absent)", "chain", chain, "err", err)
+	}
+	if _, err := e.runner.Run(ctx, "-X", chain); err != nil {
+		e.logger.DebugContext(ctx, "delete chain (likely absent)", "chain", chain, "err", err)
+	}
+	return nil
+}
+
+// ensureIsolationMasterChain makes sure KUKEON-ISOLATION exists and is the
+// first rule of KUKEON-EGRESS (itself hooked into FORWARD), so isolation
+// drops are evaluated before any per-space egress chain ACCEPTs.
+func (e *IptablesEnforcer) ensureIsolationMasterChain(ctx context.Context) error {
+	if err := e.ensureMasterChain(ctx); err != nil {
+		return err
+	}
+	if err := e.ensureChain(ctx, IsolationMasterChainName); err != nil {
+		return err
+	}
+	if _, err := e.runner.Run(ctx, "-C", MasterChainName, "-j", IsolationMasterChainName); err == nil {
+		return nil
+	}
+	_, err := e.runner.Run(ctx, "-I", MasterChainName, "1", "-j", IsolationMasterChainName)
+	return err
+}
+
 func (e *IptablesEnforcer) ensureMasterChain(ctx context.Context) error {
 	if err := e.ensureChain(ctx, MasterChainName); err != nil {
 		return err
@@ -176,18 +255,19 @@ func (e *IptablesEnforcer) ensureRule(ctx context.Context, r Rule) error {
 	return err
 }
 
-// deleteJumpsToChain enumerates FORWARD/KUKEON-EGRESS rules and deletes
-// every entry that jumps to the given per-space chain. It tolerates a
-// missing master chain (treats it as nothing to remove).
-func (e *IptablesEnforcer) deleteJumpsToChain(ctx context.Context, chain string) error {
-	out, err := e.runner.Run(ctx, "-S", MasterChainName)
+// deleteJumpsToChain enumerates the master chain's rules (KUKEON-EGRESS or
+// KUKEON-ISOLATION) and deletes every entry that jumps to the given
+// per-space chain. It tolerates a missing master chain (treats it as nothing
+// to remove).
+func (e *IptablesEnforcer) deleteJumpsToChain(ctx context.Context, master, chain string) error {
+	out, err := e.runner.Run(ctx, "-S", master)
 	if err != nil {
 		// Master chain likely absent — nothing to remove.
 		return nil
 	}
 	for _, line := range strings.Split(string(out), "\n") {
 		line = strings.TrimSpace(line)
-		if line == "" || !strings.HasPrefix(line, "-A "+MasterChainName+" ") {
+		if line == "" || !strings.HasPrefix(line, "-A "+master+" ") {
 			continue
 		}
 		if !strings.Contains(line, " -j "+chain) {
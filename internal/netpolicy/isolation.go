@@ -0,0 +1,171 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package netpolicy
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+)
+
+// IsolationMasterChainName is the shared chain holding one "jump to per-space
+// isolation chain" entry per isolated space. It is hooked at the head of
+// MasterChainName, so isolation drops run before any per-space egress chain
+// can ACCEPT a packet. Every packet between two spaces is sourced from a
+// kukeon bridge and therefore passes through MasterChainName.
+const IsolationMasterChainName = "KUKEON-ISOLATION"
+
+// Isolation is the validated cross-space isolation of one space: traffic
+// between Subnet and any Peers CIDR is dropped in both directions, except
+// traffic that stays inside Subnet.
+type Isolation struct {
+	RealmName string
+	SpaceName string
+	// Subnet is the space's IPv4 subnet.
+	Subnet string
+	// Peers are the IPv4 CIDRs holding other spaces' subnets. A peer may
+	// contain Subnet itself (e.g. the allocator's parent pool); intra-space
+	// traffic is returned before the drops.
+	Peers []string
+}
+
+// ParseNetworkPolicy normalizes a space's network policy. Empty means
+// isolated.
+func ParseNetworkPolicy(p intmodel.NetworkPolicy) (intmodel.NetworkPolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(string(p))) {
+	case "", string(intmodel.NetworkPolicyIsolated):
+		return intmodel.NetworkPolicyIsolated, nil
+	case string(intmodel.NetworkPolicyOpen):
+		return intmodel.NetworkPolicyOpen, nil
+	default:
+		return "", fmt.Errorf("%w: %q", errdefs.ErrInvalidNetworkPolicy, string(p))
+	}
+}
+
+// NewIsolation validates subnet and peers and returns the space's isolation.
+// Peers are normalized to network form and deduplicated in order.
+func NewIsolation(realmName, spaceName, subnet string, peers []string) (*Isolation, error) {
+	self, err := parseIPv4CIDR(subnet)
+	if err != nil {
+		return nil, err
+	}
+	out := &Isolation{RealmName: realmName, SpaceName: spaceName, Subnet: self}
+	seen := make(map[string]struct{}, len(peers))
+	for _, p := range peers {
+		peer, pErr := parseIPv4CIDR(p)
+		if pErr != nil {
+			return nil, pErr
+		}
+		if _, dup := seen[peer]; dup {
+			continue
+		}
+		seen[peer] = struct{}{}
+		out.Peers = append(out.Peers, peer)
+	}
+	return out, nil
+}
+
+func parseIPv4CIDR(s string) (string, error) {
+	ip, ipNet, err := net.ParseCIDR(strings.TrimSpace(s))
+	if err != nil || ip.To4() == nil {
+		return "", fmt.Errorf("%w: %q", errdefs.ErrIsolationInvalidSubnet, s)
+	}
+	return ipNet.String(), nil
+}
+
+// ChainName returns the deterministic per-space isolation chain name.
+// Format: "KUKE-ISO-<8-hex-fnv>", hashed like the egress chain.
+func (i *Isolation) ChainName() string {
+	return isolationChainName(i.RealmName, i.SpaceName)
+}
+
+func isolationChainName(realmName, spaceName string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(realmName + "/" + spaceName))
+	return fmt.Sprintf("KUKE-ISO-%08x", h.Sum32())
+}
+
+// CommentTag returns the per-space --comment prefix, shared with the egress
+// chain so `iptables -S | grep kukeon:<realm>:<space>` shows both.
+func (i *Isolation) CommentTag() string {
+	return fmt.Sprintf("kukeon:%s:%s", i.RealmName, i.SpaceName)
+}
+
+// BuildIsolationRules returns the ordered iptables rules of the per-space
+// isolation chain:
+//  1. RETURN for traffic inside Subnet, so a peer that contains Subnet does
+//     not cut the space off from itself.
+//  2. Per peer, DROP Subnet→peer and peer→Subnet.
+//
+// Anything else falls off the end of the chain and returns to the egress
+// dispatch. The generator is pure: no I/O, no iptables invocations.
+func BuildIsolationRules(i *Isolation) []Rule {
+	chain := i.ChainName()
+	tag := i.CommentTag()
+	rules := make([]Rule, 0, 1+2*len(i.Peers))
+
+	rules = append(rules, Rule{
+		Op:    "-A",
+		Chain: chain,
+		Args: []string{
+			"-s", i.Subnet, "-d", i.Subnet,
+			"-m", "comment", "--comment", tag + ":isolation:local",
+			"-j", "RETURN",
+		},
+	})
+	for _, peer := range i.Peers {
+		rules = append(rules,
+			Rule{
+				Op:    "-A",
+				Chain: chain,
+				Args: []string{
+					"-s", i.Subnet, "-d", peer,
+					"-m", "comment", "--comment", tag + ":isolation:to=" + peer,
+					"-j", "DROP",
+				},
+			},
+			Rule{
+				Op:    "-A",
+				Chain: chain,
+				Args: []string{
+					"-s", peer, "-d", i.Subnet,
+					"-m", "comment", "--comment", tag + ":isolation:from=" + peer,
+					"-j", "DROP",
+				},
+			},
+		)
+	}
+	return rules
+}
+
+// IsolationDispatchRule returns the "-A KUKEON-ISOLATION -j <per-space>" rule.
+// It carries no interface match: the per-space chain selects its traffic by
+// subnet, which covers both directions.
+func IsolationDispatchRule(i *Isolation) Rule {
+	return Rule{
+		Op:    "-A",
+		Chain: IsolationMasterChainName,
+		Args: []string{
+			"-m", "comment", "--comment", i.CommentTag() + ":isolation:dispatch",
+			"-j", i.ChainName(),
+		},
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package netpolicy_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	intmodel "github.com/eminwux/kukeon/internal/modelhub"
+	"github.com/eminwux/kukeon/internal/netpolicy"
+)
+
+func TestParseNetworkPolicy(t *testing.T) {
+	cases := map[intmodel.NetworkPolicy]intmodel.NetworkPolicy{
+		"":         intmodel.NetworkPolicyIsolated,
+		"isolated": intmodel.NetworkPolicyIsolated,
+		" Open ":   intmodel.NetworkPolicyOpen,
+		"open":     intmodel.NetworkPolicyOpen,
+		"ISOLATED": intmodel.NetworkPolicyIsolated,
+	}
+	for in, want := range cases {
+		got, err := netpolicy.ParseNetworkPolicy(in)
+		if err != nil || got != want {
+			t.Errorf("ParseNetworkPolicy(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := netpolicy.ParseNetworkPolicy("closed"); !errors.Is(err, errdefs.ErrInvalidNetworkPolicy) {
+		t.Errorf("ParseNetworkPolicy(closed) = %v, want ErrInvalidNetworkPolicy", err)
+	}
+}
+
+func TestNewIsolation_RejectsInvalidSubnets(t *testing.T) {
+	cases := []struct {
+		subnet string
+		peers  []string
+	}{
+		{subnet: "", peers: nil},
+		{subnet: "10.88.1.0", peers: nil},
+		{subnet: "fd00:88:1::/64", peers: nil},
+		{subnet: "10.88.1.0/24", peers: []string{"not-a-cidr"}},
+		{subnet: "10.88.1.0/24", peers: []string{"fd00::/8"}},
+	}
+	for _, tc := range cases {
+		if _, err := netpolicy.NewIsolation("main", "blog", tc.subnet, tc.peers); !errors.Is(
+			err, errdefs.ErrIsolationInvalidSubnet,
+		) {
+			t.Errorf("NewIsolation(%q, %v) = %v, want ErrIsolationInvalidSubnet", tc.subnet, tc.peers, err)
+		}
+	}
+}
+
+func TestBuildIsolationRules_FromSubnets(t *testing.T) {
+	iso, err := netpolicy.NewIsolation("main", "blog", "10.88.1.7/24",
+		[]string{"10.88.0.0/16", "10.99.0.0/16", "10.88.0.0/16"})
+	if err != nil {
+		t.Fatalf("NewIsolation: %v", err)
+	}
+	if iso.Subnet != "10.88.1.0/24" {
+		t.Errorf("Subnet = %q, want network form 10.88.1.0/24", iso.Subnet)
+	}
+	if !slices.Equal(iso.Peers, []string{"10.88.0.0/16", "10.99.0.0/16"}) {
+		t.Errorf("Peers = %v, want deduplicated [10.88.0.0/16 10.99.0.0/16]", iso.Peers)
+	}
+
+	rules := netpolicy.BuildIsolationRules(iso)
+	want := []string{
+		"-s 10.88.1.0/24 -d 10.88.1.0/24 -m comment --comment kukeon:main:blog:isolation:local -j RETURN",
+		"-s 10.88.1.0/24 -d 10.88.0.0/16 -m comment --comment kukeon:main:blog:isolation:to=10.88.0.0/16 -j DROP",
+		"-s 10.88.0.0/16 -d 10.88.1.0/24 -m comment --comment kukeon:main:blog:isolation:from=10.88.0.0/16 -j DROP",
+		"-s 10.88.1.0/24 -d 10.99.0.0/16 -m comment --comment kukeon:main:blog:isolation:to=10.99.0.0/16 -j DROP",
+		"-s 10.99.0.0/16 -d 10.88.1.0/24 -m comment --comment kukeon:main:blog:isolation:from=10.99.0.0/16 -j DROP",
+	}
+	if len(rules) != len(want) {
+		t.Fatalf("got %d rules, want %d: %+v", len(rules), len(want), rules)
+	}
+	for i, r := range rules {
+		if r.Op != "-A" || r.Chain != iso.ChainName() {
+			t.Errorf("rule %d = %s %s, want -A %s", i, r.Op, r.Chain, iso.ChainName())
+		}
+		if got := strings.Join(r.Args, " "); got != want[i] {
+			t.Errorf("rule %d args = %q, want %q", i, got, want[i])
+		}
+	}
+}
+
+func TestIsolationChainName_SharesEgressHash(t *testing.T) {
+	iso, err := netpolicy.NewIsolation("main", "blog", "10.88.1.0/24", nil)
+	if err != nil {
+		t.Fatalf("NewIsolation: %v", err)
+	}
+	p := netpolicy.NewAdmitAllPolicy("main", "blog", "br")
+	if !strings.HasPrefix(iso.ChainName(), "KUKE-ISO-") {
+		t.Errorf("ChainName = %q, want KUKE-ISO- prefix", iso.ChainName())
+	}
+	if strings.TrimPrefix(iso.ChainName(), "KUKE-ISO-") != strings.TrimPrefix(p.ChainName(), "KUKE-EGR-") {
+		t.Errorf("isolation chain %q and egress chain %q should share the space hash", iso.ChainName(), p.ChainName())
+	}
+}
+
+func TestEnforcer_ApplyIsolationHooksAheadOfEgress(t *testing.T) {
+	runner := &fakeRunner{
+		respond: map[string]fakeResp{
+			"-C KUKEON-EGRESS -j KUKEON-ISOLATION": {err: errors.New("absent")},
+		},
+	}
+	e := newEnforcer(runner)
+	iso, err := netpolicy.NewIsolation("main", "blog", "10.88.1.0/24", []string{"10.88.0.0/16"})
+	if err != nil {
+		t.Fatalf("NewIsolation: %v", err)
+	}
+	dispatch := netpolicy.IsolationDispatchRule(iso)
+	runner.respond[strings.Join(append([]string{"-C", dispatch.Chain}, dispatch.Args...), " ")] = fakeResp{
+		err: errors.New("absent"),
+	}
+
+	if err = e.ApplyIsolation(context.Background(), iso); err != nil {
+		t.Fatalf("ApplyIsolation: %v", err)
+	}
+	if !wasCalled(runner, []string{"-I", "KUKEON-EGRESS", "1", "-j", "KUKEON-ISOLATION"}) {
+		t.Errorf("expected KUKEON-ISOLATION hooked at the head of KUKEON-EGRESS; calls = %v", runner.calls)
+	}
+	if !wasCalled(runner, []string{"-F", iso.ChainName()}) {
+		t.Errorf("expected -F %s (flush); calls = %v", iso.ChainName(), runner.calls)
+	}
+	if !wasCalled(runner, append([]string{"-A", dispatch.Chain}, dispatch.Args...)) {
+		t.Errorf("expected dispatch rule; calls = %v", runner.calls)
+	}
+}
+
+func TestEnforcer_RemoveIsolationDeletesDispatchAndChain(t *testing.T) {
+	iso, err := netpolicy.NewIsolation("main", "blog", "10.88.1.0/24", nil)
+	if err != nil {
+		t.Fatalf("NewIsolation: %v", err)
+	}
+	chain := iso.ChainName()
+	runner := &fakeRunner{
+		respond: map[string]fakeResp{
+			"-S KUKEON-ISOLATION": {out: []byte(
+				"-N KUKEON-ISOLATION\n" +
+					"-A KUKEON-ISOLATION -m comment --comment \"kukeon:main:blog:isolation:dispatch\" -j " + chain + "\n",
+			)},
+		},
+	}
+	e := newEnforcer(runner)
+	if err = e.RemoveIsolation(context.Background(), "main", "blog"); err != nil {
+		t.Fatalf("RemoveIsolation: %v", err)
+	}
+	if !wasCalled(runner, []string{
+		"-D", "KUKEON-ISOLATION", "-m", "comment", "--comment", "kukeon:main:blog:isolation:dispatch", "-j", chain,
+	}) {
+		t.Errorf("expected dispatch delete; calls = %v", runner.calls)
+	}
+	if !wasCalled(runner, []string{"-X", chain}) {
+		t.Errorf("expected -X %s; calls = %v", chain, runner.calls)
+	}
+}
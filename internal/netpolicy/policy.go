@@ -14,12 +14,14 @@
 //
 // SPDX-License-Identifier: Apache-2.0
 
-// Package netpolicy renders space-level egress policies into iptables rules
-// and applies them on the host firewall. The public surface is:
+// Package netpolicy renders space-level egress policies and cross-space
+// isolation into iptables rules and applies them on the host firewall. The
+// public surface is:
 //
 //   - Policy: an already-validated policy-for-a-space, derived from a Space's
 //     intmodel.EgressPolicy.
 //   - BuildRules: pure rule generator — no I/O, no iptables invocations.
+//   - Isolation / BuildIsolationRules: the subnet drops of an isolated space.
 //   - Enforcer: interface for applying/removing rules on the host.
 //   - IptablesEnforcer: concrete enforcer that shells out to iptables.
 //
@@ -0,0 +1,167 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package preflight holds the host precondition checks kukeon relies on:
+// a cgroup v2 mount, the CNI plugin binaries and config directory, a
+// writable run path, and the staged kuketty binary `kuke attach` needs.
+// `kuke status` reports every check; realm creation runs the ones it cannot
+// proceed without so it fails before writing any metadata. Each check is a
+// plain function over paths so tests can point it at a temp directory.
+package preflight
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+// KukettyStagedPath is where the runner stages the kuketty binary under the
+// run path for the attachable bind mount.
+func KukettyStagedPath(runPath string) string {
+	return filepath.Join(runPath, "bin", "kuketty")
+}
+
+// RequiredCNIPlugins lists the binaries CNI execution needs in the CNI bin
+// dir: `bridge` lays the per-realm bridge network and `loopback` populates
+// the per-container lo interface. Returns a fresh slice per call.
+func RequiredCNIPlugins() []string {
+	return []string{"bridge", "loopback"}
+}
+
+// CgroupV2 reads cgroup.controllers under root, the canonical probe for a
+// unified cgroup v2 mount, and returns the controllers it advertises. A
+// missing or unreadable file fails with errdefs.ErrCgroupV2NotMounted.
+func CgroupV2(root string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(root, "cgroup.controllers"))
+	if err != nil {
+		return nil, fmt.Errorf("%w at %s: %w", errdefs.ErrCgroupV2NotMounted, root, err)
+	}
+	return strings.Fields(strings.TrimSpace(string(data))), nil
+}
+
+// MissingCNIPlugins returns the RequiredCNIPlugins not present in binDir.
+func MissingCNIPlugins(binDir string) []string {
+	var missing []string
+	for _, plugin := range RequiredCNIPlugins() {
+		if _, err := os.Stat(filepath.Join(binDir, plugin)); err != nil {
+			missing = append(missing, plugin)
+		}
+	}
+	return missing
+}
+
+// CNIConfDir checks that the CNI network-config directory exists. `kuke init`
+// creates it; a missing one fails with errdefs.ErrCNIConfDirNotFound.
+func CNIConfDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", errdefs.ErrCNIConfDirNotFound, dir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%w: %s is not a directory", errdefs.ErrCNIConfDirNotFound, dir)
+	}
+	return nil
+}
+
+// RunPathWritable checks that a file can be created under runPath by
+// creating and removing a probe file. A run path that does not exist yet is
+// checked at its nearest existing ancestor, since kukeon creates it on first
+// write. Fails with errdefs.ErrRunPathNotWritable.
+func RunPathWritable(runPath string) error {
+	dir := runPath
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("%w: %s is not a directory", errdefs.ErrRunPathNotWritable, dir)
+			}
+			break
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%w: %w", errdefs.ErrRunPathNotWritable, err)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return fmt.Errorf("%w: %s: %w", errdefs.ErrRunPathNotWritable, runPath, err)
+		}
+		dir = parent
+	}
+
+	probe, err := os.CreateTemp(dir, ".kukeon-preflight-*")
+	if err != nil {
+		return fmt.Errorf("%w: %w", errdefs.ErrRunPathNotWritable, err)
+	}
+	name := probe.Name()
+	_ = probe.Close()
+	_ = os.Remove(name)
+	return nil
+}
+
+// KukettyStaged checks that the kuketty binary is staged under runPath. The
+// runner stages it on the first attachable container, so its absence on a
+// fresh host is expected; fails with errdefs.ErrKukettyNotStaged, or the
+// same error when the staged file is empty or not executable.
+func KukettyStaged(runPath string) error {
+	path := KukettyStagedPath(runPath)
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", errdefs.ErrKukettyNotStaged, path, err)
+	}
+	if info.Size() == 0 || info.Mode().Perm()&0o111 == 0 {
+		return fmt.Errorf("%w: %s is empty or not executable", errdefs.ErrKukettyNotStaged, path)
+	}
+	return nil
+}
+
+// failure is the aggregate Join returns. It unwraps to ErrPreflightFailed
+// and every problem, so callers can match the gate as a whole or any one
+// precondition.
+type failure struct {
+	problems []error
+}
+
+func (f *failure) Error() string {
+	msgs := make([]string, 0, len(f.problems))
+	for _, p := range f.problems {
+		msgs = append(msgs, p.Error())
+	}
+	return fmt.Sprintf("%s: %s", errdefs.ErrPreflightFailed, strings.Join(msgs, "; "))
+}
+
+func (f *failure) Unwrap() []error {
+	return append([]error{errdefs.ErrPreflightFailed}, f.problems...)
+}
+
+// Join aggregates the failed checks of a preflight gate into one
+// errdefs.ErrPreflightFailed listing every problem, so the operator fixes
+// them in one pass instead of one per retry. nil entries are dropped;
+// returns nil when nothing failed.
+func Join(problems ...error) error {
+	var failed []error
+	for _, p := range problems {
+		if p != nil {
+			failed = append(failed, p)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return &failure{problems: failed}
+}
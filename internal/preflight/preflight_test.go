@@ -0,0 +1,156 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package preflight_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/preflight"
+)
+
+func TestCgroupV2(t *testing.T) {
+	root := t.TempDir()
+	if _, err := preflight.CgroupV2(root); !errors.Is(err, errdefs.ErrCgroupV2NotMounted) {
+		t.Fatalf("no cgroup.controllers: got %v, want ErrCgroupV2NotMounted", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "cgroup.controllers"), []byte("cpu memory pids\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := preflight.CgroupV2(root)
+	if err != nil {
+		t.Fatalf("mounted: %v", err)
+	}
+	if want := []string{"cpu", "memory", "pids"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("controllers = %v, want %v", got, want)
+	}
+}
+
+func TestMissingCNIPlugins(t *testing.T) {
+	binDir := t.TempDir()
+	if got := preflight.MissingCNIPlugins(binDir); !reflect.DeepEqual(got, preflight.RequiredCNIPlugins()) {
+		t.Errorf("empty bin dir: missing = %v, want all of %v", got, preflight.RequiredCNIPlugins())
+	}
+
+	if err := os.WriteFile(filepath.Join(binDir, "bridge"), []byte{}, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if got := preflight.MissingCNIPlugins(binDir); !reflect.DeepEqual(got, []string{"loopback"}) {
+		t.Errorf("bridge present: missing = %v, want [loopback]", got)
+	}
+}
+
+func TestCNIConfDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := preflight.CNIConfDir(dir); err != nil {
+		t.Errorf("existing dir: %v", err)
+	}
+	if err := preflight.CNIConfDir(filepath.Join(dir, "missing")); !errors.Is(err, errdefs.ErrCNIConfDirNotFound) {
+		t.Errorf("missing dir: got %v, want ErrCNIConfDirNotFound", err)
+	}
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, []byte{}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := preflight.CNIConfDir(file); !errors.Is(err, errdefs.ErrCNIConfDirNotFound) {
+		t.Errorf("regular file: got %v, want ErrCNIConfDirNotFound", err)
+	}
+}
+
+func TestRunPathWritable(t *testing.T) {
+	dir := t.TempDir()
+	if err := preflight.RunPathWritable(dir); err != nil {
+		t.Errorf("writable dir: %v", err)
+	}
+	if err := preflight.RunPathWritable(filepath.Join(dir, "not", "yet")); err != nil {
+		t.Errorf("not-yet-created run path under a writable dir: %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("probe left files behind: %v", entries)
+	}
+
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, []byte{}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := preflight.RunPathWritable(file); !errors.Is(err, errdefs.ErrRunPathNotWritable) {
+		t.Errorf("run path is a file: got %v, want ErrRunPathNotWritable", err)
+	}
+
+	if os.Geteuid() == 0 {
+		return // root writes through 0o500
+	}
+	readOnly := filepath.Join(dir, "ro")
+	if err := os.Mkdir(readOnly, 0o500); err != nil {
+		t.Fatal(err)
+	}
+	if err := preflight.RunPathWritable(readOnly); !errors.Is(err, errdefs.ErrRunPathNotWritable) {
+		t.Errorf("read-only dir: got %v, want ErrRunPathNotWritable", err)
+	}
+}
+
+func TestKukettyStaged(t *testing.T) {
+	runPath := t.TempDir()
+	err := preflight.KukettyStaged(runPath)
+	if !errors.Is(err, errdefs.ErrKukettyNotStaged) || !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("nothing staged: got %v, want ErrKukettyNotStaged wrapping ErrNotExist", err)
+	}
+
+	path := preflight.KukettyStagedPath(runPath)
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(path, []byte{}, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err = preflight.KukettyStaged(runPath); !errors.Is(err, errdefs.ErrKukettyNotStaged) {
+		t.Errorf("empty staged binary: got %v, want ErrKukettyNotStaged", err)
+	}
+
+	if err = os.WriteFile(path, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err = preflight.KukettyStaged(runPath); err != nil {
+		t.Errorf("staged binary: %v", err)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	if err := preflight.Join(nil, nil); err != nil {
+		t.Errorf("no problems: got %v, want nil", err)
+	}
+
+	runPath := fmt.Errorf("%w: /opt/kukeon is not a directory", errdefs.ErrRunPathNotWritable)
+	cgroup := fmt.Errorf("%w at /sys/fs/cgroup", errdefs.ErrCgroupV2NotMounted)
+	err := preflight.Join(runPath, nil, cgroup)
+	for _, want := range []error{errdefs.ErrPreflightFailed, errdefs.ErrRunPathNotWritable, errdefs.ErrCgroupV2NotMounted} {
+		if !errors.Is(err, want) {
+			t.Errorf("Join() = %v, want it to wrap %v", err, want)
+		}
+	}
+	want := "preflight failed: " + runPath.Error() + "; " + cgroup.Error()
+	if err.Error() != want {
+		t.Errorf("Join().Error() = %q, want %q", err.Error(), want)
+	}
+}
@@ -54,7 +54,7 @@
 // reads the materialized template files prepared by teamsource (#1041) and
 // produces in-memory v1beta1 documents. `--dry-run` consumers marshal the
 // Result to YAML; the apply path in step 4 (#1043) hands the same Result
-// straight to ApplyDocumentsForTeam.
+// straight to ApplyDocumentsWithOptions under the project team.
 package teamrender
 
 import (
@@ -0,0 +1,119 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing wires optional OpenTelemetry tracing into the
+// controller→runner path. Tracing is off unless an OTLP endpoint is
+// configured through the standard OTEL_EXPORTER_OTLP_ENDPOINT (or
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT) environment variable; until Setup
+// installs a provider, every Start returns a non-recording span and costs
+// next to nothing.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the tracer name every kukeon span is recorded under.
+const InstrumentationName = "github.com/eminwux/kukeon"
+
+// Attribute keys for the resource a span operates on.
+const (
+	AttrRealm     = attribute.Key("kukeon.realm")
+	AttrSpace     = attribute.Key("kukeon.space")
+	AttrStack     = attribute.Key("kukeon.stack")
+	AttrCell      = attribute.Key("kukeon.cell")
+	AttrContainer = attribute.Key("kukeon.container")
+	AttrImage     = attribute.Key("kukeon.image")
+	AttrKind      = attribute.Key("kukeon.kind")
+	AttrName      = attribute.Key("kukeon.name")
+)
+
+// Enabled reports whether the environment names an OTLP endpoint.
+func Enabled() bool {
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" ||
+		os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Setup installs the global tracer provider when Enabled and returns its
+// shutdown function, which flushes pending spans. When tracing is disabled
+// the global no-op provider is left in place and shutdown is a no-op. The
+// exporter reads the remaining OTEL_EXPORTER_OTLP_* variables (headers,
+// protocol, TLS) itself.
+func Setup(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	if !Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create otlp trace exporter: %w", err)
+	}
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewSchemaless(semconv.ServiceName(serviceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("build trace resource: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// Start opens a span named name as a child of the span carried by ctx.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(InstrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End closes span, recording err and marking the span failed when non-nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// CellAttributes returns the realm/space/stack/cell attributes for a span,
+// skipping empty names so a partially-scoped call does not emit blanks.
+func CellAttributes(realm, space, stack, cell string) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, 4)
+	for _, kv := range []attribute.KeyValue{
+		AttrRealm.String(realm),
+		AttrSpace.String(space),
+		AttrStack.String(stack),
+		AttrCell.String(cell),
+	} {
+		if kv.Value.AsString() != "" {
+			attrs = append(attrs, kv)
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,101 @@
+//go:build !integration
+
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func installRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	rec := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+	return rec
+}
+
+func TestSetup_DisabledWithoutEndpoint(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	if tracing.Enabled() {
+		t.Fatal("expected tracing to be disabled without an OTLP endpoint")
+	}
+	shutdown, err := tracing.Setup(context.Background(), "kuke")
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+	if err = shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown() error = %v", err)
+	}
+}
+
+func TestStartEnd_RecordsParentAttributesAndError(t *testing.T) {
+	rec := installRecorder(t)
+
+	ctx, parent := tracing.Start(context.Background(), "controller.CreateCell",
+		tracing.CellAttributes("default", "default", "", "web")...)
+	_, child := tracing.Start(ctx, "runner.createContainer", tracing.AttrContainer.String("app"))
+	tracing.End(child, errors.New("pull failed"))
+	tracing.End(parent, nil)
+
+	spans := rec.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 ended spans, got %d", len(spans))
+	}
+	gotChild, gotParent := spans[0], spans[1]
+
+	if gotChild.Parent().SpanID() != gotParent.SpanContext().SpanID() {
+		t.Error("child span is not parented by the controller span")
+	}
+	if gotChild.Status().Code != codes.Error || gotChild.Status().Description != "pull failed" {
+		t.Errorf("child status = %+v, want Error(pull failed)", gotChild.Status())
+	}
+	if gotParent.Status().Code != codes.Unset {
+		t.Errorf("parent status = %+v, want Unset", gotParent.Status())
+	}
+
+	want := map[attribute.Key]string{
+		tracing.AttrRealm: "default",
+		tracing.AttrSpace: "default",
+		tracing.AttrCell:  "web",
+	}
+	got := map[attribute.Key]string{}
+	for _, kv := range gotParent.Attributes() {
+		got[kv.Key] = kv.Value.AsString()
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("attribute %s = %q, want %q", key, got[key], value)
+		}
+	}
+	if _, ok := got[tracing.AttrStack]; ok {
+		t.Error("empty stack name should not be recorded as an attribute")
+	}
+}
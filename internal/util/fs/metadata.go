@@ -40,6 +40,13 @@ func MetadataRoot(baseRunPath string) string {
 	return filepath.Join(baseRunPath, consts.KukeonMetadataSubdir)
 }
 
+// BackupsDir returns the directory holding metadata backups under the
+// daemon's RunPath. It sits next to MetadataRoot, outside the tree a purge
+// removes.
+func BackupsDir(baseRunPath string) string {
+	return filepath.Join(baseRunPath, consts.KukeonBackupsSubdir)
+}
+
 // RealmMetadataDir returns the metadata directory for the given realm.
 func RealmMetadataDir(baseRunPath, realmName string) string {
 	return filepath.Join(MetadataRoot(baseRunPath), realmName)
@@ -122,6 +129,16 @@ func ContainerMetadataDir(baseRunPath, realmName, spaceName, stackName, cellName
 	)
 }
 
+// ContainerTerminationLogPath returns the host-side per-container file
+// bind-mounted at the container's terminationMessagePath. Lives under the
+// container's metadata directory so cell teardown cleans it up.
+func ContainerTerminationLogPath(baseRunPath, realmName, spaceName, stackName, cellName, containerName string) string {
+	return filepath.Join(
+		ContainerMetadataDir(baseRunPath, realmName, spaceName, stackName, cellName, containerName),
+		consts.KukeonContainerTerminationLogFile,
+	)
+}
+
 // ContainerTTYDir returns the host-side per-container directory that owns
 // the sbsh terminal socket and its capture/log siblings. It is bind-mounted
 // into the container at /run/kukeon/tty so that sbsh's unlink-and-recreate
@@ -237,6 +254,16 @@ func ContainerLogPath(baseRunPath, realmName, spaceName, stackName, cellName, co
 	)
 }
 
+// ContainerPreviousLogPath returns the host-side path the ContainerLogPath
+// file is archived to when the container starts a new task, so the output of
+// the instance before the latest restart stays readable.
+func ContainerPreviousLogPath(baseRunPath, realmName, spaceName, stackName, cellName, containerName string) string {
+	return filepath.Join(
+		ContainerMetadataDir(baseRunPath, realmName, spaceName, stackName, cellName, containerName),
+		consts.KukeonContainerPreviousLogFile,
+	)
+}
+
 // SecretScopeDir returns the metadata directory of the scope a `kind: Secret`
 // is bound to (issue #619). The scope is the deepest non-empty coordinate:
 // passing only realmName yields the realm dir, realmName+spaceName the space
@@ -41,10 +41,13 @@ func SpaceNetworkConfigPath(baseRunPath, realmName, spaceName string) (string, e
 // pinning the bridge to subnetCIDR. Pass an empty subnetCIDR to fall back to
 // the package default — left in place for tests and the legacy
 // shared-subnet path; runtime callers must allocate per-space subnets via
-// cni.SubnetAllocator and pass the result here.
-func WriteSpaceNetworkConfig(confPath, networkName, subnetCIDR string) error {
+// cni.SubnetAllocator and pass the result here. A non-empty ipv6SubnetCIDR
+// makes the network dual-stack: it is added as a second IPAM range and IPv6
+// is enabled on the bridge.
+func WriteSpaceNetworkConfig(confPath, networkName, subnetCIDR, ipv6SubnetCIDR string) error {
 	cfg := cni.NewCNINetworkConfigWithSubnet(networkName, subnetCIDR)
-	data, err := cni.BuildDefaultConflist(cfg.Name, cfg.BridgeName, cfg.SubnetCIDR)
+	cfg.IPv6SubnetCIDR = ipv6SubnetCIDR
+	data, err := cni.BuildConflist(cfg)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,343 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jsonpatch applies JSON merge patches (RFC 7386) and JSON patches
+// (RFC 6902) to raw JSON documents. It backs `kuke patch`, which edits a
+// stored resource document field by field instead of re-applying it whole.
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+// MergePatch applies an RFC 7386 merge patch to doc: objects merge key by
+// key, a null value removes the key, and any other value (arrays included)
+// replaces the target outright.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	var target, p any
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, fmt.Errorf("%w: document: %w", errdefs.ErrInvalidPatch, err)
+	}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("%w: %w", errdefs.ErrInvalidPatch, err)
+	}
+	return json.Marshal(mergeValue(target, p))
+}
+
+func mergeValue(target, patch any) any {
+	pm, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	tm, ok := target.(map[string]any)
+	if !ok {
+		tm = map[string]any{}
+	}
+	for k, v := range pm {
+		if v == nil {
+			delete(tm, k)
+			continue
+		}
+		tm[k] = mergeValue(tm[k], v)
+	}
+	return tm
+}
+
+// operation is one RFC 6902 patch operation.
+type operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Apply applies an RFC 6902 JSON patch (an array of add, remove, replace,
+// move, copy, and test operations) to doc. Operations run in order and the
+// first failing one aborts the patch; doc itself is never modified.
+func Apply(doc, patch []byte) ([]byte, error) {
+	var root any
+	if err := json.Unmarshal(doc, &root); err != nil {
+		return nil, fmt.Errorf("%w: document: %w", errdefs.ErrInvalidPatch, err)
+	}
+	var ops []operation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("%w: a JSON patch must be an array of operations: %w", errdefs.ErrInvalidPatch, err)
+	}
+	for i, op := range ops {
+		var err error
+		if root, err = applyOp(root, op); err != nil {
+			return nil, fmt.Errorf("%w: operation %d (%s %s): %w", errdefs.ErrInvalidPatch, i, op.Op, op.Path, err)
+		}
+	}
+	return json.Marshal(root)
+}
+
+func applyOp(root any, op operation) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch op.Op {
+	case "add":
+		value, valueErr := opValue(op)
+		if valueErr != nil {
+			return nil, valueErr
+		}
+		return add(root, path, value)
+	case "remove":
+		root, _, err = remove(root, path)
+		return root, err
+	case "replace":
+		value, valueErr := opValue(op)
+		if valueErr != nil {
+			return nil, valueErr
+		}
+		if root, _, err = remove(root, path); err != nil {
+			return nil, err
+		}
+		return add(root, path, value)
+	case "move":
+		from, fromErr := parsePointer(op.From)
+		if fromErr != nil {
+			return nil, fromErr
+		}
+		if isPrefix(from, path) && len(from) < len(path) {
+			return nil, fmt.Errorf("cannot move %q into its own child", op.From)
+		}
+		var value any
+		if root, value, err = remove(root, from); err != nil {
+			return nil, err
+		}
+		return add(root, path, value)
+	case "copy":
+		from, fromErr := parsePointer(op.From)
+		if fromErr != nil {
+			return nil, fromErr
+		}
+		value, getErr := get(root, from)
+		if getErr != nil {
+			return nil, getErr
+		}
+		return add(root, path, deepCopy(value))
+	case "test":
+		want, valueErr := opValue(op)
+		if valueErr != nil {
+			return nil, valueErr
+		}
+		got, getErr := get(root, path)
+		if getErr != nil {
+			return nil, getErr
+		}
+		if !reflect.DeepEqual(got, want) {
+			return nil, fmt.Errorf("test failed: value at %q does not match", op.Path)
+		}
+		return root, nil
+	default:
+		return nil, fmt.Errorf("unknown op %q", op.Op)
+	}
+}
+
+func opValue(op operation) (any, error) {
+	if len(op.Value) == 0 {
+		return nil, fmt.Errorf("op %q requires a value", op.Op)
+	}
+	var v any
+	if err := json.Unmarshal(op.Value, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// parsePointer splits an RFC 6901 JSON pointer into its unescaped reference
+// tokens. The empty pointer addresses the whole document.
+func parsePointer(ptr string) ([]string, error) {
+	if ptr == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(ptr, "/") {
+		return nil, fmt.Errorf("pointer %q must start with '/'", ptr)
+	}
+	tokens := strings.Split(ptr[1:], "/")
+	for i, tok := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(tok, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func get(root any, path []string) (any, error) {
+	cur := root
+	for _, tok := range path {
+		switch node := cur.(type) {
+		case map[string]any:
+			v, ok := node[tok]
+			if !ok {
+				return nil, fmt.Errorf("path segment %q not found", tok)
+			}
+			cur = v
+		case []any:
+			idx, err := arrayIndex(tok, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			cur = node[idx]
+		default:
+			return nil, fmt.Errorf("path segment %q is not inside an object or array", tok)
+		}
+	}
+	return cur, nil
+}
+
+// add sets value at path and returns the (possibly replaced) root. Into an
+// object it adds or replaces the member; into an array it inserts before the
+// index, with "-" appending.
+func add(root any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]any:
+		node[last] = value
+		return root, nil
+	case []any:
+		idx := len(node)
+		if last != "-" {
+			if idx, err = arrayIndex(last, len(node)); err != nil {
+				return nil, err
+			}
+		}
+		grown := make([]any, 0, len(node)+1)
+		grown = append(grown, node[:idx]...)
+		grown = append(grown, value)
+		grown = append(grown, node[idx:]...)
+		return setChild(root, path[:len(path)-1], grown)
+	default:
+		return nil, fmt.Errorf("cannot add %q: parent is not an object or array", last)
+	}
+}
+
+// remove deletes the value at path, returning the new root and the removed
+// value.
+func remove(root any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, errors.New("cannot remove the whole document")
+	}
+	parent, err := get(root, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	last := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]any:
+		v, ok := node[last]
+		if !ok {
+			return nil, nil, fmt.Errorf("path segment %q not found", last)
+		}
+		delete(node, last)
+		return root, v, nil
+	case []any:
+		idx, idxErr := arrayIndex(last, len(node)-1)
+		if idxErr != nil {
+			return nil, nil, idxErr
+		}
+		v := node[idx]
+		shrunk := make([]any, 0, len(node)-1)
+		shrunk = append(shrunk, node[:idx]...)
+		shrunk = append(shrunk, node[idx+1:]...)
+		root, err = setChild(root, path[:len(path)-1], shrunk)
+		return root, v, err
+	default:
+		return nil, nil, fmt.Errorf("cannot remove %q: parent is not an object or array", last)
+	}
+}
+
+// setChild replaces the value at path, which must already exist. Arrays are
+// rebuilt on insert and removal, so their parent has to point at the new
+// slice.
+func setChild(root any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]any:
+		node[last] = value
+	case []any:
+		idx, idxErr := arrayIndex(last, len(node)-1)
+		if idxErr != nil {
+			return nil, idxErr
+		}
+		node[idx] = value
+	}
+	return root, nil
+}
+
+func arrayIndex(tok string, limit int) (int, error) {
+	idx, err := strconv.Atoi(tok)
+	if err != nil || idx < 0 || (len(tok) > 1 && tok[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", tok)
+	}
+	if idx > limit {
+		return 0, fmt.Errorf("array index %d out of range", idx)
+	}
+	return idx, nil
+}
+
+func deepCopy(v any) any {
+	switch node := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(node))
+		for k, child := range node {
+			out[k] = deepCopy(child)
+		}
+		return out
+	case []any:
+		out := make([]any, len(node))
+		for i, child := range node {
+			out[i] = deepCopy(child)
+		}
+		return out
+	default:
+		return v
+	}
+}
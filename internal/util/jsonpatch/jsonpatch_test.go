@@ -0,0 +1,134 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpatch_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/util/jsonpatch"
+)
+
+const baseDoc = `{"metadata":{"name":"web","labels":{"env":"dev","tier":"fe"}},"spec":{"ports":[80,443],"image":"nginx"}}`
+
+func assertJSON(t *testing.T, got []byte, want string) {
+	t.Helper()
+	var g, w any
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatalf("unmarshal want: %v", err)
+	}
+	if !reflect.DeepEqual(g, w) {
+		t.Fatalf("result = %s\nwant     %s", got, want)
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	cases := []struct {
+		name  string
+		patch string
+		want  string
+	}{
+		{
+			name:  "merges nested objects",
+			patch: `{"metadata":{"labels":{"env":"prod"}}}`,
+			want:  `{"metadata":{"name":"web","labels":{"env":"prod","tier":"fe"}},"spec":{"ports":[80,443],"image":"nginx"}}`,
+		},
+		{
+			name:  "null removes a key",
+			patch: `{"metadata":{"labels":{"tier":null}}}`,
+			want:  `{"metadata":{"name":"web","labels":{"env":"dev"}},"spec":{"ports":[80,443],"image":"nginx"}}`,
+		},
+		{
+			name:  "arrays are replaced whole",
+			patch: `{"spec":{"ports":[8080]}}`,
+			want:  `{"metadata":{"name":"web","labels":{"env":"dev","tier":"fe"}},"spec":{"ports":[8080],"image":"nginx"}}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := jsonpatch.MergePatch([]byte(baseDoc), []byte(tc.patch))
+			if err != nil {
+				t.Fatalf("MergePatch() error = %v", err)
+			}
+			assertJSON(t, got, tc.want)
+		})
+	}
+}
+
+func TestMergePatch_Malformed(t *testing.T) {
+	if _, err := jsonpatch.MergePatch([]byte(baseDoc), []byte(`{`)); !errors.Is(err, errdefs.ErrInvalidPatch) {
+		t.Fatalf("MergePatch() error = %v, want ErrInvalidPatch", err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	cases := []struct {
+		name  string
+		patch string
+		want  string
+	}{
+		{
+			name:  "replace and add",
+			patch: `[{"op":"replace","path":"/spec/image","value":"nginx:1.27"},{"op":"add","path":"/metadata/labels/team","value":"a"}]`,
+			want:  `{"metadata":{"name":"web","labels":{"env":"dev","tier":"fe","team":"a"}},"spec":{"ports":[80,443],"image":"nginx:1.27"}}`,
+		},
+		{
+			name:  "array insert, append, and remove",
+			patch: `[{"op":"add","path":"/spec/ports/0","value":22},{"op":"add","path":"/spec/ports/-","value":8443},{"op":"remove","path":"/spec/ports/1"}]`,
+			want:  `{"metadata":{"name":"web","labels":{"env":"dev","tier":"fe"}},"spec":{"ports":[22,443,8443],"image":"nginx"}}`,
+		},
+		{
+			name:  "escaped pointer, move, copy, and test",
+			patch: `[{"op":"test","path":"/spec/image","value":"nginx"},{"op":"move","from":"/metadata/labels/tier","path":"/metadata/labels/a~1b"},{"op":"copy","from":"/spec/ports","path":"/spec/extra"}]`,
+			want:  `{"metadata":{"name":"web","labels":{"env":"dev","a/b":"fe"}},"spec":{"ports":[80,443],"extra":[80,443],"image":"nginx"}}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := jsonpatch.Apply([]byte(baseDoc), []byte(tc.patch))
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			assertJSON(t, got, tc.want)
+		})
+	}
+}
+
+func TestApply_Rejects(t *testing.T) {
+	for name, patch := range map[string]string{
+		"not an array":        `{"op":"add","path":"/x","value":1}`,
+		"unknown op":          `[{"op":"frob","path":"/x"}]`,
+		"missing value":       `[{"op":"add","path":"/x"}]`,
+		"missing path":        `[{"op":"remove","path":"/spec/nope"}]`,
+		"index out of range":  `[{"op":"replace","path":"/spec/ports/5","value":1}]`,
+		"failed test":         `[{"op":"test","path":"/spec/image","value":"httpd"}]`,
+		"relative pointer":    `[{"op":"remove","path":"spec/image"}]`,
+		"move into own child": `[{"op":"move","from":"/spec","path":"/spec/inner"}]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := jsonpatch.Apply([]byte(baseDoc), []byte(patch)); !errors.Is(err, errdefs.ErrInvalidPatch) {
+				t.Fatalf("Apply() error = %v, want ErrInvalidPatch", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package logrotate rotates container log files that another process keeps
+// open for appending. The containerd runtime shim opens a container's log
+// file once (cio.LogFile, O_APPEND) and never reopens it, so the classic
+// rename-and-reopen rotation would leave the shim writing into the renamed
+// segment. Rotation here is copy-then-truncate instead: the live file's bytes
+// are copied to <path>.1 and the live file is truncated in place. The shim's
+// descriptor stays valid, and because it appends, its next write lands at the
+// new end of file rather than leaving a sparse hole.
+package logrotate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// bytesPerMB converts Policy.MaxSizeMB to bytes.
+const bytesPerMB = 1024 * 1024
+
+// Policy bounds a log file. MaxFiles counts the live file, so a policy of
+// {MaxSizeMB: 10, MaxFiles: 3} keeps at most <path>, <path>.1 and <path>.2.
+// MaxFiles of 0 or 1 keeps no rotated segments: the live file is truncated.
+type Policy struct {
+	MaxSizeMB int64
+	MaxFiles  int
+}
+
+// Enabled reports whether the policy rotates at all.
+func (p Policy) Enabled() bool {
+	return p.MaxSizeMB > 0
+}
+
+// Segment returns the path of the n-th rotated segment of path.
+func Segment(path string, n int) string {
+	return path + "." + strconv.Itoa(n)
+}
+
+// Rotate rotates path when it has reached the policy's size, reporting
+// whether it did. Older segments shift up by one (<path>.1 becomes
+// <path>.2, …) and segments past MaxFiles-1 are pruned. A missing log file
+// is not an error: the container has not written anything yet.
+//
+// Bytes the writer appends between the final copy and the truncate are lost;
+// the copy loop re-reads until the file stops growing to keep that window to
+// a single write.
+func Rotate(path string, policy Policy) (bool, error) {
+	if !policy.Enabled() {
+		return false, nil
+	}
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if info.Size() < policy.MaxSizeMB*bytesPerMB {
+		return false, nil
+	}
+
+	keep := max(policy.MaxFiles-1, 0)
+	if err = shiftSegments(path, keep); err != nil {
+		return false, err
+	}
+	if keep > 0 {
+		if err = copyLive(path, Segment(path, 1)); err != nil {
+			return false, err
+		}
+	}
+	if err = os.Truncate(path, 0); err != nil {
+		return false, fmt.Errorf("truncate %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// Archive moves the live log at path to previous, replacing any earlier
+// archive, and reports whether it did. It runs before a new task opens the
+// log, so nothing is writing to path. A missing or empty log is left alone:
+// that instance produced no output, and the last archive is still the most
+// recent one worth reading.
+func Archive(path, previous string) (bool, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if info.Size() == 0 {
+		return false, nil
+	}
+	if err = os.Rename(path, previous); err != nil {
+		return false, fmt.Errorf("archive %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// shiftSegments makes room for a new <path>.1: segments at or past keep are
+// removed, and the rest move up by one, oldest first.
+func shiftSegments(path string, keep int) error {
+	if err := pruneFrom(path, max(keep, 1)); err != nil {
+		return err
+	}
+	for n := keep - 1; n >= 1; n-- {
+		err := os.Rename(Segment(path, n), Segment(path, n+1))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("shift log segment %d: %w", n, err)
+		}
+	}
+	return nil
+}
+
+// pruneFrom removes <path>.n and every higher-numbered segment, stopping at
+// the first gap. Segments left by a larger, earlier MaxFiles go this way.
+func pruneFrom(path string, n int) error {
+	for ; ; n++ {
+		err := os.Remove(Segment(path, n))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("prune log segment %d: %w", n, err)
+		}
+	}
+}
+
+// copyLive copies the live file into dst, continuing until a pass reads
+// nothing new so bytes appended during the copy are carried over too.
+func copyLive(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", src, err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o640)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", dst, err)
+	}
+	for {
+		n, copyErr := io.Copy(out, in)
+		if copyErr != nil {
+			_ = out.Close()
+			return fmt.Errorf("copy %s: %w", src, copyErr)
+		}
+		if n == 0 {
+			break
+		}
+	}
+	if err = out.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", dst, err)
+	}
+	return nil
+}
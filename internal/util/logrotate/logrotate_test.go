@@ -0,0 +1,188 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package logrotate
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeLog(t *testing.T, path string, fill byte, size int) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o640)
+	if err != nil {
+		t.Fatalf("open log: %v", err)
+	}
+	defer f.Close()
+	if _, err = f.Write(bytes.Repeat([]byte{fill}, size)); err != nil {
+		t.Fatalf("write log: %v", err)
+	}
+}
+
+func readFill(t *testing.T, path string) byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	if len(data) == 0 {
+		t.Fatalf("%s is empty", path)
+	}
+	return data[0]
+}
+
+func TestRotate_BelowThresholdIsNoop(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "c.log")
+	writeLog(t, path, 'a', bytesPerMB-1)
+
+	rotated, err := Rotate(path, Policy{MaxSizeMB: 1, MaxFiles: 3})
+	if err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	if rotated {
+		t.Fatal("rotated a file below the threshold")
+	}
+	if _, err = os.Stat(Segment(path, 1)); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("segment 1 exists after no-op rotate: %v", err)
+	}
+}
+
+func TestRotate_MissingFileAndDisabledPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "c.log")
+	if rotated, err := Rotate(path, Policy{MaxSizeMB: 1, MaxFiles: 2}); err != nil || rotated {
+		t.Fatalf("Rotate(missing) = %v, %v; want false, nil", rotated, err)
+	}
+
+	writeLog(t, path, 'a', 2*bytesPerMB)
+	if rotated, err := Rotate(path, Policy{}); err != nil || rotated {
+		t.Fatalf("Rotate(disabled) = %v, %v; want false, nil", rotated, err)
+	}
+}
+
+func TestRotate_ShiftsAndPrunesSegments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "c.log")
+	policy := Policy{MaxSizeMB: 1, MaxFiles: 3}
+
+	// The writer keeps one O_APPEND descriptor open across every rotation,
+	// the way the containerd shim does.
+	writer, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o640)
+	if err != nil {
+		t.Fatalf("open writer: %v", err)
+	}
+	defer writer.Close()
+
+	for _, fill := range []byte{'a', 'b', 'c', 'd'} {
+		if _, err = writer.Write(bytes.Repeat([]byte{fill}, bytesPerMB+1)); err != nil {
+			t.Fatalf("write %q: %v", fill, err)
+		}
+		rotated, rotErr := Rotate(path, policy)
+		if rotErr != nil {
+			t.Fatalf("Rotate after %q: %v", fill, rotErr)
+		}
+		if !rotated {
+			t.Fatalf("Rotate after %q did not rotate", fill)
+		}
+	}
+
+	if got := readFill(t, Segment(path, 1)); got != 'd' {
+		t.Errorf("segment 1 holds %q, want newest %q", got, 'd')
+	}
+	if got := readFill(t, Segment(path, 2)); got != 'c' {
+		t.Errorf("segment 2 holds %q, want %q", got, 'c')
+	}
+	if _, err = os.Stat(Segment(path, 3)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("segment 3 survived pruning: %v", err)
+	}
+
+	// The live file was truncated in place, so the open writer appends at
+	// offset 0 instead of leaving a sparse hole.
+	if _, err = writer.Write([]byte("after")); err != nil {
+		t.Fatalf("write after rotate: %v", err)
+	}
+	live, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read live: %v", err)
+	}
+	if string(live) != "after" {
+		t.Errorf("live file = %q, want %q", live, "after")
+	}
+}
+
+func TestRotate_SingleFileTruncatesAndPrunesStaleSegments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "c.log")
+	writeLog(t, Segment(path, 1), 'x', 10)
+	writeLog(t, Segment(path, 2), 'y', 10)
+	writeLog(t, path, 'a', bytesPerMB)
+
+	rotated, err := Rotate(path, Policy{MaxSizeMB: 1, MaxFiles: 1})
+	if err != nil || !rotated {
+		t.Fatalf("Rotate = %v, %v; want true, nil", rotated, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat live: %v", err)
+	}
+	if info.Size() != 0 {
+		t.Errorf("live size = %d, want 0", info.Size())
+	}
+	for n := 1; n <= 2; n++ {
+		if _, err = os.Stat(Segment(path, n)); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("segment %d survived: %v", n, err)
+		}
+	}
+}
+
+func TestArchive(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "log")
+	previous := filepath.Join(dir, "log.previous")
+
+	if archived, err := Archive(path, previous); err != nil || archived {
+		t.Fatalf("missing log: archived=%v err=%v, want false, nil", archived, err)
+	}
+
+	writeLog(t, path, 'a', 16)
+	if archived, err := Archive(path, previous); err != nil || !archived {
+		t.Fatalf("Archive: archived=%v err=%v, want true, nil", archived, err)
+	}
+	if got := readFill(t, previous); got != 'a' {
+		t.Errorf("previous holds %q, want the archived log", got)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("live log still present after archive: %v", err)
+	}
+
+	// An instance that wrote nothing keeps the older archive.
+	writeLog(t, path, 'b', 0)
+	if archived, err := Archive(path, previous); err != nil || archived {
+		t.Fatalf("empty log: archived=%v err=%v, want false, nil", archived, err)
+	}
+	if got := readFill(t, previous); got != 'a' {
+		t.Errorf("empty instance replaced the archive with %q", got)
+	}
+
+	writeLog(t, path, 'c', 16)
+	if _, err := Archive(path, previous); err != nil {
+		t.Fatalf("Archive: %v", err)
+	}
+	if got := readFill(t, previous); got != 'c' {
+		t.Errorf("previous holds %q, want the latest instance 'c'", got)
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package logstream writes and reads the stream-tagged container log used
+// when a container keeps stdout and stderr apart (ContainerSpec.
+// SeparateStreams). The daemon copies each of the task's stdout and stderr
+// fifos into the same log file as one record per line:
+//
+//	<RFC3339Nano timestamp> <stdout|stderr> <F|P> <content>
+//
+// F marks a record that ended a line of output; P marks a partial line whose
+// remainder follows in a later record. This is the CRI log layout, so the file
+// stays readable with familiar tooling. Records land in the file in the order
+// the daemon read them, and each carries the time it was read, so the
+// combined view preserves the interleaving of the two streams.
+package logstream
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Stream selects which output stream of a container to read or write.
+type Stream string
+
+const (
+	// Stdout is the container's standard output.
+	Stdout Stream = "stdout"
+	// Stderr is the container's standard error.
+	Stderr Stream = "stderr"
+	// Combined reads both streams in the order they were written. It is not
+	// a valid stream for a Writer.
+	Combined Stream = "combined"
+)
+
+const (
+	tagFull    = 'F'
+	tagPartial = 'P'
+)
+
+// ParseStream validates a --stream value. The empty string means Combined.
+func ParseStream(s string) (Stream, error) {
+	switch Stream(s) {
+	case "", Combined:
+		return Combined, nil
+	case Stdout, Stderr:
+		return Stream(s), nil
+	default:
+		return "", fmt.Errorf("%q is not one of stdout, stderr, combined", s)
+	}
+}
+
+// Writer appends the bytes of one stream to a stream-tagged log file. Each
+// Write becomes whole records emitted with a single append, so a stdout and
+// a stderr Writer on the same path can run concurrently without splitting
+// each other's records. The file is opened per Write rather than held open:
+// the writer outlives no task and leaks no descriptor, and the log can be
+// rotated by copy-then-truncate between writes.
+type Writer struct {
+	path   string
+	stream Stream
+	now    func() time.Time
+	mu     sync.Mutex
+}
+
+// NewWriter returns a Writer that tags records with stream and appends them
+// to path. stream must be Stdout or Stderr.
+func NewWriter(path string, stream Stream) (*Writer, error) {
+	if stream != Stdout && stream != Stderr {
+		return nil, fmt.Errorf("invalid writer stream %q", stream)
+	}
+	return &Writer{path: path, stream: stream, now: time.Now}, nil
+}
+
+// Write encodes p as records and appends them to the log file. Every complete
+// line becomes an F record; a trailing fragment without a newline becomes a P
+// record so output is never held back waiting for a newline that may not come.
+func (w *Writer) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var buf bytes.Buffer
+	ts := w.now().UTC().Format(time.RFC3339Nano)
+	rest := p
+	for len(rest) > 0 {
+		line, after, found := bytes.Cut(rest, []byte{'\n'})
+		tag := byte(tagFull)
+		if !found {
+			tag = tagPartial
+		}
+		buf.WriteString(ts)
+		buf.WriteByte(' ')
+		buf.WriteString(string(w.stream))
+		buf.WriteByte(' ')
+		buf.WriteByte(tag)
+		buf.WriteByte(' ')
+		buf.Write(line)
+		buf.WriteByte('\n')
+		rest = after
+	}
+
+	f, err := os.OpenFile(w.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o640)
+	if err != nil {
+		return 0, err
+	}
+	if _, err = f.Write(buf.Bytes()); err != nil {
+		_ = f.Close()
+		return 0, err
+	}
+	if err = f.Close(); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Decoder is an io.Writer that turns stream-tagged log bytes back into the
+// raw output of the selected stream, so a caller can push a log file through
+// the same copy or follow loop it uses for a plain log. Input may arrive in
+// arbitrary chunks; an incomplete record is held until its newline arrives.
+type Decoder struct {
+	out     io.Writer
+	stream  Stream
+	pending []byte
+}
+
+// NewDecoder returns a Decoder writing the content of stream's records to
+// out. Combined writes the content of every record in file order.
+func NewDecoder(out io.Writer, stream Stream) *Decoder {
+	return &Decoder{out: out, stream: stream}
+}
+
+// Write decodes every complete record in pending+p and reports len(p) on
+// success. A line that is not a well-formed record is passed through verbatim
+// in the combined view and dropped from a single-stream view.
+func (d *Decoder) Write(p []byte) (int, error) {
+	d.pending = append(d.pending, p...)
+	for {
+		line, after, found := bytes.Cut(d.pending, []byte{'\n'})
+		if !found {
+			break
+		}
+		if err := d.decodeRecord(line); err != nil {
+			return 0, err
+		}
+		d.pending = after
+	}
+	// Drop the consumed prefix so pending does not pin the whole history.
+	d.pending = append([]byte(nil), d.pending...)
+	return len(p), nil
+}
+
+func (d *Decoder) decodeRecord(line []byte) error {
+	stream, tag, content, ok := parseRecord(line)
+	if !ok {
+		if d.stream != Combined {
+			return nil
+		}
+		_, err := d.out.Write(append(append([]byte(nil), line...), '\n'))
+		return err
+	}
+	if d.stream != Combined && d.stream != stream {
+		return nil
+	}
+	if tag == tagFull {
+		content = append(content, '\n')
+	}
+	_, err := d.out.Write(content)
+	return err
+}
+
+// parseRecord splits "<ts> <stream> <tag> <content>" into its parts. The
+// timestamp only has to parse; ordering comes from the record's position.
+func parseRecord(line []byte) (Stream, byte, []byte, bool) {
+	ts, rest, ok := bytes.Cut(line, []byte{' '})
+	if !ok {
+		return "", 0, nil, false
+	}
+	if _, err := time.Parse(time.RFC3339Nano, string(ts)); err != nil {
+		return "", 0, nil, false
+	}
+	stream, rest, ok := bytes.Cut(rest, []byte{' '})
+	if !ok || (Stream(stream) != Stdout && Stream(stream) != Stderr) {
+		return "", 0, nil, false
+	}
+	// The tag is one byte; the content after its separator may be empty.
+	if len(rest) < 2 || rest[1] != ' ' || (rest[0] != tagFull && rest[0] != tagPartial) {
+		return "", 0, nil, false
+	}
+	return Stream(stream), rest[0], append([]byte(nil), rest[2:]...), true
+}
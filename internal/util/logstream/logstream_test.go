@@ -0,0 +1,171 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package logstream
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeInterleaved writes out/err/out/err chunks through a stdout and a
+// stderr Writer sharing one log, with a fixed clock so records are exact.
+func writeInterleaved(t *testing.T, path string) {
+	t.Helper()
+	clock := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	now := func() time.Time {
+		clock = clock.Add(time.Millisecond)
+		return clock
+	}
+	stdout, err := NewWriter(path, Stdout)
+	if err != nil {
+		t.Fatalf("NewWriter(stdout): %v", err)
+	}
+	stderr, err := NewWriter(path, Stderr)
+	if err != nil {
+		t.Fatalf("NewWriter(stderr): %v", err)
+	}
+	stdout.now, stderr.now = now, now
+
+	for _, step := range []struct {
+		w    *Writer
+		data string
+	}{
+		{stdout, "out one\nout "},
+		{stderr, "err one\n"},
+		{stdout, "two\n"},
+		{stderr, "err two"},
+	} {
+		if _, err = step.w.Write([]byte(step.data)); err != nil {
+			t.Fatalf("Write(%q): %v", step.data, err)
+		}
+	}
+}
+
+func TestWriter_EncodesRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log")
+	writeInterleaved(t, path)
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	want := "" +
+		"2026-10-17T12:00:00.001Z stdout F out one\n" +
+		"2026-10-17T12:00:00.001Z stdout P out \n" +
+		"2026-10-17T12:00:00.002Z stderr F err one\n" +
+		"2026-10-17T12:00:00.003Z stdout F two\n" +
+		"2026-10-17T12:00:00.004Z stderr P err two\n"
+	if string(got) != want {
+		t.Errorf("log =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDecoder_SelectsStream(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log")
+	writeInterleaved(t, path)
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	tests := []struct {
+		stream Stream
+		want   string
+	}{
+		{Stdout, "out one\nout two\n"},
+		{Stderr, "err one\nerr two"},
+		{Combined, "out one\nout err one\ntwo\nerr two"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.stream), func(t *testing.T) {
+			var out bytes.Buffer
+			dec := NewDecoder(&out, tt.stream)
+			// Feed one byte at a time: records split across writes must
+			// decode the same as whole ones.
+			for i := range raw {
+				if _, err = dec.Write(raw[i : i+1]); err != nil {
+					t.Fatalf("Write: %v", err)
+				}
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("decoded = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecoder_StderrOnlyYieldsOnlyStderrBytes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log")
+	writeInterleaved(t, path)
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	var out bytes.Buffer
+	if _, err = NewDecoder(&out, Stderr).Write(raw); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if strings.Contains(out.String(), "out") {
+		t.Errorf("stderr view leaked stdout bytes: %q", out.String())
+	}
+}
+
+func TestDecoder_MalformedLines(t *testing.T) {
+	raw := "not a record\n2026-10-17T12:00:00Z stderr F kept\n"
+
+	var combined, stderr bytes.Buffer
+	if _, err := NewDecoder(&combined, Combined).Write([]byte(raw)); err != nil {
+		t.Fatalf("Write(combined): %v", err)
+	}
+	if _, err := NewDecoder(&stderr, Stderr).Write([]byte(raw)); err != nil {
+		t.Fatalf("Write(stderr): %v", err)
+	}
+	if got, want := combined.String(), "not a record\nkept\n"; got != want {
+		t.Errorf("combined = %q, want %q", got, want)
+	}
+	if got, want := stderr.String(), "kept\n"; got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+}
+
+func TestParseStream(t *testing.T) {
+	for in, want := range map[string]Stream{
+		"":         Combined,
+		"combined": Combined,
+		"stdout":   Stdout,
+		"stderr":   Stderr,
+	} {
+		got, err := ParseStream(in)
+		if err != nil || got != want {
+			t.Errorf("ParseStream(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseStream("both"); err == nil {
+		t.Error("ParseStream(both) returned nil error")
+	}
+}
+
+func TestNewWriter_RejectsCombined(t *testing.T) {
+	if _, err := NewWriter(filepath.Join(t.TempDir(), "log"), Combined); err == nil {
+		t.Error("NewWriter(combined) returned nil error")
+	}
+}
@@ -35,8 +35,9 @@ import (
 //   - "/" injects extra path components into the cgroup path
 //     (/kukeon/{realm}/{space}/{stack}/{cell}/...).
 //
-// Empty / whitespace-only names are also rejected so callers have a single
-// entrypoint. Callers that need to surface a "required" error per kind
+// Names that pass both checks must still satisfy ValidateName's DNS-label
+// rules. Empty / whitespace-only names are also rejected so callers have a
+// single entrypoint. Callers that need to surface a "required" error per kind
 // should check emptiness and return their own sentinel before invoking this.
 //
 // The kind argument ("space", "stack", "cell", "container") is included in
@@ -53,5 +54,5 @@ func ValidateHierarchyName(kind, name string) error {
 		return fmt.Errorf("%w: %s name %q contains disallowed character (must not contain '_' or '/')",
 			errdefs.ErrInvalidName, kind, trimmed)
 	}
-	return nil
+	return ValidateName(kind, trimmed)
 }
@@ -0,0 +1,60 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package naming
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+// MaxNameLength caps every realm, space, stack, cell and container name at
+// the DNS label limit. Names are joined into containerd IDs, cgroup paths
+// and CNI network names, so an unbounded name surfaces as an opaque failure
+// far from the input that caused it.
+const MaxNameLength = 63
+
+// ValidateName enforces the DNS-label rules shared by every kind: lowercase
+// ASCII letters, digits and '-', 1 to MaxNameLength characters, and no
+// leading or trailing '-'. The name is trimmed first, matching the trim every
+// create path already applies. Failures wrap errdefs.ErrInvalidName and name
+// both the offending value and the rule it broke.
+//
+// Builders such as BuildContainerdID assume their inputs already passed
+// through here and do not re-check the character set.
+func ValidateName(kind, name string) error {
+	trimmed := strings.TrimSpace(name)
+	if trimmed == "" {
+		return fmt.Errorf("%w: %s name is required", errdefs.ErrInvalidName, kind)
+	}
+	if len(trimmed) > MaxNameLength {
+		return fmt.Errorf("%w: %s name %q is %d characters (must be at most %d)",
+			errdefs.ErrInvalidName, kind, trimmed, len(trimmed), MaxNameLength)
+	}
+	for _, r := range trimmed {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return fmt.Errorf("%w: %s name %q contains %q (must contain only lowercase letters, digits and '-')",
+				errdefs.ErrInvalidName, kind, trimmed, r)
+		}
+	}
+	if strings.HasPrefix(trimmed, "-") || strings.HasSuffix(trimmed, "-") {
+		return fmt.Errorf("%w: %s name %q must not start or end with '-'",
+			errdefs.ErrInvalidName, kind, trimmed)
+	}
+	return nil
+}
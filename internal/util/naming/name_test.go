@@ -0,0 +1,95 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package naming_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/util/naming"
+)
+
+func TestValidateName(t *testing.T) {
+	kinds := []string{"realm", "space", "stack", "cell", "container"}
+
+	tests := []struct {
+		name      string
+		input     string
+		wantValid bool
+		wantRule  string
+	}{
+		{name: "lowercase legal", input: "web", wantValid: true},
+		{name: "digits legal", input: "web1", wantValid: true},
+		{name: "leading digit legal", input: "1web", wantValid: true},
+		{name: "inner dashes legal", input: "team-alpha-1", wantValid: true},
+		{name: "surrounding whitespace trimmed", input: "  web  ", wantValid: true},
+		{name: "max length legal", input: strings.Repeat("a", naming.MaxNameLength), wantValid: true},
+		{name: "empty rejected", input: "", wantRule: "is required"},
+		{name: "whitespace-only rejected", input: "   ", wantRule: "is required"},
+		{name: "uppercase rejected", input: "Web", wantRule: "lowercase letters"},
+		{name: "inner space rejected", input: "my web", wantRule: "lowercase letters"},
+		{name: "dot rejected", input: "web.v2", wantRule: "lowercase letters"},
+		{name: "underscore rejected", input: "a_b", wantRule: "lowercase letters"},
+		{name: "non-ASCII rejected", input: "wéb", wantRule: "lowercase letters"},
+		{name: "leading dash rejected", input: "-web", wantRule: "start or end"},
+		{name: "trailing dash rejected", input: "web-", wantRule: "start or end"},
+		{
+			name:     "over max length rejected",
+			input:    strings.Repeat("a", naming.MaxNameLength+1),
+			wantRule: "at most 63",
+		},
+	}
+
+	for _, kind := range kinds {
+		for _, tt := range tests {
+			t.Run(kind+"/"+tt.name, func(t *testing.T) {
+				err := naming.ValidateName(kind, tt.input)
+				if tt.wantValid {
+					if err != nil {
+						t.Errorf("ValidateName(%q, %q) = %v, want nil", kind, tt.input, err)
+					}
+					return
+				}
+				if !errors.Is(err, errdefs.ErrInvalidName) {
+					t.Fatalf("ValidateName(%q, %q) = %v, want errors.Is(_, ErrInvalidName)", kind, tt.input, err)
+				}
+				if !strings.Contains(err.Error(), kind+" name") {
+					t.Errorf("ValidateName(%q, %q) = %q, want error naming the kind", kind, tt.input, err)
+				}
+				if !strings.Contains(err.Error(), tt.wantRule) {
+					t.Errorf("ValidateName(%q, %q) = %q, want rule %q", kind, tt.input, err, tt.wantRule)
+				}
+			})
+		}
+	}
+}
+
+func TestValidateHierarchyName_AppliesNameRules(t *testing.T) {
+	err := naming.ValidateHierarchyName("cell", "Web")
+	if !errors.Is(err, errdefs.ErrInvalidName) {
+		t.Fatalf("ValidateHierarchyName(cell, Web) = %v, want ErrInvalidName", err)
+	}
+}
+
+func TestValidateRealmName_AppliesNameRules(t *testing.T) {
+	err := naming.ValidateRealmName("Prod")
+	if !errors.Is(err, errdefs.ErrInvalidName) {
+		t.Fatalf("ValidateRealmName(Prod) = %v, want ErrInvalidName", err)
+	}
+}
@@ -39,7 +39,8 @@ func BuildSpaceNetworkName(realmName, spaceName string) (string, error) {
 
 // BuildRootContainerdID constructs a root container containerd ID using hierarchical format.
 // Format: {spaceName}__{stackName}__{cellName}__root
-// Validates that all parameters are non-empty.
+// Inputs are assumed to have passed ValidateName at the create boundary, so
+// only trimming and an emptiness check happen here.
 func BuildRootContainerdID(spaceName, stackName, cellName string) (string, error) {
 	spaceName = strings.TrimSpace(spaceName)
 	stackName = strings.TrimSpace(stackName)
@@ -60,7 +61,8 @@ func BuildRootContainerdID(spaceName, stackName, cellName string) (string, error
 
 // BuildContainerdID constructs a container containerd ID using hierarchical format.
 // Format: {spaceName}__{stackName}__{cellName}__{containerName}
-// Validates that all parameters are non-empty.
+// Inputs are assumed to have passed ValidateName at the create boundary, so
+// only trimming and an emptiness check happen here.
 func BuildContainerdID(spaceName, stackName, cellName, containerName string) (string, error) {
 	spaceName = strings.TrimSpace(spaceName)
 	stackName = strings.TrimSpace(stackName)
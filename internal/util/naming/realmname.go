@@ -32,6 +32,9 @@ import (
 //   - "/" injects extra path components into the cgroup path
 //     (/kukeon/{realm}/{space}/...).
 //
+// Names that pass both checks must still satisfy ValidateName's DNS-label
+// rules, reported as errdefs.ErrInvalidName.
+//
 // Empty names are also rejected for callers that want a single entrypoint;
 // callers that need a separate "required" error should keep using
 // errdefs.ErrRealmNameRequired before invoking this.
@@ -44,5 +47,5 @@ func ValidateRealmName(name string) error {
 		return fmt.Errorf("%w: %q contains disallowed character (must not contain '_' or '/')",
 			errdefs.ErrInvalidRealmName, trimmed)
 	}
-	return nil
+	return ValidateName("realm", trimmed)
 }
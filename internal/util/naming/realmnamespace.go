@@ -0,0 +1,39 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package naming
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/containerd/containerd/v2/pkg/identifiers"
+	"github.com/eminwux/kukeon/internal/errdefs"
+)
+
+// ValidateRealmNamespace rejects a realm's Spec.Namespace override when it
+// is not a legal containerd namespace name. containerd applies its identifier
+// grammar (alphanumerics separated by single '.', '_' or '-', at most 76
+// characters) when the namespace is created, so catching it here surfaces a
+// clear error before any realm metadata is written.
+func ValidateRealmNamespace(namespace string) error {
+	trimmed := strings.TrimSpace(namespace)
+	if err := identifiers.Validate(trimmed); err != nil {
+		return fmt.Errorf("%w: %q is not a valid containerd namespace: %w",
+			errdefs.ErrInvalidRealmNamespace, trimmed, err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 Emiliano Spinella (eminwux)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package naming_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
+	"github.com/eminwux/kukeon/internal/util/naming"
+)
+
+func TestValidateRealmNamespace(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantValid bool
+	}{
+		{name: "default realm namespace legal", input: "default.kukeon.io", wantValid: true},
+		{name: "kuke-system namespace legal", input: "kuke-system.kukeon.io", wantValid: true},
+		{name: "bare containerd default legal", input: "default", wantValid: true},
+		{name: "underscore separator legal", input: "team_alpha", wantValid: true},
+		{name: "empty rejected", input: ""},
+		{name: "slash rejected", input: "team/alpha"},
+		{name: "leading dot rejected", input: ".team"},
+		{name: "doubled separator rejected", input: "team..alpha"},
+		{name: "over 76 characters rejected", input: strings.Repeat("a", 77)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := naming.ValidateRealmNamespace(tt.input)
+			if tt.wantValid {
+				if err != nil {
+					t.Errorf("ValidateRealmNamespace(%q) = %v, want nil", tt.input, err)
+				}
+				return
+			}
+			if !errors.Is(err, errdefs.ErrInvalidRealmNamespace) {
+				t.Errorf("ValidateRealmNamespace(%q) = %v, want errors.Is(_, %v)",
+					tt.input, err, errdefs.ErrInvalidRealmNamespace)
+			}
+		})
+	}
+}
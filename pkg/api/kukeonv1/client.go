@@ -71,7 +71,17 @@ type Client interface {
 	GetSpace(ctx context.Context, doc v1beta1.SpaceDoc) (GetSpaceResult, error)
 	GetStack(ctx context.Context, doc v1beta1.StackDoc) (GetStackResult, error)
 	GetCell(ctx context.Context, doc v1beta1.CellDoc) (GetCellResult, error)
+	// CellLiveStatus reconciles a cell's persisted state with the live
+	// containerd task state of its containers (`kuke get cell --live`). When
+	// containerd is unreachable it returns the persisted state with
+	// Live=false instead of an error.
+	CellLiveStatus(ctx context.Context, doc v1beta1.CellDoc) (CellLiveStatusResult, error)
 	GetContainer(ctx context.Context, doc v1beta1.ContainerDoc) (GetContainerResult, error)
+	// InspectContainer returns the OCI runtime spec and the containerd
+	// container record of one container as containerd stores them (`kuke
+	// inspect`). The container needs a containerd record but not a running
+	// task.
+	InspectContainer(ctx context.Context, doc v1beta1.ContainerDoc) (InspectContainerResult, error)
 	// GetSecret reports the metadata-only view of a single named, scoped
 	// `kind: Secret` (issue #622). Spec.data is never echoed — the bytes do
 	// not traverse this RPC by design (#619).
@@ -101,6 +111,18 @@ type Client interface {
 		ctx context.Context,
 		realmName, spaceName, stackName, cellName string,
 	) ([]v1beta1.ContainerSpec, error)
+	// ListContainersPage is the paginated form of ListContainers: at most
+	// limit containers (limit <= 0 means all) after the position
+	// continueToken encodes, plus the token for the next page. Empty scope
+	// filters match every name at their level. Pages come in a stable
+	// realm/space/stack/cell/container order, so following Continue until it
+	// is empty visits every container once.
+	ListContainersPage(
+		ctx context.Context,
+		realmName, spaceName, stackName, cellName string,
+		limit int,
+		continueToken string,
+	) (ListContainersPageResult, error)
 	// ListSecrets enumerates the metadata of every Secret bound to the
 	// filter scope or any scope nested within it (issue #622). An empty
 	// realmName lists across all realms. spec.data is never echoed.
@@ -162,18 +184,84 @@ type Client interface {
 	PurgeStack(ctx context.Context, doc v1beta1.StackDoc, force, cascade bool) (PurgeStackResult, error)
 	PurgeCell(ctx context.Context, doc v1beta1.CellDoc, force, cascade bool) (PurgeCellResult, error)
 
+	// RenameRealm/RenameSpace/RenameStack/RenameCell rename a resource in
+	// place. A cell must be stopped; a realm, space, or stack must be empty.
+	RenameRealm(ctx context.Context, doc v1beta1.RealmDoc, newName string) (RenameRealmResult, error)
+	RenameSpace(ctx context.Context, doc v1beta1.SpaceDoc, newName string) (RenameSpaceResult, error)
+	RenameStack(ctx context.Context, doc v1beta1.StackDoc, newName string) (RenameStackResult, error)
+	RenameCell(ctx context.Context, doc v1beta1.CellDoc, newName string) (RenameCellResult, error)
+
+	// ScaleCell reconciles the replicas of a template cell in a stack to the
+	// requested count, creating <template>-<i> replicas from the template's
+	// spec and deleting surplus ones.
+	ScaleCell(ctx context.Context, realm, space, stack, template string, replicas int) (ScaleCellResult, error)
+
+	// PatchResource applies a JSON merge patch or an RFC 6902 JSON patch to
+	// one stored resource document, re-validates it, and re-applies it.
+	// Identity, scope, and status fields cannot be patched.
+	PatchResource(ctx context.Context, ref PatchRef, patchType PatchType, patch []byte) (PatchResourceResult, error)
+
+	// ExportRealm snapshots a realm's declarative state as a multi-document
+	// YAML stream suitable for ApplyDocuments. Secret material is redacted
+	// unless includeSecrets is set.
+	ExportRealm(ctx context.Context, realm string, includeSecrets bool) (ExportRealmResult, error)
+	// ImportDocuments applies a multi-document YAML stream like
+	// ApplyDocuments, but stops at the first failing resource and deletes
+	// the resources it already created, unless continueOnError is set. The
+	// result is returned alongside the error so callers can render it.
+	ImportDocuments(ctx context.Context, rawYAML []byte, continueOnError bool) (ImportDocumentsResult, error)
+	// RestoreBackup writes the metadata files held in a backup archive taken
+	// before a realm purge or cascade delete back into the metadata tree.
+	// Existing files are left untouched; runtime state is not recreated.
+	RestoreBackup(ctx context.Context, archive []byte) (RestoreBackupResult, error)
+
+	// FindOrphans lists the kukeon-labeled containers in a realm's
+	// containerd namespace that no cell metadata owns. With purge set each
+	// one is stopped and deleted; the result is returned alongside the error
+	// so callers can report partial purges.
+	FindOrphans(ctx context.Context, realm string, purge bool) (FindOrphansResult, error)
+
+	// ListCellUsage samples the live cgroup counters of every cell under
+	// realm, optionally narrowed to space and then stack. An empty realm
+	// lists every realm.
+	ListCellUsage(ctx context.Context, realm, space, stack string) ([]CellUsage, error)
+	// NodeSummary reports the kukeon root cgroup's live usage and the
+	// number of realms, spaces, stacks, cells, and containers on the host.
+	NodeSummary(ctx context.Context) (NodeSummaryResult, error)
+	// CordonNode closes the node to new cells and containers while leaving
+	// existing ones running; reason is an optional operator note.
+	CordonNode(ctx context.Context, reason string) (NodeCordonResult, error)
+	// UncordonNode reopens a cordoned node to new cells and containers.
+	UncordonNode(ctx context.Context) (NodeCordonResult, error)
+	// DrainScope stops every running cell under realm (optionally narrowed
+	// to space, then stack) for maintenance and marks the scope drained so
+	// the reconcile loop leaves it alone. Metadata is kept. The result is
+	// populated alongside a per-cell failure error.
+	DrainScope(ctx context.Context, realm, space, stack string) (DrainScopeResult, error)
+	// UndrainScope lifts a drain and starts the cells the drain stopped.
+	UndrainScope(ctx context.Context, realm, space, stack string) (DrainScopeResult, error)
+	// ListEvents reads the node's audit trail of controller actions, oldest
+	// first, narrowed by filter.
+	ListEvents(ctx context.Context, filter EventFilter) ([]Event, error)
+	// ListQuotas reports every realm and space resource quota with its
+	// current usage. A non-empty realm narrows the report to that realm, and
+	// space, which needs realm, to that space.
+	ListQuotas(ctx context.Context, realm, space string) ([]QuotaStatus, error)
+
 	RefreshAll(ctx context.Context) (RefreshAllResult, error)
 	ApplyDocuments(ctx context.Context, rawYAML []byte) (ApplyDocumentsResult, error)
-	// ApplyDocumentsForTeam is the per-team prune-apply sibling of
-	// ApplyDocuments (issue #1027). It stamps every applied CellBlueprint /
-	// CellConfig with `kukeon.io/team=<team>` and, after the apply loop,
-	// deletes daemon-stored Blueprint / Config objects carrying the same
-	// team label that the applied set did not include. Used by `kuke team
-	// init` (#796) to make project rosters converge: re-running on a
-	// shrunken roster prunes the orphans without touching other teams or
-	// running cells (deleting a Blueprint or Config never deletes the cell
-	// materialized from it). Empty team is rejected — use ApplyDocuments
-	// for the no-team path.
+	// ApplyDocumentsWithOptions is ApplyDocuments taking every
+	// per-invocation option at once: the writer of `kuke apply
+	// --field-manager`, the parent wait of `kuke apply --wait-for-parent`,
+	// the container prune of `kuke apply --prune` and the per-team
+	// prune-apply of `kuke team init`. The zero ApplyOptions is a plain
+	// ApplyDocuments.
+	ApplyDocumentsWithOptions(ctx context.Context, rawYAML []byte, opts ApplyOptions) (ApplyDocumentsResult, error)
+	// ApplyDocumentsForTeam is the per-team prune-apply of issue #1027:
+	// ApplyDocumentsWithOptions with only ApplyOptions.Team set. Empty team
+	// is rejected — use ApplyDocuments for the no-team path.
+	//
+	// Deprecated: use ApplyDocumentsWithOptions with ApplyOptions{Team: team}.
 	ApplyDocumentsForTeam(ctx context.Context, rawYAML []byte, team string) (ApplyDocumentsResult, error)
 	// DeleteDocuments is the file-driven counterpart to ApplyDocuments —
 	// `kuke delete -f` sends the raw YAML over the wire so deletes honor
@@ -280,15 +368,16 @@ const (
 	MethodGetConfig    = ServiceName + ".GetConfig"
 	MethodGetVolume    = ServiceName + ".GetVolume"
 
-	MethodListRealms     = ServiceName + ".ListRealms"
-	MethodListSpaces     = ServiceName + ".ListSpaces"
-	MethodListStacks     = ServiceName + ".ListStacks"
-	MethodListCells      = ServiceName + ".ListCells"
-	MethodListContainers = ServiceName + ".ListContainers"
-	MethodListSecrets    = ServiceName + ".ListSecrets"
-	MethodListBlueprints = ServiceName + ".ListBlueprints"
-	MethodListConfigs    = ServiceName + ".ListConfigs"
-	MethodListVolumes    = ServiceName + ".ListVolumes"
+	MethodListRealms         = ServiceName + ".ListRealms"
+	MethodListSpaces         = ServiceName + ".ListSpaces"
+	MethodListStacks         = ServiceName + ".ListStacks"
+	MethodListCells          = ServiceName + ".ListCells"
+	MethodListContainers     = ServiceName + ".ListContainers"
+	MethodListContainersPage = ServiceName + ".ListContainersPage"
+	MethodListSecrets        = ServiceName + ".ListSecrets"
+	MethodListBlueprints     = ServiceName + ".ListBlueprints"
+	MethodListConfigs        = ServiceName + ".ListConfigs"
+	MethodListVolumes        = ServiceName + ".ListVolumes"
 
 	MethodStartCell       = ServiceName + ".StartCell"
 	MethodAttachContainer = ServiceName + ".AttachContainer"
@@ -310,6 +399,36 @@ const (
 	MethodPurgeStack = ServiceName + ".PurgeStack"
 	MethodPurgeCell  = ServiceName + ".PurgeCell"
 
+	MethodRenameRealm = ServiceName + ".RenameRealm"
+	MethodRenameSpace = ServiceName + ".RenameSpace"
+	MethodRenameStack = ServiceName + ".RenameStack"
+	MethodRenameCell  = ServiceName + ".RenameCell"
+
+	MethodScaleCell = ServiceName + ".ScaleCell"
+
+	MethodPatchResource = ServiceName + ".PatchResource"
+
+	MethodCellLiveStatus = ServiceName + ".CellLiveStatus"
+
+	MethodInspectContainer = ServiceName + ".InspectContainer"
+
+	MethodExportRealm     = ServiceName + ".ExportRealm"
+	MethodImportDocuments = ServiceName + ".ImportDocuments"
+	MethodRestoreBackup   = ServiceName + ".RestoreBackup"
+
+	MethodFindOrphans   = ServiceName + ".FindOrphans"
+	MethodNodeSummary   = ServiceName + ".NodeSummary"
+	MethodListCellUsage = ServiceName + ".ListCellUsage"
+
+	MethodCordonNode   = ServiceName + ".CordonNode"
+	MethodUncordonNode = ServiceName + ".UncordonNode"
+
+	MethodDrainScope   = ServiceName + ".DrainScope"
+	MethodUndrainScope = ServiceName + ".UndrainScope"
+
+	MethodListEvents = ServiceName + ".ListEvents"
+	MethodListQuotas = ServiceName + ".ListQuotas"
+
 	MethodRefreshAll      = ServiceName + ".RefreshAll"
 	MethodApplyDocuments  = ServiceName + ".ApplyDocuments"
 	MethodDeleteDocuments = ServiceName + ".DeleteDocuments"
@@ -24,10 +24,16 @@ import (
 
 // APIError is a serializable error. Kind identifies the error class; client
 // code maps it back to an errdefs.* sentinel so that errors.Is(err, errdefs.X)
-// keeps working across the wire.
+// keeps working across the wire. Code is the stable errdefs.Code of the
+// server-side error, which also covers sentinels Kind has no entry for.
+// Codes is every errdefs.Code in the server-side chain, outermost first, so
+// a client can categorize the error by its innermost cause the way a local
+// one is.
 type APIError struct {
 	Kind    string
 	Message string
+	Code    string
+	Codes   []string
 }
 
 func (e *APIError) Error() string {
@@ -62,6 +68,22 @@ var kindToSentinel = map[string]error{
 	"ImageNotFound":           errdefs.ErrImageNotFound,
 	"ConfigNotFound":          errdefs.ErrConfigNotFound,
 	"ConfigExists":            errdefs.ErrConfigExists,
+	"InvalidPatch":            errdefs.ErrInvalidPatch,
+	"ImmutableField":          errdefs.ErrImmutableField,
+	"PatchUnsupportedKind":    errdefs.ErrPatchUnsupportedKind,
+	"InvalidRealmNamespace":   errdefs.ErrInvalidRealmNamespace,
+	"RealmNamespaceInUse":     errdefs.ErrRealmNamespaceInUse,
+	"FindOrphans":             errdefs.ErrFindOrphans,
+	"PurgeOrphans":            errdefs.ErrPurgeOrphans,
+	"ListEvents":              errdefs.ErrListEvents,
+	"ListQuotas":              errdefs.ErrListQuotas,
+	"PauseImageUnavailable":   errdefs.ErrPauseImageUnavailable,
+	"NodeCordoned":            errdefs.ErrNodeCordoned,
+	"ScopeDrained":            errdefs.ErrScopeDrained,
+	"ScopeNotDrained":         errdefs.ErrScopeNotDrained,
+	"PreflightFailed":         errdefs.ErrPreflightFailed,
+	"CellHookFailed":          errdefs.ErrCellHookFailed,
+	"ContainerHookFailed":     errdefs.ErrContainerHookFailed,
 }
 
 // sentinelToKind is the reverse lookup, populated lazily.
@@ -92,10 +114,15 @@ func ToAPIError(err error) *APIError {
 	if err == nil {
 		return nil
 	}
-	return &APIError{
+	apiErr := &APIError{
 		Kind:    KindFromError(err),
 		Message: err.Error(),
+		Code:    string(errdefs.CodeOf(err)),
 	}
+	for _, code := range errdefs.CodesOf(err) {
+		apiErr.Codes = append(apiErr.Codes, string(code))
+	}
+	return apiErr
 }
 
 // FromAPIError reconstructs a Go error that unwraps to the matching errdefs
@@ -107,16 +134,32 @@ func FromAPIError(e *APIError) error {
 	if e == nil {
 		return nil
 	}
-	sentinel := kindToSentinel[e.Kind]
-	return &wireError{msg: e.Message, sentinel: sentinel}
+	var sentinels []error
+	// The Code sentinel goes first so errdefs.CodeOf on the client resolves
+	// to the same code the server computed.
+	if sentinel := errdefs.Sentinel(errdefs.Code(e.Code)); sentinel != nil {
+		sentinels = append(sentinels, sentinel)
+	}
+	if sentinel := kindToSentinel[e.Kind]; sentinel != nil {
+		sentinels = append(sentinels, sentinel)
+	}
+	// The rest of the server's chain follows, innermost last, so the
+	// client's errdefs.CodesOf ends on the same cause.
+	for _, code := range e.Codes {
+		if sentinel := errdefs.Sentinel(errdefs.Code(code)); sentinel != nil {
+			sentinels = append(sentinels, sentinel)
+		}
+	}
+	return &wireError{msg: e.Message, sentinels: sentinels}
 }
 
 // wireError carries a wire error message verbatim and unwraps to the
-// sentinel matching the APIError.Kind so errors.Is works transparently.
+// sentinels matching the APIError's Code and Kind so errors.Is works
+// transparently.
 type wireError struct {
-	msg      string
-	sentinel error
+	msg       string
+	sentinels []error
 }
 
-func (w *wireError) Error() string { return w.msg }
-func (w *wireError) Unwrap() error { return w.sentinel }
+func (w *wireError) Error() string   { return w.msg }
+func (w *wireError) Unwrap() []error { return w.sentinels }
@@ -57,6 +57,27 @@ func TestRoundTripPreservesSentinelAndMessage(t *testing.T) {
 	}
 }
 
+// TestRoundTripPreservesCode verifies that a sentinel without a wire Kind
+// still reaches the client through APIError.Code, for both errors.Is and
+// errdefs.CodeOf.
+func TestRoundTripPreservesCode(t *testing.T) {
+	serverErr := fmt.Errorf("%w: %w", errdefs.ErrCellValidation,
+		fmt.Errorf("%w: stack %q is Pending", errdefs.ErrParentNotReady, "st1"))
+
+	apiErr := kukeonv1.ToAPIError(serverErr)
+	if apiErr.Code != string(errdefs.CodeCellValidation) {
+		t.Errorf("Code = %q, want %q", apiErr.Code, errdefs.CodeCellValidation)
+	}
+
+	clientErr := kukeonv1.FromAPIError(apiErr)
+	if !errors.Is(clientErr, errdefs.ErrCellValidation) {
+		t.Error("errors.Is(clientErr, ErrCellValidation) = false, want true")
+	}
+	if got := errdefs.CodeOf(clientErr); got != errdefs.CodeCellValidation {
+		t.Errorf("CodeOf(clientErr) = %q, want %q", got, errdefs.CodeCellValidation)
+	}
+}
+
 func TestFromAPIErrorUnknownKind(t *testing.T) {
 	apiErr := &kukeonv1.APIError{Kind: "NotAKnownKind", Message: "whoops"}
 	clientErr := kukeonv1.FromAPIError(apiErr)
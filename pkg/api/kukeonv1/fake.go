@@ -85,10 +85,18 @@ func (FakeClient) GetCell(context.Context, v1beta1.CellDoc) (GetCellResult, erro
 	return GetCellResult{}, ErrUnexpectedCall
 }
 
+func (FakeClient) CellLiveStatus(context.Context, v1beta1.CellDoc) (CellLiveStatusResult, error) {
+	return CellLiveStatusResult{}, ErrUnexpectedCall
+}
+
 func (FakeClient) GetContainer(context.Context, v1beta1.ContainerDoc) (GetContainerResult, error) {
 	return GetContainerResult{}, ErrUnexpectedCall
 }
 
+func (FakeClient) InspectContainer(context.Context, v1beta1.ContainerDoc) (InspectContainerResult, error) {
+	return InspectContainerResult{}, ErrUnexpectedCall
+}
+
 func (FakeClient) GetSecret(context.Context, v1beta1.SecretDoc) (GetSecretResult, error) {
 	return GetSecretResult{}, ErrUnexpectedCall
 }
@@ -125,6 +133,12 @@ func (FakeClient) ListContainers(context.Context, string, string, string, string
 	return nil, ErrUnexpectedCall
 }
 
+func (FakeClient) ListContainersPage(
+	context.Context, string, string, string, string, int, string,
+) (ListContainersPageResult, error) {
+	return ListContainersPageResult{}, ErrUnexpectedCall
+}
+
 func (FakeClient) ListSecrets(context.Context, string, string, string, string) ([]v1beta1.SecretDoc, error) {
 	return nil, ErrUnexpectedCall
 }
@@ -209,6 +223,78 @@ func (FakeClient) PurgeCell(context.Context, v1beta1.CellDoc, bool, bool) (Purge
 	return PurgeCellResult{}, ErrUnexpectedCall
 }
 
+func (FakeClient) RenameRealm(context.Context, v1beta1.RealmDoc, string) (RenameRealmResult, error) {
+	return RenameRealmResult{}, ErrUnexpectedCall
+}
+
+func (FakeClient) RenameSpace(context.Context, v1beta1.SpaceDoc, string) (RenameSpaceResult, error) {
+	return RenameSpaceResult{}, ErrUnexpectedCall
+}
+
+func (FakeClient) RenameStack(context.Context, v1beta1.StackDoc, string) (RenameStackResult, error) {
+	return RenameStackResult{}, ErrUnexpectedCall
+}
+
+func (FakeClient) RenameCell(context.Context, v1beta1.CellDoc, string) (RenameCellResult, error) {
+	return RenameCellResult{}, ErrUnexpectedCall
+}
+
+func (FakeClient) ScaleCell(context.Context, string, string, string, string, int) (ScaleCellResult, error) {
+	return ScaleCellResult{}, ErrUnexpectedCall
+}
+
+func (FakeClient) PatchResource(context.Context, PatchRef, PatchType, []byte) (PatchResourceResult, error) {
+	return PatchResourceResult{}, ErrUnexpectedCall
+}
+
+func (FakeClient) ExportRealm(context.Context, string, bool) (ExportRealmResult, error) {
+	return ExportRealmResult{}, ErrUnexpectedCall
+}
+
+func (FakeClient) ImportDocuments(context.Context, []byte, bool) (ImportDocumentsResult, error) {
+	return ImportDocumentsResult{}, ErrUnexpectedCall
+}
+
+func (FakeClient) RestoreBackup(context.Context, []byte) (RestoreBackupResult, error) {
+	return RestoreBackupResult{}, ErrUnexpectedCall
+}
+
+func (FakeClient) FindOrphans(context.Context, string, bool) (FindOrphansResult, error) {
+	return FindOrphansResult{}, ErrUnexpectedCall
+}
+
+func (FakeClient) ListCellUsage(context.Context, string, string, string) ([]CellUsage, error) {
+	return nil, ErrUnexpectedCall
+}
+
+func (FakeClient) NodeSummary(context.Context) (NodeSummaryResult, error) {
+	return NodeSummaryResult{}, ErrUnexpectedCall
+}
+
+func (FakeClient) CordonNode(context.Context, string) (NodeCordonResult, error) {
+	return NodeCordonResult{}, ErrUnexpectedCall
+}
+
+func (FakeClient) UncordonNode(context.Context) (NodeCordonResult, error) {
+	return NodeCordonResult{}, ErrUnexpectedCall
+}
+
+func (FakeClient) DrainScope(context.Context, string, string, string) (DrainScopeResult, error) {
+	return DrainScopeResult{}, ErrUnexpectedCall
+}
+
+func (FakeClient) UndrainScope(context.Context, string, string, string) (DrainScopeResult, error) {
+	return DrainScopeResult{}, ErrUnexpectedCall
+}
+
+func (FakeClient) ListEvents(context.Context, EventFilter) ([]Event, error) {
+	return nil, ErrUnexpectedCall
+}
+
+func (FakeClient) ListQuotas(context.Context, string, string) ([]QuotaStatus, error) {
+	return nil, ErrUnexpectedCall
+}
+
 func (FakeClient) RefreshAll(context.Context) (RefreshAllResult, error) {
 	return RefreshAllResult{}, ErrUnexpectedCall
 }
@@ -217,6 +303,12 @@ func (FakeClient) ApplyDocuments(context.Context, []byte) (ApplyDocumentsResult,
 	return ApplyDocumentsResult{}, ErrUnexpectedCall
 }
 
+func (FakeClient) ApplyDocumentsWithOptions(
+	context.Context, []byte, ApplyOptions,
+) (ApplyDocumentsResult, error) {
+	return ApplyDocumentsResult{}, ErrUnexpectedCall
+}
+
 func (FakeClient) ApplyDocumentsForTeam(
 	context.Context, []byte, string,
 ) (ApplyDocumentsResult, error) {
@@ -28,6 +28,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/eminwux/kukeon/internal/errdefs"
 	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
 )
 
@@ -57,18 +58,33 @@ func WithDialTimeout(d time.Duration) UnixOption {
 // EACCES on the kukeond socket almost always means the caller is not a member
 // of the `kukeon` group — surface the remediation instead of a raw syscall
 // error. Other failure modes (socket missing, daemon down, timeout) pass
-// through unchanged so their existing messages are preserved.
+// through unchanged so their existing messages are preserved. Either way the
+// result matches errdefs.ErrDaemonUnreachable.
 func wrapDialError(sockPath string, err error) error {
 	if errors.Is(err, syscall.EACCES) || errors.Is(err, fs.ErrPermission) {
-		return fmt.Errorf(
-			"dial kukeond at %s: permission denied — add yourself to the kukeon group "+
-				"(sudo usermod -aG kukeon $USER), then log out and back in: %w",
-			sockPath, err,
-		)
+		return &dialError{
+			msg: fmt.Sprintf(
+				"dial kukeond at %s: permission denied — add yourself to the kukeon group "+
+					"(sudo usermod -aG kukeon $USER), then log out and back in: %v",
+				sockPath, err,
+			),
+			err: err,
+		}
 	}
-	return fmt.Errorf("dial kukeond at %s: %w", sockPath, err)
+	return &dialError{msg: fmt.Sprintf("dial kukeond at %s: %v", sockPath, err), err: err}
+}
+
+// dialError classifies a failed dial as errdefs.ErrDaemonUnreachable without
+// putting the sentinel's text into the message.
+type dialError struct {
+	msg string
+	err error
 }
 
+func (e *dialError) Error() string { return e.msg }
+
+func (e *dialError) Unwrap() []error { return []error{errdefs.ErrDaemonUnreachable, e.err} }
+
 // NewUnixClient returns a ctx-aware Client that dials the given unix socket
 // path on first use and reuses the connection for subsequent calls.
 func NewUnixClient(sockPath string, opts ...UnixOption) *UnixClient {
@@ -250,6 +266,19 @@ func (c *UnixClient) GetCell(ctx context.Context, doc v1beta1.CellDoc) (GetCellR
 	return reply.Result, nil
 }
 
+// CellLiveStatus implements Client.
+func (c *UnixClient) CellLiveStatus(ctx context.Context, doc v1beta1.CellDoc) (CellLiveStatusResult, error) {
+	args := &CellLiveStatusArgs{Doc: doc}
+	reply := &CellLiveStatusReply{}
+	if err := c.call(ctx, MethodCellLiveStatus, args, reply); err != nil {
+		return CellLiveStatusResult{}, err
+	}
+	if reply.Err != nil {
+		return reply.Result, FromAPIError(reply.Err)
+	}
+	return reply.Result, nil
+}
+
 // GetContainer implements Client.
 func (c *UnixClient) GetContainer(ctx context.Context, doc v1beta1.ContainerDoc) (GetContainerResult, error) {
 	args := &GetContainerArgs{Doc: doc}
@@ -263,6 +292,22 @@ func (c *UnixClient) GetContainer(ctx context.Context, doc v1beta1.ContainerDoc)
 	return reply.Result, nil
 }
 
+// InspectContainer implements Client.
+func (c *UnixClient) InspectContainer(
+	ctx context.Context,
+	doc v1beta1.ContainerDoc,
+) (InspectContainerResult, error) {
+	args := &InspectContainerArgs{Doc: doc}
+	reply := &InspectContainerReply{}
+	if err := c.call(ctx, MethodInspectContainer, args, reply); err != nil {
+		return InspectContainerResult{}, err
+	}
+	if reply.Err != nil {
+		return reply.Result, FromAPIError(reply.Err)
+	}
+	return reply.Result, nil
+}
+
 // GetSecret implements Client.
 func (c *UnixClient) GetSecret(ctx context.Context, doc v1beta1.SecretDoc) (GetSecretResult, error) {
 	args := &GetSecretArgs{Doc: doc}
@@ -432,6 +477,31 @@ func (c *UnixClient) ListContainers(
 	return reply.Containers, nil
 }
 
+// ListContainersPage implements Client.
+func (c *UnixClient) ListContainersPage(
+	ctx context.Context,
+	realmName, spaceName, stackName, cellName string,
+	limit int,
+	continueToken string,
+) (ListContainersPageResult, error) {
+	args := &ListContainersPageArgs{
+		RealmName: realmName,
+		SpaceName: spaceName,
+		StackName: stackName,
+		CellName:  cellName,
+		Limit:     limit,
+		Continue:  continueToken,
+	}
+	reply := &ListContainersPageReply{}
+	if err := c.call(ctx, MethodListContainersPage, args, reply); err != nil {
+		return ListContainersPageResult{}, err
+	}
+	if reply.Err != nil {
+		return reply.Result, FromAPIError(reply.Err)
+	}
+	return reply.Result, nil
+}
+
 // ListSecrets implements Client.
 func (c *UnixClient) ListSecrets(
 	ctx context.Context,
@@ -751,6 +821,246 @@ func (c *UnixClient) PurgeCell(ctx context.Context, doc v1beta1.CellDoc, force,
 	return reply.Result, nil
 }
 
+// RenameRealm implements Client.
+func (c *UnixClient) RenameRealm(ctx context.Context, doc v1beta1.RealmDoc, newName string) (RenameRealmResult, error) {
+	args := &RenameRealmArgs{Doc: doc, NewName: newName}
+	reply := &RenameRealmReply{}
+	if err := c.call(ctx, MethodRenameRealm, args, reply); err != nil {
+		return RenameRealmResult{}, err
+	}
+	if reply.Err != nil {
+		return reply.Result, FromAPIError(reply.Err)
+	}
+	return reply.Result, nil
+}
+
+// RenameSpace implements Client.
+func (c *UnixClient) RenameSpace(ctx context.Context, doc v1beta1.SpaceDoc, newName string) (RenameSpaceResult, error) {
+	args := &RenameSpaceArgs{Doc: doc, NewName: newName}
+	reply := &RenameSpaceReply{}
+	if err := c.call(ctx, MethodRenameSpace, args, reply); err != nil {
+		return RenameSpaceResult{}, err
+	}
+	if reply.Err != nil {
+		return reply.Result, FromAPIError(reply.Err)
+	}
+	return reply.Result, nil
+}
+
+// RenameStack implements Client.
+func (c *UnixClient) RenameStack(ctx context.Context, doc v1beta1.StackDoc, newName string) (RenameStackResult, error) {
+	args := &RenameStackArgs{Doc: doc, NewName: newName}
+	reply := &RenameStackReply{}
+	if err := c.call(ctx, MethodRenameStack, args, reply); err != nil {
+		return RenameStackResult{}, err
+	}
+	if reply.Err != nil {
+		return reply.Result, FromAPIError(reply.Err)
+	}
+	return reply.Result, nil
+}
+
+// RenameCell implements Client.
+func (c *UnixClient) RenameCell(ctx context.Context, doc v1beta1.CellDoc, newName string) (RenameCellResult, error) {
+	args := &RenameCellArgs{Doc: doc, NewName: newName}
+	reply := &RenameCellReply{}
+	if err := c.call(ctx, MethodRenameCell, args, reply); err != nil {
+		return RenameCellResult{}, err
+	}
+	if reply.Err != nil {
+		return reply.Result, FromAPIError(reply.Err)
+	}
+	return reply.Result, nil
+}
+
+// ScaleCell implements Client.
+func (c *UnixClient) ScaleCell(
+	ctx context.Context, realm, space, stack, template string, replicas int,
+) (ScaleCellResult, error) {
+	args := &ScaleCellArgs{Realm: realm, Space: space, Stack: stack, Template: template, Replicas: replicas}
+	reply := &ScaleCellReply{}
+	if err := c.call(ctx, MethodScaleCell, args, reply); err != nil {
+		return ScaleCellResult{}, err
+	}
+	if reply.Err != nil {
+		return reply.Result, FromAPIError(reply.Err)
+	}
+	return reply.Result, nil
+}
+
+// PatchResource implements Client.
+func (c *UnixClient) PatchResource(
+	ctx context.Context, ref PatchRef, patchType PatchType, patch []byte,
+) (PatchResourceResult, error) {
+	args := &PatchResourceArgs{Ref: ref, Type: patchType, Patch: patch}
+	reply := &PatchResourceReply{}
+	if err := c.call(ctx, MethodPatchResource, args, reply); err != nil {
+		return PatchResourceResult{}, err
+	}
+	if reply.Err != nil {
+		return reply.Result, FromAPIError(reply.Err)
+	}
+	return reply.Result, nil
+}
+
+// ExportRealm implements Client.
+func (c *UnixClient) ExportRealm(ctx context.Context, realm string, includeSecrets bool) (ExportRealmResult, error) {
+	args := &ExportRealmArgs{Realm: realm, IncludeSecrets: includeSecrets}
+	reply := &ExportRealmReply{}
+	if err := c.call(ctx, MethodExportRealm, args, reply); err != nil {
+		return ExportRealmResult{}, err
+	}
+	if reply.Err != nil {
+		return reply.Result, FromAPIError(reply.Err)
+	}
+	return reply.Result, nil
+}
+
+// FindOrphans implements Client.
+func (c *UnixClient) FindOrphans(ctx context.Context, realm string, purge bool) (FindOrphansResult, error) {
+	args := &FindOrphansArgs{Realm: realm, Purge: purge}
+	reply := &FindOrphansReply{}
+	if err := c.call(ctx, MethodFindOrphans, args, reply); err != nil {
+		return FindOrphansResult{}, err
+	}
+	if reply.Err != nil {
+		return reply.Result, FromAPIError(reply.Err)
+	}
+	return reply.Result, nil
+}
+
+// ListCellUsage implements Client.
+func (c *UnixClient) ListCellUsage(ctx context.Context, realm, space, stack string) ([]CellUsage, error) {
+	args := &ListCellUsageArgs{Realm: realm, Space: space, Stack: stack}
+	reply := &ListCellUsageReply{}
+	if err := c.call(ctx, MethodListCellUsage, args, reply); err != nil {
+		return nil, err
+	}
+	if reply.Err != nil {
+		return nil, FromAPIError(reply.Err)
+	}
+	return reply.Cells, nil
+}
+
+// NodeSummary implements Client.
+func (c *UnixClient) NodeSummary(ctx context.Context) (NodeSummaryResult, error) {
+	args := &NodeSummaryArgs{}
+	reply := &NodeSummaryReply{}
+	if err := c.call(ctx, MethodNodeSummary, args, reply); err != nil {
+		return NodeSummaryResult{}, err
+	}
+	if reply.Err != nil {
+		return NodeSummaryResult{}, FromAPIError(reply.Err)
+	}
+	return reply.Result, nil
+}
+
+// CordonNode implements Client.
+func (c *UnixClient) CordonNode(ctx context.Context, reason string) (NodeCordonResult, error) {
+	args := &CordonNodeArgs{Reason: reason}
+	reply := &NodeCordonReply{}
+	if err := c.call(ctx, MethodCordonNode, args, reply); err != nil {
+		return NodeCordonResult{}, err
+	}
+	if reply.Err != nil {
+		return NodeCordonResult{}, FromAPIError(reply.Err)
+	}
+	return reply.Result, nil
+}
+
+// UncordonNode implements Client.
+func (c *UnixClient) UncordonNode(ctx context.Context) (NodeCordonResult, error) {
+	args := &UncordonNodeArgs{}
+	reply := &NodeCordonReply{}
+	if err := c.call(ctx, MethodUncordonNode, args, reply); err != nil {
+		return NodeCordonResult{}, err
+	}
+	if reply.Err != nil {
+		return NodeCordonResult{}, FromAPIError(reply.Err)
+	}
+	return reply.Result, nil
+}
+
+// DrainScope implements Client.
+func (c *UnixClient) DrainScope(ctx context.Context, realm, space, stack string) (DrainScopeResult, error) {
+	args := &DrainScopeArgs{Realm: realm, Space: space, Stack: stack}
+	reply := &DrainScopeReply{}
+	if err := c.call(ctx, MethodDrainScope, args, reply); err != nil {
+		return DrainScopeResult{}, err
+	}
+	if reply.Err != nil {
+		return reply.Result, FromAPIError(reply.Err)
+	}
+	return reply.Result, nil
+}
+
+// UndrainScope implements Client.
+func (c *UnixClient) UndrainScope(ctx context.Context, realm, space, stack string) (DrainScopeResult, error) {
+	args := &DrainScopeArgs{Realm: realm, Space: space, Stack: stack}
+	reply := &DrainScopeReply{}
+	if err := c.call(ctx, MethodUndrainScope, args, reply); err != nil {
+		return DrainScopeResult{}, err
+	}
+	if reply.Err != nil {
+		return reply.Result, FromAPIError(reply.Err)
+	}
+	return reply.Result, nil
+}
+
+// ListEvents implements Client.
+func (c *UnixClient) ListEvents(ctx context.Context, filter EventFilter) ([]Event, error) {
+	args := &ListEventsArgs{Filter: filter}
+	reply := &ListEventsReply{}
+	if err := c.call(ctx, MethodListEvents, args, reply); err != nil {
+		return nil, err
+	}
+	if reply.Err != nil {
+		return nil, FromAPIError(reply.Err)
+	}
+	return reply.Events, nil
+}
+
+// ListQuotas implements Client.
+func (c *UnixClient) ListQuotas(ctx context.Context, realm, space string) ([]QuotaStatus, error) {
+	args := &ListQuotasArgs{Realm: realm, Space: space}
+	reply := &ListQuotasReply{}
+	if err := c.call(ctx, MethodListQuotas, args, reply); err != nil {
+		return nil, err
+	}
+	if reply.Err != nil {
+		return nil, FromAPIError(reply.Err)
+	}
+	return reply.Quotas, nil
+}
+
+// ImportDocuments implements Client.
+func (c *UnixClient) ImportDocuments(
+	ctx context.Context, rawYAML []byte, continueOnError bool,
+) (ImportDocumentsResult, error) {
+	args := &ImportDocumentsArgs{RawYAML: rawYAML, ContinueOnError: continueOnError}
+	reply := &ImportDocumentsReply{}
+	if err := c.call(ctx, MethodImportDocuments, args, reply); err != nil {
+		return ImportDocumentsResult{}, err
+	}
+	if reply.Err != nil {
+		return reply.Result, FromAPIError(reply.Err)
+	}
+	return reply.Result, nil
+}
+
+// RestoreBackup implements Client.
+func (c *UnixClient) RestoreBackup(ctx context.Context, archive []byte) (RestoreBackupResult, error) {
+	args := &RestoreBackupArgs{Archive: archive}
+	reply := &RestoreBackupReply{}
+	if err := c.call(ctx, MethodRestoreBackup, args, reply); err != nil {
+		return RestoreBackupResult{}, err
+	}
+	if reply.Err != nil {
+		return reply.Result, FromAPIError(reply.Err)
+	}
+	return reply.Result, nil
+}
+
 // RefreshAll implements Client.
 func (c *UnixClient) RefreshAll(ctx context.Context) (RefreshAllResult, error) {
 	args := &RefreshAllArgs{}
@@ -766,23 +1076,35 @@ func (c *UnixClient) RefreshAll(ctx context.Context) (RefreshAllResult, error) {
 
 // ApplyDocuments implements Client.
 func (c *UnixClient) ApplyDocuments(ctx context.Context, rawYAML []byte) (ApplyDocumentsResult, error) {
-	return c.applyDocuments(ctx, rawYAML, "")
+	return c.applyDocuments(ctx, &ApplyDocumentsArgs{RawYAML: rawYAML})
+}
+
+// ApplyDocumentsWithOptions implements Client.
+func (c *UnixClient) ApplyDocumentsWithOptions(
+	ctx context.Context, rawYAML []byte, opts ApplyOptions,
+) (ApplyDocumentsResult, error) {
+	return c.applyDocuments(ctx, &ApplyDocumentsArgs{
+		RawYAML:              rawYAML,
+		Team:                 opts.Team,
+		FieldManager:         opts.FieldManager,
+		WaitForParentSeconds: opts.WaitForParentSeconds,
+		PruneContainers:      opts.PruneContainers,
+	})
 }
 
 // ApplyDocumentsForTeam implements Client.
+//
+// Deprecated: use ApplyDocumentsWithOptions with ApplyOptions{Team: team}.
 func (c *UnixClient) ApplyDocumentsForTeam(
 	ctx context.Context, rawYAML []byte, team string,
 ) (ApplyDocumentsResult, error) {
 	if team == "" {
 		return ApplyDocumentsResult{}, errors.New("apply for team: team is required")
 	}
-	return c.applyDocuments(ctx, rawYAML, team)
+	return c.ApplyDocumentsWithOptions(ctx, rawYAML, ApplyOptions{Team: team})
 }
 
-func (c *UnixClient) applyDocuments(
-	ctx context.Context, rawYAML []byte, team string,
-) (ApplyDocumentsResult, error) {
-	args := &ApplyDocumentsArgs{RawYAML: rawYAML, Team: team}
+func (c *UnixClient) applyDocuments(ctx context.Context, args *ApplyDocumentsArgs) (ApplyDocumentsResult, error) {
 	reply := &ApplyDocumentsReply{}
 	if err := c.call(ctx, MethodApplyDocuments, args, reply); err != nil {
 		return ApplyDocumentsResult{}, err
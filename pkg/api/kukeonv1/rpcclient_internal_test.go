@@ -24,6 +24,8 @@ import (
 	"strings"
 	"syscall"
 	"testing"
+
+	"github.com/eminwux/kukeon/internal/errdefs"
 )
 
 const testSockPath = "/run/kukeon/kukeond.sock"
@@ -94,5 +96,8 @@ func TestWrapDialErrorOtherErrorUnchanged(t *testing.T) {
 		if !errors.Is(got, c) {
 			t.Errorf("underlying error not preserved for %v", c)
 		}
+		if !errors.Is(got, errdefs.ErrDaemonUnreachable) {
+			t.Errorf("errors.Is(got, ErrDaemonUnreachable) = false for %v, want true", c)
+		}
 	}
 }
@@ -17,6 +17,7 @@
 package kukeonv1
 
 import (
+	"encoding/json"
 	"time"
 
 	v1beta1 "github.com/eminwux/kukeon/pkg/api/model/v1beta1"
@@ -165,6 +166,26 @@ type GetCellResult struct {
 	RootContainerTaskRunning bool
 }
 
+type CellLiveStatusArgs struct {
+	Doc v1beta1.CellDoc
+}
+
+type CellLiveStatusReply struct {
+	Result CellLiveStatusResult
+	Err    *APIError
+}
+
+// CellLiveStatusResult is a cell's persisted state reconciled against the live
+// containerd task state of its containers. State is the persisted state, or
+// Degraded when the metadata says Ready but a container's task is gone.
+// Containers maps container ID to its live state. Live is false (and
+// Containers nil) when containerd could not be queried.
+type CellLiveStatusResult struct {
+	State      v1beta1.CellState
+	Containers map[string]v1beta1.ContainerState
+	Live       bool
+}
+
 type GetContainerArgs struct {
 	Doc v1beta1.ContainerDoc
 }
@@ -180,6 +201,24 @@ type GetContainerResult struct {
 	ContainerExists    bool
 }
 
+type InspectContainerArgs struct {
+	Doc v1beta1.ContainerDoc
+}
+
+type InspectContainerReply struct {
+	Result InspectContainerResult
+	Err    *APIError
+}
+
+// InspectContainerResult carries a container's containerd-side state as JSON
+// documents rather than typed fields: OCISpec is the OCI runtime spec and
+// Containerd the containerd container record. Both are passed through
+// verbatim so the API does not pin a runtime-spec or containerd version.
+type InspectContainerResult struct {
+	OCISpec    json.RawMessage
+	Containerd json.RawMessage
+}
+
 type GetSecretArgs struct {
 	Doc v1beta1.SecretDoc
 }
@@ -306,6 +345,27 @@ type ListContainersReply struct {
 	Err        *APIError
 }
 
+type ListContainersPageArgs struct {
+	RealmName string
+	SpaceName string
+	StackName string
+	CellName  string
+	Limit     int
+	Continue  string
+}
+
+type ListContainersPageReply struct {
+	Result ListContainersPageResult
+	Err    *APIError
+}
+
+// ListContainersPageResult is one page of a container listing. Continue is
+// the opaque token for the next page; empty once the listing is complete.
+type ListContainersPageResult struct {
+	Containers []v1beta1.ContainerSpec
+	Continue   string
+}
+
 type ListSecretsArgs struct {
 	RealmName string
 	SpaceName string
@@ -408,6 +468,7 @@ type DeleteRealmResult struct {
 	MetadataDeleted            bool
 	CgroupDeleted              bool
 	ContainerdNamespaceDeleted bool
+	BackupPath                 string
 }
 
 type DeleteSpaceArgs struct {
@@ -620,6 +681,7 @@ type PurgeRealmResult struct {
 	Cascade        bool
 	Deleted        []string
 	Purged         []string
+	BackupPath     string
 }
 
 type PurgeSpaceArgs struct {
@@ -685,6 +747,403 @@ type PurgeCellResult struct {
 	Purged            []string
 }
 
+// ---- Rename ----
+
+type RenameRealmArgs struct {
+	Doc     v1beta1.RealmDoc
+	NewName string
+}
+
+type RenameRealmReply struct {
+	Result RenameRealmResult
+	Err    *APIError
+}
+
+type RenameRealmResult struct {
+	Realm   v1beta1.RealmDoc
+	OldName string
+}
+
+type RenameSpaceArgs struct {
+	Doc     v1beta1.SpaceDoc
+	NewName string
+}
+
+type RenameSpaceReply struct {
+	Result RenameSpaceResult
+	Err    *APIError
+}
+
+type RenameSpaceResult struct {
+	Space   v1beta1.SpaceDoc
+	OldName string
+}
+
+type RenameStackArgs struct {
+	Doc     v1beta1.StackDoc
+	NewName string
+}
+
+type RenameStackReply struct {
+	Result RenameStackResult
+	Err    *APIError
+}
+
+type RenameStackResult struct {
+	Stack   v1beta1.StackDoc
+	OldName string
+}
+
+type RenameCellArgs struct {
+	Doc     v1beta1.CellDoc
+	NewName string
+}
+
+type RenameCellReply struct {
+	Result RenameCellResult
+	Err    *APIError
+}
+
+type RenameCellResult struct {
+	Cell    v1beta1.CellDoc
+	OldName string
+}
+
+// ---- Scale ----
+
+type ScaleCellArgs struct {
+	Realm    string
+	Space    string
+	Stack    string
+	Template string
+	Replicas int
+}
+
+type ScaleCellReply struct {
+	Result ScaleCellResult
+	Err    *APIError
+}
+
+// ScaleCellResult lists the replica cell names ScaleCell created, removed,
+// and left in place.
+type ScaleCellResult struct {
+	Template  string
+	Replicas  int
+	Created   []string
+	Removed   []string
+	Unchanged []string
+}
+
+// ---- Patch ----
+
+// PatchType selects how PatchResource reads a patch.
+type PatchType string
+
+const (
+	// PatchTypeMerge is an RFC 7386 JSON merge patch.
+	PatchTypeMerge PatchType = "merge"
+	// PatchTypeJSON is an RFC 6902 JSON patch.
+	PatchTypeJSON PatchType = "json"
+)
+
+// PatchRef names the resource a patch targets. Realm, Space, and Stack are
+// its scope coordinates; a kind ignores the ones it does not live under.
+type PatchRef struct {
+	Kind  v1beta1.Kind
+	Name  string
+	Realm string
+	Space string
+	Stack string
+}
+
+type PatchResourceArgs struct {
+	Ref   PatchRef
+	Type  PatchType
+	Patch []byte
+}
+
+type PatchResourceReply struct {
+	Result PatchResourceResult
+	Err    *APIError
+}
+
+// PatchResourceResult reports how the patched document was re-applied.
+// Action is "unchanged" when the patch left the document as it was.
+type PatchResourceResult struct {
+	Kind    string
+	Name    string
+	Action  string
+	Changes []string
+}
+
+// ---- Export ----
+
+type ExportRealmArgs struct {
+	Realm          string
+	IncludeSecrets bool
+}
+
+type ExportRealmReply struct {
+	Result ExportRealmResult
+	Err    *APIError
+}
+
+// ExportRealmResult carries the encoded multi-document YAML stream and the
+// secret-bearing items that were left out of it.
+type ExportRealmResult struct {
+	Manifest []byte
+	Redacted []string
+}
+
+// ---- Orphans ----
+
+type FindOrphansArgs struct {
+	Realm string
+	Purge bool
+}
+
+type FindOrphansReply struct {
+	Result FindOrphansResult
+	Err    *APIError
+}
+
+// FindOrphansResult lists the orphaned containers found in a realm's
+// containerd namespace and, for a purge, the containerd IDs removed.
+type FindOrphansResult struct {
+	Realm     string            `json:"realm"            yaml:"realm"`
+	Namespace string            `json:"namespace"        yaml:"namespace"`
+	Orphans   []OrphanContainer `json:"orphans"          yaml:"orphans"`
+	Purged    []string          `json:"purged,omitempty" yaml:"purged,omitempty"`
+}
+
+// OrphanContainer is a kukeon-labeled containerd container whose owning cell
+// has no metadata. Space, Stack and Cell come from its `kukeon.io/*` labels.
+type OrphanContainer struct {
+	ContainerdID string `json:"containerdId" yaml:"containerdId"`
+	Space        string `json:"space"        yaml:"space"`
+	Stack        string `json:"stack"        yaml:"stack"`
+	Cell         string `json:"cell"         yaml:"cell"`
+}
+
+// ---- Cell usage ----
+
+type ListCellUsageArgs struct {
+	Realm string
+	Space string
+	Stack string
+}
+
+type ListCellUsageReply struct {
+	Cells []CellUsage
+	Err   *APIError
+}
+
+// CellUsage is one sample of a cell's cgroup counters. Provisioned is false,
+// and the usage fields nil, when the cell has no cgroup or it could not be
+// read. CPUUsageUsec is cumulative, so a CPU percentage takes two samples.
+type CellUsage struct {
+	Realm            string            `json:"realm"                      yaml:"realm"`
+	Space            string            `json:"space"                      yaml:"space"`
+	Stack            string            `json:"stack"                      yaml:"stack"`
+	Cell             string            `json:"cell"                       yaml:"cell"`
+	State            v1beta1.CellState `json:"state"                      yaml:"state"`
+	Provisioned      bool              `json:"provisioned"                yaml:"provisioned"`
+	MemoryBytes      *uint64           `json:"memoryBytes,omitempty"      yaml:"memoryBytes,omitempty"`
+	MemoryLimitBytes *uint64           `json:"memoryLimitBytes,omitempty" yaml:"memoryLimitBytes,omitempty"`
+	CPUUsageUsec     *uint64           `json:"cpuUsageUsec,omitempty"     yaml:"cpuUsageUsec,omitempty"`
+	Pids             *uint64           `json:"pids,omitempty"             yaml:"pids,omitempty"`
+}
+
+// ---- Node summary ----
+
+type NodeSummaryArgs struct{}
+
+type NodeSummaryReply struct {
+	Result NodeSummaryResult
+	Err    *APIError
+}
+
+// NodeSummaryResult is the host-level view of what kukeon is consuming.
+// The usage fields are read from the kukeon root cgroup, which aggregates
+// every realm; each is nil when its controller file is absent, and all of
+// them are nil when Provisioned is false because the cgroup does not exist
+// yet. MemoryLimitBytes is also nil when the root cgroup is unlimited.
+type NodeSummaryResult struct {
+	CgroupRoot       string  `json:"cgroupRoot"                 yaml:"cgroupRoot"`
+	Provisioned      bool    `json:"provisioned"                yaml:"provisioned"`
+	MemoryBytes      *uint64 `json:"memoryBytes,omitempty"      yaml:"memoryBytes,omitempty"`
+	MemoryLimitBytes *uint64 `json:"memoryLimitBytes,omitempty" yaml:"memoryLimitBytes,omitempty"`
+	CPUUsageUsec     *uint64 `json:"cpuUsageUsec,omitempty"     yaml:"cpuUsageUsec,omitempty"`
+	Pids             *uint64 `json:"pids,omitempty"             yaml:"pids,omitempty"`
+	OOMKills         *uint64 `json:"oomKills,omitempty"         yaml:"oomKills,omitempty"`
+	Realms           int     `json:"realms"                     yaml:"realms"`
+	Spaces           int     `json:"spaces"                     yaml:"spaces"`
+	Stacks           int     `json:"stacks"                     yaml:"stacks"`
+	Cells            int     `json:"cells"                      yaml:"cells"`
+	Containers       int     `json:"containers"                 yaml:"containers"`
+}
+
+// ---- Node cordon ----
+
+type CordonNodeArgs struct {
+	Reason string
+}
+
+type UncordonNodeArgs struct{}
+
+type NodeCordonReply struct {
+	Result NodeCordonResult
+	Err    *APIError
+}
+
+// NodeCordonResult is the node's cordon state after `kuke cordon` or
+// `kuke uncordon`. Changed is false when the node was already in the
+// requested state; Since and Reason are set only while cordoned.
+type NodeCordonResult struct {
+	Cordoned bool       `json:"cordoned"         yaml:"cordoned"`
+	Changed  bool       `json:"changed"          yaml:"changed"`
+	Since    *time.Time `json:"since,omitempty"  yaml:"since,omitempty"`
+	Reason   string     `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// ---- Scope drain ----
+
+// DrainScopeArgs names the drained scope. Realm is required; Space and
+// Stack narrow it.
+type DrainScopeArgs struct {
+	Realm string
+	Space string
+	Stack string
+}
+
+type DrainScopeReply struct {
+	Result DrainScopeResult
+	Err    *APIError
+}
+
+// DrainScopeResult reports `kuke drain` or `kuke undrain`. Changed is false
+// when the scope was already in the requested state. Cells lists the cells
+// stopped (drain) or started (undrain) as space/stack/name; Skipped counts
+// cells drain left alone because they were already down.
+type DrainScopeResult struct {
+	Realm   string     `json:"realm"             yaml:"realm"`
+	Space   string     `json:"space,omitempty"   yaml:"space,omitempty"`
+	Stack   string     `json:"stack,omitempty"   yaml:"stack,omitempty"`
+	Changed bool       `json:"changed"           yaml:"changed"`
+	Since   *time.Time `json:"since,omitempty"   yaml:"since,omitempty"`
+	Cells   []string   `json:"cells,omitempty"   yaml:"cells,omitempty"`
+	Skipped int        `json:"skipped,omitempty" yaml:"skipped,omitempty"`
+	Errors  []string   `json:"errors,omitempty"  yaml:"errors,omitempty"`
+}
+
+// ---- Events ----
+
+// EventFilter narrows ListEvents. Empty fields match everything; Realm,
+// Space, Stack and Cell select a scope and everything under it. A positive
+// Limit keeps only the newest events.
+type EventFilter struct {
+	Kind  string
+	Realm string
+	Space string
+	Stack string
+	Cell  string
+	Since time.Time
+	Limit int
+}
+
+type ListEventsArgs struct {
+	Filter EventFilter
+}
+
+type ListEventsReply struct {
+	Events []Event
+	Err    *APIError
+}
+
+// Event is one entry of the node's audit trail: an action the controller
+// took on a realm, space, stack or cell, and whether it succeeded. Message
+// carries the error of a failed action.
+type Event struct {
+	Time    time.Time `json:"time"              yaml:"time"`
+	Action  string    `json:"action"            yaml:"action"`
+	Kind    string    `json:"kind"              yaml:"kind"`
+	Realm   string    `json:"realm,omitempty"   yaml:"realm,omitempty"`
+	Space   string    `json:"space,omitempty"   yaml:"space,omitempty"`
+	Stack   string    `json:"stack,omitempty"   yaml:"stack,omitempty"`
+	Cell    string    `json:"cell,omitempty"    yaml:"cell,omitempty"`
+	Outcome string    `json:"outcome"           yaml:"outcome"`
+	Message string    `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// ---- Quotas ----
+
+type ListQuotasArgs struct {
+	Realm string
+	Space string
+}
+
+type ListQuotasReply struct {
+	Quotas []QuotaStatus
+	Err    *APIError
+}
+
+// QuotaStatus is a realm or space resource quota next to what its cells
+// use. Space is empty for a realm quota.
+type QuotaStatus struct {
+	Realm string                `json:"realm"           yaml:"realm"`
+	Space string                `json:"space,omitempty" yaml:"space,omitempty"`
+	Limit v1beta1.ResourceQuota `json:"limit"           yaml:"limit"`
+	Used  QuotaUsage            `json:"used"            yaml:"used"`
+}
+
+// QuotaUsage is what a scope's cells count against its quota: the cells
+// themselves and the memoryLimitBytes and cpuShares their workload
+// containers declare.
+type QuotaUsage struct {
+	Cells       int   `json:"cells"       yaml:"cells"`
+	MemoryBytes int64 `json:"memoryBytes" yaml:"memoryBytes"`
+	CPUShares   int64 `json:"cpuShares"   yaml:"cpuShares"`
+}
+
+// ---- Import ----
+
+// ImportDocumentsArgs carries a raw multi-document YAML blob. The server
+// parses and validates it like ApplyDocumentsArgs.
+type ImportDocumentsArgs struct {
+	RawYAML         []byte
+	ContinueOnError bool
+}
+
+type ImportDocumentsReply struct {
+	Result ImportDocumentsResult
+	Err    *APIError
+}
+
+// ImportDocumentsResult reports every resource the import attempted and, when
+// it stopped on a failure, the deletions that rolled back what it created.
+type ImportDocumentsResult struct {
+	Resources  []ApplyResourceResult  `json:"resources"            yaml:"resources"`
+	RolledBack []DeleteResourceResult `json:"rolledBack,omitempty" yaml:"rolledBack,omitempty"`
+}
+
+// RestoreBackupArgs carries a metadata backup archive (gzipped tar) read
+// by the client.
+type RestoreBackupArgs struct {
+	Archive []byte
+}
+
+type RestoreBackupReply struct {
+	Result RestoreBackupResult
+	Err    *APIError
+}
+
+// RestoreBackupResult lists the metadata files a restore wrote and the ones
+// it skipped because they already exist, relative to the metadata root.
+type RestoreBackupResult struct {
+	Restored []string `json:"restored,omitempty" yaml:"restored,omitempty"`
+	Skipped  []string `json:"skipped,omitempty"  yaml:"skipped,omitempty"`
+}
+
 // ---- Attach ----
 
 // AttachContainerArgs identifies the target container for an attach request.
@@ -743,6 +1202,18 @@ type LogContainerResult struct {
 	// by the containerd runtime shim (cio.LogFile mode). Set only for
 	// non-Attachable containers; the file is shim-owned, kuke only reads.
 	HostLogPath string
+
+	// HostPreviousLogPath is the host path HostLogPath is archived to when
+	// the container starts a new task, holding the output of the instance
+	// before the latest restart. Set alongside HostLogPath; the file exists
+	// only once the container has restarted after producing output.
+	HostPreviousLogPath string
+
+	// SeparateStreams reports that HostLogPath holds stream-tagged records
+	// (internal/util/logstream) rather than raw bytes, because the
+	// container was started with spec.separateStreams. Only then can the
+	// client isolate stdout or stderr.
+	SeparateStreams bool
 }
 
 // ---- Refresh ----
@@ -794,9 +1265,37 @@ type PingReply struct {
 // Config objects carrying `kukeon.io/team=<Team>` and deletes those not
 // in the applied set. The empty-string default preserves the historical
 // no-team, no-prune `kuke apply -f` behavior.
+//
+// FieldManager names the writer recorded with each resource's last-applied
+// configuration (`kuke apply --field-manager`); empty means the default.
+//
+// PruneContainers makes every applied cell stop and delete the workload
+// containers labeled for it in containerd that its spec does not declare
+// (`kuke apply --prune`).
 type ApplyDocumentsArgs struct {
-	RawYAML []byte
-	Team    string
+	RawYAML              []byte
+	Team                 string
+	FieldManager         string
+	WaitForParentSeconds int
+	PruneContainers      bool
+}
+
+// ApplyOptions are the per-invocation options of
+// Client.ApplyDocumentsWithOptions, with the meaning of the
+// ApplyDocumentsArgs fields of the same name.
+//
+// A non-empty Team is the per-team prune-apply (issue #1027): every applied
+// CellBlueprint / CellConfig is stamped with `kukeon.io/team=<team>` and,
+// after the apply loop, daemon-stored Blueprint / Config objects carrying
+// the same team label that the applied set did not include are deleted.
+// `kuke team init` (#796) uses it to make project rosters converge without
+// touching other teams or running cells (deleting a Blueprint or Config
+// never deletes the cell materialized from it).
+type ApplyOptions struct {
+	Team                 string
+	FieldManager         string
+	WaitForParentSeconds int
+	PruneContainers      bool
 }
 
 type ApplyDocumentsReply struct {
@@ -907,6 +1406,15 @@ type DeleteImageResult struct {
 	Ref       string
 }
 
+// ContainerRootFSResult reports where a running container's filesystem view
+// is reachable on the host: the task PID and its /proc/<pid>/root path.
+// Like the image results it is served by the in-process client only —
+// `kuke cp` reads and writes under HostRootPath directly.
+type ContainerRootFSResult struct {
+	PID          uint32
+	HostRootPath string
+}
+
 // PruneImagesResult reports the outcome of a `kuke image prune`: the realm /
 // namespace it ran against and the count of leases released vs. retained.
 type PruneImagesResult struct {
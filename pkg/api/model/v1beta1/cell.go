@@ -68,6 +68,13 @@ type CellSpec struct {
 	// delegate any controller it wants to its workloads. Default false
 	// keeps the existing cell-as-leaf semantics (issue #312) untouched.
 	NestedCgroupRuntime bool `json:"nestedCgroupRuntime,omitempty" yaml:"nestedCgroupRuntime,omitempty"`
+	// Bandwidth caps the cell's network traffic through the CNI bandwidth
+	// plugin, applied when the root container joins the space network and
+	// removed when it leaves. Nil leaves the cell unshaped.
+	Bandwidth *CellBandwidth `json:"bandwidth,omitempty"           yaml:"bandwidth,omitempty"`
+	// Lifecycle holds host-side commands run around the cell's start and
+	// stop. Nil runs none.
+	Lifecycle *CellLifecycle `json:"lifecycle,omitempty"           yaml:"lifecycle,omitempty"`
 	// RuntimeEnv carries CLI-injected env entries (KUKE_RUN's --env
 	// KEY=VALUE) for the cell's attachable container, merged into the
 	// container's OCI process env at cell start time. Entries collide-and-
@@ -115,6 +122,23 @@ type CellSpec struct {
 	// per-invocation override never persists into the stored cell spec; each
 	// `kuke create`/`kuke run` re-supplies its own.
 	IgnoreDiskPressure bool `json:"ignoreDiskPressure,omitempty"  yaml:"-"`
+	// WaitReadySeconds asks StartCell to hold its reply until every
+	// container's task is up, failing if any is not within this many
+	// seconds. Set by `kuke create cell --wait`; 0 keeps the fire-and-forget
+	// start. Transport-only like IgnoreDiskPressure: JSON-RPC carries it
+	// CLI → daemon and BuildCellExternalFromInternal drops it, so it never
+	// persists into the stored spec.
+	WaitReadySeconds int `json:"waitReadySeconds,omitempty"    yaml:"-"`
+	// WaitForParentSeconds asks the create/apply path to poll the cell's
+	// realm, space and stack until they are Ready, failing if they are not
+	// within this many seconds. Set by `--wait-for-parent`; 0 fails fast on
+	// a parent that is not Ready. Transport-only like WaitReadySeconds.
+	WaitForParentSeconds int `json:"waitForParentSeconds,omitempty" yaml:"-"`
+	// PruneContainers asks the apply path to stop and delete the workload
+	// containers labeled for this cell in containerd that Containers does
+	// not declare. Set by `kuke apply --prune`; the root container is never
+	// pruned. Transport-only like WaitReadySeconds.
+	PruneContainers bool `json:"pruneContainers,omitempty"      yaml:"-"`
 }
 
 // Binding-kind discriminants for CellProvenance.BindingKind. A cell is
@@ -178,6 +202,31 @@ func CloneCellProvenance(in *CellProvenance) *CellProvenance {
 	return out
 }
 
+// CellBandwidth caps a cell's traffic per direction. Rates are per second and
+// bursts are sizes, both written with a tc-style unit: "bit", "kbit",
+// "mbit", "gbit" (decimal, in bits) or "bps", "kbps", "mbps", "gbps" (in
+// bytes), case-insensitive — e.g. "10Mbit". A bare number counts bits. Each
+// direction is optional, but a rate needs a burst and vice versa.
+type CellBandwidth struct {
+	IngressRate  string `json:"ingressRate,omitempty"  yaml:"ingressRate,omitempty"`
+	IngressBurst string `json:"ingressBurst,omitempty" yaml:"ingressBurst,omitempty"`
+	EgressRate   string `json:"egressRate,omitempty"   yaml:"egressRate,omitempty"`
+	EgressBurst  string `json:"egressBurst,omitempty"  yaml:"egressBurst,omitempty"`
+}
+
+// CellLifecycle lists shell commands kukeond runs on the host, not in a
+// container, with `/bin/sh -c`, in order. PreStart runs before the cell's
+// containers are started and a failing command aborts the start; PostStop
+// runs after they are stopped and a failing command is only logged. Each
+// command is bounded by TimeoutSeconds (default 30) and sees KUKEON_CELL,
+// KUKEON_CELL_ID, KUKEON_REALM, KUKEON_SPACE, KUKEON_STACK and KUKEON_HOOK
+// in its environment.
+type CellLifecycle struct {
+	PreStart       []string `json:"preStart,omitempty"       yaml:"preStart,omitempty"`
+	PostStop       []string `json:"postStop,omitempty"       yaml:"postStop,omitempty"`
+	TimeoutSeconds int      `json:"timeoutSeconds,omitempty" yaml:"timeoutSeconds,omitempty"`
+}
+
 // CellTty is cell-level tty/attach config. Kept intentionally minimal: only
 // fields the container or container-level tty cannot express belong here.
 type CellTty struct {
@@ -234,9 +283,13 @@ type CellStatus struct {
 // CellNetworkStatus exposes the host-side bridge a cell is attached to.
 // Populated by the runner during cell provisioning so describe/get -o yaml
 // surfaces the iface name without recomputing the hash. Always emitted in
-// the canonical k-{8hex} form (see cni.SafeBridgeName).
+// the canonical k-{8hex} form (see cni.SafeBridgeName). IPv4/IPv6 carry the
+// addresses assigned to the cell on its last start; IPv6 is only set when the
+// space is dual-stack.
 type CellNetworkStatus struct {
 	BridgeName string `json:"bridgeName,omitempty" yaml:"bridgeName,omitempty"`
+	IPv4       string `json:"ipv4,omitempty"       yaml:"ipv4,omitempty"`
+	IPv6       string `json:"ipv6,omitempty"       yaml:"ipv6,omitempty"`
 }
 
 type CellState int
@@ -55,6 +55,9 @@ type CellBlueprintMetadata struct {
 	// Labels are copied onto every cell materialized from this blueprint, in
 	// addition to the kukeon.io/blueprint back-reference label.
 	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	// Annotations carry non-identifying metadata about the blueprint. Unlike
+	// Labels they are not copied onto materialized cells.
+	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
 }
 
 // CellBlueprintSpec carries the scalar parameter declarations plus the cell
@@ -139,10 +142,17 @@ type BlueprintContainer struct {
 	HostPID                bool                   `json:"hostPID,omitempty"                yaml:"hostPID,omitempty"`
 	HostCgroup             bool                   `json:"hostCgroup,omitempty"             yaml:"hostCgroup,omitempty"`
 	User                   string                 `json:"user,omitempty"                   yaml:"user,omitempty"`
+	SupplementaryGroups    []int                  `json:"supplementaryGroups,omitempty"    yaml:"supplementaryGroups,omitempty"`
 	ReadOnlyRootFilesystem bool                   `json:"readOnlyRootFilesystem,omitempty" yaml:"readOnlyRootFilesystem,omitempty"`
+	WritableTmp            *bool                  `json:"writableTmp,omitempty"            yaml:"writableTmp,omitempty"`
 	Capabilities           *ContainerCapabilities `json:"capabilities,omitempty"           yaml:"capabilities,omitempty"`
 	SecurityOpts           []string               `json:"securityOpts,omitempty"           yaml:"securityOpts,omitempty"`
-	// Devices grants per-host-device passthrough (short form, e.g. "/dev/kvm")
+	NoNewPrivileges        bool                   `json:"noNewPrivileges,omitempty"        yaml:"noNewPrivileges,omitempty"`
+	Sysctls                map[string]string      `json:"sysctls,omitempty"                yaml:"sysctls,omitempty"`
+	OOMScoreAdj            *int                   `json:"oomScoreAdj,omitempty"            yaml:"oomScoreAdj,omitempty"`
+	TerminationMessagePath string                 `json:"terminationMessagePath,omitempty" yaml:"terminationMessagePath,omitempty"`
+	// Devices grants per-host-device passthrough (e.g. "/dev/kvm" or
+	// "/dev/sdb:/dev/xvdb:r")
 	// — the least-privilege alternative to Privileged. Mirrors
 	// ContainerSpec.Devices; see that field for semantics. Issue #1252.
 	Devices       []string              `json:"devices,omitempty"                yaml:"devices,omitempty"`
@@ -73,4 +73,22 @@ type ClientConfigurationSpec struct {
 	// ServerConfiguration.spec.podSubnetCIDR on the daemon side (issue
 	// #1079). Default: 10.88.0.0/16.
 	PodSubnetCIDR string `json:"podSubnetCIDR,omitempty"             yaml:"podSubnetCIDR,omitempty"`
+	// CurrentContext names the entry in Contexts whose scope kuke uses when
+	// neither `--context` nor KUKEON_CONTEXT picks one. Empty means no
+	// context: commands fall back to the "default" realm/space/stack.
+	CurrentContext string `json:"currentContext,omitempty"            yaml:"currentContext,omitempty"`
+	// Contexts are named default scopes. The selected context's realm,
+	// space, and stack replace the built-in "default" for every command
+	// whose --realm/--space/--stack would otherwise fall back to it;
+	// explicit flags and per-command env vars still win.
+	Contexts []ClientContext `json:"contexts,omitempty"                  yaml:"contexts,omitempty"`
+}
+
+// ClientContext is one named default scope in a ClientConfiguration. An
+// empty field leaves that level at the built-in "default".
+type ClientContext struct {
+	Name  string `json:"name"            yaml:"name"`
+	Realm string `json:"realm,omitempty" yaml:"realm,omitempty"`
+	Space string `json:"space,omitempty" yaml:"space,omitempty"`
+	Stack string `json:"stack,omitempty" yaml:"stack,omitempty"`
 }
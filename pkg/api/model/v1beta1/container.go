@@ -32,16 +32,40 @@ type ContainerMetadata struct {
 }
 
 type ContainerSpec struct {
-	ID           string   `json:"id"                               yaml:"id"`
-	ContainerdID string   `json:"containerdId,omitempty"           yaml:"containerdId,omitempty"`
-	RealmID      string   `json:"realmId"                          yaml:"realmId"`
-	SpaceID      string   `json:"spaceId"                          yaml:"spaceId"`
-	StackID      string   `json:"stackId"                          yaml:"stackId"`
-	CellID       string   `json:"cellId"                           yaml:"cellId"`
-	Root         bool     `json:"root,omitempty"                   yaml:"root,omitempty"`
-	Image        string   `json:"image"                            yaml:"image"`
-	Command      string   `json:"command"                          yaml:"command"`
-	Args         []string `json:"args"                             yaml:"args"`
+	ID           string `json:"id"                               yaml:"id"`
+	ContainerdID string `json:"containerdId,omitempty"           yaml:"containerdId,omitempty"`
+	RealmID      string `json:"realmId"                          yaml:"realmId"`
+	SpaceID      string `json:"spaceId"                          yaml:"spaceId"`
+	StackID      string `json:"stackId"                          yaml:"stackId"`
+	CellID       string `json:"cellId"                           yaml:"cellId"`
+	Root         bool   `json:"root,omitempty"                   yaml:"root,omitempty"`
+	// Image is the OCI image reference. It may carry a digest
+	// (name@sha256:... or name:tag@sha256:...); the pulled manifest must then
+	// resolve to exactly that digest or container create fails.
+	Image string `json:"image"                            yaml:"image"`
+	// ImagePullPolicy selects when the image is pulled: Always pulls on
+	// every container create, IfNotPresent (the default) pulls only when the
+	// image is absent from the realm's namespace — for a digest-pinned
+	// image, when no local image carries that digest — and Never only uses
+	// a local image, failing when it is absent.
+	ImagePullPolicy string `json:"imagePullPolicy,omitempty"        yaml:"imagePullPolicy,omitempty"`
+	// Platform selects the image platform as os/arch[/variant], e.g.
+	// linux/arm64. The pull fetches that manifest of a multi-arch image
+	// and the rootfs is unpacked from it, so a host with emulation
+	// (binfmt_misc) can run a non-native image. Empty uses the host
+	// platform.
+	Platform string   `json:"platform,omitempty"               yaml:"platform,omitempty"`
+	Command  string   `json:"command"                          yaml:"command"`
+	Args     []string `json:"args"                             yaml:"args"`
+	// Snapshotter selects the containerd snapshotter (e.g. overlayfs, native)
+	// this container's rootfs is prepared on, overriding the realm's
+	// spec.snapshotter. Empty inherits the realm default.
+	Snapshotter string `json:"snapshotter,omitempty"            yaml:"snapshotter,omitempty"`
+	// DiskQuota caps the size of the container's writable rootfs snapshot.
+	// The limit is handed to the snapshotter at create time, so it is only
+	// accepted on a snapshotter that can enforce it; on any other, container
+	// create fails rather than running unbounded.
+	DiskQuota *ContainerDiskQuota `json:"diskQuota,omitempty"              yaml:"diskQuota,omitempty"`
 	// WorkingDir sets the cwd of the spawned container process — OCI
 	// process.cwd, Docker WORKDIR, K8s Container.workingDir. Empty falls
 	// back to the image's WORKDIR (no behavior change for existing specs).
@@ -52,6 +76,16 @@ type ContainerSpec struct {
 	Networks        []string      `json:"networks"                         yaml:"networks"`
 	NetworksAliases []string      `json:"networksAliases"                  yaml:"networksAliases"`
 	Privileged      bool          `json:"privileged"                       yaml:"privileged"`
+	// Hostname sets the hostname the cell's containers see. Every container
+	// in a cell shares the root container's UTS namespace, so the value is
+	// applied once, to the root, through the OCI hostname; set it on the
+	// root container (or on at most one container, with no conflicting
+	// value elsewhere). Defaults to the cell name.
+	Hostname string `json:"hostname,omitempty" yaml:"hostname,omitempty"`
+	// ExtraHosts adds host:ip entries to the cell's generated /etc/hosts,
+	// which is bind-mounted into every container of the cell. Entries from
+	// all containers are merged.
+	ExtraHosts []string `json:"extraHosts,omitempty" yaml:"extraHosts,omitempty"`
 	// HostNetwork opts the container into the host's network namespace.
 	// When true, the runner omits the network LinuxNamespace from the OCI
 	// spec (containerd's WithHostNamespace) and does not invoke CNI attach,
@@ -83,25 +117,98 @@ type ContainerSpec struct {
 	//
 	// Translates to omitting the LinuxNamespace{Type: cgroup} entry from
 	// the OCI spec when true; appending it when false.
-	HostCgroup             bool                   `json:"hostCgroup,omitempty"             yaml:"hostCgroup,omitempty"`
-	User                   string                 `json:"user,omitempty"                   yaml:"user,omitempty"`
-	ReadOnlyRootFilesystem bool                   `json:"readOnlyRootFilesystem,omitempty" yaml:"readOnlyRootFilesystem,omitempty"`
-	Capabilities           *ContainerCapabilities `json:"capabilities,omitempty"           yaml:"capabilities,omitempty"`
-	SecurityOpts           []string               `json:"securityOpts,omitempty"           yaml:"securityOpts,omitempty"`
+	HostCgroup bool `json:"hostCgroup,omitempty"             yaml:"hostCgroup,omitempty"`
+	// User overrides the image's user for the container process: a numeric
+	// "uid" or "uid:gid", or a user/group name the runtime resolves from the
+	// image's /etc/passwd and /etc/group. Numeric parts must fit a uint32.
+	User string `json:"user,omitempty"                   yaml:"user,omitempty"`
+	// SupplementaryGroups lists extra numeric GIDs for the container process,
+	// added to the groups the image's /etc/group grants the user.
+	SupplementaryGroups    []int `json:"supplementaryGroups,omitempty"    yaml:"supplementaryGroups,omitempty"`
+	ReadOnlyRootFilesystem bool  `json:"readOnlyRootFilesystem,omitempty" yaml:"readOnlyRootFilesystem,omitempty"`
+	// WritableTmp controls the implicit tmpfs a read-only root filesystem gets
+	// at /tmp. Unset or true mounts it (mode 1777) unless a tmpfs or volume
+	// entry already targets /tmp; false leaves /tmp read-only. Ignored when
+	// readOnlyRootFilesystem is false.
+	WritableTmp  *bool                  `json:"writableTmp,omitempty"            yaml:"writableTmp,omitempty"`
+	Capabilities *ContainerCapabilities `json:"capabilities,omitempty"           yaml:"capabilities,omitempty"`
+	SecurityOpts []string               `json:"securityOpts,omitempty"           yaml:"securityOpts,omitempty"`
+	// SeccompProfile selects the OCI linux.seccomp filter: "runtime-default"
+	// (containerd's default profile), "unconfined" (no filter), or the
+	// absolute path of a JSON profile on the host, read and validated at
+	// create. Empty means runtime-default, except on a privileged container,
+	// which runs unconfined, and when securityOpts carries a seccomp= entry.
+	SeccompProfile string `json:"seccompProfile,omitempty"         yaml:"seccompProfile,omitempty"`
+	// AppArmorProfile sets the OCI process.apparmorProfile: the name of a
+	// profile already loaded on the host, or "unconfined". At create the
+	// host must have AppArmor enabled and, when the kernel exposes its
+	// profile list, the named profile loaded. Empty leaves the runtime
+	// default in place.
+	AppArmorProfile string `json:"appArmorProfile,omitempty"        yaml:"appArmorProfile,omitempty"`
+	// NoNewPrivileges sets the OCI process noNewPrivileges flag, so setuid
+	// binaries and file capabilities cannot raise the container's privileges.
+	// Equivalent to the "no-new-privileges" securityOpts entry, and wins over
+	// a "no-new-privileges=false" one.
+	NoNewPrivileges bool `json:"noNewPrivileges,omitempty"        yaml:"noNewPrivileges,omitempty"`
+	// Sysctls sets namespaced kernel parameters (e.g. "net.core.somaxconn")
+	// for the container. The root container owns the cell's network
+	// namespace, so net.* keys are only accepted there; workload containers
+	// share it and inherit the root's values.
+	Sysctls map[string]string `json:"sysctls,omitempty"                yaml:"sysctls,omitempty"`
+	// OOMScoreAdj sets the OCI process oomScoreAdj, biasing which process the
+	// kernel OOM killer picks under host memory pressure: -1000 exempts the
+	// container, 1000 makes it the first candidate. Nil leaves the runtime
+	// default. Validation rejects values outside -1000..1000.
+	OOMScoreAdj *int `json:"oomScoreAdj,omitempty"            yaml:"oomScoreAdj,omitempty"`
+	// TerminationMessagePath is the in-container file a workload writes a
+	// short exit reason to. kukeond bind-mounts a per-container host file
+	// there and, when the task exits, copies its first 4 KiB into
+	// ContainerStatus.TerminationMessage. Empty defaults to
+	// /dev/termination-log. Ignored on the root container.
+	TerminationMessagePath string `json:"terminationMessagePath,omitempty" yaml:"terminationMessagePath,omitempty"`
 	// Devices grants the container access to individual host device nodes —
 	// the least-privilege alternative to Privileged (which exposes every host
-	// device). Each entry is a host device path (short form, e.g. "/dev/kvm");
-	// the node is replicated into the container at the same path with default
-	// "rwm" cgroup access. The host node is stat'd at container *create* time
-	// (type/major/minor snapshot) and materialises as a Linux.Devices entry
-	// plus a matching Linux.Resources.Devices allow rule — the same pair
-	// Docker's --device emits. A device that appears on the host after the cell
-	// is created needs a cell recreate to be picked up; a missing host node
-	// fails container create with a clear error. Issue #1252.
+	// device). Each entry is a host device path (short form, e.g. "/dev/kvm"),
+	// replicated into the container at the same path with default "rwm" cgroup
+	// access, or a long-form "hostPath:containerPath[:permissions]" mapping
+	// (e.g. "/dev/sdb:/dev/xvdb:r"). The host node is stat'd at container
+	// *create* time (type/major/minor snapshot) and materialises as a
+	// Linux.Devices entry plus a matching Linux.Resources.Devices allow rule —
+	// the same pair Docker's --device emits. A device that appears on the host after the cell
+	// is created needs a cell recreate to be picked up; a missing host node, or
+	// a host path that is not a device node, fails container create with a
+	// clear error. Issue #1252.
 	Devices   []string              `json:"devices,omitempty"                yaml:"devices,omitempty"`
 	Tmpfs     []ContainerTmpfsMount `json:"tmpfs,omitempty"                  yaml:"tmpfs,omitempty"`
 	Resources *ContainerResources   `json:"resources,omitempty"              yaml:"resources,omitempty"`
-	Secrets   []ContainerSecret     `json:"secrets,omitempty"                yaml:"secrets,omitempty"`
+	// LogRotation bounds the container's file-backed stdout/stderr log. When
+	// the live log reaches maxSizeMB the reconciler copies it to <log>.1
+	// (shifting older segments up) and truncates it in place, keeping at most
+	// maxFiles files including the live one. Nil leaves the log unbounded.
+	// Attachable and root containers do not write a log file and ignore it.
+	LogRotation *ContainerLogRotation `json:"logRotation,omitempty"            yaml:"logRotation,omitempty"`
+	// Lifecycle declares commands run inside the container: postStart after
+	// its task starts, preStop before it is sent the stop signal. See
+	// ContainerLifecycle.
+	Lifecycle *ContainerLifecycle `json:"lifecycle,omitempty"              yaml:"lifecycle,omitempty"`
+	// SeparateStreams keeps the container's stdout and stderr apart in its
+	// file-backed log: the task writes each stream to its own fifo and the
+	// daemon appends timestamped, stream-tagged records to the log, so
+	// `kuke log --stream=stdout|stderr` can isolate one of them. False keeps
+	// the shim-owned merged log. Attachable and root containers ignore it.
+	SeparateStreams bool `json:"separateStreams,omitempty"        yaml:"separateStreams,omitempty"`
+	// OpenStdin gives the container's task a stdin fifo held open by the
+	// daemon, so a process reading stdin blocks instead of seeing EOF at
+	// startup. StdinFile, when set, is a host path (regular file or named
+	// pipe) whose bytes are fed into it. Without StdinOnce stdin stays open
+	// after the source ends, until the task exits; with StdinOnce it is
+	// closed once the source is drained. Only valid on non-attachable,
+	// non-root containers. The fifo is fed by kukeond, so a daemon restart
+	// closes it.
+	OpenStdin bool              `json:"openStdin,omitempty"               yaml:"openStdin,omitempty"`
+	StdinOnce bool              `json:"stdinOnce,omitempty"               yaml:"stdinOnce,omitempty"`
+	StdinFile string            `json:"stdinFile,omitempty"               yaml:"stdinFile,omitempty"`
+	Secrets   []ContainerSecret `json:"secrets,omitempty"                 yaml:"secrets,omitempty"`
 	// Repos declares git repositories the container depends on. The kuketty
 	// wrapper clones (or fetches) each one in a pre-Serve step using the
 	// container's own git identity (~/.ssh, ~/.gitconfig, GIT_SSH_COMMAND),
@@ -484,6 +591,11 @@ type ContainerTmpfsMount struct {
 	Options   []string `json:"options,omitempty"   yaml:"options,omitempty"`
 }
 
+// ContainerDiskQuota bounds the container's writable rootfs layer.
+type ContainerDiskQuota struct {
+	SizeBytes int64 `json:"sizeBytes" yaml:"sizeBytes"`
+}
+
 // ContainerResources exposes the cgroup v2 knobs the orchestrator supports for
 // per-container resource limits.
 type ContainerResources struct {
@@ -492,6 +604,32 @@ type ContainerResources struct {
 	PidsLimit        *int64 `json:"pidsLimit,omitempty"        yaml:"pidsLimit,omitempty"`
 }
 
+// ContainerLogRotation configures size-based rotation of a container's log
+// file. MaxSizeMB is the rotation threshold in mebibytes; MaxFiles counts the
+// live log plus its rotated segments and must be at least 1.
+type ContainerLogRotation struct {
+	MaxSizeMB int64 `json:"maxSizeMB" yaml:"maxSizeMB"`
+	MaxFiles  int   `json:"maxFiles"  yaml:"maxFiles"`
+}
+
+// ContainerLifecycle lists shell commands run inside the container, as
+// extra processes in its task via `/bin/sh -c`, in order — so the image
+// must ship /bin/sh. Unlike CellLifecycle they never run on the host.
+//
+// PostStart runs right after the task starts; a failing command is logged,
+// or fails the start when FailOnPostStartError is set. PreStop runs on
+// `kuke stop` before the stop signal is sent and shares the stop grace
+// period with it: when preStop is set the grace period is TimeoutSeconds
+// (default 30), and whatever the commands leave of it is what the task
+// gets between SIGTERM and SIGKILL. `kuke kill` skips preStop. Each
+// postStart command is also bounded by TimeoutSeconds.
+type ContainerLifecycle struct {
+	PostStart            []string `json:"postStart,omitempty"            yaml:"postStart,omitempty"`
+	PreStop              []string `json:"preStop,omitempty"              yaml:"preStop,omitempty"`
+	TimeoutSeconds       int      `json:"timeoutSeconds,omitempty"       yaml:"timeoutSeconds,omitempty"`
+	FailOnPostStartError bool     `json:"failOnPostStartError,omitempty" yaml:"failOnPostStartError,omitempty"`
+}
+
 type ContainerStatus struct {
 	Name  string         `json:"name"                yaml:"name"`
 	ID    string         `json:"id"                  yaml:"id"`
@@ -508,6 +646,27 @@ type ContainerStatus struct {
 	FinishTime   time.Time `json:"finishTime"          yaml:"finishTime"`
 	ExitCode     int       `json:"exitCode"            yaml:"exitCode"`
 	ExitSignal   string    `json:"exitSignal"          yaml:"exitSignal"`
+	// OOMKilled reports that the container's most recent exit was caused by
+	// the kernel OOM killer (a new oom_kill event in the container cgroup's
+	// memory.events observed while the task was not running). Cleared once
+	// the container is observed Ready again.
+	OOMKilled bool `json:"oomKilled,omitempty"    yaml:"oomKilled,omitempty"`
+	// LastOOM is the wall-clock time the controller first observed the most
+	// recent OOM kill in the container's cgroup. Preserved across restarts.
+	LastOOM time.Time `json:"lastOOM,omitempty"      yaml:"lastOOM,omitempty"`
+	// OOMKillCount is the oom_kill counter last read from the container
+	// cgroup's memory.events; the controller compares against it to detect
+	// new OOM kills. Resets when the container's cgroup is recreated.
+	OOMKillCount int `json:"oomKillCount,omitempty" yaml:"oomKillCount,omitempty"`
+	// ImageDigest is the manifest digest (sha256:...) of the image the
+	// container was created from, recorded whether or not spec.image pins a
+	// digest.
+	ImageDigest string `json:"imageDigest,omitempty"  yaml:"imageDigest,omitempty"`
+	// TerminationMessage is what the container wrote to its
+	// spec.terminationMessagePath before its most recent exit, capped at
+	// 4 KiB. Empty when the file was absent or empty. Cleared once the
+	// container is observed Ready again.
+	TerminationMessage string `json:"terminationMessage,omitempty" yaml:"terminationMessage,omitempty"`
 	// Repos reports the per-repo outcome of kuketty's pre-Serve clone/fetch
 	// step for an Attachable container's Spec.Repos. Empty for containers
 	// with no repos[] or that have not yet been provisioned. Populated over
@@ -678,6 +837,7 @@ func NewContainerDoc(from *ContainerDoc) *ContainerDoc {
 	out.Spec.NetworksAliases = cloneSlice(out.Spec.NetworksAliases)
 	out.Spec.SecurityOpts = cloneSlice(out.Spec.SecurityOpts)
 	out.Spec.Devices = cloneSlice(out.Spec.Devices)
+	out.Spec.ExtraHosts = cloneSlice(out.Spec.ExtraHosts)
 	out.Spec.Secrets = cloneSecrets(out.Spec.Secrets)
 	out.Spec.Repos = cloneRepos(out.Spec.Repos)
 	out.Spec.Git = cloneGit(out.Spec.Git)
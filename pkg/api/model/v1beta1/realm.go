@@ -29,6 +29,9 @@ type RealmDoc struct {
 type RealmMetadata struct {
 	Name   string            `json:"name"   yaml:"name"`
 	Labels map[string]string `json:"labels" yaml:"labels"`
+	// Annotations carry non-identifying metadata about the realm. Unlike
+	// Labels, no selector or reconcile path keys off them.
+	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
 	// Generation is a monotonic counter bumped by a writer on each
 	// spec-changing update. Defaults to zero; phase 3 (issue #596 follow-up)
 	// wires the writers to populate it. See ObservedGeneration on the status.
@@ -38,6 +41,34 @@ type RealmMetadata struct {
 type RealmSpec struct {
 	Namespace           string                `json:"namespace"                     yaml:"namespace"`
 	RegistryCredentials []RegistryCredentials `json:"registryCredentials,omitempty" yaml:"registryCredentials,omitempty"`
+	// Snapshotter is the containerd snapshotter (e.g. overlayfs, native)
+	// every container in the realm is created with unless the container
+	// sets its own. Empty uses containerd's default.
+	Snapshotter string `json:"snapshotter,omitempty"         yaml:"snapshotter,omitempty"`
+	// PauseImage is the image every cell's default root (sandbox)
+	// container runs from. Empty uses the built-in default.
+	PauseImage string `json:"pauseImage,omitempty"          yaml:"pauseImage,omitempty"`
+	// DefaultResources is the cgroup limit set every cell in the realm
+	// gets unless its space declares its own. Nil leaves cells unlimited.
+	DefaultResources *ContainerResources `json:"defaultResources,omitempty"    yaml:"defaultResources,omitempty"`
+	// AllowPrivileged is the administrator's consent for containers in
+	// this realm to set privileged: true. Without it such a cell is
+	// rejected at create and apply.
+	AllowPrivileged bool `json:"allowPrivileged,omitempty"     yaml:"allowPrivileged,omitempty"`
+	// Quota caps what all the cells in the realm may use together. Nil
+	// leaves the realm unbounded.
+	Quota *ResourceQuota `json:"quota,omitempty"               yaml:"quota,omitempty"`
+}
+
+// ResourceQuota caps the cells of a realm or space, checked when a cell or
+// container is created: MaxCells counts cells, MaxMemoryBytes and
+// MaxCPUShares sum the memoryLimitBytes and cpuShares their workload
+// containers declare. Under a memory or CPU cap every workload container
+// must declare that limit. A zero field is unlimited.
+type ResourceQuota struct {
+	MaxCells       int   `json:"maxCells,omitempty"       yaml:"maxCells,omitempty"`
+	MaxMemoryBytes int64 `json:"maxMemoryBytes,omitempty" yaml:"maxMemoryBytes,omitempty"`
+	MaxCPUShares   int64 `json:"maxCpuShares,omitempty"   yaml:"maxCpuShares,omitempty"`
 }
 
 // RegistryCredentials contains authentication information for a container registry.
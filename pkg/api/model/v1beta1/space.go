@@ -29,6 +29,9 @@ type SpaceDoc struct {
 type SpaceMetadata struct {
 	Name   string            `json:"name"   yaml:"name"`
 	Labels map[string]string `json:"labels" yaml:"labels"`
+	// Annotations carry non-identifying metadata about the space. Unlike
+	// Labels, no selector or reconcile path keys off them.
+	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
 	// Generation is a monotonic counter bumped by a writer on each
 	// spec-changing update. Defaults to zero; phase 3 wires the writers to
 	// populate it. See ObservedGeneration on the status.
@@ -36,17 +39,45 @@ type SpaceMetadata struct {
 }
 
 type SpaceSpec struct {
-	RealmID       string         `json:"realmId"                 yaml:"realmId"`
-	CNIConfigPath string         `json:"cniConfigPath,omitempty" yaml:"cniConfigPath,omitempty"`
-	Network       *SpaceNetwork  `json:"network,omitempty"       yaml:"network,omitempty"`
-	Defaults      *SpaceDefaults `json:"defaults,omitempty"      yaml:"defaults,omitempty"`
+	RealmID       string         `json:"realmId"                    yaml:"realmId"`
+	CNIConfigPath string         `json:"cniConfigPath,omitempty"    yaml:"cniConfigPath,omitempty"`
+	Network       *SpaceNetwork  `json:"network,omitempty"          yaml:"network,omitempty"`
+	Defaults      *SpaceDefaults `json:"defaults,omitempty"         yaml:"defaults,omitempty"`
+	// DefaultResources is the cgroup limit set every cell in the space
+	// gets, overriding the realm's DefaultResources field by field. Unlike
+	// defaults.container.resources, which limits each container, it caps
+	// the cell cgroup that all of a cell's containers share.
+	DefaultResources *ContainerResources `json:"defaultResources,omitempty" yaml:"defaultResources,omitempty"`
+	// Quota caps what all the cells in the space may use together, on top
+	// of any realm quota. Nil leaves the space bounded by the realm alone.
+	Quota *ResourceQuota `json:"quota,omitempty"            yaml:"quota,omitempty"`
 }
 
 // SpaceNetwork groups network-scoped policy applied to the space bridge.
+// Setting DualStack together with an IPv6Subnet CIDR gives every cell in the
+// space an IPv6 address in addition to its IPv4 one. The IPv6 subnet must not
+// overlap any other space's in the same realm, and since isolation only
+// covers IPv4 the space must set Policy to NetworkPolicyOpen.
 type SpaceNetwork struct {
-	Egress *EgressPolicy `json:"egress,omitempty" yaml:"egress,omitempty"`
+	// Policy selects whether cells in other spaces can reach this space's
+	// subnet (and the other way round). Empty means NetworkPolicyIsolated.
+	Policy     NetworkPolicy `json:"policy,omitempty"     yaml:"policy,omitempty"`
+	Egress     *EgressPolicy `json:"egress,omitempty"     yaml:"egress,omitempty"`
+	IPv6Subnet string        `json:"ipv6Subnet,omitempty" yaml:"ipv6Subnet,omitempty"`
+	DualStack  bool          `json:"dualStack,omitempty"  yaml:"dualStack,omitempty"`
 }
 
+// NetworkPolicy is a space's cross-space reachability policy.
+type NetworkPolicy string
+
+const (
+	// NetworkPolicyIsolated drops traffic between the space's subnet and
+	// every other space's subnet. It is the default.
+	NetworkPolicyIsolated NetworkPolicy = "isolated"
+	// NetworkPolicyOpen leaves cross-space traffic to the egress policy.
+	NetworkPolicyOpen NetworkPolicy = "open"
+)
+
 // EgressPolicy constrains outbound traffic leaving the space bridge toward the
 // host or external networks. When nil, traffic is unconstrained (current
 // behavior). An explicit Default=allow with no Allow rules also matches